@@ -18,6 +18,8 @@
 package aggregation
 
 import (
+	"math"
+
 	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -62,7 +64,8 @@ func eval(binaryOp stmt.BinaryOP, left, right float64) float64 {
 		return left * right
 	case stmt.DIV:
 		if right == 0 {
-			return 0
+			// division by zero, NaN point will be skipped when building result
+			return math.NaN()
 		}
 		return left / right
 	default:
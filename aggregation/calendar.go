@@ -0,0 +1,148 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"sort"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// calendarGroupedIterator implements GroupedIterator interface,
+// merges the fixed interval buckets of grouped series into calendar buckets(week/month/year).
+type calendarGroupedIterator struct {
+	it        series.GroupedIterator
+	startTime int64
+	interval  int64
+	buckets   []int64
+}
+
+// NewCalendarGroupedIterator creates a grouped iterator which merges fixed interval buckets into calendar buckets,
+// buckets are the start times of calendar buckets, slot of merged series is the index of calendar bucket.
+func NewCalendarGroupedIterator(it series.GroupedIterator, startTime, interval int64, buckets []int64) series.GroupedIterator {
+	return &calendarGroupedIterator{
+		it:        it,
+		startTime: startTime,
+		interval:  interval,
+		buckets:   buckets,
+	}
+}
+
+// Tags returns the tags of series.
+func (g *calendarGroupedIterator) Tags() string {
+	return g.it.Tags()
+}
+
+// HasNext returns if the iteration has more field's iterator.
+func (g *calendarGroupedIterator) HasNext() bool {
+	return g.it.HasNext()
+}
+
+// Next returns the field's iterator which merged into calendar buckets.
+func (g *calendarGroupedIterator) Next() series.Iterator {
+	fieldSeries := g.it.Next()
+	var (
+		aggTypes        []field.AggType
+		fieldSeriesList []*collections.FloatArray
+	)
+	for fieldSeries.HasNext() {
+		startTime, fieldIt := fieldSeries.Next()
+		if fieldIt == nil {
+			continue
+		}
+		for fieldIt.HasNext() {
+			primitiveIt := fieldIt.Next()
+			aggType := primitiveIt.AggType()
+			idx := -1
+			for i := range aggTypes {
+				if aggTypes[i] == aggType {
+					idx = i
+					break
+				}
+			}
+			if idx < 0 {
+				idx = len(aggTypes)
+				aggTypes = append(aggTypes, aggType)
+				fieldSeriesList = append(fieldSeriesList, collections.NewFloatArray(len(g.buckets)))
+			}
+			values := fieldSeriesList[idx]
+			for primitiveIt.HasNext() {
+				slot, value := primitiveIt.Next()
+				pos := g.bucketOf(startTime + int64(slot)*g.interval)
+				if pos < 0 {
+					continue
+				}
+				if values.HasValue(pos) {
+					values.SetValue(pos, aggType.Aggregate(values.GetValue(pos), value))
+				} else {
+					values.SetValue(pos, value)
+				}
+			}
+		}
+	}
+	return &calendarSeriesIterator{
+		fieldName: fieldSeries.FieldName(),
+		fieldType: fieldSeries.FieldType(),
+		startTime: g.startTime,
+		fieldIt:   newFieldIterator(0, aggTypes, fieldSeriesList),
+	}
+}
+
+// bucketOf returns the index of calendar bucket which timestamp belongs to, returns -1 if out of buckets.
+func (g *calendarGroupedIterator) bucketOf(timestamp int64) int {
+	return sort.Search(len(g.buckets), func(i int) bool {
+		return g.buckets[i] > timestamp
+	}) - 1
+}
+
+// calendarSeriesIterator implements series.Iterator interface for the series merged into calendar buckets.
+type calendarSeriesIterator struct {
+	fieldName field.Name
+	fieldType field.Type
+	startTime int64
+	fieldIt   series.FieldIterator
+	consumed  bool
+}
+
+// FieldName returns field name.
+func (it *calendarSeriesIterator) FieldName() field.Name {
+	return it.fieldName
+}
+
+// FieldType returns field type.
+func (it *calendarSeriesIterator) FieldType() field.Type {
+	return it.fieldType
+}
+
+// HasNext returns if the iteration has more field's iterator.
+func (it *calendarSeriesIterator) HasNext() bool {
+	return !it.consumed
+}
+
+// Next returns the field's iterator and start time.
+func (it *calendarSeriesIterator) Next() (startTime int64, fieldIt series.FieldIterator) {
+	it.consumed = true
+	return it.startTime, it.fieldIt
+}
+
+// MarshalBinary marshals the data.
+func (it *calendarSeriesIterator) MarshalBinary() ([]byte, error) {
+	return series.MarshalIterator(it)
+}
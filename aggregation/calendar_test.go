@@ -0,0 +1,72 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestCalendarGroupedIterator(t *testing.T) {
+	interval := int64(10)
+	timeRange := timeutil.TimeRange{Start: 100, End: 190}
+	spec := NewAggregatorSpec("f", field.SumField)
+	spec.AddFunctionType(function.Sum)
+	spec.AddFunctionType(function.Max)
+	agg := NewFieldAggregates(timeutil.Interval(interval), 1, timeRange, AggregatorSpecs{spec})
+	fieldAgg := agg[0].GetAggregator(timeRange.Start)
+	for slot := 0; slot < 10; slot++ {
+		fieldAgg.AggregateBySlot(slot, float64(slot))
+	}
+	// buckets with different width, points before first bucket are dropped
+	buckets := []int64{120, 150, 190}
+	it := NewCalendarGroupedIterator(agg.ResultSet("host=1"), timeRange.Start, interval, buckets)
+	assert.Equal(t, "host=1", it.Tags())
+	assert.True(t, it.HasNext())
+	fieldSeries := it.Next()
+	assert.Equal(t, field.Name("f"), fieldSeries.FieldName())
+	assert.Equal(t, field.SumField, fieldSeries.FieldType())
+	assert.True(t, fieldSeries.HasNext())
+	startTime, fieldIt := fieldSeries.Next()
+	assert.Equal(t, timeRange.Start, startTime)
+	result := make(map[field.AggType]map[int]float64)
+	for fieldIt.HasNext() {
+		pIt := fieldIt.Next()
+		values := make(map[int]float64)
+		for pIt.HasNext() {
+			slot, value := pIt.Next()
+			values[slot] = value
+		}
+		result[pIt.AggType()] = values
+	}
+	assert.Equal(t, map[int]float64{0: 2 + 3 + 4, 1: 5 + 6 + 7 + 8, 2: 9}, result[field.Sum])
+	assert.Equal(t, map[int]float64{0: 4, 1: 8, 2: 9}, result[field.Max])
+	assert.False(t, fieldSeries.HasNext())
+	assert.False(t, it.HasNext())
+
+	it = NewCalendarGroupedIterator(agg.ResultSet("host=1"), timeRange.Start, interval, buckets)
+	assert.True(t, it.HasNext())
+	data, err := it.Next().MarshalBinary()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, data)
+}
@@ -121,12 +121,59 @@ func (e *expression) eval(parentFunc *stmt.CallExpr, expr stmt.Expr) []*collecti
 		switch ex.FuncType {
 		case function.Quantile:
 			return e.quantile(ex)
+		case function.Percent:
+			// percent of total is computed at root after all grouped series merged,
+			// here just returns the values of wrapped expression.
+			if len(ex.Params) != 1 {
+				return nil
+			}
+			return e.eval(nil, ex.Params[0])
+		case function.TopK, function.BottomK:
+			// top/bottom n grouped series are selected at root after all expressions evaluated,
+			// here just returns the values of ranking expression.
+			if len(ex.Params) != 2 {
+				return nil
+			}
+			return e.eval(nil, ex.Params[1])
+		case function.HistogramQuantile:
+			// quantile is interpolated across buckets at root after all grouped series(by le) merged,
+			// here just returns the values of bucket field.
+			if len(ex.Params) != 2 {
+				return nil
+			}
+			return e.eval(nil, ex.Params[1])
+		case function.ArgMax, function.ArgMin:
+			// tag value of extreme series is selected at root after all grouped series(by tag) merged,
+			// here just returns the max/min values of field.
+			if len(ex.Params) != 2 {
+				return nil
+			}
+			return e.funcCall(&stmt.CallExpr{FuncType: function.ArgSelectorAggFunc(ex.FuncType), Params: ex.Params[1:]})
+		case function.Absent:
+			// absent detects if series stopped reporting based on default values of field.
+			if len(ex.Params) != 1 {
+				return nil
+			}
+			values := e.eval(nil, ex.Params[0])
+			if len(values) == 0 {
+				return nil
+			}
+			result := function.AbsentCall(e.interval, ex.Window.Int64(), values...)
+			if result == nil {
+				return nil
+			}
+			return []*collections.FloatArray{result}
 		default:
 			return e.funcCall(ex)
 		}
 	case *stmt.ParenExpr:
 		return e.eval(nil, ex.Expr)
 	case *stmt.BinaryExpr:
+		if parentFunc != nil && function.IsConditional(parentFunc.FuncType) {
+			// predicate of conditional aggregation is evaluated when leaf down sampling,
+			// here just returns the conditional aggregated values of field.
+			return e.eval(parentFunc, ex.Left)
+		}
 		return e.binaryEval(ex)
 	case *stmt.NumberLiteral:
 		values := collections.NewFloatArray(e.pointCount)
@@ -153,9 +200,11 @@ func (e *expression) eval(parentFunc *stmt.CallExpr, expr stmt.Expr) []*collecti
 
 func (e *expression) quantile(expr *stmt.CallExpr) []*collections.FloatArray {
 	var (
-		histogramFields = make(map[float64][]*collections.FloatArray)
+		histogramFields   = make(map[float64][]*collections.FloatArray)
+		exponentialFields = make(map[int32]*collections.FloatArray)
+		exponentialZero   *collections.FloatArray
 	)
-	if len(expr.Params) != 1 {
+	if len(expr.Params) == 0 {
 		return nil
 	}
 	quantileValue, err := strconv.ParseFloat(expr.Params[0].Rewrite(), 64)
@@ -163,19 +212,36 @@ func (e *expression) quantile(expr *stmt.CallExpr) []*collections.FloatArray {
 		return nil
 	}
 	for fieldName, df := range e.fieldStore {
-		if df.Type() == field.HistogramField {
+		switch df.Type() {
+		case field.HistogramField:
 			var upperBound float64
 			upperBound, err = metric.UpperBound(fieldName.String())
 			if err != nil {
 				continue
 			}
 			histogramFields[upperBound] = df.GetDefaultValues()
+		case field.ExponentialHistogramField:
+			index, isZero, err0 := metric.ExponentialBucketIndex(fieldName.String())
+			values := df.GetDefaultValues()
+			if err0 != nil || len(values) == 0 {
+				continue
+			}
+			if isZero {
+				exponentialZero = values[0]
+			} else {
+				exponentialFields[index] = values[0]
+			}
 		}
 	}
-	if len(histogramFields) == 0 {
+	var array *collections.FloatArray
+	switch {
+	case len(exponentialFields) > 0 || exponentialZero != nil:
+		array, err = function.ExponentialQuantileCall(quantileValue, exponentialZero, exponentialFields)
+	case len(histogramFields) > 0:
+		array, err = function.QuantileCall(quantileValue, histogramFields)
+	default:
 		return nil
 	}
-	array, err := function.QuantileCall(quantileValue, histogramFields)
 	if err != nil {
 		return nil
 	}
@@ -197,7 +263,15 @@ func (e *expression) funcCall(expr *stmt.CallExpr) []*collections.FloatArray {
 	case function.Avg:
 		result = function.AvgCall(params...)
 	case function.Rate:
-		result = function.RateCall(e.interval, params...)
+		if expr.Window > 0 {
+			result = function.WindowRateCall(e.interval, expr.Window.Int64(), params...)
+		} else {
+			result = function.RateCall(e.interval, params...)
+		}
+	case function.IRate:
+		result = function.IRateCall(e.interval, params...)
+	case function.Derivative:
+		result = function.DerivativeCall(params...)
 	default:
 		result = function.FuncCall(expr.FuncType, params...)
 	}
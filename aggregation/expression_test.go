@@ -18,6 +18,7 @@
 package aggregation
 
 import (
+	"math"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -26,6 +27,8 @@ import (
 	commontimeutil "github.com/lindb/common/pkg/timeutil"
 
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/sketch"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
@@ -257,6 +260,53 @@ func TestExpression_BinaryEval(t *testing.T) {
 	assert.Equal(t, 0, len(resultSet))
 }
 
+func TestExpression_BinaryEval_Ratio(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+	timeRange := timeutil.TimeRange{
+		Start: now,
+		End:   now + commontimeutil.OneHour*2,
+	}
+	cases := []struct {
+		sql    string
+		assert func(value *collections.FloatArray)
+	}{
+		{
+			sql: "select sum(hits)/sum(total) as ratio from http",
+			assert: func(value *collections.FloatArray) {
+				assert.Equal(t, 1, value.Size())
+				assert.Equal(t, 1.0, value.GetValue(50-10))
+			},
+		},
+		{
+			sql: "select sum(hits)/(sum(total)-sum(total)) as ratio from http",
+			assert: func(value *collections.FloatArray) {
+				// division by zero
+				assert.Equal(t, 1, value.Size())
+				assert.True(t, math.IsNaN(value.GetValue(50-10)))
+			},
+		},
+	}
+	for _, tt := range cases {
+		q, err := sql.Parse(tt.sql)
+		assert.NoError(t, err)
+		expression := NewExpression(timeRange, commontimeutil.OneMinute, q.(*stmt.Query).SelectItems)
+		gomock.InOrder(
+			timeSeries.EXPECT().HasNext().Return(true),
+			timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "hits", field.SumField, field.Sum)),
+			timeSeries.EXPECT().HasNext().Return(true),
+			timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "total", field.SumField, field.Sum)),
+			timeSeries.EXPECT().HasNext().Return(false),
+		)
+		expression.Eval(timeSeries)
+		resultSet := expression.ResultSet()
+		assert.Len(t, resultSet, 1)
+		tt.assert(resultSet["ratio"])
+	}
+}
+
 func TestExpression_FuncCall_Sum(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -342,6 +392,239 @@ func TestExpression_FuncCall_Rate(t *testing.T) {
 	assert.Equal(t, 50.0/60, value.GetValue(50-10))
 }
 
+func TestExpression_FuncCall_RateWindow_IRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, _ := sql.Parse("select rate(f1, 1h), irate(f1) from cpu")
+	query := q.(*stmt.Query)
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + commontimeutil.OneHour*2,
+	}, commontimeutil.OneMinute, query.SelectItems)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime+commontimeutil.OneHour, "f1", field.LastField, field.Last)),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 2, len(resultSet))
+
+	// points: 4.0 at 54, 50.0 at 100
+	rate := resultSet["rate(f1,1h)"]
+	assert.Equal(t, 1, rate.Size())
+	assert.Equal(t, 46.0/3600, rate.GetValue(100))
+	irate := resultSet["irate(f1)"]
+	assert.Equal(t, 1, irate.Size())
+	assert.Equal(t, 46.0/(46*60), irate.GetValue(100))
+}
+
+func TestExpression_FuncCall_Absent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, _ := sql.Parse("select absent(f1), absent(f1, 2h) as recent, absent(f2) from cpu")
+	query := q.(*stmt.Query)
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + commontimeutil.OneHour*2,
+	}, commontimeutil.OneMinute, query.SelectItems)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "f1", field.SumField, field.Sum)),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 2, len(resultSet))
+
+	// series reports then goes silent, last seen point: 50.0 at 40, query range has 121 points
+	value := resultSet["absent(f1)"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 1.0, value.GetValue(50-10))
+	// last point in recent window
+	value = resultSet["recent"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 0.0, value.GetValue(50-10))
+	// series not exist
+	assert.Nil(t, resultSet["absent(f2)"])
+}
+
+func TestExpression_FuncCall_Conditional(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	series1 := mockTimeSeries(ctrl, familyTime, "f1", field.SumField, field.Count)
+	series2 := mockTimeSeries(ctrl, familyTime, "f2", field.SumField, field.ConditionalSum)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+
+	q, _ := sql.Parse("select count_if(f1 > 100),sum_if(f2 >= 1.5) from cpu")
+	query := q.(*stmt.Query)
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + commontimeutil.OneHour*2,
+	}, commontimeutil.OneMinute, query.SelectItems)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(series1),
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(series2),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 2, len(resultSet))
+
+	value := resultSet["count_if(f1>100.00)"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 50.0, value.GetValue(50-10))
+	value = resultSet["sum_if(f2>=1.50)"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 50.0, value.GetValue(50-10))
+}
+
+func TestExpression_FuncCall_Percent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	series1 := mockTimeSeries(ctrl, familyTime, "f1", field.SumField, field.Sum)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+
+	q, _ := sql.Parse("select percent(sum(f1)),percent(f1) from cpu")
+	query := q.(*stmt.Query)
+	// invalid params
+	query.SelectItems = append(query.SelectItems, &stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Percent}})
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + commontimeutil.OneHour*2,
+	}, commontimeutil.OneMinute, query.SelectItems)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(series1),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 2, len(resultSet))
+	// percent of total computed at root, here returns the original values
+	assert.Equal(t, 50.0, resultSet["percent(sum(f1))"].GetValue(50-10))
+	assert.Equal(t, 50.0, resultSet["percent(f1)"].GetValue(50-10))
+}
+
+func TestExpression_FuncCall_RankSelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	series1 := mockTimeSeries(ctrl, familyTime, "f1", field.SumField, field.Sum)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+
+	q, _ := sql.Parse("select topk(2, sum(f1)),bottomk(1, f1) as b from cpu")
+	query := q.(*stmt.Query)
+	// invalid params
+	query.SelectItems = append(query.SelectItems, &stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.TopK}})
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + commontimeutil.OneHour*2,
+	}, commontimeutil.OneMinute, query.SelectItems)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(series1),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 2, len(resultSet))
+	// top/bottom n selected at root, here returns the values of ranking expression
+	assert.Equal(t, 50.0, resultSet["topk(2.00,sum(f1))"].GetValue(50-10))
+	assert.Equal(t, 50.0, resultSet["b"].GetValue(50-10))
+}
+
+func TestExpression_FuncCall_HistogramQuantile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	series1 := mockTimeSeries(ctrl, familyTime, "f1", field.SumField, field.Sum)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+
+	q, _ := sql.Parse("select histogram_quantile(0.99, f1) as p99 from cpu")
+	query := q.(*stmt.Query)
+	// invalid params
+	query.SelectItems = append(query.SelectItems, &stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.HistogramQuantile}})
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + commontimeutil.OneHour*2,
+	}, commontimeutil.OneMinute, query.SelectItems)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(series1),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 1, len(resultSet))
+	// quantile interpolated at root, here returns the values of bucket field
+	assert.Equal(t, 50.0, resultSet["p99"].GetValue(50-10))
+}
+
+func TestExpression_Quantile_ExponentialHistogram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+	q, _ := sql.Parse("select quantile(0.5) as p50 from cpu")
+	query := q.(*stmt.Query)
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + commontimeutil.OneHour*2,
+	}, commontimeutil.OneMinute, query.SelectItems)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "__sketch_zero", field.ExponentialHistogramField, field.Sum)),
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "__sketch_10", field.ExponentialHistogramField, field.Sum)),
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "__sketch_20", field.ExponentialHistogramField, field.Sum)),
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "__sketch_x", field.ExponentialHistogramField, field.Sum)),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 1, len(resultSet))
+	// buckets: zero=50, 10=50, 20=50, median in bucket 10
+	assert.Equal(t, sketch.ExponentialValue(10), resultSet["p50"].GetValue(50-10))
+}
+
+func TestExpression_FuncCall_ArgSelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	series1 := mockTimeSeries(ctrl, familyTime, "f1", field.MaxField, field.Max)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+
+	q, _ := sql.Parse("select argmax(host, f1) as h from cpu")
+	query := q.(*stmt.Query)
+	// invalid params
+	query.SelectItems = append(query.SelectItems, &stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.ArgMin}})
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + commontimeutil.OneHour*2,
+	}, commontimeutil.OneMinute, query.SelectItems)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(series1),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 1, len(resultSet))
+	// tag value selected at root, here returns the max values of field
+	assert.Equal(t, 50.0, resultSet["h"].GetValue(50-10))
+}
+
 func TestExpression_NotSupport_Expr(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
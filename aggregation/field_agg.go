@@ -42,6 +42,8 @@ type FieldAggregator interface {
 // fieldAggregator implements field aggregator interface, aggregator field series based on aggregator spec.
 type fieldAggregator struct {
 	aggTypes         []field.AggType
+	predicate        Predicate // predicate of conditional aggregation, evaluated per point when down sampling
+	filter           Predicate // field filter of where clause, drops the points not matched when down sampling
 	segmentStartTime int64
 	start, end       int // slot range based on query interval and time range
 
@@ -61,6 +63,8 @@ func NewFieldAggregator(aggSpec AggregatorSpec, segmentStartTime int64, start, e
 
 	agg := &fieldAggregator{
 		aggTypes:         aggTypes,
+		predicate:        aggSpec.Predicate(),
+		filter:           aggSpec.Filter(),
 		segmentStartTime: segmentStartTime,
 		start:            start,
 		end:              end,
@@ -76,37 +80,72 @@ func (a *fieldAggregator) ResultSet() (startTime int64, it series.FieldIterator)
 
 // Aggregate aggregates the field series into current aggregator
 func (a *fieldAggregator) Aggregate(it series.FieldIterator) {
+	multiAggTypes := len(a.aggTypes) > 1
 	for it.HasNext() {
 		pIt := it.Next()
+		idx := -1
+		if multiAggTypes {
+			// if it has multi agg types, aggregates primitive series into the same agg type
+			idx = a.indexOf(pIt.AggType())
+		}
 		for pIt.HasNext() {
 			slot, value := pIt.Next()
-			a.AggregateBySlot(slot, value)
+			if idx < 0 {
+				a.AggregateBySlot(slot, value)
+			} else if !math.IsInf(value, 1) {
+				a.aggregate(idx, slot-a.start, value)
+			}
 		}
 	}
 }
 
 // AggregateBySlot aggregates the field series into current aggregator
 func (a *fieldAggregator) AggregateBySlot(slot int, value float64) {
-	// drop inf value
-	if math.IsInf(value, 1) {
+	// drop inf value and the value not matched field filter
+	if math.IsInf(value, 1) || (a.filter != nil && !a.filter(value)) {
 		return
 	}
 	pos := slot - a.start
 	for idx, aggType := range a.aggTypes {
-		values := a.fieldSeriesList[idx]
-		if values == nil {
-			values = collections.NewFloatArray(a.end - a.start + 1)
-			values.SetValue(pos, value)
-			a.fieldSeriesList[idx] = values
-		} else {
-			// slot too large for last family
-			if values.HasValue(pos) {
-				values.SetValue(pos, aggType.Aggregate(values.GetValue(pos), value))
-			} else {
-				values.SetValue(pos, value)
+		if a.predicate != nil && (aggType == field.Count || aggType == field.ConditionalSum) {
+			// conditional aggregation, only aggregates the point matching predicate
+			if !a.predicate(value) {
+				continue
+			}
+			if aggType == field.Count {
+				a.aggregate(idx, pos, 1)
+				continue
 			}
 		}
+		a.aggregate(idx, pos, value)
+	}
+}
+
+// aggregate aggregates the value into field series by agg type index.
+func (a *fieldAggregator) aggregate(idx, pos int, value float64) {
+	values := a.fieldSeriesList[idx]
+	if values == nil {
+		values = collections.NewFloatArray(a.end - a.start + 1)
+		values.SetValue(pos, value)
+		a.fieldSeriesList[idx] = values
+		return
+	}
+	// slot too large for last family
+	if values.HasValue(pos) {
+		values.SetValue(pos, a.aggTypes[idx].Aggregate(values.GetValue(pos), value))
+	} else {
+		values.SetValue(pos, value)
+	}
+}
+
+// indexOf returns the index of agg type, if not exist returns -1.
+func (a *fieldAggregator) indexOf(aggType field.AggType) int {
+	for idx, t := range a.aggTypes {
+		if t == aggType {
+			return idx
+		}
 	}
+	return -1
 }
 
 // reset aggregator context for reusing.
@@ -27,6 +27,7 @@ import (
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestFieldAggregator_Aggregate(t *testing.T) {
@@ -58,3 +59,76 @@ func TestFieldAggregator_Aggregate(t *testing.T) {
 
 	agg.reset()
 }
+
+func TestFieldAggregator_ConditionalAggregate(t *testing.T) {
+	newSpec := func() AggregatorSpec {
+		aggSpec := NewAggregatorSpec("f", field.SumField)
+		aggSpec.AddFunctionType(function.Sum)
+		aggSpec.AddFunctionType(function.CountIf)
+		aggSpec.AddFunctionType(function.SumIf)
+		return aggSpec
+	}
+	collect := func(agg FieldAggregator) map[field.AggType]map[int]float64 {
+		rs := make(map[field.AggType]map[int]float64)
+		_, it := agg.ResultSet()
+		for it.HasNext() {
+			pIt := it.Next()
+			points := make(map[int]float64)
+			for pIt.HasNext() {
+				slot, value := pIt.Next()
+				points[slot] = value
+			}
+			rs[pIt.AggType()] = points
+		}
+		return rs
+	}
+	// down sampling with predicate f > 100
+	downSamplingSpec := newSpec()
+	assert.NoError(t, downSamplingSpec.SetPredicate(&stmt.BinaryExpr{
+		Left: &stmt.FieldExpr{Name: "f"}, Right: &stmt.NumberLiteral{Val: 100}, Operator: stmt.GREATER,
+	}))
+	downSampling := NewFieldAggregator(downSamplingSpec, 1, 10, 20)
+	downSampling.AggregateBySlot(10, 50)
+	downSampling.AggregateBySlot(10, 150)
+	downSampling.AggregateBySlot(10, 200)
+	downSampling.AggregateBySlot(11, 20)
+	rs := collect(downSampling)
+	assert.Equal(t, map[int]float64{10: 400, 11: 20}, rs[field.Sum])
+	assert.Equal(t, map[int]float64{10: 2}, rs[field.Count])
+	assert.Equal(t, map[int]float64{10: 350}, rs[field.ConditionalSum])
+
+	// merge across shards sums the conditional counts
+	reduce := NewFieldAggregator(newSpec(), 1, 10, 20)
+	_, it := downSampling.ResultSet()
+	reduce.Aggregate(it)
+	_, it = downSampling.ResultSet()
+	reduce.Aggregate(it)
+	rs = collect(reduce)
+	assert.Equal(t, map[int]float64{10: 800, 11: 40}, rs[field.Sum])
+	assert.Equal(t, map[int]float64{10: 4}, rs[field.Count])
+	assert.Equal(t, map[int]float64{10: 700}, rs[field.ConditionalSum])
+}
+
+func TestFieldAggregator_Filter(t *testing.T) {
+	aggSpec := NewAggregatorSpec("f", field.SumField)
+	aggSpec.AddFunctionType(function.Sum)
+	assert.NoError(t, aggSpec.AddFilter(&stmt.BinaryExpr{
+		Left: &stmt.FieldExpr{Name: "f"}, Right: &stmt.NumberLiteral{Val: 50}, Operator: stmt.GREATER,
+	}))
+	agg := NewFieldAggregator(aggSpec, 1, 10, 20)
+	agg.AggregateBySlot(10, 50)
+	agg.AggregateBySlot(10, 60)
+	agg.AggregateBySlot(10, 70)
+	agg.AggregateBySlot(11, 20)
+	_, it := agg.ResultSet()
+	points := make(map[int]float64)
+	for it.HasNext() {
+		pIt := it.Next()
+		for pIt.HasNext() {
+			slot, value := pIt.Next()
+			points[slot] = value
+		}
+	}
+	// drops the points below threshold, slot 11 has no point matched
+	assert.Equal(t, map[int]float64{10: 130}, points)
+}
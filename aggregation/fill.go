@@ -0,0 +1,69 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// FillGaps fills the gaps(no value or NaN) of each field's values for one grouped series,
+// so that series has a value at every interval slot of query time range(e.g. dashboard line chart).
+//   - fill(null): drops the point, keeps the gap;
+//   - fill(previous): carries forward the last value, leading gaps before the first value remain empty;
+//   - fill(<value>): fills the gap with a constant value.
+func FillGaps(fill *stmt.Fill, fields map[string]*collections.FloatArray) {
+	if fill == nil || fill.Type == stmt.FillNull {
+		return
+	}
+	for fieldName, values := range fields {
+		if values == nil {
+			continue
+		}
+		fields[fieldName] = fillGaps(fill, values)
+	}
+}
+
+// fillGaps fills the gaps of values, returns new array because values maybe shared with other select item.
+func fillGaps(fill *stmt.Fill, values *collections.FloatArray) *collections.FloatArray {
+	capacity := values.Capacity()
+	result := collections.NewFloatArray(capacity)
+	hasPrevious := false
+	previous := 0.0
+	for slot := 0; slot < capacity; slot++ {
+		if values.HasValue(slot) {
+			if val := values.GetValue(slot); !math.IsNaN(val) {
+				result.SetValue(slot, val)
+				hasPrevious = true
+				previous = val
+				continue
+			}
+		}
+		switch fill.Type {
+		case stmt.FillPrevious:
+			if hasPrevious {
+				result.SetValue(slot, previous)
+			}
+		case stmt.FillValue:
+			result.SetValue(slot, fill.Value)
+		}
+	}
+	return result
+}
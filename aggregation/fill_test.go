@@ -0,0 +1,69 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestFillGaps(t *testing.T) {
+	// slot 0/3/5 are gaps, slot 4 is NaN
+	newValues := func() *collections.FloatArray {
+		values := collections.NewFloatArray(6)
+		values.SetValue(1, 1)
+		values.SetValue(2, 2)
+		values.SetValue(4, math.NaN())
+		return values
+	}
+	assertValues := func(values *collections.FloatArray, expect map[int]float64) {
+		assert.Equal(t, len(expect), values.Size())
+		for slot, val := range expect {
+			assert.True(t, values.HasValue(slot))
+			assert.Equal(t, val, values.GetValue(slot))
+		}
+	}
+
+	// no fill/fill(null)
+	for _, fill := range []*stmt.Fill{nil, {Type: stmt.FillNull}} {
+		values := newValues()
+		fields := map[string]*collections.FloatArray{"f": values}
+		FillGaps(fill, fields)
+		assert.Equal(t, values, fields["f"])
+	}
+
+	// fill(previous), leading gap remains empty
+	values := newValues()
+	fields := map[string]*collections.FloatArray{"f": values, "g": values, "h": nil}
+	FillGaps(&stmt.Fill{Type: stmt.FillPrevious}, fields)
+	assertValues(fields["f"], map[int]float64{1: 1, 2: 2, 3: 2, 4: 2, 5: 2})
+	assertValues(fields["g"], map[int]float64{1: 1, 2: 2, 3: 2, 4: 2, 5: 2})
+	assert.Nil(t, fields["h"])
+	// source values not changed
+	assert.Equal(t, 3, values.Size())
+
+	// fill(value)
+	fields = map[string]*collections.FloatArray{"f": newValues()}
+	FillGaps(&stmt.Fill{Type: stmt.FillValue, Value: 10}, fields)
+	assertValues(fields["f"], map[int]float64{0: 10, 1: 1, 2: 2, 3: 10, 4: 10, 5: 10})
+}
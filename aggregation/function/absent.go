@@ -0,0 +1,55 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+import (
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+// AbsentCall represents absent function call, detects if series stopped reporting in the recent window
+// (ending at last point of query time range), window is rounded to multiple of interval(at least one interval).
+// Returns single point at the last seen position, value is 1 if no data in recent window, else 0.
+// Returns nil if series has no data in query time range.
+func AbsentCall(interval, window int64, params ...*collections.FloatArray) *collections.FloatArray {
+	if len(params) == 0 || interval <= 0 {
+		return nil
+	}
+	points := window / interval
+	if points <= 0 {
+		points = 1
+	}
+	values := params[0]
+	capacity := values.Capacity()
+	lastSeen := -1
+	for idx := capacity - 1; idx >= 0; idx-- {
+		if values.HasValue(idx) {
+			lastSeen = idx
+			break
+		}
+	}
+	if lastSeen < 0 {
+		return nil
+	}
+	result := collections.NewFloatArray(capacity)
+	if lastSeen < capacity-int(points) {
+		result.SetValue(lastSeen, 1)
+	} else {
+		result.SetValue(lastSeen, 0)
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+func TestAbsentCall(t *testing.T) {
+	assert.Nil(t, AbsentCall(10, 10))
+	assert.Nil(t, AbsentCall(0, 10, collections.NewFloatArray(10)))
+	// no data in query time range
+	assert.Nil(t, AbsentCall(10, 0, collections.NewFloatArray(10)))
+
+	// series keeps reporting
+	values := collections.NewFloatArray(10)
+	values.SetValue(3, 1)
+	values.SetValue(9, 2)
+	result := AbsentCall(10, 0, values)
+	assert.Equal(t, 1, result.Size())
+	assert.Equal(t, 0.0, result.GetValue(9))
+
+	// series reports then goes silent
+	values = collections.NewFloatArray(10)
+	values.SetValue(3, 1)
+	values.SetValue(6, 2)
+	result = AbsentCall(10, 0, values)
+	assert.Equal(t, 1, result.Size())
+	assert.Equal(t, 1.0, result.GetValue(6))
+	// last point in recent window(4 points)
+	result = AbsentCall(10, 40, values)
+	assert.Equal(t, 0.0, result.GetValue(6))
+	// last point out of recent window(3 points)
+	result = AbsentCall(10, 30, values)
+	assert.Equal(t, 1.0, result.GetValue(6))
+}
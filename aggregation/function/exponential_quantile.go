@@ -0,0 +1,81 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/sketch"
+)
+
+// ExponentialQuantileCall computes quantile(0<=q<=1) of exponential histogram(sketch) for each point,
+// zeroBucket is the count of zero values(nil if not exist), histogramFields are the counts of bucket index,
+// buckets of sketches are merged(sum) before calling, so quantile has bounded relative error.
+func ExponentialQuantileCall(q float64,
+	zeroBucket *collections.FloatArray,
+	histogramFields map[int32]*collections.FloatArray,
+) (*collections.FloatArray, error) {
+	if q < 0 || q > 1 {
+		return nil, fmt.Errorf("ExponentialQuantileCall with illegal value: %f", q)
+	}
+	if zeroBucket == nil && len(histogramFields) == 0 {
+		return nil, fmt.Errorf("ExponentialQuantileCall without buckets")
+	}
+	indexes := make([]int32, 0, len(histogramFields))
+	capacity := 0
+	if zeroBucket != nil {
+		capacity = zeroBucket.Capacity()
+	}
+	for index, array := range histogramFields {
+		indexes = append(indexes, index)
+		if array.Capacity() > capacity {
+			capacity = array.Capacity()
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	targetFloatArray := collections.NewFloatArray(capacity)
+	counts := make([]float64, len(indexes))
+	for pos := 0; pos < capacity; pos++ {
+		var (
+			zeroCount float64
+			hasValue  bool
+		)
+		if zeroBucket != nil && zeroBucket.HasValue(pos) {
+			zeroCount = zeroBucket.GetValue(pos)
+			hasValue = true
+		}
+		for i, index := range indexes {
+			counts[i] = 0
+			if array := histogramFields[index]; array.HasValue(pos) {
+				counts[i] = array.GetValue(pos)
+				hasValue = true
+			}
+		}
+		if !hasValue {
+			continue
+		}
+		if v := sketch.ExponentialQuantile(q, zeroCount, indexes, counts); !math.IsNaN(v) {
+			targetFloatArray.SetValue(pos, v)
+		}
+	}
+	return targetFloatArray, nil
+}
@@ -0,0 +1,86 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/sketch"
+)
+
+func TestExponentialQuantileCall(t *testing.T) {
+	_, err := ExponentialQuantileCall(1.1, nil, nil)
+	assert.Error(t, err)
+	_, err = ExponentialQuantileCall(0.5, nil, nil)
+	assert.Error(t, err)
+
+	r := rand.New(rand.NewSource(1))
+	// two sketches written by different series, merged by sum of bucket counts(aggregator)
+	var values []float64
+	zeroBucket := collections.NewFloatArray(3)
+	buckets := make(map[int32]*collections.FloatArray)
+	addSketch := func(h *sketch.ExponentialHistogram) {
+		indexes, counts := h.Buckets()
+		for i, index := range indexes {
+			array, ok := buckets[index]
+			if !ok {
+				array = collections.NewFloatArray(3)
+				buckets[index] = array
+			}
+			array.SetValue(0, array.GetValue(0)+counts[i])
+		}
+		zeroBucket.SetValue(0, zeroBucket.GetValue(0)+h.ZeroCount())
+	}
+	for i := 0; i < 2; i++ {
+		h := sketch.NewExponentialHistogram()
+		for j := 0; j < 5000; j++ {
+			v := r.ExpFloat64() * float64(10*(i+1))
+			assert.NoError(t, h.Add(v))
+			values = append(values, v)
+		}
+		assert.NoError(t, h.Add(0))
+		values = append(values, 0)
+		addSketch(h)
+	}
+	// pos 2 only has zero values
+	zeroBucket.SetValue(2, 10)
+	sort.Float64s(values)
+
+	for _, q := range []float64{0, 0.5, 0.9, 0.99, 1} {
+		rs, err := ExponentialQuantileCall(q, zeroBucket, buckets)
+		assert.NoError(t, err)
+		expect := values[int(q*float64(len(values)-1))]
+		assert.InDelta(t, expect, rs.GetValue(0), expect*sketch.ExponentialRelativeAccuracy*(1+1e-9))
+		assert.False(t, rs.HasValue(1))
+		assert.True(t, rs.HasValue(2))
+		assert.Equal(t, 0.0, rs.GetValue(2))
+	}
+
+	// without zero bucket
+	rs, err := ExponentialQuantileCall(0.5, nil, map[int32]*collections.FloatArray{
+		10: makeFloatArray([]float64{1, 0})[0],
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, sketch.ExponentialValue(10), rs.GetValue(0))
+	assert.False(t, rs.HasValue(1))
+}
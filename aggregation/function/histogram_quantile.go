@@ -0,0 +1,104 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+// HistogramBucketTagKey is the tag key of bucket upper bound for histogram_quantile function,
+// like prometheus, each bucket is a series tagged with le="upper bound", le="+Inf" for the last bucket.
+const HistogramBucketTagKey = "le"
+
+// HistogramQuantileCall calculates the q-quantile from cumulative buckets, key is the upper bound of bucket,
+// value is the cumulative count of bucket, interpolates linearly within the bucket which quantile falls into.
+// Like prometheus histogram_quantile:
+// 1. no value if buckets count < 2 or the largest upper bound is not +Inf;
+// 2. returns the largest finite upper bound if quantile falls into +Inf bucket;
+// 3. bucket counts are clamped to be monotonic(cumulative count cannot decrease), missing count treated as 0.
+func HistogramQuantileCall(q float64, buckets map[float64]*collections.FloatArray) *collections.FloatArray {
+	if len(buckets) < 2 || q < 0 || q > 1 {
+		return nil
+	}
+	upperBounds := make([]float64, 0, len(buckets))
+	capacity := 0
+	for upperBound, values := range buckets {
+		if values == nil {
+			continue
+		}
+		upperBounds = append(upperBounds, upperBound)
+		if values.Capacity() > capacity {
+			capacity = values.Capacity()
+		}
+	}
+	sort.Float64s(upperBounds)
+	if len(upperBounds) < 2 || !math.IsInf(upperBounds[len(upperBounds)-1], +1) {
+		return nil
+	}
+	result := collections.NewFloatArray(capacity)
+	counts := make([]float64, len(upperBounds))
+	for pos := 0; pos < capacity; pos++ {
+		hasValue := false
+		for idx, upperBound := range upperBounds {
+			counts[idx] = 0
+			if values := buckets[upperBound]; values.HasValue(pos) {
+				counts[idx] = values.GetValue(pos)
+				hasValue = true
+			}
+			if idx > 0 && counts[idx] < counts[idx-1] {
+				counts[idx] = counts[idx-1]
+			}
+		}
+		if !hasValue {
+			continue
+		}
+		observations := counts[len(counts)-1]
+		if observations <= 0 {
+			continue
+		}
+		rank := q * observations
+		b := sort.Search(len(counts)-1, func(i int) bool { return counts[i] >= rank })
+		switch {
+		case b == len(counts)-1:
+			result.SetValue(pos, upperBounds[len(upperBounds)-2])
+			continue
+		case b == 0 && upperBounds[0] <= 0:
+			result.SetValue(pos, upperBounds[0])
+			continue
+		}
+		var (
+			bucketStart float64
+			bucketEnd   = upperBounds[b]
+			count       = counts[b]
+		)
+		if b > 0 {
+			bucketStart = upperBounds[b-1]
+			count -= counts[b-1]
+			rank -= counts[b-1]
+		}
+		if count <= 0 {
+			result.SetValue(pos, bucketEnd)
+			continue
+		}
+		result.SetValue(pos, bucketStart+(bucketEnd-bucketStart)*(rank/count))
+	}
+	return result
+}
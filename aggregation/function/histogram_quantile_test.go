@@ -0,0 +1,83 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+func TestHistogramQuantileCall(t *testing.T) {
+	bucket := func(data ...float64) *collections.FloatArray {
+		return makeFloatArray(data)[0]
+	}
+	buckets := map[float64]*collections.FloatArray{
+		0.1:         bucket(10, 0, 10),
+		0.5:         bucket(50, 0, 20),
+		1:           bucket(90, 0, 40),
+		math.Inf(1): bucket(100, 0, 100),
+	}
+	// invalid quantile
+	assert.Nil(t, HistogramQuantileCall(-0.1, buckets))
+	assert.Nil(t, HistogramQuantileCall(1.1, buckets))
+
+	rs := HistogramQuantileCall(0.5, buckets)
+	assert.Equal(t, 3, rs.Capacity())
+	assert.InDelta(t, 0.1+0.4*(40.0/40.0), rs.GetValue(0), 1e-9)
+	// no observations
+	assert.False(t, rs.HasValue(1))
+	// falls into +Inf bucket, returns the largest finite upper bound
+	assert.Equal(t, 1.0, rs.GetValue(2))
+
+	rs = HistogramQuantileCall(0.99, buckets)
+	assert.Equal(t, 1.0, rs.GetValue(0))
+	rs = HistogramQuantileCall(0.05, buckets)
+	assert.InDelta(t, 0.05, rs.GetValue(0), 1e-9)
+
+	// non-monotonic bucket counts are clamped
+	rs = HistogramQuantileCall(0.75, map[float64]*collections.FloatArray{
+		1:           bucket(40),
+		2:           bucket(20),
+		4:           bucket(80),
+		math.Inf(1): bucket(80),
+	})
+	assert.InDelta(t, 2+2*(20.0/40.0), rs.GetValue(0), 1e-9)
+
+	// negative lowest upper bound
+	rs = HistogramQuantileCall(0.1, map[float64]*collections.FloatArray{
+		-1:          bucket(20),
+		math.Inf(1): bucket(30),
+	})
+	assert.Equal(t, -1.0, rs.GetValue(0))
+
+	// missing +Inf bucket
+	assert.Nil(t, HistogramQuantileCall(0.5, map[float64]*collections.FloatArray{
+		1: bucket(1),
+		2: bucket(2),
+	}))
+	// buckets less than 2
+	assert.Nil(t, HistogramQuantileCall(0.5, map[float64]*collections.FloatArray{math.Inf(1): bucket(1)}))
+	assert.Nil(t, HistogramQuantileCall(0.5, map[float64]*collections.FloatArray{
+		1:           nil,
+		math.Inf(1): bucket(1),
+	}))
+}
@@ -23,16 +23,115 @@ import (
 	"github.com/lindb/lindb/pkg/collections"
 )
 
-// RateCall represents rate function call.
+// RateCall represents rate function call, normalizes value of each interval to per-second.
 func RateCall(interval int64, params ...*collections.FloatArray) *collections.FloatArray {
+	if len(params) == 0 || interval <= 0 {
+		return nil
+	}
+	seconds := float64(interval) / float64(timeutil.OneSecond)
+	result := collections.NewFloatArray(params[0].Capacity())
+	itr := params[0].NewIterator()
+	for itr.HasNext() {
+		idx, val := itr.Next()
+		result.SetValue(idx, val/seconds)
+	}
+	return result
+}
+
+// WindowRateCall represents rate function call with window, returns per-second increase of counter
+// over the window ending at each point, window is rounded to multiple of interval(at least one interval).
+// If value decreases(counter reset), the value is treated as increase from zero.
+func WindowRateCall(interval, window int64, params ...*collections.FloatArray) *collections.FloatArray {
+	if len(params) == 0 || interval <= 0 || window <= 0 {
+		return nil
+	}
+	points := window / interval
+	if points <= 0 {
+		points = 1
+	}
+	seconds := float64(points*interval) / float64(timeutil.OneSecond)
+	values := params[0]
+	capacity := values.Capacity()
+	result := collections.NewFloatArray(capacity)
+	for idx := 0; idx < capacity; idx++ {
+		if !values.HasValue(idx) {
+			continue
+		}
+		start := idx - int(points)
+		if start < 0 {
+			start = 0
+		}
+		var (
+			increase float64
+			prev     float64
+			count    int
+		)
+		for pos := start; pos <= idx; pos++ {
+			if !values.HasValue(pos) {
+				continue
+			}
+			val := values.GetValue(pos)
+			if count > 0 {
+				increase += counterIncrease(prev, val)
+			}
+			prev = val
+			count++
+		}
+		// increase needs two points at least
+		if count > 1 {
+			result.SetValue(idx, increase/seconds)
+		}
+	}
+	return result
+}
+
+// IRateCall represents irate function call, returns per-second instant rate between each point and previous point.
+// If value decreases(counter reset), the value is treated as increase from zero.
+func IRateCall(interval int64, params ...*collections.FloatArray) *collections.FloatArray {
+	if len(params) == 0 || interval <= 0 {
+		return nil
+	}
+	result := collections.NewFloatArray(params[0].Capacity())
+	itr := params[0].NewIterator()
+	if !itr.HasNext() {
+		return result
+	}
+	prevIdx, prev := itr.Next()
+	for itr.HasNext() {
+		idx, val := itr.Next()
+		seconds := float64(int64(idx-prevIdx)*interval) / float64(timeutil.OneSecond)
+		result.SetValue(idx, counterIncrease(prev, val)/seconds)
+		prevIdx, prev = idx, val
+	}
+	return result
+}
+
+// counterIncrease returns the increase of counter from prev to current value, handles counter reset.
+func counterIncrease(prev, val float64) float64 {
+	if val < prev {
+		return val
+	}
+	return val - prev
+}
+
+// DerivativeCall represents derivative function call, returns the raw delta between consecutive points.
+// If value decreases(counter reset), the value is treated as new baseline, no delta for this point.
+func DerivativeCall(params ...*collections.FloatArray) *collections.FloatArray {
 	if len(params) == 0 {
 		return nil
 	}
 	result := collections.NewFloatArray(params[0].Capacity())
 	itr := params[0].NewIterator()
+	if !itr.HasNext() {
+		return result
+	}
+	_, prev := itr.Next()
 	for itr.HasNext() {
 		idx, val := itr.Next()
-		result.SetValue(idx, val/float64(interval/timeutil.OneSecond))
+		if val >= prev {
+			result.SetValue(idx, val-prev)
+		}
+		prev = val
 	}
 	return result
 }
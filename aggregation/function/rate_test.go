@@ -38,4 +38,69 @@ func TestRateCall(t *testing.T) {
 
 	rs = RateCall(10 * timeutil.OneSecond)
 	assert.Nil(t, rs)
+	rs = RateCall(0, array1)
+	assert.Nil(t, rs)
+	// sub-second interval
+	rs = RateCall(timeutil.OneSecond/2, array1)
+	assert.Equal(t, 20.0, rs.GetValue(1))
+	assert.Equal(t, 10.0, rs.GetValue(2))
+}
+
+func TestDerivativeCall(t *testing.T) {
+	assert.Nil(t, DerivativeCall())
+	rs := DerivativeCall(collections.NewFloatArray(10))
+	assert.Equal(t, 0, rs.Size())
+
+	array1 := collections.NewFloatArray(10)
+	array1.SetValue(1, 10.0)
+	array1.SetValue(2, 15.0)
+	array1.SetValue(4, 30.0)
+	array1.SetValue(5, 3.0) // counter reset
+	array1.SetValue(6, 8.0)
+	rs = DerivativeCall(array1)
+	assert.False(t, rs.HasValue(1))
+	assert.Equal(t, 5.0, rs.GetValue(2))
+	assert.Equal(t, 15.0, rs.GetValue(4))
+	assert.False(t, rs.HasValue(5))
+	assert.Equal(t, 5.0, rs.GetValue(6))
+}
+
+func TestWindowRateCall_IRateCall(t *testing.T) {
+	assert.Nil(t, WindowRateCall(10*timeutil.OneSecond, 40*timeutil.OneSecond))
+	assert.Nil(t, WindowRateCall(0, 40*timeutil.OneSecond, collections.NewFloatArray(10)))
+	assert.Nil(t, WindowRateCall(10*timeutil.OneSecond, 0, collections.NewFloatArray(10)))
+	assert.Nil(t, IRateCall(10*timeutil.OneSecond))
+	assert.Nil(t, IRateCall(0, collections.NewFloatArray(10)))
+	assert.True(t, IRateCall(10*timeutil.OneSecond, collections.NewFloatArray(10)).IsEmpty())
+
+	// counter resets at index 3
+	counter := collections.NewFloatArray(10)
+	for idx, val := range []float64{10, 20, 30, 5, 15} {
+		counter.SetValue(idx, val)
+	}
+	counter.SetValue(7, 55)
+
+	irate := IRateCall(10*timeutil.OneSecond, counter)
+	assert.False(t, irate.HasValue(0))
+	assert.Equal(t, 1.0, irate.GetValue(1))
+	assert.Equal(t, 0.5, irate.GetValue(3))
+	assert.Equal(t, 1.0, irate.GetValue(4))
+	// gap between index 4 and 7
+	assert.Equal(t, 40.0/30, irate.GetValue(7))
+
+	rate := WindowRateCall(10*timeutil.OneSecond, 40*timeutil.OneSecond, counter)
+	assert.False(t, rate.HasValue(0))
+	assert.Equal(t, 10.0/40, rate.GetValue(1))
+	// 10+10+5 increase from index 0 to 3
+	assert.Equal(t, 25.0/40, rate.GetValue(3))
+	// 10+10+5+10 increase from index 0 to 4
+	assert.Equal(t, 35.0/40, rate.GetValue(4))
+	assert.False(t, rate.HasValue(5))
+	// 10+40 increase from index 3 to 7
+	assert.Equal(t, 50.0/40, rate.GetValue(7))
+
+	// window less than interval, use one interval
+	rate = WindowRateCall(10*timeutil.OneSecond, timeutil.OneSecond, counter)
+	assert.Equal(t, 1.0, rate.GetValue(4))
+	assert.False(t, rate.HasValue(7))
 }
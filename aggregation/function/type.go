@@ -32,6 +32,17 @@ const (
 	Quantile
 	Stddev
 	Rate
+	Percent
+	CountIf
+	SumIf
+	Derivative
+	TopK
+	BottomK
+	HistogramQuantile
+	ArgMax
+	ArgMin
+	IRate
+	Absent
 )
 
 // String return the function's name
@@ -57,12 +68,68 @@ func (t FuncType) String() string {
 		return "stddev"
 	case Rate:
 		return "rate"
+	case Percent:
+		return "percent"
+	case CountIf:
+		return "count_if"
+	case SumIf:
+		return "sum_if"
+	case Derivative:
+		return "derivative"
+	case TopK:
+		return "topk"
+	case BottomK:
+		return "bottomk"
+	case HistogramQuantile:
+		return "histogram_quantile"
+	case ArgMax:
+		return "argmax"
+	case ArgMin:
+		return "argmin"
+	case IRate:
+		return "irate"
+	case Absent:
+		return "absent"
 	default:
 		return "unknown"
 	}
 }
 
-// IsSupportOrderBy checks if function support order by.
+// IsSupportOrderBy checks if function support order by,
+// quantile is not supported, because it is calculated from histogram buckets when evaluating expression.
 func IsSupportOrderBy(t FuncType) bool {
 	return t == Sum || t == Min || t == Max || t == Count || t == Avg || t == Last || t == First || t == Stddev
 }
+
+// IsRankSelector checks if function is rank selector(keep top/bottom n grouped series).
+func IsRankSelector(t FuncType) bool {
+	return t == TopK || t == BottomK
+}
+
+// IsArgSelector checks if function is arg selector(tag value of series which has max/min field value).
+func IsArgSelector(t FuncType) bool {
+	return t == ArgMax || t == ArgMin
+}
+
+// ArgSelectorAggFunc returns the aggregation function of field for arg selector, else returns Unknown.
+func ArgSelectorAggFunc(t FuncType) FuncType {
+	switch t {
+	case ArgMax:
+		return Max
+	case ArgMin:
+		return Min
+	default:
+		return Unknown
+	}
+}
+
+// IsCrossGroup checks if function needs all grouped series when evaluating
+// (percent/topk/bottomk/histogram_quantile/argmax/argmin).
+func IsCrossGroup(t FuncType) bool {
+	return t == Percent || IsRankSelector(t) || t == HistogramQuantile || IsArgSelector(t)
+}
+
+// IsConditional checks if function is conditional aggregation(aggregate only points matching predicate).
+func IsConditional(t FuncType) bool {
+	return t == CountIf || t == SumIf
+}
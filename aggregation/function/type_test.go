@@ -34,6 +34,17 @@ func TestFuncTypeString(t *testing.T) {
 	assert.Equal(t, "quantile", Quantile.String())
 	assert.Equal(t, "stddev", Stddev.String())
 	assert.Equal(t, "rate", Rate.String())
+	assert.Equal(t, "percent", Percent.String())
+	assert.Equal(t, "count_if", CountIf.String())
+	assert.Equal(t, "sum_if", SumIf.String())
+	assert.Equal(t, "derivative", Derivative.String())
+	assert.Equal(t, "topk", TopK.String())
+	assert.Equal(t, "bottomk", BottomK.String())
+	assert.Equal(t, "histogram_quantile", HistogramQuantile.String())
+	assert.Equal(t, "argmax", ArgMax.String())
+	assert.Equal(t, "argmin", ArgMin.String())
+	assert.Equal(t, "irate", IRate.String())
+	assert.Equal(t, "absent", Absent.String())
 	assert.Equal(t, "unknown", Unknown.String())
 }
 
@@ -42,3 +53,34 @@ func TestIsSupportOrderBy(t *testing.T) {
 	assert.False(t, IsSupportOrderBy(Quantile))
 	assert.False(t, IsSupportOrderBy(Unknown))
 }
+
+func TestIsConditional(t *testing.T) {
+	assert.True(t, IsConditional(CountIf))
+	assert.True(t, IsConditional(SumIf))
+	assert.False(t, IsConditional(Sum))
+}
+
+func TestIsRankSelector(t *testing.T) {
+	assert.True(t, IsRankSelector(TopK))
+	assert.True(t, IsRankSelector(BottomK))
+	assert.False(t, IsRankSelector(Sum))
+}
+
+func TestIsCrossGroup(t *testing.T) {
+	assert.True(t, IsCrossGroup(Percent))
+	assert.True(t, IsCrossGroup(TopK))
+	assert.True(t, IsCrossGroup(HistogramQuantile))
+	assert.False(t, IsCrossGroup(Sum))
+	assert.False(t, IsCrossGroup(Quantile))
+	assert.True(t, IsCrossGroup(ArgMax))
+	assert.True(t, IsCrossGroup(ArgMin))
+}
+
+func TestIsArgSelector(t *testing.T) {
+	assert.True(t, IsArgSelector(ArgMax))
+	assert.True(t, IsArgSelector(ArgMin))
+	assert.False(t, IsArgSelector(Max))
+	assert.Equal(t, Max, ArgSelectorAggFunc(ArgMax))
+	assert.Equal(t, Min, ArgSelectorAggFunc(ArgMin))
+	assert.Equal(t, Unknown, ArgSelectorAggFunc(Sum))
+}
@@ -0,0 +1,124 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"strconv"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// HistogramQuantile calculates histogram_quantile(p, field) select items, grouped series which have
+// same tags except bucket tag(le) are merged into one series, the quantile is interpolated across
+// cumulative buckets(sum of field grouped by le), other select items take the values of the largest bucket.
+//
+// NOTE: it needs all buckets present, so it only can be done at root after all results merged,
+// returns group by tag keys/tags/result sets without bucket tag.
+func HistogramQuantile(selectItems []stmt.Expr, groupByKeys []string, tagsList []string,
+	resultSets []map[string]*collections.FloatArray,
+) (keys []string, mergedTags []string, mergedResultSets []map[string]*collections.FloatArray) {
+	quantiles := make(map[string]float64)
+	for _, selectItem := range selectItems {
+		item, ok := selectItem.(*stmt.SelectItem)
+		if !ok {
+			continue
+		}
+		call, ok := item.Expr.(*stmt.CallExpr)
+		if !ok || call.FuncType != function.HistogramQuantile || len(call.Params) != 2 {
+			continue
+		}
+		q, ok := call.Params[0].(*stmt.NumberLiteral)
+		if !ok {
+			continue
+		}
+		fieldName := item.Rewrite()
+		if len(item.Alias) > 0 {
+			fieldName = item.Alias
+		}
+		quantiles[fieldName] = q.Val
+	}
+	bucketKeyIdx := -1
+	for idx, tagKey := range groupByKeys {
+		if tagKey == function.HistogramBucketTagKey {
+			bucketKeyIdx = idx
+			break
+		}
+	}
+	if len(quantiles) == 0 || bucketKeyIdx < 0 {
+		return groupByKeys, tagsList, resultSets
+	}
+	keys = make([]string, 0, len(groupByKeys)-1)
+	keys = append(keys, groupByKeys[:bucketKeyIdx]...)
+	keys = append(keys, groupByKeys[bucketKeyIdx+1:]...)
+
+	type histogram struct {
+		buckets       map[float64]map[string]*collections.FloatArray
+		maxUpperBound float64
+	}
+	var histograms []*histogram
+	groups := make(map[string]*histogram)
+	for idx, tags := range tagsList {
+		tagValues := tag.SplitTagValues(tags)
+		if len(tagValues) != len(groupByKeys) {
+			continue
+		}
+		upperBound, err := strconv.ParseFloat(tagValues[bucketKeyIdx], 64)
+		if err != nil {
+			// ignore series without valid bucket upper bound
+			continue
+		}
+		groupTagValues := make([]string, 0, len(keys))
+		groupTagValues = append(groupTagValues, tagValues[:bucketKeyIdx]...)
+		groupTagValues = append(groupTagValues, tagValues[bucketKeyIdx+1:]...)
+		groupTags := tag.ConcatTagValues(groupTagValues)
+		h, ok := groups[groupTags]
+		if !ok {
+			h = &histogram{buckets: make(map[float64]map[string]*collections.FloatArray), maxUpperBound: upperBound}
+			groups[groupTags] = h
+			histograms = append(histograms, h)
+			mergedTags = append(mergedTags, groupTags)
+		}
+		h.buckets[upperBound] = resultSets[idx]
+		if upperBound > h.maxUpperBound {
+			h.maxUpperBound = upperBound
+		}
+	}
+	mergedResultSets = make([]map[string]*collections.FloatArray, 0, len(histograms))
+	for _, h := range histograms {
+		resultSet := make(map[string]*collections.FloatArray)
+		for fieldName, values := range h.buckets[h.maxUpperBound] {
+			if _, ok := quantiles[fieldName]; !ok {
+				resultSet[fieldName] = values
+			}
+		}
+		for fieldName, q := range quantiles {
+			buckets := make(map[float64]*collections.FloatArray, len(h.buckets))
+			for upperBound, bucketResultSet := range h.buckets {
+				if values := bucketResultSet[fieldName]; values != nil {
+					buckets[upperBound] = values
+				}
+			}
+			resultSet[fieldName] = function.HistogramQuantileCall(q, buckets)
+		}
+		mergedResultSets = append(mergedResultSets, resultSet)
+	}
+	return keys, mergedTags, mergedResultSets
+}
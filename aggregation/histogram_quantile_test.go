@@ -0,0 +1,78 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestHistogramQuantile(t *testing.T) {
+	newValues := func(vals ...float64) *collections.FloatArray {
+		values := collections.NewFloatArray(len(vals))
+		for idx, val := range vals {
+			values.SetValue(idx, val)
+		}
+		return values
+	}
+	selectItems := []stmt.Expr{
+		&stmt.SelectItem{Expr: &stmt.CallExpr{
+			FuncType: function.HistogramQuantile,
+			Params:   []stmt.Expr{&stmt.NumberLiteral{Val: 0.5}, &stmt.FieldExpr{Name: "value"}},
+		}, Alias: "p50"},
+		&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "value"}},
+	}
+	tagsList := []string{"a,1", "a,2", "a,+Inf", "b,+Inf", "b,1", "b,x", "c"}
+	resultSets := []map[string]*collections.FloatArray{
+		{"p50": newValues(2), "value": newValues(2)},
+		{"p50": newValues(8), "value": newValues(8)},
+		{"p50": newValues(10), "value": newValues(10)},
+		{"p50": newValues(4), "value": newValues(4)},
+		{"p50": newValues(4), "value": newValues(4)},
+		{"p50": newValues(1), "value": newValues(1)},
+		{"p50": newValues(1), "value": newValues(1)},
+	}
+
+	// no bucket tag key in group by
+	keys, tags, rs := HistogramQuantile(selectItems, []string{"host", "zone"}, tagsList, resultSets)
+	assert.Equal(t, []string{"host", "zone"}, keys)
+	assert.Equal(t, tagsList, tags)
+	assert.Equal(t, resultSets, rs)
+	// no histogram_quantile
+	keys, tags, rs = HistogramQuantile(selectItems[1:], []string{"host", "le"}, tagsList, resultSets)
+	assert.Equal(t, []string{"host", "le"}, keys)
+	assert.Equal(t, tagsList, tags)
+	assert.Equal(t, resultSets, rs)
+
+	keys, tags, rs = HistogramQuantile(selectItems, []string{"host", "le"}, tagsList, resultSets)
+	assert.Equal(t, []string{"host"}, keys)
+	assert.Equal(t, []string{"a", "b"}, tags)
+	assert.Len(t, rs, 2)
+	// rank 5 falls into bucket (1,2]
+	assert.InDelta(t, 1+(5.0-2.0)/6.0, rs[0]["p50"].GetValue(0), 1e-9)
+	// other select items take values of +Inf bucket
+	assert.Equal(t, 10.0, rs[0]["value"].GetValue(0))
+	// rank 2 falls into bucket (0,1]
+	assert.InDelta(t, 0.5, rs[1]["p50"].GetValue(0), 1e-9)
+	assert.Equal(t, 4.0, rs[1]["value"].GetValue(0))
+}
@@ -60,6 +60,10 @@ func (r *OrderByRow) aggregate(it *collections.FloatArrayIterator) *aggResult {
 	for it.HasNext() {
 		// get value
 		_, val := it.Next()
+		if math.IsNaN(val) {
+			// skip NaN point, e.g. division by zero
+			continue
+		}
 
 		last = val
 
@@ -194,7 +198,7 @@ func (o *topNOrderBy) Push(row Row) {
 	o.topn.Add(row)
 }
 
-// ResultSet returns result set for topN.
+// ResultSet returns result set for topN, sorted by order by items so that offset skips the top rows.
 func (o *topNOrderBy) ResultSet() []Row {
-	return o.topn.ResultSet()
+	return o.topn.SortedResultSet()
 }
@@ -18,7 +18,7 @@
 package aggregation
 
 import (
-	"sort"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,7 +41,7 @@ func TestTopNOrderBy(t *testing.T) {
 	for _, r := range rows {
 		rs = append(rs, r.GetValue("", function.Count))
 	}
-	sort.Float64s(rs)
+	// sorted by order by items
 	assert.Equal(t, []float64{1, 3, 10, 20, 20}, rs)
 }
 
@@ -89,6 +89,19 @@ func TestOrderByRow(t *testing.T) {
 		assert.NotZero(t, row.GetValue("f1", function.Stddev))
 		assert.Zero(t, row.GetValue("f1", function.Unknown))
 	})
+
+	t.Run("skip NaN", func(t *testing.T) {
+		values := collections.NewFloatArray(3)
+		values.SetValue(0, 2.0)
+		values.SetValue(1, math.NaN())
+		values.SetValue(2, 1.0)
+		row := NewOrderByRow("tags", map[string]*collections.FloatArray{
+			"f1": values,
+		})
+		assert.Equal(t, 2.0, row.GetValue("f1", function.Count))
+		assert.Equal(t, 3.0, row.GetValue("f1", function.Sum))
+		assert.Equal(t, 2.0, row.GetValue("f1", function.Max))
+	})
 }
 
 func TestResultLimiter(t *testing.T) {
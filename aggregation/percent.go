@@ -0,0 +1,96 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// PercentOfTotal transforms the values of percent(...) select items into the percentage of the total
+// across all grouped series for each time slot(e.g. pie-chart panel), the series of one group sum to 100.
+// If the total of a time slot is zero, the value of this slot is not set(null).
+//
+// NOTE: it needs all grouped series present, so it only can be done at root after all results merged,
+// and it is incompatible with streaming result mode.
+func PercentOfTotal(selectItems []stmt.Expr, resultSets []map[string]*collections.FloatArray) {
+	for _, selectItem := range selectItems {
+		item, ok := selectItem.(*stmt.SelectItem)
+		if !ok {
+			continue
+		}
+		call, ok := item.Expr.(*stmt.CallExpr)
+		if !ok || call.FuncType != function.Percent {
+			continue
+		}
+		fieldName := item.Rewrite()
+		if len(item.Alias) > 0 {
+			fieldName = item.Alias
+		}
+		percentOfTotal(fieldName, resultSets)
+	}
+}
+
+// percentOfTotal calculates the percentage of total for given field.
+func percentOfTotal(fieldName string, resultSets []map[string]*collections.FloatArray) {
+	capacity := 0
+	for _, resultSet := range resultSets {
+		if values, ok := resultSet[fieldName]; ok && values != nil && values.Capacity() > capacity {
+			capacity = values.Capacity()
+		}
+	}
+	if capacity == 0 {
+		return
+	}
+	total := make([]float64, capacity)
+	for _, resultSet := range resultSets {
+		values := resultSet[fieldName]
+		if values == nil {
+			continue
+		}
+		it := values.NewIterator()
+		for it.HasNext() {
+			slot, val := it.Next()
+			if math.IsNaN(val) {
+				continue
+			}
+			total[slot] += val
+		}
+	}
+	for _, resultSet := range resultSets {
+		values := resultSet[fieldName]
+		if values == nil {
+			continue
+		}
+		// values maybe shared with other select item, build new array for percent result
+		result := collections.NewFloatArray(values.Capacity())
+		it := values.NewIterator()
+		for it.HasNext() {
+			slot, val := it.Next()
+			if total[slot] == 0 {
+				// avoid divide by zero, keep it as null
+				continue
+			}
+			result.SetValue(slot, val/total[slot]*100)
+		}
+		resultSet[fieldName] = result
+	}
+}
@@ -0,0 +1,81 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestPercentOfTotal(t *testing.T) {
+	percentItem := &stmt.SelectItem{Expr: &stmt.CallExpr{
+		FuncType: function.Percent,
+		Params:   []stmt.Expr{&stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}},
+	}}
+	sumItem := &stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}}
+	fieldName := percentItem.Rewrite()
+	newValues := func(vals ...float64) *collections.FloatArray {
+		values := collections.NewFloatArray(len(vals))
+		for idx, val := range vals {
+			values.SetValue(idx, val)
+		}
+		return values
+	}
+	s1 := newValues(10, 0, 1)
+	resultSets := []map[string]*collections.FloatArray{
+		{fieldName: s1, "sum(f)": s1},
+		{fieldName: newValues(30, 0, 1)},
+		{fieldName: newValues(60, 0, 2)},
+		{fieldName: nil},
+		{"sum(f)": newValues(1, 1, 1)},
+	}
+	PercentOfTotal([]stmt.Expr{sumItem, percentItem, &stmt.FieldExpr{Name: "f"}}, resultSets)
+
+	sum := 0.0
+	for _, rs := range resultSets[:3] {
+		sum += rs[fieldName].GetValue(0)
+		// zero total emits null
+		assert.False(t, rs[fieldName].HasValue(1))
+	}
+	assert.InDelta(t, 100.0, sum, 0.000001)
+	assert.InDelta(t, 10.0, resultSets[0][fieldName].GetValue(0), 0.000001)
+	assert.InDelta(t, 30.0, resultSets[1][fieldName].GetValue(0), 0.000001)
+	assert.InDelta(t, 60.0, resultSets[2][fieldName].GetValue(0), 0.000001)
+	assert.InDelta(t, 25.0, resultSets[0][fieldName].GetValue(2), 0.000001)
+	assert.InDelta(t, 25.0, resultSets[1][fieldName].GetValue(2), 0.000001)
+	assert.InDelta(t, 50.0, resultSets[2][fieldName].GetValue(2), 0.000001)
+	// other select item not changed
+	assert.Equal(t, 10.0, resultSets[0]["sum(f)"].GetValue(0))
+	assert.Equal(t, 1.0, resultSets[4]["sum(f)"].GetValue(1))
+
+	// alias
+	resultSets = []map[string]*collections.FloatArray{{"p": newValues(1, 1)}, {"p": newValues(3, 0)}}
+	PercentOfTotal([]stmt.Expr{&stmt.SelectItem{Expr: percentItem.Expr, Alias: "p"}}, resultSets)
+	assert.InDelta(t, 25.0, resultSets[0]["p"].GetValue(0), 0.000001)
+	assert.InDelta(t, 75.0, resultSets[1]["p"].GetValue(0), 0.000001)
+	assert.InDelta(t, 100.0, resultSets[0]["p"].GetValue(1), 0.000001)
+	assert.InDelta(t, 0.0, resultSets[1]["p"].GetValue(1), 0.000001)
+
+	// no values
+	PercentOfTotal([]stmt.Expr{percentItem}, nil)
+}
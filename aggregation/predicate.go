@@ -0,0 +1,60 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// Predicate represents the predicate of conditional aggregation,
+// evaluated on each point when down sampling, like f > 100.
+type Predicate func(value float64) bool
+
+// NewPredicate creates the predicate based on field comparison expr.
+func NewPredicate(expr *stmt.BinaryExpr) (Predicate, error) {
+	if expr == nil {
+		return nil, errors.New("predicate cannot be empty")
+	}
+	if _, ok := expr.Left.(*stmt.FieldExpr); !ok {
+		return nil, fmt.Errorf("predicate left must be field, predicate: %s", expr.Rewrite())
+	}
+	threshold, ok := expr.Right.(*stmt.NumberLiteral)
+	if !ok {
+		return nil, fmt.Errorf("predicate right must be number, predicate: %s", expr.Rewrite())
+	}
+	val := threshold.Val
+	switch expr.Operator {
+	case stmt.EQUAL:
+		return func(value float64) bool { return value == val }, nil
+	case stmt.NOTEQUAL:
+		return func(value float64) bool { return value != val }, nil
+	case stmt.LESS:
+		return func(value float64) bool { return value < val }, nil
+	case stmt.LESSEQUAL:
+		return func(value float64) bool { return value <= val }, nil
+	case stmt.GREATER:
+		return func(value float64) bool { return value > val }, nil
+	case stmt.GREATEREQUAL:
+		return func(value float64) bool { return value >= val }, nil
+	default:
+		return nil, fmt.Errorf("predicate operator not support, predicate: %s", expr.Rewrite())
+	}
+}
@@ -0,0 +1,63 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestNewPredicate(t *testing.T) {
+	newExpr := func(op stmt.BinaryOP) *stmt.BinaryExpr {
+		return &stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f"}, Right: &stmt.NumberLiteral{Val: 100}, Operator: op}
+	}
+	cases := []struct {
+		op       stmt.BinaryOP
+		expected []bool // 99, 100, 101
+	}{
+		{op: stmt.EQUAL, expected: []bool{false, true, false}},
+		{op: stmt.NOTEQUAL, expected: []bool{true, false, true}},
+		{op: stmt.LESS, expected: []bool{true, false, false}},
+		{op: stmt.LESSEQUAL, expected: []bool{true, true, false}},
+		{op: stmt.GREATER, expected: []bool{false, false, true}},
+		{op: stmt.GREATEREQUAL, expected: []bool{false, true, true}},
+	}
+	for _, tt := range cases {
+		predicate, err := NewPredicate(newExpr(tt.op))
+		assert.NoError(t, err)
+		for idx, v := range []float64{99, 100, 101} {
+			assert.Equal(t, tt.expected[idx], predicate(v), stmt.BinaryOPString(tt.op))
+		}
+	}
+
+	predicate, err := NewPredicate(nil)
+	assert.Error(t, err)
+	assert.Nil(t, predicate)
+	predicate, err = NewPredicate(newExpr(stmt.ADD))
+	assert.Error(t, err)
+	assert.Nil(t, predicate)
+	predicate, err = NewPredicate(&stmt.BinaryExpr{Left: &stmt.NumberLiteral{Val: 1}, Right: &stmt.NumberLiteral{Val: 100}})
+	assert.Error(t, err)
+	assert.Nil(t, predicate)
+	predicate, err = NewPredicate(&stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f"}, Right: &stmt.FieldExpr{Name: "f"}})
+	assert.Error(t, err)
+	assert.Nil(t, predicate)
+}
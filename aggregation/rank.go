@@ -0,0 +1,113 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"sort"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// RankSelect keeps the top/bottom n grouped series for topk(n, expr)/bottomk(n, expr) select items,
+// grouped series are ranked by the scalar aggregate of expr values(aggregated by the function of expr,
+// sum if not supported), ties are broken by tags ascending so that result is stable.
+// Grouped series without values of expr are ranked last.
+//
+// NOTE: it needs all grouped series present, so it only can be done at root after all results merged,
+// returns the selected tags/result sets keeping the original order.
+func RankSelect(selectItems []stmt.Expr, tagsList []string,
+	resultSets []map[string]*collections.FloatArray,
+) (selectedTags []string, selectedResultSets []map[string]*collections.FloatArray) {
+	selectedTags = tagsList
+	selectedResultSets = resultSets
+	for _, selectItem := range selectItems {
+		item, ok := selectItem.(*stmt.SelectItem)
+		if !ok {
+			continue
+		}
+		call, ok := item.Expr.(*stmt.CallExpr)
+		if !ok || !function.IsRankSelector(call.FuncType) || len(call.Params) != 2 {
+			continue
+		}
+		n, ok := call.Params[0].(*stmt.NumberLiteral)
+		if !ok {
+			continue
+		}
+		fieldName := item.Rewrite()
+		if len(item.Alias) > 0 {
+			fieldName = item.Alias
+		}
+		aggFunc := function.Sum
+		if inner, ok := call.Params[1].(*stmt.CallExpr); ok && function.IsSupportOrderBy(inner.FuncType) {
+			aggFunc = inner.FuncType
+		}
+		selectedTags, selectedResultSets = rankSelect(fieldName, aggFunc, int(n.Val),
+			call.FuncType == function.BottomK, selectedTags, selectedResultSets)
+	}
+	return selectedTags, selectedResultSets
+}
+
+// rankSelect keeps the top/bottom n grouped series for given field.
+func rankSelect(fieldName string, aggFunc function.FuncType, n int, asc bool,
+	tagsList []string, resultSets []map[string]*collections.FloatArray,
+) (selectedTags []string, selectedResultSets []map[string]*collections.FloatArray) {
+	if n <= 0 || len(resultSets) <= n {
+		return tagsList, resultSets
+	}
+	type rankRow struct {
+		idx      int
+		hasValue bool
+		value    float64
+	}
+	rows := make([]rankRow, len(resultSets))
+	for idx, resultSet := range resultSets {
+		rows[idx].idx = idx
+		if values := resultSet[fieldName]; values != nil && !values.IsEmpty() {
+			rows[idx].hasValue = true
+			rows[idx].value = NewOrderByRow(tagsList[idx], resultSet).GetValue(fieldName, aggFunc)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		ri, rj := rows[i], rows[j]
+		if ri.hasValue != rj.hasValue {
+			return ri.hasValue
+		}
+		if ri.hasValue && ri.value != rj.value {
+			if asc {
+				return ri.value < rj.value
+			}
+			return ri.value > rj.value
+		}
+		return tagsList[ri.idx] < tagsList[rj.idx]
+	})
+	selected := make([]bool, len(resultSets))
+	for _, row := range rows[:n] {
+		selected[row.idx] = true
+	}
+	selectedTags = make([]string, 0, n)
+	selectedResultSets = make([]map[string]*collections.FloatArray, 0, n)
+	for idx := range resultSets {
+		if selected[idx] {
+			selectedTags = append(selectedTags, tagsList[idx])
+			selectedResultSets = append(selectedResultSets, resultSets[idx])
+		}
+	}
+	return selectedTags, selectedResultSets
+}
@@ -0,0 +1,115 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestRankSelect(t *testing.T) {
+	newValues := func(vals ...float64) *collections.FloatArray {
+		values := collections.NewFloatArray(len(vals))
+		for idx, val := range vals {
+			values.SetValue(idx, val)
+		}
+		return values
+	}
+	sumF := &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}
+	newRank := func(funcType function.FuncType, n float64, expr stmt.Expr, alias string) *stmt.SelectItem {
+		return &stmt.SelectItem{Expr: &stmt.CallExpr{
+			FuncType: funcType,
+			Params:   []stmt.Expr{&stmt.NumberLiteral{Val: n}, expr},
+		}, Alias: alias}
+	}
+	tagsList := []string{"d", "b", "a", "c", "e"}
+	resultSets := []map[string]*collections.FloatArray{
+		{"r": newValues(1, 2)},  // 3
+		{"r": newValues(5, 5)},  // 10
+		{"r": newValues(4, 6)},  // 10
+		{"r": newValues(20, 0)}, // 20
+		{"r": nil},
+	}
+
+	cases := []struct {
+		name        string
+		selectItems []stmt.Expr
+		tags        []string
+	}{
+		{
+			name:        "no rank selector",
+			selectItems: []stmt.Expr{&stmt.SelectItem{Expr: sumF}, &stmt.FieldExpr{Name: "f"}},
+			tags:        tagsList,
+		},
+		{
+			name:        "topk, tie broken by tags, keep original order",
+			selectItems: []stmt.Expr{newRank(function.TopK, 2, sumF, "r")},
+			tags:        []string{"a", "c"},
+		},
+		{
+			name:        "topk, series without values ranked last",
+			selectItems: []stmt.Expr{newRank(function.TopK, 4, sumF, "r")},
+			tags:        []string{"d", "b", "a", "c"},
+		},
+		{
+			name:        "bottomk",
+			selectItems: []stmt.Expr{newRank(function.BottomK, 2, sumF, "r")},
+			tags:        []string{"d", "a"},
+		},
+		{
+			name: "topk by max",
+			selectItems: []stmt.Expr{newRank(function.TopK, 2,
+				&stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}, "r")},
+			tags: []string{"a", "c"},
+		},
+		{
+			name:        "n greater than series",
+			selectItems: []stmt.Expr{newRank(function.TopK, 10, sumF, "r")},
+			tags:        tagsList,
+		},
+		{
+			name: "invalid params",
+			selectItems: []stmt.Expr{
+				&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.TopK}},
+				newRank(function.TopK, 1, sumF, "r"),
+			},
+			tags: []string{"c"},
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tags, rs := RankSelect(tt.selectItems, tagsList, resultSets)
+			assert.Equal(t, tt.tags, tags)
+			assert.Len(t, rs, len(tt.tags))
+		})
+	}
+
+	// without alias
+	item := newRank(function.TopK, 1, sumF, "")
+	tags, _ := RankSelect([]stmt.Expr{item}, []string{"a", "b"}, []map[string]*collections.FloatArray{
+		{item.Rewrite(): newValues(1)},
+		{item.Rewrite(): newValues(2)},
+	})
+	assert.Equal(t, []string{"b"}, tags)
+}
@@ -18,8 +18,11 @@
 package aggregation
 
 import (
+	"fmt"
+
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 // Aggregator represents aggregator spec for down sampling/aggregator.
@@ -41,6 +44,15 @@ type AggregatorSpec interface {
 	AddFunctionType(funcType function.FuncType)
 	// Functions returns function types for down sampling.
 	Functions() map[function.FuncType]function.FuncType
+	// SetPredicate sets the predicate of conditional aggregation for down sampling,
+	// only supports one predicate for a field.
+	SetPredicate(expr *stmt.BinaryExpr) error
+	// Predicate returns the predicate of conditional aggregation, nil if not set.
+	Predicate() Predicate
+	// AddFilter adds the field filter of where clause, the points not matched are dropped when down sampling.
+	AddFilter(expr *stmt.BinaryExpr) error
+	// Filter returns the field filter(all field filters matched), nil if not set.
+	Filter() Predicate
 }
 
 // aggregatorSpec implements AggregatorSpec interface.
@@ -48,6 +60,10 @@ type aggregatorSpec struct {
 	fieldName field.Name
 	fieldType field.Type
 	functions map[function.FuncType]function.FuncType
+
+	predicateExpr *stmt.BinaryExpr
+	predicate     Predicate
+	filter        Predicate
 }
 
 // NewAggregatorSpec creates a AggregatorSpec.
@@ -81,3 +97,48 @@ func (a *aggregatorSpec) AddFunctionType(funcType function.FuncType) {
 func (a *aggregatorSpec) Functions() map[function.FuncType]function.FuncType {
 	return a.functions
 }
+
+// SetPredicate sets the predicate of conditional aggregation for down sampling,
+// only supports one predicate for a field.
+func (a *aggregatorSpec) SetPredicate(expr *stmt.BinaryExpr) error {
+	if a.predicateExpr != nil {
+		if a.predicateExpr.Rewrite() != expr.Rewrite() {
+			return fmt.Errorf("field[%s] only supports one predicate for conditional aggregation", a.fieldName)
+		}
+		return nil
+	}
+	predicate, err := NewPredicate(expr)
+	if err != nil {
+		return err
+	}
+	a.predicateExpr = expr
+	a.predicate = predicate
+	return nil
+}
+
+// Predicate returns the predicate of conditional aggregation, nil if not set.
+func (a *aggregatorSpec) Predicate() Predicate {
+	return a.predicate
+}
+
+// AddFilter adds the field filter of where clause, the points not matched are dropped when down sampling.
+func (a *aggregatorSpec) AddFilter(expr *stmt.BinaryExpr) error {
+	filter, err := NewPredicate(expr)
+	if err != nil {
+		return err
+	}
+	if a.filter == nil {
+		a.filter = filter
+		return nil
+	}
+	prev := a.filter
+	a.filter = func(value float64) bool {
+		return prev(value) && filter(value)
+	}
+	return nil
+}
+
+// Filter returns the field filter(all field filters matched), nil if not set.
+func (a *aggregatorSpec) Filter() Predicate {
+	return a.filter
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestAggregatorSpec_FieldName(t *testing.T) {
@@ -38,3 +39,35 @@ func TestAggregatorSpec_AddFunctionType(t *testing.T) {
 	agg.AddFunctionType(function.Sum)
 	assert.Equal(t, 1, len(agg.Functions()))
 }
+
+func TestAggregatorSpec_SetPredicate(t *testing.T) {
+	agg := NewAggregatorSpec("f1", field.SumField)
+	assert.Nil(t, agg.Predicate())
+	greater := &stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f1"}, Right: &stmt.NumberLiteral{Val: 100}, Operator: stmt.GREATER}
+	assert.NoError(t, agg.SetPredicate(greater))
+	assert.NoError(t, agg.SetPredicate(greater))
+	assert.True(t, agg.Predicate()(101))
+	assert.False(t, agg.Predicate()(100))
+	// predicate conflict
+	err := agg.SetPredicate(&stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f1"}, Right: &stmt.NumberLiteral{Val: 10}, Operator: stmt.LESS})
+	assert.Error(t, err)
+	// invalid predicate
+	agg = NewAggregatorSpec("f1", field.SumField)
+	assert.Error(t, agg.SetPredicate(&stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f1"}, Right: &stmt.NumberLiteral{Val: 10}, Operator: stmt.ADD}))
+	assert.Nil(t, agg.Predicate())
+}
+
+func TestAggregatorSpec_AddFilter(t *testing.T) {
+	agg := NewAggregatorSpec("f1", field.SumField)
+	assert.Nil(t, agg.Filter())
+	assert.NoError(t, agg.AddFilter(&stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f1"}, Right: &stmt.NumberLiteral{Val: 10}, Operator: stmt.GREATEREQUAL}))
+	assert.True(t, agg.Filter()(10))
+	assert.False(t, agg.Filter()(9))
+	// all filters must be matched
+	assert.NoError(t, agg.AddFilter(&stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f1"}, Right: &stmt.NumberLiteral{Val: 100}, Operator: stmt.LESS}))
+	assert.True(t, agg.Filter()(50))
+	assert.False(t, agg.Filter()(9))
+	assert.False(t, agg.Filter()(100))
+	// invalid filter
+	assert.Error(t, agg.AddFilter(&stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f1"}, Right: &stmt.FieldExpr{Name: "f2"}, Operator: stmt.LESS}))
+}
@@ -19,6 +19,7 @@ package aggregation
 
 import (
 	"container/heap"
+	"sort"
 
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/collections"
@@ -72,19 +73,27 @@ func (h *topNHeap) Swap(i, j int) {
 
 // Less compares the value of row based on order by items.
 func (h *topNHeap) Less(i, j int) bool {
+	return h.less(h.rows[i], h.rows[j])
+}
+
+// less returns true if row a ranks behind row b based on order by items(applied in order with desc flag of each item),
+// if all order by items are equal, row with smaller tags ranks first, so that the ranking is deterministic.
+func (h *topNHeap) less(a, b Row) bool {
 	for _, by := range h.orderByItems {
-		ret := h.rows[i].GetValue(by.Name, by.FuncType) - h.rows[j].GetValue(by.Name, by.FuncType)
-		if by.Desc {
-			ret = -ret
+		aVal := a.GetValue(by.Name, by.FuncType)
+		bVal := b.GetValue(by.Name, by.FuncType)
+		if aVal == bVal {
+			// if equals goto next order by item
+			continue
 		}
-		if ret > 0 {
-			return true
-		} else if ret < 0 {
-			return false
+		if by.Desc {
+			return aVal < bVal
 		}
-		// if equals goto next order by item
+		return aVal > bVal
 	}
-	return false
+	aTags, _ := a.ResultSet()
+	bTags, _ := b.ResultSet()
+	return aTags > bTags
 }
 
 // Push pushes row into topN heap.
@@ -116,3 +125,13 @@ func (h *topNHeap) Add(row Row) {
 func (h *topNHeap) ResultSet() []Row {
 	return h.rows
 }
+
+// SortedResultSet returns result set of topN sorted by order by items(top first).
+func (h *topNHeap) SortedResultSet() []Row {
+	rows := make([]Row, len(h.rows))
+	copy(rows, h.rows)
+	sort.SliceStable(rows, func(i, j int) bool {
+		return h.less(rows[j], rows[i])
+	})
+	return rows
+}
@@ -72,3 +72,89 @@ func TestTopN(t *testing.T) {
 
 	assert.Nil(t, topNAsc.Pop())
 }
+
+type multiKeyRow struct {
+	tags   string
+	values map[string]float64
+}
+
+func (r *multiKeyRow) ResultSet() (tags string, fields map[string]*collections.FloatArray) {
+	return r.tags, nil
+}
+
+func (r *multiKeyRow) GetValue(fieldName string, _ function.FuncType) float64 {
+	return r.values[fieldName]
+}
+
+func TestTopN_MultiKeys(t *testing.T) {
+	rows := []Row{
+		&multiKeyRow{tags: "h1", values: map[string]float64{"a": 10, "b": 1, "c": 5}},
+		&multiKeyRow{tags: "h2", values: map[string]float64{"a": 20, "b": 3, "c": 5}},
+		&multiKeyRow{tags: "h3", values: map[string]float64{"a": 10, "b": 2, "c": 1}},
+		&multiKeyRow{tags: "h4", values: map[string]float64{"a": 10, "b": 1, "c": 7}},
+		&multiKeyRow{tags: "h5", values: map[string]float64{"a": 20, "b": 3, "c": 5}},
+		&multiKeyRow{tags: "h0", values: map[string]float64{"a": 10, "b": 1, "c": 5}},
+	}
+	sortedTags := func(orderByItems []*OrderByItem, limit int) []string {
+		orderBy := NewTopNOrderBy(orderByItems, limit)
+		for _, r := range rows {
+			orderBy.Push(r)
+		}
+		var result []string
+		for _, r := range orderBy.ResultSet() {
+			tags, _ := r.ResultSet()
+			result = append(result, tags)
+		}
+		return result
+	}
+	cases := []struct {
+		name         string
+		orderByItems []*OrderByItem
+		limit        int
+		tags         []string
+	}{
+		{
+			name:         "sum(a) desc, max(b) asc",
+			orderByItems: []*OrderByItem{{Name: "a", FuncType: function.Sum, Desc: true}, {Name: "b", FuncType: function.Max}},
+			limit:        10,
+			tags:         []string{"h2", "h5", "h0", "h1", "h4", "h3"},
+		},
+		{
+			name:         "sum(a) asc, max(b) desc",
+			orderByItems: []*OrderByItem{{Name: "a", FuncType: function.Sum}, {Name: "b", FuncType: function.Max, Desc: true}},
+			limit:        4,
+			tags:         []string{"h3", "h0", "h1", "h4"},
+		},
+		{
+			name: "sum(a) asc, max(b) asc, min(c) desc",
+			orderByItems: []*OrderByItem{
+				{Name: "a", FuncType: function.Sum},
+				{Name: "b", FuncType: function.Max},
+				{Name: "c", FuncType: function.Min, Desc: true},
+			},
+			limit: 10,
+			tags:  []string{"h4", "h0", "h1", "h3", "h2", "h5"},
+		},
+		{
+			name: "sum(a) desc, max(b) desc, min(c) asc, tie broken by tags",
+			orderByItems: []*OrderByItem{
+				{Name: "a", FuncType: function.Sum, Desc: true},
+				{Name: "b", FuncType: function.Max, Desc: true},
+				{Name: "c", FuncType: function.Min},
+			},
+			limit: 3,
+			tags:  []string{"h2", "h5", "h3"},
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.tags, sortedTags(tt.orderByItems, tt.limit))
+			// ranking is deterministic regardless of push order
+			for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+				rows[i], rows[j] = rows[j], rows[i]
+			}
+			assert.Equal(t, tt.tags, sortedTags(tt.orderByItems, tt.limit))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lindb/common/pkg/encoding"
+	"github.com/lindb/common/pkg/logger"
+
+	depspkg "github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+// AlterDatabaseCommand executes alter database statement, modifies the stored database config,
+// then master re-assigns shard replicas and pushes the new option to storage cluster.
+// NOTE: ttl changes only apply to the shards/segments created after altering.
+func AlterDatabaseCommand(ctx context.Context, deps *depspkg.HTTPDeps,
+	_ *models.ExecuteParam, stmt stmtpkg.Statement) (interface{}, error) {
+	alterStmt := stmt.(*stmtpkg.AlterDatabase)
+	data, err := deps.Repo.Get(ctx, constants.GetDatabaseConfigPath(alterStmt.Name))
+	if errors.Is(err, state.ErrNotExist) {
+		return nil, fmt.Errorf("%w, name: %s", constants.ErrDatabaseNotFound, alterStmt.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	database := &models.Database{}
+	if err = encoding.JSONUnmarshal(data, database); err != nil {
+		return nil, err
+	}
+	if alterStmt.ReplicaFactor > 0 {
+		if err = alterReplicaFactor(deps, database, alterStmt.ReplicaFactor); err != nil {
+			return nil, err
+		}
+	}
+	if alterStmt.TTL != "" {
+		if err = alterTTL(database, alterStmt.TTL); err != nil {
+			return nil, err
+		}
+	}
+	log.Info("Altering database", logger.String("name", database.Name),
+		logger.String("ttl", alterStmt.TTL), logger.Int("replica", alterStmt.ReplicaFactor))
+	if err := deps.Repo.Put(ctx, constants.GetDatabaseConfigPath(database.Name), encoding.JSONMarshal(database)); err != nil {
+		return nil, err
+	}
+	rs := fmt.Sprintf("Alter database[%s] ok", database.Name)
+	return &rs, nil
+}
+
+// alterReplicaFactor sets the new replica factor, reducing replica factor is not supported.
+func alterReplicaFactor(deps *depspkg.HTTPDeps, database *models.Database, replicaFactor int) error {
+	if replicaFactor < database.ReplicaFactor {
+		return fmt.Errorf("cannot reduce replica factor of database[%s] from %d to %d",
+			database.Name, database.ReplicaFactor, replicaFactor)
+	}
+	if replicaFactor > database.ReplicaFactor {
+		storage, ok := deps.StateMgr.GetStorage(database.Storage)
+		if !ok {
+			return fmt.Errorf("storage[%s] of database[%s] not found", database.Storage, database.Name)
+		}
+		if replicaFactor > len(storage.LiveNodes) {
+			return fmt.Errorf("replica factor %d of database[%s] is greater than num. of live storage nodes %d",
+				replicaFactor, database.Name, len(storage.LiveNodes))
+		}
+	}
+	database.ReplicaFactor = replicaFactor
+	return nil
+}
+
+// alterTTL sets the retention of write interval(the smallest interval).
+func alterTTL(database *models.Database, ttlStr string) error {
+	var ttl timeutil.Interval
+	if err := ttl.ValueOf(ttlStr); err != nil {
+		return err
+	}
+	if database.Option == nil || len(database.Option.Intervals) == 0 {
+		return fmt.Errorf("intervals of database[%s] not found", database.Name)
+	}
+	sort.Sort(database.Option.Intervals)
+	writeInterval := &database.Option.Intervals[0]
+	if ttl < writeInterval.Interval {
+		return fmt.Errorf("ttl %s of database[%s] cannot be less than write interval %s",
+			ttlStr, database.Name, writeInterval.Interval)
+	}
+	writeInterval.Retention = ttl
+	return database.Option.Validate()
+}
@@ -0,0 +1,192 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/pkg/encoding"
+
+	depspkg "github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestAlterDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	stateMgr := broker.NewMockStateManager(ctrl)
+	deps := &depspkg.HTTPDeps{
+		Repo:     repo,
+		StateMgr: stateMgr,
+	}
+	databaseCfg := `{"name":"test","storage":"cluster-test","numOfShard":12,`
+	databaseCfg += `"replicaFactor":2,"option":{"intervals":[{"interval":"5m","retention":"3M"},{"interval":"10s","retention":"1M"}]}}`
+	liveNodes := map[models.NodeID]models.StatefulNode{1: {}, 2: {}, 3: {}}
+
+	cases := []struct {
+		name      string
+		statement *stmt.AlterDatabase
+		prepare   func()
+		assert    func(database *models.Database)
+		wantErr   bool
+	}{
+		{
+			name:      "database not found",
+			statement: &stmt.AlterDatabase{Name: "test", TTL: "30d"},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, state.ErrNotExist)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "get database failure",
+			statement: &stmt.AlterDatabase{Name: "test", TTL: "30d"},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "unmarshal database failure",
+			statement: &stmt.AlterDatabase{Name: "test", TTL: "30d"},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte("err"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "reduce replica factor",
+			statement: &stmt.AlterDatabase{Name: "test", ReplicaFactor: 1},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(databaseCfg), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "storage not found",
+			statement: &stmt.AlterDatabase{Name: "test", ReplicaFactor: 3},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(databaseCfg), nil)
+				stateMgr.EXPECT().GetStorage("cluster-test").Return(nil, false)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "replica factor greater than live nodes",
+			statement: &stmt.AlterDatabase{Name: "test", ReplicaFactor: 4},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(databaseCfg), nil)
+				stateMgr.EXPECT().GetStorage("cluster-test").Return(&models.StorageState{LiveNodes: liveNodes}, true)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "invalid ttl",
+			statement: &stmt.AlterDatabase{Name: "test", TTL: "abc"},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(databaseCfg), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "ttl less than write interval",
+			statement: &stmt.AlterDatabase{Name: "test", TTL: "5s"},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(databaseCfg), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "intervals not found",
+			statement: &stmt.AlterDatabase{Name: "test", TTL: "30d"},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(`{"name":"test"}`), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "persist failure",
+			statement: &stmt.AlterDatabase{Name: "test", TTL: "30d"},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(databaseCfg), nil)
+				repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "alter ttl and replica factor successfully",
+			statement: &stmt.AlterDatabase{Name: "test", TTL: "7d", ReplicaFactor: 3},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(databaseCfg), nil)
+				stateMgr.EXPECT().GetStorage("cluster-test").Return(&models.StorageState{LiveNodes: liveNodes}, true)
+			},
+			assert: func(database *models.Database) {
+				assert.Equal(t, 3, database.ReplicaFactor)
+				assert.Equal(t, "10s", database.Option.Intervals[0].Interval.String())
+				assert.Equal(t, "7d", database.Option.Intervals[0].Retention.String())
+				assert.Equal(t, "3M", database.Option.Intervals[1].Retention.String())
+			},
+		},
+		{
+			name:      "keep same replica factor",
+			statement: &stmt.AlterDatabase{Name: "test", ReplicaFactor: 2},
+			prepare: func() {
+				repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte(databaseCfg), nil)
+			},
+			assert: func(database *models.Database) {
+				assert.Equal(t, 2, database.ReplicaFactor)
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			if tt.assert != nil {
+				repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, data []byte) error {
+						database := &models.Database{}
+						assert.NoError(t, encoding.JSONUnmarshal(data, database))
+						tt.assert(database)
+						return nil
+					})
+			}
+			rs, err := AlterDatabaseCommand(context.TODO(), deps, nil, tt.statement)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AlterDatabaseCommand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, "Alter database[test] ok", *(rs.(*string)))
+			}
+		})
+	}
+}
@@ -19,10 +19,12 @@ package command
 
 import (
 	"context"
+	"fmt"
 
 	depspkg "github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/query"
+	queryctx "github.com/lindb/lindb/query/context"
 	stmtpkg "github.com/lindb/lindb/sql/stmt"
 )
 
@@ -31,18 +33,73 @@ var (
 	metricDataSearchFn = query.MetricDataSearch
 )
 
-// QueryCommand executes metric query.
+// QueryCommand executes metric query, identical query is served from result cache if cached.
 func QueryCommand(ctx context.Context, deps *depspkg.HTTPDeps,
 	param *models.ExecuteParam, stmt stmtpkg.Statement) (interface{}, error) {
-	return metricDataSearchFn(
-		ctx,
-		param,
-		stmt.(*stmtpkg.Query),
-		&query.SearchMgr{
-			Timeout:      deps.BrokerCfg.Query.Timeout.Duration(),
-			CurNode:      *deps.Node,
-			Choose:       deps.StateMgr,
-			TaskMgr:      deps.TaskMgr,
-			TransportMgr: deps.TransportMgr,
-		})
+	queryStmt := stmt.(*stmtpkg.Query)
+	cache := deps.QueryResultCache
+	if queryStmt.Explain {
+		// explain returns execute stats of current query, cannot be cached.
+		cache = nil
+	}
+	var cacheKey string
+	if cache != nil {
+		// build cache key before query, because statement may be rewritten when executing
+		cacheKey = query.ResultCacheKey(param.Database, queryStmt, deps.BrokerCfg.Query.ResultCacheTTL.Duration())
+		if rs, ok := cache.Get(cacheKey); ok {
+			return rs, nil
+		}
+	}
+	result, err := metricDataSearchFn(ctx, param, queryStmt, newSearchMgr(deps))
+	if err != nil {
+		return result, err
+	}
+	if rs, ok := result.(*models.ResultSet); ok && cache != nil {
+		cache.Put(cacheKey, rs)
+	}
+	return result, nil
+}
+
+// QueryStreamCommand executes metric query, streams series of result set to series stream once made,
+// returns the result set without series, streamed query result is not cached.
+func QueryStreamCommand(ctx context.Context, deps *depspkg.HTTPDeps,
+	param *models.ExecuteParam, stmt stmtpkg.Statement, stream queryctx.SeriesStream,
+) (*models.ResultSet, error) {
+	queryStmt := stmt.(*stmtpkg.Query)
+	mgr := newSearchMgr(deps)
+	mgr.SeriesStream = stream
+	result, err := metricDataSearchFn(ctx, param, queryStmt, mgr)
+	if err != nil {
+		return nil, err
+	}
+	rs, ok := result.(*models.ResultSet)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result set of stream query: %T", result)
+	}
+	if rs.ResultSet != nil {
+		// series not streamed when making result set(e.g. sub query), stream series of final result set
+		for _, series := range rs.Series {
+			if err := stream(series); err != nil {
+				return nil, err
+			}
+		}
+		rs.Series = nil
+	}
+	return rs, nil
+}
+
+// newSearchMgr creates the dependencies for metric query.
+func newSearchMgr(deps *depspkg.HTTPDeps) *query.SearchMgr {
+	// not found policy is validated when runtime starting
+	notFoundPolicy, _ := models.ParseNotFoundPolicy(deps.BrokerCfg.Query.NotFoundPolicy)
+	return &query.SearchMgr{
+		Timeout:        deps.BrokerCfg.Query.Timeout.Duration(),
+		CurNode:        *deps.Node,
+		Choose:         deps.StateMgr,
+		TaskMgr:        deps.TaskMgr,
+		TransportMgr:   deps.TransportMgr,
+		MaxFanOut:      deps.BrokerCfg.Query.MaxFanOut,
+		MaxGroupByTags: deps.BrokerCfg.Query.MaxGroupByTags,
+		NotFoundPolicy: notFoundPolicy,
+	}
 }
@@ -19,14 +19,19 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	commonmodels "github.com/lindb/common/models"
+
 	depspkg "github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/query"
+	sqlpkg "github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
@@ -48,3 +53,122 @@ func TestQueryCommand(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, rs)
 }
+
+func TestQueryCommand_ResultCache(t *testing.T) {
+	defer func() {
+		metricDataSearchFn = query.MetricDataSearch
+	}()
+	searches := 0
+	metricDataSearchFn = func(_ context.Context, _ *models.ExecuteParam, _ *stmt.Query, _ *query.SearchMgr) (any, error) {
+		searches++
+		return &models.ResultSet{}, nil
+	}
+	deps := &depspkg.HTTPDeps{
+		Node: &models.StatelessNode{},
+		BrokerCfg: &config.Broker{
+			Query: *config.NewDefaultQuery(),
+		},
+		QueryResultCache: query.NewResultCache(16, time.Minute),
+	}
+	param := &models.ExecuteParam{Database: "db"}
+	execute := func(sql string) any {
+		q, err := sqlpkg.Parse(sql)
+		assert.NoError(t, err)
+		rs, err := QueryCommand(context.Background(), deps, param, q)
+		assert.NoError(t, err)
+		return rs
+	}
+
+	rs := execute("select f from cpu where host='a' and time>now()-1h group by time(1h)")
+	assert.Equal(t, 1, searches)
+	// identical query(whitespace, equivalent time expression) served from cache
+	assert.Same(t, rs, execute("select  f  from cpu\nwhere host='a' and time>now()-60m group by time(1h)"))
+	assert.Equal(t, 1, searches)
+	// changed filter misses cache
+	execute("select f from cpu where host='b' and time>now()-1h group by time(1h)")
+	assert.Equal(t, 2, searches)
+	// other database misses cache
+	param.Database = "db2"
+	execute("select f from cpu where host='a' and time>now()-1h group by time(1h)")
+	assert.Equal(t, 3, searches)
+	// explain not cached
+	execute("explain select f from cpu where host='a' and time>now()-1h group by time(1h)")
+	execute("explain select f from cpu where host='a' and time>now()-1h group by time(1h)")
+	assert.Equal(t, 5, searches)
+
+	// failure/partial result not cached
+	param.Database = "db3"
+	metricDataSearchFn = func(_ context.Context, _ *models.ExecuteParam, _ *stmt.Query, _ *query.SearchMgr) (any, error) {
+		searches++
+		return nil, fmt.Errorf("err")
+	}
+	q, _ := sqlpkg.Parse("select f from cpu")
+	_, err := QueryCommand(context.Background(), deps, param, q)
+	assert.Error(t, err)
+	metricDataSearchFn = func(_ context.Context, _ *models.ExecuteParam, _ *stmt.Query, _ *query.SearchMgr) (any, error) {
+		searches++
+		return &models.ResultSet{Coverage: &models.ShardCoverage{Failed: []models.ShardID{1}}}, nil
+	}
+	execute("select f from cpu")
+	execute("select f from cpu")
+	assert.Equal(t, 8, searches)
+}
+
+func TestQueryStreamCommand(t *testing.T) {
+	defer func() {
+		metricDataSearchFn = query.MetricDataSearch
+	}()
+	deps := &depspkg.HTTPDeps{
+		Node: &models.StatelessNode{},
+		BrokerCfg: &config.Broker{
+			Query: *config.NewDefaultQuery(),
+		},
+	}
+	var streamed []*commonmodels.Series
+	stream := func(series *commonmodels.Series) error {
+		streamed = append(streamed, series)
+		return nil
+	}
+
+	// search failure
+	metricDataSearchFn = func(_ context.Context, _ *models.ExecuteParam, _ *stmt.Query, _ *query.SearchMgr) (any, error) {
+		return nil, fmt.Errorf("err")
+	}
+	rs, err := QueryStreamCommand(context.Background(), deps, nil, &stmt.Query{}, stream)
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+	// unexpected result set
+	metricDataSearchFn = func(_ context.Context, _ *models.ExecuteParam, _ *stmt.Query, _ *query.SearchMgr) (any, error) {
+		return map[string]any{}, nil
+	}
+	rs, err = QueryStreamCommand(context.Background(), deps, nil, &stmt.Query{}, stream)
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+	// series streamed when making result set
+	metricDataSearchFn = func(_ context.Context, _ *models.ExecuteParam, _ *stmt.Query, mgr *query.SearchMgr) (any, error) {
+		assert.NoError(t, mgr.SeriesStream(commonmodels.NewSeries(nil, "a")))
+		return &models.ResultSet{ResultSet: commonmodels.NewResultSet()}, nil
+	}
+	rs, err = QueryStreamCommand(context.Background(), deps, nil, &stmt.Query{}, stream)
+	assert.NoError(t, err)
+	assert.NotNil(t, rs)
+	assert.Len(t, streamed, 1)
+	// series not streamed(e.g. sub query), stream series of final result set
+	streamed = nil
+	metricDataSearchFn = func(_ context.Context, _ *models.ExecuteParam, _ *stmt.Query, _ *query.SearchMgr) (any, error) {
+		resultSet := commonmodels.NewResultSet()
+		resultSet.AddSeries(commonmodels.NewSeries(nil, "a"))
+		resultSet.AddSeries(commonmodels.NewSeries(nil, "b"))
+		return &models.ResultSet{ResultSet: resultSet}, nil
+	}
+	rs, err = QueryStreamCommand(context.Background(), deps, nil, &stmt.Query{}, stream)
+	assert.NoError(t, err)
+	assert.Empty(t, rs.Series)
+	assert.Len(t, streamed, 2)
+	// stream failure
+	rs, err = QueryStreamCommand(context.Background(), deps, nil, &stmt.Query{}, func(_ *commonmodels.Series) error {
+		return fmt.Errorf("err")
+	})
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+}
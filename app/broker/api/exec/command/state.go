@@ -22,8 +22,6 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/go-resty/resty/v2"
-
 	"github.com/lindb/common/pkg/logger"
 
 	depspkg "github.com/lindb/lindb/app/broker/deps"
@@ -33,6 +31,9 @@ import (
 	stmtpkg "github.com/lindb/lindb/sql/stmt"
 )
 
+// unknownState represents the state of node which not answered.
+const unknownState = "unknown"
+
 var (
 	metricCli = client.NewMetricCli()
 )
@@ -53,6 +54,30 @@ func StateCommand(_ context.Context, deps *depspkg.HTTPDeps,
 			var state []models.FamilyLogReplicaState
 			return &state
 		})
+	case stmtpkg.StorageReplicaLag:
+		rs, err := getStateFromStorage(deps, stateStmt, "/state/replica/lag", func() interface{} {
+			var state []models.ReplicaLag
+			return &state
+		})
+		if err != nil {
+			return nil, err
+		}
+		return markUnknownState(rs), nil
+	case stmtpkg.StorageWriteStats:
+		// write statistics are tracked in the write path of each broker
+		liveNodes := deps.StateMgr.GetLiveNodes()
+		var nodes []models.Node
+		for idx := range liveNodes {
+			nodes = append(nodes, &liveNodes[idx])
+		}
+		rs, err := fetchStateData(deps, nodes, stateStmt, "/state/write/stats", func() interface{} {
+			var state []models.MetricWriteStats
+			return &state
+		})
+		if err != nil {
+			return nil, err
+		}
+		return markUnknownState(rs), nil
 	case stmtpkg.MemoryDatabase:
 		return getStateFromStorage(deps, stateStmt, "/state/tsdb/memory", func() interface{} {
 			var state []models.DataFamilyState
@@ -94,13 +119,28 @@ func getStateFromStorage(deps *depspkg.HTTPDeps, stmt *stmtpkg.State, path strin
 			n := liveNodes[id]
 			nodes = append(nodes, &n)
 		}
-		return fetchStateData(nodes, stmt, path, newStateFn)
+		return fetchStateData(deps, nodes, stmt, path, newStateFn)
 	}
 	return nil, nil
 }
 
-// fetchStateData fetches the state metric from each live node.
-func fetchStateData(nodes []models.Node, stmt *stmtpkg.State, path string, newStateFn func() interface{}) (interface{}, error) {
+// markUnknownState marks the state of node which not answered as unknown,
+// so that can tell the difference between empty state and unreachable node.
+func markUnknownState(rs interface{}) interface{} {
+	if states, ok := rs.(map[string]interface{}); ok {
+		for node, state := range states {
+			if state == nil {
+				states[node] = unknownState
+			}
+		}
+	}
+	return rs
+}
+
+// fetchStateData fetches the state metric from each live node, requests are sent by shared client of deps,
+// so that sequential fan-outs reuse the connections of nodes.
+func fetchStateData(deps *depspkg.HTTPDeps, nodes []models.Node, stmt *stmtpkg.State,
+	path string, newStateFn func() interface{}) (interface{}, error) {
 	size := len(nodes)
 	if size == 0 {
 		return nil, nil
@@ -115,12 +155,13 @@ func fetchStateData(nodes []models.Node, stmt *stmtpkg.State, path string, newSt
 			node := nodes[i]
 			address := node.HTTPAddress()
 			state := newStateFn()
-			_, err := resty.New().R().SetQueryParams(map[string]string{"db": stmt.Database}).
+			resp, err := client.NewGzipRequestFrom(deps.StateCli).SetQueryParams(map[string]string{"db": stmt.Database}).
 				SetHeader("Accept", "application/json").
 				SetResult(&state).
 				Get(address + constants.APIVersion1CliPath + path)
 			if err != nil {
-				log.Error("get state from storage node", logger.String("url", address), logger.Error(err))
+				log.Error("get state from storage node", logger.String("url", address),
+					logger.String("encoding", client.ResponseEncoding(resp)), logger.Error(err))
 				return
 			}
 			result[i] = state
@@ -19,18 +19,22 @@ package command
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
 	depspkg "github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/coordinator"
 	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/internal/client"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -235,3 +239,124 @@ func TestState(t *testing.T) {
 		})
 	}
 }
+
+func TestState_StorageReplicaLag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateMgr := broker.NewMockStateManager(ctrl)
+	deps := &depspkg.HTTPDeps{
+		StateMgr: stateMgr,
+	}
+	statement := &stmt.State{Type: stmt.StorageReplicaLag, StorageName: "a", Database: "b"}
+
+	// storage not found
+	stateMgr.EXPECT().GetStorage(gomock.Any()).Return(nil, false)
+	rs, err := StateCommand(context.TODO(), deps, nil, statement)
+	assert.NoError(t, err)
+	assert.Nil(t, rs)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("content-type", "application/json")
+		_, _ = w.Write([]byte(`[{"shardId":1,"replicator":"2","append":100,"ack":90,"lag":10}]`))
+	}))
+	defer svr.Close()
+	u, err := url.Parse(svr.URL)
+	assert.NoError(t, err)
+	p, err := strconv.Atoi(u.Port())
+	assert.NoError(t, err)
+	stateMgr.EXPECT().GetStorage(gomock.Any()).Return(&models.StorageState{
+		LiveNodes: map[models.NodeID]models.StatefulNode{
+			1: {StatelessNode: models.StatelessNode{HostIP: u.Hostname(), HTTPPort: uint16(p)}, ID: 1},
+			2: {StatelessNode: models.StatelessNode{HostIP: "127.0.01", HTTPPort: 8080}, ID: 2}, // mock host err
+		}}, true)
+	rs, err = StateCommand(context.TODO(), deps, nil, statement)
+	assert.NoError(t, err)
+	states := rs.(map[string]interface{})
+	assert.Len(t, states, 2)
+	assert.Equal(t, unknownState, states["127.0.01:8080"])
+	assert.NotEqual(t, unknownState, states[u.Host])
+}
+
+func TestState_StorageWriteStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateMgr := broker.NewMockStateManager(ctrl)
+	deps := &depspkg.HTTPDeps{
+		StateMgr: stateMgr,
+	}
+	statement := &stmt.State{Type: stmt.StorageWriteStats, Database: "b"}
+
+	// no live broker
+	stateMgr.EXPECT().GetLiveNodes().Return(nil)
+	rs, err := StateCommand(context.TODO(), deps, nil, statement)
+	assert.NoError(t, err)
+	assert.Nil(t, rs)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "b", r.URL.Query().Get("db"))
+		w.Header().Add("content-type", "application/json")
+		_, _ = w.Write([]byte(`[{"metricName":"cpu","acceptedRows":10,"acceptedBytes":100,"droppedRows":1}]`))
+	}))
+	defer svr.Close()
+	u, err := url.Parse(svr.URL)
+	assert.NoError(t, err)
+	p, err := strconv.Atoi(u.Port())
+	assert.NoError(t, err)
+	stateMgr.EXPECT().GetLiveNodes().Return([]models.StatelessNode{
+		{HostIP: u.Hostname(), HTTPPort: uint16(p)},
+		{HostIP: "127.0.01", HTTPPort: 8080}, // mock host err
+	})
+	rs, err = StateCommand(context.TODO(), deps, nil, statement)
+	assert.NoError(t, err)
+	states := rs.(map[string]interface{})
+	assert.Len(t, states, 2)
+	assert.Equal(t, unknownState, states["127.0.01:8080"])
+	stats := states[u.Host].(*[]models.MetricWriteStats)
+	assert.Equal(t, []models.MetricWriteStats{{MetricName: "cpu", AcceptedRows: 10, AcceptedBytes: 100, DroppedRows: 1}}, *stats)
+}
+
+func TestState_SharedClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateMgr := broker.NewMockStateManager(ctrl)
+	deps := &depspkg.HTTPDeps{
+		StateMgr: stateMgr,
+		StateCli: client.NewSharedClient(time.Second),
+	}
+	statement := &stmt.State{Type: stmt.StorageWriteStats, Database: "b"}
+
+	newConns := atomic.NewInt32(0)
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("content-type", "application/json")
+		_, _ = w.Write([]byte(`[{"metricName":"cpu","acceptedRows":10}]`))
+	}))
+	svr.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			newConns.Inc()
+		}
+	}
+	svr.Start()
+	defer svr.Close()
+	u, err := url.Parse(svr.URL)
+	assert.NoError(t, err)
+	p, err := strconv.Atoi(u.Port())
+	assert.NoError(t, err)
+	stateMgr.EXPECT().GetLiveNodes().Return([]models.StatelessNode{
+		{HostIP: u.Hostname(), HTTPPort: uint16(p)},
+		{HostIP: "127.0.0.1", HTTPPort: 1}, // connection refused
+	}).Times(3)
+
+	// sequential fan-outs reuse the connection, failure node doesn't poison the shared client
+	for i := 0; i < 3; i++ {
+		rs, err := StateCommand(context.TODO(), deps, nil, statement)
+		assert.NoError(t, err)
+		states := rs.(map[string]interface{})
+		assert.Equal(t, unknownState, states["127.0.0.1:1"])
+		stats := states[u.Host].(*[]models.MetricWriteStats)
+		assert.Equal(t, []models.MetricWriteStats{{MetricName: "cpu", AcceptedRows: 10}}, *stats)
+	}
+	assert.Equal(t, int32(1), newConns.Load())
+}
@@ -0,0 +1,77 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/lindb/common/pkg/timeutil"
+	protoMetricsV1 "github.com/lindb/common/proto/gen/v1/linmetrics"
+
+	depspkg "github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/metric"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+// WriteCommand executes insert statement, writes one data point into database's write channel for quick testing,
+// fields are written as gauge(last value).
+func WriteCommand(ctx context.Context, deps *depspkg.HTTPDeps,
+	param *models.ExecuteParam, stmt stmtpkg.Statement) (interface{}, error) {
+	if param == nil || param.Database == "" {
+		return nil, constants.ErrDatabaseNameRequired
+	}
+	writeStmt := stmt.(*stmtpkg.Write)
+	m := &protoMetricsV1.Metric{
+		Name:      writeStmt.MetricName,
+		Timestamp: writeStmt.Timestamp,
+	}
+	if m.Timestamp <= 0 {
+		m.Timestamp = timeutil.Now()
+	}
+	tagKeys := make([]string, 0, len(writeStmt.Tags))
+	for key := range writeStmt.Tags {
+		tagKeys = append(tagKeys, key)
+	}
+	sort.Strings(tagKeys)
+	for _, key := range tagKeys {
+		m.Tags = append(m.Tags, &protoMetricsV1.KeyValue{Key: key, Value: writeStmt.Tags[key]})
+	}
+	for _, field := range writeStmt.Fields {
+		m.SimpleFields = append(m.SimpleFields, &protoMetricsV1.SimpleField{
+			Name:  field.Name,
+			Type:  protoMetricsV1.SimpleFieldType_LAST,
+			Value: field.Value,
+		})
+	}
+	converter := metric.NewProtoConverter(deps.StateMgr.GetDatabaseLimits(param.Database))
+	rows := metric.NewBrokerBatchRows()
+	if err := rows.TryAppend(func(row *metric.BrokerRow) error {
+		return converter.ConvertTo(m, row)
+	}); err != nil {
+		return nil, err
+	}
+	if err := deps.CM.Write(ctx, param.Database, rows); err != nil {
+		return nil, err
+	}
+	rs := fmt.Sprintf("Write metric[%s] into database[%s] ok", writeStmt.MetricName, param.Database)
+	return &rs, nil
+}
@@ -0,0 +1,145 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	flatMetricsV1 "github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+
+	depspkg "github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/replica"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateMgr := broker.NewMockStateManager(ctrl)
+	cm := replica.NewMockChannelManager(ctrl)
+	deps := &depspkg.HTTPDeps{
+		StateMgr: stateMgr,
+		CM:       cm,
+	}
+	stateMgr.EXPECT().GetDatabaseLimits(gomock.Any()).Return(models.NewDefaultLimits()).AnyTimes()
+	writeStmt := &stmt.Write{
+		MetricName: "cpu",
+		Tags:       map[string]string{"region": "sh", "host": "a"},
+		Fields:     []stmt.WriteField{{Name: "usage", Value: 0.5}, {Name: "load", Value: 2}},
+		Timestamp:  1690000000000,
+	}
+
+	cases := []struct {
+		name      string
+		param     *models.ExecuteParam
+		statement *stmt.Write
+		prepare   func()
+		wantErr   bool
+	}{
+		{
+			name:      "database name required",
+			param:     &models.ExecuteParam{},
+			statement: writeStmt,
+			wantErr:   true,
+		},
+		{
+			name:      "convert row failure",
+			param:     &models.ExecuteParam{Database: "test"},
+			statement: &stmt.Write{MetricName: "cpu"},
+			wantErr:   true,
+		},
+		{
+			name:      "write failure",
+			param:     &models.ExecuteParam{Database: "test"},
+			statement: writeStmt,
+			prepare: func() {
+				cm.EXPECT().Write(gomock.Any(), "test", gomock.Any()).Return(fmt.Errorf("err"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "write successfully",
+			param:     &models.ExecuteParam{Database: "test"},
+			statement: writeStmt,
+			prepare: func() {
+				cm.EXPECT().Write(gomock.Any(), "test", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, rows *metric.BrokerBatchRows) error {
+						assert.Equal(t, 1, rows.Len())
+						m := rows.Rows()[0].Metric()
+						assert.Equal(t, "cpu", string(m.Name()))
+						assert.Equal(t, int64(1690000000000), m.Timestamp())
+						tags := make(map[string]string)
+						var kv flatMetricsV1.KeyValue
+						for i := 0; i < m.KeyValuesLength(); i++ {
+							m.KeyValues(&kv, i)
+							tags[string(kv.Key())] = string(kv.Value())
+						}
+						assert.Equal(t, writeStmt.Tags, tags)
+						var f flatMetricsV1.SimpleField
+						assert.Equal(t, 2, m.SimpleFieldsLength())
+						for i, field := range writeStmt.Fields {
+							m.SimpleFields(&f, i)
+							assert.Equal(t, field.Name, string(f.Name()))
+							assert.Equal(t, field.Value, f.Value())
+							assert.Equal(t, flatMetricsV1.SimpleFieldTypeLast, f.Type())
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name:      "write with timestamp of now",
+			param:     &models.ExecuteParam{Database: "test"},
+			statement: &stmt.Write{MetricName: "cpu", Fields: []stmt.WriteField{{Name: "usage", Value: 1}}},
+			prepare: func() {
+				cm.EXPECT().Write(gomock.Any(), "test", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, rows *metric.BrokerBatchRows) error {
+						m := rows.Rows()[0].Metric()
+						assert.Greater(t, m.Timestamp(), int64(0))
+						return nil
+					})
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			rs, err := WriteCommand(context.TODO(), deps, tt.param, tt.statement)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WriteCommand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, "Write metric[cpu] into database[test] ok", *(rs.(*string)))
+			}
+		})
+	}
+}
@@ -20,10 +20,13 @@ package exec
 import (
 	"context"
 	"errors"
+	"net/http"
 	"reflect"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	commonmodels "github.com/lindb/common/models"
 	httppkg "github.com/lindb/common/pkg/http"
 	"github.com/lindb/common/pkg/logger"
 
@@ -31,13 +34,15 @@ import (
 	depspkg "github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
+	lindbhttp "github.com/lindb/lindb/pkg/http"
 	sqlpkg "github.com/lindb/lindb/sql"
 	stmtpkg "github.com/lindb/lindb/sql/stmt"
 )
 
 // for testing
 var (
-	sqlParseFn = sqlpkg.Parse
+	sqlParseFn    = sqlpkg.Parse
+	queryStreamFn = command.QueryStreamCommand
 )
 
 // statementExecFn represents statement execution funcation define.
@@ -60,6 +65,8 @@ var (
 		stmtpkg.QueryStatement:          command.QueryCommand,
 		stmtpkg.RequestStatement:        command.RequestCommand,
 		stmtpkg.LimitStatement:          command.LimitCommand,
+		stmtpkg.AlterDatabaseStatement:  command.AlterDatabaseCommand,
+		stmtpkg.WriteStatement:          command.WriteCommand,
 	}
 )
 
@@ -84,6 +91,7 @@ func (e *ExecuteAPI) Register(route gin.IRoutes) {
 	route.GET(ExecutePath, e.Execute)
 	route.POST(ExecutePath, e.Execute)
 	route.PUT(ExecutePath, e.Execute)
+	route.POST(ValidatePath, e.Validate)
 }
 
 // Execute executes lin query language with rate limit.
@@ -100,11 +108,14 @@ func (e *ExecuteAPI) Register(route gin.IRoutes) {
 // @Accept json
 // @Param param body models.ExecuteParam ture "param data"
 // @Produce json
+// @Produce application/x-lindb-columnar
+// @Produce application/x-ndjson
 // @Success 200 {object} models.ResultSet
 // @Success 200 {object} models.Metadata
-// @Failure 404 {string} string "not found"
-// @Failure 500 {string} string "can't parse lin query language"
-// @Failure 500 {string} string "internal error"
+// @Failure 404 {object} models.Error "not found"
+// @Failure 500 {object} models.Error "internal error"
+// @Failure 502 {object} models.Error "some of target nodes failed"
+// @Failure 504 {object} models.Error "query timeout"
 // @Router /exec [get]
 // @Router /exec [put]
 // @Router /exec [post]
@@ -112,7 +123,7 @@ func (e *ExecuteAPI) Execute(c *gin.Context) {
 	if err := e.deps.QueryLimiter.Do(func() error {
 		return e.execute(c)
 	}); err != nil {
-		httppkg.Error(c, err)
+		lindbhttp.ErrorWithCode(c, err)
 	}
 }
 
@@ -136,17 +147,109 @@ func (e *ExecuteAPI) execute(c *gin.Context) error {
 		return errors.New("can't parse lin query language")
 	}
 
+	if queryStmt, ok := stmt.(*stmtpkg.Query); ok && !queryStmt.IsMultiMetrics() && accept(c, models.NDJSONContentType) {
+		return e.executeStream(ctx, c, &param, queryStmt)
+	}
+
 	if commandFn, ok := commands[stmt.StatementType()]; ok {
-		result, err := commandFn(ctx, e.deps, &param, stmt)
+		result, err := e.executeCommand(ctx, commandFn, &param, stmt)
 		if err != nil {
 			return err
 		}
 		if result == nil || reflect.ValueOf(result).IsNil() {
 			httppkg.NotFound(c)
-		} else {
-			httppkg.OK(c, result)
+			return nil
 		}
+		if rs, ok := result.(*models.ResultSet); ok && accept(c, models.ColumnarContentType) {
+			c.Data(http.StatusOK, models.ColumnarContentType, rs.MarshalColumnar())
+			return nil
+		}
+		httppkg.OK(c, result)
 		return nil
 	}
 	return errors.New("can't parse lin query language")
 }
+
+// executeCommand executes the command of statement, metric data/metadata query is limited by the concurrency of database,
+// so that heavy queries of one database cannot starve queries of other databases.
+func (e *ExecuteAPI) executeCommand(ctx context.Context, commandFn statementExecFn,
+	param *models.ExecuteParam, stmt stmtpkg.Statement,
+) (result interface{}, err error) {
+	switch stmt.StatementType() {
+	case stmtpkg.QueryStatement, stmtpkg.MetricMetadataStatement:
+		if param.Database == "" {
+			break
+		}
+		err = e.deps.DatabaseQueryLimiter.Do(ctx, param.Database, func() error {
+			result, err = commandFn(ctx, e.deps, param, stmt)
+			return err
+		})
+		return result, err
+	}
+	return commandFn(ctx, e.deps, param, stmt)
+}
+
+// executeStream executes metric query, writes one json object per line to response and flushes incrementally:
+// series line once series made, then result set line(without series) after query completed.
+// If query failed after streaming started, error line is written as last line because http status has been sent.
+func (e *ExecuteAPI) executeStream(ctx context.Context, c *gin.Context,
+	param *models.ExecuteParam, stmt *stmtpkg.Query,
+) error {
+	w := &lineWriter{c: c}
+	var rs *models.ResultSet
+	_, err := e.executeCommand(ctx, func(ctx context.Context, deps *depspkg.HTTPDeps,
+		param *models.ExecuteParam, stmt stmtpkg.Statement,
+	) (result interface{}, err error) {
+		rs, err = queryStreamFn(ctx, deps, param, stmt, func(series *commonmodels.Series) error {
+			return w.write(models.MarshalSeriesLine(series))
+		})
+		return rs, err
+	}, param, stmt)
+	if err != nil {
+		if !w.started {
+			return err
+		}
+		_ = c.Error(err)
+		err = w.write(models.MarshalErrorLine(err))
+	} else {
+		err = w.write(models.MarshalResultSetLine(rs))
+	}
+	if err != nil {
+		// response has been started, cannot return error to client
+		e.logger.Warn("write stream query result failure", logger.String("sql", param.SQL), logger.Error(err))
+	}
+	return nil
+}
+
+// lineWriter writes ndjson line to response, response status/content type is written before first line.
+type lineWriter struct {
+	c       *gin.Context
+	started bool
+}
+
+// write writes ndjson line to response, then flushes it to client.
+func (w *lineWriter) write(line []byte, err error) error {
+	if err != nil {
+		return err
+	}
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", models.NDJSONContentType)
+		w.c.Status(http.StatusOK)
+	}
+	if _, err = w.c.Writer.Write(line); err != nil {
+		return err
+	}
+	w.c.Writer.Flush()
+	return nil
+}
+
+// accept returns if client accepts the media type of result set, json is returned by default.
+func accept(c *gin.Context, mediaType string) bool {
+	for _, accept := range strings.Split(c.GetHeader("Accept"), ",") {
+		if acceptType, _, _ := strings.Cut(strings.TrimSpace(accept), ";"); acceptType == mediaType {
+			return true
+		}
+	}
+	return false
+}
@@ -19,9 +19,13 @@ package exec
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,10 +33,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	commonmodels "github.com/lindb/common/models"
 	"github.com/lindb/common/pkg/ltoml"
 
+	"github.com/lindb/lindb/app/broker/api/exec/command"
 	"github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator"
 	"github.com/lindb/lindb/coordinator/broker"
 	masterpkg "github.com/lindb/lindb/coordinator/master"
@@ -42,6 +49,7 @@ import (
 	"github.com/lindb/lindb/metrics"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/state"
+	queryctx "github.com/lindb/lindb/query/context"
 	"github.com/lindb/lindb/sql"
 	stmtpkg "github.com/lindb/lindb/sql/stmt"
 )
@@ -154,6 +162,18 @@ func TestExecuteAPI_Execute(t *testing.T) {
 			},
 			assert: func(resp *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+				assert.JSONEq(t, `{"code":"Internal","message":"err"}`, resp.Body.String())
+			},
+		},
+		{
+			name:    "get database list timeout",
+			reqBody: `{"sql":"show databases"}`,
+			prepare: func() {
+				repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, constants.ErrTimeout)
+			},
+			assert: func(resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusGatewayTimeout, resp.Code)
+				assert.JSONEq(t, `{"code":"Timeout","message":"exceed timeout"}`, resp.Body.String())
 			},
 		},
 	}
@@ -174,3 +194,253 @@ func TestExecuteAPI_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteAPI_Execute_Columnar(t *testing.T) {
+	queryCommand := commands[stmtpkg.QueryStatement]
+	defer func() {
+		commands[stmtpkg.QueryStatement] = queryCommand
+	}()
+	rs := &models.ResultSet{ResultSet: commonmodels.NewResultSet()}
+	rs.MetricName = "cpu"
+	commands[stmtpkg.QueryStatement] = func(_ context.Context, _ *deps.HTTPDeps,
+		_ *models.ExecuteParam, _ stmtpkg.Statement) (interface{}, error) {
+		return rs, nil
+	}
+	api := NewExecuteAPI(&deps.HTTPDeps{
+		Ctx: context.Background(),
+		BrokerCfg: &config.Broker{BrokerBase: config.BrokerBase{
+			HTTP: config.HTTP{ReadTimeout: ltoml.Duration(time.Second * 10)},
+		}},
+		QueryLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			2,
+			time.Second*5,
+			metrics.NewLimitStatistics("exec_columnar", linmetric.BrokerRegistry),
+		),
+	})
+	r := gin.New()
+	api.Register(r)
+	reqBody := `{"sql":"select f from cpu"}`
+
+	// json by default
+	resp := mock.DoRequest(t, r, http.MethodPut, ExecutePath, reqBody)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `{"metricName":"cpu"}`, resp.Body.String())
+
+	// columnar if accepted
+	resp = mock.DoRequest(t, r, http.MethodPut, ExecutePath, reqBody, http.Header{
+		"Content-Type": []string{"application/json"},
+		"Accept":       []string{"application/json;q=0.9, " + models.ColumnarContentType},
+	})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, models.ColumnarContentType, resp.Header().Get("Content-Type"))
+	decoded := &models.ResultSet{}
+	assert.NoError(t, decoded.UnmarshalColumnar(resp.Body.Bytes()))
+	assert.Equal(t, "cpu", decoded.MetricName)
+}
+
+func TestExecuteAPI_Execute_NDJSON(t *testing.T) {
+	defer func() {
+		queryStreamFn = command.QueryStreamCommand
+	}()
+	api := NewExecuteAPI(&deps.HTTPDeps{
+		Ctx: context.Background(),
+		BrokerCfg: &config.Broker{BrokerBase: config.BrokerBase{
+			HTTP: config.HTTP{ReadTimeout: ltoml.Duration(time.Second * 10)},
+		}},
+		QueryLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			2,
+			time.Second*5,
+			metrics.NewLimitStatistics("exec_ndjson", linmetric.BrokerRegistry),
+		),
+	})
+	r := gin.New()
+	api.Register(r)
+	reqBody := `{"sql":"select f from cpu group by host"}`
+	header := http.Header{
+		"Content-Type": []string{"application/json"},
+		"Accept":       []string{models.NDJSONContentType},
+	}
+	streamSeries := func(stream queryctx.SeriesStream, hosts ...string) {
+		for _, host := range hosts {
+			series := commonmodels.NewSeries(map[string]string{"host": host}, host)
+			points := commonmodels.NewPoints()
+			points.AddPoint(10, 1)
+			series.AddField("f", points)
+			assert.NoError(t, stream(series))
+		}
+	}
+
+	// stream series, then result set without series
+	queryStreamFn = func(_ context.Context, _ *deps.HTTPDeps, _ *models.ExecuteParam,
+		_ stmtpkg.Statement, stream queryctx.SeriesStream) (*models.ResultSet, error) {
+		streamSeries(stream, "a", "b")
+		rs := &models.ResultSet{ResultSet: commonmodels.NewResultSet()}
+		rs.MetricName = "cpu"
+		return rs, nil
+	}
+	resp := mock.DoRequest(t, r, http.MethodPut, ExecutePath, reqBody, header)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, models.NDJSONContentType, resp.Header().Get("Content-Type"))
+	assert.Equal(t, `{"series":{"tags":{"host":"a"},"fields":{"f":{"10":1}}}}
+{"series":{"tags":{"host":"b"},"fields":{"f":{"10":1}}}}
+{"resultSet":{"metricName":"cpu"}}
+`, resp.Body.String())
+
+	// failure after streaming started, signal error in last line
+	queryStreamFn = func(_ context.Context, _ *deps.HTTPDeps, _ *models.ExecuteParam,
+		_ stmtpkg.Statement, stream queryctx.SeriesStream) (*models.ResultSet, error) {
+		streamSeries(stream, "a")
+		return nil, constants.ErrTimeout
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, ExecutePath, reqBody, header)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	lines := strings.Split(strings.TrimSuffix(resp.Body.String(), "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, `{"error":{"code":"Timeout","message":"exceed timeout"}}`, lines[1])
+
+	// failure before streaming started, returns error status
+	queryStreamFn = func(_ context.Context, _ *deps.HTTPDeps, _ *models.ExecuteParam,
+		_ stmtpkg.Statement, _ queryctx.SeriesStream) (*models.ResultSet, error) {
+		return nil, constants.ErrTimeout
+	}
+	resp = mock.DoRequest(t, r, http.MethodPut, ExecutePath, reqBody, header)
+	assert.Equal(t, http.StatusGatewayTimeout, resp.Code)
+}
+
+func TestExecuteAPI_Execute_WriteStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateMgr := broker.NewMockStateManager(ctrl)
+	api := NewExecuteAPI(&deps.HTTPDeps{
+		Ctx:      context.Background(),
+		StateMgr: stateMgr,
+		BrokerCfg: &config.Broker{BrokerBase: config.BrokerBase{
+			HTTP: config.HTTP{ReadTimeout: ltoml.Duration(time.Second * 10)},
+		}},
+		QueryLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			2,
+			time.Second*5,
+			metrics.NewLimitStatistics("exec_write_stats", linmetric.BrokerRegistry),
+		),
+	})
+	r := gin.New()
+	api.Register(r)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "db", r.URL.Query().Get("db"))
+		w.Header().Add("content-type", "application/json")
+		_, _ = w.Write([]byte(`[{"metricName":"cpu","acceptedRows":10,"acceptedBytes":100,"droppedRows":1}]`))
+	}))
+	defer svr.Close()
+	u, err := url.Parse(svr.URL)
+	assert.NoError(t, err)
+	p, err := strconv.Atoi(u.Port())
+	assert.NoError(t, err)
+	stateMgr.EXPECT().GetLiveNodes().Return([]models.StatelessNode{{HostIP: u.Hostname(), HTTPPort: uint16(p)}})
+
+	resp := mock.DoRequest(t, r, http.MethodPut, ExecutePath, `{"sql":"show write stats where database='db'"}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var rs map[string][]models.MetricWriteStats
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &rs))
+	assert.Equal(t, map[string][]models.MetricWriteStats{
+		u.Host: {{MetricName: "cpu", AcceptedRows: 10, AcceptedBytes: 100, DroppedRows: 1}},
+	}, rs)
+}
+
+func TestExecuteAPI_Execute_NonFinite(t *testing.T) {
+	queryCommand := commands[stmtpkg.QueryStatement]
+	defer func() {
+		commands[stmtpkg.QueryStatement] = queryCommand
+	}()
+	zero := 0.0
+	rs := &models.ResultSet{ResultSet: commonmodels.NewResultSet()}
+	series := commonmodels.NewSeries(nil, "")
+	points := commonmodels.NewPoints()
+	points.AddPoint(10, 1)
+	points.AddPoint(20, zero/zero) // ratio of division by zero
+	series.AddField("ratio", points)
+	rs.AddSeries(series)
+	commands[stmtpkg.QueryStatement] = func(_ context.Context, _ *deps.HTTPDeps,
+		_ *models.ExecuteParam, _ stmtpkg.Statement) (interface{}, error) {
+		return rs, nil
+	}
+	api := NewExecuteAPI(&deps.HTTPDeps{
+		Ctx: context.Background(),
+		BrokerCfg: &config.Broker{BrokerBase: config.BrokerBase{
+			HTTP: config.HTTP{ReadTimeout: ltoml.Duration(time.Second * 10)},
+		}},
+		QueryLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			2,
+			time.Second*5,
+			metrics.NewLimitStatistics("exec_non_finite", linmetric.BrokerRegistry),
+		),
+	})
+	r := gin.New()
+	api.Register(r)
+
+	resp := mock.DoRequest(t, r, http.MethodPut, ExecutePath, `{"sql":"select a/b as ratio from cpu"}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.True(t, json.Valid(resp.Body.Bytes()))
+	assert.Equal(t, `{"series":[{"fields":{"ratio":{"10":1,"20":null}}}]}`, resp.Body.String())
+}
+
+func TestExecuteAPI_Execute_DatabaseIsolation(t *testing.T) {
+	queryCommand := commands[stmtpkg.QueryStatement]
+	defer func() {
+		commands[stmtpkg.QueryStatement] = queryCommand
+	}()
+	running := make(chan struct{})
+	release := make(chan struct{})
+	commands[stmtpkg.QueryStatement] = func(_ context.Context, _ *deps.HTTPDeps,
+		param *models.ExecuteParam, _ stmtpkg.Statement) (interface{}, error) {
+		if param.Database == "a" {
+			close(running)
+			<-release
+		}
+		rs := &models.ResultSet{ResultSet: commonmodels.NewResultSet()}
+		rs.MetricName = param.Database
+		return rs, nil
+	}
+	api := NewExecuteAPI(&deps.HTTPDeps{
+		Ctx: context.Background(),
+		BrokerCfg: &config.Broker{BrokerBase: config.BrokerBase{
+			HTTP: config.HTTP{ReadTimeout: ltoml.Duration(time.Millisecond * 100)},
+		}},
+		QueryLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			10,
+			time.Second*5,
+			metrics.NewLimitStatistics("exec_database_isolation", linmetric.BrokerRegistry),
+		),
+		DatabaseQueryLimiter: concurrent.NewKeyedLimiter(1, func(database string) *metrics.KeyedLimitStatistics {
+			return metrics.NewDatabaseQueryLimitStatistics(database, linmetric.BrokerRegistry)
+		}),
+	})
+	r := gin.New()
+	api.Register(r)
+
+	// saturate database a
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- mock.DoRequest(t, r, http.MethodPut, ExecutePath, `{"db":"a","sql":"select f from cpu"}`)
+	}()
+	<-running
+
+	// query of database b still proceeds
+	resp := mock.DoRequest(t, r, http.MethodPut, ExecutePath, `{"db":"b","sql":"select f from cpu"}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `{"metricName":"b"}`, resp.Body.String())
+	// query of database a waits until timeout
+	resp = mock.DoRequest(t, r, http.MethodPut, ExecutePath, `{"db":"a","sql":"select f from cpu"}`)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+
+	close(release)
+	resp = <-done
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `{"metricName":"a"}`, resp.Body.String())
+}
@@ -0,0 +1,111 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exec
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	httppkg "github.com/lindb/common/pkg/http"
+
+	"github.com/lindb/lindb/models"
+	lindbhttp "github.com/lindb/lindb/pkg/http"
+	"github.com/lindb/lindb/query"
+	sqlpkg "github.com/lindb/lindb/sql"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+// for testing
+var (
+	validateQueryFn = query.ValidateQuery
+)
+
+var (
+	// ValidatePath represents the path of query validation(dry run).
+	ValidatePath = "/query/validate"
+)
+
+// Validate validates the query statement without executing it(no data scan) with rate limit.
+//
+// @Summary validate query statement
+// @Description Parse query statement and check if metrics/fields exist against metadata without data scan,
+// @Description returns parsed query plan if valid, else returns syntax/validation errors with position.
+// @Tags LinQL
+// @Accept json
+// @Param param body models.ExecuteParam ture "param data"
+// @Produce json
+// @Success 200 {object} models.QueryValidation
+// @Failure 500 {object} models.Error "internal error"
+// @Failure 504 {object} models.Error "query timeout"
+// @Router /query/validate [post]
+func (e *ExecuteAPI) Validate(c *gin.Context) {
+	if err := e.deps.QueryLimiter.Do(func() error {
+		return e.validate(c)
+	}); err != nil {
+		lindbhttp.ErrorWithCode(c, err)
+	}
+}
+
+// validate parses query statement, then validates it against metadata.
+func (e *ExecuteAPI) validate(c *gin.Context) error {
+	ctx, cancel := e.deps.WithTimeout()
+	defer cancel()
+
+	param := models.ExecuteParam{}
+	if err := c.ShouldBind(&param); err != nil {
+		return err
+	}
+	rs := &models.QueryValidation{}
+	stmt, err := sqlParseFn(param.SQL)
+	if err != nil {
+		var syntaxErr *sqlpkg.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			rs.AddError(&models.QueryValidationError{
+				Code:    models.QuerySyntaxError,
+				Message: syntaxErr.Msg,
+				Line:    syntaxErr.Line,
+				Column:  syntaxErr.Column + 1,
+			})
+		} else {
+			rs.AddError(&models.QueryValidationError{Code: models.QueryInvalid, Message: err.Error()})
+		}
+		httppkg.OK(c, rs)
+		return nil
+	}
+	queryStmt, ok := stmt.(*stmtpkg.Query)
+	if !ok {
+		rs.AddError(&models.QueryValidationError{Code: models.QueryInvalid, Message: "only supports select statement"})
+		httppkg.OK(c, rs)
+		return nil
+	}
+	rs, err = validateQueryFn(ctx, &param, queryStmt, &query.SearchMgr{
+		Timeout:        e.deps.BrokerCfg.Query.Timeout.Duration(),
+		CurNode:        *e.deps.Node,
+		Choose:         e.deps.StateMgr,
+		TaskMgr:        e.deps.TaskMgr,
+		TransportMgr:   e.deps.TransportMgr,
+		MaxFanOut:      e.deps.BrokerCfg.Query.MaxFanOut,
+		MaxGroupByTags: e.deps.BrokerCfg.Query.MaxGroupByTags,
+	})
+	if err != nil {
+		return err
+	}
+	httppkg.OK(c, rs)
+	return nil
+}
@@ -0,0 +1,186 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/pkg/ltoml"
+
+	"github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/internal/concurrent"
+	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/metrics"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/query"
+	"github.com/lindb/lindb/sql"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+func TestExecuteAPI_Validate(t *testing.T) {
+	api := NewExecuteAPI(&deps.HTTPDeps{
+		Ctx:  context.Background(),
+		Node: &models.StatelessNode{HostIP: "127.0.0.1", GRPCPort: 9000},
+		BrokerCfg: &config.Broker{BrokerBase: config.BrokerBase{
+			HTTP: config.HTTP{ReadTimeout: ltoml.Duration(time.Second * 10)},
+		}},
+		QueryLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			2,
+			time.Second*5,
+			metrics.NewLimitStatistics("validate", linmetric.BrokerRegistry),
+		),
+	})
+	r := gin.New()
+	api.Register(r)
+
+	validation := func(resp *httptest.ResponseRecorder) *models.QueryValidation {
+		assert.Equal(t, http.StatusOK, resp.Code)
+		rs := &models.QueryValidation{}
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), rs))
+		return rs
+	}
+	cases := []struct {
+		name    string
+		reqBody string
+		prepare func()
+		assert  func(resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "param invalid",
+			assert: func(resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+		{
+			name:    "syntax error",
+			reqBody: `{"sql":"select f from cpu\nwhere"}`,
+			assert: func(resp *httptest.ResponseRecorder) {
+				rs := validation(resp)
+				assert.False(t, rs.Valid)
+				assert.Nil(t, rs.Plan)
+				assert.Len(t, rs.Errors, 1)
+				assert.Equal(t, models.QuerySyntaxError, rs.Errors[0].Code)
+				assert.NotEmpty(t, rs.Errors[0].Message)
+				assert.Equal(t, 2, rs.Errors[0].Line)
+				assert.Equal(t, len("where")+1, rs.Errors[0].Column)
+			},
+		},
+		{
+			name:    "invalid query",
+			reqBody: `{"sql":"select f from cpu"}`,
+			prepare: func() {
+				sqlParseFn = func(_ string) (stmtpkg.Statement, error) {
+					return nil, fmt.Errorf("err")
+				}
+			},
+			assert: func(resp *httptest.ResponseRecorder) {
+				rs := validation(resp)
+				assert.False(t, rs.Valid)
+				assert.Equal(t, []*models.QueryValidationError{{Code: models.QueryInvalid, Message: "err"}}, rs.Errors)
+			},
+		},
+		{
+			name:    "not select statement",
+			reqBody: `{"sql":"show databases"}`,
+			assert: func(resp *httptest.ResponseRecorder) {
+				rs := validation(resp)
+				assert.False(t, rs.Valid)
+				assert.Len(t, rs.Errors, 1)
+				assert.Equal(t, models.QueryInvalid, rs.Errors[0].Code)
+			},
+		},
+		{
+			name:    "unknown metric",
+			reqBody: `{"sql":"select f from cpu","db":"test"}`,
+			prepare: func() {
+				validateQueryFn = func(_ context.Context, _ *models.ExecuteParam,
+					_ *stmtpkg.Query, _ *query.SearchMgr) (*models.QueryValidation, error) {
+					rs := &models.QueryValidation{}
+					rs.AddError(&models.QueryValidationError{Code: models.QueryUnknownMetric, Message: "not found", Metric: "cpu"})
+					return rs, nil
+				}
+			},
+			assert: func(resp *httptest.ResponseRecorder) {
+				rs := validation(resp)
+				assert.False(t, rs.Valid)
+				assert.Equal(t, []*models.QueryValidationError{
+					{Code: models.QueryUnknownMetric, Message: "not found", Metric: "cpu"},
+				}, rs.Errors)
+			},
+		},
+		{
+			name:    "valid query",
+			reqBody: `{"sql":"select f from cpu","db":"test"}`,
+			prepare: func() {
+				validateQueryFn = func(_ context.Context, param *models.ExecuteParam,
+					q *stmtpkg.Query, _ *query.SearchMgr) (*models.QueryValidation, error) {
+					assert.Equal(t, "test", param.Database)
+					return &models.QueryValidation{Valid: true, Plan: q}, nil
+				}
+			},
+			assert: func(resp *httptest.ResponseRecorder) {
+				rs := validation(resp)
+				assert.True(t, rs.Valid)
+				assert.Empty(t, rs.Errors)
+				assert.Equal(t, "cpu", rs.Plan.MetricName)
+			},
+		},
+		{
+			name:    "validate failure",
+			reqBody: `{"sql":"select f from cpu","db":"test"}`,
+			prepare: func() {
+				validateQueryFn = func(_ context.Context, _ *models.ExecuteParam,
+					_ *stmtpkg.Query, _ *query.SearchMgr) (*models.QueryValidation, error) {
+					return nil, fmt.Errorf("err")
+				}
+			},
+			assert: func(resp *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				sqlParseFn = sql.Parse
+				validateQueryFn = query.ValidateQuery
+			}()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			resp := mock.DoRequest(t, r, http.MethodPost, ValidatePath, tt.reqBody)
+			if tt.assert != nil {
+				tt.assert(resp)
+			}
+		})
+	}
+}
@@ -19,7 +19,11 @@ package ingest
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	nethttp "net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -32,19 +36,69 @@ import (
 	depspkg "github.com/lindb/lindb/app/broker/deps"
 	"github.com/lindb/lindb/constants"
 	ingestCommon "github.com/lindb/lindb/ingestion/common"
+	"github.com/lindb/lindb/ingestion/csv"
 	"github.com/lindb/lindb/ingestion/flat"
+	"github.com/lindb/lindb/ingestion/graphite"
 	"github.com/lindb/lindb/ingestion/influx"
+	"github.com/lindb/lindb/ingestion/opentsdb"
+	"github.com/lindb/lindb/ingestion/prometheus"
 	"github.com/lindb/lindb/ingestion/proto"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/metrics"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/replica"
 	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
 )
 
 var (
 	// WritePath represents write http api router path.
 	WritePath = "/write"
+	// OpenTSDBPutPath represents opentsdb put http api router path.
+	OpenTSDBPutPath = "/opentsdb/api/put"
+	// PrometheusWritePath represents prometheus remote write http api router path.
+	PrometheusWritePath = "/prometheus/api/v1/write"
+	// CSVWritePath represents csv bulk write http api router path.
+	CSVWritePath = "/csv/write"
 )
 
+// parseFunc parses the data of write request to broker rows.
+type parseFunc func(req *nethttp.Request, enrichedTags tag.Tags, namespace string, limits *models.Limits) (*metric.BrokerBatchRows, error)
+
+// contentTypeParsers represents the parser for each supported content type of write api, matched by prefix in order.
+var contentTypeParsers = []struct {
+	contentType string
+	parse       parseFunc
+}{
+	{contentType: constants.ContentTypeFlat, parse: flat.Parse},
+	{contentType: constants.ContentTypeInflux, parse: influx.Parse},
+	{contentType: constants.ContentTypeText, parse: influx.Parse},
+	{contentType: constants.ContentTypeProto, parse: proto.Parse},
+	{contentType: constants.ContentTypeGraphite, parse: graphite.Parse},
+	{contentType: constants.ContentTypeJSON, parse: parseOpenTSDB},
+	{contentType: constants.ContentTypePrometheus, parse: prometheus.Parse},
+}
+
+// parseOpenTSDB parses opentsdb json data points, drops the summary of data points.
+func parseOpenTSDB(req *nethttp.Request, enrichedTags tag.Tags, namespace string, limits *models.Limits) (*metric.BrokerBatchRows, error) {
+	rows, _, err := opentsdb.Parse(req, enrichedTags, namespace, limits)
+	return rows, err
+}
+
+// getParser returns the parser based on content type of write request,
+// returns ErrUnsupportedContentType if content type not supported.
+func getParser(contentType string) (parseFunc, error) {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	for _, p := range contentTypeParsers {
+		if strings.HasPrefix(contentType, p.contentType) {
+			return p.parse, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s, only support %s/%s/%s/%s/%s/%s/%s", constants.ErrUnsupportedContentType, contentType,
+		constants.ContentTypeFlat, constants.ContentTypeProto, constants.ContentTypeInflux, constants.ContentTypeGraphite,
+		constants.ContentTypeText, constants.ContentTypeJSON, constants.ContentTypePrometheus)
+}
+
 // Write represents write api that processes flat/proto/influx protocol data.
 type Write struct {
 	deps *depspkg.HTTPDeps
@@ -77,6 +131,9 @@ func NewWrite(deps *depspkg.HTTPDeps) *Write {
 func (w *Write) Register(route gin.IRoutes) {
 	route.POST(WritePath, w.Write)
 	route.PUT(WritePath, w.Write)
+	route.POST(OpenTSDBPutPath, w.OpenTSDBPut)
+	route.POST(PrometheusWritePath, w.PrometheusWrite)
+	route.POST(CSVWritePath, w.CSVWrite)
 }
 
 // Write processes flat/proto/influx protocol data with ingest limit.
@@ -89,15 +146,29 @@ func (w *Write) Register(route gin.IRoutes) {
 // @Description 1. application/flatbuffer
 // @Description 2. application/protobuf
 // @Description 3. application/influx
+// @Description 4. application/graphite(template query param maps metric path to name/tags, e.g. app.*.measurement)
+// @Description 5. text/plain(influx line protocol)
+// @Description 6. application/json(opentsdb json data points)
+// @Description 7. application/x-protobuf(prometheus remote write)
+// @Description data compressed by gzip/zstd/snappy is decompressed based on Content-Encoding.
 // @Tags Write
 // @Accept application/flatbuffer
 // @Accept application/protobuf
 // @Accept application/influx
+// @Accept application/graphite
+// @Accept plain
+// @Accept json
+// @Accept application/x-protobuf
 // @Param db query string true "database name"
 // @Param ns query string false "namespace, default value: default-ns"
+// @Param template query string false "graphite template, e.g. app.*.measurement"
+// @Param X-LinDB-Batch-ID header string false "batch id for write idempotency, retried batch with same id is written once"
 // @Param string body string ture "metric data"
 // @Produce plain
 // @Success 204 {string} string ""
+// @Failure 400 {string} string "rows violate write limits of database, other rows are written"
+// @Failure 415 {string} string "unsupported content type"
+// @Failure 429 {string} string "too many in-flight rows or ingestion rate limited, client need back off"
 // @Failure 500 {string} string "internal error"
 // @Router /write [put]
 // @Router /write [post]
@@ -105,64 +176,257 @@ func (w *Write) Write(c *gin.Context) {
 	if err := w.deps.IngestLimiter.Do(func() error {
 		return w.write(c)
 	}); err != nil {
-		http.Error(c, err)
+		responseError(c, err)
 	} else {
 		http.NoContent(c)
 	}
 }
 
-// parse flat/proto/influx protocol data, then write parsed data to database's write channel.
-func (w *Write) write(c *gin.Context) (err error) {
-	var param struct {
-		Database  string `form:"db" binding:"required"`
-		Namespace string `form:"ns"`
+// OpenTSDBPut processes opentsdb put api json data(single point or point array) with ingest limit.
+//
+// @BasePath /api/v1
+// @Summary write opentsdb data points
+// @Schemes
+// @Description receive opentsdb json data points, then write data via database channel.
+// @Description invalid data points(e.g. empty metric, no tags) are dropped,
+// @Description returns summary of success/failed data points if details param set.
+// @Tags Write
+// @Accept json
+// @Param db query string true "database name"
+// @Param ns query string false "namespace, default value: default-ns"
+// @Param details query string false "return summary of success/failed data points"
+// @Param string body string ture "data points"
+// @Produce json
+// @Success 200 {object} opentsdb.Summary
+// @Success 204 {string} string ""
+// @Failure 429 {string} string "too many in-flight rows or ingestion rate limited, client need back off"
+// @Failure 500 {string} string "internal error"
+// @Router /opentsdb/api/put [post]
+func (w *Write) OpenTSDBPut(c *gin.Context) {
+	var summary *opentsdb.Summary
+	if err := w.deps.IngestLimiter.Do(func() (err error) {
+		summary, err = w.openTSDBPut(c)
+		return err
+	}); err != nil {
+		responseError(c, err)
+		return
 	}
-	err = c.ShouldBindQuery(&param)
+	if _, details := c.GetQuery("details"); details {
+		http.OK(c, summary)
+		return
+	}
+	http.NoContent(c)
+}
+
+// PrometheusWrite processes prometheus remote write data with ingest limit.
+//
+// @BasePath /api/v1
+// @Summary write prometheus remote write data
+// @Schemes
+// @Description receive prometheus remote write data(snappy compressed protobuf), then write data via database channel.
+// @Description metric name is __name__ label, other labels are tags, staleness marker samples are skipped.
+// @Tags Write
+// @Accept application/x-protobuf
+// @Param db query string true "database name"
+// @Param ns query string false "namespace, default value: default-ns"
+// @Param string body string ture "remote write request"
+// @Produce plain
+// @Success 204 {string} string ""
+// @Failure 429 {string} string "too many in-flight rows or ingestion rate limited, client need back off"
+// @Failure 500 {string} string "internal error"
+// @Router /prometheus/api/v1/write [post]
+func (w *Write) PrometheusWrite(c *gin.Context) {
+	if err := w.deps.IngestLimiter.Do(func() error {
+		return w.prometheusWrite(c)
+	}); err != nil {
+		responseError(c, err)
+	} else {
+		http.NoContent(c)
+	}
+}
+
+// CSVWrite processes csv bulk data(e.g. backfill) with ingest limit.
+//
+// @BasePath /api/v1
+// @Summary write csv data
+// @Schemes
+// @Description receive csv data with header-driven schema, then write data via database channel batch by batch.
+// @Description header names columns as __name__, time(RFC3339 or epoch ms), tag columns(tag_ prefix)
+// @Description and field columns(field_ prefix, type suffix like field_count:sum, default last).
+// @Description malformed rows are skipped, returns summary of accepted/rejected rows.
+// @Tags Write
+// @Accept text/csv
+// @Param db query string true "database name"
+// @Param ns query string false "namespace, default value: default-ns"
+// @Param string body string ture "csv data"
+// @Produce json
+// @Success 200 {object} csv.Summary
+// @Failure 429 {string} string "too many in-flight rows or ingestion rate limited, client need back off"
+// @Failure 500 {string} string "internal error"
+// @Router /csv/write [post]
+func (w *Write) CSVWrite(c *gin.Context) {
+	var summary *csv.Summary
+	if err := w.deps.IngestLimiter.Do(func() (err error) {
+		summary, err = w.csvWrite(c)
+		return err
+	}); err != nil {
+		responseError(c, err)
+		return
+	}
+	http.OK(c, summary)
+}
+
+// responseError responses the error of write, returns http status 429 if shard channel backpressure,
+// returns http status 415 if content type not supported, returns http status 400 if rows violate write limits.
+func responseError(c *gin.Context, err error) {
+	if errors.Is(err, constants.ErrUnsupportedContentType) {
+		_ = c.Error(err)
+		c.JSON(nethttp.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+	if errors.Is(err, replica.ErrInvalidRows) {
+		// rows violating write limits are rejected(other rows written), client need fix the data
+		_ = c.Error(err)
+		c.JSON(nethttp.StatusBadRequest, err.Error())
+		return
+	}
+	var rateLimitErr *replica.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		// ingestion rate of database exceeds the limit, tell client when to retry
+		retryAfter := int64(math.Ceil(rateLimitErr.RetryAfter.Seconds()))
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Header(headers.RetryAfter, strconv.FormatInt(retryAfter, 10))
+		_ = c.Error(err)
+		c.JSON(nethttp.StatusTooManyRequests, err.Error())
+		return
+	}
+	if errors.Is(err, replica.ErrChannelBackpressure) {
+		// shard buffer is full, tell client to back off and retry
+		_ = c.Error(err)
+		c.JSON(nethttp.StatusTooManyRequests, err.Error())
+		return
+	}
+	http.Error(c, err)
+}
+
+// writeParam represents the common params of write request.
+type writeParam struct {
+	Database  string `form:"db" binding:"required"`
+	Namespace string `form:"ns"`
+}
+
+// parse data based on content type(flat/proto/influx/graphite/opentsdb/prometheus),
+// then write parsed data to database's write channel.
+func (w *Write) write(c *gin.Context) error {
+	param, enrichedTags, limits, err := w.parseParam(c)
+	if err != nil {
+		return err
+	}
+	parse, err := getParser(c.Request.Header.Get(headers.ContentType))
+	if err != nil {
+		return err
+	}
+	rows, err := parse(c.Request, enrichedTags, param.Namespace, limits)
+	// rows are copied into write channel, so return them into pool after writing
+	defer metric.ReleaseBrokerBatchRows(rows)
+	if err != nil {
+		return err
+	}
+	return w.writeRows(param.Database, batchIDFromHeader(c), rows)
+}
+
+// openTSDBPut parses opentsdb json data points, then write parsed data to database's write channel.
+func (w *Write) openTSDBPut(c *gin.Context) (*opentsdb.Summary, error) {
+	param, enrichedTags, limits, err := w.parseParam(c)
+	if err != nil {
+		return nil, err
+	}
+	rows, summary, err := opentsdb.Parse(c.Request, enrichedTags, param.Namespace, limits)
+	defer metric.ReleaseBrokerBatchRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.writeRows(param.Database, batchIDFromHeader(c), rows); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// prometheusWrite parses prometheus remote write data, then write parsed data to database's write channel.
+func (w *Write) prometheusWrite(c *gin.Context) error {
+	param, enrichedTags, limits, err := w.parseParam(c)
+	if err != nil {
+		return err
+	}
+	rows, err := prometheus.Parse(c.Request, enrichedTags, param.Namespace, limits)
+	defer metric.ReleaseBrokerBatchRows(rows)
 	if err != nil {
 		return err
 	}
+	return w.writeRows(param.Database, batchIDFromHeader(c), rows)
+}
+
+// csvWrite parses csv data by streaming, then writes parsed rows to database's write channel batch by batch.
+func (w *Write) csvWrite(c *gin.Context) (*csv.Summary, error) {
+	param, enrichedTags, limits, err := w.parseParam(c)
+	if err != nil {
+		return nil, err
+	}
+	batchID := batchIDFromHeader(c)
+	chunk := 0
+	return csv.Parse(c.Request, enrichedTags, param.Namespace, limits, func(rows *metric.BrokerBatchRows) error {
+		// csv data is written batch by batch, each batch has its own id derived from batch id of request
+		chunkBatchID := ""
+		if batchID != "" {
+			chunkBatchID = batchID + "-" + strconv.Itoa(chunk)
+		}
+		chunk++
+		return w.writeRows(param.Database, chunkBatchID, rows)
+	})
+}
+
+// writeRows writes parsed rows to database's write channel with ingest timeout,
+// rows with client-supplied batch id are written once even if batch retried.
+func (w *Write) writeRows(database, batchID string, rows *metric.BrokerBatchRows) error {
 	ctx, cancel := context.WithTimeout(context.Background(),
 		w.deps.BrokerCfg.BrokerBase.Ingestion.IngestTimeout.Duration())
 	defer cancel()
+	rows.SetBatchID(batchID)
+	return w.deps.CM.Write(ctx, database, rows)
+}
 
+// batchIDFromHeader returns the client-supplied write batch id for write idempotency, empty if not supplied.
+func batchIDFromHeader(c *gin.Context) string {
+	return strings.TrimSpace(c.GetHeader(constants.HeaderBatchID))
+}
+
+// parseParam parses and validates the common params of write request, returns enriched tags and limits of database.
+func (w *Write) parseParam(c *gin.Context) (param *writeParam, enrichedTags tag.Tags, limits *models.Limits, err error) {
+	param = &writeParam{}
+	if err = c.ShouldBindQuery(param); err != nil {
+		return nil, nil, nil, err
+	}
 	if param.Namespace == "" {
 		param.Namespace = commonconstants.DefaultNamespace
 	}
-	enrichedTags, err := ingestCommon.ExtractEnrichTags(c.Request)
+	enrichedTags, err = ingestCommon.ExtractEnrichTags(c.Request)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 
-	limits := w.deps.StateMgr.GetDatabaseLimits(param.Database)
-	for _, tag := range enrichedTags {
-		if limits.EnableTagNameLengthCheck() && len(tag.Key) > limits.MaxTagNameLength {
-			return constants.ErrTagKeyTooLong
+	limits = w.deps.StateMgr.GetDatabaseLimits(param.Database)
+	for _, enrichedTag := range enrichedTags {
+		if limits.EnableTagNameLengthCheck() && len(enrichedTag.Key) > limits.MaxTagNameLength {
+			return nil, nil, nil, constants.ErrTagKeyTooLong
 		}
-		if limits.EnableTagValueLengthCheck() && len(tag.Value) > limits.MaxTagValueLength {
-			return constants.ErrTagValueTooLong
+		if limits.EnableTagValueLengthCheck() && len(enrichedTag.Value) > limits.MaxTagValueLength {
+			return nil, nil, nil, constants.ErrTagValueTooLong
 		}
 	}
 	if limits.EnableNamespaceLengthCheck() && len(param.Namespace) > limits.MaxNamespaceLength {
-		return constants.ErrNamespaceTooLong
-	}
-	contentType := strings.ToLower(strings.Trim(c.Request.Header.Get(headers.ContentType), " "))
-	var rows *metric.BrokerBatchRows
-	switch {
-	case strings.HasPrefix(contentType, constants.ContentTypeFlat):
-		rows, err = flat.Parse(c.Request, enrichedTags, param.Namespace, limits)
-	case strings.HasPrefix(contentType, constants.ContentTypeInflux):
-		rows, err = influx.Parse(c.Request, enrichedTags, param.Namespace, limits)
-	case strings.HasPrefix(contentType, constants.ContentTypeProto):
-		rows, err = proto.Parse(c.Request, enrichedTags, param.Namespace, limits)
-	default:
-		err = fmt.Errorf("not support content type: %s, only support %s/%s/%s", contentType,
-			constants.ContentTypeFlat, constants.ContentTypeProto, constants.ContentTypeInflux)
-	}
-	if err != nil {
-		return err
-	}
-	if err := w.deps.CM.Write(ctx, param.Database, rows); err != nil {
-		return err
+		return nil, nil, nil, constants.ErrNamespaceTooLong
 	}
-	return nil
+	return param, enrichedTags, limits, nil
 }
@@ -19,6 +19,7 @@ package ingest
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"io"
 	"net/http"
@@ -28,8 +29,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-http-utils/headers"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/snappy"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/common/pkg/encoding"
 	"github.com/lindb/common/pkg/ltoml"
 	"github.com/lindb/common/pkg/timeutil"
 	protoMetricsV1 "github.com/lindb/common/proto/gen/v1/linmetrics"
@@ -38,11 +41,14 @@ import (
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/ingestion/csv"
+	"github.com/lindb/lindb/ingestion/opentsdb"
 	"github.com/lindb/lindb/internal/concurrent"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/internal/mock"
 	"github.com/lindb/lindb/metrics"
 	"github.com/lindb/lindb/models"
+	protoPrometheusV1 "github.com/lindb/lindb/proto/gen/v1/prometheus"
 	"github.com/lindb/lindb/replica"
 	"github.com/lindb/lindb/series/metric"
 )
@@ -105,11 +111,44 @@ func TestWrite_Flat(t *testing.T) {
 	resp = mock.DoRequest(t, r, http.MethodPut, WritePath+"?db=test3&enrich_tag=a=b", body, header)
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 
+	// backpressure
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(replica.ErrChannelBackpressure)
+	resp = mock.DoRequest(t, r, http.MethodPut, WritePath+"?db=test3", body, header)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+
+	// invalid rows
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&replica.InvalidRowsError{Database: "test3", Rejected: 1, Err: constants.ErrInvalidMetricName})
+	resp = mock.DoRequest(t, r, http.MethodPut, WritePath+"?db=test3", body, header)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// rate limited
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&replica.RateLimitError{Database: "test3", RetryAfter: 1500 * time.Millisecond})
+	resp = mock.DoRequest(t, r, http.MethodPut, WritePath+"?db=test3", body, header)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get(headers.RetryAfter))
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&replica.RateLimitError{Database: "test3", RetryAfter: time.Millisecond})
+	resp = mock.DoRequest(t, r, http.MethodPut, WritePath+"?db=test3", body, header)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "1", resp.Header().Get(headers.RetryAfter))
+
 	// no content
 	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 	resp = mock.DoRequest(t, r, http.MethodPut, WritePath+"?db=test&ns=ns4&enrich_tag=a=b", body, header)
 	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	// batch id from header
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, rows *metric.BrokerBatchRows) error {
+			assert.Equal(t, "batch-1", rows.BatchID())
+			return nil
+		})
+	header.Set(constants.HeaderBatchID, " batch-1 ")
+	resp = mock.DoRequest(t, r, http.MethodPut, WritePath+"?db=test", body, header)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
 }
 
 func TestWrite_Influx(t *testing.T) {
@@ -176,6 +215,201 @@ measurement value=12 1439587925
 	assert.Equal(t, http.StatusNoContent, resp.Code)
 }
 
+func TestWrite_Graphite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replica.NewMockChannelManager(ctrl)
+	stateMgr := broker.NewMockStateManager(ctrl)
+	stateMgr.EXPECT().GetDatabaseLimits(gomock.Any()).Return(models.NewDefaultLimits()).AnyTimes()
+	api := NewWrite(&deps.HTTPDeps{
+		BrokerCfg: &config.Broker{
+			BrokerBase: config.BrokerBase{
+				Ingestion: config.Ingestion{
+					IngestTimeout: ltoml.Duration(time.Second * 2),
+				},
+			},
+		},
+		CM:       cm,
+		StateMgr: stateMgr,
+		IngestLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			32,
+			time.Second,
+			metrics.NewLimitStatistics("graphite_write_test", linmetric.BrokerRegistry)),
+	})
+	r := gin.New()
+	api.Register(r)
+
+	header := make(http.Header)
+	header.Set(headers.ContentType, constants.ContentTypeGraphite)
+
+	// bad template
+	resp := mock.DoRequest(t, r, http.MethodPut, WritePath+"?db=test&template=app", "web.cpu 1", header)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+
+	// no content
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, WritePath+"?db=test&template=app.*.measurement", `
+web.host1.cpu 12 1439587925
+web.host2.cpu 12 1439587925
+`, header)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+func TestWrite_OpenTSDB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replica.NewMockChannelManager(ctrl)
+	stateMgr := broker.NewMockStateManager(ctrl)
+	stateMgr.EXPECT().GetDatabaseLimits(gomock.Any()).Return(models.NewDefaultLimits()).AnyTimes()
+	api := NewWrite(&deps.HTTPDeps{
+		BrokerCfg: &config.Broker{
+			BrokerBase: config.BrokerBase{
+				Ingestion: config.Ingestion{
+					IngestTimeout: ltoml.Duration(time.Second * 2),
+				},
+			},
+		},
+		CM:       cm,
+		StateMgr: stateMgr,
+		IngestLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			32,
+			time.Second,
+			metrics.NewLimitStatistics("opentsdb_write_test", linmetric.BrokerRegistry)),
+	})
+	r := gin.New()
+	api.Register(r)
+
+	body := `[{"metric":"cpu","timestamp":1346846400,"value":18,"tags":{"host":"web01"}},{"metric":"cpu","value":18}]`
+	// missing db param
+	resp := mock.DoRequest(t, r, http.MethodPost, OpenTSDBPutPath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// bad data
+	resp = mock.DoRequest(t, r, http.MethodPost, OpenTSDBPutPath+"?db=test", "{bad")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// write error
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(io.ErrClosedPipe)
+	resp = mock.DoRequest(t, r, http.MethodPost, OpenTSDBPutPath+"?db=test", body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// backpressure
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(replica.ErrChannelBackpressure)
+	resp = mock.DoRequest(t, r, http.MethodPost, OpenTSDBPutPath+"?db=test", body)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	// no content
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPost, OpenTSDBPutPath+"?db=test", body)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	// details
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPost, OpenTSDBPutPath+"?db=test&details", body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	summary := &opentsdb.Summary{}
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), summary))
+	assert.Equal(t, 1, summary.Success)
+	assert.Equal(t, 1, summary.Failed)
+}
+
+func TestWrite_Prometheus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replica.NewMockChannelManager(ctrl)
+	stateMgr := broker.NewMockStateManager(ctrl)
+	stateMgr.EXPECT().GetDatabaseLimits(gomock.Any()).Return(models.NewDefaultLimits()).AnyTimes()
+	api := NewWrite(&deps.HTTPDeps{
+		BrokerCfg: &config.Broker{
+			BrokerBase: config.BrokerBase{
+				Ingestion: config.Ingestion{
+					IngestTimeout: ltoml.Duration(time.Second * 2),
+				},
+			},
+		},
+		CM:       cm,
+		StateMgr: stateMgr,
+		IngestLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			32,
+			time.Second,
+			metrics.NewLimitStatistics("prometheus_write_test", linmetric.BrokerRegistry)),
+	})
+	r := gin.New()
+	api.Register(r)
+
+	writeReq := &protoPrometheusV1.WriteRequest{
+		Timeseries: []*protoPrometheusV1.TimeSeries{{
+			Labels:  []*protoPrometheusV1.Label{{Name: "__name__", Value: "cpu"}, {Name: "host", Value: "web01"}},
+			Samples: []*protoPrometheusV1.Sample{{Value: 1, Timestamp: 1346846400123}},
+		}},
+	}
+	data, err := writeReq.Marshal()
+	assert.NoError(t, err)
+	body := string(snappy.Encode(nil, data))
+	// missing db param
+	resp := mock.DoRequest(t, r, http.MethodPost, PrometheusWritePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// bad data
+	resp = mock.DoRequest(t, r, http.MethodPost, PrometheusWritePath+"?db=test", "bad")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// write error
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(io.ErrClosedPipe)
+	resp = mock.DoRequest(t, r, http.MethodPost, PrometheusWritePath+"?db=test", body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// no content
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPost, PrometheusWritePath+"?db=test", body)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+func TestWrite_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replica.NewMockChannelManager(ctrl)
+	stateMgr := broker.NewMockStateManager(ctrl)
+	stateMgr.EXPECT().GetDatabaseLimits(gomock.Any()).Return(models.NewDefaultLimits()).AnyTimes()
+	api := NewWrite(&deps.HTTPDeps{
+		BrokerCfg: &config.Broker{
+			BrokerBase: config.BrokerBase{
+				Ingestion: config.Ingestion{
+					IngestTimeout: ltoml.Duration(time.Second * 2),
+				},
+			},
+		},
+		CM:       cm,
+		StateMgr: stateMgr,
+		IngestLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			32,
+			time.Second,
+			metrics.NewLimitStatistics("csv_write_test", linmetric.BrokerRegistry)),
+	})
+	r := gin.New()
+	api.Register(r)
+
+	body := "__name__,time,tag_host,field_usage\ncpu,1346846400000,web01,18\ncpu,bad,web01,18\n"
+	// missing db param
+	resp := mock.DoRequest(t, r, http.MethodPost, CSVWritePath, body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// bad header
+	resp = mock.DoRequest(t, r, http.MethodPost, CSVWritePath+"?db=test", "time,field_usage\n")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// write error
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(io.ErrClosedPipe)
+	resp = mock.DoRequest(t, r, http.MethodPost, CSVWritePath+"?db=test", body)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// summary
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPost, CSVWritePath+"?db=test", body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	summary := &csv.Summary{}
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), summary))
+	assert.Equal(t, 1, summary.Accepted)
+	assert.Equal(t, 1, summary.Rejected)
+}
+
 func TestWrite_Proto(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -252,3 +486,119 @@ func TestWrite_Proto(t *testing.T) {
 	resp = mock.DoRequest(t, r, http.MethodPost, WritePath+"?db=test&ns=ns4&enrich_tag=a=b", string(data), header)
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 }
+
+func TestWrite_ContentType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replica.NewMockChannelManager(ctrl)
+	stateMgr := broker.NewMockStateManager(ctrl)
+	stateMgr.EXPECT().GetDatabaseLimits(gomock.Any()).Return(models.NewDefaultLimits()).AnyTimes()
+	api := NewWrite(&deps.HTTPDeps{
+		BrokerCfg: &config.Broker{
+			BrokerBase: config.BrokerBase{
+				Ingestion: config.Ingestion{
+					IngestTimeout: ltoml.Duration(time.Second * 2),
+				},
+			},
+		},
+		CM:       cm,
+		StateMgr: stateMgr,
+		IngestLimiter: concurrent.NewLimiter(
+			context.TODO(),
+			32,
+			time.Second,
+			metrics.NewLimitStatistics("content_type_write_test", linmetric.BrokerRegistry)),
+	})
+	r := gin.New()
+	api.Register(r)
+
+	converter := metric.NewProtoConverter(models.NewDefaultLimits())
+	var brokerRow metric.BrokerRow
+	assert.NoError(t, converter.ConvertTo(&protoMetricsV1.Metric{
+		Name:      "cpu",
+		Timestamp: timeutil.Now(),
+		SimpleFields: []*protoMetricsV1.SimpleField{
+			{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+	}, &brokerRow))
+	var flatBuf bytes.Buffer
+	_, _ = brokerRow.WriteTo(&flatBuf)
+	metricList := protoMetricsV1.MetricList{Metrics: []*protoMetricsV1.Metric{
+		{Name: "cpu", SimpleFields: []*protoMetricsV1.SimpleField{
+			{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1},
+		}},
+	}}
+	protoData, _ := metricList.Marshal()
+	writeReq := &protoPrometheusV1.WriteRequest{
+		Timeseries: []*protoPrometheusV1.TimeSeries{{
+			Labels:  []*protoPrometheusV1.Label{{Name: "__name__", Value: "cpu"}, {Name: "host", Value: "web01"}},
+			Samples: []*protoPrometheusV1.Sample{{Value: 1, Timestamp: 1346846400123}},
+		}},
+	}
+	prometheusData, _ := writeReq.Marshal()
+	influxLine := "cpu,host=web01 f1=1 1439587925\n"
+	var gzipBuf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipBuf)
+	_, _ = gzipWriter.Write([]byte(influxLine))
+	_ = gzipWriter.Close()
+
+	cases := []struct {
+		name            string
+		contentType     string
+		contentEncoding string
+		body            string
+		rows            int
+		code            int
+	}{
+		{name: "flat", contentType: constants.ContentTypeFlat, body: flatBuf.String(), rows: 1, code: http.StatusNoContent},
+		{name: "proto", contentType: constants.ContentTypeProto, body: string(protoData), rows: 1, code: http.StatusNoContent},
+		{name: "influx", contentType: constants.ContentTypeInflux, body: influxLine, rows: 1, code: http.StatusNoContent},
+		{name: "influx line protocol", contentType: "text/plain; charset=utf-8", body: influxLine, rows: 1, code: http.StatusNoContent},
+		{
+			name:            "gzip influx line protocol",
+			contentType:     constants.ContentTypeText,
+			contentEncoding: "gzip",
+			body:            gzipBuf.String(),
+			rows:            1,
+			code:            http.StatusNoContent,
+		},
+		{name: "graphite", contentType: constants.ContentTypeGraphite, body: "cpu.load 1 1439587925\n", rows: 1, code: http.StatusNoContent},
+		{
+			name:        "opentsdb json",
+			contentType: constants.ContentTypeJSON,
+			body:        `[{"metric":"cpu","timestamp":1346846400,"value":18,"tags":{"host":"web01"}}]`,
+			rows:        1,
+			code:        http.StatusNoContent,
+		},
+		{
+			name:            "prometheus remote write",
+			contentType:     constants.ContentTypePrometheus,
+			contentEncoding: "snappy",
+			body:            string(snappy.Encode(nil, prometheusData)),
+			rows:            1,
+			code:            http.StatusNoContent,
+		},
+		{name: "unsupported content type", contentType: "application/xml", body: "<xml/>", code: http.StatusUnsupportedMediaType},
+		{name: "unsupported content encoding", contentType: constants.ContentTypeText, contentEncoding: "br", body: influxLine,
+			code: http.StatusInternalServerError},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.rows > 0 {
+				cm.EXPECT().Write(gomock.Any(), "test", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, rows *metric.BrokerBatchRows) error {
+						assert.Equal(t, tt.rows, rows.Len())
+						return nil
+					})
+			}
+			header := make(http.Header)
+			header.Set(headers.ContentType, tt.contentType)
+			if tt.contentEncoding != "" {
+				header.Set(headers.ContentEncoding, tt.contentEncoding)
+			}
+			resp := mock.DoRequest(t, r, http.MethodPost, WritePath+"?db=test", tt.body, header)
+			assert.Equal(t, tt.code, resp.Code)
+		})
+	}
+}
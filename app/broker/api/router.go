@@ -43,9 +43,11 @@ type API struct {
 	flusher            *admin.DatabaseFlusherAPI
 	storage            *admin.StorageClusterAPI
 	brokerStateMachine *state.BrokerStateMachineAPI
+	writeStats         *state.WriteStatsAPI
 	request            *apipkg.RequestAPI
 	metricExplore      *apipkg.ExploreAPI
 	log                *apipkg.LoggerAPI
+	slowQuery          *apipkg.SlowQueryAPI
 	config             *apipkg.ConfigAPI
 	env                *apipkg.EnvAPI
 	write              *ingest.Write
@@ -61,9 +63,11 @@ func NewAPI(deps *depspkg.HTTPDeps) *API {
 		flusher:            admin.NewDatabaseFlusherAPI(deps),
 		storage:            admin.NewStorageClusterAPI(deps),
 		brokerStateMachine: state.NewBrokerStateMachineAPI(deps),
+		writeStats:         state.NewWriteStatsAPI(deps),
 		request:            apipkg.NewRequestAPI(),
 		metricExplore:      apipkg.NewExploreAPI(deps.GlobalKeyValues, linmetric.BrokerRegistry),
-		log:                apipkg.NewLoggerAPI(deps.BrokerCfg.Logging.Dir),
+		log:                apipkg.NewLoggerAPI(deps.BrokerCfg.Logging.Dir, int64(deps.BrokerCfg.Monitor.MaxLogViewSize)),
+		slowQuery:          apipkg.NewSlowQueryAPI(),
 		config:             apipkg.NewConfigAPI(deps.Node, deps.BrokerCfg),
 		env:                apipkg.NewEnvAPI(deps.BrokerCfg.Monitor, constants.BrokerRole),
 		write:              ingest.NewWrite(deps),
@@ -84,6 +88,7 @@ func (api *API) RegisterRouter(router *gin.RouterGroup) {
 
 	// state
 	api.brokerStateMachine.Register(v1)
+	api.writeStats.Register(v1)
 	api.request.Register(v1)
 
 	// write metric data
@@ -92,6 +97,7 @@ func (api *API) RegisterRouter(router *gin.RouterGroup) {
 	// monitoring
 	api.metricExplore.Register(v1)
 	api.log.Register(v1)
+	api.slowQuery.Register(v1)
 	api.config.Register(v1)
 
 	api.env.Register(v1)
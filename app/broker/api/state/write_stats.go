@@ -0,0 +1,65 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package state
+
+import (
+	"github.com/gin-gonic/gin"
+
+	httppkg "github.com/lindb/common/pkg/http"
+
+	depspkg "github.com/lindb/lindb/app/broker/deps"
+	"github.com/lindb/lindb/models"
+)
+
+var (
+	WriteStatsPath = "/state/write/stats"
+)
+
+// WriteStatsAPI represents the per metric write statistics rest api of broker.
+type WriteStatsAPI struct {
+	deps *depspkg.HTTPDeps
+}
+
+// NewWriteStatsAPI creates a write statistics api instance.
+func NewWriteStatsAPI(deps *depspkg.HTTPDeps) *WriteStatsAPI {
+	return &WriteStatsAPI{
+		deps: deps,
+	}
+}
+
+// Register adds write statistics url route.
+func (api *WriteStatsAPI) Register(route gin.IRoutes) {
+	route.GET(WriteStatsPath, api.GetWriteStats)
+}
+
+// GetWriteStats returns the write statistics of each metric by given database's name.
+func (api *WriteStatsAPI) GetWriteStats(c *gin.Context) {
+	var param struct {
+		DB string `form:"db" binding:"required"`
+	}
+	err := c.ShouldBindQuery(&param)
+	if err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	rs := api.deps.CM.GetWriteStats(param.DB)
+	if rs == nil {
+		rs = make([]models.MetricWriteStats, 0)
+	}
+	httppkg.OK(c, rs)
+}
@@ -20,6 +20,8 @@ package deps
 import (
 	"context"
 
+	"github.com/go-resty/resty/v2"
+
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator"
 	"github.com/lindb/lindb/coordinator/broker"
@@ -48,6 +50,13 @@ type HTTPDeps struct {
 	CM            replica.ChannelManager
 	IngestLimiter *concurrent.Limiter
 	QueryLimiter  *concurrent.Limiter
+	// DatabaseQueryLimiter limits the query concurrency of each database, isolates queries between databases.
+	DatabaseQueryLimiter *concurrent.KeyedLimiter
+	// QueryResultCache caches the result set of metric data query, nil means cache disabled.
+	QueryResultCache *query.ResultCache
+
+	// StateCli is the shared client for fetching state from nodes, reuses connections across fan-outs.
+	StateCli *resty.Client
 
 	GlobalKeyValues tag.Tags
 }
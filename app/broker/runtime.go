@@ -38,6 +38,7 @@ import (
 	"github.com/lindb/lindb/coordinator"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/discovery"
+	"github.com/lindb/lindb/internal/client"
 	"github.com/lindb/lindb/internal/concurrent"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/internal/server"
@@ -181,7 +182,20 @@ func (r *runtime) Run() error {
 	}
 	r.BaseRuntime = app.NewBaseRuntimeFn(r.ctx, r.config.Monitor, linmetric.BrokerRegistry, r.globalKeyValues)
 
-	tackClientFct := newTaskClientFactory(r.ctx, r.node, rpc.GetBrokerClientConnFactory())
+	// set encoding of physical plan sent to other nodes
+	planEncoding, err := rpc.ParsePlanEncoding(r.config.Query.PlanEncoding)
+	if err != nil {
+		r.state = server.Failed
+		return err
+	}
+	rpc.PhysicalPlanEncoding = planEncoding
+	// check not found policy of data query
+	if _, err = models.ParseNotFoundPolicy(r.config.Query.NotFoundPolicy); err != nil {
+		r.state = server.Failed
+		return err
+	}
+
+	tackClientFct := newTaskClientFactory(r.ctx, r.node, rpc.GetBrokerClientConnFactory(), linmetric.BrokerRegistry)
 	r.factory = factory{
 		taskClient:    tackClientFct,
 		taskServer:    rpc.NewTaskServerFactory(),
@@ -369,6 +383,7 @@ func (r *runtime) startHTTPServer() {
 		TaskMgr:      r.srv.taskManager,
 		TransportMgr: r.srv.transportManager,
 		CM:           r.srv.channelManager,
+		StateCli:     client.NewSharedClient(r.config.BrokerBase.HTTP.ReadTimeout.Duration()),
 		IngestLimiter: concurrent.NewLimiter(
 			r.ctx,
 			r.config.BrokerBase.Ingestion.MaxConcurrency,
@@ -381,8 +396,20 @@ func (r *runtime) startHTTPServer() {
 			r.config.Query.Timeout.Duration(),
 			metrics.NewLimitStatistics("query", linmetric.BrokerRegistry),
 		),
+		DatabaseQueryLimiter: concurrent.NewKeyedLimiter(
+			r.config.Query.DatabaseQueryConcurrency,
+			func(database string) *metrics.KeyedLimitStatistics {
+				return metrics.NewDatabaseQueryLimitStatistics(database, linmetric.BrokerRegistry)
+			},
+		),
+		QueryResultCache: query.NewResultCache(
+			r.config.Query.ResultCacheSize,
+			r.config.Query.ResultCacheTTL.Duration(),
+		),
 		GlobalKeyValues: r.globalKeyValues,
 	})
+	// slow query threshold can be adjusted at runtime via slow query api
+	query.GetSlowQueryRecorder().SetThreshold(r.config.BrokerBase.SlowSQL.Duration())
 	httpAPI.RegisterRouter(r.httpServer.GetAPIRouter())
 	go r.runHTTPServer()
 }
@@ -416,10 +443,12 @@ func (r *runtime) buildServiceDependency() {
 	// close connections in connection-manager
 	r.factory.taskClient.SetTaskReceiver(taskMgr)
 
+	transportMgr := query.NewTransportManager(r.factory.taskClient, r.factory.taskServer, query.DefaultRetryPolicy,
+		query.DefaultCircuitBreakerPolicy, linmetric.BrokerRegistry)
 	s := srv{
 		channelManager:   cm,
 		taskManager:      taskMgr,
-		transportManager: query.NewTransportManager(r.factory.taskClient, r.factory.taskServer, linmetric.BrokerRegistry),
+		transportManager: transportMgr,
 	}
 	r.srv = s
 }
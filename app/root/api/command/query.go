@@ -34,15 +34,20 @@ var (
 // QueryCommand executes metric data query.
 func QueryCommand(ctx context.Context, deps *depspkg.HTTPDeps,
 	param *models.ExecuteParam, stmt stmtpkg.Statement) (interface{}, error) {
+	// not found policy is validated when runtime starting
+	notFoundPolicy, _ := models.ParseNotFoundPolicy(deps.Cfg.Query.NotFoundPolicy)
 	return metricDataSearchFn(
 		ctx,
 		param,
 		stmt.(*stmtpkg.Query),
 		&query.SearchMgr{
-			Timeout:      deps.Cfg.Query.Timeout.Duration(),
-			CurNode:      *deps.Node,
-			Choose:       deps.StateMgr,
-			TaskMgr:      deps.TaskMgr,
-			TransportMgr: deps.TransportMgr,
+			Timeout:        deps.Cfg.Query.Timeout.Duration(),
+			CurNode:        *deps.Node,
+			Choose:         deps.StateMgr,
+			TaskMgr:        deps.TaskMgr,
+			TransportMgr:   deps.TransportMgr,
+			MaxFanOut:      deps.Cfg.Query.MaxFanOut,
+			MaxGroupByTags: deps.Cfg.Query.MaxGroupByTags,
+			NotFoundPolicy: notFoundPolicy,
 		})
 }
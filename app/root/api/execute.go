@@ -29,6 +29,7 @@ import (
 	"github.com/lindb/lindb/app/root/api/command"
 	depspkg "github.com/lindb/lindb/app/root/deps"
 	"github.com/lindb/lindb/models"
+	lindbhttp "github.com/lindb/lindb/pkg/http"
 	sqlpkg "github.com/lindb/lindb/sql"
 	stmtpkg "github.com/lindb/lindb/sql/stmt"
 )
@@ -94,9 +95,10 @@ func (e *ExecuteAPI) Register(route gin.IRoutes) {
 // @Produce json
 // @Success 200 {object} models.ResultSet
 // @Success 200 {object} models.Metadata
-// @Failure 404 {string} string "not found"
-// @Failure 500 {string} string "can't parse lin query language"
-// @Failure 500 {string} string "internal error"
+// @Failure 404 {object} models.Error "not found"
+// @Failure 500 {object} models.Error "internal error"
+// @Failure 502 {object} models.Error "some of target nodes failed"
+// @Failure 504 {object} models.Error "query timeout"
 // @Router /exec [get]
 // @Router /exec [put]
 // @Router /exec [post]
@@ -104,7 +106,7 @@ func (e *ExecuteAPI) Execute(c *gin.Context) {
 	if err := e.deps.QueryLimiter.Do(func() error {
 		return e.execute(c)
 	}); err != nil {
-		httppkg.Error(c, err)
+		lindbhttp.ErrorWithCode(c, err)
 	}
 }
 
@@ -37,6 +37,7 @@ type API struct {
 	env              *apipkg.EnvAPI
 	config           *apipkg.ConfigAPI
 	log              *apipkg.LoggerAPI
+	slowQuery        *apipkg.SlowQueryAPI
 	proxy            *httppkg.ReverseProxy
 }
 
@@ -48,7 +49,8 @@ func NewAPI(deps *depspkg.HTTPDeps) *API {
 		request:          apipkg.NewRequestAPI(),
 		metricExplore:    apipkg.NewExploreAPI(deps.GlobalKeyValues, linmetric.RootRegistry),
 		env:              apipkg.NewEnvAPI(deps.Cfg.Monitor, constants.RootRole),
-		log:              apipkg.NewLoggerAPI(deps.Cfg.Logging.Dir),
+		log:              apipkg.NewLoggerAPI(deps.Cfg.Logging.Dir, int64(deps.Cfg.Monitor.MaxLogViewSize)),
+		slowQuery:        apipkg.NewSlowQueryAPI(),
 		config:           apipkg.NewConfigAPI(deps.Node, deps.Cfg),
 		proxy:            httppkg.NewReverseProxy(),
 	}
@@ -64,6 +66,7 @@ func (api *API) RegisterRouter(router *gin.RouterGroup) {
 	api.rootStateMachine.Register(v1)
 	api.config.Register(v1)
 	api.log.Register(v1)
+	api.slowQuery.Register(v1)
 	api.request.Register(v1)
 
 	api.proxy.Register(v1)
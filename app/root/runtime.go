@@ -141,9 +141,22 @@ func (r *runtime) Run() error {
 	r.logger.Info("starting root", logger.String("host", hostName), logger.String("ip", ip),
 		logger.Uint16("http", r.node.HTTPPort))
 
+	// set encoding of physical plan sent to other nodes
+	planEncoding, err := rpc.ParsePlanEncoding(r.config.Query.PlanEncoding)
+	if err != nil {
+		r.state = server.Failed
+		return err
+	}
+	rpc.PhysicalPlanEncoding = planEncoding
+	// check not found policy of data query
+	if _, err = models.ParseNotFoundPolicy(r.config.Query.NotFoundPolicy); err != nil {
+		r.state = server.Failed
+		return err
+	}
+
 	// build dependencies
 	repoFct := newRepositoryFactory("root")
-	taskClientFct := newTaskClientFactory(r.ctx, r.node, rpc.GetBrokerClientConnFactory())
+	taskClientFct := newTaskClientFactory(r.ctx, r.node, rpc.GetBrokerClientConnFactory(), linmetric.RootRegistry)
 	connectionMgr := rpc.NewConnectionManager(taskClientFct)
 	stateMgr := root.NewStateManager(r.ctx, repoFct, connectionMgr)
 	taskMgr := newTaskManager(
@@ -268,6 +281,9 @@ func (r *runtime) startHTTPServer() {
 	}
 
 	r.httpServer = newHTTPServer(r.config.HTTP, true, linmetric.RootRegistry)
+	// root node no grpc server
+	transportMgr := query.NewTransportManager(r.deps.taskClientFct, nil, query.DefaultRetryPolicy,
+		query.DefaultCircuitBreakerPolicy, linmetric.RootRegistry)
 	// TODO: login api is not registered
 	httpAPI := api.NewAPI(&depspkg.HTTPDeps{
 		Ctx:          r.ctx,
@@ -276,7 +292,7 @@ func (r *runtime) startHTTPServer() {
 		Repo:         r.repo,
 		RepoFactory:  r.deps.repoFct,
 		StateMgr:     r.deps.stateMgr,
-		TransportMgr: query.NewTransportManager(r.deps.taskClientFct, nil, linmetric.RootRegistry), // root node no grpc server
+		TransportMgr: transportMgr,
 		TaskMgr:      r.deps.taskMgr,
 		QueryLimiter: concurrent.NewLimiter(
 			r.ctx,
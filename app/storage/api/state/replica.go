@@ -18,16 +18,24 @@
 package state
 
 import (
+	"errors"
+
 	"github.com/gin-gonic/gin"
 
 	httppkg "github.com/lindb/common/pkg/http"
 	"github.com/lindb/common/pkg/logger"
 
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/replica"
 )
 
 var (
-	ReplicaPath = "/state/replica"
+	ReplicaPath    = "/state/replica"
+	ReplicaLagPath = "/state/replica/lag"
+	// QuarantinePath lists the replica messages which failed writing into local storage.
+	QuarantinePath = "/state/replica/quarantine"
+	// ReprocessPath reprocesses the quarantined replica message.
+	ReprocessPath = "/state/replica/quarantine/reprocess"
 )
 
 // ReplicaAPI represents internal replica state rest api.
@@ -47,6 +55,9 @@ func NewReplicaAPI(walMgr replica.WriteAheadLogManager) *ReplicaAPI {
 // Register adds explore url route.
 func (d *ReplicaAPI) Register(route gin.IRoutes) {
 	route.GET(ReplicaPath, d.GetReplicaState)
+	route.GET(ReplicaLagPath, d.GetReplicaLag)
+	route.GET(QuarantinePath, d.ListQuarantine)
+	route.PUT(ReprocessPath, d.Reprocess)
 }
 
 // GetReplicaState returns replica state by given database's name.
@@ -62,3 +73,54 @@ func (d *ReplicaAPI) GetReplicaState(c *gin.Context) {
 	rs := d.walMgr.GetReplicaState(param.DB)
 	httppkg.OK(c, rs)
 }
+
+// GetReplicaLag returns the lag of each shard's replica by given database's name.
+func (d *ReplicaAPI) GetReplicaLag(c *gin.Context) {
+	var param struct {
+		DB string `form:"db" binding:"required"`
+	}
+	err := c.ShouldBindQuery(&param)
+	if err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	rs := d.walMgr.GetReplicaState(param.DB)
+	lags := make([]models.ReplicaLag, 0)
+	for idx := range rs {
+		lags = append(lags, rs[idx].ReplicaLags()...)
+	}
+	httppkg.OK(c, lags)
+}
+
+// ListQuarantine returns the replica messages which failed writing into local storage.
+func (d *ReplicaAPI) ListQuarantine(c *gin.Context) {
+	rs, err := d.walMgr.Quarantine().List()
+	if err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.OK(c, rs)
+}
+
+// Reprocess reprocesses the quarantined replica message by given id.
+func (d *ReplicaAPI) Reprocess(c *gin.Context) {
+	var param struct {
+		ID string `form:"id" binding:"required"`
+	}
+	err := c.ShouldBindQuery(&param)
+	if err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	if err := d.walMgr.Quarantine().Reprocess(c.Request.Context(), param.ID); err != nil {
+		if errors.Is(err, replica.ErrQuarantineRecordNotFound) {
+			httppkg.NotFound(c)
+			return
+		}
+		d.logger.Error("reprocess quarantine record failure",
+			logger.String("id", param.ID), logger.Error(err))
+		httppkg.Error(c, err)
+		return
+	}
+	httppkg.NoContent(c)
+}
@@ -18,6 +18,7 @@
 package state
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -25,7 +26,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/common/pkg/encoding"
+
 	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/replica"
 )
 
@@ -48,3 +52,72 @@ func TestReplicaAPI_GetReplicaState(t *testing.T) {
 	resp = mock.DoRequest(t, r, http.MethodGet, ReplicaPath+"?db=test", "")
 	assert.Equal(t, http.StatusOK, resp.Code)
 }
+
+func TestReplicaAPI_GetReplicaLag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		ctrl.Finish()
+	}()
+
+	mgr := replica.NewMockWriteAheadLogManager(ctrl)
+	api := NewReplicaAPI(mgr)
+	r := gin.New()
+	api.Register(r)
+
+	// case 1: params invalid
+	resp := mock.DoRequest(t, r, http.MethodGet, ReplicaLagPath, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// case 2: get replica lag ok
+	mgr.EXPECT().GetReplicaState("test").Return([]models.FamilyLogReplicaState{{
+		ShardID:     1,
+		Append:      100,
+		Replicators: []models.ReplicaPeerState{{Replicator: "2", ACK: 90}},
+	}})
+	resp = mock.DoRequest(t, r, http.MethodGet, ReplicaLagPath+"?db=test", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var lags []models.ReplicaLag
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &lags))
+	assert.Equal(t, []models.ReplicaLag{{ShardID: 1, Replicator: "2", Append: 100, ACK: 90, Lag: 10}}, lags)
+}
+
+func TestReplicaAPI_Quarantine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		ctrl.Finish()
+	}()
+
+	mgr := replica.NewMockWriteAheadLogManager(ctrl)
+	quarantine := replica.NewMockQuarantine(ctrl)
+	mgr.EXPECT().Quarantine().Return(quarantine).AnyTimes()
+	api := NewReplicaAPI(mgr)
+	r := gin.New()
+	api.Register(r)
+
+	// case 1: list failure
+	quarantine.EXPECT().List().Return(nil, fmt.Errorf("err"))
+	resp := mock.DoRequest(t, r, http.MethodGet, QuarantinePath, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// case 2: list ok
+	quarantine.EXPECT().List().Return([]*models.QuarantineRecord{{ID: "id", Database: "test", Reason: "err"}}, nil)
+	resp = mock.DoRequest(t, r, http.MethodGet, QuarantinePath, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var records []*models.QuarantineRecord
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &records))
+	assert.Equal(t, []*models.QuarantineRecord{{ID: "id", Database: "test", Reason: "err"}}, records)
+
+	// case 3: reprocess params invalid
+	resp = mock.DoRequest(t, r, http.MethodPut, ReprocessPath, "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// case 4: record not found
+	quarantine.EXPECT().Reprocess(gomock.Any(), "id").Return(replica.ErrQuarantineRecordNotFound)
+	resp = mock.DoRequest(t, r, http.MethodPut, ReprocessPath+"?id=id", "")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	// case 5: reprocess failure
+	quarantine.EXPECT().Reprocess(gomock.Any(), "id").Return(fmt.Errorf("err"))
+	resp = mock.DoRequest(t, r, http.MethodPut, ReprocessPath+"?id=id", "")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	// case 6: reprocess ok
+	quarantine.EXPECT().Reprocess(gomock.Any(), "id").Return(nil)
+	resp = mock.DoRequest(t, r, http.MethodPut, ReprocessPath+"?id=id", "")
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
@@ -19,6 +19,7 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"path"
 	"time"
 
@@ -31,12 +32,18 @@ import (
 	"github.com/lindb/lindb/tsdb"
 )
 
+// for testing
+var drainCheckInterval = 100 * time.Millisecond
+
 //go:generate mockgen -source=./database_lifecycle.go -destination=./database_lifecycle_mock.go -package=storage
 
 // DatabaseLifecycle represents database's lifecycle manager include data and write ahead log.
 type DatabaseLifecycle interface {
 	// Startup startups database's lifecycle, includes background task(ttl etc.)
 	Startup()
+	// Drain waits in-flight replication of write ahead log completed, then flushes memory database,
+	// returns err if in-flight replication not completed before ctx done.
+	Drain(ctx context.Context) error
 	// Shutdown shutdowns database's lifecycle.
 	Shutdown()
 }
@@ -103,6 +110,41 @@ func (l *databaseLifecycle) Shutdown() {
 	}
 }
 
+// Drain waits in-flight replication of write ahead log completed, then flushes memory database,
+// returns err if in-flight replication not completed before ctx done(memory database also flushed).
+func (l *databaseLifecycle) Drain(ctx context.Context) (err error) {
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+
+	for pending := l.pendingReplicas(); pending > 0 && err == nil; pending = l.pendingReplicas() {
+		l.logger.Info("waiting in-flight replication completed...", logger.Any("pending", pending))
+		select {
+		case <-ctx.Done():
+			err = fmt.Errorf("drain timeout, %d pending replication messages: %w", pending, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+
+	for name, db := range l.engine.GetAllDatabases() {
+		if flushErr := db.Flush(); flushErr != nil {
+			l.logger.Error("flush database error when drain", logger.String("database", name), logger.Error(flushErr))
+		}
+	}
+	return err
+}
+
+// pendingReplicas returns num. of pending replication messages of all databases.
+func (l *databaseLifecycle) pendingReplicas() (pending int64) {
+	for name := range l.engine.GetAllDatabases() {
+		for _, familyState := range l.walMgr.GetReplicaState(name) {
+			for _, replicator := range familyState.Replicators {
+				pending += replicator.Pending
+			}
+		}
+	}
+	return pending
+}
+
 // ttlTask runs ttl task in background goroutine.
 func (l *databaseLifecycle) ttlTask() {
 	go func() {
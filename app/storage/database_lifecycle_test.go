@@ -25,11 +25,13 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/common/pkg/ltoml"
 
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/state"
 	"github.com/lindb/lindb/replica"
 	"github.com/lindb/lindb/tsdb"
@@ -163,3 +165,41 @@ func TestDatabaseLifecycle_dropDatabases(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseLifecycle_Drain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		drainCheckInterval = 100 * time.Millisecond
+		ctrl.Finish()
+	}()
+	drainCheckInterval = time.Millisecond
+
+	repo := state.NewMockRepository(ctrl)
+	walMgr := replica.NewMockWriteAheadLogManager(ctrl)
+	engine := tsdb.NewMockEngine(ctrl)
+	db := tsdb.NewMockDatabase(ctrl)
+	engine.EXPECT().GetAllDatabases().Return(map[string]tsdb.Database{"test": db}).AnyTimes()
+	dbLifecycle := NewDatabaseLifecycle(context.TODO(), repo, walMgr, engine)
+
+	replicaState := func(pending int64) []models.FamilyLogReplicaState {
+		return []models.FamilyLogReplicaState{{
+			Replicators: []models.ReplicaPeerState{{Replicator: "1", Pending: pending}, {Replicator: "2"}},
+		}}
+	}
+	// case 1: in-flight replication completed, then flush memory database
+	gomock.InOrder(
+		walMgr.EXPECT().GetReplicaState("test").Return(replicaState(2)),
+		walMgr.EXPECT().GetReplicaState("test").Return(replicaState(1)),
+		walMgr.EXPECT().GetReplicaState("test").Return(replicaState(0)),
+		db.EXPECT().Flush().Return(nil),
+	)
+	assert.NoError(t, dbLifecycle.Drain(context.TODO()))
+
+	// case 2: drain timeout, memory database also flushed
+	walMgr.EXPECT().GetReplicaState("test").Return(replicaState(2)).AnyTimes()
+	db.EXPECT().Flush().Return(fmt.Errorf("err"))
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	err := dbLifecycle.Drain(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -19,8 +19,11 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"io"
+	"time"
 
+	"go.uber.org/atomic"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -34,10 +37,17 @@ import (
 	"github.com/lindb/lindb/rpc"
 )
 
+var errWriteDraining = errors.New("storage is draining, reject write request")
+
 // WriteHandler implements protoWriteV1.WriteServiceServer interface for handling write rpc request.
 type WriteHandler struct {
 	walMgr replica.WriteAheadLogManager
 
+	ackBatchSize int32         // num. of records acknowledged by single response in batched ack mode
+	ackInterval  time.Duration // max interval of acknowledging pending records in batched ack mode
+
+	draining atomic.Bool // reject new write request if draining
+
 	logger logger.Logger
 }
 
@@ -46,13 +56,24 @@ func NewWriteHandler(
 	walMgr replica.WriteAheadLogManager,
 ) *WriteHandler {
 	return &WriteHandler{
-		walMgr: walMgr,
-		logger: logger.GetLogger("Storage", "WriteRPC"),
+		walMgr:       walMgr,
+		ackBatchSize: defaultWriteAckBatchSize,
+		ackInterval:  defaultWriteAckInterval,
+		logger:       logger.GetLogger("Storage", "WriteRPC"),
 	}
 }
 
-// Write does metric write request.
+// Drain stops accepting new write request, client need to retry other replica.
+func (r *WriteHandler) Drain() {
+	r.draining.Store(true)
+}
+
+// Write does metric write request, acknowledges write records in batch by default,
+// acknowledges every record if client requests per-record ack mode.
 func (r *WriteHandler) Write(server protoWriteV1.WriteService_WriteServer) error {
+	if r.draining.Load() {
+		return status.Error(codes.Unavailable, errWriteDraining.Error())
+	}
 	familyState, err := r.getFamilyInfoFromCtx(server.Context())
 	if err != nil {
 		r.logger.Error("get param err", logger.Error(err))
@@ -77,31 +98,48 @@ func (r *WriteHandler) Write(server protoWriteV1.WriteService_WriteServer) error
 		return status.Error(codes.Internal, err.Error())
 	}
 
+	ackBatchSize := r.ackBatchSize
+	if r.isPerRecordAck(server.Context()) {
+		ackBatchSize = 1
+	}
+	ack := newWriteAck(server, ackBatchSize, r.ackInterval)
+	defer ack.close()
+
 	// handle write request from stream
 	for {
 		req, err := server.Recv()
 		if err == io.EOF {
+			// acknowledge pending records before stream closed
+			if err := ack.flush(); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
 			return nil
 		}
 		if err != nil {
 			r.logger.Error("receive write request err", logger.Error(err))
 			return status.Error(codes.Internal, err.Error())
 		}
-
-		resp := &protoWriteV1.WriteResponse{}
-		// write wal log
-		err = p.WriteLog(req.Record)
-
-		if err != nil {
-			resp.Err = err.Error()
+		if r.draining.Load() {
+			// acknowledge accepted records, reject the others
+			if err := ack.flush(); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			return status.Error(codes.Unavailable, errWriteDraining.Error())
 		}
 
-		if err := server.Send(resp); err != nil {
+		// write wal log
+		if err := ack.ack(p.WriteLog(req.Record)); err != nil {
 			return status.Error(codes.Internal, err.Error())
 		}
 	}
 }
 
+// isPerRecordAck returns if client requests per-record ack mode.
+func (r *WriteHandler) isPerRecordAck(ctx context.Context) bool {
+	ackMode, err := rpc.GetStringFromContext(ctx, constants.RPCMetaKeyWriteAck)
+	return err == nil && ackMode == constants.RPCMetaWriteAckPerRecord
+}
+
 // getFamilyInfoFromCtx returns family state metadata from rpc context.
 func (r *WriteHandler) getFamilyInfoFromCtx(ctx context.Context) (familyState models.FamilyState, err error) {
 	familyStateDate, err := rpc.GetStringFromContext(ctx, constants.RPCMetaKeyFamilyState)
@@ -0,0 +1,140 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	protoWriteV1 "github.com/lindb/lindb/proto/gen/v1/write"
+	"github.com/lindb/lindb/replica"
+	"github.com/lindb/lindb/rpc"
+)
+
+const (
+	// defaultWriteAckBatchSize is the num. of records acknowledged by single response in batched ack mode.
+	defaultWriteAckBatchSize = 128
+	// defaultWriteAckInterval is the max interval of acknowledging pending records in batched ack mode.
+	defaultWriteAckInterval = 50 * time.Millisecond
+)
+
+// writeAck buffers the acks of write records, sends single response with the count of records
+// for every N records or every T interval, reducing response stream chatter.
+// If write record failure, sends response with the offset of failure record immediately.
+type writeAck struct {
+	server    protoWriteV1.WriteService_WriteServer
+	batchSize int32
+
+	pending int32 // num. of records not acknowledged
+	err     error // send response err
+	stopCh  chan struct{}
+	wait    sync.WaitGroup
+	lock    sync.Mutex
+}
+
+// newWriteAck creates a write ack, acknowledges every record if batch size <= 1,
+// else starts a background task which acknowledges pending records every interval.
+func newWriteAck(server protoWriteV1.WriteService_WriteServer, batchSize int32, interval time.Duration) *writeAck {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	ack := &writeAck{
+		server:    server,
+		batchSize: batchSize,
+		stopCh:    make(chan struct{}),
+	}
+	if batchSize > 1 {
+		ack.wait.Add(1)
+		go ack.flushLoop(interval)
+	}
+	return ack
+}
+
+// ack acknowledges a write record with the write result,
+// sends response if pending records reach batch size or write failure.
+func (a *writeAck) ack(writeErr error) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.err != nil {
+		return a.err
+	}
+	a.pending++
+	if writeErr != nil {
+		// report failure with the offset of failure record in this ack batch
+		return a.send(&protoWriteV1.WriteResponse{Err: encodeWriteError(writeErr), Count: a.pending, Offset: a.pending - 1})
+	}
+	if a.pending >= a.batchSize {
+		return a.send(&protoWriteV1.WriteResponse{Count: a.pending})
+	}
+	return nil
+}
+
+// encodeWriteError encodes write error with class prefix, client decides to retry or give up by it:
+//   - retryable: shard temporarily unavailable(shard channel not found, invalid shard id, channel backpressure,
+//     family channel canceled, ingest timeout), see replica.IsRetryableError;
+//   - fatal: others, e.g. parse/validation error of write record.
+func encodeWriteError(err error) string {
+	if replica.IsRetryableError(err) {
+		return rpc.EncodeWriteError(rpc.WriteErrorRetryable, err)
+	}
+	return rpc.EncodeWriteError(rpc.WriteErrorFatal, err)
+}
+
+// flush acknowledges all pending records.
+func (a *writeAck) flush() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.err != nil || a.pending == 0 {
+		return a.err
+	}
+	return a.send(&protoWriteV1.WriteResponse{Count: a.pending})
+}
+
+// send sends response, then resets pending records.
+func (a *writeAck) send(resp *protoWriteV1.WriteResponse) error {
+	a.pending = 0
+	if err := a.server.Send(resp); err != nil {
+		a.err = err
+		return err
+	}
+	return nil
+}
+
+// flushLoop acknowledges pending records every interval until stopped.
+func (a *writeAck) flushLoop(interval time.Duration) {
+	defer a.wait.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.stopCh:
+			return
+		case <-ticker.C:
+			_ = a.flush()
+		}
+	}
+}
+
+// close stops the background flush task, stream cannot send response after handler returned.
+func (a *writeAck) close() {
+	close(a.stopCh)
+	a.wait.Wait()
+}
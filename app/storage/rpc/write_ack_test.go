@@ -0,0 +1,100 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	protoWriteV1 "github.com/lindb/lindb/proto/gen/v1/write"
+	"github.com/lindb/lindb/replica"
+)
+
+func TestWriteAck_PerRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := protoWriteV1.NewMockWriteService_WriteServer(ctrl)
+	ack := newWriteAck(server, 0, time.Millisecond)
+	defer ack.close()
+
+	server.EXPECT().Send(&protoWriteV1.WriteResponse{Count: 1}).Return(nil)
+	assert.NoError(t, ack.ack(nil))
+	server.EXPECT().Send(&protoWriteV1.WriteResponse{Count: 1, Err: "[fatal] err"}).Return(nil)
+	assert.NoError(t, ack.ack(fmt.Errorf("err")))
+	server.EXPECT().Send(&protoWriteV1.WriteResponse{Count: 1, Err: "[retryable] " + replica.ErrChannelBackpressure.Error()}).Return(nil)
+	assert.NoError(t, ack.ack(replica.ErrChannelBackpressure))
+	// nothing pending
+	assert.NoError(t, ack.flush())
+}
+
+func TestWriteAck_Batch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := protoWriteV1.NewMockWriteService_WriteServer(ctrl)
+	ack := newWriteAck(server, 3, time.Hour)
+
+	// ack every 3 records
+	server.EXPECT().Send(&protoWriteV1.WriteResponse{Count: 3}).Return(nil)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, ack.ack(nil))
+	}
+	// report failure record with offset immediately
+	assert.NoError(t, ack.ack(nil))
+	server.EXPECT().Send(&protoWriteV1.WriteResponse{Count: 2, Offset: 1, Err: "[fatal] err"}).Return(nil)
+	assert.NoError(t, ack.ack(fmt.Errorf("err")))
+	// flush pending records
+	assert.NoError(t, ack.ack(nil))
+	server.EXPECT().Send(&protoWriteV1.WriteResponse{Count: 1}).Return(nil)
+	assert.NoError(t, ack.flush())
+	// send failure
+	assert.NoError(t, ack.ack(nil))
+	server.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, ack.flush())
+	assert.Error(t, ack.ack(nil))
+	assert.Error(t, ack.flush())
+	ack.close()
+}
+
+func TestWriteAck_FlushLoop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := protoWriteV1.NewMockWriteService_WriteServer(ctrl)
+	ack := newWriteAck(server, 100, 10*time.Millisecond)
+	defer ack.close()
+
+	sent := make(chan *protoWriteV1.WriteResponse, 1)
+	server.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *protoWriteV1.WriteResponse) error {
+		sent <- resp
+		return nil
+	})
+	assert.NoError(t, ack.ack(nil))
+	assert.NoError(t, ack.ack(nil))
+	select {
+	case resp := <-sent:
+		assert.Equal(t, int32(2), resp.Count)
+	case <-time.After(time.Second):
+		assert.Fail(t, "pending records not acknowledged")
+	}
+}
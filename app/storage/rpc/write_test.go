@@ -23,10 +23,13 @@ import (
 	"io"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/lindb/lindb/constants"
 	protoWriteV1 "github.com/lindb/lindb/proto/gen/v1/write"
@@ -101,4 +104,92 @@ func TestWriteHandler_Write(t *testing.T) {
 	replicaServer.EXPECT().Recv().Return(nil, io.EOF)
 	err = r.Write(replicaServer)
 	assert.NoError(t, err)
+	// case 11: ack pending records err when stream closed
+	replicaServer.EXPECT().Recv().Return(&protoWriteV1.WriteRequest{}, nil)
+	p.EXPECT().WriteLog(gomock.Any()).Return(nil)
+	replicaServer.EXPECT().Recv().Return(nil, io.EOF)
+	replicaServer.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
+	err = r.Write(replicaServer)
+	assert.Error(t, err)
+}
+
+func TestWriteHandler_Write_AckMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	walMgr := replica.NewMockWriteAheadLogManager(ctrl)
+	wal := replica.NewMockWriteAheadLog(ctrl)
+	p := replica.NewMockPartition(ctrl)
+	walMgr.EXPECT().GetOrCreateLog(gomock.Any()).Return(wal).AnyTimes()
+	wal.EXPECT().GetOrCreatePartition(gomock.Any(), gomock.Any(), gomock.Any()).Return(p, nil).AnyTimes()
+	p.EXPECT().BuildReplicaForLeader(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	r := NewWriteHandler(walMgr)
+	r.ackInterval = time.Hour
+	familyState := `{"database":"test-db","shard":{"id":1,"leader":2,"replica":{"replicas":[1,2,3]}},"familyTime":12321}`
+
+	write := func(ctx context.Context, expectResps ...*protoWriteV1.WriteResponse) {
+		server := protoWriteV1.NewMockWriteService_WriteServer(ctrl)
+		server.EXPECT().Context().Return(ctx).AnyTimes()
+		server.EXPECT().Recv().Return(&protoWriteV1.WriteRequest{}, nil).Times(3)
+		server.EXPECT().Recv().Return(nil, io.EOF)
+		gomock.InOrder(
+			p.EXPECT().WriteLog(gomock.Any()).Return(nil),
+			p.EXPECT().WriteLog(gomock.Any()).Return(fmt.Errorf("err")),
+			p.EXPECT().WriteLog(gomock.Any()).Return(nil),
+		)
+		var calls []*gomock.Call
+		for _, resp := range expectResps {
+			calls = append(calls, server.EXPECT().Send(resp).Return(nil))
+		}
+		gomock.InOrder(calls...)
+		assert.NoError(t, r.Write(server))
+	}
+
+	// batched ack
+	write(metadata.NewIncomingContext(context.TODO(),
+		metadata.Pairs(constants.RPCMetaKeyFamilyState, familyState)),
+		&protoWriteV1.WriteResponse{Count: 2, Offset: 1, Err: "[fatal] err"},
+		&protoWriteV1.WriteResponse{Count: 1},
+	)
+	// per-record ack requested by client
+	write(metadata.NewIncomingContext(context.TODO(),
+		metadata.Pairs(constants.RPCMetaKeyFamilyState, familyState,
+			constants.RPCMetaKeyWriteAck, constants.RPCMetaWriteAckPerRecord)),
+		&protoWriteV1.WriteResponse{Count: 1},
+		&protoWriteV1.WriteResponse{Count: 1, Err: "[fatal] err"},
+		&protoWriteV1.WriteResponse{Count: 1},
+	)
+}
+
+func TestWriteHandler_Write_Draining(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	walMgr := replica.NewMockWriteAheadLogManager(ctrl)
+	wal := replica.NewMockWriteAheadLog(ctrl)
+	p := replica.NewMockPartition(ctrl)
+	walMgr.EXPECT().GetOrCreateLog(gomock.Any()).Return(wal).AnyTimes()
+	wal.EXPECT().GetOrCreatePartition(gomock.Any(), gomock.Any(), gomock.Any()).Return(p, nil).AnyTimes()
+	p.EXPECT().BuildReplicaForLeader(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	r := NewWriteHandler(walMgr)
+	r.ackInterval = time.Hour
+	ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs(constants.RPCMetaKeyFamilyState,
+		`{"database":"test-db","shard":{"id":1,"leader":2,"replica":{"replicas":[1,2,3]}},"familyTime":12321}`))
+
+	// draining during stream, accepted records acknowledged
+	server := protoWriteV1.NewMockWriteService_WriteServer(ctrl)
+	server.EXPECT().Context().Return(ctx).AnyTimes()
+	server.EXPECT().Recv().Return(&protoWriteV1.WriteRequest{}, nil)
+	p.EXPECT().WriteLog(gomock.Any()).DoAndReturn(func(_ []byte) error {
+		r.Drain()
+		return nil
+	})
+	server.EXPECT().Recv().Return(&protoWriteV1.WriteRequest{}, nil)
+	server.EXPECT().Send(&protoWriteV1.WriteResponse{Count: 1}).Return(nil)
+	err := r.Write(server)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// new stream rejected
+	err = r.Write(server)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
 }
@@ -60,6 +60,9 @@ import (
 	"github.com/lindb/lindb/tsdb"
 )
 
+// defaultDrainTimeout represents the max time of waiting in-flight writes/replication completed when stopping.
+const defaultDrainTimeout = 30 * time.Second
+
 // factory represents all factories for storage
 type factory struct {
 	taskServer rpc.TaskServerFactory
@@ -344,18 +347,62 @@ func (r *runtime) startStateRepo() error {
 	return nil
 }
 
-// Stop stops storage server
+// Drain drains in-flight writes and replication before stopped, stops accepting new writes,
+// flushes memory databases after replication completed or timeout.
+func (r *runtime) Drain(timeout time.Duration) error {
+	if r.state != server.Running {
+		return nil
+	}
+	r.log.Info("draining storage server...", logger.String("timeout", timeout.String()))
+	r.state = server.Draining
+
+	if r.rpcHandler != nil {
+		r.rpcHandler.write.Drain()
+	}
+	if r.dbLifecycle == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(r.ctx, timeout)
+	defer cancel()
+	if err := r.dbLifecycle.Drain(ctx); err != nil {
+		r.log.Warn("drain storage server with error", logger.Error(err))
+		return err
+	}
+	r.log.Info("drained storage server successfully")
+	return nil
+}
+
+// Stop stops storage server, the shutdown sequence is:
+// 1. stop accepting rpc/http requests;
+// 2. drain in-flight writes/replication and flush memory database;
+// 3. stop replication channels, then close tsdb engine and write ahead log;
+// 4. deregister from coordinator and close state repo.
+// tsdb engine must be closed after replication channels stopped, else replicator maybe write closed engine.
 func (r *runtime) Stop() {
 	r.log.Info("stopping storage server...")
 	defer r.cancel()
 
+	r.stopServers()
+
+	_ = r.Drain(defaultDrainTimeout)
+
 	r.Shutdown()
 
 	if r.jobScheduler != nil {
 		r.jobScheduler.Shutdown()
 	}
 
-	// close state repo if exist
+	// stop handling coordinator events(create shard etc.) before closing tsdb engine
+	if r.stateMgr != nil {
+		r.stateMgr.Close()
+	}
+
+	// stop replication channels, then close tsdb engine/write ahead log
+	if r.dbLifecycle != nil {
+		r.dbLifecycle.Shutdown()
+	}
+
+	// finally, deregister from coordinator and close state repo
 	if r.repo != nil {
 		r.log.Info("closing state repo...")
 		if err := r.repo.Delete(r.ctx, constants.GetLiveNodePath(strconv.Itoa(int(r.node.ID)))); err != nil {
@@ -368,10 +415,12 @@ func (r *runtime) Stop() {
 		}
 	}
 
-	if r.stateMgr != nil {
-		r.stateMgr.Close()
-	}
+	r.log.Info("stopped storage server successfully")
+	r.state = server.Terminated
+}
 
+// stopServers stops http/rpc server for rejecting new requests.
+func (r *runtime) stopServers() {
 	if r.httpServer != nil {
 		r.log.Info("stopping http server...")
 		if err := r.httpServer.Close(r.ctx); err != nil {
@@ -381,19 +430,11 @@ func (r *runtime) Stop() {
 		}
 	}
 
-	// finally, shutdown rpc server
 	if r.server != nil {
 		r.log.Info("stopping GRPC server...")
 		r.server.Stop()
 		r.log.Info("stopped GRPC server")
 	}
-
-	if r.dbLifecycle != nil {
-		r.dbLifecycle.Shutdown()
-	}
-
-	r.log.Info("stopped storage server successfully")
-	r.state = server.Terminated
 }
 
 // startHTTPServer starts http server for api rpcHandler
@@ -404,7 +445,8 @@ func (r *runtime) startHTTPServer() {
 	}
 
 	r.httpServer = httppkg.NewServer(r.config.StorageBase.HTTP, false, linmetric.StorageRegistry)
-	exploreAPI := api.NewExploreAPI(r.globalKeyValues, linmetric.StorageRegistry)
+	exploreAPI := api.NewExploreAPI(r.globalKeyValues, linmetric.StorageRegistry).
+		WithExemplars(r.config.Monitor.Exemplars)
 	v1 := r.httpServer.GetAPIRouter().Group(constants.APIVersion1)
 	exploreAPI.Register(v1)
 	replicaAPI := stateapi.NewReplicaAPI(r.walMgr)
@@ -413,7 +455,7 @@ func (r *runtime) startHTTPServer() {
 	tsdbStateAPI.Register(v1)
 	stateMachineAPI := stateapi.NewStorageStateMachineAPI(r.stateMgr)
 	stateMachineAPI.Register(v1)
-	logAPI := api.NewLoggerAPI(r.config.Logging.Dir)
+	logAPI := api.NewLoggerAPI(r.config.Logging.Dir, int64(r.config.Monitor.MaxLogViewSize))
 	logAPI.Register(v1)
 	configAPI := api.NewConfigAPI(r.node, r.config)
 	configAPI.Register(v1)
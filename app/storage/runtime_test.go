@@ -31,8 +31,10 @@ import (
 
 	"github.com/lindb/common/pkg/encoding"
 	"github.com/lindb/common/pkg/fileutil"
+	"github.com/lindb/common/pkg/logger"
 	"github.com/lindb/common/pkg/ltoml"
 
+	rpchandler "github.com/lindb/lindb/app/storage/rpc"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
 	storagepkg "github.com/lindb/lindb/coordinator/storage"
@@ -73,6 +75,7 @@ func TestStorageRun(t *testing.T) {
 
 	dbLifecycle := NewMockDatabaseLifecycle(ctrl)
 	dbLifecycle.EXPECT().Startup()
+	dbLifecycle.EXPECT().Drain(gomock.Any()).Return(nil)
 	dbLifecycle.EXPECT().Shutdown()
 	newDatabaseLifecycleFn = func(ctx context.Context, repo state.Repository,
 		walMgr replica.WriteAheadLogManager, engine tsdb.Engine) DatabaseLifecycle {
@@ -122,6 +125,7 @@ func TestStorageRun_GetHost_Err(t *testing.T) {
 	}()
 	dbLifecycle := NewMockDatabaseLifecycle(ctrl)
 	dbLifecycle.EXPECT().Startup()
+	dbLifecycle.EXPECT().Drain(gomock.Any()).Return(nil)
 	dbLifecycle.EXPECT().Shutdown()
 	newDatabaseLifecycleFn = func(ctx context.Context, repo state.Repository,
 		walMgr replica.WriteAheadLogManager, engine tsdb.Engine) DatabaseLifecycle {
@@ -355,3 +359,63 @@ func TestStorage_Run_With_Wrong_MyID(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, server.Failed, r.State())
 }
+
+func TestStorage_Drain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbLifecycle := NewMockDatabaseLifecycle(ctrl)
+	r := &runtime{
+		state:       server.Running,
+		ctx:         context.TODO(),
+		dbLifecycle: dbLifecycle,
+		rpcHandler:  &rpcHandler{write: rpchandler.NewWriteHandler(nil)},
+		log:         logger.GetLogger("Storage", "Test"),
+	}
+	dbLifecycle.EXPECT().Drain(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, r.Drain(time.Second))
+	assert.Equal(t, server.Draining, r.State())
+	// already draining
+	assert.NoError(t, r.Drain(time.Second))
+
+	r = &runtime{state: server.Running, log: logger.GetLogger("Storage", "Test")}
+	assert.NoError(t, r.Drain(time.Second))
+	assert.Equal(t, server.Draining, r.State())
+}
+
+func TestStorage_Stop_Order(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	walMgr := replica.NewMockWriteAheadLogManager(ctrl)
+	engine := tsdb.NewMockEngine(ctrl)
+	stateMgr := storagepkg.NewMockStateManager(ctrl)
+	grpcServer := rpc.NewMockGRPCServer(ctrl)
+	ctx, cancel := context.WithCancel(context.TODO())
+	r := &runtime{
+		state:       server.Running,
+		ctx:         ctx,
+		cancel:      cancel,
+		node:        &models.StatefulNode{ID: 1},
+		repo:        repo,
+		server:      grpcServer,
+		stateMgr:    stateMgr,
+		dbLifecycle: NewDatabaseLifecycle(ctx, repo, walMgr, engine),
+		rpcHandler:  &rpcHandler{write: rpchandler.NewWriteHandler(nil)},
+		log:         logger.GetLogger("Storage", "Test"),
+	}
+	engine.EXPECT().GetAllDatabases().Return(nil).AnyTimes()
+	// engine must be closed after replication channels stopped, state repo closed last
+	gomock.InOrder(
+		grpcServer.EXPECT().Stop(),
+		stateMgr.EXPECT().Close(),
+		walMgr.EXPECT().Stop(),
+		engine.EXPECT().Close(),
+		walMgr.EXPECT().Close().Return(nil),
+		repo.EXPECT().Delete(gomock.Any(), constants.GetLiveNodePath("1")).Return(nil),
+		repo.EXPECT().Close().Return(nil),
+	)
+	r.Stop()
+	assert.Equal(t, server.Terminated, r.State())
+}
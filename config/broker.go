@@ -113,6 +113,12 @@ type Write struct {
 	BatchTimeout   ltoml.Duration `env:"BATCH_TIMEOUT" toml:"batch-timeout"`
 	BatchBlockSize ltoml.Size     `env:"BLOCK_SIZE" toml:"batch-block-size"`
 	GCTaskInterval ltoml.Duration `env:"GC_INTERVAL" toml:"gc-task-interval"`
+	// MaxInFlightRows is the max num. of rows in flight(being written) of each shard channel,
+	// write is rejected with backpressure error if exceeded.
+	MaxInFlightRows int `env:"MAX_IN_FLIGHT_ROWS" toml:"max-in-flight-rows"`
+	// MaxDedupBatchIDs is the max num. of recent batch ids remembered by each shard channel for write idempotency,
+	// batch written with a remembered batch id is ignored.
+	MaxDedupBatchIDs int `env:"MAX_DEDUP_BATCH_IDS" toml:"max-dedup-batch-ids"`
 }
 
 func (rc *Write) TOML() string {
@@ -129,13 +135,27 @@ batch-block-size = "%s"
 ## interval for how often expired write write family garbage collect task execute
 ## Default: %s
 ## Env: LINDB_BROKER_WRITE_GC_INTERVAL
-gc-task-interval = "%s"`,
+gc-task-interval = "%s"
+## max number of rows in flight(being written) of each shard,
+## write will be rejected(http status 429) if exceeded, client need back off.
+## Default: %d
+## Env: LINDB_BROKER_WRITE_MAX_IN_FLIGHT_ROWS
+max-in-flight-rows = %d
+## max number of recent batch ids(X-LinDB-Batch-ID header of write request) remembered by each shard,
+## batch retried with a remembered batch id is ignored.
+## Default: %d
+## Env: LINDB_BROKER_WRITE_MAX_DEDUP_BATCH_IDS
+max-dedup-batch-ids = %d`,
 		rc.BatchTimeout.String(),
 		rc.BatchTimeout.String(),
 		rc.BatchBlockSize.String(),
 		rc.BatchBlockSize.String(),
 		rc.GCTaskInterval.String(),
 		rc.GCTaskInterval.String(),
+		rc.MaxInFlightRows,
+		rc.MaxInFlightRows,
+		rc.MaxDedupBatchIDs,
+		rc.MaxDedupBatchIDs,
 	)
 }
 
@@ -193,9 +213,11 @@ func NewDefaultBrokerBase() *BrokerBase {
 			IngestTimeout:  ltoml.Duration(time.Second * 5),
 		},
 		Write: Write{
-			BatchTimeout:   ltoml.Duration(time.Second * 2),
-			BatchBlockSize: ltoml.Size(256 * 1024),
-			GCTaskInterval: ltoml.Duration(time.Minute),
+			BatchTimeout:     ltoml.Duration(time.Second * 2),
+			BatchBlockSize:   ltoml.Size(256 * 1024),
+			GCTaskInterval:   ltoml.Duration(time.Minute),
+			MaxInFlightRows:  100000,
+			MaxDedupBatchIDs: 4096,
 		},
 		GRPC: GRPC{
 			Port:                 9001,
@@ -287,6 +309,12 @@ func checkBrokerBaseCfg(brokerBaseCfg *BrokerBase) error {
 	if brokerBaseCfg.Write.GCTaskInterval <= 0 {
 		brokerBaseCfg.Write.GCTaskInterval = defaultBrokerCfg.Write.GCTaskInterval
 	}
+	if brokerBaseCfg.Write.MaxInFlightRows <= 0 {
+		brokerBaseCfg.Write.MaxInFlightRows = defaultBrokerCfg.Write.MaxInFlightRows
+	}
+	if brokerBaseCfg.Write.MaxDedupBatchIDs <= 0 {
+		brokerBaseCfg.Write.MaxDedupBatchIDs = defaultBrokerCfg.Write.MaxDedupBatchIDs
+	}
 
 	return nil
 }
@@ -58,6 +58,11 @@ func TestBroker_Env(t *testing.T) {
 		"LINDB_QUERY_CONCURRENCY":                  "100",
 		"LINDB_QUERY_IDLE_TIMEOUT":                 "100s",
 		"LINDB_QUERY_TIMEOUT":                      "120s",
+		"LINDB_QUERY_MAX_FAN_OUT":                  "64",
+		"LINDB_QUERY_DATABASE_CONCURRENCY":         "8",
+		"LINDB_QUERY_MAX_GROUP_BY_TAGS":            "16",
+		"LINDB_QUERY_RESULT_CACHE_SIZE":            "128",
+		"LINDB_QUERY_RESULT_CACHE_TTL":             "10s",
 		"LINDB_BROKER_SLOW_SQL":                    "120s",
 		"LINDB_BROKER_HTTP_PORT":                   "3000",
 		"LINDB_BROKER_HTTP_IDLE_TIMEOUT":           "120s",
@@ -93,6 +98,11 @@ func TestBroker_Env(t *testing.T) {
 	assert.Equal(t, 100, cfg.Query.QueryConcurrency)
 	assert.Equal(t, ltoml.Duration(time.Second*100), cfg.Query.IdleTimeout)
 	assert.Equal(t, ltoml.Duration(time.Second*120), cfg.Query.Timeout)
+	assert.Equal(t, 64, cfg.Query.MaxFanOut)
+	assert.Equal(t, 8, cfg.Query.DatabaseQueryConcurrency)
+	assert.Equal(t, 16, cfg.Query.MaxGroupByTags)
+	assert.Equal(t, 128, cfg.Query.ResultCacheSize)
+	assert.Equal(t, ltoml.Duration(time.Second*10), cfg.Query.ResultCacheTTL)
 	assert.Equal(t, ltoml.Duration(time.Second*120), cfg.BrokerBase.SlowSQL)
 	assert.Equal(t, uint16(3000), cfg.BrokerBase.HTTP.Port)
 	assert.Equal(t, ltoml.Duration(time.Second*120), cfg.BrokerBase.HTTP.WriteTimeout)
@@ -112,6 +112,8 @@ func Test_checkBrokerBaseCfg(t *testing.T) {
 	assert.NotZero(t, brokerCfg3.HTTP.IdleTimeout)
 	assert.NotZero(t, brokerCfg3.HTTP.WriteTimeout)
 	assert.NotZero(t, brokerCfg3.Ingestion.IngestTimeout)
+	assert.Equal(t, NewDefaultBrokerBase().Write.MaxInFlightRows, brokerCfg3.Write.MaxInFlightRows)
+	assert.Equal(t, NewDefaultBrokerBase().Write.MaxDedupBatchIDs, brokerCfg3.Write.MaxDedupBatchIDs)
 }
 
 func Test_checkStorageBaseCfg(t *testing.T) {
@@ -145,6 +147,17 @@ func Test_checkStorageBaseCfg(t *testing.T) {
 	assert.NotZero(t, storageCfg4.TSDB.MaxMemUsageBeforeFlush)
 	assert.NotZero(t, storageCfg4.TSDB.TargetMemUsageAfterFlush)
 	assert.NotZero(t, storageCfg4.TSDB.FlushConcurrency)
+	assert.NotZero(t, storageCfg4.TSDB.MetaCacheTTL)
+	assert.Equal(t, 100000, storageCfg4.TSDB.MaxPendingSeries)
+	assert.Equal(t, "none", storageCfg4.WAL.CompressCodec)
+
+	// wal compress codec error
+	storageCfg5 := &StorageBase{
+		GRPC: GRPC{Port: 2379},
+		WAL:  WAL{CompressCodec: "lz4"},
+		TSDB: TSDB{Dir: "/tmp/lindb"},
+	}
+	assert.Error(t, checkStorageBaseCfg(storageCfg5))
 }
 
 func Test_checkCoordinatorCfg(t *testing.T) {
@@ -170,9 +170,16 @@ type StorageCluster struct {
 
 // Query represents query rpc config
 type Query struct {
-	QueryConcurrency int            `env:"CONCURRENCY" toml:"query-concurrency"`
-	IdleTimeout      ltoml.Duration `env:"IDLE_TIMEOUT" toml:"idle-timeout"`
-	Timeout          ltoml.Duration `env:"TIMEOUT" toml:"timeout"`
+	QueryConcurrency         int            `env:"CONCURRENCY" toml:"query-concurrency"`
+	DatabaseQueryConcurrency int            `env:"DATABASE_CONCURRENCY" toml:"database-query-concurrency"`
+	IdleTimeout              ltoml.Duration `env:"IDLE_TIMEOUT" toml:"idle-timeout"`
+	Timeout                  ltoml.Duration `env:"TIMEOUT" toml:"timeout"`
+	MaxFanOut                int            `env:"MAX_FAN_OUT" toml:"max-fan-out"`
+	MaxGroupByTags           int            `env:"MAX_GROUP_BY_TAGS" toml:"max-group-by-tags"`
+	ResultCacheSize          int            `env:"RESULT_CACHE_SIZE" toml:"result-cache-size"`
+	ResultCacheTTL           ltoml.Duration `env:"RESULT_CACHE_TTL" toml:"result-cache-ttl"`
+	PlanEncoding             string         `env:"PLAN_ENCODING" toml:"plan-encoding"`
+	NotFoundPolicy           string         `env:"NOT_FOUND_POLICY" toml:"not-found-policy"`
 }
 
 func (q *Query) TOML() string {
@@ -181,6 +188,11 @@ func (q *Query) TOML() string {
 ## Default: %d
 ## Env: LINDB_QUERY_CONCURRENCY
 query-concurrency = %d
+## Number of queries of each database allowed to execute concurrently,
+## isolates queries between databases, so that one database cannot starve others.
+## Default: %d
+## Env: LINDB_QUERY_DATABASE_CONCURRENCY
+database-query-concurrency = %d
 ## Idle worker will be canceled in this duration
 ## Default: %s
 ## Env: LINDB_QUERY_IDLE_TIMEOUT
@@ -188,21 +200,71 @@ idle-timeout = "%s"
 ## Maximum timeout threshold for query.
 ## Default: %s
 ## Env: LINDB_QUERY_TIMEOUT
-timeout = "%s"`,
+timeout = "%s"
+## Maximum number of target nodes which a query can fan out to.
+## Default: %d
+## Env: LINDB_QUERY_MAX_FAN_OUT
+max-fan-out = %d
+## Maximum number of group by tag keys after group by * expanded.
+## Default: %d
+## Env: LINDB_QUERY_MAX_GROUP_BY_TAGS
+max-group-by-tags = %d
+## Maximum number of query result sets cached in broker, identical queries are served from cache.
+## Default: %d
+## Env: LINDB_QUERY_RESULT_CACHE_SIZE
+result-cache-size = %d
+## TTL of cached query result set.
+## Default: %s
+## Env: LINDB_QUERY_RESULT_CACHE_TTL
+result-cache-ttl = "%s"
+## Encoding of physical plan in task request sent to other nodes(json/protobuf),
+## protobuf plan is smaller, only switch to protobuf after all nodes upgraded.
+## Default: %s
+## Env: LINDB_QUERY_PLAN_ENCODING
+plan-encoding = "%s"
+## Policy of data query when target nodes return not found(e.g. metric not exist in shard),
+## all: fails query only if all target nodes return not found,
+## strict: fails query if any shard returns not found,
+## lenient: never fails query because of not found, returns partial(maybe empty) result,
+## the shards not found are returned in shard coverage of result set.
+## Default: %s
+## Env: LINDB_QUERY_NOT_FOUND_POLICY
+not-found-policy = "%s"`,
 		q.QueryConcurrency,
 		q.QueryConcurrency,
+		q.DatabaseQueryConcurrency,
+		q.DatabaseQueryConcurrency,
 		q.IdleTimeout,
 		q.IdleTimeout,
 		q.Timeout,
 		q.Timeout,
+		q.MaxFanOut,
+		q.MaxFanOut,
+		q.MaxGroupByTags,
+		q.MaxGroupByTags,
+		q.ResultCacheSize,
+		q.ResultCacheSize,
+		q.ResultCacheTTL,
+		q.ResultCacheTTL,
+		q.PlanEncoding,
+		q.PlanEncoding,
+		q.NotFoundPolicy,
+		q.NotFoundPolicy,
 	)
 }
 
 func NewDefaultQuery() *Query {
 	return &Query{
-		QueryConcurrency: 1024,
-		IdleTimeout:      ltoml.Duration(5 * time.Second),
-		Timeout:          ltoml.Duration(5 * time.Second),
+		QueryConcurrency:         1024,
+		DatabaseQueryConcurrency: 256,
+		IdleTimeout:              ltoml.Duration(5 * time.Second),
+		Timeout:                  ltoml.Duration(5 * time.Second),
+		MaxFanOut:                256,
+		MaxGroupByTags:           constants.MaxGroupByTagKeys,
+		ResultCacheSize:          1024,
+		ResultCacheTTL:           ltoml.Duration(5 * time.Second),
+		PlanEncoding:             "json",
+		NotFoundPolicy:           "all",
 	}
 }
 
@@ -243,10 +305,31 @@ func checkQueryCfg(queryCfg *Query) {
 	if queryCfg.QueryConcurrency <= 0 {
 		queryCfg.QueryConcurrency = defaultQuery.QueryConcurrency
 	}
+	if queryCfg.DatabaseQueryConcurrency <= 0 {
+		queryCfg.DatabaseQueryConcurrency = defaultQuery.DatabaseQueryConcurrency
+	}
 	if queryCfg.Timeout <= 0 {
 		queryCfg.Timeout = defaultQuery.Timeout
 	}
 	if queryCfg.IdleTimeout <= 0 {
 		queryCfg.IdleTimeout = defaultQuery.IdleTimeout
 	}
+	if queryCfg.MaxFanOut <= 0 {
+		queryCfg.MaxFanOut = defaultQuery.MaxFanOut
+	}
+	if queryCfg.MaxGroupByTags <= 0 {
+		queryCfg.MaxGroupByTags = defaultQuery.MaxGroupByTags
+	}
+	if queryCfg.ResultCacheSize <= 0 {
+		queryCfg.ResultCacheSize = defaultQuery.ResultCacheSize
+	}
+	if queryCfg.ResultCacheTTL <= 0 {
+		queryCfg.ResultCacheTTL = defaultQuery.ResultCacheTTL
+	}
+	if queryCfg.PlanEncoding == "" {
+		queryCfg.PlanEncoding = defaultQuery.PlanEncoding
+	}
+	if queryCfg.NotFoundPolicy == "" {
+		queryCfg.NotFoundPolicy = defaultQuery.NotFoundPolicy
+	}
 }
@@ -36,6 +36,8 @@ type Monitor struct {
 	PushTimeout    ltoml.Duration `env:"PUSH_TIMEOUT" toml:"push-timeout"`
 	ReportInterval ltoml.Duration `env:"REPORT_INTERVAL" toml:"report-interval"`
 	URL            string         `env:"URL" toml:"url"`
+	Exemplars      bool           `env:"EXEMPLARS" toml:"exemplars"`
+	MaxLogViewSize ltoml.Size     `env:"MAX_LOG_VIEW_SIZE" toml:"max-log-view-size"`
 }
 
 // TOML returns Monitor's toml config
@@ -55,13 +57,26 @@ report-interval = "%s"
 ## URL is the target of broker native ingestion url
 ## Default: %s
 ## Env: LINDB_MONITOR_URL
-url = "%s"`,
+url = "%s"
+## Exemplars enables exporting exemplars(trace id) in OpenMetrics format,
+## not all scrapers support exemplars.
+## Default: %v
+## Env: LINDB_MONITOR_EXEMPLARS
+exemplars = %v
+## max size of log data which can be read by log view api once.
+## Default: %s
+## Env: LINDB_MONITOR_MAX_LOG_VIEW_SIZE
+max-log-view-size = "%s"`,
 		m.PushTimeout.String(),
 		m.PushTimeout.String(),
 		m.ReportInterval.String(),
 		m.ReportInterval.String(),
 		m.URL,
 		m.URL,
+		m.Exemplars,
+		m.Exemplars,
+		m.MaxLogViewSize.String(),
+		m.MaxLogViewSize.String(),
 	)
 }
 
@@ -71,5 +86,6 @@ func NewDefaultMonitor() *Monitor {
 		PushTimeout:    ltoml.Duration(3 * time.Second),
 		ReportInterval: ltoml.Duration(10 * time.Second),
 		URL:            defaultPusherURL,
+		MaxLogViewSize: ltoml.Size(50 * 1024 * 1024),
 	}
 }
@@ -39,6 +39,8 @@ type TSDB struct {
 	FlushConcurrency         int            `env:"FLUSH_CONCURRENCY" toml:"flush-concurrency"`
 	SeriesSequenceCache      uint32         `env:"SERIES_SEQ_CACHE" toml:"series-sequence-cache"`
 	MetaSequenceCache        uint32         `env:"META_SEQ_CACHE" toml:"meta-sequence-cache"`
+	MetaCacheTTL             ltoml.Duration `env:"META_CACHE_TTL" toml:"meta-cache-ttl"`
+	MaxPendingSeries         int            `env:"MAX_PENDING_SERIES" toml:"max-pending-series"`
 }
 
 func (t *TSDB) TOML() string {
@@ -74,7 +76,20 @@ target-mem-usage-after-flush = %.2f
 ## concurrency of goroutines for flushing.
 ## Default: %d
 ## Env: LINDB_STORAGE_TSDB_FLUSH_CONCURRENCY 
-flush-concurrency = %d`,
+flush-concurrency = %d
+## Index flush will be triggered early(not waiting for data flush)
+## when num. of series created since last index flush exceeds this threshold.
+## Default: %d
+## Env: LINDB_STORAGE_TSDB_MAX_PENDING_SERIES
+max-pending-series = %d
+
+## Metadata cache configuration
+##
+## Metric metadata(field/tag key mappings) cached in memory will be evicted
+## if no data written into it this often, and will be reloaded on next write.
+## Default: %s
+## Env: LINDB_STORAGE_TSDB_META_CACHE_TTL
+meta-cache-ttl = "%s"`,
 		strings.ReplaceAll(t.Dir, "\\", "\\\\"),
 		strings.ReplaceAll(t.Dir, "\\", "\\\\"),
 		t.MaxMemDBSize.String(),
@@ -87,6 +102,10 @@ flush-concurrency = %d`,
 		t.TargetMemUsageAfterFlush,
 		t.FlushConcurrency,
 		t.FlushConcurrency,
+		t.MaxPendingSeries,
+		t.MaxPendingSeries,
+		t.MetaCacheTTL.String(),
+		t.MetaCacheTTL.String(),
 	)
 }
 
@@ -142,6 +161,7 @@ type WAL struct {
 	Dir                string         `env:"DIR" toml:"dir"`
 	DataSizeLimit      ltoml.Size     `env:"DATA_SIZE_LIMIT" toml:"data-size-limit"`
 	RemoveTaskInterval ltoml.Duration `env:"REMOVE_TASK_INTERVAL" toml:"remove-task-interval"`
+	CompressCodec      string         `env:"COMPRESS_CODEC" toml:"compress-codec"`
 }
 
 func (rc *WAL) GetDataSizeLimit() int64 {
@@ -168,13 +188,20 @@ data-size-limit = "%s"
 ## interval for how often remove expired write ahead log
 ## Default: %s
 ## Env: LINDB_STORAGE_WAL_REMOVE_TASK_INTERVAL
-remove-task-interval = "%s"`,
+remove-task-interval = "%s"
+## codec for compressing sealed page files of write ahead log in background, active page file keeps uncompressed.
+## available codec: none/gzip/zstd
+## Default: %s
+## Env: LINDB_STORAGE_WAL_COMPRESS_CODEC
+compress-codec = "%s"`,
 		strings.ReplaceAll(rc.Dir, "\\", "\\\\"),
 		strings.ReplaceAll(rc.Dir, "\\", "\\\\"),
 		rc.DataSizeLimit.String(),
 		rc.DataSizeLimit.String(),
 		rc.RemoveTaskInterval.String(),
 		rc.RemoveTaskInterval.String(),
+		rc.CompressCodec,
+		rc.CompressCodec,
 	)
 }
 
@@ -225,6 +252,7 @@ func NewDefaultStorageBase() *StorageBase {
 			Dir:                filepath.Join(defaultParentDir, "storage", "wal"),
 			DataSizeLimit:      ltoml.Size(128 * 1024 * 1024),
 			RemoveTaskInterval: ltoml.Duration(time.Minute),
+			CompressCodec:      "none",
 		},
 		TSDB: TSDB{
 			Dir:                      filepath.Join(defaultParentDir, "storage", "data"),
@@ -235,6 +263,8 @@ func NewDefaultStorageBase() *StorageBase {
 			FlushConcurrency:         int(math.Ceil(float64(runtime.GOMAXPROCS(-1)) / 2)),
 			SeriesSequenceCache:      1000,
 			MetaSequenceCache:        100,
+			MetaCacheTTL:             ltoml.Duration(time.Hour * 24),
+			MaxPendingSeries:         100000,
 		},
 	}
 }
@@ -257,6 +287,17 @@ func NewDefaultStorageTOML() string {
 	)
 }
 
+func checkWALCfg(walCfg *WAL) error {
+	switch walCfg.CompressCodec {
+	case "":
+		walCfg.CompressCodec = NewDefaultStorageBase().WAL.CompressCodec
+	case "none", "gzip", "zstd":
+	default:
+		return fmt.Errorf("unsupported wal compress codec: %s", walCfg.CompressCodec)
+	}
+	return nil
+}
+
 func checkTSDBCfg(tsdbCfg *TSDB) error {
 	defaultStorageCfg := NewDefaultStorageBase()
 	if tsdbCfg.Dir == "" {
@@ -283,6 +324,12 @@ func checkTSDBCfg(tsdbCfg *TSDB) error {
 	if tsdbCfg.MetaSequenceCache <= 0 {
 		tsdbCfg.MetaSequenceCache = defaultStorageCfg.TSDB.MetaSequenceCache
 	}
+	if tsdbCfg.MetaCacheTTL <= 0 {
+		tsdbCfg.MetaCacheTTL = defaultStorageCfg.TSDB.MetaCacheTTL
+	}
+	if tsdbCfg.MaxPendingSeries <= 0 {
+		tsdbCfg.MaxPendingSeries = defaultStorageCfg.TSDB.MaxPendingSeries
+	}
 	return nil
 }
 
@@ -295,5 +342,8 @@ func checkStorageBaseCfg(storageBaseCfg *StorageBase) error {
 	if storageBaseCfg.TTLTaskInterval <= 0 {
 		storageBaseCfg.TTLTaskInterval = defaultStorageCfg.TTLTaskInterval
 	}
+	if err := checkWALCfg(&storageBaseCfg.WAL); err != nil {
+		return err
+	}
 	return checkTSDBCfg(&storageBaseCfg.TSDB)
 }
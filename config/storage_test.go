@@ -70,6 +70,7 @@ func TestStorage_Env(t *testing.T) {
 		"LINDB_STORAGE_WAL_REMOVE_TASK_INTERVAL":          "2m",
 		"LINDB_STORAGE_WAL_DIR":                           "wal_dir",
 		"LINDB_STORAGE_WAL_DATA_SIZE_LIMIT":               "1Mib",
+		"LINDB_STORAGE_WAL_COMPRESS_CODEC":                "zstd",
 		"LINDB_STORAGE_TSDB_DIR":                          "tsdb_dir",
 		"LINDB_STORAGE_TSDB_MAX_MEMDB_SIZE":               "1Mib",
 		"LINDB_STORAGE_TSDB_MUTABLE_MEMDB_TTL":            "2m",
@@ -78,6 +79,7 @@ func TestStorage_Env(t *testing.T) {
 		"LINDB_STORAGE_TSDB_FLUSH_CONCURRENCY":            "2000",
 		"LINDB_STORAGE_TSDB_SERIES_SEQ_CACHE":             "1000",
 		"LINDB_STORAGE_TSDB_META_SEQ_CACHE":               "1000",
+		"LINDB_STORAGE_TSDB_META_CACHE_TTL":               "120s",
 		"LINDB_MONITOR_PUSH_TIMEOUT":                      "2m",
 		"LINDB_MONITOR_REPORT_INTERVAL":                   "2m",
 		"LINDB_MONITOR_URL":                               "monitor_url",
@@ -114,6 +116,7 @@ func TestStorage_Env(t *testing.T) {
 	assert.Equal(t, ltoml.Duration(time.Second*120), cfg.StorageBase.WAL.RemoveTaskInterval)
 	assert.Equal(t, "wal_dir", cfg.StorageBase.WAL.Dir)
 	assert.Equal(t, ltoml.Size(1024*1024), cfg.StorageBase.WAL.DataSizeLimit)
+	assert.Equal(t, "zstd", cfg.StorageBase.WAL.CompressCodec)
 	assert.Equal(t, "tsdb_dir", cfg.StorageBase.TSDB.Dir)
 	assert.Equal(t, ltoml.Size(1024*1024), cfg.StorageBase.TSDB.MaxMemDBSize)
 	assert.Equal(t, ltoml.Duration(time.Second*120), cfg.StorageBase.TSDB.MutableMemDBTTL)
@@ -122,6 +125,7 @@ func TestStorage_Env(t *testing.T) {
 	assert.Equal(t, 2000, cfg.StorageBase.TSDB.FlushConcurrency)
 	assert.Equal(t, uint32(1000), cfg.StorageBase.TSDB.SeriesSequenceCache)
 	assert.Equal(t, uint32(1000), cfg.StorageBase.TSDB.MetaSequenceCache)
+	assert.Equal(t, ltoml.Duration(time.Second*120), cfg.StorageBase.TSDB.MetaCacheTTL)
 
 	assert.Equal(t, ltoml.Duration(time.Second*120), cfg.Monitor.PushTimeout)
 	assert.Equal(t, ltoml.Duration(time.Second*120), cfg.Monitor.ReportInterval)
@@ -30,4 +30,14 @@ const (
 	ContentTypeProto = "application/protobuf"
 	// ContentTypeInflux represents influx content type.
 	ContentTypeInflux = "application/influx"
+	// ContentTypeGraphite represents graphite plaintext content type.
+	ContentTypeGraphite = "application/graphite"
+	// ContentTypeText represents plain text content type, data is influx line protocol.
+	ContentTypeText = "text/plain"
+	// ContentTypeJSON represents json content type, data is opentsdb json data points.
+	ContentTypeJSON = "application/json"
+	// ContentTypePrometheus represents prometheus remote write(snappy compressed protobuf) content type.
+	ContentTypePrometheus = "application/x-protobuf"
+	// HeaderBatchID represents the header of client-supplied write batch id for write idempotency.
+	HeaderBatchID = "X-LinDB-Batch-ID"
 )
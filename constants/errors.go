@@ -27,6 +27,8 @@ var (
 	ErrNotFound = errors.New("not found")
 	// ErrTimeout represents exceed timeout.
 	ErrTimeout = errors.New("exceed timeout")
+	// ErrTaskCancelled represents the task is cancelled.
+	ErrTaskCancelled = errors.New("task cancelled")
 
 	ErrTagValueFilterResultNotFound = fmt.Errorf("tag value fitler result %w", ErrNotFound)
 
@@ -51,6 +53,8 @@ var (
 	ErrInfluxLineTooLong = errors.New("influx line is too long")
 
 	ErrBadEnrichTagQueryFormat = errors.New("enrich_tag has the wrong format")
+	// ErrUnsupportedContentType represents the content type of write request not supported.
+	ErrUnsupportedContentType = errors.New("unsupported content type")
 	// ErrNoLiveReplica represents no live replica node for current shard.
 	ErrNoLiveReplica = errors.New("no live replica for shard")
 	// ErrNoLiveNode represents no live node for current cluster.
@@ -76,6 +80,8 @@ var (
 	ErrTooManyMetadata   = errors.New("too manay namespace or metric")
 	ErrNamespaceTooLong  = errors.New("namespace is too long")
 	ErrMetricNameTooLong = errors.New("metric name is too long")
+	// ErrInvalidMetricName represents metric name not matches the allowed charset.
+	ErrInvalidMetricName = errors.New("metric name contains invalid characters")
 	ErrFieldNameTooLong  = errors.New("field name is too long")
 	ErrTagKeyTooLong     = errors.New("tag key is too long")
 	ErrTagValueTooLong   = errors.New("tag value is too long")
@@ -86,6 +92,21 @@ var (
 	// ErrTooManyFields is the error returned by tsdb when
 	// writes exceed the max limit of fields.
 	ErrTooManyFields = errors.New("too many fields")
+	// ErrFieldTypeChanged is the error returned by query when
+	// query time range spans field type change.
+	ErrFieldTypeChanged = errors.New("field type changed in query time range")
+	// ErrTooManyGroupByTagKeys is the error returned by query when
+	// group by tag keys exceed the max limit after group by * expanded.
+	ErrTooManyGroupByTagKeys = errors.New("too many group by tag keys")
+	// ErrTooManySelectFields is the error returned by query when
+	// select fields exceed the max limit after select * expanded.
+	ErrTooManySelectFields = errors.New("too many select fields")
+	// ErrNoFieldsFound is the error returned by query when
+	// metric has no fields for select * expanding.
+	ErrNoFieldsFound = errors.New("no fields found under metric")
 	// ErrTooManySeriesFound is the error returned max series limit of data query.
 	ErrTooManySeriesFound = errors.New("found too many series")
+	// ErrQueryTooLarge is the error returned by query when
+	// the number of target nodes exceeds max fan-out.
+	ErrQueryTooLarge = errors.New("query fans out to too many nodes")
 )
@@ -22,4 +22,8 @@ const (
 	RPCMetaKeyDatabase    = "Database"
 	RPCMetaKeyFamilyState = "FamilyState"
 	RPCMetaReplicaState   = "ReplicaState"
+	// RPCMetaKeyWriteAck is the write ack mode requested by client, default ack mode is batched ack.
+	RPCMetaKeyWriteAck = "WriteAck"
+	// RPCMetaWriteAckPerRecord requests server to ack every write record.
+	RPCMetaWriteAckPerRecord = "record"
 )
@@ -20,6 +20,10 @@ package constants
 const (
 	// MaxSuggestions represents the max number of suggestions count
 	MaxSuggestions = 100
+	// MaxGroupByTagKeys represents the max number of group by tag keys after group by * expanded
+	MaxGroupByTagKeys = 32
+	// MaxSelectAllFields represents the max number of select fields after select * expanded
+	MaxSelectAllFields = 64
 
 	// MetricMaxAheadDuration controls the global max write ahead duration.
 	// If current timestamp is 2021-08-19 23:00:00, metric after 2021-08-20 23:00:00 will be dropped.
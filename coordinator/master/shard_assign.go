@@ -87,6 +87,29 @@ func ModifyShardAssignment(storageNodeIDs []models.NodeID, cfg *models.Database,
 	return nil
 }
 
+// IncreaseReplicaFactor adds replicas for each shard until num. of replica reaches replica factor of database,
+// new replicas are picked from storage nodes by round-robin, starting from the position of shard id.
+func IncreaseReplicaFactor(storageNodeIDs []models.NodeID, cfg *models.Database,
+	shardAssignment *models.ShardAssignment) error {
+	replicaFactor := cfg.ReplicaFactor
+	numOfNode := len(storageNodeIDs)
+	if replicaFactor > numOfNode {
+		return fmt.Errorf("shard assign error for databaes[%s], bacause replica factor > num. of storage nodes",
+			cfg.Name)
+	}
+	for shardID, replica := range shardAssignment.Shards {
+		if replica == nil {
+			replica = &models.Replica{}
+			shardAssignment.Shards[shardID] = replica
+		}
+		startIndex := int(shardID) % numOfNode
+		for i := 0; i < numOfNode && len(replica.Replicas) < replicaFactor; i++ {
+			shardAssignment.AddReplica(shardID, storageNodeIDs[(startIndex+i)%numOfNode])
+		}
+	}
+	return nil
+}
+
 // assignReplicasToStorageNodes assigns replica list for storage storageCluster
 // which database's each shard based on selected node list in storageCluster.
 func assignReplicasToStorageNodes(storageNodeIDs []models.NodeID,
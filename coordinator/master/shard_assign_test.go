@@ -105,3 +105,29 @@ func TestModifyShardAssignment(t *testing.T) {
 		}, models.NewShardAssignment("test"), -1, models.ShardID(1))
 	assert.Error(t, err)
 }
+
+func TestIncreaseReplicaFactor(t *testing.T) {
+	storageNodeIDs := []models.NodeID{1, 2, 3}
+	shardAssign := models.NewShardAssignment("test")
+	shardAssign.AddReplica(0, 1)
+	shardAssign.AddReplica(1, 2)
+	shardAssign.AddReplica(1, 3)
+	shardAssign.Shards[2] = nil
+
+	err := IncreaseReplicaFactor(storageNodeIDs, &models.Database{Name: "test", ReplicaFactor: 4}, shardAssign)
+	assert.Error(t, err)
+
+	err = IncreaseReplicaFactor(storageNodeIDs, &models.Database{Name: "test", ReplicaFactor: 2}, shardAssign)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.NodeID{1, 2}, shardAssign.Shards[0].Replicas)
+	assert.Equal(t, []models.NodeID{2, 3}, shardAssign.Shards[1].Replicas)
+	assert.Equal(t, []models.NodeID{3, 1}, shardAssign.Shards[2].Replicas)
+	assert.Equal(t, 2, shardAssign.GetReplicaFactor())
+
+	err = IncreaseReplicaFactor(storageNodeIDs, &models.Database{Name: "test", ReplicaFactor: 3}, shardAssign)
+	assert.NoError(t, err)
+	for _, replica := range shardAssign.Shards {
+		assert.Len(t, replica.Replicas, 3)
+	}
+	assert.Equal(t, 3, shardAssign.GetReplicaFactor())
+}
@@ -472,7 +472,7 @@ func (m *stateManager) shardAssignment(databaseCfg *models.Database) {
 				logger.Error(err))
 			return
 		}
-	case len(shardAssign.Shards) != databaseCfg.NumOfShard:
+	case len(shardAssign.Shards) != databaseCfg.NumOfShard || shardAssign.GetReplicaFactor() < databaseCfg.ReplicaFactor:
 		m.logger.Info("modify shard assignment starting....",
 			logger.String("storage", databaseCfg.Storage),
 			logger.Any("database", databaseCfg.Name))
@@ -496,6 +496,14 @@ func (m *stateManager) shardAssignment(databaseCfg *models.Database) {
 				logger.Error(err))
 			return
 		}
+		// save shard assignment with the latest database option into related storage repo(maybe option changed).
+		if err := cluster.SaveDatabaseAssignment(shardAssign, databaseCfg.Option); err != nil {
+			m.logger.Error("save shard assignment with database option error",
+				logger.String("storage", databaseCfg.Storage),
+				logger.Any("database", databaseCfg.Name),
+				logger.Error(err))
+			return
+		}
 	}
 }
 
@@ -622,7 +630,10 @@ func (m *stateManager) modifyShardAssignment(
 	if len(shardAssign.Shards) > cfg.NumOfShard { // reduce shardAssign's shards
 		// TODO implement the reduce shards, is needed?
 		panic("not implemented")
-	} else if len(shardAssign.Shards) < cfg.NumOfShard { // add shardAssign's shards
+	}
+	addShards := len(shardAssign.Shards) < cfg.NumOfShard
+	addReplicas := shardAssign.GetReplicaFactor() < cfg.ReplicaFactor
+	if addShards || addReplicas {
 		liveNodes, err := cluster.GetLiveNodes()
 		if err != nil {
 			return err
@@ -639,11 +650,19 @@ func (m *stateManager) modifyShardAssignment(
 			nodes[node.ID] = &node
 		}
 
-		// generate shard assignment based on node ids and config
-		// TODO check start shard id
-		err = ModifyShardAssignment(nodeIDs, cfg, shardAssign, -1, models.ShardID(len(shardAssign.Shards)))
-		if err != nil {
-			return err
+		// increase replicas of exist shards, reducing replica factor is not supported.
+		if addReplicas {
+			if err := IncreaseReplicaFactor(nodeIDs, cfg, shardAssign); err != nil {
+				return err
+			}
+		}
+		if addShards {
+			// generate shard assignment based on node ids and config
+			// TODO check start shard id
+			err = ModifyShardAssignment(nodeIDs, cfg, shardAssign, -1, models.ShardID(len(shardAssign.Shards)))
+			if err != nil {
+				return err
+			}
 		}
 	}
 	databaseName := cfg.Name
@@ -293,15 +293,48 @@ func TestStateManager_DatabaseCfg(t *testing.T) {
 		Value: data,
 	})
 	// case 6: trigger modify event
+	shardAssign := encoding.JSONMarshal(&models.ShardAssignment{
+		Name: "test",
+		Shards: map[models.ShardID]*models.Replica{
+			0: {Replicas: []models.NodeID{1, 2}},
+			1: {Replicas: []models.NodeID{2, 3}},
+			2: {Replicas: []models.NodeID{3, 1}},
+		},
+	})
 	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
-	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(encoding.JSONMarshal(&models.ShardAssignment{
-		Shards: map[models.ShardID]*models.Replica{1: nil, 2: nil, 3: nil},
-	}), nil)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(shardAssign, nil)
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/database/test",
+		Value: data,
+	})
+	// case 7: save database option err
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(shardAssign, nil)
+	storage1.EXPECT().SaveDatabaseAssignment(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
 	mgr.EmitEvent(&discovery.Event{
 		Type:  discovery.DatabaseConfigChanged,
 		Key:   "/database/test",
 		Value: data,
 	})
+	// case 8: increase replica factor
+	db.ReplicaFactor = 3
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(shardAssign, nil)
+	storage1.EXPECT().GetLiveNodes().Return([]models.StatefulNode{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+	storage1.EXPECT().SaveDatabaseAssignment(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(shardAssign *models.ShardAssignment, _ *option.DatabaseOption) error {
+			assert.Equal(t, 3, shardAssign.GetReplicaFactor())
+			for _, replica := range shardAssign.Shards {
+				assert.Len(t, replica.Replicas, 3)
+			}
+			return nil
+		})
+	mgr.EmitEvent(&discovery.Event{
+		Type:  discovery.DatabaseConfigChanged,
+		Key:   "/database/test",
+		Value: encoding.JSONMarshal(db),
+	})
 
 	time.Sleep(100 * time.Millisecond)
 	assert.Len(t, mgr.GetDatabases(), 1)
@@ -231,7 +231,7 @@ const docTemplate = `{
         },
         "/log/view": {
             "get": {
-                "description": "return last N lines in log file.",
+                "description": "return last N lines in log file, keep streaming new lines if follow is true.",
                 "consumes": [
                     "application/json"
                 ],
@@ -264,6 +264,134 @@ const docTemplate = `{
                 }
             }
         },
+        "/opentsdb/api/put": {
+            "post": {
+                "description": "receive opentsdb json data points, then write data via database channel.\ninvalid data points(e.g. empty metric, no tags) are dropped,\nreturns summary of success/failed data points if details param set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Write"
+                ],
+                "summary": "write opentsdb data points",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "database name",
+                        "name": "db",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "namespace, default value: default-ns",
+                        "name": "ns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "return summary of success/failed data points",
+                        "name": "details",
+                        "in": "query"
+                    },
+                    {
+                        "description": "data points",
+                        "name": "string",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/opentsdb.Summary"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "too many in-flight rows, client need back off",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/prometheus/api/v1/write": {
+            "post": {
+                "description": "receive prometheus remote write data(snappy compressed protobuf), then write data via database channel.\nmetric name is __name__ label, other labels are tags, staleness marker samples are skipped.",
+                "consumes": [
+                    "application/x-protobuf"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Write"
+                ],
+                "summary": "write prometheus remote write data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "database name",
+                        "name": "db",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "namespace, default value: default-ns",
+                        "name": "ns",
+                        "in": "query"
+                    },
+                    {
+                        "description": "remote write request",
+                        "name": "string",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "too many in-flight rows, client need back off",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/proxy": {
             "get": {
                 "description": "Forward request to target server by given target ip and path.",
@@ -311,11 +439,12 @@ const docTemplate = `{
         },
         "/write": {
             "put": {
-                "description": "receive metric data, then parse the data based on content type(flat buffer/proto buffer/influx).\nwrite data via database channel, support content-type as below:\n1. application/flatbuffer\n2. application/protobuf\n3. application/influx",
+                "description": "receive metric data, then parse the data based on content type(flat buffer/proto buffer/influx).\nwrite data via database channel, support content-type as below:\n1. application/flatbuffer\n2. application/protobuf\n3. application/influx\n4. application/graphite(template query param maps metric path to name/tags, e.g. app.*.measurement)",
                 "consumes": [
                     "application/flatbuffer",
                     "application/protobuf",
-                    "application/influx"
+                    "application/influx",
+                    "application/graphite"
                 ],
                 "produces": [
                     "text/plain"
@@ -338,6 +467,12 @@ const docTemplate = `{
                         "name": "ns",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "graphite template, e.g. app.*.measurement",
+                        "name": "template",
+                        "in": "query"
+                    },
                     {
                         "description": "metric data",
                         "name": "string",
@@ -354,6 +489,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "429": {
+                        "description": "too many in-flight rows, client need back off",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "internal error",
                         "schema": {
@@ -363,11 +504,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "receive metric data, then parse the data based on content type(flat buffer/proto buffer/influx).\nwrite data via database channel, support content-type as below:\n1. application/flatbuffer\n2. application/protobuf\n3. application/influx",
+                "description": "receive metric data, then parse the data based on content type(flat buffer/proto buffer/influx).\nwrite data via database channel, support content-type as below:\n1. application/flatbuffer\n2. application/protobuf\n3. application/influx\n4. application/graphite(template query param maps metric path to name/tags, e.g. app.*.measurement)",
                 "consumes": [
                     "application/flatbuffer",
                     "application/protobuf",
-                    "application/influx"
+                    "application/influx",
+                    "application/graphite"
                 ],
                 "produces": [
                     "text/plain"
@@ -390,6 +532,12 @@ const docTemplate = `{
                         "name": "ns",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "graphite template, e.g. app.*.measurement",
+                        "name": "template",
+                        "in": "query"
+                    },
                     {
                         "description": "metric data",
                         "name": "string",
@@ -406,6 +554,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "429": {
+                        "description": "too many in-flight rows, client need back off",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "internal error",
                         "schema": {
@@ -655,6 +809,54 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "opentsdb.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "datapoint": {
+                    "$ref": "#/definitions/opentsdb.Point"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "opentsdb.Point": {
+            "type": "object",
+            "properties": {
+                "metric": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "opentsdb.Summary": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/opentsdb.ErrorDetail"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
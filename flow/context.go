@@ -107,6 +107,10 @@ func (ctx *StorageExecuteContext) collectGroupingTagValueIDs(tagValueIDs []uint3
 	// need add lock, because build group concurrent(multi-shard)
 	ctx.mutex.Lock()
 	for idx, tagValueID := range tagValueIDs {
+		if tagValueID == tag.EmptyTagValueID {
+			// series missing tag key, no need collect tag value
+			continue
+		}
 		tIDs := ctx.GroupingTagValueIDs[idx]
 		if tIDs == nil {
 			ctx.GroupingTagValueIDs[idx] = roaring.BitmapOf(tagValueID)
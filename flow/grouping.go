@@ -114,32 +114,36 @@ func (g *groupingContext) BuildGroup(ctx *DataLoadContext) {
 	}
 }
 
-// buildGroupForMultiTags builds grouping for multi-tags.
+// buildGroupForMultiTags builds grouping for multi-tags,
+// if series missing some tag keys, groups it under empty tag value(tag.EmptyTagValueID) for those tag keys.
 func (g *groupingContext) buildGroupForMultiTags(ctx *DataLoadContext) {
 	tagSize := len(g.tagKeys)
 	tagValueIDsForGrouping := make([][]byte, len(ctx.LowSeriesIDs))
-	result := make(map[string]uint16)
 	g.scanGroupingTags(ctx, func(seriesIdxFromQuery uint16, tagKeyIDIdx int, tagValueID uint32) {
 		tagValueIDs := tagValueIDsForGrouping[seriesIdxFromQuery]
 		if tagValueIDs == nil {
+			// tag value ids init with empty tag value id
 			tagValueIDs = make([]byte, tagSize*4)
 			tagValueIDsForGrouping[seriesIdxFromQuery] = tagValueIDs
 		}
 		tagOffset := tagKeyIDIdx * 4
 		binary.LittleEndian.PutUint32(tagValueIDs[tagOffset:], tagValueID)
+	})
 
-		if tagKeyIDIdx == tagSize-1 {
-			key := strutil.ByteSlice2String(tagValueIDs)
-			// last tag key
-			aggIdx, ok := result[key]
-			if !ok {
-				groupingSeriesAggIdx := ctx.NewSeriesAggregator(key)
-				aggIdx = groupingSeriesAggIdx
-				result[key] = aggIdx
-			}
-			ctx.GroupingSeriesAggRefs[seriesIdxFromQuery] = aggIdx
+	result := make(map[string]uint16)
+	for seriesIdxFromQuery, tagValueIDs := range tagValueIDsForGrouping {
+		if tagValueIDs == nil {
+			// series not found in all grouping tag keys
+			continue
 		}
-	})
+		key := strutil.ByteSlice2String(tagValueIDs)
+		aggIdx, ok := result[key]
+		if !ok {
+			aggIdx = ctx.NewSeriesAggregator(key)
+			result[key] = aggIdx
+		}
+		ctx.GroupingSeriesAggRefs[seriesIdxFromQuery] = aggIdx
+	}
 }
 
 // buildGroupForMultiTags builds grouping for single-tags.
@@ -0,0 +1,51 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GetReader picks a cached decompression reader from the pool based on Content-Encoding header value,
+// supports gzip/zstd/snappy, returns raw reader if encoding is empty or identity.
+// release function must be invoked after reading completed, which puts the reader back to the pool.
+func GetReader(encoding string, r io.Reader) (reader io.Reader, release func(), err error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return r, func() {}, nil
+	case "gzip":
+		gzipReader, err := GetGzipReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gzipReader, func() { PutGzipReader(gzipReader) }, nil
+	case "zstd":
+		zstdReader, err := GetZstdReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return zstdReader, func() { PutZstdReader(zstdReader) }, nil
+	case "snappy":
+		snappyReader := GetSnappyReader(r)
+		return snappyReader, func() { PutSnappyReader(snappyReader) }, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+}
@@ -0,0 +1,81 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReader(t *testing.T) {
+	raw := []byte("cpu,host=a load=1")
+	var gzipData bytes.Buffer
+	gw := gzip.NewWriter(&gzipData)
+	_, _ = gw.Write(raw)
+	_ = gw.Close()
+
+	cases := []struct {
+		encoding string
+		data     []byte
+		wantErr  bool
+	}{
+		{encoding: "", data: raw},
+		{encoding: "identity", data: raw},
+		{encoding: "GZIP", data: gzipData.Bytes()},
+		{encoding: "zstd", data: zstdData(t, raw)},
+		{encoding: " snappy ", data: snappyData(t, raw)},
+		{encoding: "gzip", data: raw, wantErr: true},
+		{encoding: "zstd", data: raw, wantErr: true},
+		{encoding: "br", data: raw, wantErr: true},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.encoding, func(t *testing.T) {
+			r, release, err := GetReader(tt.encoding, bytes.NewReader(tt.data))
+			if err == nil {
+				defer release()
+				var rs []byte
+				rs, err = io.ReadAll(r)
+				if err == nil {
+					assert.Equal(t, raw, rs)
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encoding: %s, err: %v", tt.encoding, err)
+			}
+		})
+	}
+}
+
+func BenchmarkGetReader_Gzip(b *testing.B) {
+	var data bytes.Buffer
+	gw := gzip.NewWriter(&data)
+	_, _ = gw.Write(bytes.Repeat([]byte("cpu,host=a load=1\n"), 100))
+	_ = gw.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, release, _ := GetReader("gzip", bytes.NewReader(data.Bytes()))
+		_, _ = io.Copy(io.Discard, r)
+		release()
+	}
+}
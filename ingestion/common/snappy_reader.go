@@ -0,0 +1,63 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+)
+
+var snappyReaderPool sync.Pool
+
+// GetSnappyReader picks a cached reader(snappy framing format) from the pool
+func GetSnappyReader(r io.Reader) *snappy.Reader {
+	reader := snappyReaderPool.Get()
+	if reader == nil {
+		return snappy.NewReader(r)
+	}
+
+	snappyReader := reader.(*snappy.Reader)
+	snappyReader.Reset(r)
+	return snappyReader
+}
+
+// ReadSnappyBlock reads all data from reader, then decodes it with snappy block format,
+// which is different from framing format, e.g. prometheus remote write request.
+func ReadSnappyBlock(r io.Reader) (compressed, data []byte, err error) {
+	compressed, err = io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err = snappy.Decode(nil, compressed)
+	if err != nil {
+		return compressed, nil, err
+	}
+	return compressed, data, nil
+}
+
+// PutSnappyReader puts the snappyReader back to the pool
+func PutSnappyReader(snappyReader *snappy.Reader) {
+	if snappyReader == nil {
+		return
+	}
+	// release the reference of underlying reader
+	snappyReader.Reset(nil)
+	snappyReaderPool.Put(snappyReader)
+}
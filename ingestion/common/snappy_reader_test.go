@@ -0,0 +1,93 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+)
+
+func snappyData(t testing.TB, data []byte) []byte {
+	var buf bytes.Buffer
+	w := snappy.NewBufferedWriter(&buf)
+	_, err := w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func Test_GetAndPutSnappyReader(t *testing.T) {
+	defer func() {
+		snappyReaderPool = sync.Pool{}
+	}()
+	snappyReaderPool = sync.Pool{}
+	PutSnappyReader(nil)
+	data := snappyData(t, []byte("cpu,host=a load=1"))
+	for i := 0; i < 100; i++ {
+		r := GetSnappyReader(bytes.NewReader(data))
+		rs, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, "cpu,host=a load=1", string(rs))
+		PutSnappyReader(r)
+	}
+	// corrupted data
+	r := GetSnappyReader(bytes.NewReader([]byte("abc")))
+	_, err := io.ReadAll(r)
+	assert.Error(t, err)
+	PutSnappyReader(r)
+}
+
+func Test_ReadSnappyBlock(t *testing.T) {
+	compressed, data, err := ReadSnappyBlock(bytes.NewReader(snappy.Encode(nil, []byte("cpu"))))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, compressed)
+	assert.Equal(t, "cpu", string(data))
+	// corrupted data
+	_, _, err = ReadSnappyBlock(bytes.NewReader([]byte("abc")))
+	assert.Error(t, err)
+	// read failure
+	_, _, err = ReadSnappyBlock(iotest.ErrReader(io.ErrUnexpectedEOF))
+	assert.Error(t, err)
+}
+
+func BenchmarkSnappyReader_Pooled(b *testing.B) {
+	data := snappyData(b, bytes.Repeat([]byte("cpu,host=a load=1\n"), 100))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := GetSnappyReader(bytes.NewReader(data))
+		_, _ = io.Copy(io.Discard, r)
+		PutSnappyReader(r)
+	}
+}
+
+func BenchmarkSnappyReader_New(b *testing.B) {
+	data := snappyData(b, bytes.Repeat([]byte("cpu,host=a load=1\n"), 100))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := snappy.NewReader(bytes.NewReader(data))
+		_, _ = io.Copy(io.Discard, r)
+	}
+}
@@ -0,0 +1,69 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// for testing
+var (
+	newZstdReaderFn   = newZstdReader
+	resetZstdReaderFn = resetZstdReader
+)
+
+var zstdReaderPool sync.Pool
+
+// GetZstdReader picks a cached reader from the pool
+func GetZstdReader(r io.Reader) (*zstd.Decoder, error) {
+	reader := zstdReaderPool.Get()
+	if reader == nil {
+		return newZstdReaderFn(r)
+	}
+
+	zstdReader := reader.(*zstd.Decoder)
+	if err := resetZstdReaderFn(zstdReader, r); err != nil {
+		// illegal reader, put it back
+		PutZstdReader(zstdReader)
+		return nil, err
+	}
+	return zstdReader, nil
+}
+
+// PutZstdReader puts the zstdReader back to the pool
+func PutZstdReader(zstdReader *zstd.Decoder) {
+	if zstdReader == nil {
+		return
+	}
+	// release the reference of underlying reader, cannot close decoder because it will not be reused after closed.
+	_ = zstdReader.Reset(nil)
+	zstdReaderPool.Put(zstdReader)
+}
+
+// newZstdReader creates zstd reader, decodes synchronously without background goroutines.
+func newZstdReader(r io.Reader) (*zstd.Decoder, error) {
+	return zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+}
+
+// resetZstdReader resets zstd reader.
+func resetZstdReader(reader *zstd.Decoder, r io.Reader) error {
+	return reader.Reset(r)
+}
@@ -0,0 +1,106 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func zstdData(t testing.TB, data []byte) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	assert.NoError(t, err)
+	_, err = w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func Test_GetAndPutZstdReader(t *testing.T) {
+	defer func() {
+		zstdReaderPool = sync.Pool{}
+	}()
+	zstdReaderPool = sync.Pool{}
+	PutZstdReader(nil)
+	data := zstdData(t, []byte("cpu,host=a load=1"))
+	for i := 0; i < 100; i++ {
+		r, err := GetZstdReader(bytes.NewReader(data))
+		assert.NoError(t, err)
+		rs, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, "cpu,host=a load=1", string(rs))
+		PutZstdReader(r)
+	}
+}
+
+func Test_GetZstdReader(t *testing.T) {
+	defer func() {
+		zstdReaderPool = sync.Pool{}
+		newZstdReaderFn = newZstdReader
+		resetZstdReaderFn = resetZstdReader
+	}()
+	zstdReaderPool = sync.Pool{}
+	newZstdReaderFn = func(_ io.Reader) (*zstd.Decoder, error) {
+		return nil, fmt.Errorf("err")
+	}
+	r, err := GetZstdReader(bytes.NewReader(nil))
+	assert.Error(t, err)
+	assert.Nil(t, r)
+
+	zstdReaderPool = sync.Pool{
+		New: func() any {
+			r, _ := newZstdReader(nil)
+			return r
+		},
+	}
+	resetZstdReaderFn = func(_ *zstd.Decoder, _ io.Reader) error {
+		return fmt.Errorf("err")
+	}
+	r, err = GetZstdReader(bytes.NewReader(nil))
+	assert.Error(t, err)
+	assert.Nil(t, r)
+}
+
+func BenchmarkZstdReader_Pooled(b *testing.B) {
+	data := zstdData(b, bytes.Repeat([]byte("cpu,host=a load=1\n"), 100))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, _ := GetZstdReader(bytes.NewReader(data))
+		_, _ = io.Copy(io.Discard, r)
+		PutZstdReader(r)
+	}
+}
+
+func BenchmarkZstdReader_New(b *testing.B) {
+	data := zstdData(b, bytes.Repeat([]byte("cpu,host=a load=1\n"), 100))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, _ := newZstdReader(bytes.NewReader(data))
+		_, _ = io.Copy(io.Discard, r)
+		r.Close()
+	}
+}
@@ -0,0 +1,333 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package csv
+
+import (
+	stdcsv "encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lindb/common/pkg/logger"
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+	commonseries "github.com/lindb/common/series"
+
+	"github.com/lindb/lindb/constants"
+	ingestCommon "github.com/lindb/lindb/ingestion/common"
+	"github.com/lindb/lindb/metrics"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/strutil"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
+)
+
+var (
+	ErrMissingMetricColumn = errors.New("missing __name__ column in csv header")
+	ErrMissingTimeColumn   = errors.New("missing time column in csv header")
+	ErrMissingFieldColumn  = errors.New("missing field column(field_ prefix) in csv header")
+	ErrMissingMetricName   = errors.New("missing_metric_name")
+	ErrMissingFields       = errors.New("missing_fields")
+	ErrBadTimestamp        = errors.New("bad_timestamp")
+)
+
+var (
+	csvLogger              = logger.GetLogger("Ingestion", "CSV")
+	csvIngestionStatistics = metrics.NewCSVIngestionStatistics()
+)
+
+const (
+	metricNameColumn  = "__name__"
+	timeColumn        = "time"
+	tagColumnPrefix   = "tag_"
+	fieldColumnPrefix = "field_"
+)
+
+// for testing
+var (
+	// batchSize is the number of rows which are written in one batch,
+	// so that csv file larger than memory can be ingested by streaming.
+	batchSize = 10000
+)
+
+// Summary represents the summary of csv ingestion.
+type Summary struct {
+	Accepted int `json:"accepted"`
+	Rejected int `json:"rejected"`
+}
+
+// column represents the tag/field column of csv header.
+type column struct {
+	idx       int
+	name      []byte
+	fieldType flatMetricsV1.SimpleFieldType
+}
+
+// schema represents the columns of csv which are resolved by header.
+type schema struct {
+	numOfColumns int
+	metricIdx    int
+	timeIdx      int
+	tags         []column
+	fields       []column
+}
+
+// Parse parses csv data with header-driven schema, streams rows line by line, and writes every batch of
+// parsed rows via write function, so that csv larger than memory can be ingested.
+// header names columns as __name__, time, tag columns(tag_ prefix) and field columns(field_ prefix),
+// field type can be set by suffix like field_count:sum(sum/last/min/max/first), default is last.
+// time column is RFC3339 or epoch milliseconds, malformed rows are counted and skipped.
+func Parse(
+	req *http.Request,
+	enrichedTags tag.Tags,
+	namespace string,
+	limits *models.Limits,
+	write func(rows *metric.BrokerBatchRows) error,
+) (*Summary, error) {
+	encoding := req.Header.Get("Content-Encoding")
+	reader, releaseReader, err := ingestCommon.GetReader(encoding, req.Body)
+	if err != nil {
+		csvIngestionStatistics.CorruptedData.Incr()
+		return nil, fmt.Errorf("ingestion corrupted %s data: %w", encoding, err)
+	}
+	defer releaseReader()
+
+	bufioReader, releaseBufioReader := ingestCommon.NewBufioReader(reader)
+	defer releaseBufioReader(bufioReader)
+
+	csvReader := stdcsv.NewReader(bufioReader)
+	csvReader.ReuseRecord = true
+	header, err := csvReader.Read()
+	if err != nil {
+		csvIngestionStatistics.CorruptedData.Incr()
+		return nil, fmt.Errorf("read csv header failure: %w", err)
+	}
+	s, err := parseHeader(header)
+	if err != nil {
+		csvIngestionStatistics.CorruptedData.Incr()
+		return nil, err
+	}
+	// check number of columns by schema
+	csvReader.FieldsPerRecord = s.numOfColumns
+
+	rowBuilder, releaseFunc := commonseries.NewRowBuilder()
+	defer releaseFunc(rowBuilder)
+
+	summary := &Summary{}
+	batch := metric.AcquireBrokerBatchRows()
+	defer func() {
+		metric.ReleaseBrokerBatchRows(batch)
+	}()
+	for {
+		record, readErr := csvReader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			var parseErr *stdcsv.ParseError
+			if !errors.As(readErr, &parseErr) {
+				csvIngestionStatistics.CorruptedData.Incr()
+				return nil, readErr
+			}
+			dropRow(summary, readErr)
+			continue
+		}
+		if err := appendRow(batch, rowBuilder, s, record, namespace, enrichedTags, limits); err != nil {
+			dropRow(summary, err)
+			continue
+		}
+		csvIngestionStatistics.IngestedMetrics.Incr()
+		summary.Accepted++
+		if batch.Len() >= batchSize {
+			if err := write(batch); err != nil {
+				return nil, err
+			}
+			metric.ReleaseBrokerBatchRows(batch)
+			batch = metric.AcquireBrokerBatchRows()
+		}
+	}
+	if batch.Len() > 0 {
+		if err := write(batch); err != nil {
+			return nil, err
+		}
+	}
+	return summary, nil
+}
+
+// dropRow counts the malformed row into summary.
+func dropRow(summary *Summary, err error) {
+	csvLogger.Warn("ingest error", logger.Error(err))
+	csvIngestionStatistics.DroppedMetrics.Incr()
+	summary.Rejected++
+}
+
+// parseHeader resolves the schema of csv by header.
+func parseHeader(header []string) (*schema, error) {
+	s := &schema{
+		numOfColumns: len(header),
+		metricIdx:    -1,
+		timeIdx:      -1,
+	}
+	for idx, name := range header {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == metricNameColumn:
+			s.metricIdx = idx
+		case name == timeColumn:
+			s.timeIdx = idx
+		case strings.HasPrefix(name, tagColumnPrefix) && len(name) > len(tagColumnPrefix):
+			s.tags = append(s.tags, column{idx: idx, name: []byte(strings.TrimPrefix(name, tagColumnPrefix))})
+		case strings.HasPrefix(name, fieldColumnPrefix) && len(name) > len(fieldColumnPrefix):
+			fieldName, fieldType, err := parseFieldColumn(strings.TrimPrefix(name, fieldColumnPrefix))
+			if err != nil {
+				return nil, err
+			}
+			s.fields = append(s.fields, column{idx: idx, name: []byte(fieldName), fieldType: fieldType})
+		default:
+			return nil, fmt.Errorf("unknown csv column: %s, need __name__/time/tag_*/field_*", name)
+		}
+	}
+	switch {
+	case s.metricIdx < 0:
+		return nil, ErrMissingMetricColumn
+	case s.timeIdx < 0:
+		return nil, ErrMissingTimeColumn
+	case len(s.fields) == 0:
+		return nil, ErrMissingFieldColumn
+	}
+	return s, nil
+}
+
+// parseFieldColumn parses field name and field type from field column, like count:sum.
+func parseFieldColumn(column string) (string, flatMetricsV1.SimpleFieldType, error) {
+	idx := strings.LastIndex(column, ":")
+	if idx < 0 {
+		return column, flatMetricsV1.SimpleFieldTypeLast, nil
+	}
+	fieldName := column[:idx]
+	if fieldName == "" {
+		return "", flatMetricsV1.SimpleFieldTypeUnSpecified, fmt.Errorf("empty field name of csv column: %s", column)
+	}
+	switch strings.ToLower(column[idx+1:]) {
+	case "sum":
+		return fieldName, flatMetricsV1.SimpleFieldTypeDeltaSum, nil
+	case "last":
+		return fieldName, flatMetricsV1.SimpleFieldTypeLast, nil
+	case "min":
+		return fieldName, flatMetricsV1.SimpleFieldTypeMin, nil
+	case "max":
+		return fieldName, flatMetricsV1.SimpleFieldTypeMax, nil
+	case "first":
+		return fieldName, flatMetricsV1.SimpleFieldTypeFirst, nil
+	default:
+		return "", flatMetricsV1.SimpleFieldTypeUnSpecified,
+			fmt.Errorf("unsupported field type of csv column: %s, only support sum/last/min/max/first", column)
+	}
+}
+
+// parseTimestamp parses timestamp as epoch milliseconds or RFC3339.
+func parseTimestamp(value string) (int64, error) {
+	if timestamp, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if timestamp <= 0 {
+			return 0, ErrBadTimestamp
+		}
+		return timestamp, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, ErrBadTimestamp
+	}
+	return t.UnixMilli(), nil
+}
+
+// appendRow converts csv record to broker row, then appends it into batch.
+func appendRow(
+	batch *metric.BrokerBatchRows,
+	rowBuilder *commonseries.RowBuilder,
+	s *schema,
+	record []string,
+	namespace string,
+	enrichedTags tag.Tags,
+	limits *models.Limits,
+) error {
+	metricName := strings.TrimSpace(record[s.metricIdx])
+	if metricName == "" {
+		return ErrMissingMetricName
+	}
+	if limits.EnableMetricNameLengthCheck() && len(metricName) > limits.MaxMetricNameLength {
+		return constants.ErrMetricNameTooLong
+	}
+	timestamp, err := parseTimestamp(strings.TrimSpace(record[s.timeIdx]))
+	if err != nil {
+		return err
+	}
+	// reset for constructing next row
+	rowBuilder.Reset()
+	rowBuilder.AddNameSpace(strutil.String2ByteSlice(namespace))
+	rowBuilder.AddMetricName([]byte(metricName))
+	for _, col := range s.tags {
+		tagValue := record[col.idx]
+		if tagValue == "" {
+			// tag not set for this row
+			continue
+		}
+		if limits.EnableTagNameLengthCheck() && len(col.name) > limits.MaxTagNameLength {
+			return constants.ErrTagKeyTooLong
+		}
+		if limits.EnableTagValueLengthCheck() && len(tagValue) > limits.MaxTagValueLength {
+			return constants.ErrTagValueTooLong
+		}
+		if err := rowBuilder.AddTag(col.name, []byte(tagValue)); err != nil {
+			return err
+		}
+	}
+	for _, enrichedTag := range enrichedTags {
+		if err := rowBuilder.AddTag(enrichedTag.Key, enrichedTag.Value); err != nil {
+			return err
+		}
+	}
+	for _, col := range s.fields {
+		fieldValue := strings.TrimSpace(record[col.idx])
+		if fieldValue == "" {
+			// field not set for this row
+			continue
+		}
+		value, err := strconv.ParseFloat(fieldValue, 64)
+		if err != nil {
+			return fmt.Errorf("bad value of field: %s, %w", col.name, err)
+		}
+		if err := rowBuilder.AddSimpleField(col.name, col.fieldType, value); err != nil {
+			return err
+		}
+	}
+	if rowBuilder.SimpleFieldsLen() == 0 {
+		return ErrMissingFields
+	}
+	rowBuilder.AddTimestamp(timestamp)
+	return batch.TryAppend(func(row *metric.BrokerRow) error {
+		data, err := rowBuilder.Build()
+		if err != nil {
+			return err
+		}
+		row.FromBlock(data)
+		return nil
+	})
+}
@@ -0,0 +1,195 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package csv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+	commonseries "github.com/lindb/common/series"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
+)
+
+const _testBody = `__name__,time,tag_host,tag_region,field_usage,field_count:sum
+cpu,1439587925000,host1,sh,12,1
+cpu,2015-08-14T21:32:05Z,host2,,1.2e1,2
+cpu,bad,host3,sh,12,3
+,1439587925000,host4,sh,12,4
+cpu,1439587925000,host5,sh,,
+cpu,1439587925000,host6,sh,abc,6
+cpu,1439587925000,host7
+"cpu",1439587925000,"host,8",sh,12,
+`
+
+func Test_Parse(t *testing.T) {
+	var w bytes.Buffer
+	gw := gzip.NewWriter(&w)
+	_, _ = gw.Write([]byte(_testBody))
+	_ = gw.Close()
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "", &w)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	enrichedTags := []tag.Tag{
+		tag.NewTag([]byte("dc"), []byte("a")),
+	}
+	var rows []metric.BrokerRow
+	summary, err := Parse(req, enrichedTags, "ns", models.NewDefaultLimits(), func(batch *metric.BrokerBatchRows) error {
+		rows = append(rows, batch.Rows()...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Accepted: 3, Rejected: 5}, summary)
+	assert.Len(t, rows, 3)
+	m := rows[0].Metric()
+	assert.Equal(t, "cpu", string(m.Name()))
+	assert.Equal(t, int64(1439587925000), m.Timestamp())
+	assert.Equal(t, 3, m.KeyValuesLength())
+	assert.Equal(t, 2, m.SimpleFieldsLength())
+	m = rows[1].Metric()
+	assert.Equal(t, int64(1439587925000), m.Timestamp())
+	// empty tag value skipped
+	assert.Equal(t, 2, m.KeyValuesLength())
+	m = rows[2].Metric()
+	assert.Equal(t, 3, m.KeyValuesLength())
+	assert.Equal(t, 1, m.SimpleFieldsLength())
+}
+
+func Test_Parse_Batch(t *testing.T) {
+	defer func() {
+		batchSize = 10000
+	}()
+	batchSize = 2
+	var body strings.Builder
+	body.WriteString("__name__,time,field_usage\n")
+	for i := 0; i < 5; i++ {
+		body.WriteString(fmt.Sprintf("cpu,%d,%d\n", 1439587925000+i, i))
+	}
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "", strings.NewReader(body.String()))
+	assert.NoError(t, err)
+	var batches []int
+	summary, err := Parse(req, nil, "ns", models.NewDefaultLimits(), func(batch *metric.BrokerBatchRows) error {
+		batches = append(batches, batch.Len())
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, summary.Accepted)
+	assert.Equal(t, []int{2, 2, 1}, batches)
+
+	// write failure
+	req, err = http.NewRequestWithContext(context.TODO(), http.MethodPost, "", strings.NewReader(body.String()))
+	assert.NoError(t, err)
+	_, err = Parse(req, nil, "ns", models.NewDefaultLimits(), func(batch *metric.BrokerBatchRows) error {
+		return fmt.Errorf("err")
+	})
+	assert.Error(t, err)
+}
+
+func Test_Parse_Error(t *testing.T) {
+	write := func(batch *metric.BrokerBatchRows) error { return nil }
+	cases := []struct {
+		name string
+		body string
+		err  error
+	}{
+		{name: "empty body", body: ""},
+		{name: "missing metric column", body: "time,field_f\n", err: ErrMissingMetricColumn},
+		{name: "missing time column", body: "__name__,field_f\n", err: ErrMissingTimeColumn},
+		{name: "missing field column", body: "__name__,time,tag_host\n", err: ErrMissingFieldColumn},
+		{name: "unknown column", body: "__name__,time,field_f,host\n"},
+		{name: "bad field type", body: "__name__,time,field_f:histogram\n"},
+		{name: "empty field name", body: "__name__,time,field_:sum\n"},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "", strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			_, err = Parse(req, nil, "ns", models.NewDefaultLimits(), write)
+			assert.Error(t, err)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+
+	// corrupted gzip data
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "", strings.NewReader(_testBody))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+	_, err = Parse(req, nil, "ns", models.NewDefaultLimits(), write)
+	assert.Error(t, err)
+}
+
+func Test_parseFieldColumn(t *testing.T) {
+	cases := []struct {
+		column    string
+		name      string
+		fieldType flatMetricsV1.SimpleFieldType
+	}{
+		{column: "f", name: "f", fieldType: flatMetricsV1.SimpleFieldTypeLast},
+		{column: "f:sum", name: "f", fieldType: flatMetricsV1.SimpleFieldTypeDeltaSum},
+		{column: "f:LAST", name: "f", fieldType: flatMetricsV1.SimpleFieldTypeLast},
+		{column: "f:min", name: "f", fieldType: flatMetricsV1.SimpleFieldTypeMin},
+		{column: "f:max", name: "f", fieldType: flatMetricsV1.SimpleFieldTypeMax},
+		{column: "f:first", name: "f", fieldType: flatMetricsV1.SimpleFieldTypeFirst},
+	}
+	for _, tt := range cases {
+		name, fieldType, err := parseFieldColumn(tt.column)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.name, name)
+		assert.Equal(t, tt.fieldType, fieldType)
+	}
+}
+
+func Test_appendRow_Limits(t *testing.T) {
+	s, err := parseHeader([]string{"__name__", "time", "tag_host", "field_f"})
+	assert.NoError(t, err)
+	rowBuilder, release := commonseries.NewRowBuilder()
+	defer release(rowBuilder)
+	batch := metric.NewBrokerBatchRows()
+
+	limits := models.NewDefaultLimits()
+	limits.MaxMetricNameLength = 3
+	err = appendRow(batch, rowBuilder, s, []string{"memory", "1", "h", "1"}, "ns", nil, limits)
+	assert.ErrorIs(t, err, constants.ErrMetricNameTooLong)
+
+	limits = models.NewDefaultLimits()
+	limits.MaxTagNameLength = 3
+	err = appendRow(batch, rowBuilder, s, []string{"cpu", "1", "h", "1"}, "ns", nil, limits)
+	assert.ErrorIs(t, err, constants.ErrTagKeyTooLong)
+
+	limits = models.NewDefaultLimits()
+	limits.MaxTagValueLength = 3
+	err = appendRow(batch, rowBuilder, s, []string{"cpu", "1", "host", "1"}, "ns", nil, limits)
+	assert.ErrorIs(t, err, constants.ErrTagValueTooLong)
+	assert.Equal(t, 0, batch.Len())
+}
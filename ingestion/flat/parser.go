@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 
 	"github.com/lindb/common/pkg/logger"
 
@@ -40,16 +39,13 @@ var (
 var flatLogger = logger.GetLogger("Ingestion", "Flat")
 
 func Parse(req *http.Request, enrichedTags tag.Tags, namespace string, limits *models.Limits) (*metric.BrokerBatchRows, error) {
-	var reader = req.Body
-	if strings.EqualFold(req.Header.Get("Content-Encoding"), "gzip") {
-		gzipReader, err := ingestCommon.GetGzipReader(req.Body)
-		if err != nil {
-			flatIngestionStatistics.CorruptedData.Incr()
-			return nil, fmt.Errorf("ingestion corrupted gzip data: %w", err)
-		}
-		defer ingestCommon.PutGzipReader(gzipReader)
-		reader = gzipReader
+	encoding := req.Header.Get("Content-Encoding")
+	reader, releaseReader, err := ingestCommon.GetReader(encoding, req.Body)
+	if err != nil {
+		flatIngestionStatistics.CorruptedData.Incr()
+		return nil, fmt.Errorf("ingestion corrupted %s data: %w", encoding, err)
 	}
+	defer releaseReader()
 	bufioReader, releaseBufioReaderFunc := ingestCommon.NewBufioReader(reader)
 	defer releaseBufioReaderFunc(bufioReader)
 
@@ -59,6 +55,7 @@ func Parse(req *http.Request, enrichedTags tag.Tags, namespace string, limits *m
 		return nil, err
 	}
 	if batch.Len() == 0 {
+		metric.ReleaseBrokerBatchRows(batch)
 		return nil, fmt.Errorf("empty metrics")
 	}
 	flatIngestionStatistics.IngestedMetrics.Add(float64(batch.Len()))
@@ -73,7 +70,7 @@ func parseFlatMetric(
 ) (
 	batch *metric.BrokerBatchRows, err error,
 ) {
-	batch = metric.NewBrokerBatchRows()
+	batch = metric.AcquireBrokerBatchRows()
 
 	decoder, releaseFunc := metric.NewBrokerRowFlatDecoder(
 		reader,
@@ -0,0 +1,119 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package graphite
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/lindb/common/pkg/logger"
+	commonseries "github.com/lindb/common/series"
+
+	ingestCommon "github.com/lindb/lindb/ingestion/common"
+	"github.com/lindb/lindb/metrics"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
+)
+
+var (
+	graphiteLogger              = logger.GetLogger("Ingestion", "Graphite")
+	graphiteIngestionStatistics = metrics.NewGraphiteIngestionStatistics()
+)
+
+// Parse parses graphite plaintext protocol data to LinDB broker rows.
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-plaintext-protocol
+// metric path is mapped to metric name and tags by template query param, field name is value(delta sum).
+func Parse(req *http.Request, enrichedTags tag.Tags, namespace string, limits *models.Limits) (*metric.BrokerBatchRows, error) {
+	tmpl, err := newTemplate(req.URL.Query().Get("template"))
+	if err != nil {
+		return nil, err
+	}
+	encoding := req.Header.Get("Content-Encoding")
+	reader, releaseReader, err := ingestCommon.GetReader(encoding, req.Body)
+	if err != nil {
+		graphiteIngestionStatistics.CorruptedData.Incr()
+		return nil, fmt.Errorf("ingestion corrupted %s data: %w", encoding, err)
+	}
+	defer releaseReader()
+
+	bufioReader, releaseBufioReader := ingestCommon.NewBufioReader(reader)
+	defer releaseBufioReader(bufioReader)
+
+	rowBuilder, releaseFunc := commonseries.NewRowBuilder()
+	defer releaseFunc(rowBuilder)
+
+	batch := metric.AcquireBrokerBatchRows()
+	for {
+		line, readErr := bufioReader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			graphiteIngestionStatistics.CorruptedData.Incr()
+			return batch, readErr
+		}
+		graphiteIngestionStatistics.ReadBytes.Add(float64(len(line)))
+		line = bytes.TrimSpace(line)
+		// skip blank line
+		if len(line) > 0 {
+			appendRow(batch, rowBuilder, line, namespace, tmpl, enrichedTags, limits)
+		}
+		if readErr == io.EOF {
+			return batch, nil
+		}
+	}
+}
+
+// appendRow parses graphite line, then appends parsed row into batch, drops the line if failure.
+func appendRow(
+	batch *metric.BrokerBatchRows,
+	rowBuilder *commonseries.RowBuilder,
+	line []byte,
+	namespace string,
+	tmpl *template,
+	enrichedTags tag.Tags,
+	limits *models.Limits,
+) {
+	// reset for constructing next row
+	rowBuilder.Reset()
+	if err := parseGraphiteLine(rowBuilder, line, namespace, tmpl, limits); err != nil {
+		graphiteLogger.Warn("ingest error",
+			logger.String("line", string(line)),
+			logger.Error(err))
+		graphiteIngestionStatistics.DroppedMetrics.Incr()
+		return
+	}
+	for _, enrichedTag := range enrichedTags {
+		if err := rowBuilder.AddTag(enrichedTag.Key, enrichedTag.Value); err != nil {
+			graphiteIngestionStatistics.DroppedMetrics.Incr()
+			return
+		}
+	}
+	if err := batch.TryAppend(func(row *metric.BrokerRow) error {
+		data, err := rowBuilder.Build()
+		if err != nil {
+			return err
+		}
+		row.FromBlock(data)
+		return nil
+	}); err != nil {
+		graphiteIngestionStatistics.DroppedMetrics.Incr()
+		return
+	}
+	graphiteIngestionStatistics.IngestedMetrics.Incr()
+}
@@ -0,0 +1,79 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package graphite
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/tag"
+)
+
+const _testBody = `
+web.host1.cpu 12 1439587925
+
+web.host2.cpu 1.2e1 1439587925
+web.host3.cpu 12
+web.host4.cpu 12 -1
+bad line
+web.host5.cpu 12 1439587925`
+
+func Test_Parse(t *testing.T) {
+	var w bytes.Buffer
+	gw := gzip.NewWriter(&w)
+	_, _ = gw.Write([]byte(_testBody))
+	_ = gw.Close()
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPut, "?template=app.host.measurement", &w)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	enrichedTags := []tag.Tag{
+		tag.NewTag([]byte("region"), []byte("sh")),
+	}
+	batch, err := Parse(req, enrichedTags, "ns", models.NewDefaultLimits())
+	assert.NoError(t, err)
+	assert.Len(t, batch.Rows(), 4)
+	for _, row := range batch.Rows() {
+		m := row.Metric()
+		assert.Equal(t, "cpu", string(m.Name()))
+		assert.Equal(t, 3, m.KeyValuesLength())
+	}
+}
+
+func Test_Parse_Error(t *testing.T) {
+	// bad template
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPut, "?template=app.host", strings.NewReader(_testBody))
+	assert.NoError(t, err)
+	_, err = Parse(req, nil, "ns", models.NewDefaultLimits())
+	assert.ErrorIs(t, err, ErrBadTemplate)
+
+	// corrupted gzip data
+	req, err = http.NewRequestWithContext(context.TODO(), http.MethodPut, "", strings.NewReader(_testBody))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+	_, err = Parse(req, nil, "ns", models.NewDefaultLimits())
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package graphite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lindb/common/pkg/fasttime"
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+	commonseries "github.com/lindb/common/series"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/strutil"
+)
+
+var (
+	ErrMissingMetricName = errors.New("missing_metric_name")
+	ErrBadLine           = errors.New("bad_line")
+	ErrBadValue          = errors.New("bad_value")
+	ErrBadTimestamp      = errors.New("bad_timestamp")
+	ErrBadTemplate       = errors.New("bad_template")
+)
+
+const (
+	// templateMeasurement is the template part which path part is used as metric name.
+	templateMeasurement = "measurement"
+	// templateMeasurementGreedy is the template part which all remaining path parts are used as metric name.
+	templateMeasurementGreedy = "measurement*"
+	// templateSkip is the template part which path part is ignored.
+	templateSkip = "*"
+)
+
+// defaultFieldName is the field name of graphite value.
+var defaultFieldName = []byte("value")
+
+// template maps dotted metric path to metric name and tags, e.g.
+// template: app.*.measurement, path: web.host1.cpu => metric name: cpu, tags: app=web.
+// 1. measurement: path part is a part of metric name(multi parts joined with dot)
+// 2. measurement*: all remaining path parts are parts of metric name
+// 3. *: path part is ignored
+// 4. others: tag key, path part is tag value
+// If template is empty, whole path is metric name.
+type template struct {
+	parts []string
+}
+
+// newTemplate creates a template by given template string.
+func newTemplate(tmpl string) (*template, error) {
+	if tmpl == "" {
+		return &template{}, nil
+	}
+	parts := strings.Split(tmpl, ".")
+	hasMeasurement := false
+	for idx, part := range parts {
+		switch part {
+		case "":
+			return nil, fmt.Errorf("%w: %s, empty part", ErrBadTemplate, tmpl)
+		case templateMeasurement:
+			hasMeasurement = true
+		case templateMeasurementGreedy:
+			if idx != len(parts)-1 {
+				return nil, fmt.Errorf("%w: %s, %s must be last part", ErrBadTemplate, tmpl, templateMeasurementGreedy)
+			}
+			hasMeasurement = true
+		}
+	}
+	if !hasMeasurement {
+		return nil, fmt.Errorf("%w: %s, missing %s", ErrBadTemplate, tmpl, templateMeasurement)
+	}
+	return &template{parts: parts}, nil
+}
+
+// apply applies the template for metric path, returns metric name and tags.
+func (t *template) apply(path string, fn func(tagKey, tagValue string) error) (metricName string, err error) {
+	if len(t.parts) == 0 {
+		return path, nil
+	}
+	pathParts := strings.Split(path, ".")
+	var nameParts []string
+	for idx, part := range t.parts {
+		if idx >= len(pathParts) {
+			break
+		}
+		switch part {
+		case templateMeasurement:
+			nameParts = append(nameParts, pathParts[idx])
+		case templateMeasurementGreedy:
+			nameParts = append(nameParts, pathParts[idx:]...)
+		case templateSkip:
+		default:
+			if err := fn(part, pathParts[idx]); err != nil {
+				return "", err
+			}
+		}
+	}
+	metricName = strings.Join(nameParts, ".")
+	if metricName == "" {
+		return "", ErrMissingMetricName
+	}
+	return metricName, nil
+}
+
+// parseGraphiteLine parses graphite plaintext line: metric.path value [timestamp],
+// timestamp is seconds, current time is used if timestamp not set.
+func parseGraphiteLine(
+	builder *commonseries.RowBuilder,
+	content []byte,
+	namespace string,
+	tmpl *template,
+	limits *models.Limits,
+) error {
+	items := bytes.Fields(content)
+	if len(items) < 2 || len(items) > 3 {
+		return ErrBadLine
+	}
+	builder.AddNameSpace(strutil.String2ByteSlice(namespace))
+
+	// parse metric name/tags
+	metricName, err := tmpl.apply(string(items[0]), func(tagKey, tagValue string) error {
+		if limits.EnableTagNameLengthCheck() && len(tagKey) > limits.MaxTagNameLength {
+			return constants.ErrTagKeyTooLong
+		}
+		if limits.EnableTagValueLengthCheck() && len(tagValue) > limits.MaxTagValueLength {
+			return constants.ErrTagValueTooLong
+		}
+		return builder.AddTag([]byte(tagKey), []byte(tagValue))
+	})
+	if err != nil {
+		return err
+	}
+	if limits.EnableMetricNameLengthCheck() && len(metricName) > limits.MaxMetricNameLength {
+		return constants.ErrMetricNameTooLong
+	}
+	builder.AddMetricName([]byte(metricName))
+
+	// parse value, supports scientific notation
+	value, err := strconv.ParseFloat(string(items[1]), 64)
+	if err != nil {
+		return ErrBadValue
+	}
+	if err := builder.AddSimpleField(defaultFieldName, flatMetricsV1.SimpleFieldTypeDeltaSum, value); err != nil {
+		return err
+	}
+
+	// parse timestamp
+	timestamp := fasttime.UnixMilliseconds()
+	if len(items) == 3 {
+		seconds, err := strconv.ParseFloat(string(items[2]), 64)
+		if err != nil || seconds < 0 {
+			return ErrBadTimestamp
+		}
+		timestamp = int64(seconds * 1000)
+	}
+	builder.AddTimestamp(timestamp)
+	return nil
+}
@@ -0,0 +1,162 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+	commonseries "github.com/lindb/common/series"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/metric"
+)
+
+func Test_newTemplate(t *testing.T) {
+	for _, tmpl := range []string{"", "measurement", "app.*.measurement", "app.measurement*", "measurement.measurement"} {
+		_, err := newTemplate(tmpl)
+		assert.NoError(t, err, tmpl)
+	}
+	for _, tmpl := range []string{"app", "app..measurement", "measurement*.host", "app.*"} {
+		_, err := newTemplate(tmpl)
+		assert.ErrorIs(t, err, ErrBadTemplate, tmpl)
+	}
+}
+
+func Test_parseGraphiteLine(t *testing.T) {
+	builder, releaseFunc := commonseries.NewRowBuilder()
+	defer releaseFunc(builder)
+
+	cases := []struct {
+		line       string
+		template   string
+		metricName string
+		tags       map[string]string
+		value      float64
+		timestamp  int64
+	}{
+		{
+			line:       "servers.host1.cpu 12.5 1439587925",
+			metricName: "servers.host1.cpu",
+			tags:       map[string]string{},
+			value:      12.5,
+			timestamp:  1439587925000,
+		},
+		{
+			line:       "web.host1.cpu 1.5e3 1439587925",
+			template:   "app.*.measurement",
+			metricName: "cpu",
+			tags:       map[string]string{"app": "web"},
+			value:      1500,
+			timestamp:  1439587925000,
+		},
+		{
+			line:       "web.host1.cpu.user -2E-2\t1439587925",
+			template:   "app.host.measurement*",
+			metricName: "cpu.user",
+			tags:       map[string]string{"app": "web", "host": "host1"},
+			value:      -0.02,
+			timestamp:  1439587925000,
+		},
+		{
+			line:       "web.cpu.user 1 1439587925.5",
+			template:   "app.measurement.measurement.host",
+			metricName: "cpu.user",
+			tags:       map[string]string{"app": "web"},
+			value:      1,
+			timestamp:  1439587925500,
+		},
+	}
+	for _, tt := range cases {
+		builder.Reset()
+		tmpl, err := newTemplate(tt.template)
+		assert.NoError(t, err)
+		err = parseGraphiteLine(builder, []byte(tt.line), "ns", tmpl, models.NewDefaultLimits())
+		assert.NoError(t, err, tt.line)
+		var row metric.BrokerRow
+		data, err := builder.Build()
+		assert.NoError(t, err)
+		(&row).FromBlock(data)
+		m := row.Metric()
+		assert.Equal(t, "ns", string(m.Namespace()))
+		assert.Equal(t, tt.metricName, string(m.Name()))
+		tags := make(map[string]string)
+		var kv flatMetricsV1.KeyValue
+		for i := 0; i < m.KeyValuesLength(); i++ {
+			m.KeyValues(&kv, i)
+			tags[string(kv.Key())] = string(kv.Value())
+		}
+		assert.Equal(t, tt.tags, tags, tt.line)
+		assert.Equal(t, tt.timestamp, m.Timestamp(), tt.line)
+		assert.Equal(t, 1, m.SimpleFieldsLength())
+		var sf flatMetricsV1.SimpleField
+		m.SimpleFields(&sf, 0)
+		assert.Equal(t, "value", string(sf.Name()))
+		assert.Equal(t, flatMetricsV1.SimpleFieldTypeDeltaSum, sf.Type())
+		assert.Equal(t, tt.value, sf.Value())
+	}
+
+	// without timestamp
+	builder.Reset()
+	assert.NoError(t, parseGraphiteLine(builder, []byte("cpu 1"), "ns", &template{}, models.NewDefaultLimits()))
+	var row metric.BrokerRow
+	data, err := builder.Build()
+	assert.NoError(t, err)
+	(&row).FromBlock(data)
+	m := row.Metric()
+	assert.NotZero(t, m.Timestamp())
+}
+
+func Test_parseGraphiteLine_Error(t *testing.T) {
+	builder, releaseFunc := commonseries.NewRowBuilder()
+	defer releaseFunc(builder)
+
+	tmpl, err := newTemplate("app.*.measurement")
+	assert.NoError(t, err)
+	limits := models.NewDefaultLimits()
+	limits.MaxMetricNameLength = 5
+	limits.MaxTagNameLength = 5
+	limits.MaxTagValueLength = 5
+	cases := []struct {
+		line string
+		tmpl *template
+		err  error
+	}{
+		{line: "cpu", tmpl: &template{}, err: ErrBadLine},
+		{line: "cpu 1 1439587925 1", tmpl: &template{}, err: ErrBadLine},
+		{line: "cpu abc 1439587925", tmpl: &template{}, err: ErrBadValue},
+		{line: "cpu 1 -1439587925", tmpl: &template{}, err: ErrBadTimestamp},
+		{line: "cpu 1 abc", tmpl: &template{}, err: ErrBadTimestamp},
+		{line: "web.host1 1 1439587925", tmpl: tmpl, err: ErrMissingMetricName},
+		{line: "cpu.user.total 1 1439587925", tmpl: &template{}, err: constants.ErrMetricNameTooLong},
+		{line: "webapp.host1.cpu 1 1439587925", tmpl: tmpl, err: constants.ErrTagValueTooLong},
+	}
+	for _, tt := range cases {
+		builder.Reset()
+		err := parseGraphiteLine(builder, []byte(tt.line), "ns", tt.tmpl, limits)
+		assert.ErrorIs(t, err, tt.err, tt.line)
+	}
+	tmpl, err = newTemplate("application.measurement")
+	assert.NoError(t, err)
+	builder.Reset()
+	err = parseGraphiteLine(builder, []byte("web.cpu 1"), "ns", tmpl, limits)
+	assert.ErrorIs(t, err, constants.ErrTagKeyTooLong)
+}
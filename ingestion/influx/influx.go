@@ -41,16 +41,13 @@ var (
 // https://docs.influxdata.com/influxdb/v2.0/write-data/developer-tools/api/#example-api-write-request
 func Parse(req *http.Request, enrichedTags tag.Tags, namespace string, limits *models.Limits) (*metric.BrokerBatchRows, error) {
 	qry := req.URL.Query()
-	var reader = req.Body
-	if strings.EqualFold(req.Header.Get("Content-Encoding"), "gzip") {
-		gzipReader, err := ingestCommon.GetGzipReader(req.Body)
-		if err != nil {
-			influxIngestionStatistics.CorruptedData.Incr()
-			return nil, fmt.Errorf("ingestion corrupted gzip data: %w", err)
-		}
-		defer ingestCommon.PutGzipReader(gzipReader)
-		reader = gzipReader
+	encoding := req.Header.Get("Content-Encoding")
+	reader, releaseReader, err := ingestCommon.GetReader(encoding, req.Body)
+	if err != nil {
+		influxIngestionStatistics.CorruptedData.Incr()
+		return nil, fmt.Errorf("ingestion corrupted %s data: %w", encoding, err)
 	}
+	defer releaseReader()
 	// precision
 	multiplier := getPrecisionMultiplier(qry.Get("precision"))
 
@@ -60,7 +57,7 @@ func Parse(req *http.Request, enrichedTags tag.Tags, namespace string, limits *m
 	rowBuilder, releaseFunc := commonseries.NewRowBuilder()
 	defer releaseFunc(rowBuilder)
 
-	batch := metric.NewBrokerBatchRows()
+	batch := metric.AcquireBrokerBatchRows()
 
 	for cr.HasNext() {
 		nextLine := cr.Next()
@@ -0,0 +1,214 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package opentsdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/lindb/common/pkg/encoding"
+	"github.com/lindb/common/pkg/logger"
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+	commonseries "github.com/lindb/common/series"
+
+	"github.com/lindb/lindb/constants"
+	ingestCommon "github.com/lindb/lindb/ingestion/common"
+	"github.com/lindb/lindb/metrics"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/strutil"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
+)
+
+var (
+	ErrMissingMetricName = errors.New("missing_metric_name")
+	ErrMissingTags       = errors.New("missing_tags")
+	ErrBadTimestamp      = errors.New("bad_timestamp")
+)
+
+var (
+	openTSDBLogger              = logger.GetLogger("Ingestion", "OpenTSDB")
+	openTSDBIngestionStatistics = metrics.NewOpenTSDBIngestionStatistics()
+)
+
+const (
+	// maxSecondsTimestamp is the max timestamp in seconds(10 digits), larger timestamp is milliseconds.
+	maxSecondsTimestamp = 9999999999
+)
+
+// defaultFieldName is the field name of opentsdb point value.
+var defaultFieldName = []byte("value")
+
+// Point represents the data point of opentsdb put api.
+// http://opentsdb.net/docs/build/html/api_http/put.html
+type Point struct {
+	Metric    string            `json:"metric"`
+	Timestamp int64             `json:"timestamp"`
+	Value     float64           `json:"value"`
+	Tags      map[string]string `json:"tags"`
+}
+
+// ErrorDetail represents the failure detail of data point.
+type ErrorDetail struct {
+	Datapoint *Point `json:"datapoint"`
+	Error     string `json:"error"`
+}
+
+// Summary represents the summary of put request, returns if details param set.
+type Summary struct {
+	Success int            `json:"success"`
+	Failed  int            `json:"failed"`
+	Errors  []*ErrorDetail `json:"errors,omitempty"`
+}
+
+// Parse parses opentsdb put api json data(single point or point array) to LinDB broker rows,
+// invalid points are dropped and counted into summary, point value is written as last field named value.
+func Parse(req *http.Request, enrichedTags tag.Tags, namespace string, limits *models.Limits) (
+	batch *metric.BrokerBatchRows, summary *Summary, err error,
+) {
+	encodingType := req.Header.Get("Content-Encoding")
+	reader, releaseReader, err := ingestCommon.GetReader(encodingType, req.Body)
+	if err != nil {
+		openTSDBIngestionStatistics.CorruptedData.Incr()
+		return nil, nil, fmt.Errorf("ingestion corrupted %s data: %w", encodingType, err)
+	}
+	defer releaseReader()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		openTSDBIngestionStatistics.CorruptedData.Incr()
+		return nil, nil, err
+	}
+	openTSDBIngestionStatistics.ReadBytes.Add(float64(len(data)))
+
+	points, err := unmarshalPoints(data)
+	if err != nil {
+		openTSDBIngestionStatistics.CorruptedData.Incr()
+		return nil, nil, err
+	}
+
+	rowBuilder, releaseFunc := commonseries.NewRowBuilder()
+	defer releaseFunc(rowBuilder)
+
+	batch = metric.AcquireBrokerBatchRows()
+	summary = &Summary{}
+	for _, point := range points {
+		if point == nil {
+			// null data point in array
+			point = &Point{}
+		}
+		if err := appendRow(batch, rowBuilder, point, namespace, enrichedTags, limits); err != nil {
+			openTSDBLogger.Warn("ingest error",
+				logger.String("metric", point.Metric),
+				logger.Error(err))
+			openTSDBIngestionStatistics.DroppedMetrics.Incr()
+			summary.Failed++
+			summary.Errors = append(summary.Errors, &ErrorDetail{Datapoint: point, Error: err.Error()})
+			continue
+		}
+		openTSDBIngestionStatistics.IngestedMetrics.Incr()
+		summary.Success++
+	}
+	return batch, summary, nil
+}
+
+// unmarshalPoints unmarshals single point or point array.
+func unmarshalPoints(data []byte) ([]*Point, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty data points")
+	}
+	if data[0] == '[' {
+		var points []*Point
+		if err := encoding.JSONUnmarshal(data, &points); err != nil {
+			return nil, err
+		}
+		return points, nil
+	}
+	point := &Point{}
+	if err := encoding.JSONUnmarshal(data, point); err != nil {
+		return nil, err
+	}
+	return []*Point{point}, nil
+}
+
+// appendRow converts point to broker row, then appends it into batch.
+func appendRow(
+	batch *metric.BrokerBatchRows,
+	rowBuilder *commonseries.RowBuilder,
+	point *Point,
+	namespace string,
+	enrichedTags tag.Tags,
+	limits *models.Limits,
+) error {
+	if point.Metric == "" {
+		return ErrMissingMetricName
+	}
+	if len(point.Tags) == 0 {
+		return ErrMissingTags
+	}
+	if point.Timestamp <= 0 {
+		return ErrBadTimestamp
+	}
+	if limits.EnableMetricNameLengthCheck() && len(point.Metric) > limits.MaxMetricNameLength {
+		return constants.ErrMetricNameTooLong
+	}
+	// reset for constructing next row
+	rowBuilder.Reset()
+	rowBuilder.AddNameSpace(strutil.String2ByteSlice(namespace))
+	rowBuilder.AddMetricName([]byte(point.Metric))
+	for tagKey, tagValue := range point.Tags {
+		if limits.EnableTagNameLengthCheck() && len(tagKey) > limits.MaxTagNameLength {
+			return constants.ErrTagKeyTooLong
+		}
+		if limits.EnableTagValueLengthCheck() && len(tagValue) > limits.MaxTagValueLength {
+			return constants.ErrTagValueTooLong
+		}
+		if err := rowBuilder.AddTag([]byte(tagKey), []byte(tagValue)); err != nil {
+			return err
+		}
+	}
+	for _, enrichedTag := range enrichedTags {
+		if err := rowBuilder.AddTag(enrichedTag.Key, enrichedTag.Value); err != nil {
+			return err
+		}
+	}
+	if err := rowBuilder.AddSimpleField(defaultFieldName, flatMetricsV1.SimpleFieldTypeLast, point.Value); err != nil {
+		return err
+	}
+	rowBuilder.AddTimestamp(normalizeTimestamp(point.Timestamp))
+	return batch.TryAppend(func(row *metric.BrokerRow) error {
+		data, err := rowBuilder.Build()
+		if err != nil {
+			return err
+		}
+		row.FromBlock(data)
+		return nil
+	})
+}
+
+// normalizeTimestamp normalizes timestamp to milliseconds, timestamp may be seconds or milliseconds(detect by magnitude).
+func normalizeTimestamp(timestamp int64) int64 {
+	if timestamp <= maxSecondsTimestamp {
+		return timestamp * 1000
+	}
+	return timestamp
+}
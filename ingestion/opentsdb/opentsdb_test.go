@@ -0,0 +1,130 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package opentsdb
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/tag"
+)
+
+func newRequest(t *testing.T, body string) *http.Request {
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "", strings.NewReader(body))
+	assert.NoError(t, err)
+	return req
+}
+
+func TestParse_SinglePoint(t *testing.T) {
+	enrichedTags := []tag.Tag{tag.NewTag([]byte("region"), []byte("sh"))}
+	batch, summary, err := Parse(newRequest(t, `
+{"metric":"sys.cpu.nice","timestamp":1346846400,"value":18,"tags":{"host":"web01","dc":"lga"}}`),
+		enrichedTags, "ns", models.NewDefaultLimits())
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Success: 1}, summary)
+	assert.Len(t, batch.Rows(), 1)
+	row := batch.Rows()[0]
+	m := row.Metric()
+	assert.Equal(t, "sys.cpu.nice", string(m.Name()))
+	assert.Equal(t, "ns", string(m.Namespace()))
+	// seconds normalized to milliseconds
+	assert.Equal(t, int64(1346846400000), m.Timestamp())
+	assert.Equal(t, 3, m.KeyValuesLength())
+	var sf flatMetricsV1.SimpleField
+	m.SimpleFields(&sf, 0)
+	assert.Equal(t, "value", string(sf.Name()))
+	assert.Equal(t, flatMetricsV1.SimpleFieldTypeLast, sf.Type())
+	assert.Equal(t, 18.0, sf.Value())
+}
+
+func TestParse_Points(t *testing.T) {
+	var w bytes.Buffer
+	gw := gzip.NewWriter(&w)
+	_, _ = gw.Write([]byte(`[
+{"metric":"sys.cpu.nice","timestamp":1346846400123,"value":1.5e1,"tags":{"host":"web01"}},
+{"metric":"","timestamp":1346846400,"value":18,"tags":{"host":"web01"}},
+{"metric":"sys.cpu.nice","timestamp":1346846400,"value":18},
+{"metric":"sys.cpu.nice","timestamp":-1,"value":18,"tags":{"host":"web01"}},
+{"metric":"sys.cpu.nice","timestamp":1346846400,"value":18,"tags":{"host":"web02"}}
+]`))
+	_ = gw.Close()
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "", &w)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	batch, summary, err := Parse(req, nil, "ns", models.NewDefaultLimits())
+	assert.NoError(t, err)
+	assert.Len(t, batch.Rows(), 2)
+	row := batch.Rows()[0]
+	m := row.Metric()
+	assert.Equal(t, int64(1346846400123), m.Timestamp())
+	assert.Equal(t, 2, summary.Success)
+	assert.Equal(t, 3, summary.Failed)
+	assert.Len(t, summary.Errors, 3)
+	assert.Equal(t, ErrMissingMetricName.Error(), summary.Errors[0].Error)
+	assert.Equal(t, ErrMissingTags.Error(), summary.Errors[1].Error)
+	assert.Equal(t, ErrBadTimestamp.Error(), summary.Errors[2].Error)
+	assert.Equal(t, int64(-1), summary.Errors[2].Datapoint.Timestamp)
+}
+
+func TestParse_Error(t *testing.T) {
+	for _, body := range []string{"", "  ", "{bad", "[{bad"} {
+		_, _, err := Parse(newRequest(t, body), nil, "ns", models.NewDefaultLimits())
+		assert.Error(t, err, body)
+	}
+	// corrupted gzip data
+	req := newRequest(t, "[]")
+	req.Header.Set("Content-Encoding", "gzip")
+	_, _, err := Parse(req, nil, "ns", models.NewDefaultLimits())
+	assert.Error(t, err)
+}
+
+func TestParse_Limits(t *testing.T) {
+	limits := models.NewDefaultLimits()
+	limits.MaxMetricNameLength = 5
+	limits.MaxTagNameLength = 5
+	limits.MaxTagValueLength = 5
+	_, summary, err := Parse(newRequest(t, `[
+{"metric":"sys.cpu.nice","timestamp":1346846400,"value":18,"tags":{"host":"web01"}},
+{"metric":"cpu","timestamp":1346846400,"value":18,"tags":{"hostname":"web01"}},
+{"metric":"cpu","timestamp":1346846400,"value":18,"tags":{"host":"web01.lindb"}},
+null
+]`), nil, "ns", limits)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, summary.Failed)
+	assert.Equal(t, constants.ErrMetricNameTooLong.Error(), summary.Errors[0].Error)
+	assert.Equal(t, constants.ErrTagKeyTooLong.Error(), summary.Errors[1].Error)
+	assert.Equal(t, constants.ErrTagValueTooLong.Error(), summary.Errors[2].Error)
+	assert.Equal(t, ErrMissingMetricName.Error(), summary.Errors[3].Error)
+}
+
+func TestNormalizeTimestamp(t *testing.T) {
+	assert.Equal(t, int64(1346846400000), normalizeTimestamp(1346846400))
+	assert.Equal(t, int64(1346846400123), normalizeTimestamp(1346846400123))
+	assert.Equal(t, int64(9999999999000), normalizeTimestamp(9999999999))
+}
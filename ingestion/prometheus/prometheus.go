@@ -0,0 +1,203 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/lindb/common/pkg/logger"
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+	commonseries "github.com/lindb/common/series"
+
+	"github.com/lindb/lindb/constants"
+	ingestCommon "github.com/lindb/lindb/ingestion/common"
+	"github.com/lindb/lindb/metrics"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/strutil"
+	protoPrometheusV1 "github.com/lindb/lindb/proto/gen/v1/prometheus"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/series/tag"
+)
+
+var (
+	ErrMissingMetricName = errors.New("missing_metric_name")
+)
+
+var (
+	prometheusLogger              = logger.GetLogger("Ingestion", "Prometheus")
+	prometheusIngestionStatistics = metrics.NewPrometheusIngestionStatistics()
+)
+
+const (
+	// metricNameLabel is the label name of metric name.
+	metricNameLabel = "__name__"
+	// staleNaN is the bits of staleness marker, which marks the series is stale.
+	// https://github.com/prometheus/prometheus/blob/main/model/value/value.go
+	staleNaN uint64 = 0x7ff0000000000002
+)
+
+// defaultFieldName is the field name of prometheus sample value.
+var defaultFieldName = []byte("value")
+
+// counterSuffixes represents the suffixes of cumulative series, family name is the name without suffix.
+var counterSuffixes = []string{"_total", "_count", "_sum", "_bucket"}
+
+// Parse parses prometheus remote write request(snappy block compressed protobuf) to LinDB broker rows.
+// https://prometheus.io/docs/concepts/remote_write_spec/
+// __name__ label is mapped to metric name, other labels are mapped to tags, each sample is written as one row
+// with value field, field type is delta sum for counter(by metadata or metric name suffix), else last.
+// staleness marker samples are skipped.
+func Parse(req *http.Request, enrichedTags tag.Tags, namespace string, limits *models.Limits) (*metric.BrokerBatchRows, error) {
+	compressed, data, err := ingestCommon.ReadSnappyBlock(req.Body)
+	prometheusIngestionStatistics.ReadBytes.Add(float64(len(compressed)))
+	if err != nil {
+		prometheusIngestionStatistics.CorruptedData.Incr()
+		return nil, fmt.Errorf("ingestion corrupted prometheus data: %w", err)
+	}
+	var writeReq protoPrometheusV1.WriteRequest
+	if err := writeReq.Unmarshal(data); err != nil {
+		prometheusIngestionStatistics.CorruptedData.Incr()
+		return nil, fmt.Errorf("ingestion corrupted prometheus data: %w", err)
+	}
+
+	metricTypes := make(map[string]protoPrometheusV1.MetricType, len(writeReq.Metadata))
+	for _, metadata := range writeReq.Metadata {
+		metricTypes[metadata.MetricFamilyName] = metadata.Type
+	}
+
+	rowBuilder, releaseFunc := commonseries.NewRowBuilder()
+	defer releaseFunc(rowBuilder)
+
+	batch := metric.AcquireBrokerBatchRows()
+	for _, ts := range writeReq.Timeseries {
+		metricName := getMetricName(ts)
+		fieldType := getFieldType(metricName, metricTypes)
+		for _, sample := range ts.Samples {
+			if math.Float64bits(sample.Value) == staleNaN {
+				prometheusIngestionStatistics.StaleSamples.Incr()
+				continue
+			}
+			if err := appendRow(batch, rowBuilder, ts, metricName, fieldType, sample, namespace, enrichedTags, limits); err != nil {
+				prometheusLogger.Warn("ingest error",
+					logger.String("metric", metricName),
+					logger.Error(err))
+				prometheusIngestionStatistics.DroppedMetrics.Incr()
+				continue
+			}
+			prometheusIngestionStatistics.IngestedMetrics.Incr()
+		}
+	}
+	return batch, nil
+}
+
+// getMetricName returns the value of __name__ label.
+func getMetricName(ts *protoPrometheusV1.TimeSeries) string {
+	for _, label := range ts.Labels {
+		if label.Name == metricNameLabel {
+			return label.Value
+		}
+	}
+	return ""
+}
+
+// getFieldType returns the field type of metric, delta sum for cumulative series, else last.
+// metric type is found by metadata first, if metadata not exist, detects by metric name suffix.
+func getFieldType(metricName string, metricTypes map[string]protoPrometheusV1.MetricType) flatMetricsV1.SimpleFieldType {
+	if metricType, ok := metricTypes[metricName]; ok {
+		if metricType == protoPrometheusV1.MetricType_COUNTER {
+			return flatMetricsV1.SimpleFieldTypeDeltaSum
+		}
+		return flatMetricsV1.SimpleFieldTypeLast
+	}
+	for _, suffix := range counterSuffixes {
+		if !strings.HasSuffix(metricName, suffix) {
+			continue
+		}
+		metricType, ok := metricTypes[strings.TrimSuffix(metricName, suffix)]
+		if !ok {
+			// no metadata, cumulative series detected by suffix
+			return flatMetricsV1.SimpleFieldTypeDeltaSum
+		}
+		switch metricType {
+		case protoPrometheusV1.MetricType_COUNTER, protoPrometheusV1.MetricType_HISTOGRAM, protoPrometheusV1.MetricType_SUMMARY:
+			return flatMetricsV1.SimpleFieldTypeDeltaSum
+		default:
+			return flatMetricsV1.SimpleFieldTypeLast
+		}
+	}
+	return flatMetricsV1.SimpleFieldTypeLast
+}
+
+// appendRow converts sample of time series to broker row, then appends it into batch.
+func appendRow(
+	batch *metric.BrokerBatchRows,
+	rowBuilder *commonseries.RowBuilder,
+	ts *protoPrometheusV1.TimeSeries,
+	metricName string,
+	fieldType flatMetricsV1.SimpleFieldType,
+	sample *protoPrometheusV1.Sample,
+	namespace string,
+	enrichedTags tag.Tags,
+	limits *models.Limits,
+) error {
+	if metricName == "" {
+		return ErrMissingMetricName
+	}
+	if limits.EnableMetricNameLengthCheck() && len(metricName) > limits.MaxMetricNameLength {
+		return constants.ErrMetricNameTooLong
+	}
+	// reset for constructing next row
+	rowBuilder.Reset()
+	rowBuilder.AddNameSpace(strutil.String2ByteSlice(namespace))
+	rowBuilder.AddMetricName([]byte(metricName))
+	for _, label := range ts.Labels {
+		if label.Name == metricNameLabel {
+			continue
+		}
+		if limits.EnableTagNameLengthCheck() && len(label.Name) > limits.MaxTagNameLength {
+			return constants.ErrTagKeyTooLong
+		}
+		if limits.EnableTagValueLengthCheck() && len(label.Value) > limits.MaxTagValueLength {
+			return constants.ErrTagValueTooLong
+		}
+		if err := rowBuilder.AddTag([]byte(label.Name), []byte(label.Value)); err != nil {
+			return err
+		}
+	}
+	for _, enrichedTag := range enrichedTags {
+		if err := rowBuilder.AddTag(enrichedTag.Key, enrichedTag.Value); err != nil {
+			return err
+		}
+	}
+	if err := rowBuilder.AddSimpleField(defaultFieldName, fieldType, sample.Value); err != nil {
+		return err
+	}
+	rowBuilder.AddTimestamp(sample.Timestamp)
+	return batch.TryAppend(func(row *metric.BrokerRow) error {
+		data, err := rowBuilder.Build()
+		if err != nil {
+			return err
+		}
+		row.FromBlock(data)
+		return nil
+	})
+}
@@ -0,0 +1,157 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+
+	"github.com/lindb/lindb/models"
+	protoPrometheusV1 "github.com/lindb/lindb/proto/gen/v1/prometheus"
+	"github.com/lindb/lindb/series/tag"
+)
+
+func newRequest(t *testing.T, writeReq *protoPrometheusV1.WriteRequest) *http.Request {
+	data, err := writeReq.Marshal()
+	assert.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "", bytes.NewReader(snappy.Encode(nil, data)))
+	assert.NoError(t, err)
+	return req
+}
+
+func newTimeSeries(name string, value float64, labels ...string) *protoPrometheusV1.TimeSeries {
+	ts := &protoPrometheusV1.TimeSeries{
+		Samples: []*protoPrometheusV1.Sample{{Value: value, Timestamp: 1346846400123}},
+	}
+	if name != "" {
+		ts.Labels = append(ts.Labels, &protoPrometheusV1.Label{Name: metricNameLabel, Value: name})
+	}
+	for i := 0; i < len(labels); i += 2 {
+		ts.Labels = append(ts.Labels, &protoPrometheusV1.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return ts
+}
+
+func TestParse(t *testing.T) {
+	enrichedTags := []tag.Tag{tag.NewTag([]byte("region"), []byte("sh"))}
+	stale := newTimeSeries("cpu_load", 1, "host", "web01")
+	stale.Samples = append(stale.Samples, &protoPrometheusV1.Sample{Value: math.Float64frombits(staleNaN), Timestamp: 1346846400223})
+	batch, err := Parse(newRequest(t, &protoPrometheusV1.WriteRequest{
+		Timeseries: []*protoPrometheusV1.TimeSeries{
+			stale,
+			newTimeSeries("http_requests_total", 10, "host", "web01", "path", "/api"),
+			newTimeSeries("", 10, "host", "web01"),
+			newTimeSeries("cpu_load", math.NaN(), "host", "web01"),
+		},
+	}), enrichedTags, "ns", models.NewDefaultLimits())
+	assert.NoError(t, err)
+	// skip staleness marker, drop missing metric name/NaN value
+	assert.Len(t, batch.Rows(), 2)
+
+	m := batch.Rows()[0].Metric()
+	assert.Equal(t, "cpu_load", string(m.Name()))
+	assert.Equal(t, "ns", string(m.Namespace()))
+	assert.Equal(t, int64(1346846400123), m.Timestamp())
+	assert.Equal(t, 2, m.KeyValuesLength())
+	var sf flatMetricsV1.SimpleField
+	m.SimpleFields(&sf, 0)
+	assert.Equal(t, "value", string(sf.Name()))
+	assert.Equal(t, flatMetricsV1.SimpleFieldTypeLast, sf.Type())
+	assert.Equal(t, 1.0, sf.Value())
+
+	m = batch.Rows()[1].Metric()
+	assert.Equal(t, "http_requests_total", string(m.Name()))
+	assert.Equal(t, 3, m.KeyValuesLength())
+	m.SimpleFields(&sf, 0)
+	assert.Equal(t, flatMetricsV1.SimpleFieldTypeDeltaSum, sf.Type())
+	assert.Equal(t, 10.0, sf.Value())
+}
+
+func TestParse_Limits(t *testing.T) {
+	limits := models.NewDefaultLimits()
+	limits.MaxMetricNameLength = 5
+	limits.MaxTagNameLength = 5
+	limits.MaxTagValueLength = 5
+	batch, err := Parse(newRequest(t, &protoPrometheusV1.WriteRequest{
+		Timeseries: []*protoPrometheusV1.TimeSeries{
+			newTimeSeries("cpu_load", 1, "host", "web01"),
+			newTimeSeries("cpu", 1, "host_name", "web01"),
+			newTimeSeries("cpu", 1, "host", "web01_long"),
+			newTimeSeries("cpu", 1, "host", "web01"),
+		},
+	}), nil, "ns", limits)
+	assert.NoError(t, err)
+	assert.Len(t, batch.Rows(), 1)
+}
+
+func TestParse_Corrupted(t *testing.T) {
+	// not snappy block
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "", strings.NewReader("bad data"))
+	assert.NoError(t, err)
+	_, err = Parse(req, nil, "ns", models.NewDefaultLimits())
+	assert.Error(t, err)
+	// not protobuf
+	req, err = http.NewRequestWithContext(context.TODO(), http.MethodPost, "",
+		bytes.NewReader(snappy.Encode(nil, []byte("bad data"))))
+	assert.NoError(t, err)
+	_, err = Parse(req, nil, "ns", models.NewDefaultLimits())
+	assert.Error(t, err)
+}
+
+func TestGetFieldType(t *testing.T) {
+	metricTypes := map[string]protoPrometheusV1.MetricType{
+		"requests":      protoPrometheusV1.MetricType_COUNTER,
+		"errors_total":  protoPrometheusV1.MetricType_COUNTER,
+		"latency":       protoPrometheusV1.MetricType_HISTOGRAM,
+		"rpc":           protoPrometheusV1.MetricType_SUMMARY,
+		"memory":        protoPrometheusV1.MetricType_GAUGE,
+		"pool":          protoPrometheusV1.MetricType_GAUGEHISTOGRAM,
+		"process_total": protoPrometheusV1.MetricType_GAUGE,
+	}
+	cases := []struct {
+		name      string
+		fieldType flatMetricsV1.SimpleFieldType
+	}{
+		{name: "requests_total", fieldType: flatMetricsV1.SimpleFieldTypeDeltaSum},
+		{name: "errors_total", fieldType: flatMetricsV1.SimpleFieldTypeDeltaSum},
+		{name: "latency_bucket", fieldType: flatMetricsV1.SimpleFieldTypeDeltaSum},
+		{name: "latency_sum", fieldType: flatMetricsV1.SimpleFieldTypeDeltaSum},
+		{name: "rpc_count", fieldType: flatMetricsV1.SimpleFieldTypeDeltaSum},
+		{name: "rpc", fieldType: flatMetricsV1.SimpleFieldTypeLast},
+		{name: "memory", fieldType: flatMetricsV1.SimpleFieldTypeLast},
+		{name: "pool_bucket", fieldType: flatMetricsV1.SimpleFieldTypeLast},
+		{name: "process_total", fieldType: flatMetricsV1.SimpleFieldTypeLast},
+		{name: "unknown_total", fieldType: flatMetricsV1.SimpleFieldTypeDeltaSum},
+		{name: "unknown", fieldType: flatMetricsV1.SimpleFieldTypeLast},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.fieldType, getFieldType(tt.name, metricTypes))
+		})
+	}
+}
@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 
 	protoMetricsV1 "github.com/lindb/common/proto/gen/v1/linmetrics"
 
@@ -38,16 +37,13 @@ var (
 )
 
 func Parse(req *http.Request, enrichedTags tag.Tags, namespace string, limits *models.Limits) (*metric.BrokerBatchRows, error) {
-	var reader = req.Body
-	if strings.EqualFold(req.Header.Get("Content-Encoding"), "gzip") {
-		gzipReader, err := ingestCommon.GetGzipReader(req.Body)
-		if err != nil {
-			protoIngestionStatistics.CorruptedData.Incr()
-			return nil, fmt.Errorf("ingestion corrupted gzip data: %w", err)
-		}
-		defer ingestCommon.PutGzipReader(gzipReader)
-		reader = gzipReader
+	encoding := req.Header.Get("Content-Encoding")
+	reader, releaseReader, err := ingestCommon.GetReader(encoding, req.Body)
+	if err != nil {
+		protoIngestionStatistics.CorruptedData.Incr()
+		return nil, fmt.Errorf("ingestion corrupted %s data: %w", encoding, err)
 	}
+	defer releaseReader()
 
 	data, err := io.ReadAll(reader)
 	if err != nil {
@@ -61,6 +57,7 @@ func Parse(req *http.Request, enrichedTags tag.Tags, namespace string, limits *m
 		return nil, err
 	}
 	if batch.Len() == 0 {
+		metric.ReleaseBrokerBatchRows(batch)
 		return nil, fmt.Errorf("empty metrics")
 	}
 	protoIngestionStatistics.IngestedMetrics.Add(float64(batch.Len()))
@@ -75,7 +72,7 @@ func parseProtoMetric(
 ) (
 	batch *metric.BrokerBatchRows, err error,
 ) {
-	batch = metric.NewBrokerBatchRows()
+	batch = metric.AcquireBrokerBatchRows()
 
 	converter, releaseFunc := metric.NewBrokerRowProtoConverter(strutil.String2ByteSlice(namespace), enrichedTags, limits)
 	defer releaseFunc(converter)
@@ -18,23 +18,29 @@
 package api
 
 import (
+	"net/http"
+	"regexp"
+
 	"github.com/gin-gonic/gin"
 
 	httppkg "github.com/lindb/common/pkg/http"
 	"github.com/lindb/common/pkg/logger"
 
 	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/series/tag"
 )
 
 var (
-	ExploreCurrentPath = "/state/explore/current"
+	ExploreCurrentPath     = "/state/explore/current"
+	ExploreOpenMetricsPath = "/state/explore/openmetrics"
 )
 
 // ExploreAPI represents monitoring metric explore rest api.
 type ExploreAPI struct {
 	globalKeyValues tag.Tags
 	r               *linmetric.Registry
+	exemplars       bool
 	logger          logger.Logger
 }
 
@@ -47,15 +53,24 @@ func NewExploreAPI(globalKeyValues tag.Tags, r *linmetric.Registry) *ExploreAPI
 	}
 }
 
+// WithExemplars sets if exports exemplars in OpenMetrics format.
+func (d *ExploreAPI) WithExemplars(exemplars bool) *ExploreAPI {
+	d.exemplars = exemplars
+	return d
+}
+
 // Register adds explore url route.
 func (d *ExploreAPI) Register(route gin.IRoutes) {
 	route.GET(ExploreCurrentPath, d.ExploreCurrent)
+	route.GET(ExploreOpenMetricsPath, d.ExploreOpenMetrics)
 }
 
 // ExploreCurrent explores current node monitoring metric.
+// If regex is set, each name is a pattern which must match the whole metric name.
 func (d *ExploreAPI) ExploreCurrent(c *gin.Context) {
 	var param struct {
 		Names []string `form:"names" binding:"required"`
+		Regex bool     `form:"regex"`
 	}
 	err := c.ShouldBindQuery(&param)
 	if err != nil {
@@ -64,8 +79,24 @@ func (d *ExploreAPI) ExploreCurrent(c *gin.Context) {
 	}
 	tags := c.QueryMap("tags")
 
-	// find metric by name from default metric registry
-	rs := d.r.FindMetricList(param.Names, tags)
+	var rs map[string][]*models.StateMetric
+	if param.Regex {
+		// find metric by name pattern from default metric registry
+		patterns := make([]*regexp.Regexp, 0, len(param.Names))
+		for _, name := range param.Names {
+			pattern, err0 := regexp.Compile("^(?:" + name + ")$")
+			if err0 != nil {
+				_ = c.Error(err0)
+				c.JSON(http.StatusBadRequest, err0.Error())
+				return
+			}
+			patterns = append(patterns, pattern)
+		}
+		rs = d.r.FindMetricListByRegex(patterns, tags)
+	} else {
+		// find metric by name from default metric registry
+		rs = d.r.FindMetricList(param.Names, tags)
+	}
 	globalKeyValues := d.globalKeyValues
 	for _, metricList := range rs {
 		for _, metric := range metricList {
@@ -77,3 +108,12 @@ func (d *ExploreAPI) ExploreCurrent(c *gin.Context) {
 	}
 	httppkg.OK(c, rs)
 }
+
+// ExploreOpenMetrics exports current node monitoring metric in OpenMetrics format.
+func (d *ExploreAPI) ExploreOpenMetrics(c *gin.Context) {
+	c.Header("Content-Type", linmetric.OpenMetricsContentType)
+	c.Status(http.StatusOK)
+	if err := d.r.WriteOpenMetrics(c.Writer, d.globalKeyValues, d.exemplars); err != nil {
+		d.logger.Error("write metrics in OpenMetrics format failure", logger.Error(err))
+	}
+}
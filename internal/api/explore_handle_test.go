@@ -25,9 +25,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/common/pkg/encoding"
+
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/series/tag"
 )
 
@@ -50,3 +53,48 @@ func TestExploreAPI_ExploreCurrent(t *testing.T) {
 	resp = mock.DoRequest(t, r, http.MethodGet, ExploreCurrentPath+"?names=lindb.ut&tags[a]=b", "")
 	assert.Equal(t, http.StatusOK, resp.Code)
 }
+
+func TestExploreAPI_ExploreCurrent_Regex(t *testing.T) {
+	api := NewExploreAPI(tag.Tags{
+		{Key: []byte("role"), Value: []byte(constants.BrokerRole)},
+	}, linmetric.BrokerRegistry)
+	r := gin.New()
+	api.Register(r)
+	linmetric.BrokerRegistry.NewScope("lindb.ut.regex.query").NewGauge("path").Update(1)
+	linmetric.BrokerRegistry.NewScope("lindb.ut.regex.query_stats").NewGauge("path").Update(1)
+	linmetric.BrokerRegistry.NewScope("lindb.ut.regex.write").NewGauge("path").Update(1)
+
+	// invalid pattern
+	resp := mock.DoRequest(t, r, http.MethodGet, ExploreCurrentPath+"?names=lindb.ut.regex.(&regex=true", "")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	// pattern matches multiple metrics
+	resp = mock.DoRequest(t, r, http.MethodGet, ExploreCurrentPath+"?names=lindb\\.ut\\.regex\\.query.*&regex=true", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	rs := make(map[string][]*models.StateMetric)
+	assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &rs))
+	assert.Len(t, rs, 2)
+	assert.Contains(t, rs, "lindb.ut.regex.query")
+	assert.Contains(t, rs, "lindb.ut.regex.query_stats")
+	assert.Equal(t, constants.BrokerRole, rs["lindb.ut.regex.query"][0].Tags["role"])
+	// pattern matches whole name
+	resp = mock.DoRequest(t, r, http.MethodGet, ExploreCurrentPath+"?names=regex.write&regex=true", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "{}", resp.Body.String())
+	// exact match by default
+	resp = mock.DoRequest(t, r, http.MethodGet, ExploreCurrentPath+"?names=lindb.ut.regex.query.*", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "{}", resp.Body.String())
+}
+
+func TestExploreAPI_ExploreOpenMetrics(t *testing.T) {
+	api := NewExploreAPI(tag.Tags{
+		{Key: []byte("role"), Value: []byte(constants.StorageRole)},
+	}, linmetric.StorageRegistry).WithExemplars(true)
+	r := gin.New()
+	api.Register(r)
+	linmetric.StorageRegistry.NewScope("lindb.ut.openmetrics").NewGauge("path").Update(1)
+	resp := mock.DoRequest(t, r, http.MethodGet, ExploreOpenMetricsPath, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, linmetric.OpenMetricsContentType, resp.Header().Get("Content-Type"))
+	assert.Contains(t, resp.Body.String(), `lindb_ut_openmetrics_path{role="Storage"} 1`)
+}
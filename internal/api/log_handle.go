@@ -19,18 +19,22 @@ package api
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap/zapcore"
 
 	httppkg "github.com/lindb/common/pkg/http"
 	"github.com/lindb/common/pkg/logger"
 
 	"github.com/lindb/lindb/constants"
+	lindbhttp "github.com/lindb/lindb/pkg/http"
 )
 
 // for testing
@@ -39,6 +43,9 @@ var (
 	relFn     = filepath.Rel
 	absFn     = filepath.Abs
 	openFn    = os.Open
+
+	// followInterval is the interval of polling new lines appended to log file in follow mode.
+	followInterval = time.Second
 )
 
 // FileInfo represents file info include name/size.
@@ -52,17 +59,25 @@ var (
 	LogViewPath = "/log/view"
 )
 
+// defaultMaxLogViewSize is the default max size of log data which can be read by view api once.
+const defaultMaxLogViewSize int64 = 50 * 1024 * 1024
+
 // LoggerAPI represents view log file rest api.
 type LoggerAPI struct {
-	logDir string
-	logger logger.Logger
+	logDir      string
+	maxViewSize int64
+	logger      logger.Logger
 }
 
-// NewLoggerAPI creates log view api instance.
-func NewLoggerAPI(logDir string) *LoggerAPI {
+// NewLoggerAPI creates log view api instance, maxViewSize is the max size of log data which can be read once.
+func NewLoggerAPI(logDir string, maxViewSize int64) *LoggerAPI {
+	if maxViewSize <= 0 {
+		maxViewSize = defaultMaxLogViewSize
+	}
 	return &LoggerAPI{
-		logDir: logDir,
-		logger: logger.GetLogger("Monitoring", "ExploreAPI"),
+		logDir:      logDir,
+		maxViewSize: maxViewSize,
+		logger:      logger.GetLogger("Monitoring", "ExploreAPI"),
 	}
 }
 
@@ -108,12 +123,15 @@ func (d *LoggerAPI) List(c *gin.Context) {
 }
 
 // View tails the log file, return the last n lines.
+// If follow is true, keeps the stream open and emits new lines appended to log file(tail -f).
+// If level is given, only emits the lines whose level matches or exceeds it.
 // @Summary tail log file
-// @Description return last N lines in log file.
+// @Description return last N lines in log file, keep streaming new lines if follow is true.
 // @Tags State
 // @Accept json
 // @Produce plain
 // @Success 200 {string} string
+// @Failure 400 {string} string "bad request"
 // @Failure 404 {string} string "not found"
 // @Failure 500 {string} string "internal error"
 // @Router /log/view [get]
@@ -122,12 +140,29 @@ func (d *LoggerAPI) View(c *gin.Context) {
 		FileName string `form:"file" binding:"required"`
 		// default: read last 1MB data from log file
 		Size int64 `form:"size,default=1048576"`
+		// keep streaming new lines appended to log file
+		Follow bool `form:"follow"`
+		// min level of emitted lines, like: warn/error
+		Level string `form:"level"`
 	}
 	err := c.ShouldBindQuery(&param)
 	if err != nil {
 		httppkg.Error(c, err)
 		return
 	}
+	if param.Size <= 0 || param.Size > d.maxViewSize {
+		lindbhttp.BadRequest(c, fmt.Errorf("size must be in range (0, %d], got: %d", d.maxViewSize, param.Size))
+		return
+	}
+	if !isLocalPath(param.FileName) {
+		lindbhttp.BadRequest(c, fmt.Errorf("log file must be in log dir, got: %s", param.FileName))
+		return
+	}
+	filter, err := newLevelFilter(param.Level)
+	if err != nil {
+		lindbhttp.BadRequest(c, err)
+		return
+	}
 	// prepend slash for cleaning relative paths
 	requestedFile := filepath.Clean(filepath.Join(string(os.PathSeparator), param.FileName))
 	rel, err := relFn(string(os.PathSeparator), requestedFile)
@@ -145,6 +180,10 @@ func (d *LoggerAPI) View(c *gin.Context) {
 	}
 
 	logFilePath := filepath.Join(absLogDir, rel)
+	if !isSubPath(absLogDir, logFilePath) {
+		lindbhttp.BadRequest(c, fmt.Errorf("log file must be in log dir, got: %s", param.FileName))
+		return
+	}
 	file, err := openFn(logFilePath)
 	if err != nil {
 		httppkg.Error(c, fmt.Errorf("failed to open log file: %s", param.FileName))
@@ -172,10 +211,17 @@ func (d *LoggerAPI) View(c *gin.Context) {
 			return
 		}
 	}
+	if param.Follow {
+		d.follow(c, file, param.FileName, filter)
+		return
+	}
 	scanner := bufio.NewScanner(file)
 	scanner.Scan() // skip first line
 	c.Stream(func(w io.Writer) bool {
 		for scanner.Scan() {
+			if !filter.match(scanner.Bytes()) {
+				continue
+			}
 			if err := writeLine(w, [][]byte{scanner.Bytes(), constants.LBBytes}); err != nil {
 				d.logger.Warn("write log data to response stream err",
 					logger.String("file", param.FileName),
@@ -187,6 +233,125 @@ func (d *LoggerAPI) View(c *gin.Context) {
 	})
 }
 
+// follow streams the lines of log file, polls new lines appended to log file until client disconnected.
+func (d *LoggerAPI) follow(c *gin.Context, file io.Reader, fileName string, filter *levelFilter) {
+	ctx := c.Request.Context()
+	reader := bufio.NewReader(file)
+	_, _ = reader.ReadBytes('\n') // skip first line
+	var line []byte
+	c.Stream(func(w io.Writer) bool {
+		for {
+			data, err := reader.ReadBytes('\n')
+			// keep partial line until line break appended
+			line = append(line, data...)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				d.logger.Warn("read log file err",
+					logger.String("file", fileName),
+					logger.Error(err))
+				return false
+			}
+			if !filter.match(line) {
+				line = line[:0]
+				continue
+			}
+			if err := writeLine(w, [][]byte{line}); err != nil {
+				d.logger.Warn("write log data to response stream err",
+					logger.String("file", fileName),
+					logger.Error(err))
+				return false
+			}
+			c.Writer.Flush()
+			line = line[:0]
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(followInterval):
+			return true
+		}
+	})
+}
+
+// levelFilter filters the log lines by level,
+// line without level(like stack trace) follows the level of previous line.
+type levelFilter struct {
+	level   zapcore.Level
+	matched bool
+}
+
+// newLevelFilter creates a log level filter, returns nil filter(match all) if level is empty.
+func newLevelFilter(level string) (*levelFilter, error) {
+	if level == "" {
+		return nil, nil
+	}
+	f := &levelFilter{}
+	if err := f.level.UnmarshalText([]byte(level)); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// match checks if the line matches or exceeds the level of filter.
+func (f *levelFilter) match(line []byte) bool {
+	if f == nil {
+		return true
+	}
+	if level, ok := parseLogLevel(line); ok {
+		f.matched = level >= f.level
+	}
+	return f.matched
+}
+
+var levelKey = []byte(`"level":"`)
+
+// parseLogLevel parses the level token of log line,
+// supports console format(time\tlevel\tcaller\tmessage) and json format({"level":"info",...}).
+func parseLogLevel(line []byte) (level zapcore.Level, ok bool) {
+	var token []byte
+	if bytes.HasPrefix(line, []byte("{")) {
+		if idx := bytes.Index(line, levelKey); idx >= 0 {
+			token = line[idx+len(levelKey):]
+			if end := bytes.IndexByte(token, '"'); end >= 0 {
+				token = token[:end]
+			}
+		}
+	} else if fields := bytes.SplitN(line, []byte("\t"), 3); len(fields) == 3 {
+		token = fields[1]
+	}
+	if len(token) == 0 {
+		return level, false
+	}
+	return level, level.UnmarshalText(token) == nil
+}
+
+// isLocalPath checks if the file name is a relative path without parent(..) element,
+// which cannot escape the base dir after joined.
+func isLocalPath(name string) bool {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") {
+		return false
+	}
+	for _, elem := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '/' || r == os.PathSeparator
+	}) {
+		if elem == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubPath checks if the target path is located in the base dir.
+func isSubPath(base, target string) bool {
+	rel, err := filepath.Rel(base, target)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator))
+}
+
 // writeLine writes a line into stream.
 func writeLine(w io.Writer, data [][]byte) error {
 	for _, d := range data {
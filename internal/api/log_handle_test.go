@@ -18,12 +18,15 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -63,7 +66,7 @@ func TestLoggerAPI_List(t *testing.T) {
 		_ = fileutil.RemoveFile(logFile)
 	}()
 
-	api := NewLoggerAPI(path)
+	api := NewLoggerAPI(path, 0)
 	r := gin.New()
 	api.Register(r)
 	resp := mock.DoRequest(t, r, http.MethodGet, LogListPath, "")
@@ -97,7 +100,7 @@ func TestLoggerAPI_View(t *testing.T) {
 		_ = fileutil.RemoveFile(logFile)
 	}()
 
-	api := NewLoggerAPI(path)
+	api := NewLoggerAPI(path, 0)
 	r := gin.New()
 	api.Register(r)
 
@@ -125,7 +128,130 @@ func TestLoggerAPI_View(t *testing.T) {
 	// ok
 	resp = mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file=log_handle.go", "")
 	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file=log_handle.go&level=warn", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	// invalid level
+	resp = mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file=log_handle.go&level=abc", "")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
 	// cannot open file out of log dir
 	resp = mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file=../client/base.go", "")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestLoggerAPI_View_PathTraversal(t *testing.T) {
+	api := NewLoggerAPI(".", 0)
+	r := gin.New()
+	api.Register(r)
+
+	for _, file := range []string{
+		"../../etc/passwd",
+		"..%2F..%2Fetc%2Fpasswd",
+		"/etc/passwd",
+		"a/../../etc/passwd",
+		"..",
+	} {
+		resp := mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file="+file, "")
+		assert.Equal(t, http.StatusBadRequest, resp.Code, file)
+	}
+	// path with dot(..) prefix in file name is valid
+	resp := mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file=..log_handle.go", "")
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 }
+
+func TestLoggerAPI_View_Size(t *testing.T) {
+	api := NewLoggerAPI(".", 0)
+	r := gin.New()
+	api.Register(r)
+
+	for _, size := range []string{"0", "-1", "52428801", "9999999999999"} {
+		resp := mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file=log_handle.go&size="+size, "")
+		assert.Equal(t, http.StatusBadRequest, resp.Code, size)
+	}
+	resp := mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file=log_handle.go&size=52428800", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// configured max size
+	api = NewLoggerAPI(".", 1024)
+	r = gin.New()
+	api.Register(r)
+	resp = mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file=log_handle.go&size=1025", "")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = mock.DoRequest(t, r, http.MethodGet, LogViewPath+"?file=log_handle.go&size=1024", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestLoggerAPI_isSubPath(t *testing.T) {
+	assert.True(t, isSubPath("/data/log", "/data/log/a.log"))
+	assert.True(t, isSubPath("/data/log", "/data/log/a/..b.log"))
+	assert.False(t, isSubPath("/data/log", "/data/a.log"))
+	assert.False(t, isSubPath("/data/log", "/data/log2/a.log"))
+	assert.False(t, isSubPath("/data/log", "/data"))
+	assert.False(t, isSubPath("/data/log", "data/log/a.log"))
+}
+
+type closeNotifyingRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (c *closeNotifyingRecorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+func TestLoggerAPI_View_Follow(t *testing.T) {
+	defer func() {
+		followInterval = time.Second
+	}()
+	followInterval = 10 * time.Millisecond
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "follow.log")
+	assert.NoError(t, os.WriteFile(logFile, []byte("partial\nline1\n"), 0644))
+
+	api := NewLoggerAPI(dir, 0)
+	r := gin.New()
+	api.Register(r)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, LogViewPath+"?file=follow.log&follow=true", nil)
+	resp := &closeNotifyingRecorder{httptest.NewRecorder()}
+	go func() {
+		f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+		assert.NoError(t, err)
+		time.Sleep(50 * time.Millisecond)
+		_, _ = f.WriteString("line2 ")
+		time.Sleep(50 * time.Millisecond)
+		_, _ = f.WriteString("appended\n")
+		_ = f.Close()
+		time.Sleep(50 * time.Millisecond)
+		// client disconnected
+		cancel()
+	}()
+	r.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "line1\nline2 appended\n", resp.Body.String())
+}
+
+func TestLevelFilter(t *testing.T) {
+	f, err := newLevelFilter("")
+	assert.NoError(t, err)
+	assert.True(t, f.match([]byte("abc")))
+	_, err = newLevelFilter("abc")
+	assert.Error(t, err)
+
+	f, err = newLevelFilter("warn")
+	assert.NoError(t, err)
+	for _, tt := range []struct {
+		line  string
+		match bool
+	}{
+		{line: "2022-10-16 11:11:56.471\tINFO\tquery/search.go:10\tquery", match: false},
+		{line: "2022-10-16 11:11:56.471\tERROR\tquery/search.go:10\tpanic", match: true},
+		{line: "\t/usr/local/go/src/runtime/panic.go:859", match: true}, // stack trace follows previous line
+		{line: "2022-10-16 11:11:56.471\twarn\tquery/search.go:10\tslow", match: true},
+		{line: `{"level":"debug","msg":"abc"}`, match: false},
+		{line: "no level", match: false},
+		{line: `{"level":"error","msg":"abc"}`, match: true},
+		{line: `{"msg":"abc"}`, match: true},
+	} {
+		assert.Equal(t, tt.match, f.match([]byte(tt.line)), tt.line)
+	}
+}
@@ -0,0 +1,103 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	httppkg "github.com/lindb/common/pkg/http"
+
+	"github.com/lindb/lindb/query"
+)
+
+var (
+	SlowQueryPath          = "/query/slow"
+	SlowQueryThresholdPath = "/query/slow/threshold"
+)
+
+// SlowQueryAPI represents slow query of current node related api.
+type SlowQueryAPI struct {
+}
+
+// NewSlowQueryAPI creates a SlowQueryAPI instance.
+func NewSlowQueryAPI() *SlowQueryAPI {
+	return &SlowQueryAPI{}
+}
+
+// Register adds slow query url route.
+func (api *SlowQueryAPI) Register(route gin.IRoutes) {
+	route.GET(SlowQueryPath, api.GetSlowQueries)
+	route.GET(SlowQueryThresholdPath, api.GetThreshold)
+	route.PUT(SlowQueryThresholdPath, api.SetThreshold)
+}
+
+// GetSlowQueries returns the latest slow queries of current node, sorted by duration desc.
+// @Summary list slow queries
+// @Description return the latest slow queries of current node, sorted by duration desc.
+// @Tags State
+// @Produce json
+// @Success 200 {object} []models.SlowQuery
+// @Router /query/slow [get]
+func (api *SlowQueryAPI) GetSlowQueries(c *gin.Context) {
+	httppkg.OK(c, query.GetSlowQueryRecorder().GetSlowQueries())
+}
+
+// GetThreshold returns the threshold of slow query.
+// @Summary get slow query threshold
+// @Description return the threshold of slow query, like: 30s.
+// @Tags State
+// @Produce json
+// @Success 200 {string} string
+// @Router /query/slow/threshold [get]
+func (api *SlowQueryAPI) GetThreshold(c *gin.Context) {
+	httppkg.OK(c, query.GetSlowQueryRecorder().Threshold().String())
+}
+
+// SetThreshold adjusts the threshold of slow query at runtime.
+// @Summary set slow query threshold
+// @Description adjust the threshold of slow query at runtime, like: threshold=1s.
+// @Tags State
+// @Param threshold query string true "threshold of slow query"
+// @Produce json
+// @Success 200 {string} string
+// @Failure 500 {string} string "internal error"
+// @Router /query/slow/threshold [put]
+func (api *SlowQueryAPI) SetThreshold(c *gin.Context) {
+	var param struct {
+		Threshold string `form:"threshold" binding:"required"`
+	}
+	err := c.ShouldBindQuery(&param)
+	if err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	threshold, err := time.ParseDuration(param.Threshold)
+	if err != nil {
+		httppkg.Error(c, err)
+		return
+	}
+	if threshold <= 0 {
+		httppkg.Error(c, fmt.Errorf("slow query threshold must be positive, but got: %s", param.Threshold))
+		return
+	}
+	query.GetSlowQueryRecorder().SetThreshold(threshold)
+	httppkg.OK(c, threshold.String())
+}
@@ -0,0 +1,64 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/internal/mock"
+	"github.com/lindb/lindb/query"
+)
+
+func TestSlowQueryAPI(t *testing.T) {
+	recorder := query.GetSlowQueryRecorder()
+	threshold := recorder.Threshold()
+	defer recorder.SetThreshold(threshold)
+
+	r := gin.New()
+	api := NewSlowQueryAPI()
+	api.Register(r)
+
+	resp := mock.DoRequest(t, r, http.MethodGet, SlowQueryPath, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = mock.DoRequest(t, r, http.MethodGet, SlowQueryThresholdPath, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	cases := []struct {
+		name      string
+		threshold string
+		code      int
+	}{
+		{name: "threshold required", threshold: "", code: http.StatusInternalServerError},
+		{name: "invalid threshold", threshold: "?threshold=abc", code: http.StatusInternalServerError},
+		{name: "negative threshold", threshold: "?threshold=-1s", code: http.StatusInternalServerError},
+		{name: "set threshold", threshold: "?threshold=1s", code: http.StatusOK},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp := mock.DoRequest(t, r, http.MethodPut, SlowQueryThresholdPath+tt.threshold, "")
+			assert.Equal(t, tt.code, resp.Code)
+		})
+	}
+	assert.Equal(t, time.Second, recorder.Threshold())
+}
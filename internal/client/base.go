@@ -17,9 +17,68 @@
 
 package client
 
-import resty "github.com/go-resty/resty/v2"
+import (
+	"net"
+	"net/http"
+	"time"
+
+	resty "github.com/go-resty/resty/v2"
+)
 
 // Base represents base client.
 type Base struct {
 	cli *resty.Client
 }
+
+const (
+	// acceptEncodingGzip is the value of Accept-Encoding header, which asks node to compress response with gzip,
+	// resty decompresses the gzip response body transparently, node that doesn't compress response works as usual.
+	acceptEncodingGzip = "gzip"
+
+	// maxIdleConnsPerHost is the max idle connections kept for each node by shared client,
+	// fan-out sends one request to each node, keeps a few idle connections for concurrent queries.
+	maxIdleConnsPerHost = 4
+	maxIdleConns        = 256
+	idleConnTimeout     = 90 * time.Second
+	keepAlive           = 30 * time.Second
+	dialTimeout         = 5 * time.Second
+)
+
+// NewSharedClient creates a resty client which is shared by requests fanning out to nodes,
+// the tuned transport keeps alive idle connections for each node, so sequential fan-outs reuse tcp connections.
+// timeout limits each request, a failure request of one node doesn't affect the connections of other nodes.
+func NewSharedClient(timeout time.Duration) *resty.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: keepAlive,
+		}).DialContext,
+		MaxIdleConns:        maxIdleConns,
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+		IdleConnTimeout:     idleConnTimeout,
+	}
+	return resty.New().SetTransport(transport).SetTimeout(timeout)
+}
+
+// NewGzipRequest creates a request which accepts gzip compressed response.
+func NewGzipRequest() *resty.Request {
+	return NewGzipRequestFrom(nil)
+}
+
+// NewGzipRequestFrom creates a request of given client which accepts gzip compressed response,
+// creates a new client if given client is nil.
+func NewGzipRequestFrom(cli *resty.Client) *resty.Request {
+	if cli == nil {
+		cli = resty.New()
+	}
+	return cli.R().SetHeader("Accept-Encoding", acceptEncodingGzip)
+}
+
+// ResponseEncoding returns the content encoding of response, for logging the failure of decompressing response.
+func ResponseEncoding(resp *resty.Response) string {
+	if resp == nil || resp.RawResponse == nil {
+		return ""
+	}
+	return resp.Header().Get("Content-Encoding")
+}
@@ -0,0 +1,39 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSharedClient(t *testing.T) {
+	cli := NewSharedClient(time.Second)
+	assert.Equal(t, time.Second, cli.GetClient().Timeout)
+	transport, ok := cli.GetClient().Transport.(*http.Transport)
+	assert.True(t, ok)
+	assert.Equal(t, maxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+
+	req := NewGzipRequestFrom(cli)
+	assert.Equal(t, acceptEncodingGzip, req.Header.Get("Accept-Encoding"))
+	req = NewGzipRequest()
+	assert.Equal(t, acceptEncodingGzip, req.Header.Get("Accept-Encoding"))
+}
@@ -21,8 +21,6 @@ import (
 	"net/url"
 	"sync"
 
-	resty "github.com/go-resty/resty/v2"
-
 	"github.com/lindb/common/pkg/logger"
 
 	"github.com/lindb/lindb/constants"
@@ -35,6 +33,8 @@ import (
 type MetricCli interface {
 	// FetchMetricData fetches the state metric from each live nodes.
 	FetchMetricData(nodes []models.Node, names []string) (interface{}, error)
+	// FetchMetricDataByRegex fetches the state metric which metric name matches any given patterns from each live nodes.
+	FetchMetricDataByRegex(nodes []models.Node, patterns []string) (interface{}, error)
 }
 
 // metricCli implements MetricCli interface.
@@ -51,15 +51,30 @@ func NewMetricCli() MetricCli {
 
 // FetchMetricData fetches the state metric from each live nodes.
 func (cli *metricCli) FetchMetricData(nodes []models.Node, names []string) (interface{}, error) {
+	params := make(url.Values)
+	for _, name := range names {
+		params.Add("names", name)
+	}
+	return cli.fetchMetricData(nodes, params)
+}
+
+// FetchMetricDataByRegex fetches the state metric which metric name matches any given patterns from each live nodes.
+func (cli *metricCli) FetchMetricDataByRegex(nodes []models.Node, patterns []string) (interface{}, error) {
+	params := make(url.Values)
+	for _, pattern := range patterns {
+		params.Add("names", pattern)
+	}
+	params.Set("regex", "true")
+	return cli.fetchMetricData(nodes, params)
+}
+
+// fetchMetricData fetches the state metric from each live nodes with given query params.
+func (cli *metricCli) fetchMetricData(nodes []models.Node, params url.Values) (interface{}, error) {
 	size := len(nodes)
 	if size == 0 {
 		return nil, nil
 	}
 	result := make([]map[string][]*models.StateMetric, size)
-	params := make(url.Values)
-	for _, name := range names {
-		params.Add("names", name)
-	}
 
 	var wait sync.WaitGroup
 	wait.Add(size)
@@ -70,12 +85,13 @@ func (cli *metricCli) FetchMetricData(nodes []models.Node, names []string) (inte
 			node := nodes[i]
 			address := node.HTTPAddress()
 			metric := make(map[string][]*models.StateMetric)
-			_, err := resty.New().R().SetQueryParamsFromValues(params).
+			resp, err := NewGzipRequest().SetQueryParamsFromValues(params).
 				SetHeader("Accept", "application/json").
 				SetResult(&metric).
 				Get(address + constants.APIVersion1CliPath + "/state/explore/current")
 			if err != nil {
-				cli.logger.Error("get current metric state from alive node", logger.String("url", address), logger.Error(err))
+				cli.logger.Error("get current metric state from alive node", logger.String("url", address),
+					logger.String("encoding", ResponseEncoding(resp)), logger.Error(err))
 				return
 			}
 			result[i] = metric
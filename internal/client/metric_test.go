@@ -18,6 +18,8 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -99,3 +101,71 @@ func TestMetricCli_FetchMetricData(t *testing.T) {
 		})
 	}
 }
+
+func TestMetricCli_FetchMetricData_Gzip(t *testing.T) {
+	newNode := func(handler http.HandlerFunc) models.Node {
+		svr := httptest.NewServer(handler)
+		t.Cleanup(svr.Close)
+		u, err := url.Parse(svr.URL)
+		assert.NoError(t, err)
+		p, err := strconv.Atoi(u.Port())
+		assert.NoError(t, err)
+		return &models.StatelessNode{HostIP: u.Hostname(), HTTPPort: uint16(p)}
+	}
+	data := []byte(`{"cpu":[{"fields":[{"value":1}]}]}`)
+	nodes := []models.Node{
+		// gzip compressed response
+		newNode(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+			var buf bytes.Buffer
+			gw := gzip.NewWriter(&buf)
+			_, _ = gw.Write(data)
+			_ = gw.Close()
+			w.Header().Add("content-type", "application/json")
+			w.Header().Add("Content-Encoding", "gzip")
+			_, _ = w.Write(buf.Bytes())
+		}),
+		// node doesn't support gzip
+		newNode(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Add("content-type", "application/json")
+			_, _ = w.Write(data)
+		}),
+		// corrupted gzip response, just skip this node
+		newNode(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Add("content-type", "application/json")
+			w.Header().Add("Content-Encoding", "gzip")
+			_, _ = w.Write(data)
+		}),
+	}
+	cli := NewMetricCli()
+	rs, err := cli.FetchMetricData(nodes, []string{"cpu"})
+	assert.NoError(t, err)
+	assert.Len(t, rs.(map[string][]*models.StateMetric)["cpu"], 2)
+}
+
+func TestMetricCli_FetchMetricDataByRegex(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("regex"))
+		assert.Equal(t, []string{"lindb.query.*", "cpu"}, r.URL.Query()["names"])
+		w.Header().Add("content-type", "application/json")
+		_, _ = w.Write([]byte(`{"lindb.query":[{"fields":[{"value":1}]}],"lindb.query_stats":[{"fields":[{"value":1}]}]}`))
+	}))
+	defer svr.Close()
+	u, err := url.Parse(svr.URL)
+	assert.NoError(t, err)
+	p, err := strconv.Atoi(u.Port())
+	assert.NoError(t, err)
+	nodes := []models.Node{&models.StatelessNode{HostIP: u.Hostname(), HTTPPort: uint16(p)}}
+
+	cli := NewMetricCli()
+	rs, err := cli.FetchMetricDataByRegex(nodes, []string{"lindb.query.*", "cpu"})
+	assert.NoError(t, err)
+	assert.Len(t, rs.(map[string][]*models.StateMetric), 2)
+	rs, err = cli.FetchMetricDataByRegex(nil, []string{"cpu"})
+	assert.NoError(t, err)
+	assert.Nil(t, rs)
+}
+
+func TestResponseEncoding(t *testing.T) {
+	assert.Empty(t, ResponseEncoding(nil))
+}
@@ -0,0 +1,99 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package concurrent
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/lindb/lindb/metrics"
+)
+
+// ErrKeyedLimiterTimeout represents request waits timeout for the concurrency of key.
+var ErrKeyedLimiterTimeout = errors.New("reaches the max concurrency of key")
+
+// KeyedLimiter limits the concurrency of each key(database etc.) independently,
+// so that heavy requests of one key cannot starve requests of other keys.
+type KeyedLimiter struct {
+	maxConcurrency int
+	newStatistics  func(key string) *metrics.KeyedLimitStatistics
+
+	limiters map[string]*keyLimiter
+	mutex    sync.Mutex
+}
+
+// keyLimiter represents the limiter of a key.
+type keyLimiter struct {
+	tokens     chan struct{}
+	statistics *metrics.KeyedLimitStatistics
+}
+
+// NewKeyedLimiter creates a limiter which limits the concurrency per key,
+// if max concurrency <= 0, no limit.
+func NewKeyedLimiter(maxConcurrency int, newStatistics func(key string) *metrics.KeyedLimitStatistics) *KeyedLimiter {
+	return &KeyedLimiter{
+		maxConcurrency: maxConcurrency,
+		newStatistics:  newStatistics,
+		limiters:       make(map[string]*keyLimiter),
+	}
+}
+
+// Do executes f after acquiring the token of key, waits until ctx done if tokens of key are taken.
+func (l *KeyedLimiter) Do(ctx context.Context, key string, f func() error) error {
+	if l.maxConcurrency <= 0 {
+		return f()
+	}
+	limiter := l.getOrCreateLimiter(key)
+	select {
+	case limiter.tokens <- struct{}{}:
+	default:
+		// tokens are taken, so waits one to be free
+		limiter.statistics.Queued.Incr()
+		select {
+		case limiter.tokens <- struct{}{}:
+			limiter.statistics.Queued.Decr()
+		case <-ctx.Done():
+			limiter.statistics.Queued.Decr()
+			limiter.statistics.Timeouts.Incr()
+			return ErrKeyedLimiterTimeout
+		}
+	}
+	limiter.statistics.Running.Incr()
+	defer func() {
+		limiter.statistics.Running.Decr()
+		<-limiter.tokens
+	}()
+	return f()
+}
+
+// getOrCreateLimiter returns the limiter of key, creates it if not exist.
+func (l *KeyedLimiter) getOrCreateLimiter(key string) *keyLimiter {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	limiter, ok := l.limiters[key]
+	if !ok {
+		limiter = &keyLimiter{
+			tokens:     make(chan struct{}, l.maxConcurrency),
+			statistics: l.newStatistics(key),
+		}
+		l.limiters[key] = limiter
+	}
+	return limiter
+}
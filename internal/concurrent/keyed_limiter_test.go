@@ -0,0 +1,94 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package concurrent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/metrics"
+)
+
+func newTestKeyedLimiter(maxConcurrency int) *KeyedLimiter {
+	return NewKeyedLimiter(maxConcurrency, func(key string) *metrics.KeyedLimitStatistics {
+		return metrics.NewDatabaseQueryLimitStatistics(key, linmetric.BrokerRegistry)
+	})
+}
+
+func TestKeyedLimiter_Isolation(t *testing.T) {
+	limiter := newTestKeyedLimiter(1)
+	running := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	// saturate database a
+	go func() {
+		done <- limiter.Do(context.TODO(), "a", func() error {
+			close(running)
+			<-release
+			return nil
+		})
+	}()
+	<-running
+
+	// database b still proceeds
+	executed := false
+	assert.NoError(t, limiter.Do(context.TODO(), "b", func() error {
+		executed = true
+		return nil
+	}))
+	assert.True(t, executed)
+
+	// database a waits until context deadline
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	err := limiter.Do(ctx, "a", func() error {
+		t.Fatal("should not execute")
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrKeyedLimiterTimeout)
+	a := limiter.getOrCreateLimiter("a")
+	assert.Equal(t, float64(1), a.statistics.Running.Get())
+	assert.Equal(t, float64(0), a.statistics.Queued.Get())
+
+	// queued request executes after token released
+	queued := make(chan error)
+	go func() {
+		queued <- limiter.Do(context.TODO(), "a", func() error {
+			return nil
+		})
+	}()
+	close(release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-queued)
+	assert.Equal(t, float64(0), a.statistics.Running.Get())
+}
+
+func TestKeyedLimiter_NoLimit(t *testing.T) {
+	limiter := newTestKeyedLimiter(0)
+	executed := false
+	assert.NoError(t, limiter.Do(context.TODO(), "a", func() error {
+		executed = true
+		return nil
+	}))
+	assert.True(t, executed)
+	assert.Empty(t, limiter.limiters)
+}
@@ -41,14 +41,29 @@ const (
 	sleepInterval = time.Millisecond * 5
 )
 
+// Priority represents the priority of task.
+type Priority int
+
+const (
+	// PriorityLow is the default priority of task, e.g. background compaction task.
+	PriorityLow Priority = iota
+	// PriorityHigh is the priority of interactive task, e.g. query task.
+	PriorityHigh
+)
+
 // Task represents a task function to be executed by a worker(goroutine).
 type Task struct {
 	// handle executes task function.
 	handle func()
-	// panicHandle executes callback if task happens panic.
+	// panicHandle executes callback if task happens panic or is skipped because its context is done.
 	panicHandle func(err error)
+	// priority of task, set when submitting.
+	priority Priority
+	// ctx of task, set when submitting.
+	ctx context.Context
 
 	createTime time.Time
+	submitTime time.Time // time with monotonic clock reading, set when submitting
 }
 
 // NewTask creates a task.
@@ -75,6 +90,13 @@ type Pool interface {
 	// After the maximum number of workers are running, and no workers are ready,
 	// execute function will be blocked.
 	Submit(ctx context.Context, task *Task)
+	// SubmitWithPriority enqueues a callable task with priority for a worker to execute,
+	// the dispatcher always drains high priority tasks before low priority tasks.
+	// Submit is same as SubmitWithPriority with low priority.
+	SubmitWithPriority(ctx context.Context, task *Task, priority Priority)
+	// Resize changes the range of workers,
+	// the dispatcher spawns workers up to max under load, and retires idle workers down to min.
+	Resize(minWorkers, maxWorkers int)
 	// Stopped returns true if this pool has been stopped.
 	Stopped() bool
 	// Stop stops all goroutines gracefully,
@@ -85,8 +107,10 @@ type Pool interface {
 // workerPool is a pool for goroutines.
 type workerPool struct {
 	name                string
-	maxWorkers          int
-	tasks               chan *Task    // tasks channel
+	minWorkers          atomic.Int32
+	maxWorkers          atomic.Int32
+	tasks               chan *Task    // low priority tasks channel
+	highTasks           chan *Task    // high priority tasks channel
 	readyWorkers        chan *worker  // available worker
 	idleTimeout         time.Duration // idle goroutine recycle time
 	onDispatcherStopped chan struct{} // signal that dispatcher is stopped
@@ -102,17 +126,14 @@ type workerPool struct {
 // NewPool returns a new worker pool,
 // maxWorkers parameter specifies the maximum number workers that will execute tasks concurrently.
 func NewPool(name string, maxWorkers int, idleTimeout time.Duration, statistics *metrics.ConcurrentStatistics) Pool {
-	if maxWorkers < 1 {
-		maxWorkers = 1
-	}
 	if idleTimeout <= 0 {
 		idleTimeout = time.Second * 5
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	pool := &workerPool{
 		name:                name,
-		maxWorkers:          maxWorkers,
 		tasks:               make(chan *Task, tasksCapacity),
+		highTasks:           make(chan *Task, tasksCapacity),
 		readyWorkers:        make(chan *worker, readyWorkerQueueSize),
 		idleTimeout:         idleTimeout,
 		onDispatcherStopped: make(chan struct{}),
@@ -122,19 +143,49 @@ func NewPool(name string, maxWorkers int, idleTimeout time.Duration, statistics
 		statistics:          statistics,
 		logger:              logger.GetLogger("Pool", name),
 	}
+	pool.Resize(0, maxWorkers)
 	go pool.dispatch()
 	return pool
 }
 
+// Resize changes the range of workers,
+// the dispatcher spawns workers up to max under load, and retires idle workers down to min.
+func (p *workerPool) Resize(minWorkers, maxWorkers int) {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	if minWorkers < 0 {
+		minWorkers = 0
+	}
+	if minWorkers > maxWorkers {
+		minWorkers = maxWorkers
+	}
+	p.minWorkers.Store(int32(minWorkers))
+	p.maxWorkers.Store(int32(maxWorkers))
+}
+
 func (p *workerPool) Submit(ctx context.Context, task *Task) {
+	p.SubmitWithPriority(ctx, task, PriorityLow)
+}
+
+func (p *workerPool) SubmitWithPriority(ctx context.Context, task *Task, priority Priority) {
 	if task.handle == nil || p.Stopped() {
 		return
 	}
+	tasks := p.tasks
+	if priority == PriorityHigh {
+		tasks = p.highTasks
+	}
+	task.priority = priority
+	task.ctx = ctx
+	task.submitTime = time.Now()
+	p.statistics.TasksPending.Incr()
 	select {
 	case <-ctx.Done():
+		p.statistics.TasksPending.Decr()
 		p.statistics.TasksRejected.Incr()
 		return
-	case p.tasks <- task:
+	case tasks <- task:
 	}
 }
 
@@ -145,9 +196,14 @@ func (p *workerPool) mustGetWorker() *worker {
 		select {
 		// got a worker
 		case worker = <-p.readyWorkers:
+			if int32(p.statistics.WorkersAlive.Get()) > p.maxWorkers.Load() {
+				// pool shrunk, retire the exceeded worker
+				worker.stop(func() {})
+				continue
+			}
 			return worker
 		default:
-			if int(p.statistics.WorkersAlive.Get()) >= p.maxWorkers {
+			if int32(p.statistics.WorkersAlive.Get()) >= p.maxWorkers.Load() {
 				// no available workers
 				time.Sleep(sleepInterval)
 				continue
@@ -159,34 +215,60 @@ func (p *workerPool) mustGetWorker() *worker {
 }
 
 func (p *workerPool) dispatch() {
+	var (
+		worker *worker
+		task   *Task
+		// low priority task which is taken over by high priority task when waiting for worker
+		pending *Task
+	)
 	defer func() {
+		if pending != nil {
+			p.execTask(pending)
+		}
 		p.onDispatcherStopped <- struct{}{}
 	}()
 
 	idleTimeoutTimer := time.NewTimer(p.idleTimeout)
 	defer idleTimeoutTimer.Stop()
-	var (
-		worker *worker
-		task   *Task
-	)
 
 	for {
 		idleTimeoutTimer.Reset(p.idleTimeout)
+		// drain high priority tasks before low priority tasks
 		select {
 		case <-p.ctx.Done():
 			return
-		case task = <-p.tasks:
-			worker = p.mustGetWorker()
-			worker.execute(task)
-		case <-idleTimeoutTimer.C:
-			p.idle()
+		case task = <-p.highTasks:
+		default:
+			if pending != nil {
+				task, pending = pending, nil
+				break
+			}
+			select {
+			case <-p.ctx.Done():
+				return
+			case task = <-p.highTasks:
+			case task = <-p.tasks:
+			case <-idleTimeoutTimer.C:
+				p.idle()
+				continue
+			}
 		}
+		worker = p.mustGetWorker()
+		if task.priority == PriorityLow {
+			// high priority task may be submitted when waiting for worker, execute it first
+			select {
+			case highTask := <-p.highTasks:
+				task, pending = highTask, task
+			default:
+			}
+		}
+		worker.execute(task)
 	}
 }
 
 func (p *workerPool) idle() {
-	// timed out waiting, kill a ready worker
-	if p.statistics.WorkersAlive.Get() > 0 {
+	// timed out waiting, kill a ready worker, keep min workers alive
+	if int32(p.statistics.WorkersAlive.Get()) > p.minWorkers.Load() {
 		select {
 		case worker := <-p.readyWorkers:
 			worker.stop(func() {})
@@ -215,11 +297,18 @@ func (p *workerPool) stopWorkers() {
 	wg.Wait()
 }
 
-// consumedRemainingTasks consumes all buffered tasks in the channel
+// consumedRemainingTasks consumes all buffered tasks in the channel, high priority tasks first
 func (p *workerPool) consumedRemainingTasks() {
+	for _, tasks := range []chan *Task{p.highTasks, p.tasks} {
+		p.consumeTasks(tasks)
+	}
+}
+
+// consumeTasks consumes all buffered tasks in given channel.
+func (p *workerPool) consumeTasks(tasks chan *Task) {
 	for {
 		select {
-		case task := <-p.tasks:
+		case task := <-tasks:
 			p.execTask(task)
 		default:
 			return
@@ -241,6 +330,16 @@ func (p *workerPool) execTask(task *Task) {
 			}
 		}
 	}()
+	p.statistics.TasksPending.Decr()
+	p.statistics.TasksPendingTime.UpdateDuration(time.Since(task.submitTime))
+	if task.ctx != nil && task.ctx.Err() != nil {
+		// context done before picked up, drop the doomed task
+		p.statistics.TasksSkipped.Incr()
+		if task.panicHandle != nil {
+			task.panicHandle(task.ctx.Err())
+		}
+		return
+	}
 	p.statistics.TasksWaitingTime.UpdateDuration(time.Since(task.createTime))
 	task.Exec()
 	p.statistics.TasksExecutingTime.UpdateDuration(time.Since(task.createTime))
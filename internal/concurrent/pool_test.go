@@ -19,6 +19,7 @@ package concurrent
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -60,12 +61,17 @@ func Test_Pool_Submit(t *testing.T) {
 func TestPool_Submit_PanicTask(t *testing.T) {
 	pool := NewPool("test", 0, time.Millisecond*200, statistics)
 	var wait sync.WaitGroup
-	wait.Add(1)
+	wait.Add(2)
 	pool.Submit(context.TODO(), NewTask(func() {
 		panic("err")
 	}, func(_ error) {
 		wait.Done()
 	}))
+	pool.SubmitWithPriority(context.TODO(), NewTask(func() {
+		panic("err")
+	}, func(_ error) {
+		wait.Done()
+	}), PriorityHigh)
 	wait.Wait()
 
 	wp := pool.(*workerPool)
@@ -76,18 +82,98 @@ func TestPool_Submit_PanicTask(t *testing.T) {
 }
 
 func TestPool_Submit_Task_Timeout(t *testing.T) {
-	pool := NewPool("test", 0, time.Millisecond*100, statistics)
-	submit := func() {
+	timeoutStatistics := metrics.NewConcurrentStatistics("test_timeout", linmetric.BrokerRegistry)
+	pool := NewPool("test_timeout", 0, time.Millisecond*100, timeoutStatistics)
+	defer pool.Stop()
+	skippedBefore := timeoutStatistics.TasksSkipped.Get()
+
+	var (
+		deadlineErrs atomic.Int32
+		cancels      []context.CancelFunc
+	)
+	submit := func(priority Priority) {
 		ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond*2)
-		defer cancel()
-		pool.Submit(ctx, NewTask(func() {
+		// keep context alive until deadline, so that skipped tasks see deadline exceeded
+		cancels = append(cancels, cancel)
+		pool.SubmitWithPriority(ctx, NewTask(func() {
 			time.Sleep(20 * time.Millisecond)
-		}, nil))
+		}, func(err error) {
+			if errors.Is(err, context.DeadlineExceeded) {
+				deadlineErrs.Inc()
+			}
+		}), priority)
 	}
 	for i := 0; i < 100; i++ {
-		submit()
+		submit(PriorityLow)
+		submit(PriorityHigh)
 	}
 	time.Sleep(time.Second)
+	for _, cancel := range cancels {
+		cancel()
+	}
+	skipped := timeoutStatistics.TasksSkipped.Get() - skippedBefore
+	assert.True(t, skipped > 0)
+	assert.Equal(t, skipped, float64(deadlineErrs.Load()))
+}
+
+func TestPool_SubmitWithPriority(t *testing.T) {
+	pool := NewPool("test_priority", 1, time.Second, statistics)
+	defer pool.Stop()
+
+	var (
+		mutex  sync.Mutex
+		result []string
+		wait   sync.WaitGroup
+	)
+	submit := func(name string, priority Priority) {
+		wait.Add(1)
+		pool.SubmitWithPriority(context.TODO(), NewTask(func() {
+			mutex.Lock()
+			result = append(result, name)
+			mutex.Unlock()
+			wait.Done()
+		}, nil), priority)
+	}
+	// saturate the pool
+	started := make(chan struct{})
+	release := make(chan struct{})
+	pool.Submit(context.TODO(), NewTask(func() {
+		close(started)
+		<-release
+	}, nil))
+	<-started
+
+	submit("low-1", PriorityLow)
+	submit("low-2", PriorityLow)
+	submit("low-3", PriorityLow)
+	submit("high-1", PriorityHigh)
+	submit("high-2", PriorityHigh)
+	close(release)
+	wait.Wait()
+	assert.Equal(t, []string{"high-1", "high-2", "low-1", "low-2", "low-3"}, result)
+}
+
+func TestPool_Stop_RemainingTasks(t *testing.T) {
+	pool := NewPool("test_stop", 1, time.Second, statistics)
+	var c atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	pool.Submit(context.TODO(), NewTask(func() {
+		close(started)
+		<-release
+	}, nil))
+	<-started
+	for i := 0; i < 3; i++ {
+		pool.Submit(context.TODO(), NewTask(func() { c.Inc() }, nil))
+		pool.SubmitWithPriority(context.TODO(), NewTask(func() { c.Inc() }, nil), PriorityHigh)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+	// all pending tasks finished before exit
+	pool.Stop()
+	assert.Equal(t, int32(6), c.Load())
 }
 
 func TestPool_idle(t *testing.T) {
@@ -110,3 +196,88 @@ func TestPool_idle(t *testing.T) {
 	p1.idle()
 	<-ch
 }
+
+func TestPool_Resize(t *testing.T) {
+	stats := metrics.NewConcurrentStatistics("test_resize", linmetric.BrokerRegistry)
+	pool := NewPool("test_resize", 1, time.Millisecond*50, stats)
+	defer pool.Stop()
+	p := pool.(*workerPool)
+
+	pool.Resize(2, 4)
+	assert.Equal(t, int32(2), p.minWorkers.Load())
+	assert.Equal(t, int32(4), p.maxWorkers.Load())
+
+	// drive the pool past min under load
+	var wait sync.WaitGroup
+	release := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wait.Add(1)
+		pool.Submit(context.TODO(), NewTask(func() {
+			wait.Done()
+			<-release
+		}, nil))
+	}
+	wait.Wait()
+	assert.Equal(t, float64(4), stats.WorkersAlive.Get())
+	close(release)
+
+	// shrink back to min after idle
+	assert.Eventually(t, func() bool {
+		return stats.WorkersAlive.Get() == 2
+	}, time.Second*2, time.Millisecond*10)
+	time.Sleep(time.Millisecond * 200)
+	assert.Equal(t, float64(2), stats.WorkersAlive.Get())
+
+	// shrink max, retire exceeded worker when dispatching
+	pool.Resize(0, 1)
+	wait.Add(1)
+	pool.Submit(context.TODO(), NewTask(func() {
+		wait.Done()
+	}, nil))
+	wait.Wait()
+	assert.Equal(t, float64(1), stats.WorkersAlive.Get())
+
+	// invalid range
+	pool.Resize(3, 0)
+	assert.Equal(t, int32(1), p.minWorkers.Load())
+	assert.Equal(t, int32(1), p.maxWorkers.Load())
+	pool.Resize(-1, 2)
+	assert.Equal(t, int32(0), p.minWorkers.Load())
+}
+
+func TestPool_TasksPending(t *testing.T) {
+	statistics := metrics.NewConcurrentStatistics("test-pending", linmetric.BrokerRegistry)
+	pool := NewPool("test-pending", 1, 0, statistics)
+	defer pool.Stop()
+
+	started := make(chan struct{})
+	blocked := make(chan struct{})
+	var wait sync.WaitGroup
+	wait.Add(4)
+	pool.Submit(context.TODO(), NewTask(func() {
+		close(started)
+		<-blocked
+		wait.Done()
+	}, nil))
+	<-started
+	// all workers are blocked, submitted tasks are pending
+	for i := 0; i < 3; i++ {
+		pool.Submit(context.TODO(), NewTask(func() {
+			wait.Done()
+		}, nil))
+	}
+	assert.Equal(t, float64(3), statistics.TasksPending.Get())
+
+	close(blocked)
+	wait.Wait()
+	assert.Eventually(t, func() bool {
+		return statistics.TasksPending.Get() == 0
+	}, time.Second, time.Millisecond)
+
+	// rejected task is not pending
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	pool.(*workerPool).tasks = make(chan *Task)
+	pool.Submit(ctx, NewTask(func() {}, nil))
+	assert.Equal(t, float64(0), statistics.TasksPending.Get())
+}
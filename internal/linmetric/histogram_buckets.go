@@ -119,9 +119,10 @@ func (bkt *histogramBuckets) reset(start, to float64, count int, strategy bucket
 	bkt.upperBounds = upperBounds
 }
 
-func (bkt *histogramBuckets) Update(v float64) {
+// Update updates the value into buckets, returns the index of bucket, -1 if value is invalid.
+func (bkt *histogramBuckets) Update(v float64) int {
 	if math.IsNaN(v) || v < 0 || math.IsInf(v, 1) {
-		return
+		return -1
 	}
 	var bktIdx int
 	if bkt.strategy == exponentBucket {
@@ -131,12 +132,11 @@ func (bkt *histogramBuckets) Update(v float64) {
 	}
 	switch {
 	case bktIdx <= 0:
-		bkt.values[0]++
+		bktIdx = 0
 	case bktIdx >= len(bkt.values):
-		bkt.values[len(bkt.values)-1]++
-	default:
-		bkt.values[bktIdx]++
+		bktIdx = len(bkt.values) - 1
 	}
+	bkt.values[bktIdx]++
 
 	bkt.totalCount++
 	bkt.totalSum += v
@@ -148,6 +148,7 @@ func (bkt *histogramBuckets) Update(v float64) {
 	if v > bkt.max {
 		bkt.max = v
 	}
+	return bktIdx
 }
 
 func cloneFloat64Slice(src []float64) []float64 {
@@ -21,6 +21,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/lindb/common/pkg/fasttime"
 	commonseries "github.com/lindb/common/series"
 )
 
@@ -36,6 +37,7 @@ type BoundHistogram struct {
 	lastValues     []float64
 	lastTotalCount float64
 	lastTotalSum   float64
+	exemplars      []*Exemplar // latest exemplar of each bucket
 }
 
 func NewHistogram() *BoundHistogram {
@@ -55,6 +57,7 @@ func (h *BoundHistogram) afterResetBuckets() {
 	h.lastValues = cloneFloat64Slice(h.bkts.values)
 	h.lastTotalCount = h.bkts.totalCount
 	h.lastTotalSum = h.bkts.totalSum
+	h.exemplars = make([]*Exemplar, len(h.bkts.values))
 }
 
 func (h *BoundHistogram) WithExponentBuckets(lower, upper time.Duration, count int) *BoundHistogram {
@@ -90,6 +93,27 @@ func (h *BoundHistogram) UpdateMilliseconds(s float64) {
 	h.bkts.Update(s)
 }
 
+// UpdateSinceWithExemplar updates the duration since start, records the trace id as exemplar of the bucket.
+func (h *BoundHistogram) UpdateSinceWithExemplar(start time.Time, traceID string) {
+	h.UpdateMillisecondsWithExemplar(float64(time.Since(start).Nanoseconds()/1e6), traceID)
+}
+
+// UpdateMillisecondsWithExemplar updates the value, records the trace id as exemplar of the bucket.
+func (h *BoundHistogram) UpdateMillisecondsWithExemplar(s float64, traceID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	idx := h.bkts.Update(s)
+	if idx < 0 || traceID == "" {
+		return
+	}
+	h.exemplars[idx] = &Exemplar{
+		TraceID:   traceID,
+		Value:     s,
+		Timestamp: fasttime.UnixMilliseconds(),
+	}
+}
+
 func (h *BoundHistogram) UpdateSeconds(s float64) {
 	h.UpdateMilliseconds(s * 1000)
 }
@@ -100,6 +124,22 @@ func (h *BoundHistogram) Update(f func()) {
 	h.UpdateSince(start)
 }
 
+// snapshot returns the cumulative buckets/count/sum and exemplars of histogram.
+func (h *BoundHistogram) snapshot() *histogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rs := &histogramSnapshot{
+		upperBounds: cloneFloat64Slice(h.bkts.upperBounds),
+		counts:      cloneFloat64Slice(h.bkts.values),
+		count:       h.bkts.totalCount,
+		sum:         h.bkts.totalSum,
+		exemplars:   make([]*Exemplar, len(h.exemplars)),
+	}
+	copy(rs.exemplars, h.exemplars)
+	return rs
+}
+
 func (h *BoundHistogram) marshalToCompoundField(builder *commonseries.RowBuilder) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -0,0 +1,225 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linmetric
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+
+	"github.com/lindb/lindb/series/tag"
+)
+
+// OpenMetricsContentType represents the content type of OpenMetrics text format.
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// Exemplar represents a sample which links the metric value to a trace(e.g. slow query).
+type Exemplar struct {
+	TraceID   string  // trace id of the sample
+	Value     float64 // sample value
+	Timestamp int64   // unix milliseconds
+}
+
+// histogramSnapshot represents the snapshot of histogram for exporting.
+type histogramSnapshot struct {
+	upperBounds []float64
+	counts      []float64 // count of each bucket(not cumulative)
+	count       float64
+	sum         float64
+	exemplars   []*Exemplar
+}
+
+// metricFamily represents the metric family in OpenMetrics, all samples of family must be written together.
+type metricFamily struct {
+	name    string
+	typ     string
+	samples []string
+}
+
+// openMetricsWriter writes metrics of registry in OpenMetrics text format.
+type openMetricsWriter struct {
+	globalTags    tag.Tags
+	withExemplars bool
+
+	families map[string]*metricFamily
+}
+
+// WriteOpenMetrics writes all metrics of registry in OpenMetrics text format,
+// writes exemplars(trace id) of histogram buckets if withExemplars is true(not all scrapers support it).
+// NOTICE: counters are delta value since last native gather, so exported as unknown type.
+func (r *Registry) WriteOpenMetrics(writer io.Writer, globalTags tag.Tags, withExemplars bool) error {
+	var buffer []*taggedSeries
+	r.mu.RLock()
+	for _, s := range r.series {
+		buffer = append(buffer, s)
+	}
+	r.mu.RUnlock()
+
+	w := &openMetricsWriter{
+		globalTags:    globalTags,
+		withExemplars: withExemplars,
+		families:      make(map[string]*metricFamily),
+	}
+	for _, s := range buffer {
+		w.addSeries(s)
+	}
+	return w.writeTo(writer)
+}
+
+// addSeries adds the samples of series into metric families.
+func (w *openMetricsWriter) addSeries(s *taggedSeries) {
+	s.mu.Lock()
+	if s.payload == nil {
+		s.mu.Unlock()
+		return
+	}
+	simpleFields := make([]simpleField, len(s.payload.simpleFields))
+	copy(simpleFields, s.payload.simpleFields)
+	histogram := s.payload.histogramDelta
+	s.mu.Unlock()
+
+	labels := w.labels(s.tags)
+	for _, sf := range simpleFields {
+		name := sanitizeMetricName(s.metricName + "_" + sf.name())
+		typ := "gauge"
+		if sf.flatType() == flatMetricsV1.SimpleFieldTypeDeltaSum {
+			typ = "unknown"
+		}
+		family := w.getFamily(name, typ)
+		family.samples = append(family.samples, name+formatLabels(labels)+" "+formatFloat(sf.Get()))
+	}
+	if histogram != nil {
+		w.addHistogram(sanitizeMetricName(s.metricName), labels, histogram.snapshot())
+	}
+}
+
+// addHistogram adds the cumulative buckets/count/sum samples of histogram.
+func (w *openMetricsWriter) addHistogram(name string, labels []string, snapshot *histogramSnapshot) {
+	family := w.getFamily(name, "histogram")
+	cumulative := 0.0
+	for idx, upperBound := range snapshot.upperBounds {
+		cumulative += snapshot.counts[idx]
+		bucketLabels := append(labels[:len(labels):len(labels)], formatLabel("le", formatFloat(upperBound)))
+		sample := name + "_bucket" + formatLabels(bucketLabels) +
+			" " + formatFloat(cumulative)
+		if exemplar := snapshot.exemplars[idx]; w.withExemplars && exemplar != nil {
+			sample += " # " + formatLabels([]string{formatLabel("trace_id", exemplar.TraceID)}) +
+				" " + formatFloat(exemplar.Value) +
+				" " + strconv.FormatFloat(float64(exemplar.Timestamp)/1000, 'f', 3, 64)
+		}
+		family.samples = append(family.samples, sample)
+	}
+	family.samples = append(family.samples,
+		name+"_count"+formatLabels(labels)+" "+formatFloat(snapshot.count),
+		name+"_sum"+formatLabels(labels)+" "+formatFloat(snapshot.sum),
+	)
+}
+
+// getFamily returns the metric family by name, creates it if not exist.
+func (w *openMetricsWriter) getFamily(name, typ string) *metricFamily {
+	family, ok := w.families[name]
+	if !ok {
+		family = &metricFamily{name: name, typ: typ}
+		w.families[name] = family
+	}
+	return family
+}
+
+// labels returns the formatted labels with series tags and global tags.
+func (w *openMetricsWriter) labels(tags tag.Tags) []string {
+	var labels []string
+	keys := make(map[string]struct{})
+	for _, kv := range tags {
+		keys[string(kv.Key)] = struct{}{}
+		labels = append(labels, formatLabel(string(kv.Key), string(kv.Value)))
+	}
+	for _, kv := range w.globalTags {
+		if _, ok := keys[string(kv.Key)]; ok {
+			// series tag takes precedence
+			continue
+		}
+		labels = append(labels, formatLabel(string(kv.Key), string(kv.Value)))
+	}
+	return labels
+}
+
+// writeTo writes all metric families sorted by name, ends with EOF.
+func (w *openMetricsWriter) writeTo(writer io.Writer) error {
+	names := make([]string, 0, len(w.families))
+	for name := range w.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	bw := bufio.NewWriter(writer)
+	for _, name := range names {
+		family := w.families[name]
+		_, _ = bw.WriteString("# TYPE " + family.name + " " + family.typ + "\n")
+		for _, sample := range family.samples {
+			_, _ = bw.WriteString(sample)
+			_ = bw.WriteByte('\n')
+		}
+	}
+	_, _ = bw.WriteString("# EOF\n")
+	return bw.Flush()
+}
+
+// sanitizeMetricName replaces the invalid chars of metric name with '_', adds '_' prefix if starts with digit.
+func sanitizeMetricName(name string) string {
+	b := []byte(name)
+	for idx, c := range b {
+		valid := c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !valid {
+			b[idx] = '_'
+		}
+	}
+	if len(b) > 0 && b[0] >= '0' && b[0] <= '9' {
+		return "_" + string(b)
+	}
+	return string(b)
+}
+
+// formatLabel returns the label pair with escaped value.
+func formatLabel(key, value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+	return sanitizeMetricName(key) + `="` + value + `"`
+}
+
+// formatLabels returns the label set, empty if no labels.
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+// formatFloat returns the string value of float.
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,103 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linmetric
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/series/tag"
+)
+
+func TestRegistry_WriteOpenMetrics(t *testing.T) {
+	r := &Registry{
+		series: make(map[uint64]*taggedSeries),
+	}
+	scope := r.NewScope("lindb.ut", "node", "n1")
+	scope.NewGauge("mem").Update(2)
+	scope.NewCounter("req").Add(3)
+	// no payload
+	r.NewScope("lindb.empty")
+	h := r.NewScope("lindb.ut.duration").NewHistogram().
+		WithLinearBuckets(time.Millisecond, 3*time.Millisecond, 4)
+	h.UpdateMilliseconds(0.5)
+	h.UpdateMillisecondsWithExemplar(1.5, "req-1")
+	h.UpdateMillisecondsWithExemplar(5, "")
+	// invalid value
+	h.UpdateMillisecondsWithExemplar(-1, "req-2")
+	globalTags := tag.Tags{
+		{Key: []byte("node"), Value: []byte("global")},
+		{Key: []byte("role"), Value: []byte("storage")},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, r.WriteOpenMetrics(&buf, globalTags, false))
+	expect := `# TYPE lindb_ut_duration histogram
+lindb_ut_duration_bucket{node="global",role="storage",le="1"} 1
+lindb_ut_duration_bucket{node="global",role="storage",le="2"} 2
+lindb_ut_duration_bucket{node="global",role="storage",le="3"} 2
+lindb_ut_duration_bucket{node="global",role="storage",le="+Inf"} 3
+lindb_ut_duration_count{node="global",role="storage"} 3
+lindb_ut_duration_sum{node="global",role="storage"} 7
+# TYPE lindb_ut_mem gauge
+lindb_ut_mem{node="n1",role="storage"} 2
+# TYPE lindb_ut_req unknown
+lindb_ut_req{node="n1",role="storage"} 3
+# EOF
+`
+	assert.Equal(t, expect, buf.String())
+
+	// with exemplars
+	buf.Reset()
+	assert.NoError(t, r.WriteOpenMetrics(&buf, globalTags, true))
+	rs := regexp.MustCompile(`\d+\.\d{3}\n`).ReplaceAllString(buf.String(), "<ts>\n")
+	assert.Contains(t, rs, `lindb_ut_duration_bucket{node="global",role="storage",le="2"} 2 # {trace_id="req-1"} 1.5 <ts>`)
+	assert.Contains(t, rs, `lindb_ut_duration_bucket{node="global",role="storage",le="+Inf"} 3`+"\n")
+}
+
+func TestOpenMetrics_Format(t *testing.T) {
+	assert.Equal(t, "lindb_cpu_1m", sanitizeMetricName("lindb.cpu-1m"))
+	assert.Equal(t, "_1m", sanitizeMetricName("1m"))
+	assert.Equal(t, `path="C:\\a\"b\n"`, formatLabel("path", "C:\\a\"b\n"))
+	assert.Equal(t, "", formatLabels(nil))
+	assert.Equal(t, "-Inf", formatFloat(-1/zero()))
+	assert.Equal(t, "0.25", formatFloat(0.25))
+}
+
+func TestOpenMetrics_WriteFailure(t *testing.T) {
+	r := &Registry{
+		series: make(map[uint64]*taggedSeries),
+	}
+	r.NewScope("lindb.ut").NewGauge("mem")
+	assert.Error(t, r.WriteOpenMetrics(&errWriter{}, nil, false))
+}
+
+type errWriter struct{}
+
+func (w *errWriter) Write(_ []byte) (int, error) {
+	return 0, fmt.Errorf("err")
+}
+
+func zero() float64 {
+	return 0
+}
@@ -19,6 +19,7 @@ package linmetric
 
 import (
 	"io"
+	"regexp"
 	"sync"
 
 	commonseries "github.com/lindb/common/series"
@@ -71,10 +72,30 @@ func (r *Registry) FindMetricList(names []string, includeTags map[string]string)
 	for _, name := range names {
 		nameMap[name] = struct{}{}
 	}
+	return r.findMetricList(func(metricName string) bool {
+		_, ok := nameMap[metricName]
+		return ok
+	}, includeTags)
+}
+
+// FindMetricListByRegex returns metric list which metric name matches any given patterns and filters by tags.
+func (r *Registry) FindMetricListByRegex(patterns []*regexp.Regexp, includeTags map[string]string) map[string][]*models.StateMetric {
+	return r.findMetricList(func(metricName string) bool {
+		for _, pattern := range patterns {
+			if pattern.MatchString(metricName) {
+				return true
+			}
+		}
+		return false
+	}, includeTags)
+}
+
+// findMetricList returns metric list which metric name matched and filters by tags.
+func (r *Registry) findMetricList(match func(metricName string) bool, includeTags map[string]string) map[string][]*models.StateMetric {
 	var rs []*taggedSeries
 	r.mu.RLock()
 	for _, nm := range r.series {
-		if _, ok := nameMap[nm.metricName]; ok {
+		if match(nm.metricName) {
 			rs = append(rs, nm)
 		}
 	}
@@ -18,6 +18,7 @@
 package linmetric
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,3 +38,28 @@ func TestRegistry_FindMetricList(t *testing.T) {
 	rs = r.FindMetricList([]string{"test-1"}, map[string]string{"a": "a-1"})
 	assert.Len(t, rs["test-1"], 1)
 }
+
+func TestRegistry_FindMetricListByRegex(t *testing.T) {
+	r := &Registry{
+		series: make(map[uint64]*taggedSeries),
+	}
+	r.NewScope("query-1", "a", "a-1").NewCounter("f")
+	r.NewScope("query-2", "a", "a-2").NewCounter("f")
+	r.NewScope("write-1", "a", "a-2").NewCounter("f")
+
+	rs := r.FindMetricListByRegex([]*regexp.Regexp{regexp.MustCompile("^query-.*$")}, nil)
+	assert.Len(t, rs, 2)
+	assert.Len(t, rs["query-1"], 1)
+	assert.Len(t, rs["query-2"], 1)
+
+	rs = r.FindMetricListByRegex([]*regexp.Regexp{regexp.MustCompile("^query-.*$")}, map[string]string{"a": "a-2"})
+	assert.Len(t, rs, 1)
+	assert.Len(t, rs["query-2"], 1)
+
+	rs = r.FindMetricListByRegex([]*regexp.Regexp{regexp.MustCompile("^query-1$"), regexp.MustCompile("^write-.*$")}, nil)
+	assert.Len(t, rs, 2)
+	assert.Len(t, rs["write-1"], 1)
+
+	rs = r.FindMetricListByRegex([]*regexp.Regexp{regexp.MustCompile("^none$")}, nil)
+	assert.Empty(t, rs)
+}
@@ -29,4 +29,6 @@ const (
 	Failed
 	// Terminated is stopped
 	Terminated
+	// Draining is draining in-flight requests before stopped
+	Draining
 )
@@ -27,6 +27,9 @@ type ConcurrentStatistics struct {
 	TasksConsumed      *linmetric.BoundCounter   // tasks consumed count
 	TasksRejected      *linmetric.BoundCounter   // tasks rejected count
 	TasksPanic         *linmetric.BoundCounter   // tasks execute panic count
+	TasksSkipped       *linmetric.BoundCounter   // tasks skipped because context done before picked up
+	TasksPending       *linmetric.BoundGauge     // current tasks submitted but not picked up by worker
+	TasksPendingTime   *linmetric.BoundHistogram // tasks latency from submitting to starting execution
 	TasksWaitingTime   *linmetric.BoundHistogram // tasks waiting time
 	TasksExecutingTime *linmetric.BoundHistogram // tasks executing time with waiting period
 }
@@ -38,6 +41,13 @@ type LimitStatistics struct {
 	Processed *linmetric.BoundCounter // number of processed requests
 }
 
+// KeyedLimitStatistics represents rate limit statistics of a key(database etc.).
+type KeyedLimitStatistics struct {
+	Queued   *linmetric.BoundGauge   // number of requests waiting for token
+	Running  *linmetric.BoundGauge   // number of requests running
+	Timeouts *linmetric.BoundCounter // number of requests waiting timeout
+}
+
 // NewConcurrentStatistics creates concurrent statistics.
 func NewConcurrentStatistics(poolName string, registry *linmetric.Registry) *ConcurrentStatistics {
 	scope := registry.NewScope("lindb.concurrent.pool", "pool_name", poolName)
@@ -48,6 +58,10 @@ func NewConcurrentStatistics(poolName string, registry *linmetric.Registry) *Con
 		TasksConsumed:  scope.NewCounter("tasks_consumed"),
 		TasksRejected:  scope.NewCounter("tasks_rejected"),
 		TasksPanic:     scope.NewCounter("tasks_panic"),
+		TasksSkipped:   scope.NewCounter("tasks_skipped"),
+		TasksPending:   scope.NewGauge("tasks_pending"),
+		TasksPendingTime: scope.Scope("tasks_pending_duration").
+			NewHistogramVec("pool_name").WithTagValues(poolName),
 		TasksWaitingTime: scope.Scope("tasks_waiting_duration").
 			NewHistogramVec("pool_name").WithTagValues(poolName),
 		TasksExecutingTime: scope.Scope("tasks_executing_duration").
@@ -64,3 +78,13 @@ func NewLimitStatistics(limitType string, registry *linmetric.Registry) *LimitSt
 		Processed: scope.NewCounter("processed"),
 	}
 }
+
+// NewDatabaseQueryLimitStatistics creates a query rate limit statistics of database.
+func NewDatabaseQueryLimitStatistics(database string, registry *linmetric.Registry) *KeyedLimitStatistics {
+	scope := registry.NewScope("lindb.concurrent.limit.database.query")
+	return &KeyedLimitStatistics{
+		Queued:   scope.NewGaugeVec("queued_requests", "db").WithTagValues(database),
+		Running:  scope.NewGaugeVec("running_requests", "db").WithTagValues(database),
+		Timeouts: scope.NewCounterVec("timeout_requests", "db").WithTagValues(database),
+	}
+}
@@ -54,6 +54,38 @@ type NativeIngestionStatistics struct {
 	DroppedMetrics  *linmetric.BoundCounter // drop metric when append
 }
 
+// GraphiteIngestionStatistics represents graphite ingestion statistics.
+type GraphiteIngestionStatistics struct {
+	CorruptedData   *linmetric.BoundCounter // corrupted when parse
+	IngestedMetrics *linmetric.BoundCounter // ingested metrics
+	ReadBytes       *linmetric.BoundCounter // read data bytes
+	DroppedMetrics  *linmetric.BoundCounter // drop metric when parse/append
+}
+
+// OpenTSDBIngestionStatistics represents opentsdb ingestion statistics.
+type OpenTSDBIngestionStatistics struct {
+	CorruptedData   *linmetric.BoundCounter // corrupted when parse
+	IngestedMetrics *linmetric.BoundCounter // ingested metrics
+	ReadBytes       *linmetric.BoundCounter // read data bytes
+	DroppedMetrics  *linmetric.BoundCounter // drop metric when parse/append
+}
+
+// CSVIngestionStatistics represents csv bulk ingestion statistics.
+type CSVIngestionStatistics struct {
+	CorruptedData   *linmetric.BoundCounter // corrupted when parse
+	IngestedMetrics *linmetric.BoundCounter // ingested metrics(rows)
+	DroppedMetrics  *linmetric.BoundCounter // drop malformed rows when parse/append
+}
+
+// PrometheusIngestionStatistics represents prometheus remote write ingestion statistics.
+type PrometheusIngestionStatistics struct {
+	CorruptedData   *linmetric.BoundCounter // corrupted when parse
+	IngestedMetrics *linmetric.BoundCounter // ingested metrics(samples)
+	ReadBytes       *linmetric.BoundCounter // read data bytes
+	DroppedMetrics  *linmetric.BoundCounter // drop metric when parse/append
+	StaleSamples    *linmetric.BoundCounter // skip staleness marker samples
+}
+
 // CommonIngestionStatistics represents ingestion common statistics.
 type CommonIngestionStatistics struct {
 	Duration *linmetric.DeltaHistogramVec // ingest duration(include count)
@@ -83,6 +115,50 @@ func NewInfluxIngestionStatistics() *InfluxIngestionStatistics {
 	}
 }
 
+// NewGraphiteIngestionStatistics creates a graphite ingestion statistics.
+func NewGraphiteIngestionStatistics() *GraphiteIngestionStatistics {
+	graphiteIngestionScope := linmetric.BrokerRegistry.NewScope("lindb.ingestion.graphite")
+	return &GraphiteIngestionStatistics{
+		CorruptedData:   graphiteIngestionScope.NewCounter("data_corrupted"),
+		IngestedMetrics: graphiteIngestionScope.NewCounter("ingested_metrics"),
+		ReadBytes:       graphiteIngestionScope.NewCounter("read_bytes"),
+		DroppedMetrics:  graphiteIngestionScope.NewCounter("dropped_metrics"),
+	}
+}
+
+// NewOpenTSDBIngestionStatistics creates an opentsdb ingestion statistics.
+func NewOpenTSDBIngestionStatistics() *OpenTSDBIngestionStatistics {
+	openTSDBIngestionScope := linmetric.BrokerRegistry.NewScope("lindb.ingestion.opentsdb")
+	return &OpenTSDBIngestionStatistics{
+		CorruptedData:   openTSDBIngestionScope.NewCounter("data_corrupted"),
+		IngestedMetrics: openTSDBIngestionScope.NewCounter("ingested_metrics"),
+		ReadBytes:       openTSDBIngestionScope.NewCounter("read_bytes"),
+		DroppedMetrics:  openTSDBIngestionScope.NewCounter("dropped_metrics"),
+	}
+}
+
+// NewCSVIngestionStatistics creates a csv bulk ingestion statistics.
+func NewCSVIngestionStatistics() *CSVIngestionStatistics {
+	csvIngestionScope := linmetric.BrokerRegistry.NewScope("lindb.ingestion.csv")
+	return &CSVIngestionStatistics{
+		CorruptedData:   csvIngestionScope.NewCounter("data_corrupted"),
+		IngestedMetrics: csvIngestionScope.NewCounter("ingested_metrics"),
+		DroppedMetrics:  csvIngestionScope.NewCounter("dropped_metrics"),
+	}
+}
+
+// NewPrometheusIngestionStatistics creates a prometheus remote write ingestion statistics.
+func NewPrometheusIngestionStatistics() *PrometheusIngestionStatistics {
+	prometheusIngestionScope := linmetric.BrokerRegistry.NewScope("lindb.ingestion.prometheus")
+	return &PrometheusIngestionStatistics{
+		CorruptedData:   prometheusIngestionScope.NewCounter("data_corrupted"),
+		IngestedMetrics: prometheusIngestionScope.NewCounter("ingested_metrics"),
+		ReadBytes:       prometheusIngestionScope.NewCounter("read_bytes"),
+		DroppedMetrics:  prometheusIngestionScope.NewCounter("dropped_metrics"),
+		StaleSamples:    prometheusIngestionScope.NewCounter("stale_samples"),
+	}
+}
+
 // NewCommonIngestionStatistics creates an ingestion common statistics.
 func NewCommonIngestionStatistics() *CommonIngestionStatistics {
 	return &CommonIngestionStatistics{
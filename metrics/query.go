@@ -23,28 +23,49 @@ import (
 
 // QueryStatistics represents query statistics.
 type QueryStatistics struct {
-	CreatedTasks *linmetric.BoundCounter // create query task
-	ExpireTasks  *linmetric.BoundCounter // task expire, long-term no response
-	AliveTask    *linmetric.BoundGauge   // current executing task(alive)
-	EmitResponse *linmetric.BoundCounter // emit response to parent node
-	OmitResponse *linmetric.BoundCounter // omit response because task evicted
+	CreatedTasks   *linmetric.BoundCounter // create query task
+	ExpireTasks    *linmetric.BoundCounter // task expire, long-term no response
+	CancelledTasks *linmetric.BoundCounter // task cancelled, client disconnected
+	AliveTask      *linmetric.BoundGauge   // current executing task(alive)
+	EmitResponse   *linmetric.BoundCounter // emit response to parent node
+	OmitResponse   *linmetric.BoundCounter // omit response because task evicted
+}
+
+// QueryResultCacheStatistics represents query result cache statistics.
+type QueryResultCacheStatistics struct {
+	Hits      *linmetric.BoundCounter // query served from result cache
+	Misses    *linmetric.BoundCounter // query not cached or cached result expired
+	Evictions *linmetric.BoundCounter // cached result evicted because cache is full
 }
 
 // TransportStatistics represents request/response transport statistics.
 type TransportStatistics struct {
 	SentRequest          *linmetric.BoundCounter // send request success
 	SentRequestFailures  *linmetric.BoundCounter // send request failure
+	RetryRequests        *linmetric.BoundCounter // retry send request after failure
+	RetryRequestFailures *linmetric.BoundCounter // send request failure after all retries
+	CircuitOpenRequests  *linmetric.BoundCounter // send request fast failed because circuit of target node is open
 	SentResponses        *linmetric.BoundCounter // send response to parent success
 	SentResponseFailures *linmetric.BoundCounter // send response failure
+	CircuitOpenResponses *linmetric.BoundCounter // send response fast failed because circuit of parent node is open
+	NetPayload           *NetPayloadStatistics   // payload of sent request
+}
+
+// NetPayloadStatistics represents the payload statistics of task request/response between nodes.
+type NetPayloadStatistics struct {
+	RawBytes *linmetric.BoundCounter // payload bytes before compressing
+	NetBytes *linmetric.BoundCounter // payload bytes transferred over network(compressed if above threshold)
 }
 
 // StorageQueryStatistics represents storage query statistics.
 type StorageQueryStatistics struct {
-	MetricQuery         *linmetric.BoundCounter // execute metric query success(just plan it)
-	MetricQueryFailures *linmetric.BoundCounter // execute metric query failure
-	MetaQuery           *linmetric.BoundCounter // metadata query success
-	MetaQueryFailures   *linmetric.BoundCounter // metadata query failure
-	OmitRequest         *linmetric.BoundCounter // omit request(task no belong to current node, wrong stream etc.)
+	MetricQuery         *linmetric.BoundCounter   // execute metric query success(just plan it)
+	MetricQueryFailures *linmetric.BoundCounter   // execute metric query failure
+	MetaQuery           *linmetric.BoundCounter   // metadata query success
+	MetaQueryFailures   *linmetric.BoundCounter   // metadata query failure
+	OmitRequest         *linmetric.BoundCounter   // omit request(task no belong to current node, wrong stream etc.)
+	CancelledQuery      *linmetric.BoundCounter   // metric query cancelled by upstream
+	MetricQueryDuration *linmetric.BoundHistogram // metric query duration, with request id as exemplar
 }
 
 // NewTransportStatistics creates a transport statistics.
@@ -55,6 +76,20 @@ func NewTransportStatistics(registry *linmetric.Registry) *TransportStatistics {
 		SentResponses:        scope.NewCounter("sent_responses"),
 		SentResponseFailures: scope.NewCounter("sent_responses_failures"),
 		SentRequestFailures:  scope.NewCounter("sent_requests_failures"),
+		RetryRequests:        scope.NewCounter("retry_requests"),
+		RetryRequestFailures: scope.NewCounter("retry_requests_failures"),
+		CircuitOpenRequests:  scope.NewCounter("circuit_open_requests"),
+		CircuitOpenResponses: scope.NewCounter("circuit_open_responses"),
+		NetPayload:           NewNetPayloadStatistics(registry, "sent"),
+	}
+}
+
+// NewNetPayloadStatistics creates a net payload statistics, direction is sent or received.
+func NewNetPayloadStatistics(registry *linmetric.Registry, direction string) *NetPayloadStatistics {
+	scope := registry.NewScope("lindb.task.transport.payload", "direction", direction)
+	return &NetPayloadStatistics{
+		RawBytes: scope.NewCounter("raw_bytes"),
+		NetBytes: scope.NewCounter("net_bytes"),
 	}
 }
 
@@ -62,11 +97,22 @@ func NewTransportStatistics(registry *linmetric.Registry) *TransportStatistics {
 func NewQueryStatistics(registry *linmetric.Registry) *QueryStatistics {
 	scope := registry.NewScope("lindb.query")
 	return &QueryStatistics{
-		CreatedTasks: scope.NewCounter("created_tasks"),
-		AliveTask:    scope.NewGauge("alive_tasks"),
-		ExpireTasks:  scope.NewCounter("expire_tasks"),
-		EmitResponse: scope.NewCounter("emitted_responses"),
-		OmitResponse: scope.NewCounter("omitted_responses"),
+		CreatedTasks:   scope.NewCounter("created_tasks"),
+		AliveTask:      scope.NewGauge("alive_tasks"),
+		ExpireTasks:    scope.NewCounter("expire_tasks"),
+		CancelledTasks: scope.NewCounter("cancelled_tasks"),
+		EmitResponse:   scope.NewCounter("emitted_responses"),
+		OmitResponse:   scope.NewCounter("omitted_responses"),
+	}
+}
+
+// NewQueryResultCacheStatistics creates a query result cache statistics.
+func NewQueryResultCacheStatistics(registry *linmetric.Registry) *QueryResultCacheStatistics {
+	scope := registry.NewScope("lindb.query.result_cache")
+	return &QueryResultCacheStatistics{
+		Hits:      scope.NewCounter("hits"),
+		Misses:    scope.NewCounter("misses"),
+		Evictions: scope.NewCounter("evictions"),
 	}
 }
 
@@ -79,5 +125,7 @@ func NewStorageQueryStatistics() *StorageQueryStatistics {
 		MetaQuery:           scope.NewCounter("meta_queries"),
 		MetaQueryFailures:   scope.NewCounter("meta_query_failures"),
 		OmitRequest:         scope.NewCounter("omitted_requests"),
+		CancelledQuery:      scope.NewCounter("cancelled_queries"),
+		MetricQueryDuration: scope.Scope("metric_query_duration").NewHistogram(),
 	}
 }
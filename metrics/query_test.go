@@ -29,4 +29,6 @@ func TestQueryStatistics(t *testing.T) {
 	assert.NotNil(t, NewQueryStatistics(linmetric.RootRegistry))
 	assert.NotNil(t, NewTransportStatistics(linmetric.RootRegistry))
 	assert.NotNil(t, NewStorageQueryStatistics())
+	assert.NotNil(t, NewQueryResultCacheStatistics(linmetric.RootRegistry))
+	assert.NotNil(t, NewNetPayloadStatistics(linmetric.RootRegistry, "received"))
 }
@@ -23,6 +23,13 @@ import "github.com/lindb/lindb/internal/linmetric"
 type BrokerDatabaseWriteStatistics struct {
 	OutOfTimeRange *linmetric.BoundCounter // timestamp of metrics out of acceptable write time range
 	ShardNotFound  *linmetric.BoundCounter // shard not found count
+	Backpressure   *linmetric.BoundCounter // rows rejected because too many in-flight rows of shard channel
+	DuplicateBatch *linmetric.BoundCounter // rows ignored because batch id already written to shard channel
+}
+
+// BrokerWriteValidationStatistics represents write validation statistics.
+type BrokerWriteValidationStatistics struct {
+	InvalidRows *linmetric.DeltaCounterVec // rows rejected because violating limits of database, tagged by db/reason
 }
 
 // BrokerFamilyWriteStatistics represents family channel write statistics.
@@ -43,6 +50,12 @@ type BrokerFamilyWriteStatistics struct {
 	LeaderChanged        *linmetric.BoundCounter // shard leader changed
 }
 
+// BrokerWriteStreamStatistics represents write stream(broker to storage) statistics.
+type BrokerWriteStreamStatistics struct {
+	InFlight     *linmetric.BoundGauge   // number of sent records not acknowledged by storage
+	Backpressure *linmetric.BoundCounter // send timeout because too many records not acknowledged
+}
+
 // StorageLocalReplicatorStatistics represents local replicator statistics.
 type StorageLocalReplicatorStatistics struct {
 	DecompressFailures *linmetric.BoundCounter // decompress message failure count
@@ -50,6 +63,8 @@ type StorageLocalReplicatorStatistics struct {
 	ReplicaRows        *linmetric.BoundCounter // row number of replica
 	AckSequence        *linmetric.BoundCounter // ack persist sequence count
 	InvalidSequence    *linmetric.BoundCounter // invalid replica sequence count
+	Quarantines        *linmetric.BoundCounter // put failure message into quarantine count
+	QuarantineFailures *linmetric.BoundCounter // put failure message into quarantine failure count
 }
 
 // StorageRemoteReplicatorStatistics represents remote replicator statistics.
@@ -103,6 +118,16 @@ func NewBrokerDatabaseWriteStatistics(database string) *BrokerDatabaseWriteStati
 	return &BrokerDatabaseWriteStatistics{
 		OutOfTimeRange: scope.NewCounterVec("out_of_time_range", "db").WithTagValues(database),
 		ShardNotFound:  scope.NewCounterVec("shard_not_found", "db").WithTagValues(database),
+		Backpressure:   scope.NewCounterVec("backpressure", "db").WithTagValues(database),
+		DuplicateBatch: scope.NewCounterVec("duplicate_batch", "db").WithTagValues(database),
+	}
+}
+
+// NewBrokerWriteValidationStatistics creates a write validation statistics.
+func NewBrokerWriteValidationStatistics() *BrokerWriteValidationStatistics {
+	scope := linmetric.BrokerRegistry.NewScope("lindb.broker.database.write")
+	return &BrokerWriteValidationStatistics{
+		InvalidRows: scope.NewCounterVec("invalid_rows", "db", "reason"),
 	}
 }
 
@@ -127,6 +152,15 @@ func NewBrokerFamilyWriteStatistics(database string) *BrokerFamilyWriteStatistic
 	}
 }
 
+// NewBrokerWriteStreamStatistics creates a broker write stream statistics.
+func NewBrokerWriteStreamStatistics(database string) *BrokerWriteStreamStatistics {
+	scope := linmetric.BrokerRegistry.NewScope("lindb.broker.write_stream")
+	return &BrokerWriteStreamStatistics{
+		InFlight:     scope.NewGaugeVec("in_flight", "db").WithTagValues(database),
+		Backpressure: scope.NewCounterVec("backpressure", "db").WithTagValues(database),
+	}
+}
+
 // NewStorageLocalReplicatorStatistics creates a storage local replicator statistics.
 func NewStorageLocalReplicatorStatistics(database, shard string) *StorageLocalReplicatorStatistics {
 	scope := linmetric.StorageRegistry.NewScope("lindb.storage.replica.local")
@@ -136,6 +170,8 @@ func NewStorageLocalReplicatorStatistics(database, shard string) *StorageLocalRe
 		ReplicaRows:        scope.NewCounterVec("replica_rows", "db", "shard").WithTagValues(database, shard),
 		AckSequence:        scope.NewCounterVec("ack_sequence", "db", "shard").WithTagValues(database, shard),
 		InvalidSequence:    scope.NewCounterVec("invalid_sequence", "db", "shard").WithTagValues(database, shard),
+		Quarantines:        scope.NewCounterVec("quarantines", "db", "shard").WithTagValues(database, shard),
+		QuarantineFailures: scope.NewCounterVec("quarantine_failures", "db", "shard").WithTagValues(database, shard),
 	}
 }
 
@@ -25,7 +25,9 @@ import (
 
 func TestReplication_New(t *testing.T) {
 	assert.NotNil(t, NewBrokerFamilyWriteStatistics("db"))
+	assert.NotNil(t, NewBrokerWriteStreamStatistics("db"))
 	assert.NotNil(t, NewBrokerDatabaseWriteStatistics("db"))
+	assert.NotNil(t, NewBrokerWriteValidationStatistics())
 	assert.NotNil(t, NewStorageReplicatorRunnerStatistics("type", "db", "shard"))
 	assert.NotNil(t, NewStorageLocalReplicatorStatistics("db", "shard"))
 	assert.NotNil(t, NewStorageRemoteReplicatorStatistics("db", "shard"))
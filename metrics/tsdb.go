@@ -36,6 +36,16 @@ var (
 // IndexDBStatistics represents index database statistics.
 type IndexDBStatistics = struct {
 	BuildInvertedIndex *linmetric.BoundCounter // build inverted index count
+	FlushTagKeys       *linmetric.BoundCounter // flush tag index of tag key count
+	FlushTagValues     *linmetric.BoundCounter // flush tag value => series ids entry count
+}
+
+// TagValueInternerStatistics represents tag value interner statistics.
+type TagValueInternerStatistics struct {
+	Hits    *linmetric.BoundCounter // tag value found in interner
+	Misses  *linmetric.BoundCounter // tag value not found in interner, new string allocated
+	Evicted *linmetric.BoundCounter // cold tag values evicted from interner
+	Size    *linmetric.BoundGauge   // num. of tag values in interner
 }
 
 // MemDBStatistics represents memory database statistics.
@@ -62,8 +72,12 @@ type MetaDBStatistics struct {
 	GenMetricIDFailures *linmetric.BoundCounter // generate metric id failure
 	GenFieldIDs         *linmetric.BoundCounter // generate field id success
 	GenFieldIDFailures  *linmetric.BoundCounter // generate field id failure
+	FieldTypeChanges    *linmetric.BoundCounter // field type changed
+	FieldTypeConflicts  *linmetric.BoundCounter // field written as different type
 	GenTagKeyIDs        *linmetric.BoundCounter // generate tag key id success
 	GenTagKeyIDFailures *linmetric.BoundCounter // generate tag key id failure
+	CachedMetrics       *linmetric.BoundGauge   // number of metric metadata cached in memory
+	EvictedMetrics      *linmetric.BoundCounter // metric metadata evicted from memory
 }
 
 // ShardStatistics represents shard statistics.
@@ -71,6 +85,7 @@ type ShardStatistics struct {
 	LookupMetricMetaFailures *linmetric.BoundCounter   // lookup meta of metric failure
 	IndexDBFlushDuration     *linmetric.BoundHistogram // flush index database duration(include count)
 	IndexDBFlushFailures     *linmetric.BoundCounter   // flush index database failure
+	IndexDBEarlyFlushes      *linmetric.BoundCounter   // flush index database early because of too many new series
 }
 
 // FamilyStatistics represents family statistics.
@@ -117,6 +132,8 @@ func NewShardStatistics(database, shard string) *ShardStatistics {
 			WithTagValues(database, shard),
 		IndexDBFlushFailures: shardScope.NewCounterVec("indexdb_flush_failures", "db", "shard").
 			WithTagValues(database, shard),
+		IndexDBEarlyFlushes: shardScope.NewCounterVec("indexdb_early_flushes", "db", "shard").
+			WithTagValues(database, shard),
 		IndexDBFlushDuration: shardScope.Scope("indexdb_flush_duration").NewHistogramVec("db", "shard").
 			WithTagValues(database, shard),
 	}
@@ -131,6 +148,10 @@ func NewMetaDBStatistics(database string) *MetaDBStatistics {
 		GenTagKeyIDFailures: metaDBScope.NewCounterVec("gen_tag_key_id_failures", "db").WithTagValues(database),
 		GenFieldIDs:         metaDBScope.NewCounterVec("gen_field_ids", "db").WithTagValues(database),
 		GenFieldIDFailures:  metaDBScope.NewCounterVec("gen_field_id_failures", "db").WithTagValues(database),
+		FieldTypeChanges:    metaDBScope.NewCounterVec("field_type_changes", "db").WithTagValues(database),
+		FieldTypeConflicts:  metaDBScope.NewCounterVec("field_type_conflicts", "db").WithTagValues(database),
+		CachedMetrics:       metaDBScope.NewGaugeVec("cached_metrics", "db").WithTagValues(database),
+		EvictedMetrics:      metaDBScope.NewCounterVec("evicted_metrics", "db").WithTagValues(database),
 	}
 }
 
@@ -160,10 +181,23 @@ func NewMemDBStatistics(database string) *MemDBStatistics {
 	}
 }
 
+// NewTagValueInternerStatistics creates a tag value interner statistics.
+func NewTagValueInternerStatistics() *TagValueInternerStatistics {
+	scope := linmetric.StorageRegistry.NewScope("lindb.tsdb.tag_value_interner")
+	return &TagValueInternerStatistics{
+		Hits:    scope.NewCounter("hits"),
+		Misses:  scope.NewCounter("misses"),
+		Evicted: scope.NewCounter("evicted"),
+		Size:    scope.NewGauge("size"),
+	}
+}
+
 // NewIndexDBStatistics creates an index database statistics.
 func NewIndexDBStatistics(database string) *IndexDBStatistics {
 	scope := linmetric.StorageRegistry.NewScope("lindb.tsdb.indexdb")
 	return &IndexDBStatistics{
 		BuildInvertedIndex: scope.NewCounterVec("build_inverted_index", "db").WithTagValues(database),
+		FlushTagKeys:       scope.NewCounterVec("flush_tag_keys", "db").WithTagValues(database),
+		FlushTagValues:     scope.NewCounterVec("flush_tag_values", "db").WithTagValues(database),
 	}
 }
@@ -31,4 +31,5 @@ func TestTSDBStatistics_New(t *testing.T) {
 	assert.NotNil(t, NewFamilyStatistics("test", "shard"))
 	assert.NotNil(t, NewTagMetaStatistics("test"))
 	assert.NotNil(t, NewMetaDBStatistics("test"))
+	assert.NotNil(t, NewTagValueInternerStatistics())
 }
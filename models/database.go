@@ -167,6 +167,14 @@ func (s *ShardAssignment) AddReplica(shardID ShardID, replicaID NodeID) {
 }
 
 // GetReplicaFactor returns the factor of replica.
+// NOTE: replica factor is lost after unmarshal, calc it based on replica list of shards.
 func (s *ShardAssignment) GetReplicaFactor() int {
+	if s.replicaFactor == 0 {
+		for _, replica := range s.Shards {
+			if replica != nil && len(replica.Replicas) > s.replicaFactor {
+				s.replicaFactor = len(replica.Replicas)
+			}
+		}
+	}
 	return s.replicaFactor
 }
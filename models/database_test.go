@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/common/pkg/encoding"
 	commontimeutil "github.com/lindb/common/pkg/timeutil"
 
 	"github.com/lindb/lindb/pkg/option"
@@ -39,6 +40,11 @@ func TestNewShardAssignment(t *testing.T) {
 	assert.Equal(t, []NodeID{1, 2}, shardAssign.Shards[1].Replicas)
 	assert.Equal(t, []NodeID{3, 5, 6}, shardAssign.Shards[2].Replicas)
 	assert.Equal(t, 3, shardAssign.GetReplicaFactor())
+
+	// replica factor lost after unmarshal
+	shardAssign1 := &ShardAssignment{}
+	assert.NoError(t, encoding.JSONUnmarshal(encoding.JSONMarshal(shardAssign), shardAssign1))
+	assert.Equal(t, 3, shardAssign1.GetReplicaFactor())
 }
 
 func TestDatabase_String(t *testing.T) {
@@ -0,0 +1,102 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/lindb/lindb/constants"
+)
+
+// ErrorCode represents the code of query error.
+type ErrorCode string
+
+const (
+	// ErrCodeNotFound represents the queried data not found.
+	ErrCodeNotFound ErrorCode = "NotFound"
+	// ErrCodeTimeout represents query exceed timeout.
+	ErrCodeTimeout ErrorCode = "Timeout"
+	// ErrCodePartialFailure represents some of target nodes failed.
+	ErrCodePartialFailure ErrorCode = "PartialFailure"
+	// ErrCodeInternal represents internal error.
+	ErrCodeInternal ErrorCode = "Internal"
+)
+
+// HTTPStatus returns the http status code of error code.
+func (code ErrorCode) HTTPStatus() int {
+	switch code {
+	case ErrCodeNotFound:
+		return http.StatusNotFound
+	case ErrCodeTimeout:
+		return http.StatusGatewayTimeout
+	case ErrCodePartialFailure:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Error represents the query error with code.
+type Error struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// NewError creates a query error with code.
+func NewError(code ErrorCode, message string) *Error {
+	if code == "" {
+		code = ErrCodeInternal
+	}
+	return &Error{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error returns the message of error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrorCodeOf returns the code of error, returns Internal if error is not recognized.
+func ErrorCodeOf(err error) ErrorCode {
+	var e *Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &e):
+		return e.Code
+	case errors.Is(err, constants.ErrNotFound):
+		return ErrCodeNotFound
+	case errors.Is(err, constants.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return ErrCodeTimeout
+	default:
+		return ErrCodeInternal
+	}
+}
+
+// ToError converts error to query error with code.
+func ToError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return NewError(ErrorCodeOf(err), err.Error())
+}
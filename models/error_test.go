@@ -0,0 +1,55 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	assert.Equal(t, ErrorCode(""), ErrorCodeOf(nil))
+	assert.Equal(t, ErrCodeInternal, ErrorCodeOf(errors.New("err")))
+	assert.Equal(t, ErrCodeNotFound, ErrorCodeOf(constants.ErrMetricIDNotFound))
+	assert.Equal(t, ErrCodeTimeout, ErrorCodeOf(constants.ErrTimeout))
+	assert.Equal(t, ErrCodeTimeout, ErrorCodeOf(context.DeadlineExceeded))
+	assert.Equal(t, ErrCodePartialFailure, ErrorCodeOf(fmt.Errorf("wrap: %w", NewError(ErrCodePartialFailure, "err"))))
+}
+
+func TestErrorCode_HTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, ErrCodeNotFound.HTTPStatus())
+	assert.Equal(t, http.StatusGatewayTimeout, ErrCodeTimeout.HTTPStatus())
+	assert.Equal(t, http.StatusBadGateway, ErrCodePartialFailure.HTTPStatus())
+	assert.Equal(t, http.StatusInternalServerError, ErrCodeInternal.HTTPStatus())
+	assert.Equal(t, http.StatusInternalServerError, ErrorCode("unknown").HTTPStatus())
+}
+
+func TestToError(t *testing.T) {
+	err := NewError("", "err")
+	assert.Equal(t, ErrCodeInternal, err.Code)
+	assert.Equal(t, "err", err.Error())
+	assert.Equal(t, err, ToError(fmt.Errorf("wrap: %w", err)))
+	assert.Equal(t, &Error{Code: ErrCodeNotFound, Message: "database not found"}, ToError(constants.ErrDatabaseNotFound))
+}
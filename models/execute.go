@@ -17,8 +17,50 @@
 
 package models
 
+import (
+	"github.com/lindb/lindb/sql/stmt"
+)
+
 // ExecuteParam represents lin query language executor's param.
 type ExecuteParam struct {
 	Database string `form:"db" json:"db"`
 	SQL      string `form:"sql" json:"sql" binding:"required"`
 }
+
+// QueryValidationCode represents the error code of query validation.
+type QueryValidationCode string
+
+const (
+	// QuerySyntaxError represents the sql cannot be parsed by grammar.
+	QuerySyntaxError QueryValidationCode = "SyntaxError"
+	// QueryInvalid represents the sql is parsed, but statement is invalid(not select statement etc.).
+	QueryInvalid QueryValidationCode = "InvalidQuery"
+	// QueryUnknownMetric represents the metric of query not exist in database.
+	QueryUnknownMetric QueryValidationCode = "UnknownMetric"
+	// QueryUnknownField represents the field of query not exist under metric.
+	QueryUnknownField QueryValidationCode = "UnknownField"
+)
+
+// QueryValidationError represents the error of query validation,
+// position(1-based line/column) is only returned for syntax error.
+type QueryValidationError struct {
+	Code    QueryValidationCode `json:"code"`
+	Message string              `json:"message"`
+	Metric  string              `json:"metric,omitempty"`
+	Field   string              `json:"field,omitempty"`
+	Line    int                 `json:"line,omitempty"`
+	Column  int                 `json:"column,omitempty"`
+}
+
+// QueryValidation represents the result of query validation(dry run without data scan).
+type QueryValidation struct {
+	Valid  bool                    `json:"valid"`
+	Plan   *stmt.Query             `json:"plan,omitempty"` // parsed query statement if valid
+	Errors []*QueryValidationError `json:"errors,omitempty"`
+}
+
+// AddError adds the validation error, query is invalid if it has any error.
+func (v *QueryValidation) AddError(err *QueryValidationError) {
+	v.Valid = false
+	v.Errors = append(v.Errors, err)
+}
@@ -24,6 +24,17 @@ import (
 	commonseries "github.com/lindb/common/series"
 )
 
+const (
+	// FieldTypeEvolutionError rejects writing a field with different type(default).
+	FieldTypeEvolutionError = "error"
+	// FieldTypeEvolutionPerSegment accepts field type change, tracks field type by time range,
+	// queries use the type per segment and merge with a function compatible with all types.
+	FieldTypeEvolutionPerSegment = "per-segment"
+	// FieldTypeEvolutionCoerce keeps the field type written first, coerces data written as compatible type
+	// into it, rejects writing a field with incompatible type.
+	FieldTypeEvolutionCoerce = "coerce"
+)
+
 // Limits represents all the limit for database level; can be used to describe global
 // default limits, or per-database limits vis toml config.
 type Limits struct {
@@ -32,12 +43,19 @@ type Limits struct {
 	MaxNamespaceLength  int    `toml:"max-namespace-length"`
 	MaxMetrics          uint32 `toml:"max-metrics"`
 	MaxMetricNameLength int    `toml:"max-metric-name-length"`
-	MaxFieldNameLength  int    `toml:"max-field-name-length"`
-	MaxFieldsPerMetric  int32  `toml:"max-fields-per-metric"`
-	MaxTagNameLength    int    `toml:"max-tag-name-length"`
-	MaxTagValueLength   int    `toml:"max-tag-value-length"`
-	MaxTagsPerMetric    int    `toml:"max-tags-per-metric"`
-	MaxSeriesPerMetric  uint32 `toml:"max-series-per-metric"`
+	// allowed charset of metric name(regular expression)
+	MetricNamePattern  string `toml:"metric-name-pattern"`
+	MaxFieldNameLength int    `toml:"max-field-name-length"`
+	MaxFieldsPerMetric int32  `toml:"max-fields-per-metric"`
+	MaxTagNameLength   int    `toml:"max-tag-name-length"`
+	MaxTagValueLength  int    `toml:"max-tag-value-length"`
+	MaxTagsPerMetric   int    `toml:"max-tags-per-metric"`
+	MaxSeriesPerMetric uint32 `toml:"max-series-per-metric"`
+	// ingestion rate limit(rows/sec) with burst
+	MaxRowsPerSecond int `toml:"max-rows-per-second"`
+	MaxRowsBurst     int `toml:"max-rows-burst"`
+	// policy for field type change(error/per-segment/coerce)
+	FieldTypeEvolution string `toml:"field-type-evolution"`
 	// max series limit for metric
 	Metrics map[string]uint32 `toml:"metrics"`
 
@@ -59,6 +77,9 @@ func NewDefaultLimits() *Limits {
 		MaxTagValueLength:   1024,
 		MaxTagsPerMetric:    32,
 		MaxSeriesPerMetric:  200000,
+		MaxRowsPerSecond:    0,
+		MaxRowsBurst:        0,
+		FieldTypeEvolution:  FieldTypeEvolutionError,
 		Metrics:             make(map[string]uint32),
 		// Read limits
 		MaxSeriesPerQuery: 200000,
@@ -80,6 +101,11 @@ func (l *Limits) EnableMetricNameLengthCheck() bool {
 	return l.MaxMetricNameLength != 0
 }
 
+// EnableMetricNamePatternCheck returns if need check metric name's charset.
+func (l *Limits) EnableMetricNamePatternCheck() bool {
+	return l.MetricNamePattern != ""
+}
+
 // EnableMetricsCheck returns if need limit num. of metrics.
 func (l *Limits) EnableMetricsCheck() bool {
 	return l.MaxMetrics != 0
@@ -110,6 +136,29 @@ func (l *Limits) EnableTagsCheck() bool {
 	return l.MaxTagsPerMetric != 0
 }
 
+// EnableRowsRateLimit returns if need limit ingestion rate(rows/sec).
+func (l *Limits) EnableRowsRateLimit() bool {
+	return l.MaxRowsPerSecond > 0
+}
+
+// GetRowsBurst returns the burst of ingestion rate limit, uses rows/sec if burst not set.
+func (l *Limits) GetRowsBurst() int {
+	if l.MaxRowsBurst > 0 {
+		return l.MaxRowsBurst
+	}
+	return l.MaxRowsPerSecond
+}
+
+// EnableFieldTypeEvolution returns if accepts field type change(per-segment policy).
+func (l *Limits) EnableFieldTypeEvolution() bool {
+	return l.FieldTypeEvolution == FieldTypeEvolutionPerSegment
+}
+
+// EnableFieldTypeCoercion returns if coerces compatible field type into the type written first(coerce policy).
+func (l *Limits) EnableFieldTypeCoercion() bool {
+	return l.FieldTypeEvolution == FieldTypeEvolutionCoerce
+}
+
 // EnableSereisCheckForQuery returns if need check num. of series for query
 func (l *Limits) EnableSeriesCheckForQuery() bool {
 	return l.MaxSeriesPerQuery != 0
@@ -133,6 +182,10 @@ max-namespace-length = %d
 ## Maximum length accepted for metric name.
 ## Default: %d
 max-metric-name-length = %d
+## Regular expression of allowed charset for metric name, empty to accept any name.
+## Example: "^[a-zA-Z_][a-zA-Z0-9_.:-]*$"
+## Default: %q
+metric-name-pattern = %q
 ## Maximum number of active fields per metric.
 ## Default: %d
 max-fields-per-metric = %d
@@ -142,6 +195,12 @@ max-tags-per-metric = %d
 ## Maximum number of active series per metric.
 ## Default: %d
 max-series-per-metric = %d
+## Maximum number of rows per second accepted for ingestion(token bucket).
+## Default: %d
+max-rows-per-second = %d
+## Maximum burst of rows accepted for ingestion, uses max-rows-per-second if 0.
+## Default: %d
+max-rows-burst = %d
 ## Maximum length accepted for field name.
 ## Default: %d
 max-field-name-length = %d
@@ -151,6 +210,14 @@ max-tag-name-length = %d
 ## Maximum length accepted for tag value.
 ## Default: %d
 max-tag-value-length = %d
+## Policy for field type change(e.g. sum -> last), historical data uses the old type.
+## error: rejects writing field with different type.
+## per-segment: tracks field type by time range, queries spanning a type change
+## use the type per segment and merge with a function compatible with all types.
+## coerce: first writer wins, data written as compatible type(sum/histogram or min/max/last/first)
+## is coerced into the type written first, incompatible type is rejected.
+## Default: %s
+field-type-evolution = "%s"
 
 ## Maximum number of series for which a query can fetch.
 ## Default: %d
@@ -171,18 +238,26 @@ max-series-per-query = %d
 		l.MaxNamespaceLength,
 		l.MaxMetricNameLength,
 		l.MaxMetricNameLength,
+		"",
+		l.MetricNamePattern,
 		l.MaxFieldsPerMetric,
 		l.MaxFieldsPerMetric,
 		l.MaxTagsPerMetric,
 		l.MaxTagsPerMetric,
 		l.MaxSeriesPerMetric,
 		l.MaxSeriesPerMetric,
+		l.MaxRowsPerSecond,
+		l.MaxRowsPerSecond,
+		l.MaxRowsBurst,
+		l.MaxRowsBurst,
 		l.MaxFieldNameLength,
 		l.MaxFieldNameLength,
 		l.MaxTagNameLength,
 		l.MaxTagNameLength,
 		l.MaxTagValueLength,
 		l.MaxTagValueLength,
+		FieldTypeEvolutionError,
+		l.FieldTypeEvolution,
 		l.MaxSeriesPerQuery,
 		l.MaxSeriesPerQuery,
 		l.metricsTOML(),
@@ -32,6 +32,12 @@ func TestDefaultLimits(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, cfg, l)
 
+	l.MetricNamePattern = `^[a-zA-Z_][a-zA-Z0-9_.:\-]*$`
+	cfg = &Limits{}
+	_, err = toml.Decode(l.TOML(), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, l.MetricNamePattern, cfg.MetricNamePattern)
+
 	l.Metrics["system.cpu"] = 1000
 	assert.NotEqual(t, l.TOML(), NewDefaultLimits().TOML())
 }
@@ -59,6 +65,9 @@ func TestLimits_Disable(t *testing.T) {
 	assert.True(t, l.EnableMetricNameLengthCheck())
 	l.MaxMetricNameLength = 0
 	assert.False(t, l.EnableMetricNameLengthCheck())
+	assert.False(t, l.EnableMetricNamePatternCheck())
+	l.MetricNamePattern = "^[a-z_.]+$"
+	assert.True(t, l.EnableMetricNamePatternCheck())
 	assert.False(t, l.EnableMetricsCheck())
 	l.MaxMetrics = 10
 	assert.True(t, l.EnableMetricsCheck())
@@ -77,8 +86,22 @@ func TestLimits_Disable(t *testing.T) {
 	assert.True(t, l.EnableTagsCheck())
 	l.MaxTagsPerMetric = 0
 	assert.False(t, l.EnableTagsCheck())
+	assert.False(t, l.EnableFieldTypeEvolution())
+	assert.False(t, l.EnableFieldTypeCoercion())
+	l.FieldTypeEvolution = FieldTypeEvolutionPerSegment
+	assert.True(t, l.EnableFieldTypeEvolution())
+	l.FieldTypeEvolution = FieldTypeEvolutionCoerce
+	assert.True(t, l.EnableFieldTypeCoercion())
+	assert.False(t, l.EnableFieldTypeEvolution())
 
 	assert.True(t, l.EnableSeriesCheckForQuery())
 	l.MaxSeriesPerQuery = 0
 	assert.False(t, l.EnableSeriesCheckForQuery())
+
+	assert.False(t, l.EnableRowsRateLimit())
+	l.MaxRowsPerSecond = 100
+	assert.True(t, l.EnableRowsRateLimit())
+	assert.Equal(t, 100, l.GetRowsBurst())
+	l.MaxRowsBurst = 200
+	assert.Equal(t, 200, l.GetRowsBurst())
 }
@@ -83,6 +83,59 @@ type FamilyLogReplicaState struct {
 	Replicators []ReplicaPeerState `json:"replicators"`
 }
 
+// ReplicaLags returns the lag of each replicator, lag = appended sequence - acknowledged sequence.
+func (s *FamilyLogReplicaState) ReplicaLags() []ReplicaLag {
+	lags := make([]ReplicaLag, 0, len(s.Replicators))
+	for _, replicator := range s.Replicators {
+		lags = append(lags, ReplicaLag{
+			ShardID:    s.ShardID,
+			FamilyTime: s.FamilyTime,
+			Leader:     s.Leader,
+			Replicator: replicator.Replicator,
+			Append:     s.Append,
+			ACK:        replicator.ACK,
+			Lag:        s.Append - replicator.ACK,
+		})
+	}
+	return lags
+}
+
+// ReplicaLag represents how far behind the replicator is for the family's log.
+type ReplicaLag struct {
+	ShardID    ShardID `json:"shardId"`
+	FamilyTime string  `json:"familyTime"`
+	Leader     NodeID  `json:"leader"`
+	Replicator string  `json:"replicator"`
+	Append     int64   `json:"append"`
+	ACK        int64   `json:"ack"`
+	Lag        int64   `json:"lag"`
+}
+
+// QuarantineRecord represents the replica message which failed writing into local storage,
+// it is kept in quarantine with failure reason for reprocessing.
+type QuarantineRecord struct {
+	ID         string  `json:"id"`
+	Database   string  `json:"database"`
+	ShardID    ShardID `json:"shardId"`
+	FamilyTime int64   `json:"familyTime"`
+	Leader     NodeID  `json:"leader"`
+	Sequence   int64   `json:"sequence"`
+	Reason     string  `json:"reason"`
+	Timestamp  int64   `json:"timestamp"`
+	Attempts   int     `json:"attempts"`
+}
+
+// OtherMetricName represents the bucket which the write statistics of untracked metrics are folded into.
+const OtherMetricName = "__other__"
+
+// MetricWriteStats represents the write statistics of metric in broker write path.
+type MetricWriteStats struct {
+	MetricName    string `json:"metricName"`
+	AcceptedRows  int64  `json:"acceptedRows"`
+	AcceptedBytes int64  `json:"acceptedBytes"`
+	DroppedRows   int64  `json:"droppedRows"`
+}
+
 // ReplicaPeerState represents current wal replica peer state.
 type ReplicaPeerState struct {
 	Replicator     string          `json:"replicator"`
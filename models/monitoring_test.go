@@ -51,3 +51,13 @@ func TestReplicatorState_UnmarshalJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, ReplicatorUnknownState, rs)
 }
+
+func TestFamilyLogReplicaState_ReplicaLags(t *testing.T) {
+	state := &FamilyLogReplicaState{ShardID: 1, FamilyTime: "20221010", Leader: 1, Append: 100}
+	assert.Empty(t, state.ReplicaLags())
+	state.Replicators = []ReplicaPeerState{{Replicator: "2", ACK: 90}, {Replicator: "3", ACK: 100}}
+	assert.Equal(t, []ReplicaLag{
+		{ShardID: 1, FamilyTime: "20221010", Leader: 1, Replicator: "2", Append: 100, ACK: 90, Lag: 10},
+		{ShardID: 1, FamilyTime: "20221010", Leader: 1, Replicator: "3", Append: 100, ACK: 100, Lag: 0},
+	}, state.ReplicaLags())
+}
@@ -55,4 +55,5 @@ type Target struct {
 	ReceiveOnly bool      `json:"receiverOnly"`
 	Indicator   string    `json:"indicator"` // current node's indicator
 	ShardIDs    []ShardID `json:"shardIDs"`
+	Limit       int       `json:"limit,omitempty"` // max grouped series returned(top n by order by), 0 means no limit
 }
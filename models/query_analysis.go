@@ -0,0 +1,124 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	commonmodels "github.com/lindb/common/models"
+	"github.com/lindb/common/pkg/ltoml"
+)
+
+// FormatQueryStats formats the merged stats of query as a readable tree which matches the physical plan
+// hierarchy(root -> intermediate -> leaf), node shows total/wait cost and network payload,
+// stage/operator shows cost and num. of series.
+func FormatQueryStats(stats *commonmodels.NodeStats) string {
+	if stats == nil {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(nodeStatsTitle(stats))
+	sb.WriteString("\n")
+	writeNodeStatsChildren(&sb, "", stats)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// writeNodeStatsChildren writes the stages and child nodes of node stats, stages first.
+func writeNodeStatsChildren(sb *strings.Builder, prefix string, stats *commonmodels.NodeStats) {
+	total := len(stats.Stages) + len(stats.Children)
+	idx := 0
+	for _, stage := range stats.Stages {
+		idx++
+		writeStageStats(sb, prefix, idx == total, stage)
+	}
+	for _, child := range stats.Children {
+		idx++
+		childPrefix := writeTreeLine(sb, prefix, idx == total, nodeStatsTitle(child))
+		writeNodeStatsChildren(sb, childPrefix, child)
+	}
+}
+
+// writeStageStats writes the stage stats with its operators and child stages.
+func writeStageStats(sb *strings.Builder, prefix string, last bool, stage *commonmodels.StageStats) {
+	title := fmt.Sprintf("Stage(%s) [cost: %s", stage.Identifier, time.Duration(stage.Cost))
+	if stage.ErrMsg != "" {
+		title += ", error: " + stage.ErrMsg
+	}
+	childPrefix := writeTreeLine(sb, prefix, last, title+"]")
+	total := len(stage.Operators) + len(stage.Children)
+	idx := 0
+	for _, op := range stage.Operators {
+		idx++
+		opTitle := fmt.Sprintf("Operator(%s) [cost: %s", op.Identifier, time.Duration(op.Cost))
+		if series, ok := numOfSeries(op.Stats); ok {
+			opTitle += fmt.Sprintf(", series: %d", series)
+		}
+		if op.ErrMsg != "" {
+			opTitle += ", error: " + op.ErrMsg
+		}
+		writeTreeLine(sb, childPrefix, idx == total, opTitle+"]")
+	}
+	for _, child := range stage.Children {
+		idx++
+		writeStageStats(sb, childPrefix, idx == total, child)
+	}
+}
+
+// writeTreeLine writes a line of tree, returns the prefix for children of this line.
+func writeTreeLine(sb *strings.Builder, prefix string, last bool, line string) string {
+	sb.WriteString(prefix)
+	if last {
+		sb.WriteString("└─ ")
+	} else {
+		sb.WriteString("├─ ")
+	}
+	sb.WriteString(line)
+	sb.WriteString("\n")
+	if last {
+		return prefix + "   "
+	}
+	return prefix + "│  "
+}
+
+// nodeStatsTitle returns the title of node stats, like: node [total: 10ms, wait: 5ms, network: 1.2 KiB].
+func nodeStatsTitle(stats *commonmodels.NodeStats) string {
+	costs := []string{fmt.Sprintf("total: %s", time.Duration(stats.TotalCost))}
+	if stats.WaitStart > 0 {
+		costs = append(costs, fmt.Sprintf("wait: %s", time.Duration(stats.WaitCost)))
+	}
+	if stats.NetPayload > 0 {
+		costs = append(costs, fmt.Sprintf("network: %s", ltoml.Size(stats.NetPayload)))
+	}
+	return fmt.Sprintf("%s [%s]", stats.Node, strings.Join(costs, ", "))
+}
+
+// numOfSeries returns the num. of series from operator stats,
+// stats of remote node is decoded from json as map.
+func numOfSeries(stats interface{}) (uint64, bool) {
+	switch s := stats.(type) {
+	case *commonmodels.SeriesStats:
+		return s.NumOfSeries, true
+	case map[string]interface{}:
+		if v, ok := s["numOfSeries"].(float64); ok {
+			return uint64(v), true
+		}
+	}
+	return 0, false
+}
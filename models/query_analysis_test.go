@@ -0,0 +1,129 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	commonmodels "github.com/lindb/common/models"
+)
+
+func TestFormatQueryStats(t *testing.T) {
+	assert.Empty(t, FormatQueryStats(nil))
+
+	leaf := &commonmodels.NodeStats{
+		Node:       "leaf-1",
+		TotalCost:  (5 * time.Millisecond).Nanoseconds(),
+		NetPayload: 2048,
+		Stages: []*commonmodels.StageStats{{
+			Identifier: "Shard(1)",
+			Cost:       (3 * time.Millisecond).Nanoseconds(),
+			Operators: []*commonmodels.OperatorStats{
+				{Identifier: "Series Filtering", Cost: time.Millisecond.Nanoseconds()},
+				{Identifier: "Data Load", Cost: (2 * time.Millisecond).Nanoseconds(), ErrMsg: "err"},
+			},
+			Children: []*commonmodels.StageStats{{Identifier: "Grouping", ErrMsg: "timeout"}},
+		}},
+	}
+	// stats of remote node decoded from json
+	data, _ := json.Marshal(&commonmodels.OperatorStats{
+		Identifier: "Data Load",
+		Stats:      &commonmodels.SeriesStats{NumOfSeries: 10},
+	})
+	op := &commonmodels.OperatorStats{}
+	assert.NoError(t, json.Unmarshal(data, op))
+	leaf.Stages[0].Operators[0].Stats = op.Stats
+
+	root := &commonmodels.NodeStats{
+		Node:      "root",
+		TotalCost: (10 * time.Millisecond).Nanoseconds(),
+		WaitStart: 1,
+		WaitCost:  (6 * time.Millisecond).Nanoseconds(),
+		Stages:    []*commonmodels.StageStats{{Identifier: "Expression", Cost: time.Millisecond.Nanoseconds()}},
+		Children: []*commonmodels.NodeStats{
+			{
+				Node:      "intermediate",
+				TotalCost: (8 * time.Millisecond).Nanoseconds(),
+				Children:  []*commonmodels.NodeStats{leaf, {Node: "leaf-2", TotalCost: time.Millisecond.Nanoseconds()}},
+			},
+		},
+	}
+	assert.Equal(t, `root [total: 10ms, wait: 6ms]
+├─ Stage(Expression) [cost: 1ms]
+└─ intermediate [total: 8ms]
+   ├─ leaf-1 [total: 5ms, network: 2.0 KiB]
+   │  └─ Stage(Shard(1)) [cost: 3ms]
+   │     ├─ Operator(Series Filtering) [cost: 1ms, series: 10]
+   │     ├─ Operator(Data Load) [cost: 2ms, error: err]
+   │     └─ Stage(Grouping) [cost: 0s, error: timeout]
+   └─ leaf-2 [total: 1ms]`, FormatQueryStats(root))
+}
+
+func TestNumOfSeries(t *testing.T) {
+	n, ok := numOfSeries(&commonmodels.SeriesStats{NumOfSeries: 5})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), n)
+	n, ok = numOfSeries(map[string]interface{}{"numOfSeries": float64(6)})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(6), n)
+	_, ok = numOfSeries(map[string]interface{}{"tagKeys": []string{"a"}})
+	assert.False(t, ok)
+	_, ok = numOfSeries(&GroupByStats{})
+	assert.False(t, ok)
+	_, ok = numOfSeries(nil)
+	assert.False(t, ok)
+}
+
+func TestResultSet_ToTable(t *testing.T) {
+	rows, str := (&ResultSet{}).ToTable()
+	assert.Zero(t, rows)
+	assert.Empty(t, str)
+	rows, str = (&ResultSet{Analysis: "root [total: 1ms]"}).ToTable()
+	assert.Zero(t, rows)
+	assert.Equal(t, "root [total: 1ms]", str)
+
+	rs := &ResultSet{ResultSet: &commonmodels.ResultSet{
+		Fields: []string{"f"},
+		Stats:  &commonmodels.NodeStats{Node: "root"},
+	}}
+	series := commonmodels.NewSeries(nil, "")
+	points := commonmodels.NewPoints()
+	points.AddPoint(1000, 1)
+	series.AddField("f", points)
+	rs.AddSeries(series)
+	// explain query returns stats table
+	_, str = rs.ToTable()
+	assert.Contains(t, str, "Query Plan")
+	// explain analyze query returns series with stats tree
+	rs.Analysis = "root [total: 1ms]"
+	rows, str = rs.ToTable()
+	assert.Equal(t, 1, rows)
+	assert.NotContains(t, str, "Query Plan")
+	assert.Contains(t, str, "timestamp")
+	assert.Contains(t, str, "\nroot [total: 1ms]")
+	assert.NotNil(t, rs.Stats)
+	// no series
+	rs.Series = nil
+	rows, str = rs.ToTable()
+	assert.Zero(t, rows)
+	assert.Equal(t, "root [total: 1ms]", str)
+}
@@ -17,7 +17,233 @@
 
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	commonmodels "github.com/lindb/common/models"
+
+	"github.com/lindb/lindb/pkg/sketch"
+)
+
 // SuggestResult represents the suggest result set
 type SuggestResult struct {
-	Values []string `json:"values"`
+	Values   []string         `json:"values"`
+	Distinct *sketch.Distinct `json:"distinct,omitempty"` // distinct count sketch if statement requires distinct
+	// Cardinality represents distinct counter of tag values under each tag key for tag cardinality.
+	Cardinality map[string]*sketch.Distinct `json:"cardinality,omitempty"`
+}
+
+// TagCardinality represents the distinct count of tag values under tag key.
+type TagCardinality struct {
+	TagKey string `json:"tagKey"`
+	Count  uint64 `json:"count"`
+	// Exact represents if count is exact, otherwise estimated by hyperloglog sketch.
+	Exact         bool    `json:"exact"`
+	RelativeError float64 `json:"relativeError,omitempty"` // standard error of estimated count
+}
+
+// Field represents the field metadata of metric, which is merged from all storage nodes.
+type Field struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Functions []string `json:"functions,omitempty"` // supported functions of field
+	Histogram bool     `json:"histogram"`
+	// Conflict represents if field has different types on storage nodes, all types are returned in Types.
+	Conflict bool     `json:"conflict"`
+	Types    []string `json:"types,omitempty"`
+}
+
+// GroupByStats represents the stats of group by * expanding.
+type GroupByStats struct {
+	TagKeys []string `json:"tagKeys"`
+	Warning string   `json:"warning,omitempty"`
+}
+
+// ResultSet represents the metric data result set with the metadata of query.
+type ResultSet struct {
+	*commonmodels.ResultSet
+	Coverage *ShardCoverage `json:"coverage,omitempty"`
+	// PhysicalPlans represents the physical plans of query, only returned when explain query.
+	PhysicalPlans []*PhysicalPlan `json:"physicalPlans,omitempty"`
+	// Analysis represents the readable execute stats tree of query, only returned when explain analyze query.
+	Analysis string `json:"analysis,omitempty"`
+}
+
+// ToTable returns the result set as table, the execute stats tree of explain analyze query is appended after series.
+func (rs *ResultSet) ToTable() (rows int, tableStr string) {
+	if rs.Analysis == "" {
+		if rs.ResultSet == nil {
+			return 0, ""
+		}
+		return rs.ResultSet.ToTable()
+	}
+	if rs.ResultSet != nil {
+		series := *rs.ResultSet
+		series.Stats = nil
+		rows, tableStr = series.ToTable()
+	}
+	if tableStr == "" {
+		return rows, rs.Analysis
+	}
+	return rows, tableStr + "\n" + rs.Analysis
+}
+
+// MarshalJSON returns json data of result set, NaN/Inf point value is encoded as null(json not supports them),
+// so that the computed field(e.g. division by zero) not fails the whole response.
+func (rs *ResultSet) MarshalJSON() ([]byte, error) {
+	type resultSet ResultSet // without MarshalJSON method, avoid recursion
+	if rs.ResultSet == nil || !hasNonFiniteValue(rs.Series) {
+		return json.Marshal((*resultSet)(rs))
+	}
+	series := make([]*jsonSeries, len(rs.Series))
+	for idx, s := range rs.Series {
+		series[idx] = newJSONSeries(s)
+	}
+	// series of outer struct overrides series of embedded result set
+	return json.Marshal(&struct {
+		*resultSet
+		Series []*jsonSeries `json:"series,omitempty"`
+	}{
+		resultSet: (*resultSet)(rs),
+		Series:    series,
+	})
+}
+
+// jsonSeries represents the series for json encoding, which point value maybe null.
+type jsonSeries struct {
+	Tags   map[string]string              `json:"tags,omitempty"`
+	Fields map[string]map[int64]jsonFloat `json:"fields,omitempty"`
+}
+
+// newJSONSeries creates the series for json encoding.
+func newJSONSeries(series *commonmodels.Series) *jsonSeries {
+	if series == nil {
+		return nil
+	}
+	rs := &jsonSeries{Tags: series.Tags}
+	if series.Fields != nil {
+		rs.Fields = make(map[string]map[int64]jsonFloat, len(series.Fields))
+		for fieldName, points := range series.Fields {
+			if points == nil {
+				rs.Fields[fieldName] = nil
+				continue
+			}
+			values := make(map[int64]jsonFloat, len(points))
+			for timestamp, value := range points {
+				values[timestamp] = jsonFloat(value)
+			}
+			rs.Fields[fieldName] = values
+		}
+	}
+	return rs
+}
+
+// jsonFloat represents the point value for json encoding, NaN/Inf is encoded as null.
+type jsonFloat float64
+
+// MarshalJSON returns json data of point value.
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v)
+}
+
+// hasNonFiniteValue checks if any point value of series is NaN/Inf.
+func hasNonFiniteValue(seriesList []*commonmodels.Series) bool {
+	for _, series := range seriesList {
+		if series == nil {
+			continue
+		}
+		for _, points := range series.Fields {
+			for _, value := range points {
+				if math.IsNaN(value) || math.IsInf(value, 0) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ShardCoverage represents the effective shard coverage of query,
+// includes which shards queried and the outcome of each shard, so partial results are interpretable.
+type ShardCoverage struct {
+	Queried   []ShardID `json:"queried"`
+	Responded []ShardID `json:"responded"`
+	TimedOut  []ShardID `json:"timedOut,omitempty"`
+	NotFound  []ShardID `json:"notFound,omitempty"`
+	Failed    []ShardID `json:"failed,omitempty"`
+}
+
+// IsPartial returns if some shards queried but not responded.
+func (c *ShardCoverage) IsPartial() bool {
+	return len(c.TimedOut) > 0 || len(c.Failed) > 0
+}
+
+// Merge merges other shard coverage into current coverage.
+func (c *ShardCoverage) Merge(other *ShardCoverage) {
+	if other == nil {
+		return
+	}
+	c.Queried = append(c.Queried, other.Queried...)
+	c.Responded = append(c.Responded, other.Responded...)
+	c.TimedOut = append(c.TimedOut, other.TimedOut...)
+	c.NotFound = append(c.NotFound, other.NotFound...)
+	c.Failed = append(c.Failed, other.Failed...)
+}
+
+// NotFoundPolicy represents how data query handles the not found error returned by target nodes.
+type NotFoundPolicy string
+
+const (
+	// NotFoundPolicyAll fails query only if all target nodes return not found(default).
+	NotFoundPolicyAll NotFoundPolicy = "all"
+	// NotFoundPolicyStrict fails query if any shard returns not found.
+	NotFoundPolicyStrict NotFoundPolicy = "strict"
+	// NotFoundPolicyLenient never fails query because of not found, returns partial(maybe empty) result set.
+	NotFoundPolicyLenient NotFoundPolicy = "lenient"
+)
+
+// ParseNotFoundPolicy parses the not found policy, returns NotFoundPolicyAll if policy is empty.
+func ParseNotFoundPolicy(policy string) (NotFoundPolicy, error) {
+	switch p := NotFoundPolicy(strings.ToLower(strings.TrimSpace(policy))); p {
+	case "":
+		return NotFoundPolicyAll, nil
+	case NotFoundPolicyAll, NotFoundPolicyStrict, NotFoundPolicyLenient:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown not found policy: %s, only support all/strict/lenient", policy)
+	}
+}
+
+// Normalize removes the duplicate shards and sorts shards in each state.
+func (c *ShardCoverage) Normalize() {
+	c.Queried = dedupShardIDs(c.Queried)
+	c.Responded = dedupShardIDs(c.Responded)
+	c.TimedOut = dedupShardIDs(c.TimedOut)
+	c.NotFound = dedupShardIDs(c.NotFound)
+	c.Failed = dedupShardIDs(c.Failed)
+}
+
+// dedupShardIDs removes the duplicate shard ids, then sorts them.
+func dedupShardIDs(shardIDs []ShardID) []ShardID {
+	if len(shardIDs) == 0 {
+		return shardIDs
+	}
+	sort.Slice(shardIDs, func(i, j int) bool {
+		return shardIDs[i] < shardIDs[j]
+	})
+	rs := shardIDs[:1]
+	for _, shardID := range shardIDs[1:] {
+		if shardID != rs[len(rs)-1] {
+			rs = append(rs, shardID)
+		}
+	}
+	return rs
 }
@@ -0,0 +1,286 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+
+	commonmodels "github.com/lindb/common/models"
+	"github.com/lindb/common/pkg/encoding"
+
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+// ColumnarContentType represents the content type of columnar result set,
+// client selects it via Accept header, json is returned by default.
+const ColumnarContentType = "application/x-lindb-columnar"
+
+// columnarVersion represents the version of columnar result set format.
+const columnarVersion byte = 1
+
+// ErrInvalidColumnarResultSet represents the columnar result set data is invalid.
+var ErrInvalidColumnarResultSet = errors.New("invalid columnar result set")
+
+// columnarMeta represents the metadata of result set which is not encoded in columnar format.
+type columnarMeta struct {
+	Stats         *commonmodels.NodeStats `json:"stats,omitempty"`
+	Coverage      *ShardCoverage          `json:"coverage,omitempty"`
+	PhysicalPlans []*PhysicalPlan         `json:"physicalPlans,omitempty"`
+	Analysis      string                  `json:"analysis,omitempty"`
+}
+
+// MarshalColumnar encodes the result set as columnar format:
+// version|metric name|group by|fields|start|end|interval|series...|metadata(json),
+// for each field of series, timestamps are delta-encoded, values are encoded as float64 array.
+func (rs *ResultSet) MarshalColumnar() []byte {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutByte(columnarVersion)
+	resultSet := rs.ResultSet
+	if resultSet == nil {
+		resultSet = commonmodels.NewResultSet()
+	}
+	putString(writer, resultSet.MetricName)
+	putStrings(writer, resultSet.GroupBy)
+	putStrings(writer, resultSet.Fields)
+	writer.PutVarint64(resultSet.StartTime)
+	writer.PutVarint64(resultSet.EndTime)
+	writer.PutVarint64(resultSet.Interval)
+	writer.PutUvarint64(uint64(len(resultSet.Series)))
+	for _, series := range resultSet.Series {
+		tagKeys := sortedKeys(series.Tags)
+		putStrings(writer, tagKeys)
+		for _, tagKey := range tagKeys {
+			putString(writer, series.Tags[tagKey])
+		}
+		fieldNames := make([]string, 0, len(series.Fields))
+		for fieldName := range series.Fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		writer.PutUvarint64(uint64(len(fieldNames)))
+		for _, fieldName := range fieldNames {
+			putString(writer, fieldName)
+			putPoints(writer, series.Fields[fieldName])
+		}
+	}
+	putBytes(writer, encoding.JSONMarshal(&columnarMeta{
+		Stats:         resultSet.Stats,
+		Coverage:      rs.Coverage,
+		PhysicalPlans: rs.PhysicalPlans,
+		Analysis:      rs.Analysis,
+	}))
+	data, _ := writer.Bytes()
+	return data
+}
+
+// UnmarshalColumnar decodes the result set from columnar format.
+func (rs *ResultSet) UnmarshalColumnar(data []byte) error {
+	decoder := &columnarDecoder{reader: stream.NewReader(data)}
+	if version := decoder.readByte(); version != columnarVersion {
+		return ErrInvalidColumnarResultSet
+	}
+	resultSet := commonmodels.NewResultSet()
+	resultSet.MetricName = decoder.readString()
+	resultSet.GroupBy = decoder.readStrings()
+	resultSet.Fields = decoder.readStrings()
+	resultSet.StartTime = decoder.readVarint()
+	resultSet.EndTime = decoder.readVarint()
+	resultSet.Interval = decoder.readVarint()
+	numOfSeries := decoder.readLen()
+	for i := 0; i < numOfSeries && decoder.err == nil; i++ {
+		var tags map[string]string
+		tagKeys := decoder.readStrings()
+		if len(tagKeys) > 0 {
+			tags = make(map[string]string, len(tagKeys))
+			for _, tagKey := range tagKeys {
+				tags[tagKey] = decoder.readString()
+			}
+		}
+		series := commonmodels.NewSeries(tags, "")
+		numOfFields := decoder.readLen()
+		for j := 0; j < numOfFields && decoder.err == nil; j++ {
+			fieldName := decoder.readString()
+			series.Fields[fieldName] = decoder.readPoints()
+		}
+		resultSet.AddSeries(series)
+	}
+	var meta columnarMeta
+	metaData := decoder.readBytes()
+	if decoder.err != nil {
+		return decoder.err
+	}
+	if err := encoding.JSONUnmarshal(metaData, &meta); err != nil {
+		return err
+	}
+	resultSet.Stats = meta.Stats
+	rs.ResultSet = resultSet
+	rs.Coverage = meta.Coverage
+	rs.PhysicalPlans = meta.PhysicalPlans
+	rs.Analysis = meta.Analysis
+	return nil
+}
+
+// sortedKeys returns the sorted keys of tags.
+func sortedKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// putBytes writes length-prefixed bytes.
+func putBytes(writer *stream.BufferWriter, value []byte) {
+	writer.PutUvarint64(uint64(len(value)))
+	writer.PutBytes(value)
+}
+
+// putString writes length-prefixed string.
+func putString(writer *stream.BufferWriter, value string) {
+	putBytes(writer, []byte(value))
+}
+
+// putStrings writes string list.
+func putStrings(writer *stream.BufferWriter, values []string) {
+	writer.PutUvarint64(uint64(len(values)))
+	for _, value := range values {
+		putString(writer, value)
+	}
+}
+
+// putPoints writes the points of field, timestamps in ascending order with delta-encoding,
+// then values as float64 array.
+func putPoints(writer *stream.BufferWriter, points map[int64]float64) {
+	timestamps := make([]int64, 0, len(points))
+	for timestamp := range points {
+		timestamps = append(timestamps, timestamp)
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+	writer.PutUvarint64(uint64(len(timestamps)))
+	prev := int64(0)
+	for _, timestamp := range timestamps {
+		writer.PutVarint64(timestamp - prev)
+		prev = timestamp
+	}
+	for _, timestamp := range timestamps {
+		writer.PutUint64(math.Float64bits(points[timestamp]))
+	}
+}
+
+// columnarDecoder decodes columnar result set, stops reading after first error.
+type columnarDecoder struct {
+	reader *stream.Reader
+	err    error
+}
+
+// check records the read error, returns if reading is ok.
+func (d *columnarDecoder) check() bool {
+	if d.err == nil && d.reader.Error() != nil {
+		d.err = ErrInvalidColumnarResultSet
+	}
+	return d.err == nil
+}
+
+func (d *columnarDecoder) readByte() byte {
+	if d.err != nil {
+		return 0
+	}
+	b := d.reader.ReadByte()
+	d.check()
+	return b
+}
+
+func (d *columnarDecoder) readVarint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v := d.reader.ReadVarint64()
+	d.check()
+	return v
+}
+
+// readLen reads a length, which must be not greater than the unread data size.
+func (d *columnarDecoder) readLen() int {
+	if d.err != nil {
+		return 0
+	}
+	v := d.reader.ReadUvarint64()
+	if !d.check() {
+		return 0
+	}
+	if v > uint64(len(d.reader.UnreadSlice())) {
+		d.err = ErrInvalidColumnarResultSet
+		return 0
+	}
+	return int(v)
+}
+
+func (d *columnarDecoder) readBytes() []byte {
+	n := d.readLen()
+	if d.err != nil {
+		return nil
+	}
+	value := d.reader.ReadSlice(n)
+	d.check()
+	return value
+}
+
+func (d *columnarDecoder) readString() string {
+	return string(d.readBytes())
+}
+
+func (d *columnarDecoder) readStrings() []string {
+	n := d.readLen()
+	if n == 0 {
+		return nil
+	}
+	values := make([]string, n)
+	for i := range values {
+		values[i] = d.readString()
+	}
+	return values
+}
+
+func (d *columnarDecoder) readPoints() map[int64]float64 {
+	n := d.readLen()
+	if d.err != nil {
+		return nil
+	}
+	points := make(map[int64]float64, n)
+	timestamps := make([]int64, n)
+	prev := int64(0)
+	for i := range timestamps {
+		prev += d.readVarint()
+		timestamps[i] = prev
+	}
+	values := d.reader.ReadSlice(n * 8)
+	if !d.check() || len(values) != n*8 {
+		d.err = ErrInvalidColumnarResultSet
+		return nil
+	}
+	for i, timestamp := range timestamps {
+		points[timestamp] = math.Float64frombits(binary.LittleEndian.Uint64(values[i*8:]))
+	}
+	return points
+}
@@ -0,0 +1,72 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"encoding/json"
+
+	commonmodels "github.com/lindb/common/models"
+)
+
+// NDJSONContentType represents the content type of streaming result set(one json object per line),
+// client selects it via Accept header, series are streamed once made so that large result set not buffered.
+const NDJSONContentType = "application/x-ndjson"
+
+// resultSetLine represents one line of ndjson result set, only one of fields is set:
+// 1. series line for each series;
+// 2. result set line(without series) after all series streamed;
+// 3. error line if query failed after streaming started(http status has been sent).
+type resultSetLine struct {
+	Series    *jsonSeries `json:"series,omitempty"`
+	ResultSet *ResultSet  `json:"resultSet,omitempty"`
+	Error     *Error      `json:"error,omitempty"`
+}
+
+// MarshalSeriesLine returns the ndjson line of series, NaN/Inf point value is encoded as null.
+func MarshalSeriesLine(series *commonmodels.Series) ([]byte, error) {
+	return marshalLine(&resultSetLine{Series: newJSONSeries(series)})
+}
+
+// MarshalResultSetLine returns the ndjson line of result set, series of result set is not included.
+func MarshalResultSetLine(rs *ResultSet) ([]byte, error) {
+	line := &resultSetLine{}
+	if rs != nil {
+		meta := *rs
+		if rs.ResultSet != nil {
+			resultSet := *rs.ResultSet
+			resultSet.Series = nil
+			meta.ResultSet = &resultSet
+		}
+		line.ResultSet = &meta
+	}
+	return marshalLine(line)
+}
+
+// MarshalErrorLine returns the ndjson line of error.
+func MarshalErrorLine(err error) ([]byte, error) {
+	return marshalLine(&resultSetLine{Error: ToError(err)})
+}
+
+// marshalLine returns json data of line with line separator.
+func marshalLine(line *resultSetLine) ([]byte, error) {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,61 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonmodels "github.com/lindb/common/models"
+)
+
+func TestMarshalSeriesLine(t *testing.T) {
+	series := commonmodels.NewSeries(map[string]string{"host": "a"}, "a")
+	points := commonmodels.NewPoints()
+	points.AddPoint(10, 1)
+	points.AddPoint(20, math.NaN())
+	series.AddField("f", points)
+	data, err := MarshalSeriesLine(series)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"series":{"tags":{"host":"a"},"fields":{"f":{"10":1,"20":null}}}}`+"\n", string(data))
+}
+
+func TestMarshalResultSetLine(t *testing.T) {
+	resultSet := commonmodels.NewResultSet()
+	resultSet.MetricName = "cpu"
+	resultSet.AddSeries(commonmodels.NewSeries(nil, "a"))
+	rs := &ResultSet{ResultSet: resultSet, Coverage: &ShardCoverage{NotFound: []ShardID{1}}}
+	data, err := MarshalResultSetLine(rs)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"resultSet":{"metricName":"cpu","coverage":{"queried":null,"responded":null,"notFound":[1]}}}`+"\n", string(data))
+	// series of result set not changed
+	assert.Len(t, rs.Series, 1)
+
+	data, err = MarshalResultSetLine(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "{}\n", string(data))
+}
+
+func TestMarshalErrorLine(t *testing.T) {
+	data, err := MarshalErrorLine(errors.New("err"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"error":{"code":"Internal","message":"err"}}`+"\n", string(data))
+}
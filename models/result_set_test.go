@@ -0,0 +1,167 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonmodels "github.com/lindb/common/models"
+	"github.com/lindb/common/pkg/encoding"
+)
+
+func TestParseNotFoundPolicy(t *testing.T) {
+	cases := map[string]NotFoundPolicy{
+		"":         NotFoundPolicyAll,
+		"all":      NotFoundPolicyAll,
+		" Strict ": NotFoundPolicyStrict,
+		"lenient":  NotFoundPolicyLenient,
+	}
+	for policy, expect := range cases {
+		p, err := ParseNotFoundPolicy(policy)
+		assert.NoError(t, err, policy)
+		assert.Equal(t, expect, p, policy)
+	}
+	_, err := ParseNotFoundPolicy("partial")
+	assert.Error(t, err)
+}
+
+func TestShardCoverage(t *testing.T) {
+	coverage := &ShardCoverage{
+		Queried:   []ShardID{3, 1, 2},
+		Responded: []ShardID{1},
+	}
+	assert.False(t, coverage.IsPartial())
+	coverage.Merge(nil)
+	coverage.Merge(&ShardCoverage{
+		Queried:   []ShardID{3, 4},
+		Responded: []ShardID{3, 1},
+		NotFound:  []ShardID{4},
+		TimedOut:  []ShardID{2},
+	})
+	coverage.Normalize()
+	assert.Equal(t, &ShardCoverage{
+		Queried:   []ShardID{1, 2, 3, 4},
+		Responded: []ShardID{1, 3},
+		NotFound:  []ShardID{4},
+		TimedOut:  []ShardID{2},
+	}, coverage)
+	assert.True(t, coverage.IsPartial())
+	assert.True(t, (&ShardCoverage{Failed: []ShardID{1}}).IsPartial())
+}
+
+func TestResultSet_MarshalJSON(t *testing.T) {
+	rs := &ResultSet{
+		ResultSet: &commonmodels.ResultSet{MetricName: "cpu"},
+		Coverage:  &ShardCoverage{Queried: []ShardID{1}, Responded: []ShardID{1}},
+	}
+	assert.Equal(t, `{"metricName":"cpu","coverage":{"queried":[1],"responded":[1]}}`, string(encoding.JSONMarshal(rs)))
+}
+
+func TestResultSet_MarshalJSON_NonFinite(t *testing.T) {
+	zero := 0.0
+	rs := &ResultSet{ResultSet: &commonmodels.ResultSet{MetricName: "cpu"}}
+	series := commonmodels.NewSeries(map[string]string{"host": "a"}, "a")
+	points := commonmodels.NewPoints()
+	points.AddPoint(10, 1.5)
+	points.AddPoint(20, zero/zero) // division by zero
+	points.AddPoint(30, math.Inf(1))
+	points.AddPoint(40, math.Inf(-1))
+	series.AddField("ratio", points)
+	rs.AddSeries(series)
+
+	data, err := json.Marshal(rs)
+	assert.NoError(t, err)
+	assert.True(t, json.Valid(data))
+	assert.Equal(t, `{"metricName":"cpu",`+
+		`"series":[{"tags":{"host":"a"},"fields":{"ratio":{"10":1.5,"20":null,"30":null,"40":null}}}]}`, string(data))
+	// same as json encoding of common package
+	assert.Equal(t, string(data), string(encoding.JSONMarshal(rs)))
+
+	// decode null as zero value
+	decoded := &commonmodels.ResultSet{}
+	assert.NoError(t, encoding.JSONUnmarshal(data, decoded))
+	assert.Equal(t, 1.5, decoded.Series[0].Fields["ratio"][10])
+
+	// finite values encoded as is
+	points.AddPoint(20, 2)
+	points.AddPoint(30, 1e21)
+	points.AddPoint(40, -0.000001)
+	data, err = json.Marshal(rs)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"metricName":"cpu",`+
+		`"series":[{"tags":{"host":"a"},"fields":{"ratio":{"10":1.5,"20":2,"30":1e+21,"40":-0.000001}}}]}`, string(data))
+}
+
+func TestResultSet_Columnar(t *testing.T) {
+	newResultSet := func() *ResultSet {
+		rs := &ResultSet{
+			ResultSet: &commonmodels.ResultSet{
+				MetricName: "cpu",
+				GroupBy:    []string{"host", "region"},
+				Fields:     []string{"f1", "f2"},
+				StartTime:  1000,
+				EndTime:    100000,
+				Interval:   10000,
+			},
+			Coverage:      &ShardCoverage{Queried: []ShardID{1, 2}, Responded: []ShardID{1}, TimedOut: []ShardID{2}},
+			PhysicalPlans: []*PhysicalPlan{{Database: "db", Receivers: []string{"node"}}},
+			Analysis:      "node [total: 1ms]",
+		}
+		for i := 0; i < 3; i++ {
+			series := commonmodels.NewSeries(map[string]string{"host": "h" + string(rune('a'+i)), "region": "sh"}, "")
+			points := commonmodels.NewPoints()
+			for j := 0; j < 10; j++ {
+				points.AddPoint(int64(1000+j*10000), float64(i*j)+0.5)
+			}
+			series.AddField("f1", points)
+			points = commonmodels.NewPoints()
+			points.AddPoint(-10, -1.25)
+			series.AddField("f2", points)
+			rs.AddSeries(series)
+		}
+		return rs
+	}
+	rs := newResultSet()
+	// compare with json path
+	jsonRS := &ResultSet{}
+	assert.NoError(t, encoding.JSONUnmarshal(encoding.JSONMarshal(rs), jsonRS))
+	columnarRS := &ResultSet{}
+	data := rs.MarshalColumnar()
+	assert.NoError(t, columnarRS.UnmarshalColumnar(data))
+	assert.Equal(t, jsonRS, columnarRS)
+	assert.True(t, len(data) < len(encoding.JSONMarshal(rs)))
+
+	// empty result set
+	columnarRS = &ResultSet{}
+	assert.NoError(t, columnarRS.UnmarshalColumnar((&ResultSet{}).MarshalColumnar()))
+	assert.Equal(t, &ResultSet{ResultSet: commonmodels.NewResultSet()}, columnarRS)
+
+	// invalid data
+	assert.Equal(t, ErrInvalidColumnarResultSet, columnarRS.UnmarshalColumnar(nil))
+	assert.Equal(t, ErrInvalidColumnarResultSet, columnarRS.UnmarshalColumnar([]byte{2}))
+	for i := 1; i < len(data)-1; i++ {
+		assert.Error(t, columnarRS.UnmarshalColumnar(data[:i]))
+	}
+	invalidMeta := (&ResultSet{}).MarshalColumnar()
+	invalidMeta[len(invalidMeta)-1] = 'x'
+	assert.Error(t, columnarRS.UnmarshalColumnar(invalidMeta))
+}
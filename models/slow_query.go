@@ -0,0 +1,30 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+// SlowQuery represents the query which total cost exceeds slow query threshold.
+type SlowQuery struct {
+	Entry      string `json:"entry"`
+	RequestID  string `json:"requestId"`
+	DB         string `json:"db"`
+	SQL        string `json:"sql"`
+	Start      int64  `json:"start"`
+	Duration   int64  `json:"duration"`   // total cost(ns)
+	FanOut     int    `json:"fanOut"`     // number of target nodes which query task sent to
+	NetPayload int64  `json:"netPayload"` // payload of responses received from target nodes
+}
@@ -0,0 +1,40 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lindb/lindb/models"
+)
+
+// ErrorWithCode responses error with code, http status code is mapped from error code,
+// response body is stable json: {"code":"NotFound","message":"..."}.
+func ErrorWithCode(c *gin.Context, err error) {
+	_ = c.Error(err)
+	e := models.ToError(err)
+	c.JSON(e.Code.HTTPStatus(), e)
+}
+
+// BadRequest responses error of invalid request param with 400 status code.
+func BadRequest(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.JSON(http.StatusBadRequest, err.Error())
+}
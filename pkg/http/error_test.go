@@ -0,0 +1,81 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/pkg/encoding"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+)
+
+func TestErrorWithCode(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		body   models.Error
+	}{
+		{
+			err:    errors.New("err"),
+			status: http.StatusInternalServerError,
+			body:   models.Error{Code: models.ErrCodeInternal, Message: "err"},
+		},
+		{
+			err:    constants.ErrDatabaseNotFound,
+			status: http.StatusNotFound,
+			body:   models.Error{Code: models.ErrCodeNotFound, Message: "database not found"},
+		},
+		{
+			err:    constants.ErrTimeout,
+			status: http.StatusGatewayTimeout,
+			body:   models.Error{Code: models.ErrCodeTimeout, Message: "exceed timeout"},
+		},
+		{
+			err:    fmt.Errorf("query: %w", models.NewError(models.ErrCodePartialFailure, "leaf failure")),
+			status: http.StatusBadGateway,
+			body:   models.Error{Code: models.ErrCodePartialFailure, Message: "leaf failure"},
+		},
+	}
+	for _, tt := range cases {
+		resp := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(resp)
+		ErrorWithCode(c, tt.err)
+		assert.Equal(t, tt.status, resp.Code)
+		body := models.Error{}
+		assert.NoError(t, encoding.JSONUnmarshal(resp.Body.Bytes(), &body))
+		assert.Equal(t, tt.body, body)
+	}
+}
+
+func TestBadRequest(t *testing.T) {
+	resp := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(resp)
+	BadRequest(c, errors.New("invalid param"))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, `"invalid param"`, resp.Body.String())
+	assert.Len(t, c.Errors, 1)
+}
@@ -70,7 +70,7 @@ type fanOutQueue struct {
 }
 
 // NewFanOutQueue returns a FanOutQueue persisted in dirPath.
-func NewFanOutQueue(dirPath string, dataSizeLimit int64) (q FanOutQueue, err error) {
+func NewFanOutQueue(dirPath string, dataSizeLimit int64, opts ...Option) (q FanOutQueue, err error) {
 	fq := &fanOutQueue{
 		dirPath:          dirPath,
 		consumerGroupDir: filepath.Join(dirPath, consumerGroupDirName),
@@ -85,7 +85,7 @@ func NewFanOutQueue(dirPath string, dataSizeLimit int64) (q FanOutQueue, err err
 	}()
 
 	// create underlying queue
-	fq.queue, err = newQueueFunc(dirPath, dataSizeLimit)
+	fq.queue, err = newQueueFunc(dirPath, dataSizeLimit, opts...)
 	if err != nil {
 		return nil, err
 	}
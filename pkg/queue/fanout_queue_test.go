@@ -63,7 +63,7 @@ func TestFanOutQueue_New(t *testing.T) {
 	}()
 
 	// case 1: create underlying queue err
-	newQueueFunc = func(dirPath string, dataSizeLimit int64) (Queue, error) {
+	newQueueFunc = func(dirPath string, dataSizeLimit int64, opts ...Option) (Queue, error) {
 		return nil, fmt.Errorf("err")
 	}
 	fq, err := NewFanOutQueue(dir, 1024)
@@ -76,7 +76,7 @@ func TestFanOutQueue_New(t *testing.T) {
 	queue.EXPECT().Close().AnyTimes()
 	queue.EXPECT().Signal().AnyTimes()
 
-	newQueueFunc = func(dirPath string, dataSizeLimit int64) (Queue, error) {
+	newQueueFunc = func(dirPath string, dataSizeLimit int64, opts ...Option) (Queue, error) {
 		return queue, nil
 	}
 	mkDirFunc = func(path string) error {
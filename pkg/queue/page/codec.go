@@ -0,0 +1,106 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package page
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec represents the compression codec of sealed page.
+type Codec string
+
+const (
+	// NoCompress represents page not compressed.
+	NoCompress Codec = "none"
+	// Gzip represents page compressed by gzip.
+	Gzip Codec = "gzip"
+	// Zstd represents page compressed by zstd.
+	Zstd Codec = "zstd"
+)
+
+// codecs keeps all supported compression codecs, the order is used to find compressed page file.
+var codecs = []Codec{Gzip, Zstd}
+
+// Enabled returns if codec compresses page.
+func (c Codec) Enabled() bool {
+	return c == Gzip || c == Zstd
+}
+
+// suffix returns the file suffix of page compressed by codec.
+func (c Codec) suffix() string {
+	switch c {
+	case Gzip:
+		return "gz"
+	case Zstd:
+		return "zst"
+	default:
+		return ""
+	}
+}
+
+// compress compresses data into writer.
+func (c Codec) compress(w io.Writer, data []byte) error {
+	var cw io.WriteCloser
+	switch c {
+	case Gzip:
+		cw = gzip.NewWriter(w)
+	case Zstd:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			return err
+		}
+		cw = zw
+	default:
+		return fmt.Errorf("unsupported page codec: %s", c)
+	}
+	if _, err := cw.Write(data); err != nil {
+		_ = cw.Close()
+		return err
+	}
+	return cw.Close()
+}
+
+// decompress decompresses data from reader into buf.
+func (c Codec) decompress(r io.Reader, buf []byte) error {
+	switch c {
+	case Gzip:
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = gr.Close()
+		}()
+		r = gr
+	case Zstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return fmt.Errorf("unsupported page codec: %s", c)
+	}
+	_, err := io.ReadFull(r, buf)
+	return err
+}
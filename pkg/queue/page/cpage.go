@@ -0,0 +1,168 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package page
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/atomic"
+
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+var (
+	errPageClosed   = errors.New("page is closed")
+	errPageReadOnly = errors.New("compressed page is read-only")
+)
+
+// compressedPage represents a sealed page which is compressed on disk,
+// page data is decompressed into memory lazily when reading, compressed page is read-only.
+// Reading panics if decompress failure and writing always panics, same as accessing mapped page out of range.
+type compressedPage struct {
+	fileName string
+	codec    Codec
+	size     int
+
+	buf    []byte // decompressed data, nil if not loaded or released
+	mutex  sync.Mutex
+	closed atomic.Bool
+}
+
+// newCompressedPage creates a compressed page, size is the size of page before compressed.
+func newCompressedPage(fileName string, codec Codec, size int) *compressedPage {
+	return &compressedPage{
+		fileName: fileName,
+		codec:    codec,
+		size:     size,
+	}
+}
+
+// load decompresses page data into memory if not loaded.
+func (cp *compressedPage) load() ([]byte, error) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	if cp.closed.Load() {
+		return nil, errPageClosed
+	}
+	if cp.buf != nil {
+		return cp.buf, nil
+	}
+	f, err := os.Open(cp.fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	buf := make([]byte, cp.size)
+	if err := cp.codec.decompress(bufio.NewReader(f), buf); err != nil {
+		return nil, err
+	}
+	cp.buf = buf
+	return buf, nil
+}
+
+// release releases the decompressed data, it will be decompressed again when reading.
+func (cp *compressedPage) release() {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	cp.buf = nil
+}
+
+// bytes returns the decompressed data, panics if decompress failure.
+func (cp *compressedPage) bytes() []byte {
+	buf, err := cp.load()
+	if err != nil {
+		panic(fmt.Errorf("read page[%s] failure: %w", cp.fileName, err))
+	}
+	return buf
+}
+
+// FilePath returns compressed file path.
+func (cp *compressedPage) FilePath() string {
+	return cp.fileName
+}
+
+// WriteBytes panics, compressed page is read-only(restored to mapped page for writing).
+func (cp *compressedPage) WriteBytes(_ []byte, _ int) {
+	panic(fmt.Errorf("write page[%s] failure: %w", cp.fileName, errPageReadOnly))
+}
+
+// ReadBytes reads bytes data from decompressed data.
+func (cp *compressedPage) ReadBytes(offset, length int) []byte {
+	return cp.bytes()[offset : offset+length]
+}
+
+// PutUint64 panics, compressed page is read-only(restored to mapped page for writing).
+func (cp *compressedPage) PutUint64(_ uint64, _ int) {
+	panic(fmt.Errorf("write page[%s] failure: %w", cp.fileName, errPageReadOnly))
+}
+
+// ReadUint64 reads uint64 from decompressed data.
+func (cp *compressedPage) ReadUint64(offset int) uint64 {
+	return stream.ReadUint64(cp.bytes(), offset)
+}
+
+// PutUint32 panics, compressed page is read-only(restored to mapped page for writing).
+func (cp *compressedPage) PutUint32(_ uint32, _ int) {
+	panic(fmt.Errorf("write page[%s] failure: %w", cp.fileName, errPageReadOnly))
+}
+
+// ReadUint32 reads uint32 from decompressed data.
+func (cp *compressedPage) ReadUint32(offset int) uint32 {
+	return stream.ReadUint32(cp.bytes(), offset)
+}
+
+// PutUint8 panics, compressed page is read-only(restored to mapped page for writing).
+func (cp *compressedPage) PutUint8(_ uint8, _ int) {
+	panic(fmt.Errorf("write page[%s] failure: %w", cp.fileName, errPageReadOnly))
+}
+
+// ReadUint8 reads uint8 from decompressed data.
+func (cp *compressedPage) ReadUint8(offset int) uint8 {
+	return cp.bytes()[offset]
+}
+
+// Sync does nothing, compressed file is synced when compressing.
+func (cp *compressedPage) Sync() error {
+	return nil
+}
+
+// Close releases decompressed data.
+func (cp *compressedPage) Close() error {
+	if cp.closed.CAS(false, true) {
+		cp.release()
+	}
+	return nil
+}
+
+// Closed returns if the page is closed.
+func (cp *compressedPage) Closed() bool {
+	return cp.closed.Load()
+}
+
+// Size returns the size of page before compressed.
+func (cp *compressedPage) Size() int {
+	return cp.size
+}
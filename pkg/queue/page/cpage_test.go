@@ -0,0 +1,97 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package page
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+func TestCompressedPage(t *testing.T) {
+	tmpDir := t.TempDir()
+	data := make([]byte, 128)
+	copy(data, "page")
+	stream.PutUint32(data, 10, 100)
+	stream.PutUint64(data, 20, 200)
+	fileName := filepath.Join(tmpDir, "0.bat.gz")
+	assert.NoError(t, writeCompressedFile(fileName, Gzip, data))
+
+	cp := newCompressedPage(fileName, Gzip, 128)
+	assert.Equal(t, fileName, cp.FilePath())
+	assert.Equal(t, 128, cp.Size())
+	assert.Equal(t, []byte("page"), cp.ReadBytes(0, 4))
+	assert.Equal(t, uint8('p'), cp.ReadUint8(0))
+	assert.Equal(t, uint32(100), cp.ReadUint32(10))
+	assert.Equal(t, uint64(200), cp.ReadUint64(20))
+	// compressed page is read-only
+	assert.Panics(t, func() { cp.WriteBytes([]byte("abcd"), 0) })
+	assert.Panics(t, func() { cp.PutUint8(1, 0) })
+	assert.Panics(t, func() { cp.PutUint32(1, 10) })
+	assert.Panics(t, func() { cp.PutUint64(1, 20) })
+	assert.NoError(t, cp.Sync())
+	// decompress again after released
+	cp.release()
+	assert.Equal(t, []byte("page"), cp.ReadBytes(0, 4))
+	assert.Equal(t, uint32(100), cp.ReadUint32(10))
+
+	assert.NoError(t, cp.Close())
+	assert.True(t, cp.Closed())
+	// cannot read after closed
+	assert.Panics(t, func() { cp.ReadBytes(0, 4) })
+	assert.Panics(t, func() { cp.ReadUint8(0) })
+	assert.Panics(t, func() { cp.ReadUint32(10) })
+	assert.Panics(t, func() { cp.ReadUint64(20) })
+	assert.NoError(t, cp.Close())
+}
+
+func TestFactory_loadPage(t *testing.T) {
+	tmpDir := t.TempDir()
+	fct := &factory{}
+	var pages []*compressedPage
+	for i := 0; i <= maxLoadedPages; i++ {
+		fileName := filepath.Join(tmpDir, fmt.Sprintf("%d.bat.zst", i))
+		assert.NoError(t, writeCompressedFile(fileName, Zstd, make([]byte, 128)))
+		cp := newCompressedPage(fileName, Zstd, 128)
+		assert.NoError(t, fct.loadPage(cp))
+		pages = append(pages, cp)
+	}
+	// least recently read page released
+	assert.Nil(t, pages[0].buf)
+	assert.Len(t, fct.loaded, maxLoadedPages)
+	// read again, move to last
+	assert.NoError(t, fct.loadPage(pages[1]))
+	assert.Equal(t, pages[1], fct.loaded[maxLoadedPages-1])
+	// closed page removed
+	assert.NoError(t, pages[2].Close())
+	assert.NoError(t, fct.loadPage(pages[3]))
+	assert.Len(t, fct.loaded, maxLoadedPages-1)
+}
+
+func TestCodec(t *testing.T) {
+	assert.False(t, NoCompress.Enabled())
+	assert.True(t, Gzip.Enabled())
+	assert.True(t, Zstd.Enabled())
+	assert.Error(t, NoCompress.compress(nil, nil))
+	assert.Error(t, NoCompress.decompress(nil, nil))
+	assert.Empty(t, NoCompress.suffix())
+}
@@ -18,10 +18,13 @@
 package page
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -39,24 +42,38 @@ var (
 	mkDirFunc      = commonfileutil.MkDirIfNotExist
 	removeFileFunc = commonfileutil.RemoveFile
 	listDirFunc    = commonfileutil.ListDir
+	renameFunc     = os.Rename
 )
 
 var pageLogger = logger.GetLogger("Queue", "PageFactory")
 
 var errFactoryClosed = errors.New("page factory is closed")
 
-// pageSuffix represents the page file suffix
-const pageSuffix = "bat"
+const (
+	// pageSuffix represents the page file suffix
+	pageSuffix = "bat"
+	// tmpSuffix represents the suffix of compressing page file
+	tmpSuffix = "tmp"
+	// maxLoadedPages is the max number of decompressed pages kept in memory
+	maxLoadedPages = 3
+)
 
 // Factory represents mapped page manage factory
 type Factory interface {
 	io.Closer
 	// AcquirePage acquires a mapped page with specific index from the factory
 	AcquirePage(index int64) (MappedPage, error)
-	// GetPage returns a mapped page with specific index
+	// GetPage returns a mapped page with specific index,
+	// the bytes read from page are invalid after the page compressed, use CopyBytes if pages may be compressed.
 	GetPage(index int64) (MappedPage, bool)
+	// CopyBytes copies bytes data out of the page with specific index,
+	// the copied bytes are still valid after the page compressed(mapped page closed).
+	CopyBytes(index int64, offset, length int) ([]byte, bool)
 	// TruncatePages truncates expired page by index(page id).
 	TruncatePages(index int64)
+	// CompressPages compresses sealed pages which index(page id) < before,
+	// compressed page is decompressed when reading, and restored to mapped page when acquiring.
+	CompressPages(before int64, codec Codec) error
 	// Size returns the total page size
 	Size() int64
 }
@@ -70,6 +87,10 @@ type factory struct {
 	closed atomic.Bool
 	size   atomic.Int64 // current total queue data size
 
+	loaded        []*compressedPage // decompressed pages, the most recently read is last
+	loadMutex     sync.Mutex
+	compressMutex sync.Mutex // avoid closing/truncating page being compressed
+
 	mutex  sync.RWMutex
 	logger logger.Logger
 }
@@ -107,6 +128,10 @@ func (f *factory) AcquirePage(index int64) (MappedPage, error) {
 
 	page, ok := f.pages[index]
 	if ok {
+		if cp, ok := page.(*compressedPage); ok {
+			// page need to be written, restore it to mapped page
+			return f.restorePage(index, cp)
+		}
 		return page, nil
 	}
 
@@ -124,14 +149,53 @@ func (f *factory) AcquirePage(index int64) (MappedPage, error) {
 // GetPage returns a mapped page with specific index
 func (f *factory) GetPage(index int64) (MappedPage, bool) {
 	f.mutex.RLock()
-	defer f.mutex.RUnlock()
+	page, ok := f.pages[index]
+	f.mutex.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	if cp, ok := page.(*compressedPage); ok {
+		if err := f.loadPage(cp); err != nil {
+			f.logger.Error("decompress page failure",
+				logger.String("path", cp.FilePath()), logger.Error(err))
+			return nil, false
+		}
+	}
+	return page, true
+}
 
+// CopyBytes copies bytes data out of the page with specific index.
+func (f *factory) CopyBytes(index int64, offset, length int) ([]byte, bool) {
+	f.mutex.RLock()
 	page, ok := f.pages[index]
-	return page, ok
+	cp, compressed := page.(*compressedPage)
+	if ok && !compressed {
+		// mapped page is closed only under write lock(after compressed/truncated), copy it under read lock
+		defer f.mutex.RUnlock()
+		buf := make([]byte, length)
+		copy(buf, page.ReadBytes(offset, length))
+		return buf, true
+	}
+	f.mutex.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	if err := f.loadPage(cp); err != nil {
+		f.logger.Error("decompress page failure",
+			logger.String("path", cp.FilePath()), logger.Error(err))
+		return nil, false
+	}
+	// decompressed data is never modified, released data is just dropped(not reused), no need to copy
+	return cp.ReadBytes(offset, length), true
 }
 
 // TruncatePages truncates expired page by index(page id).
 func (f *factory) TruncatePages(index int64) {
+	f.compressMutex.Lock()
+	defer f.compressMutex.Unlock()
+
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -146,7 +210,7 @@ func (f *factory) TruncatePages(index int64) {
 					f.logger.Warn("close page failure",
 						logger.String("path", f.path), logger.Any("page", pageID), logger.Error(err))
 				}
-				if err := removeFileFunc(f.pageFileName(pageID)); err != nil {
+				if err := removeFileFunc(page.FilePath()); err != nil {
 					f.logger.Warn("remove page failure",
 						logger.String("path", f.path), logger.Any("page", pageID), logger.Error(err))
 					continue
@@ -161,6 +225,126 @@ func (f *factory) TruncatePages(index int64) {
 	}
 }
 
+// CompressPages compresses sealed pages which index(page id) < before.
+func (f *factory) CompressPages(before int64, codec Codec) error {
+	if !codec.Enabled() {
+		return nil
+	}
+	f.compressMutex.Lock()
+	defer f.compressMutex.Unlock()
+
+	for _, index := range f.sealedPages(before) {
+		if f.closed.Load() {
+			return errFactoryClosed
+		}
+		if err := f.compressPage(index, codec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sealedPages returns the sorted indexes of uncompressed pages which index < before.
+func (f *factory) sealedPages(before int64) (indexes []int64) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	for index, page := range f.pages {
+		if _, ok := page.(*compressedPage); !ok && index < before {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i] < indexes[j]
+	})
+	return indexes
+}
+
+// compressPage compresses mapped page into compressed file, then replaces mapped page with compressed page.
+func (f *factory) compressPage(index int64, codec Codec) error {
+	f.mutex.RLock()
+	page := f.pages[index]
+	f.mutex.RUnlock()
+
+	fileName := f.compressedFileName(index, codec)
+	tmpFileName := fmt.Sprintf("%s.%s", fileName, tmpSuffix)
+	if err := writeCompressedFile(tmpFileName, codec, page.ReadBytes(0, page.Size())); err != nil {
+		_ = removeFileFunc(tmpFileName)
+		return err
+	}
+	if err := renameFunc(tmpFileName, fileName); err != nil {
+		_ = removeFileFunc(tmpFileName)
+		return err
+	}
+
+	f.mutex.Lock()
+	f.pages[index] = newCompressedPage(fileName, codec, f.pageSize)
+	// readers copy bytes of mapped page under read lock, no reader holds the mapped page after replaced
+	f.retirePage(page)
+	f.mutex.Unlock()
+
+	f.logger.Info("compress page successfully",
+		logger.String("path", f.path), logger.Any("page", index), logger.String("codec", string(codec)))
+	return nil
+}
+
+// retirePage closes the mapped page which is compressed, then removes the mapped page file.
+func (f *factory) retirePage(page MappedPage) {
+	if err := page.Close(); err != nil {
+		f.logger.Warn("close compressed mapped page failure",
+			logger.String("path", page.FilePath()), logger.Error(err))
+	}
+	if err := removeFileFunc(page.FilePath()); err != nil {
+		f.logger.Warn("remove compressed mapped page failure",
+			logger.String("path", page.FilePath()), logger.Error(err))
+	}
+}
+
+// restorePage restores compressed page to mapped page for writing.
+func (f *factory) restorePage(index int64, cp *compressedPage) (MappedPage, error) {
+	buf, err := cp.load()
+	if err != nil {
+		return nil, err
+	}
+	page, err := NewMappedPage(f.pageFileName(index), f.pageSize)
+	if err != nil {
+		return nil, err
+	}
+	page.WriteBytes(buf, 0)
+	if err := page.Sync(); err != nil {
+		_ = page.Close()
+		return nil, err
+	}
+	_ = cp.Close()
+	if err := removeFileFunc(cp.FilePath()); err != nil {
+		f.logger.Warn("remove restored compressed page failure",
+			logger.String("path", cp.FilePath()), logger.Error(err))
+	}
+	f.pages[index] = page
+	return page, nil
+}
+
+// loadPage decompresses page into memory, keeps at most maxLoadedPages pages decompressed.
+func (f *factory) loadPage(cp *compressedPage) error {
+	f.loadMutex.Lock()
+	defer f.loadMutex.Unlock()
+
+	loaded := f.loaded[:0]
+	for _, page := range f.loaded {
+		if page != cp && !page.Closed() {
+			loaded = append(loaded, page)
+		}
+	}
+	if len(loaded) >= maxLoadedPages {
+		// release the least recently read page
+		loaded[0].release()
+		loaded = loaded[1:]
+	}
+	f.loaded = append(loaded, cp)
+	_, err := cp.load()
+	return err
+}
+
 // Size returns the total page size
 func (f *factory) Size() int64 {
 	return f.size.Load()
@@ -169,6 +353,9 @@ func (f *factory) Size() int64 {
 // Close closes all acquire mapped pages
 func (f *factory) Close() error {
 	if f.closed.CAS(false, true) {
+		f.compressMutex.Lock()
+		defer f.compressMutex.Unlock()
+
 		f.mutex.Lock()
 		defer f.mutex.Unlock()
 
@@ -187,6 +374,11 @@ func (f *factory) pageFileName(index int64) string {
 	return filepath.Join(f.path, fmt.Sprintf("%d.%s", index, pageSuffix))
 }
 
+// compressedFileName returns the compressed file name
+func (f *factory) compressedFileName(index int64, codec Codec) string {
+	return fmt.Sprintf("%s.%s", f.pageFileName(index), codec.suffix())
+}
+
 // loadPages loads exist pages when factory init
 func (f *factory) loadPages() error {
 	fileNames, err := listDirFunc(f.path)
@@ -198,12 +390,44 @@ func (f *factory) loadPages() error {
 		return nil
 	}
 
+	compressed := make(map[int64]Codec)
+	var seqs []int64
 	for _, fn := range fileNames {
+		if strings.HasSuffix(fn, tmpSuffix) {
+			// remove page file which compressing not completed
+			if err := removeFileFunc(filepath.Join(f.path, fn)); err != nil {
+				return err
+			}
+			continue
+		}
 		seqNumStr := fn[0 : strings.Index(fn, pageSuffix)-1]
 		seq, err := strconv.ParseInt(seqNumStr, 10, 64)
 		if err != nil {
 			return err
 		}
+		seqs = append(seqs, seq)
+		for _, codec := range codecs {
+			if strings.HasSuffix(fn, codec.suffix()) {
+				compressed[seq] = codec
+			}
+		}
+	}
+
+	for _, seq := range seqs {
+		if _, ok := f.pages[seq]; ok {
+			continue
+		}
+		if codec, ok := compressed[seq]; ok {
+			// compressed file is completed, remove mapped page file if exist(not removed after compressed)
+			if pageFileName := f.pageFileName(seq); commonfileutil.Exist(pageFileName) {
+				if err := removeFileFunc(pageFileName); err != nil {
+					return err
+				}
+			}
+			f.pages[seq] = newCompressedPage(f.compressedFileName(seq, codec), codec, f.pageSize)
+			f.size.Add(int64(f.pageSize))
+			continue
+		}
 		_, err = f.AcquirePage(seq)
 		if err != nil {
 			return err
@@ -212,3 +436,22 @@ func (f *factory) loadPages() error {
 
 	return nil
 }
+
+// writeCompressedFile writes data compressed by codec into file, then syncs file.
+func writeCompressedFile(fileName string, codec Codec, data []byte) error {
+	file, err := openFileFunc(fileName, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	w := bufio.NewWriter(file)
+	if err := codec.compress(w, data); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return file.Sync()
+}
@@ -20,6 +20,7 @@ package page
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -200,5 +201,138 @@ func TestFactory_TruncatePages(t *testing.T) {
 		logger: logger.GetLogger("Queue", "Test"),
 	}
 	page.EXPECT().Close().Return(fmt.Errorf("err"))
+	page.EXPECT().FilePath().Return("10.bat").AnyTimes()
 	fct.TruncatePages(11)
 }
+
+func TestFactory_CompressPages(t *testing.T) {
+	tmpDir := t.TempDir()
+	defer func() {
+		renameFunc = os.Rename
+		openFileFunc = os.OpenFile
+	}()
+
+	for _, codec := range []Codec{Gzip, Zstd} {
+		codec := codec
+		t.Run(string(codec), func(t *testing.T) {
+			dir := filepath.Join(tmpDir, string(codec))
+			fct, err := NewFactory(dir, 128)
+			assert.NoError(t, err)
+			for i := 0; i < 3; i++ {
+				p, err0 := fct.AcquirePage(int64(i))
+				assert.NoError(t, err0)
+				p.WriteBytes([]byte(fmt.Sprintf("page-%d", i)), 10)
+			}
+			// copied bytes still valid after mapped page closed
+			held, ok := fct.CopyBytes(0, 10, 6)
+			assert.True(t, ok)
+			assert.NoError(t, fct.CompressPages(2, NoCompress))
+			assert.NoError(t, fct.CompressPages(2, codec))
+			// compress again, ignore compressed pages
+			assert.NoError(t, fct.CompressPages(2, codec))
+			assert.Equal(t, int64(3*128), fct.Size())
+			for i := 0; i < 2; i++ {
+				p, ok := fct.GetPage(int64(i))
+				assert.True(t, ok)
+				assert.Equal(t, []byte(fmt.Sprintf("page-%d", i)), p.ReadBytes(10, 6))
+			}
+			assert.Equal(t, []byte("page-0"), held)
+			buf, ok := fct.CopyBytes(1, 10, 6)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("page-1"), buf)
+			// active page keeps uncompressed
+			p, ok := fct.GetPage(2)
+			assert.True(t, ok)
+			_, compressed := p.(*compressedPage)
+			assert.False(t, compressed)
+			buf, ok = fct.CopyBytes(2, 10, 6)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("page-2"), buf)
+			_, ok = fct.CopyBytes(3, 10, 6)
+			assert.False(t, ok)
+			// mapped page files of compressed pages removed
+			files, err := commonfileutil.ListDir(dir)
+			assert.NoError(t, err)
+			assert.Len(t, files, 3)
+			assert.NoError(t, fct.Close())
+
+			// reopen, load compressed pages
+			fct, err = NewFactory(dir, 128)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(3*128), fct.Size())
+			p, ok = fct.GetPage(0)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("page-0"), p.ReadBytes(10, 6))
+			assert.Equal(t, uint8('p'), p.ReadUint8(10))
+			// restore compressed page for writing
+			p, err = fct.AcquirePage(1)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("page-1"), p.ReadBytes(10, 6))
+			p.WriteBytes([]byte("new"), 20)
+			assert.True(t, commonfileutil.Exist(filepath.Join(dir, "1.bat")))
+			assert.False(t, commonfileutil.Exist(filepath.Join(dir, "1.bat."+codec.suffix())))
+			// truncate compressed page
+			fct.TruncatePages(1)
+			assert.False(t, commonfileutil.Exist(filepath.Join(dir, "0.bat."+codec.suffix())))
+			assert.Equal(t, int64(2*128), fct.Size())
+			assert.NoError(t, fct.Close())
+		})
+	}
+
+	t.Run("compress failure", func(t *testing.T) {
+		fct, err := NewFactory(filepath.Join(tmpDir, "failure"), 128)
+		assert.NoError(t, err)
+		_, err = fct.AcquirePage(0)
+		assert.NoError(t, err)
+		renameFunc = func(_, _ string) error {
+			return fmt.Errorf("err")
+		}
+		assert.Error(t, fct.CompressPages(1, Gzip))
+		renameFunc = os.Rename
+		openFileFunc = func(_ string, _ int, _ os.FileMode) (*os.File, error) {
+			return nil, fmt.Errorf("err")
+		}
+		assert.Error(t, fct.CompressPages(1, Gzip))
+		openFileFunc = os.OpenFile
+		assert.NoError(t, fct.Close())
+		// compress after closed
+		assert.Equal(t, errFactoryClosed, fct.CompressPages(1, Gzip))
+	})
+}
+
+func TestFactory_loadPages_compressed(t *testing.T) {
+	tmpDir := t.TempDir()
+	fct, err := NewFactory(tmpDir, 128)
+	assert.NoError(t, err)
+	p, err := fct.AcquirePage(0)
+	assert.NoError(t, err)
+	p.WriteBytes([]byte("page-0"), 0)
+	assert.NoError(t, writeCompressedFile(filepath.Join(tmpDir, "0.bat.zst"), Zstd, p.ReadBytes(0, 128)))
+	assert.NoError(t, fct.Close())
+	// compressing not completed
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "1.bat.gz.tmp"), []byte("tmp"), 0644))
+
+	fct, err = NewFactory(tmpDir, 128)
+	assert.NoError(t, err)
+	files, err := commonfileutil.ListDir(tmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0.bat.zst"}, files)
+	p, ok := fct.GetPage(0)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("page-0"), p.ReadBytes(0, 6))
+	assert.NoError(t, fct.Close())
+
+	// decompress failure
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "0.bat.zst"), []byte("bad"), 0644))
+	fct, err = NewFactory(tmpDir, 128)
+	assert.NoError(t, err)
+	p, ok = fct.GetPage(0)
+	assert.False(t, ok)
+	assert.Nil(t, p)
+	_, ok = fct.CopyBytes(0, 0, 6)
+	assert.False(t, ok)
+	p, err = fct.AcquirePage(0)
+	assert.Error(t, err)
+	assert.Nil(t, p)
+	assert.NoError(t, fct.Close())
+}
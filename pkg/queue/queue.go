@@ -80,10 +80,22 @@ type Queue interface {
 	Close()
 }
 
+// Option represents the option of queue.
+type Option func(q *queue)
+
+// WithCompressCodec sets the codec which compresses sealed data pages in background,
+// the active data page keeps uncompressed for appending.
+func WithCompressCodec(codec page.Codec) Option {
+	return func(q *queue) {
+		q.codec = codec
+	}
+}
+
 // queue implements queue.
 type queue struct {
-	dirPath       string // dirPath for queue file
-	dataSizeLimit int64  // the max size limit in bytes for data file
+	dirPath       string     // dirPath for queue file
+	dataSizeLimit int64      // the max size limit in bytes for data file
+	codec         page.Codec // codec for compressing sealed data pages
 
 	indexPageFct page.Factory // index page factory
 	dataPageFct  page.Factory // data page factory
@@ -109,7 +121,7 @@ type queue struct {
 }
 
 // NewQueue returns Queue based on dirPath, dataSizeLimit is used to limit the total data/index size,
-func NewQueue(dirPath string, dataSizeLimit int64) (Queue, error) {
+func NewQueue(dirPath string, dataSizeLimit int64, opts ...Option) (Queue, error) {
 	if err := mkDirFunc(dirPath); err != nil {
 		return nil, err
 	}
@@ -120,6 +132,9 @@ func NewQueue(dirPath string, dataSizeLimit int64) (Queue, error) {
 		rwMutex:       lock,
 		notEmpty:      sync.NewCond(lock),
 	}
+	for _, opt := range opts {
+		opt(q)
+	}
 
 	// if data size limit < default limit, need reset
 	if q.dataSizeLimit < defaultDataSizeLimit {
@@ -191,6 +206,7 @@ func NewQueue(dirPath string, dataSizeLimit int64) (Queue, error) {
 	if err != nil {
 		return nil, err
 	}
+	q.compressSealedPages(q.dataPageIndex)
 	return q, nil
 }
 
@@ -231,14 +247,22 @@ func (q *queue) Get(sequence int64) (data []byte, err error) {
 	indexOffset := int((sequence % indexItemsPerPage) * indexItemLength)
 	dataPageID := int64(indexPage.ReadUint64(indexOffset + queueDataPageIndexOffset))
 
+	messageOffset := int(indexPage.ReadUint32(indexOffset + messageOffsetOffset))
+	messageLength := int(indexPage.ReadUint32(indexOffset + messageLengthOffset))
+
+	if q.codec.Enabled() {
+		// sealed data page may be compressed in background(mapped page closed),
+		// copy message out of data page, because caller may hold it for a long time.
+		data, ok = q.dataPageFct.CopyBytes(dataPageID, messageOffset, messageLength)
+		if !ok {
+			return nil, ErrMsgNotFound
+		}
+		return data, nil
+	}
 	dataPage, ok := q.dataPageFct.GetPage(dataPageID)
 	if !ok {
 		return nil, ErrMsgNotFound
 	}
-
-	messageOffset := int(indexPage.ReadUint32(indexOffset + messageOffsetOffset))
-	messageLength := int(indexPage.ReadUint32(indexOffset + messageLengthOffset))
-
 	return dataPage.ReadBytes(messageOffset, messageLength), nil
 }
 
@@ -385,6 +409,8 @@ func (q *queue) alloc(dataLen int) (dataPageIndex int64, dataPage page.MappedPag
 		q.dataPage = dataPage
 		q.dataPageIndex = nextDataPageIndex
 		q.messageOffset = 0 // need reset message offset for new data page
+
+		q.compressSealedPages(nextDataPageIndex)
 	}
 	// advance dataOffset
 	messageOffset := q.messageOffset
@@ -392,6 +418,19 @@ func (q *queue) alloc(dataLen int) (dataPageIndex int64, dataPage page.MappedPag
 	return q.dataPageIndex, q.dataPage, messageOffset, nil
 }
 
+// compressSealedPages compresses sealed data pages in background, so it never blocks writing.
+func (q *queue) compressSealedPages(activeDataPageIndex int64) {
+	if !q.codec.Enabled() {
+		return
+	}
+	go func() {
+		if err := q.dataPageFct.CompressPages(activeDataPageIndex, q.codec); err != nil {
+			queueLogger.Warn("compress sealed data pages failure",
+				logger.String("queue", q.dirPath), logger.Error(err))
+		}
+	}()
+}
+
 // persistMetaOfMessage persists metadata of message after write data
 func (q *queue) persistMetaOfMessage(dataPageIndex int64, dataLen, messageOffset int) error {
 	q.rwMutex.Lock()
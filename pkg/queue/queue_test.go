@@ -617,3 +617,49 @@ func mockMessageData(bucket, length int) map[string][]byte {
 
 	return data
 }
+
+func TestQueue_compress(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), t.Name())
+
+	q, err := NewQueue(dir, dataPageSize*4, WithCompressCodec(page.Zstd))
+	assert.NoError(t, err)
+	message := func(seq int) []byte {
+		return []byte(strings.Repeat(fmt.Sprintf("compress-%d,", seq), 1024))
+	}
+	// write messages until first data page sealed
+	seq := 0
+	var held []byte
+	for q.(*queue).dataPageIndex == 0 {
+		assert.NoError(t, q.Put(message(seq)))
+		if seq == 0 {
+			// reader holds message of data page which will be compressed
+			held, err = q.Get(0)
+			assert.NoError(t, err)
+		}
+		seq++
+	}
+	// wait sealed data page compressed in background, mapped page closed
+	assert.Eventually(t, func() bool {
+		return fileutil.Exist(filepath.Join(dir, dataPath, "0.bat.zst")) &&
+			!fileutil.Exist(filepath.Join(dir, dataPath, "0.bat"))
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, message(0), held)
+	// write active data page
+	assert.NoError(t, q.Put(message(seq)))
+	seq++
+	assertMessages := func() {
+		for i := 0; i < seq; i++ {
+			data, err0 := q.Get(int64(i))
+			assert.NoError(t, err0)
+			assert.Equal(t, message(i), data)
+		}
+	}
+	assertMessages()
+	q.Close()
+
+	// replay after re-open
+	q, err = NewQueue(dir, dataPageSize*4, WithCompressCodec(page.Zstd))
+	assert.NoError(t, err)
+	assertMessages()
+	q.Close()
+}
@@ -0,0 +1,173 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sketch
+
+import (
+	"sort"
+
+	"github.com/lindb/common/pkg/encoding"
+)
+
+// DefaultExactThreshold is the max number of distinct values kept in exact mode,
+// if more values added, switches to HyperLogLog sketch.
+const DefaultExactThreshold = 1024
+
+// Distinct represents a mergeable distinct counter for counting distinct values across shards/nodes.
+// For small cardinality, keeps the actual value set(exact mode), ships/merges the sets and returns exact count;
+// when the number of values exceeds threshold, switches to HyperLogLog for approximate count with bounded error.
+// Not thread-safe.
+type Distinct struct {
+	precision uint8
+	threshold int
+
+	values map[string]struct{}
+	hll    *HyperLogLog
+}
+
+// NewDistinct creates a Distinct counter with HyperLogLog precision, if precision is 0 uses default precision.
+func NewDistinct(precision uint8) (*Distinct, error) {
+	if precision == 0 {
+		precision = DefaultPrecision
+	}
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, ErrInvalidPrecision
+	}
+	return &Distinct{
+		precision: precision,
+		threshold: DefaultExactThreshold,
+		values:    make(map[string]struct{}),
+	}, nil
+}
+
+// IsExact returns if the counter is exact mode.
+func (d *Distinct) IsExact() bool {
+	return d.hll == nil
+}
+
+// Precision returns the HyperLogLog precision.
+func (d *Distinct) Precision() uint8 {
+	return d.precision
+}
+
+// Add adds value into counter.
+func (d *Distinct) Add(value string) {
+	if d.hll != nil {
+		d.hll.AddString(value)
+		return
+	}
+	d.values[value] = struct{}{}
+	if len(d.values) > d.threshold {
+		d.toSketch()
+	}
+}
+
+// Merge merges other counter into current counter.
+func (d *Distinct) Merge(other *Distinct) error {
+	if other == nil {
+		return nil
+	}
+	if d.precision != other.precision {
+		return ErrPrecisionMismatch
+	}
+	if other.hll == nil {
+		for value := range other.values {
+			d.Add(value)
+		}
+		return nil
+	}
+	if d.hll == nil {
+		d.toSketch()
+	}
+	return d.hll.Merge(other.hll)
+}
+
+// Count returns the distinct count, exact in exact mode, otherwise approximate.
+func (d *Distinct) Count() uint64 {
+	if d.hll != nil {
+		return d.hll.Estimate()
+	}
+	return uint64(len(d.values))
+}
+
+// RelativeError returns the standard error of count, 0 in exact mode.
+func (d *Distinct) RelativeError() float64 {
+	if d.hll != nil {
+		return d.hll.RelativeError()
+	}
+	return 0
+}
+
+// toSketch switches exact mode to sketch mode.
+func (d *Distinct) toSketch() {
+	d.hll, _ = NewHyperLogLog(d.precision)
+	for value := range d.values {
+		d.hll.AddString(value)
+	}
+	d.values = nil
+}
+
+// innerDistinct represents a wrapper of distinct counter for json encoding.
+type innerDistinct struct {
+	Precision uint8    `json:"precision"`
+	Values    []string `json:"values,omitempty"`
+	Sketch    []byte   `json:"sketch,omitempty"`
+}
+
+// MarshalJSON returns json data of distinct counter.
+func (d *Distinct) MarshalJSON() ([]byte, error) {
+	inner := innerDistinct{
+		Precision: d.precision,
+	}
+	if d.hll != nil {
+		inner.Sketch, _ = d.hll.MarshalBinary()
+	} else {
+		for value := range d.values {
+			inner.Values = append(inner.Values, value)
+		}
+		sort.Strings(inner.Values)
+	}
+	return encoding.JSONMarshal(&inner), nil
+}
+
+// UnmarshalJSON parses json data to distinct counter.
+func (d *Distinct) UnmarshalJSON(value []byte) error {
+	inner := innerDistinct{}
+	if err := encoding.JSONUnmarshal(value, &inner); err != nil {
+		return err
+	}
+	rs, err := NewDistinct(inner.Precision)
+	if err != nil {
+		return err
+	}
+	if len(inner.Sketch) > 0 {
+		rs.hll = &HyperLogLog{}
+		if err := rs.hll.UnmarshalBinary(inner.Sketch); err != nil {
+			return err
+		}
+		if rs.hll.Precision() != rs.precision {
+			return ErrPrecisionMismatch
+		}
+		rs.values = nil
+	} else {
+		for _, v := range inner.Values {
+			rs.values[v] = struct{}{}
+		}
+	}
+	*d = *rs
+	return nil
+}
@@ -0,0 +1,122 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sketch
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/pkg/encoding"
+)
+
+func TestNewDistinct(t *testing.T) {
+	d, err := NewDistinct(0)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPrecision, d.Precision())
+	assert.True(t, d.IsExact())
+	assert.Equal(t, uint64(0), d.Count())
+	assert.Equal(t, 0.0, d.RelativeError())
+
+	d, err = NewDistinct(MaxPrecision + 1)
+	assert.Equal(t, ErrInvalidPrecision, err)
+	assert.Nil(t, d)
+}
+
+func TestDistinct_Exact(t *testing.T) {
+	shard1, _ := NewDistinct(0)
+	shard2, _ := NewDistinct(0)
+	for i := 0; i < 100; i++ {
+		shard1.Add("value-" + strconv.Itoa(i))
+		shard2.Add("value-" + strconv.Itoa(i+50))
+	}
+	root, _ := NewDistinct(0)
+	assert.NoError(t, root.Merge(shard1))
+	assert.NoError(t, root.Merge(shard2))
+	assert.NoError(t, root.Merge(nil))
+	assert.True(t, root.IsExact())
+	assert.Equal(t, uint64(150), root.Count())
+
+	other, _ := NewDistinct(10)
+	assert.Equal(t, ErrPrecisionMismatch, root.Merge(other))
+}
+
+func TestDistinct_Sketch(t *testing.T) {
+	n := 20000
+	shards := make([]*Distinct, 3)
+	for i := range shards {
+		shards[i], _ = NewDistinct(0)
+	}
+	for i := 0; i < n; i++ {
+		shards[i%3].Add("value-" + strconv.Itoa(i))
+	}
+	// small shard keeps exact mode
+	small, _ := NewDistinct(0)
+	small.Add("value-1")
+	small.Add("small")
+	root, _ := NewDistinct(0)
+	assert.NoError(t, root.Merge(small))
+	for _, shard := range shards {
+		assert.False(t, shard.IsExact())
+		assert.NoError(t, root.Merge(shard))
+	}
+	assert.NoError(t, root.Merge(small))
+	assert.False(t, root.IsExact())
+	assertWithinErrorBound(t, uint64(n+1), root.Count(), root.RelativeError())
+
+	// exact sets merged exceed threshold
+	d1, _ := NewDistinct(0)
+	d2, _ := NewDistinct(0)
+	for i := 0; i < DefaultExactThreshold; i++ {
+		d1.Add("a" + strconv.Itoa(i))
+		d2.Add("b" + strconv.Itoa(i))
+	}
+	assert.True(t, d1.IsExact())
+	assert.NoError(t, d1.Merge(d2))
+	assert.False(t, d1.IsExact())
+	assertWithinErrorBound(t, uint64(2*DefaultExactThreshold), d1.Count(), d1.RelativeError())
+}
+
+func TestDistinct_JSON(t *testing.T) {
+	exact, _ := NewDistinct(10)
+	exact.Add("a")
+	exact.Add("b")
+	data := encoding.JSONMarshal(exact)
+	rs := &Distinct{}
+	assert.NoError(t, encoding.JSONUnmarshal(data, rs))
+	assert.True(t, rs.IsExact())
+	assert.Equal(t, uint64(2), rs.Count())
+	assert.Equal(t, uint8(10), rs.Precision())
+
+	sketch, _ := NewDistinct(10)
+	for i := 0; i < 5000; i++ {
+		sketch.Add(strconv.Itoa(i))
+	}
+	data = encoding.JSONMarshal(sketch)
+	rs = &Distinct{}
+	assert.NoError(t, encoding.JSONUnmarshal(data, rs))
+	assert.False(t, rs.IsExact())
+	assert.Equal(t, sketch.Count(), rs.Count())
+
+	assert.Error(t, rs.UnmarshalJSON([]byte("abc")))
+	assert.Equal(t, ErrInvalidPrecision, rs.UnmarshalJSON([]byte(`{"precision":30}`)))
+	assert.Error(t, rs.UnmarshalJSON([]byte(`{"precision":10,"sketch":"AQI="}`)))
+	assert.Equal(t, ErrPrecisionMismatch, rs.UnmarshalJSON([]byte(`{"precision":11,"sketch":"`+
+		string(data[len(`{"precision":10,"sketch":"`):len(data)-2])+`"}`)))
+}
@@ -0,0 +1,170 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sketch
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ExponentialRelativeAccuracy is the relative accuracy of quantile estimated by exponential histogram,
+// all writers/readers share the same bucket mapping, so that sketches can be merged by bucket index.
+const ExponentialRelativeAccuracy = 0.01
+
+var (
+	// gamma is the base of exponential buckets, bucket i covers values in (gamma^(i-1), gamma^i].
+	gamma         = (1 + ExponentialRelativeAccuracy) / (1 - ExponentialRelativeAccuracy)
+	logGamma      = math.Log(gamma)
+	bucketMidRate = 2 / (1 + gamma)
+)
+
+// ExponentialIndex returns the bucket index of positive value.
+func ExponentialIndex(value float64) int32 {
+	return int32(math.Ceil(math.Log(value) / logGamma))
+}
+
+// ExponentialValue returns the representative value of bucket index,
+// whose relative error to any value in bucket is less than ExponentialRelativeAccuracy.
+func ExponentialValue(index int32) float64 {
+	return math.Exp(float64(index)*logGamma) * bucketMidRate
+}
+
+// ExponentialHistogram represents a mergeable quantile sketch(DDSketch-style) with exponential buckets,
+// values are counted by bucket index, zero values are counted in zero bucket.
+// Quantile estimated has bounded relative error(ExponentialRelativeAccuracy).
+// Not thread-safe.
+type ExponentialHistogram struct {
+	zeroCount float64
+	counts    map[int32]float64
+}
+
+// NewExponentialHistogram creates an empty exponential histogram.
+func NewExponentialHistogram() *ExponentialHistogram {
+	return &ExponentialHistogram{counts: make(map[int32]float64)}
+}
+
+// Add adds a value into histogram, value must be non-negative finite number.
+func (h *ExponentialHistogram) Add(value float64) error {
+	return h.AddWithCount(value, 1)
+}
+
+// AddWithCount adds a value with count into histogram.
+func (h *ExponentialHistogram) AddWithCount(value, count float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) || value < 0 {
+		return fmt.Errorf("exponential histogram value: %f is not non-negative finite number", value)
+	}
+	if math.IsNaN(count) || math.IsInf(count, 0) || count < 0 {
+		return fmt.Errorf("exponential histogram count: %f is not non-negative finite number", count)
+	}
+	if value == 0 {
+		h.zeroCount += count
+		return nil
+	}
+	h.counts[ExponentialIndex(value)] += count
+	return nil
+}
+
+// AddBucket adds the count of bucket index into histogram.
+func (h *ExponentialHistogram) AddBucket(index int32, count float64) {
+	h.counts[index] += count
+}
+
+// AddZeroCount adds the count of zero bucket into histogram.
+func (h *ExponentialHistogram) AddZeroCount(count float64) {
+	h.zeroCount += count
+}
+
+// Merge merges other histogram into current histogram(union of buckets).
+func (h *ExponentialHistogram) Merge(other *ExponentialHistogram) {
+	if other == nil {
+		return
+	}
+	h.zeroCount += other.zeroCount
+	for index, count := range other.counts {
+		h.counts[index] += count
+	}
+}
+
+// ZeroCount returns the count of zero bucket.
+func (h *ExponentialHistogram) ZeroCount() float64 {
+	return h.zeroCount
+}
+
+// Buckets returns the bucket indexes in ascending order and the counts of them.
+func (h *ExponentialHistogram) Buckets() (indexes []int32, counts []float64) {
+	indexes = make([]int32, 0, len(h.counts))
+	for index := range h.counts {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	counts = make([]float64, len(indexes))
+	for i, index := range indexes {
+		counts[i] = h.counts[index]
+	}
+	return indexes, counts
+}
+
+// Count returns the total count of values.
+func (h *ExponentialHistogram) Count() float64 {
+	count := h.zeroCount
+	for _, c := range h.counts {
+		count += c
+	}
+	return count
+}
+
+// Quantile returns the estimated quantile(0<=q<=1) of values, returns NaN if histogram is empty.
+func (h *ExponentialHistogram) Quantile(q float64) float64 {
+	indexes, counts := h.Buckets()
+	return ExponentialQuantile(q, h.zeroCount, indexes, counts)
+}
+
+// ExponentialQuantile returns the estimated quantile(0<=q<=1) by zero count and bucket counts with ascending indexes,
+// returns NaN if q is illegal or there is no value.
+func ExponentialQuantile(q, zeroCount float64, indexes []int32, counts []float64) float64 {
+	if q < 0 || q > 1 {
+		return math.NaN()
+	}
+	total := zeroCount
+	for _, count := range counts {
+		total += count
+	}
+	if total <= 0 {
+		return math.NaN()
+	}
+	// lower quantile: find the bucket which contains the value with rank floor(q*(n-1))
+	rank := math.Max(q*(total-1), 0)
+	cumulative := zeroCount
+	if zeroCount > 0 && cumulative > rank {
+		return 0
+	}
+	for i, count := range counts {
+		cumulative += count
+		if count > 0 && cumulative > rank {
+			return ExponentialValue(indexes[i])
+		}
+	}
+	// rank not reached because of float precision, returns the max value
+	for i := len(counts) - 1; i >= 0; i-- {
+		if counts[i] > 0 {
+			return ExponentialValue(indexes[i])
+		}
+	}
+	return 0
+}
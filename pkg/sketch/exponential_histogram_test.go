@@ -0,0 +1,100 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sketch
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialIndex(t *testing.T) {
+	for _, v := range []float64{1e-9, 0.001, 0.5, 1, 1.5, 10, 123.456, 1e9} {
+		index := ExponentialIndex(v)
+		assert.True(t, math.Pow(gamma, float64(index-1)) < v*(1+1e-12))
+		assert.True(t, v <= math.Pow(gamma, float64(index))*(1+1e-12))
+		assert.InDelta(t, v, ExponentialValue(index), v*ExponentialRelativeAccuracy)
+	}
+}
+
+func TestExponentialHistogram_Add(t *testing.T) {
+	h := NewExponentialHistogram()
+	assert.True(t, math.IsNaN(h.Quantile(0.5)))
+	assert.Error(t, h.Add(-1))
+	assert.Error(t, h.Add(math.NaN()))
+	assert.Error(t, h.Add(math.Inf(1)))
+	assert.Error(t, h.AddWithCount(1, -1))
+	assert.Error(t, h.AddWithCount(1, math.Inf(1)))
+	assert.NoError(t, h.Add(0))
+	assert.NoError(t, h.Add(0))
+	assert.NoError(t, h.AddWithCount(10, 2))
+	assert.Equal(t, 4.0, h.Count())
+	assert.Equal(t, 2.0, h.ZeroCount())
+	indexes, counts := h.Buckets()
+	assert.Equal(t, []int32{ExponentialIndex(10)}, indexes)
+	assert.Equal(t, []float64{2}, counts)
+	assert.Equal(t, 0.0, h.Quantile(0))
+	assert.Equal(t, 0.0, h.Quantile(0.3))
+	assert.InDelta(t, 10, h.Quantile(1), 10*ExponentialRelativeAccuracy)
+	assert.True(t, math.IsNaN(h.Quantile(1.1)))
+	assert.True(t, math.IsNaN(h.Quantile(-0.1)))
+
+	h.AddZeroCount(1)
+	h.AddBucket(ExponentialIndex(10), 1)
+	assert.Equal(t, 6.0, h.Count())
+}
+
+func TestExponentialHistogram_Merge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h1 := NewExponentialHistogram()
+	h2 := NewExponentialHistogram()
+	var values []float64
+	for i := 0; i < 10000; i++ {
+		// latency like distribution with long tail
+		v1 := r.ExpFloat64() * 100
+		v2 := math.Exp(r.NormFloat64()*2) * 10
+		assert.NoError(t, h1.Add(v1))
+		assert.NoError(t, h2.Add(v2))
+		values = append(values, v1, v2)
+	}
+	assert.NoError(t, h2.Add(0))
+	values = append(values, 0)
+	h1.Merge(nil)
+	h1.Merge(h2)
+	assert.Equal(t, float64(len(values)), h1.Count())
+
+	// compare against brute-force quantile
+	sort.Float64s(values)
+	for _, q := range []float64{0, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 0.99, 0.999, 1} {
+		expect := values[int(q*float64(len(values)-1))]
+		actual := h1.Quantile(q)
+		assert.InDelta(t, expect, actual, expect*ExponentialRelativeAccuracy*(1+1e-9), "quantile: %f", q)
+	}
+}
+
+func TestExponentialQuantile(t *testing.T) {
+	assert.True(t, math.IsNaN(ExponentialQuantile(0.5, 0, []int32{1}, []float64{0})))
+	// empty bucket skipped
+	assert.Equal(t, ExponentialValue(2), ExponentialQuantile(0.5, 0, []int32{1, 2, 3}, []float64{0, 2, 0}))
+	assert.Equal(t, ExponentialValue(1), ExponentialQuantile(0, 0, []int32{1, 2, 3}, []float64{0.1, 0.2, 0}))
+	// rank not reached
+	assert.Equal(t, ExponentialValue(2), ExponentialQuantile(1, 0, []int32{1, 2, 3}, []float64{1, 2, -0.5}))
+}
@@ -0,0 +1,172 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	// MinPrecision is the min precision of HyperLogLog.
+	MinPrecision uint8 = 4
+	// MaxPrecision is the max precision of HyperLogLog.
+	MaxPrecision uint8 = 18
+	// DefaultPrecision is the default precision of HyperLogLog, 16K registers with ~0.81% standard error.
+	DefaultPrecision uint8 = 14
+)
+
+var (
+	// ErrInvalidPrecision represents the precision out of range error.
+	ErrInvalidPrecision = fmt.Errorf("hyperloglog precision must be in [%d,%d]", MinPrecision, MaxPrecision)
+	// ErrPrecisionMismatch represents cannot merge sketches with different precision.
+	ErrPrecisionMismatch = errors.New("cannot merge hyperloglog with different precision")
+)
+
+// HyperLogLog represents a mergeable sketch for approximate distinct counting.
+// Not thread-safe.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog creates a HyperLogLog with given precision(number of registers = 2^precision).
+func NewHyperLogLog(precision uint8) (*HyperLogLog, error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, ErrInvalidPrecision
+	}
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}, nil
+}
+
+// Precision returns the precision of sketch.
+func (h *HyperLogLog) Precision() uint8 {
+	return h.precision
+}
+
+// Registers returns the registers of sketch.
+func (h *HyperLogLog) Registers() []uint8 {
+	return h.registers
+}
+
+// RelativeError returns the standard error of estimate(1.04/sqrt(m)).
+func (h *HyperLogLog) RelativeError() float64 {
+	return RelativeError(h.precision)
+}
+
+// AddString adds the string value into sketch.
+func (h *HyperLogLog) AddString(value string) {
+	h.AddHash(xxhash.Sum64String(value))
+}
+
+// AddHash adds the 64-bit hash of value into sketch.
+func (h *HyperLogLog) AddHash(hash uint64) {
+	idx := hash >> (64 - h.precision)
+	// set the last bit of remaining for limiting the max number of leading zeros
+	w := hash<<h.precision | 1<<(h.precision-1)
+	rho := uint8(bits.LeadingZeros64(w)) + 1
+	if rho > h.registers[idx] {
+		h.registers[idx] = rho
+	}
+}
+
+// Merge merges other sketch into current sketch.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if other == nil {
+		return nil
+	}
+	if h.precision != other.precision {
+		return ErrPrecisionMismatch
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// Estimate returns the approximate distinct count.
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += 1.0 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// small range correction, use linear counting
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// MarshalBinary returns the binary data of sketch, format: precision(1 byte) + registers.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 1+len(h.registers))
+	data[0] = h.precision
+	copy(data[1:], h.registers)
+	return data, nil
+}
+
+// UnmarshalBinary parses the binary data of sketch.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return ErrInvalidPrecision
+	}
+	precision := data[0]
+	if precision < MinPrecision || precision > MaxPrecision {
+		return ErrInvalidPrecision
+	}
+	if len(data)-1 != 1<<precision {
+		return fmt.Errorf("invalid hyperloglog registers length: %d", len(data)-1)
+	}
+	h.precision = precision
+	h.registers = make([]uint8, len(data)-1)
+	copy(h.registers, data[1:])
+	return nil
+}
+
+// RelativeError returns the standard error of HyperLogLog estimate with given precision.
+func RelativeError(precision uint8) float64 {
+	return 1.04 / math.Sqrt(float64(uint64(1)<<precision))
+}
+
+// alpha returns the bias correction constant for m registers.
+func alpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
@@ -0,0 +1,108 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sketch
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHyperLogLog(t *testing.T) {
+	h, err := NewHyperLogLog(MinPrecision - 1)
+	assert.Equal(t, ErrInvalidPrecision, err)
+	assert.Nil(t, h)
+	h, err = NewHyperLogLog(MaxPrecision + 1)
+	assert.Equal(t, ErrInvalidPrecision, err)
+	assert.Nil(t, h)
+
+	h, err = NewHyperLogLog(DefaultPrecision)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPrecision, h.Precision())
+	assert.Len(t, h.Registers(), 1<<DefaultPrecision)
+	assert.Equal(t, uint64(0), h.Estimate())
+}
+
+func TestHyperLogLog_Estimate(t *testing.T) {
+	for _, precision := range []uint8{MinPrecision, 10, DefaultPrecision} {
+		for _, n := range []int{10, 1000, 50000, 200000} {
+			h, _ := NewHyperLogLog(precision)
+			for i := 0; i < n; i++ {
+				h.AddString("value-" + strconv.Itoa(i))
+				// duplicate values not counted
+				h.AddString("value-" + strconv.Itoa(i))
+			}
+			assertWithinErrorBound(t, uint64(n), h.Estimate(), h.RelativeError())
+		}
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	// simulate values distributed across shards, with overlapping
+	shards := make([]*HyperLogLog, 4)
+	for i := range shards {
+		shards[i], _ = NewHyperLogLog(DefaultPrecision)
+	}
+	n := 100000
+	for i := 0; i < n; i++ {
+		value := "host-" + strconv.Itoa(i)
+		shards[i%4].AddString(value)
+		shards[(i+1)%4].AddString(value)
+	}
+	root, _ := NewHyperLogLog(DefaultPrecision)
+	for _, shard := range shards {
+		assert.NoError(t, root.Merge(shard))
+	}
+	assert.NoError(t, root.Merge(nil))
+	assertWithinErrorBound(t, uint64(n), root.Estimate(), root.RelativeError())
+
+	other, _ := NewHyperLogLog(10)
+	assert.Equal(t, ErrPrecisionMismatch, root.Merge(other))
+}
+
+func TestHyperLogLog_Binary(t *testing.T) {
+	h, _ := NewHyperLogLog(10)
+	for i := 0; i < 5000; i++ {
+		h.AddString(strconv.Itoa(i))
+	}
+	data, err := h.MarshalBinary()
+	assert.NoError(t, err)
+	h2 := &HyperLogLog{}
+	assert.NoError(t, h2.UnmarshalBinary(data))
+	assert.Equal(t, h, h2)
+	assert.Equal(t, h.Estimate(), h2.Estimate())
+
+	assert.Equal(t, ErrInvalidPrecision, h2.UnmarshalBinary(nil))
+	assert.Equal(t, ErrInvalidPrecision, h2.UnmarshalBinary([]byte{1, 2}))
+	assert.Error(t, h2.UnmarshalBinary([]byte{10, 2}))
+}
+
+func TestRelativeError(t *testing.T) {
+	assert.InDelta(t, 0.0081, RelativeError(DefaultPrecision), 0.0001)
+	assert.InDelta(t, 0.26, RelativeError(MinPrecision), 0.0001)
+}
+
+// assertWithinErrorBound asserts estimate within 4 standard errors of exact count(probability > 99.99%).
+func assertWithinErrorBound(t *testing.T, exact, estimate uint64, relativeError float64) {
+	t.Helper()
+	diff := math.Abs(float64(estimate) - float64(exact))
+	assert.LessOrEqualf(t, diff, 4*relativeError*float64(exact)+1,
+		"exact: %d, estimate: %d, relative error: %f", exact, estimate, relativeError)
+}
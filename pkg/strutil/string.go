@@ -19,20 +19,22 @@ package strutil
 
 import (
 	"reflect"
+	"sort"
 	"strings"
 	"unsafe"
 )
 
-// GetStringValue aggregation format function name
+// GetStringValue returns the literal value of quoted(single/double/back quote) string,
+// e.g. `http.requests.total` => http.requests.total.
 func GetStringValue(rawString string) string {
-	if len(rawString) > 0 {
+	if len(rawString) > 1 {
 		if (strings.HasPrefix(rawString, "'") && strings.HasSuffix(rawString, "'")) ||
-			(strings.HasPrefix(rawString, "\"") && strings.HasSuffix(rawString, "\"")) {
+			(strings.HasPrefix(rawString, "\"") && strings.HasSuffix(rawString, "\"")) ||
+			(strings.HasPrefix(rawString, "`") && strings.HasSuffix(rawString, "`")) {
 			return rawString[1 : len(rawString)-1]
 		}
-		return rawString
 	}
-	return ""
+	return rawString
 }
 
 func ByteSlice2String(bytes []byte) string {
@@ -73,3 +75,21 @@ func DeDupStringSlice(items []string) []string {
 	}
 	return dst
 }
+
+// SortedDeDupStringSlice sorts the list in place, removes the duplicated string
+// and keeps at most limit items.
+func SortedDeDupStringSlice(items []string, limit int) []string {
+	sort.Strings(items)
+	n := 0
+	for _, item := range items {
+		if n > 0 && items[n-1] == item {
+			continue
+		}
+		if n >= limit {
+			break
+		}
+		items[n] = item
+		n++
+	}
+	return items[:n]
+}
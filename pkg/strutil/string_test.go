@@ -28,6 +28,9 @@ func Test_GetStringValue(t *testing.T) {
 	assert.Equal(t, "sum", GetStringValue("'sum'"))
 	assert.Equal(t, "'sum", GetStringValue("'sum"))
 	assert.Equal(t, "sum", GetStringValue("\"sum\""))
+	assert.Equal(t, "http.requests.total", GetStringValue("`http.requests.total`"))
+	assert.Equal(t, "`", GetStringValue("`"))
+	assert.Equal(t, "a", GetStringValue("a"))
 	assert.Equal(t, "", GetStringValue(""))
 }
 
@@ -47,6 +50,13 @@ func Test_DeDupString(t *testing.T) {
 	assert.Len(t, DeDupStringSlice([]string{"a", "a", "b", "v"}), 3)
 }
 
+func TestSortedDeDupStringSlice(t *testing.T) {
+	assert.Empty(t, SortedDeDupStringSlice(nil, 10))
+	assert.Empty(t, SortedDeDupStringSlice([]string{"a"}, 0))
+	assert.Equal(t, []string{"a", "b", "v"}, SortedDeDupStringSlice([]string{"v", "a", "b", "a"}, 10))
+	assert.Equal(t, []string{"a", "b"}, SortedDeDupStringSlice([]string{"v", "b", "a", "a"}, 2))
+}
+
 func Test_RandomString(t *testing.T) {
 	t.Log(RandStringBytes(20))
 }
@@ -0,0 +1,117 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package timeutil
+
+import (
+	"time"
+
+	commontimeutil "github.com/lindb/common/pkg/timeutil"
+)
+
+// CalendarUnit represents the unit of calendar interval, the width of calendar bucket varies,
+// e.g. months have 28~31 days, weeks have 167~169 hours when crossing daylight saving time.
+type CalendarUnit int8
+
+// Defines all calendar units.
+const (
+	// CalendarWeek starts from Monday.
+	CalendarWeek CalendarUnit = iota + 1
+	CalendarMonth
+	CalendarYear
+)
+
+// calendarEpoch is the first Monday after unix epoch, weeks are aligned to it.
+var calendarEpoch = time.Date(1970, 1, 5, 0, 0, 0, 0, time.UTC)
+
+// CalendarInterval represents the group by time interval which aligns buckets to calendar, e.g. time(1M).
+type CalendarInterval struct {
+	Unit  CalendarUnit `json:"unit"`
+	Count int          `json:"count"`
+}
+
+// Interval returns the nominal fixed interval, e.g. 1M => 30d.
+func (c *CalendarInterval) Interval() Interval {
+	switch c.Unit {
+	case CalendarWeek:
+		return Interval(int64(c.Count) * commontimeutil.OneWeek)
+	case CalendarMonth:
+		return Interval(int64(c.Count) * commontimeutil.OneMonth)
+	default:
+		return Interval(int64(c.Count) * commontimeutil.OneYear)
+	}
+}
+
+// Truncate returns the start time(midnight in the location of time) of calendar bucket which time belongs to,
+// buckets with multi units are aligned to unix epoch, e.g. 3M buckets start from Jan/Apr/Jul/Oct.
+func (c *CalendarInterval) Truncate(tm time.Time) time.Time {
+	year, month, day := tm.Date()
+	location := tm.Location()
+	switch c.Unit {
+	case CalendarWeek:
+		// calc days based on date, so that daylight saving time doesn't affect the number of days
+		days := int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Sub(calendarEpoch).Hours()) / 24
+		weeks := floorDiv(days, 7)
+		weeks -= floorMod(weeks, c.Count)
+		return time.Date(1970, 1, 5+weeks*7, 0, 0, 0, 0, location)
+	case CalendarMonth:
+		months := year*12 + int(month) - 1
+		months -= floorMod(months, c.Count)
+		return time.Date(months/12, time.Month(months%12+1), 1, 0, 0, 0, 0, location)
+	default:
+		year -= floorMod(year, c.Count)
+		return time.Date(year, 1, 1, 0, 0, 0, 0, location)
+	}
+}
+
+// Buckets returns the start times of calendar buckets which cover the time range,
+// bucket boundaries are aligned to the calendar in location, then shifted by offset.
+func (c *CalendarInterval) Buckets(timeRange TimeRange, offset int64, location *time.Location) []int64 {
+	var buckets []int64
+	bucket := c.Truncate(time.UnixMilli(timeRange.Start - offset).In(location))
+	for bucket.UnixMilli()+offset <= timeRange.End {
+		buckets = append(buckets, bucket.UnixMilli()+offset)
+		bucket = c.next(bucket)
+	}
+	return buckets
+}
+
+// next returns the start time of next calendar bucket.
+func (c *CalendarInterval) next(bucket time.Time) time.Time {
+	switch c.Unit {
+	case CalendarWeek:
+		return bucket.AddDate(0, 0, 7*c.Count)
+	case CalendarMonth:
+		return bucket.AddDate(0, c.Count, 0)
+	default:
+		return bucket.AddDate(c.Count, 0, 0)
+	}
+}
+
+// floorDiv returns the floor of a/b.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// floorMod returns the non-negative remainder of a/b.
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
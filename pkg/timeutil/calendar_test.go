@@ -0,0 +1,82 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	commontimeutil "github.com/lindb/common/pkg/timeutil"
+)
+
+func TestCalendarInterval_Interval(t *testing.T) {
+	assert.Equal(t, Interval(2*commontimeutil.OneWeek), (&CalendarInterval{Unit: CalendarWeek, Count: 2}).Interval())
+	assert.Equal(t, Interval(commontimeutil.OneMonth), (&CalendarInterval{Unit: CalendarMonth, Count: 1}).Interval())
+	assert.Equal(t, Interval(commontimeutil.OneYear), (&CalendarInterval{Unit: CalendarYear, Count: 1}).Interval())
+}
+
+func TestCalendarInterval_Buckets(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	date := func(year int, month time.Month, day int) int64 {
+		return time.Date(year, month, day, 0, 0, 0, 0, location).UnixMilli()
+	}
+	timeRange := TimeRange{Start: date(2023, 1, 15), End: date(2023, 4, 10)}
+
+	t.Run("month", func(t *testing.T) {
+		buckets := (&CalendarInterval{Unit: CalendarMonth, Count: 1}).Buckets(timeRange, 0, location)
+		assert.Equal(t, []int64{date(2023, 1, 1), date(2023, 2, 1), date(2023, 3, 1), date(2023, 4, 1)}, buckets)
+		// 31 days vs 28 days month
+		assert.Equal(t, 31*commontimeutil.OneDay, buckets[1]-buckets[0])
+		assert.Equal(t, 28*commontimeutil.OneDay, buckets[2]-buckets[1])
+		// daylight saving time starts at 2023-03-12
+		assert.Equal(t, 31*commontimeutil.OneDay-commontimeutil.OneHour, buckets[3]-buckets[2])
+	})
+	t.Run("quarter", func(t *testing.T) {
+		buckets := (&CalendarInterval{Unit: CalendarMonth, Count: 3}).Buckets(timeRange, 0, location)
+		assert.Equal(t, []int64{date(2023, 1, 1), date(2023, 4, 1)}, buckets)
+	})
+	t.Run("week", func(t *testing.T) {
+		buckets := (&CalendarInterval{Unit: CalendarWeek, Count: 1}).Buckets(
+			TimeRange{Start: date(2023, 3, 8), End: date(2023, 3, 14)}, 0, location)
+		// weeks start from monday
+		assert.Equal(t, []int64{date(2023, 3, 6), date(2023, 3, 13)}, buckets)
+		assert.Equal(t, commontimeutil.OneWeek-commontimeutil.OneHour, buckets[1]-buckets[0])
+		assert.Equal(t, time.Monday, time.UnixMilli(buckets[0]).In(location).Weekday())
+	})
+	t.Run("year", func(t *testing.T) {
+		buckets := (&CalendarInterval{Unit: CalendarYear, Count: 1}).Buckets(
+			TimeRange{Start: date(2023, 6, 1), End: date(2024, 6, 1)}, 0, location)
+		assert.Equal(t, []int64{date(2023, 1, 1), date(2024, 1, 1)}, buckets)
+	})
+	t.Run("with offset", func(t *testing.T) {
+		buckets := (&CalendarInterval{Unit: CalendarMonth, Count: 1}).Buckets(
+			TimeRange{Start: date(2023, 2, 1), End: date(2023, 2, 20)}, 8*commontimeutil.OneHour, location)
+		// 2023-02-01 00:00 belongs to the bucket starting at 2023-01-01 08:00
+		assert.Equal(t, []int64{date(2023, 1, 1) + 8*commontimeutil.OneHour, date(2023, 2, 1) + 8*commontimeutil.OneHour}, buckets)
+	})
+}
+
+func TestFloorDiv(t *testing.T) {
+	assert.Equal(t, 1, floorDiv(7, 7))
+	assert.Equal(t, -1, floorDiv(-1, 7))
+	assert.Equal(t, 6, floorMod(-1, 7))
+	assert.Equal(t, 0, floorMod(14, 7))
+}
@@ -17,11 +17,47 @@
 
 package timeutil
 
+import (
+	"strings"
+	"time"
+
+	commontimeutil "github.com/lindb/common/pkg/timeutil"
+)
+
 // Truncate truncates timestamp based on interval
 func Truncate(timestamp, interval int64) int64 {
 	return timestamp / interval * interval
 }
 
+// TruncateInLocation truncates timestamp based on interval in the time zone of location,
+// e.g. 1d interval truncates timestamp to the midnight of location.
+func TruncateInLocation(timestamp, interval int64, location *time.Location) int64 {
+	_, offset := time.UnixMilli(timestamp).In(location).Zone()
+	offsetMillis := int64(offset) * 1000
+	return Truncate(timestamp+offsetMillis, interval) - offsetMillis
+}
+
+// ParseTimestampInLocation parses timestamp str as the time in the time zone of location,
+// returns the millisecond of timestamp, layout is detected like ParseTimestamp of common package.
+func ParseTimestampInLocation(timestampStr string, location *time.Location) (int64, error) {
+	var format string
+	switch {
+	case strings.Index(timestampStr, "-") > 0:
+		format = commontimeutil.DataTimeFormat2
+	case strings.Index(timestampStr, "/") > 0:
+		format = commontimeutil.DataTimeFormat3
+	case strings.Index(timestampStr, " ") > 0:
+		format = commontimeutil.DataTimeFormat1
+	default:
+		format = commontimeutil.DataTimeFormat4
+	}
+	tm, err := time.ParseInLocation(format, timestampStr, location)
+	if err != nil {
+		return 0, err
+	}
+	return tm.UnixMilli(), nil
+}
+
 // CalPointCount calculates point counts between start time and end time by interval
 func CalPointCount(startTime, endTime, interval int64) int {
 	diff := endTime - startTime
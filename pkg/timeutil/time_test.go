@@ -19,6 +19,7 @@ package timeutil
 
 import (
 	"testing"
+	"time"
 
 	"github.com/lindb/common/pkg/timeutil"
 	"github.com/stretchr/testify/assert"
@@ -47,3 +48,28 @@ func TestTruncate(t *testing.T) {
 	t1, _ = timeutil.ParseTimestamp("20190702 19:10:00", "20060102 15:04:05")
 	assert.Equal(t, t1, Truncate(now, 10*timeutil.OneMinute))
 }
+
+func TestTruncateInLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	now := time.Date(2024, 1, 1, 22, 30, 0, 0, location).UnixMilli()
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, location).UnixMilli()
+	assert.Equal(t, midnight, TruncateInLocation(now, timeutil.OneDay, location))
+	assert.Equal(t, Truncate(now, timeutil.OneDay), TruncateInLocation(now, timeutil.OneDay, time.UTC))
+}
+
+func TestParseTimestampInLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	expect := time.Date(2024, 1, 1, 10, 11, 10, 0, location).UnixMilli()
+	for _, str := range []string{"2024-01-01 10:11:10", "2024/01/01 10:11:10", "20240101 10:11:10", "20240101101110"} {
+		timestamp, err := ParseTimestampInLocation(str, location)
+		assert.NoError(t, err)
+		assert.Equal(t, expect, timestamp)
+	}
+	timestamp, err := ParseTimestampInLocation("2024-01-01 10:11:10", time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, expect-5*timeutil.OneHour, timestamp)
+	_, err = ParseTimestampInLocation("2024-01-01", location)
+	assert.Error(t, err)
+}
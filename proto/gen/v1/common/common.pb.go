@@ -15,6 +15,7 @@
 // specific language governing permissions and limitations
 // under the License.
 
+
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: common.proto
 
@@ -48,16 +49,19 @@ type RequestType int32
 const (
 	RequestType_Data     RequestType = 0
 	RequestType_Metadata RequestType = 1
+	RequestType_Cancel   RequestType = 2
 )
 
 var RequestType_name = map[int32]string{
 	0: "Data",
 	1: "Metadata",
+	2: "Cancel",
 }
 
 var RequestType_value = map[string]int32{
 	"Data":     0,
 	"Metadata": 1,
+	"Cancel":   2,
 }
 
 func (x RequestType) String() string {
@@ -68,14 +72,67 @@ func (RequestType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_555bd8c177793206, []int{0}
 }
 
+type CompressType int32
+
+const (
+	CompressType_NoCompress CompressType = 0
+	CompressType_Snappy     CompressType = 1
+)
+
+var CompressType_name = map[int32]string{
+	0: "NoCompress",
+	1: "Snappy",
+}
+
+var CompressType_value = map[string]int32{
+	"NoCompress": 0,
+	"Snappy":     1,
+}
+
+func (x CompressType) String() string {
+	return proto.EnumName(CompressType_name, int32(x))
+}
+
+func (CompressType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{1}
+}
+
+type PlanEncoding int32
+
+const (
+	PlanEncoding_JSONPlan  PlanEncoding = 0
+	PlanEncoding_ProtoPlan PlanEncoding = 1
+)
+
+var PlanEncoding_name = map[int32]string{
+	0: "JSONPlan",
+	1: "ProtoPlan",
+}
+
+var PlanEncoding_value = map[string]int32{
+	"JSONPlan":  0,
+	"ProtoPlan": 1,
+}
+
+func (x PlanEncoding) String() string {
+	return proto.EnumName(PlanEncoding_name, int32(x))
+}
+
+func (PlanEncoding) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{2}
+}
+
 type TaskRequest struct {
-	RequestID            string      `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
-	RequestType          RequestType `protobuf:"varint,3,opt,name=requestType,proto3,enum=protoCommonV1.RequestType" json:"requestType,omitempty"`
-	PhysicalPlan         []byte      `protobuf:"bytes,4,opt,name=physicalPlan,proto3" json:"physicalPlan,omitempty"`
-	Payload              []byte      `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	RequestID            string       `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	RequestType          RequestType  `protobuf:"varint,3,opt,name=requestType,proto3,enum=protoCommonV1.RequestType" json:"requestType,omitempty"`
+	PhysicalPlan         []byte       `protobuf:"bytes,4,opt,name=physicalPlan,proto3" json:"physicalPlan,omitempty"`
+	Payload              []byte       `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Compress             CompressType `protobuf:"varint,6,opt,name=compress,proto3,enum=protoCommonV1.CompressType" json:"compress,omitempty"`
+	AcceptCompress       CompressType `protobuf:"varint,7,opt,name=acceptCompress,proto3,enum=protoCommonV1.CompressType" json:"acceptCompress,omitempty"`
+	PlanEncoding         PlanEncoding `protobuf:"varint,8,opt,name=planEncoding,proto3,enum=protoCommonV1.PlanEncoding" json:"planEncoding,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *TaskRequest) Reset()         { *m = TaskRequest{} }
@@ -139,17 +196,41 @@ func (m *TaskRequest) GetPayload() []byte {
 	return nil
 }
 
+func (m *TaskRequest) GetCompress() CompressType {
+	if m != nil {
+		return m.Compress
+	}
+	return CompressType_NoCompress
+}
+
+func (m *TaskRequest) GetAcceptCompress() CompressType {
+	if m != nil {
+		return m.AcceptCompress
+	}
+	return CompressType_NoCompress
+}
+
+func (m *TaskRequest) GetPlanEncoding() PlanEncoding {
+	if m != nil {
+		return m.PlanEncoding
+	}
+	return PlanEncoding_JSONPlan
+}
+
 type TaskResponse struct {
-	RequestID            string      `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
-	RequestType          RequestType `protobuf:"varint,2,opt,name=requestType,proto3,enum=protoCommonV1.RequestType" json:"requestType,omitempty"`
-	Completed            bool        `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
-	ErrMsg               string      `protobuf:"bytes,4,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
-	SendTime             int64       `protobuf:"varint,5,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
-	Payload              []byte      `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
-	Stats                []byte      `protobuf:"bytes,7,opt,name=stats,proto3" json:"stats,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	RequestID            string       `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	RequestType          RequestType  `protobuf:"varint,2,opt,name=requestType,proto3,enum=protoCommonV1.RequestType" json:"requestType,omitempty"`
+	Completed            bool         `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	ErrMsg               string       `protobuf:"bytes,4,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
+	SendTime             int64        `protobuf:"varint,5,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
+	Payload              []byte       `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	Stats                []byte       `protobuf:"bytes,7,opt,name=stats,proto3" json:"stats,omitempty"`
+	Coverage             []byte       `protobuf:"bytes,8,opt,name=coverage,proto3" json:"coverage,omitempty"`
+	Compress             CompressType `protobuf:"varint,9,opt,name=compress,proto3,enum=protoCommonV1.CompressType" json:"compress,omitempty"`
+	ErrCode              string       `protobuf:"bytes,10,opt,name=errCode,proto3" json:"errCode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *TaskResponse) Reset()         { *m = TaskResponse{} }
@@ -234,6 +315,27 @@ func (m *TaskResponse) GetStats() []byte {
 	return nil
 }
 
+func (m *TaskResponse) GetCoverage() []byte {
+	if m != nil {
+		return m.Coverage
+	}
+	return nil
+}
+
+func (m *TaskResponse) GetCompress() CompressType {
+	if m != nil {
+		return m.Compress
+	}
+	return CompressType_NoCompress
+}
+
+func (m *TaskResponse) GetErrCode() string {
+	if m != nil {
+		return m.ErrCode
+	}
+	return ""
+}
+
 type TimeSeriesList struct {
 	Start                int64             `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
 	End                  int64             `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
@@ -431,54 +533,207 @@ func (m *AggregatorSpec) GetFuncTypeList() []uint32 {
 	return nil
 }
 
+type PhysicalPlan struct {
+	Database             string        `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
+	Targets              []*PlanTarget `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	Receivers            []string      `protobuf:"bytes,3,rep,name=receivers,proto3" json:"receivers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PhysicalPlan) Reset()         { *m = PhysicalPlan{} }
+func (m *PhysicalPlan) String() string { return proto.CompactTextString(m) }
+func (*PhysicalPlan) ProtoMessage()    {}
+func (*PhysicalPlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{5}
+}
+func (m *PhysicalPlan) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PhysicalPlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PhysicalPlan.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PhysicalPlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PhysicalPlan.Merge(m, src)
+}
+func (m *PhysicalPlan) XXX_Size() int {
+	return m.Size()
+}
+func (m *PhysicalPlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_PhysicalPlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PhysicalPlan proto.InternalMessageInfo
+
+func (m *PhysicalPlan) GetDatabase() string {
+	if m != nil {
+		return m.Database
+	}
+	return ""
+}
+
+func (m *PhysicalPlan) GetTargets() []*PlanTarget {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func (m *PhysicalPlan) GetReceivers() []string {
+	if m != nil {
+		return m.Receivers
+	}
+	return nil
+}
+
+type PlanTarget struct {
+	ReceiveOnly          bool     `protobuf:"varint,1,opt,name=receiveOnly,proto3" json:"receiveOnly,omitempty"`
+	Indicator            string   `protobuf:"bytes,2,opt,name=indicator,proto3" json:"indicator,omitempty"`
+	ShardIDs             []int32  `protobuf:"varint,3,rep,packed,name=shardIDs,proto3" json:"shardIDs,omitempty"`
+	Limit                int32    `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlanTarget) Reset()         { *m = PlanTarget{} }
+func (m *PlanTarget) String() string { return proto.CompactTextString(m) }
+func (*PlanTarget) ProtoMessage()    {}
+func (*PlanTarget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{6}
+}
+func (m *PlanTarget) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PlanTarget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PlanTarget.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PlanTarget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanTarget.Merge(m, src)
+}
+func (m *PlanTarget) XXX_Size() int {
+	return m.Size()
+}
+func (m *PlanTarget) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanTarget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanTarget proto.InternalMessageInfo
+
+func (m *PlanTarget) GetReceiveOnly() bool {
+	if m != nil {
+		return m.ReceiveOnly
+	}
+	return false
+}
+
+func (m *PlanTarget) GetIndicator() string {
+	if m != nil {
+		return m.Indicator
+	}
+	return ""
+}
+
+func (m *PlanTarget) GetShardIDs() []int32 {
+	if m != nil {
+		return m.ShardIDs
+	}
+	return nil
+}
+
+func (m *PlanTarget) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("protoCommonV1.RequestType", RequestType_name, RequestType_value)
+	proto.RegisterEnum("protoCommonV1.CompressType", CompressType_name, CompressType_value)
+	proto.RegisterEnum("protoCommonV1.PlanEncoding", PlanEncoding_name, PlanEncoding_value)
 	proto.RegisterType((*TaskRequest)(nil), "protoCommonV1.TaskRequest")
 	proto.RegisterType((*TaskResponse)(nil), "protoCommonV1.TaskResponse")
 	proto.RegisterType((*TimeSeriesList)(nil), "protoCommonV1.TimeSeriesList")
 	proto.RegisterType((*TimeSeries)(nil), "protoCommonV1.TimeSeries")
 	proto.RegisterMapType((map[string][]byte)(nil), "protoCommonV1.TimeSeries.FieldsEntry")
 	proto.RegisterType((*AggregatorSpec)(nil), "protoCommonV1.AggregatorSpec")
+	proto.RegisterType((*PhysicalPlan)(nil), "protoCommonV1.PhysicalPlan")
+	proto.RegisterType((*PlanTarget)(nil), "protoCommonV1.PlanTarget")
 }
 
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 537 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xcd, 0x8e, 0xd3, 0x30,
-	0x10, 0xae, 0x9b, 0xb6, 0xdb, 0x4e, 0xd3, 0xaa, 0xb2, 0x10, 0x0a, 0x65, 0xa9, 0xaa, 0x48, 0x2b,
-	0x45, 0x1c, 0x2a, 0x28, 0x17, 0x40, 0x70, 0x28, 0xbb, 0xfc, 0x49, 0x2c, 0x42, 0x6e, 0xb5, 0x77,
-	0x93, 0xcc, 0x86, 0x68, 0xd3, 0x24, 0xd8, 0x6e, 0xa5, 0xbe, 0x09, 0xe2, 0x05, 0x78, 0x15, 0x8e,
-	0x3c, 0x00, 0x07, 0x54, 0xae, 0x3c, 0x04, 0xb2, 0x93, 0x6d, 0x9a, 0x0a, 0x0e, 0x7b, 0xca, 0x7c,
-	0x9f, 0x67, 0xc6, 0xdf, 0x4c, 0x3e, 0x83, 0xed, 0xa7, 0xcb, 0x65, 0x9a, 0x4c, 0x32, 0x91, 0xaa,
-	0x94, 0xf6, 0xcc, 0xe7, 0xd4, 0x50, 0x17, 0x0f, 0xdd, 0x6f, 0x04, 0xba, 0x0b, 0x2e, 0xaf, 0x18,
-	0x7e, 0x5e, 0xa1, 0x54, 0xf4, 0x18, 0x3a, 0x22, 0x0f, 0xdf, 0x9e, 0x39, 0x64, 0x4c, 0xbc, 0x0e,
-	0x2b, 0x09, 0xfa, 0x0c, 0xba, 0x05, 0x58, 0x6c, 0x32, 0x74, 0xac, 0x31, 0xf1, 0xfa, 0xd3, 0xe1,
-	0xa4, 0xd2, 0x72, 0xc2, 0xca, 0x0c, 0xb6, 0x9f, 0x4e, 0x5d, 0xb0, 0xb3, 0x4f, 0x1b, 0x19, 0xf9,
-	0x3c, 0xfe, 0x10, 0xf3, 0xc4, 0x69, 0x8c, 0x89, 0x67, 0xb3, 0x0a, 0x47, 0x1d, 0x38, 0xca, 0xf8,
-	0x26, 0x4e, 0x79, 0xe0, 0x34, 0xcd, 0xf1, 0x35, 0x74, 0xff, 0x10, 0xb0, 0x73, 0xa5, 0x32, 0x4b,
-	0x13, 0x89, 0x37, 0x93, 0x5a, 0xbf, 0x99, 0xd4, 0x63, 0xe8, 0xf8, 0xe9, 0x32, 0x8b, 0x51, 0x61,
-	0x60, 0xc6, 0x6c, 0xb3, 0x92, 0xa0, 0xb7, 0xa1, 0x85, 0x42, 0x9c, 0xcb, 0xd0, 0x8c, 0xd0, 0x61,
-	0x05, 0xa2, 0x43, 0x68, 0x4b, 0x4c, 0x82, 0x45, 0xb4, 0x44, 0xa3, 0xde, 0x62, 0x3b, 0xbc, 0x3f,
-	0x58, 0xab, 0x32, 0x18, 0xbd, 0x05, 0x4d, 0xa9, 0xb8, 0x92, 0xce, 0x91, 0xe1, 0x73, 0xe0, 0xfe,
-	0x24, 0xd0, 0xd7, 0x85, 0x73, 0x14, 0x11, 0xca, 0x77, 0x91, 0x54, 0x45, 0xa2, 0x50, 0x66, 0x58,
-	0x8b, 0xe5, 0x80, 0x0e, 0xc0, 0xc2, 0x24, 0x30, 0x03, 0x5a, 0x4c, 0x87, 0x5a, 0x46, 0x94, 0x28,
-	0x14, 0x6b, 0x1e, 0x1b, 0xed, 0x16, 0xdb, 0x61, 0x3a, 0x83, 0xbe, 0xaa, 0x74, 0x75, 0x1a, 0x63,
-	0xcb, 0xeb, 0x4e, 0xef, 0x1c, 0x6c, 0xa6, 0xbc, 0x9a, 0x1d, 0x14, 0xd0, 0x53, 0xe8, 0x5d, 0x46,
-	0x18, 0x07, 0xb3, 0x30, 0x9c, 0x67, 0xe8, 0x4b, 0xa7, 0x69, 0x3a, 0xdc, 0x3b, 0xe8, 0x30, 0x0b,
-	0x43, 0x81, 0x21, 0x57, 0xa9, 0xd0, 0x59, 0xac, 0x5a, 0xe3, 0x7e, 0x25, 0x00, 0xe5, 0x1d, 0x94,
-	0x42, 0x43, 0xf1, 0x50, 0x16, 0xbf, 0xd1, 0xc4, 0xf4, 0x39, 0xb4, 0x4c, 0x8d, 0x74, 0xea, 0xe6,
-	0x82, 0x93, 0xff, 0x4a, 0x9c, 0xbc, 0x32, 0x79, 0x2f, 0x13, 0x25, 0x36, 0xac, 0x28, 0x1a, 0x3e,
-	0x81, 0xee, 0x1e, 0xad, 0xd7, 0x74, 0x85, 0x9b, 0xe2, 0x02, 0x1d, 0xea, 0x75, 0xae, 0x79, 0xbc,
-	0xca, 0xbd, 0x61, 0xb3, 0x1c, 0x3c, 0xad, 0x3f, 0x26, 0x6e, 0x06, 0xfd, 0xaa, 0x7a, 0xed, 0x07,
-	0xd3, 0xf6, 0x3d, 0x5f, 0xe2, 0xb5, 0xd7, 0x76, 0xc4, 0xee, 0x74, 0xe7, 0xb4, 0x1e, 0x2b, 0x09,
-	0x6d, 0xfb, 0xcb, 0x55, 0xe2, 0xeb, 0xd8, 0x2c, 0xdc, 0x1a, 0x5b, 0x5e, 0x8f, 0x55, 0xb8, 0xfb,
-	0x27, 0xd0, 0xdd, 0xf3, 0x22, 0x6d, 0x43, 0xe3, 0x8c, 0x2b, 0x3e, 0xa8, 0x51, 0x1b, 0xda, 0xe7,
-	0xa8, 0x78, 0xa0, 0x11, 0x99, 0x5e, 0xe4, 0x8f, 0x75, 0x8e, 0x62, 0x1d, 0xf9, 0x48, 0x5f, 0x43,
-	0xeb, 0x0d, 0x4f, 0x82, 0x18, 0xe9, 0xa1, 0xb1, 0xf7, 0x9e, 0xf4, 0xf0, 0xee, 0x3f, 0xcf, 0xf2,
-	0x47, 0xe4, 0xd6, 0x3c, 0xf2, 0x80, 0xbc, 0x18, 0x7c, 0xdf, 0x8e, 0xc8, 0x8f, 0xed, 0x88, 0xfc,
-	0xda, 0x8e, 0xc8, 0x97, 0xdf, 0xa3, 0xda, 0xc7, 0x96, 0xa9, 0x79, 0xf4, 0x37, 0x00, 0x00, 0xff,
-	0xff, 0x8d, 0x33, 0xad, 0xda, 0x3d, 0x04, 0x00, 0x00,
+	// 781 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdd, 0x6a, 0xe3, 0x46,
+	0x14, 0xb6, 0xac, 0xd8, 0x91, 0x8e, 0x65, 0x23, 0x86, 0x52, 0xb4, 0xde, 0x6d, 0x30, 0x82, 0x82,
+	0x49, 0x21, 0x74, 0xb3, 0x17, 0xfd, 0xa1, 0xa5, 0xa4, 0xce, 0xb6, 0xdd, 0xd2, 0xcd, 0x86, 0xb1,
+	0xd9, 0xfb, 0x59, 0xe9, 0xac, 0x56, 0x44, 0x1e, 0xa9, 0x33, 0x13, 0x83, 0x2f, 0xda, 0xe7, 0x28,
+	0x7d, 0xa2, 0x5e, 0xf6, 0xbe, 0xbd, 0x28, 0x29, 0xf4, 0x39, 0xca, 0x8c, 0xfe, 0x4d, 0x4a, 0xc9,
+	0x95, 0xe7, 0xfb, 0xce, 0xaf, 0xbe, 0x33, 0x67, 0x0c, 0x5e, 0x94, 0x6f, 0xb7, 0x39, 0x3f, 0x2b,
+	0x44, 0xae, 0x72, 0x32, 0x35, 0x3f, 0x2b, 0x43, 0xbd, 0x7e, 0x1a, 0xfe, 0x33, 0x84, 0xc9, 0x86,
+	0xc9, 0x1b, 0x8a, 0x3f, 0xde, 0xa2, 0x54, 0xe4, 0x09, 0xb8, 0xa2, 0x3c, 0xbe, 0xb8, 0x0c, 0xac,
+	0x85, 0xb5, 0x74, 0x69, 0x4b, 0x90, 0x2f, 0x60, 0x52, 0x81, 0xcd, 0xbe, 0xc0, 0xc0, 0x5e, 0x58,
+	0xcb, 0xd9, 0xf9, 0xfc, 0xac, 0x97, 0xf2, 0x8c, 0xb6, 0x1e, 0xb4, 0xeb, 0x4e, 0x42, 0xf0, 0x8a,
+	0x77, 0x7b, 0x99, 0x46, 0x2c, 0xbb, 0xce, 0x18, 0x0f, 0x8e, 0x16, 0xd6, 0xd2, 0xa3, 0x3d, 0x8e,
+	0x04, 0x70, 0x5c, 0xb0, 0x7d, 0x96, 0xb3, 0x38, 0x18, 0x19, 0x73, 0x0d, 0xc9, 0x27, 0xe0, 0x44,
+	0xf9, 0xb6, 0x10, 0x28, 0x65, 0x30, 0x36, 0x85, 0x1f, 0x1f, 0x14, 0x5e, 0x55, 0x66, 0x53, 0xb9,
+	0x71, 0x26, 0x2b, 0x98, 0xb1, 0x28, 0xc2, 0x42, 0xd5, 0xf6, 0xe0, 0xf8, 0xff, 0xc3, 0x0f, 0x42,
+	0xc8, 0x57, 0xe0, 0x15, 0x19, 0xe3, 0xcf, 0x79, 0x94, 0xc7, 0x29, 0x4f, 0x02, 0xe7, 0xde, 0x14,
+	0xd7, 0x1d, 0x17, 0xda, 0x0b, 0x08, 0xff, 0x18, 0x82, 0x57, 0x0a, 0x2d, 0x8b, 0x9c, 0x4b, 0x7c,
+	0x98, 0xd2, 0xc3, 0x87, 0x29, 0xfd, 0x04, 0x5c, 0xfd, 0xf9, 0x19, 0x2a, 0x8c, 0xcd, 0x94, 0x1c,
+	0xda, 0x12, 0xe4, 0x7d, 0x18, 0xa3, 0x10, 0x2f, 0x65, 0x62, 0x26, 0xe0, 0xd2, 0x0a, 0x91, 0x39,
+	0x38, 0x12, 0x79, 0xbc, 0x49, 0xb7, 0x68, 0xc4, 0xb7, 0x69, 0x83, 0xbb, 0x73, 0x19, 0xf7, 0xe7,
+	0xf2, 0x1e, 0x8c, 0xa4, 0x62, 0xaa, 0x54, 0xd5, 0xa3, 0x25, 0xd0, 0xb9, 0xa2, 0x7c, 0x87, 0x82,
+	0x25, 0x68, 0xb4, 0xf2, 0x68, 0x83, 0x7b, 0x93, 0x74, 0x1f, 0x32, 0xc9, 0x00, 0x8e, 0x51, 0x88,
+	0x55, 0x1e, 0x63, 0x00, 0xa6, 0xf3, 0x1a, 0x86, 0x7f, 0x5a, 0x30, 0xd3, 0x7d, 0xae, 0x51, 0xa4,
+	0x28, 0x7f, 0x48, 0xa5, 0xaa, 0xfa, 0x12, 0xca, 0x68, 0x6b, 0xd3, 0x12, 0x10, 0x1f, 0x6c, 0xe4,
+	0xb1, 0xd1, 0xd3, 0xa6, 0xfa, 0xa8, 0x3b, 0x4d, 0xb9, 0x42, 0xb1, 0x63, 0x99, 0x91, 0xca, 0xa6,
+	0x0d, 0x26, 0x17, 0x30, 0x53, 0xbd, 0xac, 0xc1, 0xd1, 0xc2, 0x5e, 0x4e, 0xce, 0x1f, 0x1d, 0xf4,
+	0xdb, 0x96, 0xa6, 0x07, 0x01, 0x64, 0x05, 0xd3, 0xb7, 0x29, 0x66, 0xf1, 0x45, 0x92, 0xac, 0x0b,
+	0x8c, 0x64, 0x30, 0x32, 0x19, 0x3e, 0x38, 0xc8, 0x70, 0x91, 0x24, 0x02, 0x13, 0xa6, 0x72, 0xa1,
+	0xbd, 0x68, 0x3f, 0x26, 0xfc, 0xd5, 0x02, 0x68, 0x6b, 0x10, 0x02, 0x47, 0x8a, 0x25, 0xb2, 0xba,
+	0x35, 0xe6, 0x4c, 0xbe, 0x84, 0xb1, 0x89, 0x91, 0xc1, 0xd0, 0x14, 0xf8, 0xf0, 0x3f, 0x5b, 0x3c,
+	0xfb, 0xc6, 0xf8, 0x3d, 0xe7, 0x4a, 0xec, 0x69, 0x15, 0x34, 0xff, 0x0c, 0x26, 0x1d, 0x5a, 0xcb,
+	0x74, 0x83, 0xfb, 0xaa, 0x80, 0x3e, 0x6a, 0x39, 0x77, 0x2c, 0xbb, 0x2d, 0xaf, 0xa2, 0x47, 0x4b,
+	0xf0, 0xf9, 0xf0, 0x53, 0x2b, 0x2c, 0x60, 0xd6, 0xef, 0x5e, 0x5f, 0x3f, 0x93, 0xf6, 0x8a, 0x6d,
+	0xb1, 0xbe, 0xda, 0x0d, 0xd1, 0x58, 0x9b, 0x8b, 0x3d, 0xa5, 0x2d, 0xa1, 0x1f, 0x89, 0xb7, 0xb7,
+	0x3c, 0xd2, 0x67, 0x23, 0xb8, 0xbd, 0xb0, 0x97, 0x53, 0xda, 0xe3, 0xc2, 0x9f, 0xc0, 0xbb, 0xee,
+	0x3e, 0x1a, 0x73, 0x70, 0x62, 0xa6, 0xd8, 0x1b, 0x26, 0xeb, 0x72, 0x0d, 0x26, 0xcf, 0xe0, 0x58,
+	0x31, 0x91, 0xa0, 0xaa, 0x85, 0x79, 0x74, 0xcf, 0xce, 0x6e, 0x8c, 0x07, 0xad, 0x3d, 0xcb, 0xdd,
+	0x8c, 0x30, 0xdd, 0xa1, 0x90, 0xa6, 0x03, 0x97, 0xb6, 0x44, 0xf8, 0x33, 0x40, 0x1b, 0x44, 0x16,
+	0x30, 0xa9, 0x4c, 0xaf, 0x78, 0x56, 0x4a, 0xe6, 0xd0, 0x2e, 0xa5, 0xb3, 0xa5, 0x3c, 0x4e, 0x23,
+	0xad, 0x8f, 0xf9, 0x60, 0x97, 0xb6, 0x84, 0xd9, 0xba, 0x77, 0x4c, 0xc4, 0x2f, 0x2e, 0xcb, 0x52,
+	0x23, 0xda, 0x60, 0x2d, 0x7a, 0x96, 0x6e, 0x53, 0x65, 0x16, 0x75, 0x44, 0x4b, 0x70, 0xfa, 0x14,
+	0x26, 0x9d, 0xcd, 0x27, 0x0e, 0x1c, 0x5d, 0x32, 0xc5, 0xfc, 0x01, 0xf1, 0xc0, 0x79, 0x89, 0x8a,
+	0xe9, 0x6f, 0xf7, 0x2d, 0x02, 0x30, 0x5e, 0x31, 0x1e, 0x61, 0xe6, 0x0f, 0x4f, 0x4f, 0xc1, 0xeb,
+	0xee, 0x14, 0x99, 0x01, 0x5c, 0xe5, 0x35, 0xe3, 0x0f, 0xb4, 0xef, 0x9a, 0xb3, 0xa2, 0xd8, 0xfb,
+	0xd6, 0xe9, 0x47, 0xe0, 0x75, 0xdf, 0x31, 0x9d, 0xf5, 0xfb, 0xf5, 0xab, 0x2b, 0xcd, 0xf9, 0x03,
+	0x32, 0x05, 0xf7, 0x5a, 0xeb, 0x67, 0xa0, 0x75, 0xfe, 0xba, 0xfc, 0xfb, 0x58, 0xa3, 0xd8, 0xa5,
+	0x11, 0x92, 0x6f, 0x61, 0xfc, 0x1d, 0xe3, 0x71, 0x86, 0xe4, 0xf0, 0xad, 0xea, 0xfc, 0xc9, 0xcc,
+	0x1f, 0xdf, 0x6b, 0x2b, 0xdf, 0xc5, 0x70, 0xb0, 0xb4, 0x3e, 0xb6, 0xbe, 0xf6, 0x7f, 0xbb, 0x3b,
+	0xb1, 0x7e, 0xbf, 0x3b, 0xb1, 0xfe, 0xba, 0x3b, 0xb1, 0x7e, 0xf9, 0xfb, 0x64, 0xf0, 0x66, 0x6c,
+	0x62, 0x9e, 0xfd, 0x3b, 0x00, 0x5e, 0x2f, 0x49, 0xf0, 0xcf, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -617,6 +872,21 @@ func (m *TaskRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.PlanEncoding != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.PlanEncoding))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.AcceptCompress != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.AcceptCompress))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Compress != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.Compress))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.Payload) > 0 {
 		i -= len(m.Payload)
 		copy(dAtA[i:], m.Payload)
@@ -670,6 +940,25 @@ func (m *TaskResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ErrCode) > 0 {
+		i -= len(m.ErrCode)
+		copy(dAtA[i:], m.ErrCode)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.ErrCode)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Compress != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.Compress))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Coverage) > 0 {
+		i -= len(m.Coverage)
+		copy(dAtA[i:], m.Coverage)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Coverage)))
+		i--
+		dAtA[i] = 0x42
+	}
 	if len(m.Stats) > 0 {
 		i -= len(m.Stats)
 		copy(dAtA[i:], m.Stats)
@@ -903,6 +1192,131 @@ func (m *AggregatorSpec) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *PhysicalPlan) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PhysicalPlan) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PhysicalPlan) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Receivers) > 0 {
+		for iNdEx := len(m.Receivers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Receivers[iNdEx])
+			copy(dAtA[i:], m.Receivers[iNdEx])
+			i = encodeVarintCommon(dAtA, i, uint64(len(m.Receivers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Targets) > 0 {
+		for iNdEx := len(m.Targets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Targets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCommon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Database) > 0 {
+		i -= len(m.Database)
+		copy(dAtA[i:], m.Database)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Database)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PlanTarget) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlanTarget) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PlanTarget) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Limit != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ShardIDs) > 0 {
+		dAtA4 := make([]byte, len(m.ShardIDs)*10)
+		var j3 int
+		for _, num1 := range m.ShardIDs {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintCommon(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Indicator) > 0 {
+		i -= len(m.Indicator)
+		copy(dAtA[i:], m.Indicator)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Indicator)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ReceiveOnly {
+		i--
+		if m.ReceiveOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintCommon(dAtA []byte, offset int, v uint64) int {
 	offset -= sovCommon(v)
 	base := offset
@@ -935,6 +1349,15 @@ func (m *TaskRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCommon(uint64(l))
 	}
+	if m.Compress != 0 {
+		n += 1 + sovCommon(uint64(m.Compress))
+	}
+	if m.AcceptCompress != 0 {
+		n += 1 + sovCommon(uint64(m.AcceptCompress))
+	}
+	if m.PlanEncoding != 0 {
+		n += 1 + sovCommon(uint64(m.PlanEncoding))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -972,6 +1395,17 @@ func (m *TaskResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCommon(uint64(l))
 	}
+	l = len(m.Coverage)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if m.Compress != 0 {
+		n += 1 + sovCommon(uint64(m.Compress))
+	}
+	l = len(m.ErrCode)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1065,23 +1499,80 @@ func (m *AggregatorSpec) Size() (n int) {
 	return n
 }
 
-func sovCommon(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozCommon(x uint64) (n int) {
-	return sovCommon(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *TaskRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowCommon
-			}
-			if iNdEx >= l {
+func (m *PhysicalPlan) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Database)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if len(m.Targets) > 0 {
+		for _, e := range m.Targets {
+			l = e.Size()
+			n += 1 + l + sovCommon(uint64(l))
+		}
+	}
+	if len(m.Receivers) > 0 {
+		for _, s := range m.Receivers {
+			l = len(s)
+			n += 1 + l + sovCommon(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PlanTarget) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReceiveOnly {
+		n += 2
+	}
+	l = len(m.Indicator)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if len(m.ShardIDs) > 0 {
+		l = 0
+		for _, e := range m.ShardIDs {
+			l += sovCommon(uint64(e))
+		}
+		n += 1 + sovCommon(uint64(l)) + l
+	}
+	if m.Limit != 0 {
+		n += 1 + sovCommon(uint64(m.Limit))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovCommon(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozCommon(x uint64) (n int) {
+	return sovCommon(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *TaskRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommon
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
 			b := dAtA[iNdEx]
@@ -1219,6 +1710,63 @@ func (m *TaskRequest) Unmarshal(dAtA []byte) error {
 				m.Payload = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compress", wireType)
+			}
+			m.Compress = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compress |= CompressType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AcceptCompress", wireType)
+			}
+			m.AcceptCompress = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AcceptCompress |= CompressType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlanEncoding", wireType)
+			}
+			m.PlanEncoding = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PlanEncoding |= PlanEncoding(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -1460,6 +2008,91 @@ func (m *TaskResponse) Unmarshal(dAtA []byte) error {
 				m.Stats = []byte{}
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Coverage", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Coverage = append(m.Coverage[:0], dAtA[iNdEx:postIndex]...)
+			if m.Coverage == nil {
+				m.Coverage = []byte{}
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compress", wireType)
+			}
+			m.Compress = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compress |= CompressType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -2047,6 +2680,353 @@ func (m *AggregatorSpec) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PhysicalPlan) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PhysicalPlan: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PhysicalPlan: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Database", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Database = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Targets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Targets = append(m.Targets, &PlanTarget{})
+			if err := m.Targets[len(m.Targets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Receivers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Receivers = append(m.Receivers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlanTarget) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlanTarget: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlanTarget: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceiveOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReceiveOnly = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indicator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Indicator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCommon
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ShardIDs = append(m.ShardIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCommon
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCommon
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthCommon
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ShardIDs) == 0 {
+					m.ShardIDs = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCommon
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ShardIDs = append(m.ShardIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardIDs", wireType)
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipCommon(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
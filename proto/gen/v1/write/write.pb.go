@@ -92,6 +92,8 @@ func (m *WriteRequest) GetRecord() []byte {
 
 type WriteResponse struct {
 	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Offset               int32    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -137,6 +139,20 @@ func (m *WriteResponse) GetErr() string {
 	return ""
 }
 
+func (m *WriteResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *WriteResponse) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*WriteRequest)(nil), "protoWriteV1.WriteRequest")
 	proto.RegisterType((*WriteResponse)(nil), "protoWriteV1.WriteResponse")
@@ -145,18 +161,19 @@ func init() {
 func init() { proto.RegisterFile("write.proto", fileDescriptor_67966b2b12a73214) }
 
 var fileDescriptor_67966b2b12a73214 = []byte{
-	// 163 bytes of a gzipped FileDescriptorProto
+	// 192 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0x2f, 0xca, 0x2c,
 	0x49, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x01, 0x53, 0xe1, 0x20, 0x91, 0x30, 0x43,
 	0x25, 0x35, 0x2e, 0x1e, 0x30, 0x33, 0x28, 0xb5, 0xb0, 0x34, 0xb5, 0xb8, 0x44, 0x48, 0x8c, 0x8b,
 	0xad, 0x28, 0x35, 0x39, 0xbf, 0x28, 0x45, 0x82, 0x51, 0x81, 0x51, 0x83, 0x27, 0x08, 0xca, 0x53,
-	0x52, 0xe4, 0xe2, 0x85, 0xaa, 0x2b, 0x2e, 0xc8, 0xcf, 0x2b, 0x4e, 0x15, 0x12, 0xe0, 0x62, 0x4e,
-	0x2d, 0x2a, 0x02, 0xab, 0xe2, 0x0c, 0x02, 0x31, 0x8d, 0xc2, 0xa0, 0x46, 0x05, 0xa7, 0x16, 0x95,
-	0x65, 0x26, 0xa7, 0x0a, 0xb9, 0x71, 0xb1, 0x82, 0xf9, 0x42, 0x52, 0x7a, 0xc8, 0x56, 0xea, 0x21,
-	0xdb, 0x27, 0x25, 0x8d, 0x55, 0x0e, 0x62, 0x87, 0x12, 0x83, 0x06, 0xa3, 0x01, 0xa3, 0x93, 0xc0,
-	0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x3e, 0x78, 0x24, 0xc7, 0x38, 0xe3, 0xb1, 0x1c,
-	0x43, 0x12, 0x1b, 0x58, 0x8f, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0x48, 0xda, 0x57, 0x19, 0xd8,
-	0x00, 0x00, 0x00,
+	0xf2, 0xe7, 0xe2, 0x85, 0xaa, 0x2b, 0x2e, 0xc8, 0xcf, 0x2b, 0x4e, 0x15, 0x12, 0xe0, 0x62, 0x4e,
+	0x2d, 0x2a, 0x02, 0xab, 0xe2, 0x0c, 0x02, 0x31, 0x85, 0x44, 0xb8, 0x58, 0x93, 0xf3, 0x4b, 0xf3,
+	0x4a, 0x24, 0x98, 0x14, 0x18, 0x35, 0x58, 0x83, 0x20, 0x1c, 0x90, 0x81, 0xf9, 0x69, 0x69, 0xc5,
+	0xa9, 0x25, 0x12, 0xcc, 0x60, 0x61, 0x28, 0xcf, 0x28, 0x0c, 0x6a, 0x71, 0x70, 0x6a, 0x51, 0x59,
+	0x66, 0x72, 0xaa, 0x90, 0x1b, 0x17, 0x2b, 0x98, 0x2f, 0x24, 0xa5, 0x87, 0xec, 0x40, 0x3d, 0x64,
+	0xd7, 0x49, 0x49, 0x63, 0x95, 0x83, 0xb8, 0x48, 0x89, 0x41, 0x83, 0xd1, 0x80, 0xd1, 0x49, 0xe0,
+	0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x1f, 0x3c, 0x92, 0x63, 0x9c, 0xf1, 0x58, 0x8e,
+	0x21, 0x89, 0x0d, 0xac, 0xc7, 0x18, 0x30, 0x00, 0x39, 0x07, 0xa8, 0xeb, 0x06, 0x01, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -329,6 +346,16 @@ func (m *WriteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Offset != 0 {
+		i = encodeVarintWrite(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Count != 0 {
+		i = encodeVarintWrite(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.Err) > 0 {
 		i -= len(m.Err)
 		copy(dAtA[i:], m.Err)
@@ -376,6 +403,12 @@ func (m *WriteResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWrite(uint64(l))
 	}
+	if m.Count != 0 {
+		n += 1 + sovWrite(uint64(m.Count))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovWrite(uint64(m.Offset))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -534,6 +567,44 @@ func (m *WriteResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.Err = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWrite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWrite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWrite(dAtA[iNdEx:])
@@ -0,0 +1,147 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"sync"
+	"time"
+)
+
+// for testing
+var (
+	nowFn = time.Now
+)
+
+// DefaultCircuitBreakerPolicy represents the default circuit breaker policy of sending task request.
+var DefaultCircuitBreakerPolicy = CircuitBreakerPolicy{
+	FailureThreshold: 5,
+	Cooldown:         10 * time.Second,
+}
+
+// CircuitBreakerPolicy represents the circuit breaker policy of target node,
+// for fast failing the task request to unresponsive node, so that one bad node not slows all queries.
+type CircuitBreakerPolicy struct {
+	FailureThreshold int           // consecutive send failures to open circuit, disabled if <= 0
+	Cooldown         time.Duration // fast fail duration after circuit opened, then half-open to probe recovery
+}
+
+// circuitState represents the circuit state of target node.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// nodeCircuit represents the circuit of target node.
+type nodeCircuit struct {
+	state    circuitState
+	failures int       // consecutive send failures
+	openedAt time.Time // time of circuit opened
+}
+
+// circuitBreaker represents the circuit breaker of all target nodes.
+type circuitBreaker struct {
+	policy   CircuitBreakerPolicy
+	circuits map[string]*nodeCircuit
+	mutex    sync.Mutex
+}
+
+// newCircuitBreaker creates a circuit breaker with policy.
+func newCircuitBreaker(policy CircuitBreakerPolicy) *circuitBreaker {
+	return &circuitBreaker{
+		policy:   policy,
+		circuits: make(map[string]*nodeCircuit),
+	}
+}
+
+// allow checks if request can be sent to target node, after cooldown of open circuit,
+// only one probe request is allowed(half-open) until it completed.
+func (b *circuitBreaker) allow(nodeID string) bool {
+	if b.policy.FailureThreshold <= 0 {
+		return true
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	circuit, ok := b.circuits[nodeID]
+	if !ok {
+		return true
+	}
+	switch circuit.state {
+	case circuitOpen:
+		if nowFn().Sub(circuit.openedAt) < b.policy.Cooldown {
+			return false
+		}
+		circuit.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// probe request is in-flight
+		return false
+	default:
+		return true
+	}
+}
+
+// onSuccess closes the circuit of target node after request sent successfully.
+func (b *circuitBreaker) onSuccess(nodeID string) {
+	if b.policy.FailureThreshold <= 0 {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	delete(b.circuits, nodeID)
+}
+
+// abort aborts the probe request which not completed(e.g. cancelled), next request probes again.
+func (b *circuitBreaker) abort(nodeID string) {
+	if b.policy.FailureThreshold <= 0 {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if circuit, ok := b.circuits[nodeID]; ok && circuit.state == circuitHalfOpen {
+		circuit.state = circuitOpen
+	}
+}
+
+// onFailure records the send failure of target node, returns true if circuit opened by this failure.
+func (b *circuitBreaker) onFailure(nodeID string) (opened bool) {
+	if b.policy.FailureThreshold <= 0 {
+		return false
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	circuit, ok := b.circuits[nodeID]
+	if !ok {
+		circuit = &nodeCircuit{}
+		b.circuits[nodeID] = circuit
+	}
+	circuit.failures++
+	if circuit.state == circuitHalfOpen || (circuit.state == circuitClosed && circuit.failures >= b.policy.FailureThreshold) {
+		// probe failure re-opens circuit
+		circuit.state = circuitOpen
+		circuit.openedAt = nowFn()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	now := time.Now()
+	defer func() {
+		nowFn = time.Now
+	}()
+	nowFn = func() time.Time {
+		return now
+	}
+	breaker := newCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 1, Cooldown: time.Second})
+	assert.True(t, breaker.allow("1"))
+	assert.True(t, breaker.onFailure("1"))
+	assert.False(t, breaker.allow("1"))
+	now = now.Add(time.Second)
+	// only one probe request allowed
+	assert.True(t, breaker.allow("1"))
+	assert.False(t, breaker.allow("1"))
+	breaker.onSuccess("1")
+	assert.True(t, breaker.allow("1"))
+
+	// disabled
+	breaker = newCircuitBreaker(CircuitBreakerPolicy{})
+	assert.False(t, breaker.onFailure("1"))
+	breaker.abort("1")
+	breaker.onSuccess("1")
+	assert.True(t, breaker.allow("1"))
+}
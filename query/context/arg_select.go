@@ -0,0 +1,233 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package context
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// argSelectItem represents the select item of arg selector(argmax/argmin).
+type argSelectItem struct {
+	name     string // name(alias) of select item, also is the tag key of selected tag value in result
+	funcType function.FuncType
+	tagIdx   int // index of arg selector's tag key in group by tag keys
+}
+
+// argCandidate represents the series which has the extreme field value in group.
+type argCandidate struct {
+	tagValue string
+	value    float64
+	values   *collections.FloatArray
+}
+
+// better checks if the extreme value of series is better than candidate, ties select the lowest tag value.
+func (c *argCandidate) better(funcType function.FuncType, tagValue string, value float64) bool {
+	if value == c.value {
+		return tagValue < c.tagValue
+	}
+	if funcType == function.ArgMax {
+		return value > c.value
+	}
+	return value < c.value
+}
+
+// argSelectedIterator represents the grouped series which tags include the selected tag values of arg selectors.
+type argSelectedIterator struct {
+	series.GroupedIterator
+	tags string
+}
+
+// Tags returns group tags with selected tag values.
+func (it *argSelectedIterator) Tags() string {
+	return it.tags
+}
+
+// argSelect selects the tag value of series which has the extreme(max/min) field value for argmax/argmin select items.
+// Grouped series are grouped by the tag keys of arg selectors implicitly(added when parsing), the series which have
+// same tags except arg selector's tag keys are merged into one grouped series again after tag value selected,
+// ties select the lowest tag value deterministically.
+//
+// NOTE: it needs all grouped series, so it only can be done at root after all results merged,
+// returns group by tag keys(arg selector's tag keys replaced by select item names), merged grouped series,
+// and the values of selected series for arg selector items(tags => item name => values).
+func (ctx *RootMetricContext) argSelect(selectItems []stmt.Expr, groupByKeys []string,
+	groupIts series.GroupedIterators,
+) (keys []string, mergedIts series.GroupedIterators, argValues map[string]map[string]*collections.FloatArray) {
+	items, argExprs := findArgSelectItems(selectItems, groupByKeys)
+	if len(items) == 0 {
+		return groupByKeys, groupIts, nil
+	}
+	argKeys := make(map[int]struct{})
+	for _, item := range items {
+		argKeys[item.tagIdx] = struct{}{}
+	}
+	for idx, tagKey := range groupByKeys {
+		if _, ok := argKeys[idx]; !ok {
+			keys = append(keys, tagKey)
+		}
+	}
+	for _, item := range items {
+		keys = append(keys, item.name)
+	}
+
+	type group struct {
+		tagValues  []string
+		candidates []*argCandidate
+	}
+	groups := make(map[string]*group)
+	aggSpecs := make([]*protoCommonV1.AggregatorSpec, 0, len(ctx.aggregatorSpecs))
+	for _, spec := range ctx.aggregatorSpecs {
+		aggSpecs = append(aggSpecs, spec)
+	}
+	groupAgg := newGroupingAgg(timeutil.Interval(ctx.interval), 1, ctx.timeRange, newAggregatorSpecs(aggSpecs))
+	for _, it := range groupIts {
+		tagValues := tag.SplitTagValues(it.Tags())
+		if len(tagValues) != len(groupByKeys) {
+			continue
+		}
+		fields := make(map[field.Name][]byte)
+		for it.HasNext() {
+			fieldIt := it.Next()
+			data, err := fieldIt.MarshalBinary()
+			if err != nil || len(data) == 0 {
+				continue
+			}
+			fields[fieldIt.FieldName()] = data
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		groupTagValues := make([]string, 0, len(keys))
+		for idx, tagValue := range tagValues {
+			if _, ok := argKeys[idx]; !ok {
+				groupTagValues = append(groupTagValues, tagValue)
+			}
+		}
+		groupTags := tag.ConcatTagValues(groupTagValues)
+		g, ok := groups[groupTags]
+		if !ok {
+			g = &group{tagValues: groupTagValues, candidates: make([]*argCandidate, len(items))}
+			groups[groupTags] = g
+		}
+		// evaluates the max/min values of field for each series
+		expression := newExpressionFn(ctx.timeRange, ctx.interval, argExprs)
+		expression.Eval(series.NewGroupedIterator(it.Tags(), fields))
+		resultSet := expression.ResultSet()
+		for idx, item := range items {
+			values := resultSet[item.name]
+			value, ok := extremeValue(item.funcType, values)
+			if !ok {
+				continue
+			}
+			tagValue := tagValues[item.tagIdx]
+			if c := g.candidates[idx]; c == nil || c.better(item.funcType, tagValue, value) {
+				g.candidates[idx] = &argCandidate{tagValue: tagValue, value: value, values: values}
+			}
+		}
+		// merge series of group again
+		groupAgg.Aggregate(series.NewGroupedIterator(groupTags, fields))
+	}
+
+	argValues = make(map[string]map[string]*collections.FloatArray)
+	for _, it := range groupAgg.ResultSet() {
+		g, ok := groups[it.Tags()]
+		if !ok {
+			continue
+		}
+		tagValues := make([]string, 0, len(keys))
+		tagValues = append(tagValues, g.tagValues...)
+		values := make(map[string]*collections.FloatArray)
+		for idx, item := range items {
+			c := g.candidates[idx]
+			if c == nil {
+				// no field value of group
+				tagValues = append(tagValues, "")
+				continue
+			}
+			tagValues = append(tagValues, c.tagValue)
+			values[item.name] = c.values
+		}
+		tags := tag.ConcatTagValues(tagValues)
+		mergedIts = append(mergedIts, &argSelectedIterator{GroupedIterator: it, tags: tags})
+		argValues[tags] = values
+	}
+	return keys, mergedIts, argValues
+}
+
+// findArgSelectItems returns the arg selector items of select list which tag key in group by tag keys.
+func findArgSelectItems(selectItems []stmt.Expr, groupByKeys []string) (items []*argSelectItem, argExprs []stmt.Expr) {
+	for _, selectItem := range selectItems {
+		item, ok := selectItem.(*stmt.SelectItem)
+		if !ok {
+			continue
+		}
+		call, ok := item.Expr.(*stmt.CallExpr)
+		if !ok || !function.IsArgSelector(call.FuncType) || len(call.Params) != 2 {
+			continue
+		}
+		tagKey, ok := call.Params[0].(*stmt.FieldExpr)
+		if !ok {
+			continue
+		}
+		tagIdx := -1
+		for idx, key := range groupByKeys {
+			if key == tagKey.Name {
+				tagIdx = idx
+				break
+			}
+		}
+		if tagIdx < 0 {
+			continue
+		}
+		name := item.Rewrite()
+		if len(item.Alias) > 0 {
+			name = item.Alias
+		}
+		items = append(items, &argSelectItem{name: name, funcType: call.FuncType, tagIdx: tagIdx})
+		argExprs = append(argExprs, item)
+	}
+	return items, argExprs
+}
+
+// extremeValue returns the max(argmax)/min(argmin) value of series, returns false if series without value.
+func extremeValue(funcType function.FuncType, values *collections.FloatArray) (rs float64, ok bool) {
+	if values == nil {
+		return 0, false
+	}
+	it := values.NewIterator()
+	for it.HasNext() {
+		_, val := it.Next()
+		if math.IsNaN(val) {
+			continue
+		}
+		if !ok || (funcType == function.ArgMax && val > rs) || (funcType == function.ArgMin && val < rs) {
+			rs = val
+			ok = true
+		}
+	}
+	return rs, ok
+}
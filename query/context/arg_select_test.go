@@ -0,0 +1,187 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package context
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestRootMetricContext_argSelect(t *testing.T) {
+	timeRange := timeutil.TimeRange{Start: 1_600_000_000_000, End: 1_600_000_020_000}
+	interval := int64(10_000)
+	// series of each leaf node(tags: region,host)
+	leafSeries := []struct {
+		tags   string
+		values []float64
+	}{
+		{tags: "r1,a", values: []float64{1, 10, 2}},
+		{tags: "r1,b", values: []float64{5, 3, 4}},
+		{tags: "r2,d", values: []float64{7, 7, 7}},
+		// another leaf node, b merged with b of other leaf node
+		{tags: "r1,b", values: []float64{30, 1, 1}},
+		{tags: "r1,c", values: []float64{2, 30, 1}},
+	}
+	latency := &stmt.FieldExpr{Name: "latency"}
+	cases := []struct {
+		name     string
+		funcType function.FuncType
+		aggType  field.AggType
+		selected map[string]string // region => host
+		values   map[string][]float64
+	}{
+		{
+			// r1: max of a=10, b=30, c=30, ties select b
+			name:     "argmax",
+			funcType: function.ArgMax,
+			aggType:  field.Max,
+			selected: map[string]string{"r1": "b", "r2": "d"},
+			values:   map[string][]float64{"r1": {30, 3, 4}, "r2": {7, 7, 7}},
+		},
+		{
+			// r1: min of a=1, b=1, c=1, ties select a
+			name:     "argmin",
+			funcType: function.ArgMin,
+			aggType:  field.Min,
+			selected: map[string]string{"r1": "a", "r2": "d"},
+			values:   map[string][]float64{"r1": {1, 10, 2}, "r2": {7, 7, 7}},
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			aggFunc := function.ArgSelectorAggFunc(tt.funcType)
+			metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+				Ctx:     context.TODO(),
+				Request: &models.Request{},
+				Statement: &stmt.Query{
+					SelectItems: []stmt.Expr{
+						&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: aggFunc, Params: []stmt.Expr{latency}}},
+						&stmt.SelectItem{
+							Expr: &stmt.CallExpr{
+								FuncType: tt.funcType,
+								Params:   []stmt.Expr{&stmt.FieldExpr{Name: "host"}, latency},
+							},
+							Alias: "h",
+						},
+					},
+					GroupBy: []string{"region", "host"},
+					Limit:   10,
+				},
+			})
+			spec := &protoCommonV1.AggregatorSpec{
+				FieldName:    "latency",
+				FieldType:    uint32(field.SumField),
+				FuncTypeList: []uint32{uint32(aggFunc)},
+			}
+			metricCtx.aggregatorSpecs = map[string]*protoCommonV1.AggregatorSpec{"latency": spec}
+			metricCtx.timeRange = timeRange
+			metricCtx.interval = interval
+			metricCtx.groupAgg = aggregation.NewGroupingAggregator(timeutil.Interval(interval), 1, timeRange,
+				newAggregatorSpecs([]*protoCommonV1.AggregatorSpec{spec}))
+			for _, s := range leafSeries {
+				metricCtx.groupAgg.Aggregate(series.NewGroupedIterator(s.tags, map[field.Name][]byte{
+					"latency": encodeField(t, timeRange.Start, tt.aggType, s.values),
+				}))
+			}
+			rs, err := metricCtx.makeResultSet()
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"region", "h"}, rs.GroupBy)
+			assert.Len(t, rs.Series, 2)
+			for _, s := range rs.Series {
+				region := s.Tags["region"]
+				assert.Equal(t, tt.selected[region], s.Tags["h"])
+				for idx, v := range tt.values[region] {
+					assert.Equal(t, v, s.Fields["h"][timeRange.Start+int64(idx)*interval])
+				}
+			}
+			// other select items merged by group(without host)
+			r1 := rs.Series[0]
+			assert.Equal(t, "r1", r1.Tags["region"])
+			if tt.funcType == function.ArgMax {
+				assert.Equal(t, 30.0, r1.Fields["max(latency)"][timeRange.Start])
+				assert.Equal(t, 30.0, r1.Fields["max(latency)"][timeRange.Start+interval])
+			} else {
+				assert.Equal(t, 1.0, r1.Fields["min(latency)"][timeRange.Start])
+				assert.Equal(t, 1.0, r1.Fields["min(latency)"][timeRange.Start+interval])
+			}
+		})
+	}
+}
+
+func TestArgSelect_findArgSelectItems(t *testing.T) {
+	argMax := &stmt.CallExpr{
+		FuncType: function.ArgMax,
+		Params:   []stmt.Expr{&stmt.FieldExpr{Name: "host"}, &stmt.FieldExpr{Name: "f"}},
+	}
+	items, exprs := findArgSelectItems([]stmt.Expr{
+		&stmt.FieldExpr{Name: "f"},
+		&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "f"}},
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.ArgMin}},
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.ArgMin, Params: []stmt.Expr{
+			&stmt.NumberLiteral{Val: 1}, &stmt.FieldExpr{Name: "f"},
+		}}},
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.ArgMin, Params: []stmt.Expr{
+			&stmt.FieldExpr{Name: "ip"}, &stmt.FieldExpr{Name: "f"},
+		}}},
+		&stmt.SelectItem{Expr: argMax},
+	}, []string{"region", "host"})
+	assert.Len(t, exprs, 1)
+	assert.Equal(t, []*argSelectItem{{name: "argmax(host,f)", funcType: function.ArgMax, tagIdx: 1}}, items)
+}
+
+func TestArgSelect_extremeValue(t *testing.T) {
+	_, ok := extremeValue(function.ArgMax, nil)
+	assert.False(t, ok)
+	values := collections.NewFloatArray(5)
+	_, ok = extremeValue(function.ArgMax, values)
+	assert.False(t, ok)
+	values.SetValue(0, math.NaN())
+	values.SetValue(1, 3)
+	values.SetValue(3, -1)
+	v, ok := extremeValue(function.ArgMax, values)
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, v)
+	v, ok = extremeValue(function.ArgMin, values)
+	assert.True(t, ok)
+	assert.Equal(t, -1.0, v)
+}
+
+func TestArgCandidate_better(t *testing.T) {
+	c := &argCandidate{tagValue: "b", value: 10}
+	assert.True(t, c.better(function.ArgMax, "c", 11))
+	assert.False(t, c.better(function.ArgMax, "a", 9))
+	assert.True(t, c.better(function.ArgMin, "c", 9))
+	assert.False(t, c.better(function.ArgMin, "a", 11))
+	// ties select lowest tag value
+	assert.True(t, c.better(function.ArgMax, "a", 10))
+	assert.False(t, c.better(function.ArgMin, "c", 10))
+}
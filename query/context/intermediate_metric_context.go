@@ -88,7 +88,9 @@ func (ctx *IntermediateMetricContext) MakePlan() error {
 		return constants.ErrDatabaseNotExist
 	}
 
-	calcTimeRangeAndInterval(ctx.statement, databaseCfg)
+	if err := calcTimeRangeAndInterval(ctx.statement, databaseCfg); err != nil {
+		return err
+	}
 
 	payload, _ := ctx.statement.MarshalJSON()
 	for _, physicalPlan := range physicalPlans {
@@ -98,13 +100,13 @@ func (ctx *IntermediateMetricContext) MakePlan() error {
 		if err := physicalPlan.Validate(); err != nil {
 			return err
 		}
-		ctx.addRequests(
-			&protoCommonV1.TaskRequest{
-				RequestID:    ctx.req.RequestID,
-				RequestType:  protoCommonV1.RequestType_Data,
-				PhysicalPlan: encoding.JSONMarshal(physicalPlan),
-				Payload:      payload,
-			}, physicalPlan)
+		req := &protoCommonV1.TaskRequest{
+			RequestID:   ctx.req.RequestID,
+			RequestType: protoCommonV1.RequestType_Data,
+			Payload:     payload,
+		}
+		rpc.EncodePhysicalPlan(req, physicalPlan)
+		ctx.addRequests(req, physicalPlan)
 	}
 	return nil
 }
@@ -160,5 +162,6 @@ func (ctx *IntermediateMetricContext) makeTaskResponse() *protoCommonV1.TaskResp
 		SendTime:    commontimeutil.NowNano(),
 		Stats:       stats,
 		Payload:     data,
+		Coverage:    encoding.JSONMarshal(ctx.shardCoverage()),
 	}
 }
@@ -145,12 +145,13 @@ func (ctx *LeafExecuteContext) SendResponse(err error) {
 // sendResponse sends result set based on receivers.
 func (ctx *LeafExecuteContext) sendResponse(resultData [][]byte, err error) {
 	var stats []byte
-	var errMsg string
+	var errMsg, errCode string
 	if ctx.StorageExecuteCtx.Query.Explain {
 		stats = encoding.JSONMarshal(ctx.Tracker.GetStats())
 	}
 	if err != nil {
 		errMsg = err.Error()
+		errCode = string(models.ErrorCodeOf(err))
 	}
 	// send result to upstream receivers
 	for idx, receiver := range ctx.Receivers {
@@ -172,7 +173,9 @@ func (ctx *LeafExecuteContext) sendResponse(resultData [][]byte, err error) {
 			Payload:     payload,
 			Stats:       stats,
 			ErrMsg:      errMsg,
+			ErrCode:     errCode,
 		}
+		rpc.CompressTaskResponse(resp, ctx.Req.AcceptCompress)
 		if err0 := stream.Send(resp); err0 != nil {
 			leafExecuteCtxLogger.Error("send storage query result, ignore result",
 				logger.String("target", receiver), logger.Error(err0))
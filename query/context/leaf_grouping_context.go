@@ -156,7 +156,10 @@ func (ctx *LeafGroupingContext) getTagValues(tagValueIDs string) string {
 		tagValuesForKey := ctx.tagValuesMap[idx]
 		offset := idx * 4
 		tagValueID := binary.LittleEndian.Uint32(tagsData[offset:])
-		if tagValue, ok := tagValuesForKey[tagValueID]; ok {
+		if tagValueID == tag.EmptyTagValueID {
+			// series missing tag key, group under empty tag value
+			ctx.tagValues[idx] = ""
+		} else if tagValue, ok := tagValuesForKey[tagValueID]; ok {
 			ctx.tagValues[idx] = tagValue
 		} else {
 			ctx.tagValues[idx] = tagValueNotFound
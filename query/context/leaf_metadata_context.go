@@ -18,9 +18,12 @@
 package context
 
 import (
+	"math"
+
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/sketch"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
@@ -35,7 +38,10 @@ type LeafMetadataContext struct {
 	StorageExecuteCtx *flow.StorageExecuteContext
 
 	ResultSet []string
-	TagKeyID  tag.KeyID // for tag values suggest
+	TagKeyID  tag.KeyID        // for tag values suggest
+	Distinct  *sketch.Distinct // for distinct count, ships sketch instead of values
+
+	Cardinality map[string]*sketch.Distinct // for tag cardinality, distinct counter of tag values under tag key
 
 	Limit int
 }
@@ -47,6 +53,9 @@ func NewLeafMetadataContext(request *stmt.MetricMetadata, database tsdb.Database
 		Database: database,
 		ShardIDs: shardIDs,
 	}
+	if request.Distinct {
+		ctx.Distinct, _ = sketch.NewDistinct(request.Precision)
+	}
 	ctx.Limit = ctx.getLimit()
 	return ctx
 }
@@ -54,6 +63,10 @@ func NewLeafMetadataContext(request *stmt.MetricMetadata, database tsdb.Database
 // getLimit returns result limit.
 func (ctx *LeafMetadataContext) getLimit() int {
 	req := ctx.Request
+	if ctx.Distinct != nil {
+		// distinct count need all values
+		return math.MaxInt
+	}
 	limit := req.Limit
 	if limit == 0 || limit > constants.MaxSuggestions {
 		// if limit = 0 or > max suggestion items, need reset limit
@@ -64,8 +77,26 @@ func (ctx *LeafMetadataContext) getLimit() int {
 
 // AddValue adds value into result set.
 func (ctx *LeafMetadataContext) AddValue(val string) {
+	if ctx.Distinct != nil {
+		ctx.Distinct.Add(val)
+		return
+	}
 	if len(ctx.ResultSet) >= ctx.Limit {
 		return
 	}
 	ctx.ResultSet = append(ctx.ResultSet, val)
 }
+
+// Result returns the suggest result which ships to upstream.
+func (ctx *LeafMetadataContext) Result() *models.SuggestResult {
+	if ctx.Cardinality != nil {
+		return &models.SuggestResult{Cardinality: ctx.Cardinality}
+	}
+	if ctx.Distinct == nil {
+		return &models.SuggestResult{Values: ctx.ResultSet}
+	}
+	for _, val := range ctx.ResultSet {
+		ctx.Distinct.Add(val)
+	}
+	return &models.SuggestResult{Distinct: ctx.Distinct}
+}
@@ -19,6 +19,7 @@ package context
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -40,4 +41,18 @@ func TestLeafMetadataContext(t *testing.T) {
 
 	ctx = NewLeafMetadataContext(&stmtpkg.MetricMetadata{Limit: 500}, nil, nil)
 	assert.Equal(t, constants.MaxSuggestions, ctx.Limit)
+	assert.Empty(t, ctx.Result().Values)
+	assert.Nil(t, ctx.Result().Distinct)
+
+	ctx = NewLeafMetadataContext(&stmtpkg.MetricMetadata{Limit: 50, Distinct: true, Precision: 10}, nil, nil)
+	assert.Equal(t, math.MaxInt, ctx.Limit)
+	for i := 0; i < 1000; i++ {
+		ctx.AddValue(fmt.Sprintf("value-%d", i))
+	}
+	assert.Empty(t, ctx.ResultSet)
+	ctx.ResultSet = []string{"value-1", "other"}
+	rs := ctx.Result()
+	assert.Nil(t, rs.Values)
+	assert.Equal(t, uint64(1001), rs.Distinct.Count())
+	assert.Equal(t, uint8(10), rs.Distinct.Precision())
 }
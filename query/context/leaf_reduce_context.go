@@ -67,6 +67,7 @@ func (ctx *LeafReduceContext) BuildResultSet(leafNode *models.Target, receivers
 	timeRange := ctx.storageExecuteCtx.Query.TimeRange
 	interval := ctx.storageExecuteCtx.Query.Interval.Int64()
 	aggregatorSpecs := make([]*protoCommonV1.AggregatorSpec, len(aggSpecs))
+	fields := make(map[string]*protoCommonV1.AggregatorSpec, len(aggSpecs))
 	for idx, spec := range aggSpecs {
 		aggregatorSpecs[idx] = &protoCommonV1.AggregatorSpec{
 			FieldName: string(spec.FieldName()),
@@ -75,12 +76,16 @@ func (ctx *LeafReduceContext) BuildResultSet(leafNode *models.Target, receivers
 		for _, funcType := range spec.Functions() {
 			aggregatorSpecs[idx].FuncTypeList = append(aggregatorSpecs[idx].FuncTypeList, uint32(funcType))
 		}
+		fields[aggregatorSpecs[idx].FieldName] = aggregatorSpecs[idx]
 	}
 	numOfReceivers := len(receivers)
 	resultSet := make([][]byte, numOfReceivers)
 	timeSeriesList := ctx.makeTimeSeriesList()
 	// root -> leaf task, return the raw total series
 	if numOfReceivers == 1 {
+		// leaf node reads all shards, keep top n grouped series for root
+		timeSeriesList = limitTimeSeriesList(ctx.storageExecuteCtx.Query, timeRange, interval,
+			fields, timeSeriesList, leafNode.Limit)
 		leaf2RootSeries := protoCommonV1.TimeSeriesList{
 			TimeSeriesList: timeSeriesList,
 			FieldAggSpecs:  aggregatorSpecs,
@@ -25,6 +25,7 @@ import (
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/sketch"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/sql/stmt"
@@ -48,7 +49,10 @@ type MetadataContext struct {
 
 	Deps *MetadataDeps
 	// handle response
-	results []string
+	results  []string
+	distinct *sketch.Distinct // merged distinct count sketch if statement requires distinct
+	// merged distinct counter of tag values under each tag key if statement is tag cardinality
+	cardinality map[string]*sketch.Distinct
 }
 
 // NewMetadataContext creates metric metadata search context.
@@ -68,6 +72,12 @@ func (ctx *MetadataContext) WaitResponse() (any, error) {
 	select {
 	case <-ctx.doneCh:
 		// received all data, break for loop
+		if ctx.distinct != nil {
+			return ctx.distinct, ctx.err
+		}
+		if ctx.cardinality != nil {
+			return ctx.cardinality, ctx.err
+		}
 		return ctx.results, ctx.err
 	case <-ctx.Deps.Ctx.Done():
 		return nil, constants.ErrTimeout
@@ -89,6 +99,16 @@ func (ctx *MetadataContext) MakePlan() error {
 	if len(physicalPlans) == 0 {
 		return constants.ErrTargetNodesNotFound
 	}
+	if ctx.Deps.Statement.Distinct {
+		distinct, err := sketch.NewDistinct(ctx.Deps.Statement.Precision)
+		if err != nil {
+			return err
+		}
+		ctx.distinct = distinct
+	}
+	if ctx.Deps.Statement.Type == stmt.TagCardinality {
+		ctx.cardinality = make(map[string]*sketch.Distinct)
+	}
 
 	suggestMarshalData, _ := ctx.Deps.Statement.MarshalJSON()
 	for _, physicalPlan := range physicalPlans {
@@ -96,13 +116,13 @@ func (ctx *MetadataContext) MakePlan() error {
 		if err := physicalPlan.Validate(); err != nil {
 			return err
 		}
-		ctx.addRequests(
-			&protoCommonV1.TaskRequest{
-				RequestID:    ctx.Deps.Request.RequestID,
-				RequestType:  protoCommonV1.RequestType_Metadata,
-				PhysicalPlan: encoding.JSONMarshal(physicalPlan),
-				Payload:      suggestMarshalData,
-			}, physicalPlan)
+		req := &protoCommonV1.TaskRequest{
+			RequestID:   ctx.Deps.Request.RequestID,
+			RequestType: protoCommonV1.RequestType_Metadata,
+			Payload:     suggestMarshalData,
+		}
+		rpc.EncodePhysicalPlan(req, physicalPlan)
+		ctx.addRequests(req, physicalPlan)
 	}
 
 	return nil
@@ -120,5 +140,26 @@ func (ctx *MetadataContext) handleResponse(resp *protoCommonV1.TaskResponse, fro
 	if err := encoding.JSONUnmarshal(resp.Payload, result); err != nil {
 		ctx.err = err
 	}
+	if ctx.distinct != nil {
+		// merge distinct sketch from leaf/intermediate node
+		if err := ctx.distinct.Merge(result.Distinct); err != nil {
+			ctx.err = err
+		}
+		return
+	}
+	if ctx.cardinality != nil {
+		// merge distinct counter of each tag key from leaf/intermediate node
+		for tagKey, counter := range result.Cardinality {
+			merged, ok := ctx.cardinality[tagKey]
+			if !ok {
+				ctx.cardinality[tagKey] = counter
+				continue
+			}
+			if err := merged.Merge(counter); err != nil {
+				ctx.err = err
+			}
+		}
+		return
+	}
 	ctx.results = append(ctx.results, result.Values...)
 }
@@ -26,9 +26,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/common/pkg/encoding"
+
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/sketch"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/query/tracker"
 	"github.com/lindb/lindb/sql/stmt"
@@ -69,9 +72,10 @@ func TestMetadataContext_MakePlan(t *testing.T) {
 
 	chooseMgr := flow.NewMockNodeChoose(ctrl)
 	cases := []struct {
-		name    string
-		prepare func()
-		wantErr bool
+		name      string
+		prepare   func()
+		statement *stmt.MetricMetadata
+		wantErr   bool
 	}{
 		{
 			name: "choose fail",
@@ -94,6 +98,15 @@ func TestMetadataContext_MakePlan(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid distinct precision",
+			prepare: func() {
+				chooseMgr.EXPECT().Choose(gomock.Any(), gomock.Any()).
+					Return([]*models.PhysicalPlan{{Database: "test", Targets: []*models.Target{{}}}}, nil)
+			},
+			statement: &stmt.MetricMetadata{Distinct: true, Precision: 100},
+			wantErr:   true,
+		},
 		{
 			name: "make plan successfully",
 			prepare: func() {
@@ -107,10 +120,14 @@ func TestMetadataContext_MakePlan(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
+			statement := tt.statement
+			if statement == nil {
+				statement = &stmt.MetricMetadata{}
+			}
 			ctx := NewMetadataContext(&MetadataDeps{
 				Ctx:         context.TODO(),
 				Request:     &models.Request{},
-				Statement:   &stmt.MetricMetadata{},
+				Statement:   statement,
 				Choose:      chooseMgr,
 				CurrentNode: models.StatelessNode{},
 			})
@@ -129,3 +146,81 @@ func TestMetadataContext_HandleResponse(t *testing.T) {
 	ctx.SetTracker(tracker.NewStageTracker(flow.NewTaskContextWithTimeout(context.TODO(), time.Minute)))
 	ctx.HandleResponse(&protoCommonV1.TaskResponse{}, "leaf")
 }
+
+func TestMetadataContext_Distinct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chooseMgr := flow.NewMockNodeChoose(ctrl)
+	chooseMgr.EXPECT().Choose(gomock.Any(), gomock.Any()).
+		Return([]*models.PhysicalPlan{
+			{Database: "test", Targets: []*models.Target{{}}},
+			{Database: "test", Targets: []*models.Target{{}}},
+		}, nil)
+	ctx := NewMetadataContext(&MetadataDeps{
+		Ctx:       context.TODO(),
+		Request:   &models.Request{},
+		Statement: &stmt.MetricMetadata{Distinct: true, Precision: 10},
+		Choose:    chooseMgr,
+	})
+	ctx.SetTracker(tracker.NewStageTracker(flow.NewTaskContextWithTimeout(context.TODO(), time.Minute)))
+	assert.NoError(t, ctx.MakePlan())
+	ctx.expectResults = 3
+
+	newPayload := func(precision uint8, values ...string) []byte {
+		distinct, _ := sketch.NewDistinct(precision)
+		for _, val := range values {
+			distinct.Add(val)
+		}
+		return encoding.JSONMarshal(&models.SuggestResult{Distinct: distinct})
+	}
+	ctx.HandleResponse(&protoCommonV1.TaskResponse{Completed: true, Payload: newPayload(10, "a", "b")}, "leaf-1")
+	ctx.HandleResponse(&protoCommonV1.TaskResponse{Completed: true, Payload: newPayload(10, "b", "c")}, "leaf-2")
+	ctx.HandleResponse(&protoCommonV1.TaskResponse{Completed: true, Payload: newPayload(10)}, "leaf-3")
+	rs, err := ctx.WaitResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), rs.(*sketch.Distinct).Count())
+
+	// precision mismatch
+	ctx.distinct, _ = sketch.NewDistinct(12)
+	ctx.handleResponse(&protoCommonV1.TaskResponse{Payload: newPayload(10, "a")}, "leaf-1")
+	assert.Equal(t, sketch.ErrPrecisionMismatch, ctx.err)
+}
+
+func TestMetadataContext_TagCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chooseMgr := flow.NewMockNodeChoose(ctrl)
+	chooseMgr.EXPECT().Choose(gomock.Any(), gomock.Any()).
+		Return([]*models.PhysicalPlan{
+			{Database: "test", Targets: []*models.Target{{}}},
+			{Database: "test", Targets: []*models.Target{{}}},
+		}, nil)
+	ctx := NewMetadataContext(&MetadataDeps{
+		Ctx:       context.TODO(),
+		Request:   &models.Request{},
+		Statement: &stmt.MetricMetadata{Type: stmt.TagCardinality},
+		Choose:    chooseMgr,
+	})
+	ctx.SetTracker(tracker.NewStageTracker(flow.NewTaskContextWithTimeout(context.TODO(), time.Minute)))
+	assert.NoError(t, ctx.MakePlan())
+	ctx.expectResults = 2
+
+	newPayload := func(precision uint8, tagKey string, values ...string) []byte {
+		distinct, _ := sketch.NewDistinct(precision)
+		for _, val := range values {
+			distinct.Add(val)
+		}
+		return encoding.JSONMarshal(&models.SuggestResult{Cardinality: map[string]*sketch.Distinct{tagKey: distinct}})
+	}
+	ctx.HandleResponse(&protoCommonV1.TaskResponse{Completed: true, Payload: newPayload(0, "host", "a", "b")}, "leaf-1")
+	ctx.HandleResponse(&protoCommonV1.TaskResponse{Completed: true, Payload: newPayload(0, "host", "b", "c")}, "leaf-2")
+	rs, err := ctx.WaitResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), rs.(map[string]*sketch.Distinct)["host"].Count())
+
+	// precision mismatch
+	ctx.handleResponse(&protoCommonV1.TaskResponse{Payload: newPayload(10, "host", "a")}, "leaf-1")
+	assert.Equal(t, sketch.ErrPrecisionMismatch, ctx.err)
+}
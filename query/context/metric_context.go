@@ -19,8 +19,7 @@ package context
 
 import (
 	"context"
-	"errors"
-	"strings"
+	"fmt"
 	"time"
 
 	commonmodels "github.com/lindb/common/models"
@@ -29,6 +28,7 @@ import (
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/rpc"
@@ -48,6 +48,10 @@ type MetricContext struct {
 	timeRange       timeutil.TimeRange
 	interval        int64
 	startTime       time.Time // task start time
+	// notFoundPolicy represents how to handle the not found error returned by target nodes
+	notFoundPolicy models.NotFoundPolicy
+	// partial represents partial result set is being made after timeout, late responses are not merged
+	partial bool
 }
 
 // newMetricContext creates metric data search context.
@@ -79,16 +83,32 @@ func (ctx *MetricContext) waitResponse() error {
 }
 
 // handleResponse hanles task response.
+// NOTE: payload decoding is done before acquiring lock, because it is expensive on wide fan-outs,
+// only merging series into grouping aggregator and bookkeeping are done in critical section.
 func (ctx *MetricContext) handleResponse(resp *protoCommonV1.TaskResponse, fromNode string) {
+	var (
+		tsList        *protoCommonV1.TimeSeriesList
+		groupedSeries []series.GroupedIterator
+		decodeErr     error
+	)
+	if resp.ErrMsg == "" {
+		tsList, groupedSeries, decodeErr = decodeTimeSeriesList(resp.Payload)
+	}
+
 	ctx.mutex.Lock()
 	defer ctx.mutex.Unlock()
 
 	ctx.handleTaskState(resp, fromNode)
+	ctx.handleShardCoverage(resp, fromNode)
 	ctx.expectResults--
 
+	if ctx.partial {
+		// partial result set is being made after timeout, not merge late response
+		return
+	}
 	ctx.handleStats(resp, fromNode)
 
-	ignoreResponse, err := ctx.checkError(resp.ErrMsg)
+	ignoreResponse, err := ctx.checkError(resp)
 	if err != nil {
 		ctx.err = err
 		return
@@ -97,10 +117,8 @@ func (ctx *MetricContext) handleResponse(resp *protoCommonV1.TaskResponse, fromN
 	if ignoreResponse {
 		return
 	}
-
-	tsList := &protoCommonV1.TimeSeriesList{}
-	if err := tsList.Unmarshal(resp.Payload); err != nil {
-		ctx.err = err
+	if decodeErr != nil {
+		ctx.err = decodeErr
 		return
 	}
 
@@ -120,26 +138,47 @@ func (ctx *MetricContext) handleResponse(resp *protoCommonV1.TaskResponse, fromN
 	}
 
 	if ctx.groupAgg == nil {
-		AggregatorSpecs := make(aggregation.AggregatorSpecs, len(tsList.FieldAggSpecs))
-		for idx, aggSpec := range tsList.FieldAggSpecs {
-			AggregatorSpecs[idx] = aggregation.NewAggregatorSpec(
-				field.Name(aggSpec.FieldName),
-				field.Type(aggSpec.FieldType),
-			)
-			for _, funcType := range aggSpec.FuncTypeList {
-				AggregatorSpecs[idx].AddFunctionType(function.FuncType(funcType))
-			}
-		}
 		ctx.groupAgg = newGroupingAgg(
 			timeutil.Interval(ctx.interval),
 			1, // interval ratio is 1 when do merge result.
 			ctx.timeRange,
-			AggregatorSpecs,
+			newAggregatorSpecs(tsList.FieldAggSpecs),
 		)
 	}
 
+	for _, it := range groupedSeries {
+		ctx.groupAgg.Aggregate(it)
+	}
+}
+
+// newAggregatorSpecs creates the aggregator specs for merging result based on field's aggregator specs.
+func newAggregatorSpecs(aggSpecs []*protoCommonV1.AggregatorSpec) aggregation.AggregatorSpecs {
+	specs := make(aggregation.AggregatorSpecs, len(aggSpecs))
+	for idx, aggSpec := range aggSpecs {
+		specs[idx] = aggregation.NewAggregatorSpec(
+			field.Name(aggSpec.FieldName),
+			field.Type(aggSpec.FieldType),
+		)
+		for _, funcType := range aggSpec.FuncTypeList {
+			specs[idx].AddFunctionType(function.FuncType(funcType))
+		}
+	}
+	return specs
+}
+
+// decodeTimeSeriesList decodes the time series list from task response payload,
+// returns the grouped series iterators which have field data.
+func decodeTimeSeriesList(payload []byte) (*protoCommonV1.TimeSeriesList, []series.GroupedIterator, error) {
+	tsList := &protoCommonV1.TimeSeriesList{}
+	if err := tsList.Unmarshal(payload); err != nil {
+		return nil, nil, err
+	}
+	if len(tsList.FieldAggSpecs) == 0 {
+		return tsList, nil, nil
+	}
+	groupedSeries := make([]series.GroupedIterator, 0, len(tsList.TimeSeriesList))
 	for _, ts := range tsList.TimeSeriesList {
-		// if no field data, ignore this response
+		// if no field data, ignore this series
 		if len(ts.Fields) == 0 {
 			continue
 		}
@@ -147,29 +186,43 @@ func (ctx *MetricContext) handleResponse(resp *protoCommonV1.TaskResponse, fromN
 		for k, v := range ts.Fields {
 			fields[field.Name(k)] = v
 		}
-		ctx.groupAgg.Aggregate(series.NewGroupedIterator(ts.Tags, fields))
+		groupedSeries = append(groupedSeries, series.NewGroupedIterator(ts.Tags, fields))
 	}
+	return tsList, groupedSeries, nil
 }
 
 // checkError checks if it has an error should be returned.
-// node of the cluster may return not found error,
+// node of the cluster may return not found error, which is handled based on not found policy(default: all),
 // ignoreResponse=true symbols that the response should be ignored
-func (ctx *MetricContext) checkError(errMsg string) (ignoreResponse bool, err error) {
-	if errMsg == "" {
+func (ctx *MetricContext) checkError(resp *protoCommonV1.TaskResponse) (ignoreResponse bool, err error) {
+	if resp.ErrMsg == "" {
 		return false, nil
 	}
-	// real error
-	if !strings.Contains(errMsg, "not found") {
-		goto ReturnError
+	code := models.ErrorCode(resp.ErrCode)
+	if code != models.ErrCodeNotFound {
+		// real error
+		if code != models.ErrCodeTimeout && len(ctx.requests) > 1 {
+			// some of target nodes failed
+			code = models.ErrCodePartialFailure
+		}
+		return true, models.NewError(code, resp.ErrMsg)
+	}
+	switch ctx.notFoundPolicy {
+	case models.NotFoundPolicyStrict:
+		// any shard not found fails the query
+		return true, models.NewError(models.ErrCodeNotFound,
+			fmt.Sprintf("%s, not found shards: %v", resp.ErrMsg, ctx.coverage.NotFound))
+	case models.NotFoundPolicyLenient:
+		// not found shards are tracked in shard coverage, returns partial result set
+		return true, nil
 	}
 	ctx.tolerantNotFounds--
 	// not found, but there may be still more responses not reached
 	if ctx.tolerantNotFounds > 0 {
 		return true, nil
 	}
-	// fallthrough, all node returns not found errors
-ReturnError:
-	return true, errors.New(errMsg)
+	// all node returns not found errors
+	return true, models.NewError(models.ErrCodeNotFound, resp.ErrMsg)
 }
 
 // handleStats handles the node stats of query task.
@@ -25,11 +25,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
-	"github.com/lindb/common/models"
+	commonmodels "github.com/lindb/common/models"
 	"github.com/lindb/common/pkg/encoding"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/models"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/query/tracker"
 	"github.com/lindb/lindb/series/field"
@@ -57,7 +58,7 @@ func TestMetricContext_HandleResponse(t *testing.T) {
 		},
 		TimeSeriesList: []*protoCommonV1.TimeSeries{{Fields: map[string][]byte{"test": nil}}},
 	}).Marshal()
-	stats := encoding.JSONMarshal(&models.NodeStats{})
+	stats := encoding.JSONMarshal(&commonmodels.NodeStats{})
 
 	cases := []struct {
 		name    string
@@ -75,7 +76,7 @@ func TestMetricContext_HandleResponse(t *testing.T) {
 			prepare: func(metricCtx *MetricContext) {
 				metricCtx.tolerantNotFounds = 2
 			},
-			resp: &protoCommonV1.TaskResponse{ErrMsg: "not found"},
+			resp: &protoCommonV1.TaskResponse{ErrMsg: "not found", ErrCode: string(models.ErrCodeNotFound)},
 		},
 		{
 			name:    "unmarshal payload failure",
@@ -112,6 +113,31 @@ func TestMetricContext_HandleResponse(t *testing.T) {
 	}
 }
 
+func TestMetricContext_decodeTimeSeriesList(t *testing.T) {
+	_, _, err := decodeTimeSeriesList([]byte("abc"))
+	assert.Error(t, err)
+	payload, _ := (&protoCommonV1.TimeSeriesList{
+		TimeSeriesList: []*protoCommonV1.TimeSeries{{Tags: "a", Fields: map[string][]byte{"test": {1}}}},
+	}).Marshal()
+	tsList, groupedSeries, err := decodeTimeSeriesList(payload)
+	assert.NoError(t, err)
+	assert.Len(t, tsList.TimeSeriesList, 1)
+	// no aggregator spec, no need to build grouped series
+	assert.Empty(t, groupedSeries)
+
+	payload, _ = (&protoCommonV1.TimeSeriesList{
+		FieldAggSpecs: []*protoCommonV1.AggregatorSpec{{FieldName: "test", FieldType: uint32(field.Sum)}},
+		TimeSeriesList: []*protoCommonV1.TimeSeries{
+			{Tags: "a", Fields: map[string][]byte{"test": {1}}},
+			{Tags: "b"},
+		},
+	}).Marshal()
+	_, groupedSeries, err = decodeTimeSeriesList(payload)
+	assert.NoError(t, err)
+	assert.Len(t, groupedSeries, 1)
+	assert.Equal(t, "a", groupedSeries[0].Tags())
+}
+
 func TestMetricContext_waitResponse(t *testing.T) {
 	t.Run("time out", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.TODO())
@@ -141,48 +167,68 @@ func TestMetricContext_waitResponse(t *testing.T) {
 	})
 }
 
-func TestMetricContext_checkErr(t *testing.T) {
+func TestMetricContext_checkError(t *testing.T) {
 	ctx := newMetricContext(context.TODO(), nil)
+	notFound := &protoCommonV1.TaskResponse{ErrMsg: "metric not found", ErrCode: string(models.ErrCodeNotFound)}
+	assertCode := func(code models.ErrorCode) func(ignore bool, err error) {
+		return func(ignore bool, err error) {
+			assert.True(t, ignore)
+			assert.Equal(t, code, models.ErrorCodeOf(err))
+		}
+	}
 
 	cases := []struct {
 		name     string
-		errMsg   string
+		resp     *protoCommonV1.TaskResponse
 		prepare  func()
 		assertFn func(ignore bool, err error)
 	}{
 		{
 			name: "empty err msg",
+			resp: &protoCommonV1.TaskResponse{},
 			assertFn: func(ignore bool, err error) {
 				assert.False(t, ignore)
 				assert.NoError(t, err)
 			},
 		},
 		{
-			name:   "fail err msg",
-			errMsg: "err",
-			assertFn: func(ignore bool, err error) {
-				assert.True(t, ignore)
-				assert.Error(t, err)
+			name:     "err without code",
+			resp:     &protoCommonV1.TaskResponse{ErrMsg: "err"},
+			assertFn: assertCode(models.ErrCodeInternal),
+		},
+		{
+			name:     "not found message without code",
+			resp:     &protoCommonV1.TaskResponse{ErrMsg: "not found"},
+			assertFn: assertCode(models.ErrCodeInternal),
+		},
+		{
+			name:     "timeout err",
+			resp:     &protoCommonV1.TaskResponse{ErrMsg: "exceed timeout", ErrCode: string(models.ErrCodeTimeout)},
+			assertFn: assertCode(models.ErrCodeTimeout),
+		},
+		{
+			name: "internal err when fan out to multiple nodes",
+			resp: &protoCommonV1.TaskResponse{ErrMsg: "err", ErrCode: string(models.ErrCodeInternal)},
+			prepare: func() {
+				ctx.requests = map[string]*protoCommonV1.TaskRequest{"leaf-1": {}, "leaf-2": {}}
 			},
+			assertFn: assertCode(models.ErrCodePartialFailure),
 		},
 		{
-			name:   "ignore not found",
-			errMsg: "not found",
+			name: "ignore not found",
+			resp: notFound,
 			assertFn: func(ignore bool, err error) {
 				assert.True(t, ignore)
 				assert.NoError(t, err)
 			},
 		},
 		{
-			name:   "ignore not found",
-			errMsg: "not found",
+			name: "all nodes not found",
+			resp: notFound,
 			prepare: func() {
-				_, _ = ctx.checkError("not found")
-			},
-			assertFn: func(ignore bool, err error) {
-				assert.True(t, ignore)
-				assert.Error(t, err)
+				_, _ = ctx.checkError(notFound)
 			},
+			assertFn: assertCode(models.ErrCodeNotFound),
 		},
 	}
 
@@ -190,11 +236,99 @@ func TestMetricContext_checkErr(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(_ *testing.T) {
 			ctx.tolerantNotFounds = 2
+			ctx.requests = nil
 			if tt.prepare != nil {
 				tt.prepare()
 			}
-			ignore, err := ctx.checkError(tt.errMsg)
+			ignore, err := ctx.checkError(tt.resp)
 			tt.assertFn(ignore, err)
 		})
 	}
 }
+
+func TestMetricContext_checkError_NotFoundPolicy(t *testing.T) {
+	notFound := &protoCommonV1.TaskResponse{ErrMsg: "metric not found", ErrCode: string(models.ErrCodeNotFound)}
+	cases := []struct {
+		name     string
+		policy   models.NotFoundPolicy
+		assertFn func(errs []error)
+	}{
+		{
+			name:   "all, fail only if all nodes not found",
+			policy: models.NotFoundPolicyAll,
+			assertFn: func(errs []error) {
+				assert.NoError(t, errs[0])
+				assert.Equal(t, models.ErrCodeNotFound, models.ErrorCodeOf(errs[1]))
+			},
+		},
+		{
+			name:   "strict, fail if any node not found",
+			policy: models.NotFoundPolicyStrict,
+			assertFn: func(errs []error) {
+				assert.Equal(t, models.ErrCodeNotFound, models.ErrorCodeOf(errs[0]))
+				assert.Contains(t, errs[0].Error(), "not found shards: [2]")
+			},
+		},
+		{
+			name:   "lenient, never fail",
+			policy: models.NotFoundPolicyLenient,
+			assertFn: func(errs []error) {
+				assert.NoError(t, errs[0])
+				assert.NoError(t, errs[1])
+			},
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(_ *testing.T) {
+			ctx := newMetricContext(context.TODO(), nil)
+			ctx.notFoundPolicy = tt.policy
+			ctx.addRequests(&protoCommonV1.TaskRequest{}, &models.PhysicalPlan{
+				Targets: []*models.Target{
+					{Indicator: "leaf-1", ShardIDs: []models.ShardID{1}},
+					{Indicator: "leaf-2", ShardIDs: []models.ShardID{2}},
+				},
+			})
+			var errs []error
+			for i, leaf := range []string{"leaf-2", "leaf-1"} {
+				ctx.handleShardCoverage(notFound, leaf)
+				ignore, err := ctx.checkError(notFound)
+				assert.True(t, ignore)
+				errs = append(errs, err)
+				if i == 0 && tt.policy == models.NotFoundPolicyStrict {
+					break
+				}
+			}
+			tt.assertFn(errs)
+		})
+	}
+}
+
+func BenchmarkMetricContext_HandleResponse(b *testing.B) {
+	tsList := &protoCommonV1.TimeSeriesList{
+		FieldAggSpecs: []*protoCommonV1.AggregatorSpec{
+			{
+				FieldName:    "test",
+				FieldType:    uint32(field.Sum),
+				FuncTypeList: []uint32{uint32(field.Sum)},
+			},
+		},
+	}
+	for i := 0; i < 1000; i++ {
+		tsList.TimeSeriesList = append(tsList.TimeSeriesList, &protoCommonV1.TimeSeries{
+			Tags:   fmt.Sprintf("host-%d,zone-%d", i, i%10),
+			Fields: map[string][]byte{"test": nil},
+		})
+	}
+	payload, _ := tsList.Marshal()
+	metricCtx := newMetricContext(context.TODO(), nil)
+	metricCtx.SetTracker(tracker.NewStageTracker(flow.NewTaskContextWithTimeout(context.TODO(), time.Minute)))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			metricCtx.HandleResponse(&protoCommonV1.TaskResponse{Payload: payload}, "leaf")
+		}
+	})
+}
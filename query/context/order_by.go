@@ -0,0 +1,139 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package context
+
+import (
+	"errors"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// buildOrderByItems builds order by items from order by expressions based on field's aggregator specs.
+func buildOrderByItems(orderByExprs []stmt.Expr,
+	fields map[string]*protoCommonV1.AggregatorSpec,
+) ([]*aggregation.OrderByItem, error) {
+	var orderByItems []*aggregation.OrderByItem
+	for _, orderBy := range orderByExprs {
+		expr := orderBy.(*stmt.OrderByExpr)
+		funcType := function.Unknown
+		var fieldName string
+		switch e := expr.Expr.(type) {
+		case *stmt.FieldExpr:
+			aggSpec, ok := fields[e.Name]
+			if ok {
+				funcType = field.Type(aggSpec.FieldType).GetOrderByFunc()
+				fieldName = e.Name
+			}
+		case *stmt.CallExpr:
+			funcType = e.FuncType
+			fieldName = e.Params[0].Rewrite()
+		}
+		if funcType == function.Unknown {
+			return nil, errors.New("cannot parse order by function")
+		}
+		orderByItems = append(orderByItems, &aggregation.OrderByItem{
+			Expr:     expr,
+			Name:     fieldName,
+			FuncType: funcType,
+			Desc:     expr.Desc,
+		})
+	}
+	return orderByItems, nil
+}
+
+// pushDownLimit returns the max grouped series which target keeps based on order by(offset+limit),
+// returns 0 if the result of query cannot be limited before merging all grouped series.
+func pushDownLimit(statement *stmt.Query) int {
+	if len(statement.OrderByItems) == 0 || statement.Limit <= 0 || !statement.HasGroupBy() ||
+		statement.AllFields || statement.IsMultiMetrics() || statement.HasSubQuery() {
+		return 0
+	}
+	for _, item := range statement.SelectItems {
+		if hasCrossGroupFunc(item) {
+			// function needs all grouped series, cannot limit grouped series before evaluating
+			return 0
+		}
+	}
+	return statement.Limit + statement.Offset
+}
+
+// hasCrossGroupFunc checks if expression has function which needs all grouped series.
+func hasCrossGroupFunc(expr stmt.Expr) bool {
+	switch e := expr.(type) {
+	case *stmt.SelectItem:
+		return hasCrossGroupFunc(e.Expr)
+	case *stmt.ParenExpr:
+		return hasCrossGroupFunc(e.Expr)
+	case *stmt.BinaryExpr:
+		return hasCrossGroupFunc(e.Left) || hasCrossGroupFunc(e.Right)
+	case *stmt.CallExpr:
+		if function.IsCrossGroup(e.FuncType) {
+			return true
+		}
+		for _, param := range e.Params {
+			if hasCrossGroupFunc(param) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// limitTimeSeriesList keeps top n grouped series based on order by of query statement,
+// evaluates select items same as root node, so that the result of root is not changed after merging.
+// NOTICE: time series list must be the complete grouped series(no other node returns the same group).
+func limitTimeSeriesList(statement *stmt.Query,
+	timeRange timeutil.TimeRange, interval int64,
+	fields map[string]*protoCommonV1.AggregatorSpec,
+	timeSeriesList []*protoCommonV1.TimeSeries,
+	limit int,
+) []*protoCommonV1.TimeSeries {
+	if limit <= 0 || len(timeSeriesList) <= limit {
+		return timeSeriesList
+	}
+	orderByItems, err := buildOrderByItems(statement.OrderByItems, fields)
+	if err != nil {
+		// root node will return the error of order by
+		return timeSeriesList
+	}
+	orderBy := aggregation.NewTopNOrderBy(orderByItems, limit)
+	seriesMap := make(map[string]*protoCommonV1.TimeSeries, len(timeSeriesList))
+	for _, ts := range timeSeriesList {
+		seriesMap[ts.Tags] = ts
+		fieldsData := make(map[field.Name][]byte, len(ts.Fields))
+		for name, data := range ts.Fields {
+			fieldsData[field.Name(name)] = data
+		}
+		expression := newExpressionFn(timeRange, interval, statement.SelectItems)
+		expression.Eval(series.NewGroupedIterator(ts.Tags, fieldsData))
+		orderBy.Push(aggregation.NewOrderByRow(ts.Tags, expression.ResultSet()))
+	}
+	rows := orderBy.ResultSet()
+	rs := make([]*protoCommonV1.TimeSeries, 0, len(rows))
+	for _, row := range rows {
+		tags, _ := row.ResultSet()
+		rs = append(rs, seriesMap[tags])
+	}
+	return rs
+}
@@ -0,0 +1,236 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package context
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonmodels "github.com/lindb/common/models"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/bit"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/pkg/timeutil"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestPushDownLimit(t *testing.T) {
+	sumF := &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}
+	newQuery := func() *stmt.Query {
+		return &stmt.Query{
+			MetricName:   "cpu",
+			SelectItems:  []stmt.Expr{&stmt.SelectItem{Expr: sumF}},
+			GroupBy:      []string{"host"},
+			OrderByItems: []stmt.Expr{&stmt.OrderByExpr{Expr: sumF}},
+			Limit:        10,
+			Offset:       5,
+		}
+	}
+	assert.Equal(t, 15, pushDownLimit(newQuery()))
+
+	cases := []struct {
+		name    string
+		prepare func(q *stmt.Query)
+	}{
+		{name: "no order by", prepare: func(q *stmt.Query) { q.OrderByItems = nil }},
+		{name: "no limit", prepare: func(q *stmt.Query) { q.Limit = 0 }},
+		{name: "no group by", prepare: func(q *stmt.Query) { q.GroupBy = nil }},
+		{name: "select all fields", prepare: func(q *stmt.Query) { q.AllFields = true }},
+		{name: "multi metrics", prepare: func(q *stmt.Query) { q.MetricNames = []string{"cpu", "mem"} }},
+		{name: "sub query", prepare: func(q *stmt.Query) { q.SubQuery = &stmt.Query{} }},
+		{
+			name: "percent of total",
+			prepare: func(q *stmt.Query) {
+				q.SelectItems = append(q.SelectItems, &stmt.SelectItem{Expr: &stmt.BinaryExpr{
+					Left:     &stmt.ParenExpr{Expr: &stmt.CallExpr{FuncType: function.Percent, Params: []stmt.Expr{sumF}}},
+					Operator: stmt.MUL,
+					Right:    &stmt.NumberLiteral{Val: 2},
+				}})
+			},
+		},
+		{
+			name: "topk",
+			prepare: func(q *stmt.Query) {
+				q.SelectItems = []stmt.Expr{&stmt.SelectItem{Expr: &stmt.CallExpr{
+					FuncType: function.Max,
+					Params:   []stmt.Expr{&stmt.CallExpr{FuncType: function.TopK, Params: []stmt.Expr{sumF}}},
+				}}}
+			},
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			q := newQuery()
+			tt.prepare(q)
+			assert.Zero(t, pushDownLimit(q))
+		})
+	}
+}
+
+func TestLimitTimeSeriesList(t *testing.T) {
+	timeRange := timeutil.TimeRange{Start: 1_600_000_000_000, End: 1_600_000_090_000}
+	interval := int64(10_000)
+	fields := map[string]*protoCommonV1.AggregatorSpec{"f": {
+		FieldName:    "f",
+		FieldType:    uint32(field.SumField),
+		FuncTypeList: []uint32{uint32(function.Sum)},
+	}}
+	r := rand.New(rand.NewSource(1))
+	var timeSeriesList []*protoCommonV1.TimeSeries
+	for i := 0; i < 100; i++ {
+		values := make([]float64, 10)
+		for j := range values {
+			values[j] = r.Float64() * 1000
+		}
+		timeSeriesList = append(timeSeriesList, &protoCommonV1.TimeSeries{
+			Tags:   fmt.Sprintf("host-%d", i),
+			Fields: map[string][]byte{"f": encodeSumField(t, timeRange.Start, values)},
+		})
+	}
+	f := &stmt.FieldExpr{Name: "f"}
+	cases := []struct {
+		name      string
+		orderBy   []stmt.Expr
+		limit     int
+		offset    int
+		seriesLen int
+	}{
+		{
+			name: "order by sum desc",
+			orderBy: []stmt.Expr{&stmt.OrderByExpr{
+				Expr: &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{f}},
+				Desc: true,
+			}},
+			limit:     10,
+			seriesLen: 10,
+		},
+		{
+			name: "order by max asc with offset",
+			orderBy: []stmt.Expr{&stmt.OrderByExpr{
+				Expr: &stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{f}},
+			}},
+			limit:     5,
+			offset:    3,
+			seriesLen: 8,
+		},
+		{
+			name:      "limit larger than series",
+			orderBy:   []stmt.Expr{&stmt.OrderByExpr{Expr: f, Desc: true}},
+			limit:     200,
+			seriesLen: 100,
+		},
+		{
+			name:      "unknown order by function",
+			orderBy:   []stmt.Expr{&stmt.OrderByExpr{Expr: &stmt.FieldExpr{Name: "unknown"}}},
+			limit:     10,
+			seriesLen: 100,
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			statement := &stmt.Query{
+				SelectItems:  []stmt.Expr{&stmt.SelectItem{Expr: f}},
+				GroupBy:      []string{"host"},
+				OrderByItems: tt.orderBy,
+				Limit:        tt.limit,
+				Offset:       tt.offset,
+			}
+			limited := limitTimeSeriesList(statement, timeRange, interval, fields,
+				timeSeriesList, pushDownLimit(statement))
+			assert.Len(t, limited, tt.seriesLen)
+			if tt.seriesLen == len(timeSeriesList) {
+				return
+			}
+			// result of root merging must be same with full time series list
+			expect, err := mergeTimeSeriesList(statement, timeRange, interval, fields, timeSeriesList)
+			assert.NoError(t, err)
+			rs, err := mergeTimeSeriesList(statement, timeRange, interval, fields, limited)
+			assert.NoError(t, err)
+			assert.Equal(t, expect.Series, rs.Series)
+			assert.Len(t, rs.Series, tt.limit)
+		})
+	}
+}
+
+// mergeTimeSeriesList merges the time series list in root node, returns the final result set.
+func mergeTimeSeriesList(statement *stmt.Query,
+	timeRange timeutil.TimeRange, interval int64,
+	fields map[string]*protoCommonV1.AggregatorSpec,
+	timeSeriesList []*protoCommonV1.TimeSeries,
+) (*commonmodels.ResultSet, error) {
+	var aggregatorSpecs []*protoCommonV1.AggregatorSpec
+	for _, spec := range fields {
+		aggregatorSpecs = append(aggregatorSpecs, spec)
+	}
+	tsList := &protoCommonV1.TimeSeriesList{
+		TimeSeriesList: timeSeriesList,
+		FieldAggSpecs:  aggregatorSpecs,
+		Start:          timeRange.Start,
+		End:            timeRange.End,
+		Interval:       interval,
+	}
+	payload, _ := tsList.Marshal()
+	ctx := NewRootMetricContext(&RootMetricContextDeps{
+		Ctx:       context.TODO(),
+		Statement: statement,
+	})
+	ctx.expectResults = 1
+	ctx.handleResponse(&protoCommonV1.TaskResponse{Payload: payload}, "leaf")
+	return ctx.makeResultSet()
+}
+
+// encodeSumField encodes the values of sum field as the binary data which leaf node returns.
+func encodeSumField(t *testing.T, startTime int64, values []float64) []byte {
+	return encodeField(t, startTime, field.Sum, values)
+}
+
+func encodeField(t *testing.T, startTime int64, aggType field.AggType, values []float64) []byte {
+	encoder := encoding.NewTSDEncoder(0)
+	for _, v := range values {
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(math.Float64bits(v))
+	}
+	data, err := encoder.Bytes()
+	assert.NoError(t, err)
+	writer := stream.NewBufferWriter(nil)
+	writer.PutByte(byte(aggType))
+	writer.PutVarint32(int32(len(data)))
+	writer.PutBytes(data)
+	fieldData, err := writer.Bytes()
+	assert.NoError(t, err)
+
+	writer = stream.NewBufferWriter(nil)
+	writer.PutByte(byte(field.SumField))
+	writer.PutVarint64(startTime)
+	writer.PutVarint32(int32(len(fieldData)))
+	writer.PutBytes(fieldData)
+	seriesData, err := writer.Bytes()
+	assert.NoError(t, err)
+	return seriesData
+}
@@ -20,13 +20,13 @@ package context
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"sort"
 	"strings"
 	"time"
 
 	commonmodels "github.com/lindb/common/models"
-	"github.com/lindb/common/pkg/encoding"
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/aggregation/function"
@@ -34,11 +34,13 @@ import (
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/query/tracker"
 	"github.com/lindb/lindb/rpc"
-	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -49,6 +51,9 @@ var (
 	newResultLimiterFn = aggregation.NewResultLimiter
 )
 
+// SeriesStream represents the receiver which series of result set are streamed to once made.
+type SeriesStream func(series *commonmodels.Series) error
+
 // RootMetricContextDeps represents root metric data search dependency.
 type RootMetricContextDeps struct {
 	Ctx          context.Context
@@ -58,6 +63,13 @@ type RootMetricContextDeps struct {
 	Statement    *stmt.Query
 	Choose       flow.NodeChoose
 	TransportMgr rpc.TransportManager
+	// MaxFanOut is the max number of target nodes which a query can fan out to, <=0 means no limit.
+	MaxFanOut int
+	// NotFoundPolicy represents how to handle the not found error returned by target nodes, empty means all.
+	NotFoundPolicy models.NotFoundPolicy
+	// SeriesStream receives series once evaluated(in order of order by rows if order by) if set,
+	// series are not kept in result set.
+	SeriesStream SeriesStream
 }
 
 // RootMetricContext represents root metric data search context.
@@ -65,14 +77,18 @@ type RootMetricContext struct {
 	MetricContext
 
 	Deps *RootMetricContextDeps
+
+	physicalPlans []*models.PhysicalPlan // keep physical plans for explain query
 }
 
 // NewRootMetricContext creates the root metric data search context.
 func NewRootMetricContext(deps *RootMetricContextDeps) *RootMetricContext {
-	return &RootMetricContext{
+	ctx := &RootMetricContext{
 		MetricContext: newMetricContext(deps.Ctx, deps.TransportMgr),
 		Deps:          deps,
 	}
+	ctx.notFoundPolicy = deps.NotFoundPolicy
+	return ctx
 }
 
 // MakePlan makes the metric data physical plan.
@@ -91,14 +107,20 @@ func (ctx *RootMetricContext) MakePlan() error {
 	if len(physicalPlans) == 0 {
 		return constants.ErrTargetNodesNotFound
 	}
+	if err := ctx.checkFanOut(physicalPlans); err != nil {
+		return err
+	}
 	stateMgr, ok := ctx.Deps.Choose.(broker.StateManager)
 	if ok {
 		databaseCfg, ok := stateMgr.GetDatabaseCfg(database)
 		if !ok {
 			return constants.ErrDatabaseNotExist
 		}
-		calcTimeRangeAndInterval(ctx.Deps.Statement, databaseCfg)
+		if err := calcTimeRangeAndInterval(ctx.Deps.Statement, databaseCfg); err != nil {
+			return err
+		}
 	}
+	ctx.pushDownLimit(physicalPlans)
 	payload, _ := ctx.Deps.Statement.MarshalJSON()
 	for _, physicalPlan := range physicalPlans {
 		//FIXME:
@@ -106,29 +128,89 @@ func (ctx *RootMetricContext) MakePlan() error {
 		if err := physicalPlan.Validate(); err != nil {
 			return err
 		}
-		ctx.addRequests(
-			&protoCommonV1.TaskRequest{
-				RequestID:    ctx.Deps.Request.RequestID,
-				RequestType:  protoCommonV1.RequestType_Data,
-				PhysicalPlan: encoding.JSONMarshal(physicalPlan),
-				Payload:      payload,
-			}, physicalPlan)
+		if ctx.Deps.Statement.Explain {
+			ctx.physicalPlans = append(ctx.physicalPlans, physicalPlan)
+		}
+		req := &protoCommonV1.TaskRequest{
+			RequestID:   ctx.Deps.Request.RequestID,
+			RequestType: protoCommonV1.RequestType_Data,
+			Payload:     payload,
+		}
+		rpc.EncodePhysicalPlan(req, physicalPlan)
+		ctx.addRequests(req, physicalPlan)
+	}
+	return nil
+}
+
+// checkFanOut checks if the number of target nodes exceeds max fan-out, reject the query before sending any task request.
+func (ctx *RootMetricContext) checkFanOut(physicalPlans []*models.PhysicalPlan) error {
+	if ctx.Deps.MaxFanOut <= 0 {
+		return nil
+	}
+	fanOut := 0
+	for _, physicalPlan := range physicalPlans {
+		fanOut += len(physicalPlan.Targets)
+	}
+	if fanOut > ctx.Deps.MaxFanOut {
+		return fmt.Errorf("%w, fan-out: %d, max fan-out: %d", constants.ErrQueryTooLarge, fanOut, ctx.Deps.MaxFanOut)
 	}
 	return nil
 }
 
-// WaitResponse waits metric data search task completed, then returns the result set,
+// pushDownLimit pushes down the limit of grouped series to leaf node which reads all shards,
+// leaf node returns top n grouped series based on order by instead of all grouped series.
+// if grouped series spread over multiple leaf nodes, the partial result of each leaf cannot be limited.
+func (ctx *RootMetricContext) pushDownLimit(physicalPlans []*models.PhysicalPlan) {
+	if len(physicalPlans) != 1 || len(physicalPlans[0].Targets) != 1 {
+		return
+	}
+	target := physicalPlans[0].Targets[0]
+	if len(target.ShardIDs) == 0 {
+		// compute node, not leaf node
+		return
+	}
+	target.Limit = pushDownLimit(ctx.Deps.Statement)
+}
+
+// WaitResponse waits metric data search task completed, then returns the result set with shard coverage,
+// if timeout but some shards responded, returns partial result set, shard coverage shows which shards timed out.
 func (ctx *RootMetricContext) WaitResponse() (any, error) {
 	err := ctx.waitResponse()
+	if errors.Is(err, constants.ErrTimeout) {
+		// response may be still handling after timeout, stop merging late responses,
+		// so that partial result set is made(and streamed) without holding lock
+		ctx.mutex.Lock()
+		ctx.partial = true
+		ctx.mutex.Unlock()
+	}
+	coverage := ctx.shardCoverage()
+	if err == nil && ctx.notFoundPolicy == models.NotFoundPolicyStrict && len(coverage.NotFound) > 0 {
+		// shards not found by leaf nodes under intermediate node are merged into shard coverage
+		return nil, models.NewError(models.ErrCodeNotFound, fmt.Sprintf("not found shards: %v", coverage.NotFound))
+	}
 	if err != nil {
-		return nil, err
+		if !errors.Is(err, constants.ErrTimeout) || len(coverage.Responded) == 0 {
+			return nil, err
+		}
 	}
 
-	return ctx.makeResultSet()
+	resultSet, err := ctx.makeResultSet()
+	if err != nil {
+		return nil, err
+	}
+	rs := &models.ResultSet{
+		ResultSet:     resultSet,
+		Coverage:      coverage,
+		PhysicalPlans: ctx.physicalPlans,
+	}
+	if ctx.Deps.Statement.Analyze {
+		rs.Analysis = models.FormatQueryStats(resultSet.Stats)
+	}
+	return rs, nil
 }
 
-// makeResultSet makes final result set from time series event(GroupedIterators).
-// TODO: can opt use stream, leaf node need return grouping if completed.
+// makeResultSet makes final result set from time series event(GroupedIterators),
+// series are streamed to series stream if set.
 func (ctx *RootMetricContext) makeResultSet() (resultSet *commonmodels.ResultSet, err error) {
 	makeResultStartTime := time.Now()
 	orderBy, err := ctx.buildOrderBy()
@@ -140,62 +222,116 @@ func (ctx *RootMetricContext) makeResultSet() (resultSet *commonmodels.ResultSet
 	resultSet = new(commonmodels.ResultSet)
 	// TODO: merge stats for cross idc query?
 	groupByKeys := statement.GroupBy
-	groupByKeysLength := len(groupByKeys)
 	fieldsMap := make(map[string]struct{})
 	timeRange := ctx.timeRange
 	interval := ctx.interval
-	if ctx.groupAgg != nil {
-		groupIts := ctx.groupAgg.ResultSet()
-		selectItems := ctx.getSelectItems()
-		for _, it := range groupIts {
-			// TODO: reuse expression??
-			expression := newExpressionFn(
-				timeRange,
-				interval,
-				selectItems,
-			)
-			// do expression eval
-			expression.Eval(it)
-
-			// result order by/limit
-			orderBy.Push(aggregation.NewOrderByRow(it.Tags(), expression.ResultSet()))
+	var calendarBuckets []int64
+	// addSeries builds series from grouped row, then adds it into result set or streams it to series stream
+	addSeries := func(tagValues string, fields map[string]*collections.FloatArray) error {
+		var tags map[string]string
+		// fill gaps of series after order by/limit, so filled values not affect series ranking
+		aggregation.FillGaps(statement.Fill, fields)
+		if groupByKeysLength := len(groupByKeys); groupByKeysLength > 0 {
+			tagValues := tag.SplitTagValues(tagValues)
+			if groupByKeysLength != len(tagValues) {
+				// if tag values not match group by tag keys, ignore this time series
+				return nil
+			}
+			// build group by tags for final result
+			tags = make(map[string]string)
+			for idx, tagKey := range groupByKeys {
+				tags[tagKey] = tagValues[idx]
+			}
 		}
+		timeSeries := commonmodels.NewSeries(tags, tagValues)
+		for fieldName, values := range fields {
+			if values == nil {
+				continue
+			}
 
-		rows := orderBy.ResultSet()
-		for _, row := range rows {
-			var tags map[string]string
-			tagValues, fields := row.ResultSet()
-			if groupByKeysLength > 0 {
-				tagValues := tag.SplitTagValues(tagValues)
-				if groupByKeysLength != len(tagValues) {
-					// if tag values not match group by tag keys, ignore this time series
+			points := commonmodels.NewPoints()
+			it := values.NewIterator()
+			for it.HasNext() {
+				slot, val := it.Next()
+				if math.IsNaN(val) {
+					// skip NaN point, e.g. division by zero of binary expression
 					continue
 				}
-				// build group by tags for final result
-				tags = make(map[string]string)
-				for idx, tagKey := range groupByKeys {
-					tags[tagKey] = tagValues[idx]
+				if calendarBuckets != nil {
+					points.AddPoint(calendarBuckets[slot], val)
+					continue
 				}
+				points.AddPoint(timeutil.CalcTimestamp(timeRange.Start, slot, timeutil.Interval(interval)), val)
 			}
-			timeSeries := commonmodels.NewSeries(tags, tagValues)
+			timeSeries.AddField(fieldName, points)
+			fieldsMap[fieldName] = struct{}{}
+		}
+		if ctx.Deps.SeriesStream == nil {
 			resultSet.AddSeries(timeSeries)
-			for fieldName, values := range fields {
-				if values == nil {
+			return nil
+		}
+		// stream series once made, so that all series of large result set not kept in memory
+		return ctx.Deps.SeriesStream(timeSeries)
+	}
+	if ctx.groupAgg != nil {
+		groupIts := ctx.groupAgg.ResultSet()
+		groupIts, calendarBuckets = ctx.mergeCalendarBuckets(groupIts)
+		selectItems := ctx.getSelectItems()
+		if ctx.Deps.SeriesStream != nil && isStreamable(statement, selectItems) {
+			// no order by and no function needs all grouped series,
+			// stream series once grouped iterator evaluated, rows before offset/after limit are skipped
+			for idx, it := range groupIts {
+				if idx >= statement.Offset+statement.Limit {
+					break
+				}
+				if idx < statement.Offset {
 					continue
 				}
+				if err := addSeries(it.Tags(), ctx.evaluate(selectItems, it)); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			// argmax/argmin needs all grouped series(by tag of arg selector), select tag value of extreme series
+			var argValues map[string]map[string]*collections.FloatArray
+			groupByKeys, groupIts, argValues = ctx.argSelect(selectItems, groupByKeys, groupIts)
+			tagsList := make([]string, 0, len(groupIts))
+			resultSets := make([]map[string]*collections.FloatArray, 0, len(groupIts))
+			for _, it := range groupIts {
+				rs := ctx.evaluate(selectItems, it)
+				tags := it.Tags()
+				for fieldName, values := range argValues[tags] {
+					// values of arg selector are the values of selected series
+					rs[fieldName] = values
+				}
+
+				tagsList = append(tagsList, tags)
+				resultSets = append(resultSets, rs)
+			}
+			// histogram_quantile needs all buckets, merge buckets(grouped by le) and interpolate quantile
+			groupByKeys, tagsList, resultSets = aggregation.HistogramQuantile(selectItems, groupByKeys, tagsList, resultSets)
+			// percent of total needs all grouped series, so do it after all expressions evaluated
+			aggregation.PercentOfTotal(selectItems, resultSets)
+			// topk/bottomk needs all grouped series, keep top/bottom n grouped series
+			tagsList, resultSets = aggregation.RankSelect(selectItems, tagsList, resultSets)
+
+			for idx, rs := range resultSets {
+				// result order by/limit
+				orderBy.Push(aggregation.NewOrderByRow(tagsList[idx], rs))
+			}
 
-				points := commonmodels.NewPoints()
-				it := values.NewIterator()
-				for it.HasNext() {
-					slot, val := it.Next()
-					if math.IsNaN(val) {
-						// TODO: need check
-						continue
-					}
-					points.AddPoint(timeutil.CalcTimestamp(timeRange.Start, slot, timeutil.Interval(interval)), val)
+			rows := orderBy.ResultSet()
+			if offset := statement.Offset; offset > 0 {
+				// skip rows before offset, if offset larger than rows, returns empty result
+				if offset > len(rows) {
+					offset = len(rows)
+				}
+				rows = rows[offset:]
+			}
+			for _, row := range rows {
+				if err := addSeries(row.ResultSet()); err != nil {
+					return nil, err
 				}
-				timeSeries.AddField(fieldName, points)
-				fieldsMap[fieldName] = struct{}{}
 			}
 		}
 	}
@@ -205,13 +341,16 @@ func (ctx *RootMetricContext) makeResultSet() (resultSet *commonmodels.ResultSet
 	})
 
 	resultSet.MetricName = statement.MetricName
-	resultSet.GroupBy = statement.GroupBy
+	resultSet.GroupBy = groupByKeys
 	for fName := range fieldsMap {
 		resultSet.Fields = append(resultSet.Fields, fName)
 	}
 	resultSet.StartTime = timeRange.Start
 	resultSet.EndTime = timeRange.End
 	resultSet.Interval = interval
+	if calendarBuckets != nil {
+		resultSet.Interval = statement.Calendar.Interval().Int64()
+	}
 
 	if ctx.stats != nil {
 		now := time.Now()
@@ -227,48 +366,99 @@ func (ctx *RootMetricContext) makeResultSet() (resultSet *commonmodels.ResultSet
 			State:      tracker.CompleteState.String(),
 			Async:      false,
 		})
+		if statement.GroupByAll {
+			ctx.stats.Stages = append(ctx.stats.Stages, ctx.groupByAllStats())
+		}
 		resultSet.Stats = ctx.stats
 	}
 	return resultSet, nil
 }
 
+// evaluate evaluates select items over grouped series, returns the values of select items.
+func (ctx *RootMetricContext) evaluate(selectItems []stmt.Expr, it series.GroupedIterator) map[string]*collections.FloatArray {
+	// TODO: reuse expression??
+	expression := newExpressionFn(
+		ctx.timeRange,
+		ctx.interval,
+		selectItems,
+	)
+	// do expression eval
+	expression.Eval(it)
+	return expression.ResultSet()
+}
+
+// isStreamable checks if series can be streamed once grouped series evaluated,
+// returns false if series need be ordered or select items need all grouped series.
+func isStreamable(statement *stmt.Query, selectItems []stmt.Expr) bool {
+	if len(statement.OrderByItems) > 0 {
+		return false
+	}
+	for _, item := range selectItems {
+		if hasCrossGroupFunc(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeCalendarBuckets merges hourly buckets of grouped series into calendar buckets if group by time(1w/1M/1y),
+// then expressions evaluate over calendar buckets(slot is the index of calendar bucket), returns start times of buckets.
+func (ctx *RootMetricContext) mergeCalendarBuckets(groupIts series.GroupedIterators) (series.GroupedIterators, []int64) {
+	statement := ctx.Deps.Statement
+	if statement.Calendar == nil || statement.AutoGroupByTime || statement.SampleInterval > 0 || ctx.interval <= 0 {
+		return groupIts, nil
+	}
+	buckets := statement.Calendar.Buckets(ctx.timeRange, statement.IntervalOffset.Int64(), queryLocation(statement))
+	if len(buckets) == 0 {
+		return groupIts, nil
+	}
+	for idx := range groupIts {
+		groupIts[idx] = aggregation.NewCalendarGroupedIterator(groupIts[idx], ctx.timeRange.Start, ctx.interval, buckets)
+	}
+	ctx.timeRange = timeutil.TimeRange{
+		Start: ctx.timeRange.Start,
+		End:   ctx.timeRange.Start + int64(len(buckets)-1)*ctx.interval,
+	}
+	return groupIts, buckets
+}
+
+// groupByAllStats returns the stage stats of group by * which expanded tag keys when plan.
+func (ctx *RootMetricContext) groupByAllStats() *commonmodels.StageStats {
+	groupBy := ctx.Deps.Statement.GroupBy
+	stats := &models.GroupByStats{TagKeys: groupBy}
+	if len(groupBy) > 0 {
+		stats.Warning = fmt.Sprintf("group by * expanded to %d tag key(s), "+
+			"result may have high cardinality, limited by max series per query", len(groupBy))
+	}
+	return &commonmodels.StageStats{
+		Identifier: "Group By *",
+		State:      tracker.CompleteState.String(),
+		Operators: []*commonmodels.OperatorStats{{
+			Identifier: "Expand Tag Keys",
+			Stats:      stats,
+		}},
+	}
+}
+
 // buildOrderBy builds order by container.
 func (ctx *RootMetricContext) buildOrderBy() (aggregation.OrderBy, error) {
 	statement := ctx.Deps.Statement
 	// build order by items if need do order by query
 	orderByExprs := statement.OrderByItems
+	// keep offset+limit rows, then skip offset rows when making result set
+	limit := statement.Limit
+	if statement.Offset > 0 {
+		limit += statement.Offset
+	}
 	if len(orderByExprs) == 0 {
 		// use default limiter
-		return newResultLimiterFn(statement.Limit), nil
-	}
-	var orderByItems []*aggregation.OrderByItem
-	fields := ctx.aggregatorSpecs
-	for _, orderBy := range orderByExprs {
-		expr := orderBy.(*stmt.OrderByExpr)
-		funcType := function.Unknown
-		var fieldName string
-		switch e := expr.Expr.(type) {
-		case *stmt.FieldExpr:
-			aggSpec, ok := fields[e.Name]
-			if ok {
-				funcType = field.Type(aggSpec.FieldType).GetOrderByFunc()
-				fieldName = e.Name
-			}
-		case *stmt.CallExpr:
-			funcType = e.FuncType
-			fieldName = e.Params[0].Rewrite()
-		}
-		if funcType == function.Unknown {
-			return nil, errors.New("cannot parse order by function")
-		}
-		orderByItems = append(orderByItems, &aggregation.OrderByItem{
-			Expr:     expr,
-			Name:     fieldName,
-			FuncType: funcType,
-			Desc:     expr.Desc,
-		})
+		return newResultLimiterFn(limit), nil
+	}
+	orderByItems, err := buildOrderByItems(orderByExprs, ctx.aggregatorSpecs)
+	if err != nil {
+		return nil, err
 	}
-	return aggregation.NewTopNOrderBy(orderByItems, statement.Limit), nil
+	return aggregation.NewTopNOrderBy(orderByItems, limit), nil
 }
 
 // getSelectItems returns select field items.
@@ -281,7 +471,7 @@ func (ctx *RootMetricContext) getSelectItems() []stmt.Expr {
 		selectItems = []stmt.Expr{}
 		isHistogram := false
 		for _, fieldName := range allAggFields {
-			if strings.HasPrefix(string(fieldName), "__bucket_") {
+			if strings.HasPrefix(string(fieldName), "__bucket_") || metric.IsExponentialBucket(string(fieldName)) {
 				// filter histogram raw field
 				isHistogram = true
 				continue
@@ -21,25 +21,32 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	commonmodels "github.com/lindb/common/models"
+	"github.com/lindb/common/pkg/encoding"
 	commontimeutil "github.com/lindb/common/pkg/timeutil"
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/sketch"
 	"github.com/lindb/lindb/pkg/timeutil"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
+	"github.com/lindb/lindb/query/tracker"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
@@ -56,6 +63,87 @@ func TestRootMetricContext_WaitResponse(t *testing.T) {
 		assert.Nil(t, resp)
 		assert.Equal(t, constants.ErrTimeout, err)
 	})
+	t.Run("timeout with partial result", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+			Ctx:       ctx,
+			Statement: &stmt.Query{},
+		})
+		metricCtx.addRequests(&protoCommonV1.TaskRequest{}, &models.PhysicalPlan{
+			Targets: []*models.Target{
+				{Indicator: "leaf-1", ShardIDs: []models.ShardID{1}},
+				{Indicator: "leaf-2", ShardIDs: []models.ShardID{2}},
+			},
+		})
+		metricCtx.handleShardCoverage(&protoCommonV1.TaskResponse{}, "leaf-1")
+		metricCtx.state["leaf-1"] = models.Complete
+		cancel()
+		resp, err := metricCtx.WaitResponse()
+		assert.NoError(t, err)
+		rs := resp.(*models.ResultSet)
+		assert.NotNil(t, rs.ResultSet)
+		assert.True(t, rs.Coverage.IsPartial())
+		assert.Equal(t, []models.ShardID{1}, rs.Coverage.Responded)
+		assert.Equal(t, []models.ShardID{2}, rs.Coverage.TimedOut)
+	})
+	t.Run("stream partial result without holding lock", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer func() {
+			newExpressionFn = aggregation.NewExpression
+			ctrl.Finish()
+		}()
+		expr := aggregation.NewMockExpression(ctrl)
+		newExpressionFn = func(_ timeutil.TimeRange, _ int64, _ []stmt.Expr) aggregation.Expression {
+			return expr
+		}
+		groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
+		groupIt := series.NewMockGroupedIterator(ctrl)
+		groupIt.EXPECT().Tags().Return("a")
+		groupAgg.EXPECT().ResultSet().Return(series.GroupedIterators{groupIt})
+		expr.EXPECT().Eval(groupIt)
+		expr.EXPECT().ResultSet().Return(map[string]*collections.FloatArray{"f": collections.NewFloatArray(10)})
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		var metricCtx *RootMetricContext
+		streamed := 0
+		metricCtx = NewRootMetricContext(&RootMetricContextDeps{
+			Ctx:       ctx,
+			Statement: &stmt.Query{GroupBy: []string{"host"}, Limit: 10},
+			SeriesStream: func(_ *commonmodels.Series) error {
+				// late response not blocked by streaming, and not merged into partial result set
+				metricCtx.HandleResponse(&protoCommonV1.TaskResponse{Completed: true}, "leaf-2")
+				streamed++
+				return nil
+			},
+		})
+		metricCtx.groupAgg = groupAgg
+		metricCtx.addRequests(&protoCommonV1.TaskRequest{}, &models.PhysicalPlan{
+			Targets: []*models.Target{
+				{Indicator: "leaf-1", ShardIDs: []models.ShardID{1}},
+				{Indicator: "leaf-2", ShardIDs: []models.ShardID{2}},
+			},
+		})
+		metricCtx.handleShardCoverage(&protoCommonV1.TaskResponse{}, "leaf-1")
+		metricCtx.state["leaf-1"] = models.Complete
+		cancel()
+		resp, err := metricCtx.WaitResponse()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, streamed)
+		rs := resp.(*models.ResultSet)
+		assert.Equal(t, []models.ShardID{2}, rs.Coverage.TimedOut)
+	})
+	t.Run("failure with partial result", func(t *testing.T) {
+		metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+			Ctx:       context.TODO(),
+			Statement: &stmt.Query{},
+		})
+		metricCtx.coverage.Responded = []models.ShardID{1}
+		metricCtx.err = fmt.Errorf("err")
+		close(metricCtx.doneCh)
+		resp, err := metricCtx.WaitResponse()
+		assert.Nil(t, resp)
+		assert.Error(t, err)
+	})
 	t.Run("complete with result", func(t *testing.T) {
 		metricCtx := NewRootMetricContext(&RootMetricContextDeps{
 			Ctx:       context.TODO(),
@@ -68,6 +156,33 @@ func TestRootMetricContext_WaitResponse(t *testing.T) {
 		assert.NotNil(t, resp)
 		assert.NoError(t, err)
 	})
+	t.Run("lenient policy returns not found shards", func(t *testing.T) {
+		metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+			Ctx:            context.TODO(),
+			Statement:      &stmt.Query{},
+			NotFoundPolicy: models.NotFoundPolicyLenient,
+		})
+		metricCtx.coverage.Responded = []models.ShardID{1}
+		metricCtx.coverage.NotFound = []models.ShardID{2}
+		close(metricCtx.doneCh)
+		resp, err := metricCtx.WaitResponse()
+		assert.NoError(t, err)
+		rs := resp.(*models.ResultSet)
+		assert.Equal(t, []models.ShardID{2}, rs.Coverage.NotFound)
+	})
+	t.Run("strict policy fails if any shard not found", func(t *testing.T) {
+		metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+			Ctx:            context.TODO(),
+			Statement:      &stmt.Query{},
+			NotFoundPolicy: models.NotFoundPolicyStrict,
+		})
+		metricCtx.coverage.Responded = []models.ShardID{1}
+		metricCtx.coverage.NotFound = []models.ShardID{2}
+		close(metricCtx.doneCh)
+		resp, err := metricCtx.WaitResponse()
+		assert.Nil(t, resp)
+		assert.Equal(t, models.ErrCodeNotFound, models.ErrorCodeOf(err))
+	})
 }
 
 func TestRootMetricDataContext_MakePlan(t *testing.T) {
@@ -150,6 +265,147 @@ func TestRootMetricDataContext_MakePlan(t *testing.T) {
 	}
 }
 
+func TestRootMetricDataContext_MakePlan_Explain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	choose := flow.NewMockNodeChoose(ctrl)
+	plan := &models.PhysicalPlan{
+		Database: "test",
+		Targets:  []*models.Target{{Indicator: "leaf-1", ShardIDs: []models.ShardID{1}}},
+	}
+	choose.EXPECT().Choose(gomock.Any(), gomock.Any()).Return([]*models.PhysicalPlan{plan}, nil).Times(2)
+
+	// non-explain query doesn't keep physical plans
+	metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+		Ctx:       context.TODO(),
+		Choose:    choose,
+		Request:   &models.Request{},
+		Statement: &stmt.Query{},
+	})
+	assert.NoError(t, metricCtx.MakePlan())
+	assert.Nil(t, metricCtx.physicalPlans)
+
+	metricCtx = NewRootMetricContext(&RootMetricContextDeps{
+		Ctx:       context.TODO(),
+		Choose:    choose,
+		Request:   &models.Request{},
+		Statement: &stmt.Query{Explain: true},
+	})
+	assert.NoError(t, metricCtx.MakePlan())
+	assert.Equal(t, []*models.PhysicalPlan{plan}, metricCtx.physicalPlans)
+	go func() {
+		close(metricCtx.doneCh)
+	}()
+	resp, err := metricCtx.WaitResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.PhysicalPlan{plan}, resp.(*models.ResultSet).PhysicalPlans)
+}
+
+func TestRootMetricDataContext_ExplainAnalyze(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	choose := flow.NewMockNodeChoose(ctrl)
+	plan := &models.PhysicalPlan{
+		Database: "test",
+		Targets:  []*models.Target{{Indicator: "leaf-1", ShardIDs: []models.ShardID{1}}},
+	}
+	choose.EXPECT().Choose(gomock.Any(), gomock.Any()).Return([]*models.PhysicalPlan{plan}, nil)
+	metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+		Ctx:         context.TODO(),
+		Choose:      choose,
+		Request:     &models.Request{},
+		CurrentNode: models.StatelessNode{HostIP: "1.1.1.1", HTTPPort: 9000},
+		Statement:   &stmt.Query{Explain: true, Analyze: true},
+	})
+	metricCtx.SetTracker(tracker.NewStageTracker(flow.NewTaskContextWithTimeout(context.TODO(), time.Minute)))
+	assert.NoError(t, metricCtx.MakePlan())
+	// leaf responses execute stats
+	leafStats := &commonmodels.NodeStats{
+		TotalCost: (5 * time.Millisecond).Nanoseconds(),
+		Stages: []*commonmodels.StageStats{{
+			Identifier: "Shard(1)",
+			Cost:       (3 * time.Millisecond).Nanoseconds(),
+			Operators: []*commonmodels.OperatorStats{{
+				Identifier: "Data Load",
+				Cost:       (2 * time.Millisecond).Nanoseconds(),
+				Stats:      &commonmodels.SeriesStats{NumOfSeries: 10},
+			}},
+		}},
+	}
+	metricCtx.handleStats(&protoCommonV1.TaskResponse{
+		Stats:   encoding.JSONMarshal(leafStats),
+		Payload: make([]byte, 1024),
+	}, "leaf-1")
+	close(metricCtx.doneCh)
+
+	resp, err := metricCtx.WaitResponse()
+	assert.NoError(t, err)
+	rs := resp.(*models.ResultSet)
+	assert.NotNil(t, rs.Stats)
+	assert.Equal(t, "1.1.1.1:9000", rs.Stats.Node)
+	assert.Len(t, rs.Stats.Children, 1)
+	leaf := rs.Stats.Children[0]
+	assert.Equal(t, "leaf-1", leaf.Node)
+	assert.Equal(t, leafStats.TotalCost, leaf.TotalCost)
+	assert.True(t, leaf.NetPayload > 1024)
+	assert.Equal(t, leafStats.Stages[0].Cost, leaf.Stages[0].Cost)
+	// readable stats tree matches physical plan hierarchy
+	assert.Contains(t, rs.Analysis, "1.1.1.1:9000 [total: ")
+	assert.Contains(t, rs.Analysis, "└─ leaf-1 [total: 5ms, network: ")
+	assert.Contains(t, rs.Analysis, "   └─ Stage(Shard(1)) [cost: 3ms]")
+	assert.Contains(t, rs.Analysis, "      └─ Operator(Data Load) [cost: 2ms, series: 10]")
+
+	// explain without analyze doesn't format stats
+	metricCtx.Deps.Statement.Analyze = false
+	resp, err = metricCtx.WaitResponse()
+	assert.NoError(t, err)
+	assert.Empty(t, resp.(*models.ResultSet).Analysis)
+}
+
+func TestRootMetricDataContext_MakePlan_TooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	choose := flow.NewMockNodeChoose(ctrl)
+	choose.EXPECT().Choose(gomock.Any(), gomock.Any()).Return([]*models.PhysicalPlan{
+		{
+			Database: "test",
+			Targets: []*models.Target{
+				{Indicator: "leaf-1", ShardIDs: []models.ShardID{1}},
+				{Indicator: "leaf-2", ShardIDs: []models.ShardID{2}},
+			},
+		},
+		{
+			Database: "test",
+			Targets:  []*models.Target{{Indicator: "leaf-3", ShardIDs: []models.ShardID{3}}},
+		},
+	}, nil).Times(2)
+
+	metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+		Ctx:       context.TODO(),
+		Choose:    choose,
+		Request:   &models.Request{},
+		Statement: &stmt.Query{},
+		MaxFanOut: 2,
+	})
+	err := metricCtx.MakePlan()
+	assert.ErrorIs(t, err, constants.ErrQueryTooLarge)
+	// reject before adding any task request
+	assert.Empty(t, metricCtx.requests)
+
+	metricCtx = NewRootMetricContext(&RootMetricContextDeps{
+		Ctx:       context.TODO(),
+		Choose:    choose,
+		Request:   &models.Request{},
+		Statement: &stmt.Query{},
+		MaxFanOut: 3,
+	})
+	assert.NoError(t, metricCtx.MakePlan())
+	assert.Len(t, metricCtx.requests, 3)
+}
+
 func TestRootMetricDataContext_makeResultSet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -166,6 +422,7 @@ func TestRootMetricDataContext_makeResultSet(t *testing.T) {
 		return orderBy
 	}
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
+	var streamed []string
 
 	cases := []struct {
 		name    string
@@ -230,6 +487,186 @@ func TestRootMetricDataContext_makeResultSet(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			name: "build group by * result set with explain",
+			prepare: func(ctx *RootMetricContext) {
+				ctx.Deps.Statement.GroupBy = []string{"host", "ip"}
+				ctx.Deps.Statement.GroupByAll = true
+			},
+			assert: func(rs *commonmodels.ResultSet, err error) {
+				assert.NoError(t, err)
+				stage := rs.Stats.Stages[len(rs.Stats.Stages)-1]
+				assert.Equal(t, "Group By *", stage.Identifier)
+				stats := stage.Operators[0].Stats.(*models.GroupByStats)
+				assert.Equal(t, []string{"host", "ip"}, stats.TagKeys)
+				assert.NotEmpty(t, stats.Warning)
+			},
+		},
+		{
+			name: "build result set with offset",
+			prepare: func(ctx *RootMetricContext) {
+				ctx.Deps.Statement.GroupBy = []string{"a"}
+				ctx.Deps.Statement.Offset = 2
+				ctx.groupAgg = groupAgg
+				groupIt := series.NewMockGroupedIterator(ctrl)
+				groupAgg.EXPECT().ResultSet().Return(series.GroupedIterators{groupIt})
+				expr.EXPECT().Eval(gomock.Any())
+				groupIt.EXPECT().Tags().Return("tags")
+				expr.EXPECT().ResultSet().Return(map[string]*collections.FloatArray{"f": collections.NewFloatArray(10)})
+				orderBy.EXPECT().Push(gomock.Any())
+				skipRow := aggregation.NewMockRow(ctrl)
+				row := aggregation.NewMockRow(ctrl)
+				values := collections.NewFloatArray(10)
+				values.SetValue(0, 1.1)
+				row.EXPECT().ResultSet().Return("c", map[string]*collections.FloatArray{"f": values})
+				orderBy.EXPECT().ResultSet().Return([]aggregation.Row{skipRow, skipRow, row})
+			},
+			assert: func(rs *commonmodels.ResultSet, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, rs.Series, 1)
+				assert.Equal(t, "c", rs.Series[0].TagValues)
+			},
+		},
+		{
+			name: "build result set with fill previous",
+			prepare: func(ctx *RootMetricContext) {
+				ctx.Deps.Statement.GroupBy = []string{"a"}
+				ctx.Deps.Statement.Fill = &stmt.Fill{Type: stmt.FillPrevious}
+				ctx.interval = 10
+				ctx.groupAgg = groupAgg
+				groupIt := series.NewMockGroupedIterator(ctrl)
+				groupAgg.EXPECT().ResultSet().Return(series.GroupedIterators{groupIt})
+				expr.EXPECT().Eval(gomock.Any())
+				groupIt.EXPECT().Tags().Return("tags")
+				expr.EXPECT().ResultSet().Return(map[string]*collections.FloatArray{"f": collections.NewFloatArray(5)})
+				orderBy.EXPECT().Push(gomock.Any())
+				row := aggregation.NewMockRow(ctrl)
+				values := collections.NewFloatArray(5)
+				values.SetValue(1, 1.1)
+				values.SetValue(3, 2.2)
+				row.EXPECT().ResultSet().Return("c", map[string]*collections.FloatArray{"f": values})
+				orderBy.EXPECT().ResultSet().Return([]aggregation.Row{row})
+			},
+			assert: func(rs *commonmodels.ResultSet, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, rs.Series, 1)
+				// leading gap remains empty
+				assert.Equal(t, map[int64]float64{10: 1.1, 20: 1.1, 30: 2.2, 40: 2.2}, rs.Series[0].Fields["f"])
+			},
+		},
+		{
+			name: "build result set with offset larger than result",
+			prepare: func(ctx *RootMetricContext) {
+				ctx.Deps.Statement.GroupBy = []string{"a"}
+				ctx.Deps.Statement.Offset = 10
+				ctx.groupAgg = groupAgg
+				groupIt := series.NewMockGroupedIterator(ctrl)
+				groupAgg.EXPECT().ResultSet().Return(series.GroupedIterators{groupIt})
+				expr.EXPECT().Eval(gomock.Any())
+				groupIt.EXPECT().Tags().Return("tags")
+				expr.EXPECT().ResultSet().Return(map[string]*collections.FloatArray{"f": collections.NewFloatArray(10)})
+				orderBy.EXPECT().Push(gomock.Any())
+				row := aggregation.NewMockRow(ctrl)
+				orderBy.EXPECT().ResultSet().Return([]aggregation.Row{row, row})
+			},
+			assert: func(rs *commonmodels.ResultSet, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, rs.Series)
+			},
+		},
+		{
+			name: "stream series once evaluated",
+			prepare: func(ctx *RootMetricContext) {
+				ctx.Deps.Statement.GroupBy = []string{"a"}
+				ctx.Deps.Statement.Offset = 1
+				ctx.Deps.Statement.Limit = 2
+				ctx.groupAgg = groupAgg
+				var its series.GroupedIterators
+				for _, tags := range []string{"a", "b", "c", "d"} {
+					groupIt := series.NewMockGroupedIterator(ctrl)
+					groupIt.EXPECT().Tags().Return(tags).AnyTimes()
+					its = append(its, groupIt)
+				}
+				groupAgg.EXPECT().ResultSet().Return(its)
+				values := collections.NewFloatArray(10)
+				values.SetValue(0, 1.1)
+				evaluated := 0
+				evalFn := func(_ series.GroupedIterator) { evaluated++ }
+				// rows before offset/after limit not evaluated
+				expr.EXPECT().Eval(its[1]).Do(evalFn)
+				expr.EXPECT().Eval(its[2]).Do(evalFn)
+				expr.EXPECT().ResultSet().Return(map[string]*collections.FloatArray{"f": values}).Times(2)
+				ctx.Deps.SeriesStream = func(series *commonmodels.Series) error {
+					// series streamed after each grouped series evaluated
+					assert.Equal(t, len(streamed)+1, evaluated)
+					streamed = append(streamed, series.TagValues)
+					assert.Equal(t, map[int64]float64{0: 1.1}, series.Fields["f"])
+					return nil
+				}
+			},
+			assert: func(rs *commonmodels.ResultSet, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"b", "c"}, streamed)
+				assert.Empty(t, rs.Series)
+				assert.Equal(t, []string{"f"}, rs.Fields)
+			},
+		},
+		{
+			name: "stream series in order of order by rows",
+			prepare: func(ctx *RootMetricContext) {
+				streamed = nil
+				ctx.Deps.Statement.GroupBy = []string{"a"}
+				ctx.Deps.Statement.Limit = 10
+				ctx.Deps.Statement.OrderByItems = []stmt.Expr{
+					&stmt.OrderByExpr{Expr: &stmt.FieldExpr{Name: "f"}, Desc: true},
+				}
+				ctx.groupAgg = groupAgg
+				evaluated := 0
+				var its series.GroupedIterators
+				for idx, tags := range []string{"a", "b"} {
+					groupIt := series.NewMockGroupedIterator(ctrl)
+					groupIt.EXPECT().Tags().Return(tags).AnyTimes()
+					its = append(its, groupIt)
+					values := collections.NewFloatArray(10)
+					values.SetValue(0, float64(idx+1))
+					expr.EXPECT().Eval(groupIt).Do(func(_ series.GroupedIterator) { evaluated++ })
+					expr.EXPECT().ResultSet().Return(map[string]*collections.FloatArray{"f": values})
+				}
+				groupAgg.EXPECT().ResultSet().Return(its)
+				ctx.Deps.SeriesStream = func(series *commonmodels.Series) error {
+					// all grouped series evaluated before streaming
+					assert.Equal(t, 2, evaluated)
+					streamed = append(streamed, series.TagValues)
+					return nil
+				}
+			},
+			assert: func(rs *commonmodels.ResultSet, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"b", "a"}, streamed)
+				assert.Empty(t, rs.Series)
+			},
+		},
+		{
+			name: "stream series failure",
+			prepare: func(ctx *RootMetricContext) {
+				ctx.Deps.Statement.GroupBy = []string{"a"}
+				ctx.Deps.Statement.Limit = 10
+				ctx.groupAgg = groupAgg
+				ctx.Deps.SeriesStream = func(_ *commonmodels.Series) error {
+					return fmt.Errorf("err")
+				}
+				groupIt := series.NewMockGroupedIterator(ctrl)
+				groupAgg.EXPECT().ResultSet().Return(series.GroupedIterators{groupIt, groupIt})
+				// stop evaluating once stream failure
+				expr.EXPECT().Eval(gomock.Any())
+				groupIt.EXPECT().Tags().Return("a")
+				expr.EXPECT().ResultSet().Return(map[string]*collections.FloatArray{"f": nil})
+			},
+			assert: func(rs *commonmodels.ResultSet, err error) {
+				assert.Nil(t, rs)
+				assert.Error(t, err)
+			},
+		},
 		{
 			name: "build all fields result set",
 			prepare: func(ctx *RootMetricContext) {
@@ -308,3 +745,224 @@ func TestRootMetricDataContext_makeResultSet(t *testing.T) {
 		})
 	}
 }
+
+func TestRootMetricContext_groupByAllStats(t *testing.T) {
+	metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+		Statement: &stmt.Query{GroupByAll: true},
+	})
+	// metric without tags, collapses to single group
+	stage := metricCtx.groupByAllStats()
+	stats := stage.Operators[0].Stats.(*models.GroupByStats)
+	assert.Empty(t, stats.TagKeys)
+	assert.Empty(t, stats.Warning)
+}
+
+func TestRootMetricContext_pushDownLimit(t *testing.T) {
+	f := &stmt.FieldExpr{Name: "f"}
+	metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+		Statement: &stmt.Query{
+			SelectItems:  []stmt.Expr{&stmt.SelectItem{Expr: f}},
+			GroupBy:      []string{"host"},
+			OrderByItems: []stmt.Expr{&stmt.OrderByExpr{Expr: f}},
+			Limit:        10,
+		},
+	})
+	// single leaf node reads all shards
+	leaf := &models.Target{Indicator: "1.1.1.1:9000", ShardIDs: []models.ShardID{1, 2}}
+	metricCtx.pushDownLimit([]*models.PhysicalPlan{{Targets: []*models.Target{leaf}}})
+	assert.Equal(t, 10, leaf.Limit)
+	// grouped series spread over multiple leaf nodes
+	leaf1 := &models.Target{Indicator: "1.1.1.1:9000", ShardIDs: []models.ShardID{1}}
+	leaf2 := &models.Target{Indicator: "1.1.1.2:9000", ShardIDs: []models.ShardID{2}}
+	metricCtx.pushDownLimit([]*models.PhysicalPlan{{Targets: []*models.Target{leaf1, leaf2}}})
+	assert.Zero(t, leaf1.Limit)
+	assert.Zero(t, leaf2.Limit)
+	// compute node
+	compute := &models.Target{Indicator: "1.1.1.1:9000"}
+	metricCtx.pushDownLimit([]*models.PhysicalPlan{{Targets: []*models.Target{compute}}})
+	assert.Zero(t, compute.Limit)
+}
+
+func TestRootMetricContext_buildOrderBy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+		Statement: &stmt.Query{Limit: 2, Offset: 3},
+	})
+	orderBy, err := metricCtx.buildOrderBy()
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		orderBy.Push(aggregation.NewMockRow(ctrl))
+	}
+	// keeps offset+limit rows
+	assert.Len(t, orderBy.ResultSet(), 5)
+}
+
+func TestRootMetricContext_quantileMergedFromLeaves(t *testing.T) {
+	timeRange := timeutil.TimeRange{Start: 1_600_000_000_000, End: 1_600_000_000_000}
+	interval := int64(10_000)
+	// latencies of each leaf node, fast requests on one leaf, slow requests on another
+	leafLatencies := [][]float64{
+		{0.05, 0.08, 0.2, 0.3, 0.3, 0.4, 0.45, 0.6, 0.7, 0.9},
+		{0.8, 1.5, 2, 3, 4, 4.5, 6, 8, 9, 12, 30},
+	}
+	var allLatencies []float64
+	for _, latencies := range leafLatencies {
+		allLatencies = append(allLatencies, latencies...)
+	}
+	// query merges bucket fields of leaves, then computes quantile at root
+	query := func(fieldType field.Type, leaves []map[string]float64) map[string]float64 {
+		metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+			Ctx:       context.TODO(),
+			Request:   &models.Request{},
+			Statement: &stmt.Query{AllFields: true, Limit: 10},
+		})
+		var specs []*protoCommonV1.AggregatorSpec
+		metricCtx.aggregatorSpecs = make(map[string]*protoCommonV1.AggregatorSpec)
+		for _, buckets := range leaves {
+			for fieldName := range buckets {
+				if _, ok := metricCtx.aggregatorSpecs[fieldName]; ok {
+					continue
+				}
+				spec := &protoCommonV1.AggregatorSpec{
+					FieldName:    fieldName,
+					FieldType:    uint32(fieldType),
+					FuncTypeList: []uint32{uint32(function.Sum)},
+				}
+				metricCtx.aggregatorSpecs[fieldName] = spec
+				specs = append(specs, spec)
+			}
+		}
+		metricCtx.timeRange = timeRange
+		metricCtx.interval = interval
+		metricCtx.groupAgg = aggregation.NewGroupingAggregator(timeutil.Interval(interval), 1, timeRange,
+			newAggregatorSpecs(specs))
+		for _, buckets := range leaves {
+			fields := make(map[field.Name][]byte)
+			for fieldName, count := range buckets {
+				fields[field.Name(fieldName)] = encodeField(t, timeRange.Start, field.Sum, []float64{count})
+			}
+			metricCtx.groupAgg.Aggregate(series.NewGroupedIterator("", fields))
+		}
+		rs, err := metricCtx.makeResultSet()
+		assert.NoError(t, err)
+		assert.Len(t, rs.Series, 1)
+		result := make(map[string]float64)
+		for fieldName, points := range rs.Series[0].Fields {
+			result[fieldName] = points[timeRange.Start]
+		}
+		return result
+	}
+	quantileFields := []string{"p99", "p95", "p90", "mean"}
+
+	t.Run("histogram", func(t *testing.T) {
+		upperBounds := []float64{0.1, 0.5, 1, 5, math.Inf(1)}
+		histogram := func(latencies []float64) map[string]float64 {
+			buckets := make(map[string]float64)
+			for _, upperBound := range upperBounds {
+				buckets[metric.BucketNameOfHistogramExplicitBound(upperBound)] = 0
+			}
+			for _, latency := range latencies {
+				for _, upperBound := range upperBounds {
+					if latency <= upperBound {
+						buckets[metric.BucketNameOfHistogramExplicitBound(upperBound)]++
+						break
+					}
+				}
+			}
+			return buckets
+		}
+		baseline := query(field.HistogramField, []map[string]float64{histogram(allLatencies)})
+		distributed := query(field.HistogramField, []map[string]float64{
+			histogram(leafLatencies[0]), histogram(leafLatencies[1]),
+		})
+		leaf0 := query(field.HistogramField, []map[string]float64{histogram(leafLatencies[0])})
+		leaf1 := query(field.HistogramField, []map[string]float64{histogram(leafLatencies[1])})
+		for _, fieldName := range quantileFields {
+			assert.Contains(t, baseline, fieldName)
+			assert.Equal(t, baseline[fieldName], distributed[fieldName], fieldName)
+		}
+		// merging reduced quantile of each leaf is wrong
+		assert.NotEqual(t, baseline["mean"], (leaf0["mean"]+leaf1["mean"])/2)
+	})
+
+	t.Run("exponential histogram", func(t *testing.T) {
+		sketchFields := func(latencies []float64) map[string]float64 {
+			h := sketch.NewExponentialHistogram()
+			for _, latency := range latencies {
+				assert.NoError(t, h.Add(latency))
+			}
+			buckets := make(map[string]float64)
+			indexes, counts := h.Buckets()
+			for idx, index := range indexes {
+				buckets[metric.BucketNameOfExponentialIndex(index)] = counts[idx]
+			}
+			return buckets
+		}
+		baseline := query(field.ExponentialHistogramField, []map[string]float64{sketchFields(allLatencies)})
+		distributed := query(field.ExponentialHistogramField, []map[string]float64{
+			sketchFields(leafLatencies[0]), sketchFields(leafLatencies[1]),
+		})
+		sort.Float64s(allLatencies)
+		for idx, q := range []float64{0.99, 0.95, 0.90, 0.50} {
+			fieldName := quantileFields[idx]
+			assert.Contains(t, baseline, fieldName)
+			assert.Equal(t, baseline[fieldName], distributed[fieldName], fieldName)
+			// lower quantile of raw latencies within relative accuracy
+			expect := allLatencies[int(q*float64(len(allLatencies)-1))]
+			assert.InEpsilon(t, expect, distributed[fieldName], sketch.ExponentialRelativeAccuracy, fieldName)
+		}
+	})
+}
+
+func TestRootMetricContext_calendarBuckets(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	date := func(month time.Month) int64 {
+		return time.Date(2023, month, 1, 0, 0, 0, 0, location).UnixMilli()
+	}
+	interval := commontimeutil.OneHour
+	// hourly data from 2023-01-01 to 2023-04-01, daylight saving time starts at 2023-03-12
+	timeRange := timeutil.TimeRange{Start: date(time.January), End: date(time.April) - interval}
+	hours := int((timeRange.End-timeRange.Start)/interval) + 1
+	values := make([]float64, hours)
+	for i := range values {
+		values[i] = 1
+	}
+	metricCtx := NewRootMetricContext(&RootMetricContextDeps{
+		Ctx:     context.TODO(),
+		Request: &models.Request{},
+		Statement: &stmt.Query{
+			AllFields: true,
+			Limit:     10,
+			TimeZone:  "America/New_York",
+			Interval:  timeutil.Interval(interval),
+			Calendar:  &timeutil.CalendarInterval{Unit: timeutil.CalendarMonth, Count: 1},
+		},
+	})
+	spec := &protoCommonV1.AggregatorSpec{
+		FieldName:    "f",
+		FieldType:    uint32(field.SumField),
+		FuncTypeList: []uint32{uint32(function.Sum)},
+	}
+	metricCtx.aggregatorSpecs = map[string]*protoCommonV1.AggregatorSpec{"f": spec}
+	metricCtx.timeRange = timeRange
+	metricCtx.interval = interval
+	metricCtx.groupAgg = aggregation.NewGroupingAggregator(timeutil.Interval(interval), 1, timeRange,
+		newAggregatorSpecs([]*protoCommonV1.AggregatorSpec{spec}))
+	metricCtx.groupAgg.Aggregate(series.NewGroupedIterator("", map[field.Name][]byte{
+		"f": encodeField(t, timeRange.Start, field.Sum, values),
+	}))
+	rs, err := metricCtx.makeResultSet()
+	assert.NoError(t, err)
+	assert.Len(t, rs.Series, 1)
+	// 31 days, 28 days, 31 days - 1 hour(daylight saving time)
+	assert.Equal(t, map[int64]float64{
+		date(time.January):  31 * 24,
+		date(time.February): 28 * 24,
+		date(time.March):    31*24 - 1,
+	}, map[int64]float64(rs.Series[0].Fields["f"]))
+	assert.Equal(t, commontimeutil.OneMonth, rs.Interval)
+	assert.Equal(t, timeRange, timeutil.TimeRange{Start: rs.StartTime, End: rs.EndTime})
+}
@@ -24,6 +24,8 @@ import (
 
 	"go.uber.org/atomic"
 
+	"github.com/lindb/common/pkg/encoding"
+
 	"github.com/lindb/lindb/models"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/query/tracker"
@@ -52,6 +54,10 @@ type TaskContext interface {
 	WaitResponse() (any, error)
 	// SetTracker sets stage tracker.
 	SetTracker(stageTracker *tracker.StageTracker)
+	// FanOut returns the number of target nodes which task request sent to.
+	FanOut() int
+	// NetPayload returns the payload size of responses received from target nodes.
+	NetPayload() int64
 }
 
 // baseTaskContext implements TaskContext interface, implements some common logic.
@@ -69,12 +75,16 @@ type baseTaskContext struct {
 	doneCh        chan struct{}
 	expectResults int
 	completed     atomic.Bool
+	netPayload    atomic.Int64
 	err           error
 	mutex         sync.Mutex
 	// tolerantNotFounds keeps the number of how many not found errors can be returned
 	// if all nodes return not-found errors, it will be treated as a error
 	// other error will be returned immediately
 	tolerantNotFounds int32
+	// targetShards keeps the shards which each target node is responsible for(target node => shards)
+	targetShards map[string][]models.ShardID
+	coverage     models.ShardCoverage
 }
 
 // newBaseTaskContext creates the base task context.
@@ -85,6 +95,7 @@ func newBaseTaskContext(ctx context.Context, transportMgr rpc.TransportManager)
 		doneCh:       make(chan struct{}),
 		requests:     make(map[string]*protoCommonV1.TaskRequest),
 		state:        make(map[string]models.TaskState),
+		targetShards: make(map[string][]models.ShardID),
 	}
 }
 
@@ -103,7 +114,7 @@ func (ctx *baseTaskContext) SendRequest(targetNodeID string, req *protoCommonV1.
 	}
 	ctx.state[targetNodeID] = models.Send
 	ctx.mutex.Unlock()
-	return ctx.transportMgr.SendRequest(targetNodeID, req)
+	return ctx.transportMgr.SendRequest(ctx.ctx, targetNodeID, req)
 }
 
 // GetRequests returns the request list which send to target node.
@@ -126,6 +137,8 @@ func (ctx *baseTaskContext) addRequests(req *protoCommonV1.TaskRequest, physical
 		// add all targets then send task request, for fail fast
 		ctx.requests[target.Indicator] = req
 		ctx.state[target.Indicator] = models.Init
+		ctx.targetShards[target.Indicator] = append(ctx.targetShards[target.Indicator], target.ShardIDs...)
+		ctx.coverage.Queried = append(ctx.coverage.Queried, target.ShardIDs...)
 	}
 }
 
@@ -143,6 +156,19 @@ func (ctx *baseTaskContext) SetTracker(stageTracker *tracker.StageTracker) {
 	ctx.stageTracker = stageTracker
 }
 
+// FanOut returns the number of target nodes which task request sent to.
+func (ctx *baseTaskContext) FanOut() int {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	return len(ctx.requests)
+}
+
+// NetPayload returns the payload size of responses received from target nodes.
+func (ctx *baseTaskContext) NetPayload() int64 {
+	return ctx.netPayload.Load()
+}
+
 // tryClose tries to complete the task.
 func (ctx *baseTaskContext) tryClose() {
 	ctx.mutex.Lock()
@@ -156,11 +182,49 @@ func (ctx *baseTaskContext) tryClose() {
 	}
 }
 
-// handleTaskState handles task state based on task response.
+// handleTaskState handles task state based on task response, and tracks the payload of response.
 func (ctx *baseTaskContext) handleTaskState(resp *protoCommonV1.TaskResponse, fromNode string) {
+	ctx.netPayload.Add(int64(len(resp.Payload) + len(resp.Stats)))
 	if resp.Completed {
 		ctx.state[fromNode] = models.Complete
 	} else {
 		ctx.state[fromNode] = models.Receive
 	}
 }
+
+// handleShardCoverage tracks the outcome of shards which the target node is responsible for,
+// if target node is intermediate node, merges the shard coverage returned by it.
+func (ctx *baseTaskContext) handleShardCoverage(resp *protoCommonV1.TaskResponse, fromNode string) {
+	shardIDs := ctx.targetShards[fromNode]
+	switch {
+	case resp.ErrMsg == "":
+		ctx.coverage.Responded = append(ctx.coverage.Responded, shardIDs...)
+	case models.ErrorCode(resp.ErrCode) == models.ErrCodeNotFound:
+		ctx.coverage.NotFound = append(ctx.coverage.NotFound, shardIDs...)
+	default:
+		ctx.coverage.Failed = append(ctx.coverage.Failed, shardIDs...)
+	}
+	if len(resp.Coverage) > 0 {
+		coverage := &models.ShardCoverage{}
+		if err := encoding.JSONUnmarshal(resp.Coverage, coverage); err == nil {
+			ctx.coverage.Merge(coverage)
+		}
+	}
+}
+
+// shardCoverage returns the shard coverage of task, the shards of target node which not responded are timed out.
+func (ctx *baseTaskContext) shardCoverage() *models.ShardCoverage {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	coverage := &models.ShardCoverage{}
+	// copy tracking state, avoid modifying it when normalizing
+	coverage.Merge(&ctx.coverage)
+	for target, state := range ctx.state {
+		if state == models.Init || state == models.Send {
+			coverage.TimedOut = append(coverage.TimedOut, ctx.targetShards[target]...)
+		}
+	}
+	coverage.Normalize()
+	return coverage
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/common/pkg/encoding"
+
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
@@ -38,7 +40,7 @@ func TestTaskContext(t *testing.T) {
 	defer ctrl.Finish()
 
 	transportMgr := rpc.NewMockTransportManager(ctrl)
-	transportMgr.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil)
+	transportMgr.EXPECT().SendRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	ctx := newBaseTaskContext(context.TODO(), transportMgr)
 	ctx.SetTracker(tracker.NewStageTracker(flow.NewTaskContextWithTimeout(context.TODO(), time.Minute)))
 	assert.NotNil(t, ctx.Context())
@@ -54,9 +56,47 @@ func TestTaskContext(t *testing.T) {
 		},
 	})
 	assert.Len(t, ctx.GetRequests(), 2)
+	assert.Equal(t, 2, ctx.FanOut())
 
 	ctx.Complete(fmt.Errorf("err"))
 	ctx.Complete(fmt.Errorf("err"))
-	ctx.handleTaskState(&protoCommonV1.TaskResponse{Completed: false}, "leaf")
-	ctx.handleTaskState(&protoCommonV1.TaskResponse{Completed: true}, "leaf")
+	ctx.handleTaskState(&protoCommonV1.TaskResponse{Completed: false, Payload: []byte("abc")}, "leaf")
+	ctx.handleTaskState(&protoCommonV1.TaskResponse{Completed: true, Stats: []byte("de")}, "leaf")
+	assert.Equal(t, int64(5), ctx.NetPayload())
+}
+
+func TestTaskContext_shardCoverage(t *testing.T) {
+	ctx := newBaseTaskContext(context.TODO(), nil)
+	ctx.addRequests(&protoCommonV1.TaskRequest{}, &models.PhysicalPlan{
+		Targets: []*models.Target{
+			{Indicator: "leaf-1", ShardIDs: []models.ShardID{1, 2}},
+			{Indicator: "leaf-2", ShardIDs: []models.ShardID{3}},
+			{Indicator: "leaf-3", ShardIDs: []models.ShardID{4}},
+			{Indicator: "leaf-4", ShardIDs: []models.ShardID{5}},
+			{Indicator: "intermediate"},
+		},
+	})
+	resps := map[string]*protoCommonV1.TaskResponse{
+		"leaf-1": {Completed: true},
+		"leaf-2": {Completed: true, ErrMsg: "metric not found", ErrCode: string(models.ErrCodeNotFound)},
+		"leaf-3": {Completed: true, ErrMsg: "err"},
+		"intermediate": {Completed: true, Coverage: encoding.JSONMarshal(&models.ShardCoverage{
+			Queried:   []models.ShardID{6, 7},
+			Responded: []models.ShardID{6},
+			TimedOut:  []models.ShardID{7},
+		})},
+	}
+	for node, resp := range resps {
+		ctx.handleTaskState(resp, node)
+		ctx.handleShardCoverage(resp, node)
+	}
+	// ignore invalid coverage
+	ctx.handleShardCoverage(&protoCommonV1.TaskResponse{Coverage: []byte("abc")}, "intermediate")
+	assert.Equal(t, &models.ShardCoverage{
+		Queried:   []models.ShardID{1, 2, 3, 4, 5, 6, 7},
+		Responded: []models.ShardID{1, 2, 6},
+		TimedOut:  []models.ShardID{5, 7},
+		NotFound:  []models.ShardID{3},
+		Failed:    []models.ShardID{4},
+	}, ctx.shardCoverage())
 }
@@ -18,21 +18,40 @@
 package context
 
 import (
+	"fmt"
+	"time"
+
+	commontimeutil "github.com/lindb/common/pkg/timeutil"
+
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
 // calcTimeRangeAndInterval calculates the query time range and interval based on input params and database config.
-func calcTimeRangeAndInterval(statement *stmt.Query, cfg models.Database) {
+func calcTimeRangeAndInterval(statement *stmt.Query, cfg models.Database) error {
+	if err := checkRateWindow(statement.SelectItems, cfg.Option.Intervals[0].Interval); err != nil {
+		return err
+	}
+	if statement.SampleInterval > 0 {
+		return calcSampleInterval(statement, cfg)
+	}
 	option := cfg.Option
 	interval := statement.Interval
-	if interval <= 0 {
+	calendar := statement.Calendar != nil && !statement.AutoGroupByTime
+	switch {
+	case calendar:
+		// width of calendar buckets varies, down sampling by hour(aligned to time zone) which
+		// daylight saving time shifts by, then root merges hourly buckets into calendar buckets.
+		interval = timeutil.Interval(commontimeutil.OneHour)
+	case interval <= 0:
 		// if query interval not set, first set it using the smallest interval in storage option.
 		interval = option.Intervals[0].Interval
+		fallthrough
+	default:
+		// re-calc query interval based on query time range
+		interval = timeutil.CalcQueryInterval(statement.TimeRange, interval)
 	}
-	// re-calc query interval based on query time range
-	interval = timeutil.CalcQueryInterval(statement.TimeRange, interval)
 	storageInterval := option.FindMatchSmallestInterval(interval)
 	intervalVal := storageInterval.Int64()
 	statement.TimeRange.Start = timeutil.Truncate(statement.TimeRange.Start, intervalVal)
@@ -42,14 +61,112 @@ func calcTimeRangeAndInterval(statement *stmt.Query, cfg models.Database) {
 		statement.Interval = timeutil.Interval(statement.TimeRange.End-statement.TimeRange.Start) + storageInterval
 	}
 	// if auto calc interval < user input, need to use use input
-	if interval < statement.Interval {
+	if interval < statement.Interval && !calendar {
 		interval = statement.Interval
 	}
 	intervalRatio := timeutil.CalIntervalRatio(interval.Int64(), storageInterval.Int64())
 	// truncate query interval
 	interval = timeutil.Interval(storageInterval.Int64() * int64(intervalRatio))
 
+	if calendar {
+		// align start to the start of first calendar bucket
+		statement.TimeRange.Start = timeutil.Truncate(alignCalendarStart(statement), intervalVal)
+	} else if (statement.TimeZone != "" || statement.IntervalOffset > 0) && !statement.AutoGroupByTime {
+		// align group by interval boundaries to the time zone of query, e.g. 1d interval starts from local midnight,
+		// then shift boundaries by interval offset, e.g. time(1d, 8h)
+		statement.TimeRange.Start = timeutil.Truncate(alignStart(statement, interval.Int64()), intervalVal)
+	}
+
 	statement.StorageInterval = storageInterval
 	statement.Interval = interval
 	statement.IntervalRatio = intervalRatio
+	return nil
+}
+
+// checkRateWindow checks if the window of rate/absent function is less than the smallest storage interval.
+func checkRateWindow(exprs []stmt.Expr, storageInterval timeutil.Interval) error {
+	for _, expr := range exprs {
+		var err error
+		switch e := expr.(type) {
+		case *stmt.SelectItem:
+			err = checkRateWindow([]stmt.Expr{e.Expr}, storageInterval)
+		case *stmt.ParenExpr:
+			err = checkRateWindow([]stmt.Expr{e.Expr}, storageInterval)
+		case *stmt.BinaryExpr:
+			err = checkRateWindow([]stmt.Expr{e.Left, e.Right}, storageInterval)
+		case *stmt.CallExpr:
+			if e.Window > 0 && e.Window < storageInterval {
+				return fmt.Errorf("%s window(%s) cannot be less than storage interval(%s)", e.FuncType, e.Window, storageInterval)
+			}
+			err = checkRateWindow(e.Params, storageInterval)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// calcSampleInterval calculates the query time range and interval for sample by query,
+// sample interval is used as group by interval regardless of query time range,
+// and bucket boundaries are rounded to the sample interval.
+func calcSampleInterval(statement *stmt.Query, cfg models.Database) error {
+	sampleInterval := statement.SampleInterval
+	intervals := cfg.Option.Intervals
+	if sampleInterval < intervals[0].Interval {
+		return fmt.Errorf("sample by interval(%s) cannot be less than storage interval(%s)",
+			sampleInterval, intervals[0].Interval)
+	}
+	// find the largest storage interval which sample interval is a multiple of
+	storageInterval := intervals[0].Interval
+	for _, it := range intervals {
+		if it.Interval > storageInterval && it.Interval <= sampleInterval &&
+			sampleInterval.Int64()%it.Interval.Int64() == 0 {
+			storageInterval = it.Interval
+		}
+	}
+	intervalRatio := timeutil.CalIntervalRatio(sampleInterval.Int64(), storageInterval.Int64())
+	interval := timeutil.Interval(storageInterval.Int64() * int64(intervalRatio))
+
+	statement.TimeRange.Start = timeutil.Truncate(alignStart(statement, interval.Int64()), storageInterval.Int64())
+	statement.TimeRange.End = timeutil.Truncate(statement.TimeRange.End, storageInterval.Int64())
+
+	statement.StorageInterval = storageInterval
+	statement.Interval = interval
+	statement.IntervalRatio = intervalRatio
+	return nil
+}
+
+// alignStart aligns the start of query time range to the boundary of interval buckets,
+// boundaries are aligned to the time zone of query if set, then shifted by interval offset.
+func alignStart(statement *stmt.Query, interval int64) int64 {
+	offset := statement.IntervalOffset.Int64()
+	start := statement.TimeRange.Start - offset
+	aligned := timeutil.Truncate(start, interval)
+	if statement.TimeZone != "" {
+		if location, err := time.LoadLocation(statement.TimeZone); err == nil {
+			aligned = timeutil.TruncateInLocation(start, interval, location)
+		}
+	}
+	return aligned + offset
+}
+
+// alignCalendarStart aligns the start of query time range to the start of calendar bucket,
+// boundaries are aligned to the calendar in time zone of query(UTC by default), then shifted by interval offset.
+func alignCalendarStart(statement *stmt.Query) int64 {
+	buckets := statement.Calendar.Buckets(statement.TimeRange, statement.IntervalOffset.Int64(), queryLocation(statement))
+	if len(buckets) == 0 {
+		return statement.TimeRange.Start
+	}
+	return buckets[0]
+}
+
+// queryLocation returns the location of query time zone, UTC if not set.
+func queryLocation(statement *stmt.Query) *time.Location {
+	if statement.TimeZone != "" {
+		if location, err := time.LoadLocation(statement.TimeZone); err == nil {
+			return location
+		}
+	}
+	return time.UTC
 }
@@ -19,11 +19,13 @@ package context
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	commontimeutil "github.com/lindb/common/pkg/timeutil"
 
+	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
@@ -40,16 +42,191 @@ func Test_calcTimeRangeAndInterval(t *testing.T) {
 		},
 	}
 	statement := &stmt.Query{}
-	calcTimeRangeAndInterval(statement, cfg)
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
 	assert.Equal(t, timeutil.Interval(commontimeutil.OneSecond), statement.Interval)
 
 	statement.Interval = timeutil.Interval(commontimeutil.OneHour)
 	statement.TimeRange = timeutil.TimeRange{Start: commontimeutil.Now(), End: commontimeutil.Now() + 6*commontimeutil.OneHour}
-	calcTimeRangeAndInterval(statement, cfg)
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
 	assert.Equal(t, timeutil.Interval(commontimeutil.OneHour), statement.Interval)
 
 	statement = &stmt.Query{AutoGroupByTime: true}
 	statement.TimeRange = timeutil.TimeRange{Start: commontimeutil.Now(), End: commontimeutil.Now() + 6*commontimeutil.OneHour}
-	calcTimeRangeAndInterval(statement, cfg)
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
 	assert.Equal(t, timeutil.Interval(6*commontimeutil.OneHour)+statement.StorageInterval, statement.Interval)
 }
+
+func Test_calcTimeRangeAndInterval_TimeZone(t *testing.T) {
+	cfg := models.Database{
+		Option: &option.DatabaseOption{
+			Intervals: option.Intervals{
+				{Interval: timeutil.Interval(commontimeutil.OneMinute)},
+			},
+		},
+	}
+	location, _ := time.LoadLocation("America/New_York")
+	start := time.Date(2024, 1, 2, 10, 30, 0, 0, location).UnixMilli()
+	statement := &stmt.Query{
+		Interval:  timeutil.Interval(commontimeutil.OneDay),
+		TimeZone:  "America/New_York",
+		TimeRange: timeutil.TimeRange{Start: start, End: start + 3*commontimeutil.OneDay},
+	}
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
+	// 1d bucket starts from midnight of new york
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, location).UnixMilli(), statement.TimeRange.Start)
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneDay), statement.Interval)
+}
+
+func Test_calcTimeRangeAndInterval_Offset(t *testing.T) {
+	cfg := models.Database{
+		Option: &option.DatabaseOption{
+			Intervals: option.Intervals{
+				{Interval: timeutil.Interval(commontimeutil.OneMinute)},
+			},
+		},
+	}
+	start := time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC).UnixMilli()
+	end := start + 3*commontimeutil.OneDay
+	// zero offset keeps buckets aligned to interval
+	statement := &stmt.Query{
+		Interval:  timeutil.Interval(commontimeutil.OneDay),
+		TimeRange: timeutil.TimeRange{Start: start, End: end},
+	}
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
+	assert.Equal(t, start, statement.TimeRange.Start)
+
+	// 1d bucket starts from 08:00 of utc
+	statement = &stmt.Query{
+		Interval:       timeutil.Interval(commontimeutil.OneDay),
+		IntervalOffset: timeutil.Interval(8 * commontimeutil.OneHour),
+		TimeRange:      timeutil.TimeRange{Start: start, End: end},
+	}
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
+	assert.Equal(t, time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC).UnixMilli(), statement.TimeRange.Start)
+
+	// start before offset of day, bucket starts from 08:00 of previous day
+	start = time.Date(2024, 1, 2, 6, 30, 0, 0, time.UTC).UnixMilli()
+	statement = &stmt.Query{
+		Interval:       timeutil.Interval(commontimeutil.OneDay),
+		IntervalOffset: timeutil.Interval(8 * commontimeutil.OneHour),
+		TimeRange:      timeutil.TimeRange{Start: start, End: end},
+	}
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
+	assert.Equal(t, time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC).UnixMilli(), statement.TimeRange.Start)
+
+	// offset with time zone
+	location, _ := time.LoadLocation("Asia/Shanghai")
+	start = time.Date(2024, 1, 2, 10, 30, 0, 0, location).UnixMilli()
+	statement = &stmt.Query{
+		Interval:       timeutil.Interval(commontimeutil.OneDay),
+		IntervalOffset: timeutil.Interval(2 * commontimeutil.OneHour),
+		TimeZone:       "Asia/Shanghai",
+		TimeRange:      timeutil.TimeRange{Start: start, End: end},
+	}
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
+	assert.Equal(t, time.Date(2024, 1, 2, 2, 0, 0, 0, location).UnixMilli(), statement.TimeRange.Start)
+}
+
+func Test_calcTimeRangeAndInterval_Calendar(t *testing.T) {
+	cfg := models.Database{
+		Option: &option.DatabaseOption{
+			Intervals: option.Intervals{
+				{Interval: timeutil.Interval(10 * commontimeutil.OneSecond)},
+				{Interval: timeutil.Interval(5 * commontimeutil.OneMinute)},
+				{Interval: timeutil.Interval(commontimeutil.OneDay)},
+			},
+		},
+	}
+	location, _ := time.LoadLocation("America/New_York")
+	start := time.Date(2023, 1, 15, 10, 30, 0, 0, location).UnixMilli()
+	statement := &stmt.Query{
+		Interval:  timeutil.Interval(commontimeutil.OneMonth),
+		Calendar:  &timeutil.CalendarInterval{Unit: timeutil.CalendarMonth, Count: 1},
+		TimeZone:  "America/New_York",
+		TimeRange: timeutil.TimeRange{Start: start, End: start + 90*commontimeutil.OneDay},
+	}
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
+	// month bucket starts from the first day of month in new york
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, location).UnixMilli(), statement.TimeRange.Start)
+	// down sampling by hour, root merges hourly buckets into months
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneHour), statement.Interval)
+	assert.Equal(t, timeutil.Interval(5*commontimeutil.OneMinute), statement.StorageInterval)
+	assert.Equal(t, 12, statement.IntervalRatio)
+}
+
+func Test_calcTimeRangeAndInterval_RateWindow(t *testing.T) {
+	cfg := models.Database{
+		Option: &option.DatabaseOption{
+			Intervals: option.Intervals{{Interval: timeutil.Interval(10 * commontimeutil.OneSecond)}},
+		},
+	}
+	rate := func(window int64) []stmt.Expr {
+		return []stmt.Expr{&stmt.SelectItem{Expr: &stmt.BinaryExpr{
+			Left: &stmt.FieldExpr{Name: "f"},
+			Right: &stmt.ParenExpr{Expr: &stmt.CallExpr{
+				FuncType: function.Rate,
+				Params:   []stmt.Expr{&stmt.FieldExpr{Name: "f"}},
+				Window:   timeutil.Interval(window),
+			}},
+		}}}
+	}
+	timeRange := timeutil.TimeRange{Start: 0, End: commontimeutil.OneHour}
+	assert.NoError(t, calcTimeRangeAndInterval(&stmt.Query{SelectItems: rate(10 * commontimeutil.OneSecond), TimeRange: timeRange}, cfg))
+	assert.Error(t, calcTimeRangeAndInterval(&stmt.Query{SelectItems: rate(5 * commontimeutil.OneSecond), TimeRange: timeRange}, cfg))
+}
+
+func Test_calcTimeRangeAndInterval_SampleBy(t *testing.T) {
+	cfg := models.Database{
+		Option: &option.DatabaseOption{
+			Intervals: option.Intervals{
+				{Interval: timeutil.Interval(10 * commontimeutil.OneSecond)},
+				{Interval: timeutil.Interval(5 * commontimeutil.OneMinute)},
+				{Interval: timeutil.Interval(commontimeutil.OneHour)},
+			},
+		},
+	}
+	start := time.Date(2024, 1, 2, 10, 35, 20, 0, time.UTC).UnixMilli()
+	end := time.Date(2024, 1, 9, 10, 35, 20, 0, time.UTC).UnixMilli()
+	// sample by overrides group by interval, bucket boundaries rounded to hour
+	statement := &stmt.Query{
+		Interval:       timeutil.Interval(commontimeutil.OneHour),
+		SampleInterval: timeutil.Interval(commontimeutil.OneHour),
+		TimeRange:      timeutil.TimeRange{Start: start, End: end},
+	}
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
+	assert.Equal(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC).UnixMilli(), statement.TimeRange.Start)
+	assert.Equal(t, time.Date(2024, 1, 9, 10, 0, 0, 0, time.UTC).UnixMilli(), statement.TimeRange.End)
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneHour), statement.Interval)
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneHour), statement.StorageInterval)
+	assert.Equal(t, 1, statement.IntervalRatio)
+
+	// storage interval which sample interval is a multiple of
+	statement = &stmt.Query{
+		SampleInterval: timeutil.Interval(90 * commontimeutil.OneMinute),
+		TimeRange:      timeutil.TimeRange{Start: start, End: end},
+	}
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
+	assert.Equal(t, timeutil.Interval(90*commontimeutil.OneMinute), statement.Interval)
+	assert.Equal(t, timeutil.Interval(5*commontimeutil.OneMinute), statement.StorageInterval)
+	assert.Equal(t, 18, statement.IntervalRatio)
+	assert.Zero(t, statement.TimeRange.Start%(90*commontimeutil.OneMinute))
+
+	// aligned to time zone
+	location, _ := time.LoadLocation("Asia/Shanghai")
+	statement = &stmt.Query{
+		SampleInterval: timeutil.Interval(commontimeutil.OneDay),
+		TimeZone:       "Asia/Shanghai",
+		TimeRange:      timeutil.TimeRange{Start: start, End: end},
+	}
+	assert.NoError(t, calcTimeRangeAndInterval(statement, cfg))
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, location).UnixMilli(), statement.TimeRange.Start)
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneDay), statement.Interval)
+	assert.Equal(t, 24, statement.IntervalRatio)
+
+	// less than storage interval
+	statement = &stmt.Query{
+		SampleInterval: timeutil.Interval(commontimeutil.OneSecond),
+		TimeRange:      timeutil.TimeRange{Start: start, End: end},
+	}
+	assert.Error(t, calcTimeRangeAndInterval(statement, cfg))
+}
@@ -32,5 +32,6 @@ var (
 	ErrNoSendStream                = errors.New("send stream not found")
 	ErrTaskSend                    = errors.New("send task request error")
 	ErrResponseSend                = errors.New("send response error")
+	ErrCircuitOpen                 = errors.New("circuit of target node is open")
 	ErrNoDatabase                  = errors.New("not found database")
 )
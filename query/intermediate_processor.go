@@ -28,6 +28,7 @@ import (
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/sketch"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/query/context"
 	"github.com/lindb/lindb/rpc"
@@ -76,8 +77,13 @@ func NewIntermediateTaskProcessor(
 // if current node is only receive task response need ignore search execute.
 func (p *intermediateTaskProcessor) Process(ctx *flow.TaskContext,
 	stream protoCommonV1.TaskService_HandleServer, req *protoCommonV1.TaskRequest) error {
-	physicalPlan := &models.PhysicalPlan{}
-	if err := encoding.JSONUnmarshal(req.PhysicalPlan, physicalPlan); err != nil {
+	if req.RequestType == protoCommonV1.RequestType_Cancel {
+		// cancel the task, and notify leaf nodes of task to stop executing
+		p.taskMgr.CancelTask(req.RequestID)
+		return nil
+	}
+	physicalPlan, err := rpc.DecodePhysicalPlan(req)
+	if err != nil {
 		return fmt.Errorf("%w: %s", ErrUnmarshalPlan, err)
 	}
 	foundTask := false
@@ -166,7 +172,16 @@ func (p *intermediateTaskProcessor) processMetadataSearch(
 	if err != nil {
 		return err
 	}
-	payload := encoding.JSONMarshal(&models.SuggestResult{Values: rs.([]string)})
+	result := &models.SuggestResult{}
+	switch val := rs.(type) {
+	case *sketch.Distinct:
+		result.Distinct = val
+	case map[string]*sketch.Distinct:
+		result.Cardinality = val
+	case []string:
+		result.Values = val
+	}
+	payload := encoding.JSONMarshal(result)
 	// send result to upstream
 	p.sendResponse(stream, req, &protoCommonV1.TaskResponse{
 		RequestID: req.RequestID,
@@ -183,6 +198,7 @@ func (p *intermediateTaskProcessor) sendResponse(
 	req *protoCommonV1.TaskRequest,
 	resp *protoCommonV1.TaskResponse,
 ) {
+	rpc.CompressTaskResponse(resp, req.AcceptCompress)
 	if err := stream.Send(resp); err != nil {
 		p.logger.Error("failed to send error message to target stream",
 			logger.String("requestID", req.RequestID),
@@ -30,6 +30,7 @@ import (
 
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/sketch"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	queryctx "github.com/lindb/lindb/query/context"
 	"github.com/lindb/lindb/sql/stmt"
@@ -149,4 +150,55 @@ func TestProcessMetricMetadataSearch(t *testing.T) {
 		PhysicalPlan: physicalPlan,
 	})
 	assert.NoError(t, err)
+
+	// distinct sketch
+	metricMetadataSearchFn = func(ctx context.Context, param *models.ExecuteParam,
+		statement *stmt.MetricMetadata, mgr *SearchMgr) (any, error) {
+		distinct, _ := sketch.NewDistinct(0)
+		distinct.Add("a")
+		return distinct, nil
+	}
+	stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *protoCommonV1.TaskResponse) error {
+		result := &models.SuggestResult{}
+		assert.NoError(t, encoding.JSONUnmarshal(resp.Payload, result))
+		assert.Equal(t, uint64(1), result.Distinct.Count())
+		return nil
+	})
+	err = ip.Process(taskCtx, stream, &protoCommonV1.TaskRequest{
+		RequestType:  protoCommonV1.RequestType_Metadata,
+		Payload:      statement,
+		PhysicalPlan: physicalPlan,
+	})
+	assert.NoError(t, err)
+
+	// tag cardinality
+	metricMetadataSearchFn = func(ctx context.Context, param *models.ExecuteParam,
+		statement *stmt.MetricMetadata, mgr *SearchMgr) (any, error) {
+		distinct, _ := sketch.NewDistinct(0)
+		distinct.Add("a")
+		return map[string]*sketch.Distinct{"host": distinct}, nil
+	}
+	stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *protoCommonV1.TaskResponse) error {
+		result := &models.SuggestResult{}
+		assert.NoError(t, encoding.JSONUnmarshal(resp.Payload, result))
+		assert.Equal(t, uint64(1), result.Cardinality["host"].Count())
+		return nil
+	})
+	err = ip.Process(taskCtx, stream, &protoCommonV1.TaskRequest{
+		RequestType:  protoCommonV1.RequestType_Metadata,
+		Payload:      statement,
+		PhysicalPlan: physicalPlan,
+	})
+	assert.NoError(t, err)
+}
+
+func TestProcessCancelTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskMgr := NewMockTaskManager(ctrl)
+	ip := NewIntermediateTaskProcessor(models.StatelessNode{HostIP: "1.1.1.1", GRPCPort: 9000}, time.Second, nil, taskMgr, nil)
+	taskMgr.EXPECT().CancelTask("1")
+	err := ip.Process(nil, nil, &protoCommonV1.TaskRequest{RequestID: "1", RequestType: protoCommonV1.RequestType_Cancel})
+	assert.NoError(t, err)
 }
@@ -20,6 +20,8 @@ package query
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/lindb/common/pkg/encoding"
 	"github.com/lindb/common/pkg/logger"
@@ -52,6 +54,10 @@ type leafTaskProcessor struct {
 	engine            tsdb.Engine
 	taskServerFactory rpc.TaskServerFactory
 
+	// running data search tasks(request id => task context), for cancelling task
+	tasks map[string]*flow.TaskContext
+	mutex sync.Mutex
+
 	statistics *metrics.StorageQueryStatistics
 	logger     logger.Logger
 }
@@ -67,6 +73,7 @@ func NewLeafTaskProcessor(
 		currentNodeID:     currentNode.Indicator(),
 		engine:            engine,
 		taskServerFactory: taskServerFactory,
+		tasks:             make(map[string]*flow.TaskContext),
 		statistics:        metrics.NewStorageQueryStatistics(),
 		logger:            logger.GetLogger("Query", "leafTaskProcessor"),
 	}
@@ -78,8 +85,12 @@ func (p *leafTaskProcessor) Process(
 	stream protoCommonV1.TaskService_HandleServer,
 	req *protoCommonV1.TaskRequest,
 ) error {
-	physicalPlan := models.PhysicalPlan{}
-	if err := encoding.JSONUnmarshal(req.PhysicalPlan, &physicalPlan); err != nil {
+	if req.RequestType == protoCommonV1.RequestType_Cancel {
+		p.cancelTask(req.RequestID)
+		return nil
+	}
+	physicalPlan, err := rpc.DecodePhysicalPlan(req)
+	if err != nil {
 		return fmt.Errorf("%w: %s", ErrUnmarshalPlan, err)
 	}
 
@@ -136,13 +147,14 @@ func (p *leafTaskProcessor) processMetadataSuggest(
 	}
 	leafExecuteCtx := context.NewLeafMetadataContext(stmtQuery, db, shardIDs)
 	pipeline := newExecutePipelineFn(trackerpkg.NewStageTracker(ctx), func(err error) {
-		var errMsg string
+		var errMsg, errCode string
 		var payload []byte
 		if err != nil && !errors.Is(err, constants.ErrNotFound) {
 			errMsg = err.Error()
+			errCode = string(models.ErrorCodeOf(err))
 			p.statistics.MetaQueryFailures.Incr()
 		} else {
-			payload = encoding.JSONMarshal(&models.SuggestResult{Values: leafExecuteCtx.ResultSet})
+			payload = encoding.JSONMarshal(leafExecuteCtx.Result())
 		}
 		// send result to upstream
 		if err := stream.Send(&protoCommonV1.TaskResponse{
@@ -150,6 +162,7 @@ func (p *leafTaskProcessor) processMetadataSuggest(
 			RequestID:   req.RequestID,
 			Completed:   true,
 			ErrMsg:      errMsg,
+			ErrCode:     errCode,
 			SendTime:    timeutil.NowNano(),
 			Payload:     payload,
 		}); err != nil {
@@ -180,14 +193,48 @@ func (p *leafTaskProcessor) processDataSearch(
 	tracker := trackerpkg.NewStageTracker(ctx)
 	leafExecuteCtx := context.NewLeafExecuteContext(ctx, tracker, &stmtQuery, req, p.taskServerFactory, leafNode, receivers, db)
 
+	start := time.Now()
+	p.addTask(req.RequestID, ctx)
 	pipeline := newExecutePipelineFn(tracker, func(err error) {
 		// remove pipeline from cache after execute completed
 		defer GetPipelineManager().RemovePipeline(req.RequestID)
+		defer p.removeTask(req.RequestID)
 
 		leafExecuteCtx.SendResponse(err)
+		// link the query duration to request(trace) id, so slow query can be found from latency metric
+		p.statistics.MetricQueryDuration.UpdateSinceWithExemplar(start, req.RequestID)
 	})
 	// cache pipeline
 	GetPipelineManager().AddPipeline(req.RequestID, pipeline)
 	pipeline.Execute(stage.NewMetadataLookupStage(leafExecuteCtx))
 	return nil
 }
+
+// addTask adds the running data search task.
+func (p *leafTaskProcessor) addTask(requestID string, ctx *flow.TaskContext) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.tasks[requestID] = ctx
+}
+
+// removeTask removes the data search task after completed.
+func (p *leafTaskProcessor) removeTask(requestID string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.tasks, requestID)
+}
+
+// cancelTask cancels the running data search task, aborts in-flight scans.
+func (p *leafTaskProcessor) cancelTask(requestID string) {
+	p.mutex.Lock()
+	ctx, ok := p.tasks[requestID]
+	delete(p.tasks, requestID)
+	p.mutex.Unlock()
+
+	if ok {
+		ctx.Cancel()
+		p.statistics.CancelledQuery.Incr()
+	}
+}
@@ -279,3 +279,22 @@ func TestLeafTask_Suggest_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestLeafProcessor_CancelTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	currentNode := models.StatelessNode{HostIP: "1.1.1.3", GRPCPort: 8000}
+	processor := NewLeafTaskProcessor(&currentNode, nil, nil).(*leafTaskProcessor)
+	taskCtx := flow.NewTaskContextWithTimeout(context.Background(), time.Minute)
+	processor.addTask("1", taskCtx)
+	// cancel unknown task
+	assert.NoError(t, processor.Process(nil, nil,
+		&protoCommonV1.TaskRequest{RequestID: "2", RequestType: protoCommonV1.RequestType_Cancel}))
+	assert.NoError(t, taskCtx.Ctx.Err())
+	// cancel running task
+	assert.NoError(t, processor.Process(nil, nil,
+		&protoCommonV1.TaskRequest{RequestID: "1", RequestType: protoCommonV1.RequestType_Cancel}))
+	assert.ErrorIs(t, taskCtx.Ctx.Err(), context.Canceled)
+	assert.Empty(t, processor.tasks)
+}
@@ -73,6 +73,9 @@ func (op *metadataLookup) Execute() error {
 	if err := op.selectList(); err != nil {
 		return err
 	}
+	if err := op.fieldFilter(); err != nil {
+		return err
+	}
 
 	op.buildField()
 	return nil
@@ -161,6 +164,30 @@ func (op *metadataLookup) selectList() error {
 	return nil
 }
 
+// fieldFilter plans the field filters of where clause, pushes them into down sampling spec of selected field,
+// so the points not matched are dropped when leaf scanning.
+func (op *metadataLookup) fieldFilter() error {
+	queryStmt := op.executeCtx.Query
+	for _, filter := range queryStmt.FieldFilters {
+		fieldExpr, ok := filter.Left.(*stmt.FieldExpr)
+		if !ok {
+			return fmt.Errorf("field filter: %s not compare field", filter.Rewrite())
+		}
+		fieldMeta, err := op.metadata.GetField(queryStmt.Namespace, queryStmt.MetricName, field.Name(fieldExpr.Name))
+		if err != nil {
+			return err
+		}
+		aggregator, ok := op.fields[fieldMeta.ID]
+		if !ok {
+			return fmt.Errorf("field filter: %s requires field[%s] in select list", filter.Rewrite(), fieldExpr.Name)
+		}
+		if err := aggregator.DownSampling.AddFilter(filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // field plans the field expr from select list
 func (op *metadataLookup) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 	if op.err != nil {
@@ -174,6 +201,38 @@ func (op *metadataLookup) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 			op.planHistogramFields(e)
 			return
 		}
+		if function.IsConditional(e.FuncType) {
+			op.planConditionalField(e)
+			return
+		}
+		if e.FuncType == function.HistogramQuantile {
+			// histogram_quantile(p, field), field of buckets using default down sampling func
+			if len(e.Params) != 2 {
+				op.err = fmt.Errorf("function[%s] params length invalid", e.FuncType)
+				return
+			}
+			op.field(nil, e.Params[1])
+			return
+		}
+		if function.IsArgSelector(e.FuncType) {
+			// argmax/argmin(tag, field), first param is tag key(grouped by implicitly), plan field with max/min
+			if len(e.Params) != 2 {
+				op.err = fmt.Errorf("function[%s] params length invalid", e.FuncType)
+				return
+			}
+			op.field(&stmt.CallExpr{FuncType: function.ArgSelectorAggFunc(e.FuncType)}, e.Params[1])
+			return
+		}
+		if e.FuncType == function.Absent || e.FuncType == function.Percent {
+			// absent(field), checks if field has data; percent(field), share of total across grouped series,
+			// both plan field using default down sampling func
+			if len(e.Params) != 1 {
+				op.err = fmt.Errorf("function[%s] params length invalid", e.FuncType)
+				return
+			}
+			op.field(nil, e.Params[0])
+			return
+		}
 		for _, param := range e.Params {
 			op.field(e, param)
 		}
@@ -195,7 +254,11 @@ func (op *metadataLookup) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 }
 
 func (op *metadataLookup) planField(parentFunc *stmt.CallExpr, fieldMeta field.Meta) {
-	fieldType := fieldMeta.Type
+	fieldType, err := op.resolveFieldType(parentFunc, fieldMeta)
+	if err != nil {
+		op.err = err
+		return
+	}
 	fieldID := fieldMeta.ID
 	aggregator, exist := op.fields[fieldID]
 	if !exist {
@@ -228,9 +291,73 @@ func (op *metadataLookup) planField(parentFunc *stmt.CallExpr, fieldMeta field.M
 	aggregator.DownSampling.AddFunctionType(funcType)
 }
 
-func (op *metadataLookup) planHistogramFields(e *stmt.CallExpr) {
+// resolveFieldType returns the field type effective in query time range.
+// If query time range spans field type change, per-segment policy uses the latest type and
+// requires the function compatible with all types(each segment stored by its own type), else returns error.
+func (op *metadataLookup) resolveFieldType(parentFunc *stmt.CallExpr, fieldMeta field.Meta) (field.Type, error) {
+	if len(fieldMeta.History) == 0 {
+		return fieldMeta.Type, nil
+	}
+	timeRange := op.executeCtx.Query.TimeRange
+	types := fieldMeta.TypesIn(timeRange.Start, timeRange.End)
+	if len(types) == 0 {
+		return fieldMeta.Type, nil
+	}
+	fieldType := types[len(types)-1]
+	if len(types) == 1 {
+		return fieldType, nil
+	}
+	if !op.database.GetLimits().EnableFieldTypeEvolution() {
+		return field.Unknown, fmt.Errorf("%w, field: %s", constants.ErrFieldTypeChanged, fieldMeta.Name)
+	}
+	funcType := fieldType.DownSamplingFunc()
+	if parentFunc != nil {
+		funcType = parentFunc.FuncType
+	}
+	for _, t := range types {
+		if !t.IsFuncSupported(funcType) {
+			return field.Unknown, fmt.Errorf("%w, field: %s, type[%s] not support function[%s]",
+				constants.ErrFieldTypeChanged, fieldMeta.Name, t, funcType)
+		}
+	}
+	return fieldType, nil
+}
+
+// planConditionalField plans the field of conditional aggregation, like count_if(f > 100),
+// predicate carried in down sampling spec, evaluated per point when down sampling.
+func (op *metadataLookup) planConditionalField(e *stmt.CallExpr) {
 	if len(e.Params) != 1 {
-		op.err = fmt.Errorf("qunantile params more than one")
+		op.err = fmt.Errorf("function[%s] requires one predicate param", e.FuncType)
+		return
+	}
+	predicate, ok := e.Params[0].(*stmt.BinaryExpr)
+	if !ok {
+		op.err = fmt.Errorf("function[%s] param: %s is not predicate", e.FuncType, e.Params[0].Rewrite())
+		return
+	}
+	fieldExpr, ok := predicate.Left.(*stmt.FieldExpr)
+	if !ok {
+		op.err = fmt.Errorf("function[%s] predicate: %s not compare field", e.FuncType, predicate.Rewrite())
+		return
+	}
+	queryStmt := op.executeCtx.Query
+	fieldMeta, err := op.metadata.GetField(queryStmt.Namespace, queryStmt.MetricName, field.Name(fieldExpr.Name))
+	if err != nil {
+		op.err = err
+		return
+	}
+	op.planField(e, fieldMeta)
+	if op.err != nil {
+		return
+	}
+	if err := op.fields[fieldMeta.ID].DownSampling.SetPredicate(predicate); err != nil {
+		op.err = err
+	}
+}
+
+func (op *metadataLookup) planHistogramFields(e *stmt.CallExpr) {
+	if len(e.Params) == 0 || len(e.Params) > 2 {
+		op.err = fmt.Errorf("qunantile params length invalid")
 		return
 	}
 	if v, err := strconv.ParseFloat(e.Params[0].Rewrite(), 64); err != nil {
@@ -241,6 +368,23 @@ func (op *metadataLookup) planHistogramFields(e *stmt.CallExpr) {
 		return
 	}
 	queryStmt := op.executeCtx.Query
+	if len(e.Params) == 2 {
+		// quantile(p, field), check if field supports quantile
+		fieldExpr, ok := e.Params[1].(*stmt.FieldExpr)
+		if !ok {
+			op.err = fmt.Errorf("quantile param: %s is not field", e.Params[1].Rewrite())
+			return
+		}
+		fieldMeta, err := op.metadata.GetField(queryStmt.Namespace, queryStmt.MetricName, field.Name(fieldExpr.Name))
+		if err != nil {
+			op.err = err
+			return
+		}
+		if !fieldMeta.Type.IsFuncSupported(function.Quantile) {
+			op.err = fmt.Errorf("field type[%s] not support function[%s]", fieldMeta.Type, function.Quantile)
+			return
+		}
+	}
 	fieldMetas, err := op.metadata.GetAllHistogramFields(queryStmt.Namespace, queryStmt.MetricName)
 	if err != nil {
 		op.err = err
@@ -26,10 +26,14 @@ import (
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql"
 	stmtpkg "github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 	"github.com/lindb/lindb/tsdb/metadb"
@@ -86,6 +90,24 @@ func TestMetadataLookup_Execute(t *testing.T) {
 		}, nil)
 		assert.NoError(t, op.Execute())
 	})
+	t.Run("field filter failure", func(t *testing.T) {
+		defer func() {
+			ctx.Query.FieldFilters = nil
+		}()
+		ctx.Query.SelectItems = []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: "f"}}
+		ctx.Query.FieldFilters = []*stmtpkg.BinaryExpr{{
+			Left: &stmtpkg.FieldExpr{Name: "g"}, Right: &stmtpkg.NumberLiteral{Val: 50}, Operator: stmtpkg.GREATER,
+		}}
+		op := NewMetadataLookup(ctx, db)
+		metaDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(metric.ID(10), nil)
+		metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).Return(field.Meta{
+			ID:   10,
+			Type: field.SumField,
+			Name: "f",
+		}, nil)
+		metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("g")).Return(field.Meta{}, fmt.Errorf("err"))
+		assert.Error(t, op.Execute())
+	})
 	t.Run("get all fields failure", func(t *testing.T) {
 		ctx.Query.AllFields = true
 		op := NewMetadataLookup(ctx, db)
@@ -218,6 +240,74 @@ func TestMetadataLookup_field(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "handle histogram_quantile function",
+			in: &stmtpkg.CallExpr{
+				FuncType: function.HistogramQuantile,
+				Params:   []stmtpkg.Expr{&stmtpkg.NumberLiteral{Val: 0.99}, &stmtpkg.FieldExpr{Name: "f"}},
+			},
+		},
+		{
+			name: "histogram_quantile params invalid",
+			in: &stmtpkg.CallExpr{
+				FuncType: function.HistogramQuantile,
+				Params:   []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: "f"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "handle argmax function",
+			in: &stmtpkg.CallExpr{
+				FuncType: function.ArgMax,
+				Params:   []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: "host"}, &stmtpkg.FieldExpr{Name: "f"}},
+			},
+		},
+		{
+			name: "argmin params invalid",
+			in: &stmtpkg.CallExpr{
+				FuncType: function.ArgMin,
+				Params:   []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: "f"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "handle absent function",
+			in: &stmtpkg.CallExpr{
+				FuncType: function.Absent,
+				Params:   []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: "f"}},
+			},
+		},
+		{
+			name: "absent params invalid",
+			in: &stmtpkg.CallExpr{
+				FuncType: function.Absent,
+			},
+			wantErr: true,
+		},
+		{
+			name: "handle percent function",
+			in: &stmtpkg.CallExpr{
+				FuncType: function.Percent,
+				Params:   []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: "f"}},
+			},
+		},
+		{
+			name: "handle percent with sum function",
+			in: &stmtpkg.CallExpr{
+				FuncType: function.Percent,
+				Params: []stmtpkg.Expr{&stmtpkg.CallExpr{
+					FuncType: function.Sum,
+					Params:   []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: "f"}},
+				}},
+			},
+		},
+		{
+			name: "percent params invalid",
+			in: &stmtpkg.CallExpr{
+				FuncType: function.Percent,
+			},
+			wantErr: true,
+		},
 		{
 			name: "handle paren",
 			in: &stmtpkg.ParenExpr{
@@ -243,6 +333,114 @@ func TestMetadataLookup_field(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("percent of field with group by", func(t *testing.T) {
+		q, err := sql.Parse("select percent(f) from m group by host")
+		assert.NoError(t, err)
+		op := &metadataLookup{
+			executeCtx: &flow.StorageExecuteContext{
+				Query:   q.(*stmtpkg.Query),
+				TagKeys: make(map[string]tag.KeyID),
+			},
+			metadata: metaDB,
+			fields:   make(map[field.ID]*aggregation.Aggregator),
+		}
+		assert.NoError(t, op.selectList())
+		aggregator := op.fields[10]
+		assert.NotNil(t, aggregator)
+		_, ok := aggregator.DownSampling.Functions()[function.Sum]
+		assert.True(t, ok)
+	})
+}
+
+func TestMetadataLookup_resolveFieldType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := tsdb.NewMockDatabase(ctrl)
+	// sum field changed to last at 100, then changed to max at 200
+	f := field.Meta{ID: 10, Type: field.SumField, Name: "f"}.
+		ChangeType(field.LastField, 100).
+		ChangeType(field.MaxField, 200)
+	sumFunc := &stmtpkg.CallExpr{FuncType: function.Sum}
+	maxFunc := &stmtpkg.CallExpr{FuncType: function.Max}
+	perSegment := &models.Limits{FieldTypeEvolution: models.FieldTypeEvolutionPerSegment}
+	cases := []struct {
+		name       string
+		timeRange  timeutil.TimeRange
+		parentFunc *stmtpkg.CallExpr
+		limits     *models.Limits
+		fieldType  field.Type
+		wantErr    bool
+	}{
+		{
+			name:      "before type change",
+			timeRange: timeutil.TimeRange{Start: 10, End: 99},
+			fieldType: field.SumField,
+		},
+		{
+			name:      "between type change",
+			timeRange: timeutil.TimeRange{Start: 100, End: 150},
+			fieldType: field.LastField,
+		},
+		{
+			name:      "after type change",
+			timeRange: timeutil.TimeRange{Start: 200, End: 300},
+			fieldType: field.MaxField,
+		},
+		{
+			name:      "span type change with error policy",
+			timeRange: timeutil.TimeRange{Start: 10, End: 150},
+			limits:    models.NewDefaultLimits(),
+			wantErr:   true,
+		},
+		{
+			name:       "span type change with per-segment policy",
+			timeRange:  timeutil.TimeRange{Start: 10, End: 150},
+			parentFunc: sumFunc,
+			limits:     perSegment,
+			fieldType:  field.LastField,
+		},
+		{
+			name:      "span type change, default function not compatible",
+			timeRange: timeutil.TimeRange{Start: 10, End: 150},
+			limits:    perSegment,
+			wantErr:   true,
+		},
+		{
+			name:       "span all type changes",
+			timeRange:  timeutil.TimeRange{Start: 10, End: 300},
+			parentFunc: maxFunc,
+			limits:     perSegment,
+			fieldType:  field.MaxField,
+		},
+		{
+			name:       "span all type changes, function not compatible",
+			timeRange:  timeutil.TimeRange{Start: 10, End: 300},
+			parentFunc: sumFunc,
+			limits:     perSegment,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.limits != nil {
+				db.EXPECT().GetLimits().Return(tt.limits)
+			}
+			op := &metadataLookup{
+				database:   db,
+				executeCtx: &flow.StorageExecuteContext{Query: &stmtpkg.Query{TimeRange: tt.timeRange}},
+			}
+			fieldType, err := op.resolveFieldType(tt.parentFunc, f)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, constants.ErrFieldTypeChanged)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.fieldType, fieldType)
+			}
+		})
+	}
 }
 
 func TestMetadataLookup_planHistogramFields(t *testing.T) {
@@ -301,6 +499,51 @@ func TestMetadataLookup_planHistogramFields(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "field param not field",
+			in: &stmtpkg.CallExpr{
+				Params: []stmtpkg.Expr{&stmtpkg.NumberLiteral{Val: 0.95}, &stmtpkg.NumberLiteral{Val: 1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "find histogram field failure",
+			in: &stmtpkg.CallExpr{
+				Params: []stmtpkg.Expr{&stmtpkg.NumberLiteral{Val: 0.95}, &stmtpkg.FieldExpr{Name: "latency"}},
+			},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).Return(field.Meta{}, fmt.Errorf("err"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "field not support quantile",
+			in: &stmtpkg.CallExpr{
+				Params: []stmtpkg.Expr{&stmtpkg.NumberLiteral{Val: 0.95}, &stmtpkg.FieldExpr{Name: "latency"}},
+			},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(field.Meta{Type: field.SumField, Name: "latency"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "find histogram field successfully",
+			in: &stmtpkg.CallExpr{
+				Params: []stmtpkg.Expr{&stmtpkg.NumberLiteral{Val: 0.5}, &stmtpkg.FieldExpr{Name: "latency"}},
+			},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(field.Meta{Type: field.HistogramField, Name: "latency"}, nil)
+				metaDB.EXPECT().GetAllHistogramFields(gomock.Any(), gomock.Any()).
+					Return(field.Metas{{
+						ID:   1,
+						Type: field.HistogramField,
+						Name: "__bucket_10",
+					}}, nil)
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range cases {
@@ -324,6 +567,201 @@ func TestMetadataLookup_planHistogramFields(t *testing.T) {
 	}
 }
 
+func TestMetadataLookup_planConditionalField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metaDB := metadb.NewMockMetadataDatabase(ctrl)
+	predicate := func(op stmtpkg.BinaryOP, val float64) *stmtpkg.BinaryExpr {
+		return &stmtpkg.BinaryExpr{Left: &stmtpkg.FieldExpr{Name: "f"}, Right: &stmtpkg.NumberLiteral{Val: val}, Operator: op}
+	}
+	countIf := &stmtpkg.CallExpr{FuncType: function.CountIf, Params: []stmtpkg.Expr{predicate(stmtpkg.GREATER, 100)}}
+	sumIf := &stmtpkg.CallExpr{FuncType: function.SumIf, Params: []stmtpkg.Expr{predicate(stmtpkg.GREATER, 100)}}
+	cases := []struct {
+		name    string
+		in      []stmtpkg.Expr
+		prepare func()
+		wantErr bool
+	}{
+		{
+			name:    "invalid params",
+			in:      []stmtpkg.Expr{&stmtpkg.CallExpr{FuncType: function.CountIf}},
+			wantErr: true,
+		},
+		{
+			name: "param not predicate",
+			in: []stmtpkg.Expr{&stmtpkg.CallExpr{
+				FuncType: function.CountIf,
+				Params:   []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: "f"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "predicate not compare field",
+			in: []stmtpkg.Expr{&stmtpkg.CallExpr{
+				FuncType: function.CountIf,
+				Params: []stmtpkg.Expr{&stmtpkg.BinaryExpr{
+					Left: &stmtpkg.NumberLiteral{Val: 1}, Right: &stmtpkg.NumberLiteral{Val: 1}, Operator: stmtpkg.GREATER,
+				}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "find field failure",
+			in:   []stmtpkg.Expr{countIf},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).Return(field.Meta{}, fmt.Errorf("err"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "histogram field not support",
+			in:   []stmtpkg.Expr{countIf},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(field.Meta{ID: 10, Type: field.HistogramField, Name: "f"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "count_if and sum_if with threshold predicate",
+			in:   []stmtpkg.Expr{countIf, sumIf},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(field.Meta{ID: 10, Type: field.SumField, Name: "f"}, nil).Times(2)
+			},
+		},
+		{
+			name: "conflict predicates",
+			in: []stmtpkg.Expr{countIf, &stmtpkg.CallExpr{
+				FuncType: function.SumIf,
+				Params:   []stmtpkg.Expr{predicate(stmtpkg.LESS, 10)},
+			}},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(field.Meta{ID: 10, Type: field.SumField, Name: "f"}, nil).Times(2)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			op := &metadataLookup{
+				executeCtx: &flow.StorageExecuteContext{
+					Query: &stmtpkg.Query{},
+				},
+				metadata: metaDB,
+				fields:   make(map[field.ID]*aggregation.Aggregator),
+			}
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			for _, in := range tt.in {
+				op.field(nil, in)
+			}
+			if (op.err != nil) != tt.wantErr {
+				t.Fatal(tt.name)
+			}
+			if !tt.wantErr {
+				agg := op.fields[10]
+				assert.Len(t, agg.DownSampling.Functions(), 2)
+				assert.Len(t, agg.Aggregator.Functions(), 2)
+				assert.True(t, agg.DownSampling.Predicate()(101))
+				assert.False(t, agg.DownSampling.Predicate()(100))
+				assert.Nil(t, agg.Aggregator.Predicate())
+			}
+		})
+	}
+}
+
+func TestMetadataLookup_fieldFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metaDB := metadb.NewMockMetadataDatabase(ctrl)
+	filter := func(name string, op stmtpkg.BinaryOP, val float64) *stmtpkg.BinaryExpr {
+		return &stmtpkg.BinaryExpr{Left: &stmtpkg.FieldExpr{Name: name}, Right: &stmtpkg.NumberLiteral{Val: val}, Operator: op}
+	}
+	cases := []struct {
+		name    string
+		filters []*stmtpkg.BinaryExpr
+		prepare func()
+		wantErr bool
+	}{
+		{
+			name:    "filter not compare field",
+			filters: []*stmtpkg.BinaryExpr{{Left: &stmtpkg.NumberLiteral{Val: 1}, Right: &stmtpkg.NumberLiteral{Val: 1}, Operator: stmtpkg.GREATER}},
+			wantErr: true,
+		},
+		{
+			name:    "find field failure",
+			filters: []*stmtpkg.BinaryExpr{filter("f", stmtpkg.GREATER, 50)},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).Return(field.Meta{}, fmt.Errorf("err"))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "field not in select list",
+			filters: []*stmtpkg.BinaryExpr{filter("g", stmtpkg.GREATER, 50)},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("g")).
+					Return(field.Meta{ID: 20, Type: field.SumField, Name: "g"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid filter",
+			filters: []*stmtpkg.BinaryExpr{filter("f", stmtpkg.ADD, 50)},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
+					Return(field.Meta{ID: 10, Type: field.SumField, Name: "f"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "filters pushed into down sampling",
+			filters: []*stmtpkg.BinaryExpr{filter("f", stmtpkg.GREATER, 50), filter("f", stmtpkg.LESS, 100)},
+			prepare: func() {
+				metaDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
+					Return(field.Meta{ID: 10, Type: field.SumField, Name: "f"}, nil).Times(2)
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			downSampling := aggregation.NewAggregatorSpec("f", field.SumField)
+			aggregator := aggregation.NewAggregatorSpec("f", field.SumField)
+			op := &metadataLookup{
+				executeCtx: &flow.StorageExecuteContext{
+					Query: &stmtpkg.Query{FieldFilters: tt.filters},
+				},
+				metadata: metaDB,
+				fields: map[field.ID]*aggregation.Aggregator{
+					10: {DownSampling: downSampling, Aggregator: aggregator},
+				},
+			}
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			err := op.fieldFilter()
+			if (err != nil) != tt.wantErr {
+				t.Fatal(tt.name)
+			}
+			if !tt.wantErr {
+				assert.True(t, downSampling.Filter()(60))
+				assert.False(t, downSampling.Filter()(50))
+				assert.False(t, downSampling.Filter()(100))
+				assert.Nil(t, aggregator.Filter())
+			}
+		})
+	}
+}
+
 func TestMetadataLookup_Identifier(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
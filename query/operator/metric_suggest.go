@@ -17,7 +17,16 @@
 
 package operator
 
-import "github.com/lindb/lindb/query/context"
+import (
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/query/context"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// maxFilterCandidates represents the max num. of candidate metrics checked by tag filter,
+// because each candidate need do tag values lookup and series filtering.
+const maxFilterCandidates = 10000
 
 // metricSuggest represents metric suggest operator.
 type metricSuggest struct {
@@ -31,18 +40,64 @@ func NewMetricSuggest(ctx *context.LeafMetadataContext) Operator {
 	}
 }
 
-// Execute returns metric list by given namespace/prefix.
+// Execute returns metric list by given namespace/prefix,
+// if tag filter condition exists, only returns the metrics which have series matching the filter.
 func (op *metricSuggest) Execute() error {
 	req := op.ctx.Request
 	limit := op.ctx.Limit
+	if req.Condition != nil {
+		limit = maxFilterCandidates
+	}
 	rs, err := op.ctx.Database.Metadata().MetadataDatabase().SuggestMetrics(req.Namespace, req.Prefix, limit)
 	if err != nil {
 		return err
 	}
-	op.ctx.ResultSet = rs
+	if req.Condition == nil {
+		op.ctx.ResultSet = rs
+		return nil
+	}
+	for _, metricName := range rs {
+		if len(op.ctx.ResultSet) >= op.ctx.Limit {
+			break
+		}
+		if op.hasMatchedSeries(metricName) {
+			op.ctx.ResultSet = append(op.ctx.ResultSet, metricName)
+		}
+	}
 	return nil
 }
 
+// hasMatchedSeries checks if the metric has at least one series matching the tag filter in any shard,
+// returns false if the tag keys of filter not exist under metric.
+func (op *metricSuggest) hasMatchedSeries(metricName string) bool {
+	req := op.ctx.Request
+	storageExecuteCtx := &flow.StorageExecuteContext{
+		Query: &stmt.Query{
+			Namespace:  req.Namespace,
+			MetricName: metricName,
+			Condition:  req.Condition,
+		},
+		TagKeys: make(map[string]tag.KeyID),
+	}
+	if err := NewTagValuesLookup(storageExecuteCtx, op.ctx.Database).Execute(); err != nil {
+		return false
+	}
+	for _, shardID := range op.ctx.ShardIDs {
+		shard, ok := op.ctx.Database.GetShard(shardID)
+		if !ok {
+			continue
+		}
+		shardExecuteCtx := flow.NewShardExecuteContext(storageExecuteCtx)
+		if err := NewSeriesFiltering(shardExecuteCtx, shard).Execute(); err != nil {
+			continue
+		}
+		if !shardExecuteCtx.SeriesIDsAfterFiltering.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
 // Identifier returns identifier string value of metric suggest operator.
 func (op *metricSuggest) Identifier() string {
 	return "Metric Suggest"
@@ -22,11 +22,15 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/query/context"
+	"github.com/lindb/lindb/series/tag"
 	stmtpkg "github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/indexdb"
 	"github.com/lindb/lindb/tsdb/metadb"
 )
 
@@ -81,6 +85,47 @@ func TestMetricSuggest_Execute(t *testing.T) {
 	}
 }
 
+func TestMetricSuggest_TagFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := tsdb.NewMockDatabase(ctrl)
+	meta := metadb.NewMockMetadata(ctrl)
+	metaDB := metadb.NewMockMetadataDatabase(ctrl)
+	tagMeta := metadb.NewMockTagMetadata(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	meta.EXPECT().MetadataDatabase().Return(metaDB).AnyTimes()
+	meta.EXPECT().TagMetadata().Return(tagMeta).AnyTimes()
+	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+
+	// cpu: series matched; mem: tag key not found; disk: no series matched
+	metaDB.EXPECT().SuggestMetrics("ns", "", maxFilterCandidates).Return([]string{"cpu", "mem", "disk"}, nil)
+	metaDB.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(tag.KeyID(1), nil)
+	metaDB.EXPECT().GetTagKeyID("ns", "mem", "host").Return(tag.KeyID(0), fmt.Errorf("err"))
+	metaDB.EXPECT().GetTagKeyID("ns", "disk", "host").Return(tag.KeyID(2), nil)
+	tagMeta.EXPECT().FindTagValueDsByExpr(tag.KeyID(1), gomock.Any()).Return(roaring.BitmapOf(1), nil)
+	tagMeta.EXPECT().FindTagValueDsByExpr(tag.KeyID(2), gomock.Any()).Return(roaring.BitmapOf(2), nil)
+	db.EXPECT().GetShard(models.ShardID(1)).Return(nil, false).Times(2)
+	db.EXPECT().GetShard(models.ShardID(2)).Return(shard, true).Times(2)
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(tag.KeyID(1), gomock.Any()).Return(roaring.BitmapOf(10), nil)
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(tag.KeyID(2), gomock.Any()).Return(roaring.New(), nil)
+
+	ctx := &context.LeafMetadataContext{
+		Database: db,
+		ShardIDs: []models.ShardID{1, 2},
+		Limit:    10,
+		Request: &stmtpkg.MetricMetadata{
+			Namespace: "ns",
+			Condition: &stmtpkg.EqualsExpr{Key: "host", Value: "a"},
+		},
+	}
+	err := NewMetricSuggest(ctx).Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpu"}, ctx.ResultSet)
+}
+
 func TestMetricSuggest_Identifier(t *testing.T) {
 	assert.Equal(t, "Metric Suggest", NewMetricSuggest(nil).Identifier())
 }
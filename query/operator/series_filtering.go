@@ -78,6 +78,25 @@ func (op *seriesFiltering) findSeriesIDsByExpr(condition stmt.Expr) (tag.KeyID,
 	case *stmt.ParenExpr:
 		return op.findSeriesIDsByExpr(expr.Expr)
 	case *stmt.NotExpr:
+		switch inner := expr.Expr.(type) {
+		case *stmt.ParenExpr:
+			return op.findSeriesIDsByExpr(&stmt.NotExpr{Expr: inner.Expr})
+		case *stmt.NotExpr:
+			// not (not a) => a
+			return op.findSeriesIDsByExpr(inner.Expr)
+		case *stmt.BinaryExpr:
+			// De Morgan's laws, not (a and b) => not a or not b, not (a or b) => not a and not b,
+			// because series ids of not expr are based on all series ids of tag key.
+			operator := stmt.AND
+			if inner.Operator == stmt.AND {
+				operator = stmt.OR
+			}
+			return op.findSeriesIDsByExpr(&stmt.BinaryExpr{
+				Left:     &stmt.NotExpr{Expr: inner.Left},
+				Operator: operator,
+				Right:    &stmt.NotExpr{Expr: inner.Right},
+			})
+		}
 		// get filter series ids
 		tagKey, matchResult := op.findSeriesIDsByExpr(expr.Expr)
 		// get all series ids for tag key
@@ -103,10 +122,15 @@ func (op *seriesFiltering) findSeriesIDsByExpr(condition stmt.Expr) (tag.KeyID,
 }
 
 // getTagKeyID returns the tag key id by tag key
-func (op *seriesFiltering) getSeriesIDsByExpr(expr stmt.Expr) (tag.KeyID, *roaring.Bitmap, error) {
+func (op *seriesFiltering) getSeriesIDsByExpr(expr stmt.TagFilter) (tag.KeyID, *roaring.Bitmap, error) {
 	tagValues, ok := op.executeCtx.StorageExecuteCtx.TagFilterResult[expr.Rewrite()]
 	if !ok {
-		return 0, nil, fmt.Errorf("%w, expr: %s", constants.ErrTagValueFilterResultNotFound, expr.Rewrite())
+		// no tag value matches filter, returns empty series ids, because expr maybe under or/not expr.
+		tagKeyID, found := op.executeCtx.StorageExecuteCtx.TagKeys[expr.TagKey()]
+		if !found {
+			return 0, nil, fmt.Errorf("%w, expr: %s", constants.ErrTagValueFilterResultNotFound, expr.Rewrite())
+		}
+		return tagKeyID, roaring.New(), nil
 	}
 	seriesIDs, err := op.indexDB.GetSeriesIDsByTagValueIDs(tagValues.TagKeyID, tagValues.TagValueIDs)
 	if err != nil {
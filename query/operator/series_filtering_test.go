@@ -28,6 +28,7 @@ import (
 
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql"
 	stmtpkg "github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 	"github.com/lindb/lindb/tsdb/indexdb"
@@ -204,6 +205,61 @@ func TestSeriesFiltering_Execute(t *testing.T) {
 	}
 }
 
+func TestSeriesFiltering_TagFilterExprs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shard := tsdb.NewMockShard(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	// tag value id => series ids(host=1:{1,2},2:{3},3:{4,5})
+	seriesOfTagValues := map[uint32][]uint32{1: {1, 2}, 2: {3}, 3: {4, 5}}
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ tag.KeyID, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error) {
+			seriesIDs := roaring.New()
+			for _, tagValueID := range tagValueIDs.ToArray() {
+				seriesIDs.AddMany(seriesOfTagValues[tagValueID])
+			}
+			return seriesIDs, nil
+		}).AnyTimes()
+	indexDB.EXPECT().GetSeriesIDsForTag(gomock.Any()).Return(roaring.BitmapOf(1, 2, 3, 4, 5), nil).AnyTimes()
+
+	inExpr := &stmtpkg.InExpr{Key: "host", Values: []string{"a", "b"}}
+	likeExpr := &stmtpkg.LikeExpr{Key: "host", Value: "c*"}
+	regexExpr := &stmtpkg.RegexExpr{Key: "host", Regexp: "^b"}
+	emptyExpr := &stmtpkg.EqualsExpr{Key: "host", Value: "not-exist"}
+	storageCtx := &flow.StorageExecuteContext{
+		Query: &stmtpkg.Query{},
+		TagFilterResult: map[string]*flow.TagFilterResult{
+			inExpr.Rewrite():    {TagKeyID: 1, TagValueIDs: roaring.BitmapOf(1, 2)},
+			likeExpr.Rewrite():  {TagKeyID: 1, TagValueIDs: roaring.BitmapOf(3)},
+			regexExpr.Rewrite(): {TagKeyID: 1, TagValueIDs: roaring.BitmapOf(2)},
+			emptyExpr.Rewrite(): {TagKeyID: 1, TagValueIDs: roaring.New()},
+		},
+	}
+	cases := []struct {
+		name      string
+		in        stmtpkg.Expr
+		seriesIDs []uint32
+	}{
+		{name: "in expr", in: inExpr, seriesIDs: []uint32{1, 2, 3}},
+		{name: "like expr", in: likeExpr, seriesIDs: []uint32{4, 5}},
+		{name: "regex expr", in: regexExpr, seriesIDs: []uint32{3}},
+		{name: "not expr", in: &stmtpkg.NotExpr{Expr: inExpr}, seriesIDs: []uint32{4, 5}},
+		{name: "empty result", in: emptyExpr, seriesIDs: []uint32{}},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			storageCtx.Query.Condition = tt.in
+			shardCtx := flow.NewShardExecuteContext(storageCtx)
+			op := NewSeriesFiltering(shardCtx, shard)
+			assert.NoError(t, op.Execute())
+			assert.Equal(t, tt.seriesIDs, shardCtx.SeriesIDsAfterFiltering.ToArray())
+		})
+	}
+}
+
 func TestSeriesFiltering_Stats(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -219,3 +275,106 @@ func TestSeriesFiltering_Stats(t *testing.T) {
 	op1 := op.(TrackableOperator)
 	assert.NotNil(t, op1.Stats())
 }
+
+func TestSeriesFiltering_CrossTagKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shard := tsdb.NewMockShard(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	// host(1): a=>1,2 b=>3; region(2): us=>1,5 eu=>3,4; zone(3) without matched tag value
+	seriesIDsByTagValue := map[uint32]*roaring.Bitmap{
+		10: roaring.BitmapOf(1, 2),
+		11: roaring.BitmapOf(3),
+		20: roaring.BitmapOf(3, 4),
+	}
+	seriesIDsByTagKey := map[tag.KeyID]*roaring.Bitmap{
+		1: roaring.BitmapOf(1, 2, 3),
+		2: roaring.BitmapOf(1, 3, 4, 5),
+		3: roaring.BitmapOf(6),
+	}
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ tag.KeyID, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error) {
+			return seriesIDsByTagValue[tagValueIDs.Minimum()].Clone(), nil
+		}).AnyTimes()
+	indexDB.EXPECT().GetSeriesIDsForTag(gomock.Any()).
+		DoAndReturn(func(tagKeyID tag.KeyID) (*roaring.Bitmap, error) {
+			return seriesIDsByTagKey[tagKeyID].Clone(), nil
+		}).AnyTimes()
+
+	cases := []struct {
+		condition string
+		seriesIDs []uint32
+	}{
+		{condition: "host='a' or region='eu'", seriesIDs: []uint32{1, 2, 3, 4}},
+		{condition: "(host='a' or region='eu') and host='b'", seriesIDs: []uint32{3}},
+		// and binds tighter than or
+		{condition: "host='a' or region='eu' and host='b'", seriesIDs: []uint32{1, 2, 3}},
+		{condition: "zone='x' or region='eu'", seriesIDs: []uint32{3, 4}},
+		{condition: "zone='x' and region='eu'", seriesIDs: []uint32{}},
+		{condition: "zone!='x' or host='b'", seriesIDs: []uint32{3, 6}},
+		// tag exists, all series which have tag key
+		{condition: "region!=''", seriesIDs: []uint32{1, 3, 4, 5}},
+		{condition: "host!='' and region!=''", seriesIDs: []uint32{1, 3}},
+	}
+	for _, c := range cases {
+		q, err := sql.Parse("select f from cpu where " + c.condition)
+		assert.NoError(t, err, c.condition)
+		assertSeriesFiltering(t, shard, q.(*stmtpkg.Query).Condition, c.seriesIDs)
+	}
+
+	hostA := &stmtpkg.EqualsExpr{Key: "host", Value: "a"}
+	regionEU := &stmtpkg.EqualsExpr{Key: "region", Value: "eu"}
+	exprCases := []struct {
+		name      string
+		condition stmtpkg.Expr
+		seriesIDs []uint32
+	}{
+		{
+			name: "not (a or b) => not a and not b",
+			condition: &stmtpkg.NotExpr{Expr: &stmtpkg.ParenExpr{Expr: &stmtpkg.BinaryExpr{
+				Left: hostA, Operator: stmtpkg.OR, Right: regionEU,
+			}}},
+			seriesIDs: []uint32{},
+		},
+		{
+			name: "not (a and b) => not a or not b",
+			condition: &stmtpkg.NotExpr{Expr: &stmtpkg.BinaryExpr{
+				Left: hostA, Operator: stmtpkg.AND, Right: regionEU,
+			}},
+			seriesIDs: []uint32{1, 3, 5},
+		},
+		{
+			name:      "not (not a) => a",
+			condition: &stmtpkg.NotExpr{Expr: &stmtpkg.NotExpr{Expr: hostA}},
+			seriesIDs: []uint32{1, 2},
+		},
+	}
+	for _, c := range exprCases {
+		// expr ships from broker to storage
+		condition, err := stmtpkg.Unmarshal(stmtpkg.Marshal(c.condition))
+		assert.NoError(t, err, c.name)
+		assert.Equal(t, c.condition, condition, c.name)
+		assertSeriesFiltering(t, shard, condition, c.seriesIDs)
+	}
+}
+
+func assertSeriesFiltering(t *testing.T, shard tsdb.Shard, condition stmtpkg.Expr, seriesIDs []uint32) {
+	storageCtx := &flow.StorageExecuteContext{
+		Query: &stmtpkg.Query{Condition: condition},
+		TagKeys: map[string]tag.KeyID{
+			"host":   1,
+			"region": 2,
+			"zone":   3,
+		},
+		TagFilterResult: map[string]*flow.TagFilterResult{
+			"host=a":    {TagKeyID: 1, TagValueIDs: roaring.BitmapOf(10)},
+			"host=b":    {TagKeyID: 1, TagValueIDs: roaring.BitmapOf(11)},
+			"region=eu": {TagKeyID: 2, TagValueIDs: roaring.BitmapOf(20)},
+		},
+	}
+	shardCtx := flow.NewShardExecuteContext(storageCtx)
+	assert.NoError(t, NewSeriesFiltering(shardCtx, shard).Execute(), condition.Rewrite())
+	assert.Equal(t, seriesIDs, shardCtx.SeriesIDsAfterFiltering.ToArray(), condition.Rewrite())
+}
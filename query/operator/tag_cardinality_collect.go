@@ -0,0 +1,60 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package operator
+
+import (
+	"github.com/lindb/lindb/pkg/sketch"
+	"github.com/lindb/lindb/query/context"
+)
+
+// tagCardinalityCollect represents tag cardinality collect operator.
+type tagCardinalityCollect struct {
+	ctx *context.LeafMetadataContext
+}
+
+// NewTagCardinalityCollect creates a tagCardinalityCollect instance.
+func NewTagCardinalityCollect(ctx *context.LeafMetadataContext) Operator {
+	return &tagCardinalityCollect{
+		ctx: ctx,
+	}
+}
+
+// Execute collects distinct counter of tag values for each tag key by given namespace/metric name.
+func (op *tagCardinalityCollect) Execute() error {
+	req := op.ctx.Request
+	metadata := op.ctx.Database.Metadata()
+	tagKeys, err := metadata.MetadataDatabase().GetAllTagKeys(req.Namespace, req.MetricName)
+	if err != nil {
+		return err
+	}
+	result := make(map[string]*sketch.Distinct, len(tagKeys))
+	for _, tagKey := range tagKeys {
+		counter, err := metadata.TagMetadata().TagCardinality(tagKey.ID)
+		if err != nil {
+			return err
+		}
+		result[tagKey.Key] = counter
+	}
+	op.ctx.Cardinality = result
+	return nil
+}
+
+// Identifier returns identifier value of tag cardinality collect operator.
+func (op *tagCardinalityCollect) Identifier() string {
+	return "Tag Cardinality Collect"
+}
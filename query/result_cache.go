@@ -0,0 +1,150 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lindb/common/pkg/encoding"
+
+	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/metrics"
+	"github.com/lindb/lindb/models"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+// ResultCache caches the result set of metric data query in broker with LRU eviction and ttl,
+// so that identical queries(e.g. dashboard refreshes every few seconds) don't hit storage each time.
+type ResultCache struct {
+	capacity int
+	ttl      time.Duration
+	lru      *list.List               // element value: *resultCacheEntry, front is most recently used
+	entries  map[string]*list.Element // cache key => lru element
+
+	now        func() time.Time
+	statistics *metrics.QueryResultCacheStatistics
+
+	mutex sync.Mutex
+}
+
+// resultCacheEntry represents the cached result set with its expire time.
+type resultCacheEntry struct {
+	key      string
+	rs       *models.ResultSet
+	expireAt time.Time
+}
+
+// NewResultCache creates a result cache with max num. of cached result sets and ttl of each result set.
+func NewResultCache(capacity int, ttl time.Duration) *ResultCache {
+	return &ResultCache{
+		capacity:   capacity,
+		ttl:        ttl,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+		now:        time.Now,
+		statistics: metrics.NewQueryResultCacheStatistics(linmetric.BrokerRegistry),
+	}
+}
+
+// Get returns the cached result set by key, returns false if not cached or expired.
+func (c *ResultCache) Get(key string) (*models.ResultSet, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.statistics.Misses.Incr()
+		return nil, false
+	}
+	entry := elem.Value.(*resultCacheEntry)
+	if !c.now().Before(entry.expireAt) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		c.statistics.Misses.Incr()
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	c.statistics.Hits.Incr()
+	return entry.rs, true
+}
+
+// Put caches the result set which is fully successful(no shard timed out/failed/not found),
+// evicts the least recently used result set if full.
+func (c *ResultCache) Put(key string, rs *models.ResultSet) {
+	if rs == nil || (rs.Coverage != nil && (rs.Coverage.IsPartial() || len(rs.Coverage.NotFound) > 0)) {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expireAt := c.now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*resultCacheEntry)
+		entry.rs = rs
+		entry.expireAt = expireAt
+		c.lru.MoveToFront(elem)
+		return
+	}
+	if c.lru.Len() >= c.capacity {
+		oldest := c.lru.Back()
+		delete(c.entries, c.lru.Remove(oldest).(*resultCacheEntry).key)
+		c.statistics.Evictions.Incr()
+	}
+	c.entries[key] = c.lru.PushFront(&resultCacheEntry{key: key, rs: rs, expireAt: expireAt})
+}
+
+// Size returns the num. of cached result sets.
+func (c *ResultCache) Size() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.lru.Len()
+}
+
+// ResultCacheKey returns the cache key of query based on database and normalized statement,
+// statement parsed from sql canonicalizes whitespace and equivalent time expressions(now()-1h = now()-60m),
+// time range is quantized to the step(group by interval, or given step if not set),
+// so that queries with now() hit the same key within the quantization window.
+// NOTE: must be invoked before executing query, because statement may be rewritten when executing.
+func ResultCacheKey(database string, statement *stmtpkg.Query, step time.Duration) string {
+	q := quantizeTimeRange(statement, step.Milliseconds())
+	var sb strings.Builder
+	sb.WriteString(database)
+	sb.WriteByte('|')
+	sb.Write(encoding.JSONMarshal(q))
+	return sb.String()
+}
+
+// quantizeTimeRange returns a copy of statement which time range(include sub query's) rounded down to the step.
+func quantizeTimeRange(statement *stmtpkg.Query, step int64) *stmtpkg.Query {
+	q := *statement
+	if q.Interval > 0 {
+		step = q.Interval.Int64()
+	}
+	if step > 0 {
+		q.TimeRange.Start -= q.TimeRange.Start % step
+		q.TimeRange.End -= q.TimeRange.End % step
+	}
+	if q.SubQuery != nil {
+		q.SubQuery = quantizeTimeRange(q.SubQuery, step)
+	}
+	return &q
+}
@@ -0,0 +1,108 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+func TestResultCache_GetPut(t *testing.T) {
+	cache := NewResultCache(2, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+
+	rsA := &models.ResultSet{}
+	cache.Put("a", rsA)
+	rs, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Same(t, rsA, rs)
+	// update
+	rsA2 := &models.ResultSet{}
+	cache.Put("a", rsA2)
+	rs, _ = cache.Get("a")
+	assert.Same(t, rsA2, rs)
+	assert.Equal(t, 1, cache.Size())
+
+	// lru eviction, a is recently used, b is evicted
+	cache.Put("b", &models.ResultSet{})
+	_, _ = cache.Get("a")
+	cache.Put("c", &models.ResultSet{})
+	assert.Equal(t, 2, cache.Size())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+
+	// expired
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Size())
+}
+
+func TestResultCache_Put_NotSuccessful(t *testing.T) {
+	cache := NewResultCache(10, time.Minute)
+	cache.Put("nil", nil)
+	cache.Put("timeout", &models.ResultSet{Coverage: &models.ShardCoverage{TimedOut: []models.ShardID{1}}})
+	cache.Put("failed", &models.ResultSet{Coverage: &models.ShardCoverage{Failed: []models.ShardID{1}}})
+	cache.Put("not-found", &models.ResultSet{Coverage: &models.ShardCoverage{NotFound: []models.ShardID{1}}})
+	assert.Equal(t, 0, cache.Size())
+	cache.Put("ok", &models.ResultSet{Coverage: &models.ShardCoverage{Queried: []models.ShardID{1}, Responded: []models.ShardID{1}}})
+	assert.Equal(t, 1, cache.Size())
+}
+
+func TestResultCacheKey(t *testing.T) {
+	q := &stmtpkg.Query{
+		MetricName: "cpu",
+		TimeRange:  timeutil.TimeRange{Start: 10_000, End: 70_000},
+	}
+	// quantized by step
+	key := ResultCacheKey("db", q, time.Minute)
+	assert.Equal(t, key, ResultCacheKey("db", &stmtpkg.Query{
+		MetricName: "cpu",
+		TimeRange:  timeutil.TimeRange{Start: 20_000, End: 100_000},
+	}, time.Minute))
+	assert.NotEqual(t, key, ResultCacheKey("db2", q, time.Minute))
+	assert.NotEqual(t, key, ResultCacheKey("db", q, 0))
+	// statement not changed
+	assert.Equal(t, timeutil.TimeRange{Start: 10_000, End: 70_000}, q.TimeRange)
+
+	// quantized by group by interval
+	q.Interval = 10_000
+	assert.NotEqual(t, ResultCacheKey("db", q, time.Minute), ResultCacheKey("db", &stmtpkg.Query{
+		MetricName: "cpu",
+		Interval:   10_000,
+		TimeRange:  timeutil.TimeRange{Start: 20_000, End: 100_000},
+	}, time.Minute))
+
+	// sub query
+	q = &stmtpkg.Query{SubQuery: &stmtpkg.Query{MetricName: "cpu", TimeRange: timeutil.TimeRange{Start: 10_000, End: 70_000}}}
+	assert.Equal(t, ResultCacheKey("db", q, time.Minute), ResultCacheKey("db",
+		&stmtpkg.Query{SubQuery: &stmtpkg.Query{MetricName: "cpu", TimeRange: timeutil.TimeRange{Start: 30_000, End: 90_000}}}, time.Minute))
+	assert.Equal(t, int64(10_000), q.SubQuery.TimeRange.Start)
+}
@@ -19,6 +19,8 @@ package query
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -26,15 +28,18 @@ import (
 	commonmodels "github.com/lindb/common/models"
 	"github.com/lindb/common/pkg/encoding"
 
+	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/sketch"
 	"github.com/lindb/lindb/pkg/strutil"
 	queryctx "github.com/lindb/lindb/query/context"
 	"github.com/lindb/lindb/query/stage"
 	trackerpkg "github.com/lindb/lindb/query/tracker"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/metric"
 	stmtpkg "github.com/lindb/lindb/sql/stmt"
 )
 
@@ -47,6 +52,14 @@ type SearchMgr struct {
 	Choose       flow.NodeChoose
 	TaskMgr      TaskManager
 	TransportMgr rpc.TransportManager
+	// MaxFanOut is the max number of target nodes which a data query can fan out to, <=0 means no limit.
+	MaxFanOut int
+	// MaxGroupByTags is the max number of group by tag keys after group by * expanded, <=0 means default limit.
+	MaxGroupByTags int
+	// NotFoundPolicy represents how data query handles the not found error returned by target nodes, empty means all.
+	NotFoundPolicy models.NotFoundPolicy
+	// SeriesStream receives series of data query once made if set, not supports multi-metric query.
+	SeriesStream queryctx.SeriesStream
 }
 
 // MetricMetadataSearchWithResult represents the metadata query executor and retruns the final result set.
@@ -58,6 +71,15 @@ func MetricMetadataSearchWithResult(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if distinct, ok := rs.(*sketch.Distinct); ok {
+		return &commonmodels.Metadata{
+			Type:   statement.Type.String(),
+			Values: distinct.Count(),
+		}, nil
+	}
+	if cardinality, ok := rs.(map[string]*sketch.Distinct); ok {
+		return buildTagCardinalityResultSet(statement, cardinality), nil
+	}
 	return buildMetadataResultSet(statement, rs.([]string))
 }
 
@@ -112,20 +134,192 @@ func MetricDataSearch(ctx context.Context,
 	param *models.ExecuteParam, statement *stmtpkg.Query,
 	mgr *SearchMgr,
 ) (any, error) {
+	if statement.HasSubQuery() {
+		return subQueryDataSearch(ctx, param, statement, mgr)
+	}
+	if statement.IsMultiMetrics() {
+		return multiMetricDataSearch(ctx, param, statement, mgr)
+	}
+	if statement.GroupByAll {
+		if err := expandGroupByAll(ctx, param, statement, mgr); err != nil {
+			return nil, err
+		}
+	}
+	if statement.AllFields {
+		if err := expandSelectAll(ctx, param, statement, mgr); err != nil {
+			return nil, err
+		}
+	}
 	req := models.NewRequest(mgr.CurNode.Indicator(), param.Database, param.SQL)
 	taskCtx := queryctx.NewRootMetricContext(
 		&queryctx.RootMetricContextDeps{
-			Ctx:          ctx,
-			Request:      req,
-			Database:     param.Database,
-			CurrentNode:  mgr.CurNode,
-			Statement:    statement,
-			Choose:       mgr.Choose,
-			TransportMgr: mgr.TransportMgr,
+			Ctx:            ctx,
+			Request:        req,
+			Database:       param.Database,
+			CurrentNode:    mgr.CurNode,
+			Statement:      statement,
+			Choose:         mgr.Choose,
+			TransportMgr:   mgr.TransportMgr,
+			MaxFanOut:      mgr.MaxFanOut,
+			NotFoundPolicy: mgr.NotFoundPolicy,
+			SeriesStream:   mgr.SeriesStream,
 		})
 	return exec(taskCtx, req, mgr)
 }
 
+// multiMetricDataSearch searches each metric of multi-metric query(from cpu, mem) as independent query,
+// so that field validation of each metric is independent, returns the result sets keyed by metric name.
+func multiMetricDataSearch(ctx context.Context,
+	param *models.ExecuteParam, statement *stmtpkg.Query,
+	mgr *SearchMgr,
+) (any, error) {
+	rs := make(map[string]any, len(statement.MetricNames))
+	for _, metricName := range statement.MetricNames {
+		if _, ok := rs[metricName]; ok {
+			continue
+		}
+		metricStmt := *statement
+		metricStmt.MetricName = metricName
+		metricStmt.MetricNames = nil
+		// group by maybe expanded by each metric
+		metricStmt.GroupBy = append([]string(nil), statement.GroupBy...)
+		// each metric search is an independent request, cannot reuse request id
+		metricMgr := *mgr
+		metricMgr.RequestID = ""
+		// result sets are keyed by metric name, cannot stream series of each metric
+		metricMgr.SeriesStream = nil
+		metricRS, err := MetricDataSearch(ctx, param, &metricStmt, &metricMgr)
+		if err != nil {
+			return nil, fmt.Errorf("%w, metric: %s", err, metricName)
+		}
+		rs[metricName] = metricRS
+	}
+	return rs, nil
+}
+
+// expandGroupByAll expands group by * into all tag keys of metric via tag key metadata,
+// so that the physical plan carries concrete tag keys, if metric has no tags collapses to single group.
+func expandGroupByAll(ctx context.Context,
+	param *models.ExecuteParam, statement *stmtpkg.Query,
+	mgr *SearchMgr,
+) error {
+	// tag key search is an independent request, cannot reuse request id of data search
+	metadataMgr := *mgr
+	metadataMgr.RequestID = ""
+	rs, err := metricMetadataSearchFn(ctx, param, &stmtpkg.MetricMetadata{
+		Namespace:  statement.Namespace,
+		MetricName: statement.MetricName,
+		Type:       stmtpkg.TagKey,
+		Limit:      constants.MaxSuggestions,
+	}, &metadataMgr)
+	if err != nil {
+		return err
+	}
+	tagKeys, _ := rs.([]string)
+	groupBy := make(map[string]struct{})
+	for _, tagKey := range statement.GroupBy {
+		groupBy[tagKey] = struct{}{}
+	}
+	tagKeys = strutil.DeDupStringSlice(tagKeys)
+	sort.Strings(tagKeys)
+	for _, tagKey := range tagKeys {
+		if _, ok := groupBy[tagKey]; !ok {
+			statement.GroupBy = append(statement.GroupBy, tagKey)
+		}
+	}
+	maxGroupByTags := mgr.MaxGroupByTags
+	if maxGroupByTags <= 0 {
+		maxGroupByTags = constants.MaxGroupByTagKeys
+	}
+	if len(statement.GroupBy) > maxGroupByTags {
+		return fmt.Errorf("%w, tag keys: %d, max: %d", constants.ErrTooManyGroupByTagKeys, len(statement.GroupBy), maxGroupByTags)
+	}
+	return nil
+}
+
+// expandSelectAll expands select * into all visible fields of metric via field metadata,
+// each field is selected by the default down sampling function of its type and keeps field name as alias,
+// quantile functions are appended if metric has histogram buckets.
+func expandSelectAll(ctx context.Context,
+	param *models.ExecuteParam, statement *stmtpkg.Query,
+	mgr *SearchMgr,
+) error {
+	// field search is an independent request, cannot reuse request id of data search
+	metadataMgr := *mgr
+	metadataMgr.RequestID = ""
+	rs, err := metricMetadataSearchFn(ctx, param, &stmtpkg.MetricMetadata{
+		Namespace:  statement.Namespace,
+		MetricName: statement.MetricName,
+		Type:       stmtpkg.Field,
+		Limit:      constants.MaxSuggestions,
+	}, &metadataMgr)
+	if err != nil {
+		return err
+	}
+	values, _ := rs.([]string)
+	var (
+		fieldNames   []field.Name
+		fieldTypes   = make(map[field.Name]field.Type)
+		hasHistogram bool
+	)
+	for _, value := range values {
+		fields := field.Metas{}
+		if err := encoding.JSONUnmarshal([]byte(value), &fields); err != nil {
+			return err
+		}
+		for _, f := range fields {
+			if f.Type == field.HistogramField || f.Type == field.ExponentialHistogramField {
+				// bucket fields are not visible, selected by quantile functions
+				hasHistogram = true
+				continue
+			}
+			fieldType, ok := fieldTypes[f.Name]
+			if !ok {
+				fieldNames = append(fieldNames, f.Name)
+				fieldTypes[f.Name] = f.Type
+				continue
+			}
+			// same as field metadata result set, uses the min type if types conflict between storage nodes
+			if f.Type < fieldType {
+				fieldTypes[f.Name] = f.Type
+			}
+		}
+	}
+	if len(fieldNames) == 0 {
+		return fmt.Errorf("%w, metric: %s", constants.ErrNoFieldsFound, statement.MetricName)
+	}
+	if len(fieldNames) > constants.MaxSelectAllFields {
+		return fmt.Errorf("%w, fields: %d, max: %d", constants.ErrTooManySelectFields, len(fieldNames), constants.MaxSelectAllFields)
+	}
+	sort.Slice(fieldNames, func(i, j int) bool {
+		return fieldNames[i] < fieldNames[j]
+	})
+	selectItems := make([]stmtpkg.Expr, 0, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		selectItems = append(selectItems, &stmtpkg.SelectItem{
+			Expr: &stmtpkg.CallExpr{
+				FuncType: fieldTypes[fieldName].DownSamplingFunc(),
+				Params:   []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: fieldName.String()}},
+			},
+			Alias: fieldName.String(),
+		})
+	}
+	if hasHistogram {
+		for _, quantile := range []struct {
+			alias string
+			num   float64
+		}{{"p99", 0.99}, {"p95", 0.95}, {"p90", 0.90}, {"mean", 0.50}} {
+			selectItems = append(selectItems, &stmtpkg.SelectItem{
+				Expr:  &stmtpkg.CallExpr{FuncType: function.Quantile, Params: []stmtpkg.Expr{&stmtpkg.NumberLiteral{Val: quantile.num}}},
+				Alias: quantile.alias,
+			})
+		}
+	}
+	statement.SelectItems = selectItems
+	statement.AllFields = false
+	return nil
+}
+
 // exec executes the query pipeline.
 func exec(ctx queryctx.TaskContext, req *models.Request, mgr *SearchMgr) (any, error) {
 	if strings.TrimSpace(req.DB) == "" {
@@ -155,60 +349,58 @@ func exec(ctx queryctx.TaskContext, req *models.Request, mgr *SearchMgr) (any, e
 	// cache pipeline
 	GetPipelineManager().AddPipeline(req.RequestID, pipeline)
 	pipeline.Execute(stage.NewPhysicalPlanStage(ctx))
-	return ctx.WaitResponse()
+	rs, err := ctx.WaitResponse()
+	if errors.Is(ctx.Context().Err(), context.Canceled) {
+		// client disconnected, notify target nodes to stop executing
+		mgr.TaskMgr.CancelTask(req.RequestID)
+	}
+	GetSlowQueryRecorder().Record(req, time.Since(time.Unix(0, req.Start)), ctx.FanOut(), ctx.NetPayload())
+	return rs, err
 }
 
 // buildMetadataResultSet builds metric metadata result set.
+// buildTagCardinalityResultSet builds tag cardinality result set, which is sorted by tag key.
+func buildTagCardinalityResultSet(statement *stmtpkg.MetricMetadata, cardinality map[string]*sketch.Distinct) *commonmodels.Metadata {
+	values := make([]models.TagCardinality, 0, len(cardinality))
+	for tagKey, counter := range cardinality {
+		values = append(values, models.TagCardinality{
+			TagKey:        tagKey,
+			Count:         counter.Count(),
+			Exact:         counter.IsExact(),
+			RelativeError: counter.RelativeError(),
+		})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].TagKey < values[j].TagKey
+	})
+	return &commonmodels.Metadata{
+		Type:   statement.Type.String(),
+		Values: values,
+	}
+}
+
 func buildMetadataResultSet(statement *stmtpkg.MetricMetadata, result []string) (*commonmodels.Metadata, error) {
 	values := strutil.DeDupStringSlice(result)
 	sort.Strings(values)
 	switch statement.Type {
 	case stmtpkg.Field:
-		// build field result model
-		result := make(map[field.Name]field.Meta)
-		fields := field.Metas{}
-		for _, value := range values {
-			err := encoding.JSONUnmarshal([]byte(value), &fields)
-			if err != nil {
-				return nil, err
-			}
-			for _, f := range fields {
-				result[f.Name] = f
-			}
+		resultFields, err := buildFieldResultSet(values)
+		if err != nil {
+			return nil, err
 		}
-		// HistogramSum(sum), HistogramCount(sum), HistogramMin(min), HistogramMax(max) is visible
-		// __bucket_{id}(HistogramField) is not visible for api,
-		// underlying histogram data is only restricted access by user via quantile function
-		// furthermore, we suggest some quantile functions for user in field names, such as quantile(0.99)
-		var (
-			resultFields []commonmodels.Field
-			hasHistogram bool
-		)
-		for _, f := range result {
-			if f.Type != field.HistogramField {
-				resultFields = append(resultFields, commonmodels.Field{
-					Name: string(f.Name),
-					Type: f.Type.String(),
-				})
-			} else {
-				hasHistogram = true
-			}
-		}
-		//
-		if hasHistogram {
-			resultFields = append(resultFields,
-				commonmodels.Field{Name: "quantile(0.99)", Type: field.HistogramField.String()},
-				commonmodels.Field{Name: "quantile(0.95)", Type: field.HistogramField.String()},
-				commonmodels.Field{Name: "quantile(0.90)", Type: field.HistogramField.String()},
-			)
-		}
-		sort.Slice(resultFields, func(i, j int) bool {
-			return resultFields[i].Name < resultFields[j].Name
-		})
 		return &commonmodels.Metadata{
 			Type:   statement.Type.String(),
 			Values: resultFields,
 		}, nil
+	case stmtpkg.Metric:
+		// union of metrics from all storage nodes maybe exceeds the limit
+		if statement.Limit > 0 && len(values) > statement.Limit {
+			values = values[:statement.Limit]
+		}
+		return &commonmodels.Metadata{
+			Type:   statement.Type.String(),
+			Values: values,
+		}, nil
 	default:
 		return &commonmodels.Metadata{
 			Type:   statement.Type.String(),
@@ -216,3 +408,96 @@ func buildMetadataResultSet(statement *stmtpkg.MetricMetadata, result []string)
 		}, nil
 	}
 }
+
+// buildFieldResultSet merges field metas from all storage nodes(union of field sets), which is sorted by field name,
+// if field has different types on storage nodes, marks it as type conflict.
+func buildFieldResultSet(values []string) ([]models.Field, error) {
+	var (
+		fieldNames   []field.Name
+		fieldTypes   = make(map[field.Name][]field.Type)
+		hasHistogram bool
+	)
+	for _, value := range values {
+		fields := field.Metas{}
+		if err := encoding.JSONUnmarshal([]byte(value), &fields); err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			// HistogramSum(sum), HistogramCount(sum), HistogramMin(min), HistogramMax(max) is visible
+			// __bucket_{id}(HistogramField)/__sketch_{index}(ExponentialHistogramField) is not visible for api,
+			// underlying histogram data is only restricted access by user via quantile function
+			if f.Type == field.HistogramField || f.Type == field.ExponentialHistogramField {
+				hasHistogram = true
+				continue
+			}
+			types, ok := fieldTypes[f.Name]
+			if !ok {
+				fieldNames = append(fieldNames, f.Name)
+			}
+			if !containsFieldType(types, f.Type) {
+				fieldTypes[f.Name] = append(types, f.Type)
+			}
+		}
+	}
+	resultFields := make([]models.Field, 0, len(fieldNames))
+	for _, name := range fieldNames {
+		types := fieldTypes[name]
+		sort.Slice(types, func(i, j int) bool {
+			return types[i] < types[j]
+		})
+		resultField := models.Field{
+			Name:      string(name),
+			Type:      types[0].String(),
+			Functions: supportedFunctions(types),
+			Histogram: hasHistogram && metric.IsHistogramStatsField(name),
+		}
+		if len(types) > 1 {
+			resultField.Conflict = true
+			for _, fieldType := range types {
+				resultField.Types = append(resultField.Types, fieldType.String())
+			}
+		}
+		resultFields = append(resultFields, resultField)
+	}
+	// furthermore, we suggest some quantile functions for user in field names, such as quantile(0.99)
+	if hasHistogram {
+		for _, name := range []string{"quantile(0.99)", "quantile(0.95)", "quantile(0.90)"} {
+			resultFields = append(resultFields, models.Field{
+				Name:      name,
+				Type:      field.HistogramField.String(),
+				Histogram: true,
+			})
+		}
+	}
+	sort.Slice(resultFields, func(i, j int) bool {
+		return resultFields[i].Name < resultFields[j].Name
+	})
+	return resultFields, nil
+}
+
+// containsFieldType checks if field type in types.
+func containsFieldType(types []field.Type, fieldType field.Type) bool {
+	for _, t := range types {
+		if t == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+// supportedFunctions returns the functions supported by all given field types.
+func supportedFunctions(types []field.Type) (rs []string) {
+	for funcType := function.Sum; funcType <= function.IRate; funcType++ {
+		supported := true
+		for _, fieldType := range types {
+			if !fieldType.IsFuncSupported(funcType) {
+				supported = false
+				break
+			}
+		}
+		if supported {
+			rs = append(rs, funcType.String())
+		}
+	}
+	return rs
+}
@@ -19,6 +19,8 @@ package query
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -26,7 +28,10 @@ import (
 
 	"github.com/lindb/common/pkg/encoding"
 
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/sketch"
 	trackerpkg "github.com/lindb/lindb/query/tracker"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/sql/stmt"
@@ -85,3 +90,295 @@ func TestBuildMetadataResultSet(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, rs)
 }
+
+func TestBuildMetadataResultSet_Metrics(t *testing.T) {
+	rs, err := buildMetadataResultSet(&stmt.MetricMetadata{Type: stmt.Metric}, []string{"mem", "cpu", "mem"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "mem"}, rs.Values)
+
+	rs, err = buildMetadataResultSet(&stmt.MetricMetadata{Type: stmt.Metric, Limit: 2}, []string{"mem", "cpu", "disk", "mem"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "disk"}, rs.Values)
+}
+
+func TestBuildMetadataResultSet_Fields(t *testing.T) {
+	rs, err := buildMetadataResultSet(
+		&stmt.MetricMetadata{Type: stmt.Field},
+		[]string{
+			string(encoding.JSONMarshal(&field.Metas{
+				{Name: "usage", Type: field.SumField},
+				{Name: "load", Type: field.LastField},
+				{Name: "HistogramSum", Type: field.SumField},
+				{Name: "__bucket_1", Type: field.HistogramField},
+				{Name: "__sketch_1", Type: field.ExponentialHistogramField},
+			})),
+			string(encoding.JSONMarshal(&field.Metas{
+				{Name: "usage", Type: field.SumField},
+				{Name: "load", Type: field.MaxField},
+				{Name: "idle", Type: field.MinField},
+			})),
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "field", rs.Type)
+	values := rs.Values.([]models.Field)
+	var names []string
+	for _, f := range values {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"HistogramSum", "idle", "load", "quantile(0.90)", "quantile(0.95)", "quantile(0.99)", "usage"}, names)
+
+	assert.Equal(t, models.Field{
+		Name:      "HistogramSum",
+		Type:      "sum",
+		Functions: []string{"sum", "min", "max", "rate", "count_if", "sum_if", "derivative", "irate"},
+		Histogram: true,
+	}, values[0])
+	assert.Equal(t, models.Field{
+		Name:      "idle",
+		Type:      "min",
+		Functions: []string{"min", "count_if", "sum_if"},
+	}, values[1])
+	// conflict type, only returns functions supported by all types
+	assert.Equal(t, models.Field{
+		Name:      "load",
+		Type:      "max",
+		Functions: []string{"max", "count_if", "sum_if"},
+		Conflict:  true,
+		Types:     []string{"max", "last"},
+	}, values[2])
+	assert.True(t, values[3].Histogram)
+	assert.Equal(t, "histogram", values[3].Type)
+	assert.False(t, values[6].Conflict)
+	assert.False(t, values[6].Histogram)
+}
+
+func TestBuildTagCardinalityResultSet(t *testing.T) {
+	host, _ := sketch.NewDistinct(4)
+	host.Add("a")
+	host.Add("b")
+	ip, _ := sketch.NewDistinct(4)
+	for i := 0; i < sketch.DefaultExactThreshold+1; i++ {
+		ip.Add(strconv.Itoa(i))
+	}
+	rs := buildTagCardinalityResultSet(&stmt.MetricMetadata{Type: stmt.TagCardinality},
+		map[string]*sketch.Distinct{"ip": ip, "host": host})
+	assert.Equal(t, "tagCardinality", rs.Type)
+	values := rs.Values.([]models.TagCardinality)
+	assert.Equal(t, models.TagCardinality{TagKey: "host", Count: 2, Exact: true}, values[0])
+	assert.Equal(t, "ip", values[1].TagKey)
+	assert.False(t, values[1].Exact)
+	assert.Equal(t, sketch.RelativeError(4), values[1].RelativeError)
+}
+
+func TestExpandGroupByAll(t *testing.T) {
+	defer func() {
+		metricMetadataSearchFn = MetricMetadataSearch
+	}()
+	mgr := &SearchMgr{RequestID: "xxxx-1bc"}
+	// mock tag key metadata
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		statement *stmt.MetricMetadata, mgr *SearchMgr) (any, error) {
+		assert.Equal(t, stmt.TagKey, statement.Type)
+		assert.Equal(t, "cpu", statement.MetricName)
+		assert.Empty(t, mgr.RequestID)
+		return []string{"ip", "region", "host", "ip"}, nil
+	}
+	q := &stmt.Query{MetricName: "cpu", GroupBy: []string{"ip"}, GroupByAll: true}
+	assert.NoError(t, expandGroupByAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, mgr))
+	assert.Equal(t, []string{"ip", "host", "region"}, q.GroupBy)
+	assert.True(t, q.HasGroupBy())
+	assert.Equal(t, "xxxx-1bc", mgr.RequestID)
+
+	// metric without tags, collapses to single group
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return []string{}, nil
+	}
+	q = &stmt.Query{MetricName: "cpu", GroupByAll: true}
+	assert.NoError(t, expandGroupByAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, mgr))
+	assert.False(t, q.HasGroupBy())
+
+	// too many group by tag keys
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		var tagKeys []string
+		for i := 0; i <= constants.MaxGroupByTagKeys; i++ {
+			tagKeys = append(tagKeys, fmt.Sprintf("key-%d", i))
+		}
+		return tagKeys, nil
+	}
+	q = &stmt.Query{MetricName: "cpu", GroupByAll: true}
+	err := expandGroupByAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, mgr)
+	assert.ErrorIs(t, err, constants.ErrTooManyGroupByTagKeys)
+
+	// limit of group by tag keys can be configured
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return []string{"ip", "region", "host"}, nil
+	}
+	q = &stmt.Query{MetricName: "cpu", GroupByAll: true}
+	err = expandGroupByAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, &SearchMgr{MaxGroupByTags: 2})
+	assert.ErrorIs(t, err, constants.ErrTooManyGroupByTagKeys)
+	q = &stmt.Query{MetricName: "cpu", GroupByAll: true}
+	err = expandGroupByAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, &SearchMgr{MaxGroupByTags: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"host", "ip", "region"}, q.GroupBy)
+
+	// search tag keys failure
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return nil, fmt.Errorf("err")
+	}
+	rs, err := MetricDataSearch(context.TODO(), &models.ExecuteParam{Database: "test"},
+		&stmt.Query{MetricName: "cpu", GroupByAll: true}, mgr)
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+}
+
+func TestExpandSelectAll(t *testing.T) {
+	defer func() {
+		metricMetadataSearchFn = MetricMetadataSearch
+	}()
+	mgr := &SearchMgr{RequestID: "xxxx-1bc"}
+	selectItem := func(funcType function.FuncType, fieldName string) stmt.Expr {
+		return &stmt.SelectItem{
+			Expr:  &stmt.CallExpr{FuncType: funcType, Params: []stmt.Expr{&stmt.FieldExpr{Name: fieldName}}},
+			Alias: fieldName,
+		}
+	}
+	// mock field metadata with mixed field types
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		statement *stmt.MetricMetadata, mgr *SearchMgr) (any, error) {
+		assert.Equal(t, stmt.Field, statement.Type)
+		assert.Equal(t, "cpu", statement.MetricName)
+		assert.Empty(t, mgr.RequestID)
+		return []string{
+			string(encoding.JSONMarshal(&field.Metas{
+				{Name: "usage", Type: field.SumField},
+				{Name: "load", Type: field.LastField},
+				{Name: "idle", Type: field.MinField},
+			})),
+			string(encoding.JSONMarshal(&field.Metas{
+				{Name: "usage", Type: field.SumField},
+				{Name: "load", Type: field.MaxField},
+				{Name: "peak", Type: field.MaxField},
+				{Name: "first", Type: field.FirstField},
+			})),
+		}, nil
+	}
+	q := &stmt.Query{MetricName: "cpu", AllFields: true, GroupBy: []string{"host"}}
+	assert.NoError(t, expandSelectAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, mgr))
+	assert.False(t, q.AllFields)
+	assert.Equal(t, []stmt.Expr{
+		selectItem(function.First, "first"),
+		selectItem(function.Min, "idle"),
+		selectItem(function.Max, "load"),
+		selectItem(function.Max, "peak"),
+		selectItem(function.Sum, "usage"),
+	}, q.SelectItems)
+	assert.Equal(t, "xxxx-1bc", mgr.RequestID)
+
+	// histogram buckets are selected by quantile functions
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return []string{string(encoding.JSONMarshal(&field.Metas{
+			{Name: "HistogramSum", Type: field.SumField},
+			{Name: "__bucket_1", Type: field.HistogramField},
+		}))}, nil
+	}
+	q = &stmt.Query{MetricName: "cpu", AllFields: true}
+	assert.NoError(t, expandSelectAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, mgr))
+	assert.Len(t, q.SelectItems, 5)
+	assert.Equal(t, selectItem(function.Sum, "HistogramSum"), q.SelectItems[0])
+	assert.Equal(t, &stmt.SelectItem{
+		Expr:  &stmt.CallExpr{FuncType: function.Quantile, Params: []stmt.Expr{&stmt.NumberLiteral{Val: 0.99}}},
+		Alias: "p99",
+	}, q.SelectItems[1])
+
+	// metric without fields
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return []string{}, nil
+	}
+	q = &stmt.Query{MetricName: "cpu", AllFields: true}
+	err := expandSelectAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, mgr)
+	assert.ErrorIs(t, err, constants.ErrNoFieldsFound)
+
+	// too many fields
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		fields := field.Metas{}
+		for i := 0; i <= constants.MaxSelectAllFields; i++ {
+			fields = append(fields, field.Meta{Name: field.Name(fmt.Sprintf("f-%d", i)), Type: field.SumField})
+		}
+		return []string{string(encoding.JSONMarshal(&fields))}, nil
+	}
+	q = &stmt.Query{MetricName: "cpu", AllFields: true}
+	err = expandSelectAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, mgr)
+	assert.ErrorIs(t, err, constants.ErrTooManySelectFields)
+
+	// unmarshal field metadata failure
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return []string{"abc"}, nil
+	}
+	q = &stmt.Query{MetricName: "cpu", AllFields: true}
+	assert.Error(t, expandSelectAll(context.TODO(), &models.ExecuteParam{Database: "test"}, q, mgr))
+
+	// search fields failure
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return nil, fmt.Errorf("err")
+	}
+	rs, err := MetricDataSearch(context.TODO(), &models.ExecuteParam{Database: "test"},
+		&stmt.Query{MetricName: "cpu", AllFields: true}, mgr)
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+}
+
+func TestMultiMetricDataSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newExecutePipelineFn = NewExecutePipeline
+		metricMetadataSearchFn = MetricMetadataSearch
+		ctrl.Finish()
+	}()
+
+	pipeline := NewMockPipeline(ctrl)
+	newExecutePipelineFn = func(_ *trackerpkg.StageTracker,
+		completeCallback func(err error)) Pipeline {
+		completeCallback(nil) // just mock invoke
+		return pipeline
+	}
+	pipeline.EXPECT().Execute(gomock.Any()).Times(2)
+	taskMgr := NewMockTaskManager(ctrl)
+	var requestIDs []string
+	taskMgr.EXPECT().AddTask(gomock.Any(), gomock.Any()).Do(func(requestID string, _ any) {
+		requestIDs = append(requestIDs, requestID)
+	}).Times(2)
+	taskMgr.EXPECT().RemoveTask(gomock.Any()).Times(2)
+	q := &stmt.Query{MetricName: "cpu", MetricNames: []string{"cpu", "mem", "cpu"}}
+	rs, err := MetricDataSearch(context.TODO(), &models.ExecuteParam{Database: "test"}, q, &SearchMgr{
+		RequestID: "xxxx-1bc",
+		TaskMgr:   taskMgr,
+	})
+	assert.NoError(t, err)
+	results := rs.(map[string]any)
+	assert.Len(t, results, 2)
+	assert.Contains(t, results, "cpu")
+	assert.Contains(t, results, "mem")
+	assert.Len(t, requestIDs, 2)
+	assert.NotEqual(t, requestIDs[0], requestIDs[1])
+	assert.NotContains(t, requestIDs, "xxxx-1bc")
+
+	// search metric failure
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return nil, fmt.Errorf("err")
+	}
+	q = &stmt.Query{MetricName: "cpu", MetricNames: []string{"cpu", "mem"}, GroupByAll: true}
+	rs, err = MetricDataSearch(context.TODO(), &models.ExecuteParam{Database: "test"}, q, &SearchMgr{TaskMgr: taskMgr})
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+}
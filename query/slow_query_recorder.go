@@ -0,0 +1,124 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"sort"
+	"sync"
+	stdatomic "sync/atomic"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/lindb/lindb/models"
+)
+
+const (
+	// defaultSlowQueryCapacity represents the number of latest slow queries kept in ring buffer.
+	defaultSlowQueryCapacity = 128
+	// defaultSlowQueryThreshold represents the default threshold of slow query.
+	defaultSlowQueryThreshold = 30 * time.Second
+)
+
+var (
+	sqRecorder             SlowQueryRecorder
+	once4SlowQueryRecorder sync.Once
+)
+
+// SlowQueryRecorder represents the recorder which keeps the latest slow queries of current node.
+type SlowQueryRecorder interface {
+	// Threshold returns the threshold of slow query.
+	Threshold() time.Duration
+	// SetThreshold sets the threshold of slow query at runtime.
+	SetThreshold(threshold time.Duration)
+	// Record records the query if its total cost exceeds the threshold.
+	Record(req *models.Request, cost time.Duration, fanOut int, netPayload int64)
+	// GetSlowQueries returns the latest slow queries sorted by duration desc.
+	GetSlowQueries() []*models.SlowQuery
+}
+
+// GetSlowQueryRecorder returns a singleton SlowQueryRecorder instance.
+func GetSlowQueryRecorder() SlowQueryRecorder {
+	if sqRecorder != nil {
+		return sqRecorder
+	}
+	once4SlowQueryRecorder.Do(func() {
+		sqRecorder = newSlowQueryRecorder(defaultSlowQueryCapacity, defaultSlowQueryThreshold)
+	})
+	return sqRecorder
+}
+
+// slowQueryRecorder implements SlowQueryRecorder interface.
+// NOTE: recording is lock free(ring buffer with atomic cursor), so that it doesn't slow the query path,
+// when slots are overwritten concurrently, only the latest one is kept.
+type slowQueryRecorder struct {
+	slots     []stdatomic.Value // *models.SlowQuery
+	cursor    atomic.Uint64
+	threshold atomic.Duration
+}
+
+// newSlowQueryRecorder creates a SlowQueryRecorder instance.
+func newSlowQueryRecorder(capacity int, threshold time.Duration) SlowQueryRecorder {
+	r := &slowQueryRecorder{
+		slots: make([]stdatomic.Value, capacity),
+	}
+	r.threshold.Store(threshold)
+	return r
+}
+
+// Threshold returns the threshold of slow query.
+func (r *slowQueryRecorder) Threshold() time.Duration {
+	return r.threshold.Load()
+}
+
+// SetThreshold sets the threshold of slow query at runtime.
+func (r *slowQueryRecorder) SetThreshold(threshold time.Duration) {
+	r.threshold.Store(threshold)
+}
+
+// Record records the query if its total cost exceeds the threshold.
+func (r *slowQueryRecorder) Record(req *models.Request, cost time.Duration, fanOut int, netPayload int64) {
+	if cost < r.threshold.Load() {
+		return
+	}
+	idx := r.cursor.Inc() - 1
+	r.slots[idx%uint64(len(r.slots))].Store(&models.SlowQuery{
+		Entry:      req.Entry,
+		RequestID:  req.RequestID,
+		DB:         req.DB,
+		SQL:        req.SQL,
+		Start:      req.Start,
+		Duration:   cost.Nanoseconds(),
+		FanOut:     fanOut,
+		NetPayload: netPayload,
+	})
+}
+
+// GetSlowQueries returns the latest slow queries sorted by duration desc.
+func (r *slowQueryRecorder) GetSlowQueries() []*models.SlowQuery {
+	rs := make([]*models.SlowQuery, 0, len(r.slots))
+	for idx := range r.slots {
+		if q, ok := r.slots[idx].Load().(*models.SlowQuery); ok {
+			rs = append(rs, q)
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].Duration > rs[j].Duration
+	})
+	return rs
+}
@@ -0,0 +1,80 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+)
+
+func TestGetSlowQueryRecorder(t *testing.T) {
+	assert.NotNil(t, GetSlowQueryRecorder())
+	assert.NotNil(t, GetSlowQueryRecorder())
+}
+
+func TestSlowQueryRecorder_Threshold(t *testing.T) {
+	r := newSlowQueryRecorder(4, time.Second)
+	assert.Equal(t, time.Second, r.Threshold())
+	r.SetThreshold(time.Minute)
+	assert.Equal(t, time.Minute, r.Threshold())
+}
+
+func TestSlowQueryRecorder_Record(t *testing.T) {
+	r := newSlowQueryRecorder(3, time.Second)
+	assert.Empty(t, r.GetSlowQueries())
+
+	// fast query ignored
+	r.Record(&models.Request{SQL: "fast"}, time.Millisecond, 1, 10)
+	assert.Empty(t, r.GetSlowQueries())
+
+	for i := 1; i <= 4; i++ {
+		r.Record(&models.Request{SQL: fmt.Sprintf("sql-%d", i), DB: "db"}, time.Duration(i)*time.Second, i, int64(i*10))
+	}
+	rs := r.GetSlowQueries()
+	// keep the latest 3 slow queries, sorted by duration desc
+	assert.Len(t, rs, 3)
+	assert.Equal(t, "sql-4", rs[0].SQL)
+	assert.Equal(t, (4 * time.Second).Nanoseconds(), rs[0].Duration)
+	assert.Equal(t, 4, rs[0].FanOut)
+	assert.Equal(t, int64(40), rs[0].NetPayload)
+	assert.Equal(t, "sql-3", rs[1].SQL)
+	assert.Equal(t, "sql-2", rs[2].SQL)
+}
+
+func TestSlowQueryRecorder_Record_Concurrent(t *testing.T) {
+	r := newSlowQueryRecorder(8, time.Second)
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.Record(&models.Request{SQL: "sql"}, 2*time.Second, 1, 1)
+				_ = r.GetSlowQueries()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, r.GetSlowQueries(), 8)
+}
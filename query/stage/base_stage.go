@@ -63,9 +63,9 @@ func (stage *baseStage) Execute(node PlanNode, completeHandle func(), errHandle
 		}
 	}
 	if stage.IsAsync() {
-		stage.execPool.Submit(stage.ctx, concurrent.NewTask(func() {
+		stage.execPool.SubmitWithPriority(stage.ctx, concurrent.NewTask(func() {
 			execFn()
-		}, errHandle))
+		}, errHandle), concurrent.PriorityHigh)
 	} else {
 		execFn()
 	}
@@ -20,7 +20,9 @@ package stage
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +31,8 @@ import (
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/internal/concurrent"
+	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/metrics"
 )
 
 type mockPool struct {
@@ -37,6 +41,11 @@ type mockPool struct {
 func (p *mockPool) Submit(_ context.Context, task *concurrent.Task) {
 	task.Exec()
 }
+func (p *mockPool) SubmitWithPriority(_ context.Context, task *concurrent.Task, _ concurrent.Priority) {
+	task.Exec()
+}
+func (p *mockPool) Resize(_, _ int) {
+}
 func (p *mockPool) Stopped() bool {
 	return false
 }
@@ -169,3 +178,51 @@ func TestBaseStage_Track(t *testing.T) {
 	assert.NotNil(t, s.Stats())
 	assert.True(t, s.IsAsync())
 }
+
+func TestBaseStage_Execute_Priority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool := concurrent.NewPool("stage_priority", 1, time.Second,
+		metrics.NewConcurrentStatistics("stage_priority", linmetric.BrokerRegistry))
+	defer pool.Stop()
+
+	var (
+		mutex  sync.Mutex
+		result []string
+		wait   sync.WaitGroup
+	)
+	record := func(name string) {
+		mutex.Lock()
+		result = append(result, name)
+		mutex.Unlock()
+		wait.Done()
+	}
+	// saturate the pool
+	started := make(chan struct{})
+	release := make(chan struct{})
+	pool.Submit(context.TODO(), concurrent.NewTask(func() {
+		close(started)
+		<-release
+	}, nil))
+	<-started
+	// background task queued before query stage
+	wait.Add(2)
+	pool.Submit(context.TODO(), concurrent.NewTask(func() {
+		record("background")
+	}, nil))
+	s := &baseStage{
+		ctx:       context.TODO(),
+		stageType: Grouping,
+		execPool:  pool,
+	}
+	p := NewMockPlanNode(ctrl)
+	p.EXPECT().ExecuteWithStats().Return(&models.OperatorStats{}, nil)
+	p.EXPECT().Children().Return(nil)
+	s.Execute(p, func() {
+		record("query")
+	}, nil)
+	close(release)
+	wait.Wait()
+	assert.Equal(t, []string{"query", "background"}, result)
+}
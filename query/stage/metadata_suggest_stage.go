@@ -51,6 +51,8 @@ func (stage *metadataSuggestStage) Plan() PlanNode {
 		return NewPlanNode(operator.NewMetricSuggest(stage.ctx))
 	case stmt.TagKey:
 		return NewPlanNode(operator.NewTagKeySuggest(stage.ctx))
+	case stmt.TagCardinality:
+		return NewPlanNode(operator.NewTagCardinalityCollect(stage.ctx))
 	case stmt.Field:
 		return NewPlanNode(operator.NewFieldSuggest(stage.ctx))
 	case stmt.TagValue:
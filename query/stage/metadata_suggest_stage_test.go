@@ -57,6 +57,10 @@ func TestMetadataSuggestStage_Plan(t *testing.T) {
 			name: "tag key suggest",
 			in:   &stmtpkg.MetricMetadata{Type: stmtpkg.TagKey},
 		},
+		{
+			name: "tag cardinality collect",
+			in:   &stmtpkg.MetricMetadata{Type: stmtpkg.TagCardinality},
+		},
 		{
 			name: "field suggest",
 			in:   &stmtpkg.MetricMetadata{Type: stmtpkg.Field},
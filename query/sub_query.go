@@ -0,0 +1,180 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	commonmodels "github.com/lindb/common/models"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/tag"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+// subQueryDataSearch searches the sub query as independent query,
+// then aggregates the grouped series of sub query by outer query in broker.
+func subQueryDataSearch(ctx context.Context,
+	param *models.ExecuteParam, statement *stmtpkg.Query,
+	mgr *SearchMgr,
+) (any, error) {
+	// sub query search is an independent request, cannot reuse request id
+	subQueryMgr := *mgr
+	subQueryMgr.RequestID = ""
+	// outer query aggregates all series of sub query, cannot stream series of sub query
+	subQueryMgr.SeriesStream = nil
+	rs, err := MetricDataSearch(ctx, param, statement.SubQuery, &subQueryMgr)
+	if err != nil {
+		return nil, fmt.Errorf("%w, sub query: %s", err, statement.SubQuery.MetricName)
+	}
+	subQueryRS, ok := rs.(*models.ResultSet)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result set of sub query: %T", rs)
+	}
+	return aggregateSubQuery(statement, subQueryRS), nil
+}
+
+// aggregateSubQuery groups series of sub query by group by tag keys of outer query,
+// then aggregates the points of same timestamp in each group by select items of outer query.
+func aggregateSubQuery(statement *stmtpkg.Query, subQueryRS *models.ResultSet) *models.ResultSet {
+	resultSet := commonmodels.NewResultSet()
+	rs := &models.ResultSet{
+		ResultSet: resultSet,
+		Coverage:  subQueryRS.Coverage,
+	}
+	if subQueryRS.ResultSet == nil {
+		return rs
+	}
+	resultSet.MetricName = subQueryRS.MetricName
+	resultSet.GroupBy = statement.GroupBy
+	resultSet.StartTime = subQueryRS.StartTime
+	resultSet.EndTime = subQueryRS.EndTime
+	resultSet.Interval = subQueryRS.Interval
+
+	// group series of sub query
+	groups := make(map[string][]*commonmodels.Series)
+	for _, series := range subQueryRS.Series {
+		tagValues := make([]string, len(statement.GroupBy))
+		for idx, tagKey := range statement.GroupBy {
+			tagValues[idx] = series.Tags[tagKey]
+		}
+		tags := tag.ConcatTagValues(tagValues)
+		groups[tags] = append(groups[tags], series)
+	}
+
+	for tags, seriesList := range groups {
+		var tagsMap map[string]string
+		if len(statement.GroupBy) > 0 {
+			tagsMap = make(map[string]string)
+			for idx, tagValue := range tag.SplitTagValues(tags) {
+				tagsMap[statement.GroupBy[idx]] = tagValue
+			}
+		}
+		timeSeries := commonmodels.NewSeries(tagsMap, tags)
+		for _, item := range statement.SelectItems {
+			fieldName, call := subQuerySelectItem(item)
+			points := aggregateSubQueryPoints(call, seriesList)
+			if len(points.Points) == 0 {
+				continue
+			}
+			timeSeries.AddField(fieldName, points)
+		}
+		resultSet.AddSeries(timeSeries)
+	}
+	sort.Slice(resultSet.Series, func(i, j int) bool {
+		return resultSet.Series[i].TagValues < resultSet.Series[j].TagValues
+	})
+	if offset := statement.Offset; offset > 0 {
+		if offset > len(resultSet.Series) {
+			offset = len(resultSet.Series)
+		}
+		resultSet.Series = resultSet.Series[offset:]
+	}
+	if limit := statement.Limit; limit > 0 && limit < len(resultSet.Series) {
+		resultSet.Series = resultSet.Series[:limit]
+	}
+	for _, item := range statement.SelectItems {
+		fieldName, _ := subQuerySelectItem(item)
+		resultSet.Fields = append(resultSet.Fields, fieldName)
+	}
+	return rs
+}
+
+// subQuerySelectItem returns the field name and aggregate function of outer query's select item,
+// select item has been validated when parsing sql.
+func subQuerySelectItem(item stmtpkg.Expr) (fieldName string, call *stmtpkg.CallExpr) {
+	fieldName = item.Rewrite()
+	expr := item
+	if selectItem, ok := item.(*stmtpkg.SelectItem); ok {
+		expr = selectItem.Expr
+		fieldName = selectItem.Expr.Rewrite()
+		if selectItem.Alias != "" {
+			fieldName = selectItem.Alias
+		}
+	}
+	return fieldName, expr.(*stmtpkg.CallExpr)
+}
+
+// aggregateSubQueryPoints aggregates the points of same timestamp in grouped series.
+func aggregateSubQueryPoints(call *stmtpkg.CallExpr, seriesList []*commonmodels.Series) *commonmodels.Points {
+	field := call.Params[0].(*stmtpkg.FieldExpr).Name
+	values := make(map[int64][]float64)
+	for _, series := range seriesList {
+		for timestamp, value := range series.Fields[field] {
+			values[timestamp] = append(values[timestamp], value)
+		}
+	}
+	points := commonmodels.NewPoints()
+	for timestamp, vs := range values {
+		points.AddPoint(timestamp, aggregateValues(call.FuncType, vs))
+	}
+	return points
+}
+
+// aggregateValues aggregates the values by function type.
+func aggregateValues(funcType function.FuncType, values []float64) float64 {
+	switch funcType {
+	case function.Count:
+		return float64(len(values))
+	case function.Min:
+		min := math.Inf(1)
+		for _, v := range values {
+			min = math.Min(min, v)
+		}
+		return min
+	case function.Max:
+		max := math.Inf(-1)
+		for _, v := range values {
+			max = math.Max(max, v)
+		}
+		return max
+	default:
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		if funcType == function.Avg {
+			return sum / float64(len(values))
+		}
+		return sum
+	}
+}
@@ -0,0 +1,150 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	commonmodels "github.com/lindb/common/models"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/models"
+	trackerpkg "github.com/lindb/lindb/query/tracker"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSubQueryDataSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newExecutePipelineFn = NewExecutePipeline
+		ctrl.Finish()
+	}()
+
+	pipeline := NewMockPipeline(ctrl)
+	newExecutePipelineFn = func(_ *trackerpkg.StageTracker,
+		completeCallback func(err error)) Pipeline {
+		completeCallback(nil) // just mock invoke
+		return pipeline
+	}
+	pipeline.EXPECT().Execute(gomock.Any())
+	taskMgr := NewMockTaskManager(ctrl)
+	var requestIDs []string
+	taskMgr.EXPECT().AddTask(gomock.Any(), gomock.Any()).Do(func(requestID string, _ any) {
+		requestIDs = append(requestIDs, requestID)
+	})
+	taskMgr.EXPECT().RemoveTask(gomock.Any())
+	q := &stmt.Query{
+		SelectItems: []stmt.Expr{&stmt.SelectItem{
+			Expr: &stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "v"}}},
+		}},
+		SubQuery: &stmt.Query{MetricName: "cpu"},
+	}
+	rs, err := MetricDataSearch(context.TODO(), &models.ExecuteParam{Database: "test"}, q, &SearchMgr{
+		RequestID: "xxxx-1bc",
+		TaskMgr:   taskMgr,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"max(v)"}, rs.(*models.ResultSet).Fields)
+	assert.Len(t, requestIDs, 1)
+	assert.NotContains(t, requestIDs, "xxxx-1bc")
+
+	// search sub query failure
+	rs, err = MetricDataSearch(context.TODO(), &models.ExecuteParam{}, q, &SearchMgr{TaskMgr: taskMgr})
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+}
+
+func TestAggregateSubQuery(t *testing.T) {
+	newSeries := func(host, region string, points map[int64]float64) *commonmodels.Series {
+		series := commonmodels.NewSeries(map[string]string{"host": host, "region": region},
+			tag.ConcatTagValues([]string{host, region}))
+		series.Fields["v"] = points
+		return series
+	}
+	subQueryRS := &models.ResultSet{
+		ResultSet: &commonmodels.ResultSet{
+			MetricName: "cpu",
+			GroupBy:    []string{"host", "region"},
+			Fields:     []string{"v"},
+			StartTime:  10,
+			EndTime:    30,
+			Interval:   10,
+			Series: []*commonmodels.Series{
+				newSeries("a", "sh", map[int64]float64{10: 1, 20: 4}),
+				newSeries("b", "sh", map[int64]float64{10: 3}),
+				newSeries("c", "bj", map[int64]float64{10: 5, 20: 6}),
+			},
+		},
+		Coverage: &models.ShardCoverage{Queried: []models.ShardID{1}},
+	}
+	selectItem := func(funcType function.FuncType, alias string) stmt.Expr {
+		return &stmt.SelectItem{
+			Expr:  &stmt.CallExpr{FuncType: funcType, Params: []stmt.Expr{&stmt.FieldExpr{Name: "v"}}},
+			Alias: alias,
+		}
+	}
+	statement := &stmt.Query{
+		SelectItems: []stmt.Expr{
+			selectItem(function.Max, "max"),
+			selectItem(function.Min, "min"),
+			selectItem(function.Sum, "sum"),
+			selectItem(function.Count, "count"),
+			selectItem(function.Avg, ""),
+		},
+		GroupBy: []string{"region"},
+	}
+	rs := aggregateSubQuery(statement, subQueryRS)
+	assert.Equal(t, subQueryRS.Coverage, rs.Coverage)
+	assert.Equal(t, "cpu", rs.MetricName)
+	assert.Equal(t, []string{"region"}, rs.GroupBy)
+	assert.Equal(t, []string{"max", "min", "sum", "count", "avg(v)"}, rs.Fields)
+	assert.Len(t, rs.Series, 2)
+	assert.Equal(t, map[string]string{"region": "bj"}, rs.Series[0].Tags)
+	assert.Equal(t, map[int64]float64{10: 5, 20: 6}, rs.Series[0].Fields["max"])
+	sh := rs.Series[1]
+	assert.Equal(t, map[string]string{"region": "sh"}, sh.Tags)
+	assert.Equal(t, map[int64]float64{10: 3, 20: 4}, sh.Fields["max"])
+	assert.Equal(t, map[int64]float64{10: 1, 20: 4}, sh.Fields["min"])
+	assert.Equal(t, map[int64]float64{10: 4, 20: 4}, sh.Fields["sum"])
+	assert.Equal(t, map[int64]float64{10: 2, 20: 1}, sh.Fields["count"])
+	assert.Equal(t, map[int64]float64{10: 2, 20: 4}, sh.Fields["avg(v)"])
+
+	// without group by, offset and limit
+	rs = aggregateSubQuery(&stmt.Query{SelectItems: []stmt.Expr{selectItem(function.Max, "")}}, subQueryRS)
+	assert.Len(t, rs.Series, 1)
+	assert.Nil(t, rs.Series[0].Tags)
+	assert.Equal(t, map[int64]float64{10: 5, 20: 6}, rs.Series[0].Fields["max(v)"])
+	statement.Offset = 1
+	statement.Limit = 1
+	rs = aggregateSubQuery(statement, subQueryRS)
+	assert.Len(t, rs.Series, 1)
+	assert.Equal(t, map[string]string{"region": "sh"}, rs.Series[0].Tags)
+	statement.Offset = 10
+	rs = aggregateSubQuery(statement, subQueryRS)
+	assert.Empty(t, rs.Series)
+
+	// sub query returns empty result set
+	rs = aggregateSubQuery(statement, &models.ResultSet{})
+	assert.NotNil(t, rs.ResultSet)
+	assert.Empty(t, rs.Series)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/internal/concurrent"
+	"github.com/lindb/lindb/models"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 	"github.com/lindb/lindb/rpc"
 )
@@ -94,14 +95,20 @@ func (q *TaskHandler) Handle(stream protoCommonV1.TaskService_HandleServer) (err
 // process dispatches request with timeout
 func (q *TaskHandler) process(ctx context.Context, stream protoCommonV1.TaskService_HandleServer, req *protoCommonV1.TaskRequest) {
 	taskCtx := flow.NewTaskContextWithTimeout(ctx, q.timeout)
-	q.taskPool.Submit(taskCtx.Ctx,
+	// query task is interactive, run it before background tasks queued in pool
+	q.taskPool.SubmitWithPriority(taskCtx.Ctx,
 		concurrent.NewTask(func() {
-			if err := q.processor.Process(taskCtx, stream, req); err != nil {
+			err := rpc.DecompressTaskRequest(req)
+			if err == nil {
+				err = q.processor.Process(taskCtx, stream, req)
+			}
+			if err != nil {
 				// if process fail, need send response with err
 				if sendError := stream.Send(&protoCommonV1.TaskResponse{
 					RequestID: req.RequestID,
 					Completed: true,
 					ErrMsg:    err.Error(),
+					ErrCode:   string(models.ErrorCodeOf(err)),
 					SendTime:  timeutil.NowNano(),
 				}); sendError != nil {
 					q.logger.Error("failed to send error message to target stream",
@@ -116,6 +123,7 @@ func (q *TaskHandler) process(ctx context.Context, stream protoCommonV1.TaskServ
 				RequestID: req.RequestID,
 				Completed: true,
 				ErrMsg:    err.Error(),
+				ErrCode:   string(models.ErrorCodeOf(err)),
 				SendTime:  timeutil.NowNano(),
 			}); sendError != nil {
 				q.logger.Error("failed to send error message to target stream",
@@ -123,5 +131,5 @@ func (q *TaskHandler) process(ctx context.Context, stream protoCommonV1.TaskServ
 					logger.Error(err),
 				)
 			}
-		}))
+		}), concurrent.PriorityHigh)
 }
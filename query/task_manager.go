@@ -25,6 +25,7 @@ import (
 
 	"github.com/lindb/common/pkg/logger"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/internal/concurrent"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/metrics"
@@ -44,6 +45,8 @@ type TaskManager interface {
 	AddTask(requestID string, taskCtx context.TaskContext)
 	// RemoveTask removes task context by request id.
 	RemoveTask(requestID string)
+	// CancelTask cancels task by request id, notifies all target nodes of task to stop executing, then evicts the task.
+	CancelTask(requestID string)
 }
 
 // taskManager implements the task manager interface, tracks all task of the current node.
@@ -92,6 +95,32 @@ func (mgr *taskManager) RemoveTask(requestID string) {
 	delete(mgr.tasks, requestID)
 }
 
+// CancelTask cancels task by request id, notifies all target nodes of task to stop executing, then evicts the task.
+func (mgr *taskManager) CancelTask(requestID string) {
+	mgr.mutex.Lock()
+	taskCtx, ok := mgr.tasks[requestID]
+	delete(mgr.tasks, requestID)
+	mgr.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+	mgr.statistics.CancelledTasks.Incr()
+	for targetNodeID, req := range taskCtx.GetRequests() {
+		if err := taskCtx.SendRequest(targetNodeID, &protoCommonV1.TaskRequest{
+			RequestID:    req.RequestID,
+			RequestType:  protoCommonV1.RequestType_Cancel,
+			PhysicalPlan: req.PhysicalPlan,
+			PlanEncoding: req.PlanEncoding,
+		}); err != nil {
+			mgr.logger.Warn("send cancel task request failure",
+				logger.String("requestID", requestID),
+				logger.String("target", targetNodeID), logger.Error(err))
+		}
+	}
+	taskCtx.Complete(constants.ErrTaskCancelled)
+}
+
 // Receive receives task response from rpc handler asynchronous.
 func (mgr *taskManager) Receive(resp *protoCommonV1.TaskResponse, fromNode string) error {
 	taskCtx := mgr.get(resp.RequestID)
@@ -100,10 +129,10 @@ func (mgr *taskManager) Receive(resp *protoCommonV1.TaskResponse, fromNode strin
 		return fmt.Errorf("request may be evicted")
 	}
 	mgr.statistics.EmitResponse.Incr()
-	mgr.workerPool.Submit(taskCtx.Context(), concurrent.NewTask(func() {
+	mgr.workerPool.SubmitWithPriority(taskCtx.Context(), concurrent.NewTask(func() {
 		// for root task and intermediate task, handle task response
 		taskCtx.HandleResponse(resp, fromNode)
-	}, nil))
+	}, nil), concurrent.PriorityHigh)
 	return nil
 }
 
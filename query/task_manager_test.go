@@ -19,6 +19,7 @@ package query
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/internal/concurrent"
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/metrics"
@@ -70,3 +72,30 @@ func TestTaskManager_Receive(t *testing.T) {
 	assert.NoError(t, mgr.Receive(&protoCommonV1.TaskResponse{RequestID: "1"}, "test"))
 	wait.Wait()
 }
+
+func TestTaskManager_CancelTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mgr := NewTaskManager(nil, linmetric.BrokerRegistry)
+	// task not exist
+	mgr.CancelTask("1")
+
+	taskCtx := queryctx.NewMockTaskContext(ctrl)
+	mgr.AddTask("1", taskCtx)
+	taskCtx.EXPECT().GetRequests().Return(map[string]*protoCommonV1.TaskRequest{
+		"1.1.1.1:9000": {RequestID: "1"},
+		"1.1.1.2:9000": {RequestID: "1"},
+	})
+	taskCtx.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).DoAndReturn(
+		func(_ string, req *protoCommonV1.TaskRequest) error {
+			assert.Equal(t, protoCommonV1.RequestType_Cancel, req.RequestType)
+			return nil
+		})
+	taskCtx.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).Return(fmt.Errorf("err"))
+	taskCtx.EXPECT().Complete(constants.ErrTaskCancelled)
+	mgr.CancelTask("1")
+	assert.Nil(t, mgr.(*taskManager).get("1"))
+	// cancel again
+	mgr.CancelTask("1")
+}
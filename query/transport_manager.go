@@ -18,9 +18,14 @@
 package query
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lindb/common/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/metrics"
@@ -28,10 +33,44 @@ import (
 	"github.com/lindb/lindb/rpc"
 )
 
+// for testing
+var (
+	afterFn = time.After
+)
+
+// DefaultRetryPolicy represents the default retry policy of sending task request.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 50 * time.Millisecond,
+	MaxBackoff:     500 * time.Millisecond,
+}
+
+// RetryPolicy represents the retry policy of sending task request to target node,
+// for tolerating transient reconnect between nodes.
+type RetryPolicy struct {
+	MaxAttempts    int           // max attempts(including the first one), no retry if <= 1
+	InitialBackoff time.Duration // backoff before the first retry
+	MaxBackoff     time.Duration // max backoff, backoff doubles after each retry
+}
+
+// backoff returns the backoff duration before the retry(starts with 1).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
 // transportManager implments rpc.TransportManager interface.
 type transportManager struct {
 	taskClientFactory rpc.TaskClientFactory
 	taskServerFactory rpc.TaskServerFactory
+	retryPolicy       RetryPolicy
+	breaker           *circuitBreaker
 
 	statistics *metrics.TransportStatistics
 
@@ -42,43 +81,126 @@ type transportManager struct {
 func NewTransportManager(
 	taskClientFactory rpc.TaskClientFactory,
 	taskServerFactory rpc.TaskServerFactory,
+	retryPolicy RetryPolicy,
+	breakerPolicy CircuitBreakerPolicy,
 	registry *linmetric.Registry,
 ) rpc.TransportManager {
 	return &transportManager{
 		taskClientFactory: taskClientFactory,
 		taskServerFactory: taskServerFactory,
+		retryPolicy:       retryPolicy,
+		breaker:           newCircuitBreaker(breakerPolicy),
 		statistics:        metrics.NewTransportStatistics(registry),
 		logger:            logger.GetLogger("Query", "TransportManager"),
 	}
 }
 
-// SendRequest sends the task request to target node.
-func (mgr *transportManager) SendRequest(targetNodeID string, req *protoCommonV1.TaskRequest) error {
+// SendRequest sends the task request to target node,
+// retries with backoff if send stream not found or send failure(except cancelled),
+// stops retrying once the query context is done,
+// fast fails if circuit of target node is open because of consecutive send failures.
+func (mgr *transportManager) SendRequest(ctx context.Context, targetNodeID string, req *protoCommonV1.TaskRequest) (err error) {
+	if !mgr.breaker.allow(targetNodeID) {
+		mgr.statistics.SentRequestFailures.Incr()
+		mgr.statistics.CircuitOpenRequests.Incr()
+		return fmt.Errorf("SendRequest: %w, targetNodeID: %s", ErrCircuitOpen, targetNodeID)
+	}
+	// compress payload if too large, keep the request of task context raw for cancelling/retrying
+	sendReq, rawSize, size := rpc.CompressTaskRequest(req)
+	for attempt := 1; ; attempt++ {
+		err = mgr.sendRequest(targetNodeID, sendReq)
+		if err == nil {
+			mgr.breaker.onSuccess(targetNodeID)
+			mgr.statistics.SentRequest.Incr()
+			mgr.statistics.NetPayload.RawBytes.Add(float64(rawSize))
+			mgr.statistics.NetPayload.NetBytes.Add(float64(size))
+			return nil
+		}
+		mgr.statistics.SentRequestFailures.Incr()
+		if !isRetryable(err) {
+			// cancelled, target node maybe healthy
+			mgr.breaker.abort(targetNodeID)
+			return err
+		}
+		if attempt >= mgr.retryPolicy.MaxAttempts {
+			if attempt > 1 {
+				mgr.statistics.RetryRequestFailures.Incr()
+			}
+			if mgr.breaker.onFailure(targetNodeID) {
+				mgr.logger.Warn("open circuit of target node because of consecutive send failures",
+					logger.String("target", targetNodeID),
+					logger.Error(err))
+			}
+			return err
+		}
+		backoff := mgr.retryPolicy.backoff(attempt)
+		mgr.logger.Warn("send query task failure, retry later",
+			logger.String("target", targetNodeID),
+			logger.Int32("attempt", int32(attempt)),
+			logger.String("backoff", backoff.String()),
+			logger.Error(err))
+		select {
+		case <-ctx.Done():
+			// query cancelled or timeout, stop retrying
+			mgr.breaker.abort(targetNodeID)
+			return ctx.Err()
+		case <-afterFn(backoff):
+		}
+		mgr.statistics.RetryRequests.Incr()
+	}
+}
+
+// sendRequest sends the task request to target node once.
+func (mgr *transportManager) sendRequest(targetNodeID string, req *protoCommonV1.TaskRequest) error {
 	mgr.logger.Debug("send query task", logger.String("target", targetNodeID))
 	client := mgr.taskClientFactory.GetTaskClient(targetNodeID)
 	if client == nil {
-		mgr.statistics.SentRequestFailures.Incr()
 		return fmt.Errorf("SendRequest: %w, targetNodeID: %s", ErrNoSendStream, targetNodeID)
 	}
 	if err := client.Send(req); err != nil {
-		mgr.statistics.SentRequestFailures.Incr()
+		if errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled {
+			return fmt.Errorf("SendRequest: %w, targetNodeID: %s", err, targetNodeID)
+		}
 		return fmt.Errorf("SendRequest: %w, targetNodeID: %s", ErrTaskSend, targetNodeID)
 	}
-	mgr.statistics.SentRequest.Incr()
 	return nil
 }
 
-// SendResponse sends the task response to target node.
+// isRetryable checks if the error of sending request can be retried.
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrNoSendStream) || errors.Is(err, ErrTaskSend)
+}
+
+// SendResponse sends the task response to target node,
+// fast fails if circuit of target node is open because of consecutive send failures.
 func (mgr *transportManager) SendResponse(targetNodeID string, resp *protoCommonV1.TaskResponse) error {
+	if !mgr.breaker.allow(targetNodeID) {
+		mgr.statistics.SentResponseFailures.Incr()
+		mgr.statistics.CircuitOpenResponses.Incr()
+		return fmt.Errorf("SendResponse: %w, parentNodeID: %s", ErrCircuitOpen, targetNodeID)
+	}
+	if err := mgr.sendResponse(targetNodeID, resp); err != nil {
+		mgr.statistics.SentResponseFailures.Incr()
+		if mgr.breaker.onFailure(targetNodeID) {
+			mgr.logger.Warn("open circuit of parent node because of consecutive send failures",
+				logger.String("parent", targetNodeID),
+				logger.Error(err))
+		}
+		return err
+	}
+	mgr.breaker.onSuccess(targetNodeID)
+	mgr.statistics.SentResponses.Incr()
+	return nil
+}
+
+// sendResponse sends the task response to target node once.
+func (mgr *transportManager) sendResponse(targetNodeID string, resp *protoCommonV1.TaskResponse) error {
 	stream := mgr.taskServerFactory.GetStream(targetNodeID)
 	if stream == nil {
-		mgr.statistics.SentResponseFailures.Incr()
 		return fmt.Errorf("SendResponse: %w, parentNodeID: %s", ErrNoSendStream, targetNodeID)
 	}
 	if err := stream.Send(resp); err != nil {
-		mgr.statistics.SentResponseFailures.Incr()
 		return fmt.Errorf("SendResponse: %w, parentNodeID: %s", ErrResponseSend, targetNodeID)
 	}
-	mgr.statistics.SentResponses.Incr()
 	return nil
 }
@@ -18,11 +18,15 @@
 package query
 
 import (
+	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/lindb/lindb/internal/linmetric"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
@@ -35,7 +39,7 @@ func TestTransportManager_SendResponse(t *testing.T) {
 
 	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
 
-	transportMgr := NewTransportManager(nil, taskServerFactory, linmetric.RootRegistry)
+	transportMgr := NewTransportManager(nil, taskServerFactory, RetryPolicy{}, CircuitBreakerPolicy{}, linmetric.RootRegistry)
 
 	// empty stream
 	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(nil)
@@ -52,25 +56,201 @@ func TestTransportManager_SendResponse(t *testing.T) {
 	assert.Nil(t, transportMgr.SendResponse("1", &protoCommonV1.TaskResponse{}))
 }
 
+func TestTransportManager_SendResponse_CircuitBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now()
+	defer func() {
+		nowFn = time.Now
+		ctrl.Finish()
+	}()
+	nowFn = func() time.Time {
+		return now
+	}
+
+	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
+	stream := protoCommonV1.NewMockTaskService_HandleServer(ctrl)
+	transportMgr := NewTransportManager(nil, taskServerFactory, RetryPolicy{}, CircuitBreakerPolicy{
+		FailureThreshold: 2,
+		Cooldown:         10 * time.Second,
+	}, linmetric.RootRegistry)
+
+	// trip breaker after consecutive failures
+	taskServerFactory.EXPECT().GetStream("1").Return(stream).Times(2)
+	stream.EXPECT().Send(gomock.Any()).Return(io.ErrClosedPipe).Times(2)
+	assert.ErrorIs(t, transportMgr.SendResponse("1", &protoCommonV1.TaskResponse{}), ErrResponseSend)
+	assert.ErrorIs(t, transportMgr.SendResponse("1", &protoCommonV1.TaskResponse{}), ErrResponseSend)
+	// fast fail, not send to parent node
+	assert.ErrorIs(t, transportMgr.SendResponse("1", &protoCommonV1.TaskResponse{}), ErrCircuitOpen)
+	// other node not affected
+	taskServerFactory.EXPECT().GetStream("2").Return(stream)
+	stream.EXPECT().Send(gomock.Any()).Return(nil)
+	assert.NoError(t, transportMgr.SendResponse("2", &protoCommonV1.TaskResponse{}))
+
+	// probe failure after cooldown, re-open circuit
+	now = now.Add(10 * time.Second)
+	taskServerFactory.EXPECT().GetStream("1").Return(nil)
+	assert.ErrorIs(t, transportMgr.SendResponse("1", &protoCommonV1.TaskResponse{}), ErrNoSendStream)
+	assert.ErrorIs(t, transportMgr.SendResponse("1", &protoCommonV1.TaskResponse{}), ErrCircuitOpen)
+
+	// recovery after successful probe
+	now = now.Add(10 * time.Second)
+	taskServerFactory.EXPECT().GetStream("1").Return(stream).Times(2)
+	stream.EXPECT().Send(gomock.Any()).Return(nil).Times(2)
+	assert.NoError(t, transportMgr.SendResponse("1", &protoCommonV1.TaskResponse{}))
+	assert.NoError(t, transportMgr.SendResponse("1", &protoCommonV1.TaskResponse{}))
+}
+
 func TestTransportManager_SendRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	taskClientFactory := rpc.NewMockTaskClientFactory(ctrl)
 
-	transportMgr := NewTransportManager(taskClientFactory, nil, linmetric.RootRegistry)
+	transportMgr := NewTransportManager(taskClientFactory, nil, RetryPolicy{}, CircuitBreakerPolicy{}, linmetric.RootRegistry)
 
 	// empty stream
 	taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).Return(nil)
-	assert.Error(t, transportMgr.SendRequest("1", &protoCommonV1.TaskRequest{}))
+	assert.Error(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}))
 
 	// send stream error
 	client := protoCommonV1.NewMockTaskService_HandleClient(ctrl)
 	taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).Return(client).Times(2)
 	client.EXPECT().Send(gomock.Any()).Return(io.ErrClosedPipe)
-	assert.Error(t, transportMgr.SendRequest("1", &protoCommonV1.TaskRequest{}))
+	assert.Error(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}))
 
 	// send ok
 	client.EXPECT().Send(gomock.Any()).Return(nil)
-	assert.Nil(t, transportMgr.SendRequest("1", &protoCommonV1.TaskRequest{}))
+	assert.Nil(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}))
+}
+
+func TestTransportManager_SendRequest_Retry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	var backoffs []time.Duration
+	defer func() {
+		afterFn = time.After
+		ctrl.Finish()
+	}()
+	afterFn = func(d time.Duration) <-chan time.Time {
+		backoffs = append(backoffs, d)
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+
+	taskClientFactory := rpc.NewMockTaskClientFactory(ctrl)
+	client := protoCommonV1.NewMockTaskService_HandleClient(ctrl)
+	transportMgr := NewTransportManager(taskClientFactory, nil, RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     30 * time.Millisecond,
+	}, CircuitBreakerPolicy{}, linmetric.RootRegistry)
+
+	// retry ok
+	gomock.InOrder(
+		taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).Return(nil),
+		taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).Return(client),
+		client.EXPECT().Send(gomock.Any()).Return(io.ErrClosedPipe),
+		taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).Return(client),
+		client.EXPECT().Send(gomock.Any()).Return(nil),
+	)
+	assert.NoError(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}))
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}, backoffs)
+
+	// retry exhausted
+	backoffs = nil
+	taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).Return(nil).Times(4)
+	err := transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{})
+	assert.ErrorIs(t, err, ErrNoSendStream)
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}, backoffs)
+
+	// cancelled, no retry
+	backoffs = nil
+	taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).Return(client).Times(2)
+	client.EXPECT().Send(gomock.Any()).Return(context.Canceled)
+	assert.ErrorIs(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}), context.Canceled)
+	client.EXPECT().Send(gomock.Any()).Return(status.Error(codes.Canceled, "canceled"))
+	assert.Error(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}))
+	assert.Empty(t, backoffs)
+}
+
+func TestTransportManager_SendRequest_ContextDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		afterFn = time.After
+		ctrl.Finish()
+	}()
+	afterFn = func(d time.Duration) <-chan time.Time {
+		// never fires, backoff only ends by context
+		return make(chan time.Time)
+	}
+
+	taskClientFactory := rpc.NewMockTaskClientFactory(ctrl)
+	transportMgr := NewTransportManager(taskClientFactory, nil, RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Hour,
+		MaxBackoff:     time.Hour,
+	}, CircuitBreakerPolicy{
+		FailureThreshold: 1,
+		Cooldown:         time.Hour,
+	}, linmetric.RootRegistry)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	// only first attempt sent, stop retrying after context done
+	taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).Return(nil)
+	assert.ErrorIs(t, transportMgr.SendRequest(ctx, "1", &protoCommonV1.TaskRequest{}), context.DeadlineExceeded)
+
+	// context done not counted as failure of target node
+	client := protoCommonV1.NewMockTaskService_HandleClient(ctrl)
+	taskClientFactory.EXPECT().GetTaskClient(gomock.Any()).Return(client)
+	client.EXPECT().Send(gomock.Any()).Return(nil)
+	assert.NoError(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}))
+}
+
+func TestTransportManager_SendRequest_CircuitBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now()
+	defer func() {
+		nowFn = time.Now
+		ctrl.Finish()
+	}()
+	nowFn = func() time.Time {
+		return now
+	}
+
+	taskClientFactory := rpc.NewMockTaskClientFactory(ctrl)
+	client := protoCommonV1.NewMockTaskService_HandleClient(ctrl)
+	transportMgr := NewTransportManager(taskClientFactory, nil, RetryPolicy{}, CircuitBreakerPolicy{
+		FailureThreshold: 2,
+		Cooldown:         10 * time.Second,
+	}, linmetric.RootRegistry)
+
+	// trip breaker after consecutive failures
+	taskClientFactory.EXPECT().GetTaskClient("1").Return(nil).Times(2)
+	assert.ErrorIs(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}), ErrNoSendStream)
+	assert.ErrorIs(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}), ErrNoSendStream)
+	// fast fail, not send to target node
+	assert.ErrorIs(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}), ErrCircuitOpen)
+	// other node not affected
+	taskClientFactory.EXPECT().GetTaskClient("2").Return(client)
+	client.EXPECT().Send(gomock.Any()).Return(nil)
+	assert.NoError(t, transportMgr.SendRequest(context.TODO(), "2", &protoCommonV1.TaskRequest{}))
+
+	// probe failure after cooldown, re-open circuit
+	now = now.Add(10 * time.Second)
+	taskClientFactory.EXPECT().GetTaskClient("1").Return(nil)
+	assert.ErrorIs(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}), ErrNoSendStream)
+	assert.ErrorIs(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}), ErrCircuitOpen)
+
+	// probe cancelled, next request probes again
+	now = now.Add(10 * time.Second)
+	taskClientFactory.EXPECT().GetTaskClient("1").Return(client)
+	client.EXPECT().Send(gomock.Any()).Return(context.Canceled)
+	assert.ErrorIs(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}), context.Canceled)
+
+	// recovery after successful probe
+	taskClientFactory.EXPECT().GetTaskClient("1").Return(client).Times(2)
+	client.EXPECT().Send(gomock.Any()).Return(nil).Times(2)
+	assert.NoError(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}))
+	assert.NoError(t, transportMgr.SendRequest(context.TODO(), "1", &protoCommonV1.TaskRequest{}))
 }
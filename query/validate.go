@@ -0,0 +1,168 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+// ValidateQuery validates the query statement against metadata without scanning data(dry run),
+// checks if the metrics of query exist, and the fields referenced by select list/field filter exist under metric.
+// Returns error if metadata cannot be searched(database not found, timeout etc.).
+func ValidateQuery(ctx context.Context,
+	param *models.ExecuteParam, statement *stmtpkg.Query,
+	mgr *SearchMgr,
+) (*models.QueryValidation, error) {
+	rs := &models.QueryValidation{Valid: true}
+	if err := validateQuery(ctx, param, statement, mgr, rs); err != nil {
+		return nil, err
+	}
+	if rs.Valid {
+		rs.Plan = statement
+	}
+	return rs, nil
+}
+
+// validateQuery validates the metrics/fields of query, sub query is validated instead of outer query,
+// because outer query selects the fields(alias) of sub query.
+func validateQuery(ctx context.Context,
+	param *models.ExecuteParam, statement *stmtpkg.Query,
+	mgr *SearchMgr, rs *models.QueryValidation,
+) error {
+	if statement.HasSubQuery() {
+		return validateQuery(ctx, param, statement.SubQuery, mgr, rs)
+	}
+	metricNames := statement.MetricNames
+	if len(metricNames) == 0 {
+		metricNames = []string{statement.MetricName}
+	}
+	fieldNames := referencedFields(statement)
+	validated := make(map[string]struct{}, len(metricNames))
+	for _, metricName := range metricNames {
+		if _, ok := validated[metricName]; ok {
+			continue
+		}
+		validated[metricName] = struct{}{}
+		fields, err := searchFields(ctx, param, statement.Namespace, metricName, mgr)
+		if err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			// metric without any field, means metric not exist
+			rs.AddError(&models.QueryValidationError{
+				Code:    models.QueryUnknownMetric,
+				Message: fmt.Sprintf("metric: %s not found", metricName),
+				Metric:  metricName,
+			})
+			continue
+		}
+		for _, fieldName := range fieldNames {
+			if _, ok := fields[fieldName]; !ok {
+				rs.AddError(&models.QueryValidationError{
+					Code:    models.QueryUnknownField,
+					Message: fmt.Sprintf("field: %s not found under metric: %s", fieldName, metricName),
+					Metric:  metricName,
+					Field:   fieldName,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// searchFields returns the field names of metric from metadata of all storage nodes.
+func searchFields(ctx context.Context,
+	param *models.ExecuteParam, namespace, metricName string,
+	mgr *SearchMgr,
+) (map[string]struct{}, error) {
+	// field search is an independent request, cannot reuse request id
+	metadataMgr := *mgr
+	metadataMgr.RequestID = ""
+	rs, err := metricMetadataSearchFn(ctx, param, &stmtpkg.MetricMetadata{
+		Namespace:  namespace,
+		MetricName: metricName,
+		Type:       stmtpkg.Field,
+		Limit:      constants.MaxSuggestions,
+	}, &metadataMgr)
+	if err != nil {
+		return nil, err
+	}
+	values, _ := rs.([]string)
+	fields, err := buildFieldResultSet(values)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		names[f.Name] = struct{}{}
+	}
+	return names, nil
+}
+
+// referencedFields returns the field names referenced by select list and field filters of query.
+func referencedFields(statement *stmtpkg.Query) (fieldNames []string) {
+	if statement.AllFields {
+		return nil
+	}
+	visited := make(map[string]struct{})
+	var collect func(expr stmtpkg.Expr)
+	collect = func(expr stmtpkg.Expr) {
+		switch e := expr.(type) {
+		case *stmtpkg.SelectItem:
+			collect(e.Expr)
+		case *stmtpkg.ParenExpr:
+			collect(e.Expr)
+		case *stmtpkg.BinaryExpr:
+			collect(e.Left)
+			collect(e.Right)
+		case *stmtpkg.CallExpr:
+			switch {
+			case e.FuncType == function.Quantile:
+				// quantile of histogram buckets, bucket fields are not visible
+				return
+			case e.FuncType == function.HistogramQuantile || function.IsArgSelector(e.FuncType):
+				// first param is quantile value/tag key
+				if len(e.Params) == 2 {
+					collect(e.Params[1])
+				}
+				return
+			}
+			for _, param := range e.Params {
+				collect(param)
+			}
+		case *stmtpkg.FieldExpr:
+			if _, ok := visited[e.Name]; !ok {
+				visited[e.Name] = struct{}{}
+				fieldNames = append(fieldNames, e.Name)
+			}
+		}
+	}
+	for _, item := range statement.SelectItems {
+		collect(item)
+	}
+	for _, filter := range statement.FieldFilters {
+		collect(filter)
+	}
+	return fieldNames
+}
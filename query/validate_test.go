@@ -0,0 +1,151 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/pkg/encoding"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestValidateQuery(t *testing.T) {
+	defer func() {
+		metricMetadataSearchFn = MetricMetadataSearch
+	}()
+	mgr := &SearchMgr{RequestID: "xxxx-1bc"}
+	// mock field metadata, only cpu metric exists
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		statement *stmt.MetricMetadata, mgr *SearchMgr) (any, error) {
+		assert.Equal(t, stmt.Field, statement.Type)
+		assert.Empty(t, mgr.RequestID)
+		if statement.MetricName != "cpu" {
+			return []string{}, nil
+		}
+		return []string{string(encoding.JSONMarshal(&field.Metas{
+			{Name: "usage", Type: field.SumField},
+			{Name: "load", Type: field.LastField},
+		}))}, nil
+	}
+	param := &models.ExecuteParam{Database: "test"}
+	cases := []struct {
+		name   string
+		sql    string
+		valid  bool
+		errors []*models.QueryValidationError
+	}{
+		{
+			name:  "valid query",
+			sql:   "select sum(usage), load/usage, count_if(load > 1) from cpu where usage > 10 group by host",
+			valid: true,
+		},
+		{
+			name:  "select all fields",
+			sql:   "select * from cpu",
+			valid: true,
+		},
+		{
+			name:  "valid sub query",
+			sql:   "select max(v) from (select avg(usage) as v from cpu group by host)",
+			valid: true,
+		},
+		{
+			name: "unknown metric",
+			sql:  "select usage from mem",
+			errors: []*models.QueryValidationError{{
+				Code:    models.QueryUnknownMetric,
+				Message: "metric: mem not found",
+				Metric:  "mem",
+			}},
+		},
+		{
+			name: "unknown field",
+			sql:  "select sum(usage)+idle, argmax(host, io) from cpu",
+			errors: []*models.QueryValidationError{
+				{
+					Code:    models.QueryUnknownField,
+					Message: "field: idle not found under metric: cpu",
+					Metric:  "cpu",
+					Field:   "idle",
+				},
+				{
+					Code:    models.QueryUnknownField,
+					Message: "field: io not found under metric: cpu",
+					Metric:  "cpu",
+					Field:   "io",
+				},
+			},
+		},
+		{
+			name: "one of metrics unknown",
+			sql:  "select usage from cpu, mem",
+			errors: []*models.QueryValidationError{{
+				Code:    models.QueryUnknownMetric,
+				Message: "metric: mem not found",
+				Metric:  "mem",
+			}},
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := sql.Parse(tt.sql)
+			assert.NoError(t, err)
+			rs, err := ValidateQuery(context.TODO(), param, q.(*stmt.Query), mgr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, rs.Valid)
+			assert.Equal(t, tt.errors, rs.Errors)
+			if tt.valid {
+				assert.Equal(t, q, rs.Plan)
+			} else {
+				assert.Nil(t, rs.Plan)
+			}
+		})
+	}
+
+	// search fields failure
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return nil, fmt.Errorf("err")
+	}
+	rs, err := ValidateQuery(context.TODO(), param, &stmt.Query{MetricName: "cpu"}, mgr)
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+	// field metadata invalid
+	metricMetadataSearchFn = func(_ context.Context, _ *models.ExecuteParam,
+		_ *stmt.MetricMetadata, _ *SearchMgr) (any, error) {
+		return []string{"abc"}, nil
+	}
+	rs, err = ValidateQuery(context.TODO(), param, &stmt.Query{MetricName: "cpu"}, mgr)
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+}
+
+func TestReferencedFields(t *testing.T) {
+	q, err := sql.Parse("select quantile(0.99), histogram_quantile(0.9, b), topk(3, f), (a+a) as c from cpu where d > 1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "f", "a", "d"}, referencedFields(q.(*stmt.Query)))
+}
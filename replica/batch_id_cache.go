@@ -0,0 +1,85 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"container/list"
+	"sync"
+)
+
+// defaultMaxDedupBatchIDs represents the default max num. of batch ids remembered by each shard channel.
+const defaultMaxDedupBatchIDs = 4096
+
+// batchIDCache remembers the recent batch ids written to shard channel with LRU eviction for write idempotency,
+// memory is bounded by capacity, batch ids are not persisted(lost after restart).
+type batchIDCache struct {
+	capacity int
+	lru      *list.List               // element value: batch id, front is most recently marked
+	ids      map[string]*list.Element // batch id => lru element
+
+	mutex sync.Mutex
+}
+
+// newBatchIDCache creates the batch id cache with max num. of remembered batch ids.
+func newBatchIDCache(capacity int) *batchIDCache {
+	if capacity <= 0 {
+		capacity = defaultMaxDedupBatchIDs
+	}
+	return &batchIDCache{
+		capacity: capacity,
+		lru:      list.New(),
+		ids:      make(map[string]*list.Element),
+	}
+}
+
+// mark marks batch id as written, returns false if batch id already marked(duplicate batch),
+// evicts the least recently marked batch id if full.
+func (c *batchIDCache) mark(batchID string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.ids[batchID]; ok {
+		c.lru.MoveToFront(elem)
+		return false
+	}
+	if c.lru.Len() >= c.capacity {
+		oldest := c.lru.Back()
+		delete(c.ids, c.lru.Remove(oldest).(string))
+	}
+	c.ids[batchID] = c.lru.PushFront(batchID)
+	return true
+}
+
+// unmark forgets batch id, so that batch failed can be retried.
+func (c *batchIDCache) unmark(batchID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.ids[batchID]; ok {
+		c.lru.Remove(elem)
+		delete(c.ids, batchID)
+	}
+}
+
+// size returns the num. of remembered batch ids.
+func (c *batchIDCache) size() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.lru.Len()
+}
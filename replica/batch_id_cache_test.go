@@ -0,0 +1,44 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchIDCache(t *testing.T) {
+	assert.Equal(t, defaultMaxDedupBatchIDs, newBatchIDCache(0).capacity)
+
+	c := newBatchIDCache(2)
+	assert.True(t, c.mark("a"))
+	assert.False(t, c.mark("a"))
+	assert.True(t, c.mark("b"))
+	// a is most recently marked, b is evicted
+	assert.False(t, c.mark("a"))
+	assert.True(t, c.mark("c"))
+	assert.Equal(t, 2, c.size())
+	assert.True(t, c.mark("b"))
+	assert.False(t, c.mark("b"))
+
+	c.unmark("b")
+	c.unmark("not-exist")
+	assert.Equal(t, 1, c.size())
+	assert.True(t, c.mark("b"))
+}
@@ -20,6 +20,7 @@ package replica
 import (
 	"context"
 	"sort"
+	"strconv"
 	"sync"
 
 	"go.uber.org/atomic"
@@ -42,10 +43,18 @@ var (
 
 // DatabaseChannel represents the database level replication shardChannel
 type DatabaseChannel interface {
-	// Write writes the metric data into shardChannel's buffer
+	// Write writes the metric data into shardChannel's buffer,
+	// returns ErrChannelBackpressure if too many rows in flight of some shard(rows of this shard rejected).
+	// If batch id of rows is set, rows of shard which already written with same batch id are ignored.
 	Write(ctx context.Context, brokerBatchRows *metric.BrokerBatchRows) error
 	// CreateChannel creates the shard level replication shardChannel by given shard id
 	CreateChannel(numOfShard int32, shardID models.ShardID) (ShardChannel, error)
+	// ResizeShards grows the num. of shard which is used to calculate the shard id of series,
+	// new num. of shard must be greater or equal than current, missing shard channels will be created before
+	// the new hash space is visible to writes.
+	ResizeShards(newNum int32) error
+	// WriteStats returns the write statistics of each metric.
+	WriteStats() []models.MetricWriteStats
 	// Stop stops current database write shardChannel.
 	Stop()
 
@@ -71,6 +80,7 @@ type (
 		interval      timeutil.Interval
 
 		statistics *metrics.BrokerDatabaseWriteStatistics
+		writeStats *metricWriteStats
 		logger     logger.Logger
 	}
 )
@@ -89,6 +99,7 @@ func newDatabaseChannel(
 		cancel:      cancel,
 		fct:         fct,
 		statistics:  metrics.NewBrokerDatabaseWriteStatistics(databaseCfg.Name),
+		writeStats:  newMetricWriteStats(maxTrackedMetrics),
 		logger:      logger.GetLogger("Replica", "DatabaseChannel"),
 	}
 	ch.shardChannels.value.Store(make(shard2Channel))
@@ -132,8 +143,11 @@ func (dc *databaseChannel) Write(ctx context.Context, brokerBatchRows *metric.Br
 	evicted := brokerBatchRows.EvictOutOfTimeRange(behind, ahead)
 	dc.statistics.OutOfTimeRange.Add(float64(evicted))
 
+	// use a stable snapshot of num. of shard for the whole batch,
+	// so that a batch isn't split across two hash spaces when shards resizing.
+	numOfShard := dc.numOfShard.Load()
 	// sharding metrics to shards
-	shardingIterator := brokerBatchRows.NewShardGroupIterator(dc.numOfShard.Load())
+	shardingIterator := brokerBatchRows.NewShardGroupIterator(numOfShard)
 	for shardingIterator.HasRowsForNextShard() {
 		shardIdx, familyIterator := shardingIterator.FamilyRowsForNextShard(dc.interval)
 		shardID := models.ShardID(shardIdx)
@@ -145,24 +159,73 @@ func (dc *databaseChannel) Write(ctx context.Context, brokerBatchRows *metric.Br
 			dc.logger.Error("shardChannel not found",
 				logger.String("database", dc.databaseCfg.Name),
 				logger.Int("shardID", shardID.Int()))
+			for familyIterator.HasNextFamily() {
+				_, rows := familyIterator.NextFamily()
+				dc.writeStats.record(rows, false)
+			}
 			continue
 		}
-		for familyIterator.HasNextFamily() {
-			familyTime, rows := familyIterator.NextFamily()
-			familyChannel := channel.GetOrCreateFamilyChannel(familyTime)
-			if err = familyChannel.Write(ctx, rows); err != nil {
-				dc.logger.Error("failed writing rows to family shardChannel",
-					logger.String("database", dc.databaseCfg.Name),
-					logger.Int("shardID", shardID.Int()),
-					logger.Int("rows", len(rows)),
-					logger.Int64("familyTime", familyTime),
-					logger.Error(err))
+		if shardErr := dc.writeShard(ctx, shardID, channel, brokerBatchRows.BatchID(), familyIterator); shardErr != nil {
+			err = shardErr
+		}
+	}
+	return err
+}
+
+// writeShard writes the rows of shard into family channels, returns the last error if some family failed.
+// If batch id is set, dedup is tracked per family of shard, so that only failed families are written
+// when client retries the batch.
+func (dc *databaseChannel) writeShard(
+	ctx context.Context,
+	shardID models.ShardID,
+	channel ShardChannel,
+	batchID string,
+	familyIterator *metric.BrokerBatchShardFamilyIterator,
+) (err error) {
+	for familyIterator.HasNextFamily() {
+		familyTime, rows := familyIterator.NextFamily()
+		familyBatchID := ""
+		if batchID != "" {
+			familyBatchID = batchID + "/" + strconv.FormatInt(familyTime, 10)
+			if !channel.markBatch(familyBatchID) {
+				// batch retried by client, rows of this family already written
+				dc.statistics.DuplicateBatch.Add(float64(len(rows)))
+				continue
 			}
 		}
+		if !channel.acquire(len(rows)) {
+			// shard buffer is full, reject rows instead of blocking, client need back off
+			dc.statistics.Backpressure.Add(float64(len(rows)))
+			dc.writeStats.record(rows, false)
+			dc.unmarkBatch(channel, familyBatchID)
+			err = ErrChannelBackpressure
+			continue
+		}
+		familyChannel := channel.GetOrCreateFamilyChannel(familyTime)
+		writeErr := familyChannel.Write(ctx, rows)
+		channel.release(len(rows))
+		dc.writeStats.record(rows, writeErr == nil)
+		if writeErr != nil {
+			dc.unmarkBatch(channel, familyBatchID)
+			err = writeErr
+			dc.logger.Error("failed writing rows to family shardChannel",
+				logger.String("database", dc.databaseCfg.Name),
+				logger.Int("shardID", shardID.Int()),
+				logger.Int("rows", len(rows)),
+				logger.Int64("familyTime", familyTime),
+				logger.Error(err))
+		}
 	}
 	return err
 }
 
+// unmarkBatch forgets the batch id of family after family failed, client can retry it with same batch id.
+func (dc *databaseChannel) unmarkBatch(channel ShardChannel, familyBatchID string) {
+	if familyBatchID != "" {
+		channel.unmarkBatch(familyBatchID)
+	}
+}
+
 // CreateChannel creates the shard level replication shardChannel by given shard id
 func (dc *databaseChannel) CreateChannel(numOfShard int32, shardID models.ShardID) (ShardChannel, error) {
 	if channel, ok := dc.getChannelByShardID(shardID); ok {
@@ -188,6 +251,43 @@ func (dc *databaseChannel) CreateChannel(numOfShard int32, shardID models.ShardI
 	return ch, nil
 }
 
+// ResizeShards grows the num. of shard which is used to calculate the shard id of series,
+// new num. of shard must be greater or equal than current, missing shard channels will be created before
+// the new hash space is visible to writes.
+func (dc *databaseChannel) ResizeShards(newNum int32) error {
+	dc.shardChannels.mu.Lock()
+	defer dc.shardChannels.mu.Unlock()
+
+	if newNum <= 0 {
+		return errInvalidShardID
+	}
+	oldNum := dc.numOfShard.Load()
+	if newNum < oldNum {
+		return errInvalidShardNum
+	}
+	if newNum == oldNum {
+		return nil
+	}
+	// create shard channels for new hash space first, make sure no rows written to missing shard
+	for shardIdx := int32(0); shardIdx < newNum; shardIdx++ {
+		shardID := models.ShardID(shardIdx)
+		if _, ok := dc.getChannelByShardID(shardID); !ok {
+			dc.insertShardChannel(shardID, createChannel(dc.ctx, dc.databaseCfg.Name, shardID, dc.fct))
+		}
+	}
+	dc.numOfShard.Store(newNum)
+	dc.logger.Info("resize num. of shard for database channel",
+		logger.String("database", dc.databaseCfg.Name),
+		logger.Int32("oldNumOfShard", oldNum),
+		logger.Int32("newNumOfShard", newNum))
+	return nil
+}
+
+// WriteStats returns the write statistics of each metric.
+func (dc *databaseChannel) WriteStats() []models.MetricWriteStats {
+	return dc.writeStats.snapshot()
+}
+
 // Stop stops current database write shardChannel.
 func (dc *databaseChannel) Stop() {
 	dc.shardChannels.mu.Lock()
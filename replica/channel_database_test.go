@@ -20,16 +20,20 @@ package replica
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
 	"github.com/lindb/common/pkg/timeutil"
 	protoMetricsV1 "github.com/lindb/common/proto/gen/v1/linmetrics"
 
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/series/metric"
 )
 
@@ -65,6 +69,8 @@ func TestDatabaseChannel_Write(t *testing.T) {
 	familyChannel := NewMockFamilyChannel(ctrl)
 	familyChannel.EXPECT().Write(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
 	shardCh.EXPECT().GetOrCreateFamilyChannel(gomock.Any()).Return(familyChannel).AnyTimes()
+	shardCh.EXPECT().acquire(1).Return(true)
+	shardCh.EXPECT().release(1)
 
 	batch = metric.NewBrokerBatchRows()
 	_ = batch.TryAppend(func(row *metric.BrokerRow) error {
@@ -78,6 +84,135 @@ func TestDatabaseChannel_Write(t *testing.T) {
 	})
 	err = ch.Write(context.TODO(), batch)
 	assert.Error(t, err)
+
+	// case: shard channel backpressure
+	shardCh.EXPECT().acquire(1).Return(false)
+	batch = metric.NewBrokerBatchRows()
+	_ = batch.TryAppend(func(row *metric.BrokerRow) error {
+		return converter.ConvertTo(&protoMetricsV1.Metric{
+			Name:      "cpu",
+			Timestamp: timeutil.Now(),
+			SimpleFields: []*protoMetricsV1.SimpleField{
+				{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+			Tags: []*protoMetricsV1.KeyValue{{Key: "host", Value: "1.1.1.1"}},
+		}, row)
+	})
+	err = ch.Write(context.TODO(), batch)
+	assert.ErrorIs(t, err, ErrChannelBackpressure)
+}
+
+func TestDatabaseChannel_Write_BatchID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opt := &option.DatabaseOption{Intervals: option.Intervals{{Interval: 10 * 1000}}}
+	ch := newDatabaseChannel(context.TODO(),
+		models.Database{
+			Name:   "database",
+			Option: opt,
+		}, 1, nil)
+	shardCh := NewMockShardChannel(ctrl)
+	ch.(*databaseChannel).insertShardChannel(models.ShardID(0), shardCh)
+	batchIDs := newBatchIDCache(10)
+	shardCh.EXPECT().markBatch(gomock.Any()).DoAndReturn(batchIDs.mark).AnyTimes()
+	shardCh.EXPECT().unmarkBatch(gomock.Any()).Do(batchIDs.unmark).AnyTimes()
+	shardCh.EXPECT().acquire(1).Return(true).AnyTimes()
+	shardCh.EXPECT().release(1).AnyTimes()
+	familyChannel := NewMockFamilyChannel(ctrl)
+	shardCh.EXPECT().GetOrCreateFamilyChannel(gomock.Any()).Return(familyChannel).AnyTimes()
+
+	converter := metric.NewProtoConverter(models.NewDefaultLimits())
+	write := func(batchID string) error {
+		batch := metric.NewBrokerBatchRows()
+		_ = batch.TryAppend(func(row *metric.BrokerRow) error {
+			return converter.ConvertTo(&protoMetricsV1.Metric{
+				Name:      "cpu",
+				Timestamp: timeutil.Now(),
+				SimpleFields: []*protoMetricsV1.SimpleField{
+					{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+				Tags: []*protoMetricsV1.KeyValue{{Key: "host", Value: "1.1.1.1"}},
+			}, row)
+		})
+		batch.SetBatchID(batchID)
+		return ch.Write(context.TODO(), batch)
+	}
+
+	// case 1: first write of batch
+	familyChannel.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	assert.NoError(t, write("batch-1"))
+	// case 2: same batch id is no-op
+	assert.NoError(t, write("batch-1"))
+	// case 3: different batch id writes normally
+	familyChannel.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	assert.NoError(t, write("batch-2"))
+	// case 4: failed batch can be retried with same batch id
+	familyChannel.EXPECT().Write(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, write("batch-3"))
+	familyChannel.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	assert.NoError(t, write("batch-3"))
+	assert.NoError(t, write("batch-3"))
+	// case 5: without batch id, always writes
+	familyChannel.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	assert.NoError(t, write(""))
+	assert.NoError(t, write(""))
+	assert.Equal(t, 3, batchIDs.size())
+}
+
+func TestDatabaseChannel_Write_BatchID_PartialFamilyFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opt := &option.DatabaseOption{Intervals: option.Intervals{{Interval: 10 * 1000}}}
+	ch := newDatabaseChannel(context.TODO(),
+		models.Database{
+			Name:   "database",
+			Option: opt,
+		}, 1, nil)
+	shardCh := NewMockShardChannel(ctrl)
+	ch.(*databaseChannel).insertShardChannel(models.ShardID(0), shardCh)
+	batchIDs := newBatchIDCache(10)
+	shardCh.EXPECT().markBatch(gomock.Any()).DoAndReturn(batchIDs.mark).AnyTimes()
+	shardCh.EXPECT().unmarkBatch(gomock.Any()).Do(batchIDs.unmark).AnyTimes()
+	shardCh.EXPECT().release(1).AnyTimes()
+	familyChannel := NewMockFamilyChannel(ctrl)
+	shardCh.EXPECT().GetOrCreateFamilyChannel(gomock.Any()).Return(familyChannel).AnyTimes()
+
+	now := timeutil.Now()
+	converter := metric.NewProtoConverter(models.NewDefaultLimits())
+	write := func() error {
+		batch := metric.NewBrokerBatchRows()
+		// rows of two families in same shard
+		for _, timestamp := range []int64{now - 2*timeutil.OneHour, now} {
+			timestamp := timestamp
+			_ = batch.TryAppend(func(row *metric.BrokerRow) error {
+				return converter.ConvertTo(&protoMetricsV1.Metric{
+					Name:      "cpu",
+					Timestamp: timestamp,
+					SimpleFields: []*protoMetricsV1.SimpleField{
+						{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+					Tags: []*protoMetricsV1.KeyValue{{Key: "host", Value: "1.1.1.1"}},
+				}, row)
+			})
+		}
+		batch.SetBatchID("batch-1")
+		return ch.Write(context.TODO(), batch)
+	}
+
+	// first family written, second family rejected by backpressure
+	gomock.InOrder(
+		shardCh.EXPECT().acquire(1).Return(true),
+		familyChannel.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil),
+		shardCh.EXPECT().acquire(1).Return(false),
+	)
+	assert.ErrorIs(t, write(), ErrChannelBackpressure)
+	assert.Equal(t, 1, batchIDs.size())
+	// retry only writes the failed family
+	shardCh.EXPECT().acquire(1).Return(true)
+	familyChannel.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	assert.NoError(t, write())
+	assert.Equal(t, 2, batchIDs.size())
+	// retry again, all families written
+	assert.NoError(t, write())
 }
 
 func TestDatabaseChannel_CreateChannel(t *testing.T) {
@@ -108,6 +243,72 @@ func TestDatabaseChannel_CreateChannel(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDatabaseChannel_ResizeShards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createChannel = newShardChannel
+		ctrl.Finish()
+	}()
+	var written atomic.Int64
+	createChannel = func(_ context.Context, _ string, _ models.ShardID, _ rpc.ClientStreamFactory) ShardChannel {
+		familyCh := NewMockFamilyChannel(ctrl)
+		familyCh.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, rows []metric.BrokerRow) error {
+			written.Add(int64(len(rows)))
+			return nil
+		}).AnyTimes()
+		shardCh := NewMockShardChannel(ctrl)
+		shardCh.EXPECT().acquire(gomock.Any()).Return(true).AnyTimes()
+		shardCh.EXPECT().release(gomock.Any()).AnyTimes()
+		shardCh.EXPECT().GetOrCreateFamilyChannel(gomock.Any()).Return(familyCh).AnyTimes()
+		return shardCh
+	}
+	opt := &option.DatabaseOption{Intervals: option.Intervals{{Interval: 10 * 1000}}}
+	ch := newDatabaseChannel(context.TODO(),
+		models.Database{
+			Name:   "database",
+			Option: opt,
+		}, 2, nil)
+	_, err := ch.CreateChannel(2, 0)
+	assert.NoError(t, err)
+	_, err = ch.CreateChannel(2, 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, errInvalidShardID, ch.ResizeShards(0))
+	assert.Equal(t, errInvalidShardNum, ch.ResizeShards(1))
+	assert.NoError(t, ch.ResizeShards(2))
+
+	converter := metric.NewProtoConverter(models.NewDefaultLimits())
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		for i := int32(3); i <= 8; i++ {
+			assert.NoError(t, ch.ResizeShards(i))
+		}
+	}()
+	batches := 50
+	metricsOfBatch := 20
+	for i := 0; i < batches; i++ {
+		batch := metric.NewBrokerBatchRows()
+		for j := 0; j < metricsOfBatch; j++ {
+			_ = batch.TryAppend(func(row *metric.BrokerRow) error {
+				return converter.ConvertTo(&protoMetricsV1.Metric{
+					Name:      "cpu",
+					Timestamp: timeutil.Now(),
+					SimpleFields: []*protoMetricsV1.SimpleField{
+						{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+					Tags: []*protoMetricsV1.KeyValue{{Key: "host", Value: strconv.Itoa(j)}},
+				}, row)
+			})
+		}
+		assert.NoError(t, ch.Write(context.TODO(), batch))
+	}
+	wait.Wait()
+	// no metric lost during resize
+	assert.Equal(t, int64(batches*metricsOfBatch), written.Load())
+	assert.Equal(t, int32(8), ch.(*databaseChannel).numOfShard.Load())
+}
+
 func TestDatabaseChannel_Stop(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -127,3 +328,67 @@ func TestDatabaseChannel_Stop(t *testing.T) {
 	shardCh.EXPECT().Stop()
 	ch.Stop()
 }
+
+func TestDatabaseChannel_WriteStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createChannel = newShardChannel
+		ctrl.Finish()
+	}()
+	var reject atomic.Bool
+	createChannel = func(_ context.Context, _ string, _ models.ShardID, _ rpc.ClientStreamFactory) ShardChannel {
+		familyCh := NewMockFamilyChannel(ctrl)
+		familyCh.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		shardCh := NewMockShardChannel(ctrl)
+		shardCh.EXPECT().acquire(gomock.Any()).DoAndReturn(func(_ int) bool {
+			return !reject.Load()
+		}).AnyTimes()
+		shardCh.EXPECT().release(gomock.Any()).AnyTimes()
+		shardCh.EXPECT().GetOrCreateFamilyChannel(gomock.Any()).Return(familyCh).AnyTimes()
+		return shardCh
+	}
+	opt := &option.DatabaseOption{Intervals: option.Intervals{{Interval: 10 * 1000}}}
+	ch := newDatabaseChannel(context.TODO(),
+		models.Database{
+			Name:   "database",
+			Option: opt,
+		}, 1, nil)
+	_, err := ch.CreateChannel(1, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, ch.WriteStats())
+
+	converter := metric.NewProtoConverter(models.NewDefaultLimits())
+	write := func(metricNames ...string) error {
+		batch := metric.NewBrokerBatchRows()
+		for idx := range metricNames {
+			_ = batch.TryAppend(func(row *metric.BrokerRow) error {
+				return converter.ConvertTo(&protoMetricsV1.Metric{
+					Name:      metricNames[idx],
+					Timestamp: timeutil.Now(),
+					SimpleFields: []*protoMetricsV1.SimpleField{
+						{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+					Tags: []*protoMetricsV1.KeyValue{{Key: "host", Value: strconv.Itoa(idx)}},
+				}, row)
+			})
+		}
+		return ch.Write(context.TODO(), batch)
+	}
+	assert.NoError(t, write("cpu", "cpu", "memory", "cpu"))
+	assert.NoError(t, write("memory", "disk"))
+	// rejected by backpressure
+	reject.Store(true)
+	assert.ErrorIs(t, write("disk", "cpu"), ErrChannelBackpressure)
+
+	stats := ch.WriteStats()
+	assert.Len(t, stats, 3)
+	assert.Equal(t, "cpu", stats[0].MetricName)
+	assert.Equal(t, int64(3), stats[0].AcceptedRows)
+	assert.Equal(t, int64(1), stats[0].DroppedRows)
+	assert.True(t, stats[0].AcceptedBytes > 0)
+	assert.Equal(t, "disk", stats[1].MetricName)
+	assert.Equal(t, int64(1), stats[1].AcceptedRows)
+	assert.Equal(t, int64(1), stats[1].DroppedRows)
+	assert.Equal(t, "memory", stats[2].MetricName)
+	assert.Equal(t, int64(2), stats[2].AcceptedRows)
+	assert.Equal(t, int64(0), stats[2].DroppedRows)
+}
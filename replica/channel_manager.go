@@ -41,6 +41,8 @@ import (
 type ChannelManager interface {
 	// Write writes a MetricList, the manager handler the database, sharding things.
 	Write(ctx context.Context, database string, brokerBatchRows *metric.BrokerBatchRows) error
+	// GetWriteStats returns the write statistics of each metric by given database's name.
+	GetWriteStats(database string) []models.MetricWriteStats
 
 	// Close closes all the shardChannel.
 	Close()
@@ -64,6 +66,10 @@ type (
 		stateMgr broker.StateManager
 
 		databaseChannels databaseChannels
+		// ingestion rate limiter keyed by database name
+		rateLimiter *rateLimiter
+		// validates rows based on write limits of database
+		rowValidator *rowValidator
 
 		logger logger.Logger
 	}
@@ -78,11 +84,13 @@ func NewChannelManager(
 ) ChannelManager {
 	ctx, cancel := context.WithCancel(ctx)
 	cm := &channelManager{
-		ctx:      ctx,
-		cancel:   cancel,
-		fct:      fct,
-		stateMgr: stateMgr,
-		logger:   logger.GetLogger("Replica", "ChannelManager"),
+		ctx:          ctx,
+		cancel:       cancel,
+		fct:          fct,
+		stateMgr:     stateMgr,
+		rateLimiter:  newRateLimiter(),
+		rowValidator: newRowValidator(),
+		logger:       logger.GetLogger("Replica", "ChannelManager"),
 	}
 	cm.databaseChannels.value.Store(make(database2Channel))
 
@@ -94,12 +102,27 @@ func NewChannelManager(
 }
 
 // Write writes a MetricList, the manager handler the database, sharding things.
+// Returns *RateLimitError(ErrRateLimited) if ingestion rate of database exceeds the limit.
+// Rows violating the write limits of database are removed before writing,
+// returns *InvalidRowsError(ErrInvalidRows) after other rows written.
 func (cm *channelManager) Write(ctx context.Context, database string, brokerBatchRows *metric.BrokerBatchRows) error {
 	if brokerBatchRows == nil || brokerBatchRows.Len() == 0 {
 		return nil
 	}
 	if databaseChannel, ok := cm.getDatabaseChannel(database); ok {
-		return databaseChannel.Write(ctx, brokerBatchRows)
+		limits := cm.stateMgr.GetDatabaseLimits(database)
+		invalidErr := cm.rowValidator.Validate(database, limits, brokerBatchRows)
+		if brokerBatchRows.Len() == 0 {
+			// all rows rejected
+			return invalidErr
+		}
+		if err := cm.rateLimiter.Allow(database, limits, brokerBatchRows.Len()); err != nil {
+			return err
+		}
+		if err := databaseChannel.Write(ctx, brokerBatchRows); err != nil {
+			return err
+		}
+		return invalidErr
 	}
 	return fmt.Errorf("database [%s] not found", database)
 }
@@ -151,6 +174,14 @@ func (cm *channelManager) Close() {
 	}
 }
 
+// GetWriteStats returns the write statistics of each metric by given database's name.
+func (cm *channelManager) GetWriteStats(database string) []models.MetricWriteStats {
+	if databaseChannel, ok := cm.getDatabaseChannel(database); ok {
+		return databaseChannel.WriteStats()
+	}
+	return nil
+}
+
 // getDatabaseChannel gets the database shardChannel by given database name
 func (cm *channelManager) getDatabaseChannel(databaseName string) (DatabaseChannel, bool) {
 	ch, ok := cm.databaseChannels.value.Load().(database2Channel)[databaseName]
@@ -185,6 +216,13 @@ func (cm *channelManager) handleShardStateChangeEvent(
 			ch.SyncShardState(shardState, liveNodes)
 		}
 	}
+	// grow hash space after all shard channels created
+	if ch, ok := cm.getDatabaseChannel(databaseCfg.Name); ok && numOfShard > 0 {
+		if err := ch.ResizeShards(int32(numOfShard)); err != nil {
+			cm.logger.Error("resize num. of shard for database shardChannel", logger.String("db", databaseCfg.Name),
+				logger.Int("numOfShard", numOfShard), logger.Error(err))
+		}
+	}
 }
 
 // gcWriteFamilies recycles write families which is expired.
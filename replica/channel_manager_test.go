@@ -19,6 +19,7 @@ package replica
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -33,6 +34,7 @@ import (
 	protoMetricsV1 "github.com/lindb/common/proto/gen/v1/linmetrics"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/option"
@@ -84,6 +86,7 @@ func TestChannelManager_Write(t *testing.T) {
 
 	stateMgr := broker.NewMockStateManager(ctrl)
 	stateMgr.EXPECT().WatchShardStateChangeEvent(gomock.Any())
+	stateMgr.EXPECT().GetDatabaseLimits(gomock.Any()).Return(models.NewDefaultLimits()).AnyTimes()
 	cm := NewChannelManager(context.TODO(), nil, stateMgr)
 	err := cm.Write(context.TODO(), "database", nil)
 	assert.NoError(t, err)
@@ -109,6 +112,129 @@ func TestChannelManager_Write(t *testing.T) {
 	cm.Close()
 }
 
+func TestChannelManager_Write_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limits := models.NewDefaultLimits()
+	limits.MaxRowsPerSecond = 1
+	limits.MaxRowsBurst = 5
+	stateMgr := broker.NewMockStateManager(ctrl)
+	stateMgr.EXPECT().WatchShardStateChangeEvent(gomock.Any())
+	stateMgr.EXPECT().GetDatabaseLimits("database").Return(limits).AnyTimes()
+	cm := NewChannelManager(context.TODO(), nil, stateMgr)
+	defer cm.Close()
+
+	accepted := 0
+	dbChannel := NewMockDatabaseChannel(ctrl)
+	dbChannel.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, rows *metric.BrokerBatchRows) error {
+			accepted += rows.Len()
+			return nil
+		}).AnyTimes()
+	dbChannel.EXPECT().Stop().AnyTimes()
+	cm1 := cm.(*channelManager)
+	cm1.insertDatabaseChannel("database", dbChannel)
+
+	// push faster than the limit
+	succeed := 0
+	throttled := 0
+	for i := 0; i < 20; i++ {
+		err := cm.Write(context.TODO(), "database", mockBrokerRows(t))
+		if err != nil {
+			assert.True(t, errors.Is(err, ErrRateLimited))
+			var rateLimitErr *RateLimitError
+			assert.True(t, errors.As(err, &rateLimitErr))
+			assert.Equal(t, "database", rateLimitErr.Database)
+			assert.True(t, rateLimitErr.RetryAfter > 0)
+			assert.True(t, IsRetryableError(err))
+			throttled++
+			continue
+		}
+		succeed++
+	}
+	assert.True(t, throttled > 0)
+	assert.True(t, succeed >= 5)
+	// accepted rows are written, not dropped
+	assert.Equal(t, succeed, accepted)
+
+	// config changed, limiter reconfigured
+	limits.MaxRowsPerSecond = 1000000
+	limits.MaxRowsBurst = 1000000
+	assert.NoError(t, cm.Write(context.TODO(), "database", mockBrokerRows(t)))
+	// rate limit disabled
+	limits.MaxRowsPerSecond = 0
+	assert.NoError(t, cm.Write(context.TODO(), "database", mockBrokerRows(t)))
+	assert.Empty(t, cm1.rateLimiter.limiters)
+}
+
+func TestChannelManager_Write_InvalidRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limits := models.NewDefaultLimits()
+	limits.MetricNamePattern = "^[a-z_.]+$"
+	stateMgr := broker.NewMockStateManager(ctrl)
+	stateMgr.EXPECT().WatchShardStateChangeEvent(gomock.Any())
+	stateMgr.EXPECT().GetDatabaseLimits("database").Return(limits).AnyTimes()
+	cm := NewChannelManager(context.TODO(), nil, stateMgr)
+	defer cm.Close()
+
+	var written []string
+	dbChannel := NewMockDatabaseChannel(ctrl)
+	dbChannel.EXPECT().Stop().AnyTimes()
+	cm1 := cm.(*channelManager)
+	cm1.insertDatabaseChannel("database", dbChannel)
+
+	// valid rows pass through unaffected
+	dbChannel.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, rows *metric.BrokerBatchRows) error {
+			for _, row := range rows.Rows() {
+				m := row.Metric()
+				written = append(written, string(m.Name()))
+			}
+			return nil
+		}).Times(2)
+	assert.NoError(t, cm.Write(context.TODO(), "database", mockNamedBrokerRows(t, "cpu", "system.mem")))
+	assert.Equal(t, []string{"cpu", "system.mem"}, written)
+
+	// invalid rows don't reach database channel
+	written = nil
+	err := cm.Write(context.TODO(), "database", mockNamedBrokerRows(t, "cpu", "CPU", "mem", "m$m"))
+	assert.True(t, errors.Is(err, ErrInvalidRows))
+	assert.False(t, IsRetryableError(err))
+	var invalidErr *InvalidRowsError
+	assert.True(t, errors.As(err, &invalidErr))
+	assert.Equal(t, 2, invalidErr.Rejected)
+	assert.Equal(t, constants.ErrInvalidMetricName, invalidErr.Err)
+	assert.Equal(t, []string{"cpu", "mem"}, written)
+
+	// all rows rejected
+	err = cm.Write(context.TODO(), "database", mockNamedBrokerRows(t, "CPU"))
+	assert.True(t, errors.Is(err, ErrInvalidRows))
+
+	// write failure of valid rows takes precedence
+	dbChannel.EXPECT().Write(gomock.Any(), gomock.Any()).Return(ErrChannelBackpressure)
+	err = cm.Write(context.TODO(), "database", mockNamedBrokerRows(t, "cpu", "CPU"))
+	assert.Equal(t, ErrChannelBackpressure, err)
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	limiter := newRateLimiter()
+	assert.NoError(t, limiter.Allow("db", nil, 10))
+	limits := models.NewDefaultLimits()
+	limits.MaxRowsPerSecond = 10
+	// batch larger than burst takes the whole bucket
+	assert.NoError(t, limiter.Allow("db", limits, 100))
+	err := limiter.Allow("db", limits, 1)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Contains(t, err.Error(), "database [db]")
+	limiter.Reset("db")
+	assert.NoError(t, limiter.Allow("db", limits, 10))
+	limiter.ResetAll()
+	assert.Empty(t, limiter.limiters)
+}
+
 func TestChannelManager_handleShardStateChangeEvent(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -137,6 +263,9 @@ func TestChannelManager_handleShardStateChangeEvent(t *testing.T) {
 			shards: map[models.ShardID]models.ShardState{
 				3: {ID: 3},
 			},
+			prepare: func() {
+				dbChannel.EXPECT().ResizeShards(int32(1)).Return(errInvalidShardNum)
+			},
 		},
 		{
 			name: "sync shard state successfully",
@@ -148,6 +277,7 @@ func TestChannelManager_handleShardStateChangeEvent(t *testing.T) {
 				shardCh := NewMockShardChannel(ctrl)
 				dbChannel.EXPECT().CreateChannel(gomock.Any(), gomock.Any()).Return(shardCh, nil)
 				shardCh.EXPECT().SyncShardState(gomock.Any(), gomock.Any())
+				dbChannel.EXPECT().ResizeShards(int32(1)).Return(nil)
 			},
 		},
 	}
@@ -190,17 +320,22 @@ func TestChannelManager_gcWriteFamilies(t *testing.T) {
 }
 
 func mockBrokerRows(t *testing.T) *metric.BrokerBatchRows {
+	return mockNamedBrokerRows(t, "cpu")
+}
+
+func mockNamedBrokerRows(t *testing.T, names ...string) *metric.BrokerBatchRows {
 	converter := metric.NewProtoConverter(models.NewDefaultLimits())
-	var brokerRow metric.BrokerRow
-	assert.NoError(t, converter.ConvertTo(&protoMetricsV1.Metric{
-		Name:      "cpu",
-		Timestamp: timeutil.Now(),
-		SimpleFields: []*protoMetricsV1.SimpleField{
-			{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
-	}, &brokerRow))
 	rows := metric.NewBrokerBatchRows()
-	assert.NoError(t, rows.TryAppend(func(row *metric.BrokerRow) error {
-		return nil
-	}))
+	for _, name := range names {
+		name := name
+		assert.NoError(t, rows.TryAppend(func(row *metric.BrokerRow) error {
+			return converter.ConvertTo(&protoMetricsV1.Metric{
+				Name:      name,
+				Timestamp: timeutil.Now(),
+				SimpleFields: []*protoMetricsV1.SimpleField{
+					{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+			}, row)
+		}))
+	}
 	return rows
 }
@@ -21,6 +21,8 @@ import (
 	"context"
 	"sync"
 
+	"go.uber.org/atomic"
+
 	"github.com/lindb/common/pkg/logger"
 	"github.com/lindb/common/pkg/timeutil"
 
@@ -47,6 +49,14 @@ type ShardChannel interface {
 
 	// garbageCollect recycles expired write family.
 	garbageCollect(ahead, behind int64)
+	// acquire acquires the quota of in-flight rows, returns false if too many rows in flight.
+	acquire(rows int) bool
+	// release releases the quota of in-flight rows after written.
+	release(rows int)
+	// markBatch marks batch id(of family) as written to shard, returns false if batch id already marked(duplicate batch).
+	markBatch(batchID string) bool
+	// unmarkBatch forgets batch id(of family) after rows of family failed, so that they can be retried.
+	unmarkBatch(batchID string)
 }
 
 // shardChannel implements ShardChannel.
@@ -65,6 +75,9 @@ type shardChannel struct {
 
 	mutex sync.Mutex
 
+	inFlightRows atomic.Int64  // num. of rows in flight(being written)
+	batchIDs     *batchIDCache // recent batch ids written to shard for write idempotency
+
 	logger logger.Logger
 }
 
@@ -82,6 +95,7 @@ func newShardChannel(
 		shardID:  shardID,
 		families: newFamilyChannelSet(),
 		fct:      fct,
+		batchIDs: newBatchIDCache(config.GlobalBrokerConfig().Write.MaxDedupBatchIDs),
 		logger:   logger.GetLogger("Replica", "ShardChannel"),
 	}
 }
@@ -164,3 +178,29 @@ func (c *shardChannel) garbageCollect(ahead, behind int64) {
 func getFamily(families *familyChannelSet, familyTime int64) (FamilyChannel, bool) {
 	return families.GetFamilyChannel(familyTime)
 }
+
+// acquire acquires the quota of in-flight rows, returns false if too many rows in flight,
+// a batch is always accepted if no rows in flight even if its size exceeds the limit.
+func (c *shardChannel) acquire(rows int) bool {
+	inFlight := c.inFlightRows.Add(int64(rows))
+	if c.cfg.MaxInFlightRows > 0 && inFlight > int64(c.cfg.MaxInFlightRows) && inFlight > int64(rows) {
+		c.inFlightRows.Sub(int64(rows))
+		return false
+	}
+	return true
+}
+
+// release releases the quota of in-flight rows after written.
+func (c *shardChannel) release(rows int) {
+	c.inFlightRows.Sub(int64(rows))
+}
+
+// markBatch marks batch id as written to shard, returns false if batch id already marked(duplicate batch).
+func (c *shardChannel) markBatch(batchID string) bool {
+	return c.batchIDs.mark(batchID)
+}
+
+// unmarkBatch forgets batch id after batch failed, so that batch can be retried.
+func (c *shardChannel) unmarkBatch(batchID string) {
+	c.batchIDs.unmark(batchID)
+}
@@ -113,3 +113,28 @@ func TestShardChannel_GetOrCreateFamilyChannel(t *testing.T) {
 	f3 := ch.GetOrCreateFamilyChannel(3)
 	assert.Equal(t, f1, f3)
 }
+
+func TestShardChannel_InFlightRows(t *testing.T) {
+	ch := newShardChannel(context.TODO(), "database", 1, nil)
+	ch1 := ch.(*shardChannel)
+	ch1.cfg.MaxInFlightRows = 10
+
+	// always accept one batch if no rows in flight
+	assert.True(t, ch.acquire(20))
+	assert.False(t, ch.acquire(1))
+	ch.release(20)
+
+	assert.True(t, ch.acquire(6))
+	assert.True(t, ch.acquire(4))
+	assert.False(t, ch.acquire(1))
+	ch.release(4)
+	assert.True(t, ch.acquire(1))
+	ch.release(1)
+	ch.release(6)
+	assert.Equal(t, int64(0), ch1.inFlightRows.Load())
+
+	// no limit
+	ch1.cfg.MaxInFlightRows = 0
+	assert.True(t, ch.acquire(100))
+	assert.True(t, ch.acquire(100))
+}
@@ -27,4 +27,33 @@ var (
 	// ErrFamilyChannelCanceled is the error returned when a family channel is closed.
 	ErrFamilyChannelCanceled = errors.New("family Channel is canceled")
 	ErrIngestTimeout         = errors.New("ingest timout")
+	// ErrChannelBackpressure is the error returned when too many rows in flight of shard channel, client need back off.
+	ErrChannelBackpressure = errors.New("shard channel backpressure, too many in-flight rows")
+	// ErrRateLimited is the error returned when ingestion rate of database exceeds the limit, client need back off.
+	ErrRateLimited = errors.New("ingestion rate limited")
+	// ErrInvalidRows is the error returned when some rows violate the write limits of database, client should fix the data.
+	ErrInvalidRows = errors.New("rows violate write limits")
+	// ErrQuarantineRecordNotFound is the error returned when quarantine record not exist or already reprocessed.
+	ErrQuarantineRecordNotFound = errors.New("quarantine record not found")
 )
+
+// retryableErrors represents the errors caused by shard temporarily unavailable, client can retry later.
+var retryableErrors = []error{
+	errChannelNotFound,
+	errInvalidShardID,
+	ErrChannelBackpressure,
+	ErrRateLimited,
+	ErrFamilyChannelCanceled,
+	ErrIngestTimeout,
+}
+
+// IsRetryableError returns if the write error is retryable(shard temporarily unavailable),
+// other errors(e.g. parse/validation error) are fatal, client should give up.
+func IsRetryableError(err error) bool {
+	for _, retryableErr := range retryableErrors {
+		if errors.Is(err, retryableErr) {
+			return true
+		}
+	}
+	return false
+}
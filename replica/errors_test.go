@@ -0,0 +1,47 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/queue"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	cases := []struct {
+		err       error
+		retryable bool
+	}{
+		{err: errChannelNotFound, retryable: true},
+		{err: errInvalidShardID, retryable: true},
+		{err: ErrChannelBackpressure, retryable: true},
+		{err: ErrFamilyChannelCanceled, retryable: true},
+		{err: ErrIngestTimeout, retryable: true},
+		{err: fmt.Errorf("write shard: %w", ErrChannelBackpressure), retryable: true},
+		{err: errInvalidShardNum, retryable: false},
+		{err: queue.ErrExceedingMessageSizeLimit, retryable: false},
+		{err: fmt.Errorf("parse record failure"), retryable: false},
+	}
+	for _, tt := range cases {
+		assert.Equal(t, tt.retryable, IsRetryableError(tt.err), tt.err.Error())
+	}
+}
@@ -0,0 +1,109 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/metric"
+)
+
+// maxTrackedMetrics represents the max num. of metric names tracked for write statistics of each database,
+// statistics of the least recently written metric are folded into other bucket if exceeds.
+const maxTrackedMetrics = 1024
+
+// metricWriteStats tracks the write statistics keyed by metric name with LRU eviction.
+type metricWriteStats struct {
+	capacity int
+	lru      *list.List               // element value: *models.MetricWriteStats, front is most recently written
+	metrics  map[string]*list.Element // metric name => lru element
+	other    models.MetricWriteStats  // folded statistics of evicted metrics
+
+	mutex sync.Mutex
+}
+
+// newMetricWriteStats creates the metric write statistics with max num. of tracked metrics.
+func newMetricWriteStats(capacity int) *metricWriteStats {
+	return &metricWriteStats{
+		capacity: capacity,
+		lru:      list.New(),
+		metrics:  make(map[string]*list.Element),
+		other:    models.MetricWriteStats{MetricName: models.OtherMetricName},
+	}
+}
+
+// record records the write statistics of rows, out-of-time-range rows are counted as dropped.
+func (s *metricWriteStats) record(rows []metric.BrokerRow, accepted bool) {
+	if len(rows) == 0 {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for idx := range rows {
+		row := &rows[idx]
+		m := row.Metric()
+		stats := s.getOrCreate(m.Name())
+		if accepted && !row.IsOutOfTimeRange {
+			stats.AcceptedRows++
+			stats.AcceptedBytes += int64(row.Size())
+		} else {
+			stats.DroppedRows++
+		}
+	}
+}
+
+// getOrCreate returns the statistics of metric, evicts the least recently written metric if full.
+func (s *metricWriteStats) getOrCreate(metricName []byte) *models.MetricWriteStats {
+	if elem, ok := s.metrics[string(metricName)]; ok {
+		s.lru.MoveToFront(elem)
+		return elem.Value.(*models.MetricWriteStats)
+	}
+	if s.lru.Len() >= s.capacity {
+		oldest := s.lru.Back()
+		evicted := s.lru.Remove(oldest).(*models.MetricWriteStats)
+		delete(s.metrics, evicted.MetricName)
+		s.other.AcceptedRows += evicted.AcceptedRows
+		s.other.AcceptedBytes += evicted.AcceptedBytes
+		s.other.DroppedRows += evicted.DroppedRows
+	}
+	stats := &models.MetricWriteStats{MetricName: string(metricName)}
+	s.metrics[stats.MetricName] = s.lru.PushFront(stats)
+	return stats
+}
+
+// snapshot returns the write statistics sorted by metric name, other bucket is the last one if not empty.
+func (s *metricWriteStats) snapshot() []models.MetricWriteStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rs := make([]models.MetricWriteStats, 0, s.lru.Len()+1)
+	for elem := s.lru.Front(); elem != nil; elem = elem.Next() {
+		rs = append(rs, *elem.Value.(*models.MetricWriteStats))
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].MetricName < rs[j].MetricName
+	})
+	if s.other != (models.MetricWriteStats{MetricName: models.OtherMetricName}) {
+		rs = append(rs, s.other)
+	}
+	return rs
+}
@@ -0,0 +1,76 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/pkg/timeutil"
+	protoMetricsV1 "github.com/lindb/common/proto/gen/v1/linmetrics"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/metric"
+)
+
+func TestMetricWriteStats_LRU(t *testing.T) {
+	converter := metric.NewProtoConverter(models.NewDefaultLimits())
+	newRows := func(metricNames ...string) []metric.BrokerRow {
+		batch := metric.NewBrokerBatchRows()
+		for idx := range metricNames {
+			_ = batch.TryAppend(func(row *metric.BrokerRow) error {
+				return converter.ConvertTo(&protoMetricsV1.Metric{
+					Name:      metricNames[idx],
+					Timestamp: timeutil.Now(),
+					SimpleFields: []*protoMetricsV1.SimpleField{
+						{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1}},
+				}, row)
+			})
+		}
+		return batch.Rows()
+	}
+	stats := newMetricWriteStats(2)
+	stats.record(nil, true)
+	assert.Empty(t, stats.snapshot())
+
+	stats.record(newRows("a", "b", "a"), true)
+	rows := newRows("b")
+	rows[0].IsOutOfTimeRange = true
+	stats.record(rows, true)
+	// a is least recently written, fold into other bucket
+	stats.record(newRows("c"), false)
+	rs := stats.snapshot()
+	assert.Len(t, rs, 3)
+	assert.Equal(t, "b", rs[0].MetricName)
+	assert.Equal(t, int64(1), rs[0].AcceptedRows)
+	assert.Equal(t, int64(1), rs[0].DroppedRows)
+	assert.Equal(t, "c", rs[1].MetricName)
+	assert.Equal(t, int64(0), rs[1].AcceptedRows)
+	assert.Equal(t, int64(1), rs[1].DroppedRows)
+	assert.Equal(t, models.OtherMetricName, rs[2].MetricName)
+	assert.Equal(t, int64(2), rs[2].AcceptedRows)
+	assert.True(t, rs[2].AcceptedBytes > 0)
+
+	// b is touched, c is evicted
+	stats.record(newRows("b", "d"), true)
+	rs = stats.snapshot()
+	assert.Equal(t, []string{"b", "d", models.OtherMetricName},
+		[]string{rs[0].MetricName, rs[1].MetricName, rs[2].MetricName})
+	assert.Equal(t, int64(1), rs[2].DroppedRows)
+}
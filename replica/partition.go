@@ -82,9 +82,10 @@ type partition struct {
 	shard         tsdb.Shard
 	family        tsdb.DataFamily
 
-	peers    map[models.NodeID]ReplicatorPeer
-	cliFct   rpc.ClientStreamFactory
-	stateMgr storage.StateManager
+	peers      map[models.NodeID]ReplicatorPeer
+	cliFct     rpc.ClientStreamFactory
+	stateMgr   storage.StateManager
+	quarantine Quarantine
 
 	mutex sync.Mutex
 
@@ -102,6 +103,7 @@ func NewPartition(
 	log queue.FanOutQueue,
 	cliFct rpc.ClientStreamFactory,
 	stateMgr storage.StateManager,
+	quarantine Quarantine,
 ) Partition {
 	c, cancel := context.WithCancel(ctx)
 	return &partition{
@@ -115,6 +117,7 @@ func NewPartition(
 		currentNodeID: currentNodeID,
 		cliFct:        cliFct,
 		stateMgr:      stateMgr,
+		quarantine:    quarantine,
 		peers:         make(map[models.NodeID]ReplicatorPeer),
 		statistics:    metrics.NewStorageWriteAheadLogStatistics(shard.Database().Name(), shard.ShardID().String()),
 		logger:        logger.GetLogger("Replica", "Partition"),
@@ -335,7 +338,7 @@ func (p *partition) buildReplica(leader, replica models.NodeID) error {
 	}
 	if replica == p.currentNodeID {
 		// local replicator
-		replicator = newLocalReplicatorFn(&channel, p.shard, p.family)
+		replicator = newLocalReplicatorFn(&channel, p.shard, p.family, p.quarantine)
 	} else {
 		// build remote replicator
 		replicator = newRemoteReplicatorFn(p.ctx, &channel, p.stateMgr, p.cliFct)
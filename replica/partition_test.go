@@ -54,7 +54,7 @@ func TestPartition_BuildReplicaRelation(t *testing.T) {
 	r.EXPECT().String().Return("TestPartition_BuildReplicaRelation").AnyTimes()
 	r.EXPECT().ReplicaState().Return(&models.ReplicaState{}).AnyTimes()
 	r.EXPECT().Pending().Return(int64(10)).AnyTimes()
-	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard, _ tsdb.DataFamily) Replicator {
+	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard, _ tsdb.DataFamily, _ Quarantine) Replicator {
 		return r
 	}
 	newRemoteReplicatorFn = func(_ context.Context, _ *ReplicatorChannel,
@@ -67,7 +67,7 @@ func TestPartition_BuildReplicaRelation(t *testing.T) {
 	log.EXPECT().Queue().Return(q).AnyTimes()
 	log.EXPECT().GetOrCreateConsumerGroup(gomock.Any()).Return(nil, nil).MaxTimes(3)
 	family.EXPECT().TimeRange().Return(timeutil.TimeRange{}).AnyTimes()
-	p := NewPartition(context.TODO(), shard, family, 1, log, nil, nil)
+	p := NewPartition(context.TODO(), shard, family, 1, log, nil, nil, nil)
 	err := p.BuildReplicaForLeader(2, []models.NodeID{1, 2, 3})
 	assert.Error(t, err)
 
@@ -91,7 +91,7 @@ func TestPartition_BuildReplicaRelation(t *testing.T) {
 	assert.Equal(t, "path", p.Path())
 
 	// create consume group failure
-	p = NewPartition(context.TODO(), shard, family, 1, log, nil, nil)
+	p = NewPartition(context.TODO(), shard, family, 1, log, nil, nil, nil)
 	log.EXPECT().GetOrCreateConsumerGroup(gomock.Any()).Return(nil, fmt.Errorf("err"))
 	err = p.BuildReplicaForLeader(1, []models.NodeID{1, 2, 3})
 	assert.Error(t, err)
@@ -115,7 +115,7 @@ func TestPartition_BuildReplicaForFollower(t *testing.T) {
 	r.EXPECT().String().Return("TestPartition_BuildReplicaForFollower").AnyTimes()
 	r.EXPECT().ReplicaState().Return(&models.ReplicaState{}).AnyTimes()
 	r.EXPECT().Pending().Return(int64(10)).AnyTimes()
-	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard, _ tsdb.DataFamily) Replicator {
+	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard, _ tsdb.DataFamily, _ Quarantine) Replicator {
 		return r
 	}
 	newRemoteReplicatorFn = func(_ context.Context, _ *ReplicatorChannel,
@@ -126,7 +126,7 @@ func TestPartition_BuildReplicaForFollower(t *testing.T) {
 	log := queue.NewMockFanOutQueue(ctrl)
 	log.EXPECT().GetOrCreateConsumerGroup(gomock.Any()).Return(nil, nil)
 	family.EXPECT().TimeRange().Return(timeutil.TimeRange{}).AnyTimes()
-	p := NewPartition(context.TODO(), shard, family, 1, log, nil, nil)
+	p := NewPartition(context.TODO(), shard, family, 1, log, nil, nil, nil)
 	err := p.BuildReplicaForFollower(2, 2)
 	assert.Error(t, err)
 
@@ -138,7 +138,7 @@ func TestPartition_BuildReplicaForFollower(t *testing.T) {
 
 	// create fan ot failure
 	log.EXPECT().GetOrCreateConsumerGroup(gomock.Any()).Return(nil, fmt.Errorf("err"))
-	p = NewPartition(context.TODO(), shard, family, 1, log, nil, nil)
+	p = NewPartition(context.TODO(), shard, family, 1, log, nil, nil, nil)
 	err = p.BuildReplicaForFollower(2, 1)
 	assert.Error(t, err)
 }
@@ -163,7 +163,7 @@ func TestPartition_Close(t *testing.T) {
 	l.EXPECT().GetOrCreateConsumerGroup(gomock.Any()).Return(nil, nil).AnyTimes()
 	r.EXPECT().String().Return("TestPartition_Close").AnyTimes()
 	r.EXPECT().Pending().Return(int64(10)).AnyTimes()
-	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard, _ tsdb.DataFamily) Replicator {
+	newLocalReplicatorFn = func(_ *ReplicatorChannel, _ tsdb.Shard, _ tsdb.DataFamily, _ Quarantine) Replicator {
 		return r
 	}
 	newRemoteReplicatorFn = func(_ context.Context, _ *ReplicatorChannel,
@@ -173,7 +173,7 @@ func TestPartition_Close(t *testing.T) {
 
 	l.EXPECT().Close().MaxTimes(2)
 	family.EXPECT().TimeRange().Return(timeutil.TimeRange{}).AnyTimes()
-	p := NewPartition(context.TODO(), shard, family, 1, l, nil, nil)
+	p := NewPartition(context.TODO(), shard, family, 1, l, nil, nil, nil)
 	err := p.Close()
 	assert.NoError(t, err)
 	r.EXPECT().IsReady().Return(true).AnyTimes()
@@ -200,7 +200,7 @@ func TestPartition_WriteLog(t *testing.T) {
 	shard.EXPECT().ShardID().Return(models.ShardID(1)).AnyTimes()
 	family := tsdb.NewMockDataFamily(ctrl)
 	family.EXPECT().FamilyTime().Return(commontimeutil.Now()).AnyTimes()
-	p := NewPartition(context.TODO(), shard, family, 1, l, nil, nil)
+	p := NewPartition(context.TODO(), shard, family, 1, l, nil, nil, nil)
 	q.EXPECT().Put(gomock.Any()).Return(fmt.Errorf("err"))
 	err := p.WriteLog([]byte{1})
 	assert.Error(t, err)
@@ -227,7 +227,7 @@ func TestPartition_ReplicaLog(t *testing.T) {
 	shard.EXPECT().ShardID().Return(models.ShardID(1)).AnyTimes()
 	family := tsdb.NewMockDataFamily(ctrl)
 	family.EXPECT().FamilyTime().Return(commontimeutil.Now()).AnyTimes()
-	p := NewPartition(context.TODO(), shard, family, 1, l, nil, nil)
+	p := NewPartition(context.TODO(), shard, family, 1, l, nil, nil, nil)
 	// case 1: replica idx err
 	q.EXPECT().AppendedSeq().Return(int64(8))
 	idx, err := p.ReplicaLog(10, []byte{1})
@@ -264,7 +264,7 @@ func TestPartition_getReplicaState(t *testing.T) {
 	shard := tsdb.NewMockShard(ctrl)
 	shard.EXPECT().Database().Return(db).AnyTimes()
 	shard.EXPECT().ShardID().Return(models.ShardID(1)).AnyTimes()
-	p := NewPartition(context.TODO(), shard, family, 1, l, nil, nil)
+	p := NewPartition(context.TODO(), shard, family, 1, l, nil, nil, nil)
 	p1 := p.(*partition)
 	peer := NewMockReplicatorPeer(ctrl)
 	peer.EXPECT().ReplicatorState().Return("remote", &state{state: models.ReplicatorReadyState}).AnyTimes()
@@ -377,7 +377,7 @@ func TestPartition_recovery(t *testing.T) {
 		q := queue.NewMockConsumerGroup(ctrl)
 		log.EXPECT().GetOrCreateConsumerGroup(gomock.Any()).Return(q, nil)
 		family.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: commontimeutil.Now()})
-		newLocalReplicatorFn = func(channel *ReplicatorChannel, shard tsdb.Shard, family tsdb.DataFamily) Replicator {
+		newLocalReplicatorFn = func(channel *ReplicatorChannel, shard tsdb.Shard, family tsdb.DataFamily, quarantine Quarantine) Replicator {
 			return nil
 		}
 		peer := NewMockReplicatorPeer(ctrl)
@@ -0,0 +1,250 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/snappy"
+	"golang.org/x/time/rate"
+
+	"github.com/lindb/common/pkg/encoding"
+	"github.com/lindb/common/pkg/fileutil"
+	"github.com/lindb/common/pkg/logger"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/tsdb"
+)
+
+//go:generate mockgen -source=./quarantine.go -destination=./quarantine_mock.go -package=replica
+
+const (
+	quarantineDataSuffix = ".data"
+	quarantineMetaSuffix = ".json"
+	// reprocessPerSecond represents the max num. of quarantine records reprocessed per second,
+	// avoids reprocessing impacts the normal write.
+	reprocessPerSecond = 10
+)
+
+// for testing
+var (
+	writeFileFn = os.WriteFile
+	readFileFn  = os.ReadFile
+)
+
+// Quarantine represents the store of replica messages which failed writing into local storage,
+// keeps the message with failure reason for troubleshooting, then reprocesses it after fixing the problem.
+type Quarantine interface {
+	// Put puts the failed replica message into quarantine.
+	Put(record *models.QuarantineRecord, msg []byte) error
+	// List returns all records in quarantine, order by id.
+	List() ([]*models.QuarantineRecord, error)
+	// Reprocess writes the message of record into local storage again, removes the record if success.
+	// Reprocess is idempotent, returns ErrQuarantineRecordNotFound if record not exist(already reprocessed).
+	Reprocess(ctx context.Context, id string) error
+}
+
+// quarantine implements Quarantine based on local file system,
+// each record includes message file(xxx.data) and metadata file(xxx.json).
+type quarantine struct {
+	dir     string
+	engine  tsdb.Engine
+	limiter *rate.Limiter
+
+	mutex  sync.Mutex
+	logger logger.Logger
+}
+
+// NewQuarantine creates a Quarantine instance which stores records under given dir.
+func NewQuarantine(dir string, engine tsdb.Engine) Quarantine {
+	return &quarantine{
+		dir:     dir,
+		engine:  engine,
+		limiter: rate.NewLimiter(rate.Limit(reprocessPerSecond), 1),
+		logger:  logger.GetLogger("Replica", "Quarantine"),
+	}
+}
+
+// quarantineDir returns the quarantine dir for wal dir, cannot put it under wal dir,
+// because all sub dirs of wal dir are treated as database when recovery.
+func quarantineDir(walDir string) string {
+	return filepath.Clean(walDir) + "_quarantine"
+}
+
+// quarantineID returns the unique id of replica message(database+shard+family+leader+sequence),
+// so that the same message quarantined again(wal replay) will overwrite the old one.
+func quarantineID(record *models.QuarantineRecord) string {
+	return fmt.Sprintf("%s_%d_%d_%d_%d", record.Database, record.ShardID, record.FamilyTime, record.Leader, record.Sequence)
+}
+
+// Put puts the failed replica message into quarantine.
+func (q *quarantine) Put(record *models.QuarantineRecord, msg []byte) error {
+	record.ID = quarantineID(record)
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if err := fileutil.MkDirIfNotExist(q.dir); err != nil {
+		return err
+	}
+	// write message first, metadata file marks the record is completed.
+	if err := writeFileFn(q.dataFile(record.ID), msg, 0644); err != nil {
+		return err
+	}
+	return q.writeMeta(record)
+}
+
+// List returns all records in quarantine, order by id.
+func (q *quarantine) List() ([]*models.QuarantineRecord, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	rs := make([]*models.QuarantineRecord, 0)
+	if !fileutil.Exist(q.dir) {
+		return rs, nil
+	}
+	files, err := fileutil.ListDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if !strings.HasSuffix(file, quarantineMetaSuffix) {
+			continue
+		}
+		record, err := q.readMeta(strings.TrimSuffix(file, quarantineMetaSuffix))
+		if err != nil {
+			q.logger.Warn("read quarantine record failure, ignore it",
+				logger.String("file", file), logger.Error(err))
+			continue
+		}
+		rs = append(rs, record)
+	}
+	return rs, nil
+}
+
+// Reprocess writes the message of record into local storage again, removes the record if success.
+func (q *quarantine) Reprocess(ctx context.Context, id string) error {
+	if err := q.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if strings.ContainsAny(id, `/\`) || !fileutil.Exist(q.metaFile(id)) {
+		return fmt.Errorf("%w, id: %s", ErrQuarantineRecordNotFound, id)
+	}
+	record, err := q.readMeta(id)
+	if err != nil {
+		return err
+	}
+	msg, err := readFileFn(q.dataFile(id))
+	if err != nil {
+		return err
+	}
+	if err0 := q.write(record, msg); err0 != nil {
+		// keep record for next reprocessing
+		record.Reason = err0.Error()
+		record.Attempts++
+		if err := q.writeMeta(record); err != nil {
+			q.logger.Warn("update quarantine record failure",
+				logger.String("id", id), logger.Error(err))
+		}
+		return err0
+	}
+	// remove metadata first, the record is invisible even if removing message failure.
+	if err := fileutil.RemoveFile(q.metaFile(id)); err != nil {
+		return err
+	}
+	if err := fileutil.RemoveFile(q.dataFile(id)); err != nil {
+		q.logger.Warn("remove quarantine message failure",
+			logger.String("id", id), logger.Error(err))
+	}
+	q.logger.Info("reprocess quarantine record successfully", logger.String("id", id))
+	return nil
+}
+
+// write writes the message of record into the data family.
+func (q *quarantine) write(record *models.QuarantineRecord, msg []byte) (err error) {
+	shard, ok := q.engine.GetShard(record.Database, record.ShardID)
+	if !ok {
+		return fmt.Errorf("shard: %d of database: %s not exist", record.ShardID, record.Database)
+	}
+	family, err := shard.GetOrCrateDataFamily(record.FamilyTime)
+	if err != nil {
+		return err
+	}
+	family.Retain()
+	defer family.Release()
+
+	// unmarshal rows will panic when data are corrupted
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unmarshal rows failure: %v", r)
+		}
+	}()
+
+	block, err := snappy.Decode(nil, msg)
+	if err != nil {
+		return err
+	}
+	batchRows := metric.NewStorageBatchRows()
+	batchRows.UnmarshalRows(block)
+	if batchRows.Len() == 0 {
+		return nil
+	}
+	rows := batchRows.Rows()
+	if err := shard.LookupRowMetricMeta(rows); err != nil {
+		return err
+	}
+	return family.WriteRows(rows)
+}
+
+// readMeta reads metadata of record by id.
+func (q *quarantine) readMeta(id string) (*models.QuarantineRecord, error) {
+	data, err := readFileFn(q.metaFile(id))
+	if err != nil {
+		return nil, err
+	}
+	record := &models.QuarantineRecord{}
+	if err := encoding.JSONUnmarshal(data, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// writeMeta writes metadata of record.
+func (q *quarantine) writeMeta(record *models.QuarantineRecord) error {
+	return writeFileFn(q.metaFile(record.ID), encoding.JSONMarshal(record), 0644)
+}
+
+func (q *quarantine) dataFile(id string) string {
+	return filepath.Join(q.dir, id+quarantineDataSuffix)
+}
+
+func (q *quarantine) metaFile(id string) string {
+	return filepath.Join(q.dir, id+quarantineMetaSuffix)
+}
@@ -0,0 +1,316 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/pkg/fasttime"
+	protoMetricsV1 "github.com/lindb/common/proto/gen/v1/linmetrics"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/queue"
+	"github.com/lindb/lindb/series/metric"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func newReplicaMessage(t *testing.T) []byte {
+	buf := &bytes.Buffer{}
+	converter := metric.NewProtoConverter(models.NewDefaultLimits())
+	var row metric.BrokerRow
+	assert.NoError(t, converter.ConvertTo(&protoMetricsV1.Metric{
+		Namespace: "test",
+		Name:      "test",
+		Timestamp: fasttime.UnixMilliseconds(),
+		SimpleFields: []*protoMetricsV1.SimpleField{
+			{Name: "f1", Type: protoMetricsV1.SimpleFieldType_DELTA_SUM, Value: 1},
+		},
+	}, &row))
+	_, _ = row.WriteTo(buf)
+	return snappy.Encode(nil, buf.Bytes())
+}
+
+func TestQuarantine_ReplicaFailure_Reprocess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	database := tsdb.NewMockDatabase(ctrl)
+	database.EXPECT().Name().Return("test-db").AnyTimes()
+	shard := tsdb.NewMockShard(ctrl)
+	shard.EXPECT().Database().Return(database).AnyTimes()
+	shard.EXPECT().ShardID().Return(models.ShardID(1)).AnyTimes()
+	family := tsdb.NewMockDataFamily(ctrl)
+	family.EXPECT().Retain().AnyTimes()
+	family.EXPECT().Release().AnyTimes()
+	family.EXPECT().AckSequence(gomock.Any(), gomock.Any()).AnyTimes()
+	family.EXPECT().ValidateSequence(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	family.EXPECT().CommitSequence(gomock.Any(), gomock.Any()).AnyTimes()
+	engine := tsdb.NewMockEngine(ctrl)
+	q := queue.NewMockConsumerGroup(ctrl)
+	q.EXPECT().ConsumedSeq().Return(int64(10)).AnyTimes()
+	q.EXPECT().SetConsumedSeq(gomock.Any()).AnyTimes()
+	q.EXPECT().AcknowledgedSeq().Return(int64(0)).AnyTimes()
+	q.EXPECT().Ack(gomock.Any()).AnyTimes()
+
+	quarantine := NewQuarantine(filepath.Join(t.TempDir(), "wal_quarantine"), engine)
+	replicator := NewLocalReplicator(&ReplicatorChannel{
+		State:         &models.ReplicaState{Database: "test-db", ShardID: 1, Leader: 2, FamilyTime: 100},
+		ConsumerGroup: q,
+	}, shard, family, quarantine)
+
+	// force write failure, message put into quarantine
+	msg := newReplicaMessage(t)
+	shard.EXPECT().LookupRowMetricMeta(gomock.Any()).Return(nil)
+	family.EXPECT().WriteRows(gomock.Any()).Return(fmt.Errorf("disk full"))
+	replicator.Replica(1, msg)
+
+	records, err := quarantine.List()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	record := records[0]
+	assert.Equal(t, "test-db_1_100_2_1", record.ID)
+	assert.Equal(t, "test-db", record.Database)
+	assert.Equal(t, models.ShardID(1), record.ShardID)
+	assert.Equal(t, int64(100), record.FamilyTime)
+	assert.Equal(t, models.NodeID(2), record.Leader)
+	assert.Equal(t, int64(1), record.Sequence)
+	assert.Equal(t, "disk full", record.Reason)
+
+	// reprocess failure, keep record
+	engine.EXPECT().GetShard("test-db", models.ShardID(1)).Return(shard, true).AnyTimes()
+	shard.EXPECT().GetOrCrateDataFamily(int64(100)).Return(family, nil).AnyTimes()
+	shard.EXPECT().LookupRowMetricMeta(gomock.Any()).Return(fmt.Errorf("lookup failure"))
+	err = quarantine.Reprocess(context.TODO(), record.ID)
+	assert.Error(t, err)
+	records, err = quarantine.List()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "lookup failure", records[0].Reason)
+	assert.Equal(t, 1, records[0].Attempts)
+
+	// reprocess successfully, remove record
+	shard.EXPECT().LookupRowMetricMeta(gomock.Any()).Return(nil)
+	family.EXPECT().WriteRows(gomock.Any()).Return(nil)
+	err = quarantine.Reprocess(context.TODO(), record.ID)
+	assert.NoError(t, err)
+	records, err = quarantine.List()
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	// reprocess again, idempotent
+	err = quarantine.Reprocess(context.TODO(), record.ID)
+	assert.True(t, errors.Is(err, ErrQuarantineRecordNotFound))
+}
+
+func TestQuarantine_Put(t *testing.T) {
+	dir := t.TempDir()
+	// dir is file
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(file, []byte("file"), 0644))
+	q := NewQuarantine(file, nil)
+	assert.Error(t, q.Put(&models.QuarantineRecord{}, []byte{1}))
+
+	q = NewQuarantine(filepath.Join(dir, "quarantine"), nil)
+	// write message failure
+	writeFileFn = func(_ string, _ []byte, _ os.FileMode) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, q.Put(&models.QuarantineRecord{}, []byte{1}))
+	writeFileFn = os.WriteFile
+
+	// same message quarantined again, overwrite old one
+	assert.NoError(t, q.Put(&models.QuarantineRecord{Database: "db", Sequence: 1, Reason: "a"}, []byte{1}))
+	assert.NoError(t, q.Put(&models.QuarantineRecord{Database: "db", Sequence: 1, Reason: "b"}, []byte{1}))
+	records, err := q.List()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "b", records[0].Reason)
+}
+
+func TestQuarantine_List(t *testing.T) {
+	dir := t.TempDir()
+	// dir not exist
+	q := NewQuarantine(filepath.Join(dir, "not-exist"), nil)
+	records, err := q.List()
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+	// dir is file
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(file, []byte("file"), 0644))
+	q = NewQuarantine(file, nil)
+	records, err = q.List()
+	assert.Error(t, err)
+	assert.Nil(t, records)
+	// bad metadata
+	qDir := filepath.Join(dir, "quarantine")
+	q = NewQuarantine(qDir, nil)
+	assert.NoError(t, q.Put(&models.QuarantineRecord{Database: "db", Sequence: 1}, []byte{1}))
+	assert.NoError(t, os.WriteFile(filepath.Join(qDir, "bad.json"), []byte("bad"), 0644))
+	records, err = q.List()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestQuarantine_Reprocess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readFileFn = os.ReadFile
+		ctrl.Finish()
+	}()
+	engine := tsdb.NewMockEngine(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	family := tsdb.NewMockDataFamily(ctrl)
+	family.EXPECT().Retain().AnyTimes()
+	family.EXPECT().Release().AnyTimes()
+	dir := t.TempDir()
+	q := NewQuarantine(dir, engine)
+	record := &models.QuarantineRecord{Database: "db", ShardID: 1, FamilyTime: 10, Sequence: 1}
+	put := func(msg []byte) {
+		assert.NoError(t, q.Put(record, msg))
+	}
+
+	cases := []struct {
+		name    string
+		id      string
+		prepare func()
+		wantErr bool
+	}{
+		{
+			name:    "invalid id",
+			id:      "../db",
+			wantErr: true,
+		},
+		{
+			name: "read metadata failure",
+			prepare: func() {
+				put([]byte{1})
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, record.ID+quarantineMetaSuffix), []byte("bad"), 0644))
+			},
+			wantErr: true,
+		},
+		{
+			name: "read message failure",
+			prepare: func() {
+				put([]byte{1})
+				readFileFn = func(name string) ([]byte, error) {
+					if filepath.Ext(name) == quarantineDataSuffix {
+						return nil, fmt.Errorf("err")
+					}
+					return os.ReadFile(name)
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "shard not exist",
+			prepare: func() {
+				put([]byte{1})
+				engine.EXPECT().GetShard(gomock.Any(), gomock.Any()).Return(nil, false)
+			},
+			wantErr: true,
+		},
+		{
+			name: "get family failure",
+			prepare: func() {
+				put([]byte{1})
+				engine.EXPECT().GetShard(gomock.Any(), gomock.Any()).Return(shard, true)
+				shard.EXPECT().GetOrCrateDataFamily(gomock.Any()).Return(nil, fmt.Errorf("err"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "decompress failure",
+			prepare: func() {
+				put([]byte{1, 2, 3})
+				engine.EXPECT().GetShard(gomock.Any(), gomock.Any()).Return(shard, true)
+				shard.EXPECT().GetOrCrateDataFamily(gomock.Any()).Return(family, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "corrupted data",
+			prepare: func() {
+				put(snappy.Encode(nil, []byte("bad-data")))
+				engine.EXPECT().GetShard(gomock.Any(), gomock.Any()).Return(shard, true)
+				shard.EXPECT().GetOrCrateDataFamily(gomock.Any()).Return(family, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty rows",
+			prepare: func() {
+				put(snappy.Encode(nil, []byte{}))
+				engine.EXPECT().GetShard(gomock.Any(), gomock.Any()).Return(shard, true)
+				shard.EXPECT().GetOrCrateDataFamily(gomock.Any()).Return(family, nil)
+			},
+		},
+		{
+			name: "write failure",
+			prepare: func() {
+				put(newReplicaMessage(t))
+				engine.EXPECT().GetShard(gomock.Any(), gomock.Any()).Return(shard, true)
+				shard.EXPECT().GetOrCrateDataFamily(gomock.Any()).Return(family, nil)
+				shard.EXPECT().LookupRowMetricMeta(gomock.Any()).Return(nil)
+				family.EXPECT().WriteRows(gomock.Any()).Return(fmt.Errorf("err"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				readFileFn = os.ReadFile
+			}()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			id := tt.id
+			if id == "" {
+				id = record.ID
+			}
+			err := q.Reprocess(context.TODO(), id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reprocess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuarantine_Reprocess_RateLimit(t *testing.T) {
+	q := NewQuarantine(t.TempDir(), nil)
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	err := q.Reprocess(ctx, "id")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrQuarantineRecordNotFound))
+}
+
+func TestQuarantineDir(t *testing.T) {
+	assert.Equal(t, filepath.Join("data", "wal_quarantine"), quarantineDir(filepath.Join("data", "wal")+"/"))
+}
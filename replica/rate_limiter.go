@@ -0,0 +1,118 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/lindb/lindb/models"
+)
+
+// RateLimitError represents the error when ingestion rate of database exceeds the limit,
+// includes the hint that how long client need to wait before retry.
+type RateLimitError struct {
+	Database   string
+	RetryAfter time.Duration
+}
+
+// Error returns the error message.
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("database [%s] %s, retry after %s", e.Database, ErrRateLimited.Error(), e.RetryAfter)
+}
+
+// Unwrap returns ErrRateLimited, so that errors.Is(err, ErrRateLimited) works.
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// databaseLimiter represents the token bucket limiter with its configuration for database.
+type databaseLimiter struct {
+	limiter       *rate.Limiter
+	rowsPerSecond int
+	burst         int
+}
+
+// rateLimiter limits the ingestion rate(rows/sec) keyed by database name.
+type rateLimiter struct {
+	limiters map[string]*databaseLimiter
+	mutex    sync.Mutex
+}
+
+// newRateLimiter creates a rate limiter for all databases.
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		limiters: make(map[string]*databaseLimiter),
+	}
+}
+
+// Allow checks if accepts num. of rows for database based on the limits,
+// returns *RateLimitError if exceeds the limit(no tokens consumed).
+// Limiter state is reset if limits changed.
+func (l *rateLimiter) Allow(database string, limits *models.Limits, rows int) error {
+	if limits == nil || !limits.EnableRowsRateLimit() {
+		l.Reset(database)
+		return nil
+	}
+	rowsPerSecond := limits.MaxRowsPerSecond
+	burst := limits.GetRowsBurst()
+	now := time.Now()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	dl, ok := l.limiters[database]
+	if !ok || dl.rowsPerSecond != rowsPerSecond || dl.burst != burst {
+		// create new limiter(full bucket) if not exist or limits changed
+		dl = &databaseLimiter{
+			limiter:       rate.NewLimiter(rate.Limit(rowsPerSecond), burst),
+			rowsPerSecond: rowsPerSecond,
+			burst:         burst,
+		}
+		l.limiters[database] = dl
+	}
+	// batch larger than burst never fits the bucket, takes the whole bucket for it
+	if rows > burst {
+		rows = burst
+	}
+	r := dl.limiter.ReserveN(now, rows)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return &RateLimitError{Database: database, RetryAfter: delay}
+	}
+	return nil
+}
+
+// Reset removes the limiter state of database, new limiter will be created based on current limits.
+func (l *rateLimiter) Reset(database string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.limiters, database)
+}
+
+// ResetAll removes the limiter state of all databases.
+func (l *rateLimiter) ResetAll() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.limiters = make(map[string]*databaseLimiter)
+}
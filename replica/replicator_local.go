@@ -20,6 +20,7 @@ package replica
 import (
 	"github.com/golang/snappy"
 
+	"github.com/lindb/common/pkg/fasttime"
 	"github.com/lindb/common/pkg/logger"
 
 	"github.com/lindb/lindb/metrics"
@@ -40,10 +41,18 @@ type localReplicator struct {
 
 	block []byte
 
+	quarantine Quarantine
+
 	statistics *metrics.StorageLocalReplicatorStatistics
 }
 
-func NewLocalReplicator(channel *ReplicatorChannel, shard tsdb.Shard, family tsdb.DataFamily) Replicator {
+// NewLocalReplicator creates a local replicator, the message which failed writing will be put into quarantine(if not nil).
+func NewLocalReplicator(
+	channel *ReplicatorChannel,
+	shard tsdb.Shard,
+	family tsdb.DataFamily,
+	quarantine Quarantine,
+) Replicator {
 	lr := &localReplicator{
 		leader: int32(channel.State.Leader),
 		replicator: replicator{
@@ -55,6 +64,7 @@ func NewLocalReplicator(channel *ReplicatorChannel, shard tsdb.Shard, family tsd
 		statistics: metrics.NewStorageLocalReplicatorStatistics(channel.State.Database, channel.State.ShardID.String()),
 		logger:     logger.GetLogger("Replica", "LocalReplicator"),
 		block:      make([]byte, 256*1024),
+		quarantine: quarantine,
 	}
 
 	// add ack sequence callback
@@ -87,6 +97,7 @@ func (r *localReplicator) State() *state {
 // 3. lookup metadata
 // 4. write metric data
 // 5. commit sequence in data family
+// if replica failure, put message into quarantine for reprocessing.
 func (r *localReplicator) Replica(sequence int64, msg []byte) {
 	var err error
 
@@ -120,6 +131,7 @@ func (r *localReplicator) Replica(sequence int64, msg []byte) {
 			logger.Int("message", len(msg)),
 			logger.String("replicator", r.String()),
 			logger.Error(err))
+		r.quarantineMessage(sequence, msg, err)
 		return
 	}
 
@@ -138,6 +150,7 @@ func (r *localReplicator) Replica(sequence int64, msg []byte) {
 			logger.Int("rows", r.batchRows.Len()),
 			logger.String("replicator", r.String()),
 			logger.Error(err))
+		r.quarantineMessage(sequence, msg, err)
 		return
 	}
 	// write metric data
@@ -148,11 +161,38 @@ func (r *localReplicator) Replica(sequence int64, msg []byte) {
 			logger.Int("rows", r.batchRows.Len()),
 			logger.String("replicator", r.String()),
 			logger.Error(err))
+		r.quarantineMessage(sequence, msg, err)
 		return
 	}
 	r.statistics.ReplicaRows.Add(float64(rowsLen))
 }
 
+// quarantineMessage puts the failure message into quarantine with failure reason.
+func (r *localReplicator) quarantineMessage(sequence int64, msg []byte, reason error) {
+	if r.quarantine == nil {
+		return
+	}
+	state := r.channel.State
+	record := &models.QuarantineRecord{
+		Database:   state.Database,
+		ShardID:    state.ShardID,
+		FamilyTime: state.FamilyTime,
+		Leader:     state.Leader,
+		Sequence:   sequence,
+		Reason:     reason.Error(),
+		Timestamp:  fasttime.UnixMilliseconds(),
+	}
+	if err := r.quarantine.Put(record, msg); err != nil {
+		r.statistics.QuarantineFailures.Incr()
+		r.logger.Error("put replica message into quarantine failure",
+			logger.Int64("sequence", sequence),
+			logger.String("replicator", r.String()),
+			logger.Error(err))
+		return
+	}
+	r.statistics.Quarantines.Incr()
+}
+
 // Close closes local replicator.
 func (r *localReplicator) Close() {
 	// mark write data completed.
@@ -57,7 +57,7 @@ func TestLocalReplicator_New(t *testing.T) {
 	q.EXPECT().AcknowledgedSeq().Return(int64(10)).AnyTimes()
 	q.EXPECT().Ack(int64(10))
 	q.EXPECT().SetConsumedSeq(int64(10))
-	replicator := NewLocalReplicator(&ReplicatorChannel{State: &models.ReplicaState{Leader: 1}, ConsumerGroup: q}, shard, family)
+	replicator := NewLocalReplicator(&ReplicatorChannel{State: &models.ReplicaState{Leader: 1}, ConsumerGroup: q}, shard, family, nil)
 	assert.NotNil(t, replicator)
 	s := replicator.State()
 	assert.Equal(t, state{state: models.ReplicatorReadyState}, *s)
@@ -86,12 +86,13 @@ func TestLocalReplicator_Replica(t *testing.T) {
 	q.EXPECT().Pending().Return(int64(10)).AnyTimes()
 	q.EXPECT().AcknowledgedSeq().Return(int64(0)).AnyTimes()
 	q.EXPECT().Ack(gomock.Any()).AnyTimes()
+	quarantine := NewMockQuarantine(ctrl)
 
 	replicator := NewLocalReplicator(
 		&ReplicatorChannel{
-			State:         &models.ReplicaState{Leader: 1},
+			State:         &models.ReplicaState{Database: "test-database", ShardID: 1, Leader: 1, FamilyTime: 10},
 			ConsumerGroup: q,
-		}, shard, family)
+		}, shard, family, quarantine)
 	assert.True(t, replicator.IsReady())
 	// bad sequence
 	family.EXPECT().ValidateSequence(gomock.Any(), gomock.Any()).Return(false)
@@ -99,7 +100,16 @@ func TestLocalReplicator_Replica(t *testing.T) {
 
 	family.EXPECT().ValidateSequence(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
 
-	// bad compressed data
+	// bad compressed data, put into quarantine
+	quarantine.EXPECT().Put(gomock.Any(), []byte{1, 2, 3}).DoAndReturn(func(record *models.QuarantineRecord, _ []byte) error {
+		assert.Equal(t, "test-database", record.Database)
+		assert.Equal(t, models.ShardID(1), record.ShardID)
+		assert.Equal(t, int64(10), record.FamilyTime)
+		assert.Equal(t, models.NodeID(1), record.Leader)
+		assert.Equal(t, int64(1), record.Sequence)
+		assert.NotEmpty(t, record.Reason)
+		return nil
+	})
 	replicator.Replica(1, []byte{1, 2, 3})
 	// data ok
 	buf := &bytes.Buffer{}
@@ -118,11 +128,13 @@ func TestLocalReplicator_Replica(t *testing.T) {
 	var dst []byte
 	dst = snappy.Encode(dst, buf.Bytes())
 	shard.EXPECT().LookupRowMetricMeta(gomock.Any()).Return(fmt.Errorf("err"))
+	quarantine.EXPECT().Put(gomock.Any(), dst).Return(nil)
 	replicator.Replica(1, dst)
 
-	// write failure
+	// write failure, put into quarantine failure
 	shard.EXPECT().LookupRowMetricMeta(gomock.Any()).Return(nil)
 	family.EXPECT().WriteRows(gomock.Any()).Return(fmt.Errorf("err"))
+	quarantine.EXPECT().Put(gomock.Any(), dst).Return(fmt.Errorf("err"))
 	replicator.Replica(1, dst)
 	// write success
 	shard.EXPECT().LookupRowMetricMeta(gomock.Any()).Return(nil)
@@ -0,0 +1,182 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/lindb/common/pkg/logger"
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/metrics"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/metric"
+)
+
+// violationReasons represents the reason(statistics tag) of each limit violation.
+var violationReasons = map[error]string{
+	constants.ErrNamespaceTooLong:  "namespace_too_long",
+	constants.ErrMetricNameTooLong: "metric_name_too_long",
+	constants.ErrInvalidMetricName: "invalid_metric_name",
+	constants.ErrTooManyTagKeys:    "too_many_tags",
+	constants.ErrTagKeyTooLong:     "tag_key_too_long",
+	constants.ErrTagValueTooLong:   "tag_value_too_long",
+	constants.ErrTooManyFields:     "too_many_fields",
+	constants.ErrFieldNameTooLong:  "field_name_too_long",
+}
+
+// InvalidRowsError represents the error when some rows violate the write limits of database,
+// rows violating limits are rejected, other rows of batch are still written.
+type InvalidRowsError struct {
+	Database string
+	Rejected int
+	// Err is the violation of first rejected row
+	Err error
+}
+
+// Error returns the error message.
+func (e *InvalidRowsError) Error() string {
+	return fmt.Sprintf("database [%s] %s, %d rows rejected, first violation: %s",
+		e.Database, ErrInvalidRows.Error(), e.Rejected, e.Err)
+}
+
+// Unwrap returns ErrInvalidRows, so that errors.Is(err, ErrInvalidRows) works.
+func (e *InvalidRowsError) Unwrap() error {
+	return ErrInvalidRows
+}
+
+// compiledPattern represents the compiled metric name pattern, regexp is nil if pattern is invalid.
+type compiledPattern struct {
+	pattern string
+	regexp  *regexp.Regexp
+}
+
+// rowValidator validates the rows of write batch based on the limits of database.
+type rowValidator struct {
+	// compiled metric name pattern keyed by database name
+	patterns   map[string]*compiledPattern
+	mutex      sync.Mutex
+	statistics *metrics.BrokerWriteValidationStatistics
+	logger     logger.Logger
+}
+
+// newRowValidator creates a row validator for all databases.
+func newRowValidator() *rowValidator {
+	return &rowValidator{
+		patterns:   make(map[string]*compiledPattern),
+		statistics: metrics.NewBrokerWriteValidationStatistics(),
+		logger:     logger.GetLogger("Replica", "RowValidator"),
+	}
+}
+
+// Validate removes the rows violating the limits of database from batch,
+// returns *InvalidRowsError if some rows rejected.
+func (v *rowValidator) Validate(database string, limits *models.Limits, rows *metric.BrokerBatchRows) error {
+	if limits == nil {
+		return nil
+	}
+	pattern := v.getPattern(database, limits)
+	var violation error
+	rejected := rows.RemoveRows(func(row *metric.BrokerRow) bool {
+		err := validateRow(limits, pattern, row.Metric())
+		if err == nil {
+			return false
+		}
+		if violation == nil {
+			violation = err
+		}
+		v.statistics.InvalidRows.WithTagValues(database, violationReasons[err]).Incr()
+		return true
+	})
+	if rejected == 0 {
+		return nil
+	}
+	return &InvalidRowsError{Database: database, Rejected: rejected, Err: violation}
+}
+
+// getPattern returns the compiled metric name pattern of database, nil if check disabled or pattern invalid.
+// Pattern is recompiled if limits changed.
+func (v *rowValidator) getPattern(database string, limits *models.Limits) *regexp.Regexp {
+	if !limits.EnableMetricNamePatternCheck() {
+		return nil
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	p, ok := v.patterns[database]
+	if !ok || p.pattern != limits.MetricNamePattern {
+		p = &compiledPattern{pattern: limits.MetricNamePattern}
+		r, err := regexp.Compile(limits.MetricNamePattern)
+		if err != nil {
+			// invalid pattern cannot reject all writes of database, skip the check
+			v.logger.Warn("invalid metric name pattern, skip metric name check",
+				logger.String("database", database),
+				logger.String("pattern", limits.MetricNamePattern),
+				logger.Error(err))
+		} else {
+			p.regexp = r
+		}
+		v.patterns[database] = p
+	}
+	return p.regexp
+}
+
+// validateRow validates the row based on limits, returns the violation if any.
+func validateRow(limits *models.Limits, pattern *regexp.Regexp, m flatMetricsV1.Metric) error {
+	if limits.EnableNamespaceLengthCheck() && len(m.Namespace()) > limits.MaxNamespaceLength {
+		return constants.ErrNamespaceTooLong
+	}
+	name := m.Name()
+	if limits.EnableMetricNameLengthCheck() && len(name) > limits.MaxMetricNameLength {
+		return constants.ErrMetricNameTooLong
+	}
+	if pattern != nil && !pattern.Match(name) {
+		return constants.ErrInvalidMetricName
+	}
+	if limits.EnableTagsCheck() && m.KeyValuesLength() > limits.MaxTagsPerMetric {
+		return constants.ErrTooManyTagKeys
+	}
+	if limits.EnableTagNameLengthCheck() || limits.EnableTagValueLengthCheck() {
+		var kv flatMetricsV1.KeyValue
+		for i := 0; i < m.KeyValuesLength(); i++ {
+			m.KeyValues(&kv, i)
+			if limits.EnableTagNameLengthCheck() && len(kv.Key()) > limits.MaxTagNameLength {
+				return constants.ErrTagKeyTooLong
+			}
+			if limits.EnableTagValueLengthCheck() && len(kv.Value()) > limits.MaxTagValueLength {
+				return constants.ErrTagValueTooLong
+			}
+		}
+	}
+	if limits.EnableFieldsCheck() && m.SimpleFieldsLength() > int(limits.MaxFieldsPerMetric) {
+		return constants.ErrTooManyFields
+	}
+	if limits.EnableFieldNameLengthCheck() {
+		var f flatMetricsV1.SimpleField
+		for i := 0; i < m.SimpleFieldsLength(); i++ {
+			m.SimpleFields(&f, i)
+			if len(f.Name()) > limits.MaxFieldNameLength {
+				return constants.ErrFieldNameTooLong
+			}
+		}
+	}
+	return nil
+}
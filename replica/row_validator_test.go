@@ -0,0 +1,195 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replica
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+	commonseries "github.com/lindb/common/series"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/metric"
+)
+
+type testRow struct {
+	namespace string
+	name      string
+	tags      map[string]string
+	fields    []string
+}
+
+func buildTestRows(t *testing.T, testRows ...testRow) *metric.BrokerBatchRows {
+	rows := metric.NewBrokerBatchRows()
+	for _, r := range testRows {
+		r := r
+		assert.NoError(t, rows.TryAppend(func(row *metric.BrokerRow) error {
+			builder, releaseFunc := commonseries.NewRowBuilder()
+			defer releaseFunc(builder)
+			builder.AddNameSpace([]byte(r.namespace))
+			builder.AddMetricName([]byte(r.name))
+			for k, v := range r.tags {
+				if err := builder.AddTag([]byte(k), []byte(v)); err != nil {
+					return err
+				}
+			}
+			for _, f := range r.fields {
+				if err := builder.AddSimpleField([]byte(f), flatMetricsV1.SimpleFieldTypeDeltaSum, 1); err != nil {
+					return err
+				}
+			}
+			data, err := builder.Build()
+			if err != nil {
+				return err
+			}
+			row.FromBlock(data)
+			return nil
+		}))
+	}
+	return rows
+}
+
+func TestRowValidator_Validate(t *testing.T) {
+	valid := testRow{namespace: "ns", name: "cpu", tags: map[string]string{"host": "h1"}, fields: []string{"f1"}}
+	cases := []struct {
+		name   string
+		limits func(l *models.Limits)
+		row    testRow
+		err    error
+	}{
+		{
+			name:   "namespace too long",
+			limits: func(l *models.Limits) { l.MaxNamespaceLength = 2 },
+			row:    testRow{namespace: "ns1", name: "cpu", fields: []string{"f1"}},
+			err:    constants.ErrNamespaceTooLong,
+		},
+		{
+			name:   "metric name too long",
+			limits: func(l *models.Limits) { l.MaxMetricNameLength = 3 },
+			row:    testRow{name: "memory", fields: []string{"f1"}},
+			err:    constants.ErrMetricNameTooLong,
+		},
+		{
+			name:   "invalid metric name",
+			limits: func(l *models.Limits) { l.MetricNamePattern = "^[a-z]+$" },
+			row:    testRow{name: "cpu-1", fields: []string{"f1"}},
+			err:    constants.ErrInvalidMetricName,
+		},
+		{
+			name:   "too many tags",
+			limits: func(l *models.Limits) { l.MaxTagsPerMetric = 1 },
+			row:    testRow{name: "cpu", tags: map[string]string{"host": "h1", "ip": "1.1.1.1"}, fields: []string{"f1"}},
+			err:    constants.ErrTooManyTagKeys,
+		},
+		{
+			name:   "tag key too long",
+			limits: func(l *models.Limits) { l.MaxTagNameLength = 4 },
+			row:    testRow{name: "cpu", tags: map[string]string{"region": "sh"}, fields: []string{"f1"}},
+			err:    constants.ErrTagKeyTooLong,
+		},
+		{
+			name:   "tag value too long",
+			limits: func(l *models.Limits) { l.MaxTagValueLength = 3 },
+			row:    testRow{name: "cpu", tags: map[string]string{"host": strings.Repeat("h", 4)}, fields: []string{"f1"}},
+			err:    constants.ErrTagValueTooLong,
+		},
+		{
+			name:   "too many fields",
+			limits: func(l *models.Limits) { l.MaxFieldsPerMetric = 1 },
+			row:    testRow{name: "cpu", fields: []string{"f1", "f2"}},
+			err:    constants.ErrTooManyFields,
+		},
+		{
+			name:   "field name too long",
+			limits: func(l *models.Limits) { l.MaxFieldNameLength = 2 },
+			row:    testRow{name: "cpu", fields: []string{"f100"}},
+			err:    constants.ErrFieldNameTooLong,
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			limits := models.NewDefaultLimits()
+			tt.limits(limits)
+			v := newRowValidator()
+			rows := buildTestRows(t, valid, tt.row, valid)
+			err := v.Validate("db", limits, rows)
+			var invalidErr *InvalidRowsError
+			assert.True(t, errors.As(err, &invalidErr))
+			assert.Equal(t, "db", invalidErr.Database)
+			assert.Equal(t, 1, invalidErr.Rejected)
+			assert.Equal(t, tt.err, invalidErr.Err)
+			assert.NotEmpty(t, violationReasons[tt.err])
+			assert.Contains(t, err.Error(), tt.err.Error())
+			// valid rows kept
+			assert.Equal(t, 2, rows.Len())
+			for _, row := range rows.Rows() {
+				m := row.Metric()
+				assert.Equal(t, "cpu", string(m.Name()))
+			}
+			// limit disabled
+			rows = buildTestRows(t, tt.row)
+			assert.NoError(t, v.Validate("db", models.NewDefaultLimits(), rows))
+			assert.Equal(t, 1, rows.Len())
+		})
+	}
+}
+
+func TestRowValidator_Validate_Valid(t *testing.T) {
+	v := newRowValidator()
+	limits := models.NewDefaultLimits()
+	limits.MetricNamePattern = `^[a-zA-Z_][a-zA-Z0-9_.:\-]*$`
+	rows := buildTestRows(t,
+		testRow{name: "system.cpu", tags: map[string]string{"host": "h1"}, fields: []string{"f1"}},
+		testRow{name: "http:requests_total", tags: map[string]string{"path": "/"}, fields: []string{"f1", "f2"}},
+	)
+	assert.NoError(t, v.Validate("db", limits, rows))
+	assert.Equal(t, 2, rows.Len())
+	// no limits
+	assert.NoError(t, v.Validate("db", nil, rows))
+	assert.Equal(t, 2, rows.Len())
+}
+
+func TestRowValidator_MetricNamePattern(t *testing.T) {
+	v := newRowValidator()
+	limits := models.NewDefaultLimits()
+	assert.Nil(t, v.getPattern("db", limits))
+
+	limits.MetricNamePattern = "^[a-z]+$"
+	p := v.getPattern("db", limits)
+	assert.NotNil(t, p)
+	// compiled pattern is cached
+	assert.Same(t, p, v.getPattern("db", limits))
+	// pattern changed
+	limits.MetricNamePattern = "^[A-Z]+$"
+	p2 := v.getPattern("db", limits)
+	assert.NotSame(t, p, p2)
+	assert.True(t, p2.MatchString("CPU"))
+
+	// invalid pattern skips the check
+	limits.MetricNamePattern = "[a-z"
+	assert.Nil(t, v.getPattern("db", limits))
+	rows := buildTestRows(t, testRow{name: "CPU", fields: []string{"f1"}})
+	assert.NoError(t, v.Validate("db", limits, rows))
+	assert.Equal(t, 1, rows.Len())
+}
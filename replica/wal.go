@@ -31,6 +31,8 @@ import (
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/storage"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/queue"
+	"github.com/lindb/lindb/pkg/queue/page"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/tsdb"
 )
@@ -68,6 +70,7 @@ type writeAheadLog struct {
 	engine        tsdb.Engine
 	cliFct        rpc.ClientStreamFactory
 	stateMgr      storage.StateManager
+	quarantine    Quarantine
 
 	mutex sync.Mutex
 	// family log = shard + family + leader
@@ -85,6 +88,7 @@ func NewWriteAheadLog(
 	engine tsdb.Engine,
 	cliFct rpc.ClientStreamFactory,
 	stateMgr storage.StateManager,
+	quarantine Quarantine,
 ) WriteAheadLog {
 	log := &writeAheadLog{
 		ctx:           ctx,
@@ -95,6 +99,7 @@ func NewWriteAheadLog(
 		engine:        engine,
 		cliFct:        cliFct,
 		stateMgr:      stateMgr,
+		quarantine:    quarantine,
 		familyLogs:    make(map[partitionKey]Partition),
 		logger:        logger.GetLogger("Replica", "WriteAheadLog"),
 	}
@@ -139,11 +144,11 @@ func (w *writeAheadLog) GetOrCreatePartition(
 		strconv.Itoa(int(leader)))
 	dirPath := filepath.Join(w.dir, dir)
 
-	q, err := newFanOutQueue(dirPath, w.cfg.GetDataSizeLimit())
+	q, err := newFanOutQueue(dirPath, w.cfg.GetDataSizeLimit(), queue.WithCompressCodec(page.Codec(w.cfg.CompressCodec)))
 	if err != nil {
 		return nil, err
 	}
-	p := NewPartitionFn(w.ctx, shard, family, w.currentNodeID, q, w.cliFct, w.stateMgr)
+	p := NewPartitionFn(w.ctx, shard, family, w.currentNodeID, q, w.cliFct, w.stateMgr, w.quarantine)
 
 	w.familyLogs[key] = p
 	return p, nil
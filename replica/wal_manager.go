@@ -66,6 +66,8 @@ type WriteAheadLogManager interface {
 	DropDatabases(activeDatabases map[string]struct{})
 	// StopDatabases stop the replicator for write ahead log of databases, keep active databases.
 	StopDatabases(activeDatabases map[string]struct{})
+	// Quarantine returns the quarantine which stores replica messages failed writing into local storage.
+	Quarantine() Quarantine
 	// Recovery recoveries local history wal when server start.
 	Recovery() error
 	// Stop stops all replicator channel.
@@ -80,6 +82,7 @@ type writeAheadLogManager struct {
 	engine        tsdb.Engine
 	cliFct        rpc.ClientStreamFactory
 	stateMgr      storage.StateManager
+	quarantine    Quarantine
 
 	databaseLogs map[string]WriteAheadLog
 
@@ -104,6 +107,7 @@ func NewWriteAheadLogManager(
 		cliFct:        cliFct,
 		databaseLogs:  make(map[string]WriteAheadLog),
 		stateMgr:      stateMgr,
+		quarantine:    NewQuarantine(quarantineDir(cfg.Dir), engine),
 		logger:        logger.GetLogger("Replica", "WriteAheadLogManager"),
 	}
 
@@ -160,11 +164,16 @@ func (w *writeAheadLogManager) GetOrCreateLog(database string) WriteAheadLog {
 		return log
 	}
 
-	log := newWriteAheadLog(w.ctx, w.cfg, w.currentNodeID, database, w.engine, w.cliFct, w.stateMgr)
+	log := newWriteAheadLog(w.ctx, w.cfg, w.currentNodeID, database, w.engine, w.cliFct, w.stateMgr, w.quarantine)
 	w.databaseLogs[database] = log
 	return log
 }
 
+// Quarantine returns the quarantine which stores replica messages failed writing into local storage.
+func (w *writeAheadLogManager) Quarantine() Quarantine {
+	return w.quarantine
+}
+
 // Recovery recoveries local history wal when server start.
 func (w *writeAheadLogManager) Recovery() error {
 	if !fileExistFn(w.cfg.Dir) {
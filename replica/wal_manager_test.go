@@ -49,6 +49,7 @@ func TestWriteAheadLogManager_GetOrCreateLog(t *testing.T) {
 		engine tsdb.Engine,
 		cliFct rpc.ClientStreamFactory,
 		_ storage.StateManager,
+		_ Quarantine,
 	) WriteAheadLog {
 		return NewMockWriteAheadLog(ctrl)
 	}
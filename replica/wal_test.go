@@ -80,7 +80,7 @@ func TestWriteAheadLog_GetOrCreatePartition(t *testing.T) {
 			prepare: func(_ *writeAheadLog) {
 				engine.EXPECT().GetShard(gomock.Any(), gomock.Any()).Return(shard, true)
 				shard.EXPECT().GetOrCrateDataFamily(gomock.Any()).Return(nil, nil)
-				newFanOutQueue = func(dirPath string, dataSizeLimit int64) (q queue.FanOutQueue, err error) {
+				newFanOutQueue = func(dirPath string, dataSizeLimit int64, opts ...queue.Option) (q queue.FanOutQueue, err error) {
 					return nil, fmt.Errorf("err")
 				}
 			},
@@ -91,12 +91,12 @@ func TestWriteAheadLog_GetOrCreatePartition(t *testing.T) {
 			prepare: func(_ *writeAheadLog) {
 				engine.EXPECT().GetShard(gomock.Any(), gomock.Any()).Return(shard, true)
 				shard.EXPECT().GetOrCrateDataFamily(gomock.Any()).Return(nil, nil)
-				newFanOutQueue = func(dirPath string, dataSizeLimit int64) (q queue.FanOutQueue, err error) {
+				newFanOutQueue = func(dirPath string, dataSizeLimit int64, opts ...queue.Option) (q queue.FanOutQueue, err error) {
 					return nil, nil
 				}
 				NewPartitionFn = func(ctx context.Context, shard tsdb.Shard, family tsdb.DataFamily,
 					currentNodeID models.NodeID, log queue.FanOutQueue,
-					cliFct rpc.ClientStreamFactory, stateMgr storage.StateManager) Partition {
+					cliFct rpc.ClientStreamFactory, stateMgr storage.StateManager, quarantine Quarantine) Partition {
 					return NewMockPartition(ctrl)
 				}
 			},
@@ -112,7 +112,7 @@ func TestWriteAheadLog_GetOrCreatePartition(t *testing.T) {
 				NewPartitionFn = NewPartition
 			}()
 			l := NewWriteAheadLog(context.TODO(), config.WAL{RemoveTaskInterval: ltoml.Duration(time.Minute)},
-				1, "test", engine, nil, nil)
+				1, "test", engine, nil, nil, nil)
 			l1 := l.(*writeAheadLog)
 
 			if tt.prepare != nil {
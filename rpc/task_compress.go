@@ -0,0 +1,108 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/golang/snappy"
+
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
+)
+
+// PayloadCompressThreshold represents the min size of payload which needs compressing,
+// small payload skips compressing to avoid overhead.
+var PayloadCompressThreshold = 4 * 1024
+
+// CompressTaskRequest compresses physical plan/payload of task request if size above threshold,
+// returns a copy of request so that the request kept by task context is not modified, and the raw/compressed size.
+// The returned request always accepts compressed response payload.
+func CompressTaskRequest(req *protoCommonV1.TaskRequest) (compressedReq *protoCommonV1.TaskRequest, rawSize, size int) {
+	rawSize = len(req.PhysicalPlan) + len(req.Payload)
+	copied := *req
+	compressedReq = &copied
+	compressedReq.AcceptCompress = protoCommonV1.CompressType_Snappy
+	if req.Compress != protoCommonV1.CompressType_NoCompress || rawSize < PayloadCompressThreshold {
+		return compressedReq, rawSize, rawSize
+	}
+	compressedReq.Compress = protoCommonV1.CompressType_Snappy
+	compressedReq.PhysicalPlan = snappy.Encode(nil, req.PhysicalPlan)
+	compressedReq.Payload = snappy.Encode(nil, req.Payload)
+	return compressedReq, rawSize, len(compressedReq.PhysicalPlan) + len(compressedReq.Payload)
+}
+
+// DecompressTaskRequest decompresses physical plan/payload of task request if compressed.
+func DecompressTaskRequest(req *protoCommonV1.TaskRequest) error {
+	if req.GetCompress() == protoCommonV1.CompressType_NoCompress {
+		return nil
+	}
+	physicalPlan, err := decompress(req.Compress, req.PhysicalPlan)
+	if err != nil {
+		return err
+	}
+	payload, err := decompress(req.Compress, req.Payload)
+	if err != nil {
+		return err
+	}
+	req.PhysicalPlan = physicalPlan
+	req.Payload = payload
+	req.Compress = protoCommonV1.CompressType_NoCompress
+	return nil
+}
+
+// CompressTaskResponse compresses payload of task response if requester accepts and size above threshold,
+// returns the raw/compressed size of payload.
+func CompressTaskResponse(resp *protoCommonV1.TaskResponse, accept protoCommonV1.CompressType) (rawSize, size int) {
+	rawSize = len(resp.GetPayload())
+	if accept != protoCommonV1.CompressType_Snappy || resp.GetCompress() != protoCommonV1.CompressType_NoCompress ||
+		rawSize < PayloadCompressThreshold {
+		return rawSize, rawSize
+	}
+	resp.Compress = protoCommonV1.CompressType_Snappy
+	resp.Payload = snappy.Encode(nil, resp.Payload)
+	return rawSize, len(resp.Payload)
+}
+
+// DecompressTaskResponse decompresses payload of task response if compressed,
+// returns the raw/compressed size of payload.
+func DecompressTaskResponse(resp *protoCommonV1.TaskResponse) (rawSize, size int, err error) {
+	size = len(resp.GetPayload())
+	if resp.GetCompress() == protoCommonV1.CompressType_NoCompress {
+		return size, size, nil
+	}
+	payload, err := decompress(resp.Compress, resp.Payload)
+	if err != nil {
+		return 0, size, err
+	}
+	resp.Payload = payload
+	resp.Compress = protoCommonV1.CompressType_NoCompress
+	return len(payload), size, nil
+}
+
+// decompress decompresses the data based on compress type.
+func decompress(compress protoCommonV1.CompressType, data []byte) ([]byte, error) {
+	switch compress {
+	case protoCommonV1.CompressType_Snappy:
+		if len(data) == 0 {
+			return data, nil
+		}
+		return snappy.Decode(nil, data)
+	default:
+		return nil, fmt.Errorf("unknown compress type: %s", compress)
+	}
+}
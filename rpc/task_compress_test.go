@@ -0,0 +1,114 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
+)
+
+func TestTaskRequest_Compress(t *testing.T) {
+	// small payload skips compressing
+	req := &protoCommonV1.TaskRequest{RequestID: "1", PhysicalPlan: []byte("plan"), Payload: []byte("payload")}
+	sendReq, rawSize, size := CompressTaskRequest(req)
+	assert.Equal(t, 11, rawSize)
+	assert.Equal(t, 11, size)
+	assert.Equal(t, protoCommonV1.CompressType_NoCompress, sendReq.Compress)
+	assert.Equal(t, protoCommonV1.CompressType_Snappy, sendReq.AcceptCompress)
+	assert.Equal(t, protoCommonV1.CompressType_NoCompress, req.AcceptCompress)
+	assert.NoError(t, DecompressTaskRequest(sendReq))
+	assert.Equal(t, req.Payload, sendReq.Payload)
+
+	// compress large payload
+	payload := bytes.Repeat([]byte("payload"), PayloadCompressThreshold)
+	req = &protoCommonV1.TaskRequest{RequestID: "1", PhysicalPlan: []byte("plan"), Payload: payload}
+	sendReq, rawSize, size = CompressTaskRequest(req)
+	assert.Equal(t, len(payload)+4, rawSize)
+	assert.Less(t, size, rawSize)
+	assert.Equal(t, protoCommonV1.CompressType_Snappy, sendReq.Compress)
+	// raw request not modified
+	assert.Equal(t, payload, req.Payload)
+	assert.Equal(t, protoCommonV1.CompressType_NoCompress, req.Compress)
+	// compressed request not compress again
+	_, _, size2 := CompressTaskRequest(sendReq)
+	assert.Equal(t, size, size2)
+
+	assert.NoError(t, DecompressTaskRequest(sendReq))
+	assert.Equal(t, protoCommonV1.CompressType_NoCompress, sendReq.Compress)
+	assert.Equal(t, []byte("plan"), sendReq.PhysicalPlan)
+	assert.Equal(t, payload, sendReq.Payload)
+
+	// decompress failure
+	assert.Error(t, DecompressTaskRequest(&protoCommonV1.TaskRequest{
+		Compress:     protoCommonV1.CompressType_Snappy,
+		PhysicalPlan: []byte("bad plan"),
+	}))
+	assert.Error(t, DecompressTaskRequest(&protoCommonV1.TaskRequest{
+		Compress: protoCommonV1.CompressType_Snappy,
+		Payload:  []byte("bad payload"),
+	}))
+	assert.Error(t, DecompressTaskRequest(&protoCommonV1.TaskRequest{
+		Compress: protoCommonV1.CompressType(100),
+		Payload:  []byte("payload"),
+	}))
+}
+
+func TestTaskResponse_Compress(t *testing.T) {
+	payload := bytes.Repeat([]byte("payload"), PayloadCompressThreshold)
+	// requester not accept compressing
+	resp := &protoCommonV1.TaskResponse{Payload: payload}
+	rawSize, size := CompressTaskResponse(resp, protoCommonV1.CompressType_NoCompress)
+	assert.Equal(t, rawSize, size)
+	assert.Equal(t, protoCommonV1.CompressType_NoCompress, resp.Compress)
+	// small payload skips compressing
+	resp = &protoCommonV1.TaskResponse{Payload: []byte("payload")}
+	rawSize, size = CompressTaskResponse(resp, protoCommonV1.CompressType_Snappy)
+	assert.Equal(t, rawSize, size)
+	assert.Equal(t, protoCommonV1.CompressType_NoCompress, resp.Compress)
+	rawSize, size, err := DecompressTaskResponse(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, rawSize)
+	assert.Equal(t, 7, size)
+
+	resp = &protoCommonV1.TaskResponse{Payload: payload}
+	rawSize, size = CompressTaskResponse(resp, protoCommonV1.CompressType_Snappy)
+	assert.Equal(t, len(payload), rawSize)
+	assert.Less(t, size, rawSize)
+	assert.Equal(t, protoCommonV1.CompressType_Snappy, resp.Compress)
+	rawSize2, size2, err := DecompressTaskResponse(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, rawSize, rawSize2)
+	assert.Equal(t, size, size2)
+	assert.Equal(t, payload, resp.Payload)
+	assert.Equal(t, protoCommonV1.CompressType_NoCompress, resp.Compress)
+
+	// empty payload
+	resp = &protoCommonV1.TaskResponse{Compress: protoCommonV1.CompressType_Snappy}
+	_, _, err = DecompressTaskResponse(resp)
+	assert.NoError(t, err)
+	// decompress failure
+	_, _, err = DecompressTaskResponse(&protoCommonV1.TaskResponse{
+		Compress: protoCommonV1.CompressType_Snappy,
+		Payload:  []byte("bad payload"),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/pkg/encoding"
+
+	"github.com/lindb/lindb/models"
+	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
+)
+
+func newTestPhysicalPlan(targets, shards int) *models.PhysicalPlan {
+	physicalPlan := &models.PhysicalPlan{Database: "test", Receivers: []string{"1.1.1.1:9000"}}
+	for i := 0; i < targets; i++ {
+		target := &models.Target{Indicator: fmt.Sprintf("192.168.1.%d:2891", i), Limit: 100}
+		for j := 0; j < shards; j++ {
+			target.ShardIDs = append(target.ShardIDs, models.ShardID(i*shards+j))
+		}
+		physicalPlan.AddTarget(target)
+	}
+	physicalPlan.AddTarget(&models.Target{Indicator: "1.1.1.2:9000", ReceiveOnly: true})
+	return physicalPlan
+}
+
+func TestParsePlanEncoding(t *testing.T) {
+	for _, c := range []struct {
+		in  string
+		out protoCommonV1.PlanEncoding
+	}{
+		{in: "", out: protoCommonV1.PlanEncoding_JSONPlan},
+		{in: "json", out: protoCommonV1.PlanEncoding_JSONPlan},
+		{in: "Protobuf", out: protoCommonV1.PlanEncoding_ProtoPlan},
+	} {
+		planEncoding, err := ParsePlanEncoding(c.in)
+		assert.NoError(t, err)
+		assert.Equal(t, c.out, planEncoding)
+	}
+	_, err := ParsePlanEncoding("gob")
+	assert.Error(t, err)
+}
+
+func TestPhysicalPlan_Encoding(t *testing.T) {
+	defer func() {
+		PhysicalPlanEncoding = protoCommonV1.PlanEncoding_JSONPlan
+	}()
+	physicalPlan := newTestPhysicalPlan(64, 16)
+	sizes := make(map[protoCommonV1.PlanEncoding]int)
+	for _, planEncoding := range []protoCommonV1.PlanEncoding{protoCommonV1.PlanEncoding_JSONPlan, protoCommonV1.PlanEncoding_ProtoPlan} {
+		PhysicalPlanEncoding = planEncoding
+		req := &protoCommonV1.TaskRequest{RequestID: "1"}
+		EncodePhysicalPlan(req, physicalPlan)
+		assert.Equal(t, planEncoding, req.PlanEncoding)
+		sizes[planEncoding] = len(req.PhysicalPlan)
+
+		// task request transferred via grpc
+		data, err := req.Marshal()
+		assert.NoError(t, err)
+		received := &protoCommonV1.TaskRequest{}
+		assert.NoError(t, received.Unmarshal(data))
+		plan, err := DecodePhysicalPlan(received)
+		assert.NoError(t, err)
+		assert.Equal(t, physicalPlan, plan)
+	}
+	// json 9245 bytes, protobuf 3548 bytes
+	assert.Less(t, sizes[protoCommonV1.PlanEncoding_ProtoPlan]*2, sizes[protoCommonV1.PlanEncoding_JSONPlan])
+}
+
+func TestPhysicalPlan_MixedVersion(t *testing.T) {
+	physicalPlan := newTestPhysicalPlan(2, 2)
+	// new broker sends json plan by default, old leaf decodes json plan(ignores plan encoding)
+	req := &protoCommonV1.TaskRequest{RequestID: "1"}
+	EncodePhysicalPlan(req, physicalPlan)
+	assert.Equal(t, protoCommonV1.PlanEncoding_JSONPlan, req.PlanEncoding)
+	oldLeafPlan := &models.PhysicalPlan{}
+	assert.NoError(t, encoding.JSONUnmarshal(req.PhysicalPlan, oldLeafPlan))
+	assert.Equal(t, physicalPlan, oldLeafPlan)
+
+	// old broker sends json plan without plan encoding, new leaf decodes json plan
+	req = &protoCommonV1.TaskRequest{RequestID: "1", PhysicalPlan: encoding.JSONMarshal(physicalPlan)}
+	plan, err := DecodePhysicalPlan(req)
+	assert.NoError(t, err)
+	assert.Equal(t, physicalPlan, plan)
+}
+
+func TestDecodePhysicalPlan_Fail(t *testing.T) {
+	_, err := DecodePhysicalPlan(&protoCommonV1.TaskRequest{PhysicalPlan: []byte("bad plan")})
+	assert.Error(t, err)
+	_, err = DecodePhysicalPlan(&protoCommonV1.TaskRequest{
+		PhysicalPlan: []byte("bad plan"),
+		PlanEncoding: protoCommonV1.PlanEncoding_ProtoPlan,
+	})
+	assert.Error(t, err)
+	_, err = DecodePhysicalPlan(&protoCommonV1.TaskRequest{PlanEncoding: 100})
+	assert.Error(t, err)
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/lindb/common/pkg/logger"
 
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/internal/linmetric"
+	"github.com/lindb/lindb/metrics"
 	"github.com/lindb/lindb/models"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 )
@@ -36,8 +38,8 @@ import (
 
 // TransportManager represents the request/response send manager.
 type TransportManager interface {
-	// SendRequest sends the task request to target node.
-	SendRequest(targetNodeID string, req *protoCommonV1.TaskRequest) error
+	// SendRequest sends the task request to target node, gives up retrying when ctx is done.
+	SendRequest(ctx context.Context, targetNodeID string, req *protoCommonV1.TaskRequest) error
 	// SendResponse sends the task response to target node.
 	SendResponse(targetNodeID string, resp *protoCommonV1.TaskResponse) error
 }
@@ -74,17 +76,21 @@ type taskClientFactory struct {
 
 	newTaskServiceClientFunc func(cc *grpc.ClientConn) protoCommonV1.TaskServiceClient
 	connFct                  ClientConnFactory
+	netPayload               *metrics.NetPayloadStatistics
 	logger                   logger.Logger
 }
 
 // NewTaskClientFactory creates a task client factory
-func NewTaskClientFactory(ctx context.Context, currentNode models.Node, connFct ClientConnFactory) TaskClientFactory {
+func NewTaskClientFactory(ctx context.Context, currentNode models.Node, connFct ClientConnFactory,
+	registry *linmetric.Registry,
+) TaskClientFactory {
 	return &taskClientFactory{
 		ctx:                      ctx,
 		currentNode:              currentNode,
 		connFct:                  connFct,
 		taskStreams:              make(map[string]*taskClient),
 		newTaskServiceClientFunc: protoCommonV1.NewTaskServiceClient,
+		netPayload:               metrics.NewNetPayloadStatistics(registry, "received"),
 		logger:                   logger.GetLogger("RPC", "TaskClient"),
 	}
 }
@@ -214,6 +220,17 @@ func (f *taskClientFactory) handleTaskResponse(client *taskClient) {
 			f.logger.Error("receive task error from stream", logger.Error(err))
 			continue
 		}
+		rawSize, size, err := DecompressTaskResponse(resp)
+		if err != nil {
+			// notify task context decompress failure, task need not wait it
+			f.logger.Error("decompress task response payload",
+				logger.String("requestID", resp.RequestID), logger.Error(err))
+			resp.Payload = nil
+			resp.ErrMsg = err.Error()
+			resp.ErrCode = string(models.ErrCodeInternal)
+		}
+		f.netPayload.RawBytes.Add(float64(rawSize))
+		f.netPayload.NetBytes.Add(float64(size))
 
 		if err = f.taskReceiver.Receive(resp, client.targetID); err != nil {
 			// FIXME: need send response to upstream
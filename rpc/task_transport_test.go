@@ -24,9 +24,11 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/snappy"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 
+	"github.com/lindb/lindb/internal/linmetric"
 	"github.com/lindb/lindb/models"
 	protoCommonV1 "github.com/lindb/lindb/proto/gen/v1/common"
 )
@@ -76,7 +78,7 @@ func TestTaskClientFactory(t *testing.T) {
 
 	fct := NewTaskClientFactory(context.TODO(),
 		&models.StatelessNode{HostIP: "127.0.0.1", GRPCPort: 123},
-		GetBrokerClientConnFactory())
+		GetBrokerClientConnFactory(), linmetric.BrokerRegistry)
 	receiver := NewMockTaskReceiver(ctl)
 	receiver.EXPECT().Receive(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	fct.SetTaskReceiver(receiver)
@@ -131,7 +133,7 @@ func TestTaskClientFactory_handler(t *testing.T) {
 	defer cancel()
 
 	receiver := NewMockTaskReceiver(ctrl)
-	fct := NewTaskClientFactory(ctx, &models.StatelessNode{HostIP: "127.0.0.1", GRPCPort: 123}, GetStorageClientConnFactory())
+	fct := NewTaskClientFactory(ctx, &models.StatelessNode{HostIP: "127.0.0.1", GRPCPort: 123}, GetStorageClientConnFactory(), linmetric.BrokerRegistry)
 	fct.SetTaskReceiver(receiver)
 
 	target := models.StatelessNode{HostIP: "127.0.0.1", GRPCPort: 321}
@@ -162,6 +164,28 @@ func TestTaskClientFactory_handler(t *testing.T) {
 		taskService.EXPECT().Handle(gomock.Any(), gomock.Any()).Return(mockTaskClient, nil),
 		mockTaskClient.EXPECT().Recv().Return(nil, nil),
 		receiver.EXPECT().Receive(gomock.Any(), gomock.Any()).Return(nil),
+		// compressed payload
+		mockTaskClient.EXPECT().Recv().Return(&protoCommonV1.TaskResponse{
+			Compress: protoCommonV1.CompressType_Snappy,
+			Payload:  snappy.Encode(nil, []byte("payload")),
+		}, nil),
+		receiver.EXPECT().Receive(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(resp *protoCommonV1.TaskResponse, _ string) error {
+				assert.Equal(t, []byte("payload"), resp.Payload)
+				assert.Empty(t, resp.ErrMsg)
+				return nil
+			}),
+		// decompress failure
+		mockTaskClient.EXPECT().Recv().Return(&protoCommonV1.TaskResponse{
+			Compress: protoCommonV1.CompressType_Snappy,
+			Payload:  []byte("bad payload"),
+		}, nil),
+		receiver.EXPECT().Receive(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(resp *protoCommonV1.TaskResponse, _ string) error {
+				assert.Nil(t, resp.Payload)
+				assert.NotEmpty(t, resp.ErrMsg)
+				return nil
+			}),
 		mockTaskClient.EXPECT().Recv().Return(&protoCommonV1.TaskResponse{}, nil),
 		receiver.EXPECT().Receive(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(_ *protoCommonV1.TaskResponse, _ string) error {
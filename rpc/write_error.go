@@ -0,0 +1,51 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"strings"
+)
+
+// WriteErrorClass represents the class of write error in write response,
+// client decides to retry or give up the write records by it.
+type WriteErrorClass string
+
+const (
+	// WriteErrorRetryable represents the write error which client can retry, e.g. shard temporarily unavailable.
+	WriteErrorRetryable WriteErrorClass = "retryable"
+	// WriteErrorFatal represents the write error which client should give up, e.g. schema/validation error.
+	WriteErrorFatal WriteErrorClass = "fatal"
+)
+
+// EncodeWriteError encodes write error with stable class prefix into error message of write response,
+// format: [class] error message.
+func EncodeWriteError(class WriteErrorClass, err error) string {
+	return "[" + string(class) + "] " + err.Error()
+}
+
+// ParseWriteError parses class and error message from error message of write response,
+// returns fatal if error message without class prefix.
+func ParseWriteError(errMsg string) (class WriteErrorClass, msg string) {
+	for _, c := range []WriteErrorClass{WriteErrorRetryable, WriteErrorFatal} {
+		prefix := "[" + string(c) + "] "
+		if strings.HasPrefix(errMsg, prefix) {
+			return c, strings.TrimPrefix(errMsg, prefix)
+		}
+	}
+	return WriteErrorFatal, errMsg
+}
@@ -0,0 +1,44 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteError(t *testing.T) {
+	errMsg := EncodeWriteError(WriteErrorRetryable, fmt.Errorf("err"))
+	assert.Equal(t, "[retryable] err", errMsg)
+	class, msg := ParseWriteError(errMsg)
+	assert.Equal(t, WriteErrorRetryable, class)
+	assert.Equal(t, "err", msg)
+
+	errMsg = EncodeWriteError(WriteErrorFatal, fmt.Errorf("err"))
+	assert.Equal(t, "[fatal] err", errMsg)
+	class, msg = ParseWriteError(errMsg)
+	assert.Equal(t, WriteErrorFatal, class)
+	assert.Equal(t, "err", msg)
+
+	// without class prefix
+	class, msg = ParseWriteError("err")
+	assert.Equal(t, WriteErrorFatal, class)
+	assert.Equal(t, "err", msg)
+}
@@ -19,7 +19,10 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"io"
+	"sync"
+	"time"
 
 	"go.uber.org/atomic"
 
@@ -27,12 +30,24 @@ import (
 	"github.com/lindb/common/pkg/logger"
 
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/metrics"
 	"github.com/lindb/lindb/models"
 	protoWriteV1 "github.com/lindb/lindb/proto/gen/v1/write"
 )
 
 //go:generate mockgen -source=./write_stream.go -destination=./write_stream_mock.go -package=rpc
 
+const (
+	// defaultWriteStreamWindow represents the max num. of records sent but not acknowledged by storage.
+	defaultWriteStreamWindow = 1024
+	// defaultWriteStreamSendTimeout represents the max time of waiting acknowledgements when window is full.
+	defaultWriteStreamSendTimeout = 5 * time.Second
+)
+
+// ErrWriteStreamBackpressure represents too many records of write stream not acknowledged by storage,
+// send timeout waiting for acknowledgements.
+var ErrWriteStreamBackpressure = errors.New("too many records not acknowledged by storage, write stream backpressure")
+
 // WriteStream represents the channel which writes metric to storage based on grpc stream,
 // and receives write response in background.
 type WriteStream interface {
@@ -55,6 +70,8 @@ type writeStream struct {
 	cli    protoWriteV1.WriteService_WriteClient
 	closed *atomic.Bool
 
+	window *sendWindow
+
 	logger logger.Logger
 }
 
@@ -75,6 +92,7 @@ func NewWriteStream(
 		familyTime: familyTime,
 		fct:        fct,
 		closed:     atomic.NewBool(false),
+		window:     newSendWindow(database, defaultWriteStreamWindow, defaultWriteStreamSendTimeout),
 		logger:     logger.GetLogger("RPC", "WriteStream"),
 	}
 
@@ -118,17 +136,23 @@ func (s *writeStream) initialize() error {
 	return nil
 }
 
-// Send sends metric data to storage.
+// Send sends metric data to storage, blocks if too many records not acknowledged by storage,
+// returns ErrWriteStreamBackpressure if no acknowledgement received before send timeout.
 func (s *writeStream) Send(data []byte) error {
-	if s.closed.Load() {
-		// if write stream is closed, return EOF err
-		return io.EOF
+	if err := s.window.acquire(s.ctx, s.closed); err != nil {
+		return err
 	}
-	return s.cli.Send(&protoWriteV1.WriteRequest{Record: data})
+	if err := s.cli.Send(&protoWriteV1.WriteRequest{Record: data}); err != nil {
+		s.window.release(1)
+		return err
+	}
+	return nil
 }
 
 // Close closes send stream, and cancel stream context, server will stop receive write request under this stream.
 func (s *writeStream) Close() error {
+	// records not acknowledged are never acknowledged after stream closed
+	defer s.window.reset()
 	defer s.cancel() // close stream context
 	s.logger.Info("close write stream",
 		logger.String("target", s.target.Indicator()))
@@ -158,6 +182,7 @@ func (s *writeStream) recvLoop() {
 					logger.Error(err))
 			}
 			s.closed.Store(true)
+			s.window.release(0)
 			return
 		default:
 			resp, err := s.cli.Recv()
@@ -167,17 +192,104 @@ func (s *writeStream) recvLoop() {
 					logger.Error(err))
 				if err == io.EOF {
 					s.closed.Store(true)
-					// stream is closed, return it.
+					// stream is closed, wake up the blocked sender, then return it.
+					s.window.release(0)
 					return
 				}
 				continue
 			}
+			// advance window by acknowledged records
+			s.window.release(int64(resp.Count))
 			if resp.Err != "" {
 				// get err from response
+				class, errMsg := ParseWriteError(resp.Err)
 				s.logger.Error("get err write response",
 					logger.String("target", s.target.Indicator()),
-					logger.String("err", resp.Err))
+					logger.Any("count", resp.Count),
+					logger.Any("offset", resp.Offset),
+					logger.String("class", string(class)),
+					logger.String("err", errMsg))
 			}
 		}
 	}
 }
+
+// sendWindow represents the flow control of write stream, send blocks if in-flight records(sent but
+// not acknowledged by storage) reach window size, acknowledgements of write response advance the window.
+type sendWindow struct {
+	size     int64
+	timeout  time.Duration
+	inFlight int64
+	signal   chan struct{}
+	lock     sync.Mutex
+
+	statistics *metrics.BrokerWriteStreamStatistics
+}
+
+// newSendWindow creates a send window with max in-flight records and timeout of waiting acknowledgements.
+func newSendWindow(database string, size int64, timeout time.Duration) *sendWindow {
+	return &sendWindow{
+		size:       size,
+		timeout:    timeout,
+		signal:     make(chan struct{}, 1),
+		statistics: metrics.NewBrokerWriteStreamStatistics(database),
+	}
+}
+
+// acquire waits until in-flight records less than window size, then increases in-flight records,
+// returns io.EOF if stream closed, ErrWriteStreamBackpressure if wait timeout.
+func (w *sendWindow) acquire(ctx context.Context, closed *atomic.Bool) error {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		if closed.Load() {
+			// if write stream is closed, return EOF err
+			return io.EOF
+		}
+		w.lock.Lock()
+		if w.inFlight < w.size {
+			w.inFlight++
+			w.lock.Unlock()
+			w.statistics.InFlight.Incr()
+			return nil
+		}
+		w.lock.Unlock()
+
+		if timer == nil {
+			timer = time.NewTimer(w.timeout)
+		}
+		select {
+		case <-w.signal:
+		case <-timer.C:
+			w.statistics.Backpressure.Incr()
+			return ErrWriteStreamBackpressure
+		case <-ctx.Done():
+			return io.EOF
+		}
+	}
+}
+
+// release decreases in-flight records by acknowledged count, then wakes up the blocked sender.
+func (w *sendWindow) release(count int64) {
+	w.lock.Lock()
+	if count > w.inFlight {
+		count = w.inFlight
+	}
+	w.inFlight -= count
+	w.lock.Unlock()
+
+	w.statistics.InFlight.Sub(float64(count))
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// reset releases all in-flight records.
+func (w *sendWindow) reset() {
+	w.release(w.size)
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -75,11 +76,78 @@ func TestWriteStream_Send(t *testing.T) {
 	stream := &writeStream{
 		cli:    cli,
 		closed: atomic.NewBool(true),
+		window: newSendWindow("test", 10, time.Second),
 	}
 	assert.Equal(t, io.EOF, stream.Send(nil))
 	stream.closed.Store(false)
 	cli.EXPECT().Send(gomock.Any()).Return(nil)
 	assert.NoError(t, stream.Send(nil))
+	assert.Equal(t, int64(1), stream.window.inFlight)
+	// send failure, release window
+	cli.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, stream.Send(nil))
+	assert.Equal(t, int64(1), stream.window.inFlight)
+}
+
+func TestWriteStream_Backpressure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// slow server acknowledges records only when test pushes responses
+	acks := make(chan *protoWriteV1.WriteResponse)
+	cli := protoWriteV1.NewMockWriteService_WriteClient(ctrl)
+	cli.EXPECT().Context().Return(context.TODO()).AnyTimes()
+	cli.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+	cli.EXPECT().Recv().DoAndReturn(func() (*protoWriteV1.WriteResponse, error) {
+		resp, ok := <-acks
+		if !ok {
+			return nil, io.EOF
+		}
+		return resp, nil
+	}).AnyTimes()
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	stream := &writeStream{
+		ctx:    ctx,
+		cli:    cli,
+		target: &models.StatefulNode{},
+		closed: atomic.NewBool(false),
+		window: newSendWindow("test", 2, 50*time.Millisecond),
+		logger: logger.GetLogger("RPC", "WriteStream"),
+	}
+	go stream.recvLoop()
+
+	assert.NoError(t, stream.Send(nil))
+	assert.NoError(t, stream.Send(nil))
+	// window is full, send timeout
+	assert.Equal(t, ErrWriteStreamBackpressure, stream.Send(nil))
+
+	// send blocks until acknowledgement arrives
+	stream.window.timeout = 5 * time.Second
+	sent := make(chan error, 1)
+	go func() {
+		sent <- stream.Send(nil)
+	}()
+	select {
+	case <-sent:
+		t.Fatal("send should be blocked when window is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+	acks <- &protoWriteV1.WriteResponse{Count: 1}
+	assert.NoError(t, <-sent)
+	assert.Equal(t, int64(2), stream.window.inFlight)
+
+	// batch ack advances window
+	acks <- &protoWriteV1.WriteResponse{Count: 2}
+	assert.NoError(t, stream.Send(nil))
+	assert.NoError(t, stream.Send(nil))
+
+	// blocked sender wakes up when stream closed
+	go func() {
+		sent <- stream.Send(nil)
+	}()
+	close(acks)
+	assert.Equal(t, io.EOF, <-sent)
 }
 
 func TestWriteStream_Recv(t *testing.T) {
@@ -89,6 +157,7 @@ func TestWriteStream_Recv(t *testing.T) {
 	stream := &writeStream{
 		target: &models.StatefulNode{},
 		closed: atomic.NewBool(false),
+		window: newSendWindow("test", 10, time.Second),
 		logger: logger.GetLogger("RPC", "WriteStream"),
 	}
 	// case 1: panic
@@ -102,6 +171,7 @@ func TestWriteStream_Recv(t *testing.T) {
 		cli:    cli,
 		target: &models.StatefulNode{},
 		closed: atomic.NewBool(false),
+		window: newSendWindow("test", 10, time.Second),
 		logger: logger.GetLogger("RPC", "WriteStream"),
 	}
 	cli.EXPECT().Context().Return(ctx).MaxTimes(2)
@@ -112,6 +182,7 @@ func TestWriteStream_Recv(t *testing.T) {
 		cli:    cli,
 		closed: atomic.NewBool(false),
 		target: &models.StatefulNode{},
+		window: newSendWindow("test", 10, time.Second),
 		logger: logger.GetLogger("RPC", "WriteStream"),
 	}
 	cli.EXPECT().Context().Return(context.TODO()).AnyTimes()
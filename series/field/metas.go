@@ -19,29 +19,90 @@ package field
 
 import (
 	"bytes"
+	"math"
 	"sort"
 	"strings"
 
 	"github.com/lindb/lindb/pkg/stream"
 )
 
+// typeChangeFlag marks the field meta record as a type change record, which has the change timestamp.
+const typeChangeFlag byte = 0x80
+
+// TypeChange represents the field type before changed.
+type TypeChange struct {
+	Type  Type  `json:"type"`  // field type before changed
+	Until int64 `json:"until"` // timestamp when the type changed
+}
+
 // Meta is the meta-data for field, which contains field-name, fieldID and field-type
 type Meta struct {
 	ID   ID   `json:"id"`   // query not use id, don't get id in query phase
 	Type Type `json:"type"` // query not use type
 	Name Name `json:"name"`
+
+	History []TypeChange `json:"history,omitempty"` // previous field types, sorted by change time
 }
 
+// MarshalBinary returns the binary data of field meta,
+// if field type changed, marshals as type change record with the latest change timestamp.
 func (m *Meta) MarshalBinary() (data []byte, err error) {
 	var buf bytes.Buffer
 	writer := stream.NewBufferWriter(&buf)
 	writer.PutByte(byte(m.ID))
-	writer.PutByte(byte(m.Type))
+	if len(m.History) > 0 {
+		writer.PutByte(byte(m.Type) | typeChangeFlag)
+	} else {
+		writer.PutByte(byte(m.Type))
+	}
 	writer.PutInt16(int16(len(m.Name)))
 	writer.PutBytes([]byte(m.Name))
+	if len(m.History) > 0 {
+		writer.PutInt64(m.History[len(m.History)-1].Until)
+	}
 	return buf.Bytes(), writer.Error()
 }
 
+// ChangeType returns a new field meta with changed type, the previous type is effective before given timestamp.
+func (m Meta) ChangeType(fieldType Type, timestamp int64) Meta {
+	history := make([]TypeChange, len(m.History), len(m.History)+1)
+	copy(history, m.History)
+	m.History = append(history, TypeChange{Type: m.Type, Until: timestamp})
+	m.Type = fieldType
+	return m
+}
+
+// TypeAt returns the field type effective at given timestamp.
+func (m *Meta) TypeAt(timestamp int64) Type {
+	for _, change := range m.History {
+		if timestamp < change.Until {
+			return change.Type
+		}
+	}
+	return m.Type
+}
+
+// TypesIn returns the field types effective in given time range[start,end], sorted by time.
+func (m *Meta) TypesIn(start, end int64) (types []Type) {
+	addType := func(fieldType Type) {
+		if len(types) == 0 || types[len(types)-1] != fieldType {
+			types = append(types, fieldType)
+		}
+	}
+	from := int64(math.MinInt64)
+	for _, change := range m.History {
+		// type effective in [from, change.Until)
+		if start < change.Until && end >= from {
+			addType(change.Type)
+		}
+		from = change.Until
+	}
+	if end >= from {
+		addType(m.Type)
+	}
+	return types
+}
+
 // Metas implements sort.Interface, it's sorted by name
 type Metas []Meta
 
@@ -58,10 +119,22 @@ func UnmarshalBinary(data []byte) (Metas, ID, error) {
 
 	for !reader.Empty() && reader.Error() == nil {
 		id := ID(reader.ReadByte())
-		fType := Type(reader.ReadByte())
+		typeFlag := reader.ReadByte()
 		nameLen := reader.ReadInt16()
 		name := reader.ReadBytes(int(nameLen))
-		fms = append(fms, Meta{ID: id, Type: fType, Name: Name(name)})
+		if typeFlag&typeChangeFlag != 0 {
+			// type change record, change the type of exist field
+			until := reader.ReadInt64()
+			fType := Type(typeFlag &^ typeChangeFlag)
+			for idx := range fms {
+				if fms[idx].ID == id {
+					fms[idx] = fms[idx].ChangeType(fType, until)
+					break
+				}
+			}
+			continue
+		}
+		fms = append(fms, Meta{ID: id, Type: Type(typeFlag), Name: Name(name)})
 		if id > max {
 			max = id
 		}
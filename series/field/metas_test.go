@@ -72,3 +72,54 @@ func Test_Metas(t *testing.T) {
 	metas = metas.Insert(Meta{ID: 3, Name: "c,"})
 	assert.Equal(t, "a,b,c,", metas.String())
 }
+
+func TestMeta_ChangeType(t *testing.T) {
+	m := Meta{ID: 1, Type: SumField, Name: "f"}
+	assert.Equal(t, []Type{SumField}, m.TypesIn(0, 100))
+	assert.Equal(t, SumField, m.TypeAt(50))
+
+	m1 := m.ChangeType(LastField, 100)
+	m2 := m1.ChangeType(MaxField, 200)
+	// origin meta not changed
+	assert.Empty(t, m.History)
+	assert.Len(t, m1.History, 1)
+	assert.Equal(t, LastField, m1.Type)
+	assert.Equal(t, []TypeChange{{Type: SumField, Until: 100}, {Type: LastField, Until: 200}}, m2.History)
+
+	assert.Equal(t, SumField, m2.TypeAt(99))
+	assert.Equal(t, LastField, m2.TypeAt(100))
+	assert.Equal(t, LastField, m2.TypeAt(199))
+	assert.Equal(t, MaxField, m2.TypeAt(200))
+
+	assert.Equal(t, []Type{SumField}, m2.TypesIn(0, 99))
+	assert.Equal(t, []Type{SumField, LastField}, m2.TypesIn(0, 100))
+	assert.Equal(t, []Type{LastField}, m2.TypesIn(100, 199))
+	assert.Equal(t, []Type{LastField, MaxField}, m2.TypesIn(150, 250))
+	assert.Equal(t, []Type{SumField, LastField, MaxField}, m2.TypesIn(50, 250))
+	assert.Equal(t, []Type{MaxField}, m2.TypesIn(300, 400))
+	// change back to same type
+	m3 := m1.ChangeType(SumField, 200)
+	assert.Equal(t, []Type{SumField, LastField, SumField}, m3.TypesIn(0, 300))
+}
+
+func TestMetas_Binary(t *testing.T) {
+	f1 := Meta{ID: 1, Type: SumField, Name: "f1"}
+	f2 := Meta{ID: 2, Type: MaxField, Name: "f2"}
+	var data []byte
+	for _, f := range []Meta{f1, f2, f1.ChangeType(LastField, 100), f1.ChangeType(LastField, 100).ChangeType(FirstField, 200)} {
+		val, err := f.MarshalBinary()
+		assert.NoError(t, err)
+		data = append(data, val...)
+	}
+	metas, max, err := UnmarshalBinary(data)
+	assert.NoError(t, err)
+	assert.Equal(t, ID(2), max)
+	assert.Equal(t, Metas{
+		{ID: 1, Type: FirstField, Name: "f1", History: []TypeChange{{Type: SumField, Until: 100}, {Type: LastField, Until: 200}}},
+		f2,
+	}, metas)
+
+	// corrupt data
+	_, _, err = UnmarshalBinary(data[:len(data)-2])
+	assert.Error(t, err)
+}
@@ -47,12 +47,14 @@ const (
 	Max
 	Last
 	First
+	// ConditionalSum represents sum of points matching predicate(sum_if), count_if uses Count.
+	ConditionalSum
 )
 
 // Aggregate aggregates two float64 values into one
 func (t AggType) Aggregate(a, b float64) float64 {
 	switch t {
-	case Sum, Count:
+	case Sum, Count, ConditionalSum:
 		return a + b
 	case Last:
 		return b
@@ -79,6 +81,8 @@ const (
 	LastField
 	HistogramField // alias for sumField, only visible for tsdb
 	FirstField
+	// ExponentialHistogramField represents bucket of exponential histogram(sketch), only visible for tsdb
+	ExponentialHistogramField
 )
 
 // String returns the field type's string value
@@ -96,6 +100,8 @@ func (t Type) String() string {
 		return "histogram"
 	case FirstField:
 		return "first"
+	case ExponentialHistogramField:
+		return "exponential_histogram"
 	default:
 		return "unknown"
 	}
@@ -104,7 +110,7 @@ func (t Type) String() string {
 // AggType returns the aggregate function
 func (t Type) AggType() AggType {
 	switch t {
-	case SumField, HistogramField:
+	case SumField, HistogramField, ExponentialHistogramField:
 		return Sum
 	case MinField:
 		return Min
@@ -119,6 +125,36 @@ func (t Type) AggType() AggType {
 	}
 }
 
+// CanMergeWith returns the type which data written as both types is stored as, if both types can be merged.
+// Types with same aggregation family can be merged, sum family(sum/histogram) accumulates deltas,
+// gauge family(min/max/last/first) keeps one of sampled values. First writer wins, so the receiver
+// (type written first) is returned, cross family types cannot be merged, because summing gauges or
+// keeping one of deltas changes the meaning of data.
+func (t Type) CanMergeWith(other Type) (Type, bool) {
+	if t == Unknown || other == Unknown {
+		return Unknown, false
+	}
+	if t == other || (t.isSumFamily() && other.isSumFamily()) || (t.isGaugeFamily() && other.isGaugeFamily()) {
+		return t, true
+	}
+	return Unknown, false
+}
+
+// isSumFamily returns if field type accumulates deltas.
+func (t Type) isSumFamily() bool {
+	return t == SumField || t == HistogramField
+}
+
+// isGaugeFamily returns if field type keeps one of sampled values.
+func (t Type) isGaugeFamily() bool {
+	switch t {
+	case MinField, MaxField, LastField, FirstField:
+		return true
+	default:
+		return false
+	}
+}
+
 func (t Type) DownSamplingFunc() function.FuncType {
 	switch t {
 	case SumField:
@@ -131,7 +167,7 @@ func (t Type) DownSamplingFunc() function.FuncType {
 		return function.Last
 	case FirstField:
 		return function.First
-	case HistogramField:
+	case HistogramField, ExponentialHistogramField:
 		return function.Sum
 	default:
 		return function.Unknown
@@ -139,10 +175,14 @@ func (t Type) DownSamplingFunc() function.FuncType {
 }
 
 func (t Type) IsFuncSupported(funcType function.FuncType) bool {
+	if function.IsConditional(funcType) {
+		// conditional aggregation evaluates predicate on stored point, histogram bucket not supported
+		return t != HistogramField && t != ExponentialHistogramField && t != Unknown
+	}
 	switch t {
 	case SumField:
 		switch funcType {
-		case function.Sum, function.Min, function.Max, function.Rate:
+		case function.Sum, function.Min, function.Max, function.Rate, function.Derivative, function.IRate:
 			return true
 		default:
 			return false
@@ -163,7 +203,7 @@ func (t Type) IsFuncSupported(funcType function.FuncType) bool {
 		}
 	case LastField:
 		switch funcType {
-		case function.Sum, function.Min, function.Max, function.Last:
+		case function.Sum, function.Min, function.Max, function.Last, function.Derivative, function.IRate:
 			return true
 		default:
 			return false
@@ -175,9 +215,9 @@ func (t Type) IsFuncSupported(funcType function.FuncType) bool {
 		default:
 			return false
 		}
-	case HistogramField:
+	case HistogramField, ExponentialHistogramField:
 		switch funcType {
-		case function.Sum:
+		case function.Sum, function.Quantile:
 			return true
 		default:
 			return false
@@ -189,6 +229,12 @@ func (t Type) IsFuncSupported(funcType function.FuncType) bool {
 
 // GetFuncFieldParams returns agg type for field aggregator by given function type.
 func (t Type) GetFuncFieldParams(funcType function.FuncType) []AggType {
+	switch funcType {
+	case function.CountIf:
+		return []AggType{Count}
+	case function.SumIf:
+		return []AggType{ConditionalSum}
+	}
 	switch t {
 	case SumField:
 		return getFieldParamsForSumField(funcType)
@@ -200,7 +246,7 @@ func (t Type) GetFuncFieldParams(funcType function.FuncType) []AggType {
 		return getFieldParamsForMinField(funcType)
 	case MaxField:
 		return getFieldParamsForMaxField(funcType)
-	case HistogramField:
+	case HistogramField, ExponentialHistogramField:
 		// Histogram field only supports sum
 		return []AggType{Sum}
 	}
@@ -220,7 +266,7 @@ func (t Type) GetDefaultFuncFieldParams() []AggType {
 		return []AggType{First}
 	case MaxField:
 		return []AggType{Max}
-	case HistogramField:
+	case HistogramField, ExponentialHistogramField:
 		return []AggType{Sum}
 	}
 	return nil
@@ -28,6 +28,7 @@ import (
 func TestDownSamplingFunc(t *testing.T) {
 	assert.Equal(t, function.Sum, SumField.DownSamplingFunc())
 	assert.Equal(t, function.Sum, HistogramField.DownSamplingFunc())
+	assert.Equal(t, function.Sum, ExponentialHistogramField.DownSamplingFunc())
 	assert.Equal(t, function.Min, MinField.DownSamplingFunc())
 	assert.Equal(t, function.Max, MaxField.DownSamplingFunc())
 	assert.Equal(t, function.Last, LastField.DownSamplingFunc())
@@ -35,6 +36,42 @@ func TestDownSamplingFunc(t *testing.T) {
 	assert.Equal(t, function.Unknown, Unknown.DownSamplingFunc())
 }
 
+func TestType_CanMergeWith(t *testing.T) {
+	cases := []struct {
+		name     string
+		existing Type
+		other    Type
+		winner   Type
+		ok       bool
+	}{
+		{name: "same type", existing: SumField, other: SumField, winner: SumField, ok: true},
+		{name: "sum vs last", existing: SumField, other: LastField, winner: Unknown, ok: false},
+		{name: "last vs sum", existing: LastField, other: SumField, winner: Unknown, ok: false},
+		{name: "min vs max", existing: MinField, other: MaxField, winner: MinField, ok: true},
+		{name: "max vs min", existing: MaxField, other: MinField, winner: MaxField, ok: true},
+		{name: "last vs first", existing: LastField, other: FirstField, winner: LastField, ok: true},
+		{name: "histogram vs sum", existing: HistogramField, other: SumField, winner: HistogramField, ok: true},
+		{name: "sum vs histogram", existing: SumField, other: HistogramField, winner: SumField, ok: true},
+		{name: "histogram vs max", existing: HistogramField, other: MaxField, winner: Unknown, ok: false},
+		{name: "exponential histogram vs sum", existing: ExponentialHistogramField, other: SumField, winner: Unknown, ok: false},
+		{name: "exponential histogram vs histogram", existing: ExponentialHistogramField, other: HistogramField, winner: Unknown, ok: false},
+		{name: "unknown", existing: Unknown, other: Unknown, winner: Unknown, ok: false},
+		{name: "sum vs unknown", existing: SumField, other: Unknown, winner: Unknown, ok: false},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			winner, ok := tt.existing.CanMergeWith(tt.other)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.winner, winner)
+			if ok {
+				// query uses the down sampling function of winning type
+				assert.Equal(t, tt.existing.DownSamplingFunc(), winner.DownSamplingFunc())
+			}
+		})
+	}
+}
+
 func TestType_String(t *testing.T) {
 	assert.Equal(t, "sum", SumField.String())
 	assert.Equal(t, "max", MaxField.String())
@@ -42,13 +79,19 @@ func TestType_String(t *testing.T) {
 	assert.Equal(t, "last", LastField.String())
 	assert.Equal(t, "first", FirstField.String())
 	assert.Equal(t, "histogram", HistogramField.String())
+	assert.Equal(t, "exponential_histogram", ExponentialHistogramField.String())
 	assert.Equal(t, "unknown", Unknown.String())
 	assert.Equal(t, "name", Name("name").String())
 }
 
 func TestIsSupportFunc(t *testing.T) {
 	assert.True(t, HistogramField.IsFuncSupported(function.Sum))
+	assert.True(t, HistogramField.IsFuncSupported(function.Quantile))
+	assert.False(t, SumField.IsFuncSupported(function.Quantile))
 	assert.False(t, HistogramField.IsFuncSupported(function.Last))
+	assert.True(t, ExponentialHistogramField.IsFuncSupported(function.Sum))
+	assert.True(t, ExponentialHistogramField.IsFuncSupported(function.Quantile))
+	assert.False(t, ExponentialHistogramField.IsFuncSupported(function.Max))
 
 	assert.True(t, SumField.IsFuncSupported(function.Sum))
 	assert.True(t, SumField.IsFuncSupported(function.Min))
@@ -68,6 +111,20 @@ func TestIsSupportFunc(t *testing.T) {
 	assert.False(t, MinField.IsFuncSupported(function.Quantile))
 
 	assert.False(t, Unknown.IsFuncSupported(function.Quantile))
+
+	assert.True(t, SumField.IsFuncSupported(function.CountIf))
+	assert.True(t, LastField.IsFuncSupported(function.SumIf))
+	assert.False(t, HistogramField.IsFuncSupported(function.CountIf))
+	assert.False(t, ExponentialHistogramField.IsFuncSupported(function.SumIf))
+	assert.False(t, Unknown.IsFuncSupported(function.SumIf))
+
+	assert.True(t, SumField.IsFuncSupported(function.Derivative))
+	assert.True(t, LastField.IsFuncSupported(function.Derivative))
+	assert.False(t, MaxField.IsFuncSupported(function.Derivative))
+	assert.False(t, HistogramField.IsFuncSupported(function.Derivative))
+	assert.True(t, SumField.IsFuncSupported(function.IRate))
+	assert.True(t, LastField.IsFuncSupported(function.IRate))
+	assert.False(t, MaxField.IsFuncSupported(function.IRate))
 }
 
 func TestAggType_Aggregate(t *testing.T) {
@@ -83,6 +140,9 @@ func TestAggType_Aggregate(t *testing.T) {
 
 	assert.Equal(t, 1.0, FirstField.AggType().Aggregate(1, 99.0))
 
+	assert.Equal(t, 3.0, Count.Aggregate(1, 2))
+	assert.Equal(t, 100.0, ConditionalSum.Aggregate(1, 99.0))
+
 	assert.Panics(t, func() {
 		AggType(22).Aggregate(1, 2)
 	})
@@ -97,6 +157,7 @@ func TestPanicAgg(t *testing.T) {
 func TestType_GetFuncFieldParams(t *testing.T) {
 	assert.Empty(t, Type(99).GetFuncFieldParams(function.Min))
 	assert.Equal(t, []AggType{Sum}, HistogramField.GetFuncFieldParams(function.Min))
+	assert.Equal(t, []AggType{Sum}, ExponentialHistogramField.GetFuncFieldParams(function.Max))
 
 	assert.Equal(t, []AggType{Max}, MaxField.GetFuncFieldParams(function.Max))
 	assert.Equal(t, []AggType{Min}, MaxField.GetFuncFieldParams(function.Min))
@@ -117,11 +178,15 @@ func TestType_GetFuncFieldParams(t *testing.T) {
 	assert.Equal(t, []AggType{Max}, FirstField.GetFuncFieldParams(function.Max))
 	assert.Equal(t, []AggType{Min}, FirstField.GetFuncFieldParams(function.Min))
 	assert.Equal(t, []AggType{First}, FirstField.GetFuncFieldParams(function.First))
+
+	assert.Equal(t, []AggType{Count}, MaxField.GetFuncFieldParams(function.CountIf))
+	assert.Equal(t, []AggType{ConditionalSum}, SumField.GetFuncFieldParams(function.SumIf))
 }
 
 func TestType_GetDefaultFuncFieldParams(t *testing.T) {
 	assert.Empty(t, Type(99).GetDefaultFuncFieldParams())
 	assert.Equal(t, []AggType{Sum}, HistogramField.GetDefaultFuncFieldParams())
+	assert.Equal(t, []AggType{Sum}, ExponentialHistogramField.GetDefaultFuncFieldParams())
 	assert.Equal(t, []AggType{Sum}, SumField.GetDefaultFuncFieldParams())
 	assert.Equal(t, []AggType{Max}, MaxField.GetDefaultFuncFieldParams())
 	assert.Equal(t, []AggType{Min}, MinField.GetDefaultFuncFieldParams())
@@ -132,6 +197,7 @@ func TestType_GetDefaultFuncFieldParams(t *testing.T) {
 func Test_GetOrderByFunc(t *testing.T) {
 	assert.Equal(t, function.Stddev, Unknown.GetOrderByFunc())
 	assert.Equal(t, function.Stddev, HistogramField.GetOrderByFunc())
+	assert.Equal(t, function.Stddev, ExponentialHistogramField.GetOrderByFunc())
 	assert.Equal(t, function.Sum, SumField.GetOrderByFunc())
 	assert.Equal(t, function.Min, MinField.GetOrderByFunc())
 	assert.Equal(t, function.Max, MaxField.GetOrderByFunc())
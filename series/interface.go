@@ -43,7 +43,8 @@ type TagValueSuggester interface {
 type Filter interface {
 	// GetSeriesIDsByTagValueIDs gets series ids by tag value ids for spec tag key of metric
 	GetSeriesIDsByTagValueIDs(tagKeyID tag.KeyID, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error)
-	// GetSeriesIDsForTag gets series ids for spec tag key of metric
+	// GetSeriesIDsForTag gets series ids for spec tag key of metric(union of series ids with any tag value),
+	// which is the base set of not expr and tag exists filter(tag != '').
 	GetSeriesIDsForTag(tagKeyID tag.KeyID) (*roaring.Bitmap, error)
 	// GetSeriesIDsForMetric gets series ids for spec metric name
 	GetSeriesIDsForMetric(namespace, metricName string) (*roaring.Bitmap, error)
@@ -0,0 +1,82 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metric
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+	commonseries "github.com/lindb/common/series"
+
+	"github.com/lindb/lindb/pkg/sketch"
+)
+
+const (
+	exponentialBucketPrefix = "__sketch_"
+	exponentialZeroBucket   = exponentialBucketPrefix + "zero"
+)
+
+// BucketNameOfExponentialIndex converts reserved field-name for exponential histogram bucket index.
+func BucketNameOfExponentialIndex(index int32) string {
+	return exponentialBucketPrefix + strconv.FormatInt(int64(index), 10)
+}
+
+// ExponentialBucketIndex extracts the bucket index from bucketName, isZero returns true for zero bucket.
+func ExponentialBucketIndex(bucketName string) (index int32, isZero bool, err error) {
+	// make sure it has prefix with __sketch_
+	if !strings.HasPrefix(bucketName, exponentialBucketPrefix) {
+		return 0, false, fmt.Errorf("bucketName:%s not startswith '%s'", bucketName, exponentialBucketPrefix)
+	}
+	if bucketName == exponentialZeroBucket {
+		return 0, true, nil
+	}
+	idx, err := strconv.ParseInt(bucketName[len(exponentialBucketPrefix):], 10, 32)
+	if err != nil {
+		return 0, false, err
+	}
+	return int32(idx), false, nil
+}
+
+// IsExponentialBucket checks if field name is the reserved field-name of exponential histogram bucket.
+func IsExponentialBucket(fieldName string) bool {
+	return strings.HasPrefix(fieldName, exponentialBucketPrefix)
+}
+
+// AddExponentialHistogram adds the buckets of exponential histogram into row as delta sum simple fields,
+// each non-empty bucket is encoded as __sketch_${index}(zero bucket as __sketch_zero),
+// storage keeps them as exponential histogram field, and buckets with same index are merged by sum.
+func AddExponentialHistogram(rb *commonseries.RowBuilder, h *sketch.ExponentialHistogram) error {
+	if zeroCount := h.ZeroCount(); zeroCount > 0 {
+		if err := rb.AddSimpleField([]byte(exponentialZeroBucket), flatMetricsV1.SimpleFieldTypeDeltaSum, zeroCount); err != nil {
+			return err
+		}
+	}
+	indexes, counts := h.Buckets()
+	for idx, index := range indexes {
+		if counts[idx] <= 0 {
+			continue
+		}
+		if err := rb.AddSimpleField([]byte(BucketNameOfExponentialIndex(index)),
+			flatMetricsV1.SimpleFieldTypeDeltaSum, counts[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metric
+
+import (
+	"testing"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/common/proto/gen/v1/flatMetricsV1"
+	commonseries "github.com/lindb/common/series"
+
+	"github.com/lindb/lindb/pkg/sketch"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestExponentialBucketName(t *testing.T) {
+	assert.Equal(t, "__sketch_10", BucketNameOfExponentialIndex(10))
+	assert.Equal(t, "__sketch_-5", BucketNameOfExponentialIndex(-5))
+	assert.True(t, IsExponentialBucket("__sketch_-5"))
+	assert.False(t, IsExponentialBucket("__bucket_5"))
+
+	index, isZero, err := ExponentialBucketIndex("__sketch_-5")
+	assert.NoError(t, err)
+	assert.False(t, isZero)
+	assert.Equal(t, int32(-5), index)
+	_, isZero, err = ExponentialBucketIndex("__sketch_zero")
+	assert.NoError(t, err)
+	assert.True(t, isZero)
+	_, _, err = ExponentialBucketIndex("__bucket_5")
+	assert.Error(t, err)
+	_, _, err = ExponentialBucketIndex("__sketch_x")
+	assert.Error(t, err)
+}
+
+func TestAddExponentialHistogram(t *testing.T) {
+	h := sketch.NewExponentialHistogram()
+	for _, v := range []float64{0, 1, 1, 100} {
+		assert.NoError(t, h.Add(v))
+	}
+	h.AddBucket(20, 0)
+
+	builder, releaseFunc := commonseries.NewRowBuilder()
+	defer releaseFunc(builder)
+	builder.AddMetricName([]byte("test"))
+	_ = builder.AddSimpleField([]byte("f1"), flatMetricsV1.SimpleFieldTypeDeltaSum, 100)
+	assert.NoError(t, AddExponentialHistogram(builder, h))
+	data, err := builder.Build()
+	assert.NoError(t, err)
+
+	var brokerRow BrokerRow
+	brokerRow.FromBlock(data)
+	var row StorageRow
+	row.Unmarshal(brokerRow.buffer[flatbuffers.SizeUOffsetT:])
+	fields := make(map[field.Name]float64)
+	itr := row.NewSimpleFieldIterator()
+	for itr.HasNext() {
+		if itr.NextName() == "f1" {
+			assert.Equal(t, field.SumField, itr.NextType())
+		} else {
+			assert.Equal(t, field.ExponentialHistogramField, itr.NextType())
+		}
+		fields[itr.NextName()] = itr.NextValue()
+	}
+	assert.Equal(t, map[field.Name]float64{
+		"f1":            100,
+		"__sketch_zero": 1,
+		field.Name(BucketNameOfExponentialIndex(sketch.ExponentialIndex(1))):   2,
+		field.Name(BucketNameOfExponentialIndex(sketch.ExponentialIndex(100))): 1,
+	}, fields)
+
+	// invalid bucket count
+	h.AddBucket(30, -1)
+	h.AddBucket(40, 0.5)
+	h.AddZeroCount(0)
+	assert.NoError(t, AddExponentialHistogram(builder, h))
+}
@@ -69,11 +69,20 @@ func (row *BrokerRow) WriteTo(writer io.Writer) (int, error) {
 
 var brokerBatchRowsPool sync.Pool
 
+// errBrokerBatchRowsReleased represents the message of panic when released batch is accessed.
+const errBrokerBatchRowsReleased = "broker batch rows is accessed after released"
+
 // BrokerBatchRows holds rows from ingestion
 // row will be putted into buffer after validation and re-building
 type BrokerBatchRows struct {
 	rows     []BrokerRow
 	rowCount int
+	// batchID is the client-supplied id of write batch for idempotency, empty if not supplied.
+	batchID string
+
+	// generation increases when batch released, iterator created before releasing cannot be used.
+	generation uint64
+	released   bool
 
 	shardGroupIterator BrokerBatchShardIterator
 }
@@ -82,21 +91,52 @@ func newBrokerBatchRows() *BrokerBatchRows {
 	return &BrokerBatchRows{}
 }
 
-// NewBrokerBatchRows returns a new batch for decoding flat metrics.
+// NewBrokerBatchRows returns a new batch for decoding flat metrics, which is not picked from pool.
 func NewBrokerBatchRows() (batch *BrokerBatchRows) {
+	return newBrokerBatchRows()
+}
+
+// AcquireBrokerBatchRows picks a batch from pool for decoding flat metrics,
+// the batch should be released by ReleaseBrokerBatchRows after using.
+func AcquireBrokerBatchRows() (batch *BrokerBatchRows) {
 	item := brokerBatchRowsPool.Get()
 	if item != nil {
-		builder := item.(*BrokerBatchRows)
-		builder.reset()
-		return builder
+		batch = item.(*BrokerBatchRows)
+		batch.reset()
+		return batch
 	}
 	return newBrokerBatchRows()
 }
 
-// Release releases rows context into sync.Pool
-func (br *BrokerBatchRows) Release() { brokerBatchRowsPool.Put(br) }
+// ReleaseBrokerBatchRows releases rows context into sync.Pool,
+// the backing buffer of rows will be reused by next batch.
+func ReleaseBrokerBatchRows(batch *BrokerBatchRows) {
+	if batch == nil {
+		return
+	}
+	batch.generation++
+	batch.released = true
+	brokerBatchRowsPool.Put(batch)
+}
+
+func (br *BrokerBatchRows) reset() {
+	br.rowCount = 0
+	br.batchID = ""
+	br.released = false
+}
+
+// SetBatchID sets the client-supplied id of write batch, rows with same batch id are written once.
+func (br *BrokerBatchRows) SetBatchID(batchID string) { br.batchID = batchID }
 
-func (br *BrokerBatchRows) reset() { br.rowCount = 0 }
+// BatchID returns the client-supplied id of write batch, empty if not supplied.
+func (br *BrokerBatchRows) BatchID() string { return br.batchID }
+
+// checkReleased panics if batch is accessed after released.
+func (br *BrokerBatchRows) checkReleased() {
+	if br.released {
+		panic(errBrokerBatchRowsReleased)
+	}
+}
 
 func (br *BrokerBatchRows) Len() int { return br.rowCount }
 
@@ -106,10 +146,14 @@ func (br *BrokerBatchRows) Less(i, j int) bool {
 
 func (br *BrokerBatchRows) Swap(i, j int) { br.rows[i], br.rows[j] = br.rows[j], br.rows[i] }
 
-func (br *BrokerBatchRows) Rows() []BrokerRow { return br.rows[:br.rowCount] }
+func (br *BrokerBatchRows) Rows() []BrokerRow {
+	br.checkReleased()
+	return br.rows[:br.rowCount]
+}
 
 // EvictOutOfTimeRange evicts and marks out-of-range metrics invalid
 func (br *BrokerBatchRows) EvictOutOfTimeRange(behind, ahead int64) (evicted int) {
+	br.checkReleased()
 	// check metric timestamp if in acceptable time range
 	now := fasttime.UnixMilliseconds()
 	for idx := 0; idx < br.Len(); idx++ {
@@ -122,11 +166,35 @@ func (br *BrokerBatchRows) EvictOutOfTimeRange(behind, ahead int64) (evicted int
 	return evicted
 }
 
+// RemoveRows removes the rows matched by remove func from batch, keeps the order of remaining rows,
+// returns the num. of removed rows.
+func (br *BrokerBatchRows) RemoveRows(remove func(row *BrokerRow) bool) (removed int) {
+	br.checkReleased()
+	kept := 0
+	for idx := 0; idx < br.rowCount; idx++ {
+		if remove(&br.rows[idx]) {
+			continue
+		}
+		if kept != idx {
+			// swap for keeping the backing buffer of removed row, which is reused by next batch
+			br.Swap(kept, idx)
+		}
+		kept++
+	}
+	removed = br.rowCount - kept
+	br.rowCount = kept
+	return removed
+}
+
 func (br *BrokerBatchRows) TryAppend(appendFunc func(row *BrokerRow) error) error {
+	br.checkReleased()
 	if len(br.rows) <= br.rowCount {
 		br.rows = append(br.rows, BrokerRow{})
 	}
-	if err := appendFunc(&br.rows[br.rowCount]); err != nil {
+	row := &br.rows[br.rowCount]
+	// row may be reused from released batch, reset the state of previous row
+	row.IsOutOfTimeRange = false
+	if err := appendFunc(row); err != nil {
 		return err
 	}
 	// decoded successfully, move to next row index
@@ -135,10 +203,12 @@ func (br *BrokerBatchRows) TryAppend(appendFunc func(row *BrokerRow) error) erro
 }
 
 func (br *BrokerBatchRows) NewShardGroupIterator(numOfShards int32) *BrokerBatchShardIterator {
+	br.checkReleased()
 	for i := 0; i < br.Len(); i++ {
 		br.rows[i].shardIdx = int(jump.Hash(br.rows[i].m.Hash(), numOfShards))
 	}
 	br.shardGroupIterator.batch = br
+	br.shardGroupIterator.generation = br.generation
 	br.shardGroupIterator.Reset()
 	return &br.shardGroupIterator
 }
@@ -150,11 +220,19 @@ type BrokerBatchShardIterator struct {
 	groupStart    int // group start index
 	groupShardIdx int // group shard index in shards list
 
-	batch *BrokerBatchRows
+	batch      *BrokerBatchRows
+	generation uint64 // generation of batch when iterator created
 
 	familyIterator BrokerBatchShardFamilyIterator
 }
 
+// checkGeneration panics if batch is released after iterator created.
+func (itr *BrokerBatchShardIterator) checkGeneration() {
+	if itr.generation != itr.batch.generation {
+		panic(errBrokerBatchRowsReleased)
+	}
+}
+
 // Reset re-sorts batch rows for batching inserting
 func (itr *BrokerBatchShardIterator) Reset() {
 	sort.Sort(itr.batch)
@@ -164,6 +242,7 @@ func (itr *BrokerBatchShardIterator) Reset() {
 }
 
 func (itr *BrokerBatchShardIterator) HasRowsForNextShard() bool {
+	itr.checkGeneration()
 	if itr.groupEnd >= itr.batch.Len() || itr.groupStart > itr.groupEnd {
 		return false
 	}
@@ -185,6 +264,7 @@ func (itr *BrokerBatchShardIterator) FamilyRowsForNextShard(
 	shardIdx int,
 	familyIterator *BrokerBatchShardFamilyIterator,
 ) {
+	itr.checkGeneration()
 	itr.familyIterator.reset(
 		itr.batch.rows[itr.groupStart:itr.groupEnd],
 		interval,
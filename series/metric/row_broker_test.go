@@ -35,12 +35,43 @@ import (
 
 func Test_BrokerBatchRows(t *testing.T) {
 	for i := 0; i < 10; i++ {
-		brokerRows := NewBrokerBatchRows()
+		brokerRows := AcquireBrokerBatchRows()
 		assertBrokerBatchRows(t, brokerRows)
-		brokerRows.Release()
+		ReleaseBrokerBatchRows(brokerRows)
 	}
 }
 
+func Test_BrokerBatchRows_Released(t *testing.T) {
+	ReleaseBrokerBatchRows(nil)
+
+	brokerRows := AcquireBrokerBatchRows()
+	assertBrokerBatchRows(t, brokerRows)
+	itr := brokerRows.NewShardGroupIterator(1)
+	ReleaseBrokerBatchRows(brokerRows)
+
+	var interval timeutil.Interval
+	_ = interval.ValueOf("10s")
+	assert.PanicsWithValue(t, errBrokerBatchRowsReleased, func() { brokerRows.Rows() })
+	assert.PanicsWithValue(t, errBrokerBatchRowsReleased, func() { brokerRows.NewShardGroupIterator(1) })
+	assert.PanicsWithValue(t, errBrokerBatchRowsReleased, func() { brokerRows.EvictOutOfTimeRange(100, 100) })
+	assert.PanicsWithValue(t, errBrokerBatchRowsReleased, func() {
+		brokerRows.RemoveRows(func(row *BrokerRow) bool { return false })
+	})
+	assert.PanicsWithValue(t, errBrokerBatchRowsReleased, func() {
+		_ = brokerRows.TryAppend(func(row *BrokerRow) error { return nil })
+	})
+	assert.PanicsWithValue(t, errBrokerBatchRowsReleased, func() { itr.HasRowsForNextShard() })
+	assert.PanicsWithValue(t, errBrokerBatchRowsReleased, func() { itr.FamilyRowsForNextShard(interval) })
+
+	// iterator of previous generation cannot be used after batch reused
+	reused := &BrokerBatchRows{}
+	itr = reused.NewShardGroupIterator(1)
+	ReleaseBrokerBatchRows(reused)
+	reused.reset()
+	assert.PanicsWithValue(t, errBrokerBatchRowsReleased, func() { itr.HasRowsForNextShard() })
+	assert.False(t, reused.NewShardGroupIterator(1).HasRowsForNextShard())
+}
+
 func assertBrokerBatchRows(t *testing.T, brokerRows *BrokerBatchRows) {
 	now := fasttime.UnixMilliseconds()
 
@@ -53,6 +84,10 @@ func assertBrokerBatchRows(t *testing.T, brokerRows *BrokerBatchRows) {
 		}))
 	}
 	assert.Equal(t, 1000, brokerRows.Len())
+	for _, row := range brokerRows.Rows() {
+		// eviction state of reused row is reset
+		assert.False(t, row.IsOutOfTimeRange)
+	}
 
 	// only one shard
 	itr := brokerRows.NewShardGroupIterator(1)
@@ -105,7 +140,6 @@ func buildRow(row *BrokerRow, timestamp int64) {
 
 func Test_BrokerBatchRows_AppendError(t *testing.T) {
 	batch := NewBrokerBatchRows()
-	defer batch.Release()
 
 	assert.Error(t, batch.TryAppend(func(row *BrokerRow) error {
 		return io.ErrShortBuffer
@@ -113,6 +147,36 @@ func Test_BrokerBatchRows_AppendError(t *testing.T) {
 	assert.Equal(t, 0, batch.Len())
 }
 
+func Test_BrokerBatchRows_RemoveRows(t *testing.T) {
+	batch := NewBrokerBatchRows()
+	for i := 0; i < 10; i++ {
+		i := i
+		assert.NoError(t, batch.TryAppend(func(row *BrokerRow) error {
+			buildRow(row, int64(1000+i))
+			return nil
+		}))
+	}
+	assert.Zero(t, batch.RemoveRows(func(row *BrokerRow) bool { return false }))
+	assert.Equal(t, 10, batch.Len())
+	// remove odd timestamp
+	assert.Equal(t, 5, batch.RemoveRows(func(row *BrokerRow) bool {
+		return row.m.Timestamp()%2 == 1
+	}))
+	assert.Equal(t, 5, batch.Len())
+	for i, row := range batch.Rows() {
+		assert.Equal(t, int64(1000+i*2), row.m.Timestamp())
+	}
+	// backing buffers of removed rows are reused by next append
+	assert.NoError(t, batch.TryAppend(func(row *BrokerRow) error {
+		buildRow(row, 100)
+		return nil
+	}))
+	assert.Equal(t, int64(100), batch.Rows()[5].m.Timestamp())
+	assert.Equal(t, 6, batch.Len())
+	assert.Equal(t, 6, batch.RemoveRows(func(row *BrokerRow) bool { return true }))
+	assert.Zero(t, batch.Len())
+}
+
 func Test_BrokerRow_Writer(t *testing.T) {
 	var row BrokerRow
 	row.IsOutOfTimeRange = true
@@ -133,6 +133,10 @@ func (itr *SimpleFieldIterator) NextType() field.Type {
 	switch itr.f.Type() {
 	// assertion: cumulative should be converted before writing into memdb
 	case flatMetricsV1.SimpleFieldTypeDeltaSum:
+		if IsExponentialBucket(string(itr.f.Name())) {
+			// reserved bucket field of exponential histogram
+			return field.ExponentialHistogramField
+		}
 		return field.SumField
 	case flatMetricsV1.SimpleFieldTypeLast:
 		return field.LastField
@@ -188,6 +192,16 @@ const (
 	histogramMin   = field.Name("HistogramMin")
 )
 
+// IsHistogramStatsField checks if field name is the sum/count/max/min field of histogram.
+func IsHistogramStatsField(name field.Name) bool {
+	switch name {
+	case histogramSum, histogramCount, histogramMax, histogramMin:
+		return true
+	default:
+		return false
+	}
+}
+
 func (itr *CompoundFieldIterator) HistogramSumFieldName() field.Name { return histogramSum }
 
 func (itr *CompoundFieldIterator) HistogramCountFieldName() field.Name { return histogramCount }
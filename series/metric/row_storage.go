@@ -29,6 +29,8 @@ type StorageRow struct {
 	SeriesID  uint32
 	SlotIndex uint16
 	FieldIDs  []field.ID
+	// FieldTypes represents the type which each field is stored as, maybe coerced from written type
+	FieldTypes []field.Type
 
 	Writable bool // Writable symbols if all meta information is set
 	readOnlyRow
@@ -41,9 +43,19 @@ func (mr *StorageRow) Unmarshal(data []byte) {
 	mr.SeriesID = 0
 	mr.SlotIndex = 0
 	mr.FieldIDs = mr.FieldIDs[:0]
+	mr.FieldTypes = mr.FieldTypes[:0]
 	mr.Writable = false
 }
 
+// StoredFieldType returns the type which field is stored as by index,
+// if not resolved returns the type written by client.
+func (mr *StorageRow) StoredFieldType(idx int, writtenType field.Type) field.Type {
+	if idx < len(mr.FieldTypes) {
+		return mr.FieldTypes[idx]
+	}
+	return writtenType
+}
+
 // StorageBatchRows holds multi rows for inserting into memdb
 // It is reused in sync.Pool
 type StorageBatchRows struct {
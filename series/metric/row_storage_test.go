@@ -259,3 +259,20 @@ func TestStorageBatchRows_Sorts(t *testing.T) {
 	rows.rows = []StorageRow{mr1, mr2}
 	sort.Sort(rows)
 }
+
+func TestStorageRow_StoredFieldType(t *testing.T) {
+	var builder = flatbuffers.NewBuilder(1024)
+	buildFlatMetric(builder)
+
+	var row StorageRow
+	row.Unmarshal(builder.FinishedBytes())
+	// not resolved, uses written type
+	assert.Equal(t, field.LastField, row.StoredFieldType(0, field.LastField))
+	// coerced into type written first
+	row.FieldTypes = append(row.FieldTypes, field.SumField)
+	assert.Equal(t, field.SumField, row.StoredFieldType(0, field.LastField))
+	assert.Equal(t, field.MaxField, row.StoredFieldType(1, field.MaxField))
+
+	row.Unmarshal(builder.FinishedBytes())
+	assert.Empty(t, row.FieldTypes)
+}
@@ -29,6 +29,10 @@ type KeyID uint32
 // EmptyTagKeyID represents empty value for tag key id.
 const EmptyTagKeyID = KeyID(0)
 
+// EmptyTagValueID represents tag value id for series which missing the tag key,
+// tag value id generated by metadata starts with 1.
+const EmptyTagValueID = uint32(0)
+
 // Metas implements sort.Interface, it's sorted by name
 type Metas []Meta
 
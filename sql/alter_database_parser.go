@@ -0,0 +1,96 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lindb/lindb/pkg/strutil"
+	"github.com/lindb/lindb/pkg/timeutil"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var errAlterDatabaseSyntax = errors.New("alter database syntax error, e.g. alter database db set ttl='30d', replica=2")
+
+// alterDatabaseKeywords represents the keywords of alter database statement.
+var alterDatabaseKeywords = []string{"alter", "database"}
+
+// splitAlterDatabase returns the clause after alter database keywords, e.g. db set ttl='30d'.
+func splitAlterDatabase(sql string) (clause string, ok bool) {
+	pos := 0
+	for _, keyword := range alterDatabaseKeywords {
+		for pos < len(sql) && isBlank(sql[pos]) {
+			pos++
+		}
+		if !isKeywordAt(sql, pos, keyword) {
+			return "", false
+		}
+		pos += len(keyword)
+	}
+	return sql[pos:], true
+}
+
+// parseAlterDatabase parses the clause of alter database statement: name set key=value[, key=value].
+func parseAlterDatabase(clause string) (stmtpkg.Statement, error) {
+	clause = strings.TrimSuffix(strings.TrimSpace(clause), ";")
+	pos := 0
+	for pos < len(clause) && !isBlank(clause[pos]) {
+		pos++
+	}
+	name := strutil.GetStringValue(strings.Trim(clause[:pos], "`"))
+	for pos < len(clause) && isBlank(clause[pos]) {
+		pos++
+	}
+	if name == "" || !isKeywordAt(clause, pos, "set") {
+		return nil, errAlterDatabaseSyntax
+	}
+	stmt := &stmtpkg.AlterDatabase{Name: name}
+	options := make(map[string]struct{})
+	for _, item := range strings.Split(clause[pos+len("set"):], ",") {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			return nil, errAlterDatabaseSyntax
+		}
+		key := strings.ToLower(strings.TrimSpace(kv[0]))
+		value := strutil.GetStringValue(strings.TrimSpace(kv[1]))
+		if _, ok := options[key]; ok {
+			return nil, fmt.Errorf("duplicate option '%s' of alter database", key)
+		}
+		options[key] = struct{}{}
+		switch key {
+		case "ttl":
+			var ttl timeutil.Interval
+			if err := ttl.ValueOf(value); err != nil || ttl <= 0 {
+				return nil, fmt.Errorf("invalid ttl '%s' of alter database, e.g. ttl='30d'", value)
+			}
+			stmt.TTL = value
+		case "replica":
+			replica, err := strconv.Atoi(value)
+			if err != nil || replica <= 0 {
+				return nil, fmt.Errorf("invalid replica '%s' of alter database, replica must be greater than 0", value)
+			}
+			stmt.ReplicaFactor = replica
+		default:
+			return nil, fmt.Errorf("unknown option '%s' of alter database, only supports ttl/replica", key)
+		}
+	}
+	return stmt, nil
+}
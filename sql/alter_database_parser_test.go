@@ -0,0 +1,72 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestAlterDatabase(t *testing.T) {
+	cases := []struct {
+		sql    string
+		stmt   stmt.Statement
+		hasErr bool
+	}{
+		{
+			sql:  "alter database db set ttl='30d', replica=2",
+			stmt: &stmt.AlterDatabase{Name: "db", TTL: "30d", ReplicaFactor: 2},
+		},
+		{
+			sql:  " ALTER\tDatabase `db_1`\nSET TTL = \"7d\";",
+			stmt: &stmt.AlterDatabase{Name: "db_1", TTL: "7d"},
+		},
+		{
+			sql:  "alter database 'db' set replica=3",
+			stmt: &stmt.AlterDatabase{Name: "db", ReplicaFactor: 3},
+		},
+		{sql: "alter database", hasErr: true},
+		{sql: "alter database db", hasErr: true},
+		{sql: "alter database db ttl='30d'", hasErr: true},
+		{sql: "alter database db set", hasErr: true},
+		{sql: "alter database db set ttl", hasErr: true},
+		{sql: "alter database db set ttl='30x'", hasErr: true},
+		{sql: "alter database db set ttl='0d'", hasErr: true},
+		{sql: "alter database db set replica=0", hasErr: true},
+		{sql: "alter database db set replica=a", hasErr: true},
+		{sql: "alter database db set replica=1, replica=2", hasErr: true},
+		{sql: "alter database db set shard=2", hasErr: true},
+	}
+	for _, c := range cases {
+		s, err := Parse(c.sql)
+		if c.hasErr {
+			assert.Error(t, err, c.sql)
+			continue
+		}
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.stmt, s, c.sql)
+	}
+	// not alter database statement
+	_, ok := splitAlterDatabase("alter databases")
+	assert.False(t, ok)
+	_, ok = splitAlterDatabase("show databases")
+	assert.False(t, ok)
+}
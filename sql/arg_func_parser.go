@@ -0,0 +1,204 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lindb/lindb/aggregation/function"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var errArgFuncNotQuery = errors.New("argmax/argmin only supports select statement")
+
+// argSelectorFuncs represents the arg selector functions, grammar not supports them.
+var argSelectorFuncs = []function.FuncType{function.ArgMax, function.ArgMin}
+
+// argFunc represents the arg selector function of select item, like argmax(host, latency).
+type argFunc struct {
+	itemIdx   int // index of select item
+	funcType  function.FuncType
+	tagKey    string
+	fieldName string
+}
+
+// splitArgFunc rewrites the arg selector functions(like argmax(host, latency)) of select list to
+// aggregation function of field(like max(latency)), because grammar not supports them,
+// arg selector function must be the whole select item(alias is allowed).
+func splitArgFunc(sql string) (rewrittenSQL string, funcs []*argFunc, err error) {
+	selectPos := findTopLevelKeyword(sql, 0, "select")
+	if selectPos < 0 {
+		return sql, nil, nil
+	}
+	listStart := selectPos + len("select")
+	listEnd := findTopLevelKeyword(sql, listStart, "from")
+	if listEnd < 0 {
+		return sql, nil, nil
+	}
+	items := splitTopLevelComma(sql[listStart:listEnd])
+	for idx, item := range items {
+		rewrittenItem, fn, err := parseArgFunc(item)
+		if err != nil {
+			return "", nil, err
+		}
+		if fn == nil {
+			continue
+		}
+		fn.itemIdx = idx
+		items[idx] = rewrittenItem
+		funcs = append(funcs, fn)
+	}
+	if len(funcs) == 0 {
+		return sql, nil, nil
+	}
+	return sql[:listStart] + strings.Join(items, ",") + sql[listEnd:], funcs, nil
+}
+
+// parseArgFunc parses the arg selector function of select item, returns nil if not arg selector function.
+func parseArgFunc(item string) (rewrittenItem string, fn *argFunc, err error) {
+	pos := 0
+	for pos < len(item) && isBlank(item[pos]) {
+		pos++
+	}
+	for _, funcType := range argSelectorFuncs {
+		name := funcType.String()
+		if !isKeywordAt(item, pos, name) {
+			continue
+		}
+		open := pos + len(name)
+		for open < len(item) && isBlank(item[open]) {
+			open++
+		}
+		if open >= len(item) || item[open] != '(' {
+			// maybe field named argmax
+			return item, nil, nil
+		}
+		closeParen := findCloseParen(item, open)
+		if closeParen < 0 {
+			return "", nil, fmt.Errorf("%s function params invalid", name)
+		}
+		params := splitTopLevelComma(item[open+1 : closeParen])
+		if len(params) != 2 {
+			return "", nil, fmt.Errorf("%s function params length invalid", name)
+		}
+		tagKey, ok := unquoteIdent(params[0])
+		if !ok {
+			return "", nil, fmt.Errorf("%s param: %s is not tag key", name, strings.TrimSpace(params[0]))
+		}
+		fieldName, ok := unquoteIdent(params[1])
+		if !ok {
+			return "", nil, fmt.Errorf("%s param: %s is not field", name, strings.TrimSpace(params[1]))
+		}
+		fn = &argFunc{funcType: funcType, tagKey: tagKey, fieldName: fieldName}
+		aggFunc := function.ArgSelectorAggFunc(funcType)
+		return fmt.Sprintf("%s%s(%s)%s", item[:pos], aggFunc, strings.TrimSpace(params[1]), item[closeParen+1:]), fn, nil
+	}
+	return item, nil, nil
+}
+
+// applyArgFunc replaces the rewritten aggregation function of select items with arg selector functions,
+// groups by tag key of arg selector implicitly, the tag value of extreme series is selected at root
+// after all grouped series merged.
+func applyArgFunc(stmt stmtpkg.Statement, funcs []*argFunc) error {
+	query, ok := stmt.(*stmtpkg.Query)
+	if !ok {
+		return errArgFuncNotQuery
+	}
+	explicitGroupBy := make(map[string]struct{}, len(query.GroupBy))
+	for _, tagKey := range query.GroupBy {
+		explicitGroupBy[tagKey] = struct{}{}
+	}
+	implicitGroupBy := make(map[string]struct{})
+	for _, fn := range funcs {
+		if fn.itemIdx >= len(query.SelectItems) {
+			return fmt.Errorf("%s function must be the whole select item", fn.funcType)
+		}
+		item, ok := query.SelectItems[fn.itemIdx].(*stmtpkg.SelectItem)
+		if !ok {
+			return fmt.Errorf("%s function must be the whole select item", fn.funcType)
+		}
+		call, ok := item.Expr.(*stmtpkg.CallExpr)
+		if !ok || call.FuncType != function.ArgSelectorAggFunc(fn.funcType) || len(call.Params) != 1 {
+			return fmt.Errorf("%s function must be the whole select item", fn.funcType)
+		}
+		if _, ok := call.Params[0].(*stmtpkg.FieldExpr); !ok {
+			return fmt.Errorf("%s param: %s is not field", fn.funcType, call.Params[0].Rewrite())
+		}
+		if fn.tagKey == fn.fieldName {
+			return fmt.Errorf("%s params: tag key and field cannot be same name(%s)", fn.funcType, fn.tagKey)
+		}
+		if _, ok := explicitGroupBy[fn.tagKey]; ok {
+			return fmt.Errorf("%s param: tag key(%s) cannot be group by tag key", fn.funcType, fn.tagKey)
+		}
+		item.Expr = &stmtpkg.CallExpr{
+			FuncType: fn.funcType,
+			Params:   []stmtpkg.Expr{&stmtpkg.FieldExpr{Name: fn.tagKey}, call.Params[0]},
+		}
+		if _, ok := implicitGroupBy[fn.tagKey]; !ok {
+			implicitGroupBy[fn.tagKey] = struct{}{}
+			query.GroupBy = append(query.GroupBy, fn.tagKey)
+		}
+	}
+	return nil
+}
+
+// splitTopLevelComma splits the text by comma which is not quoted/parenthesized.
+func splitTopLevelComma(text string) (rs []string) {
+	depth := 0
+	start := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			rs = append(rs, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(rs, text[start:])
+}
+
+// unquoteIdent returns the identifier without quotes(double quote/back quote), returns false if not identifier.
+func unquoteIdent(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if len(text) >= 2 && (text[0] == '"' || text[0] == '`') && text[len(text)-1] == text[0] {
+		ident := text[1 : len(text)-1]
+		return ident, ident != "" && !strings.ContainsRune(ident, rune(text[0]))
+	}
+	if text == "" {
+		return "", false
+	}
+	for i := 0; i < len(text); i++ {
+		if !isIdentChar(text[i]) {
+			return "", false
+		}
+	}
+	return text, true
+}
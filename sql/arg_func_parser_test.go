@@ -0,0 +1,97 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitArgFunc(t *testing.T) {
+	cases := []struct {
+		sql     string
+		result  string
+		funcs   []argFunc
+		wantErr bool
+	}{
+		{sql: "show databases", result: "show databases"},
+		{sql: "select max(f) from cpu", result: "select max(f) from cpu"},
+		{sql: "select argmax from cpu", result: "select argmax from cpu"},
+		{
+			sql:    "select max(latency), argmax(host, latency) from http",
+			result: "select max(latency), max(latency) from http",
+			funcs:  []argFunc{{itemIdx: 1, funcType: function.ArgMax, tagKey: "host", fieldName: "latency"}},
+		},
+		{
+			sql:    "select ARGMIN ( `host` ,\"latency\" ) as h, sum(f) from http where host='a,b'",
+			result: "select min(\"latency\") as h, sum(f) from http where host='a,b'",
+			funcs:  []argFunc{{itemIdx: 0, funcType: function.ArgMin, tagKey: "host", fieldName: "latency"}},
+		},
+		{sql: "select argmax(host) from http", wantErr: true},
+		{sql: "select argmax(host, latency, f) from http", wantErr: true},
+		{sql: "select argmax(host='a', latency) from http", wantErr: true},
+		{sql: "select argmax(host, max(latency)) from http", wantErr: true},
+		{sql: "select argmax(host, latency from http", result: "select argmax(host, latency from http"},
+	}
+	for _, c := range cases {
+		result, funcs, err := splitArgFunc(c.sql)
+		if c.wantErr {
+			assert.Error(t, err, c.sql)
+			continue
+		}
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.result, result, c.sql)
+		assert.Len(t, funcs, len(c.funcs), c.sql)
+		for idx, fn := range funcs {
+			assert.Equal(t, c.funcs[idx], *fn, c.sql)
+		}
+	}
+}
+
+func TestQuery_ArgFunc(t *testing.T) {
+	q, err := Parse("select max(latency), argmax(host, latency) as slowest, argmin(host, latency) from http group by region")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"region", "host"}, query.GroupBy)
+	assert.Equal(t, &stmt.SelectItem{
+		Expr: &stmt.CallExpr{
+			FuncType: function.ArgMax,
+			Params:   []stmt.Expr{&stmt.FieldExpr{Name: "host"}, &stmt.FieldExpr{Name: "latency"}},
+		},
+		Alias: "slowest",
+	}, query.SelectItems[1])
+	assert.Equal(t, "argmin(host,latency)", query.SelectItems[2].Rewrite())
+
+	// outer query of sub query not supports argmax
+	_, err = Parse("select argmax(host, v) from (select avg(f) as v from cpu group by host, region) group by region")
+	assert.Error(t, err)
+
+	// tag key cannot be group by tag key
+	_, err = Parse("select argmax(host, latency) from http group by host")
+	assert.Error(t, err)
+	// tag key and field are same name
+	_, err = Parse("select argmax(latency, latency) from http")
+	assert.Error(t, err)
+	// not whole select item
+	_, err = Parse("select argmax(host, latency)+1 from http")
+	assert.Error(t, err)
+}
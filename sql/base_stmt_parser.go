@@ -28,8 +28,9 @@ import (
 
 // baseStmtParser represents metadata statement parser
 type baseStmtParser struct {
-	namespace  string
-	metricName string
+	namespace   string
+	metricName  string
+	metricNames []string // all metric names in from clause, metricName is the first one
 
 	exprStack *collections.Stack
 	condition stmt.Expr
@@ -54,7 +55,11 @@ func (b *baseStmtParser) visitLimit(ctx *grammar.LimitClauseContext) {
 
 // visitMetricName visits when production metricName expression is entered
 func (b *baseStmtParser) visitMetricName(ctx *grammar.MetricNameContext) {
-	b.metricName = strutil.GetStringValue(ctx.Ident().GetText())
+	metricName := strutil.GetStringValue(ctx.Ident().GetText())
+	if b.metricName == "" {
+		b.metricName = metricName
+	}
+	b.metricNames = append(b.metricNames, metricName)
 }
 
 // visitPrefix visits when production namespace expression is entered
@@ -104,6 +109,10 @@ func (b *baseStmtParser) setTagFilterExprValue(expr stmt.Expr, tagValue string)
 		e.Value = tagValue
 	case *stmt.RegexExpr:
 		e.Regexp = tagValue
+		// reject invalid pattern at parse time, instead of failing when scanning index
+		if _, err := e.Pattern(); err != nil {
+			b.err = err
+		}
 	case *stmt.InExpr:
 		e.Values = append(e.Values, tagValue)
 	}
@@ -148,6 +157,9 @@ func (b *baseStmtParser) completeTagFilterExpr() {
 	if !ok {
 		return
 	}
+	if binaryExpr, ok := e.(*stmt.BinaryExpr); ok {
+		e = applyAndPrecedence(binaryExpr)
+	}
 	if !b.exprStack.Empty() {
 		parent := b.exprStack.Peek()
 		switch parentExpr := parent.(type) {
@@ -164,6 +176,19 @@ func (b *baseStmtParser) completeTagFilterExpr() {
 	b.condition = e
 }
 
+// applyAndPrecedence re-associates the left-associative tag filter expr parsed by grammar,
+// makes AND binds tighter than OR, e.g. a OR b AND c => a OR (b AND c), parenthesized expr keeps as it is.
+func applyAndPrecedence(expr *stmt.BinaryExpr) stmt.Expr {
+	left, ok := expr.Left.(*stmt.BinaryExpr)
+	if !ok || expr.Operator != stmt.AND || left.Operator != stmt.OR {
+		return expr
+	}
+	// (x OR y) AND z => x OR (y AND z)
+	expr.Left = left.Right
+	left.Right = applyAndPrecedence(expr)
+	return left
+}
+
 // setExprParam sets expr's param(call,paren,binary)
 func (b *baseStmtParser) setExprParam(param stmt.Expr) {
 	if b.exprStack.Empty() {
@@ -21,11 +21,23 @@ import (
 	"github.com/antlr/antlr4/runtime/Go/antlr/v4"
 )
 
+// SyntaxError represents the syntax error of sql with position(line/column of rewritten sql).
+type SyntaxError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+// Error returns the message of syntax error.
+func (e *SyntaxError) Error() string {
+	return e.Msg
+}
+
 type errorListener struct {
 	antlr.ErrorListener
 }
 
 func (l *errorListener) SyntaxError(recognizer antlr.Recognizer,
 	offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
-	panic(msg)
+	panic(&SyntaxError{Line: line, Column: column, Msg: msg})
 }
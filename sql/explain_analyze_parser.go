@@ -0,0 +1,58 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var errExplainAnalyzeNotQuery = errors.New("explain analyze only supports select statement")
+
+// splitExplainAnalyze removes the analyze keyword of explain analyze statement(EXPLAIN ANALYZE SELECT ...),
+// returns the explain statement and if analyze required.
+func splitExplainAnalyze(sql string) (explainSQL string, analyze bool) {
+	pos := 0
+	for pos < len(sql) && isBlank(sql[pos]) {
+		pos++
+	}
+	if !isKeywordAt(sql, pos, "explain") {
+		return sql, false
+	}
+	pos += len("explain")
+	start := pos
+	for pos < len(sql) && isBlank(sql[pos]) {
+		pos++
+	}
+	if pos == start || !isKeywordAt(sql, pos, "analyze") {
+		return sql, false
+	}
+	return sql[:start] + sql[pos+len("analyze"):], true
+}
+
+// applyExplainAnalyze marks the query as explain analyze, which executes the query,
+// then returns the result set with execute stats of each node.
+func applyExplainAnalyze(stmt stmtpkg.Statement) (stmtpkg.Statement, error) {
+	query, ok := stmt.(*stmtpkg.Query)
+	if !ok || !query.Explain {
+		return nil, errExplainAnalyzeNotQuery
+	}
+	query.Analyze = true
+	return query, nil
+}
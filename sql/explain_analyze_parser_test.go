@@ -0,0 +1,81 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitExplainAnalyze(t *testing.T) {
+	cases := []struct {
+		sql     string
+		result  string
+		analyze bool
+	}{
+		{sql: "select f from cpu", result: "select f from cpu"},
+		{sql: "explain select f from cpu", result: "explain select f from cpu"},
+		{sql: "explain select analyze from cpu", result: "explain select analyze from cpu"},
+		{sql: "explainanalyze select f from cpu", result: "explainanalyze select f from cpu"},
+		{sql: "explain analyze_f", result: "explain analyze_f"},
+		{sql: "explain analyze select f from cpu", result: "explain select f from cpu", analyze: true},
+		{sql: "  EXPLAIN\n  Analyze select f from cpu", result: "  EXPLAIN select f from cpu", analyze: true},
+	}
+	for _, c := range cases {
+		result, analyze := splitExplainAnalyze(c.sql)
+		assert.Equal(t, c.result, result, c.sql)
+		assert.Equal(t, c.analyze, analyze, c.sql)
+	}
+}
+
+func TestQuery_ExplainAnalyze(t *testing.T) {
+	q, err := Parse("explain analyze select f from cpu where host='a' group by host")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.True(t, query.Explain)
+	assert.True(t, query.Analyze)
+	assert.Equal(t, "cpu", query.MetricName)
+
+	q, err = Parse("explain select f from cpu")
+	assert.NoError(t, err)
+	assert.False(t, q.(*stmt.Query).Analyze)
+
+	// with time zone/sample by
+	q, err = Parse("explain analyze select avg(f) from cpu where time > now()-7d sample by 1h tz 'Asia/Shanghai'")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.True(t, query.Analyze)
+	assert.True(t, query.SampleInterval > 0)
+
+	// sub query
+	q, err = Parse("explain analyze select max(v) from (select avg(f) as v from cpu group by host)")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.True(t, query.Analyze)
+	assert.NotNil(t, query.SubQuery)
+
+	_, err = applyExplainAnalyze(&stmt.Query{})
+	assert.Equal(t, errExplainAnalyzeNotQuery, err)
+	_, err = applyExplainAnalyze(&stmt.Use{Name: "db"})
+	assert.Equal(t, errExplainAnalyzeNotQuery, err)
+	_, err = Parse("explain analyze show databases")
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var errFieldFilterNotQuery = errors.New("field filter only supports select statement")
+
+// whereClauseEndKeywords represents the keywords of clauses after where clause.
+var whereClauseEndKeywords = []string{"group", "order", "limit", "offset", "withvalue"}
+
+// fieldFilterOperators represents the operators of field filter, longest first for matching.
+// equal/not equal is tag filter(tag value maybe number), so field filter only supports range comparison.
+var fieldFilterOperators = []struct {
+	op       string
+	operator stmtpkg.BinaryOP
+}{
+	{op: ">=", operator: stmtpkg.GREATEREQUAL},
+	{op: "<=", operator: stmtpkg.LESSEQUAL},
+	{op: ">", operator: stmtpkg.GREATER},
+	{op: "<", operator: stmtpkg.LESS},
+}
+
+// splitFieldFilter removes the field value comparisons(like f > 50) from where clause,
+// because grammar only supports tag filter and time range in where clause.
+// Field filter must be combined with other conditions by and at top level of where clause.
+func splitFieldFilter(sql string) (sqlWithoutFilter string, filters []*stmtpkg.BinaryExpr, err error) {
+	where := findTopLevelKeyword(sql, 0, "where")
+	if where < 0 {
+		return sql, nil, nil
+	}
+	clauseStart := where + len("where")
+	clauseEnd := findTopLevelKeyword(sql, clauseStart, whereClauseEndKeywords...)
+	if clauseEnd < 0 {
+		clauseEnd = len(sql)
+	}
+	var conditions []string
+	for _, condition := range splitTopLevelAnd(sql[clauseStart:clauseEnd]) {
+		condition = strings.TrimSpace(condition)
+		if filter, ok := parseFieldFilter(condition); ok {
+			filters = append(filters, filter)
+			continue
+		}
+		if hasFieldComparison(condition) {
+			return "", nil, fmt.Errorf("field filter must be combined with other conditions by and, condition: '%s'", condition)
+		}
+		conditions = append(conditions, condition)
+	}
+	if len(filters) == 0 {
+		return sql, nil, nil
+	}
+	if len(conditions) == 0 {
+		return sql[:where] + " " + sql[clauseEnd:], filters, nil
+	}
+	return sql[:clauseStart] + " " + strings.Join(conditions, " and ") + " " + sql[clauseEnd:], filters, nil
+}
+
+// applyFieldFilter sets the field filters of query statement.
+func applyFieldFilter(stmt stmtpkg.Statement, filters []*stmtpkg.BinaryExpr) error {
+	query, ok := stmt.(*stmtpkg.Query)
+	if !ok {
+		return errFieldFilterNotQuery
+	}
+	query.FieldFilters = filters
+	return nil
+}
+
+// findTopLevelKeyword returns the position of the first keyword which is not quoted/parenthesized, -1 if not found.
+func findTopLevelKeyword(sql string, start int, keywords ...string) int {
+	depth := 0
+	var quote byte
+	for i := start; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0:
+			for _, keyword := range keywords {
+				if isKeywordAt(sql, i, keyword) {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevelAnd splits the condition by and which is not quoted/parenthesized.
+func splitTopLevelAnd(condition string) (rs []string) {
+	start := 0
+	for {
+		pos := findTopLevelKeyword(condition, start, "and")
+		if pos < 0 {
+			return append(rs, condition[start:])
+		}
+		rs = append(rs, condition[start:pos])
+		start = pos + len("and")
+	}
+}
+
+// parseFieldFilter parses the field value comparison, like f > 50, returns false if not field filter.
+func parseFieldFilter(condition string) (*stmtpkg.BinaryExpr, bool) {
+	for _, item := range fieldFilterOperators {
+		pos := strings.Index(condition, item.op)
+		if pos <= 0 {
+			continue
+		}
+		fieldName := strings.TrimSpace(condition[:pos])
+		if !isFieldName(fieldName) {
+			return nil, false
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(condition[pos+len(item.op):]), 64)
+		if err != nil {
+			return nil, false
+		}
+		return &stmtpkg.BinaryExpr{
+			Left:     &stmtpkg.FieldExpr{Name: fieldName},
+			Operator: item.operator,
+			Right:    &stmtpkg.NumberLiteral{Val: value},
+		}, true
+	}
+	return nil, false
+}
+
+// isFieldName checks if name is the field name of field filter, time is keyword of time range.
+func isFieldName(name string) bool {
+	if name == "" || strings.EqualFold(name, "time") {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isIdentChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasFieldComparison checks if the condition(not time range) has range comparison which is not quoted.
+func hasFieldComparison(condition string) bool {
+	if isKeywordAt(condition, 0, "time") {
+		return false
+	}
+	var quote byte
+	for i := 0; i < len(condition); i++ {
+		c := condition[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '<' && i+1 < len(condition) && condition[i+1] == '>':
+			// not equal of tag filter
+			i++
+		case c == '<' || c == '>':
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitFieldFilter(t *testing.T) {
+	cases := []struct {
+		sql     string
+		result  string
+		filters []string
+		wantErr bool
+	}{
+		{sql: "select f from cpu", result: "select f from cpu"},
+		{sql: "select f from cpu where host='a'", result: "select f from cpu where host='a'"},
+		{
+			sql:    "select f from cpu where host<>'a' and time > now()-1h",
+			result: "select f from cpu where host<>'a' and time > now()-1h",
+		},
+		{sql: "select f from cpu where host='a>1'", result: "select f from cpu where host='a>1'"},
+		{sql: "select f from cpu where f > 50", result: "select f from cpu  ", filters: []string{"f>50.00"}},
+		{
+			sql:     "select f from cpu where f >= 50 and f<-1.5 group by host",
+			result:  "select f from cpu  group by host",
+			filters: []string{"f>=50.00", "f<-1.50"},
+		},
+		{
+			sql:     "select f from cpu where host='a' and f <= 10 and time > now()-1h limit 10",
+			result:  "select f from cpu where host='a' and time > now()-1h limit 10",
+			filters: []string{"f<=10.00"},
+		},
+		{
+			sql:     "select f from cpu where (host='a' or host='b') AND f > 1 limit 1",
+			result:  "select f from cpu where (host='a' or host='b') limit 1",
+			filters: []string{"f>1.00"},
+		},
+		{sql: "select f from cpu where host='a' or f > 1", wantErr: true},
+		{sql: "select f from cpu where (host='a' and f > 1)", wantErr: true},
+		{sql: "select f from cpu where f > abc", wantErr: true},
+	}
+	for _, c := range cases {
+		result, filters, err := splitFieldFilter(c.sql)
+		if c.wantErr {
+			assert.Error(t, err, c.sql)
+			continue
+		}
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.result, result, c.sql)
+		var rs []string
+		for _, filter := range filters {
+			rs = append(rs, filter.Rewrite())
+		}
+		assert.Equal(t, c.filters, rs, c.sql)
+	}
+}
+
+func TestQuery_FieldFilter(t *testing.T) {
+	// field filter only
+	q, err := Parse("select f from cpu where f > 50")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Nil(t, query.Condition)
+	assert.Len(t, query.FieldFilters, 1)
+	assert.Equal(t, "f>50.00", query.FieldFilters[0].Rewrite())
+
+	// tag filter selects series, field filter drops points
+	q, err = Parse("select f from cpu where host='a' and f >= 10 and f < 100 and time > now()-1h group by host")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, "host=a", query.Condition.Rewrite())
+	assert.Len(t, query.FieldFilters, 2)
+	assert.Equal(t, []string{"host"}, query.GroupBy)
+	assert.True(t, query.TimeRange.Start > 0)
+
+	// time range with field filter
+	q, err = Parse("select f from cpu where time > now()-1h and f > 1")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Nil(t, query.Condition)
+	assert.Len(t, query.FieldFilters, 1)
+
+	// field filter of sub query
+	q, err = Parse("select sum(v) from (select avg(f) as v from cpu where f > 1 group by host)")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Len(t, query.SubQuery.FieldFilters, 1)
+	assert.Empty(t, query.FieldFilters)
+	_, err = Parse("select sum(v) from (select avg(f) as v from cpu group by host) where v > 1")
+	assert.Error(t, err)
+
+	// field filter combined by or
+	_, err = Parse("select f from cpu where host='a' or f > 1")
+	assert.Error(t, err)
+	// not query statement
+	_, err = Parse("show tag values from cpu with key=host where f > 1")
+	assert.Error(t, err)
+}
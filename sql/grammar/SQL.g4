@@ -31,6 +31,8 @@ showStmt                : showMasterStmt
                         | showBrokerMetricStmt
                         | showStorageMetricStmt
                         | showReplicationStmt
+                        | showReplicaLagStmt
+                        | showWriteStatsStmt
                         | showMemoryDatabaseStmt
                         | showSchemasStmt
                         | showDatabaseStmt
@@ -56,6 +58,8 @@ showMasterMetaStmt   : T_SHOW T_MASTER T_METADATA T_FROM source T_WHERE typeFilt
 showStorageMetaStmt  : T_SHOW T_STORAGE T_METADATA T_FROM source T_WHERE (storageFilter|typeFilter) T_AND (storageFilter|typeFilter);
 showAliveStmt        : T_SHOW (T_ROOT | T_BROKER | T_STORAGE) T_ALIVE;
 showReplicationStmt  : T_SHOW T_REPLICATION T_WHERE (storageFilter|databaseFilter) T_AND (storageFilter|databaseFilter);
+showReplicaLagStmt   : T_SHOW T_REPLICA T_LAG T_WHERE (storageFilter|databaseFilter) T_AND (storageFilter|databaseFilter);
+showWriteStatsStmt   : T_SHOW T_WRITE T_STATS T_WHERE databaseFilter;
 showMemoryDatabaseStmt  : T_SHOW T_MEMORY T_DATASBAE T_WHERE (storageFilter|databaseFilter) T_AND (storageFilter|databaseFilter);
 showRootMetricStmt   : T_SHOW T_ROOT T_METRIC T_WHERE metricListFilter ;
 showBrokerMetricStmt : T_SHOW T_BROKER T_METRIC T_WHERE metricListFilter ;
@@ -71,7 +75,8 @@ showNameSpacesStmt   : T_SHOW T_NAMESPACES (T_WHERE T_NAMESPACE T_EQUAL prefix)?
 showMetricsStmt      : T_SHOW T_METRICS (T_ON namespace)? (T_WHERE T_METRIC T_EQUAL prefix)? limitClause?;
 showFieldsStmt       : T_SHOW T_FIELDS fromClause;
 showTagKeysStmt      : T_SHOW T_TAG T_KEYS fromClause;
-showTagValuesStmt    : T_SHOW T_TAG T_VALUES fromClause T_WITH T_KEY T_EQUAL withTagKey whereClause? limitClause?;
+showTagValuesStmt    : T_SHOW T_TAG T_VALUES fromClause T_WITH T_KEY T_EQUAL withTagKey whereClause? limitClause?
+                     | T_SHOW T_TAG T_VALUES T_COUNT T_DISTINCT fromClause T_WITH T_KEY T_EQUAL withTagKey whereClause? precisionClause?;
 prefix               : ident ;
 withTagKey           : ident ;
 namespace            : ident ;
@@ -81,7 +86,7 @@ requestID            : ident ;
 source               : (T_STATE_MACHINE|T_STATE_REPO) ;
 
 //data query plan
-queryStmt               : T_EXPLAIN? sourceAndSelect whereClause? groupByClause? orderByClause? limitClause? T_WITH_VALUE?;
+queryStmt               : T_EXPLAIN? sourceAndSelect whereClause? timeRangeClause? groupByClause? orderByClause? limitClause? offsetClause? T_WITH_VALUE?;
 sourceAndSelect         : selectExpr fromClause | fromClause selectExpr ;
 selectExpr              : T_SELECT fields;
 //select fields
@@ -94,7 +99,7 @@ databaseFilter          : T_DATASBAE T_EQUAL ident  ;
 typeFilter              : T_TYPE T_EQUAL ident  ;
 
 //from clause
-fromClause              : T_FROM metricName (T_ON namespace)? ;
+fromClause              : T_FROM metricName (T_COMMA metricName)* (T_ON namespace)? ;
 
 //where clause
 whereClause             : T_WHERE conditionExpr;
@@ -118,10 +123,13 @@ nowExpr                 : nowFunc  durationLit? ;
 
 nowFunc                 : T_NOW T_OPEN_P exprFuncParams? T_CLOSE_P ;
 
+//time range relative to now, e.g. last 30m, since 2h ago until 1h ago
+timeRangeClause         : T_LAST durationLit | T_SINCE durationLit T_AGO (T_UNTIL durationLit T_AGO)? | T_UNTIL durationLit T_AGO ;
+
 //group by
 groupByClause          : T_GROUP T_BY groupByKeys (T_FILL T_OPEN_P fillOption T_CLOSE_P)? havingClause? ;
 groupByKeys            : groupByKey (T_COMMA groupByKey)* ;
-groupByKey             : ident | T_TIME T_OPEN_P durationLit T_CLOSE_P | T_TIME T_OPEN_P T_CLOSE_P;
+groupByKey             : ident | T_MUL | T_TIME T_OPEN_P durationLit T_CLOSE_P | T_TIME T_OPEN_P T_CLOSE_P;
 fillOption             : T_NULL | T_PREVIOUS | L_INT | L_DEC ;
 
 orderByClause          : T_ORDER T_BY sortFields ;
@@ -172,12 +180,14 @@ intervalItem            :
                          | T_YEAR
                          ;
 exprFunc                : funcName T_OPEN_P exprFuncParams? T_CLOSE_P ;
-funcName                : T_SUM | T_MIN | T_MAX | T_AVG | T_COUNT | T_LAST | T_FIRST | T_STDDEV | T_QUANTILE | T_RATE;
+funcName                : T_SUM | T_MIN | T_MAX | T_AVG | T_COUNT | T_LAST | T_FIRST | T_STDDEV | T_QUANTILE | T_RATE | T_PERCENT | T_COUNT_IF | T_SUM_IF | T_MEDIAN | T_DERIVATIVE | T_TOPK | T_BOTTOMK | T_HISTOGRAM_QUANTILE;
 exprFuncParams          : funcParam (T_COMMA funcParam)* ;
 funcParam               :
-                           fieldExpr
+                           fieldPredicate
+                         | fieldExpr
                          | tagFilterExpr
                          ;
+fieldPredicate          : ident (T_EQUAL | T_NOTEQUAL | T_NOTEQUAL2 | T_LESS | T_LESSEQUAL | T_GREATER | T_GREATEREQUAL) (decNumber | intNumber) ;
 exprAtom                :
                            ident identFilter?
                          | decNumber
@@ -221,6 +231,8 @@ intNumber               : ('-' | '+')? L_INT ;
 // Decimal number (positive or negative)
 decNumber               : ('-' | '+')? L_DEC ;
 limitClause             : T_LIMIT L_INT ;
+precisionClause         : T_PRECISION L_INT ;
+offsetClause            : T_OFFSET L_INT ;
 metricName              : ident ;
 tagKey                  : ident ;
 tagValue                : ident ;
@@ -235,6 +247,9 @@ nonReservedWords      :
                         | T_INTERVAL_NAME
                         | T_SHARD
                         | T_REPLICATION
+                        | T_REPLICA
+                        | T_LAG
+                        | T_WRITE
                         | T_MEMORY
                         | T_TTL
                         | T_META_TTL
@@ -259,6 +274,8 @@ nonReservedWords      :
                         | T_WITH
                         | T_VALUES
                         | T_VALUE
+                        | T_DISTINCT
+                        | T_PRECISION
                         | T_FROM
                         | T_WHERE
                         | T_LIMIT
@@ -288,6 +305,9 @@ nonReservedWords      :
                         | T_TIME
                         | T_NOW
                         | T_IN
+                        | T_SINCE
+                        | T_UNTIL
+                        | T_AGO
                         | T_LOG
                         | T_PROFILE
                         | T_SUM
@@ -300,6 +320,15 @@ nonReservedWords      :
                         | T_STDDEV
                         | T_QUANTILE
                         | T_RATE
+                        | T_PERCENT
+                        | T_COUNT_IF
+                        | T_SUM_IF
+                        | T_OFFSET
+                        | T_MEDIAN
+                        | T_DERIVATIVE
+                        | T_TOPK
+                        | T_BOTTOMK
+                        | T_HISTOGRAM_QUANTILE
                         | T_SECOND
                         | T_MINUTE
                         | T_HOUR
@@ -363,6 +392,9 @@ T_INTERVAL           : I N T E R V A L                  ;
 T_INTERVAL_NAME      : N A M E                          ;
 T_SHARD              : S H A R D                        ;
 T_REPLICATION        : R E P L I C A T I O N            ;
+T_REPLICA            : R E P L I C A                    ;
+T_LAG                : L A G                            ;
+T_WRITE              : W R I T E                        ;
 T_MEMORY             : M E M O R Y                      ;
 T_TTL                : T T L                            ;
 T_META_TTL           : M E T A T T L                    ;
@@ -402,6 +434,8 @@ T_KEY                : K E Y                            ;
 T_WITH               : W I T H                          ;
 T_VALUES             : V A L U E S                      ;
 T_VALUE              : V A L U E                        ;
+T_DISTINCT           : D I S T I N C T                  ;
+T_PRECISION          : P R E C I S I O N                ;
 T_FROM               : F R O M                          ;
 T_WHERE              : W H E R E                        ;
 T_LIMIT              : L I M I T                        ;
@@ -431,6 +465,9 @@ T_STATS              : S T A T S                        ;
 T_TIME               : T I M E                          ;
 T_NOW                : N O W                            ;
 T_IN                 : I N                              ;
+T_SINCE              : S I N C E                        ;
+T_UNTIL              : U N T I L                        ;
+T_AGO                : A G O                            ;
 
 T_LOG                : L O G                            ;
 T_PROFILE            : P R O F I L E                    ;
@@ -448,6 +485,15 @@ T_AVG                : A V G                            ;
 T_STDDEV             : S T D D E V                      ;
 T_QUANTILE           : Q U A N T I L E                  ;
 T_RATE               : R A T E                          ;
+T_PERCENT            : P E R C E N T                    ;
+T_COUNT_IF           : C O U N T '_' I F                ;
+T_SUM_IF             : S U M '_' I F                    ;
+T_OFFSET             : O F F S E T                      ;
+T_MEDIAN             : M E D I A N                      ;
+T_DERIVATIVE         : D E R I V A T I V E              ;
+T_TOPK               : T O P K                          ;
+T_BOTTOMK            : B O T T O M K                    ;
+T_HISTOGRAM_QUANTILE : H I S T O G R A M '_' Q U A N T I L E ;
 
 //time unit
 T_SECOND             : S                                ;
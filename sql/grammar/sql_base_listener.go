@@ -139,6 +139,18 @@ func (s *BaseSQLListener) EnterShowReplicationStmt(ctx *ShowReplicationStmtConte
 // ExitShowReplicationStmt is called when production showReplicationStmt is exited.
 func (s *BaseSQLListener) ExitShowReplicationStmt(ctx *ShowReplicationStmtContext) {}
 
+// EnterShowReplicaLagStmt is called when production showReplicaLagStmt is entered.
+func (s *BaseSQLListener) EnterShowReplicaLagStmt(ctx *ShowReplicaLagStmtContext) {}
+
+// ExitShowReplicaLagStmt is called when production showReplicaLagStmt is exited.
+func (s *BaseSQLListener) ExitShowReplicaLagStmt(ctx *ShowReplicaLagStmtContext) {}
+
+// EnterShowWriteStatsStmt is called when production showWriteStatsStmt is entered.
+func (s *BaseSQLListener) EnterShowWriteStatsStmt(ctx *ShowWriteStatsStmtContext) {}
+
+// ExitShowWriteStatsStmt is called when production showWriteStatsStmt is exited.
+func (s *BaseSQLListener) ExitShowWriteStatsStmt(ctx *ShowWriteStatsStmtContext) {}
+
 // EnterShowMemoryDatabaseStmt is called when production showMemoryDatabaseStmt is entered.
 func (s *BaseSQLListener) EnterShowMemoryDatabaseStmt(ctx *ShowMemoryDatabaseStmtContext) {}
 
@@ -403,6 +415,12 @@ func (s *BaseSQLListener) EnterNowFunc(ctx *NowFuncContext) {}
 // ExitNowFunc is called when production nowFunc is exited.
 func (s *BaseSQLListener) ExitNowFunc(ctx *NowFuncContext) {}
 
+// EnterTimeRangeClause is called when production timeRangeClause is entered.
+func (s *BaseSQLListener) EnterTimeRangeClause(ctx *TimeRangeClauseContext) {}
+
+// ExitTimeRangeClause is called when production timeRangeClause is exited.
+func (s *BaseSQLListener) ExitTimeRangeClause(ctx *TimeRangeClauseContext) {}
+
 // EnterGroupByClause is called when production groupByClause is entered.
 func (s *BaseSQLListener) EnterGroupByClause(ctx *GroupByClauseContext) {}
 
@@ -529,6 +547,12 @@ func (s *BaseSQLListener) EnterFuncParam(ctx *FuncParamContext) {}
 // ExitFuncParam is called when production funcParam is exited.
 func (s *BaseSQLListener) ExitFuncParam(ctx *FuncParamContext) {}
 
+// EnterFieldPredicate is called when production fieldPredicate is entered.
+func (s *BaseSQLListener) EnterFieldPredicate(ctx *FieldPredicateContext) {}
+
+// ExitFieldPredicate is called when production fieldPredicate is exited.
+func (s *BaseSQLListener) ExitFieldPredicate(ctx *FieldPredicateContext) {}
+
 // EnterExprAtom is called when production exprAtom is entered.
 func (s *BaseSQLListener) EnterExprAtom(ctx *ExprAtomContext) {}
 
@@ -595,6 +619,18 @@ func (s *BaseSQLListener) EnterLimitClause(ctx *LimitClauseContext) {}
 // ExitLimitClause is called when production limitClause is exited.
 func (s *BaseSQLListener) ExitLimitClause(ctx *LimitClauseContext) {}
 
+// EnterPrecisionClause is called when production precisionClause is entered.
+func (s *BaseSQLListener) EnterPrecisionClause(ctx *PrecisionClauseContext) {}
+
+// ExitPrecisionClause is called when production precisionClause is exited.
+func (s *BaseSQLListener) ExitPrecisionClause(ctx *PrecisionClauseContext) {}
+
+// EnterOffsetClause is called when production offsetClause is entered.
+func (s *BaseSQLListener) EnterOffsetClause(ctx *OffsetClauseContext) {}
+
+// ExitOffsetClause is called when production offsetClause is exited.
+func (s *BaseSQLListener) ExitOffsetClause(ctx *OffsetClauseContext) {}
+
 // EnterMetricName is called when production metricName is entered.
 func (s *BaseSQLListener) EnterMetricName(ctx *MetricNameContext) {}
 
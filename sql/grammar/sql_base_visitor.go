@@ -92,6 +92,14 @@ func (v *BaseSQLVisitor) VisitShowReplicationStmt(ctx *ShowReplicationStmtContex
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSQLVisitor) VisitShowReplicaLagStmt(ctx *ShowReplicaLagStmtContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSQLVisitor) VisitShowWriteStatsStmt(ctx *ShowWriteStatsStmtContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSQLVisitor) VisitShowMemoryDatabaseStmt(ctx *ShowMemoryDatabaseStmtContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -268,6 +276,10 @@ func (v *BaseSQLVisitor) VisitNowFunc(ctx *NowFuncContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSQLVisitor) VisitTimeRangeClause(ctx *TimeRangeClauseContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSQLVisitor) VisitGroupByClause(ctx *GroupByClauseContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -352,6 +364,10 @@ func (v *BaseSQLVisitor) VisitFuncParam(ctx *FuncParamContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSQLVisitor) VisitFieldPredicate(ctx *FieldPredicateContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSQLVisitor) VisitExprAtom(ctx *ExprAtomContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -396,6 +412,14 @@ func (v *BaseSQLVisitor) VisitLimitClause(ctx *LimitClauseContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSQLVisitor) VisitPrecisionClause(ctx *PrecisionClauseContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSQLVisitor) VisitOffsetClause(ctx *OffsetClauseContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSQLVisitor) VisitMetricName(ctx *MetricNameContext) interface{} {
 	return v.VisitChildren(ctx)
 }
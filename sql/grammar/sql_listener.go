@@ -75,6 +75,12 @@ type SQLListener interface {
 	// EnterShowReplicationStmt is called when entering the showReplicationStmt production.
 	EnterShowReplicationStmt(c *ShowReplicationStmtContext)
 
+	// EnterShowReplicaLagStmt is called when entering the showReplicaLagStmt production.
+	EnterShowReplicaLagStmt(c *ShowReplicaLagStmtContext)
+
+	// EnterShowWriteStatsStmt is called when entering the showWriteStatsStmt production.
+	EnterShowWriteStatsStmt(c *ShowWriteStatsStmtContext)
+
 	// EnterShowMemoryDatabaseStmt is called when entering the showMemoryDatabaseStmt production.
 	EnterShowMemoryDatabaseStmt(c *ShowMemoryDatabaseStmtContext)
 
@@ -207,6 +213,9 @@ type SQLListener interface {
 	// EnterNowFunc is called when entering the nowFunc production.
 	EnterNowFunc(c *NowFuncContext)
 
+	// EnterTimeRangeClause is called when entering the timeRangeClause production.
+	EnterTimeRangeClause(c *TimeRangeClauseContext)
+
 	// EnterGroupByClause is called when entering the groupByClause production.
 	EnterGroupByClause(c *GroupByClauseContext)
 
@@ -270,6 +279,9 @@ type SQLListener interface {
 	// EnterFuncParam is called when entering the funcParam production.
 	EnterFuncParam(c *FuncParamContext)
 
+	// EnterFieldPredicate is called when entering the fieldPredicate production.
+	EnterFieldPredicate(c *FieldPredicateContext)
+
 	// EnterExprAtom is called when entering the exprAtom production.
 	EnterExprAtom(c *ExprAtomContext)
 
@@ -303,6 +315,12 @@ type SQLListener interface {
 	// EnterLimitClause is called when entering the limitClause production.
 	EnterLimitClause(c *LimitClauseContext)
 
+	// EnterPrecisionClause is called when entering the precisionClause production.
+	EnterPrecisionClause(c *PrecisionClauseContext)
+
+	// EnterOffsetClause is called when entering the offsetClause production.
+	EnterOffsetClause(c *OffsetClauseContext)
+
 	// EnterMetricName is called when entering the metricName production.
 	EnterMetricName(c *MetricNameContext)
 
@@ -369,6 +387,12 @@ type SQLListener interface {
 	// ExitShowReplicationStmt is called when exiting the showReplicationStmt production.
 	ExitShowReplicationStmt(c *ShowReplicationStmtContext)
 
+	// ExitShowReplicaLagStmt is called when exiting the showReplicaLagStmt production.
+	ExitShowReplicaLagStmt(c *ShowReplicaLagStmtContext)
+
+	// ExitShowWriteStatsStmt is called when exiting the showWriteStatsStmt production.
+	ExitShowWriteStatsStmt(c *ShowWriteStatsStmtContext)
+
 	// ExitShowMemoryDatabaseStmt is called when exiting the showMemoryDatabaseStmt production.
 	ExitShowMemoryDatabaseStmt(c *ShowMemoryDatabaseStmtContext)
 
@@ -501,6 +525,9 @@ type SQLListener interface {
 	// ExitNowFunc is called when exiting the nowFunc production.
 	ExitNowFunc(c *NowFuncContext)
 
+	// ExitTimeRangeClause is called when exiting the timeRangeClause production.
+	ExitTimeRangeClause(c *TimeRangeClauseContext)
+
 	// ExitGroupByClause is called when exiting the groupByClause production.
 	ExitGroupByClause(c *GroupByClauseContext)
 
@@ -564,6 +591,9 @@ type SQLListener interface {
 	// ExitFuncParam is called when exiting the funcParam production.
 	ExitFuncParam(c *FuncParamContext)
 
+	// ExitFieldPredicate is called when exiting the fieldPredicate production.
+	ExitFieldPredicate(c *FieldPredicateContext)
+
 	// ExitExprAtom is called when exiting the exprAtom production.
 	ExitExprAtom(c *ExprAtomContext)
 
@@ -597,6 +627,12 @@ type SQLListener interface {
 	// ExitLimitClause is called when exiting the limitClause production.
 	ExitLimitClause(c *LimitClauseContext)
 
+	// ExitPrecisionClause is called when exiting the precisionClause production.
+	ExitPrecisionClause(c *PrecisionClauseContext)
+
+	// ExitOffsetClause is called when exiting the offsetClause production.
+	ExitOffsetClause(c *OffsetClauseContext)
+
 	// ExitMetricName is called when exiting the metricName production.
 	ExitMetricName(c *MetricNameContext)
 
@@ -55,26 +55,30 @@ func sqlParserInit() {
 		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
 		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
 		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
+		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
 		"'m'", "", "", "", "'M'", "", "'.'", "':'", "'='", "'<>'", "'!='", "'>'",
 		"'>='", "'<'", "'<='", "'=~'", "'!~'", "','", "'{'", "'}'", "'['", "']'",
 		"'('", "')'", "'+'", "'-'", "'/'", "'*'", "'%'", "'_'",
 	}
 	staticData.symbolicNames = []string{
 		"", "", "", "", "STRING", "WS", "T_CREATE", "T_UPDATE", "T_SET", "T_DROP",
-		"T_INTERVAL", "T_INTERVAL_NAME", "T_SHARD", "T_REPLICATION", "T_MEMORY",
-		"T_TTL", "T_META_TTL", "T_PAST_TTL", "T_FUTURE_TTL", "T_KILL", "T_ON",
-		"T_SHOW", "T_RECOVER", "T_USE", "T_STATE_REPO", "T_STATE_MACHINE", "T_MASTER",
-		"T_METADATA", "T_TYPES", "T_TYPE", "T_STORAGES", "T_STORAGE", "T_BROKER",
-		"T_ROOT", "T_BROKERS", "T_ALIVE", "T_SCHEMAS", "T_DATASBAE", "T_DATASBAES",
-		"T_NAMESPACE", "T_NAMESPACES", "T_NODE", "T_METRICS", "T_METRIC", "T_FIELD",
-		"T_FIELDS", "T_TAG", "T_INFO", "T_KEYS", "T_KEY", "T_WITH", "T_VALUES",
-		"T_VALUE", "T_FROM", "T_WHERE", "T_LIMIT", "T_QUERIES", "T_QUERY", "T_EXPLAIN",
+		"T_INTERVAL", "T_INTERVAL_NAME", "T_SHARD", "T_REPLICATION", "T_REPLICA",
+		"T_LAG", "T_WRITE", "T_MEMORY", "T_TTL", "T_META_TTL", "T_PAST_TTL",
+		"T_FUTURE_TTL", "T_KILL", "T_ON", "T_SHOW", "T_RECOVER", "T_USE", "T_STATE_REPO",
+		"T_STATE_MACHINE", "T_MASTER", "T_METADATA", "T_TYPES", "T_TYPE", "T_STORAGES",
+		"T_STORAGE", "T_BROKER", "T_ROOT", "T_BROKERS", "T_ALIVE", "T_SCHEMAS",
+		"T_DATASBAE", "T_DATASBAES", "T_NAMESPACE", "T_NAMESPACES", "T_NODE",
+		"T_METRICS", "T_METRIC", "T_FIELD", "T_FIELDS", "T_TAG", "T_INFO", "T_KEYS",
+		"T_KEY", "T_WITH", "T_VALUES", "T_VALUE", "T_DISTINCT", "T_PRECISION",
+		"T_FROM", "T_WHERE", "T_LIMIT", "T_QUERIES", "T_QUERY", "T_EXPLAIN",
 		"T_WITH_VALUE", "T_SELECT", "T_AS", "T_AND", "T_OR", "T_FILL", "T_NULL",
 		"T_PREVIOUS", "T_ORDER", "T_ASC", "T_DESC", "T_LIKE", "T_NOT", "T_BETWEEN",
 		"T_IS", "T_GROUP", "T_HAVING", "T_BY", "T_FOR", "T_STATS", "T_TIME",
-		"T_NOW", "T_IN", "T_LOG", "T_PROFILE", "T_REQUESTS", "T_REQUEST", "T_ID",
-		"T_SUM", "T_MIN", "T_MAX", "T_COUNT", "T_LAST", "T_FIRST", "T_AVG",
-		"T_STDDEV", "T_QUANTILE", "T_RATE", "T_SECOND", "T_MINUTE", "T_HOUR",
+		"T_NOW", "T_IN", "T_SINCE", "T_UNTIL", "T_AGO", "T_LOG", "T_PROFILE",
+		"T_REQUESTS", "T_REQUEST", "T_ID", "T_SUM", "T_MIN", "T_MAX", "T_COUNT",
+		"T_LAST", "T_FIRST", "T_AVG", "T_STDDEV", "T_QUANTILE", "T_RATE", "T_PERCENT",
+		"T_COUNT_IF", "T_SUM_IF", "T_OFFSET", "T_MEDIAN", "T_DERIVATIVE", "T_TOPK",
+		"T_BOTTOMK", "T_HISTOGRAM_QUANTILE", "T_SECOND", "T_MINUTE", "T_HOUR",
 		"T_DAY", "T_WEEK", "T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL",
 		"T_NOTEQUAL", "T_NOTEQUAL2", "T_GREATER", "T_GREATEREQUAL", "T_LESS",
 		"T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", "T_COMMA", "T_OPEN_B", "T_CLOSE_B",
@@ -86,26 +90,28 @@ func sqlParserInit() {
 		"showRequestsStmt", "showRequestStmt", "showStoragesStmt", "showBrokersStmt",
 		"showLimitStmt", "showMetadataTypesStmt", "showRootMetaStmt", "showBrokerMetaStmt",
 		"showMasterMetaStmt", "showStorageMetaStmt", "showAliveStmt", "showReplicationStmt",
-		"showMemoryDatabaseStmt", "showRootMetricStmt", "showBrokerMetricStmt",
-		"showStorageMetricStmt", "createStorageStmt", "createBrokerStmt", "recoverStorageStmt",
-		"showSchemasStmt", "createDatabaseStmt", "dropDatabaseStmt", "showDatabaseStmt",
-		"showNameSpacesStmt", "showMetricsStmt", "showFieldsStmt", "showTagKeysStmt",
-		"showTagValuesStmt", "prefix", "withTagKey", "namespace", "databaseName",
-		"storageName", "requestID", "source", "queryStmt", "sourceAndSelect",
-		"selectExpr", "fields", "field", "alias", "storageFilter", "brokerFilter",
-		"databaseFilter", "typeFilter", "fromClause", "whereClause", "conditionExpr",
-		"tagFilterExpr", "tagValueList", "metricListFilter", "metricList", "timeRangeExpr",
-		"timeExpr", "nowExpr", "nowFunc", "groupByClause", "groupByKeys", "groupByKey",
-		"fillOption", "orderByClause", "sortField", "sortFields", "havingClause",
-		"boolExpr", "boolExprLogicalOp", "boolExprAtom", "binaryExpr", "binaryOperator",
-		"fieldExpr", "star", "durationLit", "intervalItem", "exprFunc", "funcName",
-		"exprFuncParams", "funcParam", "exprAtom", "identFilter", "json", "toml",
-		"obj", "pair", "arr", "value", "intNumber", "decNumber", "limitClause",
+		"showReplicaLagStmt", "showWriteStatsStmt", "showMemoryDatabaseStmt",
+		"showRootMetricStmt", "showBrokerMetricStmt", "showStorageMetricStmt",
+		"createStorageStmt", "createBrokerStmt", "recoverStorageStmt", "showSchemasStmt",
+		"createDatabaseStmt", "dropDatabaseStmt", "showDatabaseStmt", "showNameSpacesStmt",
+		"showMetricsStmt", "showFieldsStmt", "showTagKeysStmt", "showTagValuesStmt",
+		"prefix", "withTagKey", "namespace", "databaseName", "storageName",
+		"requestID", "source", "queryStmt", "sourceAndSelect", "selectExpr",
+		"fields", "field", "alias", "storageFilter", "brokerFilter", "databaseFilter",
+		"typeFilter", "fromClause", "whereClause", "conditionExpr", "tagFilterExpr",
+		"tagValueList", "metricListFilter", "metricList", "timeRangeExpr", "timeExpr",
+		"nowExpr", "nowFunc", "timeRangeClause", "groupByClause", "groupByKeys",
+		"groupByKey", "fillOption", "orderByClause", "sortField", "sortFields",
+		"havingClause", "boolExpr", "boolExprLogicalOp", "boolExprAtom", "binaryExpr",
+		"binaryOperator", "fieldExpr", "star", "durationLit", "intervalItem",
+		"exprFunc", "funcName", "exprFuncParams", "funcParam", "fieldPredicate",
+		"exprAtom", "identFilter", "json", "toml", "obj", "pair", "arr", "value",
+		"intNumber", "decNumber", "limitClause", "precisionClause", "offsetClause",
 		"metricName", "tagKey", "tagValue", "ident", "nonReservedWords",
 	}
 	staticData.predictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 130, 861, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 147, 956, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
 		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
 		2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20, 2,
@@ -123,368 +129,413 @@ func sqlParserInit() {
 		2, 79, 7, 79, 2, 80, 7, 80, 2, 81, 7, 81, 2, 82, 7, 82, 2, 83, 7, 83, 2,
 		84, 7, 84, 2, 85, 7, 85, 2, 86, 7, 86, 2, 87, 7, 87, 2, 88, 7, 88, 2, 89,
 		7, 89, 2, 90, 7, 90, 2, 91, 7, 91, 2, 92, 7, 92, 2, 93, 7, 93, 2, 94, 7,
-		94, 2, 95, 7, 95, 2, 96, 7, 96, 2, 97, 7, 97, 1, 0, 1, 0, 1, 0, 1, 0, 1,
-		0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 3, 0, 209, 8, 0, 1, 1, 1,
-		1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1,
-		3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1,
-		3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 3, 3, 242, 8, 3, 1, 4, 1, 4, 1, 4, 1,
-		5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1,
-		7, 1, 8, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9, 1, 10, 1, 10, 1, 10, 1, 10, 1, 11,
-		1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1,
-		12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 3, 12, 287, 8, 12, 1, 13, 1, 13,
-		1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1, 14, 1, 14, 1,
-		14, 1, 14, 1, 14, 1, 14, 3, 14, 305, 8, 14, 1, 14, 1, 14, 1, 14, 3, 14,
-		310, 8, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 16, 1,
-		16, 3, 16, 321, 8, 16, 1, 16, 1, 16, 1, 16, 3, 16, 326, 8, 16, 1, 17, 1,
-		17, 1, 17, 1, 17, 1, 17, 1, 17, 3, 17, 334, 8, 17, 1, 17, 1, 17, 1, 17,
-		3, 17, 339, 8, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 19, 1,
-		19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20,
-		3, 20, 359, 8, 20, 1, 20, 1, 20, 1, 20, 3, 20, 364, 8, 20, 1, 21, 1, 21,
-		1, 21, 1, 21, 1, 22, 1, 22, 1, 22, 1, 22, 1, 23, 1, 23, 1, 23, 1, 23, 1,
-		24, 1, 24, 1, 24, 1, 25, 1, 25, 1, 25, 1, 25, 1, 26, 1, 26, 1, 26, 1, 26,
-		1, 27, 1, 27, 1, 27, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 3, 28, 398,
-		8, 28, 1, 28, 3, 28, 401, 8, 28, 1, 29, 1, 29, 1, 29, 1, 29, 3, 29, 407,
-		8, 29, 1, 29, 1, 29, 1, 29, 1, 29, 3, 29, 413, 8, 29, 1, 29, 3, 29, 416,
-		8, 29, 1, 30, 1, 30, 1, 30, 1, 30, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1,
-		32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 3, 32, 436,
-		8, 32, 1, 32, 3, 32, 439, 8, 32, 1, 33, 1, 33, 1, 34, 1, 34, 1, 35, 1,
-		35, 1, 36, 1, 36, 1, 37, 1, 37, 1, 38, 1, 38, 1, 39, 1, 39, 1, 40, 3, 40,
-		456, 8, 40, 1, 40, 1, 40, 3, 40, 460, 8, 40, 1, 40, 3, 40, 463, 8, 40,
-		1, 40, 3, 40, 466, 8, 40, 1, 40, 3, 40, 469, 8, 40, 1, 40, 3, 40, 472,
-		8, 40, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 3, 41, 480, 8, 41, 1,
-		42, 1, 42, 1, 42, 1, 43, 1, 43, 1, 43, 5, 43, 488, 8, 43, 10, 43, 12, 43,
-		491, 9, 43, 1, 44, 1, 44, 3, 44, 495, 8, 44, 1, 45, 1, 45, 1, 45, 1, 46,
-		1, 46, 1, 46, 1, 46, 1, 47, 1, 47, 1, 47, 1, 47, 1, 48, 1, 48, 1, 48, 1,
-		48, 1, 49, 1, 49, 1, 49, 1, 49, 1, 50, 1, 50, 1, 50, 1, 50, 3, 50, 520,
-		8, 50, 1, 51, 1, 51, 1, 51, 1, 52, 1, 52, 1, 52, 1, 52, 1, 52, 1, 52, 1,
-		52, 1, 52, 3, 52, 533, 8, 52, 3, 52, 535, 8, 52, 1, 53, 1, 53, 1, 53, 1,
-		53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53,
-		3, 53, 551, 8, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 3, 53, 559,
-		8, 53, 1, 53, 1, 53, 1, 53, 1, 53, 3, 53, 565, 8, 53, 1, 53, 1, 53, 1,
-		53, 5, 53, 570, 8, 53, 10, 53, 12, 53, 573, 9, 53, 1, 54, 1, 54, 1, 54,
-		5, 54, 578, 8, 54, 10, 54, 12, 54, 581, 9, 54, 1, 55, 1, 55, 1, 55, 1,
-		55, 1, 55, 1, 55, 1, 56, 1, 56, 1, 56, 5, 56, 592, 8, 56, 10, 56, 12, 56,
-		595, 9, 56, 1, 57, 1, 57, 1, 57, 3, 57, 600, 8, 57, 1, 58, 1, 58, 1, 58,
-		1, 58, 3, 58, 606, 8, 58, 1, 59, 1, 59, 3, 59, 610, 8, 59, 1, 60, 1, 60,
-		1, 60, 3, 60, 615, 8, 60, 1, 60, 1, 60, 1, 61, 1, 61, 1, 61, 1, 61, 1,
-		61, 1, 61, 1, 61, 1, 61, 3, 61, 627, 8, 61, 1, 61, 3, 61, 630, 8, 61, 1,
-		62, 1, 62, 1, 62, 5, 62, 635, 8, 62, 10, 62, 12, 62, 638, 9, 62, 1, 63,
-		1, 63, 1, 63, 1, 63, 1, 63, 1, 63, 1, 63, 1, 63, 1, 63, 3, 63, 649, 8,
-		63, 1, 64, 1, 64, 1, 65, 1, 65, 1, 65, 1, 65, 1, 66, 1, 66, 5, 66, 659,
-		8, 66, 10, 66, 12, 66, 662, 9, 66, 1, 67, 1, 67, 1, 67, 5, 67, 667, 8,
-		67, 10, 67, 12, 67, 670, 9, 67, 1, 68, 1, 68, 1, 68, 1, 69, 1, 69, 1, 69,
-		1, 69, 1, 69, 1, 69, 3, 69, 681, 8, 69, 1, 69, 1, 69, 1, 69, 1, 69, 5,
-		69, 687, 8, 69, 10, 69, 12, 69, 690, 9, 69, 1, 70, 1, 70, 1, 71, 1, 71,
-		1, 72, 1, 72, 1, 72, 1, 72, 1, 73, 1, 73, 1, 73, 1, 73, 1, 73, 1, 73, 1,
-		73, 1, 73, 3, 73, 708, 8, 73, 1, 74, 1, 74, 1, 74, 1, 74, 1, 74, 1, 74,
-		1, 74, 1, 74, 1, 74, 3, 74, 719, 8, 74, 1, 74, 1, 74, 1, 74, 1, 74, 1,
-		74, 1, 74, 1, 74, 1, 74, 1, 74, 1, 74, 1, 74, 1, 74, 5, 74, 733, 8, 74,
-		10, 74, 12, 74, 736, 9, 74, 1, 75, 1, 75, 1, 76, 1, 76, 1, 76, 1, 77, 1,
-		77, 1, 78, 1, 78, 1, 78, 3, 78, 748, 8, 78, 1, 78, 1, 78, 1, 79, 1, 79,
-		1, 80, 1, 80, 1, 80, 5, 80, 757, 8, 80, 10, 80, 12, 80, 760, 9, 80, 1,
-		81, 1, 81, 3, 81, 764, 8, 81, 1, 82, 1, 82, 3, 82, 768, 8, 82, 1, 82, 1,
-		82, 3, 82, 772, 8, 82, 1, 83, 1, 83, 1, 83, 1, 83, 1, 84, 1, 84, 1, 85,
-		1, 85, 1, 86, 1, 86, 1, 86, 1, 86, 5, 86, 786, 8, 86, 10, 86, 12, 86, 789,
-		9, 86, 1, 86, 1, 86, 1, 86, 1, 86, 3, 86, 795, 8, 86, 1, 87, 1, 87, 1,
-		87, 1, 87, 1, 88, 1, 88, 1, 88, 1, 88, 5, 88, 805, 8, 88, 10, 88, 12, 88,
-		808, 9, 88, 1, 88, 1, 88, 1, 88, 1, 88, 3, 88, 814, 8, 88, 1, 89, 1, 89,
-		1, 89, 1, 89, 1, 89, 1, 89, 1, 89, 1, 89, 3, 89, 824, 8, 89, 1, 90, 3,
-		90, 827, 8, 90, 1, 90, 1, 90, 1, 91, 3, 91, 832, 8, 91, 1, 91, 1, 91, 1,
-		92, 1, 92, 1, 92, 1, 93, 1, 93, 1, 94, 1, 94, 1, 95, 1, 95, 1, 96, 1, 96,
-		3, 96, 847, 8, 96, 1, 96, 1, 96, 1, 96, 3, 96, 852, 8, 96, 5, 96, 854,
-		8, 96, 10, 96, 12, 96, 857, 9, 96, 1, 97, 1, 97, 1, 97, 0, 3, 106, 138,
-		148, 98, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32,
-		34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 66, 68,
-		70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 94, 96, 98, 100, 102, 104,
-		106, 108, 110, 112, 114, 116, 118, 120, 122, 124, 126, 128, 130, 132, 134,
-		136, 138, 140, 142, 144, 146, 148, 150, 152, 154, 156, 158, 160, 162, 164,
-		166, 168, 170, 172, 174, 176, 178, 180, 182, 184, 186, 188, 190, 192, 194,
-		0, 10, 1, 0, 31, 33, 1, 0, 24, 25, 1, 0, 62, 63, 2, 0, 65, 66, 129, 130,
-		1, 0, 68, 69, 2, 0, 70, 70, 113, 113, 1, 0, 97, 103, 1, 0, 87, 96, 1, 0,
-		122, 123, 2, 0, 6, 21, 23, 103, 885, 0, 208, 1, 0, 0, 0, 2, 210, 1, 0,
-		0, 0, 4, 213, 1, 0, 0, 0, 6, 241, 1, 0, 0, 0, 8, 243, 1, 0, 0, 0, 10, 246,
-		1, 0, 0, 0, 12, 249, 1, 0, 0, 0, 14, 256, 1, 0, 0, 0, 16, 259, 1, 0, 0,
-		0, 18, 262, 1, 0, 0, 0, 20, 265, 1, 0, 0, 0, 22, 269, 1, 0, 0, 0, 24, 277,
-		1, 0, 0, 0, 26, 288, 1, 0, 0, 0, 28, 296, 1, 0, 0, 0, 30, 311, 1, 0, 0,
-		0, 32, 315, 1, 0, 0, 0, 34, 327, 1, 0, 0, 0, 36, 340, 1, 0, 0, 0, 38, 346,
-		1, 0, 0, 0, 40, 352, 1, 0, 0, 0, 42, 365, 1, 0, 0, 0, 44, 369, 1, 0, 0,
-		0, 46, 373, 1, 0, 0, 0, 48, 377, 1, 0, 0, 0, 50, 380, 1, 0, 0, 0, 52, 384,
-		1, 0, 0, 0, 54, 388, 1, 0, 0, 0, 56, 391, 1, 0, 0, 0, 58, 402, 1, 0, 0,
-		0, 60, 417, 1, 0, 0, 0, 62, 421, 1, 0, 0, 0, 64, 426, 1, 0, 0, 0, 66, 440,
-		1, 0, 0, 0, 68, 442, 1, 0, 0, 0, 70, 444, 1, 0, 0, 0, 72, 446, 1, 0, 0,
-		0, 74, 448, 1, 0, 0, 0, 76, 450, 1, 0, 0, 0, 78, 452, 1, 0, 0, 0, 80, 455,
-		1, 0, 0, 0, 82, 479, 1, 0, 0, 0, 84, 481, 1, 0, 0, 0, 86, 484, 1, 0, 0,
-		0, 88, 492, 1, 0, 0, 0, 90, 496, 1, 0, 0, 0, 92, 499, 1, 0, 0, 0, 94, 503,
-		1, 0, 0, 0, 96, 507, 1, 0, 0, 0, 98, 511, 1, 0, 0, 0, 100, 515, 1, 0, 0,
-		0, 102, 521, 1, 0, 0, 0, 104, 534, 1, 0, 0, 0, 106, 564, 1, 0, 0, 0, 108,
-		574, 1, 0, 0, 0, 110, 582, 1, 0, 0, 0, 112, 588, 1, 0, 0, 0, 114, 596,
-		1, 0, 0, 0, 116, 601, 1, 0, 0, 0, 118, 607, 1, 0, 0, 0, 120, 611, 1, 0,
-		0, 0, 122, 618, 1, 0, 0, 0, 124, 631, 1, 0, 0, 0, 126, 648, 1, 0, 0, 0,
-		128, 650, 1, 0, 0, 0, 130, 652, 1, 0, 0, 0, 132, 656, 1, 0, 0, 0, 134,
-		663, 1, 0, 0, 0, 136, 671, 1, 0, 0, 0, 138, 680, 1, 0, 0, 0, 140, 691,
-		1, 0, 0, 0, 142, 693, 1, 0, 0, 0, 144, 695, 1, 0, 0, 0, 146, 707, 1, 0,
-		0, 0, 148, 718, 1, 0, 0, 0, 150, 737, 1, 0, 0, 0, 152, 739, 1, 0, 0, 0,
-		154, 742, 1, 0, 0, 0, 156, 744, 1, 0, 0, 0, 158, 751, 1, 0, 0, 0, 160,
-		753, 1, 0, 0, 0, 162, 763, 1, 0, 0, 0, 164, 771, 1, 0, 0, 0, 166, 773,
-		1, 0, 0, 0, 168, 777, 1, 0, 0, 0, 170, 779, 1, 0, 0, 0, 172, 794, 1, 0,
-		0, 0, 174, 796, 1, 0, 0, 0, 176, 813, 1, 0, 0, 0, 178, 823, 1, 0, 0, 0,
-		180, 826, 1, 0, 0, 0, 182, 831, 1, 0, 0, 0, 184, 835, 1, 0, 0, 0, 186,
-		838, 1, 0, 0, 0, 188, 840, 1, 0, 0, 0, 190, 842, 1, 0, 0, 0, 192, 846,
-		1, 0, 0, 0, 194, 858, 1, 0, 0, 0, 196, 209, 3, 6, 3, 0, 197, 209, 3, 42,
-		21, 0, 198, 209, 3, 44, 22, 0, 199, 209, 3, 46, 23, 0, 200, 209, 3, 2,
-		1, 0, 201, 209, 3, 80, 40, 0, 202, 209, 3, 50, 25, 0, 203, 209, 3, 52,
-		26, 0, 204, 209, 3, 4, 2, 0, 205, 206, 3, 192, 96, 0, 206, 207, 5, 0, 0,
-		1, 207, 209, 1, 0, 0, 0, 208, 196, 1, 0, 0, 0, 208, 197, 1, 0, 0, 0, 208,
-		198, 1, 0, 0, 0, 208, 199, 1, 0, 0, 0, 208, 200, 1, 0, 0, 0, 208, 201,
-		1, 0, 0, 0, 208, 202, 1, 0, 0, 0, 208, 203, 1, 0, 0, 0, 208, 204, 1, 0,
-		0, 0, 208, 205, 1, 0, 0, 0, 209, 1, 1, 0, 0, 0, 210, 211, 5, 23, 0, 0,
-		211, 212, 3, 192, 96, 0, 212, 3, 1, 0, 0, 0, 213, 214, 5, 8, 0, 0, 214,
-		215, 5, 55, 0, 0, 215, 216, 3, 170, 85, 0, 216, 5, 1, 0, 0, 0, 217, 242,
-		3, 8, 4, 0, 218, 242, 3, 20, 10, 0, 219, 242, 3, 22, 11, 0, 220, 242, 3,
-		24, 12, 0, 221, 242, 3, 26, 13, 0, 222, 242, 3, 28, 14, 0, 223, 242, 3,
-		14, 7, 0, 224, 242, 3, 16, 8, 0, 225, 242, 3, 18, 9, 0, 226, 242, 3, 30,
-		15, 0, 227, 242, 3, 36, 18, 0, 228, 242, 3, 38, 19, 0, 229, 242, 3, 40,
-		20, 0, 230, 242, 3, 32, 16, 0, 231, 242, 3, 34, 17, 0, 232, 242, 3, 48,
-		24, 0, 233, 242, 3, 54, 27, 0, 234, 242, 3, 56, 28, 0, 235, 242, 3, 58,
-		29, 0, 236, 242, 3, 60, 30, 0, 237, 242, 3, 62, 31, 0, 238, 242, 3, 64,
-		32, 0, 239, 242, 3, 10, 5, 0, 240, 242, 3, 12, 6, 0, 241, 217, 1, 0, 0,
-		0, 241, 218, 1, 0, 0, 0, 241, 219, 1, 0, 0, 0, 241, 220, 1, 0, 0, 0, 241,
-		221, 1, 0, 0, 0, 241, 222, 1, 0, 0, 0, 241, 223, 1, 0, 0, 0, 241, 224,
-		1, 0, 0, 0, 241, 225, 1, 0, 0, 0, 241, 226, 1, 0, 0, 0, 241, 227, 1, 0,
-		0, 0, 241, 228, 1, 0, 0, 0, 241, 229, 1, 0, 0, 0, 241, 230, 1, 0, 0, 0,
-		241, 231, 1, 0, 0, 0, 241, 232, 1, 0, 0, 0, 241, 233, 1, 0, 0, 0, 241,
-		234, 1, 0, 0, 0, 241, 235, 1, 0, 0, 0, 241, 236, 1, 0, 0, 0, 241, 237,
-		1, 0, 0, 0, 241, 238, 1, 0, 0, 0, 241, 239, 1, 0, 0, 0, 241, 240, 1, 0,
-		0, 0, 242, 7, 1, 0, 0, 0, 243, 244, 5, 21, 0, 0, 244, 245, 5, 26, 0, 0,
-		245, 9, 1, 0, 0, 0, 246, 247, 5, 21, 0, 0, 247, 248, 5, 84, 0, 0, 248,
-		11, 1, 0, 0, 0, 249, 250, 5, 21, 0, 0, 250, 251, 5, 85, 0, 0, 251, 252,
-		5, 54, 0, 0, 252, 253, 5, 86, 0, 0, 253, 254, 5, 106, 0, 0, 254, 255, 3,
-		76, 38, 0, 255, 13, 1, 0, 0, 0, 256, 257, 5, 21, 0, 0, 257, 258, 5, 30,
-		0, 0, 258, 15, 1, 0, 0, 0, 259, 260, 5, 21, 0, 0, 260, 261, 5, 34, 0, 0,
-		261, 17, 1, 0, 0, 0, 262, 263, 5, 21, 0, 0, 263, 264, 5, 55, 0, 0, 264,
-		19, 1, 0, 0, 0, 265, 266, 5, 21, 0, 0, 266, 267, 5, 27, 0, 0, 267, 268,
-		5, 28, 0, 0, 268, 21, 1, 0, 0, 0, 269, 270, 5, 21, 0, 0, 270, 271, 5, 33,
-		0, 0, 271, 272, 5, 27, 0, 0, 272, 273, 5, 53, 0, 0, 273, 274, 3, 78, 39,
-		0, 274, 275, 5, 54, 0, 0, 275, 276, 3, 98, 49, 0, 276, 23, 1, 0, 0, 0,
-		277, 278, 5, 21, 0, 0, 278, 279, 5, 32, 0, 0, 279, 280, 5, 27, 0, 0, 280,
-		281, 5, 53, 0, 0, 281, 282, 3, 78, 39, 0, 282, 283, 5, 54, 0, 0, 283, 286,
-		3, 98, 49, 0, 284, 285, 5, 62, 0, 0, 285, 287, 3, 94, 47, 0, 286, 284,
-		1, 0, 0, 0, 286, 287, 1, 0, 0, 0, 287, 25, 1, 0, 0, 0, 288, 289, 5, 21,
-		0, 0, 289, 290, 5, 26, 0, 0, 290, 291, 5, 27, 0, 0, 291, 292, 5, 53, 0,
-		0, 292, 293, 3, 78, 39, 0, 293, 294, 5, 54, 0, 0, 294, 295, 3, 98, 49,
-		0, 295, 27, 1, 0, 0, 0, 296, 297, 5, 21, 0, 0, 297, 298, 5, 31, 0, 0, 298,
-		299, 5, 27, 0, 0, 299, 300, 5, 53, 0, 0, 300, 301, 3, 78, 39, 0, 301, 304,
-		5, 54, 0, 0, 302, 305, 3, 92, 46, 0, 303, 305, 3, 98, 49, 0, 304, 302,
-		1, 0, 0, 0, 304, 303, 1, 0, 0, 0, 305, 306, 1, 0, 0, 0, 306, 309, 5, 62,
-		0, 0, 307, 310, 3, 92, 46, 0, 308, 310, 3, 98, 49, 0, 309, 307, 1, 0, 0,
-		0, 309, 308, 1, 0, 0, 0, 310, 29, 1, 0, 0, 0, 311, 312, 5, 21, 0, 0, 312,
-		313, 7, 0, 0, 0, 313, 314, 5, 35, 0, 0, 314, 31, 1, 0, 0, 0, 315, 316,
-		5, 21, 0, 0, 316, 317, 5, 13, 0, 0, 317, 320, 5, 54, 0, 0, 318, 321, 3,
-		92, 46, 0, 319, 321, 3, 96, 48, 0, 320, 318, 1, 0, 0, 0, 320, 319, 1, 0,
-		0, 0, 321, 322, 1, 0, 0, 0, 322, 325, 5, 62, 0, 0, 323, 326, 3, 92, 46,
-		0, 324, 326, 3, 96, 48, 0, 325, 323, 1, 0, 0, 0, 325, 324, 1, 0, 0, 0,
-		326, 33, 1, 0, 0, 0, 327, 328, 5, 21, 0, 0, 328, 329, 5, 14, 0, 0, 329,
-		330, 5, 37, 0, 0, 330, 333, 5, 54, 0, 0, 331, 334, 3, 92, 46, 0, 332, 334,
-		3, 96, 48, 0, 333, 331, 1, 0, 0, 0, 333, 332, 1, 0, 0, 0, 334, 335, 1,
-		0, 0, 0, 335, 338, 5, 62, 0, 0, 336, 339, 3, 92, 46, 0, 337, 339, 3, 96,
-		48, 0, 338, 336, 1, 0, 0, 0, 338, 337, 1, 0, 0, 0, 339, 35, 1, 0, 0, 0,
-		340, 341, 5, 21, 0, 0, 341, 342, 5, 33, 0, 0, 342, 343, 5, 43, 0, 0, 343,
-		344, 5, 54, 0, 0, 344, 345, 3, 110, 55, 0, 345, 37, 1, 0, 0, 0, 346, 347,
-		5, 21, 0, 0, 347, 348, 5, 32, 0, 0, 348, 349, 5, 43, 0, 0, 349, 350, 5,
-		54, 0, 0, 350, 351, 3, 110, 55, 0, 351, 39, 1, 0, 0, 0, 352, 353, 5, 21,
-		0, 0, 353, 354, 5, 31, 0, 0, 354, 355, 5, 43, 0, 0, 355, 358, 5, 54, 0,
-		0, 356, 359, 3, 92, 46, 0, 357, 359, 3, 110, 55, 0, 358, 356, 1, 0, 0,
-		0, 358, 357, 1, 0, 0, 0, 359, 360, 1, 0, 0, 0, 360, 363, 5, 62, 0, 0, 361,
-		364, 3, 92, 46, 0, 362, 364, 3, 110, 55, 0, 363, 361, 1, 0, 0, 0, 363,
-		362, 1, 0, 0, 0, 364, 41, 1, 0, 0, 0, 365, 366, 5, 6, 0, 0, 366, 367, 5,
-		31, 0, 0, 367, 368, 3, 168, 84, 0, 368, 43, 1, 0, 0, 0, 369, 370, 5, 6,
-		0, 0, 370, 371, 5, 32, 0, 0, 371, 372, 3, 168, 84, 0, 372, 45, 1, 0, 0,
-		0, 373, 374, 5, 22, 0, 0, 374, 375, 5, 31, 0, 0, 375, 376, 3, 74, 37, 0,
-		376, 47, 1, 0, 0, 0, 377, 378, 5, 21, 0, 0, 378, 379, 5, 36, 0, 0, 379,
-		49, 1, 0, 0, 0, 380, 381, 5, 6, 0, 0, 381, 382, 5, 37, 0, 0, 382, 383,
-		3, 168, 84, 0, 383, 51, 1, 0, 0, 0, 384, 385, 5, 9, 0, 0, 385, 386, 5,
-		37, 0, 0, 386, 387, 3, 72, 36, 0, 387, 53, 1, 0, 0, 0, 388, 389, 5, 21,
-		0, 0, 389, 390, 5, 38, 0, 0, 390, 55, 1, 0, 0, 0, 391, 392, 5, 21, 0, 0,
-		392, 397, 5, 40, 0, 0, 393, 394, 5, 54, 0, 0, 394, 395, 5, 39, 0, 0, 395,
-		396, 5, 106, 0, 0, 396, 398, 3, 66, 33, 0, 397, 393, 1, 0, 0, 0, 397, 398,
-		1, 0, 0, 0, 398, 400, 1, 0, 0, 0, 399, 401, 3, 184, 92, 0, 400, 399, 1,
-		0, 0, 0, 400, 401, 1, 0, 0, 0, 401, 57, 1, 0, 0, 0, 402, 403, 5, 21, 0,
-		0, 403, 406, 5, 42, 0, 0, 404, 405, 5, 20, 0, 0, 405, 407, 3, 70, 35, 0,
-		406, 404, 1, 0, 0, 0, 406, 407, 1, 0, 0, 0, 407, 412, 1, 0, 0, 0, 408,
-		409, 5, 54, 0, 0, 409, 410, 5, 43, 0, 0, 410, 411, 5, 106, 0, 0, 411, 413,
-		3, 66, 33, 0, 412, 408, 1, 0, 0, 0, 412, 413, 1, 0, 0, 0, 413, 415, 1,
-		0, 0, 0, 414, 416, 3, 184, 92, 0, 415, 414, 1, 0, 0, 0, 415, 416, 1, 0,
-		0, 0, 416, 59, 1, 0, 0, 0, 417, 418, 5, 21, 0, 0, 418, 419, 5, 45, 0, 0,
-		419, 420, 3, 100, 50, 0, 420, 61, 1, 0, 0, 0, 421, 422, 5, 21, 0, 0, 422,
-		423, 5, 46, 0, 0, 423, 424, 5, 48, 0, 0, 424, 425, 3, 100, 50, 0, 425,
-		63, 1, 0, 0, 0, 426, 427, 5, 21, 0, 0, 427, 428, 5, 46, 0, 0, 428, 429,
-		5, 51, 0, 0, 429, 430, 3, 100, 50, 0, 430, 431, 5, 50, 0, 0, 431, 432,
-		5, 49, 0, 0, 432, 433, 5, 106, 0, 0, 433, 435, 3, 68, 34, 0, 434, 436,
-		3, 102, 51, 0, 435, 434, 1, 0, 0, 0, 435, 436, 1, 0, 0, 0, 436, 438, 1,
-		0, 0, 0, 437, 439, 3, 184, 92, 0, 438, 437, 1, 0, 0, 0, 438, 439, 1, 0,
-		0, 0, 439, 65, 1, 0, 0, 0, 440, 441, 3, 192, 96, 0, 441, 67, 1, 0, 0, 0,
-		442, 443, 3, 192, 96, 0, 443, 69, 1, 0, 0, 0, 444, 445, 3, 192, 96, 0,
-		445, 71, 1, 0, 0, 0, 446, 447, 3, 192, 96, 0, 447, 73, 1, 0, 0, 0, 448,
-		449, 3, 192, 96, 0, 449, 75, 1, 0, 0, 0, 450, 451, 3, 192, 96, 0, 451,
-		77, 1, 0, 0, 0, 452, 453, 7, 1, 0, 0, 453, 79, 1, 0, 0, 0, 454, 456, 5,
-		58, 0, 0, 455, 454, 1, 0, 0, 0, 455, 456, 1, 0, 0, 0, 456, 457, 1, 0, 0,
-		0, 457, 459, 3, 82, 41, 0, 458, 460, 3, 102, 51, 0, 459, 458, 1, 0, 0,
-		0, 459, 460, 1, 0, 0, 0, 460, 462, 1, 0, 0, 0, 461, 463, 3, 122, 61, 0,
-		462, 461, 1, 0, 0, 0, 462, 463, 1, 0, 0, 0, 463, 465, 1, 0, 0, 0, 464,
-		466, 3, 130, 65, 0, 465, 464, 1, 0, 0, 0, 465, 466, 1, 0, 0, 0, 466, 468,
-		1, 0, 0, 0, 467, 469, 3, 184, 92, 0, 468, 467, 1, 0, 0, 0, 468, 469, 1,
-		0, 0, 0, 469, 471, 1, 0, 0, 0, 470, 472, 5, 59, 0, 0, 471, 470, 1, 0, 0,
-		0, 471, 472, 1, 0, 0, 0, 472, 81, 1, 0, 0, 0, 473, 474, 3, 84, 42, 0, 474,
-		475, 3, 100, 50, 0, 475, 480, 1, 0, 0, 0, 476, 477, 3, 100, 50, 0, 477,
-		478, 3, 84, 42, 0, 478, 480, 1, 0, 0, 0, 479, 473, 1, 0, 0, 0, 479, 476,
-		1, 0, 0, 0, 480, 83, 1, 0, 0, 0, 481, 482, 5, 60, 0, 0, 482, 483, 3, 86,
-		43, 0, 483, 85, 1, 0, 0, 0, 484, 489, 3, 88, 44, 0, 485, 486, 5, 115, 0,
-		0, 486, 488, 3, 88, 44, 0, 487, 485, 1, 0, 0, 0, 488, 491, 1, 0, 0, 0,
-		489, 487, 1, 0, 0, 0, 489, 490, 1, 0, 0, 0, 490, 87, 1, 0, 0, 0, 491, 489,
-		1, 0, 0, 0, 492, 494, 3, 148, 74, 0, 493, 495, 3, 90, 45, 0, 494, 493,
-		1, 0, 0, 0, 494, 495, 1, 0, 0, 0, 495, 89, 1, 0, 0, 0, 496, 497, 5, 61,
-		0, 0, 497, 498, 3, 192, 96, 0, 498, 91, 1, 0, 0, 0, 499, 500, 5, 31, 0,
-		0, 500, 501, 5, 106, 0, 0, 501, 502, 3, 192, 96, 0, 502, 93, 1, 0, 0, 0,
-		503, 504, 5, 32, 0, 0, 504, 505, 5, 106, 0, 0, 505, 506, 3, 192, 96, 0,
-		506, 95, 1, 0, 0, 0, 507, 508, 5, 37, 0, 0, 508, 509, 5, 106, 0, 0, 509,
-		510, 3, 192, 96, 0, 510, 97, 1, 0, 0, 0, 511, 512, 5, 29, 0, 0, 512, 513,
-		5, 106, 0, 0, 513, 514, 3, 192, 96, 0, 514, 99, 1, 0, 0, 0, 515, 516, 5,
-		53, 0, 0, 516, 519, 3, 186, 93, 0, 517, 518, 5, 20, 0, 0, 518, 520, 3,
-		70, 35, 0, 519, 517, 1, 0, 0, 0, 519, 520, 1, 0, 0, 0, 520, 101, 1, 0,
-		0, 0, 521, 522, 5, 54, 0, 0, 522, 523, 3, 104, 52, 0, 523, 103, 1, 0, 0,
-		0, 524, 535, 3, 106, 53, 0, 525, 526, 3, 106, 53, 0, 526, 527, 5, 62, 0,
-		0, 527, 528, 3, 114, 57, 0, 528, 535, 1, 0, 0, 0, 529, 532, 3, 114, 57,
-		0, 530, 531, 5, 62, 0, 0, 531, 533, 3, 106, 53, 0, 532, 530, 1, 0, 0, 0,
-		532, 533, 1, 0, 0, 0, 533, 535, 1, 0, 0, 0, 534, 524, 1, 0, 0, 0, 534,
-		525, 1, 0, 0, 0, 534, 529, 1, 0, 0, 0, 535, 105, 1, 0, 0, 0, 536, 537,
-		6, 53, -1, 0, 537, 538, 5, 120, 0, 0, 538, 539, 3, 106, 53, 0, 539, 540,
-		5, 121, 0, 0, 540, 565, 1, 0, 0, 0, 541, 550, 3, 188, 94, 0, 542, 551,
-		5, 106, 0, 0, 543, 551, 5, 70, 0, 0, 544, 545, 5, 71, 0, 0, 545, 551, 5,
-		70, 0, 0, 546, 551, 5, 113, 0, 0, 547, 551, 5, 114, 0, 0, 548, 551, 5,
-		107, 0, 0, 549, 551, 5, 108, 0, 0, 550, 542, 1, 0, 0, 0, 550, 543, 1, 0,
-		0, 0, 550, 544, 1, 0, 0, 0, 550, 546, 1, 0, 0, 0, 550, 547, 1, 0, 0, 0,
-		550, 548, 1, 0, 0, 0, 550, 549, 1, 0, 0, 0, 551, 552, 1, 0, 0, 0, 552,
-		553, 3, 190, 95, 0, 553, 565, 1, 0, 0, 0, 554, 558, 3, 188, 94, 0, 555,
-		559, 5, 81, 0, 0, 556, 557, 5, 71, 0, 0, 557, 559, 5, 81, 0, 0, 558, 555,
-		1, 0, 0, 0, 558, 556, 1, 0, 0, 0, 559, 560, 1, 0, 0, 0, 560, 561, 5, 120,
-		0, 0, 561, 562, 3, 108, 54, 0, 562, 563, 5, 121, 0, 0, 563, 565, 1, 0,
-		0, 0, 564, 536, 1, 0, 0, 0, 564, 541, 1, 0, 0, 0, 564, 554, 1, 0, 0, 0,
-		565, 571, 1, 0, 0, 0, 566, 567, 10, 1, 0, 0, 567, 568, 7, 2, 0, 0, 568,
-		570, 3, 106, 53, 2, 569, 566, 1, 0, 0, 0, 570, 573, 1, 0, 0, 0, 571, 569,
-		1, 0, 0, 0, 571, 572, 1, 0, 0, 0, 572, 107, 1, 0, 0, 0, 573, 571, 1, 0,
-		0, 0, 574, 579, 3, 190, 95, 0, 575, 576, 5, 115, 0, 0, 576, 578, 3, 190,
-		95, 0, 577, 575, 1, 0, 0, 0, 578, 581, 1, 0, 0, 0, 579, 577, 1, 0, 0, 0,
-		579, 580, 1, 0, 0, 0, 580, 109, 1, 0, 0, 0, 581, 579, 1, 0, 0, 0, 582,
-		583, 5, 43, 0, 0, 583, 584, 5, 81, 0, 0, 584, 585, 5, 120, 0, 0, 585, 586,
-		3, 112, 56, 0, 586, 587, 5, 121, 0, 0, 587, 111, 1, 0, 0, 0, 588, 593,
-		3, 192, 96, 0, 589, 590, 5, 115, 0, 0, 590, 592, 3, 192, 96, 0, 591, 589,
-		1, 0, 0, 0, 592, 595, 1, 0, 0, 0, 593, 591, 1, 0, 0, 0, 593, 594, 1, 0,
-		0, 0, 594, 113, 1, 0, 0, 0, 595, 593, 1, 0, 0, 0, 596, 599, 3, 116, 58,
-		0, 597, 598, 5, 62, 0, 0, 598, 600, 3, 116, 58, 0, 599, 597, 1, 0, 0, 0,
-		599, 600, 1, 0, 0, 0, 600, 115, 1, 0, 0, 0, 601, 602, 5, 79, 0, 0, 602,
-		605, 3, 146, 73, 0, 603, 606, 3, 118, 59, 0, 604, 606, 3, 192, 96, 0, 605,
-		603, 1, 0, 0, 0, 605, 604, 1, 0, 0, 0, 606, 117, 1, 0, 0, 0, 607, 609,
-		3, 120, 60, 0, 608, 610, 3, 152, 76, 0, 609, 608, 1, 0, 0, 0, 609, 610,
-		1, 0, 0, 0, 610, 119, 1, 0, 0, 0, 611, 612, 5, 80, 0, 0, 612, 614, 5, 120,
-		0, 0, 613, 615, 3, 160, 80, 0, 614, 613, 1, 0, 0, 0, 614, 615, 1, 0, 0,
-		0, 615, 616, 1, 0, 0, 0, 616, 617, 5, 121, 0, 0, 617, 121, 1, 0, 0, 0,
-		618, 619, 5, 74, 0, 0, 619, 620, 5, 76, 0, 0, 620, 626, 3, 124, 62, 0,
-		621, 622, 5, 64, 0, 0, 622, 623, 5, 120, 0, 0, 623, 624, 3, 128, 64, 0,
-		624, 625, 5, 121, 0, 0, 625, 627, 1, 0, 0, 0, 626, 621, 1, 0, 0, 0, 626,
-		627, 1, 0, 0, 0, 627, 629, 1, 0, 0, 0, 628, 630, 3, 136, 68, 0, 629, 628,
-		1, 0, 0, 0, 629, 630, 1, 0, 0, 0, 630, 123, 1, 0, 0, 0, 631, 636, 3, 126,
-		63, 0, 632, 633, 5, 115, 0, 0, 633, 635, 3, 126, 63, 0, 634, 632, 1, 0,
-		0, 0, 635, 638, 1, 0, 0, 0, 636, 634, 1, 0, 0, 0, 636, 637, 1, 0, 0, 0,
-		637, 125, 1, 0, 0, 0, 638, 636, 1, 0, 0, 0, 639, 649, 3, 192, 96, 0, 640,
-		641, 5, 79, 0, 0, 641, 642, 5, 120, 0, 0, 642, 643, 3, 152, 76, 0, 643,
-		644, 5, 121, 0, 0, 644, 649, 1, 0, 0, 0, 645, 646, 5, 79, 0, 0, 646, 647,
-		5, 120, 0, 0, 647, 649, 5, 121, 0, 0, 648, 639, 1, 0, 0, 0, 648, 640, 1,
-		0, 0, 0, 648, 645, 1, 0, 0, 0, 649, 127, 1, 0, 0, 0, 650, 651, 7, 3, 0,
-		0, 651, 129, 1, 0, 0, 0, 652, 653, 5, 67, 0, 0, 653, 654, 5, 76, 0, 0,
-		654, 655, 3, 134, 67, 0, 655, 131, 1, 0, 0, 0, 656, 660, 3, 148, 74, 0,
-		657, 659, 7, 4, 0, 0, 658, 657, 1, 0, 0, 0, 659, 662, 1, 0, 0, 0, 660,
-		658, 1, 0, 0, 0, 660, 661, 1, 0, 0, 0, 661, 133, 1, 0, 0, 0, 662, 660,
-		1, 0, 0, 0, 663, 668, 3, 132, 66, 0, 664, 665, 5, 115, 0, 0, 665, 667,
-		3, 132, 66, 0, 666, 664, 1, 0, 0, 0, 667, 670, 1, 0, 0, 0, 668, 666, 1,
-		0, 0, 0, 668, 669, 1, 0, 0, 0, 669, 135, 1, 0, 0, 0, 670, 668, 1, 0, 0,
-		0, 671, 672, 5, 75, 0, 0, 672, 673, 3, 138, 69, 0, 673, 137, 1, 0, 0, 0,
-		674, 675, 6, 69, -1, 0, 675, 676, 5, 120, 0, 0, 676, 677, 3, 138, 69, 0,
-		677, 678, 5, 121, 0, 0, 678, 681, 1, 0, 0, 0, 679, 681, 3, 142, 71, 0,
-		680, 674, 1, 0, 0, 0, 680, 679, 1, 0, 0, 0, 681, 688, 1, 0, 0, 0, 682,
-		683, 10, 2, 0, 0, 683, 684, 3, 140, 70, 0, 684, 685, 3, 138, 69, 3, 685,
-		687, 1, 0, 0, 0, 686, 682, 1, 0, 0, 0, 687, 690, 1, 0, 0, 0, 688, 686,
-		1, 0, 0, 0, 688, 689, 1, 0, 0, 0, 689, 139, 1, 0, 0, 0, 690, 688, 1, 0,
-		0, 0, 691, 692, 7, 2, 0, 0, 692, 141, 1, 0, 0, 0, 693, 694, 3, 144, 72,
-		0, 694, 143, 1, 0, 0, 0, 695, 696, 3, 148, 74, 0, 696, 697, 3, 146, 73,
-		0, 697, 698, 3, 148, 74, 0, 698, 145, 1, 0, 0, 0, 699, 708, 5, 106, 0,
-		0, 700, 708, 5, 107, 0, 0, 701, 708, 5, 108, 0, 0, 702, 708, 5, 111, 0,
-		0, 703, 708, 5, 112, 0, 0, 704, 708, 5, 109, 0, 0, 705, 708, 5, 110, 0,
-		0, 706, 708, 7, 5, 0, 0, 707, 699, 1, 0, 0, 0, 707, 700, 1, 0, 0, 0, 707,
-		701, 1, 0, 0, 0, 707, 702, 1, 0, 0, 0, 707, 703, 1, 0, 0, 0, 707, 704,
-		1, 0, 0, 0, 707, 705, 1, 0, 0, 0, 707, 706, 1, 0, 0, 0, 708, 147, 1, 0,
-		0, 0, 709, 710, 6, 74, -1, 0, 710, 711, 5, 120, 0, 0, 711, 712, 3, 148,
-		74, 0, 712, 713, 5, 121, 0, 0, 713, 719, 1, 0, 0, 0, 714, 719, 3, 156,
-		78, 0, 715, 719, 3, 164, 82, 0, 716, 719, 3, 152, 76, 0, 717, 719, 3, 150,
-		75, 0, 718, 709, 1, 0, 0, 0, 718, 714, 1, 0, 0, 0, 718, 715, 1, 0, 0, 0,
-		718, 716, 1, 0, 0, 0, 718, 717, 1, 0, 0, 0, 719, 734, 1, 0, 0, 0, 720,
-		721, 10, 9, 0, 0, 721, 722, 5, 125, 0, 0, 722, 733, 3, 148, 74, 10, 723,
-		724, 10, 8, 0, 0, 724, 725, 5, 124, 0, 0, 725, 733, 3, 148, 74, 9, 726,
-		727, 10, 7, 0, 0, 727, 728, 5, 122, 0, 0, 728, 733, 3, 148, 74, 8, 729,
-		730, 10, 6, 0, 0, 730, 731, 5, 123, 0, 0, 731, 733, 3, 148, 74, 7, 732,
-		720, 1, 0, 0, 0, 732, 723, 1, 0, 0, 0, 732, 726, 1, 0, 0, 0, 732, 729,
-		1, 0, 0, 0, 733, 736, 1, 0, 0, 0, 734, 732, 1, 0, 0, 0, 734, 735, 1, 0,
-		0, 0, 735, 149, 1, 0, 0, 0, 736, 734, 1, 0, 0, 0, 737, 738, 5, 125, 0,
-		0, 738, 151, 1, 0, 0, 0, 739, 740, 3, 180, 90, 0, 740, 741, 3, 154, 77,
-		0, 741, 153, 1, 0, 0, 0, 742, 743, 7, 6, 0, 0, 743, 155, 1, 0, 0, 0, 744,
-		745, 3, 158, 79, 0, 745, 747, 5, 120, 0, 0, 746, 748, 3, 160, 80, 0, 747,
-		746, 1, 0, 0, 0, 747, 748, 1, 0, 0, 0, 748, 749, 1, 0, 0, 0, 749, 750,
-		5, 121, 0, 0, 750, 157, 1, 0, 0, 0, 751, 752, 7, 7, 0, 0, 752, 159, 1,
-		0, 0, 0, 753, 758, 3, 162, 81, 0, 754, 755, 5, 115, 0, 0, 755, 757, 3,
-		162, 81, 0, 756, 754, 1, 0, 0, 0, 757, 760, 1, 0, 0, 0, 758, 756, 1, 0,
-		0, 0, 758, 759, 1, 0, 0, 0, 759, 161, 1, 0, 0, 0, 760, 758, 1, 0, 0, 0,
-		761, 764, 3, 148, 74, 0, 762, 764, 3, 106, 53, 0, 763, 761, 1, 0, 0, 0,
-		763, 762, 1, 0, 0, 0, 764, 163, 1, 0, 0, 0, 765, 767, 3, 192, 96, 0, 766,
-		768, 3, 166, 83, 0, 767, 766, 1, 0, 0, 0, 767, 768, 1, 0, 0, 0, 768, 772,
-		1, 0, 0, 0, 769, 772, 3, 182, 91, 0, 770, 772, 3, 180, 90, 0, 771, 765,
-		1, 0, 0, 0, 771, 769, 1, 0, 0, 0, 771, 770, 1, 0, 0, 0, 772, 165, 1, 0,
-		0, 0, 773, 774, 5, 118, 0, 0, 774, 775, 3, 106, 53, 0, 775, 776, 5, 119,
-		0, 0, 776, 167, 1, 0, 0, 0, 777, 778, 3, 178, 89, 0, 778, 169, 1, 0, 0,
-		0, 779, 780, 3, 192, 96, 0, 780, 171, 1, 0, 0, 0, 781, 782, 5, 116, 0,
-		0, 782, 787, 3, 174, 87, 0, 783, 784, 5, 115, 0, 0, 784, 786, 3, 174, 87,
-		0, 785, 783, 1, 0, 0, 0, 786, 789, 1, 0, 0, 0, 787, 785, 1, 0, 0, 0, 787,
-		788, 1, 0, 0, 0, 788, 790, 1, 0, 0, 0, 789, 787, 1, 0, 0, 0, 790, 791,
-		5, 117, 0, 0, 791, 795, 1, 0, 0, 0, 792, 793, 5, 116, 0, 0, 793, 795, 5,
-		117, 0, 0, 794, 781, 1, 0, 0, 0, 794, 792, 1, 0, 0, 0, 795, 173, 1, 0,
-		0, 0, 796, 797, 5, 4, 0, 0, 797, 798, 5, 105, 0, 0, 798, 799, 3, 178, 89,
-		0, 799, 175, 1, 0, 0, 0, 800, 801, 5, 118, 0, 0, 801, 806, 3, 178, 89,
-		0, 802, 803, 5, 115, 0, 0, 803, 805, 3, 178, 89, 0, 804, 802, 1, 0, 0,
-		0, 805, 808, 1, 0, 0, 0, 806, 804, 1, 0, 0, 0, 806, 807, 1, 0, 0, 0, 807,
-		809, 1, 0, 0, 0, 808, 806, 1, 0, 0, 0, 809, 810, 5, 119, 0, 0, 810, 814,
-		1, 0, 0, 0, 811, 812, 5, 118, 0, 0, 812, 814, 5, 119, 0, 0, 813, 800, 1,
-		0, 0, 0, 813, 811, 1, 0, 0, 0, 814, 177, 1, 0, 0, 0, 815, 824, 5, 4, 0,
-		0, 816, 824, 3, 180, 90, 0, 817, 824, 3, 182, 91, 0, 818, 824, 3, 172,
-		86, 0, 819, 824, 3, 176, 88, 0, 820, 824, 5, 1, 0, 0, 821, 824, 5, 2, 0,
-		0, 822, 824, 5, 3, 0, 0, 823, 815, 1, 0, 0, 0, 823, 816, 1, 0, 0, 0, 823,
-		817, 1, 0, 0, 0, 823, 818, 1, 0, 0, 0, 823, 819, 1, 0, 0, 0, 823, 820,
-		1, 0, 0, 0, 823, 821, 1, 0, 0, 0, 823, 822, 1, 0, 0, 0, 824, 179, 1, 0,
-		0, 0, 825, 827, 7, 8, 0, 0, 826, 825, 1, 0, 0, 0, 826, 827, 1, 0, 0, 0,
-		827, 828, 1, 0, 0, 0, 828, 829, 5, 129, 0, 0, 829, 181, 1, 0, 0, 0, 830,
-		832, 7, 8, 0, 0, 831, 830, 1, 0, 0, 0, 831, 832, 1, 0, 0, 0, 832, 833,
-		1, 0, 0, 0, 833, 834, 5, 130, 0, 0, 834, 183, 1, 0, 0, 0, 835, 836, 5,
-		55, 0, 0, 836, 837, 5, 129, 0, 0, 837, 185, 1, 0, 0, 0, 838, 839, 3, 192,
-		96, 0, 839, 187, 1, 0, 0, 0, 840, 841, 3, 192, 96, 0, 841, 189, 1, 0, 0,
-		0, 842, 843, 3, 192, 96, 0, 843, 191, 1, 0, 0, 0, 844, 847, 5, 128, 0,
-		0, 845, 847, 3, 194, 97, 0, 846, 844, 1, 0, 0, 0, 846, 845, 1, 0, 0, 0,
-		847, 855, 1, 0, 0, 0, 848, 851, 5, 104, 0, 0, 849, 852, 5, 128, 0, 0, 850,
-		852, 3, 194, 97, 0, 851, 849, 1, 0, 0, 0, 851, 850, 1, 0, 0, 0, 852, 854,
-		1, 0, 0, 0, 853, 848, 1, 0, 0, 0, 854, 857, 1, 0, 0, 0, 855, 853, 1, 0,
-		0, 0, 855, 856, 1, 0, 0, 0, 856, 193, 1, 0, 0, 0, 857, 855, 1, 0, 0, 0,
-		858, 859, 7, 9, 0, 0, 859, 195, 1, 0, 0, 0, 67, 208, 241, 286, 304, 309,
-		320, 325, 333, 338, 358, 363, 397, 400, 406, 412, 415, 435, 438, 455, 459,
-		462, 465, 468, 471, 479, 489, 494, 519, 532, 534, 550, 558, 564, 571, 579,
-		593, 599, 605, 609, 614, 626, 629, 636, 648, 660, 668, 680, 688, 707, 718,
-		732, 734, 747, 758, 763, 767, 771, 787, 794, 806, 813, 823, 826, 831, 846,
-		851, 855,
+		94, 2, 95, 7, 95, 2, 96, 7, 96, 2, 97, 7, 97, 2, 98, 7, 98, 2, 99, 7, 99,
+		2, 100, 7, 100, 2, 101, 7, 101, 2, 102, 7, 102, 2, 103, 7, 103, 1, 0, 1,
+		0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 3, 0, 221,
+		8, 0, 1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 3,
+		1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3,
+		1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 3, 3, 256,
+		8, 3, 1, 4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6,
+		1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9, 1, 10,
+		1, 10, 1, 10, 1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1,
+		11, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 3, 12,
+		301, 8, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1,
+		14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 3, 14, 319, 8, 14,
+		1, 14, 1, 14, 1, 14, 3, 14, 324, 8, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1,
+		16, 1, 16, 1, 16, 1, 16, 1, 16, 3, 16, 335, 8, 16, 1, 16, 1, 16, 1, 16,
+		3, 16, 340, 8, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 3, 17, 348,
+		8, 17, 1, 17, 1, 17, 1, 17, 3, 17, 353, 8, 17, 1, 18, 1, 18, 1, 18, 1,
+		18, 1, 18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 3, 19, 367,
+		8, 19, 1, 19, 1, 19, 1, 19, 3, 19, 372, 8, 19, 1, 20, 1, 20, 1, 20, 1,
+		20, 1, 20, 1, 20, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 22, 1, 22,
+		1, 22, 1, 22, 1, 22, 1, 22, 3, 22, 392, 8, 22, 1, 22, 1, 22, 1, 22, 3,
+		22, 397, 8, 22, 1, 23, 1, 23, 1, 23, 1, 23, 1, 24, 1, 24, 1, 24, 1, 24,
+		1, 25, 1, 25, 1, 25, 1, 25, 1, 26, 1, 26, 1, 26, 1, 27, 1, 27, 1, 27, 1,
+		27, 1, 28, 1, 28, 1, 28, 1, 28, 1, 29, 1, 29, 1, 29, 1, 30, 1, 30, 1, 30,
+		1, 30, 1, 30, 1, 30, 3, 30, 431, 8, 30, 1, 30, 3, 30, 434, 8, 30, 1, 31,
+		1, 31, 1, 31, 1, 31, 3, 31, 440, 8, 31, 1, 31, 1, 31, 1, 31, 1, 31, 3,
+		31, 446, 8, 31, 1, 31, 3, 31, 449, 8, 31, 1, 32, 1, 32, 1, 32, 1, 32, 1,
+		33, 1, 33, 1, 33, 1, 33, 1, 33, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34,
+		1, 34, 1, 34, 1, 34, 3, 34, 469, 8, 34, 1, 34, 3, 34, 472, 8, 34, 1, 34,
+		1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 3,
+		34, 485, 8, 34, 1, 34, 3, 34, 488, 8, 34, 3, 34, 490, 8, 34, 1, 35, 1,
+		35, 1, 36, 1, 36, 1, 37, 1, 37, 1, 38, 1, 38, 1, 39, 1, 39, 1, 40, 1, 40,
+		1, 41, 1, 41, 1, 42, 3, 42, 507, 8, 42, 1, 42, 1, 42, 3, 42, 511, 8, 42,
+		1, 42, 3, 42, 514, 8, 42, 1, 42, 3, 42, 517, 8, 42, 1, 42, 3, 42, 520,
+		8, 42, 1, 42, 3, 42, 523, 8, 42, 1, 42, 3, 42, 526, 8, 42, 1, 42, 3, 42,
+		529, 8, 42, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 3, 43, 537, 8, 43,
+		1, 44, 1, 44, 1, 44, 1, 45, 1, 45, 1, 45, 5, 45, 545, 8, 45, 10, 45, 12,
+		45, 548, 9, 45, 1, 46, 1, 46, 3, 46, 552, 8, 46, 1, 47, 1, 47, 1, 47, 1,
+		48, 1, 48, 1, 48, 1, 48, 1, 49, 1, 49, 1, 49, 1, 49, 1, 50, 1, 50, 1, 50,
+		1, 50, 1, 51, 1, 51, 1, 51, 1, 51, 1, 52, 1, 52, 1, 52, 1, 52, 5, 52, 577,
+		8, 52, 10, 52, 12, 52, 580, 9, 52, 1, 52, 1, 52, 3, 52, 584, 8, 52, 1,
+		53, 1, 53, 1, 53, 1, 54, 1, 54, 1, 54, 1, 54, 1, 54, 1, 54, 1, 54, 1, 54,
+		3, 54, 597, 8, 54, 3, 54, 599, 8, 54, 1, 55, 1, 55, 1, 55, 1, 55, 1, 55,
+		1, 55, 1, 55, 1, 55, 1, 55, 1, 55, 1, 55, 1, 55, 1, 55, 1, 55, 3, 55, 615,
+		8, 55, 1, 55, 1, 55, 1, 55, 1, 55, 1, 55, 1, 55, 3, 55, 623, 8, 55, 1,
+		55, 1, 55, 1, 55, 1, 55, 3, 55, 629, 8, 55, 1, 55, 1, 55, 1, 55, 5, 55,
+		634, 8, 55, 10, 55, 12, 55, 637, 9, 55, 1, 56, 1, 56, 1, 56, 5, 56, 642,
+		8, 56, 10, 56, 12, 56, 645, 9, 56, 1, 57, 1, 57, 1, 57, 1, 57, 1, 57, 1,
+		57, 1, 58, 1, 58, 1, 58, 5, 58, 656, 8, 58, 10, 58, 12, 58, 659, 9, 58,
+		1, 59, 1, 59, 1, 59, 3, 59, 664, 8, 59, 1, 60, 1, 60, 1, 60, 1, 60, 3,
+		60, 670, 8, 60, 1, 61, 1, 61, 3, 61, 674, 8, 61, 1, 62, 1, 62, 1, 62, 3,
+		62, 679, 8, 62, 1, 62, 1, 62, 1, 63, 1, 63, 1, 63, 1, 63, 1, 63, 1, 63,
+		1, 63, 1, 63, 1, 63, 3, 63, 692, 8, 63, 1, 63, 1, 63, 1, 63, 1, 63, 3,
+		63, 698, 8, 63, 1, 64, 1, 64, 1, 64, 1, 64, 1, 64, 1, 64, 1, 64, 1, 64,
+		3, 64, 708, 8, 64, 1, 64, 3, 64, 711, 8, 64, 1, 65, 1, 65, 1, 65, 5, 65,
+		716, 8, 65, 10, 65, 12, 65, 719, 9, 65, 1, 66, 1, 66, 1, 66, 1, 66, 1,
+		66, 1, 66, 1, 66, 1, 66, 1, 66, 1, 66, 3, 66, 731, 8, 66, 1, 67, 1, 67,
+		1, 68, 1, 68, 1, 68, 1, 68, 1, 69, 1, 69, 5, 69, 741, 8, 69, 10, 69, 12,
+		69, 744, 9, 69, 1, 70, 1, 70, 1, 70, 5, 70, 749, 8, 70, 10, 70, 12, 70,
+		752, 9, 70, 1, 71, 1, 71, 1, 71, 1, 72, 1, 72, 1, 72, 1, 72, 1, 72, 1,
+		72, 3, 72, 763, 8, 72, 1, 72, 1, 72, 1, 72, 1, 72, 5, 72, 769, 8, 72, 10,
+		72, 12, 72, 772, 9, 72, 1, 73, 1, 73, 1, 74, 1, 74, 1, 75, 1, 75, 1, 75,
+		1, 75, 1, 76, 1, 76, 1, 76, 1, 76, 1, 76, 1, 76, 1, 76, 1, 76, 3, 76, 790,
+		8, 76, 1, 77, 1, 77, 1, 77, 1, 77, 1, 77, 1, 77, 1, 77, 1, 77, 1, 77, 3,
+		77, 801, 8, 77, 1, 77, 1, 77, 1, 77, 1, 77, 1, 77, 1, 77, 1, 77, 1, 77,
+		1, 77, 1, 77, 1, 77, 1, 77, 5, 77, 815, 8, 77, 10, 77, 12, 77, 818, 9,
+		77, 1, 78, 1, 78, 1, 79, 1, 79, 1, 79, 1, 80, 1, 80, 1, 81, 1, 81, 1, 81,
+		3, 81, 830, 8, 81, 1, 81, 1, 81, 1, 82, 1, 82, 1, 83, 1, 83, 1, 83, 5,
+		83, 839, 8, 83, 10, 83, 12, 83, 842, 9, 83, 1, 84, 1, 84, 1, 84, 3, 84,
+		847, 8, 84, 1, 85, 1, 85, 1, 85, 1, 85, 3, 85, 853, 8, 85, 1, 86, 1, 86,
+		3, 86, 857, 8, 86, 1, 86, 1, 86, 3, 86, 861, 8, 86, 1, 87, 1, 87, 1, 87,
+		1, 87, 1, 88, 1, 88, 1, 89, 1, 89, 1, 90, 1, 90, 1, 90, 1, 90, 5, 90, 875,
+		8, 90, 10, 90, 12, 90, 878, 9, 90, 1, 90, 1, 90, 1, 90, 1, 90, 3, 90, 884,
+		8, 90, 1, 91, 1, 91, 1, 91, 1, 91, 1, 92, 1, 92, 1, 92, 1, 92, 5, 92, 894,
+		8, 92, 10, 92, 12, 92, 897, 9, 92, 1, 92, 1, 92, 1, 92, 1, 92, 3, 92, 903,
+		8, 92, 1, 93, 1, 93, 1, 93, 1, 93, 1, 93, 1, 93, 1, 93, 1, 93, 3, 93, 913,
+		8, 93, 1, 94, 3, 94, 916, 8, 94, 1, 94, 1, 94, 1, 95, 3, 95, 921, 8, 95,
+		1, 95, 1, 95, 1, 96, 1, 96, 1, 96, 1, 97, 1, 97, 1, 97, 1, 98, 1, 98, 1,
+		98, 1, 99, 1, 99, 1, 100, 1, 100, 1, 101, 1, 101, 1, 102, 1, 102, 3, 102,
+		942, 8, 102, 1, 102, 1, 102, 1, 102, 3, 102, 947, 8, 102, 5, 102, 949,
+		8, 102, 10, 102, 12, 102, 952, 9, 102, 1, 103, 1, 103, 1, 103, 0, 3, 110,
+		144, 154, 104, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30,
+		32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 66,
+		68, 70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 94, 96, 98, 100, 102,
+		104, 106, 108, 110, 112, 114, 116, 118, 120, 122, 124, 126, 128, 130, 132,
+		134, 136, 138, 140, 142, 144, 146, 148, 150, 152, 154, 156, 158, 160, 162,
+		164, 166, 168, 170, 172, 174, 176, 178, 180, 182, 184, 186, 188, 190, 192,
+		194, 196, 198, 200, 202, 204, 206, 0, 11, 1, 0, 34, 36, 1, 0, 27, 28, 1,
+		0, 67, 68, 2, 0, 70, 71, 146, 147, 1, 0, 73, 74, 2, 0, 75, 75, 130, 130,
+		1, 0, 114, 120, 2, 0, 95, 107, 109, 113, 1, 0, 123, 129, 1, 0, 139, 140,
+		2, 0, 6, 24, 26, 120, 990, 0, 220, 1, 0, 0, 0, 2, 222, 1, 0, 0, 0, 4, 225,
+		1, 0, 0, 0, 6, 255, 1, 0, 0, 0, 8, 257, 1, 0, 0, 0, 10, 260, 1, 0, 0, 0,
+		12, 263, 1, 0, 0, 0, 14, 270, 1, 0, 0, 0, 16, 273, 1, 0, 0, 0, 18, 276,
+		1, 0, 0, 0, 20, 279, 1, 0, 0, 0, 22, 283, 1, 0, 0, 0, 24, 291, 1, 0, 0,
+		0, 26, 302, 1, 0, 0, 0, 28, 310, 1, 0, 0, 0, 30, 325, 1, 0, 0, 0, 32, 329,
+		1, 0, 0, 0, 34, 341, 1, 0, 0, 0, 36, 354, 1, 0, 0, 0, 38, 360, 1, 0, 0,
+		0, 40, 373, 1, 0, 0, 0, 42, 379, 1, 0, 0, 0, 44, 385, 1, 0, 0, 0, 46, 398,
+		1, 0, 0, 0, 48, 402, 1, 0, 0, 0, 50, 406, 1, 0, 0, 0, 52, 410, 1, 0, 0,
+		0, 54, 413, 1, 0, 0, 0, 56, 417, 1, 0, 0, 0, 58, 421, 1, 0, 0, 0, 60, 424,
+		1, 0, 0, 0, 62, 435, 1, 0, 0, 0, 64, 450, 1, 0, 0, 0, 66, 454, 1, 0, 0,
+		0, 68, 489, 1, 0, 0, 0, 70, 491, 1, 0, 0, 0, 72, 493, 1, 0, 0, 0, 74, 495,
+		1, 0, 0, 0, 76, 497, 1, 0, 0, 0, 78, 499, 1, 0, 0, 0, 80, 501, 1, 0, 0,
+		0, 82, 503, 1, 0, 0, 0, 84, 506, 1, 0, 0, 0, 86, 536, 1, 0, 0, 0, 88, 538,
+		1, 0, 0, 0, 90, 541, 1, 0, 0, 0, 92, 549, 1, 0, 0, 0, 94, 553, 1, 0, 0,
+		0, 96, 556, 1, 0, 0, 0, 98, 560, 1, 0, 0, 0, 100, 564, 1, 0, 0, 0, 102,
+		568, 1, 0, 0, 0, 104, 572, 1, 0, 0, 0, 106, 585, 1, 0, 0, 0, 108, 598,
+		1, 0, 0, 0, 110, 628, 1, 0, 0, 0, 112, 638, 1, 0, 0, 0, 114, 646, 1, 0,
+		0, 0, 116, 652, 1, 0, 0, 0, 118, 660, 1, 0, 0, 0, 120, 665, 1, 0, 0, 0,
+		122, 671, 1, 0, 0, 0, 124, 675, 1, 0, 0, 0, 126, 697, 1, 0, 0, 0, 128,
+		699, 1, 0, 0, 0, 130, 712, 1, 0, 0, 0, 132, 730, 1, 0, 0, 0, 134, 732,
+		1, 0, 0, 0, 136, 734, 1, 0, 0, 0, 138, 738, 1, 0, 0, 0, 140, 745, 1, 0,
+		0, 0, 142, 753, 1, 0, 0, 0, 144, 762, 1, 0, 0, 0, 146, 773, 1, 0, 0, 0,
+		148, 775, 1, 0, 0, 0, 150, 777, 1, 0, 0, 0, 152, 789, 1, 0, 0, 0, 154,
+		800, 1, 0, 0, 0, 156, 819, 1, 0, 0, 0, 158, 821, 1, 0, 0, 0, 160, 824,
+		1, 0, 0, 0, 162, 826, 1, 0, 0, 0, 164, 833, 1, 0, 0, 0, 166, 835, 1, 0,
+		0, 0, 168, 846, 1, 0, 0, 0, 170, 848, 1, 0, 0, 0, 172, 860, 1, 0, 0, 0,
+		174, 862, 1, 0, 0, 0, 176, 866, 1, 0, 0, 0, 178, 868, 1, 0, 0, 0, 180,
+		883, 1, 0, 0, 0, 182, 885, 1, 0, 0, 0, 184, 902, 1, 0, 0, 0, 186, 912,
+		1, 0, 0, 0, 188, 915, 1, 0, 0, 0, 190, 920, 1, 0, 0, 0, 192, 924, 1, 0,
+		0, 0, 194, 927, 1, 0, 0, 0, 196, 930, 1, 0, 0, 0, 198, 933, 1, 0, 0, 0,
+		200, 935, 1, 0, 0, 0, 202, 937, 1, 0, 0, 0, 204, 941, 1, 0, 0, 0, 206,
+		953, 1, 0, 0, 0, 208, 221, 3, 6, 3, 0, 209, 221, 3, 46, 23, 0, 210, 221,
+		3, 48, 24, 0, 211, 221, 3, 50, 25, 0, 212, 221, 3, 2, 1, 0, 213, 221, 3,
+		84, 42, 0, 214, 221, 3, 54, 27, 0, 215, 221, 3, 56, 28, 0, 216, 221, 3,
+		4, 2, 0, 217, 218, 3, 204, 102, 0, 218, 219, 5, 0, 0, 1, 219, 221, 1, 0,
+		0, 0, 220, 208, 1, 0, 0, 0, 220, 209, 1, 0, 0, 0, 220, 210, 1, 0, 0, 0,
+		220, 211, 1, 0, 0, 0, 220, 212, 1, 0, 0, 0, 220, 213, 1, 0, 0, 0, 220,
+		214, 1, 0, 0, 0, 220, 215, 1, 0, 0, 0, 220, 216, 1, 0, 0, 0, 220, 217,
+		1, 0, 0, 0, 221, 1, 1, 0, 0, 0, 222, 223, 5, 26, 0, 0, 223, 224, 3, 204,
+		102, 0, 224, 3, 1, 0, 0, 0, 225, 226, 5, 8, 0, 0, 226, 227, 5, 60, 0, 0,
+		227, 228, 3, 178, 89, 0, 228, 5, 1, 0, 0, 0, 229, 256, 3, 8, 4, 0, 230,
+		256, 3, 20, 10, 0, 231, 256, 3, 22, 11, 0, 232, 256, 3, 24, 12, 0, 233,
+		256, 3, 26, 13, 0, 234, 256, 3, 28, 14, 0, 235, 256, 3, 14, 7, 0, 236,
+		256, 3, 16, 8, 0, 237, 256, 3, 18, 9, 0, 238, 256, 3, 30, 15, 0, 239, 256,
+		3, 40, 20, 0, 240, 256, 3, 42, 21, 0, 241, 256, 3, 44, 22, 0, 242, 256,
+		3, 32, 16, 0, 243, 256, 3, 34, 17, 0, 244, 256, 3, 36, 18, 0, 245, 256,
+		3, 38, 19, 0, 246, 256, 3, 52, 26, 0, 247, 256, 3, 58, 29, 0, 248, 256,
+		3, 60, 30, 0, 249, 256, 3, 62, 31, 0, 250, 256, 3, 64, 32, 0, 251, 256,
+		3, 66, 33, 0, 252, 256, 3, 68, 34, 0, 253, 256, 3, 10, 5, 0, 254, 256,
+		3, 12, 6, 0, 255, 229, 1, 0, 0, 0, 255, 230, 1, 0, 0, 0, 255, 231, 1, 0,
+		0, 0, 255, 232, 1, 0, 0, 0, 255, 233, 1, 0, 0, 0, 255, 234, 1, 0, 0, 0,
+		255, 235, 1, 0, 0, 0, 255, 236, 1, 0, 0, 0, 255, 237, 1, 0, 0, 0, 255,
+		238, 1, 0, 0, 0, 255, 239, 1, 0, 0, 0, 255, 240, 1, 0, 0, 0, 255, 241,
+		1, 0, 0, 0, 255, 242, 1, 0, 0, 0, 255, 243, 1, 0, 0, 0, 255, 244, 1, 0,
+		0, 0, 255, 245, 1, 0, 0, 0, 255, 246, 1, 0, 0, 0, 255, 247, 1, 0, 0, 0,
+		255, 248, 1, 0, 0, 0, 255, 249, 1, 0, 0, 0, 255, 250, 1, 0, 0, 0, 255,
+		251, 1, 0, 0, 0, 255, 252, 1, 0, 0, 0, 255, 253, 1, 0, 0, 0, 255, 254,
+		1, 0, 0, 0, 256, 7, 1, 0, 0, 0, 257, 258, 5, 24, 0, 0, 258, 259, 5, 29,
+		0, 0, 259, 9, 1, 0, 0, 0, 260, 261, 5, 24, 0, 0, 261, 262, 5, 92, 0, 0,
+		262, 11, 1, 0, 0, 0, 263, 264, 5, 24, 0, 0, 264, 265, 5, 93, 0, 0, 265,
+		266, 5, 59, 0, 0, 266, 267, 5, 94, 0, 0, 267, 268, 5, 123, 0, 0, 268, 269,
+		3, 80, 40, 0, 269, 13, 1, 0, 0, 0, 270, 271, 5, 24, 0, 0, 271, 272, 5,
+		33, 0, 0, 272, 15, 1, 0, 0, 0, 273, 274, 5, 24, 0, 0, 274, 275, 5, 37,
+		0, 0, 275, 17, 1, 0, 0, 0, 276, 277, 5, 24, 0, 0, 277, 278, 5, 60, 0, 0,
+		278, 19, 1, 0, 0, 0, 279, 280, 5, 24, 0, 0, 280, 281, 5, 30, 0, 0, 281,
+		282, 5, 31, 0, 0, 282, 21, 1, 0, 0, 0, 283, 284, 5, 24, 0, 0, 284, 285,
+		5, 36, 0, 0, 285, 286, 5, 30, 0, 0, 286, 287, 5, 58, 0, 0, 287, 288, 3,
+		82, 41, 0, 288, 289, 5, 59, 0, 0, 289, 290, 3, 102, 51, 0, 290, 23, 1,
+		0, 0, 0, 291, 292, 5, 24, 0, 0, 292, 293, 5, 35, 0, 0, 293, 294, 5, 30,
+		0, 0, 294, 295, 5, 58, 0, 0, 295, 296, 3, 82, 41, 0, 296, 297, 5, 59, 0,
+		0, 297, 300, 3, 102, 51, 0, 298, 299, 5, 67, 0, 0, 299, 301, 3, 98, 49,
+		0, 300, 298, 1, 0, 0, 0, 300, 301, 1, 0, 0, 0, 301, 25, 1, 0, 0, 0, 302,
+		303, 5, 24, 0, 0, 303, 304, 5, 29, 0, 0, 304, 305, 5, 30, 0, 0, 305, 306,
+		5, 58, 0, 0, 306, 307, 3, 82, 41, 0, 307, 308, 5, 59, 0, 0, 308, 309, 3,
+		102, 51, 0, 309, 27, 1, 0, 0, 0, 310, 311, 5, 24, 0, 0, 311, 312, 5, 34,
+		0, 0, 312, 313, 5, 30, 0, 0, 313, 314, 5, 58, 0, 0, 314, 315, 3, 82, 41,
+		0, 315, 318, 5, 59, 0, 0, 316, 319, 3, 96, 48, 0, 317, 319, 3, 102, 51,
+		0, 318, 316, 1, 0, 0, 0, 318, 317, 1, 0, 0, 0, 319, 320, 1, 0, 0, 0, 320,
+		323, 5, 67, 0, 0, 321, 324, 3, 96, 48, 0, 322, 324, 3, 102, 51, 0, 323,
+		321, 1, 0, 0, 0, 323, 322, 1, 0, 0, 0, 324, 29, 1, 0, 0, 0, 325, 326, 5,
+		24, 0, 0, 326, 327, 7, 0, 0, 0, 327, 328, 5, 38, 0, 0, 328, 31, 1, 0, 0,
+		0, 329, 330, 5, 24, 0, 0, 330, 331, 5, 13, 0, 0, 331, 334, 5, 59, 0, 0,
+		332, 335, 3, 96, 48, 0, 333, 335, 3, 100, 50, 0, 334, 332, 1, 0, 0, 0,
+		334, 333, 1, 0, 0, 0, 335, 336, 1, 0, 0, 0, 336, 339, 5, 67, 0, 0, 337,
+		340, 3, 96, 48, 0, 338, 340, 3, 100, 50, 0, 339, 337, 1, 0, 0, 0, 339,
+		338, 1, 0, 0, 0, 340, 33, 1, 0, 0, 0, 341, 342, 5, 24, 0, 0, 342, 343,
+		5, 14, 0, 0, 343, 344, 5, 15, 0, 0, 344, 347, 5, 59, 0, 0, 345, 348, 3,
+		96, 48, 0, 346, 348, 3, 100, 50, 0, 347, 345, 1, 0, 0, 0, 347, 346, 1,
+		0, 0, 0, 348, 349, 1, 0, 0, 0, 349, 352, 5, 67, 0, 0, 350, 353, 3, 96,
+		48, 0, 351, 353, 3, 100, 50, 0, 352, 350, 1, 0, 0, 0, 352, 351, 1, 0, 0,
+		0, 353, 35, 1, 0, 0, 0, 354, 355, 5, 24, 0, 0, 355, 356, 5, 16, 0, 0, 356,
+		357, 5, 83, 0, 0, 357, 358, 5, 59, 0, 0, 358, 359, 3, 100, 50, 0, 359,
+		37, 1, 0, 0, 0, 360, 361, 5, 24, 0, 0, 361, 362, 5, 17, 0, 0, 362, 363,
+		5, 40, 0, 0, 363, 366, 5, 59, 0, 0, 364, 367, 3, 96, 48, 0, 365, 367, 3,
+		100, 50, 0, 366, 364, 1, 0, 0, 0, 366, 365, 1, 0, 0, 0, 367, 368, 1, 0,
+		0, 0, 368, 371, 5, 67, 0, 0, 369, 372, 3, 96, 48, 0, 370, 372, 3, 100,
+		50, 0, 371, 369, 1, 0, 0, 0, 371, 370, 1, 0, 0, 0, 372, 39, 1, 0, 0, 0,
+		373, 374, 5, 24, 0, 0, 374, 375, 5, 36, 0, 0, 375, 376, 5, 46, 0, 0, 376,
+		377, 5, 59, 0, 0, 377, 378, 3, 114, 57, 0, 378, 41, 1, 0, 0, 0, 379, 380,
+		5, 24, 0, 0, 380, 381, 5, 35, 0, 0, 381, 382, 5, 46, 0, 0, 382, 383, 5,
+		59, 0, 0, 383, 384, 3, 114, 57, 0, 384, 43, 1, 0, 0, 0, 385, 386, 5, 24,
+		0, 0, 386, 387, 5, 34, 0, 0, 387, 388, 5, 46, 0, 0, 388, 391, 5, 59, 0,
+		0, 389, 392, 3, 96, 48, 0, 390, 392, 3, 114, 57, 0, 391, 389, 1, 0, 0,
+		0, 391, 390, 1, 0, 0, 0, 392, 393, 1, 0, 0, 0, 393, 396, 5, 67, 0, 0, 394,
+		397, 3, 96, 48, 0, 395, 397, 3, 114, 57, 0, 396, 394, 1, 0, 0, 0, 396,
+		395, 1, 0, 0, 0, 397, 45, 1, 0, 0, 0, 398, 399, 5, 6, 0, 0, 399, 400, 5,
+		34, 0, 0, 400, 401, 3, 176, 88, 0, 401, 47, 1, 0, 0, 0, 402, 403, 5, 6,
+		0, 0, 403, 404, 5, 35, 0, 0, 404, 405, 3, 176, 88, 0, 405, 49, 1, 0, 0,
+		0, 406, 407, 5, 25, 0, 0, 407, 408, 5, 34, 0, 0, 408, 409, 3, 78, 39, 0,
+		409, 51, 1, 0, 0, 0, 410, 411, 5, 24, 0, 0, 411, 412, 5, 39, 0, 0, 412,
+		53, 1, 0, 0, 0, 413, 414, 5, 6, 0, 0, 414, 415, 5, 40, 0, 0, 415, 416,
+		3, 176, 88, 0, 416, 55, 1, 0, 0, 0, 417, 418, 5, 9, 0, 0, 418, 419, 5,
+		40, 0, 0, 419, 420, 3, 76, 38, 0, 420, 57, 1, 0, 0, 0, 421, 422, 5, 24,
+		0, 0, 422, 423, 5, 41, 0, 0, 423, 59, 1, 0, 0, 0, 424, 425, 5, 24, 0, 0,
+		425, 430, 5, 43, 0, 0, 426, 427, 5, 59, 0, 0, 427, 428, 5, 42, 0, 0, 428,
+		429, 5, 123, 0, 0, 429, 431, 3, 70, 35, 0, 430, 426, 1, 0, 0, 0, 430, 431,
+		1, 0, 0, 0, 431, 433, 1, 0, 0, 0, 432, 434, 3, 192, 96, 0, 433, 432, 1,
+		0, 0, 0, 433, 434, 1, 0, 0, 0, 434, 61, 1, 0, 0, 0, 435, 436, 5, 24, 0,
+		0, 436, 439, 5, 45, 0, 0, 437, 438, 5, 23, 0, 0, 438, 440, 3, 74, 37, 0,
+		439, 437, 1, 0, 0, 0, 439, 440, 1, 0, 0, 0, 440, 445, 1, 0, 0, 0, 441,
+		442, 5, 59, 0, 0, 442, 443, 5, 46, 0, 0, 443, 444, 5, 123, 0, 0, 444, 446,
+		3, 70, 35, 0, 445, 441, 1, 0, 0, 0, 445, 446, 1, 0, 0, 0, 446, 448, 1,
+		0, 0, 0, 447, 449, 3, 192, 96, 0, 448, 447, 1, 0, 0, 0, 448, 449, 1, 0,
+		0, 0, 449, 63, 1, 0, 0, 0, 450, 451, 5, 24, 0, 0, 451, 452, 5, 48, 0, 0,
+		452, 453, 3, 104, 52, 0, 453, 65, 1, 0, 0, 0, 454, 455, 5, 24, 0, 0, 455,
+		456, 5, 49, 0, 0, 456, 457, 5, 51, 0, 0, 457, 458, 3, 104, 52, 0, 458,
+		67, 1, 0, 0, 0, 459, 460, 5, 24, 0, 0, 460, 461, 5, 49, 0, 0, 461, 462,
+		5, 54, 0, 0, 462, 463, 3, 104, 52, 0, 463, 464, 5, 53, 0, 0, 464, 465,
+		5, 52, 0, 0, 465, 466, 5, 123, 0, 0, 466, 468, 3, 72, 36, 0, 467, 469,
+		3, 106, 53, 0, 468, 467, 1, 0, 0, 0, 468, 469, 1, 0, 0, 0, 469, 471, 1,
+		0, 0, 0, 470, 472, 3, 192, 96, 0, 471, 470, 1, 0, 0, 0, 471, 472, 1, 0,
+		0, 0, 472, 490, 1, 0, 0, 0, 473, 474, 5, 24, 0, 0, 474, 475, 5, 49, 0,
+		0, 475, 476, 5, 54, 0, 0, 476, 477, 5, 98, 0, 0, 477, 478, 5, 56, 0, 0,
+		478, 479, 3, 104, 52, 0, 479, 480, 5, 53, 0, 0, 480, 481, 5, 52, 0, 0,
+		481, 482, 5, 123, 0, 0, 482, 484, 3, 72, 36, 0, 483, 485, 3, 106, 53, 0,
+		484, 483, 1, 0, 0, 0, 484, 485, 1, 0, 0, 0, 485, 487, 1, 0, 0, 0, 486,
+		488, 3, 194, 97, 0, 487, 486, 1, 0, 0, 0, 487, 488, 1, 0, 0, 0, 488, 490,
+		1, 0, 0, 0, 489, 459, 1, 0, 0, 0, 489, 473, 1, 0, 0, 0, 490, 69, 1, 0,
+		0, 0, 491, 492, 3, 204, 102, 0, 492, 71, 1, 0, 0, 0, 493, 494, 3, 204,
+		102, 0, 494, 73, 1, 0, 0, 0, 495, 496, 3, 204, 102, 0, 496, 75, 1, 0, 0,
+		0, 497, 498, 3, 204, 102, 0, 498, 77, 1, 0, 0, 0, 499, 500, 3, 204, 102,
+		0, 500, 79, 1, 0, 0, 0, 501, 502, 3, 204, 102, 0, 502, 81, 1, 0, 0, 0,
+		503, 504, 7, 1, 0, 0, 504, 83, 1, 0, 0, 0, 505, 507, 5, 63, 0, 0, 506,
+		505, 1, 0, 0, 0, 506, 507, 1, 0, 0, 0, 507, 508, 1, 0, 0, 0, 508, 510,
+		3, 86, 43, 0, 509, 511, 3, 106, 53, 0, 510, 509, 1, 0, 0, 0, 510, 511,
+		1, 0, 0, 0, 511, 513, 1, 0, 0, 0, 512, 514, 3, 126, 63, 0, 513, 512, 1,
+		0, 0, 0, 513, 514, 1, 0, 0, 0, 514, 516, 1, 0, 0, 0, 515, 517, 3, 128,
+		64, 0, 516, 515, 1, 0, 0, 0, 516, 517, 1, 0, 0, 0, 517, 519, 1, 0, 0, 0,
+		518, 520, 3, 136, 68, 0, 519, 518, 1, 0, 0, 0, 519, 520, 1, 0, 0, 0, 520,
+		522, 1, 0, 0, 0, 521, 523, 3, 192, 96, 0, 522, 521, 1, 0, 0, 0, 522, 523,
+		1, 0, 0, 0, 523, 525, 1, 0, 0, 0, 524, 526, 3, 196, 98, 0, 525, 524, 1,
+		0, 0, 0, 525, 526, 1, 0, 0, 0, 526, 528, 1, 0, 0, 0, 527, 529, 5, 64, 0,
+		0, 528, 527, 1, 0, 0, 0, 528, 529, 1, 0, 0, 0, 529, 85, 1, 0, 0, 0, 530,
+		531, 3, 88, 44, 0, 531, 532, 3, 104, 52, 0, 532, 537, 1, 0, 0, 0, 533,
+		534, 3, 104, 52, 0, 534, 535, 3, 88, 44, 0, 535, 537, 1, 0, 0, 0, 536,
+		530, 1, 0, 0, 0, 536, 533, 1, 0, 0, 0, 537, 87, 1, 0, 0, 0, 538, 539, 5,
+		65, 0, 0, 539, 540, 3, 90, 45, 0, 540, 89, 1, 0, 0, 0, 541, 546, 3, 92,
+		46, 0, 542, 543, 5, 132, 0, 0, 543, 545, 3, 92, 46, 0, 544, 542, 1, 0,
+		0, 0, 545, 548, 1, 0, 0, 0, 546, 544, 1, 0, 0, 0, 546, 547, 1, 0, 0, 0,
+		547, 91, 1, 0, 0, 0, 548, 546, 1, 0, 0, 0, 549, 551, 3, 154, 77, 0, 550,
+		552, 3, 94, 47, 0, 551, 550, 1, 0, 0, 0, 551, 552, 1, 0, 0, 0, 552, 93,
+		1, 0, 0, 0, 553, 554, 5, 66, 0, 0, 554, 555, 3, 204, 102, 0, 555, 95, 1,
+		0, 0, 0, 556, 557, 5, 34, 0, 0, 557, 558, 5, 123, 0, 0, 558, 559, 3, 204,
+		102, 0, 559, 97, 1, 0, 0, 0, 560, 561, 5, 35, 0, 0, 561, 562, 5, 123, 0,
+		0, 562, 563, 3, 204, 102, 0, 563, 99, 1, 0, 0, 0, 564, 565, 5, 40, 0, 0,
+		565, 566, 5, 123, 0, 0, 566, 567, 3, 204, 102, 0, 567, 101, 1, 0, 0, 0,
+		568, 569, 5, 32, 0, 0, 569, 570, 5, 123, 0, 0, 570, 571, 3, 204, 102, 0,
+		571, 103, 1, 0, 0, 0, 572, 573, 5, 58, 0, 0, 573, 578, 3, 198, 99, 0, 574,
+		575, 5, 132, 0, 0, 575, 577, 3, 198, 99, 0, 576, 574, 1, 0, 0, 0, 577,
+		580, 1, 0, 0, 0, 578, 576, 1, 0, 0, 0, 578, 579, 1, 0, 0, 0, 579, 583,
+		1, 0, 0, 0, 580, 578, 1, 0, 0, 0, 581, 582, 5, 23, 0, 0, 582, 584, 3, 74,
+		37, 0, 583, 581, 1, 0, 0, 0, 583, 584, 1, 0, 0, 0, 584, 105, 1, 0, 0, 0,
+		585, 586, 5, 59, 0, 0, 586, 587, 3, 108, 54, 0, 587, 107, 1, 0, 0, 0, 588,
+		599, 3, 110, 55, 0, 589, 590, 3, 110, 55, 0, 590, 591, 5, 67, 0, 0, 591,
+		592, 3, 118, 59, 0, 592, 599, 1, 0, 0, 0, 593, 596, 3, 118, 59, 0, 594,
+		595, 5, 67, 0, 0, 595, 597, 3, 110, 55, 0, 596, 594, 1, 0, 0, 0, 596, 597,
+		1, 0, 0, 0, 597, 599, 1, 0, 0, 0, 598, 588, 1, 0, 0, 0, 598, 589, 1, 0,
+		0, 0, 598, 593, 1, 0, 0, 0, 599, 109, 1, 0, 0, 0, 600, 601, 6, 55, -1,
+		0, 601, 602, 5, 137, 0, 0, 602, 603, 3, 110, 55, 0, 603, 604, 5, 138, 0,
+		0, 604, 629, 1, 0, 0, 0, 605, 614, 3, 200, 100, 0, 606, 615, 5, 123, 0,
+		0, 607, 615, 5, 75, 0, 0, 608, 609, 5, 76, 0, 0, 609, 615, 5, 75, 0, 0,
+		610, 615, 5, 130, 0, 0, 611, 615, 5, 131, 0, 0, 612, 615, 5, 124, 0, 0,
+		613, 615, 5, 125, 0, 0, 614, 606, 1, 0, 0, 0, 614, 607, 1, 0, 0, 0, 614,
+		608, 1, 0, 0, 0, 614, 610, 1, 0, 0, 0, 614, 611, 1, 0, 0, 0, 614, 612,
+		1, 0, 0, 0, 614, 613, 1, 0, 0, 0, 615, 616, 1, 0, 0, 0, 616, 617, 3, 202,
+		101, 0, 617, 629, 1, 0, 0, 0, 618, 622, 3, 200, 100, 0, 619, 623, 5, 86,
+		0, 0, 620, 621, 5, 76, 0, 0, 621, 623, 5, 86, 0, 0, 622, 619, 1, 0, 0,
+		0, 622, 620, 1, 0, 0, 0, 623, 624, 1, 0, 0, 0, 624, 625, 5, 137, 0, 0,
+		625, 626, 3, 112, 56, 0, 626, 627, 5, 138, 0, 0, 627, 629, 1, 0, 0, 0,
+		628, 600, 1, 0, 0, 0, 628, 605, 1, 0, 0, 0, 628, 618, 1, 0, 0, 0, 629,
+		635, 1, 0, 0, 0, 630, 631, 10, 1, 0, 0, 631, 632, 7, 2, 0, 0, 632, 634,
+		3, 110, 55, 2, 633, 630, 1, 0, 0, 0, 634, 637, 1, 0, 0, 0, 635, 633, 1,
+		0, 0, 0, 635, 636, 1, 0, 0, 0, 636, 111, 1, 0, 0, 0, 637, 635, 1, 0, 0,
+		0, 638, 643, 3, 202, 101, 0, 639, 640, 5, 132, 0, 0, 640, 642, 3, 202,
+		101, 0, 641, 639, 1, 0, 0, 0, 642, 645, 1, 0, 0, 0, 643, 641, 1, 0, 0,
+		0, 643, 644, 1, 0, 0, 0, 644, 113, 1, 0, 0, 0, 645, 643, 1, 0, 0, 0, 646,
+		647, 5, 46, 0, 0, 647, 648, 5, 86, 0, 0, 648, 649, 5, 137, 0, 0, 649, 650,
+		3, 116, 58, 0, 650, 651, 5, 138, 0, 0, 651, 115, 1, 0, 0, 0, 652, 657,
+		3, 204, 102, 0, 653, 654, 5, 132, 0, 0, 654, 656, 3, 204, 102, 0, 655,
+		653, 1, 0, 0, 0, 656, 659, 1, 0, 0, 0, 657, 655, 1, 0, 0, 0, 657, 658,
+		1, 0, 0, 0, 658, 117, 1, 0, 0, 0, 659, 657, 1, 0, 0, 0, 660, 663, 3, 120,
+		60, 0, 661, 662, 5, 67, 0, 0, 662, 664, 3, 120, 60, 0, 663, 661, 1, 0,
+		0, 0, 663, 664, 1, 0, 0, 0, 664, 119, 1, 0, 0, 0, 665, 666, 5, 84, 0, 0,
+		666, 669, 3, 152, 76, 0, 667, 670, 3, 122, 61, 0, 668, 670, 3, 204, 102,
+		0, 669, 667, 1, 0, 0, 0, 669, 668, 1, 0, 0, 0, 670, 121, 1, 0, 0, 0, 671,
+		673, 3, 124, 62, 0, 672, 674, 3, 158, 79, 0, 673, 672, 1, 0, 0, 0, 673,
+		674, 1, 0, 0, 0, 674, 123, 1, 0, 0, 0, 675, 676, 5, 85, 0, 0, 676, 678,
+		5, 137, 0, 0, 677, 679, 3, 166, 83, 0, 678, 677, 1, 0, 0, 0, 678, 679,
+		1, 0, 0, 0, 679, 680, 1, 0, 0, 0, 680, 681, 5, 138, 0, 0, 681, 125, 1,
+		0, 0, 0, 682, 683, 5, 99, 0, 0, 683, 698, 3, 158, 79, 0, 684, 685, 5, 87,
+		0, 0, 685, 686, 3, 158, 79, 0, 686, 691, 5, 89, 0, 0, 687, 688, 5, 88,
+		0, 0, 688, 689, 3, 158, 79, 0, 689, 690, 5, 89, 0, 0, 690, 692, 1, 0, 0,
+		0, 691, 687, 1, 0, 0, 0, 691, 692, 1, 0, 0, 0, 692, 698, 1, 0, 0, 0, 693,
+		694, 5, 88, 0, 0, 694, 695, 3, 158, 79, 0, 695, 696, 5, 89, 0, 0, 696,
+		698, 1, 0, 0, 0, 697, 682, 1, 0, 0, 0, 697, 684, 1, 0, 0, 0, 697, 693,
+		1, 0, 0, 0, 698, 127, 1, 0, 0, 0, 699, 700, 5, 79, 0, 0, 700, 701, 5, 81,
+		0, 0, 701, 707, 3, 130, 65, 0, 702, 703, 5, 69, 0, 0, 703, 704, 5, 137,
+		0, 0, 704, 705, 3, 134, 67, 0, 705, 706, 5, 138, 0, 0, 706, 708, 1, 0,
+		0, 0, 707, 702, 1, 0, 0, 0, 707, 708, 1, 0, 0, 0, 708, 710, 1, 0, 0, 0,
+		709, 711, 3, 142, 71, 0, 710, 709, 1, 0, 0, 0, 710, 711, 1, 0, 0, 0, 711,
+		129, 1, 0, 0, 0, 712, 717, 3, 132, 66, 0, 713, 714, 5, 132, 0, 0, 714,
+		716, 3, 132, 66, 0, 715, 713, 1, 0, 0, 0, 716, 719, 1, 0, 0, 0, 717, 715,
+		1, 0, 0, 0, 717, 718, 1, 0, 0, 0, 718, 131, 1, 0, 0, 0, 719, 717, 1, 0,
+		0, 0, 720, 731, 3, 204, 102, 0, 721, 731, 5, 142, 0, 0, 722, 723, 5, 84,
+		0, 0, 723, 724, 5, 137, 0, 0, 724, 725, 3, 158, 79, 0, 725, 726, 5, 138,
+		0, 0, 726, 731, 1, 0, 0, 0, 727, 728, 5, 84, 0, 0, 728, 729, 5, 137, 0,
+		0, 729, 731, 5, 138, 0, 0, 730, 720, 1, 0, 0, 0, 730, 721, 1, 0, 0, 0,
+		730, 722, 1, 0, 0, 0, 730, 727, 1, 0, 0, 0, 731, 133, 1, 0, 0, 0, 732,
+		733, 7, 3, 0, 0, 733, 135, 1, 0, 0, 0, 734, 735, 5, 72, 0, 0, 735, 736,
+		5, 81, 0, 0, 736, 737, 3, 140, 70, 0, 737, 137, 1, 0, 0, 0, 738, 742, 3,
+		154, 77, 0, 739, 741, 7, 4, 0, 0, 740, 739, 1, 0, 0, 0, 741, 744, 1, 0,
+		0, 0, 742, 740, 1, 0, 0, 0, 742, 743, 1, 0, 0, 0, 743, 139, 1, 0, 0, 0,
+		744, 742, 1, 0, 0, 0, 745, 750, 3, 138, 69, 0, 746, 747, 5, 132, 0, 0,
+		747, 749, 3, 138, 69, 0, 748, 746, 1, 0, 0, 0, 749, 752, 1, 0, 0, 0, 750,
+		748, 1, 0, 0, 0, 750, 751, 1, 0, 0, 0, 751, 141, 1, 0, 0, 0, 752, 750,
+		1, 0, 0, 0, 753, 754, 5, 80, 0, 0, 754, 755, 3, 144, 72, 0, 755, 143, 1,
+		0, 0, 0, 756, 757, 6, 72, -1, 0, 757, 758, 5, 137, 0, 0, 758, 759, 3, 144,
+		72, 0, 759, 760, 5, 138, 0, 0, 760, 763, 1, 0, 0, 0, 761, 763, 3, 148,
+		74, 0, 762, 756, 1, 0, 0, 0, 762, 761, 1, 0, 0, 0, 763, 770, 1, 0, 0, 0,
+		764, 765, 10, 2, 0, 0, 765, 766, 3, 146, 73, 0, 766, 767, 3, 144, 72, 3,
+		767, 769, 1, 0, 0, 0, 768, 764, 1, 0, 0, 0, 769, 772, 1, 0, 0, 0, 770,
+		768, 1, 0, 0, 0, 770, 771, 1, 0, 0, 0, 771, 145, 1, 0, 0, 0, 772, 770,
+		1, 0, 0, 0, 773, 774, 7, 2, 0, 0, 774, 147, 1, 0, 0, 0, 775, 776, 3, 150,
+		75, 0, 776, 149, 1, 0, 0, 0, 777, 778, 3, 154, 77, 0, 778, 779, 3, 152,
+		76, 0, 779, 780, 3, 154, 77, 0, 780, 151, 1, 0, 0, 0, 781, 790, 5, 123,
+		0, 0, 782, 790, 5, 124, 0, 0, 783, 790, 5, 125, 0, 0, 784, 790, 5, 128,
+		0, 0, 785, 790, 5, 129, 0, 0, 786, 790, 5, 126, 0, 0, 787, 790, 5, 127,
+		0, 0, 788, 790, 7, 5, 0, 0, 789, 781, 1, 0, 0, 0, 789, 782, 1, 0, 0, 0,
+		789, 783, 1, 0, 0, 0, 789, 784, 1, 0, 0, 0, 789, 785, 1, 0, 0, 0, 789,
+		786, 1, 0, 0, 0, 789, 787, 1, 0, 0, 0, 789, 788, 1, 0, 0, 0, 790, 153,
+		1, 0, 0, 0, 791, 792, 6, 77, -1, 0, 792, 793, 5, 137, 0, 0, 793, 794, 3,
+		154, 77, 0, 794, 795, 5, 138, 0, 0, 795, 801, 1, 0, 0, 0, 796, 801, 3,
+		162, 81, 0, 797, 801, 3, 172, 86, 0, 798, 801, 3, 158, 79, 0, 799, 801,
+		3, 156, 78, 0, 800, 791, 1, 0, 0, 0, 800, 796, 1, 0, 0, 0, 800, 797, 1,
+		0, 0, 0, 800, 798, 1, 0, 0, 0, 800, 799, 1, 0, 0, 0, 801, 816, 1, 0, 0,
+		0, 802, 803, 10, 9, 0, 0, 803, 804, 5, 142, 0, 0, 804, 815, 3, 154, 77,
+		10, 805, 806, 10, 8, 0, 0, 806, 807, 5, 141, 0, 0, 807, 815, 3, 154, 77,
+		9, 808, 809, 10, 7, 0, 0, 809, 810, 5, 139, 0, 0, 810, 815, 3, 154, 77,
+		8, 811, 812, 10, 6, 0, 0, 812, 813, 5, 140, 0, 0, 813, 815, 3, 154, 77,
+		7, 814, 802, 1, 0, 0, 0, 814, 805, 1, 0, 0, 0, 814, 808, 1, 0, 0, 0, 814,
+		811, 1, 0, 0, 0, 815, 818, 1, 0, 0, 0, 816, 814, 1, 0, 0, 0, 816, 817,
+		1, 0, 0, 0, 817, 155, 1, 0, 0, 0, 818, 816, 1, 0, 0, 0, 819, 820, 5, 142,
+		0, 0, 820, 157, 1, 0, 0, 0, 821, 822, 3, 188, 94, 0, 822, 823, 3, 160,
+		80, 0, 823, 159, 1, 0, 0, 0, 824, 825, 7, 6, 0, 0, 825, 161, 1, 0, 0, 0,
+		826, 827, 3, 164, 82, 0, 827, 829, 5, 137, 0, 0, 828, 830, 3, 166, 83,
+		0, 829, 828, 1, 0, 0, 0, 829, 830, 1, 0, 0, 0, 830, 831, 1, 0, 0, 0, 831,
+		832, 5, 138, 0, 0, 832, 163, 1, 0, 0, 0, 833, 834, 7, 7, 0, 0, 834, 165,
+		1, 0, 0, 0, 835, 840, 3, 168, 84, 0, 836, 837, 5, 132, 0, 0, 837, 839,
+		3, 168, 84, 0, 838, 836, 1, 0, 0, 0, 839, 842, 1, 0, 0, 0, 840, 838, 1,
+		0, 0, 0, 840, 841, 1, 0, 0, 0, 841, 167, 1, 0, 0, 0, 842, 840, 1, 0, 0,
+		0, 843, 847, 3, 170, 85, 0, 844, 847, 3, 154, 77, 0, 845, 847, 3, 110,
+		55, 0, 846, 843, 1, 0, 0, 0, 846, 844, 1, 0, 0, 0, 846, 845, 1, 0, 0, 0,
+		847, 169, 1, 0, 0, 0, 848, 849, 3, 204, 102, 0, 849, 852, 7, 8, 0, 0, 850,
+		853, 3, 190, 95, 0, 851, 853, 3, 188, 94, 0, 852, 850, 1, 0, 0, 0, 852,
+		851, 1, 0, 0, 0, 853, 171, 1, 0, 0, 0, 854, 856, 3, 204, 102, 0, 855, 857,
+		3, 174, 87, 0, 856, 855, 1, 0, 0, 0, 856, 857, 1, 0, 0, 0, 857, 861, 1,
+		0, 0, 0, 858, 861, 3, 190, 95, 0, 859, 861, 3, 188, 94, 0, 860, 854, 1,
+		0, 0, 0, 860, 858, 1, 0, 0, 0, 860, 859, 1, 0, 0, 0, 861, 173, 1, 0, 0,
+		0, 862, 863, 5, 135, 0, 0, 863, 864, 3, 110, 55, 0, 864, 865, 5, 136, 0,
+		0, 865, 175, 1, 0, 0, 0, 866, 867, 3, 186, 93, 0, 867, 177, 1, 0, 0, 0,
+		868, 869, 3, 204, 102, 0, 869, 179, 1, 0, 0, 0, 870, 871, 5, 133, 0, 0,
+		871, 876, 3, 182, 91, 0, 872, 873, 5, 132, 0, 0, 873, 875, 3, 182, 91,
+		0, 874, 872, 1, 0, 0, 0, 875, 878, 1, 0, 0, 0, 876, 874, 1, 0, 0, 0, 876,
+		877, 1, 0, 0, 0, 877, 879, 1, 0, 0, 0, 878, 876, 1, 0, 0, 0, 879, 880,
+		5, 134, 0, 0, 880, 884, 1, 0, 0, 0, 881, 882, 5, 133, 0, 0, 882, 884, 5,
+		134, 0, 0, 883, 870, 1, 0, 0, 0, 883, 881, 1, 0, 0, 0, 884, 181, 1, 0,
+		0, 0, 885, 886, 5, 4, 0, 0, 886, 887, 5, 122, 0, 0, 887, 888, 3, 186, 93,
+		0, 888, 183, 1, 0, 0, 0, 889, 890, 5, 135, 0, 0, 890, 895, 3, 186, 93,
+		0, 891, 892, 5, 132, 0, 0, 892, 894, 3, 186, 93, 0, 893, 891, 1, 0, 0,
+		0, 894, 897, 1, 0, 0, 0, 895, 893, 1, 0, 0, 0, 895, 896, 1, 0, 0, 0, 896,
+		898, 1, 0, 0, 0, 897, 895, 1, 0, 0, 0, 898, 899, 5, 136, 0, 0, 899, 903,
+		1, 0, 0, 0, 900, 901, 5, 135, 0, 0, 901, 903, 5, 136, 0, 0, 902, 889, 1,
+		0, 0, 0, 902, 900, 1, 0, 0, 0, 903, 185, 1, 0, 0, 0, 904, 913, 5, 4, 0,
+		0, 905, 913, 3, 188, 94, 0, 906, 913, 3, 190, 95, 0, 907, 913, 3, 180,
+		90, 0, 908, 913, 3, 184, 92, 0, 909, 913, 5, 1, 0, 0, 910, 913, 5, 2, 0,
+		0, 911, 913, 5, 3, 0, 0, 912, 904, 1, 0, 0, 0, 912, 905, 1, 0, 0, 0, 912,
+		906, 1, 0, 0, 0, 912, 907, 1, 0, 0, 0, 912, 908, 1, 0, 0, 0, 912, 909,
+		1, 0, 0, 0, 912, 910, 1, 0, 0, 0, 912, 911, 1, 0, 0, 0, 913, 187, 1, 0,
+		0, 0, 914, 916, 7, 9, 0, 0, 915, 914, 1, 0, 0, 0, 915, 916, 1, 0, 0, 0,
+		916, 917, 1, 0, 0, 0, 917, 918, 5, 146, 0, 0, 918, 189, 1, 0, 0, 0, 919,
+		921, 7, 9, 0, 0, 920, 919, 1, 0, 0, 0, 920, 921, 1, 0, 0, 0, 921, 922,
+		1, 0, 0, 0, 922, 923, 5, 147, 0, 0, 923, 191, 1, 0, 0, 0, 924, 925, 5,
+		60, 0, 0, 925, 926, 5, 146, 0, 0, 926, 193, 1, 0, 0, 0, 927, 928, 5, 57,
+		0, 0, 928, 929, 5, 146, 0, 0, 929, 195, 1, 0, 0, 0, 930, 931, 5, 108, 0,
+		0, 931, 932, 5, 146, 0, 0, 932, 197, 1, 0, 0, 0, 933, 934, 3, 204, 102,
+		0, 934, 199, 1, 0, 0, 0, 935, 936, 3, 204, 102, 0, 936, 201, 1, 0, 0, 0,
+		937, 938, 3, 204, 102, 0, 938, 203, 1, 0, 0, 0, 939, 942, 5, 145, 0, 0,
+		940, 942, 3, 206, 103, 0, 941, 939, 1, 0, 0, 0, 941, 940, 1, 0, 0, 0, 942,
+		950, 1, 0, 0, 0, 943, 946, 5, 121, 0, 0, 944, 947, 5, 145, 0, 0, 945, 947,
+		3, 206, 103, 0, 946, 944, 1, 0, 0, 0, 946, 945, 1, 0, 0, 0, 947, 949, 1,
+		0, 0, 0, 948, 943, 1, 0, 0, 0, 949, 952, 1, 0, 0, 0, 950, 948, 1, 0, 0,
+		0, 950, 951, 1, 0, 0, 0, 951, 205, 1, 0, 0, 0, 952, 950, 1, 0, 0, 0, 953,
+		954, 7, 10, 0, 0, 954, 207, 1, 0, 0, 0, 78, 220, 255, 300, 318, 323, 334,
+		339, 347, 352, 366, 371, 391, 396, 430, 433, 439, 445, 448, 468, 471, 484,
+		487, 489, 506, 510, 513, 516, 519, 522, 525, 528, 536, 546, 551, 578, 583,
+		596, 598, 614, 622, 628, 635, 643, 657, 663, 669, 673, 678, 691, 697, 707,
+		710, 717, 730, 742, 750, 762, 770, 789, 800, 814, 816, 829, 840, 846, 852,
+		856, 860, 876, 883, 895, 902, 912, 915, 920, 941, 946, 950,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -522,137 +573,154 @@ func NewSQLParser(input antlr.TokenStream) *SQLParser {
 
 // SQLParser tokens.
 const (
-	SQLParserEOF             = antlr.TokenEOF
-	SQLParserT__0            = 1
-	SQLParserT__1            = 2
-	SQLParserT__2            = 3
-	SQLParserSTRING          = 4
-	SQLParserWS              = 5
-	SQLParserT_CREATE        = 6
-	SQLParserT_UPDATE        = 7
-	SQLParserT_SET           = 8
-	SQLParserT_DROP          = 9
-	SQLParserT_INTERVAL      = 10
-	SQLParserT_INTERVAL_NAME = 11
-	SQLParserT_SHARD         = 12
-	SQLParserT_REPLICATION   = 13
-	SQLParserT_MEMORY        = 14
-	SQLParserT_TTL           = 15
-	SQLParserT_META_TTL      = 16
-	SQLParserT_PAST_TTL      = 17
-	SQLParserT_FUTURE_TTL    = 18
-	SQLParserT_KILL          = 19
-	SQLParserT_ON            = 20
-	SQLParserT_SHOW          = 21
-	SQLParserT_RECOVER       = 22
-	SQLParserT_USE           = 23
-	SQLParserT_STATE_REPO    = 24
-	SQLParserT_STATE_MACHINE = 25
-	SQLParserT_MASTER        = 26
-	SQLParserT_METADATA      = 27
-	SQLParserT_TYPES         = 28
-	SQLParserT_TYPE          = 29
-	SQLParserT_STORAGES      = 30
-	SQLParserT_STORAGE       = 31
-	SQLParserT_BROKER        = 32
-	SQLParserT_ROOT          = 33
-	SQLParserT_BROKERS       = 34
-	SQLParserT_ALIVE         = 35
-	SQLParserT_SCHEMAS       = 36
-	SQLParserT_DATASBAE      = 37
-	SQLParserT_DATASBAES     = 38
-	SQLParserT_NAMESPACE     = 39
-	SQLParserT_NAMESPACES    = 40
-	SQLParserT_NODE          = 41
-	SQLParserT_METRICS       = 42
-	SQLParserT_METRIC        = 43
-	SQLParserT_FIELD         = 44
-	SQLParserT_FIELDS        = 45
-	SQLParserT_TAG           = 46
-	SQLParserT_INFO          = 47
-	SQLParserT_KEYS          = 48
-	SQLParserT_KEY           = 49
-	SQLParserT_WITH          = 50
-	SQLParserT_VALUES        = 51
-	SQLParserT_VALUE         = 52
-	SQLParserT_FROM          = 53
-	SQLParserT_WHERE         = 54
-	SQLParserT_LIMIT         = 55
-	SQLParserT_QUERIES       = 56
-	SQLParserT_QUERY         = 57
-	SQLParserT_EXPLAIN       = 58
-	SQLParserT_WITH_VALUE    = 59
-	SQLParserT_SELECT        = 60
-	SQLParserT_AS            = 61
-	SQLParserT_AND           = 62
-	SQLParserT_OR            = 63
-	SQLParserT_FILL          = 64
-	SQLParserT_NULL          = 65
-	SQLParserT_PREVIOUS      = 66
-	SQLParserT_ORDER         = 67
-	SQLParserT_ASC           = 68
-	SQLParserT_DESC          = 69
-	SQLParserT_LIKE          = 70
-	SQLParserT_NOT           = 71
-	SQLParserT_BETWEEN       = 72
-	SQLParserT_IS            = 73
-	SQLParserT_GROUP         = 74
-	SQLParserT_HAVING        = 75
-	SQLParserT_BY            = 76
-	SQLParserT_FOR           = 77
-	SQLParserT_STATS         = 78
-	SQLParserT_TIME          = 79
-	SQLParserT_NOW           = 80
-	SQLParserT_IN            = 81
-	SQLParserT_LOG           = 82
-	SQLParserT_PROFILE       = 83
-	SQLParserT_REQUESTS      = 84
-	SQLParserT_REQUEST       = 85
-	SQLParserT_ID            = 86
-	SQLParserT_SUM           = 87
-	SQLParserT_MIN           = 88
-	SQLParserT_MAX           = 89
-	SQLParserT_COUNT         = 90
-	SQLParserT_LAST          = 91
-	SQLParserT_FIRST         = 92
-	SQLParserT_AVG           = 93
-	SQLParserT_STDDEV        = 94
-	SQLParserT_QUANTILE      = 95
-	SQLParserT_RATE          = 96
-	SQLParserT_SECOND        = 97
-	SQLParserT_MINUTE        = 98
-	SQLParserT_HOUR          = 99
-	SQLParserT_DAY           = 100
-	SQLParserT_WEEK          = 101
-	SQLParserT_MONTH         = 102
-	SQLParserT_YEAR          = 103
-	SQLParserT_DOT           = 104
-	SQLParserT_COLON         = 105
-	SQLParserT_EQUAL         = 106
-	SQLParserT_NOTEQUAL      = 107
-	SQLParserT_NOTEQUAL2     = 108
-	SQLParserT_GREATER       = 109
-	SQLParserT_GREATEREQUAL  = 110
-	SQLParserT_LESS          = 111
-	SQLParserT_LESSEQUAL     = 112
-	SQLParserT_REGEXP        = 113
-	SQLParserT_NEQREGEXP     = 114
-	SQLParserT_COMMA         = 115
-	SQLParserT_OPEN_B        = 116
-	SQLParserT_CLOSE_B       = 117
-	SQLParserT_OPEN_SB       = 118
-	SQLParserT_CLOSE_SB      = 119
-	SQLParserT_OPEN_P        = 120
-	SQLParserT_CLOSE_P       = 121
-	SQLParserT_ADD           = 122
-	SQLParserT_SUB           = 123
-	SQLParserT_DIV           = 124
-	SQLParserT_MUL           = 125
-	SQLParserT_MOD           = 126
-	SQLParserT_UNDERLINE     = 127
-	SQLParserL_ID            = 128
-	SQLParserL_INT           = 129
-	SQLParserL_DEC           = 130
+	SQLParserEOF                  = antlr.TokenEOF
+	SQLParserT__0                 = 1
+	SQLParserT__1                 = 2
+	SQLParserT__2                 = 3
+	SQLParserSTRING               = 4
+	SQLParserWS                   = 5
+	SQLParserT_CREATE             = 6
+	SQLParserT_UPDATE             = 7
+	SQLParserT_SET                = 8
+	SQLParserT_DROP               = 9
+	SQLParserT_INTERVAL           = 10
+	SQLParserT_INTERVAL_NAME      = 11
+	SQLParserT_SHARD              = 12
+	SQLParserT_REPLICATION        = 13
+	SQLParserT_REPLICA            = 14
+	SQLParserT_LAG                = 15
+	SQLParserT_WRITE              = 16
+	SQLParserT_MEMORY             = 17
+	SQLParserT_TTL                = 18
+	SQLParserT_META_TTL           = 19
+	SQLParserT_PAST_TTL           = 20
+	SQLParserT_FUTURE_TTL         = 21
+	SQLParserT_KILL               = 22
+	SQLParserT_ON                 = 23
+	SQLParserT_SHOW               = 24
+	SQLParserT_RECOVER            = 25
+	SQLParserT_USE                = 26
+	SQLParserT_STATE_REPO         = 27
+	SQLParserT_STATE_MACHINE      = 28
+	SQLParserT_MASTER             = 29
+	SQLParserT_METADATA           = 30
+	SQLParserT_TYPES              = 31
+	SQLParserT_TYPE               = 32
+	SQLParserT_STORAGES           = 33
+	SQLParserT_STORAGE            = 34
+	SQLParserT_BROKER             = 35
+	SQLParserT_ROOT               = 36
+	SQLParserT_BROKERS            = 37
+	SQLParserT_ALIVE              = 38
+	SQLParserT_SCHEMAS            = 39
+	SQLParserT_DATASBAE           = 40
+	SQLParserT_DATASBAES          = 41
+	SQLParserT_NAMESPACE          = 42
+	SQLParserT_NAMESPACES         = 43
+	SQLParserT_NODE               = 44
+	SQLParserT_METRICS            = 45
+	SQLParserT_METRIC             = 46
+	SQLParserT_FIELD              = 47
+	SQLParserT_FIELDS             = 48
+	SQLParserT_TAG                = 49
+	SQLParserT_INFO               = 50
+	SQLParserT_KEYS               = 51
+	SQLParserT_KEY                = 52
+	SQLParserT_WITH               = 53
+	SQLParserT_VALUES             = 54
+	SQLParserT_VALUE              = 55
+	SQLParserT_DISTINCT           = 56
+	SQLParserT_PRECISION          = 57
+	SQLParserT_FROM               = 58
+	SQLParserT_WHERE              = 59
+	SQLParserT_LIMIT              = 60
+	SQLParserT_QUERIES            = 61
+	SQLParserT_QUERY              = 62
+	SQLParserT_EXPLAIN            = 63
+	SQLParserT_WITH_VALUE         = 64
+	SQLParserT_SELECT             = 65
+	SQLParserT_AS                 = 66
+	SQLParserT_AND                = 67
+	SQLParserT_OR                 = 68
+	SQLParserT_FILL               = 69
+	SQLParserT_NULL               = 70
+	SQLParserT_PREVIOUS           = 71
+	SQLParserT_ORDER              = 72
+	SQLParserT_ASC                = 73
+	SQLParserT_DESC               = 74
+	SQLParserT_LIKE               = 75
+	SQLParserT_NOT                = 76
+	SQLParserT_BETWEEN            = 77
+	SQLParserT_IS                 = 78
+	SQLParserT_GROUP              = 79
+	SQLParserT_HAVING             = 80
+	SQLParserT_BY                 = 81
+	SQLParserT_FOR                = 82
+	SQLParserT_STATS              = 83
+	SQLParserT_TIME               = 84
+	SQLParserT_NOW                = 85
+	SQLParserT_IN                 = 86
+	SQLParserT_SINCE              = 87
+	SQLParserT_UNTIL              = 88
+	SQLParserT_AGO                = 89
+	SQLParserT_LOG                = 90
+	SQLParserT_PROFILE            = 91
+	SQLParserT_REQUESTS           = 92
+	SQLParserT_REQUEST            = 93
+	SQLParserT_ID                 = 94
+	SQLParserT_SUM                = 95
+	SQLParserT_MIN                = 96
+	SQLParserT_MAX                = 97
+	SQLParserT_COUNT              = 98
+	SQLParserT_LAST               = 99
+	SQLParserT_FIRST              = 100
+	SQLParserT_AVG                = 101
+	SQLParserT_STDDEV             = 102
+	SQLParserT_QUANTILE           = 103
+	SQLParserT_RATE               = 104
+	SQLParserT_PERCENT            = 105
+	SQLParserT_COUNT_IF           = 106
+	SQLParserT_SUM_IF             = 107
+	SQLParserT_OFFSET             = 108
+	SQLParserT_MEDIAN             = 109
+	SQLParserT_DERIVATIVE         = 110
+	SQLParserT_TOPK               = 111
+	SQLParserT_BOTTOMK            = 112
+	SQLParserT_HISTOGRAM_QUANTILE = 113
+	SQLParserT_SECOND             = 114
+	SQLParserT_MINUTE             = 115
+	SQLParserT_HOUR               = 116
+	SQLParserT_DAY                = 117
+	SQLParserT_WEEK               = 118
+	SQLParserT_MONTH              = 119
+	SQLParserT_YEAR               = 120
+	SQLParserT_DOT                = 121
+	SQLParserT_COLON              = 122
+	SQLParserT_EQUAL              = 123
+	SQLParserT_NOTEQUAL           = 124
+	SQLParserT_NOTEQUAL2          = 125
+	SQLParserT_GREATER            = 126
+	SQLParserT_GREATEREQUAL       = 127
+	SQLParserT_LESS               = 128
+	SQLParserT_LESSEQUAL          = 129
+	SQLParserT_REGEXP             = 130
+	SQLParserT_NEQREGEXP          = 131
+	SQLParserT_COMMA              = 132
+	SQLParserT_OPEN_B             = 133
+	SQLParserT_CLOSE_B            = 134
+	SQLParserT_OPEN_SB            = 135
+	SQLParserT_CLOSE_SB           = 136
+	SQLParserT_OPEN_P             = 137
+	SQLParserT_CLOSE_P            = 138
+	SQLParserT_ADD                = 139
+	SQLParserT_SUB                = 140
+	SQLParserT_DIV                = 141
+	SQLParserT_MUL                = 142
+	SQLParserT_MOD                = 143
+	SQLParserT_UNDERLINE          = 144
+	SQLParserL_ID                 = 145
+	SQLParserL_INT                = 146
+	SQLParserL_DEC                = 147
 )
 
 // SQLParser rules.
@@ -674,87 +742,93 @@ const (
 	SQLParserRULE_showStorageMetaStmt    = 14
 	SQLParserRULE_showAliveStmt          = 15
 	SQLParserRULE_showReplicationStmt    = 16
-	SQLParserRULE_showMemoryDatabaseStmt = 17
-	SQLParserRULE_showRootMetricStmt     = 18
-	SQLParserRULE_showBrokerMetricStmt   = 19
-	SQLParserRULE_showStorageMetricStmt  = 20
-	SQLParserRULE_createStorageStmt      = 21
-	SQLParserRULE_createBrokerStmt       = 22
-	SQLParserRULE_recoverStorageStmt     = 23
-	SQLParserRULE_showSchemasStmt        = 24
-	SQLParserRULE_createDatabaseStmt     = 25
-	SQLParserRULE_dropDatabaseStmt       = 26
-	SQLParserRULE_showDatabaseStmt       = 27
-	SQLParserRULE_showNameSpacesStmt     = 28
-	SQLParserRULE_showMetricsStmt        = 29
-	SQLParserRULE_showFieldsStmt         = 30
-	SQLParserRULE_showTagKeysStmt        = 31
-	SQLParserRULE_showTagValuesStmt      = 32
-	SQLParserRULE_prefix                 = 33
-	SQLParserRULE_withTagKey             = 34
-	SQLParserRULE_namespace              = 35
-	SQLParserRULE_databaseName           = 36
-	SQLParserRULE_storageName            = 37
-	SQLParserRULE_requestID              = 38
-	SQLParserRULE_source                 = 39
-	SQLParserRULE_queryStmt              = 40
-	SQLParserRULE_sourceAndSelect        = 41
-	SQLParserRULE_selectExpr             = 42
-	SQLParserRULE_fields                 = 43
-	SQLParserRULE_field                  = 44
-	SQLParserRULE_alias                  = 45
-	SQLParserRULE_storageFilter          = 46
-	SQLParserRULE_brokerFilter           = 47
-	SQLParserRULE_databaseFilter         = 48
-	SQLParserRULE_typeFilter             = 49
-	SQLParserRULE_fromClause             = 50
-	SQLParserRULE_whereClause            = 51
-	SQLParserRULE_conditionExpr          = 52
-	SQLParserRULE_tagFilterExpr          = 53
-	SQLParserRULE_tagValueList           = 54
-	SQLParserRULE_metricListFilter       = 55
-	SQLParserRULE_metricList             = 56
-	SQLParserRULE_timeRangeExpr          = 57
-	SQLParserRULE_timeExpr               = 58
-	SQLParserRULE_nowExpr                = 59
-	SQLParserRULE_nowFunc                = 60
-	SQLParserRULE_groupByClause          = 61
-	SQLParserRULE_groupByKeys            = 62
-	SQLParserRULE_groupByKey             = 63
-	SQLParserRULE_fillOption             = 64
-	SQLParserRULE_orderByClause          = 65
-	SQLParserRULE_sortField              = 66
-	SQLParserRULE_sortFields             = 67
-	SQLParserRULE_havingClause           = 68
-	SQLParserRULE_boolExpr               = 69
-	SQLParserRULE_boolExprLogicalOp      = 70
-	SQLParserRULE_boolExprAtom           = 71
-	SQLParserRULE_binaryExpr             = 72
-	SQLParserRULE_binaryOperator         = 73
-	SQLParserRULE_fieldExpr              = 74
-	SQLParserRULE_star                   = 75
-	SQLParserRULE_durationLit            = 76
-	SQLParserRULE_intervalItem           = 77
-	SQLParserRULE_exprFunc               = 78
-	SQLParserRULE_funcName               = 79
-	SQLParserRULE_exprFuncParams         = 80
-	SQLParserRULE_funcParam              = 81
-	SQLParserRULE_exprAtom               = 82
-	SQLParserRULE_identFilter            = 83
-	SQLParserRULE_json                   = 84
-	SQLParserRULE_toml                   = 85
-	SQLParserRULE_obj                    = 86
-	SQLParserRULE_pair                   = 87
-	SQLParserRULE_arr                    = 88
-	SQLParserRULE_value                  = 89
-	SQLParserRULE_intNumber              = 90
-	SQLParserRULE_decNumber              = 91
-	SQLParserRULE_limitClause            = 92
-	SQLParserRULE_metricName             = 93
-	SQLParserRULE_tagKey                 = 94
-	SQLParserRULE_tagValue               = 95
-	SQLParserRULE_ident                  = 96
-	SQLParserRULE_nonReservedWords       = 97
+	SQLParserRULE_showReplicaLagStmt     = 17
+	SQLParserRULE_showWriteStatsStmt     = 18
+	SQLParserRULE_showMemoryDatabaseStmt = 19
+	SQLParserRULE_showRootMetricStmt     = 20
+	SQLParserRULE_showBrokerMetricStmt   = 21
+	SQLParserRULE_showStorageMetricStmt  = 22
+	SQLParserRULE_createStorageStmt      = 23
+	SQLParserRULE_createBrokerStmt       = 24
+	SQLParserRULE_recoverStorageStmt     = 25
+	SQLParserRULE_showSchemasStmt        = 26
+	SQLParserRULE_createDatabaseStmt     = 27
+	SQLParserRULE_dropDatabaseStmt       = 28
+	SQLParserRULE_showDatabaseStmt       = 29
+	SQLParserRULE_showNameSpacesStmt     = 30
+	SQLParserRULE_showMetricsStmt        = 31
+	SQLParserRULE_showFieldsStmt         = 32
+	SQLParserRULE_showTagKeysStmt        = 33
+	SQLParserRULE_showTagValuesStmt      = 34
+	SQLParserRULE_prefix                 = 35
+	SQLParserRULE_withTagKey             = 36
+	SQLParserRULE_namespace              = 37
+	SQLParserRULE_databaseName           = 38
+	SQLParserRULE_storageName            = 39
+	SQLParserRULE_requestID              = 40
+	SQLParserRULE_source                 = 41
+	SQLParserRULE_queryStmt              = 42
+	SQLParserRULE_sourceAndSelect        = 43
+	SQLParserRULE_selectExpr             = 44
+	SQLParserRULE_fields                 = 45
+	SQLParserRULE_field                  = 46
+	SQLParserRULE_alias                  = 47
+	SQLParserRULE_storageFilter          = 48
+	SQLParserRULE_brokerFilter           = 49
+	SQLParserRULE_databaseFilter         = 50
+	SQLParserRULE_typeFilter             = 51
+	SQLParserRULE_fromClause             = 52
+	SQLParserRULE_whereClause            = 53
+	SQLParserRULE_conditionExpr          = 54
+	SQLParserRULE_tagFilterExpr          = 55
+	SQLParserRULE_tagValueList           = 56
+	SQLParserRULE_metricListFilter       = 57
+	SQLParserRULE_metricList             = 58
+	SQLParserRULE_timeRangeExpr          = 59
+	SQLParserRULE_timeExpr               = 60
+	SQLParserRULE_nowExpr                = 61
+	SQLParserRULE_nowFunc                = 62
+	SQLParserRULE_timeRangeClause        = 63
+	SQLParserRULE_groupByClause          = 64
+	SQLParserRULE_groupByKeys            = 65
+	SQLParserRULE_groupByKey             = 66
+	SQLParserRULE_fillOption             = 67
+	SQLParserRULE_orderByClause          = 68
+	SQLParserRULE_sortField              = 69
+	SQLParserRULE_sortFields             = 70
+	SQLParserRULE_havingClause           = 71
+	SQLParserRULE_boolExpr               = 72
+	SQLParserRULE_boolExprLogicalOp      = 73
+	SQLParserRULE_boolExprAtom           = 74
+	SQLParserRULE_binaryExpr             = 75
+	SQLParserRULE_binaryOperator         = 76
+	SQLParserRULE_fieldExpr              = 77
+	SQLParserRULE_star                   = 78
+	SQLParserRULE_durationLit            = 79
+	SQLParserRULE_intervalItem           = 80
+	SQLParserRULE_exprFunc               = 81
+	SQLParserRULE_funcName               = 82
+	SQLParserRULE_exprFuncParams         = 83
+	SQLParserRULE_funcParam              = 84
+	SQLParserRULE_fieldPredicate         = 85
+	SQLParserRULE_exprAtom               = 86
+	SQLParserRULE_identFilter            = 87
+	SQLParserRULE_json                   = 88
+	SQLParserRULE_toml                   = 89
+	SQLParserRULE_obj                    = 90
+	SQLParserRULE_pair                   = 91
+	SQLParserRULE_arr                    = 92
+	SQLParserRULE_value                  = 93
+	SQLParserRULE_intNumber              = 94
+	SQLParserRULE_decNumber              = 95
+	SQLParserRULE_limitClause            = 96
+	SQLParserRULE_precisionClause        = 97
+	SQLParserRULE_offsetClause           = 98
+	SQLParserRULE_metricName             = 99
+	SQLParserRULE_tagKey                 = 100
+	SQLParserRULE_tagValue               = 101
+	SQLParserRULE_ident                  = 102
+	SQLParserRULE_nonReservedWords       = 103
 )
 
 // IStatementContext is an interface to support dynamic dispatch.
@@ -1025,80 +1099,80 @@ func (p *SQLParser) Statement() (localctx IStatementContext) {
 		}
 	}()
 
-	p.SetState(208)
+	p.SetState(220)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 0, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(196)
+			p.SetState(208)
 			p.ShowStmt()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(197)
+			p.SetState(209)
 			p.CreateStorageStmt()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(198)
+			p.SetState(210)
 			p.CreateBrokerStmt()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(199)
+			p.SetState(211)
 			p.RecoverStorageStmt()
 		}
 
 	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(200)
+			p.SetState(212)
 			p.UseStmt()
 		}
 
 	case 6:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(201)
+			p.SetState(213)
 			p.QueryStmt()
 		}
 
 	case 7:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(202)
+			p.SetState(214)
 			p.CreateDatabaseStmt()
 		}
 
 	case 8:
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(203)
+			p.SetState(215)
 			p.DropDatabaseStmt()
 		}
 
 	case 9:
 		p.EnterOuterAlt(localctx, 9)
 		{
-			p.SetState(204)
+			p.SetState(216)
 			p.SetLimitStmt()
 		}
 
 	case 10:
 		p.EnterOuterAlt(localctx, 10)
 		{
-			p.SetState(205)
+			p.SetState(217)
 			p.Ident()
 		}
 		{
-			p.SetState(206)
+			p.SetState(218)
 			p.Match(SQLParserEOF)
 		}
 
@@ -1224,11 +1298,11 @@ func (p *SQLParser) UseStmt() (localctx IUseStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(210)
+		p.SetState(222)
 		p.Match(SQLParserT_USE)
 	}
 	{
-		p.SetState(211)
+		p.SetState(223)
 		p.Ident()
 	}
 
@@ -1357,15 +1431,15 @@ func (p *SQLParser) SetLimitStmt() (localctx ISetLimitStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(213)
+		p.SetState(225)
 		p.Match(SQLParserT_SET)
 	}
 	{
-		p.SetState(214)
+		p.SetState(226)
 		p.Match(SQLParserT_LIMIT)
 	}
 	{
-		p.SetState(215)
+		p.SetState(227)
 		p.Toml()
 	}
 
@@ -1394,6 +1468,8 @@ type IShowStmtContext interface {
 	ShowBrokerMetricStmt() IShowBrokerMetricStmtContext
 	ShowStorageMetricStmt() IShowStorageMetricStmtContext
 	ShowReplicationStmt() IShowReplicationStmtContext
+	ShowReplicaLagStmt() IShowReplicaLagStmtContext
+	ShowWriteStatsStmt() IShowWriteStatsStmtContext
 	ShowMemoryDatabaseStmt() IShowMemoryDatabaseStmtContext
 	ShowSchemasStmt() IShowSchemasStmtContext
 	ShowDatabaseStmt() IShowDatabaseStmtContext
@@ -1660,6 +1736,38 @@ func (s *ShowStmtContext) ShowReplicationStmt() IShowReplicationStmtContext {
 	return t.(IShowReplicationStmtContext)
 }
 
+func (s *ShowStmtContext) ShowReplicaLagStmt() IShowReplicaLagStmtContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IShowReplicaLagStmtContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IShowReplicaLagStmtContext)
+}
+
+func (s *ShowStmtContext) ShowWriteStatsStmt() IShowWriteStatsStmtContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IShowWriteStatsStmtContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IShowWriteStatsStmtContext)
+}
+
 func (s *ShowStmtContext) ShowMemoryDatabaseStmt() IShowMemoryDatabaseStmtContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
@@ -1873,174 +1981,188 @@ func (p *SQLParser) ShowStmt() (localctx IShowStmtContext) {
 		}
 	}()
 
-	p.SetState(241)
+	p.SetState(255)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 1, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(217)
+			p.SetState(229)
 			p.ShowMasterStmt()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(218)
+			p.SetState(230)
 			p.ShowMetadataTypesStmt()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(219)
+			p.SetState(231)
 			p.ShowRootMetaStmt()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(220)
+			p.SetState(232)
 			p.ShowBrokerMetaStmt()
 		}
 
 	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(221)
+			p.SetState(233)
 			p.ShowMasterMetaStmt()
 		}
 
 	case 6:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(222)
+			p.SetState(234)
 			p.ShowStorageMetaStmt()
 		}
 
 	case 7:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(223)
+			p.SetState(235)
 			p.ShowStoragesStmt()
 		}
 
 	case 8:
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(224)
+			p.SetState(236)
 			p.ShowBrokersStmt()
 		}
 
 	case 9:
 		p.EnterOuterAlt(localctx, 9)
 		{
-			p.SetState(225)
+			p.SetState(237)
 			p.ShowLimitStmt()
 		}
 
 	case 10:
 		p.EnterOuterAlt(localctx, 10)
 		{
-			p.SetState(226)
+			p.SetState(238)
 			p.ShowAliveStmt()
 		}
 
 	case 11:
 		p.EnterOuterAlt(localctx, 11)
 		{
-			p.SetState(227)
+			p.SetState(239)
 			p.ShowRootMetricStmt()
 		}
 
 	case 12:
 		p.EnterOuterAlt(localctx, 12)
 		{
-			p.SetState(228)
+			p.SetState(240)
 			p.ShowBrokerMetricStmt()
 		}
 
 	case 13:
 		p.EnterOuterAlt(localctx, 13)
 		{
-			p.SetState(229)
+			p.SetState(241)
 			p.ShowStorageMetricStmt()
 		}
 
 	case 14:
 		p.EnterOuterAlt(localctx, 14)
 		{
-			p.SetState(230)
+			p.SetState(242)
 			p.ShowReplicationStmt()
 		}
 
 	case 15:
 		p.EnterOuterAlt(localctx, 15)
 		{
-			p.SetState(231)
-			p.ShowMemoryDatabaseStmt()
+			p.SetState(243)
+			p.ShowReplicaLagStmt()
 		}
 
 	case 16:
 		p.EnterOuterAlt(localctx, 16)
 		{
-			p.SetState(232)
-			p.ShowSchemasStmt()
+			p.SetState(244)
+			p.ShowWriteStatsStmt()
 		}
 
 	case 17:
 		p.EnterOuterAlt(localctx, 17)
 		{
-			p.SetState(233)
-			p.ShowDatabaseStmt()
+			p.SetState(245)
+			p.ShowMemoryDatabaseStmt()
 		}
 
 	case 18:
 		p.EnterOuterAlt(localctx, 18)
 		{
-			p.SetState(234)
-			p.ShowNameSpacesStmt()
+			p.SetState(246)
+			p.ShowSchemasStmt()
 		}
 
 	case 19:
 		p.EnterOuterAlt(localctx, 19)
 		{
-			p.SetState(235)
-			p.ShowMetricsStmt()
+			p.SetState(247)
+			p.ShowDatabaseStmt()
 		}
 
 	case 20:
 		p.EnterOuterAlt(localctx, 20)
 		{
-			p.SetState(236)
-			p.ShowFieldsStmt()
+			p.SetState(248)
+			p.ShowNameSpacesStmt()
 		}
 
 	case 21:
 		p.EnterOuterAlt(localctx, 21)
 		{
-			p.SetState(237)
-			p.ShowTagKeysStmt()
+			p.SetState(249)
+			p.ShowMetricsStmt()
 		}
 
 	case 22:
 		p.EnterOuterAlt(localctx, 22)
 		{
-			p.SetState(238)
-			p.ShowTagValuesStmt()
+			p.SetState(250)
+			p.ShowFieldsStmt()
 		}
 
 	case 23:
 		p.EnterOuterAlt(localctx, 23)
 		{
-			p.SetState(239)
-			p.ShowRequestsStmt()
+			p.SetState(251)
+			p.ShowTagKeysStmt()
 		}
 
 	case 24:
 		p.EnterOuterAlt(localctx, 24)
 		{
-			p.SetState(240)
+			p.SetState(252)
+			p.ShowTagValuesStmt()
+		}
+
+	case 25:
+		p.EnterOuterAlt(localctx, 25)
+		{
+			p.SetState(253)
+			p.ShowRequestsStmt()
+		}
+
+	case 26:
+		p.EnterOuterAlt(localctx, 26)
+		{
+			p.SetState(254)
 			p.ShowRequestStmt()
 		}
 
@@ -2154,11 +2276,11 @@ func (p *SQLParser) ShowMasterStmt() (localctx IShowMasterStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(243)
+		p.SetState(257)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(244)
+		p.SetState(258)
 		p.Match(SQLParserT_MASTER)
 	}
 
@@ -2270,11 +2392,11 @@ func (p *SQLParser) ShowRequestsStmt() (localctx IShowRequestsStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(246)
+		p.SetState(260)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(247)
+		p.SetState(261)
 		p.Match(SQLParserT_REQUESTS)
 	}
 
@@ -2418,27 +2540,27 @@ func (p *SQLParser) ShowRequestStmt() (localctx IShowRequestStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(249)
+		p.SetState(263)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(250)
+		p.SetState(264)
 		p.Match(SQLParserT_REQUEST)
 	}
 	{
-		p.SetState(251)
+		p.SetState(265)
 		p.Match(SQLParserT_WHERE)
 	}
 	{
-		p.SetState(252)
+		p.SetState(266)
 		p.Match(SQLParserT_ID)
 	}
 	{
-		p.SetState(253)
+		p.SetState(267)
 		p.Match(SQLParserT_EQUAL)
 	}
 	{
-		p.SetState(254)
+		p.SetState(268)
 		p.RequestID()
 	}
 
@@ -2550,11 +2672,11 @@ func (p *SQLParser) ShowStoragesStmt() (localctx IShowStoragesStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(256)
+		p.SetState(270)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(257)
+		p.SetState(271)
 		p.Match(SQLParserT_STORAGES)
 	}
 
@@ -2666,11 +2788,11 @@ func (p *SQLParser) ShowBrokersStmt() (localctx IShowBrokersStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(259)
+		p.SetState(273)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(260)
+		p.SetState(274)
 		p.Match(SQLParserT_BROKERS)
 	}
 
@@ -2782,11 +2904,11 @@ func (p *SQLParser) ShowLimitStmt() (localctx IShowLimitStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(262)
+		p.SetState(276)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(263)
+		p.SetState(277)
 		p.Match(SQLParserT_LIMIT)
 	}
 
@@ -2903,15 +3025,15 @@ func (p *SQLParser) ShowMetadataTypesStmt() (localctx IShowMetadataTypesStmtCont
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(265)
+		p.SetState(279)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(266)
+		p.SetState(280)
 		p.Match(SQLParserT_METADATA)
 	}
 	{
-		p.SetState(267)
+		p.SetState(281)
 		p.Match(SQLParserT_TYPES)
 	}
 
@@ -3072,31 +3194,31 @@ func (p *SQLParser) ShowRootMetaStmt() (localctx IShowRootMetaStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(269)
+		p.SetState(283)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(270)
+		p.SetState(284)
 		p.Match(SQLParserT_ROOT)
 	}
 	{
-		p.SetState(271)
+		p.SetState(285)
 		p.Match(SQLParserT_METADATA)
 	}
 	{
-		p.SetState(272)
+		p.SetState(286)
 		p.Match(SQLParserT_FROM)
 	}
 	{
-		p.SetState(273)
+		p.SetState(287)
 		p.Source()
 	}
 	{
-		p.SetState(274)
+		p.SetState(288)
 		p.Match(SQLParserT_WHERE)
 	}
 	{
-		p.SetState(275)
+		p.SetState(289)
 		p.TypeFilter()
 	}
 
@@ -3280,44 +3402,44 @@ func (p *SQLParser) ShowBrokerMetaStmt() (localctx IShowBrokerMetaStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(277)
+		p.SetState(291)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(278)
+		p.SetState(292)
 		p.Match(SQLParserT_BROKER)
 	}
 	{
-		p.SetState(279)
+		p.SetState(293)
 		p.Match(SQLParserT_METADATA)
 	}
 	{
-		p.SetState(280)
+		p.SetState(294)
 		p.Match(SQLParserT_FROM)
 	}
 	{
-		p.SetState(281)
+		p.SetState(295)
 		p.Source()
 	}
 	{
-		p.SetState(282)
+		p.SetState(296)
 		p.Match(SQLParserT_WHERE)
 	}
 	{
-		p.SetState(283)
+		p.SetState(297)
 		p.TypeFilter()
 	}
-	p.SetState(286)
+	p.SetState(300)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_AND {
 		{
-			p.SetState(284)
+			p.SetState(298)
 			p.Match(SQLParserT_AND)
 		}
 		{
-			p.SetState(285)
+			p.SetState(299)
 			p.BrokerFilter()
 		}
 
@@ -3480,31 +3602,31 @@ func (p *SQLParser) ShowMasterMetaStmt() (localctx IShowMasterMetaStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(288)
+		p.SetState(302)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(289)
+		p.SetState(303)
 		p.Match(SQLParserT_MASTER)
 	}
 	{
-		p.SetState(290)
+		p.SetState(304)
 		p.Match(SQLParserT_METADATA)
 	}
 	{
-		p.SetState(291)
+		p.SetState(305)
 		p.Match(SQLParserT_FROM)
 	}
 	{
-		p.SetState(292)
+		p.SetState(306)
 		p.Source()
 	}
 	{
-		p.SetState(293)
+		p.SetState(307)
 		p.Match(SQLParserT_WHERE)
 	}
 	{
-		p.SetState(294)
+		p.SetState(308)
 		p.TypeFilter()
 	}
 
@@ -3739,42 +3861,42 @@ func (p *SQLParser) ShowStorageMetaStmt() (localctx IShowStorageMetaStmtContext)
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(296)
+		p.SetState(310)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(297)
+		p.SetState(311)
 		p.Match(SQLParserT_STORAGE)
 	}
 	{
-		p.SetState(298)
+		p.SetState(312)
 		p.Match(SQLParserT_METADATA)
 	}
 	{
-		p.SetState(299)
+		p.SetState(313)
 		p.Match(SQLParserT_FROM)
 	}
 	{
-		p.SetState(300)
+		p.SetState(314)
 		p.Source()
 	}
 	{
-		p.SetState(301)
+		p.SetState(315)
 		p.Match(SQLParserT_WHERE)
 	}
-	p.SetState(304)
+	p.SetState(318)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_STORAGE:
 		{
-			p.SetState(302)
+			p.SetState(316)
 			p.StorageFilter()
 		}
 
 	case SQLParserT_TYPE:
 		{
-			p.SetState(303)
+			p.SetState(317)
 			p.TypeFilter()
 		}
 
@@ -3782,22 +3904,22 @@ func (p *SQLParser) ShowStorageMetaStmt() (localctx IShowStorageMetaStmtContext)
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 	{
-		p.SetState(306)
+		p.SetState(320)
 		p.Match(SQLParserT_AND)
 	}
-	p.SetState(309)
+	p.SetState(323)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_STORAGE:
 		{
-			p.SetState(307)
+			p.SetState(321)
 			p.StorageFilter()
 		}
 
 	case SQLParserT_TYPE:
 		{
-			p.SetState(308)
+			p.SetState(322)
 			p.TypeFilter()
 		}
 
@@ -3929,14 +4051,14 @@ func (p *SQLParser) ShowAliveStmt() (localctx IShowAliveStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(311)
+		p.SetState(325)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(312)
+		p.SetState(326)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&15032385536) != 0) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&120259084288) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -3944,7 +4066,7 @@ func (p *SQLParser) ShowAliveStmt() (localctx IShowAliveStmtContext) {
 		}
 	}
 	{
-		p.SetState(313)
+		p.SetState(327)
 		p.Match(SQLParserT_ALIVE)
 	}
 
@@ -4152,30 +4274,30 @@ func (p *SQLParser) ShowReplicationStmt() (localctx IShowReplicationStmtContext)
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(315)
+		p.SetState(329)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(316)
+		p.SetState(330)
 		p.Match(SQLParserT_REPLICATION)
 	}
 	{
-		p.SetState(317)
+		p.SetState(331)
 		p.Match(SQLParserT_WHERE)
 	}
-	p.SetState(320)
+	p.SetState(334)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_STORAGE:
 		{
-			p.SetState(318)
+			p.SetState(332)
 			p.StorageFilter()
 		}
 
 	case SQLParserT_DATASBAE:
 		{
-			p.SetState(319)
+			p.SetState(333)
 			p.DatabaseFilter()
 		}
 
@@ -4183,22 +4305,22 @@ func (p *SQLParser) ShowReplicationStmt() (localctx IShowReplicationStmtContext)
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 	{
-		p.SetState(322)
+		p.SetState(336)
 		p.Match(SQLParserT_AND)
 	}
-	p.SetState(325)
+	p.SetState(339)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_STORAGE:
 		{
-			p.SetState(323)
+			p.SetState(337)
 			p.StorageFilter()
 		}
 
 	case SQLParserT_DATASBAE:
 		{
-			p.SetState(324)
+			p.SetState(338)
 			p.DatabaseFilter()
 		}
 
@@ -4209,8 +4331,8 @@ func (p *SQLParser) ShowReplicationStmt() (localctx IShowReplicationStmtContext)
 	return localctx
 }
 
-// IShowMemoryDatabaseStmtContext is an interface to support dynamic dispatch.
-type IShowMemoryDatabaseStmtContext interface {
+// IShowReplicaLagStmtContext is an interface to support dynamic dispatch.
+type IShowReplicaLagStmtContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
@@ -4218,8 +4340,8 @@ type IShowMemoryDatabaseStmtContext interface {
 
 	// Getter signatures
 	T_SHOW() antlr.TerminalNode
-	T_MEMORY() antlr.TerminalNode
-	T_DATASBAE() antlr.TerminalNode
+	T_REPLICA() antlr.TerminalNode
+	T_LAG() antlr.TerminalNode
 	T_WHERE() antlr.TerminalNode
 	T_AND() antlr.TerminalNode
 	AllStorageFilter() []IStorageFilterContext
@@ -4227,58 +4349,58 @@ type IShowMemoryDatabaseStmtContext interface {
 	AllDatabaseFilter() []IDatabaseFilterContext
 	DatabaseFilter(i int) IDatabaseFilterContext
 
-	// IsShowMemoryDatabaseStmtContext differentiates from other interfaces.
-	IsShowMemoryDatabaseStmtContext()
+	// IsShowReplicaLagStmtContext differentiates from other interfaces.
+	IsShowReplicaLagStmtContext()
 }
 
-type ShowMemoryDatabaseStmtContext struct {
+type ShowReplicaLagStmtContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyShowMemoryDatabaseStmtContext() *ShowMemoryDatabaseStmtContext {
-	var p = new(ShowMemoryDatabaseStmtContext)
+func NewEmptyShowReplicaLagStmtContext() *ShowReplicaLagStmtContext {
+	var p = new(ShowReplicaLagStmtContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SQLParserRULE_showMemoryDatabaseStmt
+	p.RuleIndex = SQLParserRULE_showReplicaLagStmt
 	return p
 }
 
-func (*ShowMemoryDatabaseStmtContext) IsShowMemoryDatabaseStmtContext() {}
+func (*ShowReplicaLagStmtContext) IsShowReplicaLagStmtContext() {}
 
-func NewShowMemoryDatabaseStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ShowMemoryDatabaseStmtContext {
-	var p = new(ShowMemoryDatabaseStmtContext)
+func NewShowReplicaLagStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ShowReplicaLagStmtContext {
+	var p = new(ShowReplicaLagStmtContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SQLParserRULE_showMemoryDatabaseStmt
+	p.RuleIndex = SQLParserRULE_showReplicaLagStmt
 
 	return p
 }
 
-func (s *ShowMemoryDatabaseStmtContext) GetParser() antlr.Parser { return s.parser }
+func (s *ShowReplicaLagStmtContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ShowMemoryDatabaseStmtContext) T_SHOW() antlr.TerminalNode {
+func (s *ShowReplicaLagStmtContext) T_SHOW() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_SHOW, 0)
 }
 
-func (s *ShowMemoryDatabaseStmtContext) T_MEMORY() antlr.TerminalNode {
-	return s.GetToken(SQLParserT_MEMORY, 0)
+func (s *ShowReplicaLagStmtContext) T_REPLICA() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_REPLICA, 0)
 }
 
-func (s *ShowMemoryDatabaseStmtContext) T_DATASBAE() antlr.TerminalNode {
-	return s.GetToken(SQLParserT_DATASBAE, 0)
+func (s *ShowReplicaLagStmtContext) T_LAG() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LAG, 0)
 }
 
-func (s *ShowMemoryDatabaseStmtContext) T_WHERE() antlr.TerminalNode {
+func (s *ShowReplicaLagStmtContext) T_WHERE() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_WHERE, 0)
 }
 
-func (s *ShowMemoryDatabaseStmtContext) T_AND() antlr.TerminalNode {
+func (s *ShowReplicaLagStmtContext) T_AND() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_AND, 0)
 }
 
-func (s *ShowMemoryDatabaseStmtContext) AllStorageFilter() []IStorageFilterContext {
+func (s *ShowReplicaLagStmtContext) AllStorageFilter() []IStorageFilterContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -4299,7 +4421,7 @@ func (s *ShowMemoryDatabaseStmtContext) AllStorageFilter() []IStorageFilterConte
 	return tst
 }
 
-func (s *ShowMemoryDatabaseStmtContext) StorageFilter(i int) IStorageFilterContext {
+func (s *ShowReplicaLagStmtContext) StorageFilter(i int) IStorageFilterContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -4319,7 +4441,7 @@ func (s *ShowMemoryDatabaseStmtContext) StorageFilter(i int) IStorageFilterConte
 	return t.(IStorageFilterContext)
 }
 
-func (s *ShowMemoryDatabaseStmtContext) AllDatabaseFilter() []IDatabaseFilterContext {
+func (s *ShowReplicaLagStmtContext) AllDatabaseFilter() []IDatabaseFilterContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -4340,7 +4462,7 @@ func (s *ShowMemoryDatabaseStmtContext) AllDatabaseFilter() []IDatabaseFilterCon
 	return tst
 }
 
-func (s *ShowMemoryDatabaseStmtContext) DatabaseFilter(i int) IDatabaseFilterContext {
+func (s *ShowReplicaLagStmtContext) DatabaseFilter(i int) IDatabaseFilterContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -4360,42 +4482,42 @@ func (s *ShowMemoryDatabaseStmtContext) DatabaseFilter(i int) IDatabaseFilterCon
 	return t.(IDatabaseFilterContext)
 }
 
-func (s *ShowMemoryDatabaseStmtContext) GetRuleContext() antlr.RuleContext {
+func (s *ShowReplicaLagStmtContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ShowMemoryDatabaseStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ShowReplicaLagStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ShowMemoryDatabaseStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ShowReplicaLagStmtContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SQLListener); ok {
-		listenerT.EnterShowMemoryDatabaseStmt(s)
+		listenerT.EnterShowReplicaLagStmt(s)
 	}
 }
 
-func (s *ShowMemoryDatabaseStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ShowReplicaLagStmtContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SQLListener); ok {
-		listenerT.ExitShowMemoryDatabaseStmt(s)
+		listenerT.ExitShowReplicaLagStmt(s)
 	}
 }
 
-func (s *ShowMemoryDatabaseStmtContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ShowReplicaLagStmtContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SQLVisitor:
-		return t.VisitShowMemoryDatabaseStmt(s)
+		return t.VisitShowReplicaLagStmt(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SQLParser) ShowMemoryDatabaseStmt() (localctx IShowMemoryDatabaseStmtContext) {
+func (p *SQLParser) ShowReplicaLagStmt() (localctx IShowReplicaLagStmtContext) {
 	this := p
 	_ = this
 
-	localctx = NewShowMemoryDatabaseStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, SQLParserRULE_showMemoryDatabaseStmt)
+	localctx = NewShowReplicaLagStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, SQLParserRULE_showReplicaLagStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -4415,34 +4537,34 @@ func (p *SQLParser) ShowMemoryDatabaseStmt() (localctx IShowMemoryDatabaseStmtCo
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(327)
+		p.SetState(341)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(328)
-		p.Match(SQLParserT_MEMORY)
+		p.SetState(342)
+		p.Match(SQLParserT_REPLICA)
 	}
 	{
-		p.SetState(329)
-		p.Match(SQLParserT_DATASBAE)
+		p.SetState(343)
+		p.Match(SQLParserT_LAG)
 	}
 	{
-		p.SetState(330)
+		p.SetState(344)
 		p.Match(SQLParserT_WHERE)
 	}
-	p.SetState(333)
+	p.SetState(347)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_STORAGE:
 		{
-			p.SetState(331)
+			p.SetState(345)
 			p.StorageFilter()
 		}
 
 	case SQLParserT_DATASBAE:
 		{
-			p.SetState(332)
+			p.SetState(346)
 			p.DatabaseFilter()
 		}
 
@@ -4450,22 +4572,22 @@ func (p *SQLParser) ShowMemoryDatabaseStmt() (localctx IShowMemoryDatabaseStmtCo
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 	{
-		p.SetState(335)
+		p.SetState(349)
 		p.Match(SQLParserT_AND)
 	}
-	p.SetState(338)
+	p.SetState(352)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_STORAGE:
 		{
-			p.SetState(336)
+			p.SetState(350)
 			p.StorageFilter()
 		}
 
 	case SQLParserT_DATASBAE:
 		{
-			p.SetState(337)
+			p.SetState(351)
 			p.DatabaseFilter()
 		}
 
@@ -4476,8 +4598,8 @@ func (p *SQLParser) ShowMemoryDatabaseStmt() (localctx IShowMemoryDatabaseStmtCo
 	return localctx
 }
 
-// IShowRootMetricStmtContext is an interface to support dynamic dispatch.
-type IShowRootMetricStmtContext interface {
+// IShowWriteStatsStmtContext is an interface to support dynamic dispatch.
+type IShowWriteStatsStmtContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
@@ -4485,62 +4607,62 @@ type IShowRootMetricStmtContext interface {
 
 	// Getter signatures
 	T_SHOW() antlr.TerminalNode
-	T_ROOT() antlr.TerminalNode
-	T_METRIC() antlr.TerminalNode
+	T_WRITE() antlr.TerminalNode
+	T_STATS() antlr.TerminalNode
 	T_WHERE() antlr.TerminalNode
-	MetricListFilter() IMetricListFilterContext
+	DatabaseFilter() IDatabaseFilterContext
 
-	// IsShowRootMetricStmtContext differentiates from other interfaces.
-	IsShowRootMetricStmtContext()
+	// IsShowWriteStatsStmtContext differentiates from other interfaces.
+	IsShowWriteStatsStmtContext()
 }
 
-type ShowRootMetricStmtContext struct {
+type ShowWriteStatsStmtContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyShowRootMetricStmtContext() *ShowRootMetricStmtContext {
-	var p = new(ShowRootMetricStmtContext)
+func NewEmptyShowWriteStatsStmtContext() *ShowWriteStatsStmtContext {
+	var p = new(ShowWriteStatsStmtContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SQLParserRULE_showRootMetricStmt
+	p.RuleIndex = SQLParserRULE_showWriteStatsStmt
 	return p
 }
 
-func (*ShowRootMetricStmtContext) IsShowRootMetricStmtContext() {}
+func (*ShowWriteStatsStmtContext) IsShowWriteStatsStmtContext() {}
 
-func NewShowRootMetricStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ShowRootMetricStmtContext {
-	var p = new(ShowRootMetricStmtContext)
+func NewShowWriteStatsStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ShowWriteStatsStmtContext {
+	var p = new(ShowWriteStatsStmtContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SQLParserRULE_showRootMetricStmt
+	p.RuleIndex = SQLParserRULE_showWriteStatsStmt
 
 	return p
 }
 
-func (s *ShowRootMetricStmtContext) GetParser() antlr.Parser { return s.parser }
+func (s *ShowWriteStatsStmtContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ShowRootMetricStmtContext) T_SHOW() antlr.TerminalNode {
+func (s *ShowWriteStatsStmtContext) T_SHOW() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_SHOW, 0)
 }
 
-func (s *ShowRootMetricStmtContext) T_ROOT() antlr.TerminalNode {
-	return s.GetToken(SQLParserT_ROOT, 0)
+func (s *ShowWriteStatsStmtContext) T_WRITE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_WRITE, 0)
 }
 
-func (s *ShowRootMetricStmtContext) T_METRIC() antlr.TerminalNode {
-	return s.GetToken(SQLParserT_METRIC, 0)
+func (s *ShowWriteStatsStmtContext) T_STATS() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_STATS, 0)
 }
 
-func (s *ShowRootMetricStmtContext) T_WHERE() antlr.TerminalNode {
+func (s *ShowWriteStatsStmtContext) T_WHERE() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_WHERE, 0)
 }
 
-func (s *ShowRootMetricStmtContext) MetricListFilter() IMetricListFilterContext {
+func (s *ShowWriteStatsStmtContext) DatabaseFilter() IDatabaseFilterContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IMetricListFilterContext); ok {
+		if _, ok := ctx.(IDatabaseFilterContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -4550,45 +4672,45 @@ func (s *ShowRootMetricStmtContext) MetricListFilter() IMetricListFilterContext
 		return nil
 	}
 
-	return t.(IMetricListFilterContext)
+	return t.(IDatabaseFilterContext)
 }
 
-func (s *ShowRootMetricStmtContext) GetRuleContext() antlr.RuleContext {
+func (s *ShowWriteStatsStmtContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ShowRootMetricStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ShowWriteStatsStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ShowRootMetricStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ShowWriteStatsStmtContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SQLListener); ok {
-		listenerT.EnterShowRootMetricStmt(s)
+		listenerT.EnterShowWriteStatsStmt(s)
 	}
 }
 
-func (s *ShowRootMetricStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ShowWriteStatsStmtContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SQLListener); ok {
-		listenerT.ExitShowRootMetricStmt(s)
+		listenerT.ExitShowWriteStatsStmt(s)
 	}
 }
 
-func (s *ShowRootMetricStmtContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ShowWriteStatsStmtContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SQLVisitor:
-		return t.VisitShowRootMetricStmt(s)
+		return t.VisitShowWriteStatsStmt(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SQLParser) ShowRootMetricStmt() (localctx IShowRootMetricStmtContext) {
+func (p *SQLParser) ShowWriteStatsStmt() (localctx IShowWriteStatsStmtContext) {
 	this := p
 	_ = this
 
-	localctx = NewShowRootMetricStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 36, SQLParserRULE_showRootMetricStmt)
+	localctx = NewShowWriteStatsStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 36, SQLParserRULE_showWriteStatsStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -4608,31 +4730,31 @@ func (p *SQLParser) ShowRootMetricStmt() (localctx IShowRootMetricStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(340)
+		p.SetState(354)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(341)
-		p.Match(SQLParserT_ROOT)
+		p.SetState(355)
+		p.Match(SQLParserT_WRITE)
 	}
 	{
-		p.SetState(342)
-		p.Match(SQLParserT_METRIC)
+		p.SetState(356)
+		p.Match(SQLParserT_STATS)
 	}
 	{
-		p.SetState(343)
+		p.SetState(357)
 		p.Match(SQLParserT_WHERE)
 	}
 	{
-		p.SetState(344)
-		p.MetricListFilter()
+		p.SetState(358)
+		p.DatabaseFilter()
 	}
 
 	return localctx
 }
 
-// IShowBrokerMetricStmtContext is an interface to support dynamic dispatch.
-type IShowBrokerMetricStmtContext interface {
+// IShowMemoryDatabaseStmtContext is an interface to support dynamic dispatch.
+type IShowMemoryDatabaseStmtContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
@@ -4640,16 +4762,438 @@ type IShowBrokerMetricStmtContext interface {
 
 	// Getter signatures
 	T_SHOW() antlr.TerminalNode
-	T_BROKER() antlr.TerminalNode
-	T_METRIC() antlr.TerminalNode
+	T_MEMORY() antlr.TerminalNode
+	T_DATASBAE() antlr.TerminalNode
 	T_WHERE() antlr.TerminalNode
-	MetricListFilter() IMetricListFilterContext
+	T_AND() antlr.TerminalNode
+	AllStorageFilter() []IStorageFilterContext
+	StorageFilter(i int) IStorageFilterContext
+	AllDatabaseFilter() []IDatabaseFilterContext
+	DatabaseFilter(i int) IDatabaseFilterContext
 
-	// IsShowBrokerMetricStmtContext differentiates from other interfaces.
-	IsShowBrokerMetricStmtContext()
+	// IsShowMemoryDatabaseStmtContext differentiates from other interfaces.
+	IsShowMemoryDatabaseStmtContext()
 }
 
-type ShowBrokerMetricStmtContext struct {
+type ShowMemoryDatabaseStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyShowMemoryDatabaseStmtContext() *ShowMemoryDatabaseStmtContext {
+	var p = new(ShowMemoryDatabaseStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_showMemoryDatabaseStmt
+	return p
+}
+
+func (*ShowMemoryDatabaseStmtContext) IsShowMemoryDatabaseStmtContext() {}
+
+func NewShowMemoryDatabaseStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ShowMemoryDatabaseStmtContext {
+	var p = new(ShowMemoryDatabaseStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_showMemoryDatabaseStmt
+
+	return p
+}
+
+func (s *ShowMemoryDatabaseStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ShowMemoryDatabaseStmtContext) T_SHOW() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SHOW, 0)
+}
+
+func (s *ShowMemoryDatabaseStmtContext) T_MEMORY() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MEMORY, 0)
+}
+
+func (s *ShowMemoryDatabaseStmtContext) T_DATASBAE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DATASBAE, 0)
+}
+
+func (s *ShowMemoryDatabaseStmtContext) T_WHERE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_WHERE, 0)
+}
+
+func (s *ShowMemoryDatabaseStmtContext) T_AND() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_AND, 0)
+}
+
+func (s *ShowMemoryDatabaseStmtContext) AllStorageFilter() []IStorageFilterContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IStorageFilterContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IStorageFilterContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IStorageFilterContext); ok {
+			tst[i] = t.(IStorageFilterContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ShowMemoryDatabaseStmtContext) StorageFilter(i int) IStorageFilterContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IStorageFilterContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStorageFilterContext)
+}
+
+func (s *ShowMemoryDatabaseStmtContext) AllDatabaseFilter() []IDatabaseFilterContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IDatabaseFilterContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IDatabaseFilterContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IDatabaseFilterContext); ok {
+			tst[i] = t.(IDatabaseFilterContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ShowMemoryDatabaseStmtContext) DatabaseFilter(i int) IDatabaseFilterContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDatabaseFilterContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDatabaseFilterContext)
+}
+
+func (s *ShowMemoryDatabaseStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ShowMemoryDatabaseStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ShowMemoryDatabaseStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterShowMemoryDatabaseStmt(s)
+	}
+}
+
+func (s *ShowMemoryDatabaseStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitShowMemoryDatabaseStmt(s)
+	}
+}
+
+func (s *ShowMemoryDatabaseStmtContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SQLVisitor:
+		return t.VisitShowMemoryDatabaseStmt(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SQLParser) ShowMemoryDatabaseStmt() (localctx IShowMemoryDatabaseStmtContext) {
+	this := p
+	_ = this
+
+	localctx = NewShowMemoryDatabaseStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 38, SQLParserRULE_showMemoryDatabaseStmt)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(360)
+		p.Match(SQLParserT_SHOW)
+	}
+	{
+		p.SetState(361)
+		p.Match(SQLParserT_MEMORY)
+	}
+	{
+		p.SetState(362)
+		p.Match(SQLParserT_DATASBAE)
+	}
+	{
+		p.SetState(363)
+		p.Match(SQLParserT_WHERE)
+	}
+	p.SetState(366)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SQLParserT_STORAGE:
+		{
+			p.SetState(364)
+			p.StorageFilter()
+		}
+
+	case SQLParserT_DATASBAE:
+		{
+			p.SetState(365)
+			p.DatabaseFilter()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+	{
+		p.SetState(368)
+		p.Match(SQLParserT_AND)
+	}
+	p.SetState(371)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SQLParserT_STORAGE:
+		{
+			p.SetState(369)
+			p.StorageFilter()
+		}
+
+	case SQLParserT_DATASBAE:
+		{
+			p.SetState(370)
+			p.DatabaseFilter()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// IShowRootMetricStmtContext is an interface to support dynamic dispatch.
+type IShowRootMetricStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	T_SHOW() antlr.TerminalNode
+	T_ROOT() antlr.TerminalNode
+	T_METRIC() antlr.TerminalNode
+	T_WHERE() antlr.TerminalNode
+	MetricListFilter() IMetricListFilterContext
+
+	// IsShowRootMetricStmtContext differentiates from other interfaces.
+	IsShowRootMetricStmtContext()
+}
+
+type ShowRootMetricStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyShowRootMetricStmtContext() *ShowRootMetricStmtContext {
+	var p = new(ShowRootMetricStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_showRootMetricStmt
+	return p
+}
+
+func (*ShowRootMetricStmtContext) IsShowRootMetricStmtContext() {}
+
+func NewShowRootMetricStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ShowRootMetricStmtContext {
+	var p = new(ShowRootMetricStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_showRootMetricStmt
+
+	return p
+}
+
+func (s *ShowRootMetricStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ShowRootMetricStmtContext) T_SHOW() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SHOW, 0)
+}
+
+func (s *ShowRootMetricStmtContext) T_ROOT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_ROOT, 0)
+}
+
+func (s *ShowRootMetricStmtContext) T_METRIC() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_METRIC, 0)
+}
+
+func (s *ShowRootMetricStmtContext) T_WHERE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_WHERE, 0)
+}
+
+func (s *ShowRootMetricStmtContext) MetricListFilter() IMetricListFilterContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMetricListFilterContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMetricListFilterContext)
+}
+
+func (s *ShowRootMetricStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ShowRootMetricStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ShowRootMetricStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterShowRootMetricStmt(s)
+	}
+}
+
+func (s *ShowRootMetricStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitShowRootMetricStmt(s)
+	}
+}
+
+func (s *ShowRootMetricStmtContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SQLVisitor:
+		return t.VisitShowRootMetricStmt(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SQLParser) ShowRootMetricStmt() (localctx IShowRootMetricStmtContext) {
+	this := p
+	_ = this
+
+	localctx = NewShowRootMetricStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 40, SQLParserRULE_showRootMetricStmt)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(373)
+		p.Match(SQLParserT_SHOW)
+	}
+	{
+		p.SetState(374)
+		p.Match(SQLParserT_ROOT)
+	}
+	{
+		p.SetState(375)
+		p.Match(SQLParserT_METRIC)
+	}
+	{
+		p.SetState(376)
+		p.Match(SQLParserT_WHERE)
+	}
+	{
+		p.SetState(377)
+		p.MetricListFilter()
+	}
+
+	return localctx
+}
+
+// IShowBrokerMetricStmtContext is an interface to support dynamic dispatch.
+type IShowBrokerMetricStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	T_SHOW() antlr.TerminalNode
+	T_BROKER() antlr.TerminalNode
+	T_METRIC() antlr.TerminalNode
+	T_WHERE() antlr.TerminalNode
+	MetricListFilter() IMetricListFilterContext
+
+	// IsShowBrokerMetricStmtContext differentiates from other interfaces.
+	IsShowBrokerMetricStmtContext()
+}
+
+type ShowBrokerMetricStmtContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
@@ -4743,7 +5287,7 @@ func (p *SQLParser) ShowBrokerMetricStmt() (localctx IShowBrokerMetricStmtContex
 	_ = this
 
 	localctx = NewShowBrokerMetricStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 38, SQLParserRULE_showBrokerMetricStmt)
+	p.EnterRule(localctx, 42, SQLParserRULE_showBrokerMetricStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -4763,23 +5307,23 @@ func (p *SQLParser) ShowBrokerMetricStmt() (localctx IShowBrokerMetricStmtContex
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(346)
+		p.SetState(379)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(347)
+		p.SetState(380)
 		p.Match(SQLParserT_BROKER)
 	}
 	{
-		p.SetState(348)
+		p.SetState(381)
 		p.Match(SQLParserT_METRIC)
 	}
 	{
-		p.SetState(349)
+		p.SetState(382)
 		p.Match(SQLParserT_WHERE)
 	}
 	{
-		p.SetState(350)
+		p.SetState(383)
 		p.MetricListFilter()
 	}
 
@@ -4972,7 +5516,7 @@ func (p *SQLParser) ShowStorageMetricStmt() (localctx IShowStorageMetricStmtCont
 	_ = this
 
 	localctx = NewShowStorageMetricStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 40, SQLParserRULE_showStorageMetricStmt)
+	p.EnterRule(localctx, 44, SQLParserRULE_showStorageMetricStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -4992,34 +5536,34 @@ func (p *SQLParser) ShowStorageMetricStmt() (localctx IShowStorageMetricStmtCont
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(352)
+		p.SetState(385)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(353)
+		p.SetState(386)
 		p.Match(SQLParserT_STORAGE)
 	}
 	{
-		p.SetState(354)
+		p.SetState(387)
 		p.Match(SQLParserT_METRIC)
 	}
 	{
-		p.SetState(355)
+		p.SetState(388)
 		p.Match(SQLParserT_WHERE)
 	}
-	p.SetState(358)
+	p.SetState(391)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_STORAGE:
 		{
-			p.SetState(356)
+			p.SetState(389)
 			p.StorageFilter()
 		}
 
 	case SQLParserT_METRIC:
 		{
-			p.SetState(357)
+			p.SetState(390)
 			p.MetricListFilter()
 		}
 
@@ -5027,22 +5571,22 @@ func (p *SQLParser) ShowStorageMetricStmt() (localctx IShowStorageMetricStmtCont
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 	{
-		p.SetState(360)
+		p.SetState(393)
 		p.Match(SQLParserT_AND)
 	}
-	p.SetState(363)
+	p.SetState(396)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_STORAGE:
 		{
-			p.SetState(361)
+			p.SetState(394)
 			p.StorageFilter()
 		}
 
 	case SQLParserT_METRIC:
 		{
-			p.SetState(362)
+			p.SetState(395)
 			p.MetricListFilter()
 		}
 
@@ -5155,7 +5699,7 @@ func (p *SQLParser) CreateStorageStmt() (localctx ICreateStorageStmtContext) {
 	_ = this
 
 	localctx = NewCreateStorageStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 42, SQLParserRULE_createStorageStmt)
+	p.EnterRule(localctx, 46, SQLParserRULE_createStorageStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -5175,15 +5719,15 @@ func (p *SQLParser) CreateStorageStmt() (localctx ICreateStorageStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(365)
+		p.SetState(398)
 		p.Match(SQLParserT_CREATE)
 	}
 	{
-		p.SetState(366)
+		p.SetState(399)
 		p.Match(SQLParserT_STORAGE)
 	}
 	{
-		p.SetState(367)
+		p.SetState(400)
 		p.Json()
 	}
 
@@ -5292,7 +5836,7 @@ func (p *SQLParser) CreateBrokerStmt() (localctx ICreateBrokerStmtContext) {
 	_ = this
 
 	localctx = NewCreateBrokerStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 44, SQLParserRULE_createBrokerStmt)
+	p.EnterRule(localctx, 48, SQLParserRULE_createBrokerStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -5312,15 +5856,15 @@ func (p *SQLParser) CreateBrokerStmt() (localctx ICreateBrokerStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(369)
+		p.SetState(402)
 		p.Match(SQLParserT_CREATE)
 	}
 	{
-		p.SetState(370)
+		p.SetState(403)
 		p.Match(SQLParserT_BROKER)
 	}
 	{
-		p.SetState(371)
+		p.SetState(404)
 		p.Json()
 	}
 
@@ -5429,7 +5973,7 @@ func (p *SQLParser) RecoverStorageStmt() (localctx IRecoverStorageStmtContext) {
 	_ = this
 
 	localctx = NewRecoverStorageStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 46, SQLParserRULE_recoverStorageStmt)
+	p.EnterRule(localctx, 50, SQLParserRULE_recoverStorageStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -5449,15 +5993,15 @@ func (p *SQLParser) RecoverStorageStmt() (localctx IRecoverStorageStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(373)
+		p.SetState(406)
 		p.Match(SQLParserT_RECOVER)
 	}
 	{
-		p.SetState(374)
+		p.SetState(407)
 		p.Match(SQLParserT_STORAGE)
 	}
 	{
-		p.SetState(375)
+		p.SetState(408)
 		p.StorageName()
 	}
 
@@ -5549,7 +6093,7 @@ func (p *SQLParser) ShowSchemasStmt() (localctx IShowSchemasStmtContext) {
 	_ = this
 
 	localctx = NewShowSchemasStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 48, SQLParserRULE_showSchemasStmt)
+	p.EnterRule(localctx, 52, SQLParserRULE_showSchemasStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -5569,11 +6113,11 @@ func (p *SQLParser) ShowSchemasStmt() (localctx IShowSchemasStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(377)
+		p.SetState(410)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(378)
+		p.SetState(411)
 		p.Match(SQLParserT_SCHEMAS)
 	}
 
@@ -5682,7 +6226,7 @@ func (p *SQLParser) CreateDatabaseStmt() (localctx ICreateDatabaseStmtContext) {
 	_ = this
 
 	localctx = NewCreateDatabaseStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 50, SQLParserRULE_createDatabaseStmt)
+	p.EnterRule(localctx, 54, SQLParserRULE_createDatabaseStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -5702,15 +6246,15 @@ func (p *SQLParser) CreateDatabaseStmt() (localctx ICreateDatabaseStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(380)
+		p.SetState(413)
 		p.Match(SQLParserT_CREATE)
 	}
 	{
-		p.SetState(381)
+		p.SetState(414)
 		p.Match(SQLParserT_DATASBAE)
 	}
 	{
-		p.SetState(382)
+		p.SetState(415)
 		p.Json()
 	}
 
@@ -5819,7 +6363,7 @@ func (p *SQLParser) DropDatabaseStmt() (localctx IDropDatabaseStmtContext) {
 	_ = this
 
 	localctx = NewDropDatabaseStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 52, SQLParserRULE_dropDatabaseStmt)
+	p.EnterRule(localctx, 56, SQLParserRULE_dropDatabaseStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -5839,15 +6383,15 @@ func (p *SQLParser) DropDatabaseStmt() (localctx IDropDatabaseStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(384)
+		p.SetState(417)
 		p.Match(SQLParserT_DROP)
 	}
 	{
-		p.SetState(385)
+		p.SetState(418)
 		p.Match(SQLParserT_DATASBAE)
 	}
 	{
-		p.SetState(386)
+		p.SetState(419)
 		p.DatabaseName()
 	}
 
@@ -5939,7 +6483,7 @@ func (p *SQLParser) ShowDatabaseStmt() (localctx IShowDatabaseStmtContext) {
 	_ = this
 
 	localctx = NewShowDatabaseStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 54, SQLParserRULE_showDatabaseStmt)
+	p.EnterRule(localctx, 58, SQLParserRULE_showDatabaseStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -5959,11 +6503,11 @@ func (p *SQLParser) ShowDatabaseStmt() (localctx IShowDatabaseStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(388)
+		p.SetState(421)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(389)
+		p.SetState(422)
 		p.Match(SQLParserT_DATASBAES)
 	}
 
@@ -6104,7 +6648,7 @@ func (p *SQLParser) ShowNameSpacesStmt() (localctx IShowNameSpacesStmtContext) {
 	_ = this
 
 	localctx = NewShowNameSpacesStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 56, SQLParserRULE_showNameSpacesStmt)
+	p.EnterRule(localctx, 60, SQLParserRULE_showNameSpacesStmt)
 	var _la int
 
 	defer func() {
@@ -6125,43 +6669,43 @@ func (p *SQLParser) ShowNameSpacesStmt() (localctx IShowNameSpacesStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(391)
+		p.SetState(424)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(392)
+		p.SetState(425)
 		p.Match(SQLParserT_NAMESPACES)
 	}
-	p.SetState(397)
+	p.SetState(430)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_WHERE {
 		{
-			p.SetState(393)
+			p.SetState(426)
 			p.Match(SQLParserT_WHERE)
 		}
 		{
-			p.SetState(394)
+			p.SetState(427)
 			p.Match(SQLParserT_NAMESPACE)
 		}
 		{
-			p.SetState(395)
+			p.SetState(428)
 			p.Match(SQLParserT_EQUAL)
 		}
 		{
-			p.SetState(396)
+			p.SetState(429)
 			p.Prefix()
 		}
 
 	}
-	p.SetState(400)
+	p.SetState(433)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_LIMIT {
 		{
-			p.SetState(399)
+			p.SetState(432)
 			p.LimitClause()
 		}
 
@@ -6326,7 +6870,7 @@ func (p *SQLParser) ShowMetricsStmt() (localctx IShowMetricsStmtContext) {
 	_ = this
 
 	localctx = NewShowMetricsStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 58, SQLParserRULE_showMetricsStmt)
+	p.EnterRule(localctx, 62, SQLParserRULE_showMetricsStmt)
 	var _la int
 
 	defer func() {
@@ -6347,58 +6891,58 @@ func (p *SQLParser) ShowMetricsStmt() (localctx IShowMetricsStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(402)
+		p.SetState(435)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(403)
+		p.SetState(436)
 		p.Match(SQLParserT_METRICS)
 	}
-	p.SetState(406)
+	p.SetState(439)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_ON {
 		{
-			p.SetState(404)
+			p.SetState(437)
 			p.Match(SQLParserT_ON)
 		}
 		{
-			p.SetState(405)
+			p.SetState(438)
 			p.Namespace()
 		}
 
 	}
-	p.SetState(412)
+	p.SetState(445)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_WHERE {
 		{
-			p.SetState(408)
+			p.SetState(441)
 			p.Match(SQLParserT_WHERE)
 		}
 		{
-			p.SetState(409)
+			p.SetState(442)
 			p.Match(SQLParserT_METRIC)
 		}
 		{
-			p.SetState(410)
+			p.SetState(443)
 			p.Match(SQLParserT_EQUAL)
 		}
 		{
-			p.SetState(411)
+			p.SetState(444)
 			p.Prefix()
 		}
 
 	}
-	p.SetState(415)
+	p.SetState(448)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_LIMIT {
 		{
-			p.SetState(414)
+			p.SetState(447)
 			p.LimitClause()
 		}
 
@@ -6509,7 +7053,7 @@ func (p *SQLParser) ShowFieldsStmt() (localctx IShowFieldsStmtContext) {
 	_ = this
 
 	localctx = NewShowFieldsStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 60, SQLParserRULE_showFieldsStmt)
+	p.EnterRule(localctx, 64, SQLParserRULE_showFieldsStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -6529,15 +7073,15 @@ func (p *SQLParser) ShowFieldsStmt() (localctx IShowFieldsStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(417)
+		p.SetState(450)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(418)
+		p.SetState(451)
 		p.Match(SQLParserT_FIELDS)
 	}
 	{
-		p.SetState(419)
+		p.SetState(452)
 		p.FromClause()
 	}
 
@@ -6651,7 +7195,7 @@ func (p *SQLParser) ShowTagKeysStmt() (localctx IShowTagKeysStmtContext) {
 	_ = this
 
 	localctx = NewShowTagKeysStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 62, SQLParserRULE_showTagKeysStmt)
+	p.EnterRule(localctx, 66, SQLParserRULE_showTagKeysStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -6671,19 +7215,19 @@ func (p *SQLParser) ShowTagKeysStmt() (localctx IShowTagKeysStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(421)
+		p.SetState(454)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(422)
+		p.SetState(455)
 		p.Match(SQLParserT_TAG)
 	}
 	{
-		p.SetState(423)
+		p.SetState(456)
 		p.Match(SQLParserT_KEYS)
 	}
 	{
-		p.SetState(424)
+		p.SetState(457)
 		p.FromClause()
 	}
 
@@ -6708,6 +7252,9 @@ type IShowTagValuesStmtContext interface {
 	WithTagKey() IWithTagKeyContext
 	WhereClause() IWhereClauseContext
 	LimitClause() ILimitClauseContext
+	T_COUNT() antlr.TerminalNode
+	T_DISTINCT() antlr.TerminalNode
+	PrecisionClause() IPrecisionClauseContext
 
 	// IsShowTagValuesStmtContext differentiates from other interfaces.
 	IsShowTagValuesStmtContext()
@@ -6828,6 +7375,30 @@ func (s *ShowTagValuesStmtContext) LimitClause() ILimitClauseContext {
 	return t.(ILimitClauseContext)
 }
 
+func (s *ShowTagValuesStmtContext) T_COUNT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_COUNT, 0)
+}
+
+func (s *ShowTagValuesStmtContext) T_DISTINCT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DISTINCT, 0)
+}
+
+func (s *ShowTagValuesStmtContext) PrecisionClause() IPrecisionClauseContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IPrecisionClauseContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPrecisionClauseContext)
+}
+
 func (s *ShowTagValuesStmtContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -6863,7 +7434,7 @@ func (p *SQLParser) ShowTagValuesStmt() (localctx IShowTagValuesStmtContext) {
 	_ = this
 
 	localctx = NewShowTagValuesStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 64, SQLParserRULE_showTagValuesStmt)
+	p.EnterRule(localctx, 68, SQLParserRULE_showTagValuesStmt)
 	var _la int
 
 	defer func() {
@@ -6882,58 +7453,129 @@ func (p *SQLParser) ShowTagValuesStmt() (localctx IShowTagValuesStmtContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(426)
-		p.Match(SQLParserT_SHOW)
-	}
-	{
-		p.SetState(427)
-		p.Match(SQLParserT_TAG)
-	}
-	{
-		p.SetState(428)
-		p.Match(SQLParserT_VALUES)
-	}
-	{
-		p.SetState(429)
-		p.FromClause()
-	}
-	{
-		p.SetState(430)
-		p.Match(SQLParserT_WITH)
-	}
-	{
-		p.SetState(431)
-		p.Match(SQLParserT_KEY)
-	}
-	{
-		p.SetState(432)
-		p.Match(SQLParserT_EQUAL)
-	}
-	{
-		p.SetState(433)
-		p.WithTagKey()
-	}
-	p.SetState(435)
+	p.SetState(489)
 	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
-
-	if _la == SQLParserT_WHERE {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 22, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(434)
-			p.WhereClause()
+			p.SetState(459)
+			p.Match(SQLParserT_SHOW)
+		}
+		{
+			p.SetState(460)
+			p.Match(SQLParserT_TAG)
+		}
+		{
+			p.SetState(461)
+			p.Match(SQLParserT_VALUES)
+		}
+		{
+			p.SetState(462)
+			p.FromClause()
+		}
+		{
+			p.SetState(463)
+			p.Match(SQLParserT_WITH)
+		}
+		{
+			p.SetState(464)
+			p.Match(SQLParserT_KEY)
 		}
+		{
+			p.SetState(465)
+			p.Match(SQLParserT_EQUAL)
+		}
+		{
+			p.SetState(466)
+			p.WithTagKey()
+		}
+		p.SetState(468)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 
-	}
-	p.SetState(438)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
+		if _la == SQLParserT_WHERE {
+			{
+				p.SetState(467)
+				p.WhereClause()
+			}
 
-	if _la == SQLParserT_LIMIT {
+		}
+		p.SetState(471)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SQLParserT_LIMIT {
+			{
+				p.SetState(470)
+				p.LimitClause()
+			}
+
+		}
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(437)
-			p.LimitClause()
+			p.SetState(473)
+			p.Match(SQLParserT_SHOW)
+		}
+		{
+			p.SetState(474)
+			p.Match(SQLParserT_TAG)
+		}
+		{
+			p.SetState(475)
+			p.Match(SQLParserT_VALUES)
+		}
+		{
+			p.SetState(476)
+			p.Match(SQLParserT_COUNT)
+		}
+		{
+			p.SetState(477)
+			p.Match(SQLParserT_DISTINCT)
+		}
+		{
+			p.SetState(478)
+			p.FromClause()
+		}
+		{
+			p.SetState(479)
+			p.Match(SQLParserT_WITH)
+		}
+		{
+			p.SetState(480)
+			p.Match(SQLParserT_KEY)
+		}
+		{
+			p.SetState(481)
+			p.Match(SQLParserT_EQUAL)
+		}
+		{
+			p.SetState(482)
+			p.WithTagKey()
+		}
+		p.SetState(484)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SQLParserT_WHERE {
+			{
+				p.SetState(483)
+				p.WhereClause()
+			}
+
+		}
+		p.SetState(487)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SQLParserT_PRECISION {
+			{
+				p.SetState(486)
+				p.PrecisionClause()
+			}
+
 		}
 
 	}
@@ -7033,7 +7675,7 @@ func (p *SQLParser) Prefix() (localctx IPrefixContext) {
 	_ = this
 
 	localctx = NewPrefixContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 66, SQLParserRULE_prefix)
+	p.EnterRule(localctx, 70, SQLParserRULE_prefix)
 
 	defer func() {
 		p.ExitRule()
@@ -7053,7 +7695,7 @@ func (p *SQLParser) Prefix() (localctx IPrefixContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(440)
+		p.SetState(491)
 		p.Ident()
 	}
 
@@ -7152,7 +7794,7 @@ func (p *SQLParser) WithTagKey() (localctx IWithTagKeyContext) {
 	_ = this
 
 	localctx = NewWithTagKeyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 68, SQLParserRULE_withTagKey)
+	p.EnterRule(localctx, 72, SQLParserRULE_withTagKey)
 
 	defer func() {
 		p.ExitRule()
@@ -7172,7 +7814,7 @@ func (p *SQLParser) WithTagKey() (localctx IWithTagKeyContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(442)
+		p.SetState(493)
 		p.Ident()
 	}
 
@@ -7271,7 +7913,7 @@ func (p *SQLParser) Namespace() (localctx INamespaceContext) {
 	_ = this
 
 	localctx = NewNamespaceContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 70, SQLParserRULE_namespace)
+	p.EnterRule(localctx, 74, SQLParserRULE_namespace)
 
 	defer func() {
 		p.ExitRule()
@@ -7291,7 +7933,7 @@ func (p *SQLParser) Namespace() (localctx INamespaceContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(444)
+		p.SetState(495)
 		p.Ident()
 	}
 
@@ -7390,7 +8032,7 @@ func (p *SQLParser) DatabaseName() (localctx IDatabaseNameContext) {
 	_ = this
 
 	localctx = NewDatabaseNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 72, SQLParserRULE_databaseName)
+	p.EnterRule(localctx, 76, SQLParserRULE_databaseName)
 
 	defer func() {
 		p.ExitRule()
@@ -7410,7 +8052,7 @@ func (p *SQLParser) DatabaseName() (localctx IDatabaseNameContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(446)
+		p.SetState(497)
 		p.Ident()
 	}
 
@@ -7509,7 +8151,7 @@ func (p *SQLParser) StorageName() (localctx IStorageNameContext) {
 	_ = this
 
 	localctx = NewStorageNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 74, SQLParserRULE_storageName)
+	p.EnterRule(localctx, 78, SQLParserRULE_storageName)
 
 	defer func() {
 		p.ExitRule()
@@ -7529,7 +8171,7 @@ func (p *SQLParser) StorageName() (localctx IStorageNameContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(448)
+		p.SetState(499)
 		p.Ident()
 	}
 
@@ -7628,7 +8270,7 @@ func (p *SQLParser) RequestID() (localctx IRequestIDContext) {
 	_ = this
 
 	localctx = NewRequestIDContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 76, SQLParserRULE_requestID)
+	p.EnterRule(localctx, 80, SQLParserRULE_requestID)
 
 	defer func() {
 		p.ExitRule()
@@ -7648,7 +8290,7 @@ func (p *SQLParser) RequestID() (localctx IRequestIDContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(450)
+		p.SetState(501)
 		p.Ident()
 	}
 
@@ -7740,7 +8382,7 @@ func (p *SQLParser) Source() (localctx ISourceContext) {
 	_ = this
 
 	localctx = NewSourceContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 78, SQLParserRULE_source)
+	p.EnterRule(localctx, 82, SQLParserRULE_source)
 	var _la int
 
 	defer func() {
@@ -7761,7 +8403,7 @@ func (p *SQLParser) Source() (localctx ISourceContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(452)
+		p.SetState(503)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == SQLParserT_STATE_REPO || _la == SQLParserT_STATE_MACHINE) {
@@ -7786,9 +8428,11 @@ type IQueryStmtContext interface {
 	SourceAndSelect() ISourceAndSelectContext
 	T_EXPLAIN() antlr.TerminalNode
 	WhereClause() IWhereClauseContext
+	TimeRangeClause() ITimeRangeClauseContext
 	GroupByClause() IGroupByClauseContext
 	OrderByClause() IOrderByClauseContext
 	LimitClause() ILimitClauseContext
+	OffsetClause() IOffsetClauseContext
 	T_WITH_VALUE() antlr.TerminalNode
 
 	// IsQueryStmtContext differentiates from other interfaces.
@@ -7858,6 +8502,22 @@ func (s *QueryStmtContext) WhereClause() IWhereClauseContext {
 	return t.(IWhereClauseContext)
 }
 
+func (s *QueryStmtContext) TimeRangeClause() ITimeRangeClauseContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ITimeRangeClauseContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITimeRangeClauseContext)
+}
+
 func (s *QueryStmtContext) GroupByClause() IGroupByClauseContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
@@ -7906,6 +8566,22 @@ func (s *QueryStmtContext) LimitClause() ILimitClauseContext {
 	return t.(ILimitClauseContext)
 }
 
+func (s *QueryStmtContext) OffsetClause() IOffsetClauseContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IOffsetClauseContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IOffsetClauseContext)
+}
+
 func (s *QueryStmtContext) T_WITH_VALUE() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_WITH_VALUE, 0)
 }
@@ -7945,7 +8621,7 @@ func (p *SQLParser) QueryStmt() (localctx IQueryStmtContext) {
 	_ = this
 
 	localctx = NewQueryStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 80, SQLParserRULE_queryStmt)
+	p.EnterRule(localctx, 84, SQLParserRULE_queryStmt)
 	var _la int
 
 	defer func() {
@@ -7965,72 +8641,94 @@ func (p *SQLParser) QueryStmt() (localctx IQueryStmtContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(455)
+	p.SetState(506)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_EXPLAIN {
 		{
-			p.SetState(454)
+			p.SetState(505)
 			p.Match(SQLParserT_EXPLAIN)
 		}
 
 	}
 	{
-		p.SetState(457)
+		p.SetState(508)
 		p.SourceAndSelect()
 	}
-	p.SetState(459)
+	p.SetState(510)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_WHERE {
 		{
-			p.SetState(458)
+			p.SetState(509)
 			p.WhereClause()
 		}
 
 	}
-	p.SetState(462)
+	p.SetState(513)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if (int64((_la-87)) & ^0x3f) == 0 && ((int64(1)<<(_la-87))&4099) != 0 {
+		{
+			p.SetState(512)
+			p.TimeRangeClause()
+		}
+
+	}
+	p.SetState(516)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_GROUP {
 		{
-			p.SetState(461)
+			p.SetState(515)
 			p.GroupByClause()
 		}
 
 	}
-	p.SetState(465)
+	p.SetState(519)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_ORDER {
 		{
-			p.SetState(464)
+			p.SetState(518)
 			p.OrderByClause()
 		}
 
 	}
-	p.SetState(468)
+	p.SetState(522)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_LIMIT {
 		{
-			p.SetState(467)
+			p.SetState(521)
 			p.LimitClause()
 		}
 
 	}
-	p.SetState(471)
+	p.SetState(525)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SQLParserT_OFFSET {
+		{
+			p.SetState(524)
+			p.OffsetClause()
+		}
+
+	}
+	p.SetState(528)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_WITH_VALUE {
 		{
-			p.SetState(470)
+			p.SetState(527)
 			p.Match(SQLParserT_WITH_VALUE)
 		}
 
@@ -8148,7 +8846,7 @@ func (p *SQLParser) SourceAndSelect() (localctx ISourceAndSelectContext) {
 	_ = this
 
 	localctx = NewSourceAndSelectContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 82, SQLParserRULE_sourceAndSelect)
+	p.EnterRule(localctx, 86, SQLParserRULE_sourceAndSelect)
 
 	defer func() {
 		p.ExitRule()
@@ -8166,29 +8864,29 @@ func (p *SQLParser) SourceAndSelect() (localctx ISourceAndSelectContext) {
 		}
 	}()
 
-	p.SetState(479)
+	p.SetState(536)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_SELECT:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(473)
+			p.SetState(530)
 			p.SelectExpr()
 		}
 		{
-			p.SetState(474)
+			p.SetState(531)
 			p.FromClause()
 		}
 
 	case SQLParserT_FROM:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(476)
+			p.SetState(533)
 			p.FromClause()
 		}
 		{
-			p.SetState(477)
+			p.SetState(534)
 			p.SelectExpr()
 		}
 
@@ -8296,7 +8994,7 @@ func (p *SQLParser) SelectExpr() (localctx ISelectExprContext) {
 	_ = this
 
 	localctx = NewSelectExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 84, SQLParserRULE_selectExpr)
+	p.EnterRule(localctx, 88, SQLParserRULE_selectExpr)
 
 	defer func() {
 		p.ExitRule()
@@ -8316,11 +9014,11 @@ func (p *SQLParser) SelectExpr() (localctx ISelectExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(481)
+		p.SetState(538)
 		p.Match(SQLParserT_SELECT)
 	}
 	{
-		p.SetState(482)
+		p.SetState(539)
 		p.Fields()
 	}
 
@@ -8455,7 +9153,7 @@ func (p *SQLParser) Fields() (localctx IFieldsContext) {
 	_ = this
 
 	localctx = NewFieldsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 86, SQLParserRULE_fields)
+	p.EnterRule(localctx, 90, SQLParserRULE_fields)
 	var _la int
 
 	defer func() {
@@ -8476,24 +9174,24 @@ func (p *SQLParser) Fields() (localctx IFieldsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(484)
+		p.SetState(541)
 		p.Field()
 	}
-	p.SetState(489)
+	p.SetState(546)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(485)
+			p.SetState(542)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(486)
+			p.SetState(543)
 			p.Field()
 		}
 
-		p.SetState(491)
+		p.SetState(548)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -8610,7 +9308,7 @@ func (p *SQLParser) Field() (localctx IFieldContext) {
 	_ = this
 
 	localctx = NewFieldContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 88, SQLParserRULE_field)
+	p.EnterRule(localctx, 92, SQLParserRULE_field)
 	var _la int
 
 	defer func() {
@@ -8631,16 +9329,16 @@ func (p *SQLParser) Field() (localctx IFieldContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(492)
+		p.SetState(549)
 		p.fieldExpr(0)
 	}
-	p.SetState(494)
+	p.SetState(551)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_AS {
 		{
-			p.SetState(493)
+			p.SetState(550)
 			p.Alias()
 		}
 
@@ -8746,7 +9444,7 @@ func (p *SQLParser) Alias() (localctx IAliasContext) {
 	_ = this
 
 	localctx = NewAliasContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 90, SQLParserRULE_alias)
+	p.EnterRule(localctx, 94, SQLParserRULE_alias)
 
 	defer func() {
 		p.ExitRule()
@@ -8766,11 +9464,11 @@ func (p *SQLParser) Alias() (localctx IAliasContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(496)
+		p.SetState(553)
 		p.Match(SQLParserT_AS)
 	}
 	{
-		p.SetState(497)
+		p.SetState(554)
 		p.Ident()
 	}
 
@@ -8879,7 +9577,7 @@ func (p *SQLParser) StorageFilter() (localctx IStorageFilterContext) {
 	_ = this
 
 	localctx = NewStorageFilterContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 92, SQLParserRULE_storageFilter)
+	p.EnterRule(localctx, 96, SQLParserRULE_storageFilter)
 
 	defer func() {
 		p.ExitRule()
@@ -8899,15 +9597,15 @@ func (p *SQLParser) StorageFilter() (localctx IStorageFilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(499)
+		p.SetState(556)
 		p.Match(SQLParserT_STORAGE)
 	}
 	{
-		p.SetState(500)
+		p.SetState(557)
 		p.Match(SQLParserT_EQUAL)
 	}
 	{
-		p.SetState(501)
+		p.SetState(558)
 		p.Ident()
 	}
 
@@ -9016,7 +9714,7 @@ func (p *SQLParser) BrokerFilter() (localctx IBrokerFilterContext) {
 	_ = this
 
 	localctx = NewBrokerFilterContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 94, SQLParserRULE_brokerFilter)
+	p.EnterRule(localctx, 98, SQLParserRULE_brokerFilter)
 
 	defer func() {
 		p.ExitRule()
@@ -9036,15 +9734,15 @@ func (p *SQLParser) BrokerFilter() (localctx IBrokerFilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(503)
+		p.SetState(560)
 		p.Match(SQLParserT_BROKER)
 	}
 	{
-		p.SetState(504)
+		p.SetState(561)
 		p.Match(SQLParserT_EQUAL)
 	}
 	{
-		p.SetState(505)
+		p.SetState(562)
 		p.Ident()
 	}
 
@@ -9153,7 +9851,7 @@ func (p *SQLParser) DatabaseFilter() (localctx IDatabaseFilterContext) {
 	_ = this
 
 	localctx = NewDatabaseFilterContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 96, SQLParserRULE_databaseFilter)
+	p.EnterRule(localctx, 100, SQLParserRULE_databaseFilter)
 
 	defer func() {
 		p.ExitRule()
@@ -9173,15 +9871,15 @@ func (p *SQLParser) DatabaseFilter() (localctx IDatabaseFilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(507)
+		p.SetState(564)
 		p.Match(SQLParserT_DATASBAE)
 	}
 	{
-		p.SetState(508)
+		p.SetState(565)
 		p.Match(SQLParserT_EQUAL)
 	}
 	{
-		p.SetState(509)
+		p.SetState(566)
 		p.Ident()
 	}
 
@@ -9290,7 +9988,7 @@ func (p *SQLParser) TypeFilter() (localctx ITypeFilterContext) {
 	_ = this
 
 	localctx = NewTypeFilterContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 98, SQLParserRULE_typeFilter)
+	p.EnterRule(localctx, 102, SQLParserRULE_typeFilter)
 
 	defer func() {
 		p.ExitRule()
@@ -9310,15 +10008,15 @@ func (p *SQLParser) TypeFilter() (localctx ITypeFilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(511)
+		p.SetState(568)
 		p.Match(SQLParserT_TYPE)
 	}
 	{
-		p.SetState(512)
+		p.SetState(569)
 		p.Match(SQLParserT_EQUAL)
 	}
 	{
-		p.SetState(513)
+		p.SetState(570)
 		p.Ident()
 	}
 
@@ -9334,7 +10032,10 @@ type IFromClauseContext interface {
 
 	// Getter signatures
 	T_FROM() antlr.TerminalNode
-	MetricName() IMetricNameContext
+	AllMetricName() []IMetricNameContext
+	MetricName(i int) IMetricNameContext
+	AllT_COMMA() []antlr.TerminalNode
+	T_COMMA(i int) antlr.TerminalNode
 	T_ON() antlr.TerminalNode
 	Namespace() INamespaceContext
 
@@ -9367,18 +10068,43 @@ func NewFromClauseContext(parser antlr.Parser, parent antlr.ParserRuleContext, i
 	return p
 }
 
-func (s *FromClauseContext) GetParser() antlr.Parser { return s.parser }
+func (s *FromClauseContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *FromClauseContext) T_FROM() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_FROM, 0)
+}
+
+func (s *FromClauseContext) AllMetricName() []IMetricNameContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IMetricNameContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IMetricNameContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IMetricNameContext); ok {
+			tst[i] = t.(IMetricNameContext)
+			i++
+		}
+	}
 
-func (s *FromClauseContext) T_FROM() antlr.TerminalNode {
-	return s.GetToken(SQLParserT_FROM, 0)
+	return tst
 }
 
-func (s *FromClauseContext) MetricName() IMetricNameContext {
+func (s *FromClauseContext) MetricName(i int) IMetricNameContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IMetricNameContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -9389,6 +10115,14 @@ func (s *FromClauseContext) MetricName() IMetricNameContext {
 	return t.(IMetricNameContext)
 }
 
+func (s *FromClauseContext) AllT_COMMA() []antlr.TerminalNode {
+	return s.GetTokens(SQLParserT_COMMA)
+}
+
+func (s *FromClauseContext) T_COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(SQLParserT_COMMA, i)
+}
+
 func (s *FromClauseContext) T_ON() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_ON, 0)
 }
@@ -9444,7 +10178,7 @@ func (p *SQLParser) FromClause() (localctx IFromClauseContext) {
 	_ = this
 
 	localctx = NewFromClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 100, SQLParserRULE_fromClause)
+	p.EnterRule(localctx, 104, SQLParserRULE_fromClause)
 	var _la int
 
 	defer func() {
@@ -9465,24 +10199,42 @@ func (p *SQLParser) FromClause() (localctx IFromClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(515)
+		p.SetState(572)
 		p.Match(SQLParserT_FROM)
 	}
 	{
-		p.SetState(516)
+		p.SetState(573)
 		p.MetricName()
 	}
-	p.SetState(519)
+	p.SetState(578)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == SQLParserT_COMMA {
+		{
+			p.SetState(574)
+			p.Match(SQLParserT_COMMA)
+		}
+		{
+			p.SetState(575)
+			p.MetricName()
+		}
+
+		p.SetState(580)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	p.SetState(583)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_ON {
 		{
-			p.SetState(517)
+			p.SetState(581)
 			p.Match(SQLParserT_ON)
 		}
 		{
-			p.SetState(518)
+			p.SetState(582)
 			p.Namespace()
 		}
 
@@ -9588,7 +10340,7 @@ func (p *SQLParser) WhereClause() (localctx IWhereClauseContext) {
 	_ = this
 
 	localctx = NewWhereClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 102, SQLParserRULE_whereClause)
+	p.EnterRule(localctx, 106, SQLParserRULE_whereClause)
 
 	defer func() {
 		p.ExitRule()
@@ -9608,11 +10360,11 @@ func (p *SQLParser) WhereClause() (localctx IWhereClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(521)
+		p.SetState(585)
 		p.Match(SQLParserT_WHERE)
 	}
 	{
-		p.SetState(522)
+		p.SetState(586)
 		p.ConditionExpr()
 	}
 
@@ -9733,7 +10485,7 @@ func (p *SQLParser) ConditionExpr() (localctx IConditionExprContext) {
 	_ = this
 
 	localctx = NewConditionExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 104, SQLParserRULE_conditionExpr)
+	p.EnterRule(localctx, 108, SQLParserRULE_conditionExpr)
 	var _la int
 
 	defer func() {
@@ -9752,48 +10504,48 @@ func (p *SQLParser) ConditionExpr() (localctx IConditionExprContext) {
 		}
 	}()
 
-	p.SetState(534)
+	p.SetState(598)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 29, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 37, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(524)
+			p.SetState(588)
 			p.tagFilterExpr(0)
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(525)
+			p.SetState(589)
 			p.tagFilterExpr(0)
 		}
 		{
-			p.SetState(526)
+			p.SetState(590)
 			p.Match(SQLParserT_AND)
 		}
 		{
-			p.SetState(527)
+			p.SetState(591)
 			p.TimeRangeExpr()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(529)
+			p.SetState(593)
 			p.TimeRangeExpr()
 		}
-		p.SetState(532)
+		p.SetState(596)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 
 		if _la == SQLParserT_AND {
 			{
-				p.SetState(530)
+				p.SetState(594)
 				p.Match(SQLParserT_AND)
 			}
 			{
-				p.SetState(531)
+				p.SetState(595)
 				p.tagFilterExpr(0)
 			}
 
@@ -10041,8 +10793,8 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 	localctx = NewTagFilterExprContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx ITagFilterExprContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 106
-	p.EnterRecursionRule(localctx, 106, SQLParserRULE_tagFilterExpr, _p)
+	_startState := 110
+	p.EnterRecursionRule(localctx, 110, SQLParserRULE_tagFilterExpr, _p)
 	var _la int
 
 	defer func() {
@@ -10064,75 +10816,75 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(564)
+	p.SetState(628)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 32, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 40, p.GetParserRuleContext()) {
 	case 1:
 		{
-			p.SetState(537)
+			p.SetState(601)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(538)
+			p.SetState(602)
 			p.tagFilterExpr(0)
 		}
 		{
-			p.SetState(539)
+			p.SetState(603)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 	case 2:
 		{
-			p.SetState(541)
+			p.SetState(605)
 			p.TagKey()
 		}
-		p.SetState(550)
+		p.SetState(614)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
 		case SQLParserT_EQUAL:
 			{
-				p.SetState(542)
+				p.SetState(606)
 				p.Match(SQLParserT_EQUAL)
 			}
 
 		case SQLParserT_LIKE:
 			{
-				p.SetState(543)
+				p.SetState(607)
 				p.Match(SQLParserT_LIKE)
 			}
 
 		case SQLParserT_NOT:
 			{
-				p.SetState(544)
+				p.SetState(608)
 				p.Match(SQLParserT_NOT)
 			}
 			{
-				p.SetState(545)
+				p.SetState(609)
 				p.Match(SQLParserT_LIKE)
 			}
 
 		case SQLParserT_REGEXP:
 			{
-				p.SetState(546)
+				p.SetState(610)
 				p.Match(SQLParserT_REGEXP)
 			}
 
 		case SQLParserT_NEQREGEXP:
 			{
-				p.SetState(547)
+				p.SetState(611)
 				p.Match(SQLParserT_NEQREGEXP)
 			}
 
 		case SQLParserT_NOTEQUAL:
 			{
-				p.SetState(548)
+				p.SetState(612)
 				p.Match(SQLParserT_NOTEQUAL)
 			}
 
 		case SQLParserT_NOTEQUAL2:
 			{
-				p.SetState(549)
+				p.SetState(613)
 				p.Match(SQLParserT_NOTEQUAL2)
 			}
 
@@ -10140,32 +10892,32 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 		{
-			p.SetState(552)
+			p.SetState(616)
 			p.TagValue()
 		}
 
 	case 3:
 		{
-			p.SetState(554)
+			p.SetState(618)
 			p.TagKey()
 		}
-		p.SetState(558)
+		p.SetState(622)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
 		case SQLParserT_IN:
 			{
-				p.SetState(555)
+				p.SetState(619)
 				p.Match(SQLParserT_IN)
 			}
 
 		case SQLParserT_NOT:
 			{
-				p.SetState(556)
+				p.SetState(620)
 				p.Match(SQLParserT_NOT)
 			}
 			{
-				p.SetState(557)
+				p.SetState(621)
 				p.Match(SQLParserT_IN)
 			}
 
@@ -10173,23 +10925,23 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 		{
-			p.SetState(560)
+			p.SetState(624)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(561)
+			p.SetState(625)
 			p.TagValueList()
 		}
 		{
-			p.SetState(562)
+			p.SetState(626)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(571)
+	p.SetState(635)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 33, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 41, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
@@ -10199,13 +10951,13 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 			_prevctx = localctx
 			localctx = NewTagFilterExprContext(p, _parentctx, _parentState)
 			p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_tagFilterExpr)
-			p.SetState(566)
+			p.SetState(630)
 
 			if !(p.Precpred(p.GetParserRuleContext(), 1)) {
 				panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 1)", ""))
 			}
 			{
-				p.SetState(567)
+				p.SetState(631)
 				_la = p.GetTokenStream().LA(1)
 
 				if !(_la == SQLParserT_AND || _la == SQLParserT_OR) {
@@ -10216,14 +10968,14 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 				}
 			}
 			{
-				p.SetState(568)
+				p.SetState(632)
 				p.tagFilterExpr(2)
 			}
 
 		}
-		p.SetState(573)
+		p.SetState(637)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 33, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 41, p.GetParserRuleContext())
 	}
 
 	return localctx
@@ -10357,7 +11109,7 @@ func (p *SQLParser) TagValueList() (localctx ITagValueListContext) {
 	_ = this
 
 	localctx = NewTagValueListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 108, SQLParserRULE_tagValueList)
+	p.EnterRule(localctx, 112, SQLParserRULE_tagValueList)
 	var _la int
 
 	defer func() {
@@ -10378,24 +11130,24 @@ func (p *SQLParser) TagValueList() (localctx ITagValueListContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(574)
+		p.SetState(638)
 		p.TagValue()
 	}
-	p.SetState(579)
+	p.SetState(643)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(575)
+			p.SetState(639)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(576)
+			p.SetState(640)
 			p.TagValue()
 		}
 
-		p.SetState(581)
+		p.SetState(645)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -10515,7 +11267,7 @@ func (p *SQLParser) MetricListFilter() (localctx IMetricListFilterContext) {
 	_ = this
 
 	localctx = NewMetricListFilterContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 110, SQLParserRULE_metricListFilter)
+	p.EnterRule(localctx, 114, SQLParserRULE_metricListFilter)
 
 	defer func() {
 		p.ExitRule()
@@ -10535,24 +11287,24 @@ func (p *SQLParser) MetricListFilter() (localctx IMetricListFilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(582)
+		p.SetState(646)
 		p.Match(SQLParserT_METRIC)
 	}
 	{
-		p.SetState(583)
+		p.SetState(647)
 		p.Match(SQLParserT_IN)
 	}
 
 	{
-		p.SetState(584)
+		p.SetState(648)
 		p.Match(SQLParserT_OPEN_P)
 	}
 	{
-		p.SetState(585)
+		p.SetState(649)
 		p.MetricList()
 	}
 	{
-		p.SetState(586)
+		p.SetState(650)
 		p.Match(SQLParserT_CLOSE_P)
 	}
 
@@ -10687,7 +11439,7 @@ func (p *SQLParser) MetricList() (localctx IMetricListContext) {
 	_ = this
 
 	localctx = NewMetricListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 112, SQLParserRULE_metricList)
+	p.EnterRule(localctx, 116, SQLParserRULE_metricList)
 	var _la int
 
 	defer func() {
@@ -10708,24 +11460,24 @@ func (p *SQLParser) MetricList() (localctx IMetricListContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(588)
+		p.SetState(652)
 		p.Ident()
 	}
-	p.SetState(593)
+	p.SetState(657)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(589)
+			p.SetState(653)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(590)
+			p.SetState(654)
 			p.Ident()
 		}
 
-		p.SetState(595)
+		p.SetState(659)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -10856,7 +11608,7 @@ func (p *SQLParser) TimeRangeExpr() (localctx ITimeRangeExprContext) {
 	_ = this
 
 	localctx = NewTimeRangeExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 114, SQLParserRULE_timeRangeExpr)
+	p.EnterRule(localctx, 118, SQLParserRULE_timeRangeExpr)
 
 	defer func() {
 		p.ExitRule()
@@ -10876,19 +11628,19 @@ func (p *SQLParser) TimeRangeExpr() (localctx ITimeRangeExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(596)
+		p.SetState(660)
 		p.TimeExpr()
 	}
-	p.SetState(599)
+	p.SetState(663)
 	p.GetErrorHandler().Sync(p)
 
-	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 36, p.GetParserRuleContext()) == 1 {
+	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 44, p.GetParserRuleContext()) == 1 {
 		{
-			p.SetState(597)
+			p.SetState(661)
 			p.Match(SQLParserT_AND)
 		}
 		{
-			p.SetState(598)
+			p.SetState(662)
 			p.TimeExpr()
 		}
 
@@ -11028,7 +11780,7 @@ func (p *SQLParser) TimeExpr() (localctx ITimeExprContext) {
 	_ = this
 
 	localctx = NewTimeExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 116, SQLParserRULE_timeExpr)
+	p.EnterRule(localctx, 120, SQLParserRULE_timeExpr)
 
 	defer func() {
 		p.ExitRule()
@@ -11048,25 +11800,25 @@ func (p *SQLParser) TimeExpr() (localctx ITimeExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(601)
+		p.SetState(665)
 		p.Match(SQLParserT_TIME)
 	}
 	{
-		p.SetState(602)
+		p.SetState(666)
 		p.BinaryOperator()
 	}
-	p.SetState(605)
+	p.SetState(669)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 37, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 45, p.GetParserRuleContext()) {
 	case 1:
 		{
-			p.SetState(603)
+			p.SetState(667)
 			p.NowExpr()
 		}
 
 	case 2:
 		{
-			p.SetState(604)
+			p.SetState(668)
 			p.Ident()
 		}
 
@@ -11184,7 +11936,7 @@ func (p *SQLParser) NowExpr() (localctx INowExprContext) {
 	_ = this
 
 	localctx = NewNowExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 118, SQLParserRULE_nowExpr)
+	p.EnterRule(localctx, 122, SQLParserRULE_nowExpr)
 	var _la int
 
 	defer func() {
@@ -11205,16 +11957,16 @@ func (p *SQLParser) NowExpr() (localctx INowExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(607)
+		p.SetState(671)
 		p.NowFunc()
 	}
-	p.SetState(609)
+	p.SetState(673)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if (int64((_la-122)) & ^0x3f) == 0 && ((int64(1)<<(_la-122))&131) != 0 {
+	if (int64((_la-139)) & ^0x3f) == 0 && ((int64(1)<<(_la-139))&131) != 0 {
 		{
-			p.SetState(608)
+			p.SetState(672)
 			p.DurationLit()
 		}
 
@@ -11330,7 +12082,7 @@ func (p *SQLParser) NowFunc() (localctx INowFuncContext) {
 	_ = this
 
 	localctx = NewNowFuncContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 120, SQLParserRULE_nowFunc)
+	p.EnterRule(localctx, 124, SQLParserRULE_nowFunc)
 	var _la int
 
 	defer func() {
@@ -11351,32 +12103,265 @@ func (p *SQLParser) NowFunc() (localctx INowFuncContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(611)
+		p.SetState(675)
 		p.Match(SQLParserT_NOW)
 	}
 	{
-		p.SetState(612)
+		p.SetState(676)
 		p.Match(SQLParserT_OPEN_P)
 	}
-	p.SetState(614)
+	p.SetState(678)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if ((int64((_la-6)) & ^0x3f) == 0 && ((int64(1)<<(_la-6))&-65537) != 0) || ((int64((_la-70)) & ^0x3f) == 0 && ((int64(1)<<(_la-70))&2068278146049769471) != 0) {
+	if ((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&-33554496) != 0) || ((int64((_la-64)) & ^0x3f) == 0 && ((int64(1)<<(_la-64))&144115188075855871) != 0) || ((int64((_la-137)) & ^0x3f) == 0 && ((int64(1)<<(_la-137))&1837) != 0) {
 		{
-			p.SetState(613)
+			p.SetState(677)
 			p.ExprFuncParams()
 		}
 
 	}
 	{
-		p.SetState(616)
+		p.SetState(680)
 		p.Match(SQLParserT_CLOSE_P)
 	}
 
 	return localctx
 }
 
+// ITimeRangeClauseContext is an interface to support dynamic dispatch.
+type ITimeRangeClauseContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	T_LAST() antlr.TerminalNode
+	AllDurationLit() []IDurationLitContext
+	DurationLit(i int) IDurationLitContext
+	T_SINCE() antlr.TerminalNode
+	AllT_AGO() []antlr.TerminalNode
+	T_AGO(i int) antlr.TerminalNode
+	T_UNTIL() antlr.TerminalNode
+
+	// IsTimeRangeClauseContext differentiates from other interfaces.
+	IsTimeRangeClauseContext()
+}
+
+type TimeRangeClauseContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyTimeRangeClauseContext() *TimeRangeClauseContext {
+	var p = new(TimeRangeClauseContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_timeRangeClause
+	return p
+}
+
+func (*TimeRangeClauseContext) IsTimeRangeClauseContext() {}
+
+func NewTimeRangeClauseContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TimeRangeClauseContext {
+	var p = new(TimeRangeClauseContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_timeRangeClause
+
+	return p
+}
+
+func (s *TimeRangeClauseContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *TimeRangeClauseContext) T_LAST() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LAST, 0)
+}
+
+func (s *TimeRangeClauseContext) AllDurationLit() []IDurationLitContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IDurationLitContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IDurationLitContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IDurationLitContext); ok {
+			tst[i] = t.(IDurationLitContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *TimeRangeClauseContext) DurationLit(i int) IDurationLitContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDurationLitContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDurationLitContext)
+}
+
+func (s *TimeRangeClauseContext) T_SINCE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SINCE, 0)
+}
+
+func (s *TimeRangeClauseContext) AllT_AGO() []antlr.TerminalNode {
+	return s.GetTokens(SQLParserT_AGO)
+}
+
+func (s *TimeRangeClauseContext) T_AGO(i int) antlr.TerminalNode {
+	return s.GetToken(SQLParserT_AGO, i)
+}
+
+func (s *TimeRangeClauseContext) T_UNTIL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_UNTIL, 0)
+}
+
+func (s *TimeRangeClauseContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TimeRangeClauseContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *TimeRangeClauseContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterTimeRangeClause(s)
+	}
+}
+
+func (s *TimeRangeClauseContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitTimeRangeClause(s)
+	}
+}
+
+func (s *TimeRangeClauseContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SQLVisitor:
+		return t.VisitTimeRangeClause(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SQLParser) TimeRangeClause() (localctx ITimeRangeClauseContext) {
+	this := p
+	_ = this
+
+	localctx = NewTimeRangeClauseContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 126, SQLParserRULE_timeRangeClause)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(697)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SQLParserT_LAST:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(682)
+			p.Match(SQLParserT_LAST)
+		}
+		{
+			p.SetState(683)
+			p.DurationLit()
+		}
+
+	case SQLParserT_SINCE:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(684)
+			p.Match(SQLParserT_SINCE)
+		}
+		{
+			p.SetState(685)
+			p.DurationLit()
+		}
+		{
+			p.SetState(686)
+			p.Match(SQLParserT_AGO)
+		}
+		p.SetState(691)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SQLParserT_UNTIL {
+			{
+				p.SetState(687)
+				p.Match(SQLParserT_UNTIL)
+			}
+			{
+				p.SetState(688)
+				p.DurationLit()
+			}
+			{
+				p.SetState(689)
+				p.Match(SQLParserT_AGO)
+			}
+
+		}
+
+	case SQLParserT_UNTIL:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(693)
+			p.Match(SQLParserT_UNTIL)
+		}
+		{
+			p.SetState(694)
+			p.DurationLit()
+		}
+		{
+			p.SetState(695)
+			p.Match(SQLParserT_AGO)
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
 // IGroupByClauseContext is an interface to support dynamic dispatch.
 type IGroupByClauseContext interface {
 	antlr.ParserRuleContext
@@ -11528,7 +12513,7 @@ func (p *SQLParser) GroupByClause() (localctx IGroupByClauseContext) {
 	_ = this
 
 	localctx = NewGroupByClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 122, SQLParserRULE_groupByClause)
+	p.EnterRule(localctx, 128, SQLParserRULE_groupByClause)
 	var _la int
 
 	defer func() {
@@ -11549,47 +12534,47 @@ func (p *SQLParser) GroupByClause() (localctx IGroupByClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(618)
+		p.SetState(699)
 		p.Match(SQLParserT_GROUP)
 	}
 	{
-		p.SetState(619)
+		p.SetState(700)
 		p.Match(SQLParserT_BY)
 	}
 	{
-		p.SetState(620)
+		p.SetState(701)
 		p.GroupByKeys()
 	}
-	p.SetState(626)
+	p.SetState(707)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_FILL {
 		{
-			p.SetState(621)
+			p.SetState(702)
 			p.Match(SQLParserT_FILL)
 		}
 		{
-			p.SetState(622)
+			p.SetState(703)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(623)
+			p.SetState(704)
 			p.FillOption()
 		}
 		{
-			p.SetState(624)
+			p.SetState(705)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 	}
-	p.SetState(629)
+	p.SetState(710)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_HAVING {
 		{
-			p.SetState(628)
+			p.SetState(709)
 			p.HavingClause()
 		}
 
@@ -11726,7 +12711,7 @@ func (p *SQLParser) GroupByKeys() (localctx IGroupByKeysContext) {
 	_ = this
 
 	localctx = NewGroupByKeysContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 124, SQLParserRULE_groupByKeys)
+	p.EnterRule(localctx, 130, SQLParserRULE_groupByKeys)
 	var _la int
 
 	defer func() {
@@ -11747,24 +12732,24 @@ func (p *SQLParser) GroupByKeys() (localctx IGroupByKeysContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(631)
+		p.SetState(712)
 		p.GroupByKey()
 	}
-	p.SetState(636)
+	p.SetState(717)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(632)
+			p.SetState(713)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(633)
+			p.SetState(714)
 			p.GroupByKey()
 		}
 
-		p.SetState(638)
+		p.SetState(719)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -11781,6 +12766,7 @@ type IGroupByKeyContext interface {
 
 	// Getter signatures
 	Ident() IIdentContext
+	T_MUL() antlr.TerminalNode
 	T_TIME() antlr.TerminalNode
 	T_OPEN_P() antlr.TerminalNode
 	DurationLit() IDurationLitContext
@@ -11833,6 +12819,10 @@ func (s *GroupByKeyContext) Ident() IIdentContext {
 	return t.(IIdentContext)
 }
 
+func (s *GroupByKeyContext) T_MUL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MUL, 0)
+}
+
 func (s *GroupByKeyContext) T_TIME() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_TIME, 0)
 }
@@ -11896,7 +12886,7 @@ func (p *SQLParser) GroupByKey() (localctx IGroupByKeyContext) {
 	_ = this
 
 	localctx = NewGroupByKeyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 126, SQLParserRULE_groupByKey)
+	p.EnterRule(localctx, 132, SQLParserRULE_groupByKey)
 
 	defer func() {
 		p.ExitRule()
@@ -11914,47 +12904,54 @@ func (p *SQLParser) GroupByKey() (localctx IGroupByKeyContext) {
 		}
 	}()
 
-	p.SetState(648)
+	p.SetState(730)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 43, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 53, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(639)
+			p.SetState(720)
 			p.Ident()
 		}
 
-	case 2:
-		p.EnterOuterAlt(localctx, 2)
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(721)
+			p.Match(SQLParserT_MUL)
+		}
+
+	case 3:
+		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(640)
+			p.SetState(722)
 			p.Match(SQLParserT_TIME)
 		}
 		{
-			p.SetState(641)
+			p.SetState(723)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(642)
+			p.SetState(724)
 			p.DurationLit()
 		}
 		{
-			p.SetState(643)
+			p.SetState(725)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
-	case 3:
-		p.EnterOuterAlt(localctx, 3)
+	case 4:
+		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(645)
+			p.SetState(727)
 			p.Match(SQLParserT_TIME)
 		}
 		{
-			p.SetState(646)
+			p.SetState(728)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(647)
+			p.SetState(729)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
@@ -12058,7 +13055,7 @@ func (p *SQLParser) FillOption() (localctx IFillOptionContext) {
 	_ = this
 
 	localctx = NewFillOptionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 128, SQLParserRULE_fillOption)
+	p.EnterRule(localctx, 134, SQLParserRULE_fillOption)
 	var _la int
 
 	defer func() {
@@ -12079,7 +13076,7 @@ func (p *SQLParser) FillOption() (localctx IFillOptionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(650)
+		p.SetState(732)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == SQLParserT_NULL || _la == SQLParserT_PREVIOUS || _la == SQLParserL_INT || _la == SQLParserL_DEC) {
@@ -12195,7 +13192,7 @@ func (p *SQLParser) OrderByClause() (localctx IOrderByClauseContext) {
 	_ = this
 
 	localctx = NewOrderByClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 130, SQLParserRULE_orderByClause)
+	p.EnterRule(localctx, 136, SQLParserRULE_orderByClause)
 
 	defer func() {
 		p.ExitRule()
@@ -12215,15 +13212,15 @@ func (p *SQLParser) OrderByClause() (localctx IOrderByClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(652)
+		p.SetState(734)
 		p.Match(SQLParserT_ORDER)
 	}
 	{
-		p.SetState(653)
+		p.SetState(735)
 		p.Match(SQLParserT_BY)
 	}
 	{
-		p.SetState(654)
+		p.SetState(736)
 		p.SortFields()
 	}
 
@@ -12342,7 +13339,7 @@ func (p *SQLParser) SortField() (localctx ISortFieldContext) {
 	_ = this
 
 	localctx = NewSortFieldContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 132, SQLParserRULE_sortField)
+	p.EnterRule(localctx, 138, SQLParserRULE_sortField)
 	var _la int
 
 	defer func() {
@@ -12363,16 +13360,16 @@ func (p *SQLParser) SortField() (localctx ISortFieldContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(656)
+		p.SetState(738)
 		p.fieldExpr(0)
 	}
-	p.SetState(660)
+	p.SetState(742)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SQLParserT_ASC || _la == SQLParserT_DESC {
 		{
-			p.SetState(657)
+			p.SetState(739)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == SQLParserT_ASC || _la == SQLParserT_DESC) {
@@ -12383,7 +13380,7 @@ func (p *SQLParser) SortField() (localctx ISortFieldContext) {
 			}
 		}
 
-		p.SetState(662)
+		p.SetState(744)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -12519,7 +13516,7 @@ func (p *SQLParser) SortFields() (localctx ISortFieldsContext) {
 	_ = this
 
 	localctx = NewSortFieldsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 134, SQLParserRULE_sortFields)
+	p.EnterRule(localctx, 140, SQLParserRULE_sortFields)
 	var _la int
 
 	defer func() {
@@ -12540,24 +13537,24 @@ func (p *SQLParser) SortFields() (localctx ISortFieldsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(663)
+		p.SetState(745)
 		p.SortField()
 	}
-	p.SetState(668)
+	p.SetState(750)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(664)
+			p.SetState(746)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(665)
+			p.SetState(747)
 			p.SortField()
 		}
 
-		p.SetState(670)
+		p.SetState(752)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -12662,7 +13659,7 @@ func (p *SQLParser) HavingClause() (localctx IHavingClauseContext) {
 	_ = this
 
 	localctx = NewHavingClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 136, SQLParserRULE_havingClause)
+	p.EnterRule(localctx, 142, SQLParserRULE_havingClause)
 
 	defer func() {
 		p.ExitRule()
@@ -12682,11 +13679,11 @@ func (p *SQLParser) HavingClause() (localctx IHavingClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(671)
+		p.SetState(753)
 		p.Match(SQLParserT_HAVING)
 	}
 	{
-		p.SetState(672)
+		p.SetState(754)
 		p.boolExpr(0)
 	}
 
@@ -12863,8 +13860,8 @@ func (p *SQLParser) boolExpr(_p int) (localctx IBoolExprContext) {
 	localctx = NewBoolExprContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx IBoolExprContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 138
-	p.EnterRecursionRule(localctx, 138, SQLParserRULE_boolExpr, _p)
+	_startState := 144
+	p.EnterRecursionRule(localctx, 144, SQLParserRULE_boolExpr, _p)
 
 	defer func() {
 		p.UnrollRecursionContexts(_parentctx)
@@ -12885,34 +13882,34 @@ func (p *SQLParser) boolExpr(_p int) (localctx IBoolExprContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(680)
+	p.SetState(762)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 46, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 56, p.GetParserRuleContext()) {
 	case 1:
 		{
-			p.SetState(675)
+			p.SetState(757)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(676)
+			p.SetState(758)
 			p.boolExpr(0)
 		}
 		{
-			p.SetState(677)
+			p.SetState(759)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 	case 2:
 		{
-			p.SetState(679)
+			p.SetState(761)
 			p.BoolExprAtom()
 		}
 
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(688)
+	p.SetState(770)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 47, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 57, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
@@ -12922,24 +13919,24 @@ func (p *SQLParser) boolExpr(_p int) (localctx IBoolExprContext) {
 			_prevctx = localctx
 			localctx = NewBoolExprContext(p, _parentctx, _parentState)
 			p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_boolExpr)
-			p.SetState(682)
+			p.SetState(764)
 
 			if !(p.Precpred(p.GetParserRuleContext(), 2)) {
 				panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
 			}
 			{
-				p.SetState(683)
+				p.SetState(765)
 				p.BoolExprLogicalOp()
 			}
 			{
-				p.SetState(684)
+				p.SetState(766)
 				p.boolExpr(3)
 			}
 
 		}
-		p.SetState(690)
+		p.SetState(772)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 47, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 57, p.GetParserRuleContext())
 	}
 
 	return localctx
@@ -13030,7 +14027,7 @@ func (p *SQLParser) BoolExprLogicalOp() (localctx IBoolExprLogicalOpContext) {
 	_ = this
 
 	localctx = NewBoolExprLogicalOpContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 140, SQLParserRULE_boolExprLogicalOp)
+	p.EnterRule(localctx, 146, SQLParserRULE_boolExprLogicalOp)
 	var _la int
 
 	defer func() {
@@ -13051,7 +14048,7 @@ func (p *SQLParser) BoolExprLogicalOp() (localctx IBoolExprLogicalOpContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(691)
+		p.SetState(773)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == SQLParserT_AND || _la == SQLParserT_OR) {
@@ -13157,7 +14154,7 @@ func (p *SQLParser) BoolExprAtom() (localctx IBoolExprAtomContext) {
 	_ = this
 
 	localctx = NewBoolExprAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 142, SQLParserRULE_boolExprAtom)
+	p.EnterRule(localctx, 148, SQLParserRULE_boolExprAtom)
 
 	defer func() {
 		p.ExitRule()
@@ -13177,7 +14174,7 @@ func (p *SQLParser) BoolExprAtom() (localctx IBoolExprAtomContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(693)
+		p.SetState(775)
 		p.BinaryExpr()
 	}
 
@@ -13319,7 +14316,7 @@ func (p *SQLParser) BinaryExpr() (localctx IBinaryExprContext) {
 	_ = this
 
 	localctx = NewBinaryExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 144, SQLParserRULE_binaryExpr)
+	p.EnterRule(localctx, 150, SQLParserRULE_binaryExpr)
 
 	defer func() {
 		p.ExitRule()
@@ -13339,15 +14336,15 @@ func (p *SQLParser) BinaryExpr() (localctx IBinaryExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(695)
+		p.SetState(777)
 		p.fieldExpr(0)
 	}
 	{
-		p.SetState(696)
+		p.SetState(778)
 		p.BinaryOperator()
 	}
 	{
-		p.SetState(697)
+		p.SetState(779)
 		p.fieldExpr(0)
 	}
 
@@ -13474,7 +14471,7 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 	_ = this
 
 	localctx = NewBinaryOperatorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 146, SQLParserRULE_binaryOperator)
+	p.EnterRule(localctx, 152, SQLParserRULE_binaryOperator)
 	var _la int
 
 	defer func() {
@@ -13493,63 +14490,63 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 		}
 	}()
 
-	p.SetState(707)
+	p.SetState(789)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_EQUAL:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(699)
+			p.SetState(781)
 			p.Match(SQLParserT_EQUAL)
 		}
 
 	case SQLParserT_NOTEQUAL:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(700)
+			p.SetState(782)
 			p.Match(SQLParserT_NOTEQUAL)
 		}
 
 	case SQLParserT_NOTEQUAL2:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(701)
+			p.SetState(783)
 			p.Match(SQLParserT_NOTEQUAL2)
 		}
 
 	case SQLParserT_LESS:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(702)
+			p.SetState(784)
 			p.Match(SQLParserT_LESS)
 		}
 
 	case SQLParserT_LESSEQUAL:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(703)
+			p.SetState(785)
 			p.Match(SQLParserT_LESSEQUAL)
 		}
 
 	case SQLParserT_GREATER:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(704)
+			p.SetState(786)
 			p.Match(SQLParserT_GREATER)
 		}
 
 	case SQLParserT_GREATEREQUAL:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(705)
+			p.SetState(787)
 			p.Match(SQLParserT_GREATEREQUAL)
 		}
 
 	case SQLParserT_LIKE, SQLParserT_REGEXP:
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(706)
+			p.SetState(788)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == SQLParserT_LIKE || _la == SQLParserT_REGEXP) {
@@ -13791,8 +14788,8 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 	localctx = NewFieldExprContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx IFieldExprContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 148
-	p.EnterRecursionRule(localctx, 148, SQLParserRULE_fieldExpr, _p)
+	_startState := 154
+	p.EnterRecursionRule(localctx, 154, SQLParserRULE_fieldExpr, _p)
 
 	defer func() {
 		p.UnrollRecursionContexts(_parentctx)
@@ -13813,52 +14810,52 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(718)
+	p.SetState(800)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 49, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 59, p.GetParserRuleContext()) {
 	case 1:
 		{
-			p.SetState(710)
+			p.SetState(792)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(711)
+			p.SetState(793)
 			p.fieldExpr(0)
 		}
 		{
-			p.SetState(712)
+			p.SetState(794)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 	case 2:
 		{
-			p.SetState(714)
+			p.SetState(796)
 			p.ExprFunc()
 		}
 
 	case 3:
 		{
-			p.SetState(715)
+			p.SetState(797)
 			p.ExprAtom()
 		}
 
 	case 4:
 		{
-			p.SetState(716)
+			p.SetState(798)
 			p.DurationLit()
 		}
 
 	case 5:
 		{
-			p.SetState(717)
+			p.SetState(799)
 			p.Star()
 		}
 
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(734)
+	p.SetState(816)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 51, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 61, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
@@ -13866,83 +14863,83 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(732)
+			p.SetState(814)
 			p.GetErrorHandler().Sync(p)
-			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 50, p.GetParserRuleContext()) {
+			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 60, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewFieldExprContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
-				p.SetState(720)
+				p.SetState(802)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
 				}
 				{
-					p.SetState(721)
+					p.SetState(803)
 					p.Match(SQLParserT_MUL)
 				}
 				{
-					p.SetState(722)
+					p.SetState(804)
 					p.fieldExpr(10)
 				}
 
 			case 2:
 				localctx = NewFieldExprContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
-				p.SetState(723)
+				p.SetState(805)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
 				}
 				{
-					p.SetState(724)
+					p.SetState(806)
 					p.Match(SQLParserT_DIV)
 				}
 				{
-					p.SetState(725)
+					p.SetState(807)
 					p.fieldExpr(9)
 				}
 
 			case 3:
 				localctx = NewFieldExprContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
-				p.SetState(726)
+				p.SetState(808)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 				}
 				{
-					p.SetState(727)
+					p.SetState(809)
 					p.Match(SQLParserT_ADD)
 				}
 				{
-					p.SetState(728)
+					p.SetState(810)
 					p.fieldExpr(8)
 				}
 
 			case 4:
 				localctx = NewFieldExprContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
-				p.SetState(729)
+				p.SetState(811)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
 				}
 				{
-					p.SetState(730)
+					p.SetState(812)
 					p.Match(SQLParserT_SUB)
 				}
 				{
-					p.SetState(731)
+					p.SetState(813)
 					p.fieldExpr(7)
 				}
 
 			}
 
 		}
-		p.SetState(736)
+		p.SetState(818)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 51, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 61, p.GetParserRuleContext())
 	}
 
 	return localctx
@@ -14028,7 +15025,7 @@ func (p *SQLParser) Star() (localctx IStarContext) {
 	_ = this
 
 	localctx = NewStarContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 150, SQLParserRULE_star)
+	p.EnterRule(localctx, 156, SQLParserRULE_star)
 
 	defer func() {
 		p.ExitRule()
@@ -14048,7 +15045,7 @@ func (p *SQLParser) Star() (localctx IStarContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(737)
+		p.SetState(819)
 		p.Match(SQLParserT_MUL)
 	}
 
@@ -14164,7 +15161,7 @@ func (p *SQLParser) DurationLit() (localctx IDurationLitContext) {
 	_ = this
 
 	localctx = NewDurationLitContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 152, SQLParserRULE_durationLit)
+	p.EnterRule(localctx, 158, SQLParserRULE_durationLit)
 
 	defer func() {
 		p.ExitRule()
@@ -14184,11 +15181,11 @@ func (p *SQLParser) DurationLit() (localctx IDurationLitContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(739)
+		p.SetState(821)
 		p.IntNumber()
 	}
 	{
-		p.SetState(740)
+		p.SetState(822)
 		p.IntervalItem()
 	}
 
@@ -14305,7 +15302,7 @@ func (p *SQLParser) IntervalItem() (localctx IIntervalItemContext) {
 	_ = this
 
 	localctx = NewIntervalItemContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 154, SQLParserRULE_intervalItem)
+	p.EnterRule(localctx, 160, SQLParserRULE_intervalItem)
 	var _la int
 
 	defer func() {
@@ -14326,10 +15323,10 @@ func (p *SQLParser) IntervalItem() (localctx IIntervalItemContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(742)
+		p.SetState(824)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64((_la-97)) & ^0x3f) == 0 && ((int64(1)<<(_la-97))&127) != 0) {
+		if !((int64((_la-114)) & ^0x3f) == 0 && ((int64(1)<<(_la-114))&127) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -14459,7 +15456,7 @@ func (p *SQLParser) ExprFunc() (localctx IExprFuncContext) {
 	_ = this
 
 	localctx = NewExprFuncContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 156, SQLParserRULE_exprFunc)
+	p.EnterRule(localctx, 162, SQLParserRULE_exprFunc)
 	var _la int
 
 	defer func() {
@@ -14480,26 +15477,26 @@ func (p *SQLParser) ExprFunc() (localctx IExprFuncContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(744)
+		p.SetState(826)
 		p.FuncName()
 	}
 	{
-		p.SetState(745)
+		p.SetState(827)
 		p.Match(SQLParserT_OPEN_P)
 	}
-	p.SetState(747)
+	p.SetState(829)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if ((int64((_la-6)) & ^0x3f) == 0 && ((int64(1)<<(_la-6))&-65537) != 0) || ((int64((_la-70)) & ^0x3f) == 0 && ((int64(1)<<(_la-70))&2068278146049769471) != 0) {
+	if ((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&-33554496) != 0) || ((int64((_la-64)) & ^0x3f) == 0 && ((int64(1)<<(_la-64))&144115188075855871) != 0) || ((int64((_la-137)) & ^0x3f) == 0 && ((int64(1)<<(_la-137))&1837) != 0) {
 		{
-			p.SetState(746)
+			p.SetState(828)
 			p.ExprFuncParams()
 		}
 
 	}
 	{
-		p.SetState(749)
+		p.SetState(831)
 		p.Match(SQLParserT_CLOSE_P)
 	}
 
@@ -14524,6 +15521,14 @@ type IFuncNameContext interface {
 	T_STDDEV() antlr.TerminalNode
 	T_QUANTILE() antlr.TerminalNode
 	T_RATE() antlr.TerminalNode
+	T_PERCENT() antlr.TerminalNode
+	T_COUNT_IF() antlr.TerminalNode
+	T_SUM_IF() antlr.TerminalNode
+	T_MEDIAN() antlr.TerminalNode
+	T_DERIVATIVE() antlr.TerminalNode
+	T_TOPK() antlr.TerminalNode
+	T_BOTTOMK() antlr.TerminalNode
+	T_HISTOGRAM_QUANTILE() antlr.TerminalNode
 
 	// IsFuncNameContext differentiates from other interfaces.
 	IsFuncNameContext()
@@ -14596,6 +15601,38 @@ func (s *FuncNameContext) T_RATE() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_RATE, 0)
 }
 
+func (s *FuncNameContext) T_PERCENT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_PERCENT, 0)
+}
+
+func (s *FuncNameContext) T_COUNT_IF() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_COUNT_IF, 0)
+}
+
+func (s *FuncNameContext) T_SUM_IF() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SUM_IF, 0)
+}
+
+func (s *FuncNameContext) T_MEDIAN() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MEDIAN, 0)
+}
+
+func (s *FuncNameContext) T_DERIVATIVE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DERIVATIVE, 0)
+}
+
+func (s *FuncNameContext) T_TOPK() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TOPK, 0)
+}
+
+func (s *FuncNameContext) T_BOTTOMK() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_BOTTOMK, 0)
+}
+
+func (s *FuncNameContext) T_HISTOGRAM_QUANTILE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_HISTOGRAM_QUANTILE, 0)
+}
+
 func (s *FuncNameContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -14631,7 +15668,7 @@ func (p *SQLParser) FuncName() (localctx IFuncNameContext) {
 	_ = this
 
 	localctx = NewFuncNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 158, SQLParserRULE_funcName)
+	p.EnterRule(localctx, 164, SQLParserRULE_funcName)
 	var _la int
 
 	defer func() {
@@ -14652,10 +15689,10 @@ func (p *SQLParser) FuncName() (localctx IFuncNameContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(751)
+		p.SetState(833)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64((_la-87)) & ^0x3f) == 0 && ((int64(1)<<(_la-87))&1023) != 0) {
+		if !((int64((_la-95)) & ^0x3f) == 0 && ((int64(1)<<(_la-95))&516095) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -14794,7 +15831,7 @@ func (p *SQLParser) ExprFuncParams() (localctx IExprFuncParamsContext) {
 	_ = this
 
 	localctx = NewExprFuncParamsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 160, SQLParserRULE_exprFuncParams)
+	p.EnterRule(localctx, 166, SQLParserRULE_exprFuncParams)
 	var _la int
 
 	defer func() {
@@ -14815,24 +15852,24 @@ func (p *SQLParser) ExprFuncParams() (localctx IExprFuncParamsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(753)
+		p.SetState(835)
 		p.FuncParam()
 	}
-	p.SetState(758)
+	p.SetState(840)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(754)
+			p.SetState(836)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(755)
+			p.SetState(837)
 			p.FuncParam()
 		}
 
-		p.SetState(760)
+		p.SetState(842)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -14848,6 +15885,7 @@ type IFuncParamContext interface {
 	GetParser() antlr.Parser
 
 	// Getter signatures
+	FieldPredicate() IFieldPredicateContext
 	FieldExpr() IFieldExprContext
 	TagFilterExpr() ITagFilterExprContext
 
@@ -14882,6 +15920,22 @@ func NewFuncParamContext(parser antlr.Parser, parent antlr.ParserRuleContext, in
 
 func (s *FuncParamContext) GetParser() antlr.Parser { return s.parser }
 
+func (s *FuncParamContext) FieldPredicate() IFieldPredicateContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IFieldPredicateContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFieldPredicateContext)
+}
+
 func (s *FuncParamContext) FieldExpr() IFieldExprContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
@@ -14911,45 +15965,254 @@ func (s *FuncParamContext) TagFilterExpr() ITagFilterExprContext {
 		return nil
 	}
 
-	return t.(ITagFilterExprContext)
+	return t.(ITagFilterExprContext)
+}
+
+func (s *FuncParamContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *FuncParamContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *FuncParamContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterFuncParam(s)
+	}
+}
+
+func (s *FuncParamContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitFuncParam(s)
+	}
+}
+
+func (s *FuncParamContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SQLVisitor:
+		return t.VisitFuncParam(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SQLParser) FuncParam() (localctx IFuncParamContext) {
+	this := p
+	_ = this
+
+	localctx = NewFuncParamContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 168, SQLParserRULE_funcParam)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(846)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 64, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(843)
+			p.FieldPredicate()
+		}
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(844)
+			p.fieldExpr(0)
+		}
+
+	case 3:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(845)
+			p.tagFilterExpr(0)
+		}
+
+	}
+
+	return localctx
+}
+
+// IFieldPredicateContext is an interface to support dynamic dispatch.
+type IFieldPredicateContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	Ident() IIdentContext
+	T_EQUAL() antlr.TerminalNode
+	T_NOTEQUAL() antlr.TerminalNode
+	T_NOTEQUAL2() antlr.TerminalNode
+	T_LESS() antlr.TerminalNode
+	T_LESSEQUAL() antlr.TerminalNode
+	T_GREATER() antlr.TerminalNode
+	T_GREATEREQUAL() antlr.TerminalNode
+	DecNumber() IDecNumberContext
+	IntNumber() IIntNumberContext
+
+	// IsFieldPredicateContext differentiates from other interfaces.
+	IsFieldPredicateContext()
+}
+
+type FieldPredicateContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyFieldPredicateContext() *FieldPredicateContext {
+	var p = new(FieldPredicateContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_fieldPredicate
+	return p
+}
+
+func (*FieldPredicateContext) IsFieldPredicateContext() {}
+
+func NewFieldPredicateContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FieldPredicateContext {
+	var p = new(FieldPredicateContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_fieldPredicate
+
+	return p
+}
+
+func (s *FieldPredicateContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *FieldPredicateContext) Ident() IIdentContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIdentContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentContext)
+}
+
+func (s *FieldPredicateContext) T_EQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_EQUAL, 0)
+}
+
+func (s *FieldPredicateContext) T_NOTEQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_NOTEQUAL, 0)
+}
+
+func (s *FieldPredicateContext) T_NOTEQUAL2() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_NOTEQUAL2, 0)
+}
+
+func (s *FieldPredicateContext) T_LESS() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LESS, 0)
+}
+
+func (s *FieldPredicateContext) T_LESSEQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LESSEQUAL, 0)
+}
+
+func (s *FieldPredicateContext) T_GREATER() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_GREATER, 0)
+}
+
+func (s *FieldPredicateContext) T_GREATEREQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_GREATEREQUAL, 0)
+}
+
+func (s *FieldPredicateContext) DecNumber() IDecNumberContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDecNumberContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDecNumberContext)
+}
+
+func (s *FieldPredicateContext) IntNumber() IIntNumberContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIntNumberContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIntNumberContext)
 }
 
-func (s *FuncParamContext) GetRuleContext() antlr.RuleContext {
+func (s *FieldPredicateContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *FuncParamContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *FieldPredicateContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *FuncParamContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *FieldPredicateContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SQLListener); ok {
-		listenerT.EnterFuncParam(s)
+		listenerT.EnterFieldPredicate(s)
 	}
 }
 
-func (s *FuncParamContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *FieldPredicateContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SQLListener); ok {
-		listenerT.ExitFuncParam(s)
+		listenerT.ExitFieldPredicate(s)
 	}
 }
 
-func (s *FuncParamContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *FieldPredicateContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SQLVisitor:
-		return t.VisitFuncParam(s)
+		return t.VisitFieldPredicate(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SQLParser) FuncParam() (localctx IFuncParamContext) {
+func (p *SQLParser) FieldPredicate() (localctx IFieldPredicateContext) {
 	this := p
 	_ = this
 
-	localctx = NewFuncParamContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 162, SQLParserRULE_funcParam)
+	localctx = NewFieldPredicateContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 170, SQLParserRULE_fieldPredicate)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -14967,21 +16230,35 @@ func (p *SQLParser) FuncParam() (localctx IFuncParamContext) {
 		}
 	}()
 
-	p.SetState(763)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(848)
+		p.Ident()
+	}
+	{
+		p.SetState(849)
+		_la = p.GetTokenStream().LA(1)
+
+		if !((int64((_la-123)) & ^0x3f) == 0 && ((int64(1)<<(_la-123))&127) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+	p.SetState(852)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 54, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 65, p.GetParserRuleContext()) {
 	case 1:
-		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(761)
-			p.fieldExpr(0)
+			p.SetState(850)
+			p.DecNumber()
 		}
 
 	case 2:
-		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(762)
-			p.tagFilterExpr(0)
+			p.SetState(851)
+			p.IntNumber()
 		}
 
 	}
@@ -15132,7 +16409,7 @@ func (p *SQLParser) ExprAtom() (localctx IExprAtomContext) {
 	_ = this
 
 	localctx = NewExprAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 164, SQLParserRULE_exprAtom)
+	p.EnterRule(localctx, 172, SQLParserRULE_exprAtom)
 
 	defer func() {
 		p.ExitRule()
@@ -15150,21 +16427,21 @@ func (p *SQLParser) ExprAtom() (localctx IExprAtomContext) {
 		}
 	}()
 
-	p.SetState(771)
+	p.SetState(860)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 56, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 67, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(765)
+			p.SetState(854)
 			p.Ident()
 		}
-		p.SetState(767)
+		p.SetState(856)
 		p.GetErrorHandler().Sync(p)
 
-		if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 55, p.GetParserRuleContext()) == 1 {
+		if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 66, p.GetParserRuleContext()) == 1 {
 			{
-				p.SetState(766)
+				p.SetState(855)
 				p.IdentFilter()
 			}
 
@@ -15173,14 +16450,14 @@ func (p *SQLParser) ExprAtom() (localctx IExprAtomContext) {
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(769)
+			p.SetState(858)
 			p.DecNumber()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(770)
+			p.SetState(859)
 			p.IntNumber()
 		}
 
@@ -15291,7 +16568,7 @@ func (p *SQLParser) IdentFilter() (localctx IIdentFilterContext) {
 	_ = this
 
 	localctx = NewIdentFilterContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 166, SQLParserRULE_identFilter)
+	p.EnterRule(localctx, 174, SQLParserRULE_identFilter)
 
 	defer func() {
 		p.ExitRule()
@@ -15311,15 +16588,15 @@ func (p *SQLParser) IdentFilter() (localctx IIdentFilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(773)
+		p.SetState(862)
 		p.Match(SQLParserT_OPEN_SB)
 	}
 	{
-		p.SetState(774)
+		p.SetState(863)
 		p.tagFilterExpr(0)
 	}
 	{
-		p.SetState(775)
+		p.SetState(864)
 		p.Match(SQLParserT_CLOSE_SB)
 	}
 
@@ -15418,7 +16695,7 @@ func (p *SQLParser) Json() (localctx IJsonContext) {
 	_ = this
 
 	localctx = NewJsonContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 168, SQLParserRULE_json)
+	p.EnterRule(localctx, 176, SQLParserRULE_json)
 
 	defer func() {
 		p.ExitRule()
@@ -15438,7 +16715,7 @@ func (p *SQLParser) Json() (localctx IJsonContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(777)
+		p.SetState(866)
 		p.Value()
 	}
 
@@ -15537,7 +16814,7 @@ func (p *SQLParser) Toml() (localctx ITomlContext) {
 	_ = this
 
 	localctx = NewTomlContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 170, SQLParserRULE_toml)
+	p.EnterRule(localctx, 178, SQLParserRULE_toml)
 
 	defer func() {
 		p.ExitRule()
@@ -15557,7 +16834,7 @@ func (p *SQLParser) Toml() (localctx ITomlContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(779)
+		p.SetState(868)
 		p.Ident()
 	}
 
@@ -15702,7 +16979,7 @@ func (p *SQLParser) Obj() (localctx IObjContext) {
 	_ = this
 
 	localctx = NewObjContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 172, SQLParserRULE_obj)
+	p.EnterRule(localctx, 180, SQLParserRULE_obj)
 	var _la int
 
 	defer func() {
@@ -15721,50 +16998,50 @@ func (p *SQLParser) Obj() (localctx IObjContext) {
 		}
 	}()
 
-	p.SetState(794)
+	p.SetState(883)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 58, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 69, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(781)
+			p.SetState(870)
 			p.Match(SQLParserT_OPEN_B)
 		}
 		{
-			p.SetState(782)
+			p.SetState(871)
 			p.Pair()
 		}
-		p.SetState(787)
+		p.SetState(876)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 
 		for _la == SQLParserT_COMMA {
 			{
-				p.SetState(783)
+				p.SetState(872)
 				p.Match(SQLParserT_COMMA)
 			}
 			{
-				p.SetState(784)
+				p.SetState(873)
 				p.Pair()
 			}
 
-			p.SetState(789)
+			p.SetState(878)
 			p.GetErrorHandler().Sync(p)
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(790)
+			p.SetState(879)
 			p.Match(SQLParserT_CLOSE_B)
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(792)
+			p.SetState(881)
 			p.Match(SQLParserT_OPEN_B)
 		}
 		{
-			p.SetState(793)
+			p.SetState(882)
 			p.Match(SQLParserT_CLOSE_B)
 		}
 
@@ -15875,7 +17152,7 @@ func (p *SQLParser) Pair() (localctx IPairContext) {
 	_ = this
 
 	localctx = NewPairContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 174, SQLParserRULE_pair)
+	p.EnterRule(localctx, 182, SQLParserRULE_pair)
 
 	defer func() {
 		p.ExitRule()
@@ -15895,15 +17172,15 @@ func (p *SQLParser) Pair() (localctx IPairContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(796)
+		p.SetState(885)
 		p.Match(SQLParserSTRING)
 	}
 	{
-		p.SetState(797)
+		p.SetState(886)
 		p.Match(SQLParserT_COLON)
 	}
 	{
-		p.SetState(798)
+		p.SetState(887)
 		p.Value()
 	}
 
@@ -16048,7 +17325,7 @@ func (p *SQLParser) Arr() (localctx IArrContext) {
 	_ = this
 
 	localctx = NewArrContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 176, SQLParserRULE_arr)
+	p.EnterRule(localctx, 184, SQLParserRULE_arr)
 	var _la int
 
 	defer func() {
@@ -16067,50 +17344,50 @@ func (p *SQLParser) Arr() (localctx IArrContext) {
 		}
 	}()
 
-	p.SetState(813)
+	p.SetState(902)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 60, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 71, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(800)
+			p.SetState(889)
 			p.Match(SQLParserT_OPEN_SB)
 		}
 		{
-			p.SetState(801)
+			p.SetState(890)
 			p.Value()
 		}
-		p.SetState(806)
+		p.SetState(895)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 
 		for _la == SQLParserT_COMMA {
 			{
-				p.SetState(802)
+				p.SetState(891)
 				p.Match(SQLParserT_COMMA)
 			}
 			{
-				p.SetState(803)
+				p.SetState(892)
 				p.Value()
 			}
 
-			p.SetState(808)
+			p.SetState(897)
 			p.GetErrorHandler().Sync(p)
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(809)
+			p.SetState(898)
 			p.Match(SQLParserT_CLOSE_SB)
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(811)
+			p.SetState(900)
 			p.Match(SQLParserT_OPEN_SB)
 		}
 		{
-			p.SetState(812)
+			p.SetState(901)
 			p.Match(SQLParserT_CLOSE_SB)
 		}
 
@@ -16267,7 +17544,7 @@ func (p *SQLParser) Value() (localctx IValueContext) {
 	_ = this
 
 	localctx = NewValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 178, SQLParserRULE_value)
+	p.EnterRule(localctx, 186, SQLParserRULE_value)
 
 	defer func() {
 		p.ExitRule()
@@ -16285,62 +17562,62 @@ func (p *SQLParser) Value() (localctx IValueContext) {
 		}
 	}()
 
-	p.SetState(823)
+	p.SetState(912)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 61, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 72, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(815)
+			p.SetState(904)
 			p.Match(SQLParserSTRING)
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(816)
+			p.SetState(905)
 			p.IntNumber()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(817)
+			p.SetState(906)
 			p.DecNumber()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(818)
+			p.SetState(907)
 			p.Obj()
 		}
 
 	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(819)
+			p.SetState(908)
 			p.Arr()
 		}
 
 	case 6:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(820)
+			p.SetState(909)
 			p.Match(SQLParserT__0)
 		}
 
 	case 7:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(821)
+			p.SetState(910)
 			p.Match(SQLParserT__1)
 		}
 
 	case 8:
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(822)
+			p.SetState(911)
 			p.Match(SQLParserT__2)
 		}
 
@@ -16439,7 +17716,7 @@ func (p *SQLParser) IntNumber() (localctx IIntNumberContext) {
 	_ = this
 
 	localctx = NewIntNumberContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 180, SQLParserRULE_intNumber)
+	p.EnterRule(localctx, 188, SQLParserRULE_intNumber)
 	var _la int
 
 	defer func() {
@@ -16459,13 +17736,13 @@ func (p *SQLParser) IntNumber() (localctx IIntNumberContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(826)
+	p.SetState(915)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_ADD || _la == SQLParserT_SUB {
 		{
-			p.SetState(825)
+			p.SetState(914)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == SQLParserT_ADD || _la == SQLParserT_SUB) {
@@ -16478,7 +17755,7 @@ func (p *SQLParser) IntNumber() (localctx IIntNumberContext) {
 
 	}
 	{
-		p.SetState(828)
+		p.SetState(917)
 		p.Match(SQLParserL_INT)
 	}
 
@@ -16575,7 +17852,7 @@ func (p *SQLParser) DecNumber() (localctx IDecNumberContext) {
 	_ = this
 
 	localctx = NewDecNumberContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 182, SQLParserRULE_decNumber)
+	p.EnterRule(localctx, 190, SQLParserRULE_decNumber)
 	var _la int
 
 	defer func() {
@@ -16595,13 +17872,13 @@ func (p *SQLParser) DecNumber() (localctx IDecNumberContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(831)
+	p.SetState(920)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SQLParserT_ADD || _la == SQLParserT_SUB {
 		{
-			p.SetState(830)
+			p.SetState(919)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == SQLParserT_ADD || _la == SQLParserT_SUB) {
@@ -16614,7 +17891,7 @@ func (p *SQLParser) DecNumber() (localctx IDecNumberContext) {
 
 	}
 	{
-		p.SetState(833)
+		p.SetState(922)
 		p.Match(SQLParserL_DEC)
 	}
 
@@ -16706,7 +17983,7 @@ func (p *SQLParser) LimitClause() (localctx ILimitClauseContext) {
 	_ = this
 
 	localctx = NewLimitClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 184, SQLParserRULE_limitClause)
+	p.EnterRule(localctx, 192, SQLParserRULE_limitClause)
 
 	defer func() {
 		p.ExitRule()
@@ -16726,11 +18003,243 @@ func (p *SQLParser) LimitClause() (localctx ILimitClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(835)
+		p.SetState(924)
 		p.Match(SQLParserT_LIMIT)
 	}
 	{
-		p.SetState(836)
+		p.SetState(925)
+		p.Match(SQLParserL_INT)
+	}
+
+	return localctx
+}
+
+// IPrecisionClauseContext is an interface to support dynamic dispatch.
+type IPrecisionClauseContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	T_PRECISION() antlr.TerminalNode
+	L_INT() antlr.TerminalNode
+
+	// IsPrecisionClauseContext differentiates from other interfaces.
+	IsPrecisionClauseContext()
+}
+
+type PrecisionClauseContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPrecisionClauseContext() *PrecisionClauseContext {
+	var p = new(PrecisionClauseContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_precisionClause
+	return p
+}
+
+func (*PrecisionClauseContext) IsPrecisionClauseContext() {}
+
+func NewPrecisionClauseContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PrecisionClauseContext {
+	var p = new(PrecisionClauseContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_precisionClause
+
+	return p
+}
+
+func (s *PrecisionClauseContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *PrecisionClauseContext) T_PRECISION() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_PRECISION, 0)
+}
+
+func (s *PrecisionClauseContext) L_INT() antlr.TerminalNode {
+	return s.GetToken(SQLParserL_INT, 0)
+}
+
+func (s *PrecisionClauseContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *PrecisionClauseContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *PrecisionClauseContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterPrecisionClause(s)
+	}
+}
+
+func (s *PrecisionClauseContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitPrecisionClause(s)
+	}
+}
+
+func (s *PrecisionClauseContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SQLVisitor:
+		return t.VisitPrecisionClause(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SQLParser) PrecisionClause() (localctx IPrecisionClauseContext) {
+	this := p
+	_ = this
+
+	localctx = NewPrecisionClauseContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 194, SQLParserRULE_precisionClause)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(927)
+		p.Match(SQLParserT_PRECISION)
+	}
+	{
+		p.SetState(928)
+		p.Match(SQLParserL_INT)
+	}
+
+	return localctx
+}
+
+// IOffsetClauseContext is an interface to support dynamic dispatch.
+type IOffsetClauseContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	T_OFFSET() antlr.TerminalNode
+	L_INT() antlr.TerminalNode
+
+	// IsOffsetClauseContext differentiates from other interfaces.
+	IsOffsetClauseContext()
+}
+
+type OffsetClauseContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyOffsetClauseContext() *OffsetClauseContext {
+	var p = new(OffsetClauseContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_offsetClause
+	return p
+}
+
+func (*OffsetClauseContext) IsOffsetClauseContext() {}
+
+func NewOffsetClauseContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *OffsetClauseContext {
+	var p = new(OffsetClauseContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_offsetClause
+
+	return p
+}
+
+func (s *OffsetClauseContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *OffsetClauseContext) T_OFFSET() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_OFFSET, 0)
+}
+
+func (s *OffsetClauseContext) L_INT() antlr.TerminalNode {
+	return s.GetToken(SQLParserL_INT, 0)
+}
+
+func (s *OffsetClauseContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *OffsetClauseContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *OffsetClauseContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterOffsetClause(s)
+	}
+}
+
+func (s *OffsetClauseContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitOffsetClause(s)
+	}
+}
+
+func (s *OffsetClauseContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SQLVisitor:
+		return t.VisitOffsetClause(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SQLParser) OffsetClause() (localctx IOffsetClauseContext) {
+	this := p
+	_ = this
+
+	localctx = NewOffsetClauseContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 196, SQLParserRULE_offsetClause)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(930)
+		p.Match(SQLParserT_OFFSET)
+	}
+	{
+		p.SetState(931)
 		p.Match(SQLParserL_INT)
 	}
 
@@ -16829,7 +18338,7 @@ func (p *SQLParser) MetricName() (localctx IMetricNameContext) {
 	_ = this
 
 	localctx = NewMetricNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 186, SQLParserRULE_metricName)
+	p.EnterRule(localctx, 198, SQLParserRULE_metricName)
 
 	defer func() {
 		p.ExitRule()
@@ -16849,7 +18358,7 @@ func (p *SQLParser) MetricName() (localctx IMetricNameContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(838)
+		p.SetState(933)
 		p.Ident()
 	}
 
@@ -16948,7 +18457,7 @@ func (p *SQLParser) TagKey() (localctx ITagKeyContext) {
 	_ = this
 
 	localctx = NewTagKeyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 188, SQLParserRULE_tagKey)
+	p.EnterRule(localctx, 200, SQLParserRULE_tagKey)
 
 	defer func() {
 		p.ExitRule()
@@ -16968,7 +18477,7 @@ func (p *SQLParser) TagKey() (localctx ITagKeyContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(840)
+		p.SetState(935)
 		p.Ident()
 	}
 
@@ -17067,7 +18576,7 @@ func (p *SQLParser) TagValue() (localctx ITagValueContext) {
 	_ = this
 
 	localctx = NewTagValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 190, SQLParserRULE_tagValue)
+	p.EnterRule(localctx, 202, SQLParserRULE_tagValue)
 
 	defer func() {
 		p.ExitRule()
@@ -17087,7 +18596,7 @@ func (p *SQLParser) TagValue() (localctx ITagValueContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(842)
+		p.SetState(937)
 		p.Ident()
 	}
 
@@ -17232,7 +18741,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 	_ = this
 
 	localctx = NewIdentContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 192, SQLParserRULE_ident)
+	p.EnterRule(localctx, 204, SQLParserRULE_ident)
 
 	defer func() {
 		p.ExitRule()
@@ -17253,48 +18762,48 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(846)
+	p.SetState(941)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserL_ID:
 		{
-			p.SetState(844)
+			p.SetState(939)
 			p.Match(SQLParserL_ID)
 		}
 
-	case SQLParserT_CREATE, SQLParserT_UPDATE, SQLParserT_SET, SQLParserT_DROP, SQLParserT_INTERVAL, SQLParserT_INTERVAL_NAME, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_MEMORY, SQLParserT_TTL, SQLParserT_META_TTL, SQLParserT_PAST_TTL, SQLParserT_FUTURE_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_USE, SQLParserT_STATE_REPO, SQLParserT_STATE_MACHINE, SQLParserT_MASTER, SQLParserT_METADATA, SQLParserT_TYPES, SQLParserT_TYPE, SQLParserT_STORAGES, SQLParserT_STORAGE, SQLParserT_BROKER, SQLParserT_ROOT, SQLParserT_BROKERS, SQLParserT_ALIVE, SQLParserT_SCHEMAS, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_METRICS, SQLParserT_METRIC, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_INFO, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_VALUE, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_EXPLAIN, SQLParserT_WITH_VALUE, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_HAVING, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_NOW, SQLParserT_IN, SQLParserT_LOG, SQLParserT_PROFILE, SQLParserT_REQUESTS, SQLParserT_REQUEST, SQLParserT_ID, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_LAST, SQLParserT_FIRST, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_QUANTILE, SQLParserT_RATE, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+	case SQLParserT_CREATE, SQLParserT_UPDATE, SQLParserT_SET, SQLParserT_DROP, SQLParserT_INTERVAL, SQLParserT_INTERVAL_NAME, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_REPLICA, SQLParserT_LAG, SQLParserT_WRITE, SQLParserT_MEMORY, SQLParserT_TTL, SQLParserT_META_TTL, SQLParserT_PAST_TTL, SQLParserT_FUTURE_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_USE, SQLParserT_STATE_REPO, SQLParserT_STATE_MACHINE, SQLParserT_MASTER, SQLParserT_METADATA, SQLParserT_TYPES, SQLParserT_TYPE, SQLParserT_STORAGES, SQLParserT_STORAGE, SQLParserT_BROKER, SQLParserT_ROOT, SQLParserT_BROKERS, SQLParserT_ALIVE, SQLParserT_SCHEMAS, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_METRICS, SQLParserT_METRIC, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_INFO, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_VALUE, SQLParserT_DISTINCT, SQLParserT_PRECISION, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_EXPLAIN, SQLParserT_WITH_VALUE, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_HAVING, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_NOW, SQLParserT_IN, SQLParserT_SINCE, SQLParserT_UNTIL, SQLParserT_AGO, SQLParserT_LOG, SQLParserT_PROFILE, SQLParserT_REQUESTS, SQLParserT_REQUEST, SQLParserT_ID, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_LAST, SQLParserT_FIRST, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_QUANTILE, SQLParserT_RATE, SQLParserT_PERCENT, SQLParserT_COUNT_IF, SQLParserT_SUM_IF, SQLParserT_OFFSET, SQLParserT_MEDIAN, SQLParserT_DERIVATIVE, SQLParserT_TOPK, SQLParserT_BOTTOMK, SQLParserT_HISTOGRAM_QUANTILE, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
 		{
-			p.SetState(845)
+			p.SetState(940)
 			p.NonReservedWords()
 		}
 
 	default:
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
-	p.SetState(855)
+	p.SetState(950)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 66, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 77, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
 			{
-				p.SetState(848)
+				p.SetState(943)
 				p.Match(SQLParserT_DOT)
 			}
-			p.SetState(851)
+			p.SetState(946)
 			p.GetErrorHandler().Sync(p)
 
 			switch p.GetTokenStream().LA(1) {
 			case SQLParserL_ID:
 				{
-					p.SetState(849)
+					p.SetState(944)
 					p.Match(SQLParserL_ID)
 				}
 
-			case SQLParserT_CREATE, SQLParserT_UPDATE, SQLParserT_SET, SQLParserT_DROP, SQLParserT_INTERVAL, SQLParserT_INTERVAL_NAME, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_MEMORY, SQLParserT_TTL, SQLParserT_META_TTL, SQLParserT_PAST_TTL, SQLParserT_FUTURE_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_USE, SQLParserT_STATE_REPO, SQLParserT_STATE_MACHINE, SQLParserT_MASTER, SQLParserT_METADATA, SQLParserT_TYPES, SQLParserT_TYPE, SQLParserT_STORAGES, SQLParserT_STORAGE, SQLParserT_BROKER, SQLParserT_ROOT, SQLParserT_BROKERS, SQLParserT_ALIVE, SQLParserT_SCHEMAS, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_METRICS, SQLParserT_METRIC, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_INFO, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_VALUE, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_EXPLAIN, SQLParserT_WITH_VALUE, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_HAVING, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_NOW, SQLParserT_IN, SQLParserT_LOG, SQLParserT_PROFILE, SQLParserT_REQUESTS, SQLParserT_REQUEST, SQLParserT_ID, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_LAST, SQLParserT_FIRST, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_QUANTILE, SQLParserT_RATE, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+			case SQLParserT_CREATE, SQLParserT_UPDATE, SQLParserT_SET, SQLParserT_DROP, SQLParserT_INTERVAL, SQLParserT_INTERVAL_NAME, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_REPLICA, SQLParserT_LAG, SQLParserT_WRITE, SQLParserT_MEMORY, SQLParserT_TTL, SQLParserT_META_TTL, SQLParserT_PAST_TTL, SQLParserT_FUTURE_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_USE, SQLParserT_STATE_REPO, SQLParserT_STATE_MACHINE, SQLParserT_MASTER, SQLParserT_METADATA, SQLParserT_TYPES, SQLParserT_TYPE, SQLParserT_STORAGES, SQLParserT_STORAGE, SQLParserT_BROKER, SQLParserT_ROOT, SQLParserT_BROKERS, SQLParserT_ALIVE, SQLParserT_SCHEMAS, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_METRICS, SQLParserT_METRIC, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_INFO, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_VALUE, SQLParserT_DISTINCT, SQLParserT_PRECISION, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_EXPLAIN, SQLParserT_WITH_VALUE, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_HAVING, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_NOW, SQLParserT_IN, SQLParserT_SINCE, SQLParserT_UNTIL, SQLParserT_AGO, SQLParserT_LOG, SQLParserT_PROFILE, SQLParserT_REQUESTS, SQLParserT_REQUEST, SQLParserT_ID, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_LAST, SQLParserT_FIRST, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_QUANTILE, SQLParserT_RATE, SQLParserT_PERCENT, SQLParserT_COUNT_IF, SQLParserT_SUM_IF, SQLParserT_OFFSET, SQLParserT_MEDIAN, SQLParserT_DERIVATIVE, SQLParserT_TOPK, SQLParserT_BOTTOMK, SQLParserT_HISTOGRAM_QUANTILE, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
 				{
-					p.SetState(850)
+					p.SetState(945)
 					p.NonReservedWords()
 				}
 
@@ -17303,9 +18812,9 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 			}
 
 		}
-		p.SetState(857)
+		p.SetState(952)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 66, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 77, p.GetParserRuleContext())
 	}
 
 	return localctx
@@ -17327,6 +18836,9 @@ type INonReservedWordsContext interface {
 	T_INTERVAL_NAME() antlr.TerminalNode
 	T_SHARD() antlr.TerminalNode
 	T_REPLICATION() antlr.TerminalNode
+	T_REPLICA() antlr.TerminalNode
+	T_LAG() antlr.TerminalNode
+	T_WRITE() antlr.TerminalNode
 	T_MEMORY() antlr.TerminalNode
 	T_TTL() antlr.TerminalNode
 	T_META_TTL() antlr.TerminalNode
@@ -17351,6 +18863,8 @@ type INonReservedWordsContext interface {
 	T_WITH() antlr.TerminalNode
 	T_VALUES() antlr.TerminalNode
 	T_VALUE() antlr.TerminalNode
+	T_DISTINCT() antlr.TerminalNode
+	T_PRECISION() antlr.TerminalNode
 	T_FROM() antlr.TerminalNode
 	T_WHERE() antlr.TerminalNode
 	T_LIMIT() antlr.TerminalNode
@@ -17380,6 +18894,9 @@ type INonReservedWordsContext interface {
 	T_TIME() antlr.TerminalNode
 	T_NOW() antlr.TerminalNode
 	T_IN() antlr.TerminalNode
+	T_SINCE() antlr.TerminalNode
+	T_UNTIL() antlr.TerminalNode
+	T_AGO() antlr.TerminalNode
 	T_LOG() antlr.TerminalNode
 	T_PROFILE() antlr.TerminalNode
 	T_SUM() antlr.TerminalNode
@@ -17392,6 +18909,15 @@ type INonReservedWordsContext interface {
 	T_STDDEV() antlr.TerminalNode
 	T_QUANTILE() antlr.TerminalNode
 	T_RATE() antlr.TerminalNode
+	T_PERCENT() antlr.TerminalNode
+	T_COUNT_IF() antlr.TerminalNode
+	T_SUM_IF() antlr.TerminalNode
+	T_OFFSET() antlr.TerminalNode
+	T_MEDIAN() antlr.TerminalNode
+	T_DERIVATIVE() antlr.TerminalNode
+	T_TOPK() antlr.TerminalNode
+	T_BOTTOMK() antlr.TerminalNode
+	T_HISTOGRAM_QUANTILE() antlr.TerminalNode
 	T_SECOND() antlr.TerminalNode
 	T_MINUTE() antlr.TerminalNode
 	T_HOUR() antlr.TerminalNode
@@ -17480,6 +19006,18 @@ func (s *NonReservedWordsContext) T_REPLICATION() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_REPLICATION, 0)
 }
 
+func (s *NonReservedWordsContext) T_REPLICA() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_REPLICA, 0)
+}
+
+func (s *NonReservedWordsContext) T_LAG() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LAG, 0)
+}
+
+func (s *NonReservedWordsContext) T_WRITE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_WRITE, 0)
+}
+
 func (s *NonReservedWordsContext) T_MEMORY() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_MEMORY, 0)
 }
@@ -17576,6 +19114,14 @@ func (s *NonReservedWordsContext) T_VALUE() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_VALUE, 0)
 }
 
+func (s *NonReservedWordsContext) T_DISTINCT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DISTINCT, 0)
+}
+
+func (s *NonReservedWordsContext) T_PRECISION() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_PRECISION, 0)
+}
+
 func (s *NonReservedWordsContext) T_FROM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_FROM, 0)
 }
@@ -17692,6 +19238,18 @@ func (s *NonReservedWordsContext) T_IN() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_IN, 0)
 }
 
+func (s *NonReservedWordsContext) T_SINCE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SINCE, 0)
+}
+
+func (s *NonReservedWordsContext) T_UNTIL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_UNTIL, 0)
+}
+
+func (s *NonReservedWordsContext) T_AGO() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_AGO, 0)
+}
+
 func (s *NonReservedWordsContext) T_LOG() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_LOG, 0)
 }
@@ -17740,6 +19298,42 @@ func (s *NonReservedWordsContext) T_RATE() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_RATE, 0)
 }
 
+func (s *NonReservedWordsContext) T_PERCENT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_PERCENT, 0)
+}
+
+func (s *NonReservedWordsContext) T_COUNT_IF() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_COUNT_IF, 0)
+}
+
+func (s *NonReservedWordsContext) T_SUM_IF() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SUM_IF, 0)
+}
+
+func (s *NonReservedWordsContext) T_OFFSET() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_OFFSET, 0)
+}
+
+func (s *NonReservedWordsContext) T_MEDIAN() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MEDIAN, 0)
+}
+
+func (s *NonReservedWordsContext) T_DERIVATIVE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DERIVATIVE, 0)
+}
+
+func (s *NonReservedWordsContext) T_TOPK() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TOPK, 0)
+}
+
+func (s *NonReservedWordsContext) T_BOTTOMK() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_BOTTOMK, 0)
+}
+
+func (s *NonReservedWordsContext) T_HISTOGRAM_QUANTILE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_HISTOGRAM_QUANTILE, 0)
+}
+
 func (s *NonReservedWordsContext) T_SECOND() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_SECOND, 0)
 }
@@ -17871,7 +19465,7 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 	_ = this
 
 	localctx = NewNonReservedWordsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 194, SQLParserRULE_nonReservedWords)
+	p.EnterRule(localctx, 206, SQLParserRULE_nonReservedWords)
 	var _la int
 
 	defer func() {
@@ -17892,10 +19486,10 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(858)
+		p.SetState(953)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&-4194368) != 0) || ((int64((_la-64)) & ^0x3f) == 0 && ((int64(1)<<(_la-64))&1099511627775) != 0)) {
+		if !(((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&-33554496) != 0) || ((int64((_la-64)) & ^0x3f) == 0 && ((int64(1)<<(_la-64))&144115188075855871) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -17908,21 +19502,21 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 
 func (p *SQLParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 53:
+	case 55:
 		var t *TagFilterExprContext = nil
 		if localctx != nil {
 			t = localctx.(*TagFilterExprContext)
 		}
 		return p.TagFilterExpr_Sempred(t, predIndex)
 
-	case 69:
+	case 72:
 		var t *BoolExprContext = nil
 		if localctx != nil {
 			t = localctx.(*BoolExprContext)
 		}
 		return p.BoolExpr_Sempred(t, predIndex)
 
-	case 74:
+	case 77:
 		var t *FieldExprContext = nil
 		if localctx != nil {
 			t = localctx.(*FieldExprContext)
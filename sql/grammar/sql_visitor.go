@@ -75,6 +75,12 @@ type SQLVisitor interface {
 	// Visit a parse tree produced by SQLParser#showReplicationStmt.
 	VisitShowReplicationStmt(ctx *ShowReplicationStmtContext) interface{}
 
+	// Visit a parse tree produced by SQLParser#showReplicaLagStmt.
+	VisitShowReplicaLagStmt(ctx *ShowReplicaLagStmtContext) interface{}
+
+	// Visit a parse tree produced by SQLParser#showWriteStatsStmt.
+	VisitShowWriteStatsStmt(ctx *ShowWriteStatsStmtContext) interface{}
+
 	// Visit a parse tree produced by SQLParser#showMemoryDatabaseStmt.
 	VisitShowMemoryDatabaseStmt(ctx *ShowMemoryDatabaseStmtContext) interface{}
 
@@ -207,6 +213,9 @@ type SQLVisitor interface {
 	// Visit a parse tree produced by SQLParser#nowFunc.
 	VisitNowFunc(ctx *NowFuncContext) interface{}
 
+	// Visit a parse tree produced by SQLParser#timeRangeClause.
+	VisitTimeRangeClause(ctx *TimeRangeClauseContext) interface{}
+
 	// Visit a parse tree produced by SQLParser#groupByClause.
 	VisitGroupByClause(ctx *GroupByClauseContext) interface{}
 
@@ -270,6 +279,9 @@ type SQLVisitor interface {
 	// Visit a parse tree produced by SQLParser#funcParam.
 	VisitFuncParam(ctx *FuncParamContext) interface{}
 
+	// Visit a parse tree produced by SQLParser#fieldPredicate.
+	VisitFieldPredicate(ctx *FieldPredicateContext) interface{}
+
 	// Visit a parse tree produced by SQLParser#exprAtom.
 	VisitExprAtom(ctx *ExprAtomContext) interface{}
 
@@ -303,6 +315,12 @@ type SQLVisitor interface {
 	// Visit a parse tree produced by SQLParser#limitClause.
 	VisitLimitClause(ctx *LimitClauseContext) interface{}
 
+	// Visit a parse tree produced by SQLParser#precisionClause.
+	VisitPrecisionClause(ctx *PrecisionClauseContext) interface{}
+
+	// Visit a parse tree produced by SQLParser#offsetClause.
+	VisitOffsetClause(ctx *OffsetClauseContext) interface{}
+
 	// Visit a parse tree produced by SQLParser#metricName.
 	VisitMetricName(ctx *MetricNameContext) interface{}
 
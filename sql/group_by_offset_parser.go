@@ -0,0 +1,88 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var errGroupByOffsetNotQuery = errors.New("group by time offset only supports select statement")
+
+// splitGroupByTimeOffset removes the offset argument of group by time(interval, offset) from sql,
+// because grammar only supports time(interval), returns 0 offset if sql without offset argument.
+func splitGroupByTimeOffset(sql string) (sqlWithoutOffset string, offset int64, err error) {
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case isKeywordAt(sql, i, "time"):
+			open := i + len("time")
+			for open < len(sql) && isBlank(sql[open]) {
+				open++
+			}
+			if open >= len(sql) || sql[open] != '(' {
+				// not time function, maybe time condition
+				continue
+			}
+			closeParen := findCloseParen(sql, open)
+			if closeParen < 0 {
+				// let grammar reports the syntax error
+				return sql, 0, nil
+			}
+			comma := strings.IndexByte(sql[open:closeParen], ',')
+			if comma < 0 {
+				i = closeParen
+				continue
+			}
+			comma += open
+			duration := strings.TrimSpace(sql[comma+1 : closeParen])
+			offset, ok := parseDurationLit(duration)
+			if !ok {
+				return "", 0, fmt.Errorf("invalid group by time offset: '%s'", duration)
+			}
+			return sql[:comma] + sql[closeParen:], offset, nil
+		}
+	}
+	return sql, 0, nil
+}
+
+// applyGroupByTimeOffset sets the offset of group by time interval buckets,
+// offset must be less than group by time interval.
+func applyGroupByTimeOffset(stmt stmtpkg.Statement, offset int64) error {
+	query, ok := stmt.(*stmtpkg.Query)
+	if !ok {
+		return errGroupByOffsetNotQuery
+	}
+	if offset >= query.Interval.Int64() {
+		return fmt.Errorf("group by time offset(%s) must be less than interval(%s)",
+			timeutil.Interval(offset), query.Interval)
+	}
+	query.IntervalOffset = timeutil.Interval(offset)
+	return nil
+}
@@ -0,0 +1,98 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commontimeutil "github.com/lindb/common/pkg/timeutil"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitGroupByTimeOffset(t *testing.T) {
+	cases := []struct {
+		sql     string
+		result  string
+		offset  int64
+		wantErr bool
+	}{
+		{sql: "select f from cpu group by time(1d)", result: "select f from cpu group by time(1d)"},
+		{sql: "select f from cpu where time > now()-1d group by time()", result: "select f from cpu where time > now()-1d group by time()"},
+		{sql: "select f from cpu where host='time(1d, 8h)'", result: "select f from cpu where host='time(1d, 8h)'"},
+		{
+			sql:    "select f from cpu group by host, time(1d, 8h)",
+			result: "select f from cpu group by host, time(1d)",
+			offset: 8 * commontimeutil.OneHour,
+		},
+		{
+			sql:    "select f from cpu group by TIME ( 1h ,30m ) fill(0)",
+			result: "select f from cpu group by TIME ( 1h ) fill(0)",
+			offset: 30 * commontimeutil.OneMinute,
+		},
+		{sql: "select f from cpu group by time(1d", result: "select f from cpu group by time(1d"},
+		{sql: "select f from cpu group by time(1d, )", wantErr: true},
+		{sql: "select f from cpu group by time(1d, abc)", wantErr: true},
+		{sql: "select f from cpu group by time(1d, 0s)", wantErr: true},
+	}
+	for _, c := range cases {
+		result, offset, err := splitGroupByTimeOffset(c.sql)
+		if c.wantErr {
+			assert.Error(t, err, c.sql)
+			continue
+		}
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.result, result, c.sql)
+		assert.Equal(t, c.offset, offset, c.sql)
+	}
+}
+
+func TestQuery_GroupByTimeOffset(t *testing.T) {
+	q, err := Parse("select f from cpu group by host, time(1d, 8h)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneDay), query.Interval)
+	assert.Equal(t, timeutil.Interval(8*commontimeutil.OneHour), query.IntervalOffset)
+	assert.Equal(t, []string{"host"}, query.GroupBy)
+
+	// zero offset if not set
+	q, err = Parse("select f from cpu group by time(1d)")
+	assert.NoError(t, err)
+	assert.Zero(t, q.(*stmt.Query).IntervalOffset)
+
+	// offset of sub query
+	q, err = Parse("select sum(v) from (select avg(f) as v from cpu group by host, time(1h, 30m)) group by time(1d, 8h)")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, timeutil.Interval(8*commontimeutil.OneHour), query.IntervalOffset)
+	assert.Equal(t, timeutil.Interval(30*commontimeutil.OneMinute), query.SubQuery.IntervalOffset)
+
+	// offset must be less than interval
+	_, err = Parse("select f from cpu group by time(1h, 1h)")
+	assert.Error(t, err)
+	_, err = Parse("select f from cpu group by time(, 1h)")
+	assert.Error(t, err)
+	// not query statement
+	_, err = Parse("show databases time(1d, 8h)")
+	assert.Error(t, err)
+	_, err = Parse("select f from cpu group by time(1d, bad)")
+	assert.Error(t, err)
+}
@@ -18,6 +18,8 @@
 package sql
 
 import (
+	"time"
+
 	"github.com/lindb/lindb/sql/grammar"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -35,11 +37,16 @@ type listener struct {
 	requestStmt        *requestStmtParser
 	brokerStmt         *brokerStmtParser
 	limitStmt          *limitStmtParser
+
+	location *time.Location // location of time zone clause, nil if not set
 }
 
 // EnterQueryStmt is called when production queryStmt is entered.
 func (l *listener) EnterQueryStmt(ctx *grammar.QueryStmtContext) {
 	l.queryStmt = newQueryStmtParse(ctx.T_EXPLAIN() != nil)
+	if l.location != nil {
+		l.queryStmt.location = l.location
+	}
 }
 
 // EnterShowMetadataTypesStmt is called when production showMetadataTypesStmt is entered.
@@ -134,6 +141,16 @@ func (l *listener) EnterShowReplicationStmt(_ *grammar.ShowReplicationStmtContex
 	l.stateStmt = newStateStmtParse(stmt.Replication)
 }
 
+// EnterShowReplicaLagStmt is called when production showReplicaLagStmt is entered.
+func (l *listener) EnterShowReplicaLagStmt(_ *grammar.ShowReplicaLagStmtContext) {
+	l.stateStmt = newStateStmtParse(stmt.StorageReplicaLag)
+}
+
+// EnterShowWriteStatsStmt is called when production showWriteStatsStmt is entered.
+func (l *listener) EnterShowWriteStatsStmt(_ *grammar.ShowWriteStatsStmtContext) {
+	l.stateStmt = newStateStmtParse(stmt.StorageWriteStats)
+}
+
 // EnterShowMemoryDatabaseStmt is called when production showMemoryDatabaseStmt is entered.
 func (l *listener) EnterShowMemoryDatabaseStmt(_ *grammar.ShowMemoryDatabaseStmtContext) {
 	l.stateStmt = newStateStmtParse(stmt.MemoryDatabase)
@@ -264,8 +281,9 @@ func (l *listener) EnterShowTagKeysStmt(_ *grammar.ShowTagKeysStmtContext) {
 }
 
 // EnterShowTagValuesStmt is called when production showTagValuesStmt is entered.
-func (l *listener) EnterShowTagValuesStmt(_ *grammar.ShowTagValuesStmtContext) {
+func (l *listener) EnterShowTagValuesStmt(ctx *grammar.ShowTagValuesStmtContext) {
 	l.metricMetadataStmt = newMetricMetadataStmtParser(stmt.TagValue)
+	l.metricMetadataStmt.distinct = ctx.T_DISTINCT() != nil
 }
 
 // EnterNamespace is called when production namespace is entered.
@@ -337,6 +355,13 @@ func (l *listener) EnterFuncName(ctx *grammar.FuncNameContext) {
 	}
 }
 
+// EnterFieldPredicate is called when production fieldPredicate is entered.
+func (l *listener) EnterFieldPredicate(ctx *grammar.FieldPredicateContext) {
+	if l.queryStmt != nil {
+		l.queryStmt.visitFieldPredicate(ctx)
+	}
+}
+
 // ExitExprFunc is called when production exprFunc is exited.
 func (l *listener) ExitExprFunc(_ *grammar.ExprFuncContext) {
 	if l.queryStmt != nil {
@@ -368,6 +393,20 @@ func (l *listener) EnterLimitClause(ctx *grammar.LimitClauseContext) {
 	}
 }
 
+// EnterPrecisionClause is called when production precisionClause is entered.
+func (l *listener) EnterPrecisionClause(ctx *grammar.PrecisionClauseContext) {
+	if l.metricMetadataStmt != nil {
+		l.metricMetadataStmt.visitPrecision(ctx)
+	}
+}
+
+// EnterOffsetClause is called when production offsetClause is entered.
+func (l *listener) EnterOffsetClause(ctx *grammar.OffsetClauseContext) {
+	if l.queryStmt != nil {
+		l.queryStmt.visitOffset(ctx)
+	}
+}
+
 // EnterTagFilterExpr is called when production tagFilterExpr is entered.
 func (l *listener) EnterTagFilterExpr(ctx *grammar.TagFilterExprContext) {
 	switch {
@@ -405,6 +444,13 @@ func (l *listener) EnterTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
 	}
 }
 
+// EnterTimeRangeClause is called when production timeRangeClause is entered.
+func (l *listener) EnterTimeRangeClause(ctx *grammar.TimeRangeClauseContext) {
+	if l.queryStmt != nil {
+		l.queryStmt.visitTimeRangeClause(ctx)
+	}
+}
+
 // EnterGroupByKey is called when production groupByClause is entered.
 func (l *listener) EnterGroupByKey(ctx *grammar.GroupByKeyContext) {
 	if l.queryStmt != nil {
@@ -412,6 +458,13 @@ func (l *listener) EnterGroupByKey(ctx *grammar.GroupByKeyContext) {
 	}
 }
 
+// EnterFillOption is called when production fillOption is entered.
+func (l *listener) EnterFillOption(ctx *grammar.FillOptionContext) {
+	if l.queryStmt != nil {
+		l.queryStmt.visitFillOption(ctx)
+	}
+}
+
 // EnterSortField is called when production sortField is entered.
 func (l *listener) EnterSortField(ctx *grammar.SortFieldContext) {
 	if l.queryStmt != nil {
@@ -18,9 +18,13 @@
 package sql
 
 import (
+	"fmt"
+	"strconv"
+
 	commonconstants "github.com/lindb/common/constants"
 
 	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/sketch"
 	"github.com/lindb/lindb/pkg/strutil"
 	"github.com/lindb/lindb/sql/grammar"
 	"github.com/lindb/lindb/sql/stmt"
@@ -32,6 +36,8 @@ type metricMetadataStmtParser struct {
 	metadataType stmt.MetricMetadataType
 	tagKey       string
 	prefix       string
+	distinct     bool
+	precision    uint8
 }
 
 // newMetricMetadataStmtParser creates a new metric metadata statement parser
@@ -50,6 +56,9 @@ func (s *metricMetadataStmtParser) build() (stmt.Statement, error) {
 	if s.err != nil {
 		return nil, s.err
 	}
+	if len(s.metricNames) > 1 {
+		return nil, fmt.Errorf("metadata query only supports single metric")
+	}
 	if s.limit <= 0 {
 		s.limit = 100
 	}
@@ -61,6 +70,8 @@ func (s *metricMetadataStmtParser) build() (stmt.Statement, error) {
 		Prefix:     s.prefix,
 		Condition:  s.condition,
 		Limit:      s.limit,
+		Distinct:   s.distinct,
+		Precision:  s.precision,
 	}, nil
 }
 
@@ -73,3 +84,13 @@ func (s *metricMetadataStmtParser) visitPrefix(ctx *grammar.PrefixContext) {
 func (s *metricMetadataStmtParser) visitWithTagKey(ctx *grammar.WithTagKeyContext) {
 	s.tagKey = strutil.GetStringValue(ctx.Ident().GetText())
 }
+
+// visitPrecision visits when production precision clause is entered
+func (s *metricMetadataStmtParser) visitPrecision(ctx *grammar.PrecisionClauseContext) {
+	precision, err := strconv.ParseUint(ctx.L_INT().GetText(), 10, 8)
+	if err != nil || uint8(precision) < sketch.MinPrecision || uint8(precision) > sketch.MaxPrecision {
+		s.err = fmt.Errorf("%w, precision: %s", sketch.ErrInvalidPrecision, ctx.L_INT().GetText())
+		return
+	}
+	s.precision = uint8(precision)
+}
@@ -76,6 +76,10 @@ func TestMetaStmt_ShowFields(t *testing.T) {
 	assert.Equal(t, stmt.Field, query.Type)
 	assert.Equal(t, "cpu", query.MetricName)
 	assert.Equal(t, "ns", query.Namespace)
+
+	// metadata query only supports single metric
+	_, err = Parse("show fields from cpu, mem")
+	assert.Error(t, err)
 }
 
 func TestMetaStmt_ShowTagKeys(t *testing.T) {
@@ -106,3 +110,43 @@ func TestMetaStmt_ShowTagValues(t *testing.T) {
 			Right:    &stmt.EqualsExpr{Key: "key2", Value: "value2"},
 		}, *expr)
 }
+
+func TestMetaStmt_ShowTagValuesCountDistinct(t *testing.T) {
+	q, err := Parse("show tag values from 'cpu' with key = 'host' limit 10")
+	assert.NoError(t, err)
+	assert.False(t, q.(*stmt.MetricMetadata).Distinct)
+
+	q, err = Parse("show tag values count distinct from 'cpu' on 'ns' with key = 'host' where region='sh'")
+	assert.NoError(t, err)
+	query := q.(*stmt.MetricMetadata)
+	assert.Equal(t, stmt.TagValue, query.Type)
+	assert.Equal(t, "cpu", query.MetricName)
+	assert.Equal(t, "ns", query.Namespace)
+	assert.Equal(t, "host", query.TagKey)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "region", Value: "sh"}, query.Condition)
+	assert.True(t, query.Distinct)
+	assert.Zero(t, query.Precision)
+
+	q, err = Parse("show tag values count distinct from cpu with key=host precision 12")
+	assert.NoError(t, err)
+	query = q.(*stmt.MetricMetadata)
+	assert.True(t, query.Distinct)
+	assert.Equal(t, uint8(12), query.Precision)
+
+	// distinct/precision are non-reserved words
+	q, err = Parse("select distinct from precision")
+	assert.NoError(t, err)
+	assert.Equal(t, "precision", q.(*stmt.Query).MetricName)
+
+	for _, sql := range []string{
+		"show tag values count distinct from cpu with key=host precision 3",
+		"show tag values count distinct from cpu with key=host precision 19",
+		"show tag values count distinct from cpu with key=host precision 256",
+		"show tag values count distinct from cpu with key=host limit 10",
+		"show tag values from cpu with key=host precision 12",
+		"show tag values distinct from cpu with key=host",
+	} {
+		_, err = Parse(sql)
+		assert.Error(t, err, sql)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"strings"
 	"sync"
+	"time"
 
 	antlr "github.com/antlr/antlr4/runtime/Go/antlr/v4"
 
@@ -43,22 +44,96 @@ var walker = antlr.ParseTreeWalkerDefault
 
 // Parse parses sql using the grammar of LinDB query language
 func Parse(sql string) (stmt stmtpkg.Statement, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			switch x := r.(type) {
-			case string:
-				err = errors.New(x)
-			case error:
-				err = x
-			default:
-				err = errors.New("unknown panic when sql parse")
-			}
-			log.Error("parse sql", logger.String("sql", sql), logger.Error(err), logger.Stack())
-			stmt = nil
+	// clauses split before parsing also run under the parser's panic recovery
+	defer recoverParse(sql, &stmt, &err)
+
+	if clause, ok := splitInsert(sql); ok {
+		// insert statement doesn't support tz/sample by clauses
+		return parseInsert(clause)
+	}
+	sql, analyze := splitExplainAnalyze(sql)
+	sql, location, err := splitTimeZone(sql)
+	if err != nil {
+		return nil, err
+	}
+	sql, sampleInterval, err := splitSampleBy(sql)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err = parseStatement(sql, location)
+	if err == nil && analyze {
+		stmt, err = applyExplainAnalyze(stmt)
+	}
+	if err != nil || sampleInterval <= 0 {
+		return stmt, err
+	}
+	return applySampleBy(stmt, sampleInterval)
+}
+
+// parseStatement parses sql which removed the clauses not supported by grammar(tz/sample by).
+func parseStatement(sql string, location *time.Location) (stmtpkg.Statement, error) {
+	if outerSQL, innerSQL, ok := splitSubQuery(sql); ok {
+		return parseSubQuery(outerSQL, innerSQL, location)
+	}
+	if tagKeysSQL, ok := splitTagCardinality(sql); ok {
+		return parseTagCardinality(tagKeysSQL)
+	}
+	showSQL, condition, ok, err := splitShowMetrics(sql)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return parseShowMetrics(showSQL, condition)
+	}
+	if clause, ok := splitAlterDatabase(sql); ok {
+		return parseAlterDatabase(clause)
+	}
+	return parse(sql, location)
+}
+
+// recoverParse recovers the panic when parsing sql, converts it to the error of parse result.
+func recoverParse(sql string, stmt *stmtpkg.Statement, err *error) {
+	if r := recover(); r != nil {
+		switch x := r.(type) {
+		case string:
+			*err = errors.New(x)
+		case error:
+			*err = x
+		default:
+			*err = errors.New("unknown panic when sql parse")
 		}
-	}()
+		log.Error("parse sql", logger.String("sql", sql), logger.Error(*err), logger.Stack())
+		*stmt = nil
+	}
+}
+
+// parse parses single sql statement, absolute timestamps are parsed in location(UTC if nil).
+func parse(sql string, location *time.Location) (stmtpkg.Statement, error) {
+	return parseWithListener(sql, &listener{location: location})
+}
+
+// parseWithListener parses single sql statement, builds the statement by the sql listener.
+func parseWithListener(sql string, sqlListener *listener) (stmt stmtpkg.Statement, err error) {
+	defer recoverParse(sql, &stmt, &err)
 
+	sql, offset, err := splitGroupByTimeOffset(sql)
+	if err != nil {
+		return nil, err
+	}
+	sql, fieldFilters, err := splitFieldFilter(sql)
+	if err != nil {
+		return nil, err
+	}
+	sql, argFuncs, err := splitArgFunc(sql)
+	if err != nil {
+		return nil, err
+	}
+	sql, rateFuncs, err := splitRateFunc(sql)
+	if err != nil {
+		return nil, err
+	}
 	sql = strings.ReplaceAll(sql, `\"`, `"`)
+	sql = quoteIdentifiers(sql)
 	input := antlr.NewInputStream(sql)
 
 	lexer := getSQLLexer(input)
@@ -70,14 +145,70 @@ func Parse(sql string) (stmt stmtpkg.Statement, err error) {
 	defer putSQLParser(parser)
 
 	ctx := parser.Statement()
+	if token := tokens.LT(1); token.GetTokenType() != antlr.TokenEOF {
+		// statement is complete, but tokens left over
+		return nil, &SyntaxError{
+			Line:   token.GetLine(),
+			Column: token.GetColumn(),
+			Msg:    "extraneous input '" + token.GetText() + "' expecting <EOF>",
+		}
+	}
 
-	// create sql listener
-	sqlListener := listener{}
-
-	walker.Walk(&sqlListener, ctx)
+	walker.Walk(sqlListener, ctx)
 
 	stmt, err = sqlListener.statement()
-	return stmt, err
+	if err != nil {
+		return nil, err
+	}
+	if len(fieldFilters) > 0 {
+		if err := applyFieldFilter(stmt, fieldFilters); err != nil {
+			return nil, err
+		}
+	}
+	if len(argFuncs) > 0 {
+		if err := applyArgFunc(stmt, argFuncs); err != nil {
+			return nil, err
+		}
+	}
+	if len(rateFuncs) > 0 {
+		if err := applyRateFunc(stmt, rateFuncs); err != nil {
+			return nil, err
+		}
+	}
+	if offset > 0 {
+		if err := applyGroupByTimeOffset(stmt, offset); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+// parseDurationLit parses the positive duration using duration grammar, e.g. 1h, returns false if invalid.
+func parseDurationLit(duration string) (interval int64, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			interval, ok = 0, false
+		}
+	}()
+	if duration == "" {
+		return 0, false
+	}
+	input := antlr.NewInputStream(duration)
+
+	lexer := getSQLLexer(input)
+	defer putSQLLexer(lexer)
+
+	tokens := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+
+	parser := getSQLParserFunc(tokens)
+	defer putSQLParser(parser)
+
+	q := newQueryStmtParse(false)
+	interval = q.parseDuration(parser.DurationLit())
+	if q.err != nil || interval <= 0 || tokens.LA(1) != antlr.TokenEOF {
+		return 0, false
+	}
+	return interval, true
 }
 
 var (
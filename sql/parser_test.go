@@ -18,6 +18,7 @@
 package sql
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -69,6 +70,13 @@ func TestSelectAllFields(t *testing.T) {
 	queryStmt := query.(*stmt.Query)
 	assert.NoError(t, err)
 	assert.True(t, queryStmt.AllFields)
+
+	query, err = Parse("select * from cpu group by host")
+	assert.NoError(t, err)
+	queryStmt = query.(*stmt.Query)
+	assert.True(t, queryStmt.AllFields)
+	assert.Empty(t, queryStmt.SelectItems)
+	assert.Equal(t, []string{"host"}, queryStmt.GroupBy)
 }
 
 func TestShowDatabase(t *testing.T) {
@@ -94,6 +102,40 @@ func TestParse_panic(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestRecoverParse(t *testing.T) {
+	parseWithPanic := func(r interface{}) (q stmt.Statement, err error) {
+		defer recoverParse("select f from cpu", &q, &err)
+		q = &stmt.Query{}
+		panic(r)
+	}
+	q, err := parseWithPanic("err")
+	assert.EqualError(t, err, "err")
+	assert.Nil(t, q)
+	q, err = parseWithPanic(fmt.Errorf("err"))
+	assert.EqualError(t, err, "err")
+	assert.Nil(t, q)
+	q, err = parseWithPanic(123)
+	assert.Error(t, err)
+	assert.Nil(t, q)
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+	_, err := Parse("select f from cpu where")
+	var syntaxErr *SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, 1, syntaxErr.Line)
+	assert.Equal(t, len("select f from cpu where"), syntaxErr.Column)
+	assert.Equal(t, syntaxErr.Msg, err.Error())
+
+	_, err = Parse("select f\nfrom cpu group")
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, 2, syntaxErr.Line)
+
+	_, err = Parse("show databases limit 10")
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, len("show databases "), syntaxErr.Column)
+}
+
 func BenchmarkSQLParse(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = Parse("select f from cpu " +
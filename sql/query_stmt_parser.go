@@ -20,7 +20,9 @@ package sql
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
+	"time"
 
 	commonconstants "github.com/lindb/common/constants"
 	commontimeutil "github.com/lindb/common/pkg/timeutil"
@@ -33,6 +35,8 @@ import (
 	"github.com/lindb/lindb/sql/stmt"
 )
 
+var errRelativeTimeRangeConflict = errors.New("since/until/last cannot be used with time condition")
+
 // queryStmtParser represents query statement parser using visitor
 type queryStmtParser struct {
 	baseStmtParser
@@ -42,16 +46,24 @@ type queryStmtParser struct {
 	fieldNames  map[string]struct{} // cache field name include alias
 	allFields   bool
 
-	startTime int64
-	endTime   int64
+	startTime         int64
+	endTime           int64
+	relativeTimeRange bool           // time range set by since/until/last clause
+	location          *time.Location // location for parsing absolute timestamp, UTC by default
 
-	groupBy         []string
-	interval        int64
-	autoGroupByTime bool
-	orderBy         []stmt.Expr
+	groupBy           []string
+	groupByAll        bool
+	histogramQuantile bool // histogram_quantile needs buckets grouped by le
+	interval          int64
+	calendar          *timeutil.CalendarInterval // calendar interval of group by time(1w/1M/1y)
+	autoGroupByTime   bool
+	orderBy           []stmt.Expr
 
 	curOrderByExpr *stmt.OrderByExpr
 	hasOrderBy     bool
+
+	offset int
+	fill   *stmt.Fill
 }
 
 // newQueryStmtParse create a query statement parser
@@ -59,6 +71,7 @@ func newQueryStmtParse(explain bool) *queryStmtParser {
 	return &queryStmtParser{
 		explain:    explain,
 		fieldNames: make(map[string]struct{}),
+		location:   time.UTC,
 		baseStmtParser: baseStmtParser{
 			exprStack: collections.NewStack(),
 			namespace: commonconstants.DefaultNamespace,
@@ -77,6 +90,9 @@ func (q *queryStmtParser) build() (stmt.Statement, error) {
 	query.Explain = q.explain
 	query.Namespace = q.namespace
 	query.MetricName = q.metricName
+	if len(q.metricNames) > 1 {
+		query.MetricNames = q.metricNames
+	}
 	query.SelectItems = q.selectItems
 	query.Condition = q.condition
 
@@ -93,11 +109,31 @@ func (q *queryStmtParser) build() (stmt.Statement, error) {
 	}
 
 	query.Interval = timeutil.Interval(q.interval)
+	query.Calendar = q.calendar
 	query.AutoGroupByTime = q.autoGroupByTime
+	if q.location != time.UTC {
+		query.TimeZone = q.location.String()
+	}
 	query.AllFields = q.allFields
 	query.GroupBy = q.groupBy
+	if q.histogramQuantile {
+		// group by bucket tag implicitly, buckets are merged at root when interpolating quantile
+		hasBucketKey := false
+		for _, tagKey := range query.GroupBy {
+			if tagKey == function.HistogramBucketTagKey {
+				hasBucketKey = true
+				break
+			}
+		}
+		if !hasBucketKey {
+			query.GroupBy = append(query.GroupBy, function.HistogramBucketTagKey)
+		}
+	}
+	query.GroupByAll = q.groupByAll
 	query.OrderByItems = q.orderBy
 	query.Limit = q.limit
+	query.Offset = q.offset
+	query.Fill = q.fill
 	return query, nil
 }
 
@@ -106,9 +142,14 @@ func (q *queryStmtParser) validation() error {
 	if q.err != nil {
 		return q.err
 	}
-	if q.metricName == "" {
+	if len(q.metricNames) == 0 || q.metricName == "" {
 		return fmt.Errorf("metric name cannot be empty")
 	}
+	for _, metricName := range q.metricNames {
+		if metricName == "" {
+			return fmt.Errorf("metric name cannot be empty")
+		}
+	}
 	if !q.allFields && len(q.selectItems) == 0 {
 		return fmt.Errorf("select fields cannbe be empty")
 	}
@@ -126,9 +167,14 @@ func (q *queryStmtParser) visitGroupByKey(ctx *grammar.GroupByKeyContext) {
 	case ctx.Ident() != nil:
 		tagKey := strutil.GetStringValue(ctx.Ident().GetText())
 		q.groupBy = append(q.groupBy, tagKey)
+	case ctx.T_MUL() != nil:
+		// group by all tag keys of metric, expand when broker plan
+		q.groupByAll = true
 	case ctx.DurationLit() != nil:
 		// set group by time interval
 		q.interval = q.parseDuration(ctx.DurationLit())
+		// buckets of week/month/year are aligned to calendar, the width of which varies
+		q.calendar = q.parseCalendar(ctx.DurationLit())
 	default:
 		if ctx.T_TIME() != nil {
 			// set auto fill group by time interval flag
@@ -137,6 +183,23 @@ func (q *queryStmtParser) visitGroupByKey(ctx *grammar.GroupByKeyContext) {
 	}
 }
 
+// visitFillOption visits when production fill option expression is entered.
+func (q *queryStmtParser) visitFillOption(ctx *grammar.FillOptionContext) {
+	switch {
+	case ctx.T_NULL() != nil:
+		q.fill = &stmt.Fill{Type: stmt.FillNull}
+	case ctx.T_PREVIOUS() != nil:
+		q.fill = &stmt.Fill{Type: stmt.FillPrevious}
+	default:
+		val, err := strconv.ParseFloat(ctx.GetText(), 64)
+		if err != nil {
+			q.err = err
+			return
+		}
+		q.fill = &stmt.Fill{Type: stmt.FillValue, Value: val}
+	}
+}
+
 // visitSortField visits when production sort field expression is entered.
 func (q *queryStmtParser) visitSortField(ctx *grammar.SortFieldContext) {
 	q.hasOrderBy = true
@@ -178,6 +241,19 @@ func (q *queryStmtParser) check() error {
 	return nil
 }
 
+// visitOffset visits when production offset expression is entered.
+func (q *queryStmtParser) visitOffset(ctx *grammar.OffsetClauseContext) {
+	if ctx.L_INT() == nil {
+		return
+	}
+	offset, err := strconv.ParseInt(ctx.L_INT().GetText(), 10, 32)
+	if err != nil {
+		q.err = err
+		return
+	}
+	q.offset = int(offset)
+}
+
 // visitTimeRangeExpr visits when production timeRange expression is entered.
 func (q *queryStmtParser) visitTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
 	timeExprCtxList := ctx.AllTimeExpr()
@@ -190,7 +266,7 @@ func (q *queryStmtParser) visitTimeRangeExpr(ctx *grammar.TimeRangeExprContext)
 		var err error
 		switch {
 		case timeExprCtx.Ident() != nil:
-			timestamp, err = commontimeutil.ParseTimestamp(strutil.GetStringValue(timeExprCtx.Ident().GetText()))
+			timestamp, err = timeutil.ParseTimestampInLocation(strutil.GetStringValue(timeExprCtx.Ident().GetText()), q.location)
 		case timeExprCtx.NowExpr() != nil:
 			timestamp = commontimeutil.Now()
 			durationExpr, durationExist := timeExprCtx.NowExpr().(*grammar.NowExprContext)
@@ -219,6 +295,32 @@ func (q *queryStmtParser) visitTimeRangeExpr(ctx *grammar.TimeRangeExprContext)
 	}
 }
 
+// visitTimeRangeClause visits when production since/until/last clause is entered, sets the time range
+// relative to now, end time is now if until not set, start time is one hour before end time if since not set.
+func (q *queryStmtParser) visitTimeRangeClause(ctx *grammar.TimeRangeClauseContext) {
+	if q.startTime > 0 || q.endTime > 0 {
+		q.err = errRelativeTimeRangeConflict
+		return
+	}
+	durations := ctx.AllDurationLit()
+	if len(durations) == 0 {
+		return
+	}
+	now := commontimeutil.Now()
+	switch {
+	case ctx.T_LAST() != nil, ctx.T_SINCE() != nil:
+		q.startTime = now - q.parseDuration(durations[0])
+		q.endTime = now
+		if ctx.T_UNTIL() != nil && len(durations) > 1 {
+			q.endTime = now - q.parseDuration(durations[1])
+		}
+	case ctx.T_UNTIL() != nil:
+		q.endTime = now - q.parseDuration(durations[0])
+		q.startTime = q.endTime - commontimeutil.OneHour
+	}
+	q.relativeTimeRange = true
+}
+
 // parseDuration parses time duration from duration string
 func (q *queryStmtParser) parseDuration(ctx grammar.IDurationLitContext) int64 {
 	if ctx == nil {
@@ -261,6 +363,32 @@ func (q *queryStmtParser) parseDuration(ctx grammar.IDurationLitContext) int64 {
 	return result
 }
 
+// parseCalendar parses calendar interval from duration string, returns nil if unit is not week/month/year.
+func (q *queryStmtParser) parseCalendar(ctx grammar.IDurationLitContext) *timeutil.CalendarInterval {
+	durationCtx, ok := ctx.(*grammar.DurationLitContext)
+	if !ok || durationCtx.IntervalItem() == nil {
+		return nil
+	}
+	unit, ok := durationCtx.IntervalItem().(*grammar.IntervalItemContext)
+	if !ok {
+		return nil
+	}
+	count, err := strconv.Atoi(durationCtx.IntNumber().GetText())
+	if err != nil || count <= 0 {
+		return nil
+	}
+	switch {
+	case unit.T_WEEK() != nil:
+		return &timeutil.CalendarInterval{Unit: timeutil.CalendarWeek, Count: count}
+	case unit.T_MONTH() != nil:
+		return &timeutil.CalendarInterval{Unit: timeutil.CalendarMonth, Count: count}
+	case unit.T_YEAR() != nil:
+		return &timeutil.CalendarInterval{Unit: timeutil.CalendarYear, Count: count}
+	default:
+		return nil
+	}
+}
+
 // visitFieldExpr visits when production field expression is entered
 func (q *queryStmtParser) visitFieldExpr(ctx *grammar.FieldExprContext) {
 	switch {
@@ -321,17 +449,94 @@ func (q *queryStmtParser) visitFuncName(ctx *grammar.FuncNameContext) {
 		callExpr.FuncType = function.Stddev
 	case ctx.T_QUANTILE() != nil:
 		callExpr.FuncType = function.Quantile
+	case ctx.T_MEDIAN() != nil:
+		// median is quantile with p=0.5
+		callExpr.FuncType = function.Quantile
+		callExpr.Params = append(callExpr.Params, &stmt.NumberLiteral{Val: 0.5})
 	case ctx.T_RATE() != nil:
 		callExpr.FuncType = function.Rate
+	case ctx.T_PERCENT() != nil:
+		callExpr.FuncType = function.Percent
+	case ctx.T_COUNT_IF() != nil:
+		callExpr.FuncType = function.CountIf
+	case ctx.T_SUM_IF() != nil:
+		callExpr.FuncType = function.SumIf
+	case ctx.T_DERIVATIVE() != nil:
+		callExpr.FuncType = function.Derivative
+	case ctx.T_TOPK() != nil:
+		callExpr.FuncType = function.TopK
+	case ctx.T_BOTTOMK() != nil:
+		callExpr.FuncType = function.BottomK
+	case ctx.T_HISTOGRAM_QUANTILE() != nil:
+		callExpr.FuncType = function.HistogramQuantile
 	}
 }
 
+// visitFieldPredicate visits when production field predicate expression is entered,
+// field predicate is the param of conditional aggregation function, like count_if(f > 100).
+func (q *queryStmtParser) visitFieldPredicate(ctx *grammar.FieldPredicateContext) {
+	if q.exprStack.Empty() {
+		return
+	}
+	var (
+		valStr   string
+		operator stmt.BinaryOP
+	)
+	switch {
+	case ctx.DecNumber() != nil:
+		valStr = ctx.DecNumber().GetText()
+	case ctx.IntNumber() != nil:
+		valStr = ctx.IntNumber().GetText()
+	}
+	val, err := strconv.ParseFloat(valStr, 64)
+	if err != nil {
+		q.err = err
+		return
+	}
+	switch {
+	case ctx.T_EQUAL() != nil:
+		operator = stmt.EQUAL
+	case ctx.T_NOTEQUAL() != nil || ctx.T_NOTEQUAL2() != nil:
+		operator = stmt.NOTEQUAL
+	case ctx.T_LESS() != nil:
+		operator = stmt.LESS
+	case ctx.T_LESSEQUAL() != nil:
+		operator = stmt.LESSEQUAL
+	case ctx.T_GREATER() != nil:
+		operator = stmt.GREATER
+	case ctx.T_GREATEREQUAL() != nil:
+		operator = stmt.GREATEREQUAL
+	}
+	q.setExprParam(&stmt.BinaryExpr{
+		Left:     &stmt.FieldExpr{Name: strutil.GetStringValue(ctx.Ident().GetText())},
+		Right:    &stmt.NumberLiteral{Val: val},
+		Operator: operator,
+	})
+}
+
 // completeFuncExpr completes a function call expression for select list
 func (q *queryStmtParser) completeFuncExpr() {
 	cur := q.exprStack.Pop()
 	if cur != nil {
 		expr, ok := cur.(stmt.Expr)
 		if ok {
+			if callExpr, isCall := expr.(*stmt.CallExpr); isCall {
+				switch {
+				case callExpr.FuncType == function.Quantile:
+					if err := checkQuantile(callExpr); err != nil {
+						q.err = err
+					}
+				case function.IsRankSelector(callExpr.FuncType):
+					if err := checkRankSelector(callExpr); err != nil {
+						q.err = err
+					}
+				case callExpr.FuncType == function.HistogramQuantile:
+					if err := checkHistogramQuantile(callExpr); err != nil {
+						q.err = err
+					}
+					q.histogramQuantile = true
+				}
+			}
 			q.setExprParam(expr)
 		}
 		if q.exprStack.Empty() {
@@ -347,6 +552,63 @@ func (q *queryStmtParser) completeFuncExpr() {
 	}
 }
 
+// checkQuantile checks the params of quantile function,
+// first param is p value in (0,1), optional second param is histogram field.
+func checkQuantile(callExpr *stmt.CallExpr) error {
+	if len(callExpr.Params) == 0 || len(callExpr.Params) > 2 {
+		return errors.New("quantile function params length invalid")
+	}
+	p, ok := callExpr.Params[0].(*stmt.NumberLiteral)
+	if !ok {
+		return fmt.Errorf("quantile param: %s is not float", callExpr.Params[0].Rewrite())
+	}
+	if p.Val <= 0 || p.Val >= 1 {
+		return fmt.Errorf("quantile param: %v is illegal, must be in (0,1)", p.Val)
+	}
+	if len(callExpr.Params) == 2 {
+		if _, ok := callExpr.Params[1].(*stmt.FieldExpr); !ok {
+			return fmt.Errorf("quantile param: %s is not field", callExpr.Params[1].Rewrite())
+		}
+	}
+	return nil
+}
+
+// checkRankSelector checks the params of topk/bottomk function,
+// first param is n(positive integer), second param is the expression for ranking.
+func checkRankSelector(callExpr *stmt.CallExpr) error {
+	name := callExpr.FuncType.String()
+	if len(callExpr.Params) != 2 {
+		return fmt.Errorf("%s function params length invalid", name)
+	}
+	n, ok := callExpr.Params[0].(*stmt.NumberLiteral)
+	if !ok || n.Val != math.Trunc(n.Val) {
+		return fmt.Errorf("%s param: %s is not integer", name, callExpr.Params[0].Rewrite())
+	}
+	if n.Val <= 0 {
+		return fmt.Errorf("%s param: %v is illegal, must be greater than 0", name, n.Val)
+	}
+	return nil
+}
+
+// checkHistogramQuantile checks the params of histogram_quantile function,
+// first param is p value in (0,1), second param is the field of buckets(tagged by le).
+func checkHistogramQuantile(callExpr *stmt.CallExpr) error {
+	if len(callExpr.Params) != 2 {
+		return errors.New("histogram_quantile function params length invalid")
+	}
+	p, ok := callExpr.Params[0].(*stmt.NumberLiteral)
+	if !ok {
+		return fmt.Errorf("histogram_quantile param: %s is not float", callExpr.Params[0].Rewrite())
+	}
+	if p.Val <= 0 || p.Val >= 1 {
+		return fmt.Errorf("histogram_quantile param: %v is illegal, must be in (0,1)", p.Val)
+	}
+	if _, ok := callExpr.Params[1].(*stmt.FieldExpr); !ok {
+		return fmt.Errorf("histogram_quantile param: %s is not field", callExpr.Params[1].Rewrite())
+	}
+	return nil
+}
+
 // visitExprAtom visits when production atom expr expression is entered
 func (q *queryStmtParser) visitExprAtom(ctx *grammar.ExprAtomContext) {
 	switch {
@@ -91,9 +91,23 @@ func TestMetricName(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "cpu", query.MetricName)
 
+	assert.False(t, query.IsMultiMetrics())
+
+	sql = "select sum(f) from cpu, 'mem' on 'ns' group by host"
+	q, err = Parse(sql)
+	assert.Nil(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, "cpu", query.MetricName)
+	assert.Equal(t, []string{"cpu", "mem"}, query.MetricNames)
+	assert.Equal(t, "ns", query.Namespace)
+	assert.True(t, query.IsMultiMetrics())
+
 	sql = "select f "
 	_, err = Parse(sql)
 	assert.NotNil(t, err)
+	sql = "select f from cpu, ''"
+	_, err = Parse(sql)
+	assert.NotNil(t, err)
 }
 
 func TestSingleSelectItem(t *testing.T) {
@@ -144,6 +158,43 @@ func TestSelectFuncItem(t *testing.T) {
 		Expr: &stmt.CallExpr{FuncType: function.Quantile, Params: []stmt.Expr{&stmt.NumberLiteral{Val: 0.99}}},
 	}, *selectItem)
 
+	sql = "select quantile(0.99, latency) from http group by path"
+	q, err = Parse(sql)
+	assert.Nil(t, err)
+	query = q.(*stmt.Query)
+	selectItem = (query.SelectItems[0]).(*stmt.SelectItem)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.Quantile, Params: []stmt.Expr{
+			&stmt.NumberLiteral{Val: 0.99}, &stmt.FieldExpr{Name: "latency"},
+		}},
+	}, *selectItem)
+
+	// median is quantile with p=0.5
+	sql = "select median(latency) from http"
+	q, err = Parse(sql)
+	assert.Nil(t, err)
+	query = q.(*stmt.Query)
+	selectItem = (query.SelectItems[0]).(*stmt.SelectItem)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.Quantile, Params: []stmt.Expr{
+			&stmt.NumberLiteral{Val: 0.5}, &stmt.FieldExpr{Name: "latency"},
+		}},
+	}, *selectItem)
+
+	for _, invalidSQL := range []string{
+		"select quantile(1) from http",
+		"select quantile(0) from http",
+		"select quantile(-0.5, latency) from http",
+		"select quantile(1.5, latency) from http",
+		"select quantile(latency) from http",
+		"select quantile(0.9, 1) from http",
+		"select quantile(0.9, latency, f) from http",
+		"select median(latency, f) from http",
+	} {
+		_, err = Parse(invalidSQL)
+		assert.Error(t, err, invalidSQL)
+	}
+
 	sql = "select rate(f) from memory"
 	q, err = Parse(sql)
 	query = q.(*stmt.Query)
@@ -154,6 +205,116 @@ func TestSelectFuncItem(t *testing.T) {
 		Expr: &stmt.CallExpr{FuncType: function.Rate, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
 	}, *selectItem)
 
+	sql = "select derivative(f) from memory"
+	q, err = Parse(sql)
+	query = q.(*stmt.Query)
+	assert.Nil(t, err)
+	selectItem = (query.SelectItems[0]).(*stmt.SelectItem)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.Derivative, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+	}, *selectItem)
+
+	sql = "select percent(sum(f)) from memory group by host"
+	q, err = Parse(sql)
+	query = q.(*stmt.Query)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(query.SelectItems))
+	selectItem = (query.SelectItems[0]).(*stmt.SelectItem)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.Percent, Params: []stmt.Expr{
+			&stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+		}},
+	}, *selectItem)
+
+	sql = "select topk(5, sum(f)), bottomk(2, max(f)) as b from cpu group by host"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, 2, len(query.SelectItems))
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.TopK, Params: []stmt.Expr{
+			&stmt.NumberLiteral{Val: 5},
+			&stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+		}},
+	}, *(query.SelectItems[0]).(*stmt.SelectItem))
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.BottomK, Params: []stmt.Expr{
+			&stmt.NumberLiteral{Val: 2},
+			&stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+		}},
+		Alias: "b",
+	}, *(query.SelectItems[1]).(*stmt.SelectItem))
+	for _, invalidSQL := range []string{
+		"select topk(0, sum(f)) from cpu",
+		"select topk(-1, sum(f)) from cpu",
+		"select topk(1.5, sum(f)) from cpu",
+		"select bottomk(sum(f)) from cpu",
+		"select bottomk(f, sum(f)) from cpu",
+		"select topk(1, sum(f), f) from cpu",
+	} {
+		_, err = Parse(invalidSQL)
+		assert.Error(t, err, invalidSQL)
+	}
+
+	sql = "select histogram_quantile(0.99, value) as p99 from http_duration_bucket group by host"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.HistogramQuantile, Params: []stmt.Expr{
+			&stmt.NumberLiteral{Val: 0.99},
+			&stmt.FieldExpr{Name: "value"},
+		}},
+		Alias: "p99",
+	}, *(query.SelectItems[0]).(*stmt.SelectItem))
+	// group by le implicitly
+	assert.Equal(t, []string{"host", "le"}, query.GroupBy)
+	q, err = Parse("select histogram_quantile(0.5, value) from http_duration_bucket group by le, host")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"le", "host"}, q.(*stmt.Query).GroupBy)
+	for _, invalidSQL := range []string{
+		"select histogram_quantile(0, value) from cpu",
+		"select histogram_quantile(1, value) from cpu",
+		"select histogram_quantile(value, value) from cpu",
+		"select histogram_quantile(0.9, sum(value)) from cpu",
+		"select histogram_quantile(0.9) from cpu",
+	} {
+		_, err = Parse(invalidSQL)
+		assert.Error(t, err, invalidSQL)
+	}
+
+	sql = "select count_if(f > 100), sum_if(f <= -1.5) as s from memory"
+	q, err = Parse(sql)
+	query = q.(*stmt.Query)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(query.SelectItems))
+	selectItem = (query.SelectItems[0]).(*stmt.SelectItem)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.CountIf, Params: []stmt.Expr{
+			&stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f"}, Right: &stmt.NumberLiteral{Val: 100}, Operator: stmt.GREATER},
+		}},
+	}, *selectItem)
+	assert.Equal(t, "count_if(f>100.00)", selectItem.Rewrite())
+	selectItem = (query.SelectItems[1]).(*stmt.SelectItem)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.SumIf, Params: []stmt.Expr{
+			&stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f"}, Right: &stmt.NumberLiteral{Val: -1.5}, Operator: stmt.LESSEQUAL},
+		}},
+		Alias: "s",
+	}, *selectItem)
+	for sql, op := range map[string]stmt.BinaryOP{
+		"select count_if(f = 1) from memory":  stmt.EQUAL,
+		"select count_if(f != 1) from memory": stmt.NOTEQUAL,
+		"select count_if(f <> 1) from memory": stmt.NOTEQUAL,
+		"select count_if(f < 1) from memory":  stmt.LESS,
+		"select count_if(f >= 1) from memory": stmt.GREATEREQUAL,
+	} {
+		q, err = Parse(sql)
+		assert.NoError(t, err)
+		selectItem = (q.(*stmt.Query).SelectItems[0]).(*stmt.SelectItem)
+		assert.Equal(t, op, selectItem.Expr.(*stmt.CallExpr).Params[0].(*stmt.BinaryExpr).Operator)
+	}
+
 	sql = "select last(f) from memory"
 	q, err = Parse(sql)
 	query = q.(*stmt.Query)
@@ -351,6 +512,32 @@ func TestLimit(t *testing.T) {
 	query = q.(*stmt.Query)
 	assert.Nil(t, err)
 	assert.Equal(t, 20, query.Limit)
+	assert.Zero(t, query.Offset)
+}
+
+func TestOffset(t *testing.T) {
+	sql := "select f from cpu group by host limit 50 offset 100"
+	q, err := Parse(sql)
+	assert.Nil(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, 50, query.Limit)
+	assert.Equal(t, 100, query.Offset)
+
+	// default limit with offset
+	sql = "select f from cpu offset 10"
+	q, err = Parse(sql)
+	assert.Nil(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, 20, query.Limit)
+	assert.Equal(t, 10, query.Offset)
+
+	sql = "select f from cpu limit 10 offset -1"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+
+	sql = "select f from cpu limit 10 offset 99999999999"
+	_, err = Parse(sql)
+	assert.Error(t, err)
 }
 
 func TestTimeRange(t *testing.T) {
@@ -370,6 +557,68 @@ func TestTimeRange(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTimeRangeClause(t *testing.T) {
+	assertTimeRange := func(query *stmt.Query, since, until int64) {
+		now := commontimeutil.Now()
+		assert.InDelta(t, now-since, query.TimeRange.Start, float64(commontimeutil.OneMinute))
+		assert.InDelta(t, now-until, query.TimeRange.End, float64(commontimeutil.OneMinute))
+	}
+	q, err := Parse("select f from cpu LAST 30m")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assertTimeRange(query, 30*commontimeutil.OneMinute, 0)
+	assert.Equal(t, 30*commontimeutil.OneMinute, query.TimeRange.End-query.TimeRange.Start)
+
+	q, err = Parse("select f from cpu where host='a' SINCE 2h AGO group by host")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assertTimeRange(query, 2*commontimeutil.OneHour, 0)
+	assert.Equal(t, []string{"host"}, query.GroupBy)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "a"}, query.Condition)
+
+	q, err = Parse("select f from cpu since 3h ago until 1h ago")
+	assert.NoError(t, err)
+	assertTimeRange(q.(*stmt.Query), 3*commontimeutil.OneHour, commontimeutil.OneHour)
+	// start time is one hour before end time if since not set
+	q, err = Parse("select f from cpu until 1h ago")
+	assert.NoError(t, err)
+	assertTimeRange(q.(*stmt.Query), 2*commontimeutil.OneHour, commontimeutil.OneHour)
+
+	// since/until/last are non-reserved words
+	q, err = Parse("select last(f) from cpu where since='a' group by until")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, []string{"until"}, query.GroupBy)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "since", Value: "a"}, query.Condition)
+	q, err = Parse("select f from cpu where host='last 30m'")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "last 30m"}, q.(*stmt.Query).Condition)
+
+	// applies to sub query
+	q, err = Parse("select sum(v) from (select avg(f) as v from cpu group by host) last 1d")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assertTimeRange(query.SubQuery, commontimeutil.OneDay, 0)
+	q, err = Parse("select sum(v) from (select avg(f) as v from cpu last 2d group by host)")
+	assert.NoError(t, err)
+	assertTimeRange(q.(*stmt.Query).SubQuery, 2*commontimeutil.OneDay, 0)
+
+	_, err = Parse("select f from cpu where time > now()-1h last 30m")
+	assert.ErrorIs(t, err, errRelativeTimeRangeConflict)
+	_, err = Parse("select f from cpu where time<=now() since 1h ago")
+	assert.ErrorIs(t, err, errRelativeTimeRangeConflict)
+	for _, sql := range []string{
+		"select f from cpu since 1h ago until 2h ago",
+		"select f from cpu since 2h",
+		"select f from cpu since 2h ago last 1h",
+		"select f from cpu until 2h ago until 1h ago",
+		"show databases last 1h",
+	} {
+		_, err = Parse(sql)
+		assert.Error(t, err, sql)
+	}
+}
+
 func TestInterval(t *testing.T) {
 	sql := "select f from cpu where region='sh'"
 	q, err := Parse(sql)
@@ -391,21 +640,29 @@ func TestInterval(t *testing.T) {
 	query = q.(*stmt.Query)
 	assert.NoError(t, err)
 	assert.Equal(t, timeutil.Interval(commontimeutil.OneDay), query.Interval)
+	assert.Nil(t, query.Calendar)
 	sql = "select f from cpu group by time(1w)"
 	q, _ = Parse(sql)
 	query = q.(*stmt.Query)
 	assert.NoError(t, err)
 	assert.Equal(t, timeutil.Interval(commontimeutil.OneWeek), query.Interval)
+	assert.Equal(t, &timeutil.CalendarInterval{Unit: timeutil.CalendarWeek, Count: 1}, query.Calendar)
 	sql = "select f from cpu group by time(1M)"
 	q, _ = Parse(sql)
 	query = q.(*stmt.Query)
 	assert.NoError(t, err)
 	assert.Equal(t, timeutil.Interval(commontimeutil.OneMonth), query.Interval)
+	assert.Equal(t, &timeutil.CalendarInterval{Unit: timeutil.CalendarMonth, Count: 1}, query.Calendar)
+	sql = "select f from cpu group by time(3M)"
+	q, _ = Parse(sql)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &timeutil.CalendarInterval{Unit: timeutil.CalendarMonth, Count: 3}, query.Calendar)
 	sql = "select f from cpu group by time(1y)"
 	q, _ = Parse(sql)
 	query = q.(*stmt.Query)
 	assert.NoError(t, err)
 	assert.Equal(t, timeutil.Interval(commontimeutil.OneYear), query.Interval)
+	assert.Equal(t, &timeutil.CalendarInterval{Unit: timeutil.CalendarYear, Count: 1}, query.Calendar)
 	assert.False(t, query.AutoGroupByTime)
 
 	sql = "select f from cpu group by time()"
@@ -427,6 +684,50 @@ func TestGroupBy(t *testing.T) {
 	assert.Equal(t, 2, len(query.GroupBy))
 	assert.Equal(t, "host", query.GroupBy[0])
 	assert.Equal(t, "/data", query.GroupBy[1])
+	assert.False(t, query.GroupByAll)
+
+	sql = "select f from disk group by *"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.True(t, query.GroupByAll)
+	assert.Empty(t, query.GroupBy)
+	sql = "select f from disk group by host,*,time(1m)"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.True(t, query.GroupByAll)
+	assert.Equal(t, []string{"host"}, query.GroupBy)
+}
+
+func TestFill(t *testing.T) {
+	q, err := Parse("select f from disk group by host")
+	assert.NoError(t, err)
+	assert.Nil(t, q.(*stmt.Query).Fill)
+
+	// lowercase 'null' is the json literal token of grammar, use uppercase keyword
+	q, err = Parse("select sum(f) from disk group by host fill(NULL)")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.Fill{Type: stmt.FillNull}, q.(*stmt.Query).Fill)
+
+	q, err = Parse("select sum(f) from disk group by host,time(1m) FILL(previous)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, &stmt.Fill{Type: stmt.FillPrevious}, query.Fill)
+	assert.Equal(t, []string{"host"}, query.GroupBy)
+
+	q, err = Parse("select sum(f) from disk group by host fill(10)")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.Fill{Type: stmt.FillValue, Value: 10}, q.(*stmt.Query).Fill)
+
+	q, err = Parse("select sum(f) from disk group by host fill(1.5) limit 10")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.Fill{Type: stmt.FillValue, Value: 1.5}, query.Fill)
+	assert.Equal(t, 10, query.Limit)
+
+	_, err = Parse("select sum(f) from disk group by host fill(abc)")
+	assert.Error(t, err)
 }
 
 func TestEmptyCondition(t *testing.T) {
@@ -489,6 +790,12 @@ func TestRegexExpr(t *testing.T) {
 	query = q.(*stmt.Query)
 	notExpr := query.Condition.(*stmt.NotExpr)
 	assert.Equal(t, stmt.NotExpr{Expr: &stmt.RegexExpr{Key: "ip", Regexp: "/1.1.*.1/"}}, *notExpr)
+
+	// invalid regex rejected at parse time
+	_, err := Parse("select f from cpu where ip=~'1.1.[' and host='a'")
+	assert.ErrorContains(t, err, "invalid regex pattern '1.1.['")
+	_, err = Parse("show tag values from cpu with key=ip where ip!~'('")
+	assert.ErrorContains(t, err, "invalid regex pattern '('")
 }
 
 func TestInExpr(t *testing.T) {
@@ -512,6 +819,58 @@ func TestInExpr(t *testing.T) {
 	assert.Equal(t, stmt.NotExpr{Expr: &stmt.InExpr{Key: "ip", Values: []string{"1.1.1.1", "2.2.2.2"}}}, *notExpr)
 }
 
+func TestTagFilterBinary_Precedence(t *testing.T) {
+	hostA := &stmt.EqualsExpr{Key: "host", Value: "a"}
+	regionEU := &stmt.EqualsExpr{Key: "region", Value: "eu"}
+	zoneX := &stmt.EqualsExpr{Key: "zone", Value: "x"}
+	cases := []struct {
+		sql  string
+		expr stmt.Expr
+	}{
+		{
+			sql: "select f from cpu where host='a' or region='eu' and zone='x'",
+			expr: &stmt.BinaryExpr{
+				Left:     hostA,
+				Operator: stmt.OR,
+				Right:    &stmt.BinaryExpr{Left: regionEU, Operator: stmt.AND, Right: zoneX},
+			},
+		},
+		{
+			sql: "select f from cpu where (host='a' or region='eu') and zone='x'",
+			expr: &stmt.BinaryExpr{
+				Left:     &stmt.ParenExpr{Expr: &stmt.BinaryExpr{Left: hostA, Operator: stmt.OR, Right: regionEU}},
+				Operator: stmt.AND,
+				Right:    zoneX,
+			},
+		},
+		{
+			sql: "select f from cpu where host='a' and region='eu' or zone='x'",
+			expr: &stmt.BinaryExpr{
+				Left:     &stmt.BinaryExpr{Left: hostA, Operator: stmt.AND, Right: regionEU},
+				Operator: stmt.OR,
+				Right:    zoneX,
+			},
+		},
+		{
+			sql: "select f from cpu where host='a' or region='eu' and zone='x' and host='a'",
+			expr: &stmt.BinaryExpr{
+				Left:     hostA,
+				Operator: stmt.OR,
+				Right: &stmt.BinaryExpr{
+					Left:     &stmt.BinaryExpr{Left: regionEU, Operator: stmt.AND, Right: zoneX},
+					Operator: stmt.AND,
+					Right:    hostA,
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		q, err := Parse(c.sql)
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.expr, q.(*stmt.Query).Condition, c.sql)
+	}
+}
+
 func TestTagFilterBinary(t *testing.T) {
 	sql := "select f from cpu where ip in ('1.1.1.1','2.2.2.2') and path='/data'"
 	q, _ := Parse(sql)
@@ -659,6 +1018,25 @@ func TestOrderBy(t *testing.T) {
 				&stmt.OrderByExpr{Expr: &stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "ff"}}}, Desc: true},
 			},
 		},
+		{
+			name: "order by three keys with mixed directions",
+			sql:  "select a,b,c from cpu group by host order by sum(a) desc, max(b) asc, c desc",
+			rs: []stmt.Expr{
+				&stmt.OrderByExpr{Expr: &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "a"}}}, Desc: true},
+				&stmt.OrderByExpr{Expr: &stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "b"}}}},
+				&stmt.OrderByExpr{Expr: &stmt.FieldExpr{Name: "c"}, Desc: true},
+			},
+		},
+		{
+			name:    "second order by key not in select list",
+			sql:     "select a,b from cpu group by host order by sum(a) desc, max(c)",
+			wantErr: true,
+		},
+		{
+			name:    "second order by function not support",
+			sql:     "select a,b from cpu group by host order by sum(a) desc, rate(b)",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range cases {
@@ -674,3 +1052,44 @@ func TestOrderBy(t *testing.T) {
 		})
 	}
 }
+
+func TestQuotedIdentifiers(t *testing.T) {
+	cases := []struct {
+		name string
+		sql  string
+	}{
+		{
+			name: "double-quoted identifiers",
+			sql:  `SELECT "f.1" FROM "my.metric" WHERE "tag key"='v' group by "tag key"`,
+		},
+		{
+			name: "back-quoted identifiers",
+			sql:  "SELECT `f.1` FROM `my.metric` WHERE `tag key`='v' group by `tag key`",
+		},
+		{
+			name: "single-quoted identifiers",
+			sql:  `SELECT 'f.1' FROM 'my.metric' WHERE 'tag key'='v' group by 'tag key'`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.sql)
+			assert.NoError(t, err)
+			query := q.(*stmt.Query)
+			assert.Equal(t, "my.metric", query.MetricName)
+			assert.Equal(t, []stmt.Expr{&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "f.1"}}}, query.SelectItems)
+			assert.Equal(t, &stmt.EqualsExpr{Key: "tag key", Value: "v"}, query.Condition)
+			assert.Equal(t, []string{"tag key"}, query.GroupBy)
+		})
+	}
+
+	q, err := Parse(`select sum("f.1") as "total count" from http.requests.total where host="a""b"`)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, "http.requests.total", query.MetricName)
+	assert.Equal(t, []stmt.Expr{&stmt.SelectItem{
+		Expr:  &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f.1"}}},
+		Alias: "total count",
+	}}, query.SelectItems)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: `a"b`}, query.Condition)
+}
@@ -0,0 +1,96 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"strings"
+)
+
+// quotedIdentStatements represents the statements which identifiers can be double-quoted.
+var quotedIdentStatements = []string{"select", "explain", "show"}
+
+// quoteIdentifiers rewrites the double-quoted identifiers(e.g. "http.requests.total") to back-quoted,
+// because double-quoted text is lexed as json string by grammar, which cannot be used as identifier.
+// Doubled double quote("") in double-quoted identifier represents a literal double quote,
+// statements with json(create database etc.) are returned as is.
+func quoteIdentifiers(sql string) string {
+	if !isQuotedIdentStatement(sql) || !strings.Contains(sql, `"`) {
+		return sql
+	}
+	var b strings.Builder
+	b.Grow(len(sql))
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			b.WriteByte(c)
+		case c == '\'' || c == '`':
+			quote = c
+			b.WriteByte(c)
+		case c == '"':
+			ident, end, ok := readDoubleQuoted(sql, i)
+			if !ok || strings.Contains(ident, "`") {
+				// cannot rewrite, let grammar report the syntax error
+				b.WriteString(sql[i:])
+				return b.String()
+			}
+			b.WriteByte('`')
+			b.WriteString(ident)
+			b.WriteByte('`')
+			i = end
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// readDoubleQuoted reads the double-quoted identifier starts with pos,
+// returns the unescaped identifier and the position of close quote.
+func readDoubleQuoted(sql string, pos int) (ident string, end int, ok bool) {
+	var b strings.Builder
+	for i := pos + 1; i < len(sql); i++ {
+		if sql[i] != '"' {
+			b.WriteByte(sql[i])
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == '"' {
+			// escaped double quote
+			b.WriteByte('"')
+			i++
+			continue
+		}
+		return b.String(), i, true
+	}
+	return "", 0, false
+}
+
+// isQuotedIdentStatement checks if the statement supports double-quoted identifiers.
+func isQuotedIdentStatement(sql string) bool {
+	sql = strings.TrimLeft(sql, " \t\r\n(")
+	for _, keyword := range quotedIdentStatements {
+		if isKeywordAt(sql, 0, keyword) {
+			return true
+		}
+	}
+	return false
+}
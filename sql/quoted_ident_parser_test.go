@@ -0,0 +1,46 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteIdentifiers(t *testing.T) {
+	cases := []struct {
+		sql    string
+		result string
+	}{
+		{sql: "select f from cpu", result: "select f from cpu"},
+		{sql: `select "f.1" from "my.metric"`, result: "select `f.1` from `my.metric`"},
+		{sql: ` (SELECT "f" from cpu)`, result: " (SELECT `f` from cpu)"},
+		{sql: `explain select "f" from cpu`, result: "explain select `f` from cpu"},
+		{sql: `show fields from "my.metric"`, result: "show fields from `my.metric`"},
+		{sql: `select f from cpu where host="a""b"`, result: "select f from cpu where host=`a\"b`"},
+		{sql: `select f from cpu where host='a"b' and "tag key"='v'`, result: "select f from cpu where host='a\"b' and `tag key`='v'"},
+		{sql: "select f from cpu where `a\"b`=\"v\"", result: "select f from cpu where `a\"b`=`v`"},
+		{sql: `select f from "cpu`, result: `select f from "cpu`},
+		{sql: "select f from \"c`pu\"", result: "select f from \"c`pu\""},
+		{sql: `create database {"name":"db"}`, result: `create database {"name":"db"}`},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.result, quoteIdentifiers(c.sql), c.sql)
+	}
+}
@@ -0,0 +1,178 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var errRateFuncNotQuery = errors.New("rate/irate/absent only supports select statement")
+
+// rateFunc represents the rate function of select list, like rate(f, 5m)/irate(f)/absent(f, 5m),
+// grammar not supports them.
+type rateFunc struct {
+	funcType function.FuncType
+	window   int64
+}
+
+// splitRateFunc rewrites the rate functions with window(like rate(f, 5m)), irate functions(like irate(f))
+// and absent functions(like absent(f, 5m)) of select list to rate function without window(like rate(f)),
+// because grammar not supports them,
+// returns all rate functions of select list in order of appearance.
+func splitRateFunc(sql string) (rewrittenSQL string, funcs []*rateFunc, err error) {
+	selectPos := findTopLevelKeyword(sql, 0, "select")
+	if selectPos < 0 {
+		return sql, nil, nil
+	}
+	listStart := selectPos + len("select")
+	listEnd := findTopLevelKeyword(sql, listStart, "from")
+	if listEnd < 0 {
+		return sql, nil, nil
+	}
+	list, funcs, err := rewriteRateFunc(sql[listStart:listEnd])
+	if err != nil {
+		return "", nil, err
+	}
+	for _, fn := range funcs {
+		if fn.funcType != function.Rate || fn.window > 0 {
+			return sql[:listStart] + list + sql[listEnd:], funcs, nil
+		}
+	}
+	// no rate function need to rewrite
+	return sql, nil, nil
+}
+
+// rewriteRateFunc rewrites the rate/irate/absent functions of text recursively.
+func rewriteRateFunc(text string) (rewritten string, funcs []*rateFunc, err error) {
+	var (
+		sb    strings.Builder
+		quote byte
+		start int
+	)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		if c == '\'' || c == '"' || c == '`' {
+			quote = c
+			continue
+		}
+		funcType := function.Unknown
+		name := ""
+		switch {
+		case isKeywordAt(text, i, function.IRate.String()):
+			funcType, name = function.IRate, function.IRate.String()
+		case isKeywordAt(text, i, function.Rate.String()):
+			funcType, name = function.Rate, function.Rate.String()
+		case isKeywordAt(text, i, function.Absent.String()):
+			funcType, name = function.Absent, function.Absent.String()
+		default:
+			continue
+		}
+		open := i + len(name)
+		for open < len(text) && isBlank(text[open]) {
+			open++
+		}
+		if open >= len(text) || text[open] != '(' {
+			// maybe field named rate
+			continue
+		}
+		closeParen := findCloseParen(text, open)
+		if closeParen < 0 {
+			return "", nil, fmt.Errorf("%s function params invalid", name)
+		}
+		fn := &rateFunc{funcType: funcType}
+		funcs = append(funcs, fn)
+		params := splitTopLevelComma(text[open+1 : closeParen])
+		switch {
+		case len(params) == 2 && (funcType == function.Rate || funcType == function.Absent):
+			window, ok := parseDurationLit(strings.TrimSpace(params[1]))
+			if !ok {
+				return "", nil, fmt.Errorf("%s param: %s is not duration", name, strings.TrimSpace(params[1]))
+			}
+			fn.window = window
+		case len(params) != 1:
+			return "", nil, fmt.Errorf("%s function params length invalid", name)
+		}
+		param, innerFuncs, err := rewriteRateFunc(params[0])
+		if err != nil {
+			return "", nil, err
+		}
+		funcs = append(funcs, innerFuncs...)
+		sb.WriteString(text[start:i])
+		sb.WriteString(function.Rate.String())
+		sb.WriteString("(")
+		sb.WriteString(param)
+		sb.WriteString(")")
+		start = closeParen + 1
+		i = closeParen
+	}
+	sb.WriteString(text[start:])
+	return sb.String(), funcs, nil
+}
+
+// applyRateFunc sets the function type and window of rate functions(in order of appearance) of select items.
+func applyRateFunc(stmt stmtpkg.Statement, funcs []*rateFunc) error {
+	query, ok := stmt.(*stmtpkg.Query)
+	if !ok {
+		return errRateFuncNotQuery
+	}
+	var calls []*stmtpkg.CallExpr
+	for _, item := range query.SelectItems {
+		calls = collectRateCalls(item, calls)
+	}
+	if len(calls) != len(funcs) {
+		return errors.New("rate/irate/absent function invalid")
+	}
+	for idx, call := range calls {
+		call.FuncType = funcs[idx].funcType
+		call.Window = timeutil.Interval(funcs[idx].window)
+	}
+	return nil
+}
+
+// collectRateCalls collects the rate function calls of expr in order of appearance.
+func collectRateCalls(expr stmtpkg.Expr, calls []*stmtpkg.CallExpr) []*stmtpkg.CallExpr {
+	switch e := expr.(type) {
+	case *stmtpkg.SelectItem:
+		return collectRateCalls(e.Expr, calls)
+	case *stmtpkg.ParenExpr:
+		return collectRateCalls(e.Expr, calls)
+	case *stmtpkg.BinaryExpr:
+		calls = collectRateCalls(e.Left, calls)
+		return collectRateCalls(e.Right, calls)
+	case *stmtpkg.CallExpr:
+		if e.FuncType == function.Rate {
+			calls = append(calls, e)
+		}
+		for _, param := range e.Params {
+			calls = collectRateCalls(param, calls)
+		}
+	}
+	return calls
+}
@@ -0,0 +1,134 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commontimeutil "github.com/lindb/common/pkg/timeutil"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitRateFunc(t *testing.T) {
+	cases := []struct {
+		sql     string
+		result  string
+		funcs   []rateFunc
+		wantErr bool
+	}{
+		{sql: "show databases", result: "show databases"},
+		{sql: "select rate(f) from cpu", result: "select rate(f) from cpu"},
+		{sql: "select rate, irate from cpu", result: "select rate, irate from cpu"},
+		{
+			sql:    "select rate(f), irate(f) from cpu where host='rate(f, 5m)'",
+			result: "select rate(f), rate(f) from cpu where host='rate(f, 5m)'",
+			funcs:  []rateFunc{{funcType: function.Rate}, {funcType: function.IRate}},
+		},
+		{
+			sql:    "select RATE ( f , 5m )*100 as r, sum(g) from cpu",
+			result: "select rate( f )*100 as r, sum(g) from cpu",
+			funcs:  []rateFunc{{funcType: function.Rate, window: 5 * commontimeutil.OneMinute}},
+		},
+		{
+			sql:    "select irate(rate(f, 1h)) from cpu",
+			result: "select rate(rate(f)) from cpu",
+			funcs:  []rateFunc{{funcType: function.IRate}, {funcType: function.Rate, window: commontimeutil.OneHour}},
+		},
+		{
+			sql:    "select absent(f), absent(f, 10m) from cpu group by host",
+			result: "select rate(f), rate(f) from cpu group by host",
+			funcs:  []rateFunc{{funcType: function.Absent}, {funcType: function.Absent, window: 10 * commontimeutil.OneMinute}},
+		},
+		{sql: "select rate(f, 5) from cpu", wantErr: true},
+		{sql: "select absent(f, x) from cpu", wantErr: true},
+		{sql: "select rate(f, 5m, 1) from cpu", wantErr: true},
+		{sql: "select irate(f, 5m) from cpu", wantErr: true},
+		{sql: "select irate(rate(f, x)) from cpu", wantErr: true},
+		{sql: "select irate(f from cpu", result: "select irate(f from cpu"},
+	}
+	for _, c := range cases {
+		result, funcs, err := splitRateFunc(c.sql)
+		if c.wantErr {
+			assert.Error(t, err, c.sql)
+			continue
+		}
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.result, result, c.sql)
+		assert.Len(t, funcs, len(c.funcs), c.sql)
+		for idx, fn := range funcs {
+			assert.Equal(t, c.funcs[idx], *fn, c.sql)
+		}
+	}
+}
+
+func TestQuery_RateFunc(t *testing.T) {
+	q, err := Parse("select rate(f), rate(f, 5m) as r, irate(f)/(rate(g, 1h)) from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []stmt.Expr{
+		&stmt.SelectItem{Expr: &stmt.CallExpr{
+			FuncType: function.Rate,
+			Params:   []stmt.Expr{&stmt.FieldExpr{Name: "f"}},
+		}},
+		&stmt.SelectItem{Expr: &stmt.CallExpr{
+			FuncType: function.Rate,
+			Params:   []stmt.Expr{&stmt.FieldExpr{Name: "f"}},
+			Window:   timeutil.Interval(5 * commontimeutil.OneMinute),
+		}, Alias: "r"},
+		&stmt.SelectItem{Expr: &stmt.BinaryExpr{
+			Left: &stmt.CallExpr{
+				FuncType: function.IRate,
+				Params:   []stmt.Expr{&stmt.FieldExpr{Name: "f"}},
+			},
+			Operator: stmt.DIV,
+			Right: &stmt.ParenExpr{Expr: &stmt.CallExpr{
+				FuncType: function.Rate,
+				Params:   []stmt.Expr{&stmt.FieldExpr{Name: "g"}},
+				Window:   timeutil.Interval(commontimeutil.OneHour),
+			}},
+		}},
+	}, query.SelectItems)
+	assert.Equal(t, "rate(f,5m)", query.SelectItems[1].(*stmt.SelectItem).Expr.Rewrite())
+
+	_, err = Parse("select irate(f, 5m) from cpu")
+	assert.Error(t, err)
+}
+
+func TestQuery_AbsentFunc(t *testing.T) {
+	q, err := Parse("select absent(f, 10m) as silent from cpu group by host")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []stmt.Expr{
+		&stmt.SelectItem{Expr: &stmt.CallExpr{
+			FuncType: function.Absent,
+			Params:   []stmt.Expr{&stmt.FieldExpr{Name: "f"}},
+			Window:   timeutil.Interval(10 * commontimeutil.OneMinute),
+		}, Alias: "silent"},
+	}, query.SelectItems)
+	assert.Equal(t, "absent(f,10m)", query.SelectItems[0].(*stmt.SelectItem).Expr.Rewrite())
+}
+
+func TestApplyRateFunc(t *testing.T) {
+	assert.Equal(t, errRateFuncNotQuery, applyRateFunc(&stmt.MetricMetadata{}, []*rateFunc{{funcType: function.IRate}}))
+	assert.Error(t, applyRateFunc(&stmt.Query{}, []*rateFunc{{funcType: function.IRate}}))
+}
@@ -0,0 +1,97 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var errSampleByNotQuery = errors.New("sample by only supports select statement")
+
+// splitSampleBy removes the sample by clause(SAMPLE BY 1h) from sql,
+// returns 0 interval if sql without sample by clause.
+func splitSampleBy(sql string) (sqlWithoutSampleBy string, interval int64, err error) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && isKeywordAt(sql, i, "sample"):
+			pos := i + len("sample")
+			for pos < len(sql) && isBlank(sql[pos]) {
+				pos++
+			}
+			if !isKeywordAt(sql, pos, "by") {
+				// not sample by clause, maybe tag key named sample
+				continue
+			}
+			start := pos + len("by")
+			for start < len(sql) && isBlank(sql[start]) {
+				start++
+			}
+			end := start
+			for end < len(sql) && isIdentChar(sql[end]) {
+				end++
+			}
+			interval, err = parseSampleInterval(sql[start:end])
+			if err != nil {
+				return "", 0, err
+			}
+			return sql[:i] + sql[end:], interval, nil
+		}
+	}
+	return sql, 0, nil
+}
+
+// parseSampleInterval parses the interval of sample by clause using duration grammar, e.g. 1h.
+func parseSampleInterval(duration string) (int64, error) {
+	interval, ok := parseDurationLit(duration)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample by interval: '%s'", duration)
+	}
+	return interval, nil
+}
+
+// applySampleBy sets the sample by interval of query, which overrides group by time interval,
+// also applies to sub query because outer query aggregates the points of sub query by timestamp.
+func applySampleBy(stmt stmtpkg.Statement, interval int64) (stmtpkg.Statement, error) {
+	query, ok := stmt.(*stmtpkg.Query)
+	if !ok {
+		return nil, errSampleByNotQuery
+	}
+	for q := query; q != nil; q = q.SubQuery {
+		q.SampleInterval = timeutil.Interval(interval)
+		q.Interval = timeutil.Interval(interval)
+		q.AutoGroupByTime = false
+	}
+	return query, nil
+}
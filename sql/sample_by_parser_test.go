@@ -0,0 +1,95 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commontimeutil "github.com/lindb/common/pkg/timeutil"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitSampleBy(t *testing.T) {
+	cases := []struct {
+		sql      string
+		result   string
+		interval int64
+		wantErr  bool
+	}{
+		{sql: "select f from cpu", result: "select f from cpu"},
+		{sql: "select f from cpu where sample='a' group by sample", result: "select f from cpu where sample='a' group by sample"},
+		{sql: "select f from cpu where host='sample by 1h'", result: "select f from cpu where host='sample by 1h'"},
+		{
+			sql:      "select avg(f) from cpu where time > now()-7d SAMPLE BY 1h",
+			result:   "select avg(f) from cpu where time > now()-7d ",
+			interval: commontimeutil.OneHour,
+		},
+		{
+			sql:      "select f from cpu sample  by 5m group by host",
+			result:   "select f from cpu  group by host",
+			interval: 5 * commontimeutil.OneMinute,
+		},
+		{sql: "select f from cpu sample by", wantErr: true},
+		{sql: "select f from cpu sample by abc", wantErr: true},
+		{sql: "select f from cpu sample by 1x", wantErr: true},
+		{sql: "select f from cpu sample by 0s", wantErr: true},
+	}
+	for _, c := range cases {
+		result, interval, err := splitSampleBy(c.sql)
+		if c.wantErr {
+			assert.Error(t, err, c.sql)
+			continue
+		}
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.result, result, c.sql)
+		assert.Equal(t, c.interval, interval, c.sql)
+	}
+}
+
+func TestQuery_SampleBy(t *testing.T) {
+	q, err := Parse("select avg(f) from cpu where time > now()-7d group by time(1m) SAMPLE BY 1h")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneHour), query.SampleInterval)
+	// overrides group by time interval
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneHour), query.Interval)
+
+	q, err = Parse("select avg(f) from cpu group by time() sample by 1d")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.False(t, query.AutoGroupByTime)
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneDay), query.Interval)
+
+	// applies to sub query
+	q, err = Parse("select sum(v) from (select avg(f) as v from cpu group by host) sample by 1h")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneHour), query.SampleInterval)
+	assert.Equal(t, timeutil.Interval(commontimeutil.OneHour), query.SubQuery.SampleInterval)
+
+	_, err = Parse("show databases sample by 1h")
+	assert.ErrorIs(t, err, errSampleByNotQuery)
+	_, err = Parse("select f from cpu sample by 1x")
+	assert.Error(t, err)
+	_, err = Parse("select f from sample by 1h")
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+	"strings"
+
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var (
+	errShowMetricsNotMetadata    = errors.New("show metrics only supports metric metadata statement")
+	errShowMetricsMultiplePrefix = errors.New("show metrics only supports one metric prefix condition")
+)
+
+// showMetricsKeywords represents the keywords(metrics or measurements) after show.
+var showMetricsKeywords = []string{"metrics", "measurements"}
+
+// showMetricsTagFilterTemplate represents the show tag values statement template used to parse tag filter,
+// because grammar only supports where clause in show tag values statement.
+const showMetricsTagFilterTemplate = "show tag values from 'metric' with key='key' where "
+
+// splitShowMetrics splits show metrics/measurements statement with tag filter into show metrics statement
+// which grammar supports and tag filter condition, like show metrics where host='a' and metric='cpu' limit 10.
+func splitShowMetrics(sql string) (showSQL, condition string, ok bool, err error) {
+	pos := 0
+	for pos < len(sql) && isBlank(sql[pos]) {
+		pos++
+	}
+	if !isKeywordAt(sql, pos, "show") {
+		return "", "", false, nil
+	}
+	pos += len("show")
+	for pos < len(sql) && isBlank(sql[pos]) {
+		pos++
+	}
+	keyword := ""
+	for _, k := range showMetricsKeywords {
+		if isKeywordAt(sql, pos, k) {
+			keyword = k
+			break
+		}
+	}
+	if keyword == "" {
+		return "", "", false, nil
+	}
+	end := pos + len(keyword)
+	wherePos := findTopLevelKeyword(sql, end, "where")
+	if wherePos < 0 {
+		if keyword == "metrics" {
+			// grammar supports show metrics without where clause
+			return "", "", false, nil
+		}
+		return sql[:pos] + "metrics" + sql[end:], "", true, nil
+	}
+	limitPos := findTopLevelKeyword(sql, wherePos, "limit")
+	limitClause := ""
+	conditionEnd := len(sql)
+	if limitPos > 0 {
+		limitClause = " " + sql[limitPos:]
+		conditionEnd = limitPos
+	}
+	var (
+		prefixCondition string
+		tagConditions   []string
+	)
+	for _, item := range splitTopLevelAnd(sql[wherePos+len("where") : conditionEnd]) {
+		if isMetricPrefixCondition(item) {
+			if prefixCondition != "" {
+				return "", "", false, errShowMetricsMultiplePrefix
+			}
+			prefixCondition = item
+			continue
+		}
+		tagConditions = append(tagConditions, strings.TrimSpace(item))
+	}
+	showSQL = sql[:pos] + "metrics" + strings.TrimRightFunc(sql[end:wherePos], isBlankRune)
+	if prefixCondition != "" {
+		showSQL += " where " + strings.TrimSpace(prefixCondition)
+	}
+	return showSQL + limitClause, strings.Join(tagConditions, " and "), true, nil
+}
+
+// isMetricPrefixCondition checks if the condition is metric prefix condition, like metric='cpu'.
+func isMetricPrefixCondition(condition string) bool {
+	condition = strings.TrimSpace(condition)
+	if !isKeywordAt(condition, 0, "metric") || findTopLevelKeyword(condition, 0, "or") >= 0 {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSpace(condition[len("metric"):]), "=")
+}
+
+func isBlankRune(c rune) bool {
+	return c < 128 && isBlank(byte(c))
+}
+
+// parseShowMetrics parses the show metrics sql, then sets the tag filter condition of metric metadata statement.
+func parseShowMetrics(showSQL, condition string) (stmtpkg.Statement, error) {
+	stmt, err := parse(showSQL, nil)
+	if err != nil {
+		return nil, err
+	}
+	metadata, ok := stmt.(*stmtpkg.MetricMetadata)
+	if !ok || metadata.Type != stmtpkg.Metric {
+		return nil, errShowMetricsNotMetadata
+	}
+	if condition == "" {
+		return metadata, nil
+	}
+	tagValuesStmt, err := parse(showMetricsTagFilterTemplate+condition, nil)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Condition = tagValuesStmt.(*stmtpkg.MetricMetadata).Condition
+	return metadata, nil
+}
@@ -0,0 +1,111 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitShowMetrics(t *testing.T) {
+	cases := []struct {
+		sql       string
+		showSQL   string
+		condition string
+		ok        bool
+	}{
+		{sql: "show metrics"},
+		{sql: "show metrics on 'ns' limit 10"},
+		{sql: "show fields from cpu"},
+		{sql: "select metrics from cpu"},
+		{
+			sql:     "show measurements on 'ns' limit 10",
+			showSQL: "show metrics on 'ns' limit 10",
+			ok:      true,
+		},
+		{
+			sql:     "show metrics where metric='cpu'",
+			showSQL: "show metrics where metric='cpu'",
+			ok:      true,
+		},
+		{
+			sql:       "show metrics on 'ns' where host='a' and metric = 'cpu' limit 10",
+			showSQL:   "show metrics on 'ns' where metric = 'cpu' limit 10",
+			condition: "host='a'",
+			ok:        true,
+		},
+		{
+			sql:       "SHOW MEASUREMENTS where (host='a' or host='b') and region='sh'",
+			showSQL:   "SHOW metrics",
+			condition: "(host='a' or host='b') and region='sh'",
+			ok:        true,
+		},
+		{
+			sql:       "show metrics where metric='a' or host='b'",
+			showSQL:   "show metrics",
+			condition: "metric='a' or host='b'",
+			ok:        true,
+		},
+	}
+	for _, c := range cases {
+		showSQL, condition, ok, err := splitShowMetrics(c.sql)
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.ok, ok, c.sql)
+		assert.Equal(t, c.showSQL, showSQL, c.sql)
+		assert.Equal(t, c.condition, condition, c.sql)
+	}
+}
+
+func TestMetaStmt_ShowMetrics_TagFilter(t *testing.T) {
+	q, err := Parse("show metrics on 'ns' where host='a' and metric='cpu' limit 10")
+	assert.NoError(t, err)
+	query := q.(*stmt.MetricMetadata)
+	assert.Equal(t, stmt.Metric, query.Type)
+	assert.Equal(t, "ns", query.Namespace)
+	assert.Equal(t, "cpu", query.Prefix)
+	assert.Equal(t, 10, query.Limit)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "a"}, query.Condition)
+
+	q, err = Parse("show measurements where host in ('a','b')")
+	assert.NoError(t, err)
+	query = q.(*stmt.MetricMetadata)
+	assert.Equal(t, stmt.Metric, query.Type)
+	assert.Empty(t, query.Prefix)
+	assert.Equal(t, &stmt.InExpr{Key: "host", Values: []string{"a", "b"}}, query.Condition)
+
+	q, err = Parse("show measurements where metric='cpu'")
+	assert.NoError(t, err)
+	query = q.(*stmt.MetricMetadata)
+	assert.Equal(t, "cpu", query.Prefix)
+	assert.Nil(t, query.Condition)
+
+	_, err = Parse("show metrics where metric='a' and metric='b'")
+	assert.Equal(t, errShowMetricsMultiplePrefix, err)
+	_, err = Parse("show metrics where host=")
+	assert.Error(t, err)
+}
+
+func TestParseShowMetrics(t *testing.T) {
+	_, err := parseShowMetrics("show tag values from cpu with key=host", "")
+	assert.Equal(t, errShowMetricsNotMetadata, err)
+	_, err = parseShowMetrics("show metrics", "host=")
+	assert.Error(t, err)
+}
@@ -31,3 +31,36 @@ func TestStateStmtParse_show_master(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, s, &stmt.State{Type: stmt.Master})
 }
+
+func TestStateStmtParse_show_replica_lag(t *testing.T) {
+	q, err := Parse("show replica lag where storage='s' and database='d'")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.State{Type: stmt.StorageReplicaLag, StorageName: "s", Database: "d"}, q)
+	q, err = Parse("show replica lag where database=d and storage=s")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.State{Type: stmt.StorageReplicaLag, StorageName: "s", Database: "d"}, q)
+	// replica/lag are non-reserved words
+	q, err = Parse("select lag from replica where replica='r'")
+	assert.NoError(t, err)
+	assert.Equal(t, "replica", q.(*stmt.Query).MetricName)
+
+	_, err = Parse("show replica lag")
+	assert.Error(t, err)
+	_, err = Parse("show replica lag where storage=s")
+	assert.Error(t, err)
+}
+
+func TestStateStmtParse_show_write_stats(t *testing.T) {
+	q, err := Parse("show write stats where database='d'")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.State{Type: stmt.StorageWriteStats, Database: "d"}, q)
+	// write is non-reserved word
+	q, err = Parse("select write from stats")
+	assert.NoError(t, err)
+	assert.Equal(t, "stats", q.(*stmt.Query).MetricName)
+
+	_, err = Parse("show write stats")
+	assert.Error(t, err)
+	_, err = Parse("show write stats where storage=s")
+	assert.Error(t, err)
+}
@@ -0,0 +1,33 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stmt
+
+// AlterDatabase represents alter database options statement,
+// e.g. alter database db set ttl='30d', replica=2.
+type AlterDatabase struct {
+	Name string
+	// TTL represents the new retention of the write interval, empty if not changed.
+	TTL string
+	// ReplicaFactor represents the new replica factor, 0 if not changed.
+	ReplicaFactor int
+}
+
+// StatementType returns alter database type.
+func (q *AlterDatabase) StatementType() StatementType {
+	return AlterDatabaseStatement
+}
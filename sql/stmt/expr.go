@@ -35,11 +35,13 @@ package stmt
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/lindb/common/pkg/encoding"
 
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source ./expr.go -destination=./expr_mock.go -package=stmt
@@ -110,6 +112,8 @@ type NumberLiteral struct {
 type CallExpr struct {
 	FuncType function.FuncType
 	Params   []Expr
+	// Window represents the window of rate function, like rate(f, 5m), 0 means group by interval.
+	Window timeutil.Interval
 }
 
 // innerCallExpr represents inner wrapper of call expr for json marshal
@@ -117,6 +121,7 @@ type innerCallExpr struct {
 	Type     string            `json:"type"`
 	FuncType function.FuncType `json:"funcType"`
 	Params   []json.RawMessage `json:"params"`
+	Window   int64             `json:"window,omitempty"`
 }
 
 // ParenExpr represents a parenthesized expression
@@ -150,16 +155,20 @@ type InExpr struct {
 	Values []string `json:"values"`
 }
 
-// LikeExpr represents a like expression
+// LikeExpr represents a like expression, '%'(or '*') matches any sequence of characters,
+// '_' matches a single character, using backslash to escape them, e.g. host like 'prod\_%'.
 type LikeExpr struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 }
 
-// RegexExpr represents a regular expression
+// RegexExpr represents a regular expression, the pattern is unanchored(matches any substring of tag value),
+// using ^ and $ to anchor it, e.g. host=~'^prod-[0-9]+$'.
 type RegexExpr struct {
 	Key    string `json:"key"`
 	Regexp string `json:"regexp"`
+
+	pattern *regexp.Regexp // precompiled pattern, reused by all shards of query
 }
 
 // NotExpr represents a not expression
@@ -186,6 +195,9 @@ func (e *CallExpr) Rewrite() string {
 	for _, param := range e.Params {
 		params = append(params, param.Rewrite())
 	}
+	if e.Window > 0 {
+		params = append(params, e.Window.String())
+	}
 	return fmt.Sprintf("%s(%s)", e.FuncType, strings.Join(params, ","))
 }
 
@@ -270,6 +282,7 @@ func Marshal(expr Expr) []byte {
 		inner := innerCallExpr{
 			Type:     "call",
 			FuncType: e.FuncType,
+			Window:   e.Window.Int64(),
 		}
 		for _, param := range e.Params {
 			inner.Params = append(inner.Params, Marshal(param))
@@ -297,7 +310,7 @@ func Unmarshal(value []byte) (Expr, error) {
 	}
 	switch expr.Type {
 	case "regex":
-		return unmarshal(&expr, &RegexExpr{})
+		return unmarshalRegex(&expr)
 	case "like":
 		return unmarshal(&expr, &LikeExpr{})
 	case "in":
@@ -340,7 +353,7 @@ func unmarshalCall(value []byte) (Expr, error) {
 	if err != nil {
 		return nil, err
 	}
-	expr := &CallExpr{FuncType: innerExpr.FuncType}
+	expr := &CallExpr{FuncType: innerExpr.FuncType, Window: timeutil.Interval(innerExpr.Window)}
 	for _, param := range innerExpr.Params {
 		e, err := Unmarshal(param)
 		if err != nil {
@@ -410,6 +423,18 @@ func unmarshal(exprData *exprData, expr Expr) (Expr, error) {
 	return expr, nil
 }
 
+// unmarshalRegex parses value to regex expr, and precompiles the pattern.
+func unmarshalRegex(exprData *exprData) (Expr, error) {
+	expr := &RegexExpr{}
+	if err := encoding.JSONUnmarshal(exprData.Expr, expr); err != nil {
+		return nil, err
+	}
+	if err := expr.Compile(); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
 // TagKey returns the equals filter's tag key
 func (e *EqualsExpr) TagKey() string { return e.Key }
 
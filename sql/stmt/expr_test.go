@@ -118,7 +118,14 @@ func TestRegexExpr_Marshal(t *testing.T) {
 	data := Marshal(expr)
 	exprData, _ := Unmarshal(data)
 	e := exprData.(*RegexExpr)
+	// pattern precompiled when unmarshal
+	assert.NoError(t, expr.Compile())
 	assert.Equal(t, *expr, *e)
+
+	_, err := Unmarshal(Marshal(&RegexExpr{Key: "tagKey", Regexp: "a["}))
+	assert.Error(t, err)
+	_, err = Unmarshal([]byte(`{"type":"regex","expr":"123"}`))
+	assert.Error(t, err)
 }
 
 func TestLikeExpr_Marshal(t *testing.T) {
@@ -205,6 +212,12 @@ func TestCallExpr_Marshal(t *testing.T) {
 	assert.NoError(t, err)
 	e := exprData.(*CallExpr)
 	assert.Equal(t, *expr, *e)
+
+	expr = &CallExpr{FuncType: function.Rate, Params: []Expr{&FieldExpr{Name: "f"}}, Window: 5 * 60 * 1000}
+	exprData, err = Unmarshal(Marshal(expr))
+	assert.NoError(t, err)
+	assert.Equal(t, expr, exprData)
+	assert.Equal(t, "rate(f,5m)", expr.Rewrite())
 }
 
 func TestParenExpr_Marshal(t *testing.T) {
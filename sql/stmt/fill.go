@@ -0,0 +1,36 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stmt
+
+// FillType represents the gap filling type of grouped query result.
+type FillType int
+
+const (
+	// FillNull keeps the gap as null, drops the point.
+	FillNull FillType = iota + 1
+	// FillPrevious carries forward the last value.
+	FillPrevious
+	// FillValue fills the gap with a constant value.
+	FillValue
+)
+
+// Fill represents fill(null|previous|<value>) option of group by clause.
+type Fill struct {
+	Type  FillType `json:"type"`
+	Value float64  `json:"value,omitempty"` // constant value for FillValue
+}
@@ -0,0 +1,133 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stmt
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LikePattern represents the compiled pattern of like expr, following SQL convention:
+// '%'(or '*') matches any sequence of characters, '_' matches a single character,
+// backslash escapes the literal '%', '_', '*' and '\'.
+type LikePattern struct {
+	prefix     string         // literal prefix before the first wildcard
+	exact      bool           // pattern has no wildcard, matches prefix only
+	prefixOnly bool           // pattern is 'prefix%', matches all values starting with prefix
+	matcher    *regexp.Regexp // anchored matcher, nil if exact or prefix only
+}
+
+// NewLikePattern compiles the pattern of like expr.
+func NewLikePattern(pattern string) *LikePattern {
+	var (
+		literal     strings.Builder
+		expr        strings.Builder
+		prefix      string
+		prefixDone  bool
+		wildcards   int
+		trailingAny bool
+	)
+	flushLiteral := func() {
+		if literal.Len() == 0 {
+			return
+		}
+		if !prefixDone {
+			prefix = literal.String()
+		}
+		expr.WriteString(regexp.QuoteMeta(literal.String()))
+		literal.Reset()
+	}
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\\' && i+1 < len(runes):
+			i++
+			literal.WriteRune(runes[i])
+			continue
+		case c == '%' || c == '*':
+			flushLiteral()
+			expr.WriteString(".*")
+			trailingAny = i == len(runes)-1
+		case c == '_':
+			flushLiteral()
+			expr.WriteString(".")
+			trailingAny = false
+		default:
+			literal.WriteRune(c)
+			continue
+		}
+		prefixDone = true
+		wildcards++
+	}
+	flushLiteral()
+	p := &LikePattern{prefix: prefix}
+	switch {
+	case wildcards == 0:
+		p.exact = true
+	case wildcards == 1 && trailingAny:
+		p.prefixOnly = true
+	default:
+		p.matcher = regexp.MustCompile("(?s)^" + expr.String() + "$")
+	}
+	return p
+}
+
+// Pattern returns the compiled pattern of like expr.
+func (e *LikeExpr) Pattern() *LikePattern {
+	return NewLikePattern(e.Value)
+}
+
+// Prefix returns the literal prefix which all matched values must start with.
+func (p *LikePattern) Prefix() string {
+	return p.prefix
+}
+
+// IsExact returns if pattern has no wildcard, the matched value equals prefix.
+func (p *LikePattern) IsExact() bool {
+	return p.exact
+}
+
+// IsPrefixOnly returns if pattern is 'prefix%', all values starting with prefix are matched.
+func (p *LikePattern) IsPrefixOnly() bool {
+	return p.prefixOnly
+}
+
+// Match returns if the value matches the pattern.
+func (p *LikePattern) Match(value string) bool {
+	switch {
+	case p.exact:
+		return value == p.prefix
+	case p.prefixOnly:
+		return strings.HasPrefix(value, p.prefix)
+	default:
+		return p.matcher.MatchString(value)
+	}
+}
+
+// MatchBytes returns if the value matches the pattern.
+func (p *LikePattern) MatchBytes(value []byte) bool {
+	switch {
+	case p.exact:
+		return string(value) == p.prefix
+	case p.prefixOnly:
+		return len(value) >= len(p.prefix) && string(value[:len(p.prefix)]) == p.prefix
+	default:
+		return p.matcher.Match(value)
+	}
+}
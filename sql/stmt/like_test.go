@@ -0,0 +1,147 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLikeExpr_Pattern(t *testing.T) {
+	cases := []struct {
+		name       string
+		like       string
+		prefix     string
+		exact      bool
+		prefixOnly bool
+		matched    []string
+		unmatched  []string
+	}{
+		{
+			name:      "empty pattern",
+			like:      "",
+			exact:     true,
+			matched:   []string{""},
+			unmatched: []string{"a"},
+		},
+		{
+			name:      "no wildcard",
+			like:      "prod.1",
+			prefix:    "prod.1",
+			exact:     true,
+			matched:   []string{"prod.1"},
+			unmatched: []string{"prod-1", "prod.10"},
+		},
+		{
+			name:       "prefix only",
+			like:       "prod%",
+			prefix:     "prod",
+			prefixOnly: true,
+			matched:    []string{"prod", "prod-1", "prod\n1"},
+			unmatched:  []string{"dev-prod"},
+		},
+		{
+			name:       "legacy prefix only",
+			like:       "prod*",
+			prefix:     "prod",
+			prefixOnly: true,
+			matched:    []string{"prod-1"},
+			unmatched:  []string{"dev-prod"},
+		},
+		{
+			name:       "match all",
+			like:       "%",
+			prefixOnly: true,
+			matched:    []string{"", "prod"},
+		},
+		{
+			name:      "suffix only",
+			like:      "%-1",
+			matched:   []string{"prod-1", "-1"},
+			unmatched: []string{"prod-10"},
+		},
+		{
+			name:      "middle wildcard",
+			like:      "prod%1",
+			prefix:    "prod",
+			matched:   []string{"prod-1", "prod1", "prod-a-1"},
+			unmatched: []string{"prod-10", "dev-1"},
+		},
+		{
+			name:      "contains",
+			like:      "*prod%",
+			matched:   []string{"prod", "dev-prod-1"},
+			unmatched: []string{"pro-d"},
+		},
+		{
+			name:      "single char",
+			like:      "prod_1",
+			prefix:    "prod",
+			matched:   []string{"prod-1", "prod_1", "prod世1"},
+			unmatched: []string{"prod1", "prod--1"},
+		},
+		{
+			name:      "escaped literal",
+			like:      `prod\_1\%`,
+			prefix:    "prod_1%",
+			exact:     true,
+			matched:   []string{"prod_1%"},
+			unmatched: []string{"prod-1%", "prod_1"},
+		},
+		{
+			name:      "escaped literal with wildcard",
+			like:      `100\%_%`,
+			prefix:    "100%",
+			matched:   []string{"100%-a", "100%a"},
+			unmatched: []string{"100%", "1000-a"},
+		},
+		{
+			name:      "escaped backslash and regexp meta chars",
+			like:      `a\\.(b)_`,
+			prefix:    `a\.(b)`,
+			matched:   []string{`a\.(b)c`},
+			unmatched: []string{`a\x(b)c`, `a.(b)c`},
+		},
+		{
+			name:      "trailing backslash",
+			like:      `a\`,
+			prefix:    `a\`,
+			exact:     true,
+			matched:   []string{`a\`},
+			unmatched: []string{"a"},
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pattern := (&LikeExpr{Key: "host", Value: tt.like}).Pattern()
+			assert.Equal(t, tt.prefix, pattern.Prefix())
+			assert.Equal(t, tt.exact, pattern.IsExact())
+			assert.Equal(t, tt.prefixOnly, pattern.IsPrefixOnly())
+			for _, value := range tt.matched {
+				assert.True(t, pattern.Match(value), value)
+				assert.True(t, pattern.MatchBytes([]byte(value)), value)
+			}
+			for _, value := range tt.unmatched {
+				assert.False(t, pattern.Match(value), value)
+				assert.False(t, pattern.MatchBytes([]byte(value)), value)
+			}
+		})
+	}
+}
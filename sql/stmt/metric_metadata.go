@@ -33,6 +33,7 @@ const (
 	TagKey
 	TagValue
 	Field
+	TagCardinality
 )
 
 // String returns string value of metadata type
@@ -48,6 +49,8 @@ func (m MetricMetadataType) String() string {
 		return "tagKey"
 	case TagValue:
 		return "tagValue"
+	case TagCardinality:
+		return "tagCardinality"
 	default:
 		return unknown
 	}
@@ -62,6 +65,9 @@ type MetricMetadata struct {
 	Prefix     string
 	Condition  Expr // tag filter condition expression
 	Limit      int  // result set limit
+
+	Distinct  bool  // count distinct values via mergeable sketch instead of returning values
+	Precision uint8 // sketch precision for distinct count, 0 means default precision
 }
 
 // StatementType returns metadata query type.
@@ -78,6 +84,8 @@ type innerMetadata struct {
 	Condition  json.RawMessage    `json:"condition,omitempty"`
 	Prefix     string             `json:"prefix,omitempty"`
 	Limit      int                `json:"limit,omitempty"`
+	Distinct   bool               `json:"distinct,omitempty"`
+	Precision  uint8              `json:"precision,omitempty"`
 }
 
 // MarshalJSON returns json data of query
@@ -90,6 +98,8 @@ func (q *MetricMetadata) MarshalJSON() ([]byte, error) {
 		Type:       q.Type,
 		Prefix:     q.Prefix,
 		Limit:      q.Limit,
+		Distinct:   q.Distinct,
+		Precision:  q.Precision,
 	}
 	return encoding.JSONMarshal(&inner), nil
 }
@@ -113,5 +123,7 @@ func (q *MetricMetadata) UnmarshalJSON(value []byte) error {
 	q.TagKey = inner.TagKey
 	q.Prefix = inner.Prefix
 	q.Limit = inner.Limit
+	q.Distinct = inner.Distinct
+	q.Precision = inner.Precision
 	return nil
 }
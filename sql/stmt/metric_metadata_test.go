@@ -31,6 +31,7 @@ func TestMetadataType_String(t *testing.T) {
 	assert.Equal(t, "field", Field.String())
 	assert.Equal(t, "tagKey", TagKey.String())
 	assert.Equal(t, "tagValue", TagValue.String())
+	assert.Equal(t, "tagCardinality", TagCardinality.String())
 	assert.Equal(t, "unknown", MetricMetadataType(0).String())
 }
 
@@ -52,9 +53,11 @@ func TestMetadata_MarshalJSON(t *testing.T) {
 				Right:    &EqualsExpr{Key: "path", Value: "/home"},
 			}},
 		},
-		TagKey: "tagKey",
-		Prefix: "prefix",
-		Limit:  100,
+		TagKey:    "tagKey",
+		Prefix:    "prefix",
+		Limit:     100,
+		Distinct:  true,
+		Precision: 12,
 	}
 
 	data := encoding.JSONMarshal(&query)
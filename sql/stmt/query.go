@@ -19,6 +19,7 @@ package stmt
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/lindb/common/pkg/encoding"
 
@@ -27,12 +28,17 @@ import (
 
 // Query represents search statement
 type Query struct {
-	Explain     bool   // need explain query execute stat
-	Namespace   string // namespace
-	MetricName  string // like table name
-	SelectItems []Expr // select list, such as field, function call, math expression etc.
-	AllFields   bool   // select all fields under metric
-	Condition   Expr   // tag filter condition expression
+	Explain     bool     // need explain query execute stat
+	Analyze     bool     // explain analyze, returns result set with readable execute stats tree
+	Namespace   string   // namespace
+	MetricName  string   // like table name
+	MetricNames []string // all metric names if query multiple metrics(from cpu, mem), MetricName is the first one
+	SelectItems []Expr   // select list, such as field, function call, math expression etc.
+	AllFields   bool     // select all fields under metric
+	Condition   Expr     // tag filter condition expression
+	// FieldFilters represents field value comparisons of where clause, like f > 50,
+	// tag filter selects series, field filter drops the points of field not matched when leaf scanning.
+	FieldFilters []*BinaryExpr
 
 	// broker plan maybe reset
 	TimeRange       timeutil.TimeRange // query time range
@@ -40,10 +46,20 @@ type Query struct {
 	StorageInterval timeutil.Interval  // down sampling storage interval, data find
 	IntervalRatio   int                // down sampling interval ratio(query interval/storage Interval)
 	AutoGroupByTime bool               // auto fix group by interval based on query time range
+	TimeZone        string             // time zone name of tz clause, group by interval aligned to it if set
+	SampleInterval  timeutil.Interval  // interval of sample by clause, overrides group by time interval
+	IntervalOffset  timeutil.Interval  // offset of group by time interval buckets, e.g. time(1d, 8h)
+	// calendar interval of group by time(1w/1M/1y), buckets aligned to calendar, nil if fixed interval
+	Calendar *timeutil.CalendarInterval
 
 	GroupBy      []string // group by tag keys
+	GroupByAll   bool     // group by all tag keys of metric(group by *), expand tag keys when broker plan
 	OrderByItems []Expr   // order by field expr list
 	Limit        int      // num. of time series list for result
+	Offset       int      // num. of time series skipped before limit, for paginating result
+	Fill         *Fill    // gap filling option of grouped series, nil if not set
+
+	SubQuery *Query // inner query of from clause, outer query aggregates the result of it
 }
 
 // StatementType returns metric query type.
@@ -51,6 +67,16 @@ func (q *Query) StatementType() StatementType {
 	return QueryStatement
 }
 
+// IsMultiMetrics returns whether query multiple metrics in single query.
+func (q *Query) IsMultiMetrics() bool {
+	return len(q.MetricNames) > 1
+}
+
+// HasSubQuery returns whether query selects from an inner query.
+func (q *Query) HasSubQuery() bool {
+	return q.SubQuery != nil
+}
+
 // HasGroupBy returns whether query has grouping tag keys
 func (q *Query) HasGroupBy() bool {
 	return len(q.GroupBy) > 0
@@ -59,28 +85,44 @@ func (q *Query) HasGroupBy() bool {
 // innerQuery represents a wrapper of query for json encoding
 type innerQuery struct {
 	Explain     bool              `json:"explain,omitempty"`
+	Analyze     bool              `json:"analyze,omitempty"`
 	Namespace   string            `json:"namespace,omitempty"`
 	MetricName  string            `json:"metricName,omitempty"`
+	MetricNames []string          `json:"metricNames,omitempty"`
 	SelectItems []json.RawMessage `json:"selectItems,omitempty"`
 	AllFields   bool              `json:"allFields,omitempty"`
 	Condition   json.RawMessage   `json:"condition,omitempty"`
 
+	FieldFilters []json.RawMessage `json:"fieldFilters,omitempty"`
+
 	TimeRange       timeutil.TimeRange `json:"timeRange,omitempty"`
 	Interval        timeutil.Interval  `json:"interval,omitempty"`
 	StorageInterval timeutil.Interval  `json:"storageInterval,omitempty"`
 	IntervalRatio   int                `json:"intervalRatio,omitempty"`
 	AutoGroupByTime bool               `json:"autoGroupByTime,omitempty"`
+	TimeZone        string             `json:"timeZone,omitempty"`
+	SampleInterval  timeutil.Interval  `json:"sampleInterval,omitempty"`
+	IntervalOffset  timeutil.Interval  `json:"intervalOffset,omitempty"`
+
+	Calendar *timeutil.CalendarInterval `json:"calendar,omitempty"`
 
 	GroupBy      []string          `json:"groupBy,omitempty"`
+	GroupByAll   bool              `json:"groupByAll,omitempty"`
 	OrderByItems []json.RawMessage `json:"orderByItems,omitempty"`
 	Limit        int               `json:"limit,omitempty"`
+	Offset       int               `json:"offset,omitempty"`
+	Fill         *Fill             `json:"fill,omitempty"`
+
+	SubQuery *Query `json:"subQuery,omitempty"`
 }
 
 // MarshalJSON returns json data of query
 func (q *Query) MarshalJSON() ([]byte, error) {
 	inner := innerQuery{
 		Explain:         q.Explain,
+		Analyze:         q.Analyze,
 		MetricName:      q.MetricName,
+		MetricNames:     q.MetricNames,
 		AllFields:       q.AllFields,
 		Namespace:       q.Namespace,
 		Condition:       Marshal(q.Condition),
@@ -88,9 +130,17 @@ func (q *Query) MarshalJSON() ([]byte, error) {
 		Interval:        q.Interval,
 		IntervalRatio:   q.IntervalRatio,
 		AutoGroupByTime: q.AutoGroupByTime,
+		TimeZone:        q.TimeZone,
+		SampleInterval:  q.SampleInterval,
+		IntervalOffset:  q.IntervalOffset,
+		Calendar:        q.Calendar,
 		StorageInterval: q.StorageInterval,
 		GroupBy:         q.GroupBy,
+		GroupByAll:      q.GroupByAll,
 		Limit:           q.Limit,
+		Offset:          q.Offset,
+		Fill:            q.Fill,
+		SubQuery:        q.SubQuery,
 	}
 	for _, item := range q.SelectItems {
 		inner.SelectItems = append(inner.SelectItems, Marshal(item))
@@ -98,6 +148,9 @@ func (q *Query) MarshalJSON() ([]byte, error) {
 	for _, item := range q.OrderByItems {
 		inner.OrderByItems = append(inner.OrderByItems, Marshal(item))
 	}
+	for _, filter := range q.FieldFilters {
+		inner.FieldFilters = append(inner.FieldFilters, Marshal(filter))
+	}
 	return encoding.JSONMarshal(&inner), nil
 }
 
@@ -123,6 +176,19 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 		}
 		selectItems = append(selectItems, selectItem)
 	}
+	// field filter list
+	var fieldFilters []*BinaryExpr
+	for _, item := range inner.FieldFilters {
+		filter, err := Unmarshal(item)
+		if err != nil {
+			return err
+		}
+		binaryExpr, ok := filter.(*BinaryExpr)
+		if !ok {
+			return fmt.Errorf("field filter: %s is not binary expr", filter.Rewrite())
+		}
+		fieldFilters = append(fieldFilters, binaryExpr)
+	}
 	// order by list
 	var orderByItems []Expr
 	for _, item := range inner.OrderByItems {
@@ -134,17 +200,28 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 	}
 
 	q.Explain = inner.Explain
+	q.Analyze = inner.Analyze
 	q.MetricName = inner.MetricName
+	q.MetricNames = inner.MetricNames
 	q.Namespace = inner.Namespace
 	q.SelectItems = selectItems
 	q.AllFields = inner.AllFields
+	q.FieldFilters = fieldFilters
 	q.TimeRange = inner.TimeRange
 	q.Interval = inner.Interval
 	q.IntervalRatio = inner.IntervalRatio
 	q.AutoGroupByTime = inner.AutoGroupByTime
+	q.TimeZone = inner.TimeZone
+	q.SampleInterval = inner.SampleInterval
+	q.IntervalOffset = inner.IntervalOffset
+	q.Calendar = inner.Calendar
 	q.StorageInterval = inner.StorageInterval
 	q.GroupBy = inner.GroupBy
+	q.GroupByAll = inner.GroupByAll
 	q.OrderByItems = orderByItems
 	q.Limit = inner.Limit
+	q.Offset = inner.Offset
+	q.Fill = inner.Fill
+	q.SubQuery = inner.SubQuery
 	return nil
 }
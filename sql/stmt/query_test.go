@@ -30,9 +30,12 @@ import (
 
 func TestQuery_Marshal(t *testing.T) {
 	query := Query{
-		Namespace:  "ns",
-		MetricName: "test",
-		AllFields:  true,
+		Explain:     true,
+		Analyze:     true,
+		Namespace:   "ns",
+		MetricName:  "test",
+		MetricNames: []string{"test", "test2"},
+		AllFields:   true,
 		SelectItems: []Expr{
 			&SelectItem{Expr: &FieldExpr{Name: "a"}},
 			&SelectItem{Expr: &FieldExpr{Name: "b"}},
@@ -64,9 +67,17 @@ func TestQuery_Marshal(t *testing.T) {
 				Right:    &EqualsExpr{Key: "path", Value: "/home"},
 			}},
 		},
-		TimeRange: timeutil.TimeRange{Start: 10, End: 30},
-		Interval:  1000,
-		GroupBy:   []string{"a", "b", "c"},
+		FieldFilters: []*BinaryExpr{
+			{Left: &FieldExpr{Name: "f"}, Operator: GREATER, Right: &NumberLiteral{Val: 50}},
+		},
+		TimeRange:      timeutil.TimeRange{Start: 10, End: 30},
+		Interval:       1000,
+		TimeZone:       "Asia/Shanghai",
+		SampleInterval: 3600000,
+		IntervalOffset: 1800000,
+		Calendar:       &timeutil.CalendarInterval{Unit: timeutil.CalendarMonth, Count: 1},
+		GroupBy:        []string{"a", "b", "c"},
+		GroupByAll:     true,
 		OrderByItems: []Expr{
 			&FieldExpr{Name: "b"},
 			&CallExpr{
@@ -74,7 +85,14 @@ func TestQuery_Marshal(t *testing.T) {
 				Params:   []Expr{&FieldExpr{Name: "c"}},
 			},
 		},
-		Limit: 100,
+		Limit:  100,
+		Offset: 10,
+		Fill:   &Fill{Type: FillValue, Value: 1.5},
+		SubQuery: &Query{
+			MetricName:  "cpu",
+			SelectItems: []Expr{&SelectItem{Expr: &CallExpr{FuncType: function.Avg, Params: []Expr{&FieldExpr{Name: "f"}}}, Alias: "v"}},
+			GroupBy:     []string{"host", "region"},
+		},
 	}
 
 	data := encoding.JSONMarshal(&query)
@@ -84,6 +102,7 @@ func TestQuery_Marshal(t *testing.T) {
 	assert.Equal(t, query, query1)
 	assert.True(t, query.HasGroupBy())
 	assert.True(t, query.AllFields)
+	assert.True(t, query.HasSubQuery())
 }
 
 func TestQuery_Marshal_Fail(t *testing.T) {
@@ -96,6 +115,10 @@ func TestQuery_Marshal_Fail(t *testing.T) {
 	assert.Error(t, err)
 	err = query.UnmarshalJSON([]byte("{\"orderByItems\":[\"123\"]}"))
 	assert.Error(t, err)
+	err = query.UnmarshalJSON([]byte("{\"fieldFilters\":[\"123\"]}"))
+	assert.Error(t, err)
+	err = query.UnmarshalJSON([]byte(`{"fieldFilters":[{"type":"field","expr":{"name":"f"}}]}`))
+	assert.Error(t, err)
 }
 
 func TestQuery_StatementType(t *testing.T) {
@@ -0,0 +1,69 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stmt
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+)
+
+// Compile compiles the pattern of regex expr, the compiled pattern is cached for reusing.
+// NOTE: not thread-safe, need invoke it before sharing the expr.
+func (e *RegexExpr) Compile() error {
+	pattern, err := compileRegex(e.Regexp)
+	if err != nil {
+		return err
+	}
+	e.pattern = pattern
+	return nil
+}
+
+// Pattern returns the compiled pattern of regex expr, compiles it if not precompiled.
+func (e *RegexExpr) Pattern() (*regexp.Regexp, error) {
+	if e.pattern != nil {
+		return e.pattern, nil
+	}
+	return compileRegex(e.Regexp)
+}
+
+// LiteralPrefix returns the literal prefix which all matched tag values must start with,
+// returns empty string if the pattern is unanchored at the beginning(can match any substring).
+func (e *RegexExpr) LiteralPrefix(pattern *regexp.Regexp) string {
+	re, err := syntax.Parse(e.Regexp, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	for re.Op == syntax.OpConcat && len(re.Sub) > 0 {
+		re = re.Sub[0]
+	}
+	if re.Op != syntax.OpBeginText {
+		return ""
+	}
+	prefix, _ := pattern.LiteralPrefix()
+	return prefix
+}
+
+// compileRegex compiles the regex pattern, returns a helpful error if pattern is invalid.
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex pattern '%s': %w", expr, err)
+	}
+	return pattern, nil
+}
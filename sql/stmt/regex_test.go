@@ -0,0 +1,61 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegexExpr_Pattern(t *testing.T) {
+	expr := &RegexExpr{Key: "host", Regexp: "prod"}
+	pattern, err := expr.Pattern()
+	assert.NoError(t, err)
+	// unanchored, matches substring
+	assert.True(t, pattern.MatchString("my-prod-1"))
+	assert.NoError(t, expr.Compile())
+	pattern2, err := expr.Pattern()
+	assert.NoError(t, err)
+	assert.Same(t, pattern2, expr.pattern)
+
+	expr = &RegexExpr{Key: "host", Regexp: "prod["}
+	_, err = expr.Pattern()
+	assert.ErrorContains(t, err, "invalid regex pattern 'prod['")
+	assert.Error(t, expr.Compile())
+}
+
+func TestRegexExpr_LiteralPrefix(t *testing.T) {
+	cases := []struct {
+		regexp string
+		prefix string
+	}{
+		{regexp: "prod", prefix: ""},
+		{regexp: "prod-[0-9]+", prefix: ""},
+		{regexp: "^prod", prefix: "prod"},
+		{regexp: "^prod-[0-9]+$", prefix: "prod-"},
+		{regexp: "^(prod|dev)", prefix: ""},
+		{regexp: "(?m)^prod", prefix: ""},
+		{regexp: "a[", prefix: ""},
+	}
+	for _, c := range cases {
+		expr := &RegexExpr{Regexp: c.regexp}
+		pattern, _ := expr.Pattern()
+		assert.Equal(t, c.prefix, expr.LiteralPrefix(pattern), c.regexp)
+	}
+}
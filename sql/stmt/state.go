@@ -39,6 +39,10 @@ const (
 	StorageMetric
 	// MemoryDatabase represents show memory database statement.
 	MemoryDatabase
+	// StorageReplicaLag represents show replica lag of storage statement.
+	StorageReplicaLag
+	// StorageWriteStats represents show per metric write statistics of brokers writing to storage statement.
+	StorageWriteStats
 )
 
 // State represents show state statement.
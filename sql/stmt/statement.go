@@ -30,6 +30,8 @@ const (
 	RequestStatement
 	BrokerStatement
 	LimitStatement
+	AlterDatabaseStatement
+	WriteStatement
 )
 
 // Statement represents LinDB query language statement
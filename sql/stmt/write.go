@@ -0,0 +1,39 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stmt
+
+// Write represents write one data point of metric statement for quick testing,
+// e.g. insert into cpu,host=a,region=sh usage=0.5,load=1 1690000000000.
+type Write struct {
+	MetricName string
+	Tags       map[string]string
+	Fields     []WriteField
+	// Timestamp represents the timestamp(milliseconds) of data point, 0 means now.
+	Timestamp int64
+}
+
+// WriteField represents the field name/value of write statement.
+type WriteField struct {
+	Name  string
+	Value float64
+}
+
+// StatementType returns write type.
+func (q *Write) StatementType() StatementType {
+	return WriteStatement
+}
@@ -0,0 +1,269 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lindb/lindb/aggregation/function"
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+// subQueryMetricName represents the placeholder metric name of sub query in outer query.
+const subQueryMetricName = "__sub_query"
+
+var (
+	errSubQueryNested      = errors.New("sub query only supports single level nesting")
+	errSubQueryNotQuery    = errors.New("sub query only supports select statement")
+	errSubQueryMultiMetric = errors.New("sub query only supports single metric")
+	errSubQueryGroupByAll  = errors.New("outer query of sub query not support group by *")
+	errSubQueryFieldFilter = errors.New("outer query of sub query not support field filter")
+)
+
+// splitSubQuery splits the sql into outer sql and inner sql if from clause is a parenthesized query,
+// the inner query is replaced by placeholder metric name in outer sql.
+func splitSubQuery(sql string) (outerSQL, innerSQL string, ok bool) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && isKeywordAt(sql, i, "from"):
+			start := i + len("from")
+			for start < len(sql) && isBlank(sql[start]) {
+				start++
+			}
+			if start == len(sql) || sql[start] != '(' {
+				return "", "", false
+			}
+			end := findCloseParen(sql, start)
+			if end < 0 {
+				return "", "", false
+			}
+			return sql[:start] + subQueryMetricName + sql[end+1:], sql[start+1 : end], true
+		}
+	}
+	return "", "", false
+}
+
+// findCloseParen returns the index of close parenthesis which matches the open parenthesis at start.
+func findCloseParen(sql string, start int) int {
+	depth := 0
+	var quote byte
+	for i := start; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isKeywordAt checks if the keyword(case-insensitive) is at the position of sql as a whole word.
+func isKeywordAt(sql string, pos int, keyword string) bool {
+	end := pos + len(keyword)
+	if end > len(sql) || !strings.EqualFold(sql[pos:end], keyword) {
+		return false
+	}
+	if pos > 0 && isIdentChar(sql[pos-1]) {
+		return false
+	}
+	return end == len(sql) || !isIdentChar(sql[end])
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// parseSubQuery parses the outer/inner sql, then builds the query with sub query.
+func parseSubQuery(outerSQL, innerSQL string, location *time.Location) (stmtpkg.Statement, error) {
+	if _, _, nested := splitSubQuery(innerSQL); nested {
+		return nil, errSubQueryNested
+	}
+	innerStmt, err := parse(innerSQL, location)
+	if err != nil {
+		return nil, err
+	}
+	inner, ok := innerStmt.(*stmtpkg.Query)
+	if !ok {
+		return nil, errSubQueryNotQuery
+	}
+	outerListener := &listener{location: location}
+	outerStmt, err := parseWithListener(outerSQL, outerListener)
+	if err != nil {
+		return nil, err
+	}
+	outer, ok := outerStmt.(*stmtpkg.Query)
+	if !ok {
+		return nil, errSubQueryNotQuery
+	}
+	if outerListener.queryStmt.relativeTimeRange {
+		// since/until/last clause of outer query applies to sub query
+		inner.TimeRange = outer.TimeRange
+	}
+	outer.MetricName = ""
+	outer.MetricNames = nil
+	outer.SubQuery = inner
+	if err := validateSubQuery(outer); err != nil {
+		return nil, err
+	}
+	return outer, nil
+}
+
+// validateSubQuery checks if outer query only references the output of sub query:
+// 1) group by tag keys of outer query must be subset of sub query;
+// 2) tag filter of outer query must be on group by tag keys of sub query, pushes it down to sub query;
+// 3) select items of outer query must be aggregate function of sub query's fields.
+func validateSubQuery(outer *stmtpkg.Query) error {
+	inner := outer.SubQuery
+	if inner.IsMultiMetrics() {
+		return errSubQueryMultiMetric
+	}
+	if outer.GroupByAll {
+		return errSubQueryGroupByAll
+	}
+	if len(outer.FieldFilters) > 0 {
+		return errSubQueryFieldFilter
+	}
+	innerGroupBy := make(map[string]struct{})
+	for _, tagKey := range inner.GroupBy {
+		innerGroupBy[tagKey] = struct{}{}
+	}
+	isGrouped := func(tagKey string) bool {
+		_, ok := innerGroupBy[tagKey]
+		return inner.GroupByAll || ok
+	}
+	for _, tagKey := range outer.GroupBy {
+		if !isGrouped(tagKey) {
+			return fmt.Errorf("group by tag key of outer query must be grouped by sub query, tag key: %s", tagKey)
+		}
+	}
+	if outer.Condition != nil {
+		var tagKeys []string
+		collectTagKeys(outer.Condition, &tagKeys)
+		for _, tagKey := range tagKeys {
+			if !isGrouped(tagKey) {
+				return fmt.Errorf("correlated reference not supported in sub query, tag key: %s not grouped by sub query", tagKey)
+			}
+		}
+		// filter on group by tag keys before aggregating is same as after, so push it down to sub query
+		condition := &stmtpkg.ParenExpr{Expr: outer.Condition}
+		if inner.Condition == nil {
+			inner.Condition = condition
+		} else {
+			inner.Condition = &stmtpkg.BinaryExpr{
+				Left:     &stmtpkg.ParenExpr{Expr: inner.Condition},
+				Operator: stmtpkg.AND,
+				Right:    condition,
+			}
+		}
+		outer.Condition = nil
+	}
+	if outer.Interval > 0 && inner.Interval == 0 {
+		inner.Interval = outer.Interval
+	}
+	return validateSubQuerySelectItems(outer)
+}
+
+// validateSubQuerySelectItems checks if select items of outer query are aggregate function of sub query's fields.
+func validateSubQuerySelectItems(outer *stmtpkg.Query) error {
+	inner := outer.SubQuery
+	if outer.AllFields {
+		return errors.New("outer query of sub query must select aggregate function")
+	}
+	innerFields := make(map[string]struct{})
+	for _, item := range inner.SelectItems {
+		if selectItem, ok := item.(*stmtpkg.SelectItem); ok && selectItem.Alias != "" {
+			innerFields[selectItem.Alias] = struct{}{}
+		} else {
+			innerFields[item.Rewrite()] = struct{}{}
+		}
+	}
+	for _, item := range outer.SelectItems {
+		expr := item
+		if selectItem, ok := item.(*stmtpkg.SelectItem); ok {
+			expr = selectItem.Expr
+		}
+		call, ok := expr.(*stmtpkg.CallExpr)
+		if !ok || len(call.Params) != 1 || !isSubQueryAggregate(call.FuncType) {
+			return fmt.Errorf("outer query of sub query only supports sum/min/max/count/avg function, select item: %s", item.Rewrite())
+		}
+		field, ok := call.Params[0].(*stmtpkg.FieldExpr)
+		if !ok {
+			return fmt.Errorf("outer query of sub query only supports aggregating field, select item: %s", item.Rewrite())
+		}
+		if _, ok := innerFields[field.Name]; !ok && !inner.AllFields {
+			return fmt.Errorf("correlated reference not supported in sub query, field: %s not selected by sub query", field.Name)
+		}
+	}
+	return nil
+}
+
+// isSubQueryAggregate checks if function can aggregate the series of sub query.
+func isSubQueryAggregate(funcType function.FuncType) bool {
+	switch funcType {
+	case function.Sum, function.Min, function.Max, function.Count, function.Avg:
+		return true
+	default:
+		return false
+	}
+}
+
+// collectTagKeys collects tag keys of tag filter expr.
+func collectTagKeys(expr stmtpkg.Expr, tagKeys *[]string) {
+	switch e := expr.(type) {
+	case stmtpkg.TagFilter:
+		*tagKeys = append(*tagKeys, e.TagKey())
+	case *stmtpkg.ParenExpr:
+		collectTagKeys(e.Expr, tagKeys)
+	case *stmtpkg.NotExpr:
+		collectTagKeys(e.Expr, tagKeys)
+	case *stmtpkg.BinaryExpr:
+		collectTagKeys(e.Left, tagKeys)
+		collectTagKeys(e.Right, tagKeys)
+	}
+}
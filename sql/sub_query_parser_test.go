@@ -0,0 +1,109 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitSubQuery(t *testing.T) {
+	cases := []struct {
+		sql   string
+		outer string
+		inner string
+		ok    bool
+	}{
+		{sql: "select f from cpu"},
+		{sql: "select f from 'from (x)'"},
+		{sql: "select f from (select f from cpu"},
+		{sql: "select fromx from cpu where a='(from (b))'"},
+		{
+			sql:   "select max(v) FROM (select avg(f) as v from cpu group by host) group by host",
+			outer: "select max(v) FROM __sub_query group by host",
+			inner: "select avg(f) as v from cpu group by host",
+			ok:    true,
+		},
+		{
+			sql:   "from\n(select f from cpu where a=')') select max(f)",
+			outer: "from\n__sub_query select max(f)",
+			inner: "select f from cpu where a=')'",
+			ok:    true,
+		},
+	}
+	for _, tt := range cases {
+		outer, inner, ok := splitSubQuery(tt.sql)
+		assert.Equal(t, tt.ok, ok, tt.sql)
+		assert.Equal(t, tt.outer, outer, tt.sql)
+		assert.Equal(t, tt.inner, inner, tt.sql)
+	}
+}
+
+func TestSubQuery(t *testing.T) {
+	q, err := Parse("select max(v) as m from (select avg(f) as v from cpu group by host, region) " +
+		"where region='sh' group by region")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.True(t, query.HasSubQuery())
+	assert.Empty(t, query.MetricName)
+	assert.Nil(t, query.Condition)
+	assert.Equal(t, []string{"region"}, query.GroupBy)
+	assert.Equal(t, &stmt.SelectItem{
+		Expr:  &stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "v"}}},
+		Alias: "m",
+	}, query.SelectItems[0])
+	subQuery := query.SubQuery
+	assert.Equal(t, "cpu", subQuery.MetricName)
+	assert.Equal(t, []string{"host", "region"}, subQuery.GroupBy)
+	// outer tag filter pushed down to sub query
+	assert.Equal(t, &stmt.ParenExpr{Expr: &stmt.EqualsExpr{Key: "region", Value: "sh"}}, subQuery.Condition)
+
+	q, err = Parse("select sum(f) from (select f from cpu where host='a' group by host) where host='b'")
+	assert.NoError(t, err)
+	assert.Equal(t, "(host=a)and(host=b)", q.(*stmt.Query).SubQuery.Condition.Rewrite())
+}
+
+func TestSubQuery_Fail(t *testing.T) {
+	cases := []struct {
+		name string
+		sql  string
+	}{
+		{name: "nested sub query", sql: "select max(v) from (select max(f) as v from (select f from cpu))"},
+		{name: "inner not select", sql: "select max(v) from (show databases)"},
+		{name: "inner parse failure", sql: "select max(v) from (select from cpu)"},
+		{name: "outer not select", sql: "show fields from (select f from cpu)"},
+		{name: "outer parse failure", sql: "select from (select f from cpu)"},
+		{name: "inner multi metrics", sql: "select max(f) from (select f from cpu, mem)"},
+		{name: "outer group by *", sql: "select max(f) from (select f from cpu group by host) group by *"},
+		{name: "group by not subset", sql: "select max(f) from (select f from cpu group by host) group by region"},
+		{name: "correlated tag filter", sql: "select max(f) from (select f from cpu group by host) where region='sh'"},
+		{name: "select all fields", sql: "select * from (select f from cpu)"},
+		{name: "not aggregate", sql: "select f from (select f from cpu)"},
+		{name: "not supported function", sql: "select last(f) from (select f from cpu)"},
+		{name: "not aggregate field", sql: "select max(1) from (select f from cpu)"},
+		{name: "correlated field", sql: "select max(g) from (select f from cpu)"},
+	}
+	for _, tt := range cases {
+		_, err := Parse(tt.sql)
+		assert.Error(t, err, tt.name)
+	}
+}
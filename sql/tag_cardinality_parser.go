@@ -0,0 +1,62 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var errTagCardinalityNotMetadata = errors.New("show tag cardinality only supports metric metadata statement")
+
+// tagCardinalityKeywords represents the keywords of show tag cardinality statement.
+var tagCardinalityKeywords = []string{"show", "tag", "cardinality"}
+
+// splitTagCardinality rewrites show tag cardinality statement to show tag keys statement,
+// because both statements query tag keys of metric with same clauses.
+func splitTagCardinality(sql string) (tagKeysSQL string, ok bool) {
+	pos := 0
+	for idx, keyword := range tagCardinalityKeywords {
+		for pos < len(sql) && isBlank(sql[pos]) {
+			pos++
+		}
+		if !isKeywordAt(sql, pos, keyword) {
+			return "", false
+		}
+		if idx == len(tagCardinalityKeywords)-1 {
+			return sql[:pos] + "keys" + sql[pos+len(keyword):], true
+		}
+		pos += len(keyword)
+	}
+	return "", false
+}
+
+// parseTagCardinality parses the show tag keys sql, then builds the tag cardinality statement.
+func parseTagCardinality(tagKeysSQL string) (stmtpkg.Statement, error) {
+	stmt, err := parse(tagKeysSQL, nil)
+	if err != nil {
+		return nil, err
+	}
+	metadata, ok := stmt.(*stmtpkg.MetricMetadata)
+	if !ok || metadata.Type != stmtpkg.TagKey {
+		return nil, errTagCardinalityNotMetadata
+	}
+	metadata.Type = stmtpkg.TagCardinality
+	return metadata, nil
+}
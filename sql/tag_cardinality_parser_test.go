@@ -0,0 +1,73 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitTagCardinality(t *testing.T) {
+	cases := []struct {
+		sql       string
+		tagKeySQL string
+		ok        bool
+	}{
+		{sql: "show tag keys from cpu"},
+		{sql: "show tag"},
+		{sql: "show tag cardinalityx from cpu"},
+		{sql: "select cardinality from cpu"},
+		{
+			sql:       "show tag cardinality from cpu",
+			tagKeySQL: "show tag keys from cpu",
+			ok:        true,
+		},
+		{
+			sql:       " SHOW\tTag\nCARDINALITY from 'cpu' on 'ns'",
+			tagKeySQL: " SHOW\tTag\nkeys from 'cpu' on 'ns'",
+			ok:        true,
+		},
+	}
+	for _, c := range cases {
+		tagKeySQL, ok := splitTagCardinality(c.sql)
+		assert.Equal(t, c.ok, ok, c.sql)
+		assert.Equal(t, c.tagKeySQL, tagKeySQL, c.sql)
+	}
+}
+
+func TestMetaStmt_ShowTagCardinality(t *testing.T) {
+	q, err := Parse("show tag cardinality from 'cpu' on 'ns'")
+	assert.NoError(t, err)
+	query := q.(*stmt.MetricMetadata)
+	assert.Equal(t, stmt.TagCardinality, query.Type)
+	assert.Equal(t, "cpu", query.MetricName)
+	assert.Equal(t, "ns", query.Namespace)
+
+	_, err = Parse("show tag cardinality from cpu, mem")
+	assert.Error(t, err)
+	_, err = Parse("show tag cardinality cpu")
+	assert.Error(t, err)
+}
+
+func TestParseTagCardinality(t *testing.T) {
+	_, err := parseTagCardinality("show tag values from cpu with key=host")
+	assert.Equal(t, errTagCardinalityNotMetadata, err)
+}
@@ -0,0 +1,66 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeZoneKeyword represents the keyword of time zone clause.
+const timeZoneKeyword = "tz"
+
+// splitTimeZone removes the time zone clause(TZ 'America/New_York') from sql,
+// returns nil location if sql without time zone clause.
+func splitTimeZone(sql string) (sqlWithoutTZ string, location *time.Location, err error) {
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case isKeywordAt(sql, i, timeZoneKeyword):
+			start := i + len(timeZoneKeyword)
+			for start < len(sql) && isBlank(sql[start]) {
+				start++
+			}
+			if start == len(sql) || (sql[start] != '\'' && sql[start] != '"') {
+				// not time zone clause, maybe tag key named tz
+				continue
+			}
+			end := start + 1
+			for end < len(sql) && sql[end] != sql[start] {
+				end++
+			}
+			if end == len(sql) {
+				return "", nil, fmt.Errorf("time zone not closed by quote")
+			}
+			name := sql[start+1 : end]
+			location, err = time.LoadLocation(name)
+			if err != nil || name == "" {
+				return "", nil, fmt.Errorf("invalid time zone: '%s'", name)
+			}
+			return sql[:i] + sql[end+1:], location, nil
+		}
+	}
+	return sql, nil, nil
+}
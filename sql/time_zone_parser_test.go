@@ -0,0 +1,98 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	commontimeutil "github.com/lindb/common/pkg/timeutil"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSplitTimeZone(t *testing.T) {
+	cases := []struct {
+		sql      string
+		result   string
+		location string
+		wantErr  bool
+	}{
+		{sql: "select f from cpu", result: "select f from cpu"},
+		{sql: "select f from cpu where tz='a' group by tz", result: "select f from cpu where tz='a' group by tz"},
+		{sql: "select f from cpu where host='tz \"UTC\"'", result: "select f from cpu where host='tz \"UTC\"'"},
+		{
+			sql:      "select f from cpu where time > '2024-01-01 00:00:00' TZ 'America/New_York'",
+			result:   "select f from cpu where time > '2024-01-01 00:00:00' ",
+			location: "America/New_York",
+		},
+		{
+			sql:      "select f from cpu tz \"Asia/Shanghai\" group by host",
+			result:   "select f from cpu  group by host",
+			location: "Asia/Shanghai",
+		},
+		{sql: "select f from cpu tz 'Mars/Olympus'", wantErr: true},
+		{sql: "select f from cpu tz ''", wantErr: true},
+		{sql: "select f from cpu tz 'UTC", wantErr: true},
+	}
+	for _, c := range cases {
+		result, location, err := splitTimeZone(c.sql)
+		if c.wantErr {
+			assert.Error(t, err, c.sql)
+			continue
+		}
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.result, result, c.sql)
+		if c.location == "" {
+			assert.Nil(t, location, c.sql)
+		} else {
+			assert.Equal(t, c.location, location.String(), c.sql)
+		}
+	}
+}
+
+func TestQuery_TimeZone(t *testing.T) {
+	location, _ := time.LoadLocation("America/New_York")
+	q, err := Parse("select f from cpu where time > '2024-01-01 00:00:00' and time < now() TZ 'America/New_York' group by time(1d)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, "America/New_York", query.TimeZone)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, location).UnixMilli(), query.TimeRange.Start)
+	// now() is independent of time zone
+	assert.InDelta(t, commontimeutil.Now(), query.TimeRange.End, float64(commontimeutil.OneMinute))
+
+	// default UTC
+	q, err = Parse("select f from cpu where time > '2024-01-01 00:00:00'")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Empty(t, query.TimeZone)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli(), query.TimeRange.Start)
+
+	// sub query
+	q, err = Parse("select max(v) from (select avg(f) as v from cpu where time > '2024-01-01 00:00:00' group by host) TZ 'America/New_York'")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, "America/New_York", query.TimeZone)
+	assert.Equal(t, "America/New_York", query.SubQuery.TimeZone)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, location).UnixMilli(), query.SubQuery.TimeRange.Start)
+
+	_, err = Parse("select f from cpu TZ 'bad zone'")
+	assert.EqualError(t, err, "invalid time zone: 'bad zone'")
+}
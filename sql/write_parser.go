@@ -0,0 +1,99 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	stmtpkg "github.com/lindb/lindb/sql/stmt"
+)
+
+var errInsertSyntax = errors.New("insert syntax error, e.g. insert into cpu,host=a usage=0.5[ 1690000000000]")
+
+// insertKeywords represents the keywords of insert statement.
+var insertKeywords = []string{"insert", "into"}
+
+// splitInsert returns the line protocol like clause after insert into keywords, e.g. cpu,host=a usage=0.5.
+func splitInsert(sql string) (clause string, ok bool) {
+	pos := 0
+	for _, keyword := range insertKeywords {
+		for pos < len(sql) && isBlank(sql[pos]) {
+			pos++
+		}
+		if !isKeywordAt(sql, pos, keyword) {
+			return "", false
+		}
+		pos += len(keyword)
+	}
+	return sql[pos:], true
+}
+
+// parseInsert parses the clause of insert statement: metric[,tag=value] field=value[,field=value] [timestamp],
+// timestamp is in milliseconds, now if not set.
+func parseInsert(clause string) (stmtpkg.Statement, error) {
+	parts := strings.Fields(strings.TrimSuffix(strings.TrimSpace(clause), ";"))
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, errInsertSyntax
+	}
+	metricAndTags := strings.Split(parts[0], ",")
+	stmt := &stmtpkg.Write{MetricName: metricAndTags[0]}
+	if stmt.MetricName == "" {
+		return nil, errInsertSyntax
+	}
+	for _, item := range metricAndTags[1:] {
+		key, value, ok := strings.Cut(item, "=")
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid tag '%s' of insert, e.g. host=a", item)
+		}
+		if stmt.Tags == nil {
+			stmt.Tags = make(map[string]string)
+		}
+		if _, exist := stmt.Tags[key]; exist {
+			return nil, fmt.Errorf("duplicate tag '%s' of insert", key)
+		}
+		stmt.Tags[key] = value
+	}
+	fieldNames := make(map[string]struct{})
+	for _, item := range strings.Split(parts[1], ",") {
+		name, valueStr, ok := strings.Cut(item, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid field '%s' of insert, e.g. usage=0.5", item)
+		}
+		value, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, fmt.Errorf("invalid value '%s' of field '%s', value must be a number", valueStr, name)
+		}
+		if _, exist := fieldNames[name]; exist {
+			return nil, fmt.Errorf("duplicate field '%s' of insert", name)
+		}
+		fieldNames[name] = struct{}{}
+		stmt.Fields = append(stmt.Fields, stmtpkg.WriteField{Name: name, Value: value})
+	}
+	if len(parts) == 3 {
+		timestamp, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil || timestamp <= 0 {
+			return nil, fmt.Errorf("invalid timestamp '%s' of insert, timestamp must be milliseconds", parts[2])
+		}
+		stmt.Timestamp = timestamp
+	}
+	return stmt, nil
+}
@@ -0,0 +1,94 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestInsert(t *testing.T) {
+	cases := []struct {
+		sql    string
+		stmt   stmt.Statement
+		hasErr bool
+	}{
+		{
+			sql: "insert into cpu,host=a usage=0.5 1690000000000",
+			stmt: &stmt.Write{
+				MetricName: "cpu",
+				Tags:       map[string]string{"host": "a"},
+				Fields:     []stmt.WriteField{{Name: "usage", Value: 0.5}},
+				Timestamp:  1690000000000,
+			},
+		},
+		{
+			sql: " INSERT\tInto system.cpu,host=a,region=sh usage=0.5,load=-1,count=1e3;",
+			stmt: &stmt.Write{
+				MetricName: "system.cpu",
+				Tags:       map[string]string{"host": "a", "region": "sh"},
+				Fields: []stmt.WriteField{
+					{Name: "usage", Value: 0.5},
+					{Name: "load", Value: -1},
+					{Name: "count", Value: 1000},
+				},
+			},
+		},
+		{
+			sql: "insert into cpu usage=1",
+			stmt: &stmt.Write{
+				MetricName: "cpu",
+				Fields:     []stmt.WriteField{{Name: "usage", Value: 1}},
+			},
+		},
+		{sql: "insert into", hasErr: true},
+		{sql: "insert into cpu", hasErr: true},
+		{sql: "insert into cpu usage=1 1690000000000 1", hasErr: true},
+		{sql: "insert into ,host=a usage=1", hasErr: true},
+		{sql: "insert into cpu,host usage=1", hasErr: true},
+		{sql: "insert into cpu,host= usage=1", hasErr: true},
+		{sql: "insert into cpu,host=a,host=b usage=1", hasErr: true},
+		{sql: "insert into cpu usage", hasErr: true},
+		{sql: "insert into cpu =1", hasErr: true},
+		{sql: "insert into cpu usage=a", hasErr: true},
+		{sql: "insert into cpu usage=NaN", hasErr: true},
+		{sql: "insert into cpu usage=+Inf", hasErr: true},
+		{sql: "insert into cpu usage=1,usage=2", hasErr: true},
+		{sql: "insert into cpu usage=1 now", hasErr: true},
+		{sql: "insert into cpu usage=1 -1", hasErr: true},
+	}
+	for _, c := range cases {
+		s, err := Parse(c.sql)
+		if c.hasErr {
+			assert.Error(t, err, c.sql)
+			continue
+		}
+		assert.NoError(t, err, c.sql)
+		assert.Equal(t, c.stmt, s, c.sql)
+	}
+	// not insert statement
+	_, ok := splitInsert("insert cpu")
+	assert.False(t, ok)
+	_, ok = splitInsert("insert_into cpu")
+	assert.False(t, ok)
+	_, ok = splitInsert("select * from cpu")
+	assert.False(t, ok)
+}
@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/lindb/roaring"
+	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
@@ -50,6 +51,7 @@ type indexDatabase struct {
 	metricID2Mapping map[metric.ID]MetricIDMapping // key: metric id, value: metric id mapping
 	metadata         metadb.Metadata               // the metadata for generating ID of metric, field
 	index            InvertedIndex
+	pendingSeries    atomic.Int32 // num. of series created since last flush
 
 	statistics *metrics.IndexDBStatistics
 
@@ -66,6 +68,7 @@ func NewIndexDatabase(ctx context.Context, parent string, metadata metadb.Metada
 		return nil, err
 	}
 	c, cancel := context.WithCancel(ctx)
+	statistics := metrics.NewIndexDBStatistics(metadata.DatabaseName())
 	db := &indexDatabase{
 		path:             parent,
 		ctx:              c,
@@ -73,8 +76,8 @@ func NewIndexDatabase(ctx context.Context, parent string, metadata metadb.Metada
 		backend:          backend,
 		metadata:         metadata,
 		metricID2Mapping: make(map[metric.ID]MetricIDMapping),
-		index:            newInvertedIndex(metadata, forwardFamily, invertedFamily),
-		statistics:       metrics.NewIndexDBStatistics(metadata.DatabaseName()),
+		index:            newInvertedIndex(metadata, forwardFamily, invertedFamily, statistics),
+		statistics:       statistics,
 	}
 
 	return db, nil
@@ -145,6 +148,7 @@ func (db *indexDatabase) GetOrCreateSeriesID(namespace, metricName string, metri
 	if err := db.backend.genSeriesID(metricID, tagsHash, seriesID); err != nil {
 		return series.EmptySeriesID, false, err
 	}
+	db.pendingSeries.Inc()
 
 	return seriesID, true, nil
 }
@@ -192,6 +196,12 @@ func (db *indexDatabase) BuildInvertIndex(
 	db.statistics.BuildInvertedIndex.Incr()
 }
 
+// NeedFlush checks if num. of series created since last flush exceeds the threshold(max-pending-series),
+// index need to flush early, not waiting for data flush.
+func (db *indexDatabase) NeedFlush() bool {
+	return int(db.pendingSeries.Load()) >= config.GlobalStorageConfig().TSDB.MaxPendingSeries
+}
+
 // Flush flushes index data to disk
 func (db *indexDatabase) Flush() error {
 	// TODO need flush metric level time series sequence?
@@ -200,6 +210,8 @@ func (db *indexDatabase) Flush() error {
 		db.rwMutex.Unlock()
 		return err
 	}
+	// series created after reset will be counted for next flush
+	db.pendingSeries.Store(0)
 	db.rwMutex.Unlock()
 
 	return db.index.Flush()
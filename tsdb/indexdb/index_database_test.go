@@ -499,3 +499,48 @@ func TestIndexDatabase_Flush(t *testing.T) {
 	backend.EXPECT().sync().Return(fmt.Errorf("err"))
 	assert.Error(t, db.Flush())
 }
+
+func TestIndexDatabase_NeedFlush(t *testing.T) {
+	testPath := t.TempDir()
+	ctrl := gomock.NewController(t)
+	defer func() {
+		config.SetGlobalStorageConfig(config.NewDefaultStorageBase())
+		ctrl.Finish()
+	}()
+	cfg := config.NewDefaultStorageBase()
+	cfg.TSDB.MaxPendingSeries = 100
+	config.SetGlobalStorageConfig(cfg)
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	index := NewMockInvertedIndex(ctrl)
+	db.(*indexDatabase).index = index
+	limits := models.NewDefaultLimits()
+	tagsHash := uint64(0)
+	createSeries := func(count int) {
+		for i := 0; i < count; i++ {
+			_, isCreated, err0 := db.GetOrCreateSeriesID("ns", "cpu", 1, tagsHash, limits)
+			assert.NoError(t, err0)
+			assert.True(t, isCreated)
+			tagsHash++
+		}
+	}
+	createSeries(99)
+	assert.False(t, db.NeedFlush())
+	// exist series not counted
+	_, isCreated, err := db.GetOrCreateSeriesID("ns", "cpu", 1, 0, limits)
+	assert.NoError(t, err)
+	assert.False(t, isCreated)
+	assert.False(t, db.NeedFlush())
+	// too many new series, flush early
+	createSeries(1)
+	assert.True(t, db.NeedFlush())
+	index.EXPECT().Flush().Return(nil)
+	assert.NoError(t, db.Flush())
+	assert.False(t, db.NeedFlush())
+
+	index.EXPECT().Flush().Return(nil)
+	assert.NoError(t, db.Close())
+}
@@ -56,6 +56,9 @@ type IndexDatabase interface {
 	// BuildInvertIndex builds the inverted index for tag value => series ids,
 	// the tags is considered as an empty key-value pair while tags is nil.
 	BuildInvertIndex(namespace, metricName string, tagIterator *metric.KeyValueIterator, seriesID uint32, limits *models.Limits)
+	// NeedFlush checks if num. of series created since last flush exceeds the threshold(max-pending-series),
+	// index need to flush early, not waiting for data flush.
+	NeedFlush() bool
 	// Flush flushes index data to disk
 	Flush() error
 }
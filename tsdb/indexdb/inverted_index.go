@@ -27,6 +27,7 @@ import (
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/metrics"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/series/metric"
 	"github.com/lindb/lindb/series/tag"
@@ -69,15 +70,20 @@ type invertedIndex struct {
 	mutable   *TagIndexStore
 	immutable *TagIndexStore
 
+	statistics *metrics.IndexDBStatistics
+
 	rwMutex sync.RWMutex
 }
 
-func newInvertedIndex(metadata metadb.Metadata, forwardFamily, invertedFamily kv.Family) InvertedIndex {
+func newInvertedIndex(metadata metadb.Metadata, forwardFamily, invertedFamily kv.Family,
+	statistics *metrics.IndexDBStatistics,
+) InvertedIndex {
 	return &invertedIndex{
 		invertedFamily: invertedFamily,
 		forwardFamily:  forwardFamily,
 		metadata:       metadata,
 		mutable:        NewTagIndexStore(),
+		statistics:     statistics,
 	}
 }
 
@@ -168,28 +174,27 @@ func (index *invertedIndex) GetGroupingContext(ctx *flow.ShardExecuteContext) er
 	scannerMap := make(map[tag.KeyID][]flow.GroupingScanner)
 	tagKeyIDs := ctx.StorageExecuteCtx.GroupByTagKeyIDs
 	seriesIDs := ctx.SeriesIDsAfterFiltering
-	finalSeriesIDs := seriesIDs.Clone()
-	defer func() {
-		// maybe filtering some series ids that is result of filtering.
-		// if not found, return empty series ids.
-		ctx.SeriesIDsAfterFiltering = finalSeriesIDs
-	}()
+	// series ids which include at least one of grouping tag keys,
+	// series missing some tag keys group under empty tag value for those tag keys.
+	finalSeriesIDs := roaring.New()
 	for _, tagKeyID := range tagKeyIDs {
 		// get grouping scanners by tag key
 		scanners, err := index.getGroupingScanners(tagKeyID, seriesIDs, snapshot)
 		if err != nil {
 			return err
 		}
-		seriesIDsForCurrentTagKey := roaring.New()
 		for idx := range scanners {
-			seriesIDsForCurrentTagKey.Or(scanners[idx].GetSeriesIDs())
-		}
-		finalSeriesIDs.And(seriesIDsForCurrentTagKey)
-		if finalSeriesIDs.IsEmpty() {
-			return constants.ErrNotFound
+			finalSeriesIDs.Or(scanners[idx].GetSeriesIDs())
 		}
 		scannerMap[tagKeyID] = scanners
 	}
+	finalSeriesIDs.And(seriesIDs)
+	// maybe filtering some series ids that is result of filtering.
+	// if not found, return empty series ids.
+	ctx.SeriesIDsAfterFiltering = finalSeriesIDs
+	if finalSeriesIDs.IsEmpty() {
+		return constants.ErrNotFound
+	}
 
 	// set context for next execution stage of query
 	ctx.GroupingContext = flow.NewGroupContext(tagKeyIDs, scannerMap)
@@ -249,7 +254,8 @@ func (index *invertedIndex) buildInvertIndex(namespace, metricName string,
 
 	for tagIterator.HasNext() {
 		tagKey := string(tagIterator.NextKey())
-		tagValue := string(tagIterator.NextValue())
+		// identical tag values share backing storage in tag value id mapping of metadata
+		tagValue := globalTagValueInterner.intern(tagIterator.NextValue())
 
 		tagKeyID, err := metadataDB.GenTagKeyID(namespace, metricName, tagKey, limits)
 		if err != nil {
@@ -295,10 +301,14 @@ func (index *invertedIndex) Flush() error {
 	if err != nil {
 		return err
 	}
+	// immutable is read-only after switched, new series index built into mutable during flushing
+	tagKeys := index.immutable.Size()
+	tagValues := 0
 	if err := index.immutable.WalkEntry(func(key uint32, value TagIndex) error {
 		if err := value.flush(key, forward, inverted); err != nil {
 			return err
 		}
+		tagValues += value.getValues().Size()
 		return nil
 	}); err != nil {
 		return err
@@ -310,6 +320,8 @@ func (index *invertedIndex) Flush() error {
 	if err := inverted.Close(); err != nil {
 		return err
 	}
+	index.statistics.FlushTagKeys.Add(float64(tagKeys))
+	index.statistics.FlushTagValues.Add(float64(tagValues))
 	// finally, clear immutable
 	index.rwMutex.Lock()
 	index.immutable = nil
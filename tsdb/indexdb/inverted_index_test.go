@@ -18,6 +18,7 @@
 package indexdb
 
 import (
+	"encoding/binary"
 	"fmt"
 	"testing"
 
@@ -26,13 +27,17 @@ import (
 
 	"github.com/lindb/roaring"
 
+	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/metrics"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/metadb"
 	"github.com/lindb/lindb/tsdb/tblstore/tagindex"
 )
@@ -171,10 +176,37 @@ func TestInvertedIndex_GetSeriesIDsForTag(t *testing.T) {
 	snapshot.EXPECT().Close().AnyTimes()
 	family.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
 
-	// case 1: reader get data success
+	// case 1: tag key with multiple values in memory(zone: sh=>1, bj=>2)
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil)
+	seriesIDs, err := index.GetSeriesIDsForTag(2)
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(1, 2), seriesIDs)
+	// case 2: tag key without series
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil)
+	seriesIDs, err = index.GetSeriesIDsForTag(10)
+	assert.NoError(t, err)
+	assert.True(t, seriesIDs.IsEmpty())
+	// case 3: find kv readers failure
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	seriesIDs, err = index.GetSeriesIDsForTag(2)
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+	// case 4: reader get data failure
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{table.NewMockReader(ctrl)}, nil)
+	reader.EXPECT().GetSeriesIDsForTagKeyID(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	seriesIDs, err = index.GetSeriesIDsForTag(2)
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+	// case 5: union of series ids in memory and kv store
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{table.NewMockReader(ctrl)}, nil)
+	reader.EXPECT().GetSeriesIDsForTagKeyID(tag.KeyID(2)).Return(roaring.BitmapOf(2, 3, 5), nil)
+	seriesIDs, err = index.GetSeriesIDsForTag(2)
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(1, 2, 3, 5), seriesIDs)
+	// case 6: reader get data success
 	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{table.NewMockReader(ctrl)}, nil).AnyTimes()
 	reader.EXPECT().GetSeriesIDsForTagKeyID(gomock.Any()).Return(roaring.BitmapOf(1, 2, 3), nil)
-	seriesIDs, err := index.GetSeriesIDsForTag(1)
+	seriesIDs, err = index.GetSeriesIDsForTag(1)
 	assert.NoError(t, err)
 	assert.Equal(t, roaring.BitmapOf(1, 2, 3), seriesIDs)
 }
@@ -246,6 +278,77 @@ func TestInvertedIndex_GetGroupingContext(t *testing.T) {
 	assert.True(t, shardExecuteCtx.SeriesIDsAfterFiltering.IsEmpty())
 }
 
+func TestInvertedIndex_GetGroupingContext_MultiTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	tagMetadata := metadb.NewMockTagMetadata(ctrl)
+	metadata.EXPECT().DatabaseName().Return("test").AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadata.EXPECT().TagMetadata().Return(tagMetadata).AnyTimes()
+	metadataDB.EXPECT().GenTagKeyID(gomock.Any(), gomock.Any(), "host", gomock.Any()).Return(tag.KeyID(1), nil).AnyTimes()
+	metadataDB.EXPECT().GenTagKeyID(gomock.Any(), gomock.Any(), "zone", gomock.Any()).Return(tag.KeyID(2), nil).AnyTimes()
+	tagValueIDs := map[string]uint32{"a": 1, "b": 2, "sh": 1, "bj": 2}
+	tagMetadata.EXPECT().GenTagValueID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ tag.KeyID, tagValue string) (uint32, error) {
+			return tagValueIDs[tagValue], nil
+		}).AnyTimes()
+	index := newInvertedIndex(metadata, nil, nil, metrics.NewIndexDBStatistics("test"))
+	limits := models.NewDefaultLimits()
+	index.buildInvertIndex("ns", "name", mockTagKeyValueIterator(map[string]string{"host": "a", "zone": "sh"}), 1, limits)
+	index.buildInvertIndex("ns", "name", mockTagKeyValueIterator(map[string]string{"host": "a", "zone": "bj"}), 2, limits)
+	index.buildInvertIndex("ns", "name", mockTagKeyValueIterator(map[string]string{"host": "b"}), 3, limits)
+	index.buildInvertIndex("ns", "name", mockTagKeyValueIterator(map[string]string{"host": "a", "zone": "sh"}), 4, limits)
+	family := kv.NewMockFamily(ctrl)
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil).AnyTimes()
+	family.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	index.(*invertedIndex).forwardFamily = family
+
+	storageExecuteCtx := &flow.StorageExecuteContext{
+		GroupByTagKeyIDs:    []tag.KeyID{1, 2},
+		GroupingTagValueIDs: make([]*roaring.Bitmap, 2),
+		DownSamplingSpecs:   aggregation.AggregatorSpecs{aggregation.NewAggregatorSpec("f", field.SumField)},
+		Query:               &stmt.Query{GroupBy: []string{"host", "zone"}},
+	}
+	shardExecuteCtx := &flow.ShardExecuteContext{
+		StorageExecuteCtx:       storageExecuteCtx,
+		SeriesIDsAfterFiltering: roaring.BitmapOf(1, 2, 3, 4, 5),
+	}
+	err := index.GetGroupingContext(shardExecuteCtx)
+	assert.NoError(t, err)
+	// series 3 missing zone keeps, series 5 not found in grouping tags
+	assert.Equal(t, []uint32{1, 2, 3, 4}, shardExecuteCtx.SeriesIDsAfterFiltering.ToArray())
+
+	dataLoadCtx := &flow.DataLoadContext{
+		ShardExecuteCtx:       shardExecuteCtx,
+		SeriesIDHighKey:       0,
+		LowSeriesIDsContainer: shardExecuteCtx.SeriesIDsAfterFiltering.GetContainerAtIndex(0),
+		IsGrouping:            true,
+	}
+	dataLoadCtx.Grouping()
+	shardExecuteCtx.GroupingContext.BuildGroup(dataLoadCtx)
+	tuples := make(map[uint16][]uint32)
+	for seriesID := uint16(1); seriesID <= 4; seriesID++ {
+		aggIdx := dataLoadCtx.GroupingSeriesAggRefs[seriesID-dataLoadCtx.MinSeriesID]
+		key := []byte(dataLoadCtx.GroupingSeriesAgg[aggIdx].Key)
+		tuples[seriesID] = []uint32{binary.LittleEndian.Uint32(key), binary.LittleEndian.Uint32(key[4:])}
+	}
+	assert.Len(t, dataLoadCtx.GroupingSeriesAgg, 3)
+	assert.Equal(t, map[uint16][]uint32{
+		1: {1, 1},
+		2: {1, 2},
+		3: {2, tag.EmptyTagValueID},
+		4: {1, 1},
+	}, tuples)
+	// empty tag value id not collected
+	assert.Equal(t, []uint32{1, 2}, storageExecuteCtx.GroupingTagValueIDs[0].ToArray())
+	assert.Equal(t, []uint32{1, 2}, storageExecuteCtx.GroupingTagValueIDs[1].ToArray())
+}
+
 func TestInvertedIndex_FlushInvertedIndexTo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -268,7 +371,8 @@ func TestInvertedIndex_FlushInvertedIndexTo(t *testing.T) {
 
 	meta := metadb.NewMockMetadata(ctrl)
 	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
-	index := newInvertedIndex(meta, forwardFamily, invertedFamily)
+	statistics := metrics.NewIndexDBStatistics("flush_inverted_index_test")
+	index := newInvertedIndex(meta, forwardFamily, invertedFamily, statistics)
 	// case 1: flush not tiger
 	err := index.Flush()
 	assert.NoError(t, err)
@@ -276,6 +380,10 @@ func TestInvertedIndex_FlushInvertedIndexTo(t *testing.T) {
 	// mock data
 	idx := index.(*invertedIndex)
 	tagIndex := NewMockTagIndex(ctrl)
+	values := NewInvertedStore()
+	values.Put(1, roaring.BitmapOf(1, 2))
+	values.Put(2, roaring.BitmapOf(3))
+	tagIndex.EXPECT().getValues().Return(values).AnyTimes()
 	idx.mutable.Put(5, tagIndex)
 
 	// case 1: flush tag index flush err, immutable cannot set nil
@@ -342,6 +450,12 @@ func TestInvertedIndex_FlushInvertedIndexTo(t *testing.T) {
 	err = index.Flush()
 	assert.NoError(t, err)
 	assert.Nil(t, idx.immutable)
+	assert.Equal(t, float64(1), statistics.FlushTagKeys.Get())
+	assert.Equal(t, float64(2), statistics.FlushTagValues.Get())
+	// case 7: flush empty index is no-op
+	err = index.Flush()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), statistics.FlushTagKeys.Get())
 }
 
 func prepareInvertedIndex(ctrl *gomock.Controller) InvertedIndex {
@@ -358,7 +472,7 @@ func prepareInvertedIndex(ctrl *gomock.Controller) InvertedIndex {
 	tagMetadata.EXPECT().GenTagValueID(tag.KeyID(1), "1.1.1.5").Return(uint32(0), fmt.Errorf("err"))
 	tagMetadata.EXPECT().GenTagValueID(tag.KeyID(2), "sh").Return(uint32(1), nil)
 	tagMetadata.EXPECT().GenTagValueID(tag.KeyID(2), "bj").Return(uint32(2), nil)
-	index := newInvertedIndex(metadata, nil, nil)
+	index := newInvertedIndex(metadata, nil, nil, metrics.NewIndexDBStatistics("test"))
 	limits := models.NewDefaultLimits()
 	index.buildInvertIndex("ns", "name", mockTagKeyValueIterator(map[string]string{
 		"host": "1.1.1.1",
@@ -0,0 +1,94 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package indexdb
+
+import (
+	"sync"
+
+	"github.com/lindb/lindb/metrics"
+)
+
+// defaultTagValueInternerCapacity represents the max num. of tag values kept by interner.
+const defaultTagValueInternerCapacity = 1 << 20
+
+// globalTagValueInterner is shared by all index databases of storage, so that identical tag values
+// under different series/metrics/databases share backing storage.
+var globalTagValueInterner = newTagValueInterner(defaultTagValueInternerCapacity)
+
+// tagValueInterner represents the interner of tag values, identical tag values share one string.
+// Interner is bounded by two generations(hot/cold), hit in cold generation promotes tag value to hot generation,
+// when hot generation is full, cold generation is evicted and hot generation becomes cold.
+type tagValueInterner struct {
+	capacity int // max num. of tag values of each generation
+	hot      map[string]string
+	cold     map[string]string
+
+	statistics *metrics.TagValueInternerStatistics
+
+	lock sync.RWMutex
+}
+
+// newTagValueInterner creates a tag value interner with max num. of tag values.
+func newTagValueInterner(capacity int) *tagValueInterner {
+	capacity /= 2
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &tagValueInterner{
+		capacity:   capacity,
+		hot:        make(map[string]string),
+		cold:       make(map[string]string),
+		statistics: metrics.NewTagValueInternerStatistics(),
+	}
+}
+
+// intern returns the interned string of tag value, allocates string only if tag value not in interner.
+func (i *tagValueInterner) intern(tagValue []byte) string {
+	i.lock.RLock()
+	// map lookup by string(bytes) not allocates
+	value, ok := i.hot[string(tagValue)]
+	i.lock.RUnlock()
+	if ok {
+		i.statistics.Hits.Incr()
+		return value
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	// double check, maybe tag value added by other goroutine
+	if value, ok = i.hot[string(tagValue)]; ok {
+		i.statistics.Hits.Incr()
+		return value
+	}
+	if value, ok = i.cold[string(tagValue)]; ok {
+		i.statistics.Hits.Incr()
+	} else {
+		value = string(tagValue)
+		i.statistics.Misses.Incr()
+	}
+	if len(i.hot) >= i.capacity {
+		// evict cold generation
+		i.statistics.Evicted.Add(float64(len(i.cold)))
+		i.cold = i.hot
+		i.hot = make(map[string]string)
+	}
+	i.hot[value] = value
+	i.statistics.Size.Update(float64(len(i.hot) + len(i.cold)))
+	return value
+}
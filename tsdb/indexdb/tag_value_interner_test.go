@@ -0,0 +1,130 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package indexdb
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stringData returns the pointer of backing storage of string.
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestTagValueInterner_Intern(t *testing.T) {
+	interner := newTagValueInterner(100)
+	buf := []byte("value-1")
+	v1 := interner.intern(buf)
+	assert.Equal(t, "value-1", v1)
+	// interned string not aliases input bytes
+	buf[0] = 'x'
+	assert.Equal(t, "value-1", v1)
+
+	// identical tag values share backing storage
+	v2 := interner.intern([]byte("value-1"))
+	assert.Equal(t, v1, v2)
+	assert.Equal(t, stringData(v1), stringData(v2))
+
+	// distinct tag values not aliased
+	v3 := interner.intern([]byte("value-2"))
+	assert.Equal(t, "value-2", v3)
+	assert.NotEqual(t, stringData(v1), stringData(v3))
+	v4 := interner.intern([]byte("value-"))
+	assert.Equal(t, "value-", v4)
+	v5 := interner.intern([]byte(""))
+	assert.Equal(t, "", v5)
+	assert.Equal(t, "value-1", interner.intern([]byte("value-1")))
+}
+
+func TestTagValueInterner_Evict(t *testing.T) {
+	interner := newTagValueInterner(4)
+	hot := interner.intern([]byte("hot"))
+	cold := interner.intern([]byte("cold"))
+	// hot generation full, becomes cold generation
+	interner.intern([]byte("a"))
+	assert.Len(t, interner.hot, 1)
+	assert.Len(t, interner.cold, 2)
+	// hit in cold generation, promotes to hot generation
+	assert.Equal(t, stringData(hot), stringData(interner.intern([]byte("hot"))))
+	assert.Len(t, interner.hot, 2)
+	// evict cold generation
+	interner.intern([]byte("b"))
+	_, ok := interner.cold["hot"]
+	assert.True(t, ok)
+	_, ok = interner.cold["cold"]
+	assert.False(t, ok)
+	assert.Len(t, interner.hot, 1)
+	// evicted tag value allocated again
+	assert.Equal(t, "cold", interner.intern([]byte("cold")))
+	assert.LessOrEqual(t, len(interner.hot)+len(interner.cold), 4)
+	assert.Equal(t, "cold", cold)
+
+	interner = newTagValueInterner(0)
+	assert.Equal(t, "a", interner.intern([]byte("a")))
+	assert.Equal(t, "b", interner.intern([]byte("b")))
+	assert.Equal(t, "a", interner.intern([]byte("a")))
+}
+
+func TestTagValueInterner_Concurrent(t *testing.T) {
+	interner := newTagValueInterner(64)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				value := fmt.Sprintf("value-%d", j%100)
+				assert.Equal(t, value, interner.intern([]byte(value)))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, len(interner.hot)+len(interner.cold), 64)
+}
+
+// BenchmarkTagValueInterner simulates writing many series which share tag values(host/region of cluster).
+func BenchmarkTagValueInterner(b *testing.B) {
+	tagValues := make([][]byte, 1000)
+	for i := range tagValues {
+		tagValues[i] = []byte("host-" + strconv.Itoa(i))
+	}
+	b.Run("no-intern", func(b *testing.B) {
+		b.ReportAllocs()
+		var retained []string
+		for i := 0; i < b.N; i++ {
+			retained = append(retained[:0], string(tagValues[i%len(tagValues)]))
+		}
+		_ = retained
+	})
+	b.Run("intern", func(b *testing.B) {
+		interner := newTagValueInterner(defaultTagValueInternerCapacity)
+		b.ReportAllocs()
+		var retained []string
+		for i := 0; i < b.N; i++ {
+			retained = append(retained[:0], interner.intern(tagValues[i%len(tagValues)]))
+		}
+		_ = retained
+	})
+}
@@ -206,7 +206,7 @@ func (md *memoryDatabase) WriteRow(row *metric.StorageRow) error {
 		writtenLinFieldSize, err := md.writeLinField(
 			row.SlotIndex,
 			row.FieldIDs[fieldIDIdx],
-			simpleFieldItr.NextType(),
+			row.StoredFieldType(fieldIDIdx, simpleFieldItr.NextType()),
 			simpleFieldItr.NextValue(),
 			mStore, tStore,
 		)
@@ -229,7 +229,7 @@ func (md *memoryDatabase) WriteRow(row *metric.StorageRow) error {
 	if compoundFieldItr.Min() > 0 {
 		writtenLinFieldSize, err = md.writeLinField(
 			row.SlotIndex, row.FieldIDs[fieldIDIdx],
-			field.MinField, compoundFieldItr.Min(),
+			row.StoredFieldType(fieldIDIdx, field.MinField), compoundFieldItr.Min(),
 			mStore, tStore)
 		if err != nil {
 			return err
@@ -240,7 +240,7 @@ func (md *memoryDatabase) WriteRow(row *metric.StorageRow) error {
 	if compoundFieldItr.Max() > 0 {
 		writtenLinFieldSize, err = md.writeLinField(
 			row.SlotIndex, row.FieldIDs[fieldIDIdx],
-			field.MaxField, compoundFieldItr.Max(),
+			row.StoredFieldType(fieldIDIdx, field.MaxField), compoundFieldItr.Max(),
 			mStore, tStore)
 		if err != nil {
 			return err
@@ -250,7 +250,7 @@ func (md *memoryDatabase) WriteRow(row *metric.StorageRow) error {
 	// write histogram_sum
 	writtenLinFieldSize, err = md.writeLinField(
 		row.SlotIndex, row.FieldIDs[fieldIDIdx],
-		field.SumField, compoundFieldItr.Sum(),
+		row.StoredFieldType(fieldIDIdx, field.SumField), compoundFieldItr.Sum(),
 		mStore, tStore)
 	if err != nil {
 		return err
@@ -260,7 +260,7 @@ func (md *memoryDatabase) WriteRow(row *metric.StorageRow) error {
 	// write histogram_count
 	writtenLinFieldSize, err = md.writeLinField(
 		row.SlotIndex, row.FieldIDs[fieldIDIdx],
-		field.SumField, compoundFieldItr.Count(),
+		row.StoredFieldType(fieldIDIdx, field.SumField), compoundFieldItr.Count(),
 		mStore, tStore)
 	if err != nil {
 		return err
@@ -273,7 +273,7 @@ func (md *memoryDatabase) WriteRow(row *metric.StorageRow) error {
 	for compoundFieldItr.HasNextBucket() {
 		writtenLinFieldSize, err = md.writeLinField(
 			row.SlotIndex, row.FieldIDs[fieldIDIdx],
-			field.HistogramField, compoundFieldItr.NextValue(),
+			row.StoredFieldType(fieldIDIdx, field.HistogramField), compoundFieldItr.NextValue(),
 			mStore, tStore)
 		if err != nil {
 			return err
@@ -37,10 +37,12 @@ var metaLogger = logger.GetLogger("TSDB", "MetaDB")
 type IDGenerator interface {
 	// GenMetricID generates the metric id in the memory
 	GenMetricID(namespace, metricName string, limits *models.Limits) (metricID metric.ID, err error)
-	// GenFieldID generates the field id in the memory
+	// GenFieldID generates the field id in the memory, returns the field type which data is stored as,
+	// it may differ from given field type if the type is coerced(coerce policy).
 	// error-case1: field type doesn't match to before
 	// error-case2: there are too many fields
-	GenFieldID(namespace, metricName string, fieldName field.Name, fieldType field.Type, limits *models.Limits) (field.ID, error)
+	GenFieldID(namespace, metricName string, fieldName field.Name, fieldType field.Type,
+		limits *models.Limits) (field.ID, field.Type, error)
 	// GenTagKeyID generates the tag key id in the memory
 	GenTagKeyID(namespace, metricName, tagKey string, limits *models.Limits) (tag.KeyID, error)
 }
@@ -21,9 +21,15 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"go.uber.org/atomic"
+
+	"github.com/lindb/common/pkg/logger"
+	"github.com/lindb/common/pkg/timeutil"
 	commonseries "github.com/lindb/common/series"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/metrics"
 	"github.com/lindb/lindb/models"
@@ -36,6 +42,7 @@ import (
 // for testing
 var (
 	createMetadataBackendFn = newMetadataBackend
+	evictCheckInterval      = time.Minute
 )
 
 // metadataDatabase implements the MetadataDatabase interface,
@@ -47,6 +54,7 @@ type metadataDatabase struct {
 	cancel       context.CancelFunc
 	backend      MetadataBackend
 	metrics      map[string]MetricMetadata // metadata cache(key: namespace + delimiter + metric-name, value: metric metadata)
+	accessed     map[string]*atomic.Int64  // last write time of metric metadata cache(key: same as metrics)
 
 	rwMux sync.RWMutex
 
@@ -61,15 +69,18 @@ func NewMetadataDatabase(ctx context.Context, databaseName, parent string) (Meta
 	}
 
 	c, cancel := context.WithCancel(ctx)
-	return &metadataDatabase{
+	mdb := &metadataDatabase{
 		databaseName: databaseName,
 		path:         parent,
 		ctx:          c,
 		cancel:       cancel,
 		backend:      backend,
 		metrics:      make(map[string]MetricMetadata),
+		accessed:     make(map[string]*atomic.Int64),
 		statistics:   metrics.NewMetaDBStatistics(databaseName),
-	}, nil
+	}
+	go mdb.evictTask(config.GlobalStorageConfig().TSDB.MetaCacheTTL.Duration())
+	return mdb, nil
 }
 
 // SuggestNamespace suggests the namespace by namespace's prefix
@@ -150,9 +161,9 @@ func (mdb *metadataDatabase) GetAllHistogramFields(namespace, metricName string)
 	if err != nil {
 		return nil, err
 	}
-	// with format like __bucket_${boundary}
+	// with format like __bucket_${boundary} or __sketch_${index}(exponential histogram)
 	for idx := range fields {
-		if fields[idx].Type == field.HistogramField {
+		if fields[idx].Type == field.HistogramField || fields[idx].Type == field.ExponentialHistogramField {
 			rs = append(rs, fields[idx])
 		}
 	}
@@ -175,75 +186,147 @@ func (mdb *metadataDatabase) GetField(namespace, metricName string, fieldName fi
 // 1) get metric id from memory if existed, if not exist goto 2
 // 2) get metric metadata from backend storage, if not exist need create new metric metadata
 func (mdb *metadataDatabase) GenMetricID(namespace, metricName string, limits *models.Limits) (metricID metric.ID, err error) {
+	key := commonseries.JoinNamespaceMetric(namespace, metricName)
 	// get metric id from memory
-	if metricMetadata, ok := mdb.getMetricMetadataFromCache(namespace, metricName); ok {
+	mdb.rwMux.RLock()
+	metricMetadata, ok := mdb.metrics[key]
+	if ok {
+		mdb.touch(key)
+	}
+	mdb.rwMux.RUnlock()
+	if ok {
 		return metricMetadata.getMetricID(), nil
 	}
-	key := commonseries.JoinNamespaceMetric(namespace, metricName)
 	// assign metric id from memory, add write lock
 	mdb.rwMux.Lock()
 	defer mdb.rwMux.Unlock()
-	// double check with memory
+	metricMetadata, err = mdb.getOrCreateMetricMetadata(namespace, metricName, limits)
+	if err != nil {
+		return metric.EmptyMetricID, err
+	}
+	return metricMetadata.getMetricID(), nil
+}
+
+// getOrCreateMetricMetadata returns the metric metadata for writing, marks it as accessed.
+// If not exist in memory(maybe evicted), gets it from backend storage or creates new metric metadata.
+// NOTICE: must be called with write lock held, so that eviction cannot happen during writing.
+func (mdb *metadataDatabase) getOrCreateMetricMetadata(namespace, metricName string,
+	limits *models.Limits,
+) (MetricMetadata, error) {
+	key := commonseries.JoinNamespaceMetric(namespace, metricName)
 	if metricMetadata, ok := mdb.metrics[key]; ok {
-		return metricMetadata.getMetricID(), nil
+		mdb.touch(key)
+		return metricMetadata, nil
 	}
 	metricMetadata, err := mdb.backend.getOrCreateMetricMetadata(namespace, metricName, limits)
 	if err != nil {
 		mdb.statistics.GenMetricIDFailures.Incr()
-		return
+		return nil, err
 	}
 	mdb.statistics.GenMetricIDs.Incr()
 	mdb.metrics[key] = metricMetadata
+	mdb.accessed[key] = atomic.NewInt64(timeutil.Now())
+	mdb.statistics.CachedMetrics.Update(float64(len(mdb.metrics)))
 
-	return metricMetadata.getMetricID(), nil
+	return metricMetadata, nil
+}
+
+// touch marks the metric metadata as accessed, must be called with lock held.
+func (mdb *metadataDatabase) touch(key string) {
+	if accessed, ok := mdb.accessed[key]; ok {
+		accessed.Store(timeutil.Now())
+	}
 }
 
-// GenFieldID generates the field id in the memory,
-// !!!!! NOTICE: metric metadata must be existed in memory, because gen metric has been saved
+// GenFieldID generates the field id in the memory, returns the field type which data is stored as.
+// NOTICE: reloads metric metadata from backend storage if it has been evicted from memory.
 func (mdb *metadataDatabase) GenFieldID(
 	namespace, metricName string,
 	fieldName field.Name, fieldType field.Type,
 	limits *models.Limits,
-) (fieldID field.ID, err error) {
+) (fieldID field.ID, storedType field.Type, err error) {
 	if fieldType == field.Unknown {
-		return field.EmptyFieldID, series.ErrFieldTypeUnspecified
+		return field.EmptyFieldID, field.Unknown, series.ErrFieldTypeUnspecified
 	}
-	metricMetadata, _ := mdb.getMetricMetadataFromCache(namespace, metricName)
-
 	mdb.rwMux.Lock()
 	defer mdb.rwMux.Unlock()
+
+	metricMetadata, err := mdb.getOrCreateMetricMetadata(namespace, metricName, limits)
+	if err != nil {
+		mdb.statistics.GenFieldIDFailures.Incr()
+		return field.EmptyFieldID, field.Unknown, err
+	}
 	// read from memory metric metadata
 	if f, ok := metricMetadata.getField(fieldName); ok {
 		if f.Type == fieldType {
-			return f.ID, nil
+			return f.ID, f.Type, nil
+		}
+		mdb.statistics.FieldTypeConflicts.Incr()
+		if limits.EnableFieldTypeEvolution() {
+			// field type changed, historical data keeps the old type
+			if fieldID, err = mdb.changeFieldType(namespace, metricName, metricMetadata, fieldName, fieldType); err != nil {
+				return field.EmptyFieldID, field.Unknown, err
+			}
+			return fieldID, fieldType, nil
+		}
+		if limits.EnableFieldTypeCoercion() {
+			// first writer wins, coerces data into the type written first
+			if winner, ok := f.Type.CanMergeWith(fieldType); ok {
+				return f.ID, winner, nil
+			}
 		}
 		mdb.statistics.GenFieldIDFailures.Incr()
-		return field.EmptyFieldID, fmt.Errorf("field name:%s,field type:%s/%s,err:%s", fieldName,
+		return field.EmptyFieldID, field.Unknown, fmt.Errorf("field name:%s,field type:%s/%s,err:%s", fieldName,
 			fieldType.String(), f.Type.String(), series.ErrWrongFieldType)
 	}
 	// assign new field id, then add field into metric metadata
 	fieldMeta, err := metricMetadata.createField(fieldName, fieldType, limits)
 	if err != nil {
 		mdb.statistics.GenFieldIDFailures.Incr()
-		return field.EmptyFieldID, err
+		return field.EmptyFieldID, field.Unknown, err
 	}
 	// TODO need change?
 	err = mdb.backend.saveField(metricMetadata.getMetricID(), fieldMeta)
 	if err != nil {
 		mdb.statistics.GenFieldIDFailures.Incr()
-		return field.EmptyFieldID, err
+		return field.EmptyFieldID, field.Unknown, err
 	}
 	mdb.statistics.GenFieldIDs.Incr()
+	return fieldMeta.ID, fieldType, nil
+}
+
+// changeFieldType changes the field type from now on, then saves the type change into backend storage.
+func (mdb *metadataDatabase) changeFieldType(
+	namespace, metricName string,
+	metricMetadata MetricMetadata,
+	fieldName field.Name, fieldType field.Type,
+) (field.ID, error) {
+	fieldMeta, _ := metricMetadata.changeFieldType(fieldName, fieldType, timeutil.Now())
+	if err := mdb.backend.saveField(metricMetadata.getMetricID(), fieldMeta); err != nil {
+		mdb.statistics.GenFieldIDFailures.Incr()
+		return field.EmptyFieldID, err
+	}
+	mdb.statistics.FieldTypeChanges.Incr()
+	metaLogger.Info("field type changed",
+		logger.String("db", mdb.databaseName),
+		logger.String("namespace", namespace),
+		logger.String("metric", metricName),
+		logger.String("field", string(fieldName)),
+		logger.String("type", fieldType.String()))
 	return fieldMeta.ID, nil
 }
 
 // GenTagKeyID generates the tag key id in the memory
-// !!!!! NOTICE: metric metadata must be existed in memory, because gen metric has been saved
+// NOTICE: reloads metric metadata from backend storage if it has been evicted from memory.
 func (mdb *metadataDatabase) GenTagKeyID(namespace, metricName, tagKey string, limits *models.Limits) (tagKeyID tag.KeyID, err error) {
-	metricMetadata, _ := mdb.getMetricMetadataFromCache(namespace, metricName)
-
 	mdb.rwMux.Lock()
 	defer mdb.rwMux.Unlock()
+
+	metricMetadata, err := mdb.getOrCreateMetricMetadata(namespace, metricName, limits)
+	if err != nil {
+		mdb.statistics.GenTagKeyIDFailures.Incr()
+		return tag.EmptyTagKeyID, err
+	}
 	// read from memory metric metadata
 	if tagKeyID0, ok := metricMetadata.getTagKeyID(tagKey); ok {
 		return tagKeyID0, nil
@@ -282,6 +365,44 @@ func (mdb *metadataDatabase) Close() error {
 	return mdb.backend.Close()
 }
 
+// evictTask evicts the metric metadata which is not written for a long time periodically.
+func (mdb *metadataDatabase) evictTask(ttl time.Duration) {
+	ticker := time.NewTicker(evictCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-mdb.ctx.Done():
+			return
+		case <-ticker.C:
+			mdb.evict(timeutil.Now(), ttl.Milliseconds())
+		}
+	}
+}
+
+// evict removes the metric metadata which is not written within ttl from memory cache,
+// the metric metadata will be reloaded from backend storage on next write.
+func (mdb *metadataDatabase) evict(now, ttl int64) {
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	for key := range mdb.metrics {
+		accessed, ok := mdb.accessed[key]
+		if !ok {
+			// not tracked yet, starts tracking from now
+			mdb.accessed[key] = atomic.NewInt64(now)
+			continue
+		}
+		if now-accessed.Load() < ttl {
+			continue
+		}
+		delete(mdb.metrics, key)
+		delete(mdb.accessed, key)
+		mdb.statistics.EvictedMetrics.Incr()
+	}
+	mdb.statistics.CachedMetrics.Update(float64(len(mdb.metrics)))
+}
+
 // getMetricMetadataFromCache gets metric metadata from memory cache.
 func (mdb *metadataDatabase) getMetricMetadataFromCache(namespace, metricName string) (MetricMetadata, bool) {
 	key := commonseries.JoinNamespaceMetric(namespace, metricName)
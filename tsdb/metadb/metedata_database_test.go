@@ -21,10 +21,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/common/pkg/timeutil"
 	commonseries "github.com/lindb/common/series"
 
 	"github.com/lindb/lindb/constants"
@@ -452,6 +454,7 @@ func TestMetadataDatabase_GetAllHistogramFields(t *testing.T) {
 	fields := field.Metas{
 		{ID: 1, Type: field.SumField, Name: "sum"},
 		{ID: 2, Type: field.HistogramField, Name: "histogram"},
+		{ID: 3, Type: field.ExponentialHistogramField, Name: "__sketch_1"},
 	}
 	db2 := db.(*metadataDatabase)
 	db2.rwMux.Lock()
@@ -488,7 +491,10 @@ func TestMetadataDatabase_GetAllHistogramFields(t *testing.T) {
 			out: struct {
 				f   field.Metas
 				err error
-			}{f: field.Metas{{ID: 2, Type: field.HistogramField, Name: "histogram"}}, err: nil},
+			}{f: field.Metas{
+				{ID: 2, Type: field.HistogramField, Name: "histogram"},
+				{ID: 3, Type: field.ExponentialHistogramField, Name: "__sketch_1"},
+			}, err: nil},
 		},
 	}
 
@@ -601,9 +607,11 @@ func TestMetadataDatabase_GenFieldID(t *testing.T) {
 		name       string
 		metricName string
 		f          field.Meta
+		limits     *models.Limits
 		prepare    func()
 		out        struct {
 			id  field.ID
+			typ field.Type
 			err error
 		}
 	}{
@@ -612,6 +620,7 @@ func TestMetadataDatabase_GenFieldID(t *testing.T) {
 			f:    field.Meta{},
 			out: struct {
 				id  field.ID
+				typ field.Type
 				err error
 			}{id: field.EmptyFieldID, err: series.ErrFieldTypeUnspecified},
 		},
@@ -624,10 +633,90 @@ func TestMetadataDatabase_GenFieldID(t *testing.T) {
 			},
 			out: struct {
 				id  field.ID
+				typ field.Type
 				err error
 			}{id: field.EmptyFieldID, err: fmt.Errorf("field name:%s,field type:%s/%s,err:%s", "sum",
 				field.MaxField.String(), field.SumField.String(), series.ErrWrongFieldType)},
 		},
+		{
+			name:       "change field type, save into backend storage failure",
+			metricName: "cache",
+			f:          field.Meta{Name: "sum", Type: field.LastField},
+			limits:     &models.Limits{FieldTypeEvolution: models.FieldTypeEvolutionPerSegment},
+			prepare: func() {
+				meta.EXPECT().getField(field.Name("sum")).Return(field.Meta{Type: field.SumField, ID: 3}, true)
+				meta.EXPECT().changeFieldType(field.Name("sum"), field.LastField, gomock.Any()).
+					Return(field.Meta{Type: field.LastField, ID: 3}, true)
+				meta.EXPECT().getMetricID().Return(metric.ID(3))
+				mockBackend.EXPECT().saveField(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+			},
+			out: struct {
+				id  field.ID
+				typ field.Type
+				err error
+			}{id: field.EmptyFieldID, err: fmt.Errorf("err")},
+		},
+		{
+			name:       "change field type successfully",
+			metricName: "cache",
+			f:          field.Meta{Name: "sum", Type: field.LastField},
+			limits:     &models.Limits{FieldTypeEvolution: models.FieldTypeEvolutionPerSegment},
+			prepare: func() {
+				meta.EXPECT().getField(field.Name("sum")).Return(field.Meta{Type: field.SumField, ID: 3}, true)
+				meta.EXPECT().changeFieldType(field.Name("sum"), field.LastField, gomock.Any()).
+					Return(field.Meta{Type: field.LastField, ID: 3}, true)
+				meta.EXPECT().getMetricID().Return(metric.ID(3))
+				mockBackend.EXPECT().saveField(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			out: struct {
+				id  field.ID
+				typ field.Type
+				err error
+			}{id: field.ID(3), typ: field.LastField, err: nil},
+		},
+		{
+			name:       "coerce field type, sum vs last rejected",
+			metricName: "cache",
+			f:          field.Meta{Name: "sum", Type: field.LastField},
+			limits:     &models.Limits{FieldTypeEvolution: models.FieldTypeEvolutionCoerce},
+			prepare: func() {
+				meta.EXPECT().getField(field.Name("sum")).Return(field.Meta{Type: field.SumField, ID: 3}, true)
+			},
+			out: struct {
+				id  field.ID
+				typ field.Type
+				err error
+			}{id: field.EmptyFieldID, err: fmt.Errorf("field name:%s,field type:%s/%s,err:%s", "sum",
+				field.LastField.String(), field.SumField.String(), series.ErrWrongFieldType)},
+		},
+		{
+			name:       "coerce field type, min vs max",
+			metricName: "cache",
+			f:          field.Meta{Name: "min", Type: field.MaxField},
+			limits:     &models.Limits{FieldTypeEvolution: models.FieldTypeEvolutionCoerce},
+			prepare: func() {
+				meta.EXPECT().getField(field.Name("min")).Return(field.Meta{Type: field.MinField, ID: 4}, true)
+			},
+			out: struct {
+				id  field.ID
+				typ field.Type
+				err error
+			}{id: field.ID(4), typ: field.MinField, err: nil},
+		},
+		{
+			name:       "coerce field type, histogram vs sum",
+			metricName: "cache",
+			f:          field.Meta{Name: "__bucket_1", Type: field.SumField},
+			limits:     &models.Limits{FieldTypeEvolution: models.FieldTypeEvolutionCoerce},
+			prepare: func() {
+				meta.EXPECT().getField(field.Name("__bucket_1")).Return(field.Meta{Type: field.HistogramField, ID: 5}, true)
+			},
+			out: struct {
+				id  field.ID
+				typ field.Type
+				err error
+			}{id: field.ID(5), typ: field.HistogramField, err: nil},
+		},
 		{
 			name:       "get field from memory cache",
 			metricName: "cache",
@@ -637,8 +726,9 @@ func TestMetadataDatabase_GenFieldID(t *testing.T) {
 			},
 			out: struct {
 				id  field.ID
+				typ field.Type
 				err error
-			}{id: field.ID(3), err: nil},
+			}{id: field.ID(3), typ: field.SumField, err: nil},
 		},
 		{
 			name:       "create field failure",
@@ -650,6 +740,7 @@ func TestMetadataDatabase_GenFieldID(t *testing.T) {
 			},
 			out: struct {
 				id  field.ID
+				typ field.Type
 				err error
 			}{id: field.EmptyFieldID, err: fmt.Errorf("err")},
 		},
@@ -665,6 +756,7 @@ func TestMetadataDatabase_GenFieldID(t *testing.T) {
 			},
 			out: struct {
 				id  field.ID
+				typ field.Type
 				err error
 			}{id: field.EmptyFieldID, err: fmt.Errorf("err")},
 		},
@@ -680,8 +772,9 @@ func TestMetadataDatabase_GenFieldID(t *testing.T) {
 			},
 			out: struct {
 				id  field.ID
+				typ field.Type
 				err error
-			}{id: field.ID(3), err: nil},
+			}{id: field.ID(3), typ: field.SumField, err: nil},
 		},
 	}
 
@@ -692,8 +785,13 @@ func TestMetadataDatabase_GenFieldID(t *testing.T) {
 				tt.prepare()
 			}
 
-			id, err := db.GenFieldID("ns-1", tt.metricName, tt.f.Name, tt.f.Type, models.NewDefaultLimits())
+			limits := tt.limits
+			if limits == nil {
+				limits = models.NewDefaultLimits()
+			}
+			id, typ, err := db.GenFieldID("ns-1", tt.metricName, tt.f.Name, tt.f.Type, limits)
 			assert.Equal(t, tt.out.id, id)
+			assert.Equal(t, tt.out.typ, typ)
 			assert.Equal(t, tt.out.err, err)
 		})
 	}
@@ -810,6 +908,78 @@ func TestMetadataDatabase_Close_Sync(t *testing.T) {
 	assert.NoError(t, db.Sync())
 }
 
+func TestMetadataDatabase_Evict(t *testing.T) {
+	db := newMockMetadataDatabase(t, t.TempDir())
+	defer func() {
+		assert.NoError(t, db.Close())
+	}()
+	db2 := db.(*metadataDatabase)
+	limits := models.NewDefaultLimits()
+
+	metricID, err := db.GenMetricID("ns-1", "name1", limits)
+	assert.NoError(t, err)
+	fieldID, _, err := db.GenFieldID("ns-1", "name1", "f1", field.SumField, limits)
+	assert.NoError(t, err)
+	tagKeyID, err := db.GenTagKeyID("ns-1", "name1", "host", limits)
+	assert.NoError(t, err)
+	_, err = db.GenMetricID("ns-1", "name2", limits)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), db2.statistics.CachedMetrics.Get())
+
+	// metric written recently, keep in cache
+	db2.evict(timeutil.Now(), time.Hour.Milliseconds())
+	assert.Len(t, db2.metrics, 2)
+
+	// metric not tracked yet, start tracking
+	key := commonseries.JoinNamespaceMetric("ns-1", "name2")
+	db2.rwMux.Lock()
+	delete(db2.accessed, key)
+	db2.rwMux.Unlock()
+	db2.evict(timeutil.Now()+time.Hour.Milliseconds(), time.Hour.Milliseconds())
+	assert.Len(t, db2.metrics, 1)
+	assert.Contains(t, db2.metrics, key)
+	assert.Equal(t, float64(1), db2.statistics.CachedMetrics.Get())
+
+	// re-create evicted metric metadata on next write, ids not changed
+	fieldID2, _, err := db.GenFieldID("ns-1", "name1", "f1", field.SumField, limits)
+	assert.NoError(t, err)
+	assert.Equal(t, fieldID, fieldID2)
+	fieldID2, _, err = db.GenFieldID("ns-1", "name1", "f2", field.SumField, limits)
+	assert.NoError(t, err)
+	assert.NotEqual(t, fieldID, fieldID2)
+	assert.Len(t, db2.metrics, 2)
+	db2.evict(timeutil.Now()+time.Hour.Milliseconds(), time.Hour.Milliseconds())
+	tagKeyID2, err := db.GenTagKeyID("ns-1", "name1", "host", limits)
+	assert.NoError(t, err)
+	assert.Equal(t, tagKeyID, tagKeyID2)
+	db2.evict(timeutil.Now()+time.Hour.Milliseconds(), time.Hour.Milliseconds())
+	metricID2, err := db.GenMetricID("ns-1", "name1", limits)
+	assert.NoError(t, err)
+	assert.Equal(t, metricID, metricID2)
+	fields, err := db.GetAllFields("ns-1", "name1")
+	assert.NoError(t, err)
+	assert.Len(t, fields, 2)
+}
+
+func TestMetadataDatabase_EvictTask(t *testing.T) {
+	defer func() {
+		evictCheckInterval = time.Minute
+	}()
+	evictCheckInterval = time.Millisecond
+	db := newMockMetadataDatabase(t, t.TempDir())
+	db2 := db.(*metadataDatabase)
+	_, err := db.GenMetricID("ns-1", "name1", models.NewDefaultLimits())
+	assert.NoError(t, err)
+
+	go db2.evictTask(time.Millisecond)
+	assert.Eventually(t, func() bool {
+		db2.rwMux.RLock()
+		defer db2.rwMux.RUnlock()
+		return len(db2.metrics) == 0
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, db.Close())
+}
+
 func newMockMetadataDatabase(t *testing.T, dir string) MetadataDatabase {
 	db, err := NewMetadataDatabase(context.TODO(), "test", dir)
 	assert.NoError(t, err)
@@ -38,6 +38,8 @@ type MetricMetadata interface {
 	getMetricID() metric.ID
 	// createField creates the field meta, if success return field id, else return series.ErrTooManyFields
 	createField(fieldName field.Name, fieldType field.Type, limits *models.Limits) (field.Meta, error)
+	// changeFieldType changes the field type since given timestamp, if field not exist return false
+	changeFieldType(fieldName field.Name, fieldType field.Type, timestamp int64) (field.Meta, bool)
 	// getField gets the field meta by field name, if not exist return false
 	getField(fieldName field.Name) (field.Meta, bool)
 	// getAllFields returns the all fields of the metric
@@ -98,6 +100,17 @@ func (mm *metricMetadata) createField(fieldName field.Name, fieldType field.Type
 	return fieldMeta, nil
 }
 
+// changeFieldType changes the field type since given timestamp, if field not exist return false
+func (mm *metricMetadata) changeFieldType(fieldName field.Name, fieldType field.Type, timestamp int64) (field.Meta, bool) {
+	for idx := range mm.fields {
+		if mm.fields[idx].Name == fieldName {
+			mm.fields[idx] = mm.fields[idx].ChangeType(fieldType, timestamp)
+			return mm.fields[idx], true
+		}
+	}
+	return field.Meta{}, false
+}
+
 // getField gets the field meta by field name, if not exist return false
 func (mm *metricMetadata) getField(fieldName field.Name) (field.Meta, bool) {
 	return mm.fields.Find(fieldName)
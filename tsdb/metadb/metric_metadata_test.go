@@ -137,6 +137,23 @@ func TestMetricMetadata_getField(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestMetricMetadata_changeFieldType(t *testing.T) {
+	m := newMetricMetadata(metric.ID(2))
+	m.initialize(field.Metas{{ID: field.ID(1), Type: field.SumField, Name: "f"}}, 1, nil)
+	f, ok := m.changeFieldType("f", field.LastField, 100)
+	assert.True(t, ok)
+	assert.Equal(t, field.LastField, f.Type)
+	assert.Equal(t, []field.TypeChange{{Type: field.SumField, Until: 100}}, f.History)
+	f, ok = m.getField("f")
+	assert.True(t, ok)
+	assert.Equal(t, field.LastField, f.Type)
+	assert.Equal(t, field.SumField, f.TypeAt(99))
+
+	f, ok = m.changeFieldType("not-exist", field.LastField, 100)
+	assert.False(t, ok)
+	assert.Equal(t, field.Meta{}, f)
+}
+
 func TestMetricMetadata_createTagKey(t *testing.T) {
 	m := newMetricMetadata(metric.ID(2))
 	assert.Empty(t, m.getAllTagKeys())
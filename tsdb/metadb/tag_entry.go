@@ -18,7 +18,6 @@
 package metadb
 
 import (
-	"regexp"
 	"strings"
 
 	"go.uber.org/atomic"
@@ -135,60 +134,39 @@ func (t *tagEntry) findSeriesIDsByIn(expr *stmt.InExpr) *roaring.Bitmap {
 
 // findSeriesIDsByLike finds tag values ids by tag value - like
 // case 1: value is empty, return nil
-// case 2: value is "*", return all tag value ids
-// case 3: value is "*xxx*", do contains
-// case 4: value is "*xxx", do suffix
-// case 5: value is "xxx*", do prefix
-// case 6: value is "xxx", do equal
+// case 2: value has no wildcard, do equal
+// case 3: value is "xxx%", do prefix
+// case 4: others, do pattern matching on tag values with literal prefix
 func (t *tagEntry) findSeriesIDsByLike(expr *stmt.LikeExpr) *roaring.Bitmap {
-	likeTo := expr.Value
-	length := len(likeTo)
-	if length == 0 {
+	if len(expr.Value) == 0 {
 		return nil
 	}
-	if likeTo == "*" {
-		return t.getTagValueIDs()
+	pattern := expr.Pattern()
+	if pattern.IsExact() {
+		// like == equal
+		return t.findSeriesIDsByEqual(pattern.Prefix())
 	}
+	prefix := pattern.Prefix()
 	result := roaring.New()
-	prefix := strings.HasPrefix(likeTo, "*")
-	suffix := strings.HasSuffix(likeTo, "*")
-	switch {
-	case prefix && suffix:
-		like := likeTo[1 : length-1]
-		for value, tagValueID := range t.tagValues {
-			if strings.Contains(value, like) {
-				result.Add(tagValueID)
-			}
-		}
-	case prefix:
-		like := likeTo[1:]
-		for value, tagValueID := range t.tagValues {
-			if strings.HasSuffix(value, like) {
-				result.Add(tagValueID)
-			}
+	for value, tagValueID := range t.tagValues {
+		if !strings.HasPrefix(value, prefix) {
+			continue
 		}
-	case suffix:
-		like := likeTo[:length-1]
-		for value, tagValueID := range t.tagValues {
-			if strings.HasPrefix(value, like) {
-				result.Add(tagValueID)
-			}
+		if pattern.Match(value) {
+			result.Add(tagValueID)
 		}
-	default:
-		// like == equal
-		return t.findSeriesIDsByEqual(likeTo)
 	}
 	return result
 }
 
 // findSeriesIDsByRegex finds tag value ids by tag value - regex
 func (t *tagEntry) findSeriesIDsByRegex(expr *stmt.RegexExpr) *roaring.Bitmap {
-	pattern, err := regexp.Compile(expr.Regexp)
+	pattern, err := expr.Pattern()
 	if err != nil {
 		return nil
 	}
-	// the regex pattern is regarded as a prefix string + pattern
-	literalPrefix, _ := pattern.LiteralPrefix()
+	// only the tag values with literal prefix can match if pattern is anchored
+	literalPrefix := expr.LiteralPrefix(pattern)
 	result := roaring.New()
 	for value, tagValueID := range t.tagValues {
 		if !strings.HasPrefix(value, literalPrefix) {
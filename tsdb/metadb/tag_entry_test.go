@@ -77,6 +77,16 @@ func TestTagEntry_findSeriesIDsByLike(t *testing.T) {
 	assert.Equal(t, roaring.BitmapOf(8), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: "*cd"}))
 	// tag-value is "b*"
 	assert.Equal(t, roaring.BitmapOf(3, 5, 6, 7, 8), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: "b*"}))
+	// sql wildcards: prefix, suffix, middle, single char
+	assert.Equal(t, roaring.BitmapOf(3, 5, 6, 7, 8), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: "b%"}))
+	assert.Equal(t, roaring.BitmapOf(2, 5), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: "%bc"}))
+	assert.Equal(t, roaring.BitmapOf(2, 5, 8), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: "%bc%"}))
+	assert.Equal(t, roaring.BitmapOf(6, 7), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: "b2_"}))
+	assert.Equal(t, roaring.BitmapOf(8), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: "b%d"}))
+	// escaped literal
+	assert.Equal(t, roaring.BitmapOf(5), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: `b\c`}))
+	assert.Nil(t, tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: `b2\_`}))
+	assert.Equal(t, roaring.New(), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: `b2\_%`}))
 }
 
 func TestTagEntry_findSeriesIDsByIn(t *testing.T) {
@@ -103,8 +113,11 @@ func TestTagEntry_findSeriesIDsByRegex(t *testing.T) {
 	assert.Nil(t, tagIndex.findSeriesIDsByExpr(&stmt.RegexExpr{Key: "host", Regexp: "b.32*++++\n"}))
 	// tag-value exist
 	assert.Equal(t, roaring.BitmapOf(6, 7), tagIndex.findSeriesIDsByExpr(&stmt.RegexExpr{Key: "host", Regexp: `b2[0-9]+`}))
-	// literal prefix:22 not exist
-	assert.Equal(t, roaring.New(), tagIndex.findSeriesIDsByExpr(&stmt.RegexExpr{Key: "host", Regexp: `22+`}))
+	// unanchored pattern matches substring of tag value
+	assert.Equal(t, roaring.BitmapOf(7), tagIndex.findSeriesIDsByExpr(&stmt.RegexExpr{Key: "host", Regexp: `22+`}))
+	// anchored pattern, literal prefix:22 not exist
+	assert.Equal(t, roaring.New(), tagIndex.findSeriesIDsByExpr(&stmt.RegexExpr{Key: "host", Regexp: `^22+`}))
+	assert.Equal(t, roaring.BitmapOf(6, 7), tagIndex.findSeriesIDsByExpr(&stmt.RegexExpr{Key: "host", Regexp: `^b2[0-9]+$`}))
 }
 
 func TestTagEntry_collectTagValues(t *testing.T) {
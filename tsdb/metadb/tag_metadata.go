@@ -27,6 +27,7 @@ import (
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/metrics"
+	"github.com/lindb/lindb/pkg/sketch"
 	"github.com/lindb/lindb/pkg/strutil"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql/stmt"
@@ -58,6 +59,9 @@ type TagMetadata interface {
 		tagValueIDs *roaring.Bitmap,
 		tagValues map[uint32]string,
 	) error
+	// TagCardinality returns the distinct counter of tag values for spec tag key,
+	// counter is built from all tag values when first queried, then maintained when new tag value assigned.
+	TagCardinality(tagKeyID tag.KeyID) (*sketch.Distinct, error)
 	// Flush flushes the memory tag metadata into kv store
 	Flush() error
 }
@@ -69,6 +73,9 @@ type tagMetadata struct {
 	mutable      *TagStore // mutable store current writeable memory store
 	immutable    *TagStore // immutable need to flush into kv store
 
+	cardinality map[tag.KeyID]*sketch.Distinct // distinct counter of tag values under tag key
+	flushSeq    uint64                         // increases when immutable flushed into kv store
+
 	rwMutex sync.RWMutex
 
 	statistics *metrics.TagMetaStatistics
@@ -80,6 +87,7 @@ func NewTagMetadata(databaseName string, family kv.Family) TagMetadata {
 		databaseName: databaseName,
 		family:       family,
 		mutable:      NewTagStore(),
+		cardinality:  make(map[tag.KeyID]*sketch.Distinct),
 		statistics:   metrics.NewTagMetaStatistics(databaseName),
 	}
 }
@@ -150,15 +158,22 @@ func (m *tagMetadata) GenTagValueID(tagKeyID tag.KeyID, tagValue string) (tagVal
 	// assign new id
 	tagValueID = tagEntry.genTagValueID()
 	tagEntry.addTagValue(tagValue, tagValueID)
+	if counter, ok := m.cardinality[tagKeyID]; ok {
+		counter.Add(tagValue)
+	}
 
 	m.statistics.GenTagValueIDs.Incr()
 
 	return tagValueID, nil
 }
 
-// SuggestTagValues returns suggestions from given tag key id and prefix of tag value
+// SuggestTagValues returns suggestions from given tag key id and prefix of tag value,
+// the result is sorted and capped at limit, returns empty if tag key not exist.
 func (m *tagMetadata) SuggestTagValues(tagKeyID tag.KeyID, tagValuePrefix string, limit int) []string {
 	result := make([]string, 0)
+	if limit <= 0 {
+		return result
+	}
 	m.loadTagValueIDsInMem(tagKeyID, func(tagEntry TagEntry) {
 		for value := range tagEntry.getTagValues() {
 			if strings.HasPrefix(value, tagValuePrefix) {
@@ -172,17 +187,15 @@ func (m *tagMetadata) SuggestTagValues(tagKeyID tag.KeyID, tagValuePrefix string
 
 	readers, err := snapshot.FindReaders(uint32(tagKeyID))
 	if err != nil {
-		// find table.Reader err, return it
-		return nil
+		// find table.Reader err, return empty
+		return result[:0]
 	}
-	var reader tagkeymeta.Reader
 	if len(readers) > 0 {
-		// found tag data in kv store, try load tag value data
-		reader = newTagReaderFunc(readers)
-		readerValues := reader.SuggestTagValues(tagKeyID, tagValuePrefix, limit)
-		result = append(result, readerValues...)
+		// found tag data in kv store, values are iterated by prefix, so only need load limit values
+		reader := newTagReaderFunc(readers)
+		result = append(result, reader.SuggestTagValues(tagKeyID, tagValuePrefix, limit)...)
 	}
-	return result
+	return strutil.SortedDeDupStringSlice(result, limit)
 }
 
 // FindTagValueDsByExpr finds tag value ids by tag filter expr for spec tag key,
@@ -261,6 +274,67 @@ func (m *tagMetadata) CollectTagValues(tagKeyID tag.KeyID,
 	return nil
 }
 
+// TagCardinality returns the distinct counter of tag values for spec tag key,
+// counter is built from all tag values when first queried, then maintained when new tag value assigned.
+// Returns a copy of counter, so caller can merge it with others.
+func (m *tagMetadata) TagCardinality(tagKeyID tag.KeyID) (*sketch.Distinct, error) {
+	for {
+		m.rwMutex.RLock()
+		if counter, ok := m.cardinality[tagKeyID]; ok {
+			rs, err := copyDistinct(counter)
+			m.rwMutex.RUnlock()
+			return rs, err
+		}
+		flushSeq := m.flushSeq
+		m.rwMutex.RUnlock()
+
+		counter, err := m.buildTagCardinality(tagKeyID, flushSeq)
+		if err != nil {
+			return nil, err
+		}
+		if counter != nil {
+			return counter, nil
+		}
+		// memory tag values flushed into kv store when building, maybe missing some values, try again
+	}
+}
+
+// buildTagCardinality builds the distinct counter of tag values for spec tag key,
+// returns nil if memory tag values flushed(flush sequence changed) when building.
+func (m *tagMetadata) buildTagCardinality(tagKeyID tag.KeyID, flushSeq uint64) (*sketch.Distinct, error) {
+	counter, err := sketch.NewDistinct(0)
+	if err != nil {
+		return nil, err
+	}
+	// collect tag values in kv store without lock
+	if err := m.loadTagValueIDsInKV(tagKeyID, func(reader tagkeymeta.Reader) error {
+		return reader.WalkTagValues(tagKeyID, "", func(tagValue []byte, _ uint32) bool {
+			counter.Add(string(tagValue))
+			return true
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	m.rwMutex.Lock()
+	defer m.rwMutex.Unlock()
+
+	if cached, ok := m.cardinality[tagKeyID]; ok {
+		return copyDistinct(cached)
+	}
+	if flushSeq != m.flushSeq {
+		return nil, nil
+	}
+	// collect tag values in memory, new tag value will be added into counter when assigned
+	m.loadTagValueIDsInMemWithoutLock(tagKeyID, func(tagEntry TagEntry) {
+		for tagValue := range tagEntry.getTagValues() {
+			counter.Add(tagValue)
+		}
+	})
+	m.cardinality[tagKeyID] = counter
+	return copyDistinct(counter)
+}
+
 // Flush flushes the memory tag metadata into kv store
 func (m *tagMetadata) Flush() error {
 	if !m.checkFlush() {
@@ -293,6 +367,7 @@ func (m *tagMetadata) Flush() error {
 	// finally, clear immutable
 	m.rwMutex.Lock()
 	m.immutable = nil
+	m.flushSeq++
 	m.rwMutex.Unlock()
 	return nil
 }
@@ -338,6 +413,14 @@ func (m *tagMetadata) loadTagValueIDsInKV(tagKeyID tag.KeyID, fn func(reader tag
 
 // loadTagValueIDsInMem loads tag value ids from mutable/immutable store
 func (m *tagMetadata) loadTagValueIDsInMem(tagKeyID tag.KeyID, fn func(tagEntry TagEntry)) {
+	m.rwMutex.RLock()
+	defer m.rwMutex.RUnlock()
+
+	m.loadTagValueIDsInMemWithoutLock(tagKeyID, fn)
+}
+
+// loadTagValueIDsInMemWithoutLock loads tag value ids from mutable/immutable store, caller must hold lock.
+func (m *tagMetadata) loadTagValueIDsInMemWithoutLock(tagKeyID tag.KeyID, fn func(tagEntry TagEntry)) {
 	// define get tag value ids func
 	getTagValueIDs := func(tagStore *TagStore) {
 		if tagEntry, ok := tagStore.Get(uint32(tagKeyID)); ok {
@@ -345,9 +428,6 @@ func (m *tagMetadata) loadTagValueIDsInMem(tagKeyID tag.KeyID, fn func(tagEntry
 		}
 	}
 
-	m.rwMutex.RLock()
-	defer m.rwMutex.RUnlock()
-
 	getTagValueIDs(m.mutable)
 	if m.immutable != nil {
 		getTagValueIDs(m.immutable)
@@ -369,6 +449,18 @@ func (m *tagMetadata) getTagValueIDInMem(tagKeyID tag.KeyID, tagValue string) (t
 	return
 }
 
+// copyDistinct returns a copy of distinct counter.
+func copyDistinct(counter *sketch.Distinct) (*sketch.Distinct, error) {
+	rs, err := sketch.NewDistinct(counter.Precision())
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.Merge(counter); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
 // getTagValueID gets tag value id from tag store based on tag key id and tag value
 func getTagValueID(tags *TagStore, tagKeyID tag.KeyID, tagValue string) (tagValueID uint32, ok bool) {
 	if tagEntry, ok := tags.Get(uint32(tagKeyID)); ok {
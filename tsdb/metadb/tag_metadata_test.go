@@ -141,6 +141,23 @@ func TestTagMetadata_SuggestTagValues(t *testing.T) {
 	r.EXPECT().SuggestTagValues(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"tag-value-8"})
 	values = meta.SuggestTagValues(5, "tag-key", 10)
 	assert.Equal(t, []string{"tag-value-8"}, values)
+	// case 5: merge memory and kv store, sorted, de-duplicated and capped at limit
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{table.NewMockReader(ctrl)}, nil).Times(2)
+	r.EXPECT().SuggestTagValues(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]string{"tag-value-8", "tag-value-5", "tag-value-1"}).Times(2)
+	values = meta.SuggestTagValues(5, "tag-value", 10)
+	assert.Equal(t, []string{"tag-value-1", "tag-value-5", "tag-value-8"}, values)
+	values = meta.SuggestTagValues(5, "tag-value", 2)
+	assert.Equal(t, []string{"tag-value-1", "tag-value-5"}, values)
+	// case 6: tag key not exist
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil)
+	values = meta.SuggestTagValues(100, "tag-value", 10)
+	assert.NotNil(t, values)
+	assert.Empty(t, values)
+	// case 7: invalid limit
+	values = meta.SuggestTagValues(5, "tag-value", 0)
+	assert.NotNil(t, values)
+	assert.Empty(t, values)
 }
 
 func TestTagMetadata_FindTagValueDsByExpr(t *testing.T) {
@@ -273,6 +290,75 @@ func TestTagMetadata_CollectTagValues(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTagMetadata_TagCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newTagReaderFunc = tagkeymeta.NewReader
+		ctrl.Finish()
+	}()
+
+	meta, _, snapshot := mockTagMetadata(ctrl)
+	mockTagMetadataMemData(meta)
+	m := meta.(*tagMetadata)
+	tagReader := tagkeymeta.NewMockReader(ctrl)
+	newTagReaderFunc = func(readers []table.Reader) tagkeymeta.Reader {
+		return tagReader
+	}
+	walk := func(values ...string) func(_ tag.KeyID, _ string, fn func(tagValue []byte, tagValueID uint32) bool) error {
+		return func(_ tag.KeyID, _ string, fn func(tagValue []byte, tagValueID uint32) bool) error {
+			for idx, value := range values {
+				fn([]byte(value), uint32(idx))
+			}
+			return nil
+		}
+	}
+	// case 1: find kv readers err
+	snapshot.EXPECT().FindReaders(uint32(5)).Return(nil, fmt.Errorf("err"))
+	counter, err := meta.TagCardinality(5)
+	assert.Error(t, err)
+	assert.Nil(t, counter)
+	// case 2: walk tag values err
+	snapshot.EXPECT().FindReaders(uint32(5)).Return([]table.Reader{table.NewMockReader(ctrl)}, nil)
+	tagReader.EXPECT().WalkTagValues(tag.KeyID(5), "", gomock.Any()).Return(fmt.Errorf("err"))
+	counter, err = meta.TagCardinality(5)
+	assert.Error(t, err)
+	assert.Nil(t, counter)
+	// case 3: build counter from kv store and memory
+	snapshot.EXPECT().FindReaders(uint32(5)).Return([]table.Reader{table.NewMockReader(ctrl)}, nil)
+	tagReader.EXPECT().WalkTagValues(tag.KeyID(5), "", gomock.Any()).DoAndReturn(walk("a", "tag-value-5"))
+	counter, err = meta.TagCardinality(5)
+	assert.NoError(t, err)
+	assert.True(t, counter.IsExact())
+	assert.Equal(t, uint64(2), counter.Count())
+	// returns copy of counter
+	counter.Add("c")
+	counter, err = meta.TagCardinality(5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), counter.Count())
+	// case 4: maintain counter when new tag value assigned
+	snapshot.EXPECT().FindReaders(uint32(5)).Return(nil, nil)
+	_, err = meta.GenTagValueID(5, "b")
+	assert.NoError(t, err)
+	counter, err = meta.TagCardinality(5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), counter.Count())
+	// case 5: memory flushed when building, build again
+	snapshot.EXPECT().FindReaders(uint32(10)).Return([]table.Reader{table.NewMockReader(ctrl)}, nil).Times(2)
+	gomock.InOrder(
+		tagReader.EXPECT().WalkTagValues(tag.KeyID(10), "", gomock.Any()).
+			DoAndReturn(func(_ tag.KeyID, _ string, _ func(tagValue []byte, tagValueID uint32) bool) error {
+				m.rwMutex.Lock()
+				m.flushSeq++
+				m.rwMutex.Unlock()
+				return nil
+			}),
+		tagReader.EXPECT().WalkTagValues(tag.KeyID(10), "", gomock.Any()).DoAndReturn(walk("x")),
+	)
+	counter, err = meta.TagCardinality(10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), counter.Count())
+}
+
 func TestTagMetadata_Flush(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -281,12 +281,19 @@ func (s *shard) lookupRowMeta(row *metric.StorageRow) (err error) {
 			row.SeriesID,
 			limits,
 		)
+		if s.indexDB.NeedFlush() {
+			// too many new series in memory, flush index early
+			s.triggerFlushIndex()
+		}
 	}
 	// set field id
 	simpleFieldItr := row.NewSimpleFieldIterator()
-	var fieldID field.ID
+	var (
+		fieldID   field.ID
+		fieldType field.Type
+	)
 	for simpleFieldItr.HasNext() {
-		if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
+		if fieldID, fieldType, err = s.metadata.MetadataDatabase().GenFieldID(
 			namespace, metricName,
 			simpleFieldItr.NextName(),
 			simpleFieldItr.NextType(), limits); err != nil {
@@ -294,6 +301,7 @@ func (s *shard) lookupRowMeta(row *metric.StorageRow) (err error) {
 			return err
 		}
 		row.FieldIDs = append(row.FieldIDs, fieldID)
+		row.FieldTypes = append(row.FieldTypes, fieldType)
 	}
 
 	compoundFieldItr, ok := row.NewCompoundFieldIterator()
@@ -302,40 +310,45 @@ func (s *shard) lookupRowMeta(row *metric.StorageRow) (err error) {
 	}
 	// min
 	if compoundFieldItr.Min() > 0 {
-		if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
+		if fieldID, fieldType, err = s.metadata.MetadataDatabase().GenFieldID(
 			namespace, metricName, compoundFieldItr.HistogramMinFieldName(), field.MinField, limits); err != nil {
 			return err
 		}
 		row.FieldIDs = append(row.FieldIDs, fieldID)
+		row.FieldTypes = append(row.FieldTypes, fieldType)
 	}
 	// max
 	if compoundFieldItr.Max() > 0 {
-		if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
+		if fieldID, fieldType, err = s.metadata.MetadataDatabase().GenFieldID(
 			namespace, metricName, compoundFieldItr.HistogramMaxFieldName(), field.MaxField, limits); err != nil {
 			return err
 		}
 		row.FieldIDs = append(row.FieldIDs, fieldID)
+		row.FieldTypes = append(row.FieldTypes, fieldType)
 	}
 	// sum
-	if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
+	if fieldID, fieldType, err = s.metadata.MetadataDatabase().GenFieldID(
 		namespace, metricName, compoundFieldItr.HistogramSumFieldName(), field.SumField, limits); err != nil {
 		return err
 	}
 	row.FieldIDs = append(row.FieldIDs, fieldID)
+	row.FieldTypes = append(row.FieldTypes, fieldType)
 	// count
-	if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
+	if fieldID, fieldType, err = s.metadata.MetadataDatabase().GenFieldID(
 		namespace, metricName, compoundFieldItr.HistogramCountFieldName(), field.SumField, limits); err != nil {
 		return err
 	}
 	row.FieldIDs = append(row.FieldIDs, fieldID)
+	row.FieldTypes = append(row.FieldTypes, fieldType)
 	// explicit bounds
 	for compoundFieldItr.HasNextBucket() {
-		if fieldID, err = s.metadata.MetadataDatabase().GenFieldID(
+		if fieldID, fieldType, err = s.metadata.MetadataDatabase().GenFieldID(
 			namespace, metricName,
 			compoundFieldItr.BucketName(), field.HistogramField, limits); err != nil {
 			return err
 		}
 		row.FieldIDs = append(row.FieldIDs, fieldID)
+		row.FieldTypes = append(row.FieldTypes, fieldType)
 	}
 
 Done:
@@ -387,6 +400,24 @@ func (s *shard) FlushIndex() (err error) {
 	if !s.isFlushing.CAS(false, true) {
 		return nil
 	}
+	return s.flushIndex()
+}
+
+// triggerFlushIndex flushes index data to disk in background if no flush process is running,
+// never blocks the caller(write goroutine).
+func (s *shard) triggerFlushIndex() {
+	// another flush process is running
+	if !s.isFlushing.CAS(false, true) {
+		return
+	}
+	s.statistics.IndexDBEarlyFlushes.Incr()
+	go func() {
+		_ = s.flushIndex()
+	}()
+}
+
+// flushIndex flushes index data to disk, the flushing flag must be set by caller.
+func (s *shard) flushIndex() (err error) {
 	// 1. mark flush job doing
 	startTime := time.Now()
 	defer func() {
@@ -516,7 +516,7 @@ func TestShard_lookupRowMeta(t *testing.T) {
 			prepare: func() {
 				metadataDB.EXPECT().GenMetricID("ns", "test", gomock.Any()).Return(metric.ID(10), nil).AnyTimes()
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any(), gomock.Any()).Return(field.ID(1), nil)
+					gomock.Any(), gomock.Any(), gomock.Any()).Return(field.ID(1), field.SumField, nil)
 				indexDB.EXPECT().GetOrCreateSeriesID(gomock.Any(), gomock.Any(),
 					metric.ID(10), gomock.Any(), gomock.Any()).Return(uint32(10), false, nil)
 			},
@@ -527,7 +527,7 @@ func TestShard_lookupRowMeta(t *testing.T) {
 				metadataDB.EXPECT().GenMetricID(commonconstants.DefaultNamespace, "test", gomock.Any()).
 					Return(metric.ID(10), nil).AnyTimes()
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any(), gomock.Any()).Return(field.ID(1), nil)
+					gomock.Any(), gomock.Any(), gomock.Any()).Return(field.ID(1), field.SumField, nil)
 			},
 		},
 		{
@@ -552,8 +552,9 @@ func TestShard_lookupRowMeta(t *testing.T) {
 				indexDB.EXPECT().GetOrCreateSeriesID(gomock.Any(), gomock.Any(),
 					metric.ID(10), gomock.Any(), gomock.Any()).Return(uint32(1), true, nil)
 				indexDB.EXPECT().BuildInvertIndex(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				indexDB.EXPECT().NeedFlush().Return(false)
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(0), fmt.Errorf("err"))
+					gomock.Any(), gomock.Any()).Return(field.ID(0), field.Unknown, fmt.Errorf("err"))
 			},
 			wantErr: true,
 		},
@@ -610,7 +611,7 @@ func TestShard_lookup_histogram_fields(t *testing.T) {
 			name: "gen min field failure",
 			prepare: func() {
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(0), fmt.Errorf("err"))
+					gomock.Any(), gomock.Any()).Return(field.ID(0), field.Unknown, fmt.Errorf("err"))
 			},
 			wantErr: true,
 		},
@@ -618,9 +619,9 @@ func TestShard_lookup_histogram_fields(t *testing.T) {
 			name: "gen max field failure",
 			prepare: func() {
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(1), nil)
+					gomock.Any(), gomock.Any()).Return(field.ID(1), field.SumField, nil)
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(0), fmt.Errorf("err"))
+					gomock.Any(), gomock.Any()).Return(field.ID(0), field.Unknown, fmt.Errorf("err"))
 			},
 			wantErr: true,
 		},
@@ -628,9 +629,9 @@ func TestShard_lookup_histogram_fields(t *testing.T) {
 			name: "gen sum field failure",
 			prepare: func() {
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(1), nil).MaxTimes(2)
+					gomock.Any(), gomock.Any()).Return(field.ID(1), field.SumField, nil).MaxTimes(2)
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(0), fmt.Errorf("err"))
+					gomock.Any(), gomock.Any()).Return(field.ID(0), field.Unknown, fmt.Errorf("err"))
 			},
 			wantErr: true,
 		},
@@ -638,9 +639,9 @@ func TestShard_lookup_histogram_fields(t *testing.T) {
 			name: "gen count field failure",
 			prepare: func() {
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(1), nil).MaxTimes(3)
+					gomock.Any(), gomock.Any()).Return(field.ID(1), field.SumField, nil).MaxTimes(3)
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(0), fmt.Errorf("err"))
+					gomock.Any(), gomock.Any()).Return(field.ID(0), field.Unknown, fmt.Errorf("err"))
 			},
 			wantErr: true,
 		},
@@ -648,9 +649,9 @@ func TestShard_lookup_histogram_fields(t *testing.T) {
 			name: "gen bucket field failure",
 			prepare: func() {
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(1), nil).MaxTimes(4)
+					gomock.Any(), gomock.Any()).Return(field.ID(1), field.SumField, nil).MaxTimes(4)
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(0), fmt.Errorf("err"))
+					gomock.Any(), gomock.Any()).Return(field.ID(0), field.Unknown, fmt.Errorf("err"))
 			},
 			wantErr: true,
 		},
@@ -658,7 +659,7 @@ func TestShard_lookup_histogram_fields(t *testing.T) {
 			name: "gen all fields successfully",
 			prepare: func() {
 				metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
-					gomock.Any(), gomock.Any()).Return(field.ID(1), nil).AnyTimes()
+					gomock.Any(), gomock.Any()).Return(field.ID(1), field.SumField, nil).AnyTimes()
 			},
 		},
 	}
@@ -729,6 +730,62 @@ func TestShard_WaitFlushIndexCompleted(t *testing.T) {
 	assert.True(t, commontimeutil.Now()-now >= 90*time.Millisecond.Milliseconds())
 }
 
+func TestShard_lookupRowMeta_FlushIndexEarly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	db := NewMockDatabase(ctrl)
+	db.EXPECT().Name().Return("test").AnyTimes()
+	s := &shard{
+		indexDB:        indexDB,
+		db:             db,
+		metadata:       metadata,
+		flushCondition: sync.NewCond(&sync.Mutex{}),
+		statistics:     metrics.NewShardStatistics("data", "1"),
+		logger:         logger.GetLogger("TSDB", "Test"),
+	}
+	metadataDB.EXPECT().GenMetricID(gomock.Any(), gomock.Any(), gomock.Any()).Return(metric.ID(10), nil).AnyTimes()
+	metadataDB.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).Return(field.ID(1), field.SumField, nil).AnyTimes()
+	indexDB.EXPECT().GetOrCreateSeriesID(gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(1), true, nil).AnyTimes()
+	indexDB.EXPECT().BuildInvertIndex(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	indexDB.EXPECT().NeedFlush().Return(true).AnyTimes()
+	flushing := make(chan struct{})
+	indexDB.EXPECT().Flush().DoAndReturn(func() error {
+		<-flushing
+		return nil
+	})
+	lookup := func() {
+		assert.NoError(t, s.lookupRowMeta(&(mockBatchRows(&protoMetricsV1.Metric{
+			Name:      "test",
+			Timestamp: commontimeutil.Now(),
+			Tags:      tag.KeyValuesFromMap(map[string]string{"ip": "1.1.1.1"}),
+			SimpleFields: []*protoMetricsV1.SimpleField{{
+				Name:  "f1",
+				Value: 1.0,
+				Type:  protoMetricsV1.SimpleFieldType_DELTA_SUM,
+			}},
+		})[0])))
+	}
+	// case 1: too many new series, trigger early flush which doesn't block writing
+	for i := 0; i < 10; i++ {
+		lookup()
+	}
+	assert.True(t, s.isFlushing.Load())
+	close(flushing)
+	s.WaitFlushIndexCompleted()
+	assert.False(t, s.isFlushing.Load())
+	// case 2: trigger early flush again after previous flush completed
+	indexDB.EXPECT().Flush().Return(nil)
+	lookup()
+	s.WaitFlushIndexCompleted()
+}
+
 func TestShard_TTL(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -18,11 +18,8 @@
 package tagkeymeta
 
 import (
-	"bytes"
 	"encoding/binary"
-	"regexp"
 	"sort"
-	"strings"
 
 	"github.com/lindb/roaring"
 
@@ -30,6 +27,7 @@ import (
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/strutil"
 	"github.com/lindb/lindb/pkg/trie"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 //go:generate mockgen -source ./meta.go -destination=./meta_mock.go -package tagkeymeta
@@ -50,10 +48,10 @@ type TagKeyMeta interface {
 	// FindTagValueIDs finds tagValueIDs in tagValue
 	FindTagValueIDs(tagValues []string) (tagValueIDs []uint32)
 	// FindTagValueIDsByLike finds tagValueIDs like tagValue,
-	// 3 cases: *sdb, ts*, *sd*
+	// '%'(or '*') matches any sequence, '_' matches a single character, backslash escapes them.
 	FindTagValueIDsByLike(tagValue string) (tagValueIDs []uint32)
 	// FindTagValueIDsByRegex finds tagValueIDs by regex pattern,
-	FindTagValueIDsByRegex(tagValuePattern string) (tagValueIDs []uint32)
+	FindTagValueIDsByRegex(expr *stmt.RegexExpr) (tagValueIDs []uint32)
 }
 
 const (
@@ -286,60 +284,35 @@ func (meta *tagKeyMeta) PrefixIterator(tagValuePrefix []byte) (*trie.PrefixItera
 }
 
 func (meta *tagKeyMeta) FindTagValueIDsByLike(tagValue string) (tagValueIDs []uint32) {
-	hashPrefix := strings.HasPrefix(tagValue, "*")
-	hasSuffix := strings.HasSuffix(tagValue, "*")
-	tagValueSlice := strutil.String2ByteSlice(tagValue)
-	switch {
-	case tagValue == "":
-		break
-	// only ends with *
-	case !hashPrefix && hasSuffix:
-		itr, err := meta.PrefixIterator(tagValueSlice[:len(tagValueSlice)-1])
-		if err != nil {
-			return nil
-		}
-		for itr.Valid() {
+	if tagValue == "" {
+		return nil
+	}
+	pattern := stmt.NewLikePattern(tagValue)
+	if pattern.IsExact() {
+		return meta.FindTagValueID(pattern.Prefix())
+	}
+	// only the tag values with literal prefix can match, scan them via sorted tag value trie
+	itr, err := meta.PrefixIterator(strutil.String2ByteSlice(pattern.Prefix()))
+	if err != nil {
+		return nil
+	}
+	prefixOnly := pattern.IsPrefixOnly()
+	for itr.Valid() {
+		if prefixOnly || pattern.MatchBytes(itr.Key()) {
 			tagValueIDs = append(tagValueIDs, encoding.ByteSlice2Uint32(itr.Value()))
-			itr.Next()
 		}
-	// only starts with *
-	case hashPrefix && !hasSuffix:
-		suffix := tagValueSlice[1:]
-		itr, err := meta.PrefixIterator(nil)
-		if err != nil {
-			return nil
-		}
-		for itr.Valid() {
-			if bytes.HasSuffix(itr.Key(), suffix) {
-				tagValueIDs = append(tagValueIDs, encoding.ByteSlice2Uint32(itr.Value()))
-			}
-			itr.Next()
-		}
-	// starts with and ends with *
-	case hashPrefix && hasSuffix:
-		middle := tagValueSlice[1 : len(tagValueSlice)-1]
-		itr, err := meta.PrefixIterator(nil)
-		if err != nil {
-			return nil
-		}
-		for itr.Valid() {
-			if bytes.Contains(itr.Key(), middle) {
-				tagValueIDs = append(tagValueIDs, encoding.ByteSlice2Uint32(itr.Value()))
-			}
-			itr.Next()
-		}
-	default:
-		return meta.FindTagValueID(tagValue)
+		itr.Next()
 	}
 	return tagValueIDs
 }
 
-func (meta *tagKeyMeta) FindTagValueIDsByRegex(tagValuePattern string) (tagValueIDs []uint32) {
-	rp, err := regexp.Compile(tagValuePattern)
+func (meta *tagKeyMeta) FindTagValueIDsByRegex(expr *stmt.RegexExpr) (tagValueIDs []uint32) {
+	rp, err := expr.Pattern()
 	if err != nil {
 		return nil
 	}
-	literalPrefix, _ := rp.LiteralPrefix()
+	// only the tag values with literal prefix can match if pattern is anchored
+	literalPrefix := expr.LiteralPrefix(rp)
 	literalPrefixByte := strutil.String2ByteSlice(literalPrefix)
 	itr, err := meta.PrefixIterator(literalPrefixByte)
 	if err != nil {
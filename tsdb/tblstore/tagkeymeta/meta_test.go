@@ -27,6 +27,7 @@ import (
 	"github.com/lindb/roaring"
 
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func Test_newTagKeyMeta_error_cases(t *testing.T) {
@@ -161,19 +162,32 @@ func TestTagKeyMeta_FindTagValueIDsByLike(t *testing.T) {
 
 	// case5: nil search
 	assert.Len(t, meta.FindTagValueIDsByLike(""), 0)
+
+	// case6: sql wildcards
+	assert.Equal(t, []uint32{0, 9, 1, 2, 3, 4, 5, 6, 7, 8}, meta.FindTagValueIDsByLike("1.1.1.%"))
+	assert.Equal(t, []uint32{0, 0x2328, 0x3e8, 0x7d0, 0xbb8, 0xfa0, 0x1388, 0x1770, 0x1b58, 0x1f40},
+		meta.FindTagValueIDsByLike("%.1.1.1"))
+	assert.Len(t, meta.FindTagValueIDsByLike("%.1.1.%"), 100)
+	assert.Equal(t, []uint32{0, 0x2328}, meta.FindTagValueIDsByLike("1%.1.1.1"))
+	// single char
+	assert.Equal(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7, 8}, meta.FindTagValueIDsByLike("1.1.1._"))
+	// escaped literal
+	assert.Equal(t, []uint32{0}, meta.FindTagValueIDsByLike(`1.1.1\.1`))
+	assert.Len(t, meta.FindTagValueIDsByLike(`1.1.1.1\%`), 0)
+	assert.Len(t, meta.FindTagValueIDsByLike(`1.1.1.\_`), 0)
 }
 
 func TestTagKeyMeta_FindTagValueIDsByRegex(t *testing.T) {
 	meta, _ := newTagKeyMeta(buildTestTrieData())
 
 	// case1: bad pattern
-	assert.Len(t, meta.FindTagValueIDsByRegex("1["), 0)
+	assert.Len(t, meta.FindTagValueIDsByRegex(&stmt.RegexExpr{Regexp: "1["}), 0)
 
 	// case2: prefix regex
-	assert.Len(t, meta.FindTagValueIDsByRegex("1\\.1\\.1\\.[1-3]"), 4)
+	assert.Len(t, meta.FindTagValueIDsByRegex(&stmt.RegexExpr{Regexp: "1\\.1\\.1\\.[1-3]"}), 4)
 
 	// case3: regex all
-	assert.Len(t, meta.FindTagValueIDsByRegex(".*"), 10000)
+	assert.Len(t, meta.FindTagValueIDsByRegex(&stmt.RegexExpr{Regexp: ".*"}), 10000)
 }
 
 func TestTagKeyMeta_CollectTagValues(t *testing.T) {
@@ -235,7 +249,7 @@ func TestTagKeyMeta_Error(t *testing.T) {
 	metaImpl.trieBlock = append([]byte{1, 2, 3, 4}, metaImpl.trieBlock...)
 
 	// FindTagValueIDsByRegex error
-	assert.Len(t, meta.FindTagValueIDsByRegex("x"), 0)
+	assert.Len(t, meta.FindTagValueIDsByRegex(&stmt.RegexExpr{Regexp: "x"}), 0)
 	// FindTagValueIDsByLike error
 	assert.Len(t, meta.FindTagValueIDsByLike("x*"), 0)
 	assert.Len(t, meta.FindTagValueIDsByLike("*x*"), 0)
@@ -137,7 +137,7 @@ func (r *tagReader) FindValueIDsByExprForTagKeyID(tagKeyID tag.KeyID, expr stmt.
 		case *stmt.LikeExpr:
 			tagValueIDs.AddMany(tagKeyMeta.FindTagValueIDsByLike(expression.Value))
 		case *stmt.RegexExpr:
-			tagValueIDs.AddMany(tagKeyMeta.FindTagValueIDsByRegex(expression.Regexp))
+			tagValueIDs.AddMany(tagKeyMeta.FindTagValueIDsByRegex(expression))
 		default:
 			return nil, fmt.Errorf("%w, unsupported expr, tagKeyID: %d",
 				constants.ErrTagKeyMetaNotFound, tagKeyID)
@@ -192,15 +192,17 @@ func (r *tagReader) SuggestTagValues(
 		if err != nil {
 			continue
 		}
-		for itr.Valid() {
-			if len(tagValues) >= limit {
-				return tagValues
-			}
+		// tag values are sorted in each reader, so at most limit values need to be loaded per reader
+		for found := 0; itr.Valid() && found < limit; found++ {
 			// if use strutil.ByteSlice2String will get one tag value(all tag values is duplicate)
 			tagValues = append(tagValues, string(itr.Key()))
 			itr.Next()
 		}
 	}
+	if len(r.readers) > 1 {
+		// merge values from multi readers
+		tagValues = strutil.SortedDeDupStringSlice(tagValues, limit)
+	}
 	return tagValues
 }
 
@@ -107,6 +107,22 @@ export const ConcurrentPoolDashboard: Dashboard = {
           },
           span: 8,
         },
+        {
+          chart: {
+            title: "Task Skipped",
+            description: "task skipped because context done before picked up by worker",
+            config: { type: "line", options: chartOptions },
+            targets: [
+              {
+                db: MonitoringDB,
+                sql: "select tasks_skipped from lindb.concurrent.pool group by node,pool_name",
+                watch: ["namespace", "node", "role"],
+              },
+            ],
+            unit: Unit.Short,
+          },
+          span: 8,
+        },
         {
           chart: {
             title: "Tasks Execute Panic",
@@ -159,5 +175,41 @@ export const ConcurrentPoolDashboard: Dashboard = {
         },
       ],
     },
+    {
+      panels: [
+        {
+          chart: {
+            title: "Tasks Pending",
+            description: "current tasks submitted but not picked up by worker",
+            config: { type: "line", options: chartOptions },
+            targets: [
+              {
+                db: MonitoringDB,
+                sql: "select tasks_pending from lindb.concurrent.pool group by node,pool_name",
+                watch: ["namespace", "node", "role"],
+              },
+            ],
+            unit: Unit.Short,
+          },
+          span: 12,
+        },
+        {
+          chart: {
+            title: "Task Pending Time(P99)",
+            description: "latency from submitting to starting execution",
+            config: { type: "area", options: chartOptions },
+            targets: [
+              {
+                db: MonitoringDB,
+                sql: "select quantile(0.99) as p99 from lindb.concurrent.pool.tasks_pending_duration group by node,pool_name",
+                watch: ["namespace", "node", "role"],
+              },
+            ],
+            unit: Unit.Milliseconds,
+          },
+          span: 12,
+        },
+      ],
+    },
   ],
 };
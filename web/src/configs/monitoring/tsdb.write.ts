@@ -172,6 +172,40 @@ export const TSDBWriteDashboard: Dashboard = {
         },
       ],
     },
+    {
+      panels: [
+        {
+          chart: {
+            title: "Flush Tag Keys",
+            config: { type: "line", options: chartOptions },
+            targets: [
+              {
+                db: MonitoringDB,
+                sql: "select 'flush_tag_keys' from 'lindb.tsdb.indexdb' group by node",
+                watch: ["node", "namespace"],
+              },
+            ],
+            unit: Unit.Short,
+          },
+          span: 12,
+        },
+        {
+          chart: {
+            title: "Flush Tag Values",
+            config: { type: "line", options: chartOptions },
+            targets: [
+              {
+                db: MonitoringDB,
+                sql: "select 'flush_tag_values' from 'lindb.tsdb.indexdb' group by node",
+                watch: ["node", "namespace"],
+              },
+            ],
+            unit: Unit.Short,
+          },
+          span: 12,
+        },
+      ],
+    },
     {
       panels: [
         {
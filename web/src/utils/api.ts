@@ -78,10 +78,13 @@ async function POST<T>(
 
 const getErrorMsg = (err: any) => {
   if (_.has(err, "response.data")) {
-    return _.get(err, "response.data");
+    const data = _.get(err, "response.data");
+    // error with code: {code, message}
+    return _.get(data, "message", data);
   }
   if (_.has(err, "reason.response.data")) {
-    return _.get(err, "reason.response.data");
+    const data = _.get(err, "reason.response.data");
+    return _.get(data, "message", data);
   }
   const msg = _.get(err, "reason", "Unknown internal error");
   return `${msg}`;